@@ -0,0 +1,215 @@
+package iso20022
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// CBPRPlusProfile checks the postal addresses of messages against the CBPR+ rules in
+// force from November 2025, when unstructured addresses are no longer accepted: every
+// address is structured, with at least a town name and a country, or hybrid, with at
+// most two address lines of 70 characters besides them.
+var CBPRPlusProfile = Profile{
+	Name:  "CBPR+",
+	Rules: []ProfileRule{StructuredAddressRule("CBPR+", 2)},
+}
+
+// cbprAddressLineLength is the length of the address lines of a CBPR+ hybrid address
+const cbprAddressLineLength = 70
+
+// StructuredAddressRule returns a profile rule requiring every postal address of a
+// message, of the parties and of the agents, to have a town name (TwnNm) and a
+// country (Ctry), and at most maxLines address lines (AdrLine) of 70 characters.
+// With maxLines zero, address lines are prohibited. Errors name the scheme.
+func StructuredAddressRule(scheme string, maxLines int) ProfileRule {
+	return func(doc interface{}) ValidationErrors {
+		var errs ValidationErrors
+		visitAddresses(reflect.ValueOf(doc), "", func(path string, adr reflect.Value) {
+			for _, name := range []string{"TwnNm", "Ctry"} {
+				if v, _ := childElement(adr, name); v.IsZero() {
+					errs = append(errs, ValidationError{Field: name, Path: path + "/" + name, Message: "is required in " + scheme})
+				}
+			}
+			lines, _ := childElement(adr, "AdrLine")
+			switch {
+			case lines.Len() > 0 && maxLines == 0:
+				errs = append(errs, ValidationError{Field: "AdrLine", Path: path + "/AdrLine",
+					Message: "is not allowed in " + scheme + ", use the structured address elements"})
+			case lines.Len() > maxLines:
+				errs = append(errs, ValidationError{Field: "AdrLine", Path: path + "/AdrLine",
+					Message: fmt.Sprintf("occurs %d times, more than the %d allowed by %s", lines.Len(), maxLines, scheme)})
+			}
+			for i := 0; i < lines.Len() && maxLines > 0; i++ {
+				if n := utf8.RuneCountInString(lines.Index(i).String()); n > cbprAddressLineLength {
+					errs = append(errs, ValidationError{Field: "AdrLine", Path: fmt.Sprintf("%s/AdrLine[%d]", path, i+1),
+						Message: fmt.Sprintf("has %d characters, more than the %d allowed by %s", n, cbprAddressLineLength, scheme)})
+				}
+			}
+		})
+		return errs
+	}
+}
+
+// visitAddresses calls visit with every postal address below v, a struct with a
+// town name, country and address lines, and its path
+func visitAddresses(v reflect.Value, path string, visit func(path string, adr reflect.Value)) {
+	visitStructs(v, path, isPostalAddress, visit)
+}
+
+// visitStructs calls visit with every struct below v whose type matches, and its
+// path, without descending into them
+func visitStructs(v reflect.Value, path string, match func(reflect.Type) bool, visit func(path string, v reflect.Value)) {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			visitStructs(v.Index(i), fmt.Sprintf("%s[%d]", path, i+1), match, visit)
+		}
+	case reflect.Struct:
+		if match(v.Type()) {
+			visit(path, v)
+			return
+		}
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			name, ok := diffElementName(field)
+			if !ok || !field.IsExported() || strings.HasPrefix(name, "@") {
+				continue
+			}
+			child := path
+			if name != "" {
+				if child != "" {
+					child += "/"
+				}
+				child += name
+			}
+			visitStructs(v.Field(i), child, match, visit)
+		}
+	}
+}
+
+// isPostalAddress reports whether t is a postal address with the elements checked
+// by StructuredAddressRule
+func isPostalAddress(t reflect.Type) bool {
+	if !strings.HasPrefix(t.Name(), "PostalAddress") {
+		return false
+	}
+	v := reflect.New(t).Elem()
+	for _, name := range []string{"TwnNm", "Ctry", "AdrLine"} {
+		if _, ok := childElement(v, name); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// addressFields are the structured elements of a postal address that StructureAddress
+// fills in
+type addressFields struct {
+	streetName, buildingNumber, postCode, townName, countrySubDivision, country **string
+}
+
+// Patterns of the address lines StructureAddress recognizes
+var (
+	// 10115 Berlin, F-75001 Paris
+	postCodeTownLine = regexp.MustCompile(`^(?:[A-Z]{1,2}-)?(\d{4,6}|\d{2}-\d{3}|\d{3} \d{2}) +(\D.*)$`)
+	// New York, NY 10001
+	usTownLine = regexp.MustCompile(`^(.+?),? +([A-Z]{2}) +(\d{5}(?:-\d{4})?)$`)
+	// London EC1A 1BB, Toronto ON M5H 2N2
+	townPostCodeLine = regexp.MustCompile(`^(.+?),? +(?:([A-Z]{2}) +)?([A-Z]{1,2}\d[A-Z\d]? ?\d[A-Z]{2}|[A-Z]\d[A-Z] ?\d[A-Z]\d|\d{4,6})$`)
+	// 100 Main Street
+	numberStreetLine = regexp.MustCompile(`^(\d+[A-Za-z]?(?:-\d+)?),? +(\D.*)$`)
+	// Hauptstrasse 5a
+	streetNumberLine = regexp.MustCompile(`^(\D.*?),? +(\d+[A-Za-z]?(?:-\d+)?)$`)
+	// GB
+	countryLine = regexp.MustCompile(`^[A-Z]{2}$`)
+)
+
+// Structure fills in the structured elements of the address from its address
+// lines, on a best-effort basis: a last line holding a country code gives the
+// country, a line with a postal code and a town gives them, in the European
+// (10115 Berlin), US (New York, NY 10001) or British (London EC1A 1BB) order, and a
+// line with a building number and a street name gives them. Elements already present
+// are kept, and the lines that were used are removed; the others are kept as the
+// lines of a hybrid address. It reports whether the address now has the town and
+// country StructuredAddressRule requires.
+func (a *PostalAddress24) Structure() bool {
+	a.AddressLine = structureAddress(a.AddressLine, addressFields{&a.StreetName, &a.BuildingNumber, &a.PostCode, &a.TownName, &a.CountrySubDivision, &a.Country})
+	return a.TownName != nil && a.Country != nil
+}
+
+// structureAddress sets the absent fields from the lines and returns the lines that
+// were not used
+func structureAddress(lines []string, f addressFields) []string {
+	set := func(field **string, value string) bool {
+		value = strings.TrimSpace(value)
+		if *field != nil || value == "" {
+			return false
+		}
+		*field = &value
+		return true
+	}
+	rest := make([]string, 0, len(lines))
+	for i := range lines {
+		rest = append(rest, strings.TrimSpace(lines[i]))
+	}
+
+	// The country comes last
+	if n := len(rest); n > 0 && countryLine.MatchString(rest[n-1]) && set(f.country, rest[n-1]) {
+		rest = rest[:n-1]
+	}
+
+	// The town line is searched from the end, the street line before it
+	town := -1
+	for i := len(rest) - 1; i >= 0 && town < 0 && *f.townName == nil; i-- {
+		switch m := usTownLine.FindStringSubmatch(rest[i]); {
+		case m != nil:
+			set(f.townName, m[1])
+			set(f.countrySubDivision, m[2])
+			set(f.postCode, m[3])
+			town = i
+		default:
+			if m := postCodeTownLine.FindStringSubmatch(rest[i]); m != nil {
+				set(f.postCode, m[1])
+				set(f.townName, m[2])
+				town = i
+			} else if m := townPostCodeLine.FindStringSubmatch(rest[i]); m != nil {
+				set(f.townName, m[1])
+				set(f.countrySubDivision, m[2])
+				set(f.postCode, m[3])
+				town = i
+			}
+		}
+	}
+	if town < 0 {
+		return rest
+	}
+	used := map[int]bool{town: true}
+	for i := town - 1; i >= 0 && *f.streetName == nil; i-- {
+		if m := numberStreetLine.FindStringSubmatch(rest[i]); m != nil {
+			set(f.buildingNumber, m[1])
+			set(f.streetName, m[2])
+			used[i] = true
+		} else if m := streetNumberLine.FindStringSubmatch(rest[i]); m != nil {
+			set(f.streetName, m[1])
+			set(f.buildingNumber, m[2])
+			used[i] = true
+		}
+	}
+	var remaining []string
+	for i, line := range rest {
+		if !used[i] && line != "" {
+			remaining = append(remaining, line)
+		}
+	}
+	return remaining
+}
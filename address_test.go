@@ -0,0 +1,85 @@
+package iso20022
+
+import (
+	"encoding/xml"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestStructuredAddressRule(t *testing.T) {
+	doc := loadPacs008Sample(t)
+	if err := CBPRPlusProfile.Check(doc); err != nil {
+		t.Errorf("Expected the sample addresses to be accepted, got %v", err)
+	}
+	if errs := StructuredAddressRule("Test", 0)(doc); len(errs) != 1 || errs[0].Location() != "FIToFICstmrCdtTrf/CdtTrfTxInf[1]/Cdtr/PstlAdr/AdrLine" {
+		t.Errorf("Expected the hybrid address to be rejected, got %v", errs)
+	}
+
+	tx := &doc.FICustomerCreditTransfer.CreditTransferTransactionInfo[0]
+	tx.Creditor.PostalAddress = &PostalAddress24{AddressLine: []string{"1 Threadneedle Street", "London", strings.Repeat("x", 71)}}
+	tx.DebtorAgent.FinancialInstitutionID.PostalAddress = &PostalAddress24{TownName: Ptr("New York")}
+	errs := StructuredAddressRule("CBPR+", 2)(doc)
+	want := []string{
+		"FIToFICstmrCdtTrf/CdtTrfTxInf[1]/DbtrAgt/FinInstnId/PstlAdr/Ctry",
+		"FIToFICstmrCdtTrf/CdtTrfTxInf[1]/Cdtr/PstlAdr/TwnNm",
+		"FIToFICstmrCdtTrf/CdtTrfTxInf[1]/Cdtr/PstlAdr/Ctry",
+		"FIToFICstmrCdtTrf/CdtTrfTxInf[1]/Cdtr/PstlAdr/AdrLine",
+		"FIToFICstmrCdtTrf/CdtTrfTxInf[1]/Cdtr/PstlAdr/AdrLine[3]",
+	}
+	var got []string
+	for _, e := range errs {
+		got = append(got, e.Location())
+		if !strings.Contains(e.Message, "CBPR+") {
+			t.Errorf("Expected the scheme to be named in %q", e.Message)
+		}
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected errors at\n%s\ngot\n%s", strings.Join(want, "\n"), strings.Join(got, "\n"))
+	}
+}
+
+func TestPostalAddressStructure(t *testing.T) {
+	tests := []struct {
+		lines []string
+		want  PostalAddress24
+		ok    bool
+	}{
+		{[]string{"100 Main Street", "New York, NY 10001", "US"},
+			PostalAddress24{StreetName: Ptr("Main Street"), BuildingNumber: Ptr("100"), PostCode: Ptr("10001"),
+				TownName: Ptr("New York"), CountrySubDivision: Ptr("NY"), Country: Ptr("US")}, true},
+		{[]string{"Hauptstrasse 5a", "10115 Berlin", "DE"},
+			PostalAddress24{StreetName: Ptr("Hauptstrasse"), BuildingNumber: Ptr("5a"), PostCode: Ptr("10115"),
+				TownName: Ptr("Berlin"), Country: Ptr("DE")}, true},
+		{[]string{"Widget House", "1 Threadneedle Street", "London EC2R 8AH", "GB"},
+			PostalAddress24{StreetName: Ptr("Threadneedle Street"), BuildingNumber: Ptr("1"), PostCode: Ptr("EC2R 8AH"),
+				TownName: Ptr("London"), Country: Ptr("GB"), AddressLine: []string{"Widget House"}}, true},
+		{[]string{"F-75001 Paris"},
+			PostalAddress24{PostCode: Ptr("75001"), TownName: Ptr("Paris")}, false},
+		{[]string{"Somewhere over the rainbow"},
+			PostalAddress24{AddressLine: []string{"Somewhere over the rainbow"}}, false},
+	}
+	for _, tt := range tests {
+		adr := PostalAddress24{AddressLine: tt.lines}
+		if ok := adr.Structure(); ok != tt.ok || !reflect.DeepEqual(adr, tt.want) {
+			t.Errorf("%q: got %v, %s, want %v, %s", tt.lines, ok, mustMarshalAddress(adr), tt.ok, mustMarshalAddress(tt.want))
+		}
+	}
+
+	// Elements already present are kept
+	adr := PostalAddress24{Country: Ptr("CH"), AddressLine: []string{"Bahnhofstrasse 45", "8001 Zurich"}}
+	if !adr.Structure() || *adr.TownName != "Zurich" || *adr.PostCode != "8001" || *adr.StreetName != "Bahnhofstrasse" || adr.AddressLine != nil {
+		t.Errorf("Unexpected address %+v", adr)
+	}
+}
+
+func mustMarshalAddress(adr PostalAddress24) string {
+	data, err := xml.Marshal(struct {
+		XMLName xml.Name `xml:"PstlAdr"`
+		PostalAddress24
+	}{PostalAddress24: adr})
+	if err != nil {
+		return err.Error()
+	}
+	return string(data)
+}
@@ -0,0 +1,119 @@
+package iso20022
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Reasons of the admi.002 rejections of NewMessageRejection, of the
+// ExternalStatusReason1Code list
+const (
+	reasonInvalidFileFormat = "FF01" // InvalidFileFormat
+	reasonSyntaxError       = "FF02" // SyntaxError
+)
+
+// unknownReference is the reference of a rejected message whose identification
+// could not be read
+const unknownReference = "NONREF"
+
+// maxRejectionText is the length of the Max350Text of ErrLctn and RsnDesc
+const maxRejectionText = 350
+
+// NewMessageRejection returns the admi.002 rejecting data, an inbound message that
+// failed to decode or validate with err, as returned by DecodeDocument, Unmarshal
+// with the Strict option or ValidateXML. The rejection refers to the BizMsgIdr of
+// the business application header of data or, without one, to the first MsgId, as
+// far as they can be read before the error, and else to NONREF. Its reason is FF02,
+// syntax error, for malformed XML and FF01, invalid file format, otherwise. The
+// error location gives the line and, when known, the element of the error, and
+// the reason description the error itself.
+func NewMessageRejection(data []byte, err error) *Admi00200101Document {
+	now := NewISODateTime(time.Now().UTC())
+	reason := RejectionReason2{
+		RejectingPartyReason: reasonInvalidFileFormat,
+		RejectionDateTime:    &now,
+	}
+	var (
+		syntaxErr      *xml.SyntaxError
+		unexpectedErr  *UnexpectedElementError
+		validationErr  ValidationError
+		validationErrs ValidationErrors
+		location       string
+	)
+	switch {
+	case errors.As(err, &syntaxErr):
+		reason.RejectingPartyReason = reasonSyntaxError
+		location = fmt.Sprintf("line %d", syntaxErr.Line)
+	case errors.As(err, &unexpectedErr):
+		location = fmt.Sprintf("%s, line %d", unexpectedErr.Path, unexpectedErr.Line)
+	case errors.As(err, &validationErrs) && len(validationErrs) > 0:
+		location = validationErrorLocation(validationErrs[0])
+	case errors.As(err, &validationErr):
+		location = validationErrorLocation(validationErr)
+	}
+	if location != "" {
+		location = truncateRunes(location, maxRejectionText)
+		reason.ErrorLocation = &location
+	}
+	if err != nil {
+		description := truncateRunes(err.Error(), maxRejectionText)
+		reason.ReasonDescription = &description
+	}
+	return &Admi00200101Document{MessageRejection: MessageRejectionV01{
+		RelatedReference: MessageReference{Reference: inboundReference(data)},
+		Reason:           reason,
+	}}
+}
+
+// validationErrorLocation returns the element of a validation error with its line
+// and column when ValidateXML found them
+func validationErrorLocation(e ValidationError) string {
+	if e.Line > 0 {
+		return fmt.Sprintf("%s, line %d, column %d", e.Location(), e.Line, e.Column)
+	}
+	return e.Location()
+}
+
+// inboundReference returns the BizMsgIdr of data or else its first MsgId, reading
+// the elements before any syntax error, or NONREF
+func inboundReference(data []byte) string {
+	d := xml.NewDecoder(bytes.NewReader(data))
+	var msgID, element string
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			break
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			element = t.Name.Local
+		case xml.EndElement:
+			element = ""
+		case xml.CharData:
+			value := strings.TrimSpace(string(t))
+			switch {
+			case value == "" || len(value) > 35:
+			case element == "BizMsgIdr":
+				return value
+			case element == "MsgId" && msgID == "":
+				msgID = value
+			}
+		}
+	}
+	if msgID != "" {
+		return msgID
+	}
+	return unknownReference
+}
+
+// truncateRunes cuts s to at most n characters
+func truncateRunes(s string, n int) string {
+	if runes := []rune(s); len(runes) > n {
+		return string(runes[:n])
+	}
+	return s
+}
@@ -0,0 +1,68 @@
+package iso20022
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestNewMessageRejection(t *testing.T) {
+	sample, err := os.ReadFile(filepath.Join("testdata", "roundtrip", "pacs.008.001.08", "customer_credit_transfer.xml"))
+	if err != nil {
+		t.Fatalf("Failed to read fixture: %v", err)
+	}
+
+	t.Run("Malformed XML", func(t *testing.T) {
+		data := []byte("<Document xmlns=\"urn:iso:std:iso:20022:tech:xsd:pacs.008.001.08\"><FIToFICstmrCdtTrf>\n<GrpHdr><MsgId>M1</MsgId>\n</Document>")
+		_, _, err := DecodeDocument(data)
+		rejection := NewMessageRejection(data, err)
+		r := rejection.MessageRejection
+		if r.RelatedReference.Reference != "M1" || r.Reason.RejectingPartyReason != "FF02" || r.Reason.ErrorLocation == nil || *r.Reason.ErrorLocation != "line 3" {
+			t.Errorf("Expected a syntax error at line 3 of M1, got %+v, %v", r, err)
+		}
+		if err := rejection.Validate(); err != nil {
+			t.Errorf("Expected a valid admi.002, got %v", err)
+		}
+		if _, err := Marshal(rejection); err != nil {
+			t.Errorf("Failed to marshal: %v", err)
+		}
+
+		// The BizMsgIdr of the header comes first
+		data = []byte("<Envelope><AppHdr><BizMsgIdr>BAH-0001</BizMsgIdr></AppHdr><Document><FIToFICstmrCdtTrf><GrpHdr><MsgId>M1</MsgId>")
+		if ref := NewMessageRejection(data, err).MessageRejection.RelatedReference.Reference; ref != "BAH-0001" {
+			t.Errorf("Expected the BizMsgIdr to be referenced, got %s", ref)
+		}
+	})
+
+	t.Run("Unexpected element", func(t *testing.T) {
+		data := []byte(strings.Replace(string(sample), "<NbOfTxs>", "<Foo>1</Foo><NbOfTxs>", 1))
+		_, _, err := DecodeDocument(data, Strict())
+		r := NewMessageRejection(data, err).MessageRejection
+		if r.RelatedReference.Reference != "BBBBUS33-20240315-0001" || r.Reason.RejectingPartyReason != "FF01" || r.Reason.ErrorLocation == nil ||
+			!strings.HasPrefix(*r.Reason.ErrorLocation, "FIToFICstmrCdtTrf/GrpHdr/Foo, line ") || !strings.Contains(*r.Reason.ReasonDescription, "unknown") {
+			t.Errorf("Expected the unknown element to be located, got %+v", r)
+		}
+	})
+
+	t.Run("Validation error", func(t *testing.T) {
+		data := []byte(strings.Replace(string(sample), "GB29NWBK60161331926819", "GB29NWBK60161331926818", 1))
+		err := ValidateXML(data, new(Pacs00800108Document))
+		if err == nil {
+			t.Fatal("Expected a validation error")
+		}
+		r := NewMessageRejection(data, err).MessageRejection
+		if r.Reason.ErrorLocation == nil || !strings.Contains(*r.Reason.ErrorLocation, "CdtrAcct/Id/IBAN, line ") {
+			t.Errorf("Expected the element to be located, got %+v, %v", r.Reason, err)
+		}
+	})
+
+	t.Run("Unknown message", func(t *testing.T) {
+		data := []byte(`<Document xmlns="urn:example:unknown"><Msg/></Document>`)
+		_, _, err := DecodeDocument(data)
+		r := NewMessageRejection(data, err).MessageRejection
+		if r.RelatedReference.Reference != "NONREF" || r.Reason.RejectingPartyReason != "FF01" || r.Reason.ErrorLocation != nil || r.Reason.ReasonDescription == nil {
+			t.Errorf("Expected an unreferenced rejection, got %+v", r)
+		}
+	})
+}
@@ -0,0 +1,171 @@
+package iso20022
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// EventKind is what a system event notified by an admi.004 means to the
+// participants of a scheme
+type EventKind int
+
+// Kinds of system events
+const (
+	EventUnknown EventKind = iota
+	// EventCutoffExtension postpones a cut-off of the business day, the new time
+	// usually being a parameter of the event
+	EventCutoffExtension
+	// EventSystemClosed closes the system to new payments
+	EventSystemClosed
+	// EventSystemReopening opens the system again after a closure
+	EventSystemReopening
+)
+
+// String returns the name of the kind
+func (k EventKind) String() string {
+	switch k {
+	case EventCutoffExtension:
+		return "cutoff extension"
+	case EventSystemClosed:
+		return "system closed"
+	case EventSystemReopening:
+		return "system reopening"
+	}
+	return "unknown"
+}
+
+// SystemEvent is the event of an admi.004 system event notification
+type SystemEvent struct {
+	Kind EventKind
+	// Code is the EvtCd of the notification, Parameters its EvtParam and
+	// Description its EvtDesc
+	Code        string
+	Parameters  []string
+	Description string
+	// Time is the EvtTm of the notification, zero when it has none
+	Time time.Time
+}
+
+var (
+	systemEventCodesMu sync.RWMutex
+	// systemEventCodes maps the EvtCd values of each scheme to the kind of their
+	// events
+	systemEventCodes = map[string]map[string]EventKind{
+		"FedNow": {
+			"EXTN": EventCutoffExtension,
+			"CLSD": EventSystemClosed,
+			"OPEN": EventSystemReopening,
+		},
+		"TARGET2": {
+			"CUTX": EventCutoffExtension,
+			"STOP": EventSystemClosed,
+			"REOP": EventSystemReopening,
+		},
+	}
+)
+
+// RegisterSystemEventCode makes ParseSystemEvent read the events of scheme with
+// EvtCd code as kind, for the codes the defaults do not know or that a scheme
+// uses otherwise
+func RegisterSystemEventCode(scheme, code string, kind EventKind) {
+	systemEventCodesMu.Lock()
+	defer systemEventCodesMu.Unlock()
+	codes, ok := systemEventCodes[scheme]
+	if !ok {
+		codes = make(map[string]EventKind)
+		systemEventCodes[scheme] = codes
+	}
+	codes[code] = kind
+}
+
+// ParseSystemEvent returns the event notified by doc in scheme. An EvtCd the
+// scheme has not registered is read as an EventUnknown rather than an error, so
+// that new codes reach the subscribers of every event.
+func ParseSystemEvent(scheme string, doc *Admi00400102Document) (SystemEvent, error) {
+	info := &doc.SystemEventNotification.EventInfo
+	if info.EventCode == "" {
+		return SystemEvent{}, errors.New("the system event notification has no event code")
+	}
+	e := SystemEvent{
+		Code:        info.EventCode,
+		Parameters:  info.EventParameter,
+		Description: deref(info.EventDescription),
+	}
+	if info.EventTime != nil {
+		e.Time = info.EventTime.Time
+	}
+	systemEventCodesMu.RLock()
+	e.Kind = systemEventCodes[scheme][info.EventCode]
+	systemEventCodesMu.RUnlock()
+	return e, nil
+}
+
+// AcknowledgeSystemEvent returns the admi.011 acknowledging the event of doc with
+// the identification msgID. Its details repeat the event acknowledged.
+func AcknowledgeSystemEvent(msgID string, doc *Admi00400102Document) *Admi01100101Document {
+	info := doc.SystemEventNotification.EventInfo
+	return &Admi01100101Document{SystemEventAcknowledgement: SystemEventAcknowledgementV01{
+		MessageID: msgID,
+		AcknowledgementDetails: &Event1{
+			EventCode:        info.EventCode,
+			EventParameter:   info.EventParameter,
+			EventDescription: info.EventDescription,
+			EventTime:        info.EventTime,
+		},
+	}}
+}
+
+// SystemEventHandler is called with the events a subscriber subscribed to
+type SystemEventHandler func(SystemEvent)
+
+// SystemEvents dispatches the admi.004 notifications of a scheme to the handlers
+// subscribed to their kind of event, and acknowledges them. It is safe for
+// concurrent use.
+type SystemEvents struct {
+	scheme   string
+	ids      IDGenerator
+	mu       sync.RWMutex
+	handlers map[EventKind][]SystemEventHandler
+	all      []SystemEventHandler
+}
+
+// NewSystemEvents returns the dispatcher of the notifications of scheme, whose
+// acknowledgements take their MsgId from ids
+func NewSystemEvents(scheme string, ids IDGenerator) *SystemEvents {
+	return &SystemEvents{scheme: scheme, ids: ids, handlers: make(map[EventKind][]SystemEventHandler)}
+}
+
+// Subscribe calls h with the events of kind
+func (s *SystemEvents) Subscribe(kind EventKind, h SystemEventHandler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.handlers[kind] = append(s.handlers[kind], h)
+}
+
+// SubscribeAll calls h with every event, of a known kind or not
+func (s *SystemEvents) SubscribeAll(h SystemEventHandler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.all = append(s.all, h)
+}
+
+// Notify reads the event of doc, calls the handlers subscribed to it in the order
+// they subscribed and returns the admi.011 acknowledging it
+func (s *SystemEvents) Notify(doc *Admi00400102Document) (*Admi01100101Document, error) {
+	e, err := ParseSystemEvent(s.scheme, doc)
+	if err != nil {
+		return nil, err
+	}
+	msgID, err := s.ids.NextID()
+	if err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	handlers := append(append([]SystemEventHandler(nil), s.handlers[e.Kind]...), s.all...)
+	s.mu.RUnlock()
+	for _, h := range handlers {
+		h(e)
+	}
+	return AcknowledgeSystemEvent(msgID, doc), nil
+}
@@ -0,0 +1,99 @@
+package iso20022
+
+import (
+	"encoding/xml"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+const cutoffExtension = `<Document xmlns="urn:iso:std:iso:20022:tech:xsd:admi.004.001.02">
+<SysEvtNtfctn><EvtInf>
+<EvtCd>EXTN</EvtCd><EvtParam>18:30</EvtParam><EvtDesc>Customer transfer cut-off extended</EvtDesc><EvtTm>2026-10-16T17:45:00Z</EvtTm>
+</EvtInf></SysEvtNtfctn>
+</Document>`
+
+func TestParseSystemEvent(t *testing.T) {
+	doc := new(Admi00400102Document)
+	if err := Unmarshal([]byte(cutoffExtension), doc); err != nil {
+		t.Fatal(err)
+	}
+	e, err := ParseSystemEvent("FedNow", doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := SystemEvent{
+		Kind:        EventCutoffExtension,
+		Code:        "EXTN",
+		Parameters:  []string{"18:30"},
+		Description: "Customer transfer cut-off extended",
+		Time:        time.Date(2026, 10, 16, 17, 45, 0, 0, time.UTC),
+	}
+	if !reflect.DeepEqual(e, want) {
+		t.Errorf("Expected %+v, got %+v", want, e)
+	}
+
+	// The code is unknown to another scheme until registered
+	if e, _ := ParseSystemEvent("Lynx", doc); e.Kind != EventUnknown || e.Kind.String() != "unknown" {
+		t.Errorf("Expected an unknown event, got %v", e.Kind)
+	}
+	RegisterSystemEventCode("Lynx", "EXTN", EventCutoffExtension)
+	defer func() {
+		systemEventCodesMu.Lock()
+		delete(systemEventCodes, "Lynx")
+		systemEventCodesMu.Unlock()
+	}()
+	if e, _ := ParseSystemEvent("Lynx", doc); e.Kind != EventCutoffExtension {
+		t.Errorf("Expected the registered kind, got %v", e.Kind)
+	}
+
+	if _, err := ParseSystemEvent("FedNow", &Admi00400102Document{}); err == nil {
+		t.Error("Expected an event without a code to fail")
+	}
+}
+
+func TestSystemEvents(t *testing.T) {
+	ids, err := NewSequenceGenerator("BBBBUS33-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	events := NewSystemEvents("FedNow", ids)
+	var got []string
+	events.Subscribe(EventCutoffExtension, func(e SystemEvent) { got = append(got, "extension "+e.Parameters[0]) })
+	events.Subscribe(EventSystemClosed, func(e SystemEvent) { got = append(got, "closed") })
+	events.SubscribeAll(func(e SystemEvent) { got = append(got, "all "+e.Kind.String()) })
+
+	doc := new(Admi00400102Document)
+	if err := Unmarshal([]byte(cutoffExtension), doc); err != nil {
+		t.Fatal(err)
+	}
+	ack, err := events.Notify(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"extension 18:30", "all cutoff extension"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected the handlers %q to be called, got %q", want, got)
+	}
+	if err := ack.Validate(); err != nil {
+		t.Errorf("Expected a valid admi.011, got %v", err)
+	}
+	r := ack.SystemEventAcknowledgement
+	if !strings.HasPrefix(r.MessageID, "BBBBUS33-") || r.AcknowledgementDetails.EventCode != "EXTN" || r.AcknowledgementDetails.EventParameter[0] != "18:30" {
+		t.Errorf("Expected the event to be acknowledged, got %+v", r)
+	}
+	data, err := xml.Marshal(ack)
+	if err != nil || !strings.Contains(string(data), "<AckDtls><EvtCd>EXTN</EvtCd>") {
+		t.Errorf("Expected the acknowledgement to marshal, got %s, %v", data, err)
+	}
+
+	// An unknown code reaches only the subscribers of every event
+	got = nil
+	doc.SystemEventNotification.EventInfo.EventCode = "NEWC"
+	if _, err := events.Notify(doc); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"all unknown"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+}
@@ -0,0 +1,94 @@
+package iso20022
+
+import (
+	"errors"
+	"time"
+)
+
+// Status codes of the ReqHdlg of the admi.007 receipt acknowledgements of the
+// FedNow Service
+const (
+	FedNowReceiptAccepted = "ACTC" // received and technically valid
+	FedNowReceiptRejected = "RJCT" // received but technically invalid
+)
+
+// Status codes of the ReqHdlg of the admi.007 receipt acknowledgements of the
+// Eurosystem Single Market Infrastructure Gateway (ESMIG) of TARGET Services
+const (
+	ECBReceiptReceived = "RCVD" // received, not yet processed
+	ECBReceiptAccepted = "ACPT" // received and accepted for processing
+	ECBReceiptRejected = "RJCT" // received but rejected
+)
+
+// AcknowledgeOption configures AcknowledgeReceipt
+type AcknowledgeOption func(*acknowledgeOptions)
+
+type acknowledgeOptions struct {
+	ids         IDGenerator
+	description string
+}
+
+// AcknowledgeIDs takes the MsgId of the acknowledgement from ids rather than from
+// a ULIDGenerator
+func AcknowledgeIDs(ids IDGenerator) AcknowledgeOption {
+	return func(o *acknowledgeOptions) {
+		o.ids = ids
+	}
+}
+
+// AcknowledgeDescription sets the Desc of the request handling, cut to 140
+// characters
+func AcknowledgeDescription(description string) AcknowledgeOption {
+	return func(o *acknowledgeOptions) {
+		o.description = description
+	}
+}
+
+// maxReceiptDescription is the length of the Max140Text of Desc
+const maxReceiptDescription = 140
+
+// AcknowledgeReceipt returns the admi.007 acknowledging the receipt of the message
+// of appHdr with statusCode, a Max4AlphaNumericText such as FedNowReceiptAccepted
+// or ECBReceiptRejected. The acknowledgement refers to the BizMsgIdr and MsgDefIdr
+// of the header, issued by the BIC of its sender when it has one, and is dated now.
+func AcknowledgeReceipt(appHdr *BusinessApplicationHeaderV02, statusCode string, opts ...AcknowledgeOption) (*Admi00700101Document, error) {
+	if appHdr == nil || appHdr.BusinessMessageID == "" {
+		return nil, errors.New("no business message identifier to acknowledge")
+	}
+	if err := validatePattern(statusCode, `^[a-zA-Z0-9]{1,4}$`, "StsCd"); err != nil {
+		return nil, err
+	}
+	var o acknowledgeOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.ids == nil {
+		ids, err := NewULIDGenerator("")
+		if err != nil {
+			return nil, err
+		}
+		o.ids = ids
+	}
+	msgID, err := o.ids.NextID()
+	if err != nil {
+		return nil, err
+	}
+
+	now := NewISODateTime(time.Now().UTC())
+	ref := MessageReference1{Reference: appHdr.BusinessMessageID}
+	if name := appHdr.MessageDefinitionID; name != "" {
+		ref.MessageName = &name
+	}
+	if bic := party44BIC(appHdr.From); bic != "" {
+		ref.ReferenceIssuer = &PartyIdentification136{ID: PartyIdentification120{AnyBIC: &bic}}
+	}
+	handling := RequestHandling2{StatusCode: statusCode, StatusDateTime: &now}
+	if o.description != "" {
+		description := truncateRunes(o.description, maxReceiptDescription)
+		handling.Description = &description
+	}
+	return &Admi00700101Document{ReceiptAcknowledgement: ReceiptAcknowledgementV01{
+		MessageID: MessageHeader10{MessageID: msgID, CreationDateTime: &now},
+		Report:    []ReceiptAcknowledgementReport2{{RelatedReference: ref, RequestHandling: handling}},
+	}}, nil
+}
@@ -0,0 +1,60 @@
+package iso20022
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAcknowledgeReceipt(t *testing.T) {
+	bic := "AAAADEFF"
+	appHdr := &BusinessApplicationHeaderV02{
+		From:                Party44{FinancialInstitutionID: &BranchAndFinancialInstitutionIdentification6{FinancialInstitutionID: FinancialInstitutionIdentification18{BankIdentifierCode: &bic}}},
+		BusinessMessageID:   "BAH-0001",
+		MessageDefinitionID: "pacs.008.001.08",
+	}
+	ids, err := NewSequenceGenerator("CCCCGB2L-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ack, err := AcknowledgeReceipt(appHdr, FedNowReceiptAccepted, AcknowledgeIDs(ids), AcknowledgeDescription(strings.Repeat("x", 200)))
+	if err != nil {
+		t.Fatalf("Failed to acknowledge: %v", err)
+	}
+	if err := ack.Validate(); err != nil {
+		t.Errorf("Expected a valid admi.007, got %v", err)
+	}
+
+	r := ack.ReceiptAcknowledgement
+	if !strings.HasPrefix(r.MessageID.MessageID, "CCCCGB2L-") || r.MessageID.CreationDateTime == nil {
+		t.Errorf("Expected a message identification from the generator, got %+v", r.MessageID)
+	}
+	ref := r.Report[0].RelatedReference
+	if ref.Reference != "BAH-0001" || *ref.MessageName != "pacs.008.001.08" || *ref.ReferenceIssuer.ID.AnyBIC != "AAAADEFF" {
+		t.Errorf("Expected the inbound message to be referenced, got %+v", ref)
+	}
+	handling := r.Report[0].RequestHandling
+	if handling.StatusCode != "ACTC" || handling.StatusDateTime == nil || len(*handling.Description) != 140 {
+		t.Errorf("Expected the status and a cut description, got %+v", handling)
+	}
+
+	data, err := Marshal(ack)
+	if err != nil || !strings.Contains(string(data), "<StsCd>ACTC</StsCd>") {
+		t.Errorf("Expected the acknowledgement to marshal, got %s, %v", data, err)
+	}
+
+	// Without a sender BIC or options
+	ack, err = AcknowledgeReceipt(&BusinessApplicationHeaderV02{BusinessMessageID: "BAH-0002"}, ECBReceiptRejected)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ref := ack.ReceiptAcknowledgement.Report[0].RelatedReference; ref.ReferenceIssuer != nil || ref.MessageName != nil || ack.ReceiptAcknowledgement.MessageID.MessageID == "" {
+		t.Errorf("Expected a bare reference and a default identification, got %+v", ack.ReceiptAcknowledgement)
+	}
+
+	if _, err := AcknowledgeReceipt(appHdr, "ACCEPTED"); err == nil {
+		t.Error("Expected a status code over 4 characters to fail")
+	}
+	if _, err := AcknowledgeReceipt(nil, FedNowReceiptAccepted); err == nil {
+		t.Error("Expected a missing header to fail")
+	}
+}
@@ -0,0 +1,99 @@
+package iso20022
+
+import (
+	"encoding/xml"
+	"testing"
+)
+
+func TestStaticDataRequestAndReport(t *testing.T) {
+	participant := BranchAndFinancialInstitutionIdentification6{
+		FinancialInstitutionID: FinancialInstitutionIdentification18{BankIdentifierCode: Ptr("CHASUS33")},
+	}
+
+	req, err := NewStaticDataRequest("SDR001", []StaticDataSearchCriteria1{{
+		DataType:      StaticDataParticipantProfile,
+		ParticipantID: &participant,
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := req.Validate(); err != nil {
+		t.Fatalf("Expected valid admi.009, got: %v", err)
+	}
+
+	xmlData, err := xml.Marshal(req)
+	if err != nil {
+		t.Fatalf("Failed to marshal admi.009: %v", err)
+	}
+	var parsed Admi00900102Document
+	if err := xml.Unmarshal(xmlData, &parsed); err != nil {
+		t.Fatalf("Failed to unmarshal admi.009: %v", err)
+	}
+	if got := parsed.StaticDataRequest.DataRequestDetails.SearchCriteria[0].DataType; got != StaticDataParticipantProfile {
+		t.Errorf("Expected data type PRFL, got %s", got)
+	}
+
+	t.Run("Unknown data type", func(t *testing.T) {
+		bad, err := NewStaticDataRequest("SDR002", []StaticDataSearchCriteria1{{DataType: "XXXX"}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := bad.Validate(); err == nil {
+			t.Error("Expected validation error for unknown data type")
+		}
+	})
+
+	t.Run("Report", func(t *testing.T) {
+		rpt := Admi01000101Document{
+			StaticDataReport: StaticDataReportV01{
+				MessageHeader: MessageHeader7{MessageID: "SDR-RPT-001"},
+				ReportDetails: []StaticDataReport1{{
+					ParticipantID:      &participant,
+					ParticipantProfile: &ParticipantProfile1{Name: Ptr("JPMorgan Chase"), Status: "ENBL"},
+				}},
+			},
+		}
+		if err := rpt.Validate(); err != nil {
+			t.Fatalf("Expected valid admi.010, got: %v", err)
+		}
+
+		rpt.StaticDataReport.ReportDetails = nil
+		if err := rpt.Validate(); err == nil {
+			t.Error("Expected validation error for report without details or error")
+		}
+	})
+}
+
+func TestReportQueryRequest(t *testing.T) {
+	doc := NewReportQueryRequest("RQR001", "EODSTMT", AccountIdentification4{Other: &GenericAccountIdentification1{ID: "RTGSACCT01"}})
+	if err := doc.Validate(); err != nil {
+		t.Fatalf("Expected valid admi.005, got: %v", err)
+	}
+
+	xmlData, err := xml.Marshal(doc)
+	if err != nil {
+		t.Fatalf("Failed to marshal admi.005: %v", err)
+	}
+	var parsed Admi00500101Document
+	if err := xml.Unmarshal(xmlData, &parsed); err != nil {
+		t.Fatalf("Failed to unmarshal admi.005: %v", err)
+	}
+	if got := parsed.ReportQueryRequest.ReportQueryCriteria[0].SearchCriteria.ReportName; got != "EODSTMT" {
+		t.Errorf("Expected report name EODSTMT, got %s", got)
+	}
+
+	t.Run("Missing report name", func(t *testing.T) {
+		doc.ReportQueryRequest.ReportQueryCriteria[0].SearchCriteria.ReportName = ""
+		if err := doc.Validate(); err == nil {
+			t.Error("Expected validation error for missing report name")
+		}
+	})
+
+	t.Run("Invalid event code", func(t *testing.T) {
+		doc.ReportQueryRequest.ReportQueryCriteria[0].SearchCriteria.ReportName = "EODSTMT"
+		doc.ReportQueryRequest.ReportQueryCriteria[0].SearchCriteria.Event = Ptr("TOO-LONG")
+		if err := doc.Validate(); err == nil {
+			t.Error("Expected validation error for invalid event code")
+		}
+	})
+}
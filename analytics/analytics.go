@@ -0,0 +1,359 @@
+// Package analytics flattens payments and cash management messages into records
+// for data lakes, and writes them as Avro object container files that Spark, Hive,
+// BigQuery and the like load directly.
+//
+// EntryRecord has a row per transaction of the entries of camt.052 and camt.054
+// messages, and TransactionRecord a row per transaction of pacs.008 messages. Their
+// fields only hold strings, numbers, dates and times, so that they map to columns
+// of Parquet files as they are, and their names, given by their avro tags, stay the
+// same across releases: fields are added but not renamed or removed.
+package analytics
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ckbaum/iso20022-go"
+)
+
+// ErrUnsupportedDocument is returned for documents the package does not flatten
+var ErrUnsupportedDocument = errors.New("analytics: unsupported document")
+
+// EntryRecord is a transaction of an entry of an account report or notification.
+// Entries without transaction details have a record of their own, with
+// TransactionIndex -1 and no transaction fields; entries with several, such as
+// batch bookings, have one per transaction, which repeat the fields of the entry.
+type EntryRecord struct {
+	MessageID                string            `avro:"message_id"`
+	MessageType              string            `avro:"message_type"`
+	CreationTime             *time.Time        `avro:"creation_time"`
+	ReportID                 string            `avro:"report_id"`
+	Account                  string            `avro:"account"`
+	AccountCurrency          *string           `avro:"account_currency"`
+	EntryIndex               int64             `avro:"entry_index"`
+	TransactionIndex         int64             `avro:"transaction_index"`
+	EntryReference           *string           `avro:"entry_reference"`
+	AccountServicerReference *string           `avro:"account_servicer_reference"`
+	Status                   string            `avro:"status"`
+	CreditDebit              string            `avro:"credit_debit"`
+	Reversal                 bool              `avro:"reversal"`
+	Amount                   iso20022.Decimal  `avro:"amount"`
+	Currency                 string            `avro:"currency"`
+	BookingDate              *iso20022.ISODate `avro:"booking_date"`
+	ValueDate                *iso20022.ISODate `avro:"value_date"`
+	BankTransactionCode      *string           `avro:"bank_transaction_code"`
+	TransactionAmount        *iso20022.Decimal `avro:"transaction_amount"`
+	TransactionCurrency      *string           `avro:"transaction_currency"`
+	EndToEndID               *string           `avro:"end_to_end_id"`
+	TransactionID            *string           `avro:"transaction_id"`
+	CounterpartyName         *string           `avro:"counterparty_name"`
+	CounterpartyAccount      *string           `avro:"counterparty_account"`
+	CounterpartyAgent        *string           `avro:"counterparty_agent"`
+	Purpose                  *string           `avro:"purpose"`
+	RemittanceInfo           *string           `avro:"remittance_info"`
+	AdditionalInfo           *string           `avro:"additional_info"`
+}
+
+// TransactionRecord is a transaction of a pacs.008
+type TransactionRecord struct {
+	MessageID          string            `avro:"message_id"`
+	MessageType        string            `avro:"message_type"`
+	CreationTime       *time.Time        `avro:"creation_time"`
+	SettlementMethod   string            `avro:"settlement_method"`
+	TransactionIndex   int64             `avro:"transaction_index"`
+	InstructionID      *string           `avro:"instruction_id"`
+	EndToEndID         string            `avro:"end_to_end_id"`
+	TransactionID      *string           `avro:"transaction_id"`
+	UETR               *string           `avro:"uetr"`
+	Amount             iso20022.Decimal  `avro:"amount"`
+	Currency           string            `avro:"currency"`
+	SettlementDate     *iso20022.ISODate `avro:"settlement_date"`
+	InstructedAmount   *iso20022.Decimal `avro:"instructed_amount"`
+	InstructedCurrency *string           `avro:"instructed_currency"`
+	ChargeBearer       string            `avro:"charge_bearer"`
+	Priority           *string           `avro:"priority"`
+	ServiceLevel       *string           `avro:"service_level"`
+	LocalInstrument    *string           `avro:"local_instrument"`
+	CategoryPurpose    *string           `avro:"category_purpose"`
+	Purpose            *string           `avro:"purpose"`
+	DebtorName         *string           `avro:"debtor_name"`
+	DebtorCountry      *string           `avro:"debtor_country"`
+	DebtorAccount      *string           `avro:"debtor_account"`
+	DebtorAgent        *string           `avro:"debtor_agent"`
+	CreditorName       *string           `avro:"creditor_name"`
+	CreditorCountry    *string           `avro:"creditor_country"`
+	CreditorAccount    *string           `avro:"creditor_account"`
+	CreditorAgent      *string           `avro:"creditor_agent"`
+	InstructingAgent   *string           `avro:"instructing_agent"`
+	InstructedAgent    *string           `avro:"instructed_agent"`
+	RemittanceInfo     *string           `avro:"remittance_info"`
+}
+
+// Entries returns the entry records of a camt.052 or camt.054 message
+func Entries(doc interface{}) ([]EntryRecord, error) {
+	var records []EntryRecord
+	add := func(hdr iso20022.GroupHeader81, msgType, reportID string, account iso20022.CashAccount39, entries []iso20022.ReportEntry10) {
+		for i, entry := range entries {
+			base := EntryRecord{
+				MessageID:                hdr.MsgID,
+				MessageType:              msgType,
+				CreationTime:             dateTime(hdr.CreationDateTime),
+				ReportID:                 reportID,
+				Account:                  account.ID.Identifier(),
+				AccountCurrency:          account.Currency,
+				EntryIndex:               int64(i),
+				TransactionIndex:         -1,
+				EntryReference:           entry.EntryReference,
+				AccountServicerReference: entry.AccountServicerReference,
+				Status:                   code(entry.Status.Code, entry.Status.Proprietary),
+				CreditDebit:              string(entry.CreditDebitIndicator),
+				Reversal:                 entry.ReversalIndicator != nil && *entry.ReversalIndicator,
+				Amount:                   entry.Amount.Value,
+				Currency:                 entry.Amount.Currency,
+				BookingDate:              date(entry.BookingDate),
+				ValueDate:                date(entry.ValueDate),
+				BankTransactionCode:      bankTransactionCode(&entry.BankTransactionCode),
+				AdditionalInfo:           entry.AdditionalEntryInfo,
+			}
+			n := 0
+			for _, details := range entry.EntryDetails {
+				for _, tx := range details.TransactionDetails {
+					record := base
+					record.TransactionIndex = int64(n)
+					record.setTransaction(tx)
+					records = append(records, record)
+					n++
+				}
+			}
+			if n == 0 {
+				records = append(records, base)
+			}
+		}
+	}
+	switch doc := doc.(type) {
+	case *iso20022.Camt05200108Document:
+		msg := &doc.BankAccountReport
+		for _, report := range msg.Report {
+			add(msg.GroupHeader, "camt.052.001.08", report.ID, report.Account, report.Entry)
+		}
+	case *iso20022.Camt05400108Document:
+		msg := &doc.BankDebitCreditNotification
+		for _, ntfctn := range msg.Notification {
+			add(msg.GroupHeader, "camt.054.001.08", ntfctn.ID, ntfctn.Account, ntfctn.Entry)
+		}
+	default:
+		return nil, fmt.Errorf("%w: %T is not a camt.052 or camt.054", ErrUnsupportedDocument, doc)
+	}
+	return records, nil
+}
+
+// setTransaction sets the transaction fields of an entry record. The counterparty
+// is the debtor of a credit and the creditor of a debit.
+func (r *EntryRecord) setTransaction(tx iso20022.EntryTransaction10) {
+	if tx.Amount != nil {
+		r.TransactionAmount, r.TransactionCurrency = &tx.Amount.Value, &tx.Amount.Currency
+	}
+	if refs := tx.References; refs != nil {
+		r.EndToEndID, r.TransactionID = refs.EndToEndID, refs.TransactionID
+	}
+	if code := bankTransactionCode(tx.BankTransactionCode); code != nil {
+		r.BankTransactionCode = code
+	}
+	credit := r.CreditDebit == "CRDT"
+	if tx.CreditDebitIndicator != nil {
+		credit = *tx.CreditDebitIndicator == iso20022.CreditDebitCRDT
+	}
+	if parties := tx.RelatedParties; parties != nil {
+		party, account := parties.Creditor, parties.CreditorAccount
+		if credit {
+			party, account = parties.Debtor, parties.DebtorAccount
+		}
+		if party != nil {
+			switch {
+			case party.Party != nil:
+				r.CounterpartyName = party.Party.Name
+			case party.Agent != nil:
+				r.CounterpartyName = party.Agent.FinancialInstitutionID.Name
+			}
+		}
+		if account != nil {
+			r.CounterpartyAccount = optional(account.ID.Identifier())
+		}
+	}
+	if agents := tx.RelatedAgents; agents != nil {
+		agent := agents.CreditorAgent
+		if credit {
+			agent = agents.DebtorAgent
+		}
+		r.CounterpartyAgent = agentID(agent)
+	}
+	if tx.Purpose != nil {
+		r.Purpose = optional(code(tx.Purpose.Code, tx.Purpose.Proprietary))
+	}
+	if tx.RemittanceInfo != nil {
+		r.RemittanceInfo = optional(strings.Join(tx.RemittanceInfo.Unstructured, " "))
+	}
+}
+
+// Transactions returns the transaction records of a pacs.008
+func Transactions(doc interface{}) ([]TransactionRecord, error) {
+	d, ok := doc.(*iso20022.Pacs00800108Document)
+	if !ok {
+		return nil, fmt.Errorf("%w: %T is not a pacs.008.001.08", ErrUnsupportedDocument, doc)
+	}
+	msg := &d.FICustomerCreditTransfer
+	hdr := &msg.GroupHeader
+	records := make([]TransactionRecord, 0, len(msg.CreditTransferTransactionInfo))
+	for i, tx := range msg.CreditTransferTransactionInfo {
+		r := TransactionRecord{
+			MessageID:        hdr.MessageID,
+			MessageType:      "pacs.008.001.08",
+			CreationTime:     dateTime(hdr.CreationDateTime),
+			SettlementMethod: string(hdr.SettlementInfo.SettlementMethod),
+			TransactionIndex: int64(i),
+			InstructionID:    tx.PaymentID.InstructionID,
+			EndToEndID:       tx.PaymentID.EndToEndID,
+			TransactionID:    tx.PaymentID.TransactionID,
+			UETR:             tx.PaymentID.UETR,
+			Amount:           tx.InterbankSettlementAmount.Value,
+			Currency:         tx.InterbankSettlementAmount.Currency,
+			SettlementDate:   tx.InterbankSettlementDate,
+			ChargeBearer:     string(tx.ChargeBearer),
+			DebtorName:       tx.Debtor.Name,
+			DebtorCountry:    country(tx.Debtor),
+			DebtorAgent:      agentID(&tx.DebtorAgent),
+			CreditorName:     tx.Creditor.Name,
+			CreditorCountry:  country(tx.Creditor),
+			CreditorAgent:    agentID(&tx.CreditorAgent),
+			InstructingAgent: agentID(tx.InstructingAgent),
+			InstructedAgent:  agentID(tx.InstructedAgent),
+		}
+		if r.SettlementDate == nil {
+			r.SettlementDate = hdr.InterbankSettlementDate
+		}
+		if r.InstructingAgent == nil {
+			r.InstructingAgent = agentID(hdr.InstructingAgent)
+		}
+		if r.InstructedAgent == nil {
+			r.InstructedAgent = agentID(hdr.InstructedAgent)
+		}
+		if amt := tx.InstructedAmount; amt != nil {
+			r.InstructedAmount, r.InstructedCurrency = &amt.Value, &amt.Currency
+		}
+		paymentType := tx.PaymentTypeInfo
+		if paymentType == nil {
+			paymentType = hdr.PaymentTypeInfo
+		}
+		if p := paymentType; p != nil {
+			if p.InstructionPriority != nil {
+				r.Priority = optional(string(*p.InstructionPriority))
+			}
+			if len(p.ServiceLevel) > 0 {
+				r.ServiceLevel = optional(code(p.ServiceLevel[0].Code, p.ServiceLevel[0].Proprietary))
+			}
+			if p.LocalInstrument != nil {
+				r.LocalInstrument = optional(code(p.LocalInstrument.Code, p.LocalInstrument.Proprietary))
+			}
+			if p.CategoryPurpose != nil {
+				r.CategoryPurpose = optional(code(p.CategoryPurpose.Code, p.CategoryPurpose.Proprietary))
+			}
+		}
+		if tx.Purpose != nil {
+			r.Purpose = optional(code(tx.Purpose.Code, tx.Purpose.Proprietary))
+		}
+		if tx.DebtorAccount != nil {
+			r.DebtorAccount = optional(tx.DebtorAccount.ID.Identifier())
+		}
+		if tx.CreditorAccount != nil {
+			r.CreditorAccount = optional(tx.CreditorAccount.ID.Identifier())
+		}
+		if tx.RemittanceInfo != nil {
+			r.RemittanceInfo = optional(strings.Join(tx.RemittanceInfo.Unstructured, " "))
+		}
+		records = append(records, r)
+	}
+	return records, nil
+}
+
+// optional returns a pointer to s, or nil for the empty string
+func optional(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// code returns a code, or else a proprietary code
+func code(cd, prtry *string) string {
+	switch {
+	case cd != nil:
+		return *cd
+	case prtry != nil:
+		return *prtry
+	}
+	return ""
+}
+
+// agentID returns the BIC of an agent, or its clearing system member
+// identification or LEI
+func agentID(agent *iso20022.BranchAndFinancialInstitutionIdentification6) *string {
+	if agent == nil {
+		return nil
+	}
+	id := agent.FinancialInstitutionID
+	switch {
+	case id.BankIdentifierCode != nil:
+		return id.BankIdentifierCode
+	case id.ClearingSystemMemberID != nil:
+		return optional(id.ClearingSystemMemberID.MemberID)
+	}
+	return id.LegalEntityIdentifier
+}
+
+// country returns the country of the address of a party, or else its country of
+// residence
+func country(p iso20022.PartyIdentification135) *string {
+	if p.PostalAddress != nil && p.PostalAddress.Country != nil {
+		return p.PostalAddress.Country
+	}
+	return p.CountryOfResidence
+}
+
+// bankTransactionCode returns a bank transaction code as domain/family/sub-family,
+// or else its proprietary code
+func bankTransactionCode(c *iso20022.BankTransactionCodeStructure4) *string {
+	switch {
+	case c == nil:
+		return nil
+	case c.Domain != nil:
+		return optional(c.Domain.Code + "/" + c.Domain.Family.Code + "/" + c.Domain.Family.SubFamilyCode)
+	case c.Proprietary != nil:
+		return optional(c.Proprietary.Code)
+	}
+	return nil
+}
+
+// date returns the date of a date or date and time
+func date(d *iso20022.DateAndDateTime2) *iso20022.ISODate {
+	switch {
+	case d == nil:
+		return nil
+	case d.Date != nil:
+		return d.Date
+	case d.DateTime != nil:
+		y, m, day := d.DateTime.Date()
+		date := iso20022.NewISODate(y, m, day)
+		return &date
+	}
+	return nil
+}
+
+// dateTime returns the time of a date and time
+func dateTime(t *iso20022.ISODateTime) *time.Time {
+	if t == nil {
+		return nil
+	}
+	return &t.Time
+}
@@ -0,0 +1,212 @@
+package analytics
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ckbaum/iso20022-go"
+	"github.com/ckbaum/iso20022-go/samples"
+)
+
+// loadDocument decodes a round-trip fixture, for the tests that need entries the
+// samples do not have, such as batches and pending entries
+func loadDocument(t *testing.T, msgType, name string) interface{} {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("..", "testdata", "roundtrip", msgType, name))
+	if err != nil {
+		t.Fatalf("Failed to read fixture: %v", err)
+	}
+	_, doc, err := iso20022.DecodeDocument(data)
+	if err != nil {
+		t.Fatalf("Failed to decode fixture: %v", err)
+	}
+	return doc
+}
+
+func TestEntries(t *testing.T) {
+	records, err := Entries(loadDocument(t, "camt.054.001.08", "debit_credit_notification.xml"))
+	if err != nil {
+		t.Fatalf("Failed to flatten: %v", err)
+	}
+	if len(records) != 3 {
+		t.Fatalf("Expected a record per transaction, got %d", len(records))
+	}
+	credit := records[0]
+	if credit.MessageID != "NTF-20240315-0001" || credit.Account != "DE89370400440532013000" || credit.Amount != 1250 || credit.CreditDebit != "CRDT" ||
+		*credit.BankTransactionCode != "PMNT/RCDT/ESCT" || *credit.EndToEndID != "INV-2024-0311" || *credit.CounterpartyName != "Muller Maschinenbau GmbH" ||
+		*credit.CounterpartyAccount != "DE75512108001245126199" || *credit.RemittanceInfo != "Invoice 2024-0311" || credit.BookingDate == nil {
+		t.Errorf("Expected the credit from Muller Maschinenbau, got %+v", credit)
+	}
+	batch := records[2]
+	if batch.EntryIndex != 1 || batch.TransactionIndex != 1 || batch.Amount != 300 || *batch.TransactionAmount != 200 || *batch.CounterpartyName != "Jonas Weber" || batch.CounterpartyAccount != nil {
+		t.Errorf("Expected the second transaction of the batch, got %+v", batch)
+	}
+
+	report, err := Entries(loadDocument(t, "camt.052.001.08", "account_report.xml"))
+	if err != nil || len(report) != 3 || report[2].TransactionIndex != -1 || report[2].Status != "PDNG" {
+		t.Errorf("Expected a record per entry of the report, got %+v, %v", report, err)
+	}
+	if _, err := Entries(&iso20022.Pacs00800108Document{}); !errors.Is(err, ErrUnsupportedDocument) {
+		t.Errorf("Expected a pacs.008 to be refused, got %v", err)
+	}
+}
+
+func TestTransactions(t *testing.T) {
+	doc := samples.SamplePacs008(samples.WithBICs("COBADEFFXXX", "NWBKGB2LXXX"), samples.WithCurrencies("EUR"), samples.WithTransactions(1))
+	records, err := Transactions(doc)
+	if err != nil || len(records) != 1 {
+		t.Fatalf("Expected one transaction, got %+v, %v", records, err)
+	}
+	tx := doc.FICustomerCreditTransfer.CreditTransferTransactionInfo[0]
+	r := records[0]
+	if r.EndToEndID != tx.PaymentID.EndToEndID || *r.UETR != *tx.PaymentID.UETR || r.Amount != tx.InterbankSettlementAmount.Value || r.Currency != "EUR" ||
+		r.SettlementDate.String() != "2024-03-15" || *r.Purpose != *tx.Purpose.Code || *r.CreditorAccount != *tx.CreditorAccount.ID.IBAN {
+		t.Errorf("Expected the sample transaction, got %+v", r)
+	}
+	if agents := *r.DebtorAgent + "/" + *r.CreditorAgent; (agents != "COBADEFFXXX/NWBKGB2LXXX" && agents != "NWBKGB2LXXX/COBADEFFXXX") ||
+		*r.CreditorCountry != (*r.CreditorAgent)[4:6] {
+		t.Errorf("Expected the agents and creditor country of the sample, got %+v", r)
+	}
+	if _, err := Transactions(&iso20022.Camt05400108Document{}); !errors.Is(err, ErrUnsupportedDocument) {
+		t.Errorf("Expected a camt.054 to be refused, got %v", err)
+	}
+}
+
+func TestWriter(t *testing.T) {
+	doc := samples.SampleCamt054()
+	records, err := Entries(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, EntryRecord{})
+	if err != nil {
+		t.Fatalf("Failed to create writer: %v", err)
+	}
+	for i := range records {
+		if err := w.Write(&records[i]); err != nil {
+			t.Fatalf("Failed to write: %v", err)
+		}
+	}
+	if err := w.Write(TransactionRecord{}); err == nil {
+		t.Error("Expected a record of another type to be refused")
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Failed to close: %v", err)
+	}
+
+	file := readContainer(t, buf.Bytes())
+	if file.meta["avro.codec"] != "null" || file.count != 3 {
+		t.Fatalf("Expected 3 records without compression, got %d, %q", file.count, file.meta["avro.codec"])
+	}
+	var schema struct {
+		Name   string
+		Fields []struct {
+			Name string
+			Type interface{}
+		}
+	}
+	if err := json.Unmarshal([]byte(file.meta["avro.schema"]), &schema); err != nil || schema.Name != "EntryRecord" {
+		t.Fatalf("Expected the EntryRecord schema, got %s, %v", file.meta["avro.schema"], err)
+	}
+
+	// The first fields of the first record
+	r := &reader{data: file.block}
+	if got, want := r.string(), doc.BankDebitCreditNotification.GroupHeader.MsgID; got != want {
+		t.Errorf("Expected message_id %s, got %q", want, got)
+	}
+	if got := r.string(); got != "camt.054.001.08" {
+		t.Errorf("Expected message_type camt.054.001.08, got %q", got)
+	}
+	if branch := r.long(); branch != 1 || r.long() != time.Date(2024, time.March, 15, 10, 30, 0, 0, time.UTC).UnixMilli() {
+		t.Error("Expected creation_time 2024-03-15T10:30:00Z")
+	}
+}
+
+func TestDecimalBytes(t *testing.T) {
+	for _, c := range []struct {
+		amount iso20022.Decimal
+		want   int64
+	}{
+		{0, 0},
+		{1250, 125000000},
+		{0.00127, 127},
+		{0.00128, 128},
+		{-0.00128, -128},
+		{-0.00129, -129},
+		{-300.5, -30050000},
+	} {
+		b, err := decimalBytes(c.amount)
+		if err != nil {
+			t.Fatalf("%v: %v", c.amount, err)
+		}
+		got := new(big.Int).SetBytes(b)
+		if len(b) > 0 && b[0]&0x80 != 0 {
+			got.Sub(got, new(big.Int).Lsh(big.NewInt(1), uint(8*len(b))))
+		}
+		if got.Int64() != c.want {
+			t.Errorf("%v: expected unscaled %d, got %d from % x", c.amount, c.want, got, b)
+		}
+	}
+	if _, err := decimalBytes(0.000001); err == nil {
+		t.Error("Expected a sixth decimal place to be refused")
+	}
+	if _, err := decimalBytes(1e14); err == nil {
+		t.Error("Expected more than 18 digits to be refused")
+	}
+}
+
+// container is what readContainer reads of an object container file
+type container struct {
+	meta  map[string]string
+	count int64
+	block []byte
+}
+
+// readContainer reads the header and first block of an object container file
+func readContainer(t *testing.T, data []byte) container {
+	t.Helper()
+	if !bytes.HasPrefix(data, []byte("Obj\x01")) {
+		t.Fatalf("Expected the Avro magic, got % x", data[:4])
+	}
+	r := &reader{data: data[4:]}
+	c := container{meta: make(map[string]string)}
+	for n := r.long(); n != 0; n = r.long() {
+		for ; n > 0; n-- {
+			key := r.string()
+			c.meta[key] = r.string()
+		}
+	}
+	sync := r.next(16)
+	c.count = r.long()
+	c.block = r.next(int(r.long()))
+	if !bytes.Equal(r.next(16), sync) {
+		t.Fatal("Expected the block to end with the sync marker")
+	}
+	return c
+}
+
+type reader struct{ data []byte }
+
+func (r *reader) next(n int) []byte {
+	b := r.data[:n]
+	r.data = r.data[n:]
+	return b
+}
+
+func (r *reader) long() int64 {
+	u, n := binary.Uvarint(r.data)
+	r.data = r.data[n:]
+	return int64(u>>1) ^ -int64(u&1)
+}
+
+func (r *reader) string() string {
+	return string(r.next(int(r.long())))
+}
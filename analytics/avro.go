@@ -0,0 +1,293 @@
+package analytics
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"reflect"
+	"time"
+
+	"github.com/ckbaum/iso20022-go"
+)
+
+// Namespace is the namespace of the Avro records of the package
+const Namespace = "iso20022.analytics"
+
+// Decimals are written with the Avro decimal logical type, with this precision and
+// scale, which hold every amount of ISO 20022
+const (
+	DecimalPrecision = 18
+	DecimalScale     = 5
+)
+
+// blockRecords is the number of records of the blocks of a Writer
+const blockRecords = 1000
+
+var (
+	decimalType = reflect.TypeOf(iso20022.Decimal(0))
+	dateType    = reflect.TypeOf(iso20022.ISODate{})
+	timeType    = reflect.TypeOf(time.Time{})
+
+	// decimalFactor is 10^DecimalScale
+	decimalFactor = new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(DecimalScale), nil))
+	// decimalLimit is 10^DecimalPrecision, the first unscaled value that does not fit
+	decimalLimit = new(big.Int).Exp(big.NewInt(10), big.NewInt(DecimalPrecision), nil)
+)
+
+// avroField is a field of an Avro record schema
+type avroField struct {
+	Name    string          `json:"name"`
+	Type    interface{}     `json:"type"`
+	Default json.RawMessage `json:"default,omitempty"`
+	index   int
+	null    bool // a pointer, written as a union of null and the type
+	kind    reflect.Type
+}
+
+// avroRecord is an Avro record schema
+type avroRecord struct {
+	Type      string       `json:"type"`
+	Name      string       `json:"name"`
+	Namespace string       `json:"namespace"`
+	Fields    []*avroField `json:"fields"`
+}
+
+// recordSchema returns the Avro schema of a record type, a struct whose fields have
+// avro tags
+func recordSchema(t reflect.Type) (*avroRecord, error) {
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("analytics: %s is not a struct", t)
+	}
+	r := &avroRecord{Type: "record", Name: t.Name(), Namespace: Namespace}
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		name := sf.Tag.Get("avro")
+		if name == "" || !sf.IsExported() {
+			continue
+		}
+		f := &avroField{Name: name, index: i, kind: sf.Type}
+		if sf.Type.Kind() == reflect.Ptr {
+			f.null, f.kind = true, sf.Type.Elem()
+		}
+		var typ interface{}
+		switch {
+		case f.kind == decimalType:
+			typ = map[string]interface{}{"type": "bytes", "logicalType": "decimal", "precision": DecimalPrecision, "scale": DecimalScale}
+		case f.kind == dateType:
+			typ = map[string]interface{}{"type": "int", "logicalType": "date"}
+		case f.kind == timeType:
+			typ = map[string]interface{}{"type": "long", "logicalType": "timestamp-millis"}
+		case f.kind.Kind() == reflect.String:
+			typ = "string"
+		case f.kind.Kind() == reflect.Bool:
+			typ = "boolean"
+		case f.kind.Kind() == reflect.Int64:
+			typ = "long"
+		default:
+			return nil, fmt.Errorf("analytics: field %s of %s has unsupported type %s", sf.Name, t, sf.Type)
+		}
+		if f.null {
+			f.Type, f.Default = []interface{}{"null", typ}, json.RawMessage("null")
+		} else {
+			f.Type = typ
+		}
+		r.Fields = append(r.Fields, f)
+	}
+	return r, nil
+}
+
+// Schema returns the Avro schema of a record type, such as EntryRecord or
+// TransactionRecord, as JSON
+func Schema(record interface{}) (string, error) {
+	t := reflect.TypeOf(record)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil {
+		return "", fmt.Errorf("analytics: no record type")
+	}
+	r, err := recordSchema(t)
+	if err != nil {
+		return "", err
+	}
+	data, err := json.Marshal(r)
+	return string(data), err
+}
+
+// Writer writes records of one type as an Avro object container file, without
+// compression. Records are written in blocks, so Close must be called once the
+// last one is written.
+type Writer struct {
+	w      io.Writer
+	typ    reflect.Type
+	schema *avroRecord
+	sync   [16]byte
+	block  []byte
+	count  int
+	err    error
+}
+
+// NewWriter returns a writer of records of the type of record, such as
+// EntryRecord{}, and writes the header of the file to w
+func NewWriter(w io.Writer, record interface{}) (*Writer, error) {
+	t := reflect.TypeOf(record)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil {
+		return nil, fmt.Errorf("analytics: no record type")
+	}
+	schema, err := recordSchema(t)
+	if err != nil {
+		return nil, err
+	}
+	schemaJSON, err := json.Marshal(schema)
+	if err != nil {
+		return nil, err
+	}
+	aw := &Writer{w: w, typ: t, schema: schema}
+	if _, err := rand.Read(aw.sync[:]); err != nil {
+		return nil, err
+	}
+
+	header := []byte("Obj\x01")
+	header = appendLong(header, 2)
+	header = appendString(header, "avro.schema")
+	header = appendBytes(header, schemaJSON)
+	header = appendString(header, "avro.codec")
+	header = appendString(header, "null")
+	header = appendLong(header, 0)
+	header = append(header, aw.sync[:]...)
+	if _, err := w.Write(header); err != nil {
+		return nil, err
+	}
+	return aw, nil
+}
+
+// Write writes a record, or a pointer to one, of the type of the writer
+func (w *Writer) Write(record interface{}) error {
+	if w.err != nil {
+		return w.err
+	}
+	v := reflect.ValueOf(record)
+	for v.Kind() == reflect.Ptr && !v.IsNil() {
+		v = v.Elem()
+	}
+	if !v.IsValid() || v.Type() != w.typ {
+		return fmt.Errorf("analytics: writing a %T to a writer of %s", record, w.typ)
+	}
+	block, err := appendRecord(w.block, w.schema, v)
+	if err != nil {
+		return err
+	}
+	w.block = block
+	if w.count++; w.count >= blockRecords {
+		return w.Flush()
+	}
+	return nil
+}
+
+// Flush writes the records written since the last block as a block
+func (w *Writer) Flush() error {
+	if w.err != nil || w.count == 0 {
+		return w.err
+	}
+	b := appendLong(nil, int64(w.count))
+	b = appendLong(b, int64(len(w.block)))
+	b = append(b, w.block...)
+	b = append(b, w.sync[:]...)
+	if _, err := w.w.Write(b); err != nil {
+		w.err = err
+		return err
+	}
+	w.block, w.count = w.block[:0], 0
+	return nil
+}
+
+// Close flushes the records not yet written. It does not close the underlying
+// writer.
+func (w *Writer) Close() error {
+	return w.Flush()
+}
+
+// appendRecord appends the binary encoding of a record
+func appendRecord(b []byte, r *avroRecord, v reflect.Value) ([]byte, error) {
+	for _, f := range r.Fields {
+		fv := v.Field(f.index)
+		if f.null {
+			if fv.IsNil() {
+				b = appendLong(b, 0)
+				continue
+			}
+			b = appendLong(b, 1)
+			fv = fv.Elem()
+		}
+		switch {
+		case f.kind == decimalType:
+			unscaled, err := decimalBytes(iso20022.Decimal(fv.Float()))
+			if err != nil {
+				return nil, fmt.Errorf("analytics: %s: %w", f.Name, err)
+			}
+			b = appendBytes(b, unscaled)
+		case f.kind == dateType:
+			y, m, d := fv.Interface().(iso20022.ISODate).Date()
+			b = appendLong(b, time.Date(y, m, d, 0, 0, 0, 0, time.UTC).Unix()/86400)
+		case f.kind == timeType:
+			b = appendLong(b, fv.Interface().(time.Time).UnixMilli())
+		case f.kind.Kind() == reflect.String:
+			b = appendString(b, fv.String())
+		case f.kind.Kind() == reflect.Bool:
+			if fv.Bool() {
+				b = append(b, 1)
+			} else {
+				b = append(b, 0)
+			}
+		case f.kind.Kind() == reflect.Int64:
+			b = appendLong(b, fv.Int())
+		}
+	}
+	return b, nil
+}
+
+// decimalBytes returns the unscaled value of an amount as the big-endian two's
+// complement bytes of the Avro decimal logical type
+func decimalBytes(d iso20022.Decimal) ([]byte, error) {
+	r := iso20022.DecimalRat(d)
+	if r == nil {
+		return nil, fmt.Errorf("amount %v is not a number", d)
+	}
+	r.Mul(r, decimalFactor)
+	if !r.IsInt() {
+		return nil, fmt.Errorf("amount %v has more than %d decimal places", d, DecimalScale)
+	}
+	n := r.Num()
+	if new(big.Int).Abs(n).Cmp(decimalLimit) >= 0 {
+		return nil, fmt.Errorf("amount %v has more than %d digits", d, DecimalPrecision)
+	}
+	if n.Sign() >= 0 {
+		size := n.BitLen()/8 + 1
+		return n.FillBytes(make([]byte, size)), nil
+	}
+	magnitude := new(big.Int).Neg(n)
+	size := new(big.Int).Sub(magnitude, big.NewInt(1)).BitLen()/8 + 1
+	twos := new(big.Int).Lsh(big.NewInt(1), uint(8*size))
+	return twos.Add(twos, n).FillBytes(make([]byte, size)), nil
+}
+
+// appendLong appends a zig-zag encoded long
+func appendLong(b []byte, n int64) []byte {
+	return binary.AppendUvarint(b, uint64(n<<1)^uint64(n>>63))
+}
+
+func appendBytes(b, data []byte) []byte {
+	b = appendLong(b, int64(len(data)))
+	return append(b, data...)
+}
+
+func appendString(b []byte, s string) []byte {
+	b = appendLong(b, int64(len(s)))
+	return append(b, s...)
+}
@@ -0,0 +1,300 @@
+// Package bai2 exports bank-to-customer cash reports in the BAI2 cash management
+// format that US treasury workstations import.
+//
+// Write turns each camt.052 account report or camt.054 notification document into a
+// group (02 and 98 records) holding one account (03 and 49 records) per report or
+// notification. The 03 record carries the balances of the report and the totals of
+// its credit and debit entries, and each entry becomes a transaction detail (16)
+// record. BAI type codes of entries come from their bank transaction codes through
+// a mapping that callers can extend or override. Amounts are written in the minor unit
+// of the account currency, such as cents for USD and whole yen for JPY, and every
+// trailer carries the control total and record count of its level.
+package bai2
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ckbaum/iso20022-go"
+)
+
+// ErrUnsupported is returned for documents that are neither camt.052 nor camt.054
+var ErrUnsupported = errors.New("bai2: unsupported document")
+
+// Miscellaneous type codes, used for entries whose bank transaction code has no
+// mapping
+const (
+	MiscellaneousCredit = "399"
+	MiscellaneousDebit  = "699"
+)
+
+// DefaultTypeCodes maps bank transaction codes, written Domain/Family/SubFamily or
+// Domain/Family for a whole family, to BAI transaction type codes
+var DefaultTypeCodes = map[string]string{
+	"PMNT/RCDT":      "195", // incoming money transfer
+	"PMNT/ICDT":      "495", // outgoing money transfer
+	"PMNT/RCDT/ACDT": "169", // miscellaneous ACH credit
+	"PMNT/ICDT/ACDT": "469", // miscellaneous ACH debit
+	"PMNT/RCDT/BOOK": "206", // book transfer credit
+	"PMNT/ICDT/BOOK": "506", // book transfer debit
+	"PMNT/IDDT":      "165", // preauthorized ACH credit
+	"PMNT/RDDT":      "455", // preauthorized ACH debit
+	"PMNT/RCHQ":      "301", // commercial deposit
+	"PMNT/ICHQ":      "475", // check paid
+	"PMNT/LBOX":      "115", // lockbox deposit
+}
+
+// balanceTypeCodes maps balance types to BAI status type codes
+var balanceTypeCodes = map[string]string{
+	"OPBD": "010", // opening ledger
+	"CLBD": "015", // closing ledger
+	"ITBD": "030", // current ledger
+	"OPAV": "040", // opening available
+	"CLAV": "045", // closing available
+	"ITAV": "060", // current available
+}
+
+// Options identify the file and the parties to it
+type Options struct {
+	Sender   string // sender identification of the file header, usually the bank
+	Receiver string // receiver identification of the file header and the groups
+	FileID   string // file identification number, unique per sender and day
+	// TypeCodes map bank transaction codes to BAI type codes over DefaultTypeCodes
+	TypeCodes map[string]string
+	// Now gives the creation time of the file, time.Now when nil
+	Now func() time.Time
+}
+
+// Write writes a BAI2 file with one group per camt.052 or camt.054 document
+func Write(w io.Writer, opts Options, docs ...interface{}) error {
+	now := time.Now
+	if opts.Now != nil {
+		now = opts.Now
+	}
+	created := now()
+
+	f := &file{opts: opts}
+	f.record("01", opts.Sender, opts.Receiver, created.Format("060102"), created.Format("1504"), opts.FileID, "", "", "2")
+	fileTotal, groups := new(big.Int), 0
+	for _, doc := range docs {
+		total, err := f.group(doc, created)
+		if err != nil {
+			return err
+		}
+		fileTotal.Add(fileTotal, total)
+		groups++
+	}
+	f.record("99", fileTotal.String(), strconv.Itoa(groups), strconv.Itoa(f.records+1))
+	_, err := io.WriteString(w, f.String())
+	return err
+}
+
+// file builds the records of a BAI2 file, counting them for the trailers
+type file struct {
+	strings.Builder
+	opts    Options
+	records int // records of the file so far
+}
+
+// record writes a record of fields, ended with a slash
+func (f *file) record(fields ...string) {
+	f.WriteString(strings.Join(fields, ",") + "/\n")
+	f.records++
+}
+
+// detail writes a transaction detail record. Its text runs to the end of the record,
+// so the record is only ended with a slash when it has no text.
+func (f *file) detail(typeCode, amount, bankRef, customerRef, text string) {
+	fields := []string{"16", typeCode, amount, "Z", bankRef, customerRef}
+	if text == "" {
+		f.record(append(fields, "")...)
+		return
+	}
+	f.WriteString(strings.Join(append(fields, text), ",") + "\n")
+	f.records++
+}
+
+// group writes the group of a document and returns its control total
+func (f *file) group(doc interface{}, created time.Time) (*big.Int, error) {
+	type account struct {
+		account  iso20022.CashAccount39
+		balances []iso20022.CashBalance8
+		entries  []iso20022.ReportEntry10
+	}
+	var accounts []account
+	var asOf *iso20022.ISODateTime
+	switch d := doc.(type) {
+	case *iso20022.Camt05200108Document:
+		asOf = d.BankAccountReport.GroupHeader.CreationDateTime
+		for _, r := range d.BankAccountReport.Report {
+			accounts = append(accounts, account{r.Account, r.Balance, r.Entry})
+		}
+	case *iso20022.Camt05400108Document:
+		asOf = d.BankDebitCreditNotification.GroupHeader.CreationDateTime
+		for _, n := range d.BankDebitCreditNotification.Notification {
+			accounts = append(accounts, account{n.Account, nil, n.Entry})
+		}
+	default:
+		return nil, fmt.Errorf("%w %T", ErrUnsupported, doc)
+	}
+	at := created
+	if asOf != nil {
+		at = asOf.Time
+	}
+	var currency string
+	if len(accounts) > 0 {
+		currency = iso20022.AccountCurrency(accounts[0].account, accounts[0].entries)
+	}
+
+	start := f.records
+	f.record("02", f.opts.Receiver, f.opts.Sender, "1", at.Format("060102"), at.Format("1504"), currency, "2")
+	total := new(big.Int)
+	for _, a := range accounts {
+		total.Add(total, f.account(a.account, a.balances, a.entries))
+	}
+	f.record("98", total.String(), strconv.Itoa(len(accounts)), strconv.Itoa(f.records-start+1))
+	return total, nil
+}
+
+// account writes the records of an account and returns its control total: the sum
+// of the amounts of its 03 and 16 records, in the minor unit of the account currency
+func (f *file) account(acct iso20022.CashAccount39, balances []iso20022.CashBalance8, entries []iso20022.ReportEntry10) *big.Int {
+	start := f.records
+	total := new(big.Int)
+	currency := iso20022.AccountCurrency(acct, entries)
+	fields := []string{"03", acct.ID.Identifier(), currency}
+	for _, b := range balances {
+		code, ok := balanceTypeCodes[iso20022.BalanceCode(b.Type)]
+		if !ok {
+			continue
+		}
+		amount := minorAmount(signed(b.Amount.Value, b.CreditDebitIndicator), currency)
+		total.Add(total, amount)
+		fields = append(fields, code, amount.String(), "", "")
+	}
+
+	amounts := make([]*big.Int, len(entries))
+	credits, debits := new(big.Int), new(big.Int)
+	var nCredits, nDebits int
+	for i, e := range entries {
+		amounts[i] = minorAmount(iso20022.DecimalRat(e.Amount.Value), currency)
+		if e.CreditDebitIndicator == "DBIT" {
+			debits.Add(debits, amounts[i])
+			nDebits++
+		} else {
+			credits.Add(credits, amounts[i])
+			nCredits++
+		}
+	}
+	fields = append(fields, "100", credits.String(), strconv.Itoa(nCredits), "", "400", debits.String(), strconv.Itoa(nDebits), "")
+	total.Add(total, credits).Add(total, debits)
+	f.record(fields...)
+
+	for i, e := range entries {
+		total.Add(total, amounts[i])
+		f.detail(f.typeCode(e), amounts[i].String(), reference(bankReference(e)), reference(customerReference(e)), entryText(e))
+	}
+	f.record("49", total.String(), strconv.Itoa(f.records-start+1))
+	return total
+}
+
+// typeCode returns the BAI type code of an entry from its bank transaction code,
+// looking up the subfamily then the family. A code of the wrong direction for the
+// entry is ignored.
+func (f *file) typeCode(e iso20022.ReportEntry10) string {
+	fallback, credit := MiscellaneousCredit, e.CreditDebitIndicator != "DBIT"
+	if !credit {
+		fallback = MiscellaneousDebit
+	}
+	d := e.BankTransactionCode.Domain
+	if d == nil {
+		return fallback
+	}
+	family := d.Code + "/" + d.Family.Code
+	for _, key := range []string{family + "/" + d.Family.SubFamilyCode, family} {
+		code, ok := f.opts.TypeCodes[key]
+		if !ok {
+			code, ok = DefaultTypeCodes[key]
+		}
+		if ok && isCreditCode(code) == credit {
+			return code
+		}
+	}
+	return fallback
+}
+
+// isCreditCode reports whether a BAI detail type code is a credit: 100 to 399
+func isCreditCode(code string) bool {
+	n, err := strconv.Atoi(code)
+	return err == nil && n >= 100 && n < 400
+}
+
+// bankReference returns the reference of the account servicer for an entry
+func bankReference(e iso20022.ReportEntry10) string {
+	switch {
+	case e.AccountServicerReference != nil:
+		return *e.AccountServicerReference
+	case e.EntryReference != nil:
+		return *e.EntryReference
+	}
+	return ""
+}
+
+// customerReference returns the end-to-end identification of the transaction of an
+// entry, or the payment information identification of its batch
+func customerReference(e iso20022.ReportEntry10) string {
+	for _, details := range e.EntryDetails {
+		if len(details.TransactionDetails) == 1 {
+			if refs := details.TransactionDetails[0].References; refs != nil && refs.EndToEndID != nil && *refs.EndToEndID != "NOTPROVIDED" {
+				return *refs.EndToEndID
+			}
+		}
+		if details.Batch != nil && details.Batch.PaymentInfoID != nil {
+			return *details.Batch.PaymentInfoID
+		}
+	}
+	return ""
+}
+
+// entryText returns the text of a detail record: the unstructured remittance
+// information of the transaction of an entry, or else its additional information
+func entryText(e iso20022.ReportEntry10) string {
+	for _, details := range e.EntryDetails {
+		if len(details.TransactionDetails) == 1 {
+			if info := details.TransactionDetails[0].RemittanceInfo; info != nil && len(info.Unstructured) > 0 {
+				return strings.Join(info.Unstructured, " ")
+			}
+		}
+	}
+	if e.AdditionalEntryInfo != nil {
+		return *e.AdditionalEntryInfo
+	}
+	return ""
+}
+
+// reference removes the field and record delimiters from a reference
+func reference(s string) string {
+	return strings.NewReplacer(",", "", "/", "").Replace(s)
+}
+
+// signed returns an amount as an exact decimal, negative for debits
+func signed(d iso20022.Decimal, creditDebit iso20022.CreditDebitCode) *big.Rat {
+	r := iso20022.DecimalRat(d)
+	if creditDebit == iso20022.CreditDebitDBIT {
+		r.Neg(r)
+	}
+	return r
+}
+
+// minorAmount returns an amount in the minor unit of its currency, the implied
+// decimals of BAI2 amounts, rounded half away from zero
+func minorAmount(r *big.Rat, currency string) *big.Int {
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(iso20022.MinorUnits(currency))), nil)
+	n, _ := new(big.Int).SetString(new(big.Rat).Mul(r, new(big.Rat).SetInt(scale)).FloatString(0), 10)
+	return n
+}
@@ -0,0 +1,114 @@
+package bai2
+
+import (
+	"encoding/xml"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ckbaum/iso20022-go"
+)
+
+// load decodes a round-trip fixture, whose fixed content the expected output of
+// these tests is written from
+func load(t *testing.T, dir, name string, doc interface{}) {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("..", "testdata", "roundtrip", dir, name))
+	if err != nil {
+		t.Fatalf("Failed to read sample: %v", err)
+	}
+	if err := xml.Unmarshal(data, doc); err != nil {
+		t.Fatalf("Failed to parse sample: %v", err)
+	}
+}
+
+func TestWrite(t *testing.T) {
+	report := &iso20022.Camt05200108Document{}
+	load(t, "camt.052.001.08", "account_report.xml", report)
+	notification := &iso20022.Camt05400108Document{}
+	load(t, "camt.054.001.08", "debit_credit_notification.xml", notification)
+
+	opts := Options{
+		Sender:    "BANKUS33",
+		Receiver:  "CUST01",
+		FileID:    "1",
+		TypeCodes: map[string]string{"PMNT/CCRD/POSD": "699", "PMNT/RCDT/ESCT": "208"},
+		Now:       func() time.Time { return time.Date(2024, 3, 16, 6, 30, 0, 0, time.UTC) },
+	}
+	var b strings.Builder
+	if err := Write(&b, opts, report, notification); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	want := strings.Join([]string{
+		"01,BANKUS33,CUST01,240316,0630,1,,,2/",
+		"02,CUST01,BANKUS33,1,240315,2300,EUR,2/",
+		"03,DE89370400440532013000,EUR,010,1000000,,,015,1095000,,,100,125000,1,,400,37500,2,/",
+		"16,208,125000,Z,E-0001,,/",
+		"16,495,30000,Z,E-0002,,/",
+		"16,699,7500,Z,E-0003,,/",
+		"49,2420000,5/",
+		"98,2420000,1,7/",
+		"02,CUST01,BANKUS33,1,240315,1800,EUR,2/",
+		"03,DE89370400440532013000,EUR,100,125000,1,,400,30000,1,/",
+		"16,208,125000,Z,ASR-7781,INV-2024-0311,Invoice 2024-0311",
+		"16,495,30000,Z,E-0002,PMTINF-01,/",
+		"49,310000,4/",
+		"98,310000,1,6/",
+		"99,2730000,2,15/",
+	}, "\n") + "\n"
+	if got := b.String(); got != want {
+		t.Errorf("Unexpected file\n%s\nwant\n%s", got, want)
+	}
+
+	if err := Write(&b, opts, &iso20022.Pacs00800108Document{}); !errors.Is(err, ErrUnsupported) {
+		t.Errorf("Expected ErrUnsupported, got %v", err)
+	}
+}
+
+func TestTypeCodeDirection(t *testing.T) {
+	f := &file{opts: Options{TypeCodes: map[string]string{"PMNT/ICDT": "195"}}}
+	entry := iso20022.ReportEntry10{
+		CreditDebitIndicator: "DBIT",
+		BankTransactionCode: iso20022.BankTransactionCodeStructure4{Domain: &iso20022.BankTransactionCodeStructure5{
+			Code: "PMNT", Family: iso20022.BankTransactionCodeStructure6{Code: "ICDT", SubFamilyCode: "ACDT"},
+		}},
+	}
+	if code := f.typeCode(entry); code != "469" {
+		t.Errorf("Expected the subfamily code 469, got %s", code)
+	}
+	entry.BankTransactionCode.Domain.Family.SubFamilyCode = "ESCT"
+	if code := f.typeCode(entry); code != MiscellaneousDebit {
+		t.Errorf("Expected a credit code to be ignored for a debit, got %s", code)
+	}
+}
+
+func TestMinorUnits(t *testing.T) {
+	f := &file{}
+	entry := func(value iso20022.Decimal, currency string) iso20022.ReportEntry10 {
+		return iso20022.ReportEntry10{
+			Amount:               iso20022.ActiveOrHistoricCurrencyAndAmount{Value: value, Currency: currency},
+			CreditDebitIndicator: "CRDT",
+		}
+	}
+	tests := []struct {
+		currency string
+		value    iso20022.Decimal
+		want     string
+	}{
+		{"JPY", 1000, "1000"},
+		{"BHD", 12.345, "12345"},
+		{"USD", 0.295, "30"},
+		{"USD", 10.004, "1000"},
+	}
+	for _, tt := range tests {
+		f.Reset()
+		acct := iso20022.CashAccount39{Currency: &tt.currency}
+		f.account(acct, nil, []iso20022.ReportEntry10{entry(tt.value, tt.currency)})
+		if !strings.Contains(f.String(), ",100,"+tt.want+",1,") || !strings.Contains(f.String(), "\n16,399,"+tt.want+",Z,") {
+			t.Errorf("%v %s: expected amount %s in\n%s", tt.value, tt.currency, tt.want, f.String())
+		}
+	}
+}
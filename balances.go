@@ -0,0 +1,188 @@
+package iso20022
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+)
+
+// BalanceReconciliation is the outcome of ReconcileBalances. Amounts are signed:
+// positive for credit balances and negative for debit balances.
+type BalanceReconciliation struct {
+	Currency        string
+	OpeningType     string // OPBD, PRCD or ITBD
+	Opening         Decimal
+	ClosingType     string // CLBD or ITBD
+	Closing         Decimal
+	BookedEntries   int
+	Credits         Decimal // sum of the booked credit entries
+	Debits          Decimal // sum of the booked debit entries, as a positive amount
+	ExpectedClosing Decimal // Opening + Credits - Debits
+}
+
+// Difference returns the closing balance less the expected closing balance
+func (r BalanceReconciliation) Difference() Decimal {
+	diff := DecimalRat(r.Closing)
+	diff.Sub(diff, DecimalRat(r.ExpectedClosing))
+	return RatDecimal(diff)
+}
+
+// ReconcileBalances checks that the opening booked balance of a report plus its
+// booked entries gives the closing booked balance. The opening balance is OPBD, or
+// PRCD when the report has no OPBD, and the closing balance is CLBD. Intraday
+// reports without them are reconciled from their first to their last ITBD balance,
+// counting only the entries booked after the first one. Entries in another
+// currency than the balances, and totals in TxsSummry that do not match the booked
+// entries, are reported as well. The reconciliation is returned even when it fails.
+func ReconcileBalances(report AccountReport25) (BalanceReconciliation, error) {
+	var errs ValidationErrors
+	var result BalanceReconciliation
+
+	opening, closing := -1, -1
+	var interim []int
+	for i, bal := range report.Balance {
+		switch BalanceCode(bal.Type) {
+		case "OPBD":
+			opening = i
+		case "PRCD":
+			if opening < 0 || BalanceCode(report.Balance[opening].Type) != "OPBD" {
+				opening = i
+			}
+		case "CLBD":
+			closing = i
+		case "ITBD":
+			interim = append(interim, i)
+		}
+	}
+	intraday := false
+	if opening < 0 && len(interim) > 1 {
+		opening, intraday = interim[0], true
+	}
+	if closing < 0 && len(interim) > 0 && interim[len(interim)-1] != opening {
+		closing = interim[len(interim)-1]
+	}
+	if opening < 0 {
+		errs = append(errs, ValidationError{Field: "Bal", Message: "has no opening booked balance (OPBD, PRCD or ITBD)"})
+	}
+	if closing < 0 {
+		errs = append(errs, ValidationError{Field: "Bal", Message: "has no closing booked balance (CLBD or ITBD)"})
+	}
+	if errs.HasErrors() {
+		return result, errs
+	}
+
+	openBal, closeBal := report.Balance[opening], report.Balance[closing]
+	result.Currency = openBal.Amount.Currency
+	result.OpeningType, result.Opening = BalanceCode(openBal.Type), RatDecimal(signedAmount(openBal.Amount.Value, openBal.CreditDebitIndicator))
+	result.ClosingType, result.Closing = BalanceCode(closeBal.Type), RatDecimal(signedAmount(closeBal.Amount.Value, closeBal.CreditDebitIndicator))
+	if closeBal.Amount.Currency != result.Currency {
+		errs = append(errs, ValidationError{Field: "Ccy", Path: fmt.Sprintf("Bal[%d]/Amt/@Ccy", closing+1),
+			Message: fmt.Sprintf("must be %s, the currency of the opening balance", result.Currency)})
+	}
+
+	credits, debits := new(big.Rat), new(big.Rat)
+	creditCount, debitCount := 0, 0
+	for i, entry := range report.Entry {
+		if entry.Status.Code == nil || *entry.Status.Code != "BOOK" {
+			continue
+		}
+		if intraday && !bookedAfter(entry, openBal.Date) {
+			continue
+		}
+		if entry.Amount.Currency != result.Currency {
+			errs = append(errs, ValidationError{Field: "Ccy", Path: fmt.Sprintf("Ntry[%d]/Amt/@Ccy", i+1),
+				Message: fmt.Sprintf("must be %s, the currency of the balances", result.Currency)})
+			continue
+		}
+		result.BookedEntries++
+		switch entry.CreditDebitIndicator {
+		case "CRDT":
+			credits.Add(credits, DecimalRat(entry.Amount.Value))
+			creditCount++
+		case "DBIT":
+			debits.Add(debits, DecimalRat(entry.Amount.Value))
+			debitCount++
+		}
+	}
+	result.Credits, result.Debits = RatDecimal(credits), RatDecimal(debits)
+
+	expected := signedAmount(openBal.Amount.Value, openBal.CreditDebitIndicator)
+	expected.Add(expected, credits)
+	expected.Sub(expected, debits)
+	result.ExpectedClosing = RatDecimal(expected)
+	if closingValue := signedAmount(closeBal.Amount.Value, closeBal.CreditDebitIndicator); closingValue.Cmp(expected) != 0 {
+		errs = append(errs, ValidationError{Field: "Amt", Path: fmt.Sprintf("Bal[%d]/Amt/text()", closing+1),
+			Message: fmt.Sprintf("%s balance is %s but %s balance %s plus booked entries %s gives %s",
+				result.ClosingType, formatRat(closingValue), result.OpeningType,
+				formatRat(signedAmount(openBal.Amount.Value, openBal.CreditDebitIndicator)),
+				formatRat(new(big.Rat).Sub(credits, debits)), formatRat(expected))})
+	}
+
+	if summary := report.TransactionsSummary; summary != nil && !intraday {
+		errs = append(errs, checkEntryTotals(summary.TotalCreditEntries, "TxsSummry.TtlCdtNtries", creditCount, credits)...)
+		errs = append(errs, checkEntryTotals(summary.TotalDebitEntries, "TxsSummry.TtlDbtNtries", debitCount, debits)...)
+	}
+
+	if errs.HasErrors() {
+		return result, errs
+	}
+	return result, nil
+}
+
+// AccountCurrency returns the currency of an account, or else of the first of its
+// entries
+func AccountCurrency(account CashAccount39, entries []ReportEntry10) string {
+	if account.Currency != nil {
+		return *account.Currency
+	}
+	if len(entries) > 0 {
+		return entries[0].Amount.Currency
+	}
+	return ""
+}
+
+// BalanceCode returns the code, or else the proprietary type, of a balance
+func BalanceCode(t BalanceType13) string {
+	switch {
+	case t.CodeOrProprietary.Code != nil:
+		return *t.CodeOrProprietary.Code
+	case t.CodeOrProprietary.Proprietary != nil:
+		return *t.CodeOrProprietary.Proprietary
+	}
+	return ""
+}
+
+// signedAmount returns an amount as an exact decimal, negative for debits
+func signedAmount(value Decimal, creditDebit CreditDebitCode) *big.Rat {
+	r := DecimalRat(value)
+	if creditDebit == CreditDebitDBIT {
+		r.Neg(r)
+	}
+	return r
+}
+
+// bookedAfter reports whether an entry was booked after the date of a balance
+func bookedAfter(entry ReportEntry10, balance DateAndDateTime2) bool {
+	booked, at := dateOf(entry.BookingDate), dateOf(&balance)
+	return booked.IsZero() || at.IsZero() || booked.After(at)
+}
+
+// checkEntryTotals compares the number and sum of entries reported in a summary
+// with those counted
+func checkEntryTotals(totals *NumberAndSumOfTransactions1, field string, count int, sum *big.Rat) ValidationErrors {
+	if totals == nil {
+		return nil
+	}
+	var errs ValidationErrors
+	if totals.NumberOfEntries != nil {
+		if n, err := strconv.Atoi(*totals.NumberOfEntries); err == nil && n != count {
+			errs = append(errs, ValidationError{Field: field + ".NbOfNtries",
+				Message: fmt.Sprintf("is %d but the report contains %d booked entries", n, count)})
+		}
+	}
+	if totals.Sum != nil && DecimalRat(*totals.Sum).Cmp(sum) != 0 {
+		errs = append(errs, ValidationError{Field: field + ".Sum",
+			Message: fmt.Sprintf("is %s but the booked entries sum to %s", formatRat(DecimalRat(*totals.Sum)), formatRat(sum))})
+	}
+	return errs
+}
@@ -0,0 +1,113 @@
+package iso20022
+
+import (
+	"testing"
+)
+
+func TestReconcileBalances(t *testing.T) {
+	// The single report of the camt.052 fixture has booked entries that take the
+	// OPBD balance to the CLBD balance
+	result, err := ReconcileBalances(loadSample[Camt05200108Document](t, "camt.052.001.08/account_report.xml").BankAccountReport.Report[0])
+	if err != nil {
+		t.Fatalf("Expected the sample to reconcile, got %v", err)
+	}
+	if result.OpeningType != "OPBD" || result.ClosingType != "CLBD" || result.BookedEntries != 2 {
+		t.Errorf("Unexpected reconciliation %+v", result)
+	}
+	if result.Credits != 1250 || result.Debits != 300 || result.ExpectedClosing != 10950 || result.Difference() != 0 {
+		t.Errorf("Unexpected amounts %+v", result)
+	}
+
+	code := func(c string) BalanceType13 {
+		return BalanceType13{CodeOrProprietary: BalanceType10{Code: Ptr(c)}}
+	}
+	tests := []struct {
+		name   string
+		modify func(report *AccountReport25)
+		path   string
+	}{
+		{
+			name: "ClosingBalanceMismatch",
+			modify: func(report *AccountReport25) {
+				report.Balance[1].Amount.Value = 10900
+			},
+			path: "Bal[2]/Amt/text()",
+		},
+		{
+			name: "DebitOpeningBalance",
+			modify: func(report *AccountReport25) {
+				report.Balance[0].CreditDebitIndicator = "DBIT"
+			},
+			path: "Bal[2]/Amt/text()",
+		},
+		{
+			name: "EntryInOtherCurrency",
+			modify: func(report *AccountReport25) {
+				report.Entry[1].Amount.Currency = "USD"
+				report.Balance[1].Amount.Value = 11250
+				report.TransactionsSummary = nil
+			},
+			path: "Ntry[2]/Amt/@Ccy",
+		},
+		{
+			name: "SummaryCount",
+			modify: func(report *AccountReport25) {
+				report.TransactionsSummary.TotalDebitEntries.NumberOfEntries = Ptr("2")
+			},
+			path: "TxsSummry/TtlDbtNtries/NbOfNtries",
+		},
+		{
+			name: "NoClosingBalance",
+			modify: func(report *AccountReport25) {
+				report.Balance = report.Balance[:1]
+			},
+			path: "Bal",
+		},
+		{
+			name: "IntradayReport",
+			modify: func(report *AccountReport25) {
+				report.Balance[0].Type = code("ITBD")
+				report.Balance[1].Type = code("ITBD")
+				report.Balance[1].Amount.Value = 10900
+				report.TransactionsSummary = nil
+			},
+			path: "Bal[2]/Amt/text()",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := loadSample[Camt05200108Document](t, "camt.052.001.08/account_report.xml").BankAccountReport.Report[0]
+			tt.modify(&report)
+
+			_, err := ReconcileBalances(report)
+			errs, ok := err.(ValidationErrors)
+			if !ok || len(errs) != 1 {
+				t.Fatalf("Expected one discrepancy, got %v", err)
+			}
+			if errs[0].Location() != tt.path {
+				t.Errorf("Expected discrepancy at %s, got %s", tt.path, errs[0].Location())
+			}
+		})
+	}
+}
+
+func TestAccountIdentification(t *testing.T) {
+	report := loadSample[Camt05200108Document](t, "camt.052.001.08/account_report.xml").BankAccountReport.Report[0]
+	if got := report.Account.ID.Identifier(); got == "" || got != deref(report.Account.ID.IBAN) {
+		t.Errorf("Expected the IBAN, got %q", got)
+	}
+	other := AccountIdentification4{Other: &GenericAccountIdentification1{ID: "12345678"}}
+	if got := other.Identifier(); got != "12345678" {
+		t.Errorf("Expected the other identification, got %q", got)
+	}
+	if got := (AccountIdentification4{}).Identifier(); got != "" {
+		t.Errorf("Expected no identification, got %q", got)
+	}
+
+	if got := AccountCurrency(report.Account, report.Entry); got != report.Entry[0].Amount.Currency {
+		t.Errorf("Unexpected currency %q", got)
+	}
+	if got := AccountCurrency(CashAccount39{Currency: Ptr("CHF")}, report.Entry); got != "CHF" {
+		t.Errorf("Expected the account currency, got %q", got)
+	}
+}
@@ -0,0 +1,164 @@
+package iso20022
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+)
+
+// maxMessageIDLength is the length of a Max35Text message identification
+const maxMessageIDLength = 35
+
+// Split divides a pacs.008 message into messages of at most maxTx transactions, in
+// transaction order. Each part keeps the group header of the original, with the
+// message identification suffixed with the part number (MSG-1, MSG-2, ...), NbOfTxs
+// recomputed, and CtrlSum and TtlIntrBkSttlmAmt recomputed when the original has
+// them. With WithIDs, each part is identified by the generator instead. A message
+// of at most maxTx transactions is returned as a single copy with its
+// identification unchanged.
+func Split(doc *Pacs00800108Document, maxTx int, opts ...IDOption) ([]*Pacs00800108Document, error) {
+	if maxTx < 1 {
+		return nil, fmt.Errorf("maxTx must be positive, got %d", maxTx)
+	}
+	o := newIDOptions(opts)
+	msg := &doc.FICustomerCreditTransfer
+	txs := msg.CreditTransferTransactionInfo
+	if len(txs) <= maxTx {
+		part := *doc
+		part.FICustomerCreditTransfer.CreditTransferTransactionInfo = append([]CreditTransferTransaction39(nil), txs...)
+		return []*Pacs00800108Document{&part}, nil
+	}
+
+	parts := (len(txs) + maxTx - 1) / maxTx
+	suffixLength := len(strconv.Itoa(parts)) + 1
+	base := msg.GroupHeader.MessageID
+	if len(base)+suffixLength > maxMessageIDLength {
+		base = base[:maxMessageIDLength-suffixLength]
+	}
+
+	docs := make([]*Pacs00800108Document, 0, parts)
+	for start := 0; start < len(txs); start += maxTx {
+		end := start + maxTx
+		if end > len(txs) {
+			end = len(txs)
+		}
+		part := &Pacs00800108Document{XMLName: doc.XMLName, FICustomerCreditTransfer: FIToFICustomerCreditTransferV08{
+			GroupHeader:                   msg.GroupHeader,
+			CreditTransferTransactionInfo: append([]CreditTransferTransaction39(nil), txs[start:end]...),
+			SupplementaryData:             msg.SupplementaryData,
+		}}
+		id := ""
+		if o.ids == nil {
+			id = fmt.Sprintf("%s-%d", base, len(docs)+1)
+		} else if err := o.assign(&id); err != nil {
+			return nil, err
+		}
+		part.FICustomerCreditTransfer.GroupHeader.MessageID = id
+		part.FICustomerCreditTransfer.recomputeTotals()
+		docs = append(docs, part)
+	}
+	return docs, nil
+}
+
+// Merge joins pacs.008 messages into one, with the transactions in message order.
+// The messages must share their settlement information and batch booking
+// indicator. The interbank settlement date, payment type information and
+// instructing and instructed agents stay in the group header when they are the same
+// in every message; otherwise they are moved to the transactions that do not
+// specify their own. NbOfTxs is recomputed, as are CtrlSum and TtlIntrBkSttlmAmt
+// when every message has them. The merged message takes the identification and
+// creation date and time of the first message; give it a new identification before
+// sending it.
+func Merge(docs ...*Pacs00800108Document) (*Pacs00800108Document, error) {
+	if len(docs) == 0 {
+		return nil, errors.New("no messages to merge")
+	}
+	first := &docs[0].FICustomerCreditTransfer.GroupHeader
+	merged := &Pacs00800108Document{XMLName: docs[0].XMLName, FICustomerCreditTransfer: FIToFICustomerCreditTransferV08{GroupHeader: *first}}
+	hdr := &merged.FICustomerCreditTransfer.GroupHeader
+
+	sameDate, sameType, sameInstructing, sameInstructed := true, true, true, true
+	withSum, withTotal := true, true
+	for i, doc := range docs {
+		h := &doc.FICustomerCreditTransfer.GroupHeader
+		if !reflect.DeepEqual(h.SettlementInfo, first.SettlementInfo) {
+			return nil, fmt.Errorf("message %d (%s) has other settlement information than message 1 (%s)", i+1, h.MessageID, first.MessageID)
+		}
+		if batchBooking(h) != batchBooking(first) {
+			return nil, fmt.Errorf("message %d (%s) has another batch booking indicator than message 1 (%s)", i+1, h.MessageID, first.MessageID)
+		}
+		sameDate = sameDate && reflect.DeepEqual(h.InterbankSettlementDate, first.InterbankSettlementDate)
+		sameType = sameType && reflect.DeepEqual(h.PaymentTypeInfo, first.PaymentTypeInfo)
+		sameInstructing = sameInstructing && reflect.DeepEqual(h.InstructingAgent, first.InstructingAgent)
+		sameInstructed = sameInstructed && reflect.DeepEqual(h.InstructedAgent, first.InstructedAgent)
+		withSum = withSum && h.ControlSum != nil
+		withTotal = withTotal && h.TotalInterbankSettlementAmount != nil &&
+			h.TotalInterbankSettlementAmount.Currency == first.TotalInterbankSettlementAmount.Currency
+	}
+
+	for _, doc := range docs {
+		h := &doc.FICustomerCreditTransfer.GroupHeader
+		for _, tx := range doc.FICustomerCreditTransfer.CreditTransferTransactionInfo {
+			if !sameDate && tx.InterbankSettlementDate == nil {
+				tx.InterbankSettlementDate = h.InterbankSettlementDate
+			}
+			if !sameType && tx.PaymentTypeInfo == nil {
+				tx.PaymentTypeInfo = h.PaymentTypeInfo
+			}
+			if !sameInstructing && tx.InstructingAgent == nil {
+				tx.InstructingAgent = h.InstructingAgent
+			}
+			if !sameInstructed && tx.InstructedAgent == nil {
+				tx.InstructedAgent = h.InstructedAgent
+			}
+			merged.FICustomerCreditTransfer.CreditTransferTransactionInfo = append(merged.FICustomerCreditTransfer.CreditTransferTransactionInfo, tx)
+		}
+		merged.FICustomerCreditTransfer.SupplementaryData = append(merged.FICustomerCreditTransfer.SupplementaryData, doc.FICustomerCreditTransfer.SupplementaryData...)
+	}
+	if !sameDate {
+		hdr.InterbankSettlementDate = nil
+	}
+	if !sameType {
+		hdr.PaymentTypeInfo = nil
+	}
+	if !sameInstructing {
+		hdr.InstructingAgent = nil
+	}
+	if !sameInstructed {
+		hdr.InstructedAgent = nil
+	}
+	if !withSum {
+		hdr.ControlSum = nil
+	}
+	if !withTotal {
+		hdr.TotalInterbankSettlementAmount = nil
+	}
+	merged.FICustomerCreditTransfer.recomputeTotals()
+	return merged, nil
+}
+
+// recomputeTotals sets NbOfTxs, and CtrlSum and TtlIntrBkSttlmAmt when present, from
+// the transactions of the message
+func (f *FIToFICustomerCreditTransferV08) recomputeTotals() {
+	hdr := &f.GroupHeader
+	sum := new(big.Rat)
+	for _, tx := range f.CreditTransferTransactionInfo {
+		sum.Add(sum, DecimalRat(tx.InterbankSettlementAmount.Value))
+	}
+	hdr.NumberOfTransactions = strconv.Itoa(len(f.CreditTransferTransactionInfo))
+	if hdr.ControlSum != nil {
+		total := RatDecimal(sum)
+		hdr.ControlSum = &total
+	}
+	if hdr.TotalInterbankSettlementAmount != nil {
+		hdr.TotalInterbankSettlementAmount = &ActiveCurrencyAndAmount{Value: RatDecimal(sum), Currency: hdr.TotalInterbankSettlementAmount.Currency}
+	}
+}
+
+// batchBooking returns the batch booking indicator of a group header, which
+// defaults to true
+func batchBooking(hdr *GroupHeader93) bool {
+	return hdr.BatchBooking == nil || *hdr.BatchBooking
+}
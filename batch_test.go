@@ -0,0 +1,114 @@
+package iso20022
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// loadPacs008Batch returns the sample with n transactions and group totals
+func loadPacs008Batch(t *testing.T, n int) *Pacs00800108Document {
+	t.Helper()
+	doc := loadPacs008Sample(t)
+	msg := &doc.FICustomerCreditTransfer
+	tx := msg.CreditTransferTransactionInfo[0]
+	msg.CreditTransferTransactionInfo = nil
+	for i := 0; i < n; i++ {
+		tx.PaymentID.EndToEndID = fmt.Sprintf("E2E-%d", i+1)
+		tx.InterbankSettlementAmount.Value = Decimal(100*(i+1)) + 0.1
+		tx.InstructedAmount = nil
+		tx.ChargesInfo = nil
+		msg.CreditTransferTransactionInfo = append(msg.CreditTransferTransactionInfo, tx)
+	}
+	sum := Decimal(0)
+	msg.GroupHeader.ControlSum = &sum
+	msg.GroupHeader.TotalInterbankSettlementAmount = &ActiveCurrencyAndAmount{Currency: "USD"}
+	msg.recomputeTotals()
+	return doc
+}
+
+func TestSplitAndMerge(t *testing.T) {
+	doc := loadPacs008Batch(t, 5)
+	parts, err := Split(doc, 2)
+	if err != nil {
+		t.Fatalf("Split failed: %v", err)
+	}
+	if len(parts) != 3 {
+		t.Fatalf("Expected 3 parts, got %d", len(parts))
+	}
+	for i, part := range parts {
+		hdr := &part.FICustomerCreditTransfer.GroupHeader
+		if want := fmt.Sprintf("BBBBUS33-20240315-0001-%d", i+1); hdr.MessageID != want {
+			t.Errorf("Part %d: expected MsgId %s, got %s", i+1, want, hdr.MessageID)
+		}
+		if err := part.ValidateBusinessRules(); err != nil {
+			t.Errorf("Part %d: business rules failed: %v", i+1, err)
+		}
+	}
+	if got := parts[2].FICustomerCreditTransfer.GroupHeader; got.NumberOfTransactions != "1" || *got.ControlSum != 500.1 {
+		t.Errorf("Expected the last part to hold one transaction of 500.1, got %s, %v", got.NumberOfTransactions, *got.ControlSum)
+	}
+
+	merged, err := Merge(parts...)
+	if err != nil {
+		t.Fatalf("Merge failed: %v", err)
+	}
+	merged.FICustomerCreditTransfer.GroupHeader.MessageID = doc.FICustomerCreditTransfer.GroupHeader.MessageID
+	if !reflect.DeepEqual(merged, doc) {
+		t.Errorf("Merging the parts did not give back the original:\n%+v\n%+v", merged.FICustomerCreditTransfer.GroupHeader, doc.FICustomerCreditTransfer.GroupHeader)
+	}
+}
+
+func TestSplitShortensLongMessageIDs(t *testing.T) {
+	doc := loadPacs008Batch(t, 12)
+	doc.FICustomerCreditTransfer.GroupHeader.MessageID = "ABCDEFGHIJKLMNOPQRSTUVWXYZ012345678"
+	parts, err := Split(doc, 1)
+	if err != nil {
+		t.Fatalf("Split failed: %v", err)
+	}
+	if id := parts[11].FICustomerCreditTransfer.GroupHeader.MessageID; id != "ABCDEFGHIJKLMNOPQRSTUVWXYZ012345-12" {
+		t.Errorf("Unexpected MsgId %s", id)
+	}
+	if _, err := Split(doc, 0); err == nil {
+		t.Error("Expected an error for a maximum of zero transactions")
+	}
+}
+
+func TestMergeMovesDifferingHeaderElements(t *testing.T) {
+	a, b := loadPacs008Batch(t, 1), loadPacs008Batch(t, 2)
+	for _, doc := range []*Pacs00800108Document{a, b} {
+		for i := range doc.FICustomerCreditTransfer.CreditTransferTransactionInfo {
+			doc.FICustomerCreditTransfer.CreditTransferTransactionInfo[i].InterbankSettlementDate = nil
+		}
+	}
+	first, later := NewISODate(2024, time.March, 15), NewISODate(2024, time.March, 18)
+	a.FICustomerCreditTransfer.GroupHeader.InterbankSettlementDate = &first
+	b.FICustomerCreditTransfer.GroupHeader.InterbankSettlementDate = &later
+	b.FICustomerCreditTransfer.GroupHeader.ControlSum = nil
+
+	merged, err := Merge(a, b)
+	if err != nil {
+		t.Fatalf("Merge failed: %v", err)
+	}
+	msg := &merged.FICustomerCreditTransfer
+	if msg.GroupHeader.InterbankSettlementDate != nil || msg.GroupHeader.ControlSum != nil {
+		t.Errorf("Expected no group settlement date nor control sum, got %+v", msg.GroupHeader)
+	}
+	if msg.GroupHeader.NumberOfTransactions != "3" || msg.GroupHeader.TotalInterbankSettlementAmount.Value != 400.3 {
+		t.Errorf("Unexpected totals %+v", msg.GroupHeader)
+	}
+	for i, want := range []ISODate{first, later, later} {
+		if date := msg.CreditTransferTransactionInfo[i].InterbankSettlementDate; date == nil || !date.Equal(want.Time) {
+			t.Errorf("Transaction %d: expected settlement date %v, got %v", i+1, want, date)
+		}
+	}
+	if err := merged.ValidateBusinessRules(); err != nil {
+		t.Errorf("Merged message fails the business rules: %v", err)
+	}
+
+	b.FICustomerCreditTransfer.GroupHeader.SettlementInfo.SettlementMethod = "CLRG"
+	if _, err := Merge(a, b); err == nil {
+		t.Error("Expected an error for differing settlement information")
+	}
+}
@@ -0,0 +1,143 @@
+package iso20022
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+// Bulk files for the benchmarks, built once from the round-trip fixtures by
+// repeating their transactions and entries
+var (
+	bulkPacs008Once sync.Once
+	bulkPacs008     []byte
+	bulkCamt054Once sync.Once
+	bulkCamt054     []byte
+)
+
+// bulkPacs008XML returns a pacs.008 of 10,000 transactions
+func bulkPacs008XML(b *testing.B) []byte {
+	bulkPacs008Once.Do(func() {
+		doc := loadSample[Pacs00800108Document](b, "pacs.008.001.08/customer_credit_transfer.xml")
+		msg := &doc.FICustomerCreditTransfer
+		tx := msg.CreditTransferTransactionInfo[0]
+		msg.CreditTransferTransactionInfo = make([]CreditTransferTransaction39, 10000)
+		for i := range msg.CreditTransferTransactionInfo {
+			msg.CreditTransferTransactionInfo[i] = tx
+			msg.CreditTransferTransactionInfo[i].PaymentID.EndToEndID = "E2E-" + strconv.Itoa(i+1)
+		}
+		msg.GroupHeader.NumberOfTransactions = strconv.Itoa(len(msg.CreditTransferTransactionInfo))
+		bulkPacs008 = mustMarshal(b, doc)
+	})
+	return bulkPacs008
+}
+
+// bulkCamt054XML returns a camt.054 of 100,000 entries; there is no camt.053 in
+// this package, and the entries of a camt.054 have the same structure
+func bulkCamt054XML(b *testing.B) []byte {
+	bulkCamt054Once.Do(func() {
+		doc := loadSample[Camt05400108Document](b, "camt.054.001.08/debit_credit_notification.xml")
+		n := &doc.BankDebitCreditNotification.Notification[0]
+		entries := n.Entry
+		n.Entry = make([]ReportEntry10, 100000)
+		for i := range n.Entry {
+			n.Entry[i] = entries[i%len(entries)]
+		}
+		bulkCamt054 = mustMarshal(b, doc)
+	})
+	return bulkCamt054
+}
+
+func mustMarshal(b *testing.B, doc interface{}) []byte {
+	data, err := xml.Marshal(doc)
+	if err != nil {
+		b.Fatalf("Failed to marshal: %v", err)
+	}
+	return data
+}
+
+func BenchmarkUnmarshalPacs008(b *testing.B) {
+	data := bulkPacs008XML(b)
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		doc := new(Pacs00800108Document)
+		if err := xml.Unmarshal(data, doc); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkMarshalPacs008(b *testing.B) {
+	doc := new(Pacs00800108Document)
+	if err := xml.Unmarshal(bulkPacs008XML(b), doc); err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		data, err := Marshal(doc)
+		if err != nil {
+			b.Fatal(err)
+		}
+		b.SetBytes(int64(len(data)))
+	}
+}
+
+func BenchmarkValidatePacs008(b *testing.B) {
+	doc := new(Pacs00800108Document)
+	if err := xml.Unmarshal(bulkPacs008XML(b), doc); err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := doc.Validate(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkUnmarshalCamt054(b *testing.B) {
+	data := bulkCamt054XML(b)
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		doc := new(Camt05400108Document)
+		if err := xml.Unmarshal(data, doc); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkMarshalCamt054(b *testing.B) {
+	doc := new(Camt05400108Document)
+	if err := xml.Unmarshal(bulkCamt054XML(b), doc); err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var buf bytes.Buffer
+		if err := NewEncoder(&buf).Encode(doc); err != nil {
+			b.Fatal(err)
+		}
+		b.SetBytes(int64(buf.Len()))
+	}
+}
+
+func BenchmarkParsePacs008Headers(b *testing.B) {
+	data := bulkPacs008XML(b)
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := ParsePacs008Headers(data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
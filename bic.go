@@ -0,0 +1,217 @@
+package iso20022
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// BICRecord is the directory entry of a BIC
+type BICRecord struct {
+	BIC     string // 11-character BIC, with branch code XXX for the head office
+	Name    string
+	Address *PostalAddress24
+	Active  bool
+}
+
+// BICResolver looks up BICs in a directory such as SWIFTRef or an internal table.
+// LookupBIC receives an 8 or 11 character BIC and reports whether the directory
+// knows it; an error means the directory could not be consulted.
+type BICResolver interface {
+	LookupBIC(bic string) (BICRecord, bool, error)
+}
+
+// BICContextResolver is a BICResolver whose lookups can be cancelled. ValidateContext
+// and Validate pass their context to LookupBICContext instead of calling LookupBIC.
+type BICContextResolver interface {
+	BICResolver
+	LookupBICContext(ctx context.Context, bic string) (BICRecord, bool, error)
+}
+
+// bicResolverHolder lets atomic.Pointer store an interface value
+type bicResolverHolder struct {
+	resolver BICResolver
+}
+
+// defaultBICResolver is consulted by FinancialInstitutionIdentification18.Validate
+var defaultBICResolver atomic.Pointer[bicResolverHolder]
+
+// SetBICResolver makes Validate check every BICFI against r, reporting BICs that are
+// unknown or no longer active. Passing nil restores the default of checking the
+// format only.
+func SetBICResolver(r BICResolver) {
+	if r == nil {
+		defaultBICResolver.Store(nil)
+		return
+	}
+	defaultBICResolver.Store(&bicResolverHolder{resolver: r})
+}
+
+// validateBICDirectory checks a well-formed BIC against the configured resolver
+func validateBICDirectory(ctx context.Context, bic string, fieldName string) error {
+	holder := defaultBICResolver.Load()
+	// a validation whose context is done is abandoned, with the error of the context
+	if holder == nil || ctx.Err() != nil {
+		return nil
+	}
+	lookup := holder.resolver.LookupBIC
+	if r, ok := holder.resolver.(BICContextResolver); ok {
+		lookup = func(bic string) (BICRecord, bool, error) { return r.LookupBICContext(ctx, bic) }
+	}
+	record, ok, err := lookup(bic)
+	switch {
+	case err != nil:
+		return newValidationError(fieldName, RuleBICDirectory, "BIC_DIRECTORY_ERROR", err)
+	case !ok:
+		return newValidationError(fieldName, RuleBICDirectory, "BIC_DIRECTORY_UNKNOWN", bic)
+	case !record.Active:
+		return newValidationError(fieldName, RuleBICDirectory, "BIC_DIRECTORY_INACTIVE", bic)
+	}
+	return nil
+}
+
+// EnrichFromBIC fills in the name and postal address of the institution from its
+// directory entry, keeping any values already present. It reports whether the BIC
+// was found.
+func (f *FinancialInstitutionIdentification18) EnrichFromBIC(r BICResolver) (bool, error) {
+	if f.BankIdentifierCode == nil {
+		return false, nil
+	}
+	record, ok, err := r.LookupBIC(*f.BankIdentifierCode)
+	if err != nil || !ok {
+		return false, err
+	}
+	if f.Name == nil && record.Name != "" {
+		name := record.Name
+		f.Name = &name
+	}
+	if f.PostalAddress == nil && record.Address != nil {
+		address := *record.Address
+		address.AddressLine = append([]string(nil), address.AddressLine...)
+		f.PostalAddress = &address
+	}
+	return true, nil
+}
+
+// NormalizeBIC returns the 11-character form of a BIC: upper-cased, with a BIC8
+// completed by the XXX branch code
+func NormalizeBIC(bic string) string {
+	bic = strings.ToUpper(strings.TrimSpace(bic))
+	if len(bic) == 8 {
+		bic += "XXX"
+	}
+	return bic
+}
+
+// BICDirectory is an in-memory BICResolver. It is safe for concurrent use.
+type BICDirectory struct {
+	mu      sync.RWMutex
+	records map[string]BICRecord
+}
+
+// NewBICDirectory returns an empty directory
+func NewBICDirectory() *BICDirectory {
+	return &BICDirectory{records: make(map[string]BICRecord)}
+}
+
+// Add stores record, replacing any entry for the same BIC
+func (d *BICDirectory) Add(record BICRecord) {
+	record.BIC = NormalizeBIC(record.BIC)
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.records[record.BIC] = record
+}
+
+// Len returns the number of entries
+func (d *BICDirectory) Len() int {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return len(d.records)
+}
+
+// LookupBIC implements BICResolver. An 8-character BIC matches the head office
+// entry with branch code XXX.
+func (d *BICDirectory) LookupBIC(bic string) (BICRecord, bool, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	record, ok := d.records[NormalizeBIC(bic)]
+	return record, ok, nil
+}
+
+// bicCSVColumns maps the recognised CSV columns to the address fields they fill
+var bicCSVColumns = map[string]func(a *PostalAddress24) **string{
+	"department":           func(a *PostalAddress24) **string { return &a.Department },
+	"street_name":          func(a *PostalAddress24) **string { return &a.StreetName },
+	"building_number":      func(a *PostalAddress24) **string { return &a.BuildingNumber },
+	"post_code":            func(a *PostalAddress24) **string { return &a.PostCode },
+	"town_name":            func(a *PostalAddress24) **string { return &a.TownName },
+	"country_sub_division": func(a *PostalAddress24) **string { return &a.CountrySubDivision },
+	"country":              func(a *PostalAddress24) **string { return &a.Country },
+}
+
+// LoadBICDirectoryCSV reads a directory from CSV with a header row. The bic column is
+// required; name, the address columns department, street_name, building_number,
+// post_code, town_name, country_sub_division and country, and active (a boolean,
+// true when absent or empty) are optional. Other columns are ignored.
+func LoadBICDirectoryCSV(r io.Reader) (*BICDirectory, error) {
+	cr := csv.NewReader(r)
+	cr.TrimLeadingSpace = true
+	header, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("reading BIC directory header: %w", err)
+	}
+	columns := make(map[string]int)
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	bicColumn, ok := columns["bic"]
+	if !ok {
+		return nil, fmt.Errorf("BIC directory has no bic column")
+	}
+
+	dir := NewBICDirectory()
+	for {
+		row, err := cr.Read()
+		if err == io.EOF {
+			return dir, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading BIC directory: %w", err)
+		}
+		line, _ := cr.FieldPos(0)
+		value := func(column string) string {
+			if i, ok := columns[column]; ok && i < len(row) {
+				return strings.TrimSpace(row[i])
+			}
+			return ""
+		}
+
+		record := BICRecord{BIC: NormalizeBIC(row[bicColumn]), Name: value("name"), Active: true}
+		if err := validateBIC(record.BIC, "bic"); err != nil {
+			return nil, fmt.Errorf("BIC directory line %d: %w", line, err)
+		}
+		if active := value("active"); active != "" {
+			if record.Active, err = strconv.ParseBool(active); err != nil {
+				return nil, fmt.Errorf("BIC directory line %d: invalid active value %q", line, active)
+			}
+		}
+
+		var address PostalAddress24
+		hasAddress := false
+		for column, field := range bicCSVColumns {
+			if v := value(column); v != "" {
+				*field(&address) = &v
+				hasAddress = true
+			}
+		}
+		if hasAddress {
+			record.Address = &address
+		}
+		dir.Add(record)
+	}
+}
@@ -0,0 +1,165 @@
+package iso20022
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+const testBICDirectoryCSV = `bic,name,street_name,town_name,country,active
+DEUTDEFF,Deutsche Bank AG,Taunusanlage 12,Frankfurt am Main,DE,true
+BBBBUS33XXX,Bank B,,New York,US,
+OLDBGB2LXXX,Closed Bank plc,,,GB,false
+`
+
+func TestLoadBICDirectoryCSV(t *testing.T) {
+	dir, err := LoadBICDirectoryCSV(strings.NewReader(testBICDirectoryCSV))
+	if err != nil {
+		t.Fatalf("Failed to load directory: %v", err)
+	}
+	if dir.Len() != 3 {
+		t.Fatalf("Expected 3 entries, got %d", dir.Len())
+	}
+
+	record, ok, _ := dir.LookupBIC("DEUTDEFFXXX")
+	if !ok || record.Name != "Deutsche Bank AG" || !record.Active {
+		t.Errorf("Unexpected record for DEUTDEFFXXX: %+v", record)
+	}
+	if record.Address == nil || *record.Address.TownName != "Frankfurt am Main" || *record.Address.Country != "DE" {
+		t.Errorf("Expected the address to be loaded, got %+v", record.Address)
+	}
+	if _, ok, _ := dir.LookupBIC("BBBBUS33"); !ok {
+		t.Error("Expected an 8-character BIC to match the head office entry")
+	}
+	if record, _, _ := dir.LookupBIC("OLDBGB2L"); record.Active {
+		t.Error("Expected OLDBGB2L to be inactive")
+	}
+
+	for _, bad := range []string{"name\nBank", "bic\nNOT-A-BIC", "bic,active\nDEUTDEFF,maybe"} {
+		if _, err := LoadBICDirectoryCSV(strings.NewReader(bad)); err == nil {
+			t.Errorf("Expected an error loading %q", bad)
+		}
+	}
+}
+
+type failingBICResolver struct{}
+
+func (failingBICResolver) LookupBIC(string) (BICRecord, bool, error) {
+	return BICRecord{}, false, errors.New("directory unavailable")
+}
+
+func TestValidateWithBICResolver(t *testing.T) {
+	dir, err := LoadBICDirectoryCSV(strings.NewReader(testBICDirectoryCSV))
+	if err != nil {
+		t.Fatalf("Failed to load directory: %v", err)
+	}
+	SetBICResolver(dir)
+	defer SetBICResolver(nil)
+
+	tests := []struct {
+		bic     string
+		message string
+	}{
+		{"DEUTDEFF", ""},
+		{"BBBBUS33XXX", ""},
+		{"OLDBGB2L", "is not an active BIC"},
+		{"UNKNGB2L", "is not in the BIC directory"},
+	}
+	for _, tt := range tests {
+		fi := FinancialInstitutionIdentification18{BankIdentifierCode: Ptr(tt.bic)}
+		err := fi.Validate()
+		if tt.message == "" {
+			if err != nil {
+				t.Errorf("Expected %s to validate, got %v", tt.bic, err)
+			}
+		} else if err == nil || !strings.Contains(err.Error(), tt.message) {
+			t.Errorf("Expected %s to fail with %q, got %v", tt.bic, tt.message, err)
+		} else if errs := ValidationErrors(nil); !errors.As(err, &errs) || errs[0].Rule != RuleBICDirectory {
+			t.Errorf("Expected %s to fail the %s rule, got %+v", tt.bic, RuleBICDirectory, errs)
+		}
+	}
+
+	SetBICResolver(failingBICResolver{})
+	fi := FinancialInstitutionIdentification18{BankIdentifierCode: Ptr("DEUTDEFF")}
+	if err := fi.Validate(); err == nil || !strings.Contains(err.Error(), "directory unavailable") {
+		t.Errorf("Expected the resolver error to be reported, got %v", err)
+	}
+
+	SetBICResolver(nil)
+	fi = FinancialInstitutionIdentification18{BankIdentifierCode: Ptr("UNKNGB2L")}
+	if err := fi.Validate(); err != nil {
+		t.Errorf("Expected only the format to be checked without a resolver, got %v", err)
+	}
+}
+
+func TestEnrichFromBIC(t *testing.T) {
+	dir, err := LoadBICDirectoryCSV(strings.NewReader(testBICDirectoryCSV))
+	if err != nil {
+		t.Fatalf("Failed to load directory: %v", err)
+	}
+
+	fi := FinancialInstitutionIdentification18{BankIdentifierCode: Ptr("DEUTDEFF")}
+	found, err := fi.EnrichFromBIC(dir)
+	if err != nil || !found {
+		t.Fatalf("Expected DEUTDEFF to be found, got %v, %v", found, err)
+	}
+	if fi.Name == nil || *fi.Name != "Deutsche Bank AG" {
+		t.Errorf("Expected the name to be filled in, got %v", fi.Name)
+	}
+	if fi.PostalAddress == nil || *fi.PostalAddress.StreetName != "Taunusanlage 12" {
+		t.Errorf("Expected the address to be filled in, got %+v", fi.PostalAddress)
+	}
+
+	named := FinancialInstitutionIdentification18{BankIdentifierCode: Ptr("DEUTDEFF"), Name: Ptr("Deutsche Bank")}
+	if _, err := named.EnrichFromBIC(dir); err != nil {
+		t.Fatalf("EnrichFromBIC failed: %v", err)
+	}
+	if *named.Name != "Deutsche Bank" {
+		t.Errorf("Expected an existing name to be kept, got %q", *named.Name)
+	}
+
+	unknown := FinancialInstitutionIdentification18{BankIdentifierCode: Ptr("UNKNGB2L")}
+	if found, _ := unknown.EnrichFromBIC(dir); found || unknown.Name != nil {
+		t.Error("Expected an unknown BIC to be left alone")
+	}
+}
+
+// cancellingBICResolver cancels the validation at its first lookup
+type cancellingBICResolver struct {
+	cancel  context.CancelFunc
+	lookups int
+}
+
+func (r *cancellingBICResolver) LookupBIC(string) (BICRecord, bool, error) {
+	panic("LookupBIC called instead of LookupBICContext")
+}
+
+func (r *cancellingBICResolver) LookupBICContext(ctx context.Context, bic string) (BICRecord, bool, error) {
+	r.lookups++
+	r.cancel()
+	return BICRecord{}, false, ctx.Err()
+}
+
+func TestValidateWithBICContextResolver(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	r := &cancellingBICResolver{cancel: cancel}
+	SetBICResolver(r)
+	defer SetBICResolver(nil)
+
+	if err := ValidateContext(ctx, loadPacs008Sample(t)); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected the validation to be cancelled, got %v", err)
+	}
+	if r.lookups != 1 {
+		t.Errorf("Expected the walk to stop at the first lookup, got %d lookups", r.lookups)
+	}
+}
+
+func TestNormalizeBIC(t *testing.T) {
+	for in, want := range map[string]string{"deutdeff": "DEUTDEFFXXX", " DEUTDEFF500 ": "DEUTDEFF500", "DEUTDEFFXXX": "DEUTDEFFXXX"} {
+		if got := NormalizeBIC(in); got != want {
+			t.Errorf("NormalizeBIC(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,137 @@
+// Package btc describes and checks ISO 20022 Bank Transaction Codes, the
+// domain/family/sub-family triples such as PMNT/RCDT/ESCT with which account
+// servicers classify the entries of camt.052, camt.053 and camt.054 reports.
+//
+// Each family may only be used in certain domains, and each sub-family in certain
+// families. Check reports combinations outside the ISO code list, and Describe
+// returns the names of the codes, e.g. "Payments / Received Credit Transfers /
+// SEPA Credit Transfer".
+package btc
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Domain is an ExternalBankTransactionDomain1Code
+type Domain string
+
+// Family is an ExternalBankTransactionFamily1Code
+type Family string
+
+// SubFamily is an ExternalBankTransactionSubFamily1Code
+type SubFamily string
+
+// Name returns the description of the domain, or "" for unknown codes
+func (d Domain) Name() string { return domainNames[d] }
+
+// Name returns the description of the family, or "" for unknown codes
+func (f Family) Name() string { return familyNames[f] }
+
+// Name returns the description of the sub-family, or "" for unknown codes
+func (s SubFamily) Name() string { return subFamilyNames[s] }
+
+// Level identifies the part of a bank transaction code that failed a check
+type Level int
+
+// Levels of a bank transaction code
+const (
+	LevelDomain Level = iota
+	LevelFamily
+	LevelSubFamily
+)
+
+// String returns the name of the level
+func (l Level) String() string {
+	switch l {
+	case LevelDomain:
+		return "domain"
+	case LevelFamily:
+		return "family"
+	case LevelSubFamily:
+		return "sub-family"
+	}
+	return fmt.Sprintf("Level(%d)", int(l))
+}
+
+// CodeError reports a code that is unknown, or not allowed within its parent code
+type CodeError struct {
+	Level  Level
+	Code   string
+	Parent string // the domain of a family, or domain/family of a sub-family
+}
+
+// Error implements error
+func (e *CodeError) Error() string {
+	if e.Parent == "" {
+		return fmt.Sprintf("'%s' is not a bank transaction %s code", e.Code, e.Level)
+	}
+	return fmt.Sprintf("'%s' is not a %s of %s", e.Code, e.Level, e.Parent)
+}
+
+// Families returns the families allowed in a domain
+func Families(domain Domain) []Family {
+	return append([]Family(nil), domainFamilies[domain]...)
+}
+
+// SubFamilies returns the sub-families allowed in a family: those specific to it
+// and the generic ones, such as CHRG, FEES and OTHR
+func SubFamilies(family Family) []SubFamily {
+	if _, ok := familyNames[family]; !ok {
+		return nil
+	}
+	if family == FamilyNotAvailable {
+		return []SubFamily{SubFamilyNotAvailable}
+	}
+	subFamilies := append([]SubFamily(nil), familySubFamilies[family]...)
+	return append(subFamilies, genericSubFamilies...)
+}
+
+// Check reports whether domain, family and subFamily form a code of the ISO list.
+// The error is a *CodeError naming the first code that does not fit.
+func Check(domain, family, subFamily string) error {
+	d, f, s := Domain(domain), Family(family), SubFamily(subFamily)
+	if _, ok := domainNames[d]; !ok {
+		return &CodeError{Level: LevelDomain, Code: domain}
+	}
+	if !contains(domainFamilies[d], f) {
+		return &CodeError{Level: LevelFamily, Code: family, Parent: domain}
+	}
+	if !contains(SubFamilies(f), s) {
+		return &CodeError{Level: LevelSubFamily, Code: subFamily, Parent: domain + "/" + family}
+	}
+	return nil
+}
+
+// Description holds the names of the three parts of a bank transaction code
+type Description struct {
+	Domain    string
+	Family    string
+	SubFamily string
+}
+
+// String joins the names, e.g. "Payments / Received Credit Transfers / SEPA Credit Transfer"
+func (d Description) String() string {
+	return strings.Join([]string{d.Domain, d.Family, d.SubFamily}, " / ")
+}
+
+// Describe returns the names of a bank transaction code, after checking it with Check
+func Describe(domain, family, subFamily string) (Description, error) {
+	if err := Check(domain, family, subFamily); err != nil {
+		return Description{}, err
+	}
+	return Description{
+		Domain:    Domain(domain).Name(),
+		Family:    Family(family).Name(),
+		SubFamily: SubFamily(subFamily).Name(),
+	}, nil
+}
+
+func contains[T comparable](list []T, v T) bool {
+	for _, item := range list {
+		if item == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,65 @@
+package btc
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDescribe(t *testing.T) {
+	d, err := Describe("PMNT", "RCDT", "ESCT")
+	if err != nil {
+		t.Fatalf("Describe(PMNT, RCDT, ESCT) failed: %v", err)
+	}
+	if got := d.String(); got != "Payments / Received Credit Transfers / SEPA Credit Transfer" {
+		t.Errorf("Unexpected description %q", got)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		domain, family, subFamily string
+		level                     Level
+		valid                     bool
+	}{
+		{"PMNT", "RCDT", "ESCT", 0, true},
+		{"PMNT", "IDDT", "ESDD", 0, true},
+		{"PMNT", "ICDT", "CHRG", 0, true},
+		{"CAMT", "ACCB", "SWEP", 0, true},
+		{"XTND", "NTAV", "NTAV", 0, true},
+		{"PMNX", "RCDT", "ESCT", LevelDomain, false},
+		{"CAMT", "RCDT", "ESCT", LevelFamily, false},
+		{"PMNT", "RCDT", "ESDD", LevelSubFamily, false},
+		{"XTND", "NTAV", "OTHR", LevelSubFamily, false},
+	}
+	for _, tt := range tests {
+		err := Check(tt.domain, tt.family, tt.subFamily)
+		if tt.valid {
+			if err != nil {
+				t.Errorf("Check(%s, %s, %s) = %v, want nil", tt.domain, tt.family, tt.subFamily, err)
+			}
+			continue
+		}
+		var codeErr *CodeError
+		if !errors.As(err, &codeErr) || codeErr.Level != tt.level {
+			t.Errorf("Check(%s, %s, %s) = %v, want a %s error", tt.domain, tt.family, tt.subFamily, err, tt.level)
+		}
+	}
+}
+
+func TestCodeTablesAreConsistent(t *testing.T) {
+	for domain, families := range domainFamilies {
+		if domain.Name() == "" {
+			t.Errorf("Domain %s has no description", domain)
+		}
+		for _, family := range families {
+			if family.Name() == "" {
+				t.Errorf("Family %s of %s has no description", family, domain)
+			}
+			for _, subFamily := range SubFamilies(family) {
+				if subFamily.Name() == "" {
+					t.Errorf("Sub-family %s of %s has no description", subFamily, family)
+				}
+			}
+		}
+	}
+}
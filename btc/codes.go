@@ -0,0 +1,569 @@
+package btc
+
+// The codes below follow the ISO 20022 external code sets ExternalBankTransactionDomain1Code,
+// ExternalBankTransactionFamily1Code and ExternalBankTransactionSubFamily1Code.
+
+// Domain codes
+const (
+	DomainPayments                  Domain = "PMNT" // Payments
+	DomainCashManagement            Domain = "CAMT" // Cash Management
+	DomainAccountManagement         Domain = "ACMT" // Account Management
+	DomainLoansDepositsSyndications Domain = "LDAS" // Loans, Deposits & Syndications
+	DomainForeignExchange           Domain = "FORX" // Foreign Exchange
+	DomainPreciousMetal             Domain = "PMET" // Precious Metal
+	DomainCommodities               Domain = "CMDT" // Commodities
+	DomainDerivatives               Domain = "DERV" // Derivatives
+	DomainSecurities                Domain = "SECU" // Securities
+	DomainTradeServices             Domain = "TRAD" // Trade Services
+	DomainExtended                  Domain = "XTND" // Extended Domain
+)
+
+// Family codes. Families are shared between domains; Families lists the
+// families allowed in each domain.
+const (
+	FamilyReceivedCreditTransfers           Family = "RCDT" // Received Credit Transfers
+	FamilyIssuedCreditTransfers             Family = "ICDT" // Issued Credit Transfers
+	FamilyReceivedRealTimeCreditTransfers   Family = "RRCT" // Received Real-Time Credit Transfers
+	FamilyIssuedRealTimeCreditTransfers     Family = "IRCT" // Issued Real-Time Credit Transfers
+	FamilyReceivedCheques                   Family = "RCHQ" // Received Cheques
+	FamilyIssuedCheques                     Family = "ICHQ" // Issued Cheques
+	FamilyReceivedDirectDebits              Family = "RDDT" // Received Direct Debits
+	FamilyIssuedDirectDebits                Family = "IDDT" // Issued Direct Debits
+	FamilyCustomerCardTransactions          Family = "CCRD" // Customer Card Transactions
+	FamilyMerchantCardTransactions          Family = "MCRD" // Merchant Card Transactions
+	FamilyLockboxTransactions               Family = "LBOX" // Lockbox Transactions
+	FamilyCounterTransactions               Family = "CNTR" // Counter Transactions
+	FamilyDrafts                            Family = "DRFT" // Drafts/Bill of Orders
+	FamilyAccountBalancing                  Family = "ACCB" // Account Balancing
+	FamilyCashPooling                       Family = "CAPL" // Cash Pooling
+	FamilyOpeningClosing                    Family = "OPCL" // Opening & Closing
+	FamilyFixedTermDeposits                 Family = "FTDP" // Fixed Term Deposits
+	FamilyFixedTermLoans                    Family = "FTLN" // Fixed Term Loans
+	FamilyNoticeDeposits                    Family = "NTDP" // Notice Deposits
+	FamilyNoticeLoans                       Family = "NTLN" // Notice Loans
+	FamilyConsumerLoans                     Family = "CSLN" // Consumer Loans
+	FamilyMortgageLoans                     Family = "MGLN" // Mortgage Loans
+	FamilySyndications                      Family = "SYDN" // Syndications
+	FamilySpot                              Family = "SPOT" // Spot
+	FamilyForwards                          Family = "FWRD" // Forwards
+	FamilySwaps                             Family = "SWAP" // Swaps
+	FamilyNonDeliverable                    Family = "NDFX" // Non Deliverable
+	FamilyFutures                           Family = "FUTR" // Futures
+	FamilyOptions                           Family = "OPTN" // Options
+	FamilyDelivery                          Family = "DLVR" // Delivery
+	FamilyOTCDerivatives                    Family = "OTCD" // OTC Derivatives
+	FamilyListedFutures                     Family = "LFUT" // Listed Derivatives - Futures
+	FamilyListedOptions                     Family = "LOPT" // Listed Derivatives - Options
+	FamilyTradeSettlement                   Family = "SETT" // Trade, Clearing and Settlement
+	FamilyNonSettled                        Family = "NSET" // Non Settled
+	FamilyBlockedTransactions               Family = "BLOC" // Blocked Transactions
+	FamilyCSDBlockedTransactions            Family = "OTHB" // CSD Blocked Transactions
+	FamilyCorporateAction                   Family = "CORP" // Corporate Action
+	FamilyCollateralManagement              Family = "COLL" // Collateral Management
+	FamilyCustody                           Family = "CUST" // Custody
+	FamilyMiscellaneousSecuritiesOperations Family = "CASH" // Miscellaneous Securities Operations
+	FamilyLack                              Family = "LACK" // Lack
+	FamilyDocumentaryCredit                 Family = "DCCT" // Documentary Credit
+	FamilyDocumentaryCollection             Family = "DOCC" // Documentary Collection
+	FamilyCleanCollection                   Family = "CLNC" // Clean Collection
+	FamilyGuarantees                        Family = "GUAR" // Guarantees
+	FamilyStandbyLetterOfCredit             Family = "LOCT" // Stand-By Letter of Credit
+	FamilyMiscellaneousCreditOperations     Family = "MCOP" // Miscellaneous Credit Operations
+	FamilyMiscellaneousDebitOperations      Family = "MDOP" // Miscellaneous Debit Operations
+	FamilyNotAvailable                      Family = "NTAV" // Not Available
+	FamilyOther                             Family = "OTHR" // Other
+)
+
+// Sub-family codes
+const (
+	SubFamilyAccountClosing                          SubFamily = "ACCC" // Account Closing
+	SubFamilyAccountOpening                          SubFamily = "ACCO" // Account Opening
+	SubFamilyAccountTransfer                         SubFamily = "ACCT" // Account Transfer
+	SubFamilyACHCredit                               SubFamily = "ACDT" // ACH Credit
+	SubFamilyACHCorporateTrade                       SubFamily = "ACOR" // ACH Corporate Trade
+	SubFamilyACHDebit                                SubFamily = "ADBT" // ACH Debit
+	SubFamilyAdjustments                             SubFamily = "ADJT" // Adjustments
+	SubFamilyACHPreAuthorised                        SubFamily = "APAC" // ACH Pre-Authorised
+	SubFamilyACHReturn                               SubFamily = "ARET" // ACH Return
+	SubFamilyACHReversal                             SubFamily = "AREV" // ACH Reversal
+	SubFamilyARPDebit                                SubFamily = "ARPD" // ARP Debit
+	SubFamilyACHSettlement                           SubFamily = "ASET" // ACH Settlement
+	SubFamilyACHTransaction                          SubFamily = "ATXN" // ACH Transaction
+	SubFamilyAutomaticTransfer                       SubFamily = "AUTT" // Automatic Transfer
+	SubFamilyBranchAccountTransfer                   SubFamily = "BACT" // Branch Account Transfer
+	SubFamilySEPAB2BDirectDebit                      SubFamily = "BBDD" // SEPA B2B Direct Debit
+	SubFamilyBranchDeposit                           SubFamily = "BCDP" // Branch Deposit
+	SubFamilyBankCheque                              SubFamily = "BCHQ" // Bank Cheque
+	SubFamilyBranchWithdrawal                        SubFamily = "BCWD" // Branch Withdrawal
+	SubFamilyBonusIssue                              SubFamily = "BONU" // Bonus Issue
+	SubFamilyInternalBookTransfer                    SubFamily = "BOOK" // Internal Book Transfer
+	SubFamilyCreditAdjustments                       SubFamily = "CAJT" // Credit Adjustments
+	SubFamilyCapitalGainsDistribution                SubFamily = "CAPG" // Capital Gains Distribution
+	SubFamilyCashLetter                              SubFamily = "CASH" // Cash Letter
+	SubFamilyCertifiedCustomerCheque                 SubFamily = "CCCH" // Certified Customer Cheque
+	SubFamilyCheque                                  SubFamily = "CCHQ" // Cheque
+	SubFamilyControlledDisbursement                  SubFamily = "CDIS" // Controlled Disbursement
+	SubFamilyCashDeposit                             SubFamily = "CDPT" // Cash Deposit
+	SubFamilyChequeDeposit                           SubFamily = "CHKD" // Cheque Deposit
+	SubFamilyCharges                                 SubFamily = "CHRG" // Charges
+	SubFamilyCircularCheque                          SubFamily = "CLCQ" // Circular Cheque
+	SubFamilyCommission                              SubFamily = "COMM" // Commission
+	SubFamilyNonTaxableCommissions                   SubFamily = "COMT" // Non Taxable Commissions
+	SubFamilyChequeReversal                          SubFamily = "CQRV" // Cheque Reversal
+	SubFamilyCrossedCheque                           SubFamily = "CRCQ" // Crossed Cheque
+	SubFamilyCashLetterAdjustment                    SubFamily = "CSHA" // Cash Letter Adjustment
+	SubFamilyCashWithdrawal                          SubFamily = "CWDL" // Cash Withdrawal
+	SubFamilyDebitAdjustments                        SubFamily = "DAJT" // Debit Adjustments
+	SubFamilyDiscountedDraft                         SubFamily = "DDFT" // Discounted Draft
+	SubFamilyDrawdown                                SubFamily = "DDWN" // Drawdown
+	SubFamilyDraftMaturityChange                     SubFamily = "DMCG" // Draft Maturity Change
+	SubFamilyDomesticCreditTransfer                  SubFamily = "DMCT" // Domestic Credit Transfer
+	SubFamilyDeposit                                 SubFamily = "DPST" // Deposit
+	SubFamilyDividendReinvestment                    SubFamily = "DRIP" // Dividend Reinvestment
+	SubFamilyCashManagementControlledDisbursement    SubFamily = "DSBR" // Controlled Disbursement
+	SubFamilyCashDividend                            SubFamily = "DVCA" // Cash Dividend
+	SubFamilySEPACreditTransfer                      SubFamily = "ESCT" // SEPA Credit Transfer
+	SubFamilySEPACoreDirectDebit                     SubFamily = "ESDD" // SEPA Core Direct Debit
+	SubFamilyForeignCurrenciesDeposit                SubFamily = "FCDP" // Foreign Currencies Deposit
+	SubFamilyForeignCurrenciesWithdrawal             SubFamily = "FCWD" // Foreign Currencies Withdrawal
+	SubFamilyFees                                    SubFamily = "FEES" // Fees
+	SubFamilyFinancialInstitutionCreditTransfer      SubFamily = "FICT" // Financial Institution Credit Transfer
+	SubFamilyFinancialInstitutionOwnAccountTransfer  SubFamily = "FIOA" // Financial Institution Own Account Transfer
+	SubFamilyIntraCompanyTransfer                    SubFamily = "ICCT" // Intra Company Transfer
+	SubFamilyInterest                                SubFamily = "INTR" // Interest
+	SubFamilyLockboxCreditAdjustment                 SubFamily = "LBCA" // Credit Adjustment
+	SubFamilyLockboxDebit                            SubFamily = "LBDB" // Debit
+	SubFamilyLockboxDeposit                          SubFamily = "LBDP" // Deposit
+	SubFamilyMixedDeposit                            SubFamily = "MIXD" // Mixed Deposit
+	SubFamilyMiscellaneousDeposit                    SubFamily = "MSCD" // Miscellaneous Deposit
+	SubFamilyNotAvailable                            SubFamily = "NTAV" // Not Available
+	SubFamilyOverdraft                               SubFamily = "ODFT" // Overdraft
+	SubFamilyOneOffDirectDebit                       SubFamily = "OODD" // One-Off Direct Debit
+	SubFamilyOpenCheque                              SubFamily = "OPCQ" // Open Cheque
+	SubFamilyOrderCheque                             SubFamily = "ORCQ" // Order Cheque
+	SubFamilyOther                                   SubFamily = "OTHR" // Other
+	SubFamilyPreAuthorisedDirectDebit                SubFamily = "PADD" // Pre-Authorised Direct Debit
+	SubFamilyDirectDebitPayment                      SubFamily = "PMDD" // Direct Debit Payment
+	SubFamilyCreditCardPayment                       SubFamily = "POSC" // Credit Card Payment
+	SubFamilyPointOfSaleDebitCardPayment             SubFamily = "POSD" // Point-of-Sale Payment - Debit Card
+	SubFamilyPointOfSalePayment                      SubFamily = "POSP" // Point-of-Sale Payment
+	SubFamilyPrincipalPayment                        SubFamily = "PPAY" // Principal Payment
+	SubFamilyPriorityCreditTransfer                  SubFamily = "PRCT" // Priority Credit Transfer
+	SubFamilyReversalDueToPaymentReversal            SubFamily = "PRDD" // Reversal Due To Payment Reversal
+	SubFamilyPartialRedemption                       SubFamily = "PRED" // Partial Redemption
+	SubFamilyReversalDueToDirectDebitCancellation    SubFamily = "RCDD" // Reversal Due To Payment Cancellation Request
+	SubFamilyRedemption                              SubFamily = "REDM" // Redemption
+	SubFamilyRepo                                    SubFamily = "REPU" // Repo
+	SubFamilyReimbursements                          SubFamily = "RIMB" // Reimbursements
+	SubFamilyRenewal                                 SubFamily = "RNEW" // Renewal
+	SubFamilyReversalDueToPaymentCancellationRequest SubFamily = "RPCR" // Reversal Due To Payment Cancellation Request
+	SubFamilyRepayment                               SubFamily = "RPMT" // Repayment
+	SubFamilyReversalDueToPaymentReturn              SubFamily = "RRTN" // Reversal Due To Payment Return
+	SubFamilyReverseRepo                             SubFamily = "RVPO" // Reverse Repo
+	SubFamilyPayrollSalaryPayment                    SubFamily = "SALA" // Payroll/Salary Payment
+	SubFamilySameDayValueCreditTransfer              SubFamily = "SDVA" // Same Day Value Credit Transfer
+	SubFamilySecuritiesBorrowing                     SubFamily = "SECB" // Securities Borrowing
+	SubFamilySecuritiesLending                       SubFamily = "SECL" // Securities Lending
+	SubFamilyMerchantSmartCardPayment                SubFamily = "SMCD" // Smart-Card Payment
+	SubFamilySmartCardPayment                        SubFamily = "SMRT" // Smart-Card Payment
+	SubFamilyStockSplit                              SubFamily = "SPLF" // Stock Split
+	SubFamilySettlementAtMaturity                    SubFamily = "STAM" // Settlement At Maturity
+	SubFamilyStandingOrder                           SubFamily = "STDO" // Standing Order
+	SubFamilySettlementUnderReserve                  SubFamily = "STLR" // Settlement Under Reserve
+	SubFamilySubscription                            SubFamily = "SUBS" // Subscription
+	SubFamilySweeping                                SubFamily = "SWEP" // Sweeping
+	SubFamilyTaxes                                   SubFamily = "TAXE" // Taxes
+	SubFamilyTravellersChequesDeposit                SubFamily = "TCDP" // Travellers Cheques Deposit
+	SubFamilyTravellersChequesWithdrawal             SubFamily = "TCWD" // Travellers Cheques Withdrawal
+	SubFamilyTender                                  SubFamily = "TEND" // Tender
+	SubFamilyTopping                                 SubFamily = "TOPG" // Topping
+	SubFamilyTransferOut                             SubFamily = "TOUT" // Transfer Out
+	SubFamilyTrade                                   SubFamily = "TRAD" // Trade
+	SubFamilyTransferIn                              SubFamily = "TRIN" // Transfer In
+	SubFamilyTreasuryTaxAndLoanService               SubFamily = "TTLS" // Treasury Tax And Loan Service
+	SubFamilyUnpaidDraft                             SubFamily = "UDFT" // Dishonoured/Unpaid Draft
+	SubFamilyUnpaidCheque                            SubFamily = "UPCQ" // Unpaid Cheque
+	SubFamilyUnpaidCardTransaction                   SubFamily = "UPCT" // Unpaid Card Transaction
+	SubFamilyReversalDueToUnpaidDirectDebit          SubFamily = "UPDD" // Reversal Due To Return/Unpaid Direct Debit
+	SubFamilyChequeUnderReserve                      SubFamily = "URCQ" // Cheque Under Reserve
+	SubFamilyDirectDebitUnderReserve                 SubFamily = "URDD" // Direct Debit Under Reserve
+	SubFamilyCreditTransferWithCommercialInformation SubFamily = "VCOM" // Credit Transfer With Agreed Commercial Information
+	SubFamilyForeignCheque                           SubFamily = "XBCQ" // Foreign Cheque
+	SubFamilyCrossBorderCreditTransfer               SubFamily = "XBCT" // Cross-Border Credit Transfer
+	SubFamilyCrossBorderCashWithdrawal               SubFamily = "XBCW" // Cross-Border Cash Withdrawal
+	SubFamilyCrossBorderDirectDebit                  SubFamily = "XBDD" // Cross-Border Direct Debit
+	SubFamilyCrossBorder                             SubFamily = "XBRD" // Cross-Border
+	SubFamilyCrossBorderPayrollSalaryPayment         SubFamily = "XBSA" // Cross-Border Payroll/Salary Payment
+	SubFamilyCrossBorderStandingOrder                SubFamily = "XBST" // Cross-Border Standing Order
+	SubFamilyCrossBorderIntraCompanyTransaction      SubFamily = "XICT" // Cross-Border Intra Company Transaction
+	SubFamilyUnpaidForeignCheque                     SubFamily = "XPCQ" // Unpaid Foreign Cheque
+	SubFamilyForeignChequeUnderReserve               SubFamily = "XRCQ" // Foreign Cheque Under Reserve
+	SubFamilyZeroBalancing                           SubFamily = "ZABA" // Zero Balancing
+)
+
+// domainNames describes the domain codes
+var domainNames = map[Domain]string{
+	DomainPayments:                  "Payments",
+	DomainCashManagement:            "Cash Management",
+	DomainAccountManagement:         "Account Management",
+	DomainLoansDepositsSyndications: "Loans, Deposits & Syndications",
+	DomainForeignExchange:           "Foreign Exchange",
+	DomainPreciousMetal:             "Precious Metal",
+	DomainCommodities:               "Commodities",
+	DomainDerivatives:               "Derivatives",
+	DomainSecurities:                "Securities",
+	DomainTradeServices:             "Trade Services",
+	DomainExtended:                  "Extended Domain",
+}
+
+// familyNames describes the family codes
+var familyNames = map[Family]string{
+	FamilyReceivedCreditTransfers:           "Received Credit Transfers",
+	FamilyIssuedCreditTransfers:             "Issued Credit Transfers",
+	FamilyReceivedRealTimeCreditTransfers:   "Received Real-Time Credit Transfers",
+	FamilyIssuedRealTimeCreditTransfers:     "Issued Real-Time Credit Transfers",
+	FamilyReceivedCheques:                   "Received Cheques",
+	FamilyIssuedCheques:                     "Issued Cheques",
+	FamilyReceivedDirectDebits:              "Received Direct Debits",
+	FamilyIssuedDirectDebits:                "Issued Direct Debits",
+	FamilyCustomerCardTransactions:          "Customer Card Transactions",
+	FamilyMerchantCardTransactions:          "Merchant Card Transactions",
+	FamilyLockboxTransactions:               "Lockbox Transactions",
+	FamilyCounterTransactions:               "Counter Transactions",
+	FamilyDrafts:                            "Drafts/Bill of Orders",
+	FamilyAccountBalancing:                  "Account Balancing",
+	FamilyCashPooling:                       "Cash Pooling",
+	FamilyOpeningClosing:                    "Opening & Closing",
+	FamilyFixedTermDeposits:                 "Fixed Term Deposits",
+	FamilyFixedTermLoans:                    "Fixed Term Loans",
+	FamilyNoticeDeposits:                    "Notice Deposits",
+	FamilyNoticeLoans:                       "Notice Loans",
+	FamilyConsumerLoans:                     "Consumer Loans",
+	FamilyMortgageLoans:                     "Mortgage Loans",
+	FamilySyndications:                      "Syndications",
+	FamilySpot:                              "Spot",
+	FamilyForwards:                          "Forwards",
+	FamilySwaps:                             "Swaps",
+	FamilyNonDeliverable:                    "Non Deliverable",
+	FamilyFutures:                           "Futures",
+	FamilyOptions:                           "Options",
+	FamilyDelivery:                          "Delivery",
+	FamilyOTCDerivatives:                    "OTC Derivatives",
+	FamilyListedFutures:                     "Listed Derivatives - Futures",
+	FamilyListedOptions:                     "Listed Derivatives - Options",
+	FamilyTradeSettlement:                   "Trade, Clearing and Settlement",
+	FamilyNonSettled:                        "Non Settled",
+	FamilyBlockedTransactions:               "Blocked Transactions",
+	FamilyCSDBlockedTransactions:            "CSD Blocked Transactions",
+	FamilyCorporateAction:                   "Corporate Action",
+	FamilyCollateralManagement:              "Collateral Management",
+	FamilyCustody:                           "Custody",
+	FamilyMiscellaneousSecuritiesOperations: "Miscellaneous Securities Operations",
+	FamilyLack:                              "Lack",
+	FamilyDocumentaryCredit:                 "Documentary Credit",
+	FamilyDocumentaryCollection:             "Documentary Collection",
+	FamilyCleanCollection:                   "Clean Collection",
+	FamilyGuarantees:                        "Guarantees",
+	FamilyStandbyLetterOfCredit:             "Stand-By Letter of Credit",
+	FamilyMiscellaneousCreditOperations:     "Miscellaneous Credit Operations",
+	FamilyMiscellaneousDebitOperations:      "Miscellaneous Debit Operations",
+	FamilyNotAvailable:                      "Not Available",
+	FamilyOther:                             "Other",
+}
+
+// subFamilyNames describes the sub-family codes
+var subFamilyNames = map[SubFamily]string{
+	SubFamilyAccountClosing:                          "Account Closing",
+	SubFamilyAccountOpening:                          "Account Opening",
+	SubFamilyAccountTransfer:                         "Account Transfer",
+	SubFamilyACHCredit:                               "ACH Credit",
+	SubFamilyACHCorporateTrade:                       "ACH Corporate Trade",
+	SubFamilyACHDebit:                                "ACH Debit",
+	SubFamilyAdjustments:                             "Adjustments",
+	SubFamilyACHPreAuthorised:                        "ACH Pre-Authorised",
+	SubFamilyACHReturn:                               "ACH Return",
+	SubFamilyACHReversal:                             "ACH Reversal",
+	SubFamilyARPDebit:                                "ARP Debit",
+	SubFamilyACHSettlement:                           "ACH Settlement",
+	SubFamilyACHTransaction:                          "ACH Transaction",
+	SubFamilyAutomaticTransfer:                       "Automatic Transfer",
+	SubFamilyBranchAccountTransfer:                   "Branch Account Transfer",
+	SubFamilySEPAB2BDirectDebit:                      "SEPA B2B Direct Debit",
+	SubFamilyBranchDeposit:                           "Branch Deposit",
+	SubFamilyBankCheque:                              "Bank Cheque",
+	SubFamilyBranchWithdrawal:                        "Branch Withdrawal",
+	SubFamilyBonusIssue:                              "Bonus Issue",
+	SubFamilyInternalBookTransfer:                    "Internal Book Transfer",
+	SubFamilyCreditAdjustments:                       "Credit Adjustments",
+	SubFamilyCapitalGainsDistribution:                "Capital Gains Distribution",
+	SubFamilyCashLetter:                              "Cash Letter",
+	SubFamilyCertifiedCustomerCheque:                 "Certified Customer Cheque",
+	SubFamilyCheque:                                  "Cheque",
+	SubFamilyControlledDisbursement:                  "Controlled Disbursement",
+	SubFamilyCashDeposit:                             "Cash Deposit",
+	SubFamilyChequeDeposit:                           "Cheque Deposit",
+	SubFamilyCharges:                                 "Charges",
+	SubFamilyCircularCheque:                          "Circular Cheque",
+	SubFamilyCommission:                              "Commission",
+	SubFamilyNonTaxableCommissions:                   "Non Taxable Commissions",
+	SubFamilyChequeReversal:                          "Cheque Reversal",
+	SubFamilyCrossedCheque:                           "Crossed Cheque",
+	SubFamilyCashLetterAdjustment:                    "Cash Letter Adjustment",
+	SubFamilyCashWithdrawal:                          "Cash Withdrawal",
+	SubFamilyDebitAdjustments:                        "Debit Adjustments",
+	SubFamilyDiscountedDraft:                         "Discounted Draft",
+	SubFamilyDrawdown:                                "Drawdown",
+	SubFamilyDraftMaturityChange:                     "Draft Maturity Change",
+	SubFamilyDomesticCreditTransfer:                  "Domestic Credit Transfer",
+	SubFamilyDeposit:                                 "Deposit",
+	SubFamilyDividendReinvestment:                    "Dividend Reinvestment",
+	SubFamilyCashManagementControlledDisbursement:    "Controlled Disbursement",
+	SubFamilyCashDividend:                            "Cash Dividend",
+	SubFamilySEPACreditTransfer:                      "SEPA Credit Transfer",
+	SubFamilySEPACoreDirectDebit:                     "SEPA Core Direct Debit",
+	SubFamilyForeignCurrenciesDeposit:                "Foreign Currencies Deposit",
+	SubFamilyForeignCurrenciesWithdrawal:             "Foreign Currencies Withdrawal",
+	SubFamilyFees:                                    "Fees",
+	SubFamilyFinancialInstitutionCreditTransfer:      "Financial Institution Credit Transfer",
+	SubFamilyFinancialInstitutionOwnAccountTransfer:  "Financial Institution Own Account Transfer",
+	SubFamilyIntraCompanyTransfer:                    "Intra Company Transfer",
+	SubFamilyInterest:                                "Interest",
+	SubFamilyLockboxCreditAdjustment:                 "Credit Adjustment",
+	SubFamilyLockboxDebit:                            "Debit",
+	SubFamilyLockboxDeposit:                          "Deposit",
+	SubFamilyMixedDeposit:                            "Mixed Deposit",
+	SubFamilyMiscellaneousDeposit:                    "Miscellaneous Deposit",
+	SubFamilyNotAvailable:                            "Not Available",
+	SubFamilyOverdraft:                               "Overdraft",
+	SubFamilyOneOffDirectDebit:                       "One-Off Direct Debit",
+	SubFamilyOpenCheque:                              "Open Cheque",
+	SubFamilyOrderCheque:                             "Order Cheque",
+	SubFamilyOther:                                   "Other",
+	SubFamilyPreAuthorisedDirectDebit:                "Pre-Authorised Direct Debit",
+	SubFamilyDirectDebitPayment:                      "Direct Debit Payment",
+	SubFamilyCreditCardPayment:                       "Credit Card Payment",
+	SubFamilyPointOfSaleDebitCardPayment:             "Point-of-Sale Payment - Debit Card",
+	SubFamilyPointOfSalePayment:                      "Point-of-Sale Payment",
+	SubFamilyPrincipalPayment:                        "Principal Payment",
+	SubFamilyPriorityCreditTransfer:                  "Priority Credit Transfer",
+	SubFamilyReversalDueToPaymentReversal:            "Reversal Due To Payment Reversal",
+	SubFamilyPartialRedemption:                       "Partial Redemption",
+	SubFamilyReversalDueToDirectDebitCancellation:    "Reversal Due To Payment Cancellation Request",
+	SubFamilyRedemption:                              "Redemption",
+	SubFamilyRepo:                                    "Repo",
+	SubFamilyReimbursements:                          "Reimbursements",
+	SubFamilyRenewal:                                 "Renewal",
+	SubFamilyReversalDueToPaymentCancellationRequest: "Reversal Due To Payment Cancellation Request",
+	SubFamilyRepayment:                               "Repayment",
+	SubFamilyReversalDueToPaymentReturn:              "Reversal Due To Payment Return",
+	SubFamilyReverseRepo:                             "Reverse Repo",
+	SubFamilyPayrollSalaryPayment:                    "Payroll/Salary Payment",
+	SubFamilySameDayValueCreditTransfer:              "Same Day Value Credit Transfer",
+	SubFamilySecuritiesBorrowing:                     "Securities Borrowing",
+	SubFamilySecuritiesLending:                       "Securities Lending",
+	SubFamilyMerchantSmartCardPayment:                "Smart-Card Payment",
+	SubFamilySmartCardPayment:                        "Smart-Card Payment",
+	SubFamilyStockSplit:                              "Stock Split",
+	SubFamilySettlementAtMaturity:                    "Settlement At Maturity",
+	SubFamilyStandingOrder:                           "Standing Order",
+	SubFamilySettlementUnderReserve:                  "Settlement Under Reserve",
+	SubFamilySubscription:                            "Subscription",
+	SubFamilySweeping:                                "Sweeping",
+	SubFamilyTaxes:                                   "Taxes",
+	SubFamilyTravellersChequesDeposit:                "Travellers Cheques Deposit",
+	SubFamilyTravellersChequesWithdrawal:             "Travellers Cheques Withdrawal",
+	SubFamilyTender:                                  "Tender",
+	SubFamilyTopping:                                 "Topping",
+	SubFamilyTransferOut:                             "Transfer Out",
+	SubFamilyTrade:                                   "Trade",
+	SubFamilyTransferIn:                              "Transfer In",
+	SubFamilyTreasuryTaxAndLoanService:               "Treasury Tax And Loan Service",
+	SubFamilyUnpaidDraft:                             "Dishonoured/Unpaid Draft",
+	SubFamilyUnpaidCheque:                            "Unpaid Cheque",
+	SubFamilyUnpaidCardTransaction:                   "Unpaid Card Transaction",
+	SubFamilyReversalDueToUnpaidDirectDebit:          "Reversal Due To Return/Unpaid Direct Debit",
+	SubFamilyChequeUnderReserve:                      "Cheque Under Reserve",
+	SubFamilyDirectDebitUnderReserve:                 "Direct Debit Under Reserve",
+	SubFamilyCreditTransferWithCommercialInformation: "Credit Transfer With Agreed Commercial Information",
+	SubFamilyForeignCheque:                           "Foreign Cheque",
+	SubFamilyCrossBorderCreditTransfer:               "Cross-Border Credit Transfer",
+	SubFamilyCrossBorderCashWithdrawal:               "Cross-Border Cash Withdrawal",
+	SubFamilyCrossBorderDirectDebit:                  "Cross-Border Direct Debit",
+	SubFamilyCrossBorder:                             "Cross-Border",
+	SubFamilyCrossBorderPayrollSalaryPayment:         "Cross-Border Payroll/Salary Payment",
+	SubFamilyCrossBorderStandingOrder:                "Cross-Border Standing Order",
+	SubFamilyCrossBorderIntraCompanyTransaction:      "Cross-Border Intra Company Transaction",
+	SubFamilyUnpaidForeignCheque:                     "Unpaid Foreign Cheque",
+	SubFamilyForeignChequeUnderReserve:               "Foreign Cheque Under Reserve",
+	SubFamilyZeroBalancing:                           "Zero Balancing",
+}
+
+// Sub-families shared by groups of families. Every family also allows genericSubFamilies.
+var (
+	genericSubFamilies = []SubFamily{
+		SubFamilyAdjustments, SubFamilyCreditAdjustments, SubFamilyCharges, SubFamilyCommission,
+		SubFamilyNonTaxableCommissions, SubFamilyDebitAdjustments, SubFamilyFees, SubFamilyInterest,
+		SubFamilyReimbursements, SubFamilyTaxes, SubFamilyNotAvailable, SubFamilyOther,
+	}
+	creditTransferSubFamilies = []SubFamily{
+		SubFamilyACHCredit, SubFamilyACHCorporateTrade, SubFamilyACHPreAuthorised, SubFamilyACHReturn,
+		SubFamilyACHReversal, SubFamilyACHSettlement, SubFamilyACHTransaction,
+		SubFamilyAutomaticTransfer, SubFamilyBranchAccountTransfer, SubFamilyInternalBookTransfer,
+		SubFamilyDomesticCreditTransfer, SubFamilySEPACreditTransfer,
+		SubFamilyFinancialInstitutionCreditTransfer, SubFamilyFinancialInstitutionOwnAccountTransfer,
+		SubFamilyIntraCompanyTransfer, SubFamilyPriorityCreditTransfer,
+		SubFamilyReversalDueToPaymentCancellationRequest, SubFamilyReversalDueToPaymentReturn,
+		SubFamilyPayrollSalaryPayment, SubFamilySameDayValueCreditTransfer, SubFamilyStandingOrder,
+		SubFamilyTreasuryTaxAndLoanService, SubFamilyCreditTransferWithCommercialInformation,
+		SubFamilyCrossBorderCreditTransfer, SubFamilyCrossBorderPayrollSalaryPayment,
+		SubFamilyCrossBorderStandingOrder, SubFamilyCrossBorderIntraCompanyTransaction,
+	}
+	directDebitSubFamilies = []SubFamily{
+		SubFamilyACHCredit, SubFamilyACHPreAuthorised, SubFamilyACHReturn, SubFamilyACHReversal,
+		SubFamilyACHSettlement, SubFamilyACHTransaction, SubFamilyACHDebit, SubFamilySEPAB2BDirectDebit,
+		SubFamilySEPACoreDirectDebit, SubFamilyOneOffDirectDebit, SubFamilyPreAuthorisedDirectDebit,
+		SubFamilyDirectDebitPayment, SubFamilyReversalDueToPaymentReversal,
+		SubFamilyReversalDueToDirectDebitCancellation, SubFamilyReversalDueToUnpaidDirectDebit,
+		SubFamilyDirectDebitUnderReserve, SubFamilyCrossBorderDirectDebit,
+	}
+	chequeSubFamilies = []SubFamily{
+		SubFamilyARPDebit, SubFamilyBankCheque, SubFamilyCashLetter, SubFamilyCertifiedCustomerCheque,
+		SubFamilyCheque, SubFamilyControlledDisbursement, SubFamilyCircularCheque,
+		SubFamilyChequeReversal, SubFamilyCrossedCheque, SubFamilyCashLetterAdjustment,
+		SubFamilyOpenCheque, SubFamilyOrderCheque, SubFamilyUnpaidCheque, SubFamilyChequeUnderReserve,
+		SubFamilyForeignCheque, SubFamilyUnpaidForeignCheque, SubFamilyForeignChequeUnderReserve,
+	}
+	customerCardSubFamilies = []SubFamily{
+		SubFamilyCashDeposit, SubFamilyCashWithdrawal, SubFamilyCreditCardPayment,
+		SubFamilyPointOfSaleDebitCardPayment, SubFamilySmartCardPayment,
+		SubFamilyCrossBorderCashWithdrawal,
+	}
+	merchantCardSubFamilies = []SubFamily{
+		SubFamilyCreditCardPayment, SubFamilyPointOfSalePayment, SubFamilyMerchantSmartCardPayment,
+		SubFamilyUnpaidCardTransaction,
+	}
+	lockboxSubFamilies = []SubFamily{
+		SubFamilyLockboxCreditAdjustment, SubFamilyLockboxDebit, SubFamilyLockboxDeposit,
+	}
+	counterSubFamilies = []SubFamily{
+		SubFamilyBranchDeposit, SubFamilyBranchWithdrawal, SubFamilyCashDeposit, SubFamilyCashWithdrawal,
+		SubFamilyChequeDeposit, SubFamilyForeignCurrenciesDeposit, SubFamilyForeignCurrenciesWithdrawal,
+		SubFamilyMixedDeposit, SubFamilyMiscellaneousDeposit, SubFamilyTravellersChequesDeposit,
+		SubFamilyTravellersChequesWithdrawal,
+	}
+	draftSubFamilies = []SubFamily{
+		SubFamilyDiscountedDraft, SubFamilyDraftMaturityChange, SubFamilySettlementAtMaturity,
+		SubFamilySettlementUnderReserve, SubFamilyUnpaidDraft,
+	}
+	cashManagementSubFamilies = []SubFamily{
+		SubFamilyCashManagementControlledDisbursement, SubFamilyOverdraft, SubFamilySweeping,
+		SubFamilyTopping, SubFamilyCrossBorder, SubFamilyZeroBalancing,
+	}
+	accountManagementSubFamilies = []SubFamily{
+		SubFamilyAccountClosing, SubFamilyAccountOpening, SubFamilyAccountTransfer,
+	}
+	loanSubFamilies = []SubFamily{
+		SubFamilyDrawdown, SubFamilyDeposit, SubFamilyPrincipalPayment, SubFamilyRenewal,
+		SubFamilyRepayment,
+	}
+	settlementSubFamilies = []SubFamily{
+		SubFamilyRedemption, SubFamilyRepo, SubFamilyReverseRepo, SubFamilySecuritiesBorrowing,
+		SubFamilySecuritiesLending, SubFamilySubscription, SubFamilyTrade, SubFamilyTransferIn,
+		SubFamilyTransferOut,
+	}
+	corporateActionSubFamilies = []SubFamily{
+		SubFamilyBonusIssue, SubFamilyCapitalGainsDistribution, SubFamilyDividendReinvestment,
+		SubFamilyCashDividend, SubFamilyPartialRedemption, SubFamilyRedemption, SubFamilyStockSplit,
+		SubFamilyTender,
+	}
+)
+
+// familySubFamilies gives the sub-families specific to each family
+var familySubFamilies = map[Family][]SubFamily{
+	FamilyReceivedCreditTransfers:         creditTransferSubFamilies,
+	FamilyIssuedCreditTransfers:           creditTransferSubFamilies,
+	FamilyReceivedRealTimeCreditTransfers: creditTransferSubFamilies,
+	FamilyIssuedRealTimeCreditTransfers:   creditTransferSubFamilies,
+	FamilyReceivedCheques:                 chequeSubFamilies,
+	FamilyIssuedCheques:                   chequeSubFamilies,
+	FamilyReceivedDirectDebits:            directDebitSubFamilies,
+	FamilyIssuedDirectDebits:              directDebitSubFamilies,
+	FamilyCustomerCardTransactions:        customerCardSubFamilies,
+	FamilyMerchantCardTransactions:        merchantCardSubFamilies,
+	FamilyLockboxTransactions:             lockboxSubFamilies,
+	FamilyCounterTransactions:             counterSubFamilies,
+	FamilyDrafts:                          draftSubFamilies,
+	FamilyAccountBalancing:                cashManagementSubFamilies,
+	FamilyCashPooling:                     cashManagementSubFamilies,
+	FamilyOpeningClosing:                  accountManagementSubFamilies,
+	FamilyFixedTermDeposits:               loanSubFamilies,
+	FamilyFixedTermLoans:                  loanSubFamilies,
+	FamilyNoticeDeposits:                  loanSubFamilies,
+	FamilyNoticeLoans:                     loanSubFamilies,
+	FamilyConsumerLoans:                   loanSubFamilies,
+	FamilyMortgageLoans:                   loanSubFamilies,
+	FamilySyndications:                    loanSubFamilies,
+	FamilyTradeSettlement:                 settlementSubFamilies,
+	FamilyNonSettled:                      settlementSubFamilies,
+	FamilyCorporateAction:                 corporateActionSubFamilies,
+}
+
+// domainFamilies gives the families allowed in each domain
+var domainFamilies = map[Domain][]Family{
+	DomainPayments: {
+		FamilyReceivedCreditTransfers, FamilyIssuedCreditTransfers,
+		FamilyReceivedRealTimeCreditTransfers, FamilyIssuedRealTimeCreditTransfers,
+		FamilyReceivedCheques, FamilyIssuedCheques, FamilyReceivedDirectDebits, FamilyIssuedDirectDebits,
+		FamilyCustomerCardTransactions, FamilyMerchantCardTransactions, FamilyLockboxTransactions,
+		FamilyCounterTransactions, FamilyDrafts, FamilyMiscellaneousCreditOperations,
+		FamilyMiscellaneousDebitOperations, FamilyNotAvailable, FamilyOther,
+	},
+	DomainCashManagement: {
+		FamilyAccountBalancing, FamilyCashPooling, FamilyMiscellaneousCreditOperations,
+		FamilyMiscellaneousDebitOperations, FamilyNotAvailable, FamilyOther,
+	},
+	DomainAccountManagement: {
+		FamilyOpeningClosing, FamilyMiscellaneousCreditOperations, FamilyMiscellaneousDebitOperations,
+		FamilyNotAvailable, FamilyOther,
+	},
+	DomainLoansDepositsSyndications: {
+		FamilyFixedTermDeposits, FamilyFixedTermLoans, FamilyNoticeDeposits, FamilyNoticeLoans,
+		FamilyConsumerLoans, FamilyMortgageLoans, FamilySyndications,
+		FamilyMiscellaneousCreditOperations, FamilyMiscellaneousDebitOperations, FamilyNotAvailable,
+		FamilyOther,
+	},
+	DomainForeignExchange: {
+		FamilySpot, FamilyForwards, FamilySwaps, FamilyNonDeliverable,
+		FamilyMiscellaneousCreditOperations, FamilyMiscellaneousDebitOperations, FamilyNotAvailable,
+		FamilyOther,
+	},
+	DomainPreciousMetal: {
+		FamilySpot, FamilyFutures, FamilyOptions, FamilyForwards, FamilyDelivery,
+		FamilyMiscellaneousCreditOperations, FamilyMiscellaneousDebitOperations, FamilyNotAvailable,
+		FamilyOther,
+	},
+	DomainCommodities: {
+		FamilySpot, FamilyFutures, FamilyOptions, FamilyForwards, FamilyDelivery,
+		FamilyMiscellaneousCreditOperations, FamilyMiscellaneousDebitOperations, FamilyNotAvailable,
+		FamilyOther,
+	},
+	DomainDerivatives: {
+		FamilyOTCDerivatives, FamilyListedFutures, FamilyListedOptions,
+		FamilyMiscellaneousCreditOperations, FamilyMiscellaneousDebitOperations, FamilyNotAvailable,
+		FamilyOther,
+	},
+	DomainSecurities: {
+		FamilyTradeSettlement, FamilyNonSettled, FamilyBlockedTransactions, FamilyCSDBlockedTransactions,
+		FamilyCorporateAction, FamilyCollateralManagement, FamilyCustody,
+		FamilyMiscellaneousSecuritiesOperations, FamilyLack, FamilyMiscellaneousCreditOperations,
+		FamilyMiscellaneousDebitOperations, FamilyNotAvailable, FamilyOther,
+	},
+	DomainTradeServices: {
+		FamilyDocumentaryCredit, FamilyDocumentaryCollection, FamilyCleanCollection, FamilyGuarantees,
+		FamilyStandbyLetterOfCredit, FamilyMiscellaneousCreditOperations,
+		FamilyMiscellaneousDebitOperations, FamilyNotAvailable, FamilyOther,
+	},
+	DomainExtended: {
+		FamilyNotAvailable,
+	},
+}
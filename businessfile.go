@@ -0,0 +1,345 @@
+package iso20022
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Namespaces of the Business File Header and of the Business Application Headers
+// of its payloads
+const (
+	businessFileNamespace = namespacePrefix + "head.002.001.01"
+	appHdrNamespace       = namespacePrefix + "head.001.001.02"
+)
+
+// ErrFileTotals is returned for a business file whose payloads do not add up to
+// the total number of documents or the manifest of its payload description
+var ErrFileTotals = errors.New("business file totals do not match its payloads")
+
+// BusinessFileHeaderV01 - head.002.001.01, the Business File Header exchanged by
+// the infrastructures that send many business messages in one file. Each payload
+// holds the AppHdr and the Document of a message, kept as they appear in the file;
+// use FileReader and FileWriter to read and write them one at a time.
+type BusinessFileHeaderV01 struct {
+	XMLName            xml.Name            `xml:"urn:iso:std:iso:20022:tech:xsd:head.002.001.01 Xchg"`
+	PayloadDescription PayloadDescription1 `xml:"PyldDesc"`
+	Payload            []StrictPayload     `xml:"Pyld"`
+}
+
+// PayloadDescription1 describes the payloads of a business file
+type PayloadDescription1 struct {
+	PayloadDetails                 PayloadDetails1        `xml:"PyldDtls"`
+	ApplicationSpecificInformation *ApplicationSpecifics1 `xml:"ApplSpcfInf,omitempty"`
+	PayloadTypeDetails             PayloadTypeDetails1    `xml:"PyldTpDtls"`
+	ManifestDetails                []ManifestDetails1     `xml:"MnfstDtls,omitempty"`
+}
+
+// PayloadDetails1 identifies the payload of a business file
+type PayloadDetails1 struct {
+	PayloadIdentifier       string      `xml:"PyldIdr"`                 // Max35Text
+	CreationDateAndDateTime ISODateTime `xml:"CreDtAndTm"`              // ISODateTime
+	PossibleDuplicateFlag   *bool       `xml:"PssblDplctFlg,omitempty"` // YesNoIndicator
+}
+
+// ApplicationSpecifics1 carries the information of the sending application
+type ApplicationSpecifics1 struct {
+	SystemUser             []string           `xml:"SysUsr,omitempty"` // Max140Text
+	Signature              *SignatureEnvelope `xml:"Sgntr,omitempty"`
+	TotalNumberOfDocuments string             `xml:"TtlNbOfDocs"` // Number
+}
+
+// PayloadTypeDetails1 tells the type of the payload
+type PayloadTypeDetails1 struct {
+	Type string `xml:"Tp"` // PayloadType1Code, such as XML
+}
+
+// ManifestDetails1 gives the number of documents of a type in the payload
+type ManifestDetails1 struct {
+	DocumentType      string `xml:"DocTp"`    // Max35Text, such as pacs.008.001.08
+	NumberOfDocuments string `xml:"NbOfDocs"` // Number
+}
+
+// StrictPayload is a payload of a business file: the AppHdr and Document of a
+// business message
+type StrictPayload struct {
+	Content RawXML `xml:",innerxml"`
+}
+
+// Validate checks the payload identifier and the totals of the file against its
+// payloads
+func (f *BusinessFileHeaderV01) Validate() error {
+	var errs ValidationErrors
+	if err := validateRequired(f.PayloadDescription.PayloadDetails.PayloadIdentifier, "PyldDesc.PyldDtls.PyldIdr"); err != nil {
+		errs = append(errs, err.(ValidationError))
+	}
+	counts := make(map[string]int)
+	for _, p := range f.Payload {
+		counts[payloadMessageType(p.Content)]++
+	}
+	if err := f.PayloadDescription.checkTotals(len(f.Payload), counts); err != nil {
+		errs = append(errs, ValidationError{Field: "PyldDesc", Message: err.Error()})
+	}
+	if errs.HasErrors() {
+		return errs
+	}
+	return nil
+}
+
+// payloadMessageType returns the message of the Document of a payload, read from
+// its namespace
+func payloadMessageType(content []byte) string {
+	d := xml.NewDecoder(bytes.NewReader(content))
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return ""
+		}
+		if start, ok := tok.(xml.StartElement); ok && start.Name.Local == "Document" {
+			return strings.TrimPrefix(resolveNamespace(start.Name.Space), namespacePrefix)
+		}
+	}
+}
+
+// checkTotals checks the number of documents of a file, in total and per message,
+// against the TtlNbOfDocs and the manifest of its description, when it has them
+func (p *PayloadDescription1) checkTotals(total int, counts map[string]int) error {
+	if info := p.ApplicationSpecificInformation; info != nil && info.TotalNumberOfDocuments != strconv.Itoa(total) {
+		return fmt.Errorf("%w: TtlNbOfDocs is %s but the file has %d documents", ErrFileTotals, info.TotalNumberOfDocuments, total)
+	}
+	if len(p.ManifestDetails) == 0 {
+		return nil
+	}
+	declared := make(map[string]bool)
+	for _, m := range p.ManifestDetails {
+		declared[m.DocumentType] = true
+		if n := counts[m.DocumentType]; m.NumberOfDocuments != strconv.Itoa(n) {
+			return fmt.Errorf("%w: NbOfDocs of %s is %s but the file has %d", ErrFileTotals, m.DocumentType, m.NumberOfDocuments, n)
+		}
+	}
+	var missing []string
+	for msgType := range counts {
+		if !declared[msgType] {
+			missing = append(missing, msgType)
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return fmt.Errorf("%w: the manifest has no %s", ErrFileTotals, strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// FileMessage is a business message of a business file
+type FileMessage struct {
+	AppHdr      *BusinessApplicationHeaderV02
+	MessageType string // such as pacs.008.001.08
+	Document    interface{}
+}
+
+// FileWriter writes a business file one message at a time. The payload
+// description is written first, so its totals must be known before the messages
+// are written; Close checks them.
+type FileWriter struct {
+	enc    *Encoder
+	desc   PayloadDescription1
+	total  int
+	counts map[string]int
+}
+
+// NewFileWriter writes the start of a business file described by desc to w. The
+// options apply to every message of the file.
+func NewFileWriter(w io.Writer, desc PayloadDescription1, opts ...EncodeOption) (*FileWriter, error) {
+	f := &FileWriter{enc: NewEncoder(w, opts...), desc: desc, counts: make(map[string]int)}
+	start := xml.StartElement{Name: xml.Name{Space: businessFileNamespace, Local: "Xchg"}}
+	if err := f.enc.enc.EncodeToken(start); err != nil {
+		return nil, err
+	}
+	if err := f.enc.encodeElement(desc, xml.StartElement{Name: xml.Name{Local: "PyldDesc"}}); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// Write writes a message of the file: the header appHdr, which may be nil, and the
+// document doc
+func (f *FileWriter) Write(appHdr *BusinessApplicationHeaderV02, doc interface{}) error {
+	payload := xml.StartElement{Name: xml.Name{Local: "Pyld"}}
+	if err := f.enc.enc.EncodeToken(payload); err != nil {
+		return err
+	}
+	if appHdr != nil {
+		if err := f.enc.encodeElement(appHdr, xml.StartElement{Name: xml.Name{Space: appHdrNamespace, Local: "AppHdr"}}); err != nil {
+			return err
+		}
+	}
+	if err := f.enc.Encode(doc); err != nil {
+		return err
+	}
+	if err := f.enc.enc.EncodeToken(payload.End()); err != nil {
+		return err
+	}
+	f.total++
+	f.counts[documentMessageType(doc)]++
+	return nil
+}
+
+// Close writes the end of the file and checks that the messages written add up to
+// the totals of its description
+func (f *FileWriter) Close() error {
+	if err := f.enc.enc.EncodeToken(xml.EndElement{Name: xml.Name{Space: businessFileNamespace, Local: "Xchg"}}); err != nil {
+		return err
+	}
+	if err := f.enc.enc.Flush(); err != nil {
+		return err
+	}
+	return f.desc.checkTotals(f.total, f.counts)
+}
+
+// FileReader reads the messages of a business file one at a time, without holding
+// the file in memory
+type FileReader struct {
+	d      *xml.Decoder
+	desc   PayloadDescription1
+	total  int
+	counts map[string]int
+	done   bool
+}
+
+// NewFileReader reads the start and the payload description of the business file
+// of r. Documents in a namespace registered with RegisterNamespace are read as
+// documents of its message.
+func NewFileReader(r io.Reader) (*FileReader, error) {
+	f := &FileReader{d: xml.NewTokenDecoder(&namespaceReader{d: xml.NewDecoder(r)}), counts: make(map[string]int)}
+	start, err := f.nextStart()
+	if err != nil {
+		return nil, err
+	}
+	if start.Name.Space != businessFileNamespace || start.Name.Local != "Xchg" {
+		return nil, fmt.Errorf("%w: <%s xmlns=%q> is not a business file", ErrUnknownMessage, start.Name.Local, start.Name.Space)
+	}
+	if start, err = f.nextStart(); err != nil {
+		return nil, err
+	}
+	if start.Name.Local != "PyldDesc" {
+		return nil, fmt.Errorf("business file starts with <%s> rather than <PyldDesc>", start.Name.Local)
+	}
+	if err := f.d.DecodeElement(&f.desc, &start); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// nextStart returns the next start element
+func (f *FileReader) nextStart() (xml.StartElement, error) {
+	for {
+		tok, err := f.d.Token()
+		if err == io.EOF {
+			return xml.StartElement{}, io.ErrUnexpectedEOF
+		}
+		if err != nil {
+			return xml.StartElement{}, err
+		}
+		if start, ok := tok.(xml.StartElement); ok {
+			return start, nil
+		}
+	}
+}
+
+// Description returns the payload description of the file
+func (f *FileReader) Description() PayloadDescription1 {
+	return f.desc
+}
+
+// Next returns the next message of the file, and io.EOF once the file has no more.
+// At the end of the file it checks that the messages read add up to the totals of
+// its description, failing with ErrFileTotals otherwise. A message of a document
+// unknown to this package fails with ErrUnknownMessage; the next call reads on.
+func (f *FileReader) Next() (*FileMessage, error) {
+	if f.done {
+		return nil, io.EOF
+	}
+	for {
+		tok, err := f.d.Token()
+		if err == io.EOF {
+			return nil, io.ErrUnexpectedEOF
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if t.Name.Local != "Pyld" {
+				if err := f.d.Skip(); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			return f.payload()
+		case xml.EndElement:
+			f.done = true
+			if err := f.desc.checkTotals(f.total, f.counts); err != nil {
+				return nil, err
+			}
+			return nil, io.EOF
+		}
+	}
+}
+
+// payload reads the elements of a payload up to its end
+func (f *FileReader) payload() (*FileMessage, error) {
+	msg := new(FileMessage)
+	var docErr error
+	for {
+		tok, err := f.d.Token()
+		if err == io.EOF {
+			return nil, io.ErrUnexpectedEOF
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "AppHdr":
+				msg.AppHdr = new(BusinessApplicationHeaderV02)
+				if err := f.d.DecodeElement(msg.AppHdr, &t); err != nil {
+					return nil, err
+				}
+			case "Document":
+				msg.MessageType = strings.TrimPrefix(t.Name.Space, namespacePrefix)
+				doc, ok := NewDocument(msg.MessageType)
+				if !ok {
+					docErr = fmt.Errorf("%w: %s", ErrUnknownMessage, msg.MessageType)
+					if err := f.d.Skip(); err != nil {
+						return nil, err
+					}
+					continue
+				}
+				if err := f.d.DecodeElement(doc, &t); err != nil {
+					return nil, err
+				}
+				msg.Document = doc
+			default:
+				if err := f.d.Skip(); err != nil {
+					return nil, err
+				}
+			}
+		case xml.EndElement:
+			if msg.MessageType == "" {
+				return nil, errors.New("business file payload has no document")
+			}
+			f.total++
+			f.counts[msg.MessageType]++
+			if docErr != nil {
+				return nil, docErr
+			}
+			return msg, nil
+		}
+	}
+}
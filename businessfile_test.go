@@ -0,0 +1,130 @@
+package iso20022
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func businessFile(t *testing.T, desc PayloadDescription1) []byte {
+	t.Helper()
+	event := new(Admi00400102Document)
+	if err := Unmarshal([]byte(cutoffExtension), event); err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	w, err := NewFileWriter(&buf, desc, WithDateTimeLocation(time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, doc := range []interface{}{loadPacs008Sample(t), event, loadPacs008Sample(t)} {
+		appHdr := &BusinessApplicationHeaderV02{
+			BusinessMessageID:   "BAH-000" + string(rune('1'+i)),
+			MessageDefinitionID: documentMessageType(doc),
+			CreationDate:        NewISODateTime(time.Date(2024, 3, 15, 9, 0, 0, 0, time.UTC)),
+		}
+		if err := w.Write(appHdr, doc); err != nil {
+			t.Fatalf("Failed to write message %d: %v", i+1, err)
+		}
+	}
+	if err := w.Close(); err != nil && !errors.Is(err, ErrFileTotals) {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func fileDescription(total string, manifest ...ManifestDetails1) PayloadDescription1 {
+	return PayloadDescription1{
+		PayloadDetails:                 PayloadDetails1{PayloadIdentifier: "FILE-20240315-01", CreationDateAndDateTime: NewISODateTime(time.Date(2024, 3, 15, 9, 0, 0, 0, time.UTC))},
+		ApplicationSpecificInformation: &ApplicationSpecifics1{TotalNumberOfDocuments: total},
+		PayloadTypeDetails:             PayloadTypeDetails1{Type: "XML"},
+		ManifestDetails:                manifest,
+	}
+}
+
+func TestBusinessFile(t *testing.T) {
+	desc := fileDescription("3", ManifestDetails1{DocumentType: "pacs.008.001.08", NumberOfDocuments: "2"}, ManifestDetails1{DocumentType: "admi.004.001.02", NumberOfDocuments: "1"})
+	data := businessFile(t, desc)
+	if !bytes.HasPrefix(data, []byte(`<Xchg xmlns="urn:iso:std:iso:20022:tech:xsd:head.002.001.01"><PyldDesc><PyldDtls><PyldIdr>FILE-20240315-01</PyldIdr>`)) ||
+		!strings.Contains(string(data), `<Pyld><AppHdr xmlns="urn:iso:std:iso:20022:tech:xsd:head.001.001.02">`) {
+		t.Errorf("Unexpected file layout: %.300s", data)
+	}
+
+	r, err := NewFileReader(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Description().PayloadDetails.PayloadIdentifier != "FILE-20240315-01" {
+		t.Errorf("Expected the payload description, got %+v", r.Description())
+	}
+	var got []string
+	for {
+		msg, err := r.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Failed to read message %d: %v", len(got)+1, err)
+		}
+		got = append(got, msg.AppHdr.BusinessMessageID+" "+msg.MessageType)
+		if pacs008, ok := msg.Document.(*Pacs00800108Document); ok && pacs008.FICustomerCreditTransfer.GroupHeader.MessageID != "BBBBUS33-20240315-0001" {
+			t.Errorf("Expected the pacs.008 to be decoded, got %+v", pacs008.FICustomerCreditTransfer.GroupHeader)
+		}
+	}
+	want := "BAH-0001 pacs.008.001.08, BAH-0002 admi.004.001.02, BAH-0003 pacs.008.001.08"
+	if strings.Join(got, ", ") != want {
+		t.Errorf("Expected %s, got %s", want, strings.Join(got, ", "))
+	}
+
+	// The whole file decodes too
+	file := new(BusinessFileHeaderV01)
+	if err := Unmarshal(data, file); err != nil {
+		t.Fatal(err)
+	}
+	if err := file.Validate(); err != nil || len(file.Payload) != 3 {
+		t.Errorf("Expected a valid file of 3 payloads, got %d, %v", len(file.Payload), err)
+	}
+}
+
+func TestBusinessFileTotals(t *testing.T) {
+	for _, desc := range []PayloadDescription1{
+		fileDescription("2"),
+		fileDescription("3", ManifestDetails1{DocumentType: "pacs.008.001.08", NumberOfDocuments: "3"}),
+		fileDescription("3", ManifestDetails1{DocumentType: "pacs.008.001.08", NumberOfDocuments: "2"}),
+	} {
+		data := businessFile(t, desc)
+		r, err := NewFileReader(bytes.NewReader(data))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for err == nil {
+			_, err = r.Next()
+		}
+		if !errors.Is(err, ErrFileTotals) {
+			t.Errorf("Expected the totals %+v to fail, got %v", desc.ManifestDetails, err)
+		}
+		file := new(BusinessFileHeaderV01)
+		if err := Unmarshal(data, file); err != nil {
+			t.Fatal(err)
+		}
+		if err := file.Validate(); err == nil {
+			t.Error("Expected the decoded file to be invalid")
+		}
+	}
+
+	var buf bytes.Buffer
+	w, err := NewFileWriter(&buf, fileDescription("1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); !errors.Is(err, ErrFileTotals) {
+		t.Errorf("Expected an empty file to fail its totals, got %v", err)
+	}
+
+	if _, err := NewFileReader(strings.NewReader(cutoffExtension)); !errors.Is(err, ErrUnknownMessage) {
+		t.Errorf("Expected a document to be refused as a file, got %v", err)
+	}
+}
@@ -0,0 +1,393 @@
+package iso20022
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrNoCalendar is returned for a currency without a registered calendar
+var ErrNoCalendar = errors.New("no calendar")
+
+// Calendar tells the days a settlement system is open
+type Calendar interface {
+	// Name identifies the calendar in messages, such as TARGET2
+	Name() string
+	// IsBusinessDay reports whether the system settles on the day of date
+	IsBusinessDay(date ISODate) bool
+}
+
+// HolidayCalendar is a Calendar closed on Saturdays, Sundays and holidays
+type HolidayCalendar struct {
+	name string
+	// holidays returns the holidays of a year
+	holidays func(year int) []ISODate
+
+	mu    sync.Mutex
+	years map[int]map[time.Time]bool
+}
+
+// NewHolidayCalendar returns a calendar closed on weekends and on the days holidays
+// returns for each year. Holidays are computed once per year.
+func NewHolidayCalendar(name string, holidays func(year int) []ISODate) *HolidayCalendar {
+	return &HolidayCalendar{name: name, holidays: holidays, years: make(map[int]map[time.Time]bool)}
+}
+
+// Name returns the name of the calendar
+func (c *HolidayCalendar) Name() string {
+	return c.name
+}
+
+// IsBusinessDay reports whether date is neither a weekend day nor a holiday
+func (c *HolidayCalendar) IsBusinessDay(date ISODate) bool {
+	day := calendarDay(date)
+	if day.Weekday() == time.Saturday || day.Weekday() == time.Sunday {
+		return false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	year, ok := c.years[day.Year()]
+	if !ok {
+		year = make(map[time.Time]bool)
+		for _, h := range c.holidays(day.Year()) {
+			year[calendarDay(h)] = true
+		}
+		c.years[day.Year()] = year
+	}
+	return !year[day]
+}
+
+// calendarDay returns the day of date at midnight UTC, so that days compare equal
+// whatever the time and location of the dates
+func calendarDay(date ISODate) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// The calendars of the settlement systems of the euro, the US dollar and the pound,
+// registered for EUR, USD and GBP
+var (
+	// TARGET2Calendar closes on New Year's Day, Good Friday, Easter Monday, 1 May
+	// and 25 and 26 December
+	TARGET2Calendar = NewHolidayCalendar("TARGET2", target2Holidays)
+	// FedCalendar closes on the Federal Reserve holidays. A holiday falling on a
+	// Sunday is observed the next Monday; one falling on a Saturday is not moved.
+	FedCalendar = NewHolidayCalendar("Fed", fedHolidays)
+	// UKCalendar closes on the bank holidays of England and Wales, with their
+	// substitute days
+	UKCalendar = NewHolidayCalendar("UK", ukHolidays)
+)
+
+// easterSunday returns the date of Easter Sunday in the Gregorian calendar
+func easterSunday(year int) time.Time {
+	a, b, c := year%19, year/100, year%100
+	d, e := b/4, b%4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i, k := c/4, c%4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+}
+
+// weekdayOf returns the nth weekday of a month, counting from the end when n is
+// negative
+func weekdayOf(year int, month time.Month, weekday time.Weekday, n int) ISODate {
+	if n < 0 {
+		last := time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC)
+		return ISODate{last.AddDate(0, 0, -((int(last.Weekday())-int(weekday)+7)%7 + 7*(-n-1)))}
+	}
+	first := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	return ISODate{first.AddDate(0, 0, (int(weekday)-int(first.Weekday())+7)%7+7*(n-1))}
+}
+
+func target2Holidays(year int) []ISODate {
+	easter := easterSunday(year)
+	return []ISODate{
+		NewISODate(year, time.January, 1),
+		{easter.AddDate(0, 0, -2)},
+		{easter.AddDate(0, 0, 1)},
+		NewISODate(year, time.May, 1),
+		NewISODate(year, time.December, 25),
+		NewISODate(year, time.December, 26),
+	}
+}
+
+func fedHolidays(year int) []ISODate {
+	fixed := []ISODate{
+		NewISODate(year, time.January, 1),
+		NewISODate(year, time.July, 4),
+		NewISODate(year, time.November, 11),
+		NewISODate(year, time.December, 25),
+	}
+	if year >= 2022 {
+		fixed = append(fixed, NewISODate(year, time.June, 19))
+	}
+	holidays := []ISODate{
+		weekdayOf(year, time.January, time.Monday, 3),    // Martin Luther King Jr. Day
+		weekdayOf(year, time.February, time.Monday, 3),   // Washington's Birthday
+		weekdayOf(year, time.May, time.Monday, -1),       // Memorial Day
+		weekdayOf(year, time.September, time.Monday, 1),  // Labor Day
+		weekdayOf(year, time.October, time.Monday, 2),    // Columbus Day
+		weekdayOf(year, time.November, time.Thursday, 4), // Thanksgiving Day
+	}
+	for _, d := range fixed {
+		if d.Weekday() == time.Sunday {
+			d = ISODate{d.AddDate(0, 0, 1)}
+		}
+		holidays = append(holidays, d)
+	}
+	return holidays
+}
+
+// ukSpecialHolidays are the bank holidays of England and Wales proclaimed for a
+// single year, and the years their usual May holidays were moved
+var (
+	ukSpecialHolidays = map[int][]ISODate{
+		2011: {NewISODate(2011, time.April, 29)},
+		2012: {NewISODate(2012, time.June, 5)},
+		2022: {NewISODate(2022, time.June, 3), NewISODate(2022, time.September, 19)},
+		2023: {NewISODate(2023, time.May, 8)},
+	}
+	ukEarlyMayHolidays = map[int]ISODate{
+		2020: NewISODate(2020, time.May, 8),
+	}
+	ukSpringHolidays = map[int]ISODate{
+		2012: NewISODate(2012, time.June, 4),
+		2022: NewISODate(2022, time.June, 2),
+	}
+)
+
+func ukHolidays(year int) []ISODate {
+	easter := easterSunday(year)
+	earlyMay, ok := ukEarlyMayHolidays[year]
+	if !ok {
+		earlyMay = weekdayOf(year, time.May, time.Monday, 1)
+	}
+	spring, ok := ukSpringHolidays[year]
+	if !ok {
+		spring = weekdayOf(year, time.May, time.Monday, -1)
+	}
+	holidays := []ISODate{
+		{easter.AddDate(0, 0, -2)},
+		{easter.AddDate(0, 0, 1)},
+		earlyMay,
+		spring,
+		weekdayOf(year, time.August, time.Monday, -1),
+	}
+	holidays = append(holidays, ukSpecialHolidays[year]...)
+
+	// New Year's Day, Christmas Day and Boxing Day falling on a weekend are
+	// replaced by the next weekdays that are not already holidays
+	taken := make(map[time.Time]bool)
+	for _, d := range []ISODate{NewISODate(year, time.January, 1), NewISODate(year, time.December, 25), NewISODate(year, time.December, 26)} {
+		for d.Weekday() == time.Saturday || d.Weekday() == time.Sunday || taken[calendarDay(d)] {
+			d = ISODate{d.AddDate(0, 0, 1)}
+		}
+		taken[calendarDay(d)] = true
+		holidays = append(holidays, d)
+	}
+	return holidays
+}
+
+// calendars maps currencies to the calendars of their settlement systems
+var (
+	calendarsMu sync.RWMutex
+	calendars   = map[string]Calendar{
+		"EUR": TARGET2Calendar,
+		"USD": FedCalendar,
+		"GBP": UKCalendar,
+	}
+)
+
+// RegisterCalendar sets the calendar of a currency, replacing any calendar it has.
+// Passing a nil calendar removes it.
+func RegisterCalendar(currency string, c Calendar) {
+	calendarsMu.Lock()
+	defer calendarsMu.Unlock()
+	currency = strings.ToUpper(currency)
+	if c == nil {
+		delete(calendars, currency)
+		return
+	}
+	calendars[currency] = c
+}
+
+// CalendarFor returns the calendar registered for a currency
+func CalendarFor(currency string) (Calendar, bool) {
+	calendarsMu.RLock()
+	defer calendarsMu.RUnlock()
+	c, ok := calendars[strings.ToUpper(currency)]
+	return c, ok
+}
+
+// maxClosedDays bounds the search for a business day, so that a calendar that is
+// never open does not loop forever
+const maxClosedDays = 366
+
+// NextSettlementDate returns the first business day of the calendar of currency on
+// or after date: date itself when it is a business day.
+func NextSettlementDate(currency string, date ISODate) (ISODate, error) {
+	c, ok := CalendarFor(currency)
+	if !ok {
+		return ISODate{}, fmt.Errorf("%w for %s", ErrNoCalendar, currency)
+	}
+	day := ISODate{calendarDay(date)}
+	for i := 0; i < maxClosedDays; i++ {
+		if c.IsBusinessDay(day) {
+			return day, nil
+		}
+		day = ISODate{day.AddDate(0, 0, 1)}
+	}
+	return ISODate{}, fmt.Errorf("%s calendar has no business day in the year after %s", c.Name(), date)
+}
+
+// settlementCalendarValidator is implemented by the documents whose settlement
+// dates are checked against the calendars
+type settlementCalendarValidator interface {
+	validateSettlementCalendars() ValidationErrors
+}
+
+// validateSettlementDate reports a settlement date that is not a business day of
+// the calendar of its currency. Currencies without a calendar are not checked.
+func validateSettlementDate(d settlementDate) ValidationErrors {
+	c, ok := CalendarFor(d.currency)
+	if !ok || c.IsBusinessDay(d.date) {
+		return nil
+	}
+	return ValidationErrors{newValidationError(d.field, RuleSettlementCalendar, "SETTLEMENT_CALENDAR", d.date.String(), c.Name(), d.currency)}
+}
+
+func (d *Pacs00800108Document) validateSettlementCalendars() ValidationErrors {
+	return validateSettlementCalendars(d)
+}
+
+func (d *Pacs00900108Document) validateSettlementCalendars() ValidationErrors {
+	return validateSettlementCalendars(d)
+}
+
+// validateSettlementCalendars checks the interbank settlement dates of a credit
+// transfer
+func validateSettlementCalendars(doc interface{}) ValidationErrors {
+	root, dates := settlementDates(doc)
+	var errs ValidationErrors
+	for _, d := range dates {
+		errs = append(errs, validateSettlementDate(d)...)
+	}
+	return errs.within(root)
+}
+
+// settlementDate is a settlement date of a message, with the currency it settles
+type settlementDate struct {
+	// field is the path of the date below the message element
+	field    string
+	date     ISODate
+	currency string
+}
+
+// settlementDates returns the message element and the settlement dates of a pacs.008
+// or pacs.009, or the requested execution dates of a pain.001. The date of a group
+// settles in the currency of its first transaction.
+func settlementDates(doc interface{}) (string, []settlementDate) {
+	var dates []settlementDate
+	add := func(field string, date *ISODate, currency string) {
+		if date != nil {
+			dates = append(dates, settlementDate{field: field, date: *date, currency: currency})
+		}
+	}
+	switch d := doc.(type) {
+	case *Pacs00800108Document:
+		f := &d.FICustomerCreditTransfer
+		for i, tx := range f.CreditTransferTransactionInfo {
+			if i == 0 {
+				add("GrpHdr.IntrBkSttlmDt", f.GroupHeader.InterbankSettlementDate, tx.InterbankSettlementAmount.Currency)
+			}
+			add(fmt.Sprintf("CdtTrfTxInf[%d].IntrBkSttlmDt", i+1), tx.InterbankSettlementDate, tx.InterbankSettlementAmount.Currency)
+		}
+		return "FIToFICstmrCdtTrf", dates
+	case *Pacs00900108Document:
+		f := &d.FICreditTransfer
+		for i, tx := range f.CreditTransferTransactionInfo {
+			if i == 0 {
+				add("GrpHdr.IntrBkSttlmDt", f.GroupHeader.InterbankSettlementDate, tx.InterbankSettlementAmount.Currency)
+			}
+			add(fmt.Sprintf("CdtTrfTxInf[%d].IntrBkSttlmDt", i+1), tx.InterbankSettlementDate, tx.InterbankSettlementAmount.Currency)
+		}
+		return "FICdtTrf", dates
+	case *Pain00100109Document:
+		for i, pmt := range d.CustomerCreditTransferInitiation.PaymentInfo {
+			date, err := executionDate(pmt.RequestedExecutionDate)
+			if err != nil || len(pmt.CreditTransferTransactionInfo) == 0 {
+				continue
+			}
+			field := fmt.Sprintf("PmtInf[%d].ReqdExctnDt.Dt", i+1)
+			if pmt.RequestedExecutionDate.Date == nil {
+				field = fmt.Sprintf("PmtInf[%d].ReqdExctnDt.DtTm", i+1)
+			}
+			add(field, &date, transactionAmount(pmt.CreditTransferTransactionInfo[0].Amount).Currency)
+		}
+		return "CstmrCdtTrfInitn", dates
+	}
+	return "", nil
+}
+
+// SettlementWindow is when a scheme accepts payments: for settlement on the business
+// days of its calendar and, for settlement on the day they are submitted, until its
+// cut-off. Set it in the Settlement of a profile, such as a copy of FedwireProfile
+// with the Fed calendar and an 18:00 cut-off in America/New_York.
+type SettlementWindow struct {
+	// Calendar is the calendar of the scheme. When nil, each payment is checked
+	// against the calendar registered for its currency, if any.
+	Calendar Calendar
+	// CutOff is the time of day after which payments can no longer settle the same
+	// day, zero for schemes without one
+	CutOff time.Duration
+	// Location is the time zone of the cut-off and of the submission day, UTC when
+	// nil
+	Location *time.Location
+	// Tolerance is the time after the cut-off during which same-day payments are
+	// still accepted
+	Tolerance time.Duration
+	// Now gives the submission time, time.Now when nil
+	Now func() time.Time
+}
+
+// check reports the settlement dates of doc the scheme cannot settle on
+func (w *SettlementWindow) check(doc interface{}, scheme string) ValidationErrors {
+	root, dates := settlementDates(doc)
+	if len(dates) == 0 {
+		return nil
+	}
+	loc := w.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+	now := templateNow(w.Now).In(loc)
+	today := calendarDay(ISODate{now})
+	// The cut-off is a wall-clock time, even on the days the clocks change
+	cutOff := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, int(w.CutOff/time.Second), 0, loc)
+	closed := w.CutOff > 0 && now.After(cutOff.Add(w.Tolerance))
+	var errs ValidationErrors
+	for _, d := range dates {
+		c := w.Calendar
+		if c == nil {
+			var ok bool
+			if c, ok = CalendarFor(d.currency); !ok {
+				continue
+			}
+		}
+		switch {
+		case !c.IsBusinessDay(d.date):
+			errs = append(errs, ValidationError{Field: d.field,
+				Message: fmt.Sprintf("%s is not a business day of %s", d.date, scheme)})
+		case closed && calendarDay(d.date).Equal(today):
+			errs = append(errs, ValidationError{Field: d.field,
+				Message: fmt.Sprintf("%s is today, but same-day settlement with %s closed at %s", d.date, scheme, cutOff.Format("15:04"))})
+		}
+	}
+	return errs.within(root)
+}
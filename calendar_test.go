@@ -0,0 +1,122 @@
+package iso20022
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCalendars(t *testing.T) {
+	tests := []struct {
+		calendar Calendar
+		date     string
+		open     bool
+	}{
+		{TARGET2Calendar, "2024-03-28", true},
+		{TARGET2Calendar, "2024-03-29", false}, // Good Friday
+		{TARGET2Calendar, "2024-04-01", false}, // Easter Monday
+		{TARGET2Calendar, "2025-05-01", false},
+		{TARGET2Calendar, "2025-12-26", false},
+		{TARGET2Calendar, "2024-03-30", false}, // Saturday
+		{FedCalendar, "2023-01-02", false},     // New Year's Day observed on Monday
+		{FedCalendar, "2021-12-31", true},      // New Year's Day 2022 is a Saturday
+		{FedCalendar, "2022-06-20", false},     // Juneteenth observed on Monday
+		{FedCalendar, "2021-06-18", true},
+		{FedCalendar, "2024-01-15", false}, // Martin Luther King Jr. Day
+		{FedCalendar, "2024-05-27", false}, // Memorial Day
+		{FedCalendar, "2024-10-14", false}, // Columbus Day
+		{FedCalendar, "2024-11-28", false}, // Thanksgiving Day
+		{FedCalendar, "2024-11-29", true},
+		{FedCalendar, "2024-03-29", true},
+		{UKCalendar, "2024-03-29", false},
+		{UKCalendar, "2022-01-03", false}, // substitute for New Year's Day
+		{UKCalendar, "2022-06-02", false},
+		{UKCalendar, "2022-06-03", false},
+		{UKCalendar, "2022-05-30", true},
+		{UKCalendar, "2022-09-19", false},
+		{UKCalendar, "2022-12-27", false}, // substitute for Christmas Day
+		{UKCalendar, "2021-12-28", false}, // substitute for Boxing Day
+		{UKCalendar, "2023-05-08", false},
+		{UKCalendar, "2020-05-08", false},
+		{UKCalendar, "2020-05-04", true},
+		{UKCalendar, "2024-08-26", false},
+		{UKCalendar, "2025-05-05", false},
+	}
+	for _, tt := range tests {
+		date, err := ParseISODate(tt.date)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if open := tt.calendar.IsBusinessDay(date); open != tt.open {
+			t.Errorf("%s %s: expected business day %v, got %v", tt.calendar.Name(), tt.date, tt.open, open)
+		}
+	}
+}
+
+func TestNextSettlementDate(t *testing.T) {
+	tests := []struct {
+		currency, date, want string
+	}{
+		{"EUR", "2024-03-28", "2024-03-28"},
+		{"EUR", "2024-03-29", "2024-04-02"},
+		{"eur", "2024-12-24", "2024-12-24"},
+		{"GBP", "2022-12-24", "2022-12-28"},
+		{"USD", "2024-11-28", "2024-11-29"},
+	}
+	for _, tt := range tests {
+		date, _ := ParseISODate(tt.date)
+		got, err := NextSettlementDate(tt.currency, date)
+		if err != nil || got.String() != tt.want {
+			t.Errorf("%s %s: got %s, %v, want %s", tt.currency, tt.date, got, err, tt.want)
+		}
+	}
+	if _, err := NextSettlementDate("XAU", NewISODate(2024, time.March, 28)); !errors.Is(err, ErrNoCalendar) {
+		t.Errorf("Expected ErrNoCalendar, got %v", err)
+	}
+
+	closed := NewHolidayCalendar("closed", func(year int) []ISODate {
+		var days []ISODate
+		for d := NewISODate(year, time.January, 1); d.Year() == year; d = (ISODate{d.AddDate(0, 0, 1)}) {
+			days = append(days, d)
+		}
+		return days
+	})
+	RegisterCalendar("XAU", closed)
+	defer RegisterCalendar("XAU", nil)
+	if _, err := NextSettlementDate("XAU", NewISODate(2024, time.March, 28)); err == nil {
+		t.Errorf("Expected an error for a calendar without business days")
+	}
+}
+
+func TestValidateSettlementCalendar(t *testing.T) {
+	doc := loadPacs008Sample(t)
+	report, err := Validate(context.Background(), doc)
+	if err != nil || !report.Valid() || len(report.Warnings) != 0 {
+		t.Fatalf("Expected a clean report, got %+v, %v", report, err)
+	}
+
+	// Saturday
+	saturday := NewISODate(2024, time.March, 16)
+	doc.FICustomerCreditTransfer.CreditTransferTransactionInfo[0].InterbankSettlementDate = &saturday
+	report, err = Validate(context.Background(), doc)
+	if err != nil || !report.Valid() || len(report.Warnings) != 1 {
+		t.Fatalf("Expected a warning, got %+v, %v", report, err)
+	}
+	if w := report.Warnings[0]; w.Rule != RuleSettlementCalendar || w.Location() != "FIToFICstmrCdtTrf/CdtTrfTxInf[1]/IntrBkSttlmDt" ||
+		w.Message != "2024-03-16 is not a business day of the Fed calendar for USD" {
+		t.Errorf("Unexpected warning %+v", w)
+	}
+
+	report, err = Validate(context.Background(), doc, WithSeverity(SeverityError, RuleSettlementCalendar))
+	if err != nil || report.Valid() || report.Errors[0].Rule != RuleSettlementCalendar {
+		t.Errorf("Expected an error, got %+v, %v", report, err)
+	}
+
+	RegisterCalendar("USD", nil)
+	defer RegisterCalendar("USD", FedCalendar)
+	report, err = Validate(context.Background(), doc)
+	if err != nil || len(report.Warnings) != 0 {
+		t.Errorf("Expected no warning without a calendar, got %+v, %v", report, err)
+	}
+}
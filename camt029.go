@@ -0,0 +1,317 @@
+package iso20022
+
+import (
+	"encoding/xml"
+	"fmt"
+	"time"
+)
+
+// Camt029Versions are the versions of camt.029 this package reads and writes, in
+// order
+var Camt029Versions = []string{"camt.029.001.09", "camt.029.001.13"}
+
+// Camt029 is a camt.029 resolution of investigation of any of the Camt029Versions,
+// so that the version can be chosen per corridor
+type Camt029 interface {
+	// Version returns the message name identification, such as camt.029.001.09
+	Version() string
+	AssignmentID() string
+	CreationDateTime() time.Time
+	// Resolution returns the message with the components of camt.029.001.09. Later
+	// versions return a copy, without the elements those components do not have.
+	Resolution() *ResolutionOfInvestigationV09
+}
+
+// DecodeCamt029 decodes a camt.029 of any of the Camt029Versions
+func DecodeCamt029(data []byte) (Camt029, error) {
+	msgType, doc, err := DecodeDocument(data)
+	if err != nil {
+		return nil, err
+	}
+	c, ok := doc.(Camt029)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s is not a camt.029", ErrUnknownMessage, msgType)
+	}
+	return c, nil
+}
+
+// ConvertCamt029 returns a camt.029 in another of the Camt029Versions. It fails
+// with ErrVersionLoss, naming the element, when the message has an element the
+// target version does not, such as the CareOf of an address below
+// camt.029.001.13, or an element Unmarshal could not decode.
+func ConvertCamt029(doc Camt029, version string) (Camt029, error) {
+	newDocument, ok := documentsByType[version]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownMessage, version)
+	}
+	target, ok := newDocument().(Camt029)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s is not a camt.029", ErrUnknownMessage, version)
+	}
+	if err := unmodelledLoss(doc); err != nil {
+		return nil, fmt.Errorf("converting %s to %s: %w", doc.Version(), version, err)
+	}
+	if err := convertVersion(target, doc); err != nil {
+		return nil, fmt.Errorf("converting %s to %s: %w", doc.Version(), version, err)
+	}
+	return target, nil
+}
+
+// Camt02900113Document is a camt.029.001.13 message, the version CBPR+ migrates
+// to. Its agents, parties and postal addresses are those of the 2023 components,
+// which add CareOf and UnitNb to postal addresses. Unmarshal records the elements
+// of other components it has no field for, which Unmodelled returns.
+type Camt02900113Document struct {
+	XMLName                 xml.Name                     `xml:"urn:iso:std:iso:20022:tech:xsd:camt.029.001.13 Document"`
+	InvestigationResolution ResolutionOfInvestigationV13 `xml:"RsltnOfInvstgtn"`
+	unmodelled              []string
+}
+
+// ResolutionOfInvestigationV13 is the message of camt.029.001.13
+type ResolutionOfInvestigationV13 struct {
+	Assignment              CaseAssignment6            `xml:"Assgnmt"`
+	ResolvedCase            *Case6                     `xml:"RslvdCase,omitempty"`
+	Status                  InvestigationStatus6       `xml:"Sts"`
+	CancellationDetails     []UnderlyingTransaction32  `xml:"CxlDtls,omitempty"`
+	ModificationDetails     *PaymentTransaction146     `xml:"ModDtls,omitempty"`
+	ClaimNonReceiptDetails3 *ClaimNonReceipt3          `xml:"ClmNonRctDtls,omitempty"`
+	StatementDetails        *StatementResolutionEntry5 `xml:"StmtDtls,omitempty"`
+	CorrectionTransaction   *CorrectiveTransaction6    `xml:"CrrctnTx,omitempty"`
+	ResolutionRelatedInfo   *ResolutionData5           `xml:"RsltnRltdInf,omitempty"`
+	SupplementaryData       []SupplementaryData1       `xml:"SplmtryData,omitempty"`
+}
+
+// InvestigationStatus6 is the status of an investigation in camt.029.001.13
+type InvestigationStatus6 struct {
+	Confirmation                       *string                     `xml:"Conf,omitempty"`
+	RejectedModification               []ModificationStatusReason1 `xml:"RjctdMod,omitempty"`
+	DuplicateOf                        *Case6                      `xml:"DplctOf,omitempty"`
+	AssignmentCancellationConfirmation *bool                       `xml:"AssgnmtCxlConf,omitempty"`
+}
+
+// UnderlyingTransaction32 is the cancellation details of camt.029.001.13
+type UnderlyingTransaction32 struct {
+	OriginalGroupInfo          *OriginalGroupHeader20         `xml:"OrgnlGrpInfAndSts,omitempty"`
+	OriginalPaymentInformation []OriginalPaymentInstruction45 `xml:"OrgnlPmtInfAndSts,omitempty"`
+	TransactionInfo            []PaymentTransaction152        `xml:"TxInfAndSts,omitempty"`
+}
+
+// OriginalGroupHeader20 is the status of the cancellation of an original message in
+// camt.029.001.13
+type OriginalGroupHeader20 struct {
+	OriginalGroupCancellationID   *string                          `xml:"OrgnlGrpCxlId,omitempty"`
+	ResolvedCase                  *Case6                           `xml:"RslvdCase,omitempty"`
+	OriginalMessageID             string                           `xml:"OrgnlMsgId"`
+	OriginalMessageNameID         string                           `xml:"OrgnlMsgNmId"`
+	OriginalCreationDateTime      *ISODateTime                     `xml:"OrgnlCreDtTm,omitempty"`
+	OriginalNumberOfTransactions  *string                          `xml:"OrgnlNbOfTxs,omitempty"`
+	OriginalControlSum            *Decimal                         `xml:"OrgnlCtrlSum,omitempty"`
+	GroupCancellationStatus       *string                          `xml:"GrpCxlSts,omitempty"`
+	CancellationStatusReasonInfo  []CancellationStatusReason5      `xml:"CxlStsRsnInf,omitempty"`
+	NumberOfTransactionsPerStatus []NumberOfTransactionsPerStatus1 `xml:"NbOfTxsPerCxlSts,omitempty"`
+}
+
+// OriginalPaymentInstruction45 is the status of the cancellation of an original
+// payment information in camt.029.001.13
+type OriginalPaymentInstruction45 struct {
+	OriginalPaymentInfoCancellationID *string                           `xml:"OrgnlPmtInfCxlId,omitempty"`
+	ResolvedCase                      *Case6                            `xml:"RslvdCase,omitempty"`
+	OriginalPaymentInfoID             string                            `xml:"OrgnlPmtInfId"`
+	OriginalGroupInfo                 *OriginalGroupInformation29       `xml:"OrgnlGrpInf,omitempty"`
+	OriginalNumberOfTransactions      *string                           `xml:"OrgnlNbOfTxs,omitempty"`
+	OriginalControlSum                *Decimal                          `xml:"OrgnlCtrlSum,omitempty"`
+	PaymentInfoCancellationStatus     *string                           `xml:"PmtInfCxlSts,omitempty"`
+	CancellationStatusReasonInfo      []CancellationStatusReason5       `xml:"CxlStsRsnInf,omitempty"`
+	NumberOfTransactionsPerStatus     []NumberOfCancellationsPerStatus1 `xml:"NbOfTxsPerCxlSts,omitempty"`
+	TransactionInfo                   []PaymentTransaction153           `xml:"TxInfAndSts,omitempty"`
+}
+
+// PaymentTransaction152 is the status of the cancellation of a transaction in
+// camt.029.001.13
+type PaymentTransaction152 struct {
+	CancellationStatusID              *string                            `xml:"CxlStsId,omitempty"`
+	ResolvedCase                      *Case6                             `xml:"RslvdCase,omitempty"`
+	OriginalGroupInfo                 *OriginalGroupInformation29        `xml:"OrgnlGrpInf,omitempty"`
+	OriginalInstructionID             *string                            `xml:"OrgnlInstrId,omitempty"`
+	OriginalEndToEndID                *string                            `xml:"OrgnlEndToEndId,omitempty"`
+	OriginalTransactionID             *string                            `xml:"OrgnlTxId,omitempty"`
+	OriginalClearingSystemRef         *string                            `xml:"OrgnlClrSysRef,omitempty"`
+	OriginalUETR                      *string                            `xml:"OrgnlUETR,omitempty"`
+	TransactionCancellationStatus     *string                            `xml:"TxCxlSts,omitempty"`
+	CancellationStatusReasonInfo      []CancellationStatusReason5        `xml:"CxlStsRsnInf,omitempty"`
+	ResolutionRelatedInfo             *ResolutionData5                   `xml:"RsltnRltdInf,omitempty"`
+	OriginalInterbankSettlementAmount *ActiveOrHistoricCurrencyAndAmount `xml:"OrgnlIntrBkSttlmAmt,omitempty"`
+	OriginalInterbankSettlementDate   *ISODate                           `xml:"OrgnlIntrBkSttlmDt,omitempty"`
+	Assignor                          *Party50                           `xml:"Assgnr,omitempty"`
+	Assignee                          *Party50                           `xml:"Assgne,omitempty"`
+	OriginalTransactionReference      *OriginalTransactionReference42    `xml:"OrgnlTxRef,omitempty"`
+}
+
+// PaymentTransaction153 is the status of the cancellation of a transaction of an
+// original payment information in camt.029.001.13
+type PaymentTransaction153 struct {
+	CancellationStatusID            *string                            `xml:"CxlStsId,omitempty"`
+	ResolvedCase                    *Case6                             `xml:"RslvdCase,omitempty"`
+	OriginalInstructionID           *string                            `xml:"OrgnlInstrId,omitempty"`
+	OriginalEndToEndID              *string                            `xml:"OrgnlEndToEndId,omitempty"`
+	UETR                            *string                            `xml:"UETR,omitempty"`
+	TransactionCancellationStatus   *string                            `xml:"TxCxlSts,omitempty"`
+	CancellationStatusReasonInfo    []CancellationStatusReason5        `xml:"CxlStsRsnInf,omitempty"`
+	OriginalInstructedAmount        *ActiveOrHistoricCurrencyAndAmount `xml:"OrgnlInstdAmt,omitempty"`
+	OriginalRequestedExecutionDate  *DateAndDateTime2                  `xml:"OrgnlReqdExctnDt,omitempty"`
+	OriginalRequestedCollectionDate *ISODate                           `xml:"OrgnlReqdColltnDt,omitempty"`
+	OriginalTransactionReference    *OriginalTransactionReference42    `xml:"OrgnlTxRef,omitempty"`
+}
+
+// CancellationStatusReason5 is a reason for a cancellation status in
+// camt.029.001.13
+type CancellationStatusReason5 struct {
+	Originator            *PartyIdentification272          `xml:"Orgtr,omitempty"`
+	Reason                *CancellationStatusReason3Choice `xml:"Rsn,omitempty"`
+	AdditionalInformation []string                         `xml:"AddtlInf,omitempty"`
+}
+
+// PaymentTransaction146 is the modification details of camt.029.001.13
+type PaymentTransaction146 struct {
+	ModificationStatusID              *string                            `xml:"ModStsId,omitempty"`
+	ResolvedCase                      *Case6                             `xml:"RslvdCase,omitempty"`
+	OriginalGroupInfo                 OriginalGroupInformation29         `xml:"OrgnlGrpInf"`
+	OriginalPaymentInfoID             *string                            `xml:"OrgnlPmtInfId,omitempty"`
+	OriginalInstructionID             *string                            `xml:"OrgnlInstrId,omitempty"`
+	OriginalEndToEndID                *string                            `xml:"OrgnlEndToEndId,omitempty"`
+	OriginalTransactionID             *string                            `xml:"OrgnlTxId,omitempty"`
+	OriginalClearingSystemRef         *string                            `xml:"OrgnlClrSysRef,omitempty"`
+	OriginalUETR                      *string                            `xml:"OrgnlUETR,omitempty"`
+	ModificationStatusReasonInfo      []ModificationStatusReason3        `xml:"ModStsRsnInf,omitempty"`
+	ResolutionRelatedInfo             *ResolutionData5                   `xml:"RsltnRltdInf,omitempty"`
+	OriginalInterbankSettlementAmount *ActiveOrHistoricCurrencyAndAmount `xml:"OrgnlIntrBkSttlmAmt,omitempty"`
+	OriginalInterbankSettlementDate   *ISODate                           `xml:"OrgnlIntrBkSttlmDt,omitempty"`
+	Assignor                          *Party50                           `xml:"Assgnr,omitempty"`
+	Assignee                          *Party50                           `xml:"Assgne,omitempty"`
+	OriginalTransactionReference      *OriginalTransactionReference42    `xml:"OrgnlTxRef,omitempty"`
+}
+
+// ModificationStatusReason3 is a reason for a modification status in
+// camt.029.001.13
+type ModificationStatusReason3 struct {
+	Originator            *PartyIdentification272    `xml:"Orgtr,omitempty"`
+	Reason                *ModificationStatusReason1 `xml:"Rsn,omitempty"`
+	AdditionalInformation []string                   `xml:"AddtlInf,omitempty"`
+}
+
+// ClaimNonReceipt3 is the outcome of a claim non receipt in camt.029.001.13
+type ClaimNonReceipt3 struct {
+	Accepted *ClaimNonReceiptDetails3      `xml:"Accptd,omitempty"`
+	Rejected *ClaimNonReceiptRejectReason1 `xml:"Rjctd,omitempty"`
+}
+
+// ClaimNonReceiptDetails3 is an accepted claim non receipt in camt.029.001.13
+type ClaimNonReceiptDetails3 struct {
+	DateProcessed     ISODate                                       `xml:"DtPrcd"`
+	OriginalNextAgent *BranchAndFinancialInstitutionIdentification8 `xml:"OrgnlNxtAgt,omitempty"`
+}
+
+// StatementResolutionEntry5 is the statement details of camt.029.001.13
+type StatementResolutionEntry5 struct {
+	OriginalGroupInfo                *OriginalGroupInfo3         `xml:"OrgnlGrpInf,omitempty"`
+	OriginalStatementID              *string                     `xml:"OrgnlStmtId,omitempty"`
+	OriginalAccountServicerReference *string                     `xml:"OrgnlAcctSvcrRef,omitempty"`
+	Account                          *CashAccount40              `xml:"Acct,omitempty"`
+	RelatedAccount                   *CashAccount40              `xml:"RltdAcct,omitempty"`
+	Statement                        []StatementResolutionEntry5 `xml:"Stmt,omitempty"`
+}
+
+// CorrectiveTransaction6 is the corrective transaction of camt.029.001.13
+type CorrectiveTransaction6 struct {
+	PaymentInitiation    *CorrectivePaymentInitiation6    `xml:"Initn,omitempty"`
+	InterbankTransaction *CorrectiveInterbankTransaction2 `xml:"IntrBk,omitempty"`
+}
+
+// CorrectivePaymentInitiation6 is a corrective payment initiation in
+// camt.029.001.13
+type CorrectivePaymentInitiation6 struct {
+	GroupHeader                  *CorrectiveGroupInformation1      `xml:"GrpHdr,omitempty"`
+	PaymentInfoID                *string                           `xml:"PmtInfId,omitempty"`
+	InstructionID                *string                           `xml:"InstrId,omitempty"`
+	EndToEndID                   *string                           `xml:"EndToEndId,omitempty"`
+	UETR                         *string                           `xml:"UETR,omitempty"`
+	InstructedAmount             ActiveOrHistoricCurrencyAndAmount `xml:"InstdAmt"`
+	RequestedExecutionDate       *DateAndDateTime2                 `xml:"ReqdExctnDt,omitempty"`
+	RequestedCollectionDate      *ISODate                          `xml:"ReqdColltnDt,omitempty"`
+	CreditorSchemeIdentification *PartyIdentification272           `xml:"CdtrSchmeId,omitempty"`
+}
+
+// ResolutionData5 is the resolution related information of camt.029.001.13
+type ResolutionData5 struct {
+	EndToEndID                *string                            `xml:"EndToEndId,omitempty"`
+	TransactionID             *string                            `xml:"TxId,omitempty"`
+	UETR                      *string                            `xml:"UETR,omitempty"`
+	InterbankSettlementAmount *ActiveOrHistoricCurrencyAndAmount `xml:"IntrBkSttlmAmt,omitempty"`
+	InterbankSettlementDate   *ISODate                           `xml:"IntrBkSttlmDt,omitempty"`
+	ClearingChannel           *ClearingChannel2Code              `xml:"ClrChanl,omitempty"`
+	Compensation              *Compensation5                     `xml:"Compstn,omitempty"`
+	Charges                   []Charges16                        `xml:"Chrgs,omitempty"`
+}
+
+// Compensation5 is a compensation in camt.029.001.13
+type Compensation5 struct {
+	Amount        ActiveCurrencyAndAmount                      `xml:"Amt"`
+	DebtorAgent   BranchAndFinancialInstitutionIdentification8 `xml:"DbtrAgt"`
+	CreditorAgent BranchAndFinancialInstitutionIdentification8 `xml:"CdtrAgt"`
+	Reason        CompensationReason1                          `xml:"Rsn"`
+}
+
+// Unmodelled returns the paths of the elements of the message, as read by
+// Unmarshal, that its components have no field for. They are not decoded, and
+// ConvertCamt029 fails on them with ErrVersionLoss.
+func (d *Camt02900113Document) Unmodelled() []string { return d.unmodelled }
+
+func (d *Camt02900113Document) setUnmodelled(paths []string) { d.unmodelled = paths }
+
+// Version implements Camt029
+func (d *Camt02900109Document) Version() string { return "camt.029.001.09" }
+
+// Version implements Camt029
+func (d *Camt02900113Document) Version() string { return "camt.029.001.13" }
+
+// AssignmentID implements Camt029
+func (d *Camt02900109Document) AssignmentID() string {
+	return d.InvestigationResolution.Assignment.ID
+}
+
+// AssignmentID implements Camt029
+func (d *Camt02900113Document) AssignmentID() string {
+	return d.InvestigationResolution.Assignment.ID
+}
+
+// CreationDateTime implements Camt029
+func (d *Camt02900109Document) CreationDateTime() time.Time {
+	return d.InvestigationResolution.Assignment.CreationDateTime.Time
+}
+
+// CreationDateTime implements Camt029
+func (d *Camt02900113Document) CreationDateTime() time.Time {
+	return d.InvestigationResolution.Assignment.CreationDateTime.Time
+}
+
+// Resolution implements Camt029
+func (d *Camt02900109Document) Resolution() *ResolutionOfInvestigationV09 {
+	return &d.InvestigationResolution
+}
+
+// Resolution implements Camt029. The message is converted to the components of
+// camt.029.001.09, leaving out the elements those do not have, so changes to it do not
+// change d.
+func (d *Camt02900113Document) Resolution() *ResolutionOfInvestigationV09 {
+	m := new(ResolutionOfInvestigationV09)
+	convertCore(m, &d.InvestigationResolution)
+	return m
+}
+
+// Validate checks the repeating elements and the choices of camt.029.001.13
+func (d *Camt02900113Document) Validate() error {
+	if errs := validateStructure(d); errs.HasErrors() {
+		return errs
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package iso20022
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestConvertCamt029(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("testdata", "roundtrip", "camt.029.001.09", "cancellation_rejected.xml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	v09, err := DecodeCamt029(data)
+	if err != nil {
+		t.Fatalf("DecodeCamt029 failed: %v", err)
+	}
+	if v09.Version() != "camt.029.001.09" || v09.AssignmentID() != "CCCCGB2L-RSL-0001" || v09.CreationDateTime().IsZero() {
+		t.Errorf("Unexpected resolution %s %s %v", v09.Version(), v09.AssignmentID(), v09.CreationDateTime())
+	}
+
+	v13, err := ConvertCamt029(v09, "camt.029.001.13")
+	if err != nil {
+		t.Fatalf("Upgrade failed: %v", err)
+	}
+	want, _ := os.ReadFile(filepath.Join("testdata", "roundtrip", "camt.029.001.13", "cancellation_rejected.xml"))
+	got, _ := Marshal(v13)
+	for _, diff := range diffXML(t, want, got) {
+		t.Error(diff)
+	}
+
+	if _, err := DecodeCamt029([]byte(`<Document xmlns="urn:iso:std:iso:20022:tech:xsd:camt.056.001.08"/>`)); err == nil {
+		t.Error("Expected an error for a camt.056")
+	}
+}
+
+func TestCamt029CareOf(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("testdata", "roundtrip", "camt.029.001.13", "cancellation_rejected.xml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	data = bytes.Replace(data, []byte("<Nm>Acme Manufacturing Inc</Nm>"),
+		[]byte("<Nm>Acme Manufacturing Inc</Nm><PstlAdr><CareOf>Treasury</CareOf></PstlAdr>"), 1)
+	var v13 Camt02900113Document
+	if err := Unmarshal(data, &v13); err != nil {
+		t.Fatal(err)
+	}
+	creator := v13.InvestigationResolution.ResolvedCase.Creator
+	if len(v13.Unmodelled()) != 0 || deref(creator.Party.PostalAddress.CareOf) != "Treasury" {
+		t.Fatalf("Expected CareOf to be decoded, got %v", v13.Unmodelled())
+	}
+	if deref(v13.Resolution().ResolvedCase.Creator.Party.Name) != "Acme Manufacturing Inc" {
+		t.Error("Expected the resolution with the camt.029.001.09 components")
+	}
+	if _, err := ConvertCamt029(&v13, "camt.029.001.09"); !errors.Is(err, ErrVersionLoss) || !strings.Contains(err.Error(), "Cretr/Pty/PstlAdr/CareOf") {
+		t.Errorf("Expected CareOf to be reported, got %v", err)
+	}
+}
@@ -0,0 +1,291 @@
+package iso20022
+
+import (
+	"encoding/xml"
+	"fmt"
+	"time"
+)
+
+// Camt056Versions are the versions of camt.056 this package reads and writes, in
+// order
+var Camt056Versions = []string{"camt.056.001.08", "camt.056.001.11"}
+
+// Camt056 is a camt.056 FI to FI payment cancellation request of any of the
+// Camt056Versions, so that the version can be chosen per corridor
+type Camt056 interface {
+	// Version returns the message name identification, such as camt.056.001.08
+	Version() string
+	AssignmentID() string
+	CreationDateTime() time.Time
+	// CancellationRequest returns the message with the components of
+	// camt.056.001.08. Later versions return a copy, without the elements those
+	// components do not have.
+	CancellationRequest() *FIToFIPaymentCancellationRequestV08
+}
+
+// DecodeCamt056 decodes a camt.056 of any of the Camt056Versions
+func DecodeCamt056(data []byte) (Camt056, error) {
+	msgType, doc, err := DecodeDocument(data)
+	if err != nil {
+		return nil, err
+	}
+	c, ok := doc.(Camt056)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s is not a camt.056", ErrUnknownMessage, msgType)
+	}
+	return c, nil
+}
+
+// ConvertCamt056 returns a camt.056 in another of the Camt056Versions. It fails
+// with ErrVersionLoss, naming the element, when the message has an element the
+// target version does not, such as the CareOf of an address below
+// camt.056.001.11, or an element Unmarshal could not decode.
+func ConvertCamt056(doc Camt056, version string) (Camt056, error) {
+	newDocument, ok := documentsByType[version]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownMessage, version)
+	}
+	target, ok := newDocument().(Camt056)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s is not a camt.056", ErrUnknownMessage, version)
+	}
+	if err := unmodelledLoss(doc); err != nil {
+		return nil, fmt.Errorf("converting %s to %s: %w", doc.Version(), version, err)
+	}
+	if err := convertVersion(target, doc); err != nil {
+		return nil, fmt.Errorf("converting %s to %s: %w", doc.Version(), version, err)
+	}
+	return target, nil
+}
+
+// Camt05600111Document is a camt.056.001.11 message, the version CBPR+ migrates
+// to. Its agents, parties and postal addresses are those of the 2023 components,
+// which add CareOf and UnitNb to postal addresses. Unmarshal records the elements
+// of other components it has no field for, which Unmodelled returns.
+type Camt05600111Document struct {
+	XMLName                xml.Name                            `xml:"urn:iso:std:iso:20022:tech:xsd:camt.056.001.11 Document"`
+	FIPaymentCancelRequest FIToFIPaymentCancellationRequestV11 `xml:"FIToFIPmtCxlReq"`
+	unmodelled             []string
+}
+
+// FIToFIPaymentCancellationRequestV11 is the message of camt.056.001.11
+type FIToFIPaymentCancellationRequestV11 struct {
+	Assignment        CaseAssignment6           `xml:"Assgnmt"`
+	Case              *Case6                    `xml:"Case,omitempty"`
+	ControlData       *ControlData1             `xml:"CtrlData,omitempty"`
+	Underlying        []UnderlyingTransaction34 `xml:"Undrlyg"`
+	SupplementaryData []SupplementaryData1      `xml:"SplmtryData,omitempty"`
+}
+
+// CaseAssignment6 is the assignment of a case in the 2023 investigation messages
+type CaseAssignment6 struct {
+	ID               string      `xml:"Id"`
+	Assigner         Party50     `xml:"Assgnr"`
+	Assignee         Party50     `xml:"Assgne"`
+	CreationDateTime ISODateTime `xml:"CreDtTm"`
+}
+
+// Case6 identifies a case in the 2023 investigation messages
+type Case6 struct {
+	ID                   string  `xml:"Id"`
+	Creator              Party50 `xml:"Cretr"`
+	ReopenCaseIndication *bool   `xml:"ReopCaseIndctn,omitempty"`
+}
+
+// Party50 is a party or an agent of the 2023 investigation messages
+type Party50 struct {
+	Party *PartyIdentification272                       `xml:"Pty,omitempty"`
+	Agent *BranchAndFinancialInstitutionIdentification8 `xml:"Agt,omitempty"`
+}
+
+// UnderlyingTransaction34 is the underlying of camt.056.001.11
+type UnderlyingTransaction34 struct {
+	OriginalGroupInfoAndCancellation *OriginalGroupHeader21  `xml:"OrgnlGrpInfAndCxl,omitempty"`
+	TransactionInfo                  []PaymentTransaction155 `xml:"TxInf,omitempty"`
+}
+
+// OriginalGroupHeader21 is the original message to cancel in camt.056.001.11
+type OriginalGroupHeader21 struct {
+	GroupCancellationID      *string                      `xml:"GrpCxlId,omitempty"`
+	Case                     *Case6                       `xml:"Case,omitempty"`
+	OriginalMessageID        string                       `xml:"OrgnlMsgId"`
+	OriginalMessageNameID    string                       `xml:"OrgnlMsgNmId"`
+	OriginalCreationDateTime *ISODateTime                 `xml:"OrgnlCreDtTm,omitempty"`
+	NumberOfTransactions     *string                      `xml:"NbOfTxs,omitempty"`
+	ControlSum               *Decimal                     `xml:"CtrlSum,omitempty"`
+	GroupCancellation        *bool                        `xml:"GrpCxl,omitempty"`
+	CancellationReasonInfo   []PaymentCancellationReason6 `xml:"CxlRsnInf,omitempty"`
+}
+
+// PaymentCancellationReason6 is a cancellation reason of camt.056.001.11
+type PaymentCancellationReason6 struct {
+	Originator            *PartyIdentification272 `xml:"Orgtr,omitempty"`
+	Reason                *CancellationReason33   `xml:"Rsn,omitempty"`
+	AdditionalInformation []string                `xml:"AddtlInf,omitempty"`
+}
+
+// PaymentTransaction155 is a transaction to cancel in camt.056.001.11
+type PaymentTransaction155 struct {
+	CancellationID                    *string                                       `xml:"CxlId,omitempty"`
+	Case                              *Case6                                        `xml:"Case,omitempty"`
+	OriginalGroupInfo                 *OriginalGroupInformation29                   `xml:"OrgnlGrpInf,omitempty"`
+	OriginalInstructionID             *string                                       `xml:"OrgnlInstrId,omitempty"`
+	OriginalEndToEndID                *string                                       `xml:"OrgnlEndToEndId,omitempty"`
+	OriginalTransactionID             *string                                       `xml:"OrgnlTxId,omitempty"`
+	OriginalUETR                      *string                                       `xml:"OrgnlUETR,omitempty"`
+	OriginalClearingSystemReference   *string                                       `xml:"OrgnlClrSysRef,omitempty"`
+	OriginalInterbankSettlementAmount *ActiveOrHistoricCurrencyAndAmount            `xml:"OrgnlIntrBkSttlmAmt,omitempty"`
+	OriginalInterbankSettlementDate   *ISODate                                      `xml:"OrgnlIntrBkSttlmDt,omitempty"`
+	Assignor                          *BranchAndFinancialInstitutionIdentification8 `xml:"Assgnr,omitempty"`
+	Assignee                          *BranchAndFinancialInstitutionIdentification8 `xml:"Assgne,omitempty"`
+	CancellationReasonInfo            []PaymentCancellationReason6                  `xml:"CxlRsnInf,omitempty"`
+	OriginalTransactionReference      *OriginalTransactionReference42               `xml:"OrgnlTxRef,omitempty"`
+	SupplementaryData                 []SupplementaryData1                          `xml:"SplmtryData,omitempty"`
+}
+
+// OriginalTransactionReference42 holds the references of the original transaction
+// in the 2023 investigation messages
+type OriginalTransactionReference42 struct {
+	InterbankSettlementAmount *ActiveOrHistoricCurrencyAndAmount            `xml:"IntrBkSttlmAmt,omitempty"`
+	Amount                    *AmountType4                                  `xml:"Amt,omitempty"`
+	InterbankSettlementDate   *ISODate                                      `xml:"IntrBkSttlmDt,omitempty"`
+	RequestedCollectionDate   *ISODate                                      `xml:"ReqdColltnDt,omitempty"`
+	RequestedExecutionDate    *DateAndDateTime2                             `xml:"ReqdExctnDt,omitempty"`
+	CreditorSchemeID          *PartyIdentification272                       `xml:"CdtrSchmeId,omitempty"`
+	SettlementInfo            *SettlementInstruction15                      `xml:"SttlmInf,omitempty"`
+	PaymentTypeInfo           *PaymentTypeInfo19                            `xml:"PmtTpInf,omitempty"`
+	PaymentMethod             *string                                       `xml:"PmtMtd,omitempty"`
+	MandateRelatedInfo        *MandateRelatedInfo16                         `xml:"MndtRltdInf,omitempty"`
+	RemittanceInfo            *RemittanceInfo22                             `xml:"RmtInf,omitempty"`
+	UltimateDebtor            *Party50                                      `xml:"UltmtDbtr,omitempty"`
+	Debtor                    *Party50                                      `xml:"Dbtr,omitempty"`
+	DebtorAccount             *CashAccount40                                `xml:"DbtrAcct,omitempty"`
+	DebtorAgent               *BranchAndFinancialInstitutionIdentification8 `xml:"DbtrAgt,omitempty"`
+	DebtorAgentAccount        *CashAccount40                                `xml:"DbtrAgtAcct,omitempty"`
+	CreditorAgent             *BranchAndFinancialInstitutionIdentification8 `xml:"CdtrAgt,omitempty"`
+	CreditorAgentAccount      *CashAccount40                                `xml:"CdtrAgtAcct,omitempty"`
+	Creditor                  *Party50                                      `xml:"Cdtr,omitempty"`
+	CreditorAccount           *CashAccount40                                `xml:"CdtrAcct,omitempty"`
+	UltimateCreditor          *Party50                                      `xml:"UltmtCdtr,omitempty"`
+	Purpose                   *Purpose2                                     `xml:"Purp,omitempty"`
+}
+
+// MandateRelatedInfo16 is the mandate of an original direct debit in the 2023
+// investigation messages
+type MandateRelatedInfo16 struct {
+	MandateID            *string                 `xml:"MndtId,omitempty"`
+	DateOfSignature      *ISODate                `xml:"DtOfSgntr,omitempty"`
+	AmentmentIndicator   *bool                   `xml:"AmdmntInd,omitempty"`
+	AmendmentInfoDetails *AmendmentInfoDetails15 `xml:"AmdmntInfDtls,omitempty"`
+	ElectronicSignature  *string                 `xml:"ElctrncSgntr,omitempty"`
+	FirstCollectionDate  *ISODate                `xml:"FrstColltnDt,omitempty"`
+	FinalCollectionDate  *ISODate                `xml:"FnlColltnDt,omitempty"`
+	Frequency            *string                 `xml:"Frqcy,omitempty"`
+	Reason               *MandateSetupReason1    `xml:"Rsn,omitempty"`
+	TrackingDays         *string                 `xml:"TrckgDays,omitempty"`
+}
+
+// AmendmentInfoDetails15 is the amendment of a mandate in the 2023 investigation
+// messages
+type AmendmentInfoDetails15 struct {
+	OriginalMandateID            *string                                       `xml:"OrgnlMndtId,omitempty"`
+	OriginalCreditorSchemeID     *PartyIdentification272                       `xml:"OrgnlCdtrSchmeId,omitempty"`
+	OriginalCreditorAgent        *BranchAndFinancialInstitutionIdentification8 `xml:"OrgnlCdtrAgt,omitempty"`
+	OriginalCreditorAgentAccount *CashAccount40                                `xml:"OrgnlCdtrAgtAcct,omitempty"`
+	OriginalDebtor               *PartyIdentification272                       `xml:"OrgnlDbtr,omitempty"`
+	OriginalDebtorAccount        *CashAccount40                                `xml:"OrgnlDbtrAcct,omitempty"`
+	OriginalDebtorAgent          *BranchAndFinancialInstitutionIdentification8 `xml:"OrgnlDbtrAgt,omitempty"`
+	OriginalDebtorAgentAccount   *CashAccount40                                `xml:"OrgnlDbtrAgtAcct,omitempty"`
+	OriginalFinalCollectionDate  *ISODate                                      `xml:"OrgnlFnlColltnDt,omitempty"`
+	OriginalFrequency            *Frequency36                                  `xml:"OrgnlFrqcy,omitempty"`
+	OriginalReason               *MandateSetupReason1                          `xml:"OrgnlRsn,omitempty"`
+	OriginalTrackingDays         *string                                       `xml:"OrgnlTrckgDays,omitempty"`
+}
+
+// RemittanceInfo22 is the remittance information of an original transaction in the
+// 2023 investigation messages
+type RemittanceInfo22 struct {
+	Unstructured []string                     `xml:"Ustrd,omitempty"`
+	Structured   []StructuredRemittanceInfo18 `xml:"Strd,omitempty"`
+}
+
+// StructuredRemittanceInfo18 is structured remittance information of the 2023
+// investigation messages
+type StructuredRemittanceInfo18 struct {
+	ReferredDocumentInfo     []ReferredDocumentInfo7 `xml:"RfrdDocInf,omitempty"`
+	ReferredDocumentAmount   *RemittanceAmount2      `xml:"RfrdDocAmt,omitempty"`
+	CreditorReferenceInfo    *CreditorReferenceInfo2 `xml:"CdtrRefInf,omitempty"`
+	Invoicer                 *PartyIdentification272 `xml:"Invcr,omitempty"`
+	Invoicee                 *PartyIdentification272 `xml:"Invcee,omitempty"`
+	TaxRemittance            *TaxInfo7               `xml:"TaxRmt,omitempty"`
+	GarnishmentRemittance    *Garnishment4           `xml:"GrnshmtRmt,omitempty"`
+	AdditionalRemittanceInfo []string                `xml:"AddtlRmtInf,omitempty"`
+}
+
+// Garnishment4 is the garnishment of a structured remittance of the 2023
+// investigation messages
+type Garnishment4 struct {
+	Type                            GarnishmentTypeAndDeduction1       `xml:"Tp"`
+	Garnishee                       *PartyIdentification272            `xml:"Grnshee,omitempty"`
+	GarnishmentAdministrator        *PartyIdentification272            `xml:"GrnshmtAdmstr,omitempty"`
+	ReferenceNumber                 *string                            `xml:"RefNb,omitempty"`
+	Date                            *ISODate                           `xml:"Dt,omitempty"`
+	RemittedAmount                  *ActiveOrHistoricCurrencyAndAmount `xml:"RmtdAmt,omitempty"`
+	FamilyMedicalInsuranceIndicator *bool                              `xml:"FmlyMdclInsrncInd,omitempty"`
+	EmployeeTerminationIndicator    *bool                              `xml:"MplyeeTermntnInd,omitempty"`
+}
+
+// Unmodelled returns the paths of the elements of the message, as read by
+// Unmarshal, that its components have no field for. They are not decoded, and
+// ConvertCamt056 fails on them with ErrVersionLoss.
+func (d *Camt05600111Document) Unmodelled() []string { return d.unmodelled }
+
+func (d *Camt05600111Document) setUnmodelled(paths []string) { d.unmodelled = paths }
+
+// Version implements Camt056
+func (d *Camt05600108Document) Version() string { return "camt.056.001.08" }
+
+// Version implements Camt056
+func (d *Camt05600111Document) Version() string { return "camt.056.001.11" }
+
+// AssignmentID implements Camt056
+func (d *Camt05600108Document) AssignmentID() string {
+	return d.FIPaymentCancelRequest.Assignment.ID
+}
+
+// AssignmentID implements Camt056
+func (d *Camt05600111Document) AssignmentID() string {
+	return d.FIPaymentCancelRequest.Assignment.ID
+}
+
+// CreationDateTime implements Camt056
+func (d *Camt05600108Document) CreationDateTime() time.Time {
+	return d.FIPaymentCancelRequest.Assignment.CreationDateTime.Time
+}
+
+// CreationDateTime implements Camt056
+func (d *Camt05600111Document) CreationDateTime() time.Time {
+	return d.FIPaymentCancelRequest.Assignment.CreationDateTime.Time
+}
+
+// CancellationRequest implements Camt056
+func (d *Camt05600108Document) CancellationRequest() *FIToFIPaymentCancellationRequestV08 {
+	return &d.FIPaymentCancelRequest
+}
+
+// CancellationRequest implements Camt056. The message is converted to the components of
+// camt.056.001.08, leaving out the elements those do not have, so changes to it do not
+// change d.
+func (d *Camt05600111Document) CancellationRequest() *FIToFIPaymentCancellationRequestV08 {
+	m := new(FIToFIPaymentCancellationRequestV08)
+	convertCore(m, &d.FIPaymentCancelRequest)
+	return m
+}
+
+// Validate checks the repeating elements and the choices of camt.056.001.11
+func (d *Camt05600111Document) Validate() error {
+	if errs := validateStructure(d); errs.HasErrors() {
+		return errs
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package iso20022
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestConvertCamt056(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("testdata", "roundtrip", "camt.056.001.08", "cancellation_request.xml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	v08, err := DecodeCamt056(data)
+	if err != nil {
+		t.Fatalf("DecodeCamt056 failed: %v", err)
+	}
+	if v08.Version() != "camt.056.001.08" || v08.AssignmentID() != "BBBBUS33-CXL-0001" || v08.CreationDateTime().IsZero() {
+		t.Errorf("Unexpected request %s %s %v", v08.Version(), v08.AssignmentID(), v08.CreationDateTime())
+	}
+
+	v11, err := ConvertCamt056(v08, "camt.056.001.11")
+	if err != nil {
+		t.Fatalf("Upgrade failed: %v", err)
+	}
+	want, _ := os.ReadFile(filepath.Join("testdata", "roundtrip", "camt.056.001.11", "cancellation_request.xml"))
+	got, _ := Marshal(v11)
+	for _, diff := range diffXML(t, want, got) {
+		t.Error(diff)
+	}
+
+	back, err := ConvertCamt056(v11, "camt.056.001.08")
+	if err != nil || back.AssignmentID() != v08.AssignmentID() {
+		t.Errorf("Downgrade failed: %v", err)
+	}
+	if _, err := ConvertCamt056(v08, "camt.029.001.09"); !errors.Is(err, ErrUnknownMessage) {
+		t.Errorf("Expected ErrUnknownMessage for another message, got %v", err)
+	}
+}
+
+func TestCamt056CareOf(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("testdata", "roundtrip", "camt.056.001.11", "cancellation_request.xml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	data = bytes.Replace(data, []byte("<Nm>Acme Manufacturing Inc</Nm>\n          </Orgtr>"),
+		[]byte("<Nm>Acme Manufacturing Inc</Nm><PstlAdr><CareOf>Treasury</CareOf></PstlAdr></Orgtr>"), 1)
+	var v11 Camt05600111Document
+	if err := Unmarshal(data, &v11); err != nil {
+		t.Fatal(err)
+	}
+	reason := v11.FIPaymentCancelRequest.Underlying[0].TransactionInfo[0].CancellationReasonInfo[0]
+	if len(v11.Unmodelled()) != 0 || deref(reason.Originator.PostalAddress.CareOf) != "Treasury" {
+		t.Fatalf("Expected CareOf to be decoded, got %v", v11.Unmodelled())
+	}
+	if v11.CancellationRequest().Underlying[0].TransactionInfo[0].OriginalUETR == nil {
+		t.Error("Expected the request with the camt.056.001.08 components")
+	}
+	if _, err := ConvertCamt056(&v11, "camt.056.001.08"); !errors.Is(err, ErrVersionLoss) || !strings.Contains(err.Error(), "Orgtr/PstlAdr/CareOf") {
+		t.Errorf("Expected CareOf to be reported, got %v", err)
+	}
+}
@@ -0,0 +1,236 @@
+// Package cases tracks the lifecycle of payment investigation cases exchanged through the
+// camt exception and investigation messages (camt.026, camt.027, camt.028, camt.029, camt.030,
+// camt.031, camt.038 and camt.039).
+//
+// A Manager keeps one Case per case identification and moves it through the states
+// Opened, Assigned, Pending and Resolved as messages are applied. Messages that answer or
+// forward a case are rejected when the case is unknown or already resolved, and forwarded
+// assignments must chain from the current assignee.
+package cases
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/ckbaum/iso20022-go"
+)
+
+// State is the lifecycle state of an investigation case
+type State int
+
+const (
+	Opened State = iota
+	Assigned
+	Pending
+	Resolved
+)
+
+// String returns the lower-case state name
+func (s State) String() string {
+	switch s {
+	case Opened:
+		return "opened"
+	case Assigned:
+		return "assigned"
+	case Pending:
+		return "pending"
+	case Resolved:
+		return "resolved"
+	}
+	return fmt.Sprintf("State(%d)", int(s))
+}
+
+// MessageType identifies the camt message that carries a case event
+type MessageType string
+
+const (
+	UnableToApply             MessageType = "camt.026"
+	ClaimNonReceipt           MessageType = "camt.027"
+	AdditionalPaymentInfo     MessageType = "camt.028"
+	ResolutionOfInvestigation MessageType = "camt.029"
+	NotificationOfAssignment  MessageType = "camt.030"
+	RejectInvestigation       MessageType = "camt.031"
+	CaseStatusReportRequest   MessageType = "camt.038"
+	CaseStatusReport          MessageType = "camt.039"
+	RequestToModifyPayment    MessageType = "camt.087"
+)
+
+// opens reports whether the message type starts a new case
+func (t MessageType) opens() bool {
+	switch t {
+	case UnableToApply, ClaimNonReceipt, RequestToModifyPayment:
+		return true
+	}
+	return false
+}
+
+// Errors returned by Manager.Apply
+var (
+	ErrUnknownCase        = errors.New("cases: message references an unknown case")
+	ErrCaseResolved       = errors.New("cases: case is already resolved")
+	ErrCaseExists         = errors.New("cases: case is already open")
+	ErrBrokenAssignment   = errors.New("cases: assignment does not chain from the current assignee")
+	ErrMissingCase        = errors.New("cases: message carries no case identification")
+	ErrUnsupportedMessage = errors.New("cases: unsupported message")
+)
+
+// Message is the case-relevant content of an investigation message
+type Message struct {
+	Type       MessageType
+	Assignment iso20022.CaseAssignment5
+	Case       *iso20022.Case5
+}
+
+// CaseID returns the case identification the message refers to
+func (m Message) CaseID() string {
+	if m.Case != nil {
+		return m.Case.ID
+	}
+	return ""
+}
+
+// Event records a message applied to a case
+type Event struct {
+	Type       MessageType
+	Assignment iso20022.CaseAssignment5
+	From       State
+	To         State
+}
+
+// Case is the tracked state of one investigation
+type Case struct {
+	ID          string
+	Creator     iso20022.Party40
+	State       State
+	Assignments []iso20022.CaseAssignment5
+	History     []Event
+}
+
+// CurrentAssignment returns the most recent assignment of the case
+func (c *Case) CurrentAssignment() iso20022.CaseAssignment5 {
+	return c.Assignments[len(c.Assignments)-1]
+}
+
+// Manager tracks investigation cases. It is safe for concurrent use.
+type Manager struct {
+	mu    sync.Mutex
+	cases map[string]*Case
+}
+
+// NewManager returns an empty case manager
+func NewManager() *Manager {
+	return &Manager{cases: make(map[string]*Case)}
+}
+
+// Get returns a copy of the case with the given identification
+func (m *Manager) Get(id string) (Case, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	c, ok := m.cases[id]
+	if !ok {
+		return Case{}, false
+	}
+	return *c, true
+}
+
+// Apply moves the referenced case through its lifecycle and returns its new state
+func (m *Manager) Apply(msg Message) (State, error) {
+	id := msg.CaseID()
+	if id == "" {
+		return 0, ErrMissingCase
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	c, ok := m.cases[id]
+	if msg.Type.opens() {
+		if ok && c.State != Resolved {
+			return c.State, fmt.Errorf("%w: %s", ErrCaseExists, id)
+		}
+		c = &Case{ID: id, Creator: msg.Case.Creator, State: Opened}
+		c.Assignments = append(c.Assignments, msg.Assignment)
+		c.History = append(c.History, Event{Type: msg.Type, Assignment: msg.Assignment, From: Opened, To: Opened})
+		m.cases[id] = c
+		return c.State, nil
+	}
+
+	if !ok {
+		return 0, fmt.Errorf("%w: %s", ErrUnknownCase, id)
+	}
+	if c.State == Resolved {
+		return c.State, fmt.Errorf("%w: %s", ErrCaseResolved, id)
+	}
+
+	var next State
+	switch msg.Type {
+	case NotificationOfAssignment:
+		if !reflect.DeepEqual(msg.Assignment.Assigner, c.CurrentAssignment().Assignee) {
+			return c.State, fmt.Errorf("%w: %s", ErrBrokenAssignment, id)
+		}
+		c.Assignments = append(c.Assignments, msg.Assignment)
+		next = Assigned
+	case AdditionalPaymentInfo, CaseStatusReport:
+		next = Pending
+	case CaseStatusReportRequest:
+		next = c.State
+	case ResolutionOfInvestigation, RejectInvestigation:
+		next = Resolved
+	default:
+		return c.State, fmt.Errorf("%w: %s", ErrUnsupportedMessage, msg.Type)
+	}
+
+	c.History = append(c.History, Event{Type: msg.Type, Assignment: msg.Assignment, From: c.State, To: next})
+	c.State = next
+	return next, nil
+}
+
+// NextAssignment returns a CaseAssignment5 forwarding the case to assignee. The assigner is the
+// current assignee of the case, so the result can be used in a camt.030 without breaking the chain.
+func (m *Manager) NextAssignment(caseID, assignmentID string, assignee iso20022.Party40) (iso20022.CaseAssignment5, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	c, ok := m.cases[caseID]
+	if !ok {
+		return iso20022.CaseAssignment5{}, fmt.Errorf("%w: %s", ErrUnknownCase, caseID)
+	}
+	if c.State == Resolved {
+		return iso20022.CaseAssignment5{}, fmt.Errorf("%w: %s", ErrCaseResolved, caseID)
+	}
+
+	return iso20022.CaseAssignment5{
+		ID:               assignmentID,
+		Assigner:         c.CurrentAssignment().Assignee,
+		Assignee:         assignee,
+		CreationDateTime: iso20022.NewISODateTime(time.Now().UTC()),
+	}, nil
+}
+
+// FromDocument extracts the case event carried by an investigation document
+func FromDocument(doc interface{}) (Message, error) {
+	switch d := doc.(type) {
+	case *iso20022.Camt02600107Document:
+		return Message{Type: UnableToApply, Assignment: d.UnableToApply.Assignment, Case: d.UnableToApply.Case}, nil
+	case *iso20022.Camt02700107Document:
+		return Message{Type: ClaimNonReceipt, Assignment: d.ClaimNonReceipt.Assignment, Case: d.ClaimNonReceipt.Case}, nil
+	case *iso20022.Camt02800109Document:
+		return Message{Type: AdditionalPaymentInfo, Assignment: d.AdditionalPaymentInfo.Assignment, Case: d.AdditionalPaymentInfo.Case}, nil
+	case *iso20022.Camt02900109Document:
+		return Message{Type: ResolutionOfInvestigation, Assignment: d.InvestigationResolution.Assignment, Case: d.InvestigationResolution.ResolvedCase}, nil
+	case *iso20022.Camt02900113Document:
+		r := d.Resolution()
+		return Message{Type: ResolutionOfInvestigation, Assignment: r.Assignment, Case: r.ResolvedCase}, nil
+	case *iso20022.Camt03000105Document:
+		return Message{Type: NotificationOfAssignment, Assignment: d.NotificationOfCaseAssignment.Assignment, Case: &d.NotificationOfCaseAssignment.Case}, nil
+	case *iso20022.Camt03100106Document:
+		return Message{Type: RejectInvestigation, Assignment: d.RejectInvestigation.Assignment, Case: d.RejectInvestigation.Case}, nil
+	case *iso20022.Camt08700108Document:
+		return Message{Type: RequestToModifyPayment, Assignment: d.RequestToModifyPayment.Assignment, Case: d.RequestToModifyPayment.Case}, nil
+	}
+	return Message{}, fmt.Errorf("%w: %T", ErrUnsupportedMessage, doc)
+}
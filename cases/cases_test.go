@@ -0,0 +1,114 @@
+package cases
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ckbaum/iso20022-go"
+)
+
+func agent(bic string) iso20022.Party40 {
+	return iso20022.Party40{
+		Agent: &iso20022.BranchAndFinancialInstitutionIdentification6{
+			FinancialInstitutionID: iso20022.FinancialInstitutionIdentification18{BankIdentifierCode: &bic},
+		},
+	}
+}
+
+func TestCaseLifecycle(t *testing.T) {
+	m := NewManager()
+	claim := &iso20022.Camt02700107Document{
+		ClaimNonReceipt: iso20022.ClaimNonReceiptV07{
+			Assignment: iso20022.CaseAssignment5{
+				ID:               "A1",
+				Assigner:         agent("DEUTDEFF"),
+				Assignee:         agent("COBADEFF"),
+				CreationDateTime: iso20022.NewISODateTime(time.Now()),
+			},
+			Case: &iso20022.Case5{ID: "CASE-1", Creator: agent("DEUTDEFF")},
+		},
+	}
+
+	msg, err := FromDocument(claim)
+	if err != nil {
+		t.Fatalf("FromDocument failed: %v", err)
+	}
+	if state, err := m.Apply(msg); err != nil || state != Opened {
+		t.Fatalf("Expected opened case, got %v, %v", state, err)
+	}
+
+	t.Run("Duplicate open", func(t *testing.T) {
+		if _, err := m.Apply(msg); !errors.Is(err, ErrCaseExists) {
+			t.Errorf("Expected ErrCaseExists, got %v", err)
+		}
+	})
+
+	t.Run("Chained assignment", func(t *testing.T) {
+		next, err := m.NextAssignment("CASE-1", "A2", agent("BNPAFRPP"))
+		if err != nil {
+			t.Fatalf("NextAssignment failed: %v", err)
+		}
+		state, err := m.Apply(Message{Type: NotificationOfAssignment, Assignment: next, Case: claim.ClaimNonReceipt.Case})
+		if err != nil || state != Assigned {
+			t.Fatalf("Expected assigned case, got %v, %v", state, err)
+		}
+	})
+
+	t.Run("Broken assignment chain", func(t *testing.T) {
+		bad := iso20022.CaseAssignment5{ID: "A3", Assigner: agent("DEUTDEFF"), Assignee: agent("BARCGB22"), CreationDateTime: iso20022.NewISODateTime(time.Now())}
+		if _, err := m.Apply(Message{Type: NotificationOfAssignment, Assignment: bad, Case: claim.ClaimNonReceipt.Case}); !errors.Is(err, ErrBrokenAssignment) {
+			t.Errorf("Expected ErrBrokenAssignment, got %v", err)
+		}
+	})
+
+	t.Run("Pending and resolved", func(t *testing.T) {
+		info := iso20022.NewAdditionalPaymentInfoForClaim(claim, "A4", iso20022.PaymentComplementaryInfo9{})
+		msg, _ := FromDocument(info)
+		if state, err := m.Apply(msg); err != nil || state != Pending {
+			t.Fatalf("Expected pending case, got %v, %v", state, err)
+		}
+
+		rsl := iso20022.NewClaimNonReceiptResolution(claim, "A5", iso20022.ClaimNonReceipt2{
+			Accepted: &iso20022.ClaimNonReceiptDetails{DateProcessed: iso20022.NewISODate(2024, time.March, 16)},
+		})
+		msg, _ = FromDocument(rsl)
+		if state, err := m.Apply(msg); err != nil || state != Resolved {
+			t.Fatalf("Expected resolved case, got %v, %v", state, err)
+		}
+
+		if _, err := m.Apply(msg); !errors.Is(err, ErrCaseResolved) {
+			t.Errorf("Expected ErrCaseResolved, got %v", err)
+		}
+
+		c, _ := m.Get("CASE-1")
+		if len(c.History) != 4 {
+			t.Errorf("Expected 4 history events, got %d", len(c.History))
+		}
+	})
+
+	t.Run("Unknown case", func(t *testing.T) {
+		msg := Message{Type: ResolutionOfInvestigation, Case: &iso20022.Case5{ID: "NOPE"}}
+		if _, err := m.Apply(msg); !errors.Is(err, ErrUnknownCase) {
+			t.Errorf("Expected ErrUnknownCase, got %v", err)
+		}
+	})
+}
+
+func TestRejectInvestigationClosesCase(t *testing.T) {
+	m := NewManager()
+	received := iso20022.CaseAssignment5{ID: "A1", Assigner: agent("DEUTDEFF"), Assignee: agent("COBADEFF"), CreationDateTime: iso20022.NewISODateTime(time.Now())}
+	caseInfo := &iso20022.Case5{ID: "CASE-2", Creator: agent("DEUTDEFF")}
+
+	if _, err := m.Apply(Message{Type: UnableToApply, Assignment: received, Case: caseInfo}); err != nil {
+		t.Fatalf("Failed to open case: %v", err)
+	}
+
+	msg, err := FromDocument(iso20022.NewRejectInvestigation("A2", received, caseInfo, "NFOU"))
+	if err != nil {
+		t.Fatalf("FromDocument failed: %v", err)
+	}
+	if state, err := m.Apply(msg); err != nil || state != Resolved {
+		t.Errorf("Expected resolved case, got %v, %v", state, err)
+	}
+}
@@ -0,0 +1,157 @@
+package iso20022
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// ChargesReconciliation breaks down how the charges of a credit transfer transaction
+// account for the difference between the instructed and the settled amount. All
+// amounts are in the interbank settlement currency.
+type ChargesReconciliation struct {
+	ChargeBearer ChargeBearerType1Code
+	Currency     string
+	// InstructedAmount is InstdAmt converted with XchgRate. Without InstdAmt it is
+	// the settled amount plus the deducted charges.
+	InstructedAmount Decimal
+	// DeductedCharges are the charges taken from the amount of the transfer: all of
+	// them for CRED, and those of agents after the debtor agent for SHAR
+	DeductedCharges Decimal
+	// BilledCharges are the charges billed to the debtor rather than deducted: all of
+	// them for DEBT, and those of the debtor agent for SHAR
+	BilledCharges Decimal
+	// CreditedAmount is the amount passed on to the creditor agent, IntrBkSttlmAmt
+	CreditedAmount Decimal
+}
+
+// ReconcileCharges reconciles the charges of every transaction of the message. The
+// reconciliations are returned in transaction order even when some fail to reconcile.
+func (f *FIToFICustomerCreditTransferV08) ReconcileCharges() ([]ChargesReconciliation, error) {
+	var errs ValidationErrors
+	results := make([]ChargesReconciliation, len(f.CreditTransferTransactionInfo))
+	for i := range f.CreditTransferTransactionInfo {
+		result, err := f.CreditTransferTransactionInfo[i].ReconcileCharges()
+		results[i] = result
+		if err != nil {
+			errs = append(errs, nestErrors(fmt.Sprintf("CdtTrfTxInf[%d]", i+1), err)...)
+		}
+	}
+	if errs.HasErrors() {
+		return results, errs
+	}
+	return results, nil
+}
+
+// ReconcileCharges splits the charges of the transaction into those deducted from
+// the amount and those billed to the debtor according to the charge bearer, and
+// checks that the instructed amount less the deducted charges is the interbank
+// settlement amount. It also checks the charge bearer code against the charges: CRED
+// requires ChrgsInf, as a zero amount when nothing was deducted, and SLEV forbids it.
+// Charges must be in the settlement currency, or in the instructed currency when an
+// exchange rate is given.
+func (c *CreditTransferTransaction39) ReconcileCharges() (ChargesReconciliation, error) {
+	var errs ValidationErrors
+	settlement := c.InterbankSettlementAmount
+	result := ChargesReconciliation{
+		ChargeBearer:   c.ChargeBearer,
+		Currency:       settlement.Currency,
+		CreditedAmount: settlement.Value,
+	}
+
+	switch c.ChargeBearer {
+	case "DEBT", "SHAR":
+	case "CRED":
+		if len(c.ChargesInfo) == 0 {
+			errs = append(errs, newValidationError("ChrgsInf", RuleChargeBearer, "CHARGES_REQUIRED"))
+		}
+	case "SLEV":
+		if len(c.ChargesInfo) > 0 {
+			errs = append(errs, newValidationError("ChrgsInf", RuleChargeBearer, "CHARGE_BEARER"))
+		}
+	default:
+		// Without a valid bearer it is unknown which charges were deducted
+		return result, ValidationErrors{newValidationError("ChrgBr", RuleCode, "CODE", c.ChargeBearer)}
+	}
+
+	deducted, billed := new(big.Rat), new(big.Rat)
+	for i, charge := range c.ChargesInfo {
+		amount, ok := c.settlementAmount(charge.Amount)
+		if !ok {
+			errs = append(errs, newValidationError("Ccy", RuleCharges, "CHARGES_CURRENCY", charge.Amount.Currency, settlement.Currency).
+				at(fmt.Sprintf("ChrgsInf[%d]/Amt/@Ccy", i+1)))
+			continue
+		}
+		switch {
+		case c.ChargeBearer == "DEBT",
+			c.ChargeBearer == "SHAR" && sameAgent(&charge.Agent, &c.DebtorAgent):
+			billed.Add(billed, amount)
+		default:
+			deducted.Add(deducted, amount)
+		}
+	}
+
+	credited := DecimalRat(settlement.Value)
+	instructed := new(big.Rat).Add(credited, deducted)
+	if c.InstructedAmount != nil {
+		if amount, ok := c.settlementAmount(*c.InstructedAmount); ok {
+			instructed = amount
+			if expected := new(big.Rat).Sub(instructed, deducted); expected.Cmp(credited) != 0 {
+				errs = append(errs, newValidationError("IntrBkSttlmAmt", RuleCharges, "CHARGES_MISMATCH", formatRat(credited), formatRat(expected)).
+					at("IntrBkSttlmAmt/text()"))
+			}
+		}
+	}
+
+	result.InstructedAmount = RatDecimal(instructed)
+	result.DeductedCharges = RatDecimal(deducted)
+	result.BilledCharges = RatDecimal(billed)
+	if errs.HasErrors() {
+		return result, errs
+	}
+	return result, nil
+}
+
+// settlementAmount converts an amount to the interbank settlement currency, rounded to
+// its minor unit. It reports false when the amount is in another currency and no
+// exchange rate from that currency is given.
+func (c *CreditTransferTransaction39) settlementAmount(amount ActiveOrHistoricCurrencyAndAmount) (*big.Rat, bool) {
+	switch {
+	case amount.Currency == c.InterbankSettlementAmount.Currency:
+		return DecimalRat(amount.Value), true
+	case c.InstructedAmount != nil && amount.Currency == c.InstructedAmount.Currency && c.ExchangeRate != nil:
+		return DecimalRat(ConvertAmount(amount.Value, *c.ExchangeRate, c.InterbankSettlementAmount.Currency)), true
+	}
+	return nil, false
+}
+
+// sameAgent reports whether two agents are identified by the same BIC or the same
+// clearing system member identifier
+func sameAgent(a, b *BranchAndFinancialInstitutionIdentification6) bool {
+	x, y := &a.FinancialInstitutionID, &b.FinancialInstitutionID
+	if x.BankIdentifierCode != nil && y.BankIdentifierCode != nil {
+		return NormalizeBIC(*x.BankIdentifierCode) == NormalizeBIC(*y.BankIdentifierCode)
+	}
+	if x.ClearingSystemMemberID != nil && y.ClearingSystemMemberID != nil {
+		return x.ClearingSystemMemberID.MemberID == y.ClearingSystemMemberID.MemberID
+	}
+	return false
+}
+
+// roundRat rounds r half away from zero to the given number of fraction digits
+func roundRat(r *big.Rat, digits int) *big.Rat {
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(digits)), nil)
+	scaled := new(big.Rat).Mul(r, new(big.Rat).SetInt(scale))
+	half := big.NewRat(1, 2)
+	if scaled.Sign() < 0 {
+		half.Neg(half)
+	}
+	scaled.Add(scaled, half)
+	quotient := new(big.Int).Quo(scaled.Num(), scaled.Denom())
+	return new(big.Rat).SetFrac(quotient, scale)
+}
+
+// RatDecimal converts an exact decimal back to a Decimal
+func RatDecimal(r *big.Rat) Decimal {
+	f, _ := r.Float64()
+	return Decimal(f)
+}
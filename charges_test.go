@@ -0,0 +1,125 @@
+package iso20022
+
+import "testing"
+
+func TestReconcileCharges(t *testing.T) {
+	results, err := loadPacs008Sample(t).FICustomerCreditTransfer.ReconcileCharges()
+	if err != nil {
+		t.Fatalf("Expected the sample to reconcile, got %v", err)
+	}
+	if got := results[0]; got.BilledCharges != 25 || got.DeductedCharges != 0 || got.CreditedAmount != 15000 {
+		t.Errorf("Expected the debtor agent's charges to be billed under SHAR, got %+v", got)
+	}
+
+	charge := func(amount float64, currency, bic string) Charges7 {
+		return Charges7{
+			Amount: ActiveOrHistoricCurrencyAndAmount{Value: Decimal(amount), Currency: currency},
+			Agent:  *testAgentParty(bic).Agent,
+		}
+	}
+	tests := []struct {
+		name     string
+		modify   func(tx *CreditTransferTransaction39)
+		path     string // expected error location, empty when the charges reconcile
+		deducted Decimal
+	}{
+		{
+			name: "SharedIntermediaryChargesDeducted",
+			modify: func(tx *CreditTransferTransaction39) {
+				tx.ChargesInfo = append(tx.ChargesInfo, charge(10, "USD", "CCCCGB2L"))
+				tx.InterbankSettlementAmount.Value = 14990
+			},
+			deducted: 10,
+		},
+		{
+			name: "CreditorBearsAllCharges",
+			modify: func(tx *CreditTransferTransaction39) {
+				tx.ChargeBearer = "CRED"
+				tx.InterbankSettlementAmount.Value = 14975
+			},
+			deducted: 25,
+		},
+		{
+			name: "ChargesInInstructedCurrency",
+			modify: func(tx *CreditTransferTransaction39) {
+				rate := Decimal(1.1)
+				tx.ChargeBearer = "CRED"
+				tx.InstructedAmount = &ActiveOrHistoricCurrencyAndAmount{Value: 10000, Currency: "EUR"}
+				tx.ExchangeRate = &rate
+				tx.ChargesInfo = []Charges7{charge(20, "EUR", "BBBBUS33")}
+				tx.InterbankSettlementAmount.Value = 10978
+			},
+			deducted: 22,
+		},
+		{
+			name: "CreditorWithoutCharges",
+			modify: func(tx *CreditTransferTransaction39) {
+				tx.ChargeBearer = "CRED"
+				tx.ChargesInfo = nil
+			},
+			path: "ChrgsInf",
+		},
+		{
+			name: "DebtorChargesDeducted",
+			modify: func(tx *CreditTransferTransaction39) {
+				tx.ChargeBearer = "DEBT"
+				tx.InterbankSettlementAmount.Value = 14975
+			},
+			path: "IntrBkSttlmAmt/text()",
+		},
+		{
+			name: "UnconvertibleCharge",
+			modify: func(tx *CreditTransferTransaction39) {
+				tx.ChargesInfo = append(tx.ChargesInfo, charge(10, "GBP", "CCCCGB2L"))
+			},
+			path: "ChrgsInf[2]/Amt/@Ccy",
+		},
+		{
+			name: "UnknownChargeBearer",
+			modify: func(tx *CreditTransferTransaction39) {
+				tx.ChargeBearer = "OUR"
+			},
+			path: "ChrgBr",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx := &loadPacs008Sample(t).FICustomerCreditTransfer.CreditTransferTransactionInfo[0]
+			tt.modify(tx)
+
+			result, err := tx.ReconcileCharges()
+			if tt.path == "" {
+				if err != nil {
+					t.Fatalf("Expected the charges to reconcile, got %v", err)
+				}
+				if result.DeductedCharges != tt.deducted || result.InstructedAmount != result.CreditedAmount+tt.deducted {
+					t.Errorf("Expected %v deducted charges, got %+v", tt.deducted, result)
+				}
+				return
+			}
+			errs, ok := err.(ValidationErrors)
+			if !ok || len(errs) != 1 {
+				t.Fatalf("Expected one reconciliation error, got %v", err)
+			}
+			if errs[0].Location() != tt.path {
+				t.Errorf("Expected error at %s, got %s", tt.path, errs[0].Location())
+			}
+		})
+	}
+}
+
+func TestRoundRat(t *testing.T) {
+	for _, tt := range []struct {
+		value float64
+		want  string
+	}{
+		{1.005, "1.01"},
+		{-1.005, "-1.01"},
+		{2.344, "2.34"},
+	} {
+		if got := formatRat(roundRat(DecimalRat(Decimal(tt.value)), 2)); got != tt.want {
+			t.Errorf("roundRat(%v, 2) = %s, want %s", tt.value, got, tt.want)
+		}
+	}
+}
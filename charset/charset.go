@@ -0,0 +1,278 @@
+// Package charset checks and transliterates the free-text fields of ISO 20022 messages
+// so that they fit the restricted character sets accepted on the SWIFT network: the
+// FIN X character set, and the extended set allowed by the CBPR+ usage guidelines.
+//
+// Check reports every free-text element holding characters outside a set. Sanitize
+// rewrites those elements in place, mapping accented Latin letters to their base
+// letters and any other character to a substitute, and returns the changes so they
+// can be logged or shown to the originator before the message is sent.
+package charset
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/ckbaum/iso20022-go"
+)
+
+// Set is a restricted character set
+type Set int
+
+const (
+	// FINX is the SWIFT X character set: a-z A-Z 0-9 / - ? : ( ) . , ' + space,
+	// carriage return and line feed
+	FINX Set = iota
+	// CBPRPlus is the CBPR+ extended character set, which adds
+	// ! # $ % & * = ^ _ ` { | } ~ " ; < > @ [ \ ] to FINX
+	CBPRPlus
+)
+
+// String returns the name of the set
+func (s Set) String() string {
+	switch s {
+	case FINX:
+		return "FIN X"
+	case CBPRPlus:
+		return "CBPR+"
+	}
+	return fmt.Sprintf("Set(%d)", int(s))
+}
+
+const (
+	finXSymbols     = "/-?:().,'+ \r\n"
+	cbprPlusSymbols = "!#$%&*=^_`{|}~\";<>@[\\]"
+)
+
+// Contains reports whether r belongs to the set
+func (s Set) Contains(r rune) bool {
+	switch {
+	case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		return true
+	case strings.ContainsRune(finXSymbols, r):
+		return true
+	}
+	return s == CBPRPlus && strings.ContainsRune(cbprPlusSymbols, r)
+}
+
+// Valid reports whether every character of text belongs to the set
+func (s Set) Valid(text string) bool {
+	for _, r := range text {
+		if !s.Contains(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// Substitute replaces characters that have no transliteration
+const Substitute = '.'
+
+// transliterations maps characters outside FIN X to their closest equivalent in it.
+// Letters with diacritics become their base letter; ligatures and special letters
+// are spelled out.
+var transliterations = map[rune]string{
+	'À': "A", 'Á': "A", 'Â': "A", 'Ã': "A", 'Ä': "A", 'Å': "A", 'Ā': "A", 'Ă': "A", 'Ą': "A",
+	'à': "a", 'á': "a", 'â': "a", 'ã': "a", 'ä': "a", 'å': "a", 'ā': "a", 'ă': "a", 'ą': "a",
+	'Æ': "AE", 'æ': "ae",
+	'Ç': "C", 'Ć': "C", 'Ĉ': "C", 'Ċ': "C", 'Č': "C",
+	'ç': "c", 'ć': "c", 'ĉ': "c", 'ċ': "c", 'č': "c",
+	'Ð': "D", 'Ď': "D", 'Đ': "D", 'ð': "d", 'ď': "d", 'đ': "d",
+	'È': "E", 'É': "E", 'Ê': "E", 'Ë': "E", 'Ē': "E", 'Ĕ': "E", 'Ė': "E", 'Ę': "E", 'Ě': "E",
+	'è': "e", 'é': "e", 'ê': "e", 'ë': "e", 'ē': "e", 'ĕ': "e", 'ė': "e", 'ę': "e", 'ě': "e",
+	'Ĝ': "G", 'Ğ': "G", 'Ġ': "G", 'Ģ': "G", 'ĝ': "g", 'ğ': "g", 'ġ': "g", 'ģ': "g",
+	'Ĥ': "H", 'Ħ': "H", 'ĥ': "h", 'ħ': "h",
+	'Ì': "I", 'Í': "I", 'Î': "I", 'Ï': "I", 'Ĩ': "I", 'Ī': "I", 'Ĭ': "I", 'Į': "I", 'İ': "I",
+	'ì': "i", 'í': "i", 'î': "i", 'ï': "i", 'ĩ': "i", 'ī': "i", 'ĭ': "i", 'į': "i", 'ı': "i",
+	'Ĳ': "IJ", 'ĳ': "ij", 'Ĵ': "J", 'ĵ': "j", 'Ķ': "K", 'ķ': "k",
+	'Ĺ': "L", 'Ļ': "L", 'Ľ': "L", 'Ŀ': "L", 'Ł': "L", 'ĺ': "l", 'ļ': "l", 'ľ': "l", 'ŀ': "l", 'ł': "l",
+	'Ñ': "N", 'Ń': "N", 'Ņ': "N", 'Ň': "N", 'ñ': "n", 'ń': "n", 'ņ': "n", 'ň': "n",
+	'Ò': "O", 'Ó': "O", 'Ô': "O", 'Õ': "O", 'Ö': "O", 'Ø': "O", 'Ō': "O", 'Ŏ': "O", 'Ő': "O",
+	'ò': "o", 'ó': "o", 'ô': "o", 'õ': "o", 'ö': "o", 'ø': "o", 'ō': "o", 'ŏ': "o", 'ő': "o",
+	'Œ': "OE", 'œ': "oe",
+	'Ŕ': "R", 'Ŗ': "R", 'Ř': "R", 'ŕ': "r", 'ŗ': "r", 'ř': "r",
+	'Ś': "S", 'Ŝ': "S", 'Ş': "S", 'Š': "S", 'Ș': "S", 'ś': "s", 'ŝ': "s", 'ş': "s", 'š': "s", 'ș': "s",
+	'ß': "ss",
+	'Ţ': "T", 'Ť': "T", 'Ŧ': "T", 'Ț': "T", 'ţ': "t", 'ť': "t", 'ŧ': "t", 'ț': "t",
+	'Þ': "TH", 'þ': "th",
+	'Ù': "U", 'Ú': "U", 'Û': "U", 'Ü': "U", 'Ũ': "U", 'Ū': "U", 'Ŭ': "U", 'Ů': "U", 'Ű': "U", 'Ų': "U",
+	'ù': "u", 'ú': "u", 'û': "u", 'ü': "u", 'ũ': "u", 'ū': "u", 'ŭ': "u", 'ů': "u", 'ű': "u", 'ų': "u",
+	'Ŵ': "W", 'ŵ': "w",
+	'Ý': "Y", 'Ÿ': "Y", 'Ŷ': "Y", 'ý': "y", 'ÿ': "y", 'ŷ': "y",
+	'Ź': "Z", 'Ż': "Z", 'Ž': "Z", 'ź': "z", 'ż': "z", 'ž': "z",
+	// Punctuation and spacing outside FIN X
+	'\t': " ", ' ': " ", '‘': "'", '’': "'", '‚': "'", '´': "'", '`': "'",
+	'“': "'", '”': "'", '„': "'", '"': "'", '–': "-", '—': "-", '_': "-", '…': "...",
+	'&': "+", '@': "(at)", '[': "(", ']': ")", '{': "(", '}': ")", '<': "(", '>': ")",
+	';': ",", '\\': "/", '|': "/",
+}
+
+// Transliterate rewrites text into the set. Characters in the set are kept, and the
+// others are replaced by their transliteration, or by Substitute when none fits.
+func (s Set) Transliterate(text string) string {
+	var b strings.Builder
+	for _, r := range text {
+		switch {
+		case s.Contains(r):
+			b.WriteRune(r)
+		case s.Valid(transliterations[r]) && transliterations[r] != "":
+			b.WriteString(transliterations[r])
+		default:
+			b.WriteRune(Substitute)
+		}
+	}
+	return b.String()
+}
+
+// FreeTextElements lists the XML elements checked by Check and Sanitize: names,
+// postal address lines and remittance or additional information. Identifiers and
+// codes are left alone, since rewriting them would change their meaning.
+var FreeTextElements = map[string]bool{
+	"Nm":          true,
+	"AdrLine":     true,
+	"Dept":        true,
+	"SubDept":     true,
+	"StrtNm":      true,
+	"BldgNb":      true,
+	"BldgNm":      true,
+	"Flr":         true,
+	"PstBx":       true,
+	"Room":        true,
+	"PstCd":       true,
+	"TwnNm":       true,
+	"TwnLctnNm":   true,
+	"DstrctNm":    true,
+	"CtrySubDvsn": true,
+	"CityOfBirth": true,
+	"Ustrd":       true,
+	"AddtlInf":    true,
+	"AddtlRmtInf": true,
+	"InstrInf":    true,
+}
+
+// Change records a free-text element rewritten by Sanitize
+type Change struct {
+	Path      string // element path, e.g. FIToFICstmrCdtTrf/CdtTrfTxInf[1]/Dbtr/Nm
+	Original  string
+	Sanitized string
+}
+
+// Check returns one validation error per free-text element of doc that holds
+// characters outside the set. doc is a document or any message component.
+func Check(doc interface{}, set Set) error {
+	var errs iso20022.ValidationErrors
+	walk(reflect.ValueOf(doc), "", func(path string, v reflect.Value) {
+		if !set.Valid(v.String()) {
+			errs = append(errs, iso20022.ValidationError{
+				Field:   elementOf(path),
+				Path:    path,
+				Message: fmt.Sprintf("contains characters outside the %s character set", set),
+			})
+		}
+	})
+	if errs.HasErrors() {
+		return errs
+	}
+	return nil
+}
+
+// Sanitize transliterates every free-text element of doc into the set, and returns
+// the elements it changed in document order. doc must be a pointer.
+func Sanitize(doc interface{}, set Set) ([]Change, error) {
+	v := reflect.ValueOf(doc)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return nil, fmt.Errorf("charset: Sanitize needs a non-nil pointer, got %T", doc)
+	}
+	var changes []Change
+	walk(v, "", func(path string, v reflect.Value) {
+		original := v.String()
+		if sanitized := set.Transliterate(original); sanitized != original {
+			v.SetString(sanitized)
+			changes = append(changes, Change{Path: path, Original: original, Sanitized: sanitized})
+		}
+	})
+	return changes, nil
+}
+
+// elementOf returns the element name at the end of a path, without its index
+func elementOf(path string) string {
+	name := path[strings.LastIndexByte(path, '/')+1:]
+	name, _, _ = strings.Cut(name, "[")
+	return name
+}
+
+// walk calls visit for every string in a free-text element below v. Paths follow
+// the style of iso20022.ValidationError, with 1-based indices on repeated elements
+// and the document element left out.
+func walk(v reflect.Value, path string, visit func(path string, v reflect.Value)) {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if !v.IsNil() {
+			walk(v.Elem(), path, visit)
+		}
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			name := elementName(field)
+			if name == "" {
+				continue
+			}
+			child := name
+			if path != "" {
+				child = path + "/" + name
+			}
+			fv := v.Field(i)
+			if FreeTextElements[name] {
+				visitText(fv, child, visit)
+				continue
+			}
+			if fv.Kind() == reflect.Slice {
+				for j := 0; j < fv.Len(); j++ {
+					walk(fv.Index(j), fmt.Sprintf("%s[%d]", child, j+1), visit)
+				}
+				continue
+			}
+			walk(fv, child, visit)
+		}
+	}
+}
+
+// visitText calls visit for the string, *string or []string value of a free-text
+// element
+func visitText(v reflect.Value, path string, visit func(path string, v reflect.Value)) {
+	switch v.Kind() {
+	case reflect.String:
+		if v.Len() > 0 {
+			visit(path, v)
+		}
+	case reflect.Ptr:
+		if !v.IsNil() {
+			visitText(v.Elem(), path, visit)
+		}
+	case reflect.Slice:
+		for j := 0; j < v.Len(); j++ {
+			visitText(v.Index(j), fmt.Sprintf("%s[%d]", path, j+1), visit)
+		}
+	}
+}
+
+// elementName returns the XML element name of a struct field, or "" for fields that
+// are unexported, attributes, character data or the XMLName
+func elementName(field reflect.StructField) string {
+	if field.PkgPath != "" || field.Name == "XMLName" {
+		return ""
+	}
+	tag := field.Tag.Get("xml")
+	name, opts, _ := strings.Cut(tag, ",")
+	if name == "-" || strings.Contains(opts, "attr") || strings.Contains(opts, "chardata") || strings.Contains(opts, "innerxml") {
+		return ""
+	}
+	if i := strings.LastIndexByte(name, ' '); i >= 0 {
+		name = name[i+1:]
+	}
+	if name == "" {
+		return field.Name
+	}
+	return name
+}
@@ -0,0 +1,92 @@
+package charset
+
+import (
+	"testing"
+
+	"github.com/ckbaum/iso20022-go"
+)
+
+func TestTransliterate(t *testing.T) {
+	tests := []struct {
+		set  Set
+		in   string
+		want string
+	}{
+		{FINX, "Müller & Söhne GmbH", "Muller + Sohne GmbH"},
+		{FINX, "Straße 5; Łódź", "Strasse 5, Lodz"},
+		{FINX, "info@example.com", "info(at)example.com"},
+		{FINX, "Invoice №42", "Invoice .42"},
+		{CBPRPlus, "Müller & Söhne GmbH", "Muller & Sohne GmbH"},
+		{CBPRPlus, "info@example.com", "info@example.com"},
+		{CBPRPlus, "“Quoted”", "'Quoted'"},
+		{FINX, "Plain text (ok) 1/2-3?", "Plain text (ok) 1/2-3?"},
+	}
+	for _, tt := range tests {
+		if got := tt.set.Transliterate(tt.in); got != tt.want {
+			t.Errorf("%s.Transliterate(%q) = %q, want %q", tt.set, tt.in, got, tt.want)
+		}
+		if got := tt.set.Transliterate(tt.in); !tt.set.Valid(got) {
+			t.Errorf("%s.Transliterate(%q) = %q is not valid in the set", tt.set, tt.in, got)
+		}
+	}
+}
+
+func TestSanitizeReportsChanges(t *testing.T) {
+	newTransaction := func() iso20022.CreditTransferTransaction39 {
+		return iso20022.CreditTransferTransaction39{
+			Debtor: iso20022.PartyIdentification135{
+				Name: iso20022.Ptr("José Núñez"),
+				PostalAddress: &iso20022.PostalAddress24{
+					StreetName: iso20022.Ptr("Calle Mayor"),
+					TownName:   iso20022.Ptr("A Coruña"),
+				},
+			},
+			Creditor:  iso20022.PartyIdentification135{Name: iso20022.Ptr("ACME Ltd")},
+			PaymentID: iso20022.PaymentIdentification7{EndToEndID: "E2E_ü"},
+		}
+	}
+	doc := &iso20022.Pacs00800108Document{}
+	doc.FICustomerCreditTransfer.CreditTransferTransactionInfo = []iso20022.CreditTransferTransaction39{newTransaction(), newTransaction()}
+
+	err := Check(doc, FINX)
+	errs, ok := err.(iso20022.ValidationErrors)
+	if !ok || len(errs) != 4 {
+		t.Fatalf("Expected 4 errors, got %v", err)
+	}
+	if errs[0].Path != "FIToFICstmrCdtTrf/CdtTrfTxInf[1]/Dbtr/Nm" || errs[0].Field != "Nm" {
+		t.Errorf("Unexpected first error %+v", errs[0])
+	}
+
+	changes, err := Sanitize(doc, FINX)
+	if err != nil {
+		t.Fatalf("Sanitize failed: %v", err)
+	}
+	want := []Change{
+		{"FIToFICstmrCdtTrf/CdtTrfTxInf[1]/Dbtr/Nm", "José Núñez", "Jose Nunez"},
+		{"FIToFICstmrCdtTrf/CdtTrfTxInf[1]/Dbtr/PstlAdr/TwnNm", "A Coruña", "A Coruna"},
+		{"FIToFICstmrCdtTrf/CdtTrfTxInf[2]/Dbtr/Nm", "José Núñez", "Jose Nunez"},
+		{"FIToFICstmrCdtTrf/CdtTrfTxInf[2]/Dbtr/PstlAdr/TwnNm", "A Coruña", "A Coruna"},
+	}
+	if len(changes) != len(want) {
+		t.Fatalf("Expected %d changes, got %+v", len(want), changes)
+	}
+	for i := range want {
+		if changes[i] != want[i] {
+			t.Errorf("Change %d: got %+v, want %+v", i, changes[i], want[i])
+		}
+	}
+
+	// Identifiers are not free text and are left alone
+	if got := doc.FICustomerCreditTransfer.CreditTransferTransactionInfo[0].PaymentID.EndToEndID; got != "E2E_ü" {
+		t.Errorf("Expected EndToEndId to be unchanged, got %q", got)
+	}
+	if err := Check(doc, FINX); err != nil {
+		t.Errorf("Expected no errors after sanitizing, got %v", err)
+	}
+}
+
+func TestSanitizeRequiresPointer(t *testing.T) {
+	if _, err := Sanitize(iso20022.Pacs00800108Document{}, FINX); err == nil {
+		t.Error("Expected an error for a non-pointer document")
+	}
+}
@@ -0,0 +1,276 @@
+package iso20022
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// CHIPSProfile is the CHIPS large-value system of The Clearing House: single USD
+// customer (pacs.008) and institution (pacs.009) transfers between participants
+// identified by their CHIPS participant identifiers, with their status reports
+// (pacs.002) and returns (pacs.004). Other agents may be identified by CHIPS
+// universal identifiers (UIDs), and supplementary data must name its place and
+// carry one XML element.
+var CHIPSProfile = Profile{
+	Name:   "CHIPS",
+	Limits: Limits{MaxTransactions: 1},
+	Rules:  []ProfileRule{chipsCustomerTransferRule, chipsInstitutionTransferRule, chipsStatusReportRule, chipsReturnRule},
+}
+
+// CHIPSClearingSystem is the code of CHIPS in SttlmInf/ClrSys/Cd
+const CHIPSClearingSystem = "CHI"
+
+// CHIPSParticipant returns the agent identification of the CHIPS participant with
+// the given four-digit participant identifier
+func CHIPSParticipant(participantID string) BranchAndFinancialInstitutionIdentification6 {
+	return AgentFromClearingSystem("USPID", participantID, "")
+}
+
+// CHIPSParticipantID returns the participant identifier of a CHIPS participant. It
+// fails when the agent is not identified by a valid USPID clearing system member
+// identification.
+func CHIPSParticipantID(agent *BranchAndFinancialInstitutionIdentification6) (string, error) {
+	return memberIDOf(agent, "USPID")
+}
+
+// CHIPSUID returns the six-digit CHIPS universal identifier of an agent. It fails
+// when the agent is not identified by a valid USCHU clearing system member
+// identification.
+func CHIPSUID(agent *BranchAndFinancialInstitutionIdentification6) (string, error) {
+	return memberIDOf(agent, "USCHU")
+}
+
+// chipsCustomerTransferRule checks a pacs.008 against the CHIPS rules
+func chipsCustomerTransferRule(doc interface{}) ValidationErrors {
+	d, ok := doc.(*Pacs00800108Document)
+	if !ok {
+		return nil
+	}
+	f := &d.FICustomerCreditTransfer
+	errs := chipsGroupHeader(&f.GroupHeader)
+	for i := range f.CreditTransferTransactionInfo {
+		tx := &f.CreditTransferTransactionInfo[i]
+		var txErrs ValidationErrors
+		txErrs = append(txErrs, chipsCurrency("IntrBkSttlmAmt/@Ccy", tx.InterbankSettlementAmount.Currency)...)
+		txErrs = append(txErrs, chipsParticipants(f.GroupHeader.InstructingAgent, f.GroupHeader.InstructedAgent, tx.InstructingAgent, tx.InstructedAgent)...)
+		txErrs = append(txErrs, chipsAgents(map[string]*BranchAndFinancialInstitutionIdentification6{
+			"IntrmyAgt1": tx.IntermediaryAgent1, "IntrmyAgt2": tx.IntermediaryAgent2, "IntrmyAgt3": tx.IntermediaryAgent3,
+			"DbtrAgt": &tx.DebtorAgent, "CdtrAgt": &tx.CreditorAgent,
+		})...)
+		for j, data := range tx.SupplementaryData {
+			txErrs = append(txErrs, chipsSupplementaryData(fmt.Sprintf("SplmtryData[%d]", j+1), data.PlaceAndName, data.Envelope.Content)...)
+		}
+		errs = append(errs, txErrs.within(fmt.Sprintf("CdtTrfTxInf[%d]", i+1))...)
+	}
+	for i, data := range f.SupplementaryData {
+		errs = append(errs, chipsSupplementaryData(fmt.Sprintf("SplmtryData[%d]", i+1), data.PlaceAndName, data.Envelope.Content)...)
+	}
+	return errs.within("FIToFICstmrCdtTrf")
+}
+
+// chipsInstitutionTransferRule checks a pacs.009 against the CHIPS rules
+func chipsInstitutionTransferRule(doc interface{}) ValidationErrors {
+	d, ok := doc.(*Pacs00900108Document)
+	if !ok {
+		return nil
+	}
+	f := &d.FICreditTransfer
+	errs := chipsGroupHeader(&f.GroupHeader)
+	for i := range f.CreditTransferTransactionInfo {
+		tx := &f.CreditTransferTransactionInfo[i]
+		var txErrs ValidationErrors
+		txErrs = append(txErrs, chipsCurrency("IntrBkSttlmAmt/@Ccy", tx.InterbankSettlementAmount.Currency)...)
+		txErrs = append(txErrs, chipsParticipants(f.GroupHeader.InstructingAgent, f.GroupHeader.InstructedAgent, tx.InstructingAgent, tx.InstructedAgent)...)
+		txErrs = append(txErrs, chipsAgents(map[string]*BranchAndFinancialInstitutionIdentification6{
+			"IntrmyAgt1": tx.IntermediaryAgent1, "IntrmyAgt2": tx.IntermediaryAgent2, "IntrmyAgt3": tx.IntermediaryAgent3,
+			"DbtrAgt": tx.DebtorAgent, "CdtrAgt": tx.CreditorAgent,
+		})...)
+		for j, data := range tx.SupplementaryData {
+			txErrs = append(txErrs, chipsSupplementaryData(fmt.Sprintf("SplmtryData[%d]", j+1), data.PlaceAndName, data.Envelope.Content)...)
+		}
+		errs = append(errs, txErrs.within(fmt.Sprintf("CdtTrfTxInf[%d]", i+1))...)
+	}
+	for i, data := range f.SupplementaryData {
+		errs = append(errs, chipsSupplementaryData(fmt.Sprintf("SplmtryData[%d]", i+1), data.PlaceAndName, data.Envelope.Content)...)
+	}
+	return errs.within("FICdtTrf")
+}
+
+// chipsStatusReportRule checks that the rejections of a pacs.002 have a reason
+func chipsStatusReportRule(doc interface{}) ValidationErrors {
+	d, ok := doc.(*Pacs00200110Document)
+	if !ok {
+		return nil
+	}
+	r := &d.FIPaymentStatusReport
+	var errs ValidationErrors
+	for i := range r.TransactionInfoAndStatus {
+		tx := &r.TransactionInfoAndStatus[i]
+		var txErrs ValidationErrors
+		txErrs = append(txErrs, chipsParticipants(r.GroupHeader.InstructingAgent, r.GroupHeader.InstructedAgent, tx.InstructingAgent, tx.InstructedAgent)...)
+		if tx.TransactionStatus != nil && *tx.TransactionStatus == "RJCT" && (len(tx.StatusReasonInfo) == 0 || tx.StatusReasonInfo[0].Reason == nil) {
+			txErrs = append(txErrs, chipsError("StsRsnInf/Rsn", "is required for a rejection"))
+		}
+		for j, data := range tx.SupplementaryData {
+			txErrs = append(txErrs, chipsSupplementaryData(fmt.Sprintf("SplmtryData[%d]", j+1), data.PlaceAndName, data.Envelope.Content)...)
+		}
+		errs = append(errs, txErrs.within(fmt.Sprintf("TxInfAndSts[%d]", i+1))...)
+	}
+	for i, data := range r.SupplementaryData {
+		errs = append(errs, chipsSupplementaryData(fmt.Sprintf("SplmtryData[%d]", i+1), data.PlaceAndName, data.Envelope.Content)...)
+	}
+	return errs.within("FIToFIPmtStsRpt")
+}
+
+// chipsReturnRule checks a pacs.004 against the CHIPS rules
+func chipsReturnRule(doc interface{}) ValidationErrors {
+	d, ok := doc.(*Pacs00400110Document)
+	if !ok {
+		return nil
+	}
+	r := &d.PaymentReturn
+	hdr := &r.GroupHeader
+	var errs ValidationErrors
+	errs = append(errs, chipsSettlement("GrpHdr/SttlmInf", &hdr.SettlementInfo)...)
+	for i := range r.TransactionInfo {
+		tx := &r.TransactionInfo[i]
+		var txErrs ValidationErrors
+		txErrs = append(txErrs, chipsCurrency("RtrdIntrBkSttlmAmt/@Ccy", tx.ReturnedInterbankSettlementAmount.Currency)...)
+		txErrs = append(txErrs, chipsParticipants(hdr.InstructingAgent, hdr.InstructedAgent, tx.InstructingAgent, tx.InstructedAgent)...)
+		if len(tx.ReturnReasonInfo) == 0 || tx.ReturnReasonInfo[0].Reason == nil {
+			txErrs = append(txErrs, chipsError("RtrRsnInf/Rsn", "is required"))
+		}
+		for j, data := range tx.SupplementaryData {
+			txErrs = append(txErrs, chipsSupplementaryData(fmt.Sprintf("SplmtryData[%d]", j+1), data.PlaceAndName, data.Envelope.Content)...)
+		}
+		errs = append(errs, txErrs.within(fmt.Sprintf("TxInf[%d]", i+1))...)
+	}
+	for i, data := range r.SupplementaryData {
+		errs = append(errs, chipsSupplementaryData(fmt.Sprintf("SplmtryData[%d]", i+1), data.PlaceAndName, data.Envelope.Content)...)
+	}
+	return errs.within("PmtRtr")
+}
+
+func chipsError(path, message string) ValidationError {
+	return ValidationError{Field: path[strings.LastIndexByte(path, '/')+1:], Path: path, Message: message + " in CHIPS"}
+}
+
+// chipsGroupHeader checks the settlement of a transfer and its participants given in
+// the group header
+func chipsGroupHeader(hdr *GroupHeader93) ValidationErrors {
+	errs := chipsSettlement("GrpHdr/SttlmInf", &hdr.SettlementInfo)
+	if hdr.InstructingAgent != nil {
+		errs = append(errs, chipsParticipant("GrpHdr/InstgAgt", hdr.InstructingAgent)...)
+	}
+	if hdr.InstructedAgent != nil {
+		errs = append(errs, chipsParticipant("GrpHdr/InstdAgt", hdr.InstructedAgent)...)
+	}
+	return errs
+}
+
+// chipsSettlement checks that a transfer settles in CHIPS
+func chipsSettlement(path string, settlement *SettlementInstruction7) ValidationErrors {
+	var errs ValidationErrors
+	if settlement.SettlementMethod != "CLRG" {
+		errs = append(errs, chipsError(path+"/SttlmMtd", "must be CLRG"))
+	}
+	if cs := settlement.ClearingSystem; cs == nil || cs.Code == nil || *cs.Code != CHIPSClearingSystem {
+		errs = append(errs, chipsError(path+"/ClrSys/Cd", "must be "+CHIPSClearingSystem))
+	}
+	return errs
+}
+
+func chipsCurrency(path, currency string) ValidationErrors {
+	if currency != "USD" {
+		return ValidationErrors{chipsError(path, fmt.Sprintf("must be USD, got %s", currency))}
+	}
+	return nil
+}
+
+// chipsParticipants checks the instructing and instructed agents of a transaction,
+// which may be given once in the group header
+func chipsParticipants(hdrInstructing, hdrInstructed, instructing, instructed *BranchAndFinancialInstitutionIdentification6) ValidationErrors {
+	var errs ValidationErrors
+	if instructing != nil || hdrInstructing == nil {
+		errs = append(errs, chipsParticipant("InstgAgt", instructing)...)
+	}
+	if instructed != nil || hdrInstructed == nil {
+		errs = append(errs, chipsParticipant("InstdAgt", instructed)...)
+	}
+	return errs
+}
+
+// chipsParticipant checks that an agent is identified by its participant identifier
+func chipsParticipant(path string, agent *BranchAndFinancialInstitutionIdentification6) ValidationErrors {
+	if _, err := CHIPSParticipantID(agent); err != nil {
+		return ValidationErrors{chipsError(path+"/FinInstnId/ClrSysMmbId", "must identify a CHIPS participant: "+err.Error())}
+	}
+	return nil
+}
+
+// chipsAgents checks the CHIPS identifiers of other agents, which may be identified
+// any other way
+func chipsAgents(agents map[string]*BranchAndFinancialInstitutionIdentification6) ValidationErrors {
+	var errs ValidationErrors
+	for _, tag := range []string{"IntrmyAgt1", "IntrmyAgt2", "IntrmyAgt3", "DbtrAgt", "CdtrAgt"} {
+		agent := agents[tag]
+		if agent == nil || agent.FinancialInstitutionID.ClearingSystemMemberID == nil {
+			continue
+		}
+		member := agent.FinancialInstitutionID.ClearingSystemMemberID
+		if member.ClearingSystemID == nil || member.ClearingSystemID.Code == nil {
+			continue
+		}
+		switch code := *member.ClearingSystemID.Code; code {
+		case "USCHU", "USPID":
+			if err := ValidateClearingSystemMemberID(code, member.MemberID); err != nil {
+				errs = append(errs, chipsError(tag+"/FinInstnId/ClrSysMmbId/MmbId", err.(ValidationError).Message))
+			}
+		}
+	}
+	return errs
+}
+
+// chipsSupplementaryData checks that supplementary data names its place and that its
+// envelope holds exactly one XML element
+func chipsSupplementaryData(path string, placeAndName *string, content string) ValidationErrors {
+	var errs ValidationErrors
+	if placeAndName == nil || strings.TrimSpace(*placeAndName) == "" {
+		errs = append(errs, chipsError(path+"/PlcAndNm", "is required"))
+	}
+	if n, err := xmlElements(content); err != nil {
+		errs = append(errs, chipsError(path+"/Envlp", "must be well-formed XML: "+err.Error()))
+	} else if n != 1 {
+		errs = append(errs, chipsError(path+"/Envlp", fmt.Sprintf("must hold one element, got %d", n)))
+	}
+	return errs
+}
+
+// xmlElements counts the top-level elements of an XML fragment
+func xmlElements(content string) (int, error) {
+	dec := xml.NewDecoder(strings.NewReader(content))
+	depth, n := 0, 0
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			if depth != 0 {
+				return n, fmt.Errorf("unclosed element")
+			}
+			return n, nil
+		}
+		if err != nil {
+			return n, err
+		}
+		switch tok.(type) {
+		case xml.StartElement:
+			if depth == 0 {
+				n++
+			}
+			depth++
+		case xml.EndElement:
+			depth--
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package iso20022
+
+import (
+	"testing"
+)
+
+// loadCHIPSSample returns the pacs.008 sample made to settle in CHIPS
+func loadCHIPSSample(t *testing.T) *Pacs00800108Document {
+	t.Helper()
+	doc := loadPacs008Sample(t)
+	doc.FICustomerCreditTransfer.GroupHeader.SettlementInfo = SettlementInstruction7{
+		SettlementMethod: "CLRG",
+		ClearingSystem:   &ClearingSystemIdentificationSecondary{Code: Ptr(CHIPSClearingSystem)},
+	}
+	tx := &doc.FICustomerCreditTransfer.CreditTransferTransactionInfo[0]
+	sender, receiver := CHIPSParticipant("0001"), CHIPSParticipant("0002")
+	tx.InstructingAgent, tx.InstructedAgent = &sender, &receiver
+	tx.CreditorAgent = AgentFromClearingSystem("USCHU", "123456", "")
+	return doc
+}
+
+func TestCHIPSProfile(t *testing.T) {
+	profile, ok := LookupProfile("CHIPS")
+	if !ok {
+		t.Fatal("Expected the CHIPS profile to be registered")
+	}
+	doc := loadCHIPSSample(t)
+	if err := profile.Check(doc); err != nil {
+		t.Fatalf("Expected the CHIPS sample to pass, got %v", err)
+	}
+	if uid, err := CHIPSUID(&doc.FICustomerCreditTransfer.CreditTransferTransactionInfo[0].CreditorAgent); err != nil || uid != "123456" {
+		t.Errorf("Expected UID 123456, got %q, %v", uid, err)
+	}
+
+	tx := &doc.FICustomerCreditTransfer.CreditTransferTransactionInfo[0]
+	tx.CreditorAgent = AgentFromClearingSystem("USCHU", "12345", "")
+	receiver := CHIPSParticipant("02")
+	tx.InstructedAgent = &receiver
+	tx.SupplementaryData = []SupplementaryData{
+		{PlaceAndName: Ptr("CHIPS"), Envelope: SupplementaryDataEnvelope{Content: "<Ref>1</Ref>"}},
+		{Envelope: SupplementaryDataEnvelope{Content: "<Ref>1</Ref><Ref>2</Ref>"}},
+		{PlaceAndName: Ptr("CHIPS"), Envelope: SupplementaryDataEnvelope{Content: "<Ref>1"}},
+	}
+	errs, _ := profile.Check(doc).(ValidationErrors)
+	want := []string{
+		"FIToFICstmrCdtTrf/CdtTrfTxInf[1]/InstdAgt/FinInstnId/ClrSysMmbId",
+		"FIToFICstmrCdtTrf/CdtTrfTxInf[1]/CdtrAgt/FinInstnId/ClrSysMmbId/MmbId",
+		"FIToFICstmrCdtTrf/CdtTrfTxInf[1]/SplmtryData[2]/PlcAndNm",
+		"FIToFICstmrCdtTrf/CdtTrfTxInf[1]/SplmtryData[2]/Envlp",
+		"FIToFICstmrCdtTrf/CdtTrfTxInf[1]/SplmtryData[3]/Envlp",
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("Expected %d errors, got %v", len(want), errs)
+	}
+	for i, location := range want {
+		if errs[i].Location() != location {
+			t.Errorf("Expected an error at %s, got %v", location, errs[i])
+		}
+	}
+}
+
+func TestCHIPSReturn(t *testing.T) {
+	sender, receiver := CHIPSParticipant("0002"), CHIPSParticipant("0001")
+	ret := &Pacs00400110Document{PaymentReturn: PaymentReturnV10{
+		GroupHeader: GroupHeader90{
+			SettlementInfo:   SettlementInstruction7{SettlementMethod: "CLRG", ClearingSystem: &ClearingSystemIdentificationSecondary{Code: Ptr("CHI")}},
+			InstructingAgent: &sender,
+			InstructedAgent:  &receiver,
+		},
+		TransactionInfo: []PaymentTransaction118{{
+			ReturnedInterbankSettlementAmount: ActiveCurrencyAndAmount{Value: 100, Currency: "USD"},
+			ReturnReasonInfo:                  []PaymentReturnReason6{{Reason: &ReturnReason5{Code: Ptr("AC04")}}},
+		}},
+	}}
+	if err := CHIPSProfile.Check(ret); err != nil {
+		t.Fatalf("Expected the return to pass, got %v", err)
+	}
+
+	ret.PaymentReturn.TransactionInfo[0].ReturnReasonInfo = nil
+	ret.PaymentReturn.GroupHeader.SettlementInfo.ClearingSystem = nil
+	errs, _ := CHIPSProfile.Check(ret).(ValidationErrors)
+	if len(errs) != 2 || errs[0].Location() != "PmtRtr/GrpHdr/SttlmInf/ClrSys/Cd" || errs[1].Location() != "PmtRtr/TxInf[1]/RtrRsnInf/Rsn" {
+		t.Errorf("Unexpected errors %v", errs)
+	}
+}
@@ -0,0 +1,114 @@
+package iso20022
+
+import (
+	"reflect"
+	"strings"
+)
+
+// xsdChoices lists the components that are XSD choices without Choice in their
+// name, for which exactly one element must be present. Types whose name ends in
+// Choice are choices too. A document with none or several of the elements of a
+// choice is structurally ambiguous, and schema validation rejects it.
+var xsdChoices = map[string]bool{
+	// Parties and accounts
+	"Party38":                               true,
+	"Party40":                               true,
+	"Party50":                               true,
+	"Party44":                               true,
+	"Party":                                 true,
+	"PartyIdentification120":                true,
+	"AccountIdentification4":                true,
+	"CashAccountType2":                      true,
+	"ProxyAccountType1":                     true,
+	"AccountSchemeName1":                    true,
+	"OrganizationIdentificationSchemeName1": true,
+	"OrganizationIdentificationSchemeName":  true,
+	"PersonIdentificationSchemeName2":       true,
+	"PersonIdentificationSchemeName":        true,
+	"FinancialIdentificationSchemeName":     true,
+	"ClearingSystemIdentification":          true,
+	"ClearingSystemIdentificationSecondary": true,
+	"Authorization1":                        true,
+
+	// Payment type and purpose
+	"ServiceLevel8":       true,
+	"ServiceLevel":        true,
+	"LocalInstrument2":    true,
+	"LocalInstrument":     true,
+	"CategoryPurpose1":    true,
+	"CategoryPurpose":     true,
+	"Purpose2":            true,
+	"Purpose":             true,
+	"MandateSetupReason1": true,
+
+	// Remittance
+	"ReferredDocumentType3":       true,
+	"ReferredDocumentTypeOption":  true,
+	"CreditorReferenceType1":      true,
+	"CreditorReferenceTypeOption": true,
+	"DiscountAmountType1":         true,
+	"DiscountAmountType":          true,
+	"TaxAmountType1":              true,
+	"TaxAmountType":               true,
+	"DateAndDateTime2":            true,
+
+	// Statuses and reasons
+	"ReturnReason5":             true,
+	"StatusReason62":            true,
+	"ModificationStatusReason1": true,
+	"CompensationReason1":       true,
+	"CancellationReason33":      true,
+
+	// Investigations
+	"InvestigationStatus5":         true,
+	"InvestigationStatus6":         true,
+	"ClaimNonReceipt2":             true,
+	"ClaimNonReceipt3":             true,
+	"ClaimNonReceiptRejectReason1": true,
+	"CorrectiveTransaction4":       true,
+	"CorrectiveTransaction6":       true,
+	"UnderlyingTransaction5":       true,
+	"UnableToApplyJustification3":  true,
+	"RequestType4":                 true,
+
+	// Account reporting
+	"ReportingSource1":         true,
+	"CreditLineType1":          true,
+	"EntryStatus1":             true,
+	"TechnicalInputChannel1":   true,
+	"AmountType4":              true,
+	"InterestType1":            true,
+	"BalanceType10":            true,
+	"BalanceSubType1":          true,
+	"RateType4":                true,
+	"SequenceRange1":           true,
+	"Frequency36":              true,
+	"TransactionPrice4":        true,
+	"IdentificationSource3":    true,
+	"SafekeepingPlaceFormat28": true,
+}
+
+// isChoice reports whether t is an XSD choice
+func isChoice(t reflect.Type) bool {
+	return strings.HasSuffix(t.Name(), "Choice") || xsdChoices[t.Name()]
+}
+
+// checkChoice reports a choice v at path that has none or several of its elements
+func checkChoice(v reflect.Value, path string, errs *ValidationErrors) {
+	present := 0
+	for _, field := range reflect.VisibleFields(v.Type()) {
+		if !field.IsExported() || field.Anonymous || field.Name == "XMLName" {
+			continue
+		}
+		if _, flags, _ := strings.Cut(field.Tag.Get("xml"), ","); strings.Contains(flags, "attr") {
+			continue
+		}
+		value := v.FieldByIndex(field.Index)
+		if value.Kind() == reflect.Slice && value.Len() > 0 || value.Kind() != reflect.Slice && !value.IsZero() {
+			present++
+		}
+	}
+	if present != 1 {
+		*errs = append(*errs, newValidationError("Choice", RuleChoice, "CHOICE").at(path))
+	}
+}
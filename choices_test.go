@@ -0,0 +1,99 @@
+package iso20022
+
+import (
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestChoicesTable checks that every listed choice is a component of the package
+func TestChoicesTable(t *testing.T) {
+	names := make(map[string]bool)
+	seen := make(map[reflect.Type]bool)
+	var walk func(reflect.Type)
+	walk = func(typ reflect.Type) {
+		for typ.Kind() == reflect.Ptr || typ.Kind() == reflect.Slice {
+			typ = typ.Elem()
+		}
+		if typ.Kind() != reflect.Struct || seen[typ] {
+			return
+		}
+		seen[typ] = true
+		names[typ.Name()] = true
+		for _, field := range reflect.VisibleFields(typ) {
+			walk(field.Type)
+		}
+	}
+	for _, newDocument := range documentTypes {
+		walk(reflect.TypeOf(newDocument()))
+	}
+	for name := range xsdChoices {
+		if !names[name] {
+			t.Errorf("%s is not a component of any message", name)
+		}
+	}
+}
+
+func TestValidateChoices(t *testing.T) {
+	t.Run("Both party identifications", func(t *testing.T) {
+		doc := loadPacs008Sample(t)
+		doc.FICustomerCreditTransfer.CreditTransferTransactionInfo[0].Debtor.ID = &Party38{
+			OrganizationID: &OrganizationIdentification29{AnyBankIdentifierCode: Ptr("AAAAGB2L")},
+			PrivateID:      &PersonIdentification13{},
+		}
+		err := doc.Validate()
+		if err == nil || !strings.Contains(err.Error(), "Field 'FIToFICstmrCdtTrf/CdtTrfTxInf[1]/Dbtr/Id': exactly one choice must be present") {
+			t.Errorf("Expected Dbtr/Id to be reported, got %v", err)
+		}
+	})
+
+	t.Run("Empty account identification", func(t *testing.T) {
+		acct := CashAccount38{}
+		errs := validateStructure(&acct)
+		if len(errs) != 1 || errs[0].Location() != "Id" || errs[0].Field != "Choice" {
+			t.Errorf("Expected Id to be reported, got %v", errs)
+		}
+	})
+
+	t.Run("Date and date time", func(t *testing.T) {
+		date := ISODate{}
+		dt := DateAndDateTime2{Date: &date, DateTime: &ISODateTime{}}
+		if errs := validateStructure(&dt); len(errs) != 1 || errs[0].Path != "" || errs[0].Field != "Choice" {
+			t.Errorf("Expected the choice itself to be reported, got %v", errs)
+		}
+	})
+
+	t.Run("Investigation status", func(t *testing.T) {
+		data, err := os.ReadFile(filepath.Join("testdata", "roundtrip", "camt.029.001.09", "cancellation_rejected.xml"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		doc := new(Camt02900109Document)
+		if err := xml.Unmarshal(data, doc); err != nil {
+			t.Fatal(err)
+		}
+		if err := doc.Validate(); err != nil {
+			t.Fatalf("Expected the sample to be valid, got %v", err)
+		}
+		doc.InvestigationResolution.Status = InvestigationStatus5{}
+		err = doc.Validate()
+		if err == nil || !strings.Contains(err.Error(), "Field 'RsltnOfInvstgtn/Sts': exactly one choice must be present") {
+			t.Errorf("Expected Sts to be reported, got %v", err)
+		}
+	})
+
+	t.Run("Reported once", func(t *testing.T) {
+		doc := loadPacs008Sample(t)
+		doc.FICustomerCreditTransfer.CreditTransferTransactionInfo[0].DebtorAccount = &CashAccount38{
+			ID:   AccountIdentification4{IBAN: Ptr("GB29NWBK60161331926819")},
+			Type: &CashAccountType2{Code: Ptr("CACC"), Proprietary: Ptr("CURRENT")},
+		}
+		err := doc.Validate()
+		if n := strings.Count(err.Error(), "DbtrAcct/Tp': exactly one choice"); n != 1 {
+			t.Errorf("Expected DbtrAcct/Tp to be reported once, got %d in %v", n, err)
+		}
+	})
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/ckbaum/iso20022-go"
+	"github.com/ckbaum/iso20022-go/bai2"
+	"github.com/ckbaum/iso20022-go/mt"
+	"github.com/ckbaum/iso20022-go/nacha"
+)
+
+// conversions maps the output formats of convert to the messages they convert
+// from
+var conversions = map[string]string{
+	"pacs.008": "pain.001.001.09 or a NACHA file of PPD and CCD credits",
+	"nacha":    "pacs.008.001.08",
+	"mt":       "camt.052.001.08 or camt.054.001.08",
+	"bai2":     "camt.052.001.08 or camt.054.001.08",
+}
+
+func runConvert(fs *flag.FlagSet, args []string, stdout io.Writer) error {
+	to := fs.String("to", "", "output format: pacs.008, nacha, mt or bai2")
+	description := fs.String("entry-description", "", "nacha: company entry description of the batch, such as PAYROLL")
+	destination := fs.String("destination", "", "nacha: immediate destination routing number")
+	origin := fs.String("origin", "", "nacha: immediate origin identification")
+	sender := fs.String("sender", "", "bai2: sender identification")
+	receiver := fs.String("receiver", "", "bai2: receiver identification")
+	fileID := fs.String("file-id", "1", "bai2: file identification number")
+	if err := parse(fs, args, 1, 1); err != nil {
+		return err
+	}
+	from, ok := conversions[*to]
+	if !ok {
+		fs.Usage()
+		return errUsage
+	}
+	data, err := readFile(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	// NACHA files are the only input that is not XML
+	if *to == "pacs.008" && !bytes.HasPrefix(bytes.TrimSpace(data), []byte("<")) {
+		return nachaToPacs008(data, stdout)
+	}
+	msgType, doc, err := iso20022.DecodeDocument(data)
+	if err != nil {
+		return fmt.Errorf("%s: %w", fs.Arg(0), err)
+	}
+	unsupported := fmt.Errorf("cannot convert %s to %s, only %s", msgType, *to, from)
+
+	switch *to {
+	case "pacs.008":
+		pain001, ok := doc.(*iso20022.Pain00100109Document)
+		if !ok {
+			return unsupported
+		}
+		docs, err := iso20022.Transform(pain001)
+		if err != nil {
+			return err
+		}
+		return writeDocuments(stdout, docs)
+	case "nacha":
+		pacs008, ok := doc.(*iso20022.Pacs00800108Document)
+		if !ok {
+			return unsupported
+		}
+		batch, err := nacha.FromCreditTransfer(pacs008, nacha.BatchOptions{EntryDescription: *description, Number: 1})
+		if err != nil {
+			return err
+		}
+		f := &nacha.File{
+			Header: nacha.FileHeader{
+				ImmediateDestination: *destination,
+				ImmediateOrigin:      *origin,
+				Created:              time.Now(),
+			},
+			Batches: []nacha.Batch{batch},
+		}
+		return nacha.Write(stdout, f)
+	case "mt":
+		if !strings.HasPrefix(msgType, "camt.052") && !strings.HasPrefix(msgType, "camt.054") {
+			return unsupported
+		}
+		return mt.Export(stdout, doc)
+	case "bai2":
+		if !strings.HasPrefix(msgType, "camt.052") && !strings.HasPrefix(msgType, "camt.054") {
+			return unsupported
+		}
+		return bai2.Write(stdout, bai2.Options{Sender: *sender, Receiver: *receiver, FileID: *fileID}, doc)
+	}
+	return nil
+}
+
+// nachaToPacs008 writes a pacs.008 per batch of a NACHA file
+func nachaToPacs008(data []byte, w io.Writer) error {
+	f, err := nacha.Parse(bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	ids, err := iso20022.NewULIDGenerator("")
+	if err != nil {
+		return err
+	}
+	docs, err := nacha.CreditTransfers(f, ids)
+	if err != nil {
+		return err
+	}
+	return writeDocuments(w, docs)
+}
+
+// writeDocuments writes documents one after the other, each on its own lines
+func writeDocuments(w io.Writer, docs []*iso20022.Pacs00800108Document) error {
+	for _, doc := range docs {
+		data, err := iso20022.Marshal(doc, iso20022.WithIndent("", "  "))
+		if err != nil {
+			return err
+		}
+		if _, err := w.Write(append(data, '\n')); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,351 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/ckbaum/iso20022-go"
+)
+
+// summary is the view of a message shown by inspect
+type summary struct {
+	Type      string `json:"type"`
+	MessageID string `json:"messageId,omitempty"`
+	Created   string `json:"created,omitempty"`
+	Items     []item `json:"items,omitempty"`
+}
+
+// item is a transaction, status, return or entry of a message
+type item struct {
+	Kind            string `json:"kind"`
+	Reference       string `json:"reference,omitempty"`
+	TransactionID   string `json:"transactionId,omitempty"`
+	UETR            string `json:"uetr,omitempty"`
+	Amount          string `json:"amount,omitempty"`
+	Date            string `json:"date,omitempty"`
+	CreditDebit     string `json:"creditDebit,omitempty"`
+	Status          string `json:"status,omitempty"`
+	Reason          string `json:"reason,omitempty"`
+	Debtor          string `json:"debtor,omitempty"`
+	DebtorAccount   string `json:"debtorAccount,omitempty"`
+	DebtorAgent     string `json:"debtorAgent,omitempty"`
+	Creditor        string `json:"creditor,omitempty"`
+	CreditorAccount string `json:"creditorAccount,omitempty"`
+	CreditorAgent   string `json:"creditorAgent,omitempty"`
+	Account         string `json:"account,omitempty"`
+}
+
+func runInspect(fs *flag.FlagSet, args []string, stdout io.Writer) error {
+	asJSON := fs.Bool("json", false, "write the summary as JSON")
+	if err := parse(fs, args, 1, 1); err != nil {
+		return err
+	}
+	data, err := readFile(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	msgType, doc, err := iso20022.DecodeDocument(data)
+	if err != nil {
+		return fmt.Errorf("%s: %w", fs.Arg(0), err)
+	}
+	s := summarize(msgType, data, doc)
+	if *asJSON {
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(s)
+	}
+	return s.write(stdout)
+}
+
+// summarize returns the summary of a document. The header is read from the first
+// MsgId and CreDtTm of the message, which works for any message; the items are
+// listed for the payment and cash management messages.
+func summarize(msgType string, data []byte, doc interface{}) summary {
+	s := summary{Type: msgType}
+	s.MessageID, s.Created = header(data)
+
+	switch d := doc.(type) {
+	case *iso20022.Pacs00800108Document:
+		for _, tx := range d.FICustomerCreditTransfer.CreditTransferTransactionInfo {
+			s.Items = append(s.Items, item{
+				Kind:            "transaction",
+				Reference:       tx.PaymentID.EndToEndID,
+				TransactionID:   deref(tx.PaymentID.TransactionID),
+				UETR:            deref(tx.PaymentID.UETR),
+				Amount:          amount(tx.InterbankSettlementAmount.Currency, tx.InterbankSettlementAmount.Value),
+				Date:            date(tx.InterbankSettlementDate),
+				Debtor:          party(&tx.Debtor),
+				DebtorAccount:   account(tx.DebtorAccount),
+				DebtorAgent:     agent(&tx.DebtorAgent),
+				Creditor:        party(&tx.Creditor),
+				CreditorAccount: account(tx.CreditorAccount),
+				CreditorAgent:   agent(&tx.CreditorAgent),
+			})
+		}
+	case iso20022.Pacs008:
+		// The other versions of pacs.008 show their core fields
+		for _, tx := range d.Transactions() {
+			s.Items = append(s.Items, item{
+				Kind:            "transaction",
+				Reference:       tx.EndToEndID,
+				TransactionID:   tx.TransactionID,
+				UETR:            tx.UETR,
+				Amount:          amount(tx.InterbankSettlementAmount.Currency, tx.InterbankSettlementAmount.Value),
+				Date:            date(tx.InterbankSettlementDate),
+				Debtor:          tx.DebtorName,
+				DebtorAccount:   tx.DebtorAccount,
+				DebtorAgent:     tx.DebtorAgent,
+				Creditor:        tx.CreditorName,
+				CreditorAccount: tx.CreditorAccount,
+				CreditorAgent:   tx.CreditorAgent,
+			})
+		}
+	case *iso20022.Pacs00900108Document:
+		for _, tx := range d.FICreditTransfer.CreditTransferTransactionInfo {
+			s.Items = append(s.Items, item{
+				Kind:            "transaction",
+				Reference:       tx.PaymentID.EndToEndID,
+				TransactionID:   deref(tx.PaymentID.TransactionID),
+				UETR:            deref(tx.PaymentID.UETR),
+				Amount:          amount(tx.InterbankSettlementAmount.Currency, tx.InterbankSettlementAmount.Value),
+				Date:            date(tx.InterbankSettlementDate),
+				Debtor:          agent(&tx.Debtor),
+				DebtorAccount:   account(tx.DebtorAccount),
+				DebtorAgent:     agent(tx.DebtorAgent),
+				Creditor:        agent(&tx.Creditor),
+				CreditorAccount: account(tx.CreditorAccount),
+				CreditorAgent:   agent(tx.CreditorAgent),
+			})
+		}
+	case *iso20022.Pain00100109Document:
+		for _, pmt := range d.CustomerCreditTransferInitiation.PaymentInfo {
+			for _, tx := range pmt.CreditTransferTransactionInfo {
+				it := item{
+					Kind:            "transaction",
+					Reference:       tx.PaymentID.EndToEndID,
+					UETR:            deref(tx.PaymentID.UETR),
+					Date:            dateOrDateTime(&pmt.RequestedExecutionDate),
+					Debtor:          party(&pmt.Debtor),
+					DebtorAccount:   account(&pmt.DebtorAccount),
+					DebtorAgent:     agent(&pmt.DebtorAgent),
+					Creditor:        party(tx.Creditor),
+					CreditorAccount: account(tx.CreditorAccount),
+					CreditorAgent:   agent(tx.CreditorAgent),
+				}
+				if amt := tx.Amount.InstructedAmount; amt != nil {
+					it.Amount = amount(amt.Currency, amt.Value)
+				}
+				s.Items = append(s.Items, it)
+			}
+		}
+	case iso20022.Pacs002:
+		for _, grp := range d.GroupStatuses() {
+			if grp.Status != "" {
+				s.Items = append(s.Items, item{Kind: "group", Reference: grp.OriginalMessageID, Status: grp.Status, Reason: first(grp.Reasons)})
+			}
+		}
+		for _, tx := range d.TransactionStatuses() {
+			s.Items = append(s.Items, item{
+				Kind:          "status",
+				Reference:     tx.OriginalEndToEndID,
+				TransactionID: tx.OriginalTransactionID,
+				UETR:          tx.OriginalUETR,
+				Status:        tx.Status,
+				Reason:        first(tx.Reasons),
+			})
+		}
+	case *iso20022.Pacs00400110Document:
+		for _, tx := range d.PaymentReturn.TransactionInfo {
+			it := item{
+				Kind:          "return",
+				Reference:     deref(tx.OriginalEndToEndID),
+				TransactionID: deref(tx.OriginalTransactionID),
+				UETR:          deref(tx.OriginalUETR),
+				Amount:        amount(tx.ReturnedInterbankSettlementAmount.Currency, tx.ReturnedInterbankSettlementAmount.Value),
+				Date:          date(tx.InterbankSettlementDate),
+			}
+			if len(tx.ReturnReasonInfo) > 0 && tx.ReturnReasonInfo[0].Reason != nil {
+				it.Reason = deref(tx.ReturnReasonInfo[0].Reason.Code)
+			}
+			s.Items = append(s.Items, it)
+		}
+	case *iso20022.Camt05200108Document:
+		for _, rpt := range d.BankAccountReport.Report {
+			s.Items = append(s.Items, entries(cashAccount(&rpt.Account), rpt.Entry)...)
+		}
+	case *iso20022.Camt05400108Document:
+		for _, n := range d.BankDebitCreditNotification.Notification {
+			s.Items = append(s.Items, entries(cashAccount(&n.Account), n.Entry)...)
+		}
+	}
+	return s
+}
+
+// entries returns the items of the entries of an account
+func entries(acct string, ntries []iso20022.ReportEntry10) []item {
+	items := make([]item, 0, len(ntries))
+	for _, e := range ntries {
+		it := item{
+			Kind:        "entry",
+			Account:     acct,
+			Amount:      amount(e.Amount.Currency, e.Amount.Value),
+			CreditDebit: string(e.CreditDebitIndicator),
+		}
+		if e.EntryReference != nil {
+			it.Reference = *e.EntryReference
+		} else {
+			it.Reference = deref(e.AccountServicerReference)
+		}
+		if e.BookingDate != nil {
+			it.Date = dateOrDateTime(e.BookingDate)
+		} else if e.ValueDate != nil {
+			it.Date = dateOrDateTime(e.ValueDate)
+		}
+		items = append(items, it)
+	}
+	return items
+}
+
+// write writes the summary as aligned text
+func (s summary) write(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(tw, "Message\t%s\n", s.Type)
+	if s.MessageID != "" {
+		fmt.Fprintf(tw, "MsgId\t%s\n", s.MessageID)
+	}
+	if s.Created != "" {
+		fmt.Fprintf(tw, "Created\t%s\n", s.Created)
+	}
+	for i, it := range s.Items {
+		fmt.Fprintf(tw, "\n%s %d\n", it.Kind, i+1)
+		for _, f := range []struct{ label, value string }{
+			{"Reference", it.Reference}, {"TxId", it.TransactionID}, {"UETR", it.UETR},
+			{"Amount", it.Amount}, {"Credit/debit", it.CreditDebit}, {"Date", it.Date}, {"Status", it.Status}, {"Reason", it.Reason},
+			{"Account", it.Account},
+			{"Debtor", it.Debtor}, {"Debtor account", it.DebtorAccount}, {"Debtor agent", it.DebtorAgent},
+			{"Creditor", it.Creditor}, {"Creditor account", it.CreditorAccount}, {"Creditor agent", it.CreditorAgent},
+		} {
+			if f.value != "" {
+				fmt.Fprintf(tw, "  %s\t%s\n", f.label, f.value)
+			}
+		}
+	}
+	return tw.Flush()
+}
+
+// header returns the first MsgId and CreDtTm of a message
+func header(data []byte) (msgID, created string) {
+	d := xml.NewDecoder(bytes.NewReader(data))
+	for msgID == "" || created == "" {
+		tok, err := d.Token()
+		if err != nil {
+			break
+		}
+		start, ok := tok.(xml.StartElement)
+		if !ok || start.Name.Local != "MsgId" && start.Name.Local != "CreDtTm" {
+			continue
+		}
+		var text string
+		if d.DecodeElement(&text, &start) != nil {
+			break
+		}
+		if start.Name.Local == "MsgId" && msgID == "" {
+			msgID = text
+		} else if start.Name.Local == "CreDtTm" && created == "" {
+			created = text
+		}
+	}
+	return msgID, created
+}
+
+func amount(currency string, value iso20022.Decimal) string {
+	return currency + " " + strconv.FormatFloat(float64(value), 'f', -1, 64)
+}
+
+func date(d *iso20022.ISODate) string {
+	if d == nil {
+		return ""
+	}
+	return d.String()
+}
+
+func dateOrDateTime(d *iso20022.DateAndDateTime2) string {
+	if d.Date != nil {
+		return d.Date.String()
+	}
+	if d.DateTime != nil {
+		return d.DateTime.String()
+	}
+	return ""
+}
+
+// party returns the name of a party, or its identification
+func party(p *iso20022.PartyIdentification135) string {
+	if p == nil {
+		return ""
+	}
+	if p.Name != nil {
+		return *p.Name
+	}
+	if p.ID != nil && p.ID.OrganizationID != nil {
+		if org := p.ID.OrganizationID; org.AnyBankIdentifierCode != nil {
+			return *org.AnyBankIdentifierCode
+		} else if org.LegalEntityIdentifier != nil {
+			return *org.LegalEntityIdentifier
+		}
+	}
+	return ""
+}
+
+// agent returns the BIC of an agent, or its clearing system member identification
+// or name
+func agent(a *iso20022.BranchAndFinancialInstitutionIdentification6) string {
+	if a == nil {
+		return ""
+	}
+	fi := a.FinancialInstitutionID
+	var ids []string
+	if fi.BankIdentifierCode != nil {
+		ids = append(ids, *fi.BankIdentifierCode)
+	}
+	if fi.ClearingSystemMemberID != nil {
+		ids = append(ids, fi.ClearingSystemMemberID.MemberID)
+	}
+	if fi.Name != nil {
+		ids = append(ids, *fi.Name)
+	}
+	return strings.Join(ids, " ")
+}
+
+func account(a *iso20022.CashAccount38) string {
+	if a == nil {
+		return ""
+	}
+	return a.ID.Identifier()
+}
+
+func cashAccount(a *iso20022.CashAccount39) string {
+	return a.ID.Identifier()
+}
+
+// first returns the first of a list of reasons
+func first(reasons []string) string {
+	if len(reasons) == 0 {
+		return ""
+	}
+	return reasons[0]
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"flag"
+	"io"
+	"strings"
+
+	"github.com/ckbaum/iso20022-go"
+)
+
+func runJSON(fs *flag.FlagSet, args []string, stdout io.Writer) error {
+	if err := parse(fs, args, 1, 1); err != nil {
+		return err
+	}
+	msgType, doc, err := decodeFile(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	// Encode the decoded document rather than the file, so that the JSON holds the
+	// elements the package knows of, in their order
+	data, err := iso20022.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	root, err := parseElement(data)
+	if err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	buf.WriteString(`{"type":`)
+	writeString(&buf, msgType)
+	buf.WriteString(`,"Document":`)
+	root.writeJSON(&buf)
+	buf.WriteString("}")
+
+	var out bytes.Buffer
+	if err := json.Indent(&out, buf.Bytes(), "", "  "); err != nil {
+		return err
+	}
+	out.WriteByte('\n')
+	_, err = out.WriteTo(stdout)
+	return err
+}
+
+// element is an XML element read for conversion to JSON
+type element struct {
+	name     string
+	attrs    []xml.Attr
+	text     string
+	children []*element
+}
+
+// parseElement reads the document element of data
+func parseElement(data []byte) (*element, error) {
+	d := xml.NewDecoder(bytes.NewReader(data))
+	var stack []*element
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return nil, err
+		}
+		switch tok := tok.(type) {
+		case xml.StartElement:
+			e := &element{name: tok.Name.Local, attrs: tok.Attr}
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent.children = append(parent.children, e)
+			}
+			stack = append(stack, e)
+		case xml.CharData:
+			if len(stack) > 0 {
+				stack[len(stack)-1].text += string(tok)
+			}
+		case xml.EndElement:
+			e := stack[len(stack)-1]
+			if stack = stack[:len(stack)-1]; len(stack) == 0 {
+				return e, nil
+			}
+		}
+	}
+}
+
+// writeJSON writes an element as a JSON value in the usual mapping of ISO 20022
+// XML: an element holding only text is a string, other elements are objects with
+// their attributes as @name members, their text as a #text member and a member per
+// child element name, holding an array when the name repeats. Members keep the
+// order of the elements.
+func (e *element) writeJSON(buf *bytes.Buffer) {
+	text := strings.TrimSpace(e.text)
+	if len(e.children) == 0 && len(e.attrs) == 0 {
+		writeString(buf, text)
+		return
+	}
+	buf.WriteByte('{')
+	first := true
+	member := func(name string) {
+		if !first {
+			buf.WriteByte(',')
+		}
+		first = false
+		writeString(buf, name)
+		buf.WriteByte(':')
+	}
+	for _, attr := range e.attrs {
+		name := attr.Name.Local
+		if attr.Name.Space == "xmlns" || attr.Name.Space == "" && name == "xmlns" {
+			continue
+		}
+		member("@" + name)
+		writeString(buf, attr.Value)
+	}
+	if len(e.children) == 0 && text != "" {
+		member("#text")
+		writeString(buf, text)
+	}
+	var names []string
+	byName := make(map[string][]*element)
+	for _, child := range e.children {
+		if _, ok := byName[child.name]; !ok {
+			names = append(names, child.name)
+		}
+		byName[child.name] = append(byName[child.name], child)
+	}
+	for _, name := range names {
+		member(name)
+		if same := byName[name]; len(same) == 1 {
+			same[0].writeJSON(buf)
+			continue
+		}
+		buf.WriteByte('[')
+		for i, child := range byName[name] {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			child.writeJSON(buf)
+		}
+		buf.WriteByte(']')
+	}
+	buf.WriteByte('}')
+}
+
+// writeString writes a JSON string, leaving <, > and & as they are
+func writeString(buf *bytes.Buffer, s string) {
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	enc.Encode(s)
+	buf.Truncate(buf.Len() - 1) // the newline of Encode
+}
@@ -0,0 +1,145 @@
+// Command iso20022 validates, inspects and converts ISO 20022 messages, for the
+// operations and support teams that deal with them outside of an application.
+//
+// The message of a file is told from the namespace of its document element. A file
+// name of - reads standard input.
+//
+//	iso20022 validate [-profile name] [-strict] file...
+//	iso20022 inspect [-json] file
+//	iso20022 convert -to format [flags] file
+//	iso20022 json file
+//	iso20022 types
+//	iso20022 proto [-package name] [type...]
+//
+// validate checks files against the message definitions, the cross-element rules
+// of the messages that have them and, with -profile, the rules of a payment
+// scheme; -strict also reports the elements the message definitions do not have
+// where they appear. inspect shows the parties, amounts and references of a
+// message. convert turns a message into another format, and json writes a message
+// as JSON. proto writes the protocol buffer definitions of messages, by default
+// those of package protobuf. The exit status is 1 when a file is invalid or cannot
+// be processed, and 2 for a usage error.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/ckbaum/iso20022-go"
+)
+
+// errUsage is returned by commands given the wrong arguments, which they report
+// themselves along with their usage
+var errUsage = errors.New("usage")
+
+// errInvalid is returned by commands that reported invalid files
+var errInvalid = errors.New("invalid")
+
+// command is a subcommand of iso20022
+type command struct {
+	name    string
+	args    string
+	summary string
+	run     func(fs *flag.FlagSet, args []string, stdout io.Writer) error
+}
+
+var commands = []command{
+	{"validate", "[-profile name] [-strict] file...", "check messages against their definitions and a scheme profile", runValidate},
+	{"inspect", "[-json] file", "show the parties, amounts and references of a message", runInspect},
+	{"convert", "-to format [flags] file", "convert a message to another format", runConvert},
+	{"json", "file", "write a message as JSON", runJSON},
+	{"types", "", "list the supported messages", runTypes},
+	{"proto", "[-package name] [type...]", "write the protocol buffer definitions of messages", runProto},
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run runs the command line args and returns the exit status
+func run(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		usage(stderr)
+		return 2
+	}
+	for _, cmd := range commands {
+		if cmd.name != args[0] {
+			continue
+		}
+		fs := flag.NewFlagSet(cmd.name, flag.ContinueOnError)
+		fs.SetOutput(stderr)
+		fs.Usage = func() {
+			fmt.Fprintf(stderr, "usage: iso20022 %s %s\n", cmd.name, cmd.args)
+			fs.PrintDefaults()
+		}
+		err := cmd.run(fs, args[1:], stdout)
+		switch {
+		case err == nil:
+			return 0
+		case errors.Is(err, errUsage), errors.Is(err, flag.ErrHelp):
+			return 2
+		case errors.Is(err, errInvalid):
+			return 1
+		default:
+			fmt.Fprintf(stderr, "iso20022 %s: %v\n", cmd.name, err)
+			return 1
+		}
+	}
+	fmt.Fprintf(stderr, "iso20022: unknown command %q\n", args[0])
+	usage(stderr)
+	return 2
+}
+
+func usage(w io.Writer) {
+	fmt.Fprintf(w, "usage: iso20022 command [arguments]\n\ncommands:\n")
+	for _, cmd := range commands {
+		fmt.Fprintf(w, "  %-9s %s\n", cmd.name, cmd.summary)
+	}
+}
+
+// parse parses the flags of a command and checks that it is given from min to max
+// files, max being negative for any number
+func parse(fs *flag.FlagSet, args []string, min, max int) error {
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() < min || max >= 0 && fs.NArg() > max {
+		fs.Usage()
+		return errUsage
+	}
+	return nil
+}
+
+// readFile reads a file, or standard input for -
+func readFile(name string) ([]byte, error) {
+	if name == "-" {
+		return io.ReadAll(os.Stdin)
+	}
+	return os.ReadFile(name)
+}
+
+// decodeFile reads and decodes the document of a file
+func decodeFile(name string) (string, interface{}, error) {
+	data, err := readFile(name)
+	if err != nil {
+		return "", nil, err
+	}
+	msgType, doc, err := iso20022.DecodeDocument(data)
+	if err != nil {
+		return "", nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return msgType, doc, nil
+}
+
+func runTypes(fs *flag.FlagSet, args []string, stdout io.Writer) error {
+	if err := parse(fs, args, 0, 0); err != nil {
+		return err
+	}
+	for _, msgType := range iso20022.MessageTypes() {
+		fmt.Fprintln(stdout, msgType)
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func fixture(msgType, name string) string {
+	return filepath.Join("..", "..", "testdata", "roundtrip", msgType, name)
+}
+
+func runCommand(t *testing.T, args ...string) (int, string, string) {
+	t.Helper()
+	var stdout, stderr bytes.Buffer
+	code := run(args, &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func TestValidate(t *testing.T) {
+	pacs008 := fixture("pacs.008.001.08", "customer_credit_transfer.xml")
+	code, out, _ := runCommand(t, "validate", pacs008, fixture("pacs.002.001.10", "rejected_transaction.xml"))
+	if code != 0 || !strings.Contains(out, "pacs.008.001.08 ok") || !strings.Contains(out, "pacs.002.001.10 ok") {
+		t.Errorf("Expected the files to be valid, got %d:\n%s", code, out)
+	}
+
+	data, err := os.ReadFile(pacs008)
+	if err != nil {
+		t.Fatal(err)
+	}
+	invalid := filepath.Join(t.TempDir(), "invalid.xml")
+	data = bytes.Replace(data, []byte("<MsgId>BBBBUS33-20240315-0001</MsgId>"), []byte("<MsgId></MsgId>"), 1)
+	if err := os.WriteFile(invalid, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	code, out, _ = runCommand(t, "validate", invalid)
+	if code != 1 || !strings.Contains(out, "MsgId") || !strings.Contains(out, "line ") {
+		t.Errorf("Expected the located MsgId error, got %d:\n%s", code, out)
+	}
+
+	unknown := filepath.Join(t.TempDir(), "unknown.xml")
+	data = bytes.Replace(data, []byte("<MsgId></MsgId>"), []byte("<MsgId>BBBBUS33-20240315-0001</MsgId><Note>x</Note>"), 1)
+	if err := os.WriteFile(unknown, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if code, out, _ := runCommand(t, "validate", unknown); code != 0 {
+		t.Errorf("Expected the unknown element to be ignored, got %d:\n%s", code, out)
+	}
+	if code, out, _ := runCommand(t, "validate", "-strict", unknown); code != 1 || !strings.Contains(out, "GrpHdr/Note at line") {
+		t.Errorf("Expected the unknown element to be reported, got %d:\n%s", code, out)
+	}
+
+	if code, _, stderr := runCommand(t, "validate", "-profile", "Nope", pacs008); code != 1 || !strings.Contains(stderr, "unknown profile") {
+		t.Errorf("Expected an unknown profile to fail, got %d: %s", code, stderr)
+	}
+}
+
+func TestInspect(t *testing.T) {
+	code, out, stderr := runCommand(t, "inspect", "-json", fixture("pacs.008.001.08", "customer_credit_transfer.xml"))
+	if code != 0 {
+		t.Fatalf("inspect failed with %d: %s", code, stderr)
+	}
+	var s summary
+	if err := json.Unmarshal([]byte(out), &s); err != nil {
+		t.Fatal(err)
+	}
+	if s.Type != "pacs.008.001.08" || s.MessageID != "BBBBUS33-20240315-0001" || len(s.Items) != 1 {
+		t.Fatalf("Unexpected summary %+v", s)
+	}
+	if it := s.Items[0]; it.Reference != "INV-2024-0042" || it.Amount != "USD 15000" || it.Creditor != "Widget Supplies Ltd" || it.CreditorAgent != "CCCCGB2L" {
+		t.Errorf("Unexpected transaction %+v", it)
+	}
+
+	code, out, _ = runCommand(t, "inspect", fixture("camt.054.001.08", "debit_credit_notification.xml"))
+	if code != 0 || !strings.Contains(out, "DE89370400440532013000") || !strings.Contains(out, "CRDT") {
+		t.Errorf("Expected the entries of the notification, got %d:\n%s", code, out)
+	}
+}
+
+func TestJSON(t *testing.T) {
+	code, out, stderr := runCommand(t, "json", fixture("camt.054.001.08", "debit_credit_notification.xml"))
+	if code != 0 {
+		t.Fatalf("json failed with %d: %s", code, stderr)
+	}
+	var v struct {
+		Type     string
+		Document struct {
+			BkToCstmrDbtCdtNtfctn struct {
+				GrpHdr struct{ MsgId string }
+				Ntfctn struct {
+					Ntry []struct {
+						Amt struct {
+							Ccy  string `json:"@Ccy"`
+							Text string `json:"#text"`
+						}
+					}
+				}
+			}
+		}
+	}
+	if err := json.Unmarshal([]byte(out), &v); err != nil {
+		t.Fatalf("Invalid JSON: %v\n%s", err, out)
+	}
+	ntfctn := v.Document.BkToCstmrDbtCdtNtfctn
+	if v.Type != "camt.054.001.08" || ntfctn.GrpHdr.MsgId != "NTF-20240315-0001" || len(ntfctn.Ntfctn.Ntry) < 2 {
+		t.Fatalf("Unexpected JSON:\n%s", out)
+	}
+	if amt := ntfctn.Ntfctn.Ntry[0].Amt; amt.Ccy != "EUR" || amt.Text != "1250" {
+		t.Errorf("Unexpected amount %+v", amt)
+	}
+}
+
+func TestConvert(t *testing.T) {
+	code, out, stderr := runCommand(t, "convert", "-to", "mt", fixture("camt.054.001.08", "debit_credit_notification.xml"))
+	if code != 0 || !strings.Contains(out, ":61:") {
+		t.Errorf("Expected an MT942, got %d: %s\n%s", code, stderr, out)
+	}
+
+	code, out, stderr = runCommand(t, "convert", "-to", "pacs.008", fixture("pain.001.001.09", "credit_transfer_initiation.xml"))
+	if code != 0 || !strings.Contains(out, "urn:iso:std:iso:20022:tech:xsd:pacs.008.001.08") {
+		t.Errorf("Expected a pacs.008, got %d: %s\n%s", code, stderr, out)
+	}
+
+	code, _, stderr = runCommand(t, "convert", "-to", "bai2", fixture("pacs.008.001.08", "customer_credit_transfer.xml"))
+	if code != 1 || !strings.Contains(stderr, "cannot convert pacs.008.001.08") {
+		t.Errorf("Expected the conversion to be refused, got %d: %s", code, stderr)
+	}
+}
+
+func TestProto(t *testing.T) {
+	code, out, stderr := runCommand(t, "proto", "-package", "payments.v1", "pacs.002.001.10")
+	if code != 0 || !strings.Contains(out, "package payments.v1;") || !strings.Contains(out, "message Pacs00200110Document {") {
+		t.Errorf("Expected the definitions of pacs.002, got %d: %s\n%s", code, stderr, out)
+	}
+	if code, _, stderr := runCommand(t, "proto", "pacs.999.001.01"); code != 1 || !strings.Contains(stderr, "pacs.999.001.01") {
+		t.Errorf("Expected an unknown message to be refused, got %d: %s", code, stderr)
+	}
+}
+
+func TestUsage(t *testing.T) {
+	if code, _, stderr := runCommand(t, "frobnicate"); code != 2 || !strings.Contains(stderr, "unknown command") {
+		t.Errorf("Expected a usage error, got %d: %s", code, stderr)
+	}
+	if code, _, _ := runCommand(t, "convert", "-to", "pdf", "x.xml"); code != 2 {
+		t.Errorf("Expected a usage error for an unknown format, got %d", code)
+	}
+	if code, out, _ := runCommand(t, "types"); code != 0 || !strings.Contains(out, "pacs.008.001.08\n") {
+		t.Errorf("Expected the message types, got %d:\n%s", code, out)
+	}
+}
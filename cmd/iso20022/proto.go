@@ -0,0 +1,35 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/ckbaum/iso20022-go"
+	"github.com/ckbaum/iso20022-go/protobuf"
+)
+
+func runProto(fs *flag.FlagSet, args []string, stdout io.Writer) error {
+	pkg := fs.String("package", protobuf.DefaultPackage, "proto package of the definitions")
+	if err := parse(fs, args, 0, -1); err != nil {
+		return err
+	}
+	msgTypes := fs.Args()
+	if len(msgTypes) == 0 {
+		msgTypes = protobuf.CoreMessageTypes
+	}
+	docs := make([]interface{}, 0, len(msgTypes))
+	for _, msgType := range msgTypes {
+		doc, ok := iso20022.NewDocument(msgType)
+		if !ok {
+			return fmt.Errorf("%w: %s", iso20022.ErrUnknownMessage, msgType)
+		}
+		docs = append(docs, doc)
+	}
+	schema, err := protobuf.Schema(*pkg, docs...)
+	if err != nil {
+		return err
+	}
+	_, err = stdout.Write(schema)
+	return err
+}
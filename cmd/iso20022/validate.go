@@ -0,0 +1,109 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/ckbaum/iso20022-go"
+)
+
+// businessRules is implemented by the documents with cross-element rules
+type businessRules interface {
+	ValidateBusinessRules() error
+}
+
+func runValidate(fs *flag.FlagSet, args []string, stdout io.Writer) error {
+	profileName := fs.String("profile", "", "scheme profile to check the messages against, such as SEPA or Fedwire")
+	strict := fs.Bool("strict", false, "report unknown, misplaced and repeated elements")
+	if err := parse(fs, args, 1, -1); err != nil {
+		return err
+	}
+	var profile *iso20022.Profile
+	if *profileName != "" {
+		p, ok := iso20022.LookupProfile(*profileName)
+		if !ok {
+			return fmt.Errorf("unknown profile %q", *profileName)
+		}
+		profile = &p
+	}
+
+	invalid := false
+	for _, name := range fs.Args() {
+		msgType, errs, err := validateFile(name, profile, *strict)
+		if err != nil {
+			return err
+		}
+		if len(errs) == 0 {
+			fmt.Fprintf(stdout, "%s: %s ok\n", name, msgType)
+			continue
+		}
+		invalid = true
+		fmt.Fprintf(stdout, "%s: %s has %d errors\n", name, msgType, len(errs))
+		for _, e := range errs {
+			fmt.Fprintf(stdout, "  %s\n", e)
+		}
+	}
+	if invalid {
+		return errInvalid
+	}
+	return nil
+}
+
+// validateFile checks a file and returns its message type and the errors found in
+// it. Files that cannot be read or decoded fail with an error.
+func validateFile(name string, profile *iso20022.Profile, strict bool) (string, []error, error) {
+	data, err := readFile(name)
+	if err != nil {
+		return "", nil, err
+	}
+	msgType, err := iso20022.MessageType(data)
+	if err != nil {
+		return "", nil, fmt.Errorf("%s: %w", name, err)
+	}
+	doc, ok := iso20022.NewDocument(msgType)
+	if !ok {
+		return "", nil, fmt.Errorf("%s: %w: %s", name, iso20022.ErrUnknownMessage, msgType)
+	}
+
+	var errs []error
+	if strict {
+		// Strict decoding stops at the first unexpected element, which is reported
+		// along with the validation errors of the lenient decoding
+		fresh, _ := iso20022.NewDocument(msgType)
+		if err := iso20022.Unmarshal(data, fresh, iso20022.Strict()); errors.Is(err, iso20022.ErrUnexpectedElement) {
+			errs = append(errs, err)
+		}
+	}
+	if v, ok := doc.(iso20022.Validator); ok {
+		// ValidateXML locates the errors in the file
+		err := iso20022.ValidateXML(data, v)
+		verrs, invalid := err.(iso20022.ValidationErrors)
+		if err != nil && !invalid {
+			return "", nil, fmt.Errorf("%s: %w", name, err)
+		}
+		for _, e := range verrs {
+			errs = append(errs, e)
+		}
+	} else if err := iso20022.Unmarshal(data, doc); err != nil {
+		return "", nil, fmt.Errorf("%s: %w", name, err)
+	}
+	collect := func(err error) {
+		if verrs, ok := err.(iso20022.ValidationErrors); ok {
+			for _, e := range verrs {
+				errs = append(errs, e)
+			}
+		} else if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	// The cross-element rules assume a structurally valid document
+	if r, ok := doc.(businessRules); ok && len(errs) == 0 {
+		collect(r.ValidateBusinessRules())
+	}
+	if profile != nil {
+		collect(profile.Check(doc))
+	}
+	return msgType, errs, nil
+}
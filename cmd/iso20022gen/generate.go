@@ -0,0 +1,676 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// builtinTypes maps XML Schema built-in types to the Go types the iso20022 package
+// uses for them.
+var builtinTypes = map[string]string{
+	"string":             "string",
+	"normalizedString":   "string",
+	"token":              "string",
+	"anyURI":             "string",
+	"ID":                 "string",
+	"base64Binary":       "string",
+	"gYear":              "string",
+	"gYearMonth":         "string",
+	"gMonth":             "string",
+	"gDay":               "string",
+	"decimal":            "Decimal",
+	"boolean":            "bool",
+	"date":               "ISODate",
+	"dateTime":           "ISODateTime",
+	"time":               "ISOTime",
+	"int":                "int",
+	"integer":            "int",
+	"long":               "int",
+	"short":              "int",
+	"nonNegativeInteger": "int",
+	"positiveInteger":    "int",
+}
+
+// Options controls code generation.
+type Options struct {
+	Package string // package clause of the generated file
+	Source  string // XSD file name recorded in the generated header
+
+	// Existing lists the types already declared in the target package. They are
+	// referenced rather than generated again, which keeps a single definition of
+	// shared components such as ActiveCurrencyAndAmount across message versions.
+	Existing *PackageInfo
+}
+
+// field is an element or attribute of a generated struct.
+type field struct {
+	Name      string       // Go field name
+	Tag       string       // XML element or attribute name
+	XSDType   string       // local name of the XSD type
+	GoType    string       // Go type of a single occurrence
+	Complex   bool         // the type is a complex type with its own struct
+	Attribute bool         // the field is an XML attribute
+	Min, Max  int          // occurrence bounds, Max is -1 when unbounded
+	Facets    *restriction // facets of simple types, nil for complex types
+}
+
+func (f field) repeated() bool { return f.Max != 1 }
+
+type generator struct {
+	opts    Options
+	complex map[string]*complexType
+	simple  map[string]*simpleType
+	emitted map[string]bool
+	queue   []string
+	body    bytes.Buffer
+	imports map[string]bool
+}
+
+// Generate emits the Go source for the message described by s: a document type
+// named after the target namespace, plus every complex type reachable from it
+// that the target package does not already declare.
+func Generate(s *schema, opts Options) ([]byte, error) {
+	if opts.Package == "" {
+		opts.Package = "iso20022"
+	}
+	if opts.Existing == nil {
+		opts.Existing = &PackageInfo{Types: map[string]bool{}, Validators: map[string]bool{}}
+	}
+
+	g := &generator{
+		opts:    opts,
+		complex: make(map[string]*complexType),
+		simple:  make(map[string]*simpleType),
+		emitted: make(map[string]bool),
+		imports: map[string]bool{"encoding/xml": true},
+	}
+	for i := range s.ComplexTypes {
+		g.complex[s.ComplexTypes[i].Name] = &s.ComplexTypes[i]
+	}
+	for i := range s.SimpleTypes {
+		g.simple[s.SimpleTypes[i].Name] = &s.SimpleTypes[i]
+	}
+
+	if err := g.document(s); err != nil {
+		return nil, err
+	}
+	for len(g.queue) > 0 {
+		name := g.queue[0]
+		g.queue = g.queue[1:]
+		if err := g.complexType(g.complex[name]); err != nil {
+			return nil, err
+		}
+	}
+
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "// Code generated by iso20022gen from %s. DO NOT EDIT.\n\n", opts.Source)
+	fmt.Fprintf(&out, "package %s\n\nimport (\n", opts.Package)
+	var imports []string
+	for path := range g.imports {
+		imports = append(imports, path)
+	}
+	sort.Strings(imports)
+	for _, path := range imports {
+		fmt.Fprintf(&out, "\t%q\n", path)
+	}
+	out.WriteString(")\n")
+	out.Write(g.body.Bytes())
+
+	src, err := format.Source(out.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting generated code: %w", err)
+	}
+	return src, nil
+}
+
+// messageName derives the message identifier, e.g. pacs.008.001.12, from the
+// schema's target namespace.
+func messageName(namespace string) (string, error) {
+	i := strings.LastIndex(namespace, ":")
+	name := namespace[i+1:]
+	if strings.Count(name, ".") != 3 {
+		return "", fmt.Errorf("target namespace %q does not name an ISO 20022 message", namespace)
+	}
+	return name, nil
+}
+
+// documentTypeName follows the package convention of naming documents after the
+// message identifier, so pacs.008.001.12 becomes Pacs00800112Document.
+func documentTypeName(message string) string {
+	name := strings.ReplaceAll(message, ".", "")
+	return strings.ToUpper(name[:1]) + name[1:] + "Document"
+}
+
+func (g *generator) document(s *schema) error {
+	message, err := messageName(s.TargetNamespace)
+	if err != nil {
+		return err
+	}
+	var root *element
+	for i := range s.Elements {
+		if s.Elements[i].Name == "Document" {
+			root = &s.Elements[i]
+		}
+	}
+	if root == nil {
+		return fmt.Errorf("schema %s has no Document element", message)
+	}
+	docType, ok := g.complex[localName(root.Type)]
+	if !ok {
+		return fmt.Errorf("Document element type %s is not a complex type", root.Type)
+	}
+	fields, err := g.fields(docType)
+	if err != nil {
+		return err
+	}
+	if len(fields) != 1 || !fields[0].Complex {
+		return fmt.Errorf("Document type %s must contain exactly one message element", docType.Name)
+	}
+	msg := fields[0]
+	g.enqueue(msg.XSDType)
+
+	name := documentTypeName(message)
+	if g.opts.Existing.Types[name] {
+		return fmt.Errorf("%s is already declared in the target package", name)
+	}
+	upper := strings.ToUpper(message)
+	w := &g.body
+	fmt.Fprintf(w, "\n// %s represents the %s %s message.\n", name, upper, describe(msg.XSDType))
+	fmt.Fprintf(w, "type %s struct {\n", name)
+	fmt.Fprintf(w, "\tXMLName xml.Name `xml:\"%s Document\"`\n", s.TargetNamespace)
+	fmt.Fprintf(w, "\t%s %s `xml:\"%s\"`\n", msg.Name, msg.GoType, msg.Tag)
+	fmt.Fprintf(w, "}\n")
+
+	fmt.Fprintf(w, "\n// Validate performs comprehensive validation according to %s XSD\n", message)
+	fmt.Fprintf(w, "func (d *%s) Validate() error {\n", name)
+	if g.hasValidate(msg.GoType) {
+		fmt.Fprintf(w, "\tif err := d.%s.Validate(); err != nil {\n", msg.Name)
+		fmt.Fprintf(w, "\t\treturn nestErrors(%q, err)\n\t}\n\treturn nil\n", msg.Tag)
+	} else {
+		fmt.Fprintf(w, "\treturn nil\n")
+	}
+	fmt.Fprintf(w, "}\n")
+	return nil
+}
+
+// enqueue schedules a complex type for generation unless the target package
+// already declares it.
+func (g *generator) enqueue(name string) {
+	if g.emitted[name] || g.opts.Existing.Types[name] {
+		return
+	}
+	g.emitted[name] = true
+	g.queue = append(g.queue, name)
+}
+
+// hasValidate reports whether values of the Go type have a Validate method,
+// either generated here or declared in the target package.
+func (g *generator) hasValidate(goType string) bool {
+	if g.opts.Existing.Types[goType] {
+		return g.opts.Existing.Validators[goType]
+	}
+	return g.emitted[goType]
+}
+
+// fields flattens a complex type's content model into struct fields. Elements of a
+// choice, and of groups nested in a choice, become optional.
+func (g *generator) fields(ct *complexType) ([]field, error) {
+	var fields []field
+	var walk func(grp *group, optional bool) error
+	walk = func(grp *group, optional bool) error {
+		for _, p := range grp.Particles {
+			switch {
+			case p.Element != nil:
+				f, err := g.elementField(p.Element)
+				if err != nil {
+					return fmt.Errorf("%s/%s: %w", ct.Name, p.Element.Name, err)
+				}
+				if optional {
+					f.Min = 0
+				}
+				fields = append(fields, f)
+			case p.Sequence != nil:
+				if err := walk(p.Sequence, optional); err != nil {
+					return err
+				}
+			case p.Choice != nil:
+				if err := walk(p.Choice, true); err != nil {
+					return err
+				}
+			case p.Any:
+				fields = append(fields, field{Name: "Content", GoType: "string", Max: 1})
+			}
+		}
+		return nil
+	}
+	switch {
+	case ct.Sequence != nil:
+		return fields, walk(ct.Sequence, false)
+	case ct.Choice != nil:
+		return fields, walk(ct.Choice, true)
+	}
+	return fields, nil
+}
+
+func (g *generator) elementField(e *element) (field, error) {
+	min, err := occurs(e.MinOccurs)
+	if err != nil {
+		return field{}, err
+	}
+	max, err := occurs(e.MaxOccurs)
+	if err != nil {
+		return field{}, err
+	}
+	f := field{Name: fieldName(e.Name), Tag: e.Name, XSDType: localName(e.Type), Min: min, Max: max}
+	if _, ok := g.complex[f.XSDType]; ok && !isBuiltin(e.Type) {
+		f.GoType = f.XSDType
+		f.Complex = true
+		return f, nil
+	}
+	f.GoType, f.Facets, err = g.resolveSimple(e.Type)
+	return f, err
+}
+
+// resolveSimple follows a simple type's restriction chain down to a built-in type
+// and returns the Go type together with the facets of the named type.
+func (g *generator) resolveSimple(qname string) (string, *restriction, error) {
+	if isBuiltin(qname) {
+		goType, ok := builtinTypes[localName(qname)]
+		if !ok {
+			return "", nil, fmt.Errorf("unsupported built-in type %s", qname)
+		}
+		return goType, nil, nil
+	}
+	st, ok := g.simple[localName(qname)]
+	if !ok {
+		return "", nil, fmt.Errorf("unknown type %s", qname)
+	}
+	goType, _, err := g.resolveSimple(st.Restriction.Base)
+	if err != nil {
+		return "", nil, err
+	}
+	return goType, &st.Restriction, nil
+}
+
+func (g *generator) complexType(ct *complexType) error {
+	w := &g.body
+	description := strings.TrimSpace(ct.Documentation)
+	if description == "" {
+		description = describe(ct.Name)
+	}
+	fmt.Fprintf(w, "\n// %s - %s\n", ct.Name, firstSentence(description))
+
+	if ct.SimpleContent != nil {
+		return g.simpleContentType(ct)
+	}
+
+	fields, err := g.fields(ct)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "type %s struct {\n", ct.Name)
+	for _, f := range fields {
+		if f.Complex {
+			g.enqueue(f.GoType)
+		}
+		if f.Tag == "" {
+			fmt.Fprintf(w, "\tContent string `xml:\",innerxml\"`\n")
+			continue
+		}
+		goType, tag := f.GoType, f.Tag
+		switch {
+		case f.repeated():
+			goType = "[]" + goType
+			if f.Min == 0 {
+				tag += ",omitempty"
+			}
+		case f.Min == 0:
+			goType = "*" + goType
+			tag += ",omitempty"
+		}
+		fmt.Fprintf(w, "\t%s %s `xml:\"%s\"`%s\n", f.Name, goType, tag, fieldComment(f))
+	}
+	fmt.Fprintf(w, "}\n")
+
+	return g.validate(ct, fields, ct.Choice != nil)
+}
+
+// simpleContentType emits a value with attributes, such as an amount with its
+// currency. Decimal values get explicit XML methods because the chardata tag
+// bypasses Decimal's own MarshalXML.
+func (g *generator) simpleContentType(ct *complexType) error {
+	ext := ct.SimpleContent.Extension
+	valueType, valueFacets, err := g.resolveSimple(ext.Base)
+	if err != nil {
+		return fmt.Errorf("%s: %w", ct.Name, err)
+	}
+	fields := []field{{Name: "Value", XSDType: localName(ext.Base), GoType: valueType, Min: 1, Max: 1, Facets: valueFacets}}
+	for _, attr := range ext.Attributes {
+		goType, facets, err := g.resolveSimple(attr.Type)
+		if err != nil {
+			return fmt.Errorf("%s/@%s: %w", ct.Name, attr.Name, err)
+		}
+		min := 0
+		if attr.Use == "required" {
+			min = 1
+		}
+		fields = append(fields, field{Name: fieldName(attr.Name), Tag: attr.Name, XSDType: localName(attr.Type),
+			GoType: goType, Attribute: true, Min: min, Max: 1, Facets: facets})
+	}
+
+	w := &g.body
+	fmt.Fprintf(w, "type %s struct {\n", ct.Name)
+	for _, f := range fields {
+		if !f.Attribute {
+			fmt.Fprintf(w, "\t%s %s `xml:\",chardata\"`\n", f.Name, f.GoType)
+			continue
+		}
+		tag := f.Tag + ",attr"
+		if f.Min == 0 {
+			tag += ",omitempty"
+		}
+		fmt.Fprintf(w, "\t%s %s `xml:\"%s\"`\n", f.Name, f.GoType, tag)
+	}
+	fmt.Fprintf(w, "}\n")
+
+	if valueType == "Decimal" {
+		if err := g.decimalMethods(ct.Name, fields[1:]); err != nil {
+			return err
+		}
+	}
+	return g.validate(ct, fields, false)
+}
+
+func (g *generator) decimalMethods(typeName string, attrs []field) error {
+	for _, f := range attrs {
+		if f.GoType != "string" {
+			return fmt.Errorf("%s/@%s: only string attributes are supported on decimal values", typeName, f.Tag)
+		}
+	}
+	g.imports["strconv"] = true
+	g.imports["strings"] = true
+	r := receiver(typeName)
+	if r == "d" || r == "e" {
+		r = "a" // d and e name the decoder and encoder parameters
+	}
+	w := &g.body
+
+	fmt.Fprintf(w, "\n// MarshalXML encodes the value in decimal notation. The chardata tag bypasses the\n")
+	fmt.Fprintf(w, "// Decimal type's MarshalXML, so we handle it at the struct level.\n")
+	fmt.Fprintf(w, "func (%s %s) MarshalXML(e *xml.Encoder, start xml.StartElement) error {\n", r, typeName)
+	for _, f := range attrs {
+		if f.Min == 0 {
+			fmt.Fprintf(w, "\tif %s.%s != \"\" {\n\t", r, f.Name)
+		}
+		fmt.Fprintf(w, "\tstart.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: %q}, Value: %s.%s})\n", f.Tag, r, f.Name)
+		if f.Min == 0 {
+			fmt.Fprintf(w, "\t}\n")
+		}
+	}
+	fmt.Fprintf(w, "\te.EncodeToken(start)\n")
+	fmt.Fprintf(w, "\te.EncodeToken(xml.CharData(strconv.FormatFloat(float64(%s.Value), 'f', -1, 64)))\n", r)
+	fmt.Fprintf(w, "\te.EncodeToken(start.End())\n")
+	fmt.Fprintf(w, "\treturn nil\n}\n")
+
+	fmt.Fprintf(w, "\n// UnmarshalXML decodes the value and its attributes from XML.\n")
+	fmt.Fprintf(w, "func (%s *%s) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {\n", r, typeName)
+	fmt.Fprintf(w, "\tfor _, attr := range start.Attr {\n\t\tswitch attr.Name.Local {\n")
+	for _, f := range attrs {
+		fmt.Fprintf(w, "\t\tcase %q:\n\t\t\t%s.%s = attr.Value\n", f.Tag, r, f.Name)
+	}
+	fmt.Fprintf(w, "\t\t}\n\t}\n")
+	fmt.Fprintf(w, "\tvar raw string\n")
+	fmt.Fprintf(w, "\tif err := d.DecodeElement(&raw, &start); err != nil {\n\t\treturn err\n\t}\n")
+	fmt.Fprintf(w, "\tv, err := strconv.ParseFloat(strings.TrimSpace(raw), 64)\n")
+	fmt.Fprintf(w, "\tif err != nil {\n\t\treturn err\n\t}\n")
+	fmt.Fprintf(w, "\t%s.Value = Decimal(v)\n", r)
+	fmt.Fprintf(w, "\treturn nil\n}\n")
+	return nil
+}
+
+// validate emits the Validate method: occurrence checks, facet checks on simple
+// values, recursion into nested components, and the choice count.
+func (g *generator) validate(ct *complexType, fields []field, choice bool) error {
+	r := receiver(ct.Name)
+	var checks bytes.Buffer
+	for _, f := range fields {
+		if f.Tag == "" {
+			continue
+		}
+		if err := g.fieldChecks(&checks, r, f, choice); err != nil {
+			return fmt.Errorf("%s/%s: %w", ct.Name, f.Tag, err)
+		}
+	}
+
+	w := &g.body
+	fmt.Fprintf(w, "\n// Validate performs validation for %s\n", ct.Name)
+	fmt.Fprintf(w, "func (%s *%s) Validate() error {\n", r, ct.Name)
+	if checks.Len() == 0 && !choice {
+		fmt.Fprintf(w, "\treturn nil\n}\n")
+		return nil
+	}
+	fmt.Fprintf(w, "\tvar errs ValidationErrors\n\n")
+	if choice {
+		fmt.Fprintf(w, "\t// Exactly one choice must be present\n\tchoiceCount := 0\n")
+	}
+	w.Write(checks.Bytes())
+	if choice {
+		fmt.Fprintf(w, "\n\tif choiceCount != 1 {\n")
+		fmt.Fprintf(w, "\t\terrs = append(errs, ValidationError{Field: \"Choice\", Message: \"exactly one choice must be present\"})\n\t}\n")
+	}
+	fmt.Fprintf(w, "\n\tif errs.HasErrors() {\n\t\treturn errs\n\t}\n\treturn nil\n}\n")
+	return nil
+}
+
+func (g *generator) fieldChecks(w *bytes.Buffer, r string, f field, choice bool) error {
+	value := r + "." + f.Name
+
+	if f.repeated() {
+		if choice {
+			fmt.Fprintf(w, "\tif len(%s) > 0 {\n\t\tchoiceCount++\n\t}\n", value)
+		} else if f.Min > 0 {
+			fmt.Fprintf(w, "\tif len(%s) == 0 {\n", value)
+			fmt.Fprintf(w, "\t\terrs = append(errs, ValidationError{Field: %q, Message: \"at least one occurrence is required\"})\n\t}\n", f.Tag)
+		}
+		if f.Max > 1 {
+			fmt.Fprintf(w, "\tif len(%s) > %d {\n", value, f.Max)
+			fmt.Fprintf(w, "\t\terrs = append(errs, ValidationError{Field: %q, Message: \"at most %d occurrences are allowed\"})\n\t}\n", f.Tag, f.Max)
+		}
+		var inner bytes.Buffer
+		g.imports["fmt"] = true
+		if err := g.valueChecks(&inner, value+"[i]", fmt.Sprintf("fmt.Sprintf(\"%s[%%d]\", i+1)", f.Tag), f, "\t\t"); err != nil {
+			return err
+		}
+		if inner.Len() > 0 {
+			fmt.Fprintf(w, "\tfor i := range %s {\n", value)
+			w.Write(inner.Bytes())
+			fmt.Fprintf(w, "\t}\n")
+		}
+		return nil
+	}
+
+	if f.Min == 0 {
+		var inner bytes.Buffer
+		deref := value
+		if !f.Complex && !f.Attribute {
+			deref = "*" + value
+		}
+		if f.Attribute {
+			// Optional attributes are plain values omitted when empty.
+			if err := g.valueChecks(&inner, value, fmt.Sprintf("%q", f.Tag), f, "\t\t"); err != nil {
+				return err
+			}
+			if inner.Len() > 0 {
+				fmt.Fprintf(w, "\tif %s != \"\" {\n", value)
+				w.Write(inner.Bytes())
+				fmt.Fprintf(w, "\t}\n")
+			}
+			return nil
+		}
+		if err := g.valueChecks(&inner, deref, fmt.Sprintf("%q", f.Tag), f, "\t\t"); err != nil {
+			return err
+		}
+		if inner.Len() > 0 || choice {
+			fmt.Fprintf(w, "\tif %s != nil {\n", value)
+			if choice {
+				fmt.Fprintf(w, "\t\tchoiceCount++\n")
+			}
+			w.Write(inner.Bytes())
+			fmt.Fprintf(w, "\t}\n")
+		}
+		return nil
+	}
+
+	// Required single occurrence. Values without a length facet to catch the empty
+	// case are checked for presence first, and their other facets only when set.
+	if f.Complex || f.GoType == "Decimal" || f.GoType == "bool" || f.GoType == "int" ||
+		f.GoType == "string" && hasLengthFacet(f.Facets) {
+		return g.valueChecks(w, value, fmt.Sprintf("%q", f.Tag), f, "\t")
+	}
+	var inner bytes.Buffer
+	if err := g.valueChecks(&inner, value, fmt.Sprintf("%q", f.Tag), f, "\t\t"); err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "\tif err := validateRequired(%s, %q); err != nil {\n", value, f.Tag)
+	fmt.Fprintf(w, "\t\terrs = append(errs, %s)\n", validationError(f))
+	if inner.Len() > 0 {
+		fmt.Fprintf(w, "\t} else {\n")
+		w.Write(inner.Bytes())
+	}
+	fmt.Fprintf(w, "\t}\n")
+	return nil
+}
+
+// validationError returns the expression that converts a failed check into the
+// ValidationError appended to errs. Attribute errors carry an @Name path.
+func validationError(f field) string {
+	if f.Attribute {
+		return "attributeError(err)"
+	}
+	return "err.(ValidationError)"
+}
+
+// valueChecks emits the checks for one occurrence of a field. fieldExpr is the Go
+// expression for the error's field name.
+func (g *generator) valueChecks(w *bytes.Buffer, value, fieldExpr string, f field, indent string) error {
+	if f.Complex {
+		if g.hasValidate(f.GoType) || g.willGenerate(f.GoType) {
+			fmt.Fprintf(w, "%sif err := %s.Validate(); err != nil {\n", indent, value)
+			fmt.Fprintf(w, "%s\terrs = append(errs, nestErrors(%s, err)...)\n%s}\n", indent, fieldExpr, indent)
+		}
+		return nil
+	}
+	if f.GoType != "string" || f.Facets == nil {
+		return nil
+	}
+
+	check := func(call string) {
+		fmt.Fprintf(w, "%sif err := %s; err != nil {\n", indent, call)
+		fmt.Fprintf(w, "%s\terrs = append(errs, %s)\n%s}\n", indent, validationError(f), indent)
+	}
+	r := f.Facets
+	switch {
+	case r.Length != nil:
+		check(fmt.Sprintf("validateStringLength(%s, %s, %s, %s)", value, r.Length.Value, r.Length.Value, fieldExpr))
+	case r.MaxLength != nil:
+		minLength := "0"
+		if r.MinLength != nil {
+			minLength = r.MinLength.Value
+		}
+		check(fmt.Sprintf("validateStringLength(%s, %s, %s, %s)", value, minLength, r.MaxLength.Value, fieldExpr))
+	}
+	if len(r.Patterns) > 0 {
+		pattern, err := goPattern(r.Patterns)
+		if err != nil {
+			return err
+		}
+		check(fmt.Sprintf("validatePattern(%s, `%s`, %s)", value, pattern, fieldExpr))
+	}
+	if len(r.Enumerations) > 0 {
+		values := make([]string, len(r.Enumerations))
+		for i, e := range r.Enumerations {
+			values[i] = fmt.Sprintf("%q", e.Value)
+		}
+		check(fmt.Sprintf("validateEnumeration(%s, []string{%s}, %s)", value, strings.Join(values, ", "), fieldExpr))
+	}
+	return nil
+}
+
+// willGenerate reports whether a complex type is part of this generation run.
+func (g *generator) willGenerate(name string) bool {
+	_, ok := g.complex[name]
+	return ok && !g.opts.Existing.Types[name]
+}
+
+func hasLengthFacet(r *restriction) bool {
+	return r != nil && (r.Length != nil || r.MinLength != nil && r.MinLength.Value != "0")
+}
+
+// goPattern converts XSD patterns, which are implicitly anchored and combined with
+// "or" when repeated, into a single anchored Go regular expression.
+func goPattern(patterns []facet) (string, error) {
+	alternatives := make([]string, len(patterns))
+	for i, p := range patterns {
+		alternatives[i] = p.Value
+	}
+	pattern := strings.Join(alternatives, "|")
+	if len(patterns) > 1 || strings.Contains(pattern, "|") {
+		pattern = "(?:" + pattern + ")"
+	}
+	pattern = "^" + pattern + "$"
+	if strings.Contains(pattern, "`") {
+		return "", fmt.Errorf("pattern %q cannot be quoted", pattern)
+	}
+	if _, err := regexp.Compile(pattern); err != nil {
+		return "", fmt.Errorf("pattern %q is not supported: %w", pattern, err)
+	}
+	return pattern, nil
+}
+
+// fieldComment mirrors the package's field annotations: the XSD simple type and
+// whether the element is required, or the occurrence range of repeated elements.
+func fieldComment(f field) string {
+	var parts []string
+	if !f.Complex {
+		parts = append(parts, f.XSDType)
+	}
+	switch {
+	case f.repeated():
+		max := "unbounded"
+		if f.Max > 0 {
+			max = fmt.Sprint(f.Max)
+		}
+		parts = append(parts, fmt.Sprintf("%d..%s", f.Min, max))
+	case f.Min > 0 && f.Complex:
+		parts = append(parts, "Required")
+	case f.Min > 0:
+		parts = append(parts, "required")
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return " // " + strings.Join(parts, " - ")
+}
+
+// receiver picks the method receiver name from the type's initial, avoiding i,
+// which the generated loops use.
+func receiver(typeName string) string {
+	r := string(unicode.ToLower([]rune(typeName)[0]))
+	if r == "i" {
+		return "v"
+	}
+	return r
+}
+
+func firstSentence(text string) string {
+	text = strings.Join(strings.Fields(text), " ")
+	if i := strings.Index(text, ". "); i >= 0 {
+		return text[:i]
+	}
+	return strings.TrimSuffix(text, ".")
+}
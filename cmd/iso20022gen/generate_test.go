@@ -0,0 +1,158 @@
+package main
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFieldName(t *testing.T) {
+	tests := map[string]string{
+		"CdtTrfTxInf":       "CreditTransferTransactionInfo",
+		"FIToFICstmrCdtTrf": "FIToFICustomerCreditTransfer",
+		"IntrBkSttlmAmt":    "InterbankSettlementAmount",
+		"CreDtTm":           "CreationDateTime",
+		"EndToEndId":        "EndToEndID",
+		"BICFI":             "BankIdentifierCode",
+		"IntrmyAgt1Acct":    "IntermediaryAgent1Account",
+		"UETR":              "UETR",
+		"XyzAbc":            "XyzAbc",
+	}
+	for tag, want := range tests {
+		if got := fieldName(tag); got != want {
+			t.Errorf("fieldName(%q) = %q, want %q", tag, got, want)
+		}
+	}
+}
+
+func generateSample(t *testing.T, existing *PackageInfo) string {
+	t.Helper()
+	f, err := os.Open(filepath.Join("testdata", "camt.050.001.06.xsd"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	s, err := parseSchema(f)
+	if err != nil {
+		t.Fatalf("Failed to parse schema: %v", err)
+	}
+	src, err := Generate(s, Options{Source: "camt.050.001.06.xsd", Existing: existing})
+	if err != nil {
+		t.Fatalf("Failed to generate: %v", err)
+	}
+	return string(src)
+}
+
+func TestGenerateStandalone(t *testing.T) {
+	src := generateSample(t, nil)
+
+	for _, want := range []string{
+		"// Code generated by iso20022gen from camt.050.001.06.xsd. DO NOT EDIT.",
+		"type Camt05000106Document struct",
+		"`xml:\"urn:iso:std:iso:20022:tech:xsd:camt.050.001.06 Document\"`",
+		"TransferredAmount   Amount3Choice",
+		"AdditionalInfo      []string",
+		`validateEnumeration(*l.Priority, []string{"URGT", "HIGH", "NORM"}, "Prty")`,
+		"validateStringLength(m.MessageID, 1, 35, \"MsgId\")",
+		"`^[a-f0-9]{8}-[a-f0-9]{4}-4[a-f0-9]{3}-[89ab][a-f0-9]{3}-[a-f0-9]{12}$`",
+		"at most 3 occurrences are allowed",
+		"exactly one choice must be present",
+		"func (a ActiveCurrencyAndAmount) MarshalXML",
+		"Content string `xml:\",innerxml\"`",
+		`return nestErrors("LqdtyCdtTrf", err)`,
+		`nestErrors(fmt.Sprintf("SplmtryData[%d]", i+1), err)`,
+		`errs = append(errs, attributeError(err))`,
+	} {
+		if !strings.Contains(src, want) {
+			t.Errorf("Expected generated code to contain %q", want)
+		}
+	}
+}
+
+// TestGenerateIntoPackage generates the sample against the iso20022 package and
+// type-checks the result together with the package, so the output must reuse the
+// existing components instead of redeclaring them.
+func TestGenerateIntoPackage(t *testing.T) {
+	pkgDir := filepath.Join("..", "..")
+	existing, err := LoadPackage(pkgDir, "")
+	if err != nil {
+		t.Fatalf("Failed to load package: %v", err)
+	}
+	src := generateSample(t, existing)
+
+	for _, reused := range []string{"MessageHeader1", "ActiveCurrencyAndAmount", "BranchAndFinancialInstitutionIdentification6"} {
+		if strings.Contains(src, "type "+reused+" struct") {
+			t.Errorf("Expected existing type %s to be reused", reused)
+		}
+	}
+	if !strings.Contains(src, "type Amount3Choice struct") {
+		t.Errorf("Expected new type Amount3Choice to be generated")
+	}
+
+	fset := token.NewFileSet()
+	files := []*ast.File{}
+	paths, _ := filepath.Glob(filepath.Join(pkgDir, "*.go"))
+	for _, path := range paths {
+		if strings.HasSuffix(path, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		files = append(files, file)
+	}
+	generated, err := parser.ParseFile(fset, "generated.go", src, 0)
+	if err != nil {
+		t.Fatalf("Generated code does not parse: %v", err)
+	}
+	files = append(files, generated)
+
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	if _, err := conf.Check("iso20022", fset, files, nil); err != nil {
+		t.Fatalf("Generated code does not type-check with the package: %v", err)
+	}
+}
+
+func TestSequences(t *testing.T) {
+	f, err := os.Open(filepath.Join("testdata", "camt.050.001.06.xsd"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	s, err := parseSchema(f)
+	if err != nil {
+		t.Fatalf("Failed to parse schema: %v", err)
+	}
+	out, err := Sequences(s, Options{Source: "camt.050.001.06.xsd"})
+	if err != nil {
+		t.Fatalf("Failed to generate: %v", err)
+	}
+	src := strings.Join(strings.Fields(string(out)), " ")
+
+	for _, want := range []string{
+		"// Code generated by iso20022gen -sequences from camt.050.001.06.xsd. DO NOT EDIT.",
+		"registerSequences(map[string][]string{",
+		`"LiquidityCreditTransferV06": {"MsgHdr", "LqdtyCdtTrf", "SplmtryData"},`,
+		`"MessageHeader1": {"MsgId", "CreDtTm"},`,
+		`"LiquidityCreditTransfer3": {"LqdtyTrfId", "Cdtr", "TrfdAmt", "SttlmDt", "Prty", "AddtlInf", "UETR"},`,
+	} {
+		if !strings.Contains(src, want) {
+			t.Errorf("Expected generated sequences to contain %q:\n%s", want, src)
+		}
+	}
+	for _, unwanted := range []string{`"Document"`, `"Amount3Choice"`, `"ActiveCurrencyAndAmount"`, `"SupplementaryDataEnvelope1"`} {
+		if strings.Contains(src, unwanted) {
+			t.Errorf("Expected no sequence for %s", unwanted)
+		}
+	}
+	if _, err := parser.ParseFile(token.NewFileSet(), "sequences.go", out, 0); err != nil {
+		t.Errorf("Generated code does not parse: %v", err)
+	}
+}
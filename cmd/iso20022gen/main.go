@@ -0,0 +1,135 @@
+// Command iso20022gen generates Go types for an ISO 20022 message from its official
+// XSD, in the style of the iso20022 package: structs with XML tags, field names
+// expanded from the ISO abbreviations, and Validate methods that check occurrences,
+// choices, lengths, patterns and code lists.
+//
+// Components the package already declares are referenced instead of generated
+// again, so adding a message version only emits what is new in that version:
+//
+//	go run ./cmd/iso20022gen -o pacs_008_001_12.go pacs.008.001.12.xsd
+//
+// With -sequences it emits the XSD sequences of the components of the message
+// instead, which iso20022.WithSequenceCheck compares the field order of the
+// structs against:
+//
+//	go run ./cmd/iso20022gen -sequences -o pacs_008_001_12_sequences.go pacs.008.001.12.xsd
+package main
+
+import (
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	out := flag.String("o", "", "output file (default standard output)")
+	pkg := flag.String("pkg", "iso20022", "package name of the generated file")
+	dir := flag.String("existing", ".", "directory of the target package, whose declared types are reused")
+	sequences := flag.Bool("sequences", false, "emit the XSD sequences of the message components for WithSequenceCheck instead of types")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: iso20022gen [flags] message.xsd\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if err := run(flag.Arg(0), *out, *pkg, *dir, *sequences); err != nil {
+		fmt.Fprintf(os.Stderr, "iso20022gen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(xsdPath, outPath, pkg, dir string, sequences bool) error {
+	f, err := os.Open(xsdPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	s, err := parseSchema(f)
+	if err != nil {
+		return err
+	}
+
+	var src []byte
+	if sequences {
+		src, err = Sequences(s, Options{Package: pkg, Source: filepath.Base(xsdPath)})
+	} else {
+		var existing *PackageInfo
+		existing, err = LoadPackage(dir, outPath)
+		if err != nil {
+			return err
+		}
+		src, err = Generate(s, Options{Package: pkg, Source: filepath.Base(xsdPath), Existing: existing})
+	}
+	if err != nil {
+		return err
+	}
+
+	if outPath == "" {
+		_, err = os.Stdout.Write(src)
+		return err
+	}
+	return os.WriteFile(outPath, src, 0o644)
+}
+
+// PackageInfo records the declarations of the target package that generated code
+// can reuse.
+type PackageInfo struct {
+	Types      map[string]bool // declared type names
+	Validators map[string]bool // type names with a Validate method
+}
+
+// LoadPackage scans the non-test Go files in dir. The file being regenerated is
+// skipped so that running the generator twice does not see its own output.
+func LoadPackage(dir, skip string) (*PackageInfo, error) {
+	info := &PackageInfo{Types: make(map[string]bool), Validators: make(map[string]bool)}
+	files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+	skipAbs, _ := filepath.Abs(skip)
+
+	fset := token.NewFileSet()
+	for _, file := range files {
+		if strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+		if abs, _ := filepath.Abs(file); skip != "" && abs == skipAbs {
+			continue
+		}
+		parsed, err := parser.ParseFile(fset, file, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, err
+		}
+		for _, decl := range parsed.Decls {
+			switch decl := decl.(type) {
+			case *ast.GenDecl:
+				for _, spec := range decl.Specs {
+					if ts, ok := spec.(*ast.TypeSpec); ok {
+						info.Types[ts.Name.Name] = true
+					}
+				}
+			case *ast.FuncDecl:
+				if decl.Recv == nil || decl.Name.Name != "Validate" {
+					continue
+				}
+				recv := decl.Recv.List[0].Type
+				if star, ok := recv.(*ast.StarExpr); ok {
+					recv = star.X
+				}
+				if ident, ok := recv.(*ast.Ident); ok {
+					info.Validators[ident.Name] = true
+				}
+			}
+		}
+	}
+	return info, nil
+}
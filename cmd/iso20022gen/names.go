@@ -0,0 +1,283 @@
+package main
+
+import (
+	"strings"
+	"unicode"
+)
+
+// abbreviations expands the short element names used in ISO 20022 XML into the
+// field names used throughout the iso20022 package, e.g. CdtTrfTxInf becomes
+// CreditTransferTransactionInfo. Keys may span several name segments; the longest
+// match wins, so IntrBk becomes Interbank rather than InterBank.
+var abbreviations = map[string]string{
+	"Accptnc":  "Acceptance",
+	"Acct":     "Account",
+	"Addtl":    "Additional",
+	"Adjstmnt": "Adjustment",
+	"Adr":      "Address",
+	"Agt":      "Agent",
+	"Amt":      "Amount",
+	"Anncd":    "Announced",
+	"AnyBIC":   "AnyBankIdentifierCode",
+	"Apld":     "Applied",
+	"Assgne":   "Assignee",
+	"Assgnmt":  "Assignment",
+	"Assgnr":   "Assigner",
+	"Authrty":  "Authority",
+	"Authstn":  "Authorisation",
+	"Avlbty":   "Availability",
+	"BICFI":    "BankIdentifierCode",
+	"Bal":      "Balance",
+	"Biz":      "Business",
+	"Bk":       "Bank",
+	"Bldg":     "Building",
+	"Bookg":    "Booking",
+	"Br":       "Bearer",
+	"Brnch":    "Branch",
+	"Brth":     "Birth",
+	"Btch":     "Batch",
+	"Ccy":      "Currency",
+	"Cd":       "Code",
+	"Cdt":      "Credit",
+	"Cdtr":     "Creditor",
+	"Chanl":    "Channel",
+	"Chq":      "Cheque",
+	"Chrg":     "Charge",
+	"Chrgs":    "Charges",
+	"Clr":      "Clearing",
+	"Cntr":     "Counter",
+	"Conf":     "Confirmation",
+	"Cpy":      "Copy",
+	"Cre":      "Creation",
+	"Cretr":    "Creator",
+	"Crit":     "Criteria",
+	"Cstmr":    "Customer",
+	"Ctct":     "Contact",
+	"Ctgy":     "Category",
+	"Ctrl":     "Control",
+	"Ctry":     "Country",
+	"Cur":      "Current",
+	"Cxl":      "Cancellation",
+	"Dbt":      "Debit",
+	"Dbtr":     "Debtor",
+	"Dept":     "Department",
+	"Dflt":     "Default",
+	"Dlvrg":    "Delivering",
+	"Doc":      "Document",
+	"Dplct":    "Duplicate",
+	"Dscnt":    "Discount",
+	"Dstrct":   "District",
+	"Dt":       "Date",
+	"Dtls":     "Details",
+	"Dvsn":     "Division",
+	"Elctrnc":  "Electronic",
+	"Envlp":    "Envelope",
+	"Eqvt":     "Equivalent",
+	"Evt":      "Event",
+	"Fctv":     "Effective",
+	"Fin":      "Financial",
+	"Flr":      "Floor",
+	"Fmly":     "Family",
+	"Fr":       "From",
+	"Frmt":     "Format",
+	"Grnshmt":  "Garnishment",
+	"Grp":      "Group",
+	"Hdr":      "Header",
+	"Hstrc":    "Historic",
+	"Id":       "ID",
+	"Idr":      "Identifier",
+	"Ind":      "Indicator",
+	"Inf":      "Info",
+	"Instd":    "Instructed",
+	"Instg":    "Instructing",
+	"Instn":    "Institution",
+	"Instr":    "Instruction",
+	"Instrm":   "Instrument",
+	"IntrBk":   "Interbank",
+	"Intrmy":   "Intermediary",
+	"Intrst":   "Interest",
+	"Invcee":   "Invoicee",
+	"Invcr":    "Invoicer",
+	"Invstgtn": "Investigation",
+	"Issr":     "Issuer",
+	"LEI":      "LegalEntityIdentifier",
+	"Lang":     "Language",
+	"Lcl":      "Local",
+	"Lctn":     "Location",
+	"Lgl":      "Legal",
+	"Lmt":      "Limit",
+	"Lqdty":    "Liquidity",
+	"Lvl":      "Level",
+	"Mkt":      "Market",
+	"Mmb":      "Member",
+	"Mndt":     "Mandate",
+	"Mob":      "Mobile",
+	"Mod":      "Modification",
+	"Msg":      "Message",
+	"Mtd":      "Method",
+	"Nb":       "Number",
+	"Nm":       "Name",
+	"Ntfctn":   "Notification",
+	"Ntry":     "Entry",
+	"Org":      "Organization",
+	"Orgnl":    "Original",
+	"Orgtr":    "Originator",
+	"Othr":     "Other",
+	"Ownr":     "Owner",
+	"Pg":       "Page",
+	"Pgntn":    "Pagination",
+	"Phne":     "Phone",
+	"Plc":      "Place",
+	"Pmt":      "Payment",
+	"Prcg":     "Processing",
+	"Prctc":    "Practice",
+	"Prd":      "Period",
+	"Pric":     "Price",
+	"Prfx":     "Prefix",
+	"Prtry":    "Proprietary",
+	"Prty":     "Priority",
+	"Prvc":     "Province",
+	"Prvs":     "Previous",
+	"Prvt":     "Private",
+	"Pssbl":    "Possible",
+	"Pst":      "Post",
+	"Pstl":     "Postal",
+	"Pties":    "Parties",
+	"Pty":      "Party",
+	"Purp":     "Purpose",
+	"Pybl":     "Payable",
+	"Qry":      "Query",
+	"Qties":    "Quantities",
+	"Rcpt":     "Recipient",
+	"Rcrd":     "Record",
+	"Rcvg":     "Receiving",
+	"Ref":      "Reference",
+	"Refs":     "References",
+	"Req":      "Request",
+	"Rfrd":     "Referred",
+	"Rgltry":   "Regulatory",
+	"Rjct":     "Reject",
+	"Rjctd":    "Rejected",
+	"Rjctn":    "Rejection",
+	"Rltd":     "Related",
+	"Rmt":      "Remittance",
+	"Rmtd":     "Remitted",
+	"Rpt":      "Report",
+	"Rptg":     "Reporting",
+	"Rsltn":    "Resolution",
+	"Rsn":      "Reason",
+	"Rsvatn":   "Reservation",
+	"Rtr":      "Return",
+	"Rtrd":     "Returned",
+	"Rvsl":     "Reversal",
+	"Sch":      "Search",
+	"Schme":    "Scheme",
+	"Seq":      "Sequence",
+	"Sgntr":    "Signature",
+	"Splmtry":  "Supplementary",
+	"Stmt":     "Statement",
+	"Strd":     "Structured",
+	"Strt":     "Street",
+	"Sts":      "Status",
+	"Sttlm":    "Settlement",
+	"Svc":      "Service",
+	"Svcr":     "Servicer",
+	"Sys":      "System",
+	"Tm":       "Time",
+	"Tp":       "Type",
+	"Trf":      "Transfer",
+	"Trfd":     "Transferred",
+	"Ttl":      "Total",
+	"Twn":      "Town",
+	"Tx":       "Transaction",
+	"Txs":      "Transactions",
+	"Ultmt":    "Ultimate",
+	"Undrlyg":  "Underlying",
+	"Ustrd":    "Unstructured",
+	"Val":      "Value",
+	"Vrfctn":   "Verification",
+	"Wth":      "With",
+	"Wtht":     "Without",
+	"Xchg":     "Exchange",
+	"Yr":       "Year",
+}
+
+// maxAbbreviationSegments bounds the multi-segment lookups in fieldName.
+const maxAbbreviationSegments = 2
+
+// fieldName expands an XML element or attribute name into a Go field name.
+// Segments without an entry in abbreviations are kept as they are.
+func fieldName(tag string) string {
+	segments := splitName(tag)
+	var b strings.Builder
+	for i := 0; i < len(segments); {
+		n := min(maxAbbreviationSegments, len(segments)-i)
+		for ; n > 0; n-- {
+			if expanded, ok := abbreviations[strings.Join(segments[i:i+n], "")]; ok {
+				b.WriteString(expanded)
+				break
+			}
+		}
+		if n == 0 {
+			b.WriteString(segments[i])
+			n = 1
+		}
+		i += n
+	}
+	return b.String()
+}
+
+// splitName splits an ISO 20022 name into its capitalised segments and digit runs.
+// A run of capitals is an acronym, except that its last letter starts the next
+// segment when followed by a lower-case letter: FIToFICstmr splits into FI, To, FI
+// and Cstmr.
+func splitName(name string) []string {
+	runes := []rune(name)
+	var segments []string
+	start := 0
+	for i := 1; i < len(runes); i++ {
+		prev, cur := runes[i-1], runes[i]
+		boundary := false
+		switch {
+		case unicode.IsDigit(cur) != unicode.IsDigit(prev):
+			boundary = true
+		case unicode.IsUpper(cur) && unicode.IsLower(prev):
+			boundary = true
+		case unicode.IsUpper(cur) && unicode.IsUpper(prev) && i+1 < len(runes) && unicode.IsLower(runes[i+1]):
+			boundary = true
+		}
+		if boundary {
+			segments = append(segments, string(runes[start:i]))
+			start = i
+		}
+	}
+	if start < len(runes) {
+		segments = append(segments, string(runes[start:]))
+	}
+	return segments
+}
+
+// describe turns a type name into the short lower-case description used in the
+// package's type comments, e.g. GroupHeader113 becomes "Group header".
+func describe(typeName string) string {
+	segments := splitName(typeName)
+	var words []string
+	for i, segment := range segments {
+		if unicode.IsDigit([]rune(segment)[0]) {
+			continue
+		}
+		// The V of a message version suffix such as V06 is not a word.
+		if segment == "V" && i+1 < len(segments) && unicode.IsDigit([]rune(segments[i+1])[0]) {
+			continue
+		}
+		if len(words) > 0 && !isAcronym(segment) {
+			segment = strings.ToLower(segment)
+		}
+		words = append(words, segment)
+	}
+	return strings.Join(words, " ")
+}
+
+func isAcronym(segment string) bool {
+	return len(segment) > 1 && strings.ToUpper(segment) == segment
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"strings"
+)
+
+// Sequences emits the XSD sequences of the complex types reachable from the
+// message of s, in an init function registering them with the iso20022 package.
+// An Encoder created with WithSequenceCheck compares the field order of those
+// types against them, so that a struct drifting from its schema is caught before
+// a document fails validation downstream. Types declared in the target package are
+// included, since the check applies to every component a message uses.
+func Sequences(s *schema, opts Options) ([]byte, error) {
+	if opts.Package == "" {
+		opts.Package = "iso20022"
+	}
+	g := &generator{
+		opts:    opts,
+		complex: make(map[string]*complexType),
+		simple:  make(map[string]*simpleType),
+	}
+	for i := range s.ComplexTypes {
+		g.complex[s.ComplexTypes[i].Name] = &s.ComplexTypes[i]
+	}
+	for i := range s.SimpleTypes {
+		g.simple[s.SimpleTypes[i].Name] = &s.SimpleTypes[i]
+	}
+	var root *element
+	for i := range s.Elements {
+		if s.Elements[i].Name == "Document" {
+			root = &s.Elements[i]
+		}
+	}
+	if root == nil {
+		return nil, fmt.Errorf("schema %s has no Document element", s.TargetNamespace)
+	}
+
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "// Code generated by iso20022gen -sequences from %s. DO NOT EDIT.\n\n", opts.Source)
+	fmt.Fprintf(&out, "package %s\n\nfunc init() {\n\tregisterSequences(map[string][]string{\n", opts.Package)
+	seen := map[string]bool{localName(root.Type): true}
+	queue := []string{localName(root.Type)}
+	for len(queue) > 0 {
+		ct := g.complex[queue[0]]
+		queue = queue[1:]
+		fields, err := g.fields(ct)
+		if err != nil {
+			return nil, err
+		}
+		for _, f := range fields {
+			if f.Complex && !seen[f.XSDType] {
+				seen[f.XSDType] = true
+				queue = append(queue, f.XSDType)
+			}
+		}
+		if ct.Sequence == nil || ct.Name == localName(root.Type) {
+			continue
+		}
+		var tags []string
+		for _, f := range fields {
+			if f.Tag != "" {
+				tags = append(tags, fmt.Sprintf("%q", f.Tag))
+			}
+		}
+		if len(tags) > 0 {
+			fmt.Fprintf(&out, "\t\t%q: {%s},\n", ct.Name, strings.Join(tags, ", "))
+		}
+	}
+	out.WriteString("\t})\n}\n")
+
+	src, err := format.Source(out.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting generated code: %w", err)
+	}
+	return src, nil
+}
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// schema is the subset of XML Schema used by the ISO 20022 message XSDs: a single
+// Document element, named complex types built from sequences and choices, and named
+// simple types restricted by facets. Element names carry no namespace so that both
+// xs: and xsd: prefixes decode.
+type schema struct {
+	TargetNamespace string        `xml:"targetNamespace,attr"`
+	Elements        []element     `xml:"element"`
+	ComplexTypes    []complexType `xml:"complexType"`
+	SimpleTypes     []simpleType  `xml:"simpleType"`
+}
+
+type element struct {
+	Name          string `xml:"name,attr"`
+	Type          string `xml:"type,attr"`
+	MinOccurs     string `xml:"minOccurs,attr"`
+	MaxOccurs     string `xml:"maxOccurs,attr"`
+	Documentation string `xml:"annotation>documentation"`
+}
+
+type complexType struct {
+	Name          string         `xml:"name,attr"`
+	Documentation string         `xml:"annotation>documentation"`
+	Sequence      *group         `xml:"sequence"`
+	Choice        *group         `xml:"choice"`
+	SimpleContent *simpleContent `xml:"simpleContent"`
+}
+
+type simpleContent struct {
+	Extension struct {
+		Base       string      `xml:"base,attr"`
+		Attributes []attribute `xml:"attribute"`
+	} `xml:"extension"`
+}
+
+type attribute struct {
+	Name string `xml:"name,attr"`
+	Type string `xml:"type,attr"`
+	Use  string `xml:"use,attr"`
+}
+
+type simpleType struct {
+	Name          string      `xml:"name,attr"`
+	Documentation string      `xml:"annotation>documentation"`
+	Restriction   restriction `xml:"restriction"`
+}
+
+type restriction struct {
+	Base         string  `xml:"base,attr"`
+	Length       *facet  `xml:"length"`
+	MinLength    *facet  `xml:"minLength"`
+	MaxLength    *facet  `xml:"maxLength"`
+	Patterns     []facet `xml:"pattern"`
+	Enumerations []facet `xml:"enumeration"`
+}
+
+type facet struct {
+	Value string `xml:"value,attr"`
+}
+
+// particle is one entry of a sequence or choice, kept in document order: an
+// element, a nested group, or a wildcard.
+type particle struct {
+	Element  *element
+	Sequence *group
+	Choice   *group
+	Any      bool
+}
+
+// group is an xs:sequence or xs:choice. It decodes its children by hand because
+// encoding/xml cannot keep the relative order of differently named children.
+type group struct {
+	MinOccurs string
+	MaxOccurs string
+	Particles []particle
+}
+
+func (g *group) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	for _, attr := range start.Attr {
+		switch attr.Name.Local {
+		case "minOccurs":
+			g.MinOccurs = attr.Value
+		case "maxOccurs":
+			g.MaxOccurs = attr.Value
+		}
+	}
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return err
+		}
+		switch tok := tok.(type) {
+		case xml.StartElement:
+			switch tok.Name.Local {
+			case "element":
+				var e element
+				if err := d.DecodeElement(&e, &tok); err != nil {
+					return err
+				}
+				g.Particles = append(g.Particles, particle{Element: &e})
+			case "sequence", "choice":
+				nested := new(group)
+				if err := d.DecodeElement(nested, &tok); err != nil {
+					return err
+				}
+				if tok.Name.Local == "sequence" {
+					g.Particles = append(g.Particles, particle{Sequence: nested})
+				} else {
+					g.Particles = append(g.Particles, particle{Choice: nested})
+				}
+			case "any":
+				if err := d.Skip(); err != nil {
+					return err
+				}
+				g.Particles = append(g.Particles, particle{Any: true})
+			default:
+				if err := d.Skip(); err != nil {
+					return err
+				}
+			}
+		case xml.EndElement:
+			return nil
+		}
+	}
+}
+
+// parseSchema decodes an XSD document.
+func parseSchema(r io.Reader) (*schema, error) {
+	var s schema
+	if err := xml.NewDecoder(r).Decode(&s); err != nil {
+		return nil, fmt.Errorf("parsing schema: %w", err)
+	}
+	return &s, nil
+}
+
+// occurs converts minOccurs/maxOccurs attribute values, where an empty value means
+// the XSD default of 1 and "unbounded" is reported as -1.
+func occurs(value string) (int, error) {
+	switch value {
+	case "":
+		return 1, nil
+	case "unbounded":
+		return -1, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid occurrence %q", value)
+	}
+	return n, nil
+}
+
+// localName strips the namespace prefix from a QName such as xs:string.
+func localName(qname string) string {
+	if i := strings.LastIndexByte(qname, ':'); i >= 0 {
+		return qname[i+1:]
+	}
+	return qname
+}
+
+// isBuiltin reports whether a QName refers to an XML Schema built-in type.
+func isBuiltin(qname string) bool {
+	return strings.HasPrefix(qname, "xs:") || strings.HasPrefix(qname, "xsd:")
+}
@@ -0,0 +1,97 @@
+package iso20022
+
+// ChargeBearerType1Code is the party bearing the charges of a payment (ChrgBr)
+type ChargeBearerType1Code string
+
+// Charge bearers
+const (
+	ChargeBearerDEBT ChargeBearerType1Code = "DEBT" // borne by the debtor
+	ChargeBearerCRED ChargeBearerType1Code = "CRED" // borne by the creditor
+	ChargeBearerSHAR ChargeBearerType1Code = "SHAR" // shared: each party bears the charges of its own agent
+	ChargeBearerSLEV ChargeBearerType1Code = "SLEV" // following the service level
+)
+
+// Valid reports whether c is one of the charge bearers
+func (c ChargeBearerType1Code) Valid() bool {
+	return validCode(c, ChargeBearerDEBT, ChargeBearerCRED, ChargeBearerSHAR, ChargeBearerSLEV)
+}
+
+// SettlementMethod1Code is how the instructing and instructed agents settle
+// (SttlmMtd)
+type SettlementMethod1Code string
+
+// Settlement methods
+const (
+	SettlementMethodINDA SettlementMethod1Code = "INDA" // the instructed agent debits an account of the instructing agent
+	SettlementMethodINGA SettlementMethod1Code = "INGA" // the instructing agent credits an account of the instructed agent
+	SettlementMethodCOVE SettlementMethod1Code = "COVE" // through a cover payment
+	SettlementMethodCLRG SettlementMethod1Code = "CLRG" // through a clearing system
+)
+
+// Valid reports whether c is one of the settlement methods
+func (c SettlementMethod1Code) Valid() bool {
+	return validCode(c, SettlementMethodINDA, SettlementMethodINGA, SettlementMethodCOVE, SettlementMethodCLRG)
+}
+
+// CreditDebitCode is the side of an amount or an entry (CdtDbtInd)
+type CreditDebitCode string
+
+// Credit debit indicators
+const (
+	CreditDebitCRDT CreditDebitCode = "CRDT"
+	CreditDebitDBIT CreditDebitCode = "DBIT"
+)
+
+// Valid reports whether c is CRDT or DBIT
+func (c CreditDebitCode) Valid() bool {
+	return validCode(c, CreditDebitCRDT, CreditDebitDBIT)
+}
+
+// Priority2Code is the processing priority of an instruction (InstrPrty)
+type Priority2Code string
+
+// Instruction priorities
+const (
+	PriorityHIGH Priority2Code = "HIGH"
+	PriorityNORM Priority2Code = "NORM"
+)
+
+// Valid reports whether c is HIGH or NORM
+func (c Priority2Code) Valid() bool {
+	return validCode(c, PriorityHIGH, PriorityNORM)
+}
+
+// ClearingChannel2Code is the channel through which a payment is cleared
+// (ClrChanl)
+type ClearingChannel2Code string
+
+// Clearing channels
+const (
+	ClearingChannelRTGS ClearingChannel2Code = "RTGS" // real-time gross settlement system
+	ClearingChannelRTNS ClearingChannel2Code = "RTNS" // real-time net settlement system
+	ClearingChannelMPNS ClearingChannel2Code = "MPNS" // mass payment net settlement system
+	ClearingChannelBOOK ClearingChannel2Code = "BOOK" // book transfer
+)
+
+// Valid reports whether c is one of the clearing channels
+func (c ClearingChannel2Code) Valid() bool {
+	return validCode(c, ClearingChannelRTGS, ClearingChannelRTNS, ClearingChannelMPNS, ClearingChannelBOOK)
+}
+
+// validCode reports whether c is one of codes
+func validCode[T ~string](c T, codes ...T) bool {
+	for _, code := range codes {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
+
+// validateCode returns a code validation error for field when c is not valid
+func validateCode(c interface{ Valid() bool }, value, field string) error {
+	if !c.Valid() {
+		return newValidationError(field, RuleCode, "CODE", value)
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package iso20022
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+)
+
+func TestCodes(t *testing.T) {
+	if !ChargeBearerSLEV.Valid() || ChargeBearerType1Code("XXXX").Valid() {
+		t.Error("Unexpected charge bearer validity")
+	}
+	if !SettlementMethodCOVE.Valid() || SettlementMethod1Code("").Valid() {
+		t.Error("Unexpected settlement method validity")
+	}
+	if !CreditDebitDBIT.Valid() || CreditDebitCode("DEBT").Valid() {
+		t.Error("Unexpected credit debit validity")
+	}
+	if !PriorityNORM.Valid() || Priority2Code("URGT").Valid() || !ClearingChannelRTGS.Valid() {
+		t.Error("Unexpected priority or clearing channel validity")
+	}
+
+	doc := loadPacs008Sample(t)
+	tx := &doc.FICustomerCreditTransfer.CreditTransferTransactionInfo[0]
+	if !tx.ChargeBearer.Valid() {
+		t.Errorf("Unexpected charge bearer %q", tx.ChargeBearer)
+	}
+	out, err := xml.Marshal(tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(out), "<ChrgBr>"+string(tx.ChargeBearer)+"</ChrgBr>") {
+		t.Errorf("Expected the charge bearer to be written as text, got %s", out)
+	}
+
+	doc.FICustomerCreditTransfer.GroupHeader.SettlementInfo.SettlementMethod = "XXXX"
+	if err := doc.Validate(); err == nil || !strings.Contains(err.Error(), "SttlmMtd") {
+		t.Errorf("Expected an invalid settlement method to fail, got %v", err)
+	}
+}
+
+func TestCreditDebitValidation(t *testing.T) {
+	doc := loadSample[Camt05200108Document](t, "camt.052.001.08/account_report.xml")
+	if err := doc.Validate(); err != nil {
+		t.Fatalf("Expected the sample to validate, got %v", err)
+	}
+
+	rpt := &doc.BankAccountReport.Report[0]
+	rpt.Balance[0].CreditDebitIndicator = "CRED"
+	rpt.Entry[0].CreditDebitIndicator = "DEBT"
+	err := doc.Validate()
+	for _, path := range []string{"BkToCstmrAcctRpt/Rpt[1]/Bal[1]/CdtDbtInd", "BkToCstmrAcctRpt/Rpt[1]/Ntry[1]/CdtDbtInd"} {
+		if err == nil || !strings.Contains(err.Error(), path) {
+			t.Errorf("Expected an invalid indicator at %s, got %v", path, err)
+		}
+	}
+}
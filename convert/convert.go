@@ -0,0 +1,223 @@
+// Package convert converts pacs.008 messages between adjacent versions of the
+// message, so that corridors whose members send different versions can
+// interoperate.
+//
+// Each step, such as V02ToV06 or V08ToV06, converts to the next version up or
+// down. Unlike iso20022.ConvertPacs008, which refuses to lose anything, a step
+// drops the elements the target version does not have and reports each of them as
+// a Dropped warning, as it does the elements of the source message that were not
+// decoded. It reports as Renamed the elements that are written under another name
+// in the target version, such as the BIC of an agent, which is BICFI from
+// pacs.008.001.06. Pacs008 chains the steps to reach any version.
+package convert
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/ckbaum/iso20022-go"
+)
+
+// WarningKind tells what a conversion did to an element
+type WarningKind int
+
+// Kinds of warnings
+const (
+	// Dropped is an element left out because the target version does not have it
+	Dropped WarningKind = iota
+	// Renamed is an element written under another name in the target version
+	Renamed
+)
+
+// Warning is an element of the source message that the target version does not
+// carry as it is
+type Warning struct {
+	Kind    WarningKind
+	Path    string // path of the element in the source message, such as FIToFICstmrCdtTrf/CdtTrfTxInf[1]/PmtId/UETR
+	Version string // version converted to
+	Message string
+}
+
+// String returns the warning as a line to log
+func (w Warning) String() string {
+	return w.Version + ": " + w.Path + ": " + w.Message
+}
+
+// V02ToV06 converts a pacs.008.001.02 to pacs.008.001.06
+func V02ToV06(doc *iso20022.Pacs00800102Document) (*iso20022.Pacs00800106Document, []Warning, error) {
+	return step(doc, new(iso20022.Pacs00800106Document))
+}
+
+// V06ToV08 converts a pacs.008.001.06 to pacs.008.001.08
+func V06ToV08(doc *iso20022.Pacs00800106Document) (*iso20022.Pacs00800108Document, []Warning, error) {
+	return step(doc, new(iso20022.Pacs00800108Document))
+}
+
+// V08ToV10 converts a pacs.008.001.08 to pacs.008.001.10
+func V08ToV10(doc *iso20022.Pacs00800108Document) (*iso20022.Pacs00800110Document, []Warning, error) {
+	return step(doc, new(iso20022.Pacs00800110Document))
+}
+
+// V10ToV12 converts a pacs.008.001.10 to pacs.008.001.12
+func V10ToV12(doc *iso20022.Pacs00800110Document) (*iso20022.Pacs00800112Document, []Warning, error) {
+	return step(doc, new(iso20022.Pacs00800112Document))
+}
+
+// V12ToV10 converts a pacs.008.001.12 to pacs.008.001.10
+func V12ToV10(doc *iso20022.Pacs00800112Document) (*iso20022.Pacs00800110Document, []Warning, error) {
+	return step(doc, new(iso20022.Pacs00800110Document))
+}
+
+// V10ToV08 converts a pacs.008.001.10 to pacs.008.001.08
+func V10ToV08(doc *iso20022.Pacs00800110Document) (*iso20022.Pacs00800108Document, []Warning, error) {
+	return step(doc, new(iso20022.Pacs00800108Document))
+}
+
+// V08ToV06 converts a pacs.008.001.08 to pacs.008.001.06, dropping the UETR and
+// the LEI of the agents among others
+func V08ToV06(doc *iso20022.Pacs00800108Document) (*iso20022.Pacs00800106Document, []Warning, error) {
+	return step(doc, new(iso20022.Pacs00800106Document))
+}
+
+// V06ToV02 converts a pacs.008.001.06 to pacs.008.001.02
+func V06ToV02(doc *iso20022.Pacs00800106Document) (*iso20022.Pacs00800102Document, []Warning, error) {
+	return step(doc, new(iso20022.Pacs00800102Document))
+}
+
+// Pacs008 converts doc to version, one of iso20022.Pacs008Versions, going
+// through the versions in between. The warnings of every step are returned in
+// order.
+func Pacs008(doc iso20022.Pacs008, version string) (iso20022.Pacs008, []Warning, error) {
+	from, to := versionIndex(doc.Version()), versionIndex(version)
+	if from < 0 || to < 0 {
+		return nil, nil, fmt.Errorf("%w: cannot convert %s to %s", iso20022.ErrUnknownMessage, doc.Version(), version)
+	}
+	var warnings []Warning
+	for from != to {
+		next := from + 1
+		if to < from {
+			next = from - 1
+		}
+		target, _ := iso20022.NewDocument(iso20022.Pacs008Versions[next])
+		converted, stepWarnings, err := step(doc, target.(iso20022.Pacs008))
+		if err != nil {
+			return nil, warnings, err
+		}
+		doc, from = converted, next
+		warnings = append(warnings, stepWarnings...)
+	}
+	return doc, warnings, nil
+}
+
+// versionIndex returns the position of version in iso20022.Pacs008Versions, or -1
+func versionIndex(version string) int {
+	for i, v := range iso20022.Pacs008Versions {
+		if v == version {
+			return i
+		}
+	}
+	return -1
+}
+
+// step converts doc to the version of target, leaving out what target cannot carry
+func step[T iso20022.Pacs008](doc iso20022.Pacs008, target T) (T, []Warning, error) {
+	var zero T
+	version := target.Version()
+	var warnings []Warning
+	warn := func(kind WarningKind, path, format string, args ...interface{}) {
+		warnings = append(warnings, Warning{Kind: kind, Path: path, Version: version, Message: fmt.Sprintf(format, args...)})
+	}
+	if u, ok := doc.(interface{ Unmodelled() []string }); ok {
+		for _, path := range u.Unmodelled() {
+			warn(Dropped, path, "%s was not decoded", path[strings.LastIndexByte(path, '/')+1:])
+		}
+	}
+	src := reflect.ValueOf(doc).Elem()
+	pruned := reflect.New(src.Type())
+	pruned.Elem().Set(prune(reflect.TypeOf(target).Elem(), src, "", warn))
+	converted, err := iso20022.ConvertPacs008(pruned.Interface().(iso20022.Pacs008), version)
+	if err != nil {
+		return zero, nil, err
+	}
+	return converted.(T), warnings, nil
+}
+
+// prune returns a copy of src without the elements the type dst has no field for,
+// matching fields by name as iso20022.ConvertPacs008 does, and without the
+// repetitions of a list dst has a single value for
+func prune(dst reflect.Type, src reflect.Value, path string, warn func(kind WarningKind, path, format string, args ...interface{})) reflect.Value {
+	for dst.Kind() == reflect.Ptr || dst.Kind() == reflect.Slice && src.Kind() != reflect.Slice && src.Kind() != reflect.Ptr {
+		dst = dst.Elem()
+	}
+	if dst == src.Type() {
+		return src
+	}
+	switch src.Kind() {
+	case reflect.Ptr:
+		if src.IsNil() {
+			return src
+		}
+		out := reflect.New(src.Type().Elem())
+		out.Elem().Set(prune(dst, src.Elem(), path, warn))
+		return out
+	case reflect.Struct:
+		if dst.Kind() != reflect.Struct {
+			return src
+		}
+		out := reflect.New(src.Type()).Elem()
+		for i := 0; i < src.NumField(); i++ {
+			field := src.Type().Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			if field.Name == "XMLName" {
+				out.Field(i).Set(src.Field(i))
+				continue
+			}
+			name := xmlName(field)
+			fieldPath := name
+			if path != "" && name != "" {
+				fieldPath = path + "/" + name
+			}
+			target, ok := dst.FieldByName(field.Name)
+			empty := src.Field(i).IsZero()
+			if !ok {
+				if !empty {
+					warn(Dropped, fieldPath, "%s is not in this version", name)
+				}
+				continue
+			}
+			if targetName := xmlName(target); !empty && targetName != name && targetName != "" && name != "" {
+				warn(Renamed, fieldPath, "%s is written as %s", name, targetName)
+			}
+			out.Field(i).Set(prune(target.Type, src.Field(i), fieldPath, warn))
+		}
+		return out
+	case reflect.Slice:
+		if src.Len() == 0 {
+			return src
+		}
+		n, elem := src.Len(), dst
+		if dst.Kind() == reflect.Slice {
+			elem = dst.Elem()
+		} else if n > 1 {
+			for i := 1; i < n; i++ {
+				warn(Dropped, fmt.Sprintf("%s[%d]", path, i+1), "this version has a single %s", path[strings.LastIndexByte(path, '/')+1:])
+			}
+			n = 1
+		}
+		out := reflect.MakeSlice(src.Type(), n, n)
+		for i := 0; i < n; i++ {
+			out.Index(i).Set(prune(elem, src.Index(i), fmt.Sprintf("%s[%d]", path, i+1), warn))
+		}
+		return out
+	}
+	return src
+}
+
+// xmlName returns the element name of a field
+func xmlName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("xml"), ",")
+	return name
+}
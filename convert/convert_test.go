@@ -0,0 +1,118 @@
+package convert
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ckbaum/iso20022-go"
+)
+
+func loadPacs008(t *testing.T) *iso20022.Pacs00800108Document {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("..", "testdata", "roundtrip", "pacs.008.001.08", "customer_credit_transfer.xml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc := new(iso20022.Pacs00800108Document)
+	if err := iso20022.Unmarshal(data, doc); err != nil {
+		t.Fatal(err)
+	}
+	return doc
+}
+
+func find(warnings []Warning, kind WarningKind, path string) bool {
+	for _, w := range warnings {
+		if w.Kind == kind && w.Path == path {
+			return true
+		}
+	}
+	return false
+}
+
+func TestDowngrade(t *testing.T) {
+	doc := loadPacs008(t)
+	uetr := "FIToFICstmrCdtTrf/CdtTrfTxInf[1]/PmtId/UETR"
+	if _, err := iso20022.ConvertPacs008(doc, "pacs.008.001.06"); !errors.Is(err, iso20022.ErrVersionLoss) {
+		t.Fatalf("Expected the lossless conversion to refuse the UETR, got %v", err)
+	}
+
+	v06, warnings, err := V08ToV06(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !find(warnings, Dropped, uetr) {
+		t.Errorf("Expected the UETR to be dropped, got %v", warnings)
+	}
+	if v06.MessageID() != "BBBBUS33-20240315-0001" || v06.Transactions()[0].UETR != "" || v06.Transactions()[0].EndToEndID != "INV-2024-0042" {
+		t.Errorf("Expected the message without its UETR, got %+v", v06.Transactions()[0])
+	}
+	if doc.Transactions()[0].UETR == "" {
+		t.Error("Expected the original to keep its UETR")
+	}
+
+	v02, warnings, err := V06ToV02(v06)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !find(warnings, Renamed, "FIToFICstmrCdtTrf/CdtTrfTxInf[1]/InstgAgt/FinInstnId/BICFI") {
+		t.Errorf("Expected the BICFI of the instructing agent to be renamed, got %v", warnings)
+	}
+	if err := v02.Validate(); err != nil {
+		t.Errorf("Expected a valid pacs.008.001.02, got %v", err)
+	}
+	for _, w := range warnings {
+		if w.Version != "pacs.008.001.02" || w.String() == "" {
+			t.Errorf("Unexpected warning %+v", w)
+		}
+	}
+}
+
+func TestPacs008(t *testing.T) {
+	doc := loadPacs008(t)
+	v02, warnings, err := Pacs008(doc, "pacs.008.001.02")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v02.Version() != "pacs.008.001.02" || len(warnings) == 0 || warnings[0].Version != "pacs.008.001.06" {
+		t.Errorf("Expected a conversion through pacs.008.001.06, got %s, %v", v02.Version(), warnings)
+	}
+
+	v12, warnings, err := Pacs008(v02, "pacs.008.001.12")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v12.Version() != "pacs.008.001.12" || v12.Transactions()[0].CreditorAccount != "GB29NWBK60161331926819" {
+		t.Errorf("Expected the upgraded message, got %+v", v12.Transactions()[0])
+	}
+	for _, w := range warnings {
+		if w.Kind == Dropped {
+			t.Errorf("Expected an upgrade to lose nothing, got %v", w)
+		}
+	}
+
+	same, warnings, err := Pacs008(doc, "pacs.008.001.08")
+	if err != nil || same != iso20022.Pacs008(doc) || warnings != nil {
+		t.Errorf("Expected the message itself, got %v, %v", warnings, err)
+	}
+	if _, _, err := Pacs008(doc, "pacs.009.001.08"); !errors.Is(err, iso20022.ErrUnknownMessage) {
+		t.Errorf("Expected an unknown version to fail, got %v", err)
+	}
+}
+
+func TestUnmodelled(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("..", "testdata", "roundtrip", "pacs.008.001.10", "customer_credit_transfer.xml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	data = bytes.Replace(data, []byte("<StrtNm>"), []byte("<CareOf>Accounts Payable</CareOf><StrtNm>"), 1)
+	doc := new(iso20022.Pacs00800110Document)
+	if err := iso20022.Unmarshal(data, doc); err != nil {
+		t.Fatal(err)
+	}
+	if _, warnings, err := V10ToV12(doc); err != nil || !find(warnings, Dropped, doc.Unmodelled()[0]) {
+		t.Errorf("Expected CareOf to be dropped, got %v, %v", warnings, err)
+	}
+}
@@ -0,0 +1,272 @@
+// Package currency is the ISO 4217 table of currency codes, with the numeric code,
+// the minor unit and whether each currency is still active, as used to check the
+// amounts of ISO 20022 messages.
+//
+// The table holds the active currencies and the currencies withdrawn since the
+// introduction of the euro. Funds and precious metals without a minor unit, such as
+// XAU, have a MinorUnits of -1.
+package currency
+
+// Currency is an entry of the ISO 4217 table
+type Currency struct {
+	// Code is the alphabetic code, such as EUR
+	Code string
+	// Numeric is the three-digit numeric code, such as 978
+	Numeric string
+	// MinorUnits is the number of decimal places of the currency, -1 when it has
+	// no minor unit
+	MinorUnits int
+	// Historic currencies have been withdrawn and are only accepted where ISO
+	// 20022 allows historic currencies
+	Historic bool
+}
+
+// Currencies is the table, sorted by code
+var Currencies = []Currency{
+	{Code: "AED", Numeric: "784", MinorUnits: 2},
+	{Code: "AFN", Numeric: "971", MinorUnits: 2},
+	{Code: "ALL", Numeric: "008", MinorUnits: 2},
+	{Code: "AMD", Numeric: "051", MinorUnits: 2},
+	{Code: "ANG", Numeric: "532", MinorUnits: 2, Historic: true},
+	{Code: "AOA", Numeric: "973", MinorUnits: 2},
+	{Code: "ARS", Numeric: "032", MinorUnits: 2},
+	{Code: "ATS", Numeric: "040", MinorUnits: 2, Historic: true},
+	{Code: "AUD", Numeric: "036", MinorUnits: 2},
+	{Code: "AWG", Numeric: "533", MinorUnits: 2},
+	{Code: "AZN", Numeric: "944", MinorUnits: 2},
+	{Code: "BAM", Numeric: "977", MinorUnits: 2},
+	{Code: "BBD", Numeric: "052", MinorUnits: 2},
+	{Code: "BDT", Numeric: "050", MinorUnits: 2},
+	{Code: "BEF", Numeric: "056", MinorUnits: 0, Historic: true},
+	{Code: "BGN", Numeric: "975", MinorUnits: 2},
+	{Code: "BHD", Numeric: "048", MinorUnits: 3},
+	{Code: "BIF", Numeric: "108", MinorUnits: 0},
+	{Code: "BMD", Numeric: "060", MinorUnits: 2},
+	{Code: "BND", Numeric: "096", MinorUnits: 2},
+	{Code: "BOB", Numeric: "068", MinorUnits: 2},
+	{Code: "BOV", Numeric: "984", MinorUnits: 2},
+	{Code: "BRL", Numeric: "986", MinorUnits: 2},
+	{Code: "BSD", Numeric: "044", MinorUnits: 2},
+	{Code: "BTN", Numeric: "064", MinorUnits: 2},
+	{Code: "BWP", Numeric: "072", MinorUnits: 2},
+	{Code: "BYN", Numeric: "933", MinorUnits: 2},
+	{Code: "BYR", Numeric: "974", MinorUnits: 0, Historic: true},
+	{Code: "BZD", Numeric: "084", MinorUnits: 2},
+	{Code: "CAD", Numeric: "124", MinorUnits: 2},
+	{Code: "CDF", Numeric: "976", MinorUnits: 2},
+	{Code: "CHE", Numeric: "947", MinorUnits: 2},
+	{Code: "CHF", Numeric: "756", MinorUnits: 2},
+	{Code: "CHW", Numeric: "948", MinorUnits: 2},
+	{Code: "CLF", Numeric: "990", MinorUnits: 4},
+	{Code: "CLP", Numeric: "152", MinorUnits: 0},
+	{Code: "CNY", Numeric: "156", MinorUnits: 2},
+	{Code: "COP", Numeric: "170", MinorUnits: 2},
+	{Code: "COU", Numeric: "970", MinorUnits: 2},
+	{Code: "CRC", Numeric: "188", MinorUnits: 2},
+	{Code: "CSD", Numeric: "891", MinorUnits: 2, Historic: true},
+	{Code: "CUC", Numeric: "931", MinorUnits: 2},
+	{Code: "CUP", Numeric: "192", MinorUnits: 2},
+	{Code: "CVE", Numeric: "132", MinorUnits: 2},
+	{Code: "CYP", Numeric: "196", MinorUnits: 2, Historic: true},
+	{Code: "CZK", Numeric: "203", MinorUnits: 2},
+	{Code: "DEM", Numeric: "276", MinorUnits: 2, Historic: true},
+	{Code: "DJF", Numeric: "262", MinorUnits: 0},
+	{Code: "DKK", Numeric: "208", MinorUnits: 2},
+	{Code: "DOP", Numeric: "214", MinorUnits: 2},
+	{Code: "DZD", Numeric: "012", MinorUnits: 2},
+	{Code: "EEK", Numeric: "233", MinorUnits: 2, Historic: true},
+	{Code: "EGP", Numeric: "818", MinorUnits: 2},
+	{Code: "ERN", Numeric: "232", MinorUnits: 2},
+	{Code: "ESP", Numeric: "724", MinorUnits: 0, Historic: true},
+	{Code: "ETB", Numeric: "230", MinorUnits: 2},
+	{Code: "EUR", Numeric: "978", MinorUnits: 2},
+	{Code: "FIM", Numeric: "246", MinorUnits: 2, Historic: true},
+	{Code: "FJD", Numeric: "242", MinorUnits: 2},
+	{Code: "FKP", Numeric: "238", MinorUnits: 2},
+	{Code: "FRF", Numeric: "250", MinorUnits: 2, Historic: true},
+	{Code: "GBP", Numeric: "826", MinorUnits: 2},
+	{Code: "GEL", Numeric: "981", MinorUnits: 2},
+	{Code: "GHC", Numeric: "288", MinorUnits: 2, Historic: true},
+	{Code: "GHS", Numeric: "936", MinorUnits: 2},
+	{Code: "GIP", Numeric: "292", MinorUnits: 2},
+	{Code: "GMD", Numeric: "270", MinorUnits: 2},
+	{Code: "GNF", Numeric: "324", MinorUnits: 0},
+	{Code: "GRD", Numeric: "300", MinorUnits: 0, Historic: true},
+	{Code: "GTQ", Numeric: "320", MinorUnits: 2},
+	{Code: "GYD", Numeric: "328", MinorUnits: 2},
+	{Code: "HKD", Numeric: "344", MinorUnits: 2},
+	{Code: "HNL", Numeric: "340", MinorUnits: 2},
+	{Code: "HRK", Numeric: "191", MinorUnits: 2, Historic: true},
+	{Code: "HTG", Numeric: "332", MinorUnits: 2},
+	{Code: "HUF", Numeric: "348", MinorUnits: 2},
+	{Code: "IDR", Numeric: "360", MinorUnits: 2},
+	{Code: "IEP", Numeric: "372", MinorUnits: 2, Historic: true},
+	{Code: "ILS", Numeric: "376", MinorUnits: 2},
+	{Code: "INR", Numeric: "356", MinorUnits: 2},
+	{Code: "IQD", Numeric: "368", MinorUnits: 3},
+	{Code: "IRR", Numeric: "364", MinorUnits: 2},
+	{Code: "ISK", Numeric: "352", MinorUnits: 0},
+	{Code: "ITL", Numeric: "380", MinorUnits: 0, Historic: true},
+	{Code: "JMD", Numeric: "388", MinorUnits: 2},
+	{Code: "JOD", Numeric: "400", MinorUnits: 3},
+	{Code: "JPY", Numeric: "392", MinorUnits: 0},
+	{Code: "KES", Numeric: "404", MinorUnits: 2},
+	{Code: "KGS", Numeric: "417", MinorUnits: 2},
+	{Code: "KHR", Numeric: "116", MinorUnits: 2},
+	{Code: "KMF", Numeric: "174", MinorUnits: 0},
+	{Code: "KPW", Numeric: "408", MinorUnits: 2},
+	{Code: "KRW", Numeric: "410", MinorUnits: 0},
+	{Code: "KWD", Numeric: "414", MinorUnits: 3},
+	{Code: "KYD", Numeric: "136", MinorUnits: 2},
+	{Code: "KZT", Numeric: "398", MinorUnits: 2},
+	{Code: "LAK", Numeric: "418", MinorUnits: 2},
+	{Code: "LBP", Numeric: "422", MinorUnits: 2},
+	{Code: "LKR", Numeric: "144", MinorUnits: 2},
+	{Code: "LRD", Numeric: "430", MinorUnits: 2},
+	{Code: "LSL", Numeric: "426", MinorUnits: 2},
+	{Code: "LTL", Numeric: "440", MinorUnits: 2, Historic: true},
+	{Code: "LUF", Numeric: "442", MinorUnits: 0, Historic: true},
+	{Code: "LVL", Numeric: "428", MinorUnits: 2, Historic: true},
+	{Code: "LYD", Numeric: "434", MinorUnits: 3},
+	{Code: "MAD", Numeric: "504", MinorUnits: 2},
+	{Code: "MDL", Numeric: "498", MinorUnits: 2},
+	{Code: "MGA", Numeric: "969", MinorUnits: 2},
+	{Code: "MKD", Numeric: "807", MinorUnits: 2},
+	{Code: "MMK", Numeric: "104", MinorUnits: 2},
+	{Code: "MNT", Numeric: "496", MinorUnits: 2},
+	{Code: "MOP", Numeric: "446", MinorUnits: 2},
+	{Code: "MRO", Numeric: "478", MinorUnits: 2, Historic: true},
+	{Code: "MRU", Numeric: "929", MinorUnits: 2},
+	{Code: "MTL", Numeric: "470", MinorUnits: 2, Historic: true},
+	{Code: "MUR", Numeric: "480", MinorUnits: 2},
+	{Code: "MVR", Numeric: "462", MinorUnits: 2},
+	{Code: "MWK", Numeric: "454", MinorUnits: 2},
+	{Code: "MXN", Numeric: "484", MinorUnits: 2},
+	{Code: "MXV", Numeric: "979", MinorUnits: 2},
+	{Code: "MYR", Numeric: "458", MinorUnits: 2},
+	{Code: "MZM", Numeric: "508", MinorUnits: 2, Historic: true},
+	{Code: "MZN", Numeric: "943", MinorUnits: 2},
+	{Code: "NAD", Numeric: "516", MinorUnits: 2},
+	{Code: "NGN", Numeric: "566", MinorUnits: 2},
+	{Code: "NIO", Numeric: "558", MinorUnits: 2},
+	{Code: "NLG", Numeric: "528", MinorUnits: 2, Historic: true},
+	{Code: "NOK", Numeric: "578", MinorUnits: 2},
+	{Code: "NPR", Numeric: "524", MinorUnits: 2},
+	{Code: "NZD", Numeric: "554", MinorUnits: 2},
+	{Code: "OMR", Numeric: "512", MinorUnits: 3},
+	{Code: "PAB", Numeric: "590", MinorUnits: 2},
+	{Code: "PEN", Numeric: "604", MinorUnits: 2},
+	{Code: "PGK", Numeric: "598", MinorUnits: 2},
+	{Code: "PHP", Numeric: "608", MinorUnits: 2},
+	{Code: "PKR", Numeric: "586", MinorUnits: 2},
+	{Code: "PLN", Numeric: "985", MinorUnits: 2},
+	{Code: "PTE", Numeric: "620", MinorUnits: 0, Historic: true},
+	{Code: "PYG", Numeric: "600", MinorUnits: 0},
+	{Code: "QAR", Numeric: "634", MinorUnits: 2},
+	{Code: "ROL", Numeric: "642", MinorUnits: 2, Historic: true},
+	{Code: "RON", Numeric: "946", MinorUnits: 2},
+	{Code: "RSD", Numeric: "941", MinorUnits: 2},
+	{Code: "RUB", Numeric: "643", MinorUnits: 2},
+	{Code: "RWF", Numeric: "646", MinorUnits: 0},
+	{Code: "SAR", Numeric: "682", MinorUnits: 2},
+	{Code: "SBD", Numeric: "090", MinorUnits: 2},
+	{Code: "SCR", Numeric: "690", MinorUnits: 2},
+	{Code: "SDD", Numeric: "736", MinorUnits: 2, Historic: true},
+	{Code: "SDG", Numeric: "938", MinorUnits: 2},
+	{Code: "SEK", Numeric: "752", MinorUnits: 2},
+	{Code: "SGD", Numeric: "702", MinorUnits: 2},
+	{Code: "SHP", Numeric: "654", MinorUnits: 2},
+	{Code: "SIT", Numeric: "705", MinorUnits: 2, Historic: true},
+	{Code: "SKK", Numeric: "703", MinorUnits: 2, Historic: true},
+	{Code: "SLE", Numeric: "925", MinorUnits: 2},
+	{Code: "SLL", Numeric: "694", MinorUnits: 2, Historic: true},
+	{Code: "SOS", Numeric: "706", MinorUnits: 2},
+	{Code: "SRD", Numeric: "968", MinorUnits: 2},
+	{Code: "SSP", Numeric: "728", MinorUnits: 2},
+	{Code: "STD", Numeric: "678", MinorUnits: 2, Historic: true},
+	{Code: "STN", Numeric: "930", MinorUnits: 2},
+	{Code: "SVC", Numeric: "222", MinorUnits: 2},
+	{Code: "SYP", Numeric: "760", MinorUnits: 2},
+	{Code: "SZL", Numeric: "748", MinorUnits: 2},
+	{Code: "THB", Numeric: "764", MinorUnits: 2},
+	{Code: "TJS", Numeric: "972", MinorUnits: 2},
+	{Code: "TMT", Numeric: "934", MinorUnits: 2},
+	{Code: "TND", Numeric: "788", MinorUnits: 3},
+	{Code: "TOP", Numeric: "776", MinorUnits: 2},
+	{Code: "TRL", Numeric: "792", MinorUnits: 0, Historic: true},
+	{Code: "TRY", Numeric: "949", MinorUnits: 2},
+	{Code: "TTD", Numeric: "780", MinorUnits: 2},
+	{Code: "TWD", Numeric: "901", MinorUnits: 2},
+	{Code: "TZS", Numeric: "834", MinorUnits: 2},
+	{Code: "UAH", Numeric: "980", MinorUnits: 2},
+	{Code: "UGX", Numeric: "800", MinorUnits: 0},
+	{Code: "USD", Numeric: "840", MinorUnits: 2},
+	{Code: "USN", Numeric: "997", MinorUnits: 2},
+	{Code: "UYI", Numeric: "940", MinorUnits: 0},
+	{Code: "UYU", Numeric: "858", MinorUnits: 2},
+	{Code: "UYW", Numeric: "927", MinorUnits: 4},
+	{Code: "UZS", Numeric: "860", MinorUnits: 2},
+	{Code: "VED", Numeric: "926", MinorUnits: 2},
+	{Code: "VEF", Numeric: "937", MinorUnits: 2, Historic: true},
+	{Code: "VES", Numeric: "928", MinorUnits: 2},
+	{Code: "VND", Numeric: "704", MinorUnits: 0},
+	{Code: "VUV", Numeric: "548", MinorUnits: 0},
+	{Code: "WST", Numeric: "882", MinorUnits: 2},
+	{Code: "XAF", Numeric: "950", MinorUnits: 0},
+	{Code: "XAG", Numeric: "961", MinorUnits: -1},
+	{Code: "XAU", Numeric: "959", MinorUnits: -1},
+	{Code: "XBA", Numeric: "955", MinorUnits: -1},
+	{Code: "XBB", Numeric: "956", MinorUnits: -1},
+	{Code: "XBC", Numeric: "957", MinorUnits: -1},
+	{Code: "XBD", Numeric: "958", MinorUnits: -1},
+	{Code: "XCD", Numeric: "951", MinorUnits: 2},
+	{Code: "XCG", Numeric: "532", MinorUnits: 2},
+	{Code: "XDR", Numeric: "960", MinorUnits: -1},
+	{Code: "XOF", Numeric: "952", MinorUnits: 0},
+	{Code: "XPD", Numeric: "964", MinorUnits: -1},
+	{Code: "XPF", Numeric: "953", MinorUnits: 0},
+	{Code: "XPT", Numeric: "962", MinorUnits: -1},
+	{Code: "XSU", Numeric: "994", MinorUnits: -1},
+	{Code: "XTS", Numeric: "963", MinorUnits: -1},
+	{Code: "XUA", Numeric: "965", MinorUnits: -1},
+	{Code: "XXX", Numeric: "999", MinorUnits: -1},
+	{Code: "YER", Numeric: "886", MinorUnits: 2},
+	{Code: "ZAR", Numeric: "710", MinorUnits: 2},
+	{Code: "ZMK", Numeric: "894", MinorUnits: 2, Historic: true},
+	{Code: "ZMW", Numeric: "967", MinorUnits: 2},
+	{Code: "ZWD", Numeric: "716", MinorUnits: 2, Historic: true},
+	{Code: "ZWG", Numeric: "924", MinorUnits: 2},
+	{Code: "ZWL", Numeric: "932", MinorUnits: 2, Historic: true},
+}
+
+// byCode indexes the table by alphabetic code
+var byCode = func() map[string]Currency {
+	m := make(map[string]Currency, len(Currencies))
+	for _, c := range Currencies {
+		m[c.Code] = c
+	}
+	return m
+}()
+
+// Lookup returns the currency with an alphabetic code
+func Lookup(code string) (Currency, bool) {
+	c, ok := byCode[code]
+	return c, ok
+}
+
+// MinorUnits returns the number of decimal places of a currency, such as 2 for USD,
+// 0 for JPY and 3 for BHD. It returns false for unknown codes and for the codes
+// without a minor unit.
+func MinorUnits(code string) (int, bool) {
+	c, ok := byCode[code]
+	if !ok || c.MinorUnits < 0 {
+		return 0, false
+	}
+	return c.MinorUnits, true
+}
+
+// IsActive reports whether code is a currency that has not been withdrawn
+func IsActive(code string) bool {
+	c, ok := byCode[code]
+	return ok && !c.Historic
+}
@@ -0,0 +1,57 @@
+package currency
+
+import (
+	"sort"
+	"testing"
+)
+
+func TestTable(t *testing.T) {
+	if !sort.SliceIsSorted(Currencies, func(i, j int) bool { return Currencies[i].Code < Currencies[j].Code }) {
+		t.Errorf("Expected the table to be sorted by code")
+	}
+	numeric := make(map[string]string)
+	for _, c := range Currencies {
+		if len(c.Code) != 3 || len(c.Numeric) != 3 || c.MinorUnits < -1 || c.MinorUnits > 4 {
+			t.Errorf("Malformed entry %+v", c)
+		}
+		if other, ok := numeric[c.Numeric]; ok && !c.Historic && !byCode[other].Historic {
+			t.Errorf("%s and %s are both active with numeric code %s", other, c.Code, c.Numeric)
+		}
+		numeric[c.Numeric] = c.Code
+	}
+	if len(byCode) != len(Currencies) {
+		t.Errorf("Expected unique codes")
+	}
+}
+
+func TestMinorUnits(t *testing.T) {
+	tests := []struct {
+		code  string
+		units int
+		ok    bool
+	}{
+		{"USD", 2, true},
+		{"JPY", 0, true},
+		{"BHD", 3, true},
+		{"CLF", 4, true},
+		{"XAU", 0, false},
+		{"ABC", 0, false},
+	}
+	for _, tt := range tests {
+		if units, ok := MinorUnits(tt.code); units != tt.units || ok != tt.ok {
+			t.Errorf("%s: got %d, %v, want %d, %v", tt.code, units, ok, tt.units, tt.ok)
+		}
+	}
+}
+
+func TestLookup(t *testing.T) {
+	if c, ok := Lookup("EUR"); !ok || c.Numeric != "978" || c.Historic {
+		t.Errorf("Unexpected EUR entry %+v", c)
+	}
+	if !IsActive("GBP") || IsActive("DEM") || IsActive("HRK") || IsActive("ABC") {
+		t.Errorf("Unexpected activity")
+	}
+	if c, ok := Lookup("DEM"); !ok || !c.Historic {
+		t.Errorf("Expected DEM to be a historic currency, got %+v", c)
+	}
+}
@@ -1,6 +1,8 @@
 package iso20022
 
 import (
+	"encoding/json"
+	"encoding/xml"
 	"testing"
 	"time"
 )
@@ -113,7 +115,7 @@ func TestDateHelperFunctions(t *testing.T) {
 func TestGroupHeaderWithStringDateTime(t *testing.T) {
 	// Test that GroupHeader93 works with string datetime
 	t.Run("GroupHeaderValidation", func(t *testing.T) {
-		testTime, _ := time.Parse("2006-01-02T15:04:05", "2023-12-25T14:30:45")
+		testTime, _ := ParseISODateTime("2023-12-25T14:30:45")
 		groupHeader := GroupHeader93{
 			MessageID:            "TEST123",
 			CreationDateTime:     &testTime,
@@ -144,20 +146,20 @@ func TestBusinessApplicationHeaderWithStringDateTime(t *testing.T) {
 			From: Party44{
 				FinancialInstitutionID: &BranchAndFinancialInstitutionIdentification6{
 					FinancialInstitutionID: FinancialInstitutionIdentification18{
-						BankIdentifierCode: stringPtr("CHASUS33"),
+						BankIdentifierCode: Ptr("CHASUS33"),
 					},
 				},
 			},
 			To: Party44{
 				FinancialInstitutionID: &BranchAndFinancialInstitutionIdentification6{
 					FinancialInstitutionID: FinancialInstitutionIdentification18{
-						BankIdentifierCode: stringPtr("BOFA0011"),
+						BankIdentifierCode: Ptr("BOFA0011"),
 					},
 				},
 			},
 			BusinessMessageID: "TEST123",
 			MessageDefinitionID: "pacs.008.001.08",
-			CreationDate: func() time.Time { t, _ := time.Parse("2006-01-02T15:04:05", "2023-12-25T14:30:45"); return t }(),
+			CreationDate: func() ISODateTime { t, _ := ParseISODateTime("2023-12-25T14:30:45"); return t }(),
 		}
 
 		err := bah.Validate()
@@ -173,4 +175,89 @@ func TestBusinessApplicationHeaderWithStringDateTime(t *testing.T) {
 
 	// Invalid datetime test removed since time.Time fields are validated at creation
 	// and cannot contain invalid datetime values
-}
\ No newline at end of file
+}
+func TestISODateTypes(t *testing.T) {
+	type sample struct {
+		XMLName  xml.Name     `xml:"Sample"`
+		Date     ISODate      `xml:"Dt"`
+		DateTime ISODateTime  `xml:"DtTm"`
+		Time     *ISOTime     `xml:"Tm,omitempty"`
+		Optional *ISODateTime `xml:"Opt,omitempty"`
+	}
+
+	t.Run("Marshal", func(t *testing.T) {
+		cet := time.FixedZone("CET", 3600)
+		s := sample{
+			Date:     NewISODate(2023, time.December, 25),
+			DateTime: NewISODateTime(time.Date(2023, 12, 25, 14, 30, 45, 0, time.UTC)),
+			Time:     &ISOTime{time.Date(0, 1, 1, 9, 15, 0, 500000000, cet)},
+		}
+		data, err := xml.Marshal(s)
+		if err != nil {
+			t.Fatalf("Failed to marshal: %v", err)
+		}
+		expected := "<Sample><Dt>2023-12-25</Dt><DtTm>2023-12-25T14:30:45Z</DtTm><Tm>09:15:00.5+01:00</Tm></Sample>"
+		if string(data) != expected {
+			t.Errorf("Expected %s, got %s", expected, data)
+		}
+	})
+
+	t.Run("Unmarshal", func(t *testing.T) {
+		data := "<Sample><Dt>2023-12-25</Dt><DtTm>2023-12-25T14:30:45.123+01:00</DtTm><Opt>2023-12-25T14:30:45</Opt></Sample>"
+		var s sample
+		if err := xml.Unmarshal([]byte(data), &s); err != nil {
+			t.Fatalf("Failed to unmarshal: %v", err)
+		}
+		if s.Date.String() != "2023-12-25" {
+			t.Errorf("Expected date 2023-12-25, got %s", s.Date)
+		}
+		if s.DateTime.String() != "2023-12-25T14:30:45.123+01:00" {
+			t.Errorf("Expected offset to be preserved, got %s", s.DateTime)
+		}
+		if s.Optional == nil || s.Optional.Location() != time.UTC || s.Optional.HasOffset() ||
+			s.Optional.String() != "2023-12-25T14:30:45" {
+			t.Errorf("Expected datetime without offset to be read as UTC and kept without one, got %v", s.Optional)
+		}
+	})
+
+	t.Run("Lexical", func(t *testing.T) {
+		data := "<Sample><Dt>2023-12-25</Dt><DtTm>2023-12-25T14:30:45.120+01:00</DtTm><Opt>2023-01-01T10:00:00</Opt></Sample>"
+		var s sample
+		if err := xml.Unmarshal([]byte(data), &s); err != nil {
+			t.Fatalf("Failed to unmarshal: %v", err)
+		}
+		out, err := Marshal(s, WithDateTimeLocation(time.UTC))
+		if err != nil {
+			t.Fatalf("Failed to marshal: %v", err)
+		}
+		expected := "<Sample><Dt>2023-12-25</Dt><DtTm>2023-12-25T13:30:45.120Z</DtTm><Opt>2023-01-01T10:00:00</Opt></Sample>"
+		if string(out) != expected {
+			t.Errorf("Expected %s, got %s", expected, out)
+		}
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		for _, data := range []string{
+			"<Sample><Dt>2023-02-30</Dt></Sample>",
+			"<Sample><Dt>2023-12-25T10:00:00</Dt></Sample>",
+			"<Sample><DtTm>2023-12-25</DtTm></Sample>",
+		} {
+			var s sample
+			if err := xml.Unmarshal([]byte(data), &s); err == nil {
+				t.Errorf("Expected error unmarshaling %s", data)
+			}
+		}
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		d := NewISODate(2024, time.February, 29)
+		data, err := json.Marshal(d)
+		if err != nil || string(data) != `"2024-02-29"` {
+			t.Fatalf("Expected \"2024-02-29\", got %s (%v)", data, err)
+		}
+		var back ISODate
+		if err := json.Unmarshal(data, &back); err != nil || !back.Equal(d.Time) {
+			t.Errorf("Expected JSON round trip, got %s (%v)", back, err)
+		}
+	})
+}
@@ -0,0 +1,186 @@
+// Package dedup detects business messages received more than once.
+//
+// Messages are identified by their sender and their BizMsgIdr, or the MsgId of the
+// group header for messages without a Business Application Header. A
+// DuplicateChecker remembers the identifications it has seen; Check consults it and
+// interprets the duplicate flags of the header: PssblDplct, set when the sender
+// cannot tell whether the message was already delivered, and CpyDplct, which marks
+// copies sent for information and duplicates sent on purpose.
+package dedup
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ckbaum/iso20022-go"
+)
+
+// Key identifies a business message
+type Key struct {
+	Sender    string // BIC or other identification of the sender
+	MessageID string // BizMsgIdr, or the MsgId of the group header
+}
+
+// String returns the key as sender/message identification
+func (k Key) String() string {
+	return k.Sender + "/" + k.MessageID
+}
+
+// DuplicateChecker remembers the messages that have been received.
+// Implementations must be safe for concurrent use.
+type DuplicateChecker interface {
+	// Seen returns when the message was first recorded, and whether it was
+	Seen(key Key) (first time.Time, ok bool, err error)
+	// Record records the message as received at the given time, unless it was
+	// already recorded, and returns when it was first recorded and whether it was
+	// recorded before
+	Record(key Key, at time.Time) (first time.Time, duplicate bool, err error)
+}
+
+// Message holds what Check needs to know about a received message
+type Message struct {
+	Key
+	PossibleDuplicate bool                        // PssblDplct
+	CopyDuplicate     iso20022.CopyDuplicate1Code // CpyDplct, or "" when absent
+}
+
+// FromHeader returns the identification and duplicate flags of a message from its
+// Business Application Header
+func FromHeader(hdr *iso20022.BusinessApplicationHeaderV02) Message {
+	msg := Message{Key: Key{Sender: PartyID(&hdr.From), MessageID: hdr.BusinessMessageID}}
+	if hdr.PossibleDuplicate != nil {
+		msg.PossibleDuplicate = *hdr.PossibleDuplicate
+	}
+	if hdr.CopyDuplicate != nil {
+		msg.CopyDuplicate = *hdr.CopyDuplicate
+	}
+	return msg
+}
+
+// PartyID returns the identification of a header party used in keys: the BIC of a
+// financial institution or organisation, and otherwise its clearing system member
+// identification, LEI, other identification or name
+func PartyID(p *iso20022.Party44) string {
+	if fi := p.FinancialInstitutionID; fi != nil {
+		id := &fi.FinancialInstitutionID
+		switch {
+		case id.BankIdentifierCode != nil:
+			return iso20022.NormalizeBIC(*id.BankIdentifierCode)
+		case id.ClearingSystemMemberID != nil:
+			return id.ClearingSystemMemberID.MemberID
+		case id.LegalEntityIdentifier != nil:
+			return *id.LegalEntityIdentifier
+		case id.Other != nil:
+			return id.Other.ID
+		case id.Name != nil:
+			return *id.Name
+		}
+	}
+	if org := p.OrganisationIdentification; org != nil {
+		if org.ID != nil && org.ID.OrganizationID != nil {
+			id := org.ID.OrganizationID
+			switch {
+			case id.AnyBankIdentifierCode != nil:
+				return iso20022.NormalizeBIC(*id.AnyBankIdentifierCode)
+			case id.LegalEntityIdentifier != nil:
+				return *id.LegalEntityIdentifier
+			case len(id.Other) > 0:
+				return id.Other[0].ID
+			}
+		}
+		if org.ID != nil && org.ID.PrivateID != nil && len(org.ID.PrivateID.Other) > 0 {
+			return org.ID.PrivateID.Other[0].ID
+		}
+		if org.Name != nil {
+			return *org.Name
+		}
+	}
+	return ""
+}
+
+// Status is the outcome of a duplicate check
+type Status int
+
+// Statuses of a checked message
+const (
+	// Unique messages have not been received before and are to be processed
+	Unique Status = iota
+	// Duplicate messages have been received before and must not be processed again
+	Duplicate
+	// Copy messages (CpyDplct COPY or CODU) are sent for information only and are
+	// never processed, whether or not the original was received
+	Copy
+)
+
+// String returns the name of the status
+func (s Status) String() string {
+	switch s {
+	case Unique:
+		return "unique"
+	case Duplicate:
+		return "duplicate"
+	case Copy:
+		return "copy"
+	}
+	return fmt.Sprintf("Status(%d)", int(s))
+}
+
+// Verdict is the outcome of Check
+type Verdict struct {
+	Key    Key
+	Status Status
+	// Flagged reports whether the sender marked the message as a possible or
+	// deliberate duplicate. A Duplicate that is not flagged points at a sender
+	// reusing identifications, and a Unique one that is flagged at an original that
+	// never arrived.
+	Flagged bool
+	// FirstSeen is when the message, or for a copy its original, was first
+	// received. It is zero when it had not been received.
+	FirstSeen time.Time
+}
+
+// Process reports whether the message is to be processed
+func (v Verdict) Process() bool {
+	return v.Status == Unique
+}
+
+// Check classifies a message received at the given time and records it with the
+// checker. Copies are not recorded, so that the original is still processed when it
+// arrives after its copy. A message without a message identification cannot be
+// checked and is an error.
+func Check(c DuplicateChecker, msg Message, at time.Time) (Verdict, error) {
+	if msg.MessageID == "" {
+		return Verdict{}, fmt.Errorf("dedup: message from %q has no message identification", msg.Sender)
+	}
+	verdict := Verdict{
+		Key:     msg.Key,
+		Flagged: msg.PossibleDuplicate || msg.CopyDuplicate == iso20022.CopyDuplicateCodeDupl,
+	}
+	switch msg.CopyDuplicate {
+	case iso20022.CopyDuplicateCodeCopy, iso20022.CopyDuplicateCodeCoDu:
+		first, ok, err := c.Seen(msg.Key)
+		if err != nil {
+			return Verdict{}, err
+		}
+		verdict.Status = Copy
+		if ok {
+			verdict.FirstSeen = first
+		}
+		return verdict, nil
+	}
+
+	first, duplicate, err := c.Record(msg.Key, at)
+	if err != nil {
+		return Verdict{}, err
+	}
+	if duplicate {
+		verdict.Status = Duplicate
+		verdict.FirstSeen = first
+	}
+	return verdict, nil
+}
+
+// CheckHeader checks a message by its Business Application Header
+func CheckHeader(c DuplicateChecker, hdr *iso20022.BusinessApplicationHeaderV02, at time.Time) (Verdict, error) {
+	return Check(c, FromHeader(hdr), at)
+}
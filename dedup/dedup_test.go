@@ -0,0 +1,142 @@
+package dedup
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ckbaum/iso20022-go"
+)
+
+func header(msgID string, possibleDuplicate bool, copyDuplicate iso20022.CopyDuplicate1Code) *iso20022.BusinessApplicationHeaderV02 {
+	bic := "deutdeff"
+	hdr := &iso20022.BusinessApplicationHeaderV02{
+		From: iso20022.Party44{FinancialInstitutionID: &iso20022.BranchAndFinancialInstitutionIdentification6{
+			FinancialInstitutionID: iso20022.FinancialInstitutionIdentification18{BankIdentifierCode: &bic},
+		}},
+		BusinessMessageID: msgID,
+	}
+	if possibleDuplicate {
+		hdr.PossibleDuplicate = &possibleDuplicate
+	}
+	if copyDuplicate != "" {
+		hdr.CopyDuplicate = &copyDuplicate
+	}
+	return hdr
+}
+
+func TestCheckHeader(t *testing.T) {
+	t0 := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		hdr     *iso20022.BusinessApplicationHeaderV02
+		status  Status
+		flagged bool
+		seen    bool
+	}{
+		{"Original", header("MSG-1", false, ""), Unique, false, false},
+		{"CopyOfSeen", header("MSG-1", false, iso20022.CopyDuplicateCodeCopy), Copy, false, true},
+		{"CopyOfUnseen", header("MSG-2", false, iso20022.CopyDuplicateCodeCopy), Copy, false, false},
+		{"OriginalAfterCopy", header("MSG-2", false, ""), Unique, false, false},
+		{"PossibleDuplicateOfSeen", header("MSG-1", true, ""), Duplicate, true, true},
+		{"PossibleDuplicateOfUnseen", header("MSG-3", true, ""), Unique, true, false},
+		{"DeliberateDuplicate", header("MSG-3", false, iso20022.CopyDuplicateCodeDupl), Duplicate, true, true},
+		{"UnflaggedDuplicate", header("MSG-2", false, ""), Duplicate, false, true},
+		{"CopyOfDuplicate", header("MSG-3", false, iso20022.CopyDuplicateCodeCoDu), Copy, false, true},
+	}
+
+	c := NewMemory(0)
+	for i, tt := range tests {
+		verdict, err := CheckHeader(c, tt.hdr, t0.Add(time.Duration(i)*time.Minute))
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.name, err)
+		}
+		if verdict.Status != tt.status || verdict.Flagged != tt.flagged || verdict.FirstSeen.IsZero() == tt.seen {
+			t.Errorf("%s: got %+v, expected status %v, flagged %v, seen %v", tt.name, verdict, tt.status, tt.flagged, tt.seen)
+		}
+		if verdict.Key.Sender != "DEUTDEFFXXX" {
+			t.Errorf("%s: expected normalized sender, got %q", tt.name, verdict.Key.Sender)
+		}
+	}
+
+	if _, err := CheckHeader(c, header("", false, ""), t0); err == nil {
+		t.Error("Expected an error for a message without identification")
+	}
+}
+
+func TestMemoryEvictsLeastRecent(t *testing.T) {
+	c := NewMemory(2)
+	now := time.Now()
+	a, b, d := Key{"S", "A"}, Key{"S", "B"}, Key{"S", "D"}
+	c.Record(a, now)
+	c.Record(b, now)
+	c.Record(a, now) // a is now the most recent
+	c.Record(d, now)
+
+	if _, ok, _ := c.Seen(b); ok {
+		t.Error("Expected B to be evicted")
+	}
+	if _, ok, _ := c.Seen(a); !ok {
+		t.Error("Expected A to be remembered")
+	}
+	if c.Len() != 2 {
+		t.Errorf("Expected 2 remembered messages, got %d", c.Len())
+	}
+}
+
+func TestFilePersistsAcrossReopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "seen.jsonl")
+	first := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	key := Key{"DEUTDEFFXXX", "MSG-1"}
+
+	c, err := OpenFile(path)
+	if err != nil {
+		t.Fatalf("OpenFile failed: %v", err)
+	}
+	if _, dup, err := c.Record(key, first); err != nil || dup {
+		t.Fatalf("Expected a new message, got %v, %v", dup, err)
+	}
+	if err := c.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	// Simulate a crash in the middle of writing a record
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.WriteString(`{"sender":"DEUTDEFFXXX","msgId":"MSG-`)
+	f.Close()
+
+	c, err = OpenFile(path)
+	if err != nil {
+		t.Fatalf("Reopening failed: %v", err)
+	}
+	defer c.Close()
+	at, dup, err := c.Record(key, first.Add(time.Hour))
+	if err != nil || !dup || !at.Equal(first) {
+		t.Errorf("Expected a duplicate first seen at %v, got %v, %v, %v", first, at, dup, err)
+	}
+	if _, dup, err := c.Record(Key{"DEUTDEFFXXX", "MSG-2"}, first); err != nil || dup {
+		t.Errorf("Expected a new message, got %v, %v", dup, err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := 2; countLines(data) != want {
+		t.Errorf("Expected %d records in the file, got:\n%s", want, data)
+	}
+}
+
+func countLines(data []byte) int {
+	n := 0
+	for _, b := range data {
+		if b == '\n' {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,125 @@
+package dedup
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// File is a DuplicateChecker that keeps the messages it has seen in a file, so that
+// duplicates are detected across restarts. Each recorded message is appended to the
+// file as a line of JSON and written to stable storage before Record returns. The
+// file is read into memory when opened. It is safe for concurrent use, but not for
+// use by several processes at once.
+type File struct {
+	mu    sync.Mutex
+	f     *os.File
+	first map[Key]time.Time
+}
+
+// fileRecord is a line of the file
+type fileRecord struct {
+	Sender    string    `json:"sender"`
+	MessageID string    `json:"msgId"`
+	Received  time.Time `json:"received"`
+}
+
+// OpenFile opens the file at path, creating it if it does not exist, and loads the
+// messages recorded in it. A last line left incomplete by a crash is ignored and
+// overwritten.
+func OpenFile(path string) (*File, error) {
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	c := &File{f: f, first: make(map[Key]time.Time)}
+	end, err := c.load()
+	if err == nil {
+		_, err = f.Seek(end, io.SeekStart)
+	}
+	if err == nil {
+		err = f.Truncate(end)
+	}
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("dedup: loading %s: %w", path, err)
+	}
+	return c, nil
+}
+
+// load reads the records of the file and returns the offset after the last
+// complete one
+func (c *File) load() (int64, error) {
+	r := bufio.NewReader(c.f)
+	var end int64
+	for line := 1; ; line++ {
+		data, err := r.ReadBytes('\n')
+		if errors.Is(err, io.EOF) {
+			return end, nil
+		}
+		if err != nil {
+			return 0, err
+		}
+		var rec fileRecord
+		if err := json.Unmarshal(data, &rec); err != nil {
+			return 0, fmt.Errorf("line %d: %w", line, err)
+		}
+		key := Key{Sender: rec.Sender, MessageID: rec.MessageID}
+		if _, ok := c.first[key]; !ok {
+			c.first[key] = rec.Received
+		}
+		end += int64(len(data))
+	}
+}
+
+// Seen implements DuplicateChecker
+func (c *File) Seen(key Key) (time.Time, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	first, ok := c.first[key]
+	return first, ok, nil
+}
+
+// Record implements DuplicateChecker
+func (c *File) Record(key Key, at time.Time) (time.Time, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if first, ok := c.first[key]; ok {
+		return first, true, nil
+	}
+	if c.f == nil {
+		return time.Time{}, false, os.ErrClosed
+	}
+	data, err := json.Marshal(fileRecord{Sender: key.Sender, MessageID: key.MessageID, Received: at})
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	if _, err := c.f.Write(append(data, '\n')); err != nil {
+		return time.Time{}, false, err
+	}
+	if err := c.f.Sync(); err != nil {
+		return time.Time{}, false, err
+	}
+	c.first[key] = at
+	return at, false, nil
+}
+
+// Close closes the file. Messages recorded so far remain available to Seen.
+func (c *File) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.f == nil {
+		return nil
+	}
+	err := c.f.Close()
+	c.f = nil
+	return err
+}
@@ -0,0 +1,65 @@
+package dedup
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// Memory is an in-memory DuplicateChecker that remembers a bounded number of
+// messages, forgetting the least recently received ones first. It is safe for
+// concurrent use.
+type Memory struct {
+	mu       sync.Mutex
+	capacity int
+	order    *list.List // of *memoryEntry, most recently received first
+	entries  map[Key]*list.Element
+}
+
+type memoryEntry struct {
+	key   Key
+	first time.Time
+}
+
+// NewMemory returns a checker that remembers up to capacity messages. A capacity
+// of zero or less remembers every message.
+func NewMemory(capacity int) *Memory {
+	return &Memory{capacity: capacity, order: list.New(), entries: make(map[Key]*list.Element)}
+}
+
+// Seen implements DuplicateChecker
+func (m *Memory) Seen(key Key) (time.Time, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if e, ok := m.entries[key]; ok {
+		return e.Value.(*memoryEntry).first, true, nil
+	}
+	return time.Time{}, false, nil
+}
+
+// Record implements DuplicateChecker. Receiving a message again keeps it from
+// being forgotten.
+func (m *Memory) Record(key Key, at time.Time) (time.Time, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if e, ok := m.entries[key]; ok {
+		m.order.MoveToFront(e)
+		return e.Value.(*memoryEntry).first, true, nil
+	}
+	m.entries[key] = m.order.PushFront(&memoryEntry{key: key, first: at})
+	if m.capacity > 0 && m.order.Len() > m.capacity {
+		oldest := m.order.Back()
+		m.order.Remove(oldest)
+		delete(m.entries, oldest.Value.(*memoryEntry).key)
+	}
+	return at, false, nil
+}
+
+// Len returns the number of messages remembered
+func (m *Memory) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.order.Len()
+}
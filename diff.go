@@ -0,0 +1,286 @@
+package iso20022
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/xml"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// ChangeKind says how an element differs between two documents
+type ChangeKind int
+
+// Change kinds
+const (
+	// Modified elements are in both documents with different values
+	Modified ChangeKind = iota
+	// Added elements are only in the second document
+	Added
+	// Removed elements are only in the first document
+	Removed
+)
+
+func (k ChangeKind) String() string {
+	switch k {
+	case Modified:
+		return "modified"
+	case Added:
+		return "added"
+	case Removed:
+		return "removed"
+	}
+	return fmt.Sprintf("ChangeKind(%d)", int(k))
+}
+
+// Change is a value that differs between two documents. Old is empty for an added
+// value and New for a removed one.
+type Change struct {
+	Kind ChangeKind
+	// Path locates the value below the document element in the style of the paths
+	// of ValidationError, e.g. FIToFICstmrCdtTrf/CdtTrfTxInf[2]/IntrBkSttlmAmt/@Ccy
+	Path string
+	Old  string
+	New  string
+}
+
+func (c Change) String() string {
+	switch c.Kind {
+	case Added:
+		return fmt.Sprintf("+ %s: %q", c.Path, c.New)
+	case Removed:
+		return fmt.Sprintf("- %s: %q", c.Path, c.Old)
+	}
+	return fmt.Sprintf("~ %s: %q -> %q", c.Path, c.Old, c.New)
+}
+
+// VolatileElements are the elements Diff ignores by default: the identification
+// and creation time of a message, which differ every time it is generated
+var VolatileElements = []string{"MsgId", "CreDtTm"}
+
+// DiffOption configures Diff
+type DiffOption func(*differ)
+
+// IgnoreElements makes Diff ignore the given elements, and everything below them,
+// instead of VolatileElements. An element is given by its name, which matches it
+// anywhere, or by the end of its path, such as GrpHdr/MsgId or Amt/@Ccy. Call it
+// without elements to compare every element.
+func IgnoreElements(elements ...string) DiffOption {
+	return func(d *differ) {
+		d.ignored = elements
+	}
+}
+
+// Diff compares two documents of the same type, or two components, and returns
+// the values that differ in document order. Values are compared as they are
+// written to XML, and repeated elements by position, so that a transaction inserted
+// in a list changes every transaction after it. Optional elements that are absent
+// from one document are reported with each of their values as added or removed.
+func Diff(a, b interface{}, opts ...DiffOption) ([]Change, error) {
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	if !va.IsValid() || !vb.IsValid() || va.Type() != vb.Type() {
+		return nil, fmt.Errorf("diff: cannot compare %T with %T", a, b)
+	}
+	d := &differ{ignored: VolatileElements}
+	for _, opt := range opts {
+		opt(d)
+	}
+	d.compare(va, vb, "")
+	return d.changes, nil
+}
+
+type differ struct {
+	ignored []string
+	changes []Change
+}
+
+// compare compares the values a and b of the element at path. A value is invalid
+// when the element is absent from its document.
+func (d *differ) compare(a, b reflect.Value, path string) {
+	a, b = present(a), present(b)
+	if !a.IsValid() && !b.IsValid() {
+		return
+	}
+	v := a
+	if !v.IsValid() {
+		v = b
+	}
+	if text, ok := leafText(v); ok {
+		switch {
+		case !a.IsValid():
+			d.changes = append(d.changes, Change{Kind: Added, Path: path, New: text})
+		case !b.IsValid():
+			d.changes = append(d.changes, Change{Kind: Removed, Path: path, Old: text})
+		default:
+			if other, _ := leafText(b); other != text {
+				d.changes = append(d.changes, Change{Kind: Modified, Path: path, Old: text, New: other})
+			}
+		}
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.Slice:
+		n := 0
+		if a.IsValid() {
+			n = a.Len()
+		}
+		if b.IsValid() && b.Len() > n {
+			n = b.Len()
+		}
+		for i := 0; i < n; i++ {
+			d.compare(elementAt(a, i), elementAt(b, i), fmt.Sprintf("%s[%d]", path, i+1))
+		}
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			name, ok := diffElementName(field)
+			if !ok || !field.IsExported() {
+				continue
+			}
+			child := path
+			if name != "" {
+				if path != "" {
+					child += "/"
+				}
+				child += name
+				if d.ignore(child) {
+					continue
+				}
+			}
+			d.compare(fieldOf(a, i), fieldOf(b, i), child)
+		}
+	}
+}
+
+// ignore reports whether the element at path is ignored
+func (d *differ) ignore(path string) bool {
+	steps := strings.Split(path, "/")
+	for i, step := range steps {
+		if j := strings.IndexByte(step, '['); j >= 0 {
+			steps[i] = step[:j]
+		}
+	}
+	unindexed := "/" + strings.Join(steps, "/")
+	for _, element := range d.ignored {
+		if strings.HasSuffix(unindexed, "/"+element) {
+			return true
+		}
+	}
+	return false
+}
+
+// diffElementName returns the step of a struct field in a path: the element name,
+// @ and the attribute name, or "" for character data and embedded structs. It
+// returns false for the fields that are not written to XML.
+func diffElementName(field reflect.StructField) (string, bool) {
+	if field.Name == "XMLName" {
+		return "", false
+	}
+	parts := strings.Split(field.Tag.Get("xml"), ",")
+	name := parts[0]
+	if name == "-" {
+		return "", false
+	}
+	if i := strings.LastIndexAny(name, " >"); i >= 0 {
+		name = name[i+1:]
+	}
+	for _, opt := range parts[1:] {
+		switch opt {
+		case "attr":
+			if name == "" {
+				name = field.Name
+			}
+			return "@" + name, true
+		case "chardata", "innerxml":
+			return "", true
+		case "comment":
+			return "", false
+		}
+	}
+	if name == "" && !field.Anonymous {
+		name = field.Name
+	}
+	return name, true
+}
+
+// present returns v without its pointers and interfaces, or the invalid value for
+// an absent element, which is a nil pointer or an empty slice
+func present(v reflect.Value) reflect.Value {
+	for v.IsValid() && (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	if v.IsValid() && v.Kind() == reflect.Slice && v.Len() == 0 {
+		return reflect.Value{}
+	}
+	return v
+}
+
+// elementAt returns element i of the slice s, or the invalid value
+func elementAt(s reflect.Value, i int) reflect.Value {
+	if !s.IsValid() || i >= s.Len() {
+		return reflect.Value{}
+	}
+	return s.Index(i)
+}
+
+// fieldOf returns field i of the struct v, or the invalid value
+func fieldOf(v reflect.Value, i int) reflect.Value {
+	if !v.IsValid() {
+		return reflect.Value{}
+	}
+	return v.Field(i)
+}
+
+var xmlMarshalerType = reflect.TypeOf((*xml.Marshaler)(nil)).Elem()
+
+// leafText returns the text v is written as, when v is a value rather than a
+// component
+func leafText(v reflect.Value) (string, bool) {
+	if m, ok := v.Interface().(encoding.TextMarshaler); ok {
+		text, err := m.MarshalText()
+		return string(text), err == nil
+	}
+	// Components such as amounts marshal themselves but are compared by their
+	// character data and attributes
+	if v.Kind() != reflect.Struct && v.Type().Implements(xmlMarshalerType) {
+		var buf bytes.Buffer
+		enc := xml.NewEncoder(&buf)
+		if err := v.Interface().(xml.Marshaler).MarshalXML(enc, xml.StartElement{Name: xml.Name{Local: "v"}}); err != nil {
+			return "", false
+		}
+		if err := enc.Flush(); err != nil {
+			return "", false
+		}
+		var text struct {
+			Value string `xml:",chardata"`
+		}
+		if err := xml.Unmarshal(buf.Bytes(), &text); err != nil {
+			return "", false
+		}
+		return text.Value, true
+	}
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), true
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), true
+	case reflect.Uint, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), true
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 64), true
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return string(v.Bytes()), true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,120 @@
+package iso20022
+
+import (
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDiff(t *testing.T) {
+	a := loadPacs008Sample(t)
+
+	t.Run("Same", func(t *testing.T) {
+		changes, err := Diff(a, loadPacs008Sample(t))
+		if err != nil || len(changes) != 0 {
+			t.Errorf("Expected no changes, got %v, %v", changes, err)
+		}
+	})
+
+	t.Run("Volatile", func(t *testing.T) {
+		b := loadPacs008Sample(t)
+		b.FICustomerCreditTransfer.GroupHeader.MessageID = "BBBBUS33-20240316-0001"
+		b.FICustomerCreditTransfer.GroupHeader.CreationDateTime = &ISODateTime{Time: time.Date(2024, 3, 16, 9, 0, 0, 0, time.UTC)}
+		if changes, err := Diff(a, b); err != nil || len(changes) != 0 {
+			t.Errorf("Expected the volatile elements to be ignored, got %v, %v", changes, err)
+		}
+		changes, err := Diff(a, b, IgnoreElements())
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []Change{
+			{Kind: Modified, Path: "FIToFICstmrCdtTrf/GrpHdr/MsgId", Old: "BBBBUS33-20240315-0001", New: "BBBBUS33-20240316-0001"},
+			{Kind: Modified, Path: "FIToFICstmrCdtTrf/GrpHdr/CreDtTm", Old: "2024-03-15T09:30:47.000Z", New: "2024-03-16T09:00:00Z"},
+		}
+		if !reflect.DeepEqual(changes, want) {
+			t.Errorf("Got %v, want %v", changes, want)
+		}
+	})
+
+	t.Run("Changes", func(t *testing.T) {
+		b := loadPacs008Sample(t)
+		tx := &b.FICustomerCreditTransfer.CreditTransferTransactionInfo[0]
+		tx.InterbankSettlementAmount = ActiveCurrencyAndAmount{Value: 15250.5, Currency: "EUR"}
+		tx.PaymentID.InstructionID = nil
+		tx.RemittanceInfo.Unstructured = []string{"INV-2024-0042"}
+		tx.ChargeBearer = "DEBT"
+
+		changes, err := Diff(a, b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []Change{
+			{Kind: Removed, Path: "FIToFICstmrCdtTrf/CdtTrfTxInf[1]/PmtId/InstrId", Old: "BBBBUS33-INSTR-0001"},
+			{Kind: Modified, Path: "FIToFICstmrCdtTrf/CdtTrfTxInf[1]/IntrBkSttlmAmt", Old: "15000", New: "15250.5"},
+			{Kind: Modified, Path: "FIToFICstmrCdtTrf/CdtTrfTxInf[1]/IntrBkSttlmAmt/@Ccy", Old: "USD", New: "EUR"},
+			{Kind: Modified, Path: "FIToFICstmrCdtTrf/CdtTrfTxInf[1]/ChrgBr", Old: "SHAR", New: "DEBT"},
+			{Kind: Added, Path: "FIToFICstmrCdtTrf/CdtTrfTxInf[1]/RmtInf/Ustrd[1]", New: "INV-2024-0042"},
+		}
+		if !reflect.DeepEqual(changes, want) {
+			t.Errorf("Got %v, want %v", changes, want)
+		}
+
+		// Ignoring the amount by its path leaves the other changes
+		changes, err = Diff(a, b, IgnoreElements("CdtTrfTxInf/IntrBkSttlmAmt", "Ustrd"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := []Change{want[0], want[3]}; !reflect.DeepEqual(changes, want) {
+			t.Errorf("Got %v, want %v", changes, want)
+		}
+	})
+
+	t.Run("Fixtures", func(t *testing.T) {
+		files, err := filepath.Glob(filepath.Join("testdata", "roundtrip", "*", "*.xml"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, file := range files {
+			data, err := os.ReadFile(file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			newDocument := roundTripDocuments[filepath.Base(filepath.Dir(file))]
+			a, b := newDocument(), newDocument()
+			if err := xml.Unmarshal(data, a); err != nil {
+				t.Fatalf("%s: %v", file, err)
+			}
+			if err := xml.Unmarshal(data, b); err != nil {
+				t.Fatalf("%s: %v", file, err)
+			}
+			if changes, err := Diff(a, b, IgnoreElements()); err != nil || len(changes) != 0 {
+				t.Errorf("%s: expected no changes, got %v, %v", file, changes, err)
+			}
+		}
+	})
+
+	t.Run("Types", func(t *testing.T) {
+		if _, err := Diff(a, new(Pacs00900108Document)); err == nil {
+			t.Errorf("Expected documents of different types to be rejected")
+		}
+	})
+}
+
+func TestChangeString(t *testing.T) {
+	tests := []struct {
+		change Change
+		want   string
+	}{
+		{Change{Kind: Modified, Path: "GrpHdr/NbOfTxs", Old: "1", New: "2"}, `~ GrpHdr/NbOfTxs: "1" -> "2"`},
+		{Change{Kind: Added, Path: "RmtInf/Ustrd[1]", New: "INV"}, `+ RmtInf/Ustrd[1]: "INV"`},
+		{Change{Kind: Removed, Path: "PmtId/InstrId", Old: "ID"}, `- PmtId/InstrId: "ID"`},
+	}
+	for _, tt := range tests {
+		if got := tt.change.String(); got != tt.want {
+			t.Errorf("Got %q, want %q", got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package iso20022
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// namespacePrefix is the part of a document namespace before the message name
+// identification
+const namespacePrefix = "urn:iso:std:iso:20022:tech:xsd:"
+
+// ErrUnknownMessage is returned for a document whose namespace names none of the
+// messages of this package
+var ErrUnknownMessage = errors.New("unknown message")
+
+// documentTypes returns a new document of each message of this package
+var documentTypes = []func() interface{}{
+	func() interface{} { return new(Pacs00800102Document) },
+	func() interface{} { return new(Pacs00800106Document) },
+	func() interface{} { return new(Pacs00800108Document) },
+	func() interface{} { return new(Pacs00800110Document) },
+	func() interface{} { return new(Pacs00800112Document) },
+	func() interface{} { return new(Pacs00900108Document) },
+	func() interface{} { return new(Pacs00200103Document) },
+	func() interface{} { return new(Pacs00200110Document) },
+	func() interface{} { return new(Pacs00200112Document) },
+	func() interface{} { return new(Pacs00200114Document) },
+	func() interface{} { return new(Pacs00400110Document) },
+	func() interface{} { return new(Pacs02800103Document) },
+	func() interface{} { return new(Camt02500105Document) },
+	func() interface{} { return new(Camt02600107Document) },
+	func() interface{} { return new(Camt02700107Document) },
+	func() interface{} { return new(Camt02800109Document) },
+	func() interface{} { return new(Camt02900109Document) },
+	func() interface{} { return new(Camt02900113Document) },
+	func() interface{} { return new(Camt03000105Document) },
+	func() interface{} { return new(Camt03100106Document) },
+	func() interface{} { return new(Camt03500105Document) },
+	func() interface{} { return new(Camt04600105Document) },
+	func() interface{} { return new(Camt04700106Document) },
+	func() interface{} { return new(Camt04800105Document) },
+	func() interface{} { return new(Camt04900105Document) },
+	func() interface{} { return new(Camt05000105Document) },
+	func() interface{} { return new(Camt05100105Document) },
+	func() interface{} { return new(Camt05200108Document) },
+	func() interface{} { return new(Camt05400108Document) },
+	func() interface{} { return new(Camt05500109Document) },
+	func() interface{} { return new(Camt05600108Document) },
+	func() interface{} { return new(Camt05600111Document) },
+	func() interface{} { return new(Camt06000105Document) },
+	func() interface{} { return new(Camt08700108Document) },
+	func() interface{} { return new(Pain00100109Document) },
+	func() interface{} { return new(Pain01300107Document) },
+	func() interface{} { return new(Pain01400107Document) },
+	func() interface{} { return new(Admi00200101Document) },
+	func() interface{} { return new(Admi00400102Document) },
+	func() interface{} { return new(Admi00500101Document) },
+	func() interface{} { return new(Admi00600101Document) },
+	func() interface{} { return new(Admi00700101Document) },
+	func() interface{} { return new(Admi00900102Document) },
+	func() interface{} { return new(Admi01000101Document) },
+	func() interface{} { return new(Admi01100101Document) },
+	func() interface{} { return new(Admi99800102Document) },
+	func() interface{} { return new(Acmt02300103Document) },
+	func() interface{} { return new(Acmt02400103Document) },
+	func() interface{} { return new(Remt00100105Document) },
+	func() interface{} { return new(BusinessApplicationHeaderDocument) },
+}
+
+// documentsByType maps message name identifications, such as pacs.008.001.08, to
+// the constructors of their documents, read from the namespace of their XMLName
+var documentsByType = func() map[string]func() interface{} {
+	byType := make(map[string]func() interface{}, len(documentTypes))
+	for _, newDocument := range documentTypes {
+		byType[documentMessageType(newDocument())] = newDocument
+	}
+	return byType
+}()
+
+// MessageTypes returns the message name identifications of the documents of this
+// package, in order
+func MessageTypes() []string {
+	types := make([]string, 0, len(documentsByType))
+	for msgType := range documentsByType {
+		types = append(types, msgType)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// NewDocument returns a new document of the message with the given name
+// identification, such as pacs.008.001.08, and whether the package has it
+func NewDocument(msgType string) (interface{}, bool) {
+	newDocument, ok := documentsByType[msgType]
+	if !ok {
+		return nil, false
+	}
+	return newDocument(), true
+}
+
+// MessageType returns the message name identification of a document, read from the
+// namespace of its document element without decoding the rest of it. A namespace
+// registered with RegisterNamespace gives the message it was registered for.
+func MessageType(data []byte) (string, error) {
+	d := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		tok, err := d.Token()
+		if err == io.EOF {
+			return "", io.ErrUnexpectedEOF
+		}
+		if err != nil {
+			return "", err
+		}
+		if start, ok := tok.(xml.StartElement); ok {
+			if canonical, ok := canonicalNamespace(start.Name.Space); ok {
+				return strings.TrimPrefix(canonical, namespacePrefix), nil
+			}
+			if !strings.HasPrefix(start.Name.Space, namespacePrefix) {
+				return "", fmt.Errorf("%w: <%s xmlns=%q>", ErrUnknownMessage, start.Name.Local, start.Name.Space)
+			}
+			return strings.TrimPrefix(start.Name.Space, namespacePrefix), nil
+		}
+	}
+}
+
+// DecodeDocument decodes a document of any message of this package, telling its
+// message from its namespace
+func DecodeDocument(data []byte, opts ...DecodeOption) (msgType string, doc interface{}, err error) {
+	return ParseContext(context.Background(), data, opts...)
+}
+
+// ParseContext decodes a document as DecodeDocument does, stopping with ctx.Err()
+// once ctx is done, so that the parse of a large file can be cancelled or given a
+// deadline
+func ParseContext(ctx context.Context, data []byte, opts ...DecodeOption) (msgType string, doc interface{}, err error) {
+	if err := ctx.Err(); err != nil {
+		return "", nil, err
+	}
+	msgType, err = MessageType(data)
+	if err != nil {
+		return "", nil, err
+	}
+	doc, ok := NewDocument(msgType)
+	if !ok {
+		return msgType, nil, fmt.Errorf("%w: %s", ErrUnknownMessage, msgType)
+	}
+	if err := unmarshalContext(ctx, data, doc, opts...); err != nil {
+		return msgType, nil, err
+	}
+	return msgType, doc, nil
+}
@@ -0,0 +1,66 @@
+package iso20022
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestDecodeDocument(t *testing.T) {
+	if got := len(MessageTypes()); got != len(documentTypes) {
+		t.Errorf("Expected a message type per document, got %d for %d documents", got, len(documentTypes))
+	}
+	for msgType, newDocument := range roundTripDocuments {
+		doc, ok := NewDocument(msgType)
+		if !ok {
+			t.Errorf("No document for %s", msgType)
+			continue
+		}
+		if want := newDocument(); reflect.TypeOf(doc) != reflect.TypeOf(want) {
+			t.Errorf("Expected a %T for %s, got %T", want, msgType, doc)
+		}
+	}
+
+	data, err := os.ReadFile(filepath.Join("testdata", "roundtrip", "camt.054.001.08", "debit_credit_notification.xml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	msgType, doc, err := DecodeDocument(data)
+	if err != nil {
+		t.Fatalf("DecodeDocument failed: %v", err)
+	}
+	if msgType != "camt.054.001.08" || len(doc.(*Camt05400108Document).BankDebitCreditNotification.Notification) == 0 {
+		t.Errorf("Unexpected %s document %+v", msgType, doc)
+	}
+
+	if _, _, err := DecodeDocument([]byte(`<Document xmlns="urn:iso:std:iso:20022:tech:xsd:pacs.008.001.13"/>`)); !errors.Is(err, ErrUnknownMessage) {
+		t.Errorf("Expected ErrUnknownMessage for an unsupported version, got %v", err)
+	}
+	if _, err := MessageType([]byte(`<Invoice xmlns="urn:example"/>`)); !errors.Is(err, ErrUnknownMessage) {
+		t.Errorf("Expected ErrUnknownMessage for another namespace, got %v", err)
+	}
+}
+
+func TestParseContext(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("testdata", "roundtrip", "pacs.008.001.08", "customer_credit_transfer.xml"))
+	if err != nil {
+		t.Fatalf("Failed to read fixture: %v", err)
+	}
+	msgType, doc, err := ParseContext(context.Background(), data)
+	if err != nil || msgType != "pacs.008.001.08" || doc.(*Pacs00800108Document).FICustomerCreditTransfer.GroupHeader.MessageID != "BBBBUS33-20240315-0001" {
+		t.Fatalf("Expected the sample to be parsed, got %s, %v", msgType, err)
+	}
+
+	// The context is checked while reading the tokens
+	if _, _, err := ParseContext(newCountdownContext(1), data); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected the deadline to stop the parse, got %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, _, err := ParseContext(ctx, data); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected the cancellation to be reported, got %v", err)
+	}
+}
@@ -0,0 +1,182 @@
+package iso20022
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// defaultLocation is the location ISODateTime and ISOTime values are converted to when marshaling
+// outside an Encoder. A nil location keeps each value's own offset.
+var defaultLocation atomic.Pointer[time.Location]
+
+// encoderLocations maps the xml.Encoder of an in-flight Encoder.Encode call to its location
+var encoderLocations sync.Map
+
+// SetDateTimeLocation sets the location that all ISODateTime and ISOTime values are converted to
+// when marshaled, for example time.UTC to always emit a Z suffix. Passing nil restores the default
+// of serializing each value with its own offset. Encoders created with WithDateTimeLocation override
+// this setting.
+func SetDateTimeLocation(loc *time.Location) {
+	defaultLocation.Store(loc)
+}
+
+// EncodeOption configures an Encoder
+type EncodeOption func(*Encoder)
+
+// WithDateTimeLocation converts every ISODateTime and ISOTime value written by the encoder to loc.
+// Use time.UTC for clearing systems that only accept the Z designator, or time.Local to emit the
+// local offset.
+func WithDateTimeLocation(loc *time.Location) EncodeOption {
+	return func(e *Encoder) {
+		e.location = loc
+	}
+}
+
+// WithIndent indents the output as xml.Encoder.Indent does
+func WithIndent(prefix, indent string) EncodeOption {
+	return func(e *Encoder) {
+		e.enc.Indent(prefix, indent)
+	}
+}
+
+// WithNamespace writes the document element in namespace rather than in the
+// namespace of its message, for market infrastructures that use a modified one;
+// see RegisterNamespace
+func WithNamespace(namespace string) EncodeOption {
+	return func(e *Encoder) {
+		e.namespace = namespace
+	}
+}
+
+// WithSequenceCheck makes the encoder fail with ErrSequence, before writing
+// anything, for a document with a component whose fields are declared in another
+// order than its XSD sequence. encoding/xml writes elements in field order, so
+// such a document would fail schema validation downstream.
+func WithSequenceCheck() EncodeOption {
+	return func(e *Encoder) {
+		e.checkSequences = true
+	}
+}
+
+// Encoder writes ISO 20022 documents as XML with consistent datetime serialization
+type Encoder struct {
+	enc            *xml.Encoder
+	out            *countingWriter
+	location       *time.Location
+	namespace      string
+	checkSequences bool
+}
+
+// NewEncoder returns an Encoder writing to w
+func NewEncoder(w io.Writer, opts ...EncodeOption) *Encoder {
+	out := &countingWriter{w: w}
+	e := &Encoder{enc: xml.NewEncoder(out), out: out, location: defaultLocation.Load()}
+	for _, opt := range opts {
+		opt(e)
+	}
+	return e
+}
+
+// Encode writes the XML encoding of v
+func (e *Encoder) Encode(v interface{}) error {
+	ob := currentObserver()
+	if ob == nil {
+		return e.encode(v)
+	}
+	start, written := time.Now(), e.out.n
+	err := e.encode(v)
+	ob.Observe(context.Background(), Observation{
+		Operation:   OperationMarshal,
+		MessageType: documentMessageType(v),
+		Start:       start,
+		Duration:    time.Since(start),
+		Size:        e.out.n - written,
+		Err:         err,
+	})
+	return err
+}
+
+func (e *Encoder) encode(v interface{}) error {
+	if e.checkSequences && v != nil {
+		if err := checkSequences(reflect.TypeOf(v)); err != nil {
+			return err
+		}
+	}
+	if e.location != nil {
+		encoderLocations.Store(e.enc, e.location)
+		defer encoderLocations.Delete(e.enc)
+	}
+	if e.namespace == "" {
+		if err := e.enc.Encode(v); err != nil {
+			return err
+		}
+		return e.enc.Flush()
+	}
+	local, ok := documentElement(v)
+	if !ok {
+		return fmt.Errorf("%T has no document element name", v)
+	}
+	if err := e.enc.EncodeElement(v, xml.StartElement{Name: xml.Name{Space: e.namespace, Local: local}}); err != nil {
+		return err
+	}
+	return e.enc.Flush()
+}
+
+// encodeElement writes v as the element start, converting its datetimes as Encode
+// does
+func (e *Encoder) encodeElement(v interface{}, start xml.StartElement) error {
+	if e.location != nil {
+		encoderLocations.Store(e.enc, e.location)
+		defer encoderLocations.Delete(e.enc)
+	}
+	if err := e.enc.EncodeElement(v, start); err != nil {
+		return err
+	}
+	return e.enc.Flush()
+}
+
+// marshalBuffers holds the buffers Marshal encodes into, so that marshaling one
+// document after another reuses the capacity grown for the previous ones
+var marshalBuffers = sync.Pool{New: func() interface{} { return new(bytes.Buffer) }}
+
+// maxPooledBuffer is the capacity above which a buffer is left to the garbage
+// collector rather than kept for the next Marshal
+const maxPooledBuffer = 64 << 20
+
+// Marshal returns the XML encoding of v using the given options
+func Marshal(v interface{}, opts ...EncodeOption) ([]byte, error) {
+	buf := marshalBuffers.Get().(*bytes.Buffer)
+	defer func() {
+		if buf.Cap() <= maxPooledBuffer {
+			buf.Reset()
+			marshalBuffers.Put(buf)
+		}
+	}()
+	if err := NewEncoder(buf, opts...).Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.Clone(buf.Bytes()), nil
+}
+
+// marshalLocation returns the location values encoded by e should be converted to, or nil
+func marshalLocation(e *xml.Encoder) *time.Location {
+	if loc, ok := encoderLocations.Load(e); ok {
+		return loc.(*time.Location)
+	}
+	return defaultLocation.Load()
+}
+
+// inLocation converts t to the marshal location of e
+func inLocation(e *xml.Encoder, t time.Time) time.Time {
+	if loc := marshalLocation(e); loc != nil {
+		return t.In(loc)
+	}
+	return t
+}
@@ -0,0 +1,96 @@
+package iso20022
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDateTimeLocation(t *testing.T) {
+	cet := time.FixedZone("CET", 3600)
+	hdr := MessageHeader1{
+		MessageID:        "MSG001",
+		CreationDateTime: Ptr(NewISODateTime(time.Date(2023, 1, 1, 10, 0, 0, 0, cet))),
+	}
+
+	t.Run("Preserve offset by default", func(t *testing.T) {
+		data, err := xml.Marshal(hdr)
+		if err != nil {
+			t.Fatalf("Failed to marshal: %v", err)
+		}
+		if !strings.Contains(string(data), "<CreDtTm>2023-01-01T10:00:00+01:00</CreDtTm>") {
+			t.Errorf("Expected original offset, got %s", data)
+		}
+	})
+
+	t.Run("Encoder option", func(t *testing.T) {
+		data, err := Marshal(hdr, WithDateTimeLocation(time.UTC))
+		if err != nil {
+			t.Fatalf("Failed to marshal: %v", err)
+		}
+		if !strings.Contains(string(data), "<CreDtTm>2023-01-01T09:00:00Z</CreDtTm>") {
+			t.Errorf("Expected UTC datetime, got %s", data)
+		}
+
+		// The option must not leak into plain xml.Marshal calls
+		data, _ = xml.Marshal(hdr)
+		if !strings.Contains(string(data), "+01:00") {
+			t.Errorf("Expected encoder option to be scoped to the encoder, got %s", data)
+		}
+	})
+
+	t.Run("Package setting", func(t *testing.T) {
+		SetDateTimeLocation(time.UTC)
+		defer SetDateTimeLocation(nil)
+
+		data, err := xml.Marshal(hdr)
+		if err != nil {
+			t.Fatalf("Failed to marshal: %v", err)
+		}
+		if !strings.Contains(string(data), "<CreDtTm>2023-01-01T09:00:00Z</CreDtTm>") {
+			t.Errorf("Expected UTC datetime, got %s", data)
+		}
+
+		data, _ = Marshal(hdr, WithDateTimeLocation(cet))
+		if !strings.Contains(string(data), "+01:00") {
+			t.Errorf("Expected encoder option to override package setting, got %s", data)
+		}
+	})
+
+	t.Run("Indent", func(t *testing.T) {
+		data, err := Marshal(hdr, WithIndent("", "  "))
+		if err != nil {
+			t.Fatalf("Failed to marshal: %v", err)
+		}
+		if !strings.Contains(string(data), "\n  <MsgId>MSG001</MsgId>") {
+			t.Errorf("Expected indented output, got %s", data)
+		}
+	})
+}
+
+func TestMarshalBuffers(t *testing.T) {
+	first, err := Marshal(ActiveCurrencyAndAmount{Value: 1250, Currency: "EUR"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := Marshal(ActiveCurrencyAndAmount{Value: 7, Currency: "USD"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(first) != `<ActiveCurrencyAndAmount Ccy="EUR">1250</ActiveCurrencyAndAmount>` {
+		t.Errorf("Expected the first result to be left alone by the second Marshal, got %s", first)
+	}
+	if string(second) != `<ActiveCurrencyAndAmount Ccy="USD">7</ActiveCurrencyAndAmount>` {
+		t.Errorf("Unexpected second result %s", second)
+	}
+
+	// Character data is read around comments and nested elements, as DecodeElement does
+	var amount ActiveCurrencyAndAmount
+	if err := xml.Unmarshal([]byte(`<Amt Ccy="EUR"> 12<!-- c --><X>9</X>.50 </Amt>`), &amount); err != nil {
+		t.Fatal(err)
+	}
+	if amount.Value != 12.5 || amount.Currency != "EUR" {
+		t.Errorf("Unexpected amount %+v", amount)
+	}
+}
@@ -0,0 +1,170 @@
+// Package fileact packages ISO 20022 messages for delivery over SWIFT FileAct
+// through the file transfer interface of Alliance Access.
+//
+// A payload is delivered as two files: the payload itself, here a Document or a
+// business file, and a parameter file describing the transfer, with the service,
+// request type, sender and receiver distinguished names and the name of the
+// payload file. Both are protected by local authentication (LAU): an HMAC-SHA256
+// of the payload keyed with the LAU key shared by the back office and Alliance
+// Access, written as 64 uppercase hexadecimal digits. The parameter file carries
+// the LAU of its payload; messages exchanged without a parameter file carry it in
+// a trailer after the payload, {S:{MDG:<hmac>}}, as FIN messages do.
+package fileact
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ckbaum/iso20022-go"
+)
+
+// Errors returned by Verify, VerifyTrailer and Open
+var (
+	ErrNoTrailer = errors.New("fileact: payload has no LAU trailer")
+	ErrLAU       = errors.New("fileact: LAU does not match the payload")
+)
+
+// Delimiters of the LAU trailer
+const (
+	trailerStart = "{S:{MDG:"
+	trailerEnd   = "}}"
+)
+
+// keyHalfLength is the length of each half of a LAU key
+const keyHalfLength = 16
+
+// Key is a LAU key: the left and right halves of 16 characters each, as entered
+// in Alliance Access, joined
+type Key []byte
+
+// NewKey returns the LAU key of the left and right halves
+func NewKey(left, right string) (Key, error) {
+	if len(left) != keyHalfLength || len(right) != keyHalfLength {
+		return nil, fmt.Errorf("fileact: each half of a LAU key must be %d characters long", keyHalfLength)
+	}
+	return Key(left + right), nil
+}
+
+// Sign returns the LAU of payload: its HMAC-SHA256 keyed with key, in uppercase
+// hexadecimal
+func Sign(payload []byte, key Key) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(payload)
+	return strings.ToUpper(hex.EncodeToString(mac.Sum(nil)))
+}
+
+// Verify checks that lau is the LAU of payload
+func Verify(payload []byte, lau string, key Key) error {
+	want, err := hex.DecodeString(lau)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrLAU, err)
+	}
+	mac := hmac.New(sha256.New, key)
+	mac.Write(payload)
+	if !hmac.Equal(mac.Sum(nil), want) {
+		return ErrLAU
+	}
+	return nil
+}
+
+// AppendTrailer returns payload followed by its LAU trailer
+func AppendTrailer(payload []byte, key Key) []byte {
+	signed := make([]byte, 0, len(payload)+len(trailerStart)+sha256.Size*2+len(trailerEnd))
+	signed = append(signed, payload...)
+	signed = append(signed, trailerStart...)
+	signed = append(signed, Sign(payload, key)...)
+	return append(signed, trailerEnd...)
+}
+
+// VerifyTrailer checks the LAU trailer of signed and returns the payload before it
+func VerifyTrailer(signed []byte, key Key) ([]byte, error) {
+	i := bytes.LastIndex(signed, []byte(trailerStart))
+	if i < 0 || !bytes.HasSuffix(signed, []byte(trailerEnd)) {
+		return nil, ErrNoTrailer
+	}
+	payload := signed[:i]
+	lau := string(signed[i+len(trailerStart) : len(signed)-len(trailerEnd)])
+	if err := Verify(payload, lau, key); err != nil {
+		return nil, err
+	}
+	return payload, nil
+}
+
+// Parameters is the parameter file of a FileAct transfer
+type Parameters struct {
+	XMLName             xml.Name `xml:"FileActParameters"`
+	Service             string   `xml:"Service"`                       // such as swift.generic.fa
+	RequestType         string   `xml:"RequestType,omitempty"`         // message of the payload, such as pacs.008.001.08
+	Requestor           string   `xml:"Requestor"`                     // DN of the sender, such as o=bbbbus33,o=swift
+	Responder           string   `xml:"Responder"`                     // DN of the receiver
+	Priority            string   `xml:"Priority,omitempty"`            // Normal or Urgent
+	NonRepudiation      bool     `xml:"NonRepudiation,omitempty"`      // ask for non-repudiation of emission
+	FileName            string   `xml:"FileName"`                      // name of the payload file
+	FileDescription     string   `xml:"FileDescription,omitempty"`     // free text delivered with the file
+	FileInfo            string   `xml:"FileInfo,omitempty"`            // such as SwCompression=None
+	TransferDescription string   `xml:"TransferDescription,omitempty"` // free text about the transfer
+	UserReference       string   `xml:"UserReference,omitempty"`       // reference of the sender
+	LAU                 string   `xml:"LAU"`                           // LAU of the payload file
+}
+
+// MarshalParameters returns the parameter file of p
+func MarshalParameters(p *Parameters) ([]byte, error) {
+	data, err := xml.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), data...), nil
+}
+
+// ParseParameters reads a parameter file
+func ParseParameters(data []byte) (*Parameters, error) {
+	p := new(Parameters)
+	if err := xml.Unmarshal(data, p); err != nil {
+		return nil, fmt.Errorf("fileact: parameter file: %w", err)
+	}
+	return p, nil
+}
+
+// Wrap returns the payload file of doc, an ISO 20022 document or business file,
+// and its parameter file: p with the request type set to the message of doc when
+// empty and the LAU of the payload
+func Wrap(doc interface{}, p Parameters, key Key, opts ...iso20022.EncodeOption) (payload, params []byte, err error) {
+	payload, err = iso20022.Marshal(doc, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	if p.RequestType == "" {
+		if msgType, err := iso20022.MessageType(payload); err == nil {
+			p.RequestType = msgType
+		}
+	}
+	p.LAU = Sign(payload, key)
+	params, err = MarshalParameters(&p)
+	if err != nil {
+		return nil, nil, err
+	}
+	return payload, params, nil
+}
+
+// Open checks the LAU of a received payload file against its parameter file and
+// decodes the document of the payload
+func Open(payload, params []byte, key Key, opts ...iso20022.DecodeOption) (*Parameters, string, interface{}, error) {
+	p, err := ParseParameters(params)
+	if err != nil {
+		return nil, "", nil, err
+	}
+	if err := Verify(payload, p.LAU, key); err != nil {
+		return p, "", nil, fmt.Errorf("%s: %w", p.FileName, err)
+	}
+	msgType, doc, err := iso20022.DecodeDocument(payload, opts...)
+	if err != nil {
+		return p, msgType, nil, err
+	}
+	return p, msgType, doc, nil
+}
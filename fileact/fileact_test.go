@@ -0,0 +1,103 @@
+package fileact
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ckbaum/iso20022-go"
+)
+
+func testKey(t *testing.T) Key {
+	t.Helper()
+	key, err := NewKey("Abcd1234Efgh5678", "Ijkl9012Mnop3456")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func TestSign(t *testing.T) {
+	key := testKey(t)
+	payload := []byte("<Document/>")
+	mac := hmac.New(sha256.New, []byte("Abcd1234Efgh5678Ijkl9012Mnop3456"))
+	mac.Write(payload)
+	want := strings.ToUpper(hex.EncodeToString(mac.Sum(nil)))
+	if got := Sign(payload, key); got != want || len(got) != 64 {
+		t.Errorf("Expected %s, got %s", want, got)
+	}
+	if err := Verify(payload, want, key); err != nil {
+		t.Errorf("Expected the LAU to verify, got %v", err)
+	}
+	if err := Verify([]byte("<Document />"), want, key); !errors.Is(err, ErrLAU) {
+		t.Errorf("Expected a changed payload to fail, got %v", err)
+	}
+	if _, err := NewKey("short", "Ijkl9012Mnop3456"); err == nil {
+		t.Error("Expected a short key half to fail")
+	}
+}
+
+func TestTrailer(t *testing.T) {
+	key := testKey(t)
+	payload := []byte("<Document>{S:{MDG:in the text}}</Document>")
+	signed := AppendTrailer(payload, key)
+	if !bytes.HasSuffix(signed, []byte("{S:{MDG:"+Sign(payload, key)+"}}")) {
+		t.Errorf("Unexpected trailer: %s", signed)
+	}
+	got, err := VerifyTrailer(signed, key)
+	if err != nil || !bytes.Equal(got, payload) {
+		t.Errorf("Expected the payload back, got %s, %v", got, err)
+	}
+
+	other, _ := NewKey("Abcd1234Efgh5678", "Ijkl9012Mnop3457")
+	if _, err := VerifyTrailer(signed, other); !errors.Is(err, ErrLAU) {
+		t.Errorf("Expected another key to fail, got %v", err)
+	}
+	if _, err := VerifyTrailer(payload, key); !errors.Is(err, ErrNoTrailer) {
+		t.Errorf("Expected no trailer, got %v", err)
+	}
+}
+
+func TestWrapAndOpen(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("..", "testdata", "roundtrip", "pacs.008.001.08", "customer_credit_transfer.xml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc := new(iso20022.Pacs00800108Document)
+	if err := iso20022.Unmarshal(data, doc); err != nil {
+		t.Fatal(err)
+	}
+	key := testKey(t)
+	payload, params, err := Wrap(doc, Parameters{
+		Service:   "swift.generic.fa",
+		Requestor: "o=bbbbus33,o=swift",
+		Responder: "o=ccccgb2l,o=swift",
+		FileName:  "BBBBUS33-20240315-0001.xml",
+	}, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(params), "<RequestType>pacs.008.001.08</RequestType>") || !strings.Contains(string(params), "<LAU>"+Sign(payload, key)+"</LAU>") {
+		t.Errorf("Expected the request type and LAU in the parameters, got %s", params)
+	}
+
+	p, msgType, got, err := Open(payload, params, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.FileName != "BBBBUS33-20240315-0001.xml" || msgType != "pacs.008.001.08" ||
+		got.(*iso20022.Pacs00800108Document).FICustomerCreditTransfer.GroupHeader.MessageID != "BBBBUS33-20240315-0001" {
+		t.Errorf("Expected the payload to be opened, got %+v, %s", p, msgType)
+	}
+
+	tampered := bytes.Replace(payload, []byte("BBBBUS33-20240315-0001"), []byte("BBBBUS33-20240315-0002"), 1)
+	if _, _, _, err := Open(tampered, params, key); !errors.Is(err, ErrLAU) {
+		t.Errorf("Expected a tampered payload to fail, got %v", err)
+	}
+}
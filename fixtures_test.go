@@ -0,0 +1,30 @@
+package iso20022
+
+import (
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// loadSample unmarshals the fixture name, a path under testdata/roundtrip, into a
+// new T
+func loadSample[T any](t testing.TB, name string) *T {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", "roundtrip", filepath.FromSlash(name)))
+	if err != nil {
+		t.Fatalf("Failed to read fixture: %v", err)
+	}
+	doc := new(T)
+	if err := xml.Unmarshal(data, doc); err != nil {
+		t.Fatalf("Failed to unmarshal fixture: %v", err)
+	}
+	return doc
+}
+
+// loadPacs008Sample returns the pacs.008 round-trip fixture, which satisfies every
+// business rule
+func loadPacs008Sample(t *testing.T) *Pacs00800108Document {
+	t.Helper()
+	return loadSample[Pacs00800108Document](t, "pacs.008.001.08/customer_credit_transfer.xml")
+}
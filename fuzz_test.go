@@ -0,0 +1,149 @@
+package iso20022
+
+import (
+	"bytes"
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// fuzzDocument feeds arbitrary XML into the document type T. Whatever the input,
+// unmarshaling and validating must not panic, and a document that unmarshals must
+// marshal into XML that unmarshals again and marshals into the same bytes. The
+// corpus is seeded with the round-trip fixtures of the message, when it has any, an
+// empty document and a few hostile shapes of it.
+func fuzzDocument[T any](f *testing.F, corpus string) {
+	empty, err := xml.Marshal(new(T))
+	if err != nil {
+		f.Fatalf("Failed to marshal an empty document: %v", err)
+	}
+	f.Add(empty)
+	if corpus != "" {
+		files, err := filepath.Glob(filepath.Join("testdata", "roundtrip", corpus, "*.xml"))
+		if err != nil {
+			f.Fatal(err)
+		}
+		for _, file := range files {
+			data, err := os.ReadFile(file)
+			if err != nil {
+				f.Fatal(err)
+			}
+			f.Add(data)
+		}
+	}
+	// Wrap the content of the document element in hostile shapes: deep nesting of an
+	// unknown element, attributes and text where none are expected, and numbers and
+	// dates that do not parse
+	open, end := string(empty[:bytes.IndexByte(empty, '>')]), "</Document>"
+	for _, content := range []string{
+		strings.Repeat("<X>", 1000) + strings.Repeat("</X>", 1000),
+		`<GrpHdr Ccy="EUR">text<MsgId/><CreDtTm>not a date</CreDtTm><NbOfTxs>-1</NbOfTxs><CtrlSum>1e999</CtrlSum></GrpHdr>`,
+		`<CdtTrfTxInf><IntrBkSttlmAmt>NaN</IntrBkSttlmAmt><IntrBkSttlmDt>2024-02-30</IntrBkSttlmDt></CdtTrfTxInf>`,
+	} {
+		f.Add([]byte(open + ">" + content + end))
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		doc := new(T)
+		if v, ok := any(doc).(Validator); ok {
+			if err := ValidateXML(data, v); err != nil {
+				if _, invalid := err.(ValidationErrors); !invalid {
+					return
+				}
+			}
+		} else if err := xml.Unmarshal(data, doc); err != nil {
+			return
+		}
+
+		first, err := xml.Marshal(doc)
+		if err != nil {
+			// Values that unmarshal but cannot be marshaled again are not round-trip
+			// stable either
+			t.Fatalf("Failed to marshal the unmarshaled document: %v", err)
+		}
+		again := new(T)
+		if err := xml.Unmarshal(first, again); err != nil {
+			t.Fatalf("Failed to unmarshal the marshaled document: %v\n%s", err, first)
+		}
+		second, err := xml.Marshal(again)
+		if err != nil {
+			t.Fatalf("Failed to marshal the document again: %v", err)
+		}
+		if !bytes.Equal(first, second) {
+			t.Fatalf("Round trip is not stable:\n%s\n%s", first, second)
+		}
+	})
+}
+
+func FuzzPacs008(f *testing.F) { fuzzDocument[Pacs00800108Document](f, "pacs.008.001.08") }
+func FuzzPacs009(f *testing.F) { fuzzDocument[Pacs00900108Document](f, "") }
+func FuzzPacs002(f *testing.F) { fuzzDocument[Pacs00200110Document](f, "pacs.002.001.10") }
+func FuzzPacs004(f *testing.F) { fuzzDocument[Pacs00400110Document](f, "pacs.004.001.10") }
+func FuzzPacs028(f *testing.F) { fuzzDocument[Pacs02800103Document](f, "") }
+func FuzzCamt052(f *testing.F) { fuzzDocument[Camt05200108Document](f, "camt.052.001.08") }
+func FuzzCamt054(f *testing.F) { fuzzDocument[Camt05400108Document](f, "camt.054.001.08") }
+func FuzzCamt055(f *testing.F) { fuzzDocument[Camt05500109Document](f, "") }
+func FuzzCamt056(f *testing.F) { fuzzDocument[Camt05600108Document](f, "camt.056.001.08") }
+func FuzzCamt060(f *testing.F) { fuzzDocument[Camt06000105Document](f, "") }
+func FuzzCamt026(f *testing.F) { fuzzDocument[Camt02600107Document](f, "") }
+func FuzzCamt035(f *testing.F) { fuzzDocument[Camt03500105Document](f, "camt.035.001.05") }
+func FuzzCamt027(f *testing.F) { fuzzDocument[Camt02700107Document](f, "") }
+func FuzzCamt030(f *testing.F) { fuzzDocument[Camt03000105Document](f, "") }
+func FuzzCamt031(f *testing.F) { fuzzDocument[Camt03100106Document](f, "") }
+func FuzzCamt028(f *testing.F) { fuzzDocument[Camt02800109Document](f, "") }
+func FuzzCamt029(f *testing.F) { fuzzDocument[Camt02900109Document](f, "camt.029.001.09") }
+func FuzzCamt087(f *testing.F) { fuzzDocument[Camt08700108Document](f, "") }
+func FuzzCamt050(f *testing.F) { fuzzDocument[Camt05000105Document](f, "camt.050.001.05") }
+func FuzzCamt051(f *testing.F) { fuzzDocument[Camt05100105Document](f, "") }
+func FuzzCamt025(f *testing.F) { fuzzDocument[Camt02500105Document](f, "") }
+func FuzzCamt046(f *testing.F) { fuzzDocument[Camt04600105Document](f, "") }
+func FuzzCamt047(f *testing.F) { fuzzDocument[Camt04700106Document](f, "") }
+func FuzzCamt048(f *testing.F) { fuzzDocument[Camt04800105Document](f, "") }
+func FuzzCamt049(f *testing.F) { fuzzDocument[Camt04900105Document](f, "") }
+func FuzzPain001(f *testing.F) { fuzzDocument[Pain00100109Document](f, "pain.001.001.09") }
+func FuzzPain013(f *testing.F) { fuzzDocument[Pain01300107Document](f, "") }
+func FuzzPain014(f *testing.F) { fuzzDocument[Pain01400107Document](f, "") }
+func FuzzAdmi002(f *testing.F) { fuzzDocument[Admi00200101Document](f, "") }
+func FuzzAdmi004(f *testing.F) { fuzzDocument[Admi00400102Document](f, "") }
+func FuzzAdmi005(f *testing.F) { fuzzDocument[Admi00500101Document](f, "admi.005.001.01") }
+func FuzzAdmi006(f *testing.F) { fuzzDocument[Admi00600101Document](f, "") }
+func FuzzAdmi007(f *testing.F) { fuzzDocument[Admi00700101Document](f, "") }
+func FuzzAdmi009(f *testing.F) { fuzzDocument[Admi00900102Document](f, "") }
+func FuzzAdmi010(f *testing.F) { fuzzDocument[Admi01000101Document](f, "") }
+func FuzzAdmi011(f *testing.F) { fuzzDocument[Admi01100101Document](f, "") }
+func FuzzAdmi998(f *testing.F) { fuzzDocument[Admi99800102Document](f, "") }
+func FuzzAcmt023(f *testing.F) { fuzzDocument[Acmt02300103Document](f, "acmt.023.001.03") }
+func FuzzAcmt024(f *testing.F) { fuzzDocument[Acmt02400103Document](f, "") }
+func FuzzRemt001(f *testing.F) { fuzzDocument[Remt00100105Document](f, "remt.001.001.05") }
+func FuzzHead001(f *testing.F) { fuzzDocument[BusinessApplicationHeaderDocument](f, "head.001.001.02") }
+
+// Other versions of the messages
+func FuzzPacs008V02(f *testing.F) { fuzzDocument[Pacs00800102Document](f, "pacs.008.001.02") }
+func FuzzPacs008V06(f *testing.F) { fuzzDocument[Pacs00800106Document](f, "pacs.008.001.06") }
+func FuzzPacs008V10(f *testing.F) { fuzzDocument[Pacs00800110Document](f, "pacs.008.001.10") }
+func FuzzPacs008V12(f *testing.F) { fuzzDocument[Pacs00800112Document](f, "pacs.008.001.12") }
+func FuzzPacs002V03(f *testing.F) { fuzzDocument[Pacs00200103Document](f, "pacs.002.001.03") }
+func FuzzPacs002V12(f *testing.F) { fuzzDocument[Pacs00200112Document](f, "pacs.002.001.12") }
+func FuzzPacs002V14(f *testing.F) { fuzzDocument[Pacs00200114Document](f, "pacs.002.001.14") }
+func FuzzCamt056V11(f *testing.F) { fuzzDocument[Camt05600111Document](f, "camt.056.001.11") }
+func FuzzCamt029V13(f *testing.F) { fuzzDocument[Camt02900113Document](f, "camt.029.001.13") }
+
+// FuzzPacs008Headers feeds arbitrary input to the header-only parser, which scans
+// the skipped elements itself
+func FuzzPacs008Headers(f *testing.F) {
+	data, err := os.ReadFile(filepath.Join("testdata", "roundtrip", "pacs.008.001.08", "customer_credit_transfer.xml"))
+	if err != nil {
+		f.Fatal(err)
+	}
+	f.Add(data)
+	f.Add([]byte(`<Document xmlns="urn:iso:std:iso:20022:tech:xsd:pacs.008.001.08"><FIToFICstmrCdtTrf><CdtTrfTxInf><X a='>'/><!--`))
+	f.Fuzz(func(t *testing.T, data []byte) {
+		h, err := ParsePacs008Headers(data)
+		if err != nil {
+			return
+		}
+		h.Document()
+	})
+}
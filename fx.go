@@ -0,0 +1,88 @@
+package iso20022
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ckbaum/iso20022-go/currency"
+)
+
+// exchangeRateDigits is the number of fraction digits of a BaseOneRate
+const exchangeRateDigits = 10
+
+// MinorUnits returns the number of decimal places amounts in currency are rounded to,
+// two for the currencies without a minor unit in ISO 4217
+func MinorUnits(code string) int {
+	if units, ok := currency.MinorUnits(code); ok {
+		return units
+	}
+	return 2
+}
+
+// ConvertAmount converts an amount with an exchange rate, rounding the result half
+// away from zero to the minor unit of the target currency
+func ConvertAmount(amount, rate Decimal, currency string) Decimal {
+	converted := DecimalRat(amount)
+	converted.Mul(converted, DecimalRat(rate))
+	return RatDecimal(roundRat(converted, MinorUnits(currency)))
+}
+
+// SettlementAmountFor computes the interbank settlement amount of an instructed
+// amount converted at rate, the price of one unit of the instructed currency in the
+// settlement currency. Charges deducted on the way are not taken into account.
+func SettlementAmountFor(instructed ActiveOrHistoricCurrencyAndAmount, rate Decimal, currency string) ActiveCurrencyAndAmount {
+	return ActiveCurrencyAndAmount{Value: ConvertAmount(instructed.Value, rate, currency), Currency: currency}
+}
+
+// InstructedAmountFor computes the instructed amount that converts at rate into an
+// interbank settlement amount, rounded to the minor unit of the instructed currency
+func InstructedAmountFor(settlement ActiveCurrencyAndAmount, rate Decimal, currency string) (ActiveOrHistoricCurrencyAndAmount, error) {
+	if rate <= 0 {
+		return ActiveOrHistoricCurrencyAndAmount{}, fmt.Errorf("exchange rate must be positive, got %v", rate)
+	}
+	amount := new(big.Rat).Quo(DecimalRat(settlement.Value), DecimalRat(rate))
+	return ActiveOrHistoricCurrencyAndAmount{Value: RatDecimal(roundRat(amount, MinorUnits(currency))), Currency: currency}, nil
+}
+
+// ExchangeRateFor computes the rate that converts an instructed amount into an
+// interbank settlement amount, rounded to the ten fraction digits of a BaseOneRate
+func ExchangeRateFor(instructed ActiveOrHistoricCurrencyAndAmount, settlement ActiveCurrencyAndAmount) (Decimal, error) {
+	if instructed.Value <= 0 {
+		return 0, fmt.Errorf("instructed amount must be positive, got %v", instructed.Value)
+	}
+	rate := new(big.Rat).Quo(DecimalRat(settlement.Value), DecimalRat(instructed.Value))
+	return RatDecimal(roundRat(rate, exchangeRateDigits)), nil
+}
+
+// validateExchangeRate checks that InstdAmt converted at XchgRate, less the charges
+// deducted on the way, rounds to IntrBkSttlmAmt in the minor unit of the settlement
+// currency. Transactions without all three elements, or with a single currency, are
+// left to the InstructedAmountAndExchangeRateRule.
+func (c *CreditTransferTransaction39) validateExchangeRate() ValidationErrors {
+	instructed, settlement := c.InstructedAmount, c.InterbankSettlementAmount
+	if instructed == nil || c.ExchangeRate == nil || instructed.Currency == settlement.Currency {
+		return nil
+	}
+	if *c.ExchangeRate <= 0 {
+		return ValidationErrors{newValidationError("XchgRate", RuleExchangeRate, "EXCHANGE_RATE_POSITIVE")}
+	}
+
+	// Charges that cannot be converted are reported by ReconcileCharges
+	charges, _ := c.ReconcileCharges()
+	received := DecimalRat(settlement.Value)
+	received.Add(received, DecimalRat(charges.DeductedCharges))
+
+	converted := DecimalRat(ConvertAmount(instructed.Value, *c.ExchangeRate, settlement.Currency))
+	if converted.Cmp(received) == 0 {
+		return nil
+	}
+	id := "EXCHANGE_RATE_MISMATCH"
+	// A rate quoted the other way round is a common mistake
+	inverse := new(big.Rat).Quo(DecimalRat(instructed.Value), DecimalRat(*c.ExchangeRate))
+	if roundRat(inverse, MinorUnits(settlement.Currency)).Cmp(received) == 0 {
+		id = "EXCHANGE_RATE_INVERTED"
+	}
+	return ValidationErrors{newValidationError("XchgRate", RuleExchangeRate, id,
+		formatRat(DecimalRat(instructed.Value)), instructed.Currency, formatRat(converted), settlement.Currency,
+		formatRat(received), settlement.Currency)}
+}
@@ -0,0 +1,83 @@
+package iso20022
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestConvertAmountRoundsToMinorUnit(t *testing.T) {
+	tests := []struct {
+		amount, rate Decimal
+		currency     string
+		want         Decimal
+	}{
+		{100, 1.08345, "USD", 108.35},
+		{100, 161.237, "JPY", 16124},
+		{1000, 0.376, "BHD", 376},
+		{12.345, 0.3771234, "KWD", 4.656},
+	}
+	for _, tt := range tests {
+		if got := ConvertAmount(tt.amount, tt.rate, tt.currency); got != tt.want {
+			t.Errorf("ConvertAmount(%v, %v, %s) = %v, want %v", tt.amount, tt.rate, tt.currency, got, tt.want)
+		}
+	}
+}
+
+func TestExchangeLegHelpers(t *testing.T) {
+	instructed := ActiveOrHistoricCurrencyAndAmount{Value: 10000, Currency: "EUR"}
+	settlement := SettlementAmountFor(instructed, 1.0834, "USD")
+	if settlement.Value != 10834 || settlement.Currency != "USD" {
+		t.Errorf("Expected 10834 USD, got %v %s", settlement.Value, settlement.Currency)
+	}
+
+	back, err := InstructedAmountFor(settlement, 1.0834, "EUR")
+	if err != nil || back != instructed {
+		t.Errorf("Expected %v, got %v (%v)", instructed, back, err)
+	}
+
+	rate, err := ExchangeRateFor(instructed, settlement)
+	if err != nil || rate != 1.0834 {
+		t.Errorf("Expected rate 1.0834, got %v (%v)", rate, err)
+	}
+
+	if _, err := InstructedAmountFor(settlement, 0, "EUR"); err == nil {
+		t.Error("Expected a zero rate to be rejected")
+	}
+}
+
+func TestValidateExchangeRate(t *testing.T) {
+	tests := []struct {
+		name       string
+		rate       Decimal
+		settlement Decimal
+		bearer     ChargeBearerType1Code
+		message    string // expected error message fragment, empty when consistent
+	}{
+		{"Consistent", 1.0834, 10834, "DEBT", ""},
+		{"WithinRounding", 1.08345, 10834.5, "DEBT", ""},
+		{"DeductedCharges", 1.0834, 10809, "CRED", ""},
+		{"Inconsistent", 1.0834, 10900, "DEBT", "converts InstdAmt 10000 EUR to 10834 USD"},
+		{"Inverted", 1.25, 8000, "DEBT", "the rate appears to be inverted"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx := &loadPacs008Sample(t).FICustomerCreditTransfer.CreditTransferTransactionInfo[0]
+			rate := tt.rate
+			tx.InstructedAmount = &ActiveOrHistoricCurrencyAndAmount{Value: 10000, Currency: "EUR"}
+			tx.ExchangeRate = &rate
+			tx.InterbankSettlementAmount.Value = tt.settlement
+			tx.ChargeBearer = tt.bearer
+
+			errs := tx.validateExchangeRate()
+			if tt.message == "" {
+				if errs.HasErrors() {
+					t.Errorf("Expected a consistent exchange, got %v", errs)
+				}
+				return
+			}
+			if len(errs) != 1 || !strings.Contains(errs[0].Message, tt.message) {
+				t.Errorf("Expected an error containing %q, got %v", tt.message, errs)
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package iso20022
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewGarnishmentPayment(t *testing.T) {
+	order := GarnishmentOrder{
+		CaseID:            "000123456789",
+		EmployeeName:      "John Doe",
+		EmployeeSSN:       "123456789",
+		Administrator:     "New York State Disbursement Unit",
+		AdministratorFIPS: "3600000",
+		PayDate:           NewISODate(2024, time.March, 15),
+		Amount:            ActiveOrHistoricCurrencyAndAmount{Value: 412.5, Currency: "USD"},
+		MedicalSupport:    true,
+	}
+	info, err := NewGarnishmentPayment(order)
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := info.GarnishmentRemittance
+	if *g.Type.CodeOrProprietary.Code != GarnishmentChildSupportThirdParty || *g.ReferenceNumber != "000123456789" || !*g.FamilyMedicalInsuranceIndicator || *g.EmployeeTerminationIndicator {
+		t.Errorf("Expected a child support garnishment, got %+v", g)
+	}
+
+	doc := loadPacs008Sample(t)
+	tx := &doc.FICustomerCreditTransfer.CreditTransferTransactionInfo[0]
+	tx.RemittanceInfo = nil
+	if err := tx.SetGarnishment(info); err != nil {
+		t.Fatal(err)
+	}
+	data, err := xml.Marshal(tx.RemittanceInfo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"<Strd><GrnshmtRmt><Tp><CdOrPrtry><Cd>GNCS</Cd></CdOrPrtry></Tp>",
+		"<Grnshee><Nm>John Doe</Nm><Id><PrvtId><Othr><Id>123456789</Id><SchmeNm><Cd>SOSE</Cd></SchmeNm></Othr></PrvtId></Id></Grnshee>",
+		"<GrnshmtAdmstr><Nm>New York State Disbursement Unit</Nm><Id><OrgId><Othr><Id>3600000</Id><SchmeNm><Prtry>FIPS</Prtry></SchmeNm></Othr></OrgId></Id></GrnshmtAdmstr>",
+		`<RefNb>000123456789</RefNb><Dt>2024-03-15</Dt><RmtdAmt Ccy="USD">412.5</RmtdAmt><FmlyMdclInsrncInd>true</FmlyMdclInsrncInd><MplyeeTermntnInd>false</MplyeeTermntnInd>`,
+	} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("Expected %s in %s", want, data)
+		}
+	}
+
+	_, err = NewGarnishmentPayment(GarnishmentOrder{Type: "GNXX"})
+	if errs, ok := err.(ValidationErrors); !ok || len(errs) != 4 {
+		t.Errorf("Expected the case, employee, amount and type to be reported, got %v", err)
+	}
+}
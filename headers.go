@@ -0,0 +1,228 @@
+package iso20022
+
+import (
+	"reflect"
+	"strings"
+)
+
+// Names of the headers returned by Headers
+const (
+	HeaderMessageType       = "iso20022-message-type"
+	HeaderBusinessMessageID = "iso20022-biz-msg-idr"
+	HeaderUETR              = "iso20022-uetr"
+	HeaderSenderBIC         = "iso20022-sender-bic"
+	HeaderReceiverBIC       = "iso20022-receiver-bic"
+	HeaderSettlementDate    = "iso20022-settlement-date"
+	HeaderAmount            = "iso20022-amount"
+	HeaderCurrency          = "iso20022-currency"
+)
+
+// Headers returns the routing metadata of a message as a flat map, to be sent as
+// the headers of a message bus record so that brokers and consumers can route it
+// without decoding the body again. appHdr may be nil.
+//
+// The message type and BizMsgIdr come from the business application header, or
+// else from the namespace of the document and the MsgId of its group header. The
+// sender and receiver BICs come from the Fr and To of the header, or else from the
+// instructing and instructed agents of the message when all of them are the same.
+// The UETR is set when the message carries a single one, as UETR or OrgnlUETR. The
+// settlement date, amount and currency are set for a pacs.008, pacs.009 or
+// pain.001 whose transactions have a single settlement date, or currency, the
+// amount being their total. Metadata the message does not have is left out. A
+// pacs.008 or pacs.002 of any of the supported versions is read through its
+// interface and its upgrade to pacs.008.001.08 or pacs.002.001.10, so that the
+// metadata does not depend on the version.
+func Headers(doc interface{}, appHdr *BusinessApplicationHeaderV02) map[string]string {
+	h := make(map[string]string)
+	set := func(name, value string) {
+		if value != "" {
+			h[name] = value
+		}
+	}
+	msg := messageElement(doc)
+	var msgID string
+	switch d := doc.(type) {
+	case Pacs008:
+		msgID = d.MessageID()
+		if upgraded, err := asPacs00800108(d); err == nil {
+			msg = messageElement(upgraded)
+		}
+	case Pacs002:
+		msgID = d.MessageID()
+		upgraded := new(Pacs00200110Document)
+		if err := convertCore(upgraded, d); err == nil {
+			msg = messageElement(upgraded)
+		}
+	}
+
+	if appHdr != nil {
+		set(HeaderMessageType, appHdr.MessageDefinitionID)
+		set(HeaderBusinessMessageID, appHdr.BusinessMessageID)
+		set(HeaderSenderBIC, party44BIC(appHdr.From))
+		set(HeaderReceiverBIC, party44BIC(appHdr.To))
+	}
+	if _, ok := h[HeaderMessageType]; !ok {
+		set(HeaderMessageType, documentMessageType(doc))
+	}
+	if _, ok := h[HeaderBusinessMessageID]; !ok {
+		if msgID == "" {
+			msgID, _ = textAt(msg, "GrpHdr", "MsgId")
+		}
+		set(HeaderBusinessMessageID, msgID)
+	}
+	if _, ok := h[HeaderSenderBIC]; !ok {
+		set(HeaderSenderBIC, singleText(msg, []string{"InstgAgt"}, "FinInstnId", "BICFI"))
+	}
+	if _, ok := h[HeaderReceiverBIC]; !ok {
+		set(HeaderReceiverBIC, singleText(msg, []string{"InstdAgt"}, "FinInstnId", "BICFI"))
+	}
+	set(HeaderUETR, singleText(msg, []string{"UETR", "OrgnlUETR"}))
+
+	if summary, err := Summarize(doc); err == nil {
+		if len(summary.SettlementDates) == 1 {
+			set(HeaderSettlementDate, summary.SettlementDates[0].String())
+		}
+		if len(summary.Totals) == 1 {
+			for currency, total := range summary.Totals {
+				set(HeaderCurrency, currency)
+				set(HeaderAmount, formatRat(DecimalRat(total)))
+			}
+		}
+	}
+	return h
+}
+
+// party44BIC returns the BIC of a header party identified as a financial institution
+func party44BIC(p Party44) string {
+	if p.FinancialInstitutionID != nil {
+		return deref(p.FinancialInstitutionID.FinancialInstitutionID.BankIdentifierCode)
+	}
+	return ""
+}
+
+// documentMessageType returns the message name identification of a document, read
+// from the namespace of its XMLName, or "" when it has none
+func documentMessageType(doc interface{}) string {
+	t := reflect.TypeOf(doc)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return ""
+	}
+	field, ok := t.FieldByName("XMLName")
+	if !ok {
+		return ""
+	}
+	namespace, _, _ := strings.Cut(field.Tag.Get("xml"), " ")
+	if !strings.HasPrefix(namespace, namespacePrefix) {
+		return ""
+	}
+	return strings.TrimPrefix(namespace, namespacePrefix)
+}
+
+// messageElement returns the message element of a document, its first element
+func messageElement(doc interface{}) reflect.Value {
+	v := reflect.ValueOf(doc)
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return reflect.Value{}
+	}
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		if name, ok := elementName(t.Field(i)); ok && name != "" {
+			return v.Field(i)
+		}
+	}
+	return reflect.Value{}
+}
+
+// textAt returns the text of the element at the steps below v
+func textAt(v reflect.Value, steps ...string) (string, bool) {
+	for _, step := range steps {
+		for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+			if v.IsNil() {
+				return "", false
+			}
+			v = v.Elem()
+		}
+		if v.Kind() == reflect.Slice {
+			if v.Len() == 0 {
+				return "", false
+			}
+			v = v.Index(0)
+		}
+		child, ok := childElement(v, step)
+		if !ok {
+			return "", false
+		}
+		v = child
+	}
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return "", false
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return "", false
+	}
+	return elementText(v)
+}
+
+// singleText returns the text at the steps below the elements named names anywhere
+// below v when they all have the same, and "" when they differ or none has one
+func singleText(v reflect.Value, names []string, steps ...string) string {
+	single := ""
+	conflict := false
+	visitNamedElements(v, names, func(element reflect.Value) {
+		text, ok := textAt(element, steps...)
+		if !ok || text == "" {
+			return
+		}
+		if single != "" && text != single {
+			conflict = true
+		}
+		single = text
+	})
+	if conflict {
+		return ""
+	}
+	return single
+}
+
+// visitNamedElements calls visit with every element below v whose name is one of
+// names, without descending into them
+func visitNamedElements(v reflect.Value, names []string, visit func(v reflect.Value)) {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			visitNamedElements(v.Index(i), names, visit)
+		}
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			name, ok := elementName(field)
+			if !ok || !field.IsExported() {
+				continue
+			}
+			if containsString(names, name) {
+				visit(v.Field(i))
+				continue
+			}
+			visitNamedElements(v.Field(i), names, visit)
+		}
+	}
+}
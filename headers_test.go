@@ -0,0 +1,110 @@
+package iso20022
+
+import (
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestHeaders(t *testing.T) {
+	doc := loadPacs008Sample(t)
+	want := map[string]string{
+		HeaderMessageType:       "pacs.008.001.08",
+		HeaderBusinessMessageID: "BBBBUS33-20240315-0001",
+		HeaderUETR:              "8a562c67-ca16-48ba-b074-65581be6f011",
+		HeaderSenderBIC:         "BBBBUS33",
+		HeaderReceiverBIC:       "CCCCGB2L",
+		HeaderSettlementDate:    "2024-03-15",
+		HeaderAmount:            "15000",
+		HeaderCurrency:          "USD",
+	}
+	if got := Headers(doc, nil); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected headers %v, got %v", want, got)
+	}
+
+	bic := "AAAADEFF"
+	appHdr := &BusinessApplicationHeaderV02{
+		From:                Party44{FinancialInstitutionID: &BranchAndFinancialInstitutionIdentification6{FinancialInstitutionID: FinancialInstitutionIdentification18{BankIdentifierCode: &bic}}},
+		BusinessMessageID:   "BAH-0001",
+		MessageDefinitionID: "pacs.008.001.08",
+	}
+	got := Headers(doc, appHdr)
+	if got[HeaderBusinessMessageID] != "BAH-0001" || got[HeaderSenderBIC] != "AAAADEFF" || got[HeaderReceiverBIC] != "CCCCGB2L" {
+		t.Errorf("Expected the header to take precedence, got %v", got)
+	}
+
+	// Transactions with different UETRs and currencies have none of them
+	tx := doc.FICustomerCreditTransfer.CreditTransferTransactionInfo[0]
+	other := "0b8fba0f-6ff0-4a7a-9a3a-41a6f2bd0a3c"
+	tx.PaymentID.UETR = &other
+	tx.InterbankSettlementAmount.Currency = "EUR"
+	doc.FICustomerCreditTransfer.CreditTransferTransactionInfo = append(doc.FICustomerCreditTransfer.CreditTransferTransactionInfo, tx)
+	got = Headers(doc, nil)
+	for _, name := range []string{HeaderUETR, HeaderAmount, HeaderCurrency} {
+		if value, ok := got[name]; ok {
+			t.Errorf("Expected no %s header, got %q", name, value)
+		}
+	}
+	if got[HeaderSettlementDate] != "2024-03-15" {
+		t.Errorf("Expected the shared settlement date, got %v", got)
+	}
+}
+
+func TestHeadersStatusReport(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("testdata", "roundtrip", "pacs.002.001.10", "rejected_transaction.xml"))
+	if err != nil {
+		t.Fatalf("Failed to read fixture: %v", err)
+	}
+	doc := new(Pacs00200110Document)
+	if err := xml.Unmarshal(data, doc); err != nil {
+		t.Fatalf("Failed to unmarshal fixture: %v", err)
+	}
+	want := map[string]string{
+		HeaderMessageType:       "pacs.002.001.10",
+		HeaderBusinessMessageID: "CCCCGB2L-STS-0001",
+		HeaderUETR:              "8a562c67-ca16-48ba-b074-65581be6f011",
+		HeaderSenderBIC:         "CCCCGB2L",
+		HeaderReceiverBIC:       "BBBBUS33",
+	}
+	if got := Headers(doc, nil); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected headers %v, got %v", want, got)
+	}
+}
+
+func TestHeadersVersions(t *testing.T) {
+	for _, version := range Pacs008Versions {
+		want := map[string]string{
+			HeaderMessageType:       version,
+			HeaderBusinessMessageID: "BBBBUS33-20240315-0001",
+			HeaderUETR:              "8a562c67-ca16-48ba-b074-65581be6f011",
+			HeaderSenderBIC:         "BBBBUS33",
+			HeaderReceiverBIC:       "CCCCGB2L",
+			HeaderSettlementDate:    "2024-03-15",
+			HeaderAmount:            "15000",
+			HeaderCurrency:          "USD",
+		}
+		if version < "pacs.008.001.07" {
+			delete(want, HeaderUETR)
+		}
+		if got := Headers(loadPacs008Version(t, version), nil); !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: expected headers %v, got %v", version, want, got)
+		}
+	}
+	for _, version := range Pacs002Versions {
+		want := map[string]string{
+			HeaderMessageType:       version,
+			HeaderBusinessMessageID: "CCCCGB2L-STS-0001",
+			HeaderUETR:              "8a562c67-ca16-48ba-b074-65581be6f011",
+			HeaderSenderBIC:         "CCCCGB2L",
+			HeaderReceiverBIC:       "BBBBUS33",
+		}
+		if version == "pacs.002.001.03" {
+			delete(want, HeaderUETR)
+		}
+		if got := Headers(loadPacs002Version(t, version), nil); !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: expected headers %v, got %v", version, want, got)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package iso20022
+
+// HVPSPlusRules are the field rules of the HVPS+ market practice for the pacs.008
+// and pacs.009 of high-value payment systems, which the RTGS profiles build on
+var HVPSPlusRules = []FieldRule{
+	{Path: "FIToFICstmrCdtTrf/GrpHdr/SttlmInf/SttlmMtd", Required: true, Codes: []string{"CLRG"}},
+	{Path: "FIToFICstmrCdtTrf/CdtTrfTxInf/PmtId/UETR", Required: true},
+	{Path: "FIToFICstmrCdtTrf/CdtTrfTxInf/ChrgBr", Required: true, Codes: []string{"DEBT", "CRED", "SHAR"}},
+	{Path: "FIToFICstmrCdtTrf/CdtTrfTxInf/InstgAgt/FinInstnId/BICFI", Required: true},
+	{Path: "FIToFICstmrCdtTrf/CdtTrfTxInf/InstdAgt/FinInstnId/BICFI", Required: true},
+	{Path: "FIToFICstmrCdtTrf/CdtTrfTxInf/Dbtr/Nm", Required: true, MaxLength: 140},
+	{Path: "FIToFICstmrCdtTrf/CdtTrfTxInf/Cdtr/Nm", Required: true, MaxLength: 140},
+	{Path: "FIToFICstmrCdtTrf/CdtTrfTxInf/RmtInf/Ustrd", MaxLength: 140},
+	{Path: "FICdtTrf/GrpHdr/SttlmInf/SttlmMtd", Required: true, Codes: []string{"CLRG"}},
+	{Path: "FICdtTrf/CdtTrfTxInf/PmtId/UETR", Required: true},
+	{Path: "FICdtTrf/CdtTrfTxInf/InstgAgt/FinInstnId/BICFI", Required: true},
+	{Path: "FICdtTrf/CdtTrfTxInf/InstdAgt/FinInstnId/BICFI", Required: true},
+	{Path: "FICdtTrf/CdtTrfTxInf/Dbtr/FinInstnId/BICFI", Required: true},
+	{Path: "FICdtTrf/CdtTrfTxInf/Cdtr/FinInstnId/BICFI", Required: true},
+}
+
+// RTGS profiles layering their rules on HVPS+. Each allows one transaction per
+// message in the currency of its system.
+var (
+	// LynxProfile is Lynx, the RTGS system of Payments Canada
+	LynxProfile = hvpsProfile("Lynx", "CAD",
+		FieldRule{Path: "FIToFICstmrCdtTrf/CdtTrfTxInf/CdtrAcct/Id/Othr/Id", Required: true, MaxLength: 34},
+		FieldRule{Path: "FIToFICstmrCdtTrf/CdtTrfTxInf/Purp/Cd", MaxLength: 4},
+	)
+	// CHAPSProfile is CHAPS, the RTGS system of the Bank of England
+	CHAPSProfile = hvpsProfile("CHAPS", "GBP",
+		FieldRule{Path: "FIToFICstmrCdtTrf/CdtTrfTxInf/Dbtr/PstlAdr/Ctry", Required: true},
+		FieldRule{Path: "FIToFICstmrCdtTrf/CdtTrfTxInf/Cdtr/PstlAdr/Ctry", Required: true},
+		FieldRule{Path: "FIToFICstmrCdtTrf/CdtTrfTxInf/CdtrAcct/Id/IBAN", MaxLength: 34},
+		FieldRule{Path: "FIToFICstmrCdtTrf/CdtTrfTxInf/ChrgBr", Codes: []string{"SHAR", "DEBT"}},
+	)
+	// MEPSProfile is MEPS+, the RTGS system of the Monetary Authority of Singapore
+	MEPSProfile = hvpsProfile("MEPS+", "SGD",
+		FieldRule{Path: "FIToFICstmrCdtTrf/CdtTrfTxInf/Dbtr/Nm", MaxLength: 70},
+		FieldRule{Path: "FIToFICstmrCdtTrf/CdtTrfTxInf/Cdtr/Nm", MaxLength: 70},
+		FieldRule{Path: "FIToFICstmrCdtTrf/CdtTrfTxInf/Purp/Cd", Required: true},
+	)
+)
+
+// hvpsProfile returns the profile of an RTGS system settling in currency, checking
+// the HVPS+ rules then its own
+func hvpsProfile(name, currency string, rules ...FieldRule) Profile {
+	rules = append([]FieldRule{
+		{Path: "FIToFICstmrCdtTrf/CdtTrfTxInf/IntrBkSttlmAmt/@Ccy", Codes: []string{currency}},
+		{Path: "FICdtTrf/CdtTrfTxInf/IntrBkSttlmAmt/@Ccy", Codes: []string{currency}},
+	}, rules...)
+	return Profile{
+		Name:   name,
+		Limits: Limits{MaxTransactions: 1},
+		Rules:  []ProfileRule{FieldRules(name, HVPSPlusRules...), FieldRules(name, rules...)},
+	}
+}
@@ -0,0 +1,79 @@
+package iso20022
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFieldRules(t *testing.T) {
+	doc := loadPacs008Sample(t)
+	txs := &doc.FICustomerCreditTransfer.CreditTransferTransactionInfo
+	*txs = append(*txs, (*txs)[0])
+	(*txs)[1].Purpose = nil
+	(*txs)[0].Debtor.Name = Ptr(strings.Repeat("x", 41))
+	(*txs)[0].RemittanceInfo = &RemittanceInfo{Unstructured: []string{"short", strings.Repeat("y", 11)}}
+
+	rule := FieldRules("Test",
+		FieldRule{Path: "FIToFICstmrCdtTrf/CdtTrfTxInf/Purp/Cd", Required: true},
+		FieldRule{Path: "FIToFICstmrCdtTrf/CdtTrfTxInf/ChrgBr", Codes: []string{"DEBT", "CRED"}},
+		FieldRule{Path: "FIToFICstmrCdtTrf/CdtTrfTxInf/Dbtr/Nm", MaxLength: 40},
+		FieldRule{Path: "FIToFICstmrCdtTrf/CdtTrfTxInf/RmtInf/Ustrd", MaxLength: 10},
+		FieldRule{Path: "FIToFICstmrCdtTrf/CdtTrfTxInf/IntrBkSttlmAmt/@Ccy", Codes: []string{"USD"}},
+		FieldRule{Path: "FIToFICstmrCdtTrf/CdtTrfTxInf/IntrBkSttlmAmt", Codes: []string{"15000"}},
+		FieldRule{Path: "FICdtTrf/GrpHdr/MsgId", Required: true},
+	)
+	(*txs)[0].Purpose = &Purpose{Code: Ptr("SALA")}
+	errs := rule(doc)
+	want := []string{
+		"FIToFICstmrCdtTrf/CdtTrfTxInf[2]/Purp/Cd",
+		"FIToFICstmrCdtTrf/CdtTrfTxInf[1]/ChrgBr",
+		"FIToFICstmrCdtTrf/CdtTrfTxInf[2]/ChrgBr",
+		"FIToFICstmrCdtTrf/CdtTrfTxInf[1]/Dbtr/Nm",
+		"FIToFICstmrCdtTrf/CdtTrfTxInf[1]/RmtInf/Ustrd[2]",
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("Expected %d errors, got %v", len(want), errs)
+	}
+	for i, location := range want {
+		if errs[i].Location() != location {
+			t.Errorf("Expected an error at %s, got %v", location, errs[i])
+		}
+		if !strings.Contains(errs[i].Message, "Test") {
+			t.Errorf("Expected the scheme to be named in %q", errs[i].Message)
+		}
+	}
+}
+
+func TestHVPSProfiles(t *testing.T) {
+	for _, name := range []string{"Lynx", "CHAPS", "MEPS+"} {
+		if _, ok := LookupProfile(name); !ok {
+			t.Errorf("Expected the %s profile to be registered", name)
+		}
+	}
+
+	doc := loadPacs008Sample(t)
+	doc.FICustomerCreditTransfer.GroupHeader.SettlementInfo.SettlementMethod = "CLRG"
+	tx := &doc.FICustomerCreditTransfer.CreditTransferTransactionInfo[0]
+	tx.InterbankSettlementAmount.Currency = "GBP"
+	if err := CHAPSProfile.Check(doc); err != nil {
+		t.Fatalf("Expected the sample in GBP to pass CHAPS, got %v", err)
+	}
+
+	tx.InterbankSettlementAmount.Currency = "USD"
+	tx.ChargeBearer = "CRED"
+	tx.PaymentID.UETR = nil
+	errs, _ := CHAPSProfile.Check(doc).(ValidationErrors)
+	want := []string{
+		"FIToFICstmrCdtTrf/CdtTrfTxInf[1]/PmtId/UETR",
+		"FIToFICstmrCdtTrf/CdtTrfTxInf[1]/IntrBkSttlmAmt/@Ccy",
+		"FIToFICstmrCdtTrf/CdtTrfTxInf[1]/ChrgBr",
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("Expected %d errors, got %v", len(want), errs)
+	}
+	for i, location := range want {
+		if errs[i].Location() != location {
+			t.Errorf("Expected an error at %s, got %v", location, errs[i])
+		}
+	}
+}
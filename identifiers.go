@@ -0,0 +1,197 @@
+package iso20022
+
+import (
+	"fmt"
+)
+
+// ibanLengths gives the IBAN length of every country in the SWIFT IBAN registry
+var ibanLengths = map[string]int{
+	"AD": 24, "AE": 23, "AL": 28, "AT": 20, "AZ": 28, "BA": 20, "BE": 16, "BG": 22,
+	"BH": 22, "BI": 27, "BR": 29, "BY": 28, "CH": 21, "CR": 22, "CY": 28, "CZ": 24,
+	"DE": 22, "DJ": 27, "DK": 18, "DO": 28, "EE": 20, "EG": 29, "ES": 24, "FI": 18,
+	"FK": 18, "FO": 18, "FR": 27, "GB": 22, "GE": 22, "GI": 23, "GL": 18, "GR": 27,
+	"GT": 28, "HR": 21, "HU": 28, "IE": 22, "IL": 23, "IQ": 23, "IS": 26, "IT": 27,
+	"JO": 30, "KW": 30, "KZ": 20, "LB": 28, "LC": 32, "LI": 21, "LT": 20, "LU": 20,
+	"LV": 21, "LY": 25, "MC": 27, "MD": 24, "ME": 22, "MK": 19, "MN": 20, "MR": 27,
+	"MT": 31, "MU": 30, "NI": 28, "NL": 18, "NO": 15, "OM": 23, "PK": 24, "PL": 28,
+	"PS": 29, "PT": 25, "QA": 29, "RO": 24, "RS": 22, "RU": 33, "SA": 24, "SC": 31,
+	"SD": 18, "SE": 24, "SI": 19, "SK": 24, "SM": 27, "SO": 23, "ST": 25, "SV": 28,
+	"TL": 23, "TN": 24, "TR": 26, "UA": 29, "VA": 22, "VG": 24, "XK": 20, "YE": 30,
+}
+
+// ValidateIBAN checks an IBAN in its electronic format, without spaces: the
+// pattern, the length registered for its country and the ISO 13616 mod-97 check
+// digits. It returns a ValidationError for field IBAN.
+func ValidateIBAN(iban string) error {
+	return validateIBAN(iban, "IBAN")
+}
+
+// validateIBAN validates IBAN format, country length and check digits
+func validateIBAN(iban string, fieldName string) error {
+	if err := validateStringLength(iban, 15, 34, fieldName); err != nil {
+		return err
+	}
+	// Basic IBAN pattern (country code + 2 digits + up to 30 alphanumeric)
+	if err := validatePattern(iban, `^[A-Z]{2}[0-9]{2}[A-Z0-9]{1,30}$`, fieldName); err != nil {
+		return err
+	}
+
+	country := iban[:2]
+	length, ok := ibanLengths[country]
+	if !ok {
+		return newValidationError(fieldName, RuleIBANLength, "IBAN_COUNTRY", country)
+	}
+	if len(iban) != length {
+		return newValidationError(fieldName, RuleIBANLength, "IBAN_LENGTH", len(iban), length, country)
+	}
+
+	// The check digits are valid when the IBAN, with its first four characters moved
+	// to the end, is 1 mod 97
+	if mod97(iban[4:]+iban[:4]) != 1 {
+		return newValidationError(fieldName, RuleIBANChecksum, "IBAN_CHECKSUM")
+	}
+	return nil
+}
+
+// mod97 computes the ISO 7064 MOD 97-10 remainder of s, reading letters as the
+// numbers 10 to 35. s must be upper-case alphanumeric.
+func mod97(s string) int {
+	remainder := 0
+	for _, r := range s {
+		switch {
+		case r >= '0' && r <= '9':
+			remainder = (remainder*10 + int(r-'0')) % 97
+		case r >= 'A' && r <= 'Z':
+			remainder = (remainder*100 + int(r-'A') + 10) % 97
+		}
+	}
+	return remainder
+}
+
+// ValidateLEI checks a Legal Entity Identifier: the ISO 17442 pattern of 18
+// alphanumeric characters followed by two check digits, and the ISO 7064 MOD 97-10
+// check digits. It returns a ValidationError for field LEI.
+func ValidateLEI(lei string) error {
+	return validateLEI(lei, "LEI")
+}
+
+// validateLEI validates Legal Entity Identifier format and check digits
+func validateLEI(lei string, fieldName string) error {
+	if err := validateStringLength(lei, 20, 20, fieldName); err != nil {
+		return err
+	}
+	// LEI pattern: 18 alphanumeric characters + 2 check digits
+	if err := validatePattern(lei, `^[A-Z0-9]{18}[0-9]{2}$`, fieldName); err != nil {
+		return err
+	}
+	if mod97(lei) != 1 {
+		return newValidationError(fieldName, RuleLEIChecksum, "LEI_CHECKSUM")
+	}
+	return nil
+}
+
+// clearingSystemMemberIDs gives, for codes of the ISO external clearing system
+// identification code list, the pattern of the member identifiers the clearing
+// system issues
+var clearingSystemMemberIDs = map[string]string{
+	"ATBLZ": `^[0-9]{5}$`,             // Austrian Bankleitzahl
+	"AUBSB": `^[0-9]{6}$`,             // Australian Bank State Branch code
+	"CACPA": `^0[0-9]{8}$`,            // Canadian Payments Association routing number
+	"CHBCC": `^[0-9]{3,5}$`,           // Swiss bank clearing code
+	"CHSIC": `^[0-9]{6}$`,             // Swiss SIC code
+	"CNAPS": `^[0-9]{12}$`,            // China National Advanced Payment System code
+	"DEBLZ": `^[0-9]{8}$`,             // German Bankleitzahl
+	"ESNCC": `^[0-9]{8,9}$`,           // Spanish domestic interbank clearing code
+	"GBDSC": `^[0-9]{6}$`,             // UK domestic sort code
+	"GRBIC": `^[0-9]{7}$`,             // Hellenic Bank Identification Code
+	"HKNCC": `^[0-9]{3}$`,             // Hong Kong bank code
+	"IENCC": `^[0-9]{6}$`,             // Irish national clearing code
+	"INFSC": `^[A-Z]{4}0[A-Z0-9]{6}$`, // Indian Financial System Code
+	"ITNCC": `^[0-9]{10}$`,            // Italian domestic identification code
+	"JPZGN": `^[0-9]{7}$`,             // Japan Zengin clearing code
+	"NZNCC": `^[0-9]{6}$`,             // New Zealand national clearing code
+	"PLKNR": `^[0-9]{8}$`,             // Polish national clearing code
+	"PTNCC": `^[0-9]{8}$`,             // Portuguese national clearing code
+	"RUCBC": `^[0-9]{9}$`,             // Russian Central Bank identification code
+	"SESBA": `^[0-9]{4}$`,             // Sweden bankgiro clearing code
+	"TWNCC": `^[0-9]{7}$`,             // Financial Institution Code of Taiwan
+	"USABA": `^[0-9]{9}$`,             // United States routing number (Fedwire, NACHA)
+	"USCHU": `^[0-9]{6}$`,             // CHIPS universal identifier
+	"USPID": `^[0-9]{4}$`,             // CHIPS participant identifier
+	"ZANCC": `^[0-9]{6}$`,             // South African national clearing code
+}
+
+// ValidateClearingSystemMemberID checks a member identifier against the format of
+// the clearing system identified by code, and the check digit of US routing numbers.
+// Identifiers of clearing systems without a known format are accepted. It returns a
+// ValidationError for field MmbId.
+func ValidateClearingSystemMemberID(code, memberID string) error {
+	return validateClearingSystemMemberID(code, memberID, "MmbId")
+}
+
+// validateClearingSystemMemberID validates a member identifier for its clearing system
+func validateClearingSystemMemberID(code, memberID string, fieldName string) error {
+	pattern, ok := clearingSystemMemberIDs[code]
+	if !ok {
+		return nil
+	}
+	if re, err := compilePattern(pattern); err != nil || !re.MatchString(memberID) {
+		return newValidationError(fieldName, RuleClearingMemberID, "CLEARING_MEMBER_ID", memberID, code)
+	}
+	if code == "USABA" && !abaChecksumValid(memberID) {
+		return newValidationError(fieldName, RuleClearingMemberID, "ROUTING_CHECKSUM")
+	}
+	return nil
+}
+
+// AgentFromBIC returns an agent identified by its BIC alone
+func AgentFromBIC(bic string) BranchAndFinancialInstitutionIdentification6 {
+	return BranchAndFinancialInstitutionIdentification6{
+		FinancialInstitutionID: FinancialInstitutionIdentification18{BankIdentifierCode: &bic},
+	}
+}
+
+// AgentFromClearingSystem returns an agent identified by its member identifier in
+// the clearing system with the given code, such as USABA and a routing number, and
+// by name unless name is empty
+func AgentFromClearingSystem(code, memberID, name string) BranchAndFinancialInstitutionIdentification6 {
+	agent := BranchAndFinancialInstitutionIdentification6{
+		FinancialInstitutionID: FinancialInstitutionIdentification18{
+			ClearingSystemMemberID: &ClearingSystemMemberIdentification{
+				ClearingSystemID: &ClearingSystemIdentification{Code: &code},
+				MemberID:         memberID,
+			},
+		},
+	}
+	if name != "" {
+		agent.FinancialInstitutionID.Name = &name
+	}
+	return agent
+}
+
+// memberIDOf returns the member identifier of an agent identified in the clearing
+// system with the given code, checked against the format of that clearing system
+func memberIDOf(agent *BranchAndFinancialInstitutionIdentification6, code string) (string, error) {
+	if agent == nil {
+		return "", fmt.Errorf("no agent")
+	}
+	member := agent.FinancialInstitutionID.ClearingSystemMemberID
+	if member == nil || member.ClearingSystemID == nil || member.ClearingSystemID.Code == nil || *member.ClearingSystemID.Code != code {
+		return "", fmt.Errorf("agent is not identified by a %s member identifier", code)
+	}
+	if err := ValidateClearingSystemMemberID(code, member.MemberID); err != nil {
+		return "", err
+	}
+	return member.MemberID, nil
+}
+
+// abaChecksumValid reports whether a nine-digit ABA routing number satisfies its
+// check: the digits weighted 3, 7, 1 in turn sum to a multiple of 10
+func abaChecksumValid(routing string) bool {
+	weights := [3]int{3, 7, 1}
+	sum := 0
+	for i, r := range routing {
+		sum += int(r-'0') * weights[i%3]
+	}
+	return sum%10 == 0
+}
@@ -0,0 +1,124 @@
+package iso20022
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateIBAN(t *testing.T) {
+	tests := []struct {
+		iban    string
+		message string // expected error message fragment, empty when valid
+	}{
+		{"DE89370400440532013000", ""},
+		{"GB29NWBK60161331926819", ""},
+		{"NO9386011117947", ""},
+		{"FR1420041010050500013M02606", ""},
+		{"DE89370400440532013001", "check digits are invalid"},
+		{"DE8937040044053201300", "length 21 does not match length 22 of DE IBANs"},
+		{"US12345678901234567890", "does not issue IBANs"},
+		{"de89370400440532013000", "does not match required pattern"},
+		{"DE12", "length 4 is below minimum 15"},
+	}
+	for _, tt := range tests {
+		err := ValidateIBAN(tt.iban)
+		if tt.message == "" {
+			if err != nil {
+				t.Errorf("ValidateIBAN(%q) = %v, want nil", tt.iban, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tt.message) {
+			t.Errorf("ValidateIBAN(%q) = %v, want error containing %q", tt.iban, err, tt.message)
+		}
+		if valErr, ok := err.(ValidationError); !ok || valErr.Field != "IBAN" {
+			t.Errorf("ValidateIBAN(%q) should return a ValidationError for IBAN, got %#v", tt.iban, err)
+		}
+	}
+}
+
+func TestAccountIdentificationChecksIBANDigits(t *testing.T) {
+	account := AccountIdentification4{IBAN: Ptr("DE89370400440532013001")}
+	if err := account.Validate(); err == nil {
+		t.Error("Expected an IBAN with wrong check digits to fail validation")
+	}
+}
+
+func TestValidateClearingSystemMemberID(t *testing.T) {
+	tests := []struct {
+		code     string
+		memberID string
+		message  string // expected error message fragment, empty when valid
+	}{
+		{"USABA", "021000021", ""},
+		{"USABA", "026009593", ""},
+		{"USABA", "021000022", "check digit of the routing number is invalid"},
+		{"USABA", "02100002", "is not a valid USABA member identifier"},
+		{"GBDSC", "401276", ""},
+		{"GBDSC", "40-12-76", "is not a valid GBDSC member identifier"},
+		{"DEBLZ", "37040044", ""},
+		{"DEBLZ", "3704004", "is not a valid DEBLZ member identifier"},
+		{"CHBCC", "230", ""},
+		{"CHBCC", "09000", ""},
+		{"CHBCC", "123456", "is not a valid CHBCC member identifier"},
+		{"INFSC", "HDFC0000001", ""},
+		{"INFSC", "HDFC1000001", "is not a valid INFSC member identifier"},
+		{"XXXXX", "anything", ""},
+	}
+	for _, tt := range tests {
+		err := ValidateClearingSystemMemberID(tt.code, tt.memberID)
+		if tt.message == "" {
+			if err != nil {
+				t.Errorf("ValidateClearingSystemMemberID(%q, %q) = %v, want nil", tt.code, tt.memberID, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tt.message) {
+			t.Errorf("ValidateClearingSystemMemberID(%q, %q) = %v, want error containing %q", tt.code, tt.memberID, err, tt.message)
+		}
+	}
+}
+
+func TestFinancialInstitutionChecksClearingSystemMemberID(t *testing.T) {
+	institution := FinancialInstitutionIdentification18{
+		ClearingSystemMemberID: &ClearingSystemMemberIdentification{
+			ClearingSystemID: &ClearingSystemIdentification{Code: Ptr("USABA")},
+			MemberID:         "021000022",
+		},
+	}
+	errs, ok := institution.Validate().(ValidationErrors)
+	if !ok || len(errs) != 1 {
+		t.Fatalf("Expected one validation error, got %v", errs)
+	}
+	if errs[0].Location() != "ClrSysMmbId/MmbId" {
+		t.Errorf("Expected the error at ClrSysMmbId/MmbId, got %s", errs[0].Location())
+	}
+
+	institution.ClearingSystemMemberID.ClearingSystemID = &ClearingSystemIdentification{Proprietary: Ptr("FEDWIRE")}
+	if err := institution.Validate(); err != nil {
+		t.Errorf("Expected proprietary clearing systems to accept any member identifier, got %v", err)
+	}
+}
+
+func TestAgentConstructors(t *testing.T) {
+	agent := AgentFromBIC("CHASUS33")
+	if deref(agent.FinancialInstitutionID.BankIdentifierCode) != "CHASUS33" || agent.FinancialInstitutionID.ClearingSystemMemberID != nil {
+		t.Errorf("Unexpected agent: %+v", agent.FinancialInstitutionID)
+	}
+	if err := agent.FinancialInstitutionID.Validate(); err != nil {
+		t.Errorf("Expected a valid agent, got %v", err)
+	}
+
+	agent = AgentFromClearingSystem("USABA", "026009593", "Bank of America")
+	member := agent.FinancialInstitutionID.ClearingSystemMemberID
+	if member == nil || deref(member.ClearingSystemID.Code) != "USABA" || member.MemberID != "026009593" ||
+		deref(agent.FinancialInstitutionID.Name) != "Bank of America" {
+		t.Errorf("Unexpected agent: %+v", agent.FinancialInstitutionID)
+	}
+	if err := agent.FinancialInstitutionID.Validate(); err != nil {
+		t.Errorf("Expected a valid agent, got %v", err)
+	}
+	if AgentFromClearingSystem("USABA", "026009593", "").FinancialInstitutionID.Name != nil {
+		t.Error("Expected no name")
+	}
+}
@@ -0,0 +1,229 @@
+package iso20022
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrIDsExhausted is returned by a generator that has no identifiers left, such as
+// a sequence generator that reached the largest sequence number of the day
+var ErrIDsExhausted = errors.New("identifiers exhausted")
+
+// IDGenerator generates identifications such as MsgId, InstrId, EndToEndId and
+// TxId for the builders of this package. Every identification it returns is at
+// most 35 characters long and different from the ones it returned before.
+// Implementations are safe for concurrent use.
+type IDGenerator interface {
+	NextID() (string, error)
+}
+
+// IDOption configures where a builder of this package takes identifications from
+type IDOption func(*idOptions)
+
+type idOptions struct {
+	ids IDGenerator
+}
+
+// WithIDs draws the identifications a builder is given empty, such as its MsgId and
+// EndToEndId, from ids. Split and NewCreditNotification identify each message or
+// notification they produce with an identification of ids rather than with a
+// number suffix.
+func WithIDs(ids IDGenerator) IDOption {
+	return func(o *idOptions) {
+		o.ids = ids
+	}
+}
+
+// newIDOptions applies opts
+func newIDOptions(opts []IDOption) idOptions {
+	var o idOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// assign replaces each empty identification of ids with the next identification of
+// the generator, if any
+func (o idOptions) assign(ids ...*string) error {
+	for _, id := range ids {
+		if *id != "" || o.ids == nil {
+			continue
+		}
+		next, err := o.ids.NextID()
+		if err != nil {
+			return err
+		}
+		*id = next
+	}
+	return nil
+}
+
+// SequenceOption configures a SequenceGenerator
+type SequenceOption func(*SequenceGenerator)
+
+// WithSequenceWidth sets the number of digits of the sequence number, 6 by default.
+// It bounds the number of identifications a generator hands out per day.
+func WithSequenceWidth(width int) SequenceOption {
+	return func(g *SequenceGenerator) {
+		g.width = width
+	}
+}
+
+// WithClock sets the function a generator reads the date from, time.Now by default
+func WithClock(now func() time.Time) SequenceOption {
+	return func(g *SequenceGenerator) {
+		g.now = now
+	}
+}
+
+// WithLastSequence resumes the sequence of the day of date after last, so that a
+// restarted process does not hand out the identifications of the previous run again
+func WithLastSequence(date time.Time, last int) SequenceOption {
+	return func(g *SequenceGenerator) {
+		g.day, g.seq = date.Format("20060102"), last
+	}
+}
+
+// SequenceGenerator generates identifications made of a prefix, the date and a
+// sequence number restarting at 1 every day, e.g. BBBBUS33-20240315-000042. The
+// identifications are deterministic: the same clock and starting point give the
+// same identifications. They are unique as long as only one generator uses the
+// prefix; give each process or instance its own prefix.
+type SequenceGenerator struct {
+	mu     sync.Mutex
+	prefix string
+	width  int
+	now    func() time.Time
+	day    string
+	seq    int
+}
+
+// NewSequenceGenerator returns a generator of identifications starting with prefix.
+// It fails when the identifications would exceed 35 characters.
+func NewSequenceGenerator(prefix string, opts ...SequenceOption) (*SequenceGenerator, error) {
+	g := &SequenceGenerator{prefix: prefix, width: 6, now: time.Now}
+	for _, opt := range opts {
+		opt(g)
+	}
+	if g.width < 1 || g.width > 18 {
+		return nil, fmt.Errorf("sequence width must be between 1 and 18, got %d", g.width)
+	}
+	if n := len(prefix) + len("20060102-") + g.width; n > maxMessageIDLength {
+		return nil, fmt.Errorf("identifications of prefix %q would be %d characters long, more than %d", prefix, n, maxMessageIDLength)
+	}
+	return g, nil
+}
+
+// NewBICSequenceGenerator returns a sequence generator scoped to an institution,
+// whose identifications start with its BIC, e.g. BBBBUS33XXX-20240315-000042.
+// Institutions running several generators should set them apart with a suffix.
+func NewBICSequenceGenerator(bic, suffix string, opts ...SequenceOption) (*SequenceGenerator, error) {
+	bic = NormalizeBIC(bic)
+	if err := validateBIC(bic, "BIC"); err != nil {
+		return nil, err
+	}
+	prefix := bic + "-"
+	if suffix != "" {
+		prefix += suffix + "-"
+	}
+	return NewSequenceGenerator(prefix, opts...)
+}
+
+// NextID implements IDGenerator
+func (g *SequenceGenerator) NextID() (string, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	day := g.now().Format("20060102")
+	switch {
+	case day > g.day:
+		g.day, g.seq = day, 0
+	case day < g.day:
+		// The clock went back; keep numbering within the later day
+		day = g.day
+	}
+	if len(strconv.Itoa(g.seq+1)) > g.width {
+		return "", fmt.Errorf("%w: %d sequence numbers used on %s", ErrIDsExhausted, g.seq, day)
+	}
+	g.seq++
+	return fmt.Sprintf("%s%s-%0*d", g.prefix, day, g.width, g.seq), nil
+}
+
+// crockford is the alphabet of ULIDs
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// ULIDGenerator generates ULIDs: 26 characters encoding a millisecond timestamp
+// and 80 random bits, which sort in generation order and need no coordination
+// between processes. Identifications generated within the same millisecond
+// increment the random part, so a generator never repeats itself.
+type ULIDGenerator struct {
+	mu      sync.Mutex
+	prefix  string
+	now     func() time.Time
+	entropy io.Reader
+	last    int64
+	random  [10]byte
+}
+
+// NewULIDGenerator returns a generator of ULIDs following prefix, which may be at
+// most 9 characters long. Entropy is read from crypto/rand.
+func NewULIDGenerator(prefix string) (*ULIDGenerator, error) {
+	if n := len(prefix) + 26; n > maxMessageIDLength {
+		return nil, fmt.Errorf("identifications of prefix %q would be %d characters long, more than %d", prefix, n, maxMessageIDLength)
+	}
+	return &ULIDGenerator{prefix: prefix, now: time.Now, entropy: rand.Reader}, nil
+}
+
+// NextID implements IDGenerator
+func (g *ULIDGenerator) NextID() (string, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	ms := g.now().UnixMilli()
+	if ms <= g.last {
+		// Same millisecond, or the clock went back: increment the random part
+		ms = g.last
+		i := len(g.random) - 1
+		for ; i >= 0; i-- {
+			g.random[i]++
+			if g.random[i] != 0 {
+				break
+			}
+		}
+		if i < 0 {
+			// Keep failing until the next millisecond rather than wrap around
+			for j := range g.random {
+				g.random[j] = 0xff
+			}
+			return "", fmt.Errorf("%w: random part overflowed within a millisecond", ErrIDsExhausted)
+		}
+	} else if _, err := io.ReadFull(g.entropy, g.random[:]); err != nil {
+		return "", err
+	}
+	g.last = ms
+
+	var b strings.Builder
+	b.WriteString(g.prefix)
+	for shift := 45; shift >= 0; shift -= 5 {
+		b.WriteByte(crockford[(ms>>uint(shift))&31])
+	}
+	// 80 random bits make 16 characters of 5 bits
+	var bits uint64
+	n := 0
+	for _, c := range g.random {
+		bits = bits<<8 | uint64(c)
+		n += 8
+		for n >= 5 {
+			n -= 5
+			b.WriteByte(crockford[(bits>>uint(n))&31])
+		}
+	}
+	return b.String(), nil
+}
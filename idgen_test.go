@@ -0,0 +1,126 @@
+package iso20022
+
+import (
+	"bytes"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestSequenceGenerator(t *testing.T) {
+	now := time.Date(2024, 3, 15, 23, 59, 0, 0, time.UTC)
+	g, err := NewBICSequenceGenerator("bbbbus33", "", WithSequenceWidth(2), WithClock(func() time.Time { return now }),
+		WithLastSequence(now, 97))
+	if err != nil {
+		t.Fatalf("NewBICSequenceGenerator failed: %v", err)
+	}
+	for _, want := range []string{"BBBBUS33XXX-20240315-98", "BBBBUS33XXX-20240315-99"} {
+		if id, err := g.NextID(); err != nil || id != want {
+			t.Errorf("Expected %s, got %s, %v", want, id, err)
+		}
+	}
+	if _, err := g.NextID(); !errors.Is(err, ErrIDsExhausted) {
+		t.Errorf("Expected ErrIDsExhausted, got %v", err)
+	}
+
+	now = now.Add(time.Minute)
+	if id, err := g.NextID(); err != nil || id != "BBBBUS33XXX-20240316-01" {
+		t.Errorf("Expected the sequence to restart the next day, got %s, %v", id, err)
+	}
+	now = now.Add(-time.Hour)
+	if id, err := g.NextID(); err != nil || id != "BBBBUS33XXX-20240316-02" {
+		t.Errorf("Expected the sequence to continue when the clock goes back, got %s, %v", id, err)
+	}
+}
+
+func TestSequenceGeneratorLength(t *testing.T) {
+	if _, err := NewSequenceGenerator("ABCDEFGHIJKLMNOPQRSTU"); err == nil {
+		t.Error("Expected an error for identifications longer than 35 characters")
+	}
+	if _, err := NewSequenceGenerator("ABCDEFGHIJKLMNOPQRST"); err != nil {
+		t.Errorf("Expected 35 characters to be allowed, got %v", err)
+	}
+	if _, err := NewBICSequenceGenerator("NOTABIC", ""); err == nil {
+		t.Error("Expected an error for an invalid BIC")
+	}
+}
+
+func TestULIDGenerator(t *testing.T) {
+	g, err := NewULIDGenerator("E2E")
+	if err != nil {
+		t.Fatalf("NewULIDGenerator failed: %v", err)
+	}
+	now := time.UnixMilli(1710495047000)
+	g.now = func() time.Time { return now }
+	g.entropy = bytes.NewReader(append(bytes.Repeat([]byte{0xff}, 10), make([]byte, 10)...))
+
+	first, err := g.NextID()
+	if err != nil {
+		t.Fatalf("NextID failed: %v", err)
+	}
+	if len(first) != 29 || first[:13] != "E2E01HS0Q67AR" {
+		t.Errorf("Unexpected ULID %s", first)
+	}
+	// All random bits set: the next identification in the same millisecond overflows
+	if _, err := g.NextID(); !errors.Is(err, ErrIDsExhausted) {
+		t.Errorf("Expected ErrIDsExhausted, got %v", err)
+	}
+
+	now = now.Add(time.Millisecond)
+	var ids []string
+	for i := 0; i < 3; i++ {
+		id, err := g.NextID()
+		if err != nil {
+			t.Fatalf("NextID failed: %v", err)
+		}
+		ids = append(ids, id)
+	}
+	if _, err := NewULIDGenerator("TOOLONGPREFIX"); err == nil {
+		t.Error("Expected an error for a prefix making identifications longer than 35 characters")
+	}
+	if !sort.StringsAreSorted(append([]string{first}, ids...)) || ids[0] == ids[1] {
+		t.Errorf("Expected increasing identifications, got %s %v", first, ids)
+	}
+}
+
+func TestWithIDs(t *testing.T) {
+	now := time.Date(2024, 3, 15, 9, 0, 0, 0, time.UTC)
+	g, err := NewSequenceGenerator("BLD-", WithSequenceWidth(1), WithClock(func() time.Time { return now }))
+	if err != nil {
+		t.Fatalf("NewSequenceGenerator failed: %v", err)
+	}
+
+	doc, err := NewLiquidityCreditTransfer("", "E2E001", ActiveCurrencyAndAmount{Value: 100, Currency: "EUR"},
+		SettlementAccount("MCA001"), SettlementAccount("MCA002"), WithIDs(g))
+	if err != nil {
+		t.Fatalf("NewLiquidityCreditTransfer failed: %v", err)
+	}
+	transfer := doc.LiquidityCreditTransfer
+	if transfer.MessageHeader.MessageID != "BLD-20240315-1" || transfer.LiquidityCreditTransfer.LiquidityTransferID.EndToEndID != "E2E001" {
+		t.Errorf("Expected only the empty MsgId to be generated, got %+v", transfer.MessageHeader)
+	}
+
+	parts, err := Split(loadPacs008Batch(t, 3), 2, WithIDs(g))
+	if err != nil {
+		t.Fatalf("Split failed: %v", err)
+	}
+	for i, want := range []string{"BLD-20240315-2", "BLD-20240315-3"} {
+		if id := parts[i].FICustomerCreditTransfer.GroupHeader.MessageID; id != want {
+			t.Errorf("Part %d: expected MsgId %s, got %s", i+1, want, id)
+		}
+	}
+
+	notification, err := NewCreditNotification("", loadPacs008Sample(t), WithIDs(g))
+	if err != nil {
+		t.Fatalf("NewCreditNotification failed: %v", err)
+	}
+	if msg := notification.BankDebitCreditNotification; msg.GroupHeader.MsgID != "BLD-20240315-4" || msg.Notification[0].ID != "BLD-20240315-5" {
+		t.Errorf("Unexpected identifications %s, %s", msg.GroupHeader.MsgID, msg.Notification[0].ID)
+	}
+
+	g, _ = NewSequenceGenerator("BLD-", WithSequenceWidth(1), WithLastSequence(time.Now(), 9))
+	if _, err := NewReceipt("", "LQT001", "camt.050.001.05", "COMP", WithIDs(g)); !errors.Is(err, ErrIDsExhausted) {
+		t.Errorf("Expected ErrIDsExhausted, got %v", err)
+	}
+}
@@ -0,0 +1,161 @@
+package iso20022
+
+import (
+	"encoding/xml"
+	"testing"
+	"time"
+)
+
+func testAgentParty(bic string) Party40 {
+	return Party40{
+		Agent: &BranchAndFinancialInstitutionIdentification6{
+			FinancialInstitutionID: FinancialInstitutionIdentification18{BankIdentifierCode: Ptr(bic)},
+		},
+	}
+}
+
+func TestRequestToModifyPayment(t *testing.T) {
+	underlying := UnderlyingPaymentTransaction4{
+		OriginalEndToEndID:                Ptr("E2E-001"),
+		OriginalInterbankSettlementAmount: ActiveOrHistoricCurrencyAndAmount{Value: 1500, Currency: "EUR"},
+		OriginalInterbankSettlementDate:   NewISODate(2024, time.March, 15),
+	}
+	modification := RequestedModification8{
+		InterbankSettlementAmount: &ActiveOrHistoricCurrencyAndAmount{Value: 1250, Currency: "EUR"},
+		CreditorAccount: &CashAccount38{
+			ID: AccountIdentification4{IBAN: Ptr("DE89370400440532013000")},
+		},
+	}
+
+	doc := NewRequestToModifyPayment("CASE-001", testAgentParty("DEUTDEFF"), testAgentParty("BNPAFRPP"), underlying, modification)
+	if err := doc.Validate(); err != nil {
+		t.Fatalf("Expected valid camt.087, got: %v", err)
+	}
+
+	xmlData, err := xml.Marshal(doc)
+	if err != nil {
+		t.Fatalf("Failed to marshal camt.087: %v", err)
+	}
+	var parsed Camt08700108Document
+	if err := xml.Unmarshal(xmlData, &parsed); err != nil {
+		t.Fatalf("Failed to unmarshal camt.087: %v", err)
+	}
+	if amt := parsed.RequestToModifyPayment.Modification.InterbankSettlementAmount; amt == nil || amt.Value != 1250 {
+		t.Errorf("Expected modified amount 1250, got %+v", amt)
+	}
+
+	t.Run("Assigner without party or agent", func(t *testing.T) {
+		doc.RequestToModifyPayment.Assignment.Assigner = Party40{}
+		if err := doc.Validate(); err == nil {
+			t.Error("Expected validation error for empty assigner")
+		}
+	})
+
+	t.Run("Invalid charge bearer", func(t *testing.T) {
+		doc.RequestToModifyPayment.Assignment.Assigner = testAgentParty("DEUTDEFF")
+		doc.RequestToModifyPayment.Modification.ChargeBearer = Ptr(ChargeBearerType1Code("XXXX"))
+		if err := doc.Validate(); err == nil {
+			t.Error("Expected validation error for invalid charge bearer")
+		}
+	})
+}
+
+func TestClaimNonReceiptResponses(t *testing.T) {
+	claim := &Camt02700107Document{
+		ClaimNonReceipt: ClaimNonReceiptV07{
+			Assignment: CaseAssignment5{
+				ID:               "ASSGN-001",
+				Assigner:         testAgentParty("DEUTDEFF"),
+				Assignee:         testAgentParty("BNPAFRPP"),
+				CreationDateTime: NewISODateTime(time.Now()),
+			},
+			Case: &Case5{ID: "CASE-002", Creator: testAgentParty("DEUTDEFF")},
+			Underlying: UnderlyingTransaction5{
+				InterbankTransaction: &UnderlyingPaymentTransaction4{
+					OriginalEndToEndID:                Ptr("E2E-002"),
+					OriginalInterbankSettlementAmount: ActiveOrHistoricCurrencyAndAmount{Value: 900, Currency: "EUR"},
+					OriginalInterbankSettlementDate:   NewISODate(2024, time.March, 15),
+				},
+			},
+		},
+	}
+	if err := claim.Validate(); err != nil {
+		t.Fatalf("Expected valid camt.027, got: %v", err)
+	}
+
+	t.Run("Additional payment info", func(t *testing.T) {
+		rsp := NewAdditionalPaymentInfoForClaim(claim, "ASSGN-002", PaymentComplementaryInfo9{EndToEndID: Ptr("E2E-002")})
+		if *rsp.AdditionalPaymentInfo.Assignment.Assignee.Agent.FinancialInstitutionID.BankIdentifierCode != "DEUTDEFF" {
+			t.Error("Expected claimant to become the assignee of the camt.028")
+		}
+		if rsp.AdditionalPaymentInfo.Case.ID != "CASE-002" {
+			t.Errorf("Expected case CASE-002, got %s", rsp.AdditionalPaymentInfo.Case.ID)
+		}
+	})
+
+	t.Run("Resolution", func(t *testing.T) {
+		rsp := NewClaimNonReceiptResolution(claim, "ASSGN-003", ClaimNonReceipt2{
+			Accepted: &ClaimNonReceiptDetails{DateProcessed: NewISODate(2024, time.March, 16)},
+		})
+		if got := *rsp.InvestigationResolution.Status.Confirmation; got != ClaimNonReceiptAccepted {
+			t.Errorf("Expected confirmation %s, got %s", ClaimNonReceiptAccepted, got)
+		}
+
+		rsp = NewClaimNonReceiptResolution(claim, "ASSGN-004", ClaimNonReceipt2{
+			Rejected: &ClaimNonReceiptRejectReason1{Code: Ptr("NOOR")},
+		})
+		if got := *rsp.InvestigationResolution.Status.Confirmation; got != ClaimNonReceiptRejected {
+			t.Errorf("Expected confirmation %s, got %s", ClaimNonReceiptRejected, got)
+		}
+	})
+}
+
+func TestCaseAssignmentAndRejection(t *testing.T) {
+	received := CaseAssignment5{
+		ID:               "ASSGN-010",
+		Assigner:         testAgentParty("DEUTDEFF"),
+		Assignee:         testAgentParty("COBADEFF"),
+		CreationDateTime: NewISODateTime(time.Now()),
+	}
+	caseInfo := Case5{ID: "CASE-010", Creator: testAgentParty("DEUTDEFF")}
+
+	t.Run("Notification of case assignment", func(t *testing.T) {
+		next := CaseAssignment5{
+			ID:               "ASSGN-011",
+			Assigner:         received.Assignee,
+			Assignee:         testAgentParty("BNPAFRPP"),
+			CreationDateTime: NewISODateTime(time.Now()),
+		}
+		doc := NewNotificationOfCaseAssignment("NTF-001", received, caseInfo, next, "FTHI")
+		if err := doc.Validate(); err != nil {
+			t.Fatalf("Expected valid camt.030, got: %v", err)
+		}
+		if *doc.NotificationOfCaseAssignment.Header.To.Agent.FinancialInstitutionID.BankIdentifierCode != "DEUTDEFF" {
+			t.Error("Expected notification to be addressed to the original assigner")
+		}
+
+		doc.NotificationOfCaseAssignment.Notification.Justification = "XXXX"
+		if err := doc.Validate(); err == nil {
+			t.Error("Expected validation error for unknown justification")
+		}
+	})
+
+	t.Run("Reject investigation", func(t *testing.T) {
+		doc := NewRejectInvestigation("ASSGN-012", received, &caseInfo, "NFOU")
+		if err := doc.Validate(); err != nil {
+			t.Fatalf("Expected valid camt.031, got: %v", err)
+		}
+
+		xmlData, err := xml.Marshal(doc)
+		if err != nil {
+			t.Fatalf("Failed to marshal camt.031: %v", err)
+		}
+		var parsed Camt03100106Document
+		if err := xml.Unmarshal(xmlData, &parsed); err != nil {
+			t.Fatalf("Failed to unmarshal camt.031: %v", err)
+		}
+		if parsed.RejectInvestigation.Justification.RejectionReason != "NFOU" {
+			t.Errorf("Expected rejection reason NFOU, got %s", parsed.RejectInvestigation.Justification.RejectionReason)
+		}
+	})
+}
@@ -1,13 +1,18 @@
 package iso20022
 
 import (
+	"context"
 	"encoding/xml"
 	"fmt"
 	"reflect"
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
+
+	"github.com/ckbaum/iso20022-go/btc"
+	"github.com/ckbaum/iso20022-go/currency"
 )
 
 // PACS.008.001.08 - FI to FI Customer Credit Transfer
@@ -99,6 +104,38 @@ type Camt02600107Document struct {
 	UnableToApply UnableToApplyV07 `xml:"UblToApply"`
 }
 
+// Camt03500105Document represents the CAMT.035.001.05 Proprietary Format Investigation message.
+// Clearing systems such as RTP use it to carry proprietary data within an investigation,
+// for instance to acknowledge a request for information or a remittance advice.
+type Camt03500105Document struct {
+	XMLName                        xml.Name                          `xml:"urn:iso:std:iso:20022:tech:xsd:camt.035.001.05 Document"`
+	ProprietaryFormatInvestigation ProprietaryFormatInvestigationV05 `xml:"PrtryFrmtInvstgtn"`
+}
+
+// Camt02700107Document represents the CAMT.027.001.07 Claim Non Receipt message.
+// This message is sent by the debtor's agent, or on behalf of the creditor, when a payment
+// that was expected has not been received, opening an investigation case with the next agent.
+type Camt02700107Document struct {
+	XMLName         xml.Name           `xml:"urn:iso:std:iso:20022:tech:xsd:camt.027.001.07 Document"`
+	ClaimNonReceipt ClaimNonReceiptV07 `xml:"ClmNonRct"`
+}
+
+// Camt03000105Document represents the CAMT.030.001.05 Notification Of Case Assignment message.
+// This message informs the previous assigner that a case has been forwarded to the next agent
+// in the payment chain, or that the assignee will handle it itself.
+type Camt03000105Document struct {
+	XMLName                      xml.Name                        `xml:"urn:iso:std:iso:20022:tech:xsd:camt.030.001.05 Document"`
+	NotificationOfCaseAssignment NotificationOfCaseAssignmentV05 `xml:"NtfctnOfCaseAssgnmt"`
+}
+
+// Camt03100106Document represents the CAMT.031.001.06 Reject Investigation message.
+// This message is sent by the assignee of a case to reject an investigation request that it cannot
+// accept, for example because the underlying payment cannot be found or the request is malformed.
+type Camt03100106Document struct {
+	XMLName             xml.Name               `xml:"urn:iso:std:iso:20022:tech:xsd:camt.031.001.06 Document"`
+	RejectInvestigation RejectInvestigationV06 `xml:"RjctInvstgtn"`
+}
+
 // Camt02800109Document represents the CAMT.028.001.09 Additional Payment Info message.
 // This message provides supplementary information related to payments that could not be included
 // in the original payment instruction, supporting enhanced payment processing and reconciliation.
@@ -115,6 +152,22 @@ type Camt02900109Document struct {
 	InvestigationResolution ResolutionOfInvestigationV09 `xml:"RsltnOfInvstgtn"`
 }
 
+// Camt08700108Document represents the CAMT.087.001.08 Request To Modify Payment message.
+// This message is used by an agent to request that a previously sent payment instruction be modified,
+// for example to correct the amount, a party, an account or the remittance information.
+type Camt08700108Document struct {
+	XMLName                xml.Name                  `xml:"urn:iso:std:iso:20022:tech:xsd:camt.087.001.08 Document"`
+	RequestToModifyPayment RequestToModifyPaymentV08 `xml:"ReqToModfyPmt"`
+}
+
+// Pain00100109Document represents the PAIN.001.001.09 Customer Credit Transfer Initiation message.
+// Debtors send it to their agent to initiate credit transfers, grouped in payment information
+// blocks that share a debtor, debtor account and requested execution date.
+type Pain00100109Document struct {
+	XMLName                          xml.Name                            `xml:"urn:iso:std:iso:20022:tech:xsd:pain.001.001.09 Document"`
+	CustomerCreditTransferInitiation CustomerCreditTransferInitiationV09 `xml:"CstmrCdtTrfInitn"`
+}
+
 // Pain01300107Document represents the PAIN.013.001.07 Creditor Payment Activation Request message.
 // This message allows creditors to request payment activation from debtors,
 // commonly used for direct debit scenarios and electronic invoice presentment.
@@ -155,6 +208,14 @@ type Admi00600101Document struct {
 	ResendRequest ResendRequestV01 `xml:"RsndReq"`
 }
 
+// Admi00500101Document represents the ADMI.005.001.01 Report Query Request message.
+// This message is used by a participant to request that the system operator sends one or more
+// system reports, such as end-of-day statements or activity reports, for the given criteria.
+type Admi00500101Document struct {
+	XMLName            xml.Name              `xml:"urn:iso:std:iso:20022:tech:xsd:admi.005.001.01 Document"`
+	ReportQueryRequest ReportQueryRequestV01 `xml:"RptQryReq"`
+}
+
 // Admi00700101Document represents the ADMI.007.001.01 Receipt Acknowledgement message.
 // This administrative message acknowledges the successful receipt of messages,
 // providing confirmation that transmitted messages have been properly received and processed.
@@ -175,6 +236,100 @@ type Admi99800102Document struct {
 	AdministrationMessage AdministrationProprietaryMessageV02 `xml:"AdmstnPrtryMsg"`
 }
 
+// Acmt02300103Document represents the ACMT.023.001.03 Identification Verification Request message.
+// This message asks the account servicer to confirm that an account identification belongs to the
+// named party before a payment is sent, as used by confirmation-of-payee schemes.
+type Acmt02300103Document struct {
+	XMLName                           xml.Name                             `xml:"urn:iso:std:iso:20022:tech:xsd:acmt.023.001.03 Document"`
+	IdentificationVerificationRequest IdentificationVerificationRequestV03 `xml:"IdVrfctnReq"`
+}
+
+// Acmt02400103Document represents the ACMT.024.001.03 Identification Verification Report message.
+// This message returns, for each verification requested, whether the party and account match and,
+// where they do not, the reason and possibly the corrected party details.
+type Acmt02400103Document struct {
+	XMLName                          xml.Name                            `xml:"urn:iso:std:iso:20022:tech:xsd:acmt.024.001.03 Document"`
+	IdentificationVerificationReport IdentificationVerificationReportV03 `xml:"IdVrfctnRpt"`
+}
+
+// Remt00100105Document represents the REMT.001.001.05 Remittance Advice message.
+// This message carries structured remittance information separately from the payment it settles,
+// for example when the remittance is too large for the payment message or travels through a different channel.
+type Remt00100105Document struct {
+	XMLName          xml.Name            `xml:"urn:iso:std:iso:20022:tech:xsd:remt.001.001.05 Document"`
+	RemittanceAdvice RemittanceAdviceV05 `xml:"RmtAdvc"`
+}
+
+// Admi00900102Document represents the ADMI.009.001.02 Static Data Request message.
+// This message is used by a participant to query reference data held by a market infrastructure,
+// such as its own participant profile, its accounts, or the operating calendar.
+type Admi00900102Document struct {
+	XMLName           xml.Name             `xml:"urn:iso:std:iso:20022:tech:xsd:admi.009.001.02 Document"`
+	StaticDataRequest StaticDataRequestV02 `xml:"StatcDataReq"`
+}
+
+// Admi01000101Document represents the ADMI.010.001.01 Static Data Report message.
+// This message returns the reference data matching a Static Data Request, or the business errors
+// raised for search criteria that could not be answered.
+type Admi01000101Document struct {
+	XMLName          xml.Name            `xml:"urn:iso:std:iso:20022:tech:xsd:admi.010.001.01 Document"`
+	StaticDataReport StaticDataReportV01 `xml:"StatcDataRpt"`
+}
+
+// Camt05000105Document represents the CAMT.050.001.05 Liquidity Credit Transfer message.
+// This message is sent by an RTGS participant to move liquidity into one of its settlement accounts,
+// typically from another account it holds or controls within the same settlement system.
+type Camt05000105Document struct {
+	XMLName                 xml.Name                   `xml:"urn:iso:std:iso:20022:tech:xsd:camt.050.001.05 Document"`
+	LiquidityCreditTransfer LiquidityCreditTransferV05 `xml:"LqdtyCdtTrf"`
+}
+
+// Camt05100105Document represents the CAMT.051.001.05 Liquidity Debit Transfer message.
+// This message is sent by an RTGS participant or central bank to withdraw liquidity from a settlement account,
+// for example when sweeping balances back to a central or home account at end of day.
+type Camt05100105Document struct {
+	XMLName                xml.Name                  `xml:"urn:iso:std:iso:20022:tech:xsd:camt.051.001.05 Document"`
+	LiquidityDebitTransfer LiquidityDebitTransferV05 `xml:"LqdtyDbtTrf"`
+}
+
+// Camt02500105Document represents the CAMT.025.001.05 Receipt message.
+// This message is returned by the settlement system to confirm or reject the processing of a previously
+// received request, such as a liquidity transfer or a reservation change.
+type Camt02500105Document struct {
+	XMLName xml.Name   `xml:"urn:iso:std:iso:20022:tech:xsd:camt.025.001.05 Document"`
+	Receipt ReceiptV05 `xml:"Rct"`
+}
+
+// Camt04600105Document represents the CAMT.046.001.05 Get Reservation message.
+// This message is used by an RTGS participant to query the current and default liquidity
+// reservations held on its settlement accounts.
+type Camt04600105Document struct {
+	XMLName        xml.Name          `xml:"urn:iso:std:iso:20022:tech:xsd:camt.046.001.05 Document"`
+	GetReservation GetReservationV05 `xml:"GetRsvatn"`
+}
+
+// Camt04700106Document represents the CAMT.047.001.06 Return Reservation message.
+// This message is sent by the settlement system in response to a Get Reservation query and reports
+// the reservations found or the errors encountered while processing the query.
+type Camt04700106Document struct {
+	XMLName           xml.Name             `xml:"urn:iso:std:iso:20022:tech:xsd:camt.047.001.06 Document"`
+	ReturnReservation ReturnReservationV06 `xml:"RtrRsvatn"`
+}
+
+// Camt04800105Document represents the CAMT.048.001.05 Modify Reservation message.
+// This message is used to change the amount or start of a current or default liquidity reservation.
+type Camt04800105Document struct {
+	XMLName           xml.Name             `xml:"urn:iso:std:iso:20022:tech:xsd:camt.048.001.05 Document"`
+	ModifyReservation ModifyReservationV05 `xml:"ModfyRsvatn"`
+}
+
+// Camt04900105Document represents the CAMT.049.001.05 Delete Reservation message.
+// This message is used to remove a current or default liquidity reservation.
+type Camt04900105Document struct {
+	XMLName           xml.Name             `xml:"urn:iso:std:iso:20022:tech:xsd:camt.049.001.05 Document"`
+	DeleteReservation DeleteReservationV05 `xml:"DelRsvatn"`
+}
+
 // FIToFICustomerCreditTransferV08 represents the core structure of a PACS.008.001.08 message.
 // This structure contains the group header with message-level information and multiple
 // credit transfer transaction details for inter-bank customer payment processing.
@@ -189,12 +344,12 @@ type FIToFICustomerCreditTransferV08 struct {
 // that are common across all credit transfer transactions in the message batch.
 type GroupHeader93 struct {
 	MessageID                      string                                        `xml:"MsgId"`
-	CreationDateTime               *time.Time                                    `xml:"CreDtTm,omitempty"`
+	CreationDateTime               *ISODateTime                                  `xml:"CreDtTm,omitempty"`
 	BatchBooking                   *bool                                         `xml:"BtchBookg,omitempty"`
 	NumberOfTransactions           string                                        `xml:"NbOfTxs"`
 	ControlSum                     *Decimal                                      `xml:"CtrlSum,omitempty"`
 	TotalInterbankSettlementAmount *ActiveCurrencyAndAmount                      `xml:"TtlIntrBkSttlmAmt,omitempty"`
-	InterbankSettlementDate        *string                                       `xml:"IntrBkSttlmDt,omitempty"`
+	InterbankSettlementDate        *ISODate                                      `xml:"IntrBkSttlmDt,omitempty"`
 	SettlementInfo                 SettlementInstruction7                        `xml:"SttlmInf"`
 	PaymentTypeInfo                *PaymentTypeInfo28                            `xml:"PmtTpInf,omitempty"`
 	InstructingAgent               *BranchAndFinancialInstitutionIdentification6 `xml:"InstgAgt,omitempty"`
@@ -208,15 +363,15 @@ type CreditTransferTransaction39 struct {
 	PaymentID                        PaymentIdentification7                        `xml:"PmtId"`
 	PaymentTypeInfo                  *PaymentTypeInfo28                            `xml:"PmtTpInf,omitempty"`
 	InterbankSettlementAmount        ActiveCurrencyAndAmount                       `xml:"IntrBkSttlmAmt"`
-	InterbankSettlementDate          *string                                       `xml:"IntrBkSttlmDt,omitempty"`
+	InterbankSettlementDate          *ISODate                                      `xml:"IntrBkSttlmDt,omitempty"`
 	SettlementPriority               *string                                       `xml:"SttlmPrty,omitempty"`
 	SettlementTimeIndication         *SettlementDateTimeIndication                 `xml:"SttlmTmIndctn,omitempty"`
 	SettlementTimeRequest            *SettlementTimeRequest                        `xml:"SttlmTmReq,omitempty"`
-	AcceptanceDateTime               *time.Time                                    `xml:"AccptncDtTm,omitempty"`
-	PoolingAdjustmentDate            *string                                       `xml:"PoolgAdjstmntDt,omitempty"`
+	AcceptanceDateTime               *ISODateTime                                  `xml:"AccptncDtTm,omitempty"`
+	PoolingAdjustmentDate            *ISODate                                      `xml:"PoolgAdjstmntDt,omitempty"`
 	InstructedAmount                 *ActiveOrHistoricCurrencyAndAmount            `xml:"InstdAmt,omitempty"`
 	ExchangeRate                     *Decimal                                      `xml:"XchgRate,omitempty"`
-	ChargeBearer                     string                                        `xml:"ChrgBr"`
+	ChargeBearer                     ChargeBearerType1Code                         `xml:"ChrgBr"`
 	ChargesInfo                      []Charges7                                    `xml:"ChrgsInf,omitempty"`
 	PreviousInstructingAgent1        *BranchAndFinancialInstitutionIdentification6 `xml:"PrvsInstgAgt1,omitempty"`
 	PreviousInstructingAgent1Account *CashAccount38                                `xml:"PrvsInstgAgt1Acct,omitempty"`
@@ -266,6 +421,9 @@ type PaymentIdentification7 struct {
 
 // PaymentIdentification provides legacy payment identification structure for backward compatibility.
 // Contains basic payment identifiers including instruction ID, end-to-end ID, transaction ID and UETR.
+//
+// Deprecated: use PaymentIdentification7; PaymentIdentification.PaymentIdentification7
+// and PaymentIdentification7.Legacy convert between them.
 type PaymentIdentification struct {
 	InstructionID *string `xml:"InstrId,omitempty"`
 	EndToEndID    string  `xml:"EndToEndId"`
@@ -277,12 +435,12 @@ type PaymentIdentification struct {
 // Includes instruction priority, clearing channel, service level, local instruments and category purpose
 // to guide how the payment should be processed by financial institutions.
 type PaymentTypeInfo struct {
-	InstructionPriority *string          `xml:"InstrPrty,omitempty"`
-	ClearingChannel     *string          `xml:"ClrChanl,omitempty"`
-	ServiceLevel        []ServiceLevel   `xml:"SvcLvl,omitempty"`
-	LocalInstrument     *LocalInstrument `xml:"LclInstrm,omitempty"`
-	SequenceType        *string          `xml:"SeqTp,omitempty"`
-	CategoryPurpose     *CategoryPurpose `xml:"CtgyPurp,omitempty"`
+	InstructionPriority *Priority2Code        `xml:"InstrPrty,omitempty"`
+	ClearingChannel     *ClearingChannel2Code `xml:"ClrChanl,omitempty"`
+	ServiceLevel        []ServiceLevel        `xml:"SvcLvl,omitempty"`
+	LocalInstrument     *LocalInstrument      `xml:"LclInstrm,omitempty"`
+	SequenceType        *string               `xml:"SeqTp,omitempty"`
+	CategoryPurpose     *CategoryPurpose      `xml:"CtgyPurp,omitempty"`
 }
 
 // Decimal is a float64 that serializes to XML in decimal notation, never scientific notation.
@@ -291,16 +449,13 @@ type Decimal float64
 
 // MarshalXML encodes the decimal value without scientific notation.
 func (d Decimal) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
-	e.EncodeToken(start)
-	e.EncodeToken(xml.CharData(strconv.FormatFloat(float64(d), 'f', -1, 64)))
-	e.EncodeToken(start.End())
-	return nil
+	return encodeText(e, start, strconv.FormatFloat(float64(d), 'f', -1, 64))
 }
 
 // UnmarshalXML decodes a decimal value from XML character data.
 func (d *Decimal) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
-	var s string
-	if err := dec.DecodeElement(&s, &start); err != nil {
+	s, err := charData(dec)
+	if err != nil {
 		return err
 	}
 	v, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
@@ -323,10 +478,7 @@ type ActiveCurrencyAndAmount struct {
 // Decimal type's MarshalXML, so we handle it at the struct level.
 func (a ActiveCurrencyAndAmount) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
 	start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "Ccy"}, Value: a.Currency})
-	e.EncodeToken(start)
-	e.EncodeToken(xml.CharData(strconv.FormatFloat(float64(a.Value), 'f', -1, 64)))
-	e.EncodeToken(start.End())
-	return nil
+	return encodeText(e, start, strconv.FormatFloat(float64(a.Value), 'f', -1, 64))
 }
 
 // UnmarshalXML decodes the amount value and currency attribute from XML.
@@ -336,8 +488,8 @@ func (a *ActiveCurrencyAndAmount) UnmarshalXML(d *xml.Decoder, start xml.StartEl
 			a.Currency = attr.Value
 		}
 	}
-	var raw string
-	if err := d.DecodeElement(&raw, &start); err != nil {
+	raw, err := charData(d)
+	if err != nil {
 		return err
 	}
 	v, err := strconv.ParseFloat(strings.TrimSpace(raw), 64)
@@ -360,10 +512,7 @@ type ActiveOrHistoricCurrencyAndAmount struct {
 // Decimal type's MarshalXML, so we handle it at the struct level.
 func (a ActiveOrHistoricCurrencyAndAmount) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
 	start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "Ccy"}, Value: a.Currency})
-	e.EncodeToken(start)
-	e.EncodeToken(xml.CharData(strconv.FormatFloat(float64(a.Value), 'f', -1, 64)))
-	e.EncodeToken(start.End())
-	return nil
+	return encodeText(e, start, strconv.FormatFloat(float64(a.Value), 'f', -1, 64))
 }
 
 // UnmarshalXML decodes the amount value and currency attribute from XML.
@@ -373,8 +522,8 @@ func (a *ActiveOrHistoricCurrencyAndAmount) UnmarshalXML(d *xml.Decoder, start x
 			a.Currency = attr.Value
 		}
 	}
-	var raw string
-	if err := d.DecodeElement(&raw, &start); err != nil {
+	raw, err := charData(d)
+	if err != nil {
 		return err
 	}
 	v, err := strconv.ParseFloat(strings.TrimSpace(raw), 64)
@@ -385,6 +534,328 @@ func (a *ActiveOrHistoricCurrencyAndAmount) UnmarshalXML(d *xml.Decoder, start x
 	return nil
 }
 
+// ISODate is a calendar date without a time of day, serialized as YYYY-MM-DD.
+// Schemas that type an element as ISODate reject datetimes and timezone offsets, so the
+// time-of-day and location of the wrapped time.Time are ignored when marshaling.
+type ISODate struct {
+	time.Time
+}
+
+// ISODateTime is a point in time serialized as YYYY-MM-DDThh:mm:ss with optional fractional
+// seconds, followed by Z for UTC values or by the numeric offset otherwise. A parsed value
+// remembers how its text designated the zone and how many fraction digits it had, so that
+// it is written back as it was read.
+type ISODateTime struct {
+	time.Time
+	local    bool // the text had no offset
+	numeric  bool // the text wrote a zero offset as +00:00 rather than Z
+	fraction int  // digits of the fraction of the text, 0 when it had none
+}
+
+// ISOTime is a time of day serialized as hh:mm:ss with optional fractional seconds and offset.
+type ISOTime struct {
+	time.Time
+}
+
+// Layouts used to marshal ISO 20022 date and time values
+const (
+	isoDateLayout     = "2006-01-02"
+	isoDateTimeLayout = "2006-01-02T15:04:05.999999999Z07:00"
+	isoTimeLayout     = "15:04:05.999999999Z07:00"
+)
+
+// Layouts accepted when unmarshaling; the schema types allow the offset to be omitted
+var (
+	isoDateParseLayouts     = []string{"2006-01-02", "2006-01-02Z07:00"}
+	isoDateTimeParseLayouts = []string{time.RFC3339Nano, "2006-01-02T15:04:05.999999999"}
+	isoTimeParseLayouts     = []string{"15:04:05.999999999Z07:00", "15:04:05.999999999"}
+)
+
+// NewISODate returns the ISODate for the given calendar day
+func NewISODate(year int, month time.Month, day int) ISODate {
+	return ISODate{time.Date(year, month, day, 0, 0, 0, 0, time.UTC)}
+}
+
+// NewISODateTime wraps t as an ISODateTime
+func NewISODateTime(t time.Time) ISODateTime {
+	return ISODateTime{Time: t}
+}
+
+// ParseISODate parses a YYYY-MM-DD date, optionally followed by a timezone
+func ParseISODate(s string) (ISODate, error) {
+	t, err := parseISO(s, isoDateParseLayouts)
+	return ISODate{t}, err
+}
+
+// ParseISODateTime parses an ISO 8601 datetime with optional fractional seconds and offset.
+// Values without an offset are interpreted as UTC, and are written back without one.
+func ParseISODateTime(s string) (ISODateTime, error) {
+	s = strings.TrimSpace(s)
+	t, err := parseISO(s, isoDateTimeParseLayouts)
+	if err != nil {
+		return ISODateTime{}, err
+	}
+	d := ISODateTime{Time: t, local: !hasOffset(s)}
+	if _, offset := t.Zone(); !d.local && offset == 0 {
+		d.numeric = !strings.HasSuffix(s, "Z")
+	}
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		for i++; i < len(s) && s[i] >= '0' && s[i] <= '9'; i++ {
+			d.fraction++
+		}
+	}
+	return d, nil
+}
+
+// hasOffset reports whether a datetime ends with Z or with a numeric offset
+func hasOffset(s string) bool {
+	n := len(s)
+	return strings.HasSuffix(s, "Z") || n >= 6 && (s[n-6] == '+' || s[n-6] == '-')
+}
+
+// HasOffset reports whether the datetime is written with a zone designator: false for a
+// value parsed from text without one
+func (d ISODateTime) HasOffset() bool {
+	return !d.local
+}
+
+// layout returns the layout that writes the datetime as it was parsed
+func (d ISODateTime) layout() string {
+	if !d.local && !d.numeric && d.fraction == 0 {
+		return isoDateTimeLayout
+	}
+	layout := "2006-01-02T15:04:05.999999999"
+	if d.fraction > 0 {
+		layout = layout[:len("2006-01-02T15:04:05.")] + strings.Repeat("0", d.fraction)
+	}
+	switch {
+	case d.local:
+		return layout
+	case d.numeric:
+		return layout + "-07:00"
+	}
+	return layout + "Z07:00"
+}
+
+// ParseISOTime parses an hh:mm:ss time with optional fractional seconds and offset
+func ParseISOTime(s string) (ISOTime, error) {
+	t, err := parseISO(s, isoTimeParseLayouts)
+	return ISOTime{t}, err
+}
+
+func parseISO(s string, layouts []string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	var err error
+	for _, layout := range layouts {
+		var t time.Time
+		if t, err = time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, err
+}
+
+// String returns the date as YYYY-MM-DD
+func (d ISODate) String() string {
+	return d.Format(isoDateLayout)
+}
+
+// String returns the datetime in its XML representation
+func (d ISODateTime) String() string {
+	return d.Format(d.layout())
+}
+
+// String returns the time in its XML representation
+func (t ISOTime) String() string {
+	return t.Format(isoTimeLayout)
+}
+
+// MarshalXML encodes the date as YYYY-MM-DD.
+func (d ISODate) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return encodeText(e, start, d.String())
+}
+
+// UnmarshalXML decodes a date from XML character data.
+func (d *ISODate) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	s, err := charData(dec)
+	if err != nil {
+		return err
+	}
+	v, err := ParseISODate(s)
+	if err != nil {
+		return err
+	}
+	*d = v
+	return nil
+}
+
+// MarshalXML encodes the datetime with Z for UTC values. The value is first converted to the
+// location configured with SetDateTimeLocation or WithDateTimeLocation, if any, unless it
+// was parsed without an offset.
+func (d ISODateTime) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if d.local {
+		return encodeText(e, start, d.String())
+	}
+	return encodeText(e, start, inLocation(e, d.Time).Format(d.layout()))
+}
+
+// UnmarshalXML decodes a datetime from XML character data.
+func (d *ISODateTime) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	s, err := charData(dec)
+	if err != nil {
+		return err
+	}
+	v, err := ParseISODateTime(s)
+	if err != nil {
+		return err
+	}
+	*d = v
+	return nil
+}
+
+// MarshalXML encodes the time of day, converted to the configured marshal location if any.
+func (t ISOTime) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return encodeText(e, start, inLocation(e, t.Time).Format(isoTimeLayout))
+}
+
+// UnmarshalXML decodes a time of day from XML character data.
+func (t *ISOTime) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	s, err := charData(dec)
+	if err != nil {
+		return err
+	}
+	v, err := ParseISOTime(s)
+	if err != nil {
+		return err
+	}
+	*t = v
+	return nil
+}
+
+// charData returns the character data of the element whose start tag was just
+// read, skipping nested elements as DecodeElement does for a string, without the
+// reflection DecodeElement costs on every amount, date and time of a bulk file
+func charData(d *xml.Decoder) (string, error) {
+	var text []byte
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return "", err
+		}
+		switch t := tok.(type) {
+		case xml.CharData:
+			text = append(text, t...)
+		case xml.StartElement:
+			if err := d.Skip(); err != nil {
+				return "", err
+			}
+		case xml.EndElement:
+			return string(text), nil
+		}
+	}
+}
+
+// encodeText writes an element with text as its character data
+func encodeText(e *xml.Encoder, start xml.StartElement, text string) error {
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+	if err := e.EncodeToken(xml.CharData(text)); err != nil {
+		return err
+	}
+	return e.EncodeToken(start.End())
+}
+
+// The embedded time.Time would otherwise provide RFC 3339 text and JSON encodings, so the
+// ISO types override them to keep every encoding consistent with the XML representation.
+
+// MarshalText implements encoding.TextMarshaler.
+func (d ISODate) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (d *ISODate) UnmarshalText(b []byte) error {
+	v, err := ParseISODate(string(b))
+	if err != nil {
+		return err
+	}
+	*d = v
+	return nil
+}
+
+// MarshalJSON encodes the date as a JSON string.
+func (d ISODate) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(d.String())), nil
+}
+
+// UnmarshalJSON decodes the date from a JSON string.
+func (d *ISODate) UnmarshalJSON(b []byte) error {
+	return unmarshalJSONText(b, d)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (d ISODateTime) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (d *ISODateTime) UnmarshalText(b []byte) error {
+	v, err := ParseISODateTime(string(b))
+	if err != nil {
+		return err
+	}
+	*d = v
+	return nil
+}
+
+// MarshalJSON encodes the datetime as a JSON string.
+func (d ISODateTime) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(d.String())), nil
+}
+
+// UnmarshalJSON decodes the datetime from a JSON string.
+func (d *ISODateTime) UnmarshalJSON(b []byte) error {
+	return unmarshalJSONText(b, d)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (t ISOTime) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (t *ISOTime) UnmarshalText(b []byte) error {
+	v, err := ParseISOTime(string(b))
+	if err != nil {
+		return err
+	}
+	*t = v
+	return nil
+}
+
+// MarshalJSON encodes the time as a JSON string.
+func (t ISOTime) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(t.String())), nil
+}
+
+// UnmarshalJSON decodes the time from a JSON string.
+func (t *ISOTime) UnmarshalJSON(b []byte) error {
+	return unmarshalJSONText(b, t)
+}
+
+// unmarshalJSONText decodes a JSON string into a TextUnmarshaler, ignoring null
+func unmarshalJSONText(b []byte, v interface{ UnmarshalText([]byte) error }) error {
+	if string(b) == "null" {
+		return nil
+	}
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return v.UnmarshalText([]byte(s))
+}
+
 // Authorization1 represents authorization information using either a standard code or proprietary format.
 // Used in group headers to specify authorization levels and types for payment messages.
 type Authorization1 struct {
@@ -438,7 +909,7 @@ type FinancialInstitutionIdentification18 struct {
 	ClearingSystemMemberID *ClearingSystemMemberIdentification `xml:"ClrSysMmbId,omitempty"`
 	LegalEntityIdentifier  *string                             `xml:"LEI,omitempty"`
 	Name                   *string                             `xml:"Nm,omitempty"`
-	PostalAddress          *PostalAddress                      `xml:"PstlAdr,omitempty"`
+	PostalAddress          *PostalAddress24                    `xml:"PstlAdr,omitempty"`
 	Other                  *GenericFinancialIdentification     `xml:"Othr,omitempty"`
 }
 
@@ -446,21 +917,21 @@ type FinancialInstitutionIdentification18 struct {
 // This structure matches the exact XSD schema requirements for branch data
 // within the pacs.008.001.08 message format.
 type BranchData3 struct {
-	ID                    *string        `xml:"Id,omitempty"`
-	LegalEntityIdentifier *string        `xml:"LEI,omitempty"`
-	Name                  *string        `xml:"Nm,omitempty"`
-	PostalAddress         *PostalAddress `xml:"PstlAdr,omitempty"`
+	ID                    *string          `xml:"Id,omitempty"`
+	LegalEntityIdentifier *string          `xml:"LEI,omitempty"`
+	Name                  *string          `xml:"Nm,omitempty"`
+	PostalAddress         *PostalAddress24 `xml:"PstlAdr,omitempty"`
 }
 
 // PaymentTypeInfo28 provides PACS.008.001.08 specific payment type details.
 // Contains instruction priority, service level, local instrument, sequence type and category purpose
 // as defined by the pacs.008.001.08 XSD schema specification.
 type PaymentTypeInfo28 struct {
-	InstructionPriority *string          `xml:"InstrPrty,omitempty"`
-	ServiceLevel        []ServiceLevel   `xml:"SvcLvl,omitempty"`
-	LocalInstrument     *LocalInstrument `xml:"LclInstrm,omitempty"`
-	SequenceType        *string          `xml:"SeqTp,omitempty"`
-	CategoryPurpose     *CategoryPurpose `xml:"CtgyPurp,omitempty"`
+	InstructionPriority *Priority2Code        `xml:"InstrPrty,omitempty"`
+	ClearingChannel     *ClearingChannel2Code `xml:"ClrChanl,omitempty"`
+	ServiceLevel        []ServiceLevel        `xml:"SvcLvl,omitempty"`
+	LocalInstrument     *LocalInstrument      `xml:"LclInstrm,omitempty"`
+	CategoryPurpose     *CategoryPurpose      `xml:"CtgyPurp,omitempty"`
 }
 
 // PartyIdentification135 contains PACS.008.001.08 specific party identification information.
@@ -611,10 +1082,10 @@ type PersonIdentification13 struct {
 }
 
 type DateAndPlaceOfBirth1 struct {
-	BirthDate       *string `xml:"BirthDt,omitempty"`
-	ProvinceOfBirth *string `xml:"PrvcOfBirth,omitempty"`
-	CityOfBirth     string  `xml:"CityOfBirth"`
-	CountryOfBirth  string  `xml:"CtryOfBirth"`
+	BirthDate       *ISODate `xml:"BirthDt,omitempty"`
+	ProvinceOfBirth *string  `xml:"PrvcOfBirth,omitempty"`
+	CityOfBirth     string   `xml:"CityOfBirth"`
+	CountryOfBirth  string   `xml:"CtryOfBirth"`
 }
 
 type GenericPersonIdentification2 struct {
@@ -641,7 +1112,7 @@ type Charges7 struct {
 
 // SettlementInstruction7 for pacs.008.001.08 (exact XSD match)
 type SettlementInstruction7 struct {
-	SettlementMethod                     string                                        `xml:"SttlmMtd"`
+	SettlementMethod                     SettlementMethod1Code                         `xml:"SttlmMtd"`
 	SettlementAccount                    *CashAccount                                  `xml:"SttlmAcct,omitempty"`
 	ClearingSystem                       *ClearingSystemIdentificationSecondary        `xml:"ClrSys,omitempty"`
 	InstructingReimbursementAgent        *BranchAndFinancialInstitutionIdentification6 `xml:"InstgRmbrsmntAgt,omitempty"`
@@ -652,20 +1123,16 @@ type SettlementInstruction7 struct {
 	ThirdReimbursementAgentAccount       *CashAccount                                  `xml:"ThrdRmbrsmntAgtAcct,omitempty"`
 }
 
-// CashAccount represents a cash account
-type CashAccount struct {
-	ID       AccountIdentification       `xml:"Id"`
-	Type     *CashAccountType            `xml:"Tp,omitempty"`
-	Currency *string                     `xml:"Ccy,omitempty"`
-	Name     *string                     `xml:"Nm,omitempty"`
-	Proxy    *ProxyAccountIdentification `xml:"Prxy,omitempty"`
-}
+// CashAccount is the cash account of the legacy components.
+//
+// Deprecated: CashAccount is an alias of CashAccount38; use CashAccount38.
+type CashAccount = CashAccount38
 
-// AccountIdentification contains account identification choices
-type AccountIdentification struct {
-	IBAN  *string                       `xml:"IBAN,omitempty"`
-	Other *GenericAccountIdentification `xml:"Othr,omitempty"`
-}
+// AccountIdentification is the account identification of the legacy components.
+//
+// Deprecated: AccountIdentification is an alias of AccountIdentification4; use
+// AccountIdentification4.
+type AccountIdentification = AccountIdentification4
 
 // PartyIdentification contains party identification information
 type PartyIdentification struct {
@@ -682,7 +1149,12 @@ type Party struct {
 	PrivateID      *PersonIdentification       `xml:"PrvtId,omitempty"`
 }
 
-// PostalAddress contains postal address information
+// PostalAddress contains postal address information. It has the elements of
+// PostalAddress24 under other field names, and is kept for the components that
+// still use it.
+//
+// Deprecated: use PostalAddress24; PostalAddress.PostalAddress24 and
+// PostalAddress24.Legacy convert between them.
 type PostalAddress struct {
 	AddressType        *string  `xml:"AdrTp,omitempty"`
 	Department         *string  `xml:"Dept,omitempty"`
@@ -718,7 +1190,7 @@ type TaxInfo struct {
 	Method                 *string                            `xml:"Mtd,omitempty"`
 	TotalTaxableBaseAmount *ActiveOrHistoricCurrencyAndAmount `xml:"TtlTaxblBaseAmt,omitempty"`
 	TotalTaxAmount         *ActiveOrHistoricCurrencyAndAmount `xml:"TtlTaxAmt,omitempty"`
-	Date                   *string                            `xml:"Dt,omitempty"`
+	Date                   *ISODate                           `xml:"Dt,omitempty"`
 	SequenceNumber         *Decimal                           `xml:"SeqNb,omitempty"`
 	Record                 []TaxRecord                        `xml:"Rcrd,omitempty"`
 }
@@ -777,15 +1249,15 @@ type SupplementaryDataEnvelope1 struct {
 // Additional supporting types for pacs.009.001.08
 
 type SettlementDateTimeIndication1 struct {
-	DebitDateTime  *time.Time `xml:"DbtDtTm,omitempty"`
-	CreditDateTime *time.Time `xml:"CdtDtTm,omitempty"`
+	DebitDateTime  *ISODateTime `xml:"DbtDtTm,omitempty"`
+	CreditDateTime *ISODateTime `xml:"CdtDtTm,omitempty"`
 }
 
 type SettlementTimeRequest2 struct {
-	ContinuousLinkedSettlementTime *time.Time `xml:"CLSTm,omitempty"`
-	TillTime                       *time.Time `xml:"TillTm,omitempty"`
-	FromTime                       *time.Time `xml:"FrTm,omitempty"`
-	RejectTime                     *time.Time `xml:"RjctTm,omitempty"`
+	ContinuousLinkedSettlementTime *ISOTime `xml:"CLSTm,omitempty"`
+	TillTime                       *ISOTime `xml:"TillTm,omitempty"`
+	FromTime                       *ISOTime `xml:"FrTm,omitempty"`
+	RejectTime                     *ISOTime `xml:"RjctTm,omitempty"`
 }
 
 type InstructionForCreditorAgent2 struct {
@@ -863,7 +1335,7 @@ type TaxInfo8 struct {
 	Method                 *string                            `xml:"Mtd,omitempty"`
 	TotalTaxableBaseAmount *ActiveOrHistoricCurrencyAndAmount `xml:"TtlTaxblBaseAmt,omitempty"`
 	TotalTaxAmount         *ActiveOrHistoricCurrencyAndAmount `xml:"TtlTaxAmt,omitempty"`
-	Date                   *string                            `xml:"Dt,omitempty"`
+	Date                   *ISODate                           `xml:"Dt,omitempty"`
 	SequenceNumber         *Decimal                           `xml:"SeqNb,omitempty"`
 	Record                 []TaxRecord2                       `xml:"Rcrd,omitempty"`
 }
@@ -922,8 +1394,8 @@ type TaxRecordDetails2 struct {
 }
 
 type DatePeriod2 struct {
-	FromDate *string `xml:"FrDt,omitempty"`
-	ToDate   *string `xml:"ToDt,omitempty"`
+	FromDate *ISODate `xml:"FrDt,omitempty"`
+	ToDate   *ISODate `xml:"ToDt,omitempty"`
 }
 
 type StructuredRemittanceInfo16 struct {
@@ -940,7 +1412,7 @@ type StructuredRemittanceInfo16 struct {
 type ReferredDocumentInfo7 struct {
 	Type        *ReferredDocumentType4 `xml:"Tp,omitempty"`
 	Number      *string                `xml:"Nb,omitempty"`
-	RelatedDate *string                `xml:"RltdDt,omitempty"`
+	RelatedDate *ISODate               `xml:"RltdDt,omitempty"`
 	LineDetails []DocumentLineInfo1    `xml:"LineDtls,omitempty"`
 }
 
@@ -963,7 +1435,7 @@ type DocumentLineInfo1 struct {
 type DocumentLineIdentification1 struct {
 	Type        *DocumentLineTypeAndIssuer1 `xml:"Tp,omitempty"`
 	Number      *string                     `xml:"Nb,omitempty"`
-	RelatedDate *time.Time                  `xml:"RltdDt,omitempty"`
+	RelatedDate *ISODate                    `xml:"RltdDt,omitempty"`
 }
 
 type DocumentLineTypeAndIssuer1 struct {
@@ -1018,7 +1490,7 @@ type TaxInfo7 struct {
 	Method                 *string                            `xml:"Mtd,omitempty"`
 	TotalTaxableBaseAmount *ActiveOrHistoricCurrencyAndAmount `xml:"TtlTaxblBaseAmt,omitempty"`
 	TotalTaxAmount         *ActiveOrHistoricCurrencyAndAmount `xml:"TtlTaxAmt,omitempty"`
-	Date                   *string                            `xml:"Dt,omitempty"`
+	Date                   *ISODate                           `xml:"Dt,omitempty"`
 	SequenceNumber         *Decimal                           `xml:"SeqNb,omitempty"`
 	Record                 []TaxRecord2                       `xml:"Rcrd,omitempty"`
 }
@@ -1028,7 +1500,7 @@ type Garnishment3 struct {
 	Garnishee                       *PartyIdentification135            `xml:"Grnshee,omitempty"`
 	GarnishmentAdministrator        *PartyIdentification135            `xml:"GrnshmtAdmstr,omitempty"`
 	ReferenceNumber                 *string                            `xml:"RefNb,omitempty"`
-	Date                            *time.Time                         `xml:"Dt,omitempty"`
+	Date                            *ISODate                           `xml:"Dt,omitempty"`
 	RemittedAmount                  *ActiveOrHistoricCurrencyAndAmount `xml:"RmtdAmt,omitempty"`
 	FamilyMedicalInsuranceIndicator *bool                              `xml:"FmlyMdclInsrncInd,omitempty"`
 	EmployeeTerminationIndicator    *bool                              `xml:"MplyeeTermntnInd,omitempty"`
@@ -1070,15 +1542,15 @@ type TaxAmountType1 struct {
 // DocumentAdjustment1 matches XSD type
 type DocumentAdjustment1 struct {
 	Amount               ActiveOrHistoricCurrencyAndAmount `xml:"Amt"`
-	CreditDebitIndicator *string                           `xml:"CdtDbtInd,omitempty"`
+	CreditDebitIndicator *CreditDebitCode                  `xml:"CdtDbtInd,omitempty"`
 	Reason               *string                           `xml:"Rsn,omitempty"`
 	AdditionalInfo       *string                           `xml:"AddtlInf,omitempty"`
 }
 
 // DateAndDateTime2 - Choice between date or datetime
 type DateAndDateTime2 struct {
-	Date     *string    `xml:"Dt,omitempty"`   // ISODate
-	DateTime *time.Time `xml:"DtTm,omitempty"` // ISODateTime
+	Date     *ISODate     `xml:"Dt,omitempty"`   // ISODate
+	DateTime *ISODateTime `xml:"DtTm,omitempty"` // ISODateTime
 }
 
 // Supporting types for completeness
@@ -1118,31 +1590,24 @@ type FinancialIdentificationSchemeName struct {
 	Proprietary *string `xml:"Prtry,omitempty"`
 }
 
-type CashAccountType struct {
-	Code        *string `xml:"Cd,omitempty"`
-	Proprietary *string `xml:"Prtry,omitempty"`
-}
+// Deprecated: CashAccountType is an alias of CashAccountType2; use CashAccountType2.
+type CashAccountType = CashAccountType2
 
-type ProxyAccountIdentification struct {
-	Type *ProxyAccountType `xml:"Tp,omitempty"`
-	ID   string            `xml:"Id"`
-}
+// Deprecated: ProxyAccountIdentification is an alias of
+// ProxyAccountIdentification1; use ProxyAccountIdentification1.
+type ProxyAccountIdentification = ProxyAccountIdentification1
 
-type ProxyAccountType struct {
-	Code        *string `xml:"Cd,omitempty"`
-	Proprietary *string `xml:"Prtry,omitempty"`
-}
+// Deprecated: ProxyAccountType is an alias of ProxyAccountType1; use
+// ProxyAccountType1.
+type ProxyAccountType = ProxyAccountType1
 
-type GenericAccountIdentification struct {
-	ID         string             `xml:"Id"`
-	SchemeName *AccountSchemeName `xml:"SchmeNm,omitempty"`
-	Issuer     *string            `xml:"Issr,omitempty"`
-}
+// Deprecated: GenericAccountIdentification is an alias of
+// GenericAccountIdentification1; use GenericAccountIdentification1.
+type GenericAccountIdentification = GenericAccountIdentification1
 
-type AccountSchemeName struct {
-	Code        *string `xml:"Cd,omitempty"`
-	Proprietary *string `xml:"Prtry,omitempty"`
-}
+// Deprecated: AccountSchemeName is an alias of AccountSchemeName1; use
+// AccountSchemeName1.
+type AccountSchemeName = AccountSchemeName1
 
 type OrganizationIdentification struct {
 	AnyBankIdentifierCode *string                             `xml:"AnyBIC,omitempty"`
@@ -1167,10 +1632,10 @@ type OrganizationIdentificationSchemeName struct {
 }
 
 type DateAndPlaceOfBirth struct {
-	BirthDate       *string `xml:"BirthDt,omitempty"`
-	ProvinceOfBirth *string `xml:"PrvcOfBirth,omitempty"`
-	CityOfBirth     string  `xml:"CityOfBirth"`
-	CountryOfBirth  string  `xml:"CtryOfBirth"`
+	BirthDate       *ISODate `xml:"BirthDt,omitempty"`
+	ProvinceOfBirth *string  `xml:"PrvcOfBirth,omitempty"`
+	CityOfBirth     string   `xml:"CityOfBirth"`
+	CountryOfBirth  string   `xml:"CtryOfBirth"`
 }
 
 type GenericPersonIdentification struct {
@@ -1222,14 +1687,14 @@ type TaxRecord struct {
 }
 
 type TaxPeriod struct {
-	Year       *time.Time  `xml:"Yr,omitempty"`
+	Year       *string     `xml:"Yr,omitempty"` // ISOYear
 	Type       *string     `xml:"Tp,omitempty"`
 	FromToDate *DatePeriod `xml:"FrToDt,omitempty"`
 }
 
 type DatePeriod struct {
-	FromDate *string `xml:"FrDt,omitempty"`
-	ToDate   *string `xml:"ToDt,omitempty"`
+	FromDate *ISODate `xml:"FrDt,omitempty"`
+	ToDate   *ISODate `xml:"ToDt,omitempty"`
 }
 
 type TaxAmount struct {
@@ -1269,7 +1734,7 @@ type StructuredRemittanceInfo struct {
 type ReferredDocumentInfo struct {
 	Type        *ReferredDocumentType `xml:"Tp,omitempty"`
 	Number      *string               `xml:"Nb,omitempty"`
-	RelatedDate *string               `xml:"RltdDt,omitempty"`
+	RelatedDate *ISODate              `xml:"RltdDt,omitempty"`
 	LineDetails []DocumentLineInfo    `xml:"LineDtls,omitempty"`
 }
 
@@ -1292,7 +1757,7 @@ type DocumentLineInfo struct {
 type DocumentLineIdentification struct {
 	Type        *DocumentLineType `xml:"Tp,omitempty"`
 	Number      *string           `xml:"Nb,omitempty"`
-	RelatedDate *string           `xml:"RltdDt,omitempty"`
+	RelatedDate *ISODate          `xml:"RltdDt,omitempty"`
 }
 
 type DocumentLineType struct {
@@ -1345,7 +1810,7 @@ type TaxAmountType struct {
 
 type DocumentAdjustment struct {
 	Amount                ActiveOrHistoricCurrencyAndAmount `xml:"Amt"`
-	CreditDebitIndicator  *string                           `xml:"CdtDbtInd,omitempty"`
+	CreditDebitIndicator  *CreditDebitCode                  `xml:"CdtDbtInd,omitempty"`
 	Reason                *string                           `xml:"Rsn,omitempty"`
 	AdditionalInformation *string                           `xml:"AddtlInf,omitempty"`
 }
@@ -1373,7 +1838,7 @@ type TaxInfoSecondary struct {
 	Method                 *string                            `xml:"Mtd,omitempty"`
 	TotalTaxableBaseAmount *ActiveOrHistoricCurrencyAndAmount `xml:"TtlTaxblBaseAmt,omitempty"`
 	TotalTaxAmount         *ActiveOrHistoricCurrencyAndAmount `xml:"TtlTaxAmt,omitempty"`
-	Date                   *string                            `xml:"Dt,omitempty"`
+	Date                   *ISODate                           `xml:"Dt,omitempty"`
 	SequenceNumber         *Decimal                           `xml:"SeqNb,omitempty"`
 	Record                 []TaxRecord                        `xml:"Rcrd,omitempty"`
 }
@@ -1383,7 +1848,7 @@ type Garnishment struct {
 	Garnishee                       *PartyIdentification               `xml:"Grnshee,omitempty"`
 	GarnishmentAdministrator        *PartyIdentification               `xml:"GrnshmtAdmstr,omitempty"`
 	ReferenceNumber                 *string                            `xml:"RefNb,omitempty"`
-	Date                            *string                            `xml:"Dt,omitempty"`
+	Date                            *ISODate                           `xml:"Dt,omitempty"`
 	RemittedAmount                  *ActiveOrHistoricCurrencyAndAmount `xml:"RmtdAmt,omitempty"`
 	FamilyMedicalInsuranceIndicator *bool                              `xml:"FmlyMdclInsrncInd,omitempty"`
 	EmployeeTerminationIndicator    *bool                              `xml:"MplyeeTermntnInd,omitempty"`
@@ -1401,7 +1866,7 @@ type GarnishmentTypeOption struct {
 
 // Additional supporting types
 type SettlementInstruction struct {
-	SettlementMethod                     string                                       `xml:"SttlmMtd"`
+	SettlementMethod                     SettlementMethod1Code                        `xml:"SttlmMtd"`
 	SettlementAccount                    *CashAccount                                 `xml:"SttlmAcct,omitempty"`
 	ClearingSystem                       *ClearingSystemIdentificationSecondary       `xml:"ClrSys,omitempty"`
 	InstructingReimbursementAgent        *BranchAndFinancialInstitutionIdentification `xml:"InstgRmbrsmntAgt,omitempty"`
@@ -1418,15 +1883,15 @@ type ClearingSystemIdentificationSecondary struct {
 }
 
 type SettlementDateTimeIndication struct {
-	DebitDateTime  *time.Time `xml:"DbtDtTm,omitempty"`
-	CreditDateTime *time.Time `xml:"CdtDtTm,omitempty"`
+	DebitDateTime  *ISODateTime `xml:"DbtDtTm,omitempty"`
+	CreditDateTime *ISODateTime `xml:"CdtDtTm,omitempty"`
 }
 
 type SettlementTimeRequest struct {
-	ClearingSystemTime *time.Time `xml:"CLSTm,omitempty"`
-	TillTime           *time.Time `xml:"TillTm,omitempty"`
-	FromTime           *time.Time `xml:"FrTm,omitempty"`
-	RejectTime         *time.Time `xml:"RjctTm,omitempty"`
+	ClearingSystemTime *ISOTime `xml:"CLSTm,omitempty"`
+	TillTime           *ISOTime `xml:"TillTm,omitempty"`
+	FromTime           *ISOTime `xml:"FrTm,omitempty"`
+	RejectTime         *ISOTime `xml:"RjctTm,omitempty"`
 }
 
 type InstructionForCreditorAgent struct {
@@ -1457,7 +1922,7 @@ type RegulatoryAuthority2 struct {
 
 type StructuredRegulatoryReporting3 struct {
 	Type        *string                            `xml:"Tp,omitempty"`
-	Date        *string                            `xml:"Dt,omitempty"`
+	Date        *ISODate                           `xml:"Dt,omitempty"`
 	Country     *string                            `xml:"Ctry,omitempty"`
 	Code        *string                            `xml:"Cd,omitempty"`
 	Amount      *ActiveOrHistoricCurrencyAndAmount `xml:"Amt,omitempty"`
@@ -1536,10 +2001,10 @@ type AccountReportingRequestV05 struct {
 
 // UnderlyingGroupInformation1 - Group information for camt.026.001.07
 type UnderlyingGroupInformation1 struct {
-	OriginalMessageID              string     `xml:"OrgnlMsgId"`                   // Max35Text - Required
-	OriginalMessageNameID          string     `xml:"OrgnlMsgNmId"`                 // Max35Text - Required
-	OriginalCreationDateTime       *time.Time `xml:"OrgnlCreDtTm,omitempty"`       // ISODateTime
-	OriginalMessageDeliveryChannel *string    `xml:"OrgnlMsgDlvryChanl,omitempty"` // Max35Text
+	OriginalMessageID              string       `xml:"OrgnlMsgId"`                   // Max35Text - Required
+	OriginalMessageNameID          string       `xml:"OrgnlMsgNmId"`                 // Max35Text - Required
+	OriginalCreationDateTime       *ISODateTime `xml:"OrgnlCreDtTm,omitempty"`       // ISODateTime
+	OriginalMessageDeliveryChannel *string      `xml:"OrgnlMsgDlvryChanl,omitempty"` // Max35Text
 }
 
 // UnderlyingPaymentInstruction5 - Payment instruction for camt.026.001.07
@@ -1551,7 +2016,7 @@ type UnderlyingPaymentInstruction5 struct {
 	OriginalUETR             *string                           `xml:"OrgnlUETR,omitempty"`       // UUIDv4Identifier
 	OriginalInstructedAmount ActiveOrHistoricCurrencyAndAmount `xml:"OrgnlInstdAmt"`             // Required
 	RequestedExecutionDate   *DateAndDateTime2                 `xml:"ReqdExctnDt,omitempty"`
-	RequestedCollectionDate  *string                           `xml:"ReqdColltnDt,omitempty"` // ISODate
+	RequestedCollectionDate  *ISODate                          `xml:"ReqdColltnDt,omitempty"` // ISODate
 	OriginalTransactionRef   *OriginalTransactionReference28   `xml:"OrgnlTxRef,omitempty"`
 }
 
@@ -1563,7 +2028,7 @@ type UnderlyingPaymentTransaction4 struct {
 	OriginalTransactionID             *string                           `xml:"OrgnlTxId,omitempty"`       // Max35Text
 	OriginalUETR                      *string                           `xml:"OrgnlUETR,omitempty"`       // UUIDv4Identifier
 	OriginalInterbankSettlementAmount ActiveOrHistoricCurrencyAndAmount `xml:"OrgnlIntrBkSttlmAmt"`       // ADDED: Required
-	OriginalInterbankSettlementDate   string                            `xml:"OrgnlIntrBkSttlmDt"`        // ADDED: Required ISODate
+	OriginalInterbankSettlementDate   ISODate                           `xml:"OrgnlIntrBkSttlmDt"`        // ADDED: Required ISODate
 	OriginalTransactionReference      *OriginalTransactionReference28   `xml:"OrgnlTxRef,omitempty"`      // ADDED: Optional
 }
 
@@ -1605,6 +2070,25 @@ type UnableToApplyV07 struct {
 	SupplementaryData []SupplementaryData1        `xml:"SplmtryData,omitempty"` // FIXED: was SupplementaryData, now SupplementaryData1
 }
 
+// ProprietaryFormatInvestigationV05 - camt.035.001.05
+type ProprietaryFormatInvestigationV05 struct {
+	Assignment        CaseAssignment5      `xml:"Assgnmt"`
+	Case              *Case5               `xml:"Case,omitempty"`
+	ProprietaryData   ProprietaryData7     `xml:"PrtryData"`
+	SupplementaryData []SupplementaryData1 `xml:"SplmtryData,omitempty"`
+}
+
+// ProprietaryData7 - Proprietary data of camt.035.001.05
+type ProprietaryData7 struct {
+	Type string                 `xml:"Tp"` // Max35Text
+	Data ProprietaryDataContent `xml:"Data"`
+}
+
+// ProprietaryDataContent holds the proprietary data as XML of any content
+type ProprietaryDataContent struct {
+	Content string `xml:",innerxml"`
+}
+
 // AdditionalPaymentInfoV09 - camt.028.001.09
 type AdditionalPaymentInfoV09 struct {
 	Assignment        CaseAssignment5           `xml:"Assgnmt"`
@@ -1625,7 +2109,7 @@ type InvestigationStatus5 struct {
 
 // ClaimNonReceiptDetails - Actual claim details from camt.029.001.09 XSD
 type ClaimNonReceiptDetails struct {
-	DateProcessed     string                                        `xml:"DtPrcd"` // ISODate - Required
+	DateProcessed     ISODate                                       `xml:"DtPrcd"` // ISODate - Required
 	OriginalNextAgent *BranchAndFinancialInstitutionIdentification6 `xml:"OrgnlNxtAgt,omitempty"`
 }
 
@@ -1643,9 +2127,9 @@ type ClaimNonReceipt2 struct {
 
 // CorrectiveGroupInformation1 - Group information for corrective transactions from camt.029.001.09 XSD
 type CorrectiveGroupInformation1 struct {
-	MessageID        string     `xml:"MsgId"`             // Max35Text - Required
-	MessageNameID    string     `xml:"MsgNmId"`           // Max35Text - Required
-	CreationDateTime *time.Time `xml:"CreDtTm,omitempty"` // ISODateTime
+	MessageID        string       `xml:"MsgId"`             // Max35Text - Required
+	MessageNameID    string       `xml:"MsgNmId"`           // Max35Text - Required
+	CreationDateTime *ISODateTime `xml:"CreDtTm,omitempty"` // ISODateTime
 }
 
 // CorrectivePaymentInitiation4 - Corrective payment initiation from camt.029.001.09 XSD
@@ -1657,7 +2141,7 @@ type CorrectivePaymentInitiation4 struct {
 	UETR                         *string                           `xml:"UETR,omitempty"`       // UUIDv4Identifier
 	InstructedAmount             ActiveOrHistoricCurrencyAndAmount `xml:"InstdAmt"`             // Required
 	RequestedExecutionDate       *DateAndDateTime2                 `xml:"ReqdExctnDt,omitempty"`
-	RequestedCollectionDate      *string                           `xml:"ReqdColltnDt,omitempty"` // ISODate
+	RequestedCollectionDate      *ISODate                          `xml:"ReqdColltnDt,omitempty"` // ISODate
 	CreditorSchemeIdentification *PartyIdentification135           `xml:"CdtrSchmeId,omitempty"`
 	// Note: Additional fields from XSD not implemented for brevity - can be added as needed
 }
@@ -1670,7 +2154,7 @@ type CorrectiveInterbankTransaction2 struct {
 	TransactionID             *string                           `xml:"TxId,omitempty"`       // Max35Text
 	UETR                      *string                           `xml:"UETR,omitempty"`       // UUIDv4Identifier
 	InterbankSettlementAmount ActiveOrHistoricCurrencyAndAmount `xml:"IntrBkSttlmAmt"`       // Required
-	InterbankSettlementDate   string                            `xml:"IntrBkSttlmDt"`        // ISODate - Required
+	InterbankSettlementDate   ISODate                           `xml:"IntrBkSttlmDt"`        // ISODate - Required
 }
 
 // CorrectiveTransaction4 - Choice for corrective transaction from camt.029.001.09 XSD
@@ -1693,6 +2177,13 @@ type ResolutionOfInvestigationV09 struct {
 	SupplementaryData      []SupplementaryData1       `xml:"SplmtryData,omitempty"`  // FIXED: was SupplementaryData, now SupplementaryData1
 }
 
+// CustomerCreditTransferInitiationV09 - pain.001.001.09
+type CustomerCreditTransferInitiationV09 struct {
+	GroupHeader       GroupHeader85          `xml:"GrpHdr"`
+	PaymentInfo       []PaymentInstruction30 `xml:"PmtInf"`
+	SupplementaryData []SupplementaryData1   `xml:"SplmtryData,omitempty"`
+}
+
 // CreditorPaymentActivationRequestV07 - pain.013.001.07
 type CreditorPaymentActivationRequestV07 struct {
 	GroupHeader       GroupHeader78          `xml:"GrpHdr"`
@@ -1731,9 +2222,9 @@ type ResendRequestV01 struct {
 
 // MessageHeader10 represents message identification and optional creation date/time for admi.007.001.01
 type MessageHeader10 struct {
-	MessageID        string     `xml:"MsgId"`
-	CreationDateTime *time.Time `xml:"CreDtTm,omitempty"`
-	QueryName        *string    `xml:"QryNm,omitempty"`
+	MessageID        string       `xml:"MsgId"`
+	CreationDateTime *ISODateTime `xml:"CreDtTm,omitempty"`
+	QueryName        *string      `xml:"QryNm,omitempty"`
 }
 
 // MessageReference1 contains a reference to the original message and optional issuer
@@ -1745,9 +2236,9 @@ type MessageReference1 struct {
 
 // RequestHandling2 contains status information for the receipt acknowledgement
 type RequestHandling2 struct {
-	StatusCode     string     `xml:"StsCd"`
-	StatusDateTime *time.Time `xml:"StsDtTm,omitempty"`
-	Description    *string    `xml:"Desc,omitempty"`
+	StatusCode     string       `xml:"StsCd"`
+	StatusDateTime *ISODateTime `xml:"StsDtTm,omitempty"`
+	Description    *string      `xml:"Desc,omitempty"`
 }
 
 // ReceiptAcknowledgementReport2 contains the related reference and request handling information
@@ -1827,11 +2318,11 @@ type MessageReference struct {
 // Includes the rejecting party's reason code, optional rejection timestamp, error location,
 // descriptive reason, and additional diagnostic data for troubleshooting.
 type RejectionReason2 struct {
-	RejectingPartyReason string     `xml:"RjctgPtyRsn"`
-	RejectionDateTime    *time.Time `xml:"RjctnDtTm,omitempty"`
-	ErrorLocation        *string    `xml:"ErrLctn,omitempty"`
-	ReasonDescription    *string    `xml:"RsnDesc,omitempty"`
-	AdditionalData       *string    `xml:"AddtlData,omitempty"`
+	RejectingPartyReason string       `xml:"RjctgPtyRsn"`
+	RejectionDateTime    *ISODateTime `xml:"RjctnDtTm,omitempty"`
+	ErrorLocation        *string      `xml:"ErrLctn,omitempty"`
+	ReasonDescription    *string      `xml:"RsnDesc,omitempty"`
+	AdditionalData       *string      `xml:"AddtlData,omitempty"`
 }
 
 // AdministrationProprietaryMessageV02 - admi.998.001.02
@@ -1846,14 +2337,14 @@ type AdministrationProprietaryMessageV02 struct {
 // Transaction and Group Header types
 type GroupHeader90 struct {
 	MessageID                              string                                        `xml:"MsgId"`
-	CreationDateTime                       time.Time                                     `xml:"CreDtTm"`
+	CreationDateTime                       ISODateTime                                   `xml:"CreDtTm"`
 	Authorization                          []Authorization1                              `xml:"Authstn,omitempty"`
 	BatchBooking                           *bool                                         `xml:"BtchBookg,omitempty"`
 	NumberOfTransactions                   string                                        `xml:"NbOfTxs"`
 	ControlSum                             *Decimal                                      `xml:"CtrlSum,omitempty"`
 	GroupReturn                            *bool                                         `xml:"GrpRtr,omitempty"`
 	TotalReturnedInterbankSettlementAmount *ActiveCurrencyAndAmount                      `xml:"TtlRtrdIntrBkSttlmAmt,omitempty"`
-	InterbankSettlementDate                *string                                       `xml:"IntrBkSttlmDt,omitempty"`
+	InterbankSettlementDate                *ISODate                                      `xml:"IntrBkSttlmDt,omitempty"`
 	SettlementInfo                         SettlementInstruction7                        `xml:"SttlmInf"`
 	InstructingAgent                       *BranchAndFinancialInstitutionIdentification6 `xml:"InstgAgt,omitempty"`
 	InstructedAgent                        *BranchAndFinancialInstitutionIdentification6 `xml:"InstdAgt,omitempty"`
@@ -1861,14 +2352,14 @@ type GroupHeader90 struct {
 
 type GroupHeader91 struct {
 	MessageID        string                                        `xml:"MsgId"`
-	CreationDateTime time.Time                                     `xml:"CreDtTm"`
+	CreationDateTime ISODateTime                                   `xml:"CreDtTm"`
 	InstructingAgent *BranchAndFinancialInstitutionIdentification6 `xml:"InstgAgt,omitempty"`
 	InstructedAgent  *BranchAndFinancialInstitutionIdentification6 `xml:"InstdAgt,omitempty"`
 }
 
 type GroupHeader81 struct {
 	MsgID                 string                  `xml:"MsgId"`
-	CreationDateTime      *time.Time              `xml:"CreDtTm,omitempty"`
+	CreationDateTime      *ISODateTime            `xml:"CreDtTm,omitempty"`
 	MessageRecipient      *PartyIdentification    `xml:"MsgRcpt,omitempty"`
 	MessagePagination     *Pagination1            `xml:"MsgPgntn,omitempty"`
 	OriginalBusinessQuery *OriginalBusinessQuery1 `xml:"OrgnlBizQry,omitempty"`
@@ -1877,15 +2368,25 @@ type GroupHeader81 struct {
 
 type GroupHeader78 struct {
 	MessageID            string                 `xml:"MsgId"`
-	CreationDateTime     time.Time              `xml:"CreDtTm"`
+	CreationDateTime     ISODateTime            `xml:"CreDtTm"`
 	NumberOfTransactions string                 `xml:"NbOfTxs"`
 	ControlSum           *Decimal               `xml:"CtrlSum,omitempty"`
 	InitiatingParty      PartyIdentification135 `xml:"InitgPty"`
 }
 
+// GroupHeader85 - Group header for pain.001.001.09
+type GroupHeader85 struct {
+	MessageID            string                                        `xml:"MsgId"`
+	CreationDateTime     ISODateTime                                   `xml:"CreDtTm"`
+	NumberOfTransactions string                                        `xml:"NbOfTxs"`
+	ControlSum           *Decimal                                      `xml:"CtrlSum,omitempty"`
+	InitiatingParty      PartyIdentification135                        `xml:"InitgPty"`
+	ForwardingAgent      *BranchAndFinancialInstitutionIdentification6 `xml:"FwdgAgt,omitempty"`
+}
+
 type GroupHeader86 struct {
 	MessageID        string                                       `xml:"MsgId"`
-	CreationDateTime *time.Time                                   `xml:"CreDtTm,omitempty"`
+	CreationDateTime *ISODateTime                                 `xml:"CreDtTm,omitempty"`
 	InitiatingParty  PartyIdentification                          `xml:"InitgPty"`
 	ForwardingAgent  *BranchAndFinancialInstitutionIdentification `xml:"FwdgAgt,omitempty"`
 }
@@ -1893,7 +2394,7 @@ type GroupHeader86 struct {
 // GroupHeader87 - Group header for pain.014.001.07
 type GroupHeader87 struct {
 	MessageID        string                                        `xml:"MsgId"`
-	CreationDateTime time.Time                                     `xml:"CreDtTm"`
+	CreationDateTime ISODateTime                                   `xml:"CreDtTm"`
 	InitiatingParty  PartyIdentification135                        `xml:"InitgPty"`
 	DebtorAgent      *BranchAndFinancialInstitutionIdentification6 `xml:"DbtrAgt,omitempty"`
 	CreditorAgent    *BranchAndFinancialInstitutionIdentification6 `xml:"CdtrAgt,omitempty"`
@@ -1903,7 +2404,7 @@ type GroupHeader87 struct {
 type OriginalGroupInformation30 struct {
 	OriginalMessageID             string                           `xml:"OrgnlMsgId"`
 	OriginalMessageNameID         string                           `xml:"OrgnlMsgNmId"`
-	OriginalCreationDateTime      *time.Time                       `xml:"OrgnlCreDtTm,omitempty"`
+	OriginalCreationDateTime      *ISODateTime                     `xml:"OrgnlCreDtTm,omitempty"`
 	OriginalNumberOfTransactions  *string                          `xml:"OrgnlNbOfTxs,omitempty"`
 	OriginalControlSum            *Decimal                         `xml:"OrgnlCtrlSum,omitempty"`
 	GroupStatus                   *string                          `xml:"GrpSts,omitempty"`
@@ -1930,7 +2431,7 @@ type PaymentTransaction104 struct {
 	OriginalUETR                 *string                         `xml:"OrgnlUETR,omitempty"`
 	TransactionStatus            *string                         `xml:"TxSts,omitempty"`
 	StatusReasonInfo             []StatusReasonInfo12            `xml:"StsRsnInf,omitempty"`
-	AcceptanceDateTime           *time.Time                      `xml:"AccptncDtTm,omitempty"`
+	AcceptanceDateTime           *ISODateTime                    `xml:"AccptncDtTm,omitempty"`
 	AccountServicerReference     *string                         `xml:"AcctSvcrRef,omitempty"`
 	ClearingSystemReference      *string                         `xml:"ClrSysRef,omitempty"`
 	OriginalTransactionReference *OriginalTransactionReference29 `xml:"OrgnlTxRef,omitempty"`
@@ -1961,7 +2462,7 @@ type CreditTransferTransaction36 struct {
 	PaymentID                        PaymentIdentification7                        `xml:"PmtId"`
 	PaymentTypeInfo                  *PaymentTypeInfo28                            `xml:"PmtTpInf,omitempty"`
 	InterbankSettlementAmount        ActiveCurrencyAndAmount                       `xml:"IntrBkSttlmAmt"`
-	InterbankSettlementDate          *string                                       `xml:"IntrBkSttlmDt,omitempty"`
+	InterbankSettlementDate          *ISODate                                      `xml:"IntrBkSttlmDt,omitempty"`
 	SettlementPriority               *string                                       `xml:"SttlmPrty,omitempty"`
 	SettlementTimeIndication         *SettlementDateTimeIndication1                `xml:"SttlmTmIndctn,omitempty"`
 	SettlementTimeRequest            *SettlementTimeRequest2                       `xml:"SttlmTmReq,omitempty"`
@@ -2001,7 +2502,7 @@ type CreditTransferTransactionInfo35 struct {
 	PaymentID                    PaymentIdentification                        `xml:"PmtId"`
 	PaymentTypeInfo              *PaymentTypeInfo                             `xml:"PmtTpInf,omitempty"`
 	InterbankSettlementAmount    ActiveCurrencyAndAmount                      `xml:"IntrBkSttlmAmt"`
-	InterbankSettlementDate      *string                                      `xml:"IntrBkSttlmDt,omitempty"`
+	InterbankSettlementDate      *ISODate                                     `xml:"IntrBkSttlmDt,omitempty"`
 	SettlementPriority           *string                                      `xml:"SttlmPrty,omitempty"`
 	SettlementTimeIndication     *SettlementDateTimeIndication                `xml:"SttlmTmIndctn,omitempty"`
 	SettlementTimeRequest        *SettlementTimeRequest                       `xml:"SttlmTmReq,omitempty"`
@@ -2034,7 +2535,7 @@ type PaymentTransactionInfo52 struct {
 	TransactionStatus                *string                                       `xml:"TxSts,omitempty"`
 	StatusReasonInfo                 []StatusReasonInfo12                          `xml:"StsRsnInf,omitempty"`
 	ChargesInfo                      []Charges                                     `xml:"ChrgsInf,omitempty"`
-	AcceptanceDateTime               *time.Time                                    `xml:"AccptncDtTm,omitempty"`
+	AcceptanceDateTime               *ISODateTime                                  `xml:"AccptncDtTm,omitempty"`
 	EffectiveInterbankSettlementDate *DateAndDateTime2                             `xml:"FctvIntrBkSttlmDt,omitempty"`
 	AccountServicerReference         *string                                       `xml:"AcctSvcrRef,omitempty"`
 	ClearingSystemReference          *string                                       `xml:"ClrSysRef,omitempty"`
@@ -2054,7 +2555,7 @@ type PaymentTransactionInfo51 struct {
 	OriginalClearingSystemReference   *string                            `xml:"OrgnlClrSysRef,omitempty"`
 	OriginalInterbankSettlementAmount *ActiveOrHistoricCurrencyAndAmount `xml:"OrgnlIntrBkSttlmAmt,omitempty"`
 	ReturnedInterbankSettlementAmount ActiveCurrencyAndAmount            `xml:"RtrdIntrBkSttlmAmt"`
-	InterbankSettlementDate           *string                            `xml:"IntrBkSttlmDt,omitempty"`
+	InterbankSettlementDate           *ISODate                           `xml:"IntrBkSttlmDt,omitempty"`
 	ReturnedInstructedAmount          *ActiveOrHistoricCurrencyAndAmount `xml:"RtrdInstdAmt,omitempty"`
 	ExchangeRate                      *Decimal                           `xml:"XchgRate,omitempty"`
 	CompensationAmount                *ActiveOrHistoricCurrencyAndAmount `xml:"CompstnAmt,omitempty"`
@@ -2078,7 +2579,7 @@ type PaymentTransactionInfo50 struct {
 type OriginalGroupHeader17 struct {
 	OriginalMessageID             string                           `xml:"OrgnlMsgId"`
 	OriginalMessageNameID         string                           `xml:"OrgnlMsgNmId"`
-	OriginalCreationDateTime      *time.Time                       `xml:"OrgnlCreDtTm,omitempty"`
+	OriginalCreationDateTime      *ISODateTime                     `xml:"OrgnlCreDtTm,omitempty"`
 	OriginalNumberOfTransactions  *string                          `xml:"OrgnlNbOfTxs,omitempty"`
 	OriginalControlSum            *Decimal                         `xml:"OrgnlCtrlSum,omitempty"`
 	GroupStatus                   *string                          `xml:"GrpSts,omitempty"`
@@ -2089,7 +2590,7 @@ type OriginalGroupHeader17 struct {
 type OriginalGroupInfo29 struct {
 	OriginalMessageID            string                `xml:"OrgnlMsgId"`
 	OriginalMessageNameID        string                `xml:"OrgnlMsgNmId"`
-	OriginalCreationDateTime     *time.Time            `xml:"OrgnlCreDtTm,omitempty"`
+	OriginalCreationDateTime     *ISODateTime          `xml:"OrgnlCreDtTm,omitempty"`
 	OriginalNumberOfTransactions *string               `xml:"OrgnlNbOfTxs,omitempty"`
 	OriginalControlSum           *Decimal              `xml:"OrgnlCtrlSum,omitempty"`
 	ReturnReason                 *PaymentReturnReason5 `xml:"RtrRsn,omitempty"`
@@ -2097,18 +2598,18 @@ type OriginalGroupInfo29 struct {
 
 // OriginalGroupInformation27 - for pacs.028.001.03 (exact XSD match)
 type OriginalGroupInformation27 struct {
-	OriginalMessageID            string     `xml:"OrgnlMsgId"`
-	OriginalMessageNameID        string     `xml:"OrgnlMsgNmId"`
-	OriginalCreationDateTime     *time.Time `xml:"OrgnlCreDtTm,omitempty"`
-	OriginalNumberOfTransactions *string    `xml:"OrgnlNbOfTxs,omitempty"`
-	OriginalControlSum           *Decimal   `xml:"OrgnlCtrlSum,omitempty"`
+	OriginalMessageID            string       `xml:"OrgnlMsgId"`
+	OriginalMessageNameID        string       `xml:"OrgnlMsgNmId"`
+	OriginalCreationDateTime     *ISODateTime `xml:"OrgnlCreDtTm,omitempty"`
+	OriginalNumberOfTransactions *string      `xml:"OrgnlNbOfTxs,omitempty"`
+	OriginalControlSum           *Decimal     `xml:"OrgnlCtrlSum,omitempty"`
 }
 
 // OriginalGroupInformation29 - for pacs.028.001.03 PaymentTransaction113 (exact XSD match)
 type OriginalGroupInformation29 struct {
-	OriginalMessageID        string     `xml:"OrgnlMsgId"`
-	OriginalMessageNameID    string     `xml:"OrgnlMsgNmId"`
-	OriginalCreationDateTime *time.Time `xml:"OrgnlCreDtTm,omitempty"`
+	OriginalMessageID        string       `xml:"OrgnlMsgId"`
+	OriginalMessageNameID    string       `xml:"OrgnlMsgNmId"`
+	OriginalCreationDateTime *ISODateTime `xml:"OrgnlCreDtTm,omitempty"`
 }
 
 // PaymentTransaction110 - for pacs.002.001.10 (exact XSD match)
@@ -2122,7 +2623,7 @@ type PaymentTransaction110 struct {
 	TransactionStatus                *string                                       `xml:"TxSts,omitempty"`
 	StatusReasonInfo                 []StatusReasonInfo12                          `xml:"StsRsnInf,omitempty"`
 	ChargesInfo                      []Charges7                                    `xml:"ChrgsInf,omitempty"`
-	AcceptanceDateTime               *time.Time                                    `xml:"AccptncDtTm,omitempty"`
+	AcceptanceDateTime               *ISODateTime                                  `xml:"AccptncDtTm,omitempty"`
 	EffectiveInterbankSettlementDate *DateAndDateTime2                             `xml:"FctvIntrBkSttlmDt,omitempty"`
 	AccountServicerReference         *string                                       `xml:"AcctSvcrRef,omitempty"`
 	ClearingSystemReference          *string                                       `xml:"ClrSysRef,omitempty"`
@@ -2140,7 +2641,7 @@ type PaymentTransaction113 struct {
 	OriginalEndToEndID           *string                                       `xml:"OrgnlEndToEndId,omitempty"`
 	OriginalTransactionID        *string                                       `xml:"OrgnlTxId,omitempty"`
 	OriginalUETR                 *string                                       `xml:"OrgnlUETR,omitempty"`
-	AcceptanceDateTime           *time.Time                                    `xml:"AccptncDtTm,omitempty"`
+	AcceptanceDateTime           *ISODateTime                                  `xml:"AccptncDtTm,omitempty"`
 	ClearingSystemReference      *string                                       `xml:"ClrSysRef,omitempty"`
 	InstructingAgent             *BranchAndFinancialInstitutionIdentification6 `xml:"InstgAgt,omitempty"`
 	InstructedAgent              *BranchAndFinancialInstitutionIdentification6 `xml:"InstdAgt,omitempty"`
@@ -2152,7 +2653,7 @@ type PaymentTransaction113 struct {
 type OriginalGroupHeader18 struct {
 	OriginalMessageID        string                 `xml:"OrgnlMsgId"`
 	OriginalMessageNameID    string                 `xml:"OrgnlMsgNmId"`
-	OriginalCreationDateTime *time.Time             `xml:"OrgnlCreDtTm,omitempty"`
+	OriginalCreationDateTime *ISODateTime           `xml:"OrgnlCreDtTm,omitempty"`
 	ReturnReasonInfo         []PaymentReturnReason6 `xml:"RtrRsnInf,omitempty"`
 }
 
@@ -2173,15 +2674,15 @@ type PaymentTransaction118 struct {
 	OriginalUETR                      *string                                       `xml:"OrgnlUETR,omitempty"`
 	OriginalClearingSystemReference   *string                                       `xml:"OrgnlClrSysRef,omitempty"`
 	OriginalInterbankSettlementAmount *ActiveOrHistoricCurrencyAndAmount            `xml:"OrgnlIntrBkSttlmAmt,omitempty"`
-	OriginalInterbankSettlementDate   *string                                       `xml:"OrgnlIntrBkSttlmDt,omitempty"`
+	OriginalInterbankSettlementDate   *ISODate                                      `xml:"OrgnlIntrBkSttlmDt,omitempty"`
 	ReturnedInterbankSettlementAmount ActiveCurrencyAndAmount                       `xml:"RtrdIntrBkSttlmAmt"`
-	InterbankSettlementDate           *string                                       `xml:"IntrBkSttlmDt,omitempty"`
+	InterbankSettlementDate           *ISODate                                      `xml:"IntrBkSttlmDt,omitempty"`
 	SettlementPriority                *string                                       `xml:"SttlmPrty,omitempty"`
 	SettlementTimeIndication          *SettlementDateTimeIndication1                `xml:"SttlmTmIndctn,omitempty"`
 	ReturnedInstructedAmount          *ActiveOrHistoricCurrencyAndAmount            `xml:"RtrdInstdAmt,omitempty"`
 	ExchangeRate                      *Decimal                                      `xml:"XchgRate,omitempty"`
 	CompensationAmount                *ActiveOrHistoricCurrencyAndAmount            `xml:"CompstnAmt,omitempty"`
-	ChargeBearer                      *string                                       `xml:"ChrgBr,omitempty"`
+	ChargeBearer                      *ChargeBearerType1Code                        `xml:"ChrgBr,omitempty"`
 	ChargesInfo                       []Charges7                                    `xml:"ChrgsInf,omitempty"`
 	ClearingSystemReference           *string                                       `xml:"ClrSysRef,omitempty"`
 	InstructingAgent                  *BranchAndFinancialInstitutionIdentification6 `xml:"InstgAgt,omitempty"`
@@ -2196,8 +2697,8 @@ type PaymentTransaction118 struct {
 type OriginalTransactionReference32 struct {
 	InterbankSettlementAmount *ActiveOrHistoricCurrencyAndAmount            `xml:"IntrBkSttlmAmt,omitempty"`
 	Amount                    *AmountType4                                  `xml:"Amt,omitempty"`
-	InterbankSettlementDate   *string                                       `xml:"IntrBkSttlmDt,omitempty"`
-	RequestedCollectionDate   *string                                       `xml:"ReqdColltnDt,omitempty"`
+	InterbankSettlementDate   *ISODate                                      `xml:"IntrBkSttlmDt,omitempty"`
+	RequestedCollectionDate   *ISODate                                      `xml:"ReqdColltnDt,omitempty"`
 	RequestedExecutionDate    *DateAndDateTime2                             `xml:"ReqdExctnDt,omitempty"`
 	CreditorSchemeID          *PartyIdentification135                       `xml:"CdtrSchmeId,omitempty"`
 	SettlementInfo            *SettlementInstruction7                       `xml:"SttlmInf,omitempty"`
@@ -2222,18 +2723,18 @@ type OriginalTransactionReference32 struct {
 
 // Event1 - Event details for admi.011.001.01
 type Event1 struct {
-	EventCode        string     `xml:"EvtCd"`
-	EventParameter   []string   `xml:"EvtParam,omitempty"`
-	EventDescription *string    `xml:"EvtDesc,omitempty"`
-	EventTime        *time.Time `xml:"EvtTm,omitempty"`
+	EventCode        string       `xml:"EvtCd"`
+	EventParameter   []string     `xml:"EvtParam,omitempty"`
+	EventDescription *string      `xml:"EvtDesc,omitempty"`
+	EventTime        *ISODateTime `xml:"EvtTm,omitempty"`
 }
 
 // Event2 - Event details for admi.004.001.02
 type Event2 struct {
-	EventCode        string     `xml:"EvtCd"`
-	EventParameter   []string   `xml:"EvtParam,omitempty"`
-	EventDescription *string    `xml:"EvtDesc,omitempty"`
-	EventTime        *time.Time `xml:"EvtTm,omitempty"`
+	EventCode        string       `xml:"EvtCd"`
+	EventParameter   []string     `xml:"EvtParam,omitempty"`
+	EventDescription *string      `xml:"EvtDesc,omitempty"`
+	EventTime        *ISODateTime `xml:"EvtTm,omitempty"`
 }
 
 type Acknowledgement1 struct {
@@ -2286,7 +2787,7 @@ type AccountReport25 struct {
 	ElectronicSequenceNumber *Decimal            `xml:"ElctrncSeqNb,omitempty"` // Number - optional
 	ReportingSequence        *SequenceRange1     `xml:"RptgSeq,omitempty"`      // Optional
 	LegalSequenceNumber      *Decimal            `xml:"LglSeqNb,omitempty"`     // Number - optional
-	CreationDateTime         *time.Time          `xml:"CreDtTm,omitempty"`      // ISODateTime - optional
+	CreationDateTime         *ISODateTime        `xml:"CreDtTm,omitempty"`      // ISODateTime - optional
 	FromToDate               *DateTimePeriod1    `xml:"FrToDt,omitempty"`       // Optional
 	CopyDuplicateIndicator   *string             `xml:"CpyDplctInd,omitempty"`  // CopyDuplicate1Code - optional
 	ReportingSource          *ReportingSource1   `xml:"RptgSrc,omitempty"`      // Optional
@@ -2309,7 +2810,7 @@ type AccountNotification17 struct {
 	ElectronicSequenceNumber   *Decimal            `xml:"ElctrncSeqNb,omitempty"`   // Number - optional
 	ReportingSequence          *SequenceRange1     `xml:"RptgSeq,omitempty"`        // Optional
 	LegalSequenceNumber        *Decimal            `xml:"LglSeqNb,omitempty"`       // Number - optional
-	CreationDateTime           *time.Time          `xml:"CreDtTm,omitempty"`        // ISODateTime - optional
+	CreationDateTime           *ISODateTime        `xml:"CreDtTm,omitempty"`        // ISODateTime - optional
 	FromToDate                 *DateTimePeriod1    `xml:"FrToDt,omitempty"`         // Optional
 	CopyDuplicateIndicator     *string             `xml:"CpyDplctInd,omitempty"`    // CopyDuplicate1Code - optional
 	ReportingSource            *ReportingSource1   `xml:"RptgSrc,omitempty"`        // Optional
@@ -2323,10 +2824,10 @@ type AccountNotification17 struct {
 
 // CaseAssignment5 - Case assignment for investigation messages
 type CaseAssignment5 struct {
-	ID               string    `xml:"Id"`      // Max35Text - required
-	Assigner         Party40   `xml:"Assgnr"`  // Required
-	Assignee         Party40   `xml:"Assgne"`  // Required
-	CreationDateTime time.Time `xml:"CreDtTm"` // ISODateTime - required
+	ID               string      `xml:"Id"`      // Max35Text - required
+	Assigner         Party40     `xml:"Assgnr"`  // Required
+	Assignee         Party40     `xml:"Assgne"`  // Required
+	CreationDateTime ISODateTime `xml:"CreDtTm"` // ISODateTime - required
 }
 
 // Case5 - Case information for investigation messages
@@ -2363,7 +2864,7 @@ type OriginalGroupHeader14 struct {
 	ResolvedCase                  *Case5                           `xml:"RslvdCase,omitempty"`
 	OriginalMessageID             string                           `xml:"OrgnlMsgId"`
 	OriginalMessageNameID         string                           `xml:"OrgnlMsgNmId"`
-	OriginalCreationDateTime      *time.Time                       `xml:"OrgnlCreDtTm,omitempty"`
+	OriginalCreationDateTime      *ISODateTime                     `xml:"OrgnlCreDtTm,omitempty"`
 	OriginalNumberOfTransactions  *string                          `xml:"OrgnlNbOfTxs,omitempty"`
 	OriginalControlSum            *Decimal                         `xml:"OrgnlCtrlSum,omitempty"`
 	GroupCancellationStatus       *string                          `xml:"GrpCxlSts,omitempty"` // GroupCancellationStatus1Code
@@ -2426,7 +2927,7 @@ type PaymentTransaction102 struct {
 	CancellationStatusReasonInfo      []CancellationStatusReason4        `xml:"CxlStsRsnInf,omitempty"`
 	ResolutionRelatedInfo             *ResolutionData1                   `xml:"RsltnRltdInf,omitempty"`
 	OriginalInterbankSettlementAmount *ActiveOrHistoricCurrencyAndAmount `xml:"OrgnlIntrBkSttlmAmt,omitempty"`
-	OriginalInterbankSettlementDate   *string                            `xml:"OrgnlIntrBkSttlmDt,omitempty"`
+	OriginalInterbankSettlementDate   *ISODate                           `xml:"OrgnlIntrBkSttlmDt,omitempty"`
 	Assignor                          *Party40                           `xml:"Assgnr,omitempty"`
 	Assignee                          *Party40                           `xml:"Assgne,omitempty"`
 	OriginalTransactionReference      *OriginalTransactionReference28    `xml:"OrgnlTxRef,omitempty"`
@@ -2443,7 +2944,7 @@ type PaymentTransaction103 struct {
 	CancellationStatusReasonInfo    []CancellationStatusReason4        `xml:"CxlStsRsnInf,omitempty"`
 	OriginalInstructedAmount        *ActiveOrHistoricCurrencyAndAmount `xml:"OrgnlInstdAmt,omitempty"`
 	OriginalRequestedExecutionDate  *DateAndDateTime2                  `xml:"OrgnlReqdExctnDt,omitempty"`
-	OriginalRequestedCollectionDate *string                            `xml:"OrgnlReqdColltnDt,omitempty"`
+	OriginalRequestedCollectionDate *ISODate                           `xml:"OrgnlReqdColltnDt,omitempty"`
 	OriginalTransactionReference    *OriginalTransactionReference28    `xml:"OrgnlTxRef,omitempty"`
 }
 
@@ -2460,7 +2961,7 @@ type OriginalGroupHeader15 struct {
 	Case                     *Case5                       `xml:"Case,omitempty"`
 	OriginalMessageID        string                       `xml:"OrgnlMsgId"`             // Max35Text - Required
 	OriginalMessageNameID    string                       `xml:"OrgnlMsgNmId"`           // Max35Text - Required
-	OriginalCreationDateTime *time.Time                   `xml:"OrgnlCreDtTm,omitempty"` // ISODateTime
+	OriginalCreationDateTime *ISODateTime                 `xml:"OrgnlCreDtTm,omitempty"` // ISODateTime
 	NumberOfTransactions     *string                      `xml:"NbOfTxs,omitempty"`      // Max15NumericText
 	ControlSum               *Decimal                     `xml:"CtrlSum,omitempty"`      // DecimalNumber
 	GroupCancellation        *bool                        `xml:"GrpCxl,omitempty"`       // GroupCancellationIndicator (boolean)
@@ -2478,7 +2979,7 @@ type PaymentTransaction106 struct {
 	OriginalUETR                      *string                                       `xml:"OrgnlUETR,omitempty"`      // UUIDv4Identifier
 	OriginalClearingSystemReference   *string                                       `xml:"OrgnlClrSysRef,omitempty"` // Max35Text
 	OriginalInterbankSettlementAmount *ActiveOrHistoricCurrencyAndAmount            `xml:"OrgnlIntrBkSttlmAmt,omitempty"`
-	OriginalInterbankSettlementDate   *string                                       `xml:"OrgnlIntrBkSttlmDt,omitempty"` // ISODate
+	OriginalInterbankSettlementDate   *ISODate                                      `xml:"OrgnlIntrBkSttlmDt,omitempty"` // ISODate
 	Assignor                          *BranchAndFinancialInstitutionIdentification6 `xml:"Assgnr,omitempty"`
 	Assignee                          *BranchAndFinancialInstitutionIdentification6 `xml:"Assgne,omitempty"`
 	CancellationReasonInfo            []PaymentCancellationReason5                  `xml:"CxlRsnInf,omitempty"` // unbounded
@@ -2524,9 +3025,9 @@ type PaymentTransaction109 struct {
 
 // GroupHeader77 - Group header for camt.060.001.05
 type GroupHeader77 struct {
-	MessageID        string    `xml:"MsgId"`
-	CreationDateTime time.Time `xml:"CreDtTm"`
-	MessageSender    *Party40  `xml:"MsgSndr,omitempty"`
+	MessageID        string      `xml:"MsgId"`
+	CreationDateTime ISODateTime `xml:"CreDtTm"`
+	MessageSender    *Party40    `xml:"MsgSndr,omitempty"`
 }
 
 // ReportingRequest5 - Reporting request information
@@ -2547,11 +3048,11 @@ type PaymentComplementaryInfo9 struct {
 	TransactionID                 *string                                       `xml:"TxId,omitempty"`
 	PaymentTypeInfo               *PaymentTypeInfo19                            `xml:"PmtTpInf,omitempty"`
 	RequestedExecutionDate        *DateAndDateTime2                             `xml:"ReqdExctnDt,omitempty"`
-	RequestedCollectionDate       *string                                       `xml:"ReqdColltnDt,omitempty"`  // ISODate
-	InterbankSettlementDate       *string                                       `xml:"IntrBkSttlmDt,omitempty"` // ISODate
+	RequestedCollectionDate       *ISODate                                      `xml:"ReqdColltnDt,omitempty"`  // ISODate
+	InterbankSettlementDate       *ISODate                                      `xml:"IntrBkSttlmDt,omitempty"` // ISODate
 	Amount                        *AmountType4                                  `xml:"Amt,omitempty"`
 	InterbankSettlementAmount     *ActiveOrHistoricCurrencyAndAmount            `xml:"IntrBkSttlmAmt,omitempty"`
-	ChargeBearer                  *string                                       `xml:"ChrgBr,omitempty"` // ChargeBearerType1Code
+	ChargeBearer                  *ChargeBearerType1Code                        `xml:"ChrgBr,omitempty"`
 	UltimateDebtor                *PartyIdentification135                       `xml:"UltmtDbtr,omitempty"`
 	Debtor                        *PartyIdentification135                       `xml:"Dbtr,omitempty"`
 	DebtorAccount                 *CashAccount38                                `xml:"DbtrAcct,omitempty"`
@@ -2615,8 +3116,8 @@ type ResolutionData1 struct {
 	TransactionID             *string                            `xml:"TxId,omitempty"`       // Max35Text
 	UETR                      *string                            `xml:"UETR,omitempty"`       // UUIDv4Identifier
 	InterbankSettlementAmount *ActiveOrHistoricCurrencyAndAmount `xml:"IntrBkSttlmAmt,omitempty"`
-	InterbankSettlementDate   *string                            `xml:"IntrBkSttlmDt,omitempty"` // ISODate
-	ClearingChannel           *string                            `xml:"ClrChanl,omitempty"`      // ClearingChannel2Code
+	InterbankSettlementDate   *ISODate                           `xml:"IntrBkSttlmDt,omitempty"` // ISODate
+	ClearingChannel           *ClearingChannel2Code              `xml:"ClrChanl,omitempty"`
 	Compensation              *Compensation2                     `xml:"Compstn,omitempty"`
 	Charges                   []Charges7                         `xml:"Chrgs,omitempty"`
 }
@@ -2635,7 +3136,7 @@ type PaymentTransaction91 struct {
 	ModificationStatusReasonInfo      []ModificationStatusReason2        `xml:"ModStsRsnInf,omitempty"`        // NEW: unbounded
 	ResolutionRelatedInfo             *ResolutionData1                   `xml:"RsltnRltdInf,omitempty"`        // NEW
 	OriginalInterbankSettlementAmount *ActiveOrHistoricCurrencyAndAmount `xml:"OrgnlIntrBkSttlmAmt,omitempty"` // NEW
-	OriginalInterbankSettlementDate   *string                            `xml:"OrgnlIntrBkSttlmDt,omitempty"`
+	OriginalInterbankSettlementDate   *ISODate                           `xml:"OrgnlIntrBkSttlmDt,omitempty"`
 	Assignor                          *Party40                           `xml:"Assgnr,omitempty"` // NEW: Party40
 	Assignee                          *Party40                           `xml:"Assgne,omitempty"` // NEW: Party40
 	OriginalTransactionReference      *OriginalTransactionReference28    `xml:"OrgnlTxRef,omitempty"`
@@ -2657,13 +3158,35 @@ type ResolutionData2 struct {
 	TransactionID             *string                            `xml:"TxId,omitempty"`
 	UETR                      *string                            `xml:"UETR,omitempty"`
 	InterbankSettlementAmount *ActiveOrHistoricCurrencyAndAmount `xml:"IntrBkSttlmAmt,omitempty"`
-	InterbankSettlementDate   *string                            `xml:"IntrBkSttlmDt,omitempty"`
-	ClearingChannel           *string                            `xml:"ClrChanl,omitempty"`
+	InterbankSettlementDate   *ISODate                           `xml:"IntrBkSttlmDt,omitempty"`
+	ClearingChannel           *ClearingChannel2Code              `xml:"ClrChanl,omitempty"`
 	DebtorName                *string                            `xml:"DbtrNm,omitempty"`
 	CreditorName              *string                            `xml:"CdtrNm,omitempty"`
 	CreditorReference         *CreditorReferenceInfo2            `xml:"CdtrRefInf,omitempty"`
 }
 
+// PaymentInstruction30 - Payment information block of pain.001.001.09
+type PaymentInstruction30 struct {
+	PaymentInfoID                 string                                        `xml:"PmtInfId"`
+	PaymentMethod                 string                                        `xml:"PmtMtd"` // CHK, TRF or TRA
+	BatchBooking                  *bool                                         `xml:"BtchBookg,omitempty"`
+	NumberOfTransactions          *string                                       `xml:"NbOfTxs,omitempty"`
+	ControlSum                    *Decimal                                      `xml:"CtrlSum,omitempty"`
+	PaymentTypeInfo               *PaymentTypeInformation26                     `xml:"PmtTpInf,omitempty"`
+	RequestedExecutionDate        DateAndDateTime2                              `xml:"ReqdExctnDt"`
+	PoolingAdjustmentDate         *ISODate                                      `xml:"PoolgAdjstmntDt,omitempty"`
+	Debtor                        PartyIdentification135                        `xml:"Dbtr"`
+	DebtorAccount                 CashAccount38                                 `xml:"DbtrAcct"`
+	DebtorAgent                   BranchAndFinancialInstitutionIdentification6  `xml:"DbtrAgt"`
+	DebtorAgentAccount            *CashAccount38                                `xml:"DbtrAgtAcct,omitempty"`
+	InstructionForDebtorAgent     *string                                       `xml:"InstrForDbtrAgt,omitempty"`
+	UltimateDebtor                *PartyIdentification135                       `xml:"UltmtDbtr,omitempty"`
+	ChargeBearer                  *ChargeBearerType1Code                        `xml:"ChrgBr,omitempty"`
+	ChargesAccount                *CashAccount38                                `xml:"ChrgsAcct,omitempty"`
+	ChargesAccountAgent           *BranchAndFinancialInstitutionIdentification6 `xml:"ChrgsAcctAgt,omitempty"`
+	CreditTransferTransactionInfo []CreditTransferTransaction34                 `xml:"CdtTrfTxInf"`
+}
+
 // PaymentInstruction31 - Payment instruction information for pain.013.001.07
 type PaymentInstruction31 struct {
 	PaymentInfoID             *string                                      `xml:"PmtInfId,omitempty"`
@@ -2688,7 +3211,7 @@ type OriginalPaymentInstruction32 struct {
 	OriginalTransactionID             *string                            `xml:"OrgnlTxId,omitempty"`
 	OriginalUETR                      *string                            `xml:"OrgnlUETR,omitempty"`
 	OriginalInterbankSettlementAmount *ActiveOrHistoricCurrencyAndAmount `xml:"OrgnlIntrBkSttlmAmt,omitempty"`
-	OriginalInterbankSettlementDate   *string                            `xml:"OrgnlIntrBkSttlmDt,omitempty"`
+	OriginalInterbankSettlementDate   *ISODate                           `xml:"OrgnlIntrBkSttlmDt,omitempty"`
 	ReversalReasonInformation         []PaymentReversalReason7           `xml:"RvslRsnInf,omitempty"`
 	OriginalTransactionReference      *OriginalTransactionReference31    `xml:"OrgnlTxRef,omitempty"`
 	SupplementaryData                 []SupplementaryData1               `xml:"SplmtryData,omitempty"`
@@ -2697,7 +3220,7 @@ type OriginalPaymentInstruction32 struct {
 // MessageHeader7 - Message header for admi.006.001.01
 type MessageHeader7 struct {
 	MessageID             string                  `xml:"MsgId"`
-	CreationDateTime      *time.Time              `xml:"CreDtTm,omitempty"`
+	CreationDateTime      *ISODateTime            `xml:"CreDtTm,omitempty"`
 	RequestType           *RequestType4           `xml:"ReqTp,omitempty"`
 	OriginalBusinessQuery *OriginalBusinessQuery1 `xml:"OrgnlBizQry,omitempty"`
 	QueryName             *string                 `xml:"QryNm,omitempty"`
@@ -2719,14 +3242,14 @@ type GenericIdentification1 struct {
 
 // OriginalBusinessQuery1 - Original business query reference
 type OriginalBusinessQuery1 struct {
-	MessageID        string     `xml:"MsgId"`
-	MessageNameID    *string    `xml:"MsgNmId,omitempty"`
-	CreationDateTime *time.Time `xml:"CreDtTm,omitempty"`
+	MessageID        string       `xml:"MsgId"`
+	MessageNameID    *string      `xml:"MsgNmId,omitempty"`
+	CreationDateTime *ISODateTime `xml:"CreDtTm,omitempty"`
 }
 
 // ResendSearchCriteria2 - Search criteria for admi.006.001.01
 type ResendSearchCriteria2 struct {
-	BusinessDate          *string                `xml:"BizDt,omitempty"`
+	BusinessDate          *ISODate               `xml:"BizDt,omitempty"`
 	SequenceNumber        *string                `xml:"SeqNb,omitempty"`
 	SequenceRange         *SequenceRange1        `xml:"SeqRg,omitempty"`
 	OriginalMessageNameID *string                `xml:"OrgnlMsgNmId,omitempty"`
@@ -2742,11 +3265,11 @@ type SequenceRange1Admi struct {
 
 // RequestHandling1 - Request handling information
 type RequestHandling1 struct {
-	Identification  string     `xml:"Id"`                // Max35Text - required
-	RequestType     string     `xml:"ReqTp"`             // RequestType4 - required
-	RequestDateTime *time.Time `xml:"ReqDtTm,omitempty"` // ISODateTime - required
-	Description     *string    `xml:"Desc,omitempty"`    // Max350Text
-	Reference       []string   `xml:"Ref,omitempty"`     // Max35Text
+	Identification  string       `xml:"Id"`                // Max35Text - required
+	RequestType     string       `xml:"ReqTp"`             // RequestType4 - required
+	RequestDateTime *ISODateTime `xml:"ReqDtTm,omitempty"` // ISODateTime - required
+	Description     *string      `xml:"Desc,omitempty"`    // Max350Text
+	Reference       []string     `xml:"Ref,omitempty"`     // Max35Text
 }
 
 // RequestReportOrError1 - Request report or error information
@@ -2765,8 +3288,8 @@ type AcknowledgementOrError2 struct {
 type OriginalTransactionReference28 struct {
 	InterbankSettlementAmount *ActiveOrHistoricCurrencyAndAmount            `xml:"IntrBkSttlmAmt,omitempty"`
 	Amount                    *AmountType4                                  `xml:"Amt,omitempty"`
-	InterbankSettlementDate   *string                                       `xml:"IntrBkSttlmDt,omitempty"`
-	RequestedCollectionDate   *string                                       `xml:"ReqdColltnDt,omitempty"`
+	InterbankSettlementDate   *ISODate                                      `xml:"IntrBkSttlmDt,omitempty"`
+	RequestedCollectionDate   *ISODate                                      `xml:"ReqdColltnDt,omitempty"`
 	RequestedExecutionDate    *DateAndDateTime2                             `xml:"ReqdExctnDt,omitempty"`
 	CreditorSchemeID          *PartyIdentification135                       `xml:"CdtrSchmeId,omitempty"`
 	SettlementInfo            *SettlementInstruction7                       `xml:"SttlmInf,omitempty"`
@@ -2799,8 +3322,8 @@ type SequenceRange1 struct {
 }
 
 type DateTimePeriod1 struct {
-	FromDateTime *time.Time `xml:"FrDtTm,omitempty"`
-	ToDateTime   *time.Time `xml:"ToDtTm,omitempty"`
+	FromDateTime *ISODateTime `xml:"FrDtTm,omitempty"`
+	ToDateTime   *ISODateTime `xml:"ToDtTm,omitempty"`
 }
 
 type ReportingSource1 struct {
@@ -2828,7 +3351,7 @@ type CashBalance8 struct {
 	Type                 BalanceType13                     `xml:"Tp"`
 	CreditLine           []CreditLine3                     `xml:"CdtLine,omitempty"`
 	Amount               ActiveOrHistoricCurrencyAndAmount `xml:"Amt"`
-	CreditDebitIndicator string                            `xml:"CdtDbtInd"`
+	CreditDebitIndicator CreditDebitCode                   `xml:"CdtDbtInd"`
 	Date                 DateAndDateTime2                  `xml:"Dt"`
 	Availability         []CashAvailability1               `xml:"Avlbty,omitempty"`
 }
@@ -2860,14 +3383,51 @@ type TotalTransactions6 struct {
 }
 
 type ReportEntry10 struct {
-	EntryReference       *string                           `xml:"NtryRef,omitempty"`
-	Amount               ActiveOrHistoricCurrencyAndAmount `xml:"Amt"`
-	CreditDebitIndicator string                            `xml:"CdtDbtInd"`
-	Status               string                            `xml:"Sts"`
-	BookingDate          *DateAndDateTime2                 `xml:"BookgDt,omitempty"`
-	ValueDate            *DateAndDateTime2                 `xml:"ValDt,omitempty"`
-	TransactionDetails   []EntryTransaction10              `xml:"NtryDtls,omitempty"`
-	AdditionalEntryInfo  *string                           `xml:"AddtlNtryInf,omitempty"`
+	EntryReference            *string                           `xml:"NtryRef,omitempty"`
+	Amount                    ActiveOrHistoricCurrencyAndAmount `xml:"Amt"`
+	CreditDebitIndicator      CreditDebitCode                   `xml:"CdtDbtInd"`
+	ReversalIndicator         *bool                             `xml:"RvslInd,omitempty"`
+	Status                    EntryStatus1                      `xml:"Sts"`
+	BookingDate               *DateAndDateTime2                 `xml:"BookgDt,omitempty"`
+	ValueDate                 *DateAndDateTime2                 `xml:"ValDt,omitempty"`
+	AccountServicerReference  *string                           `xml:"AcctSvcrRef,omitempty"`
+	Availability              []CashAvailability1               `xml:"Avlbty,omitempty"`
+	BankTransactionCode       BankTransactionCodeStructure4     `xml:"BkTxCd"`
+	CommissionWaiverIndicator *bool                             `xml:"ComssnWvrInd,omitempty"`
+	AdditionalInfoIndicator   *MessageIdentification2           `xml:"AddtlInfInd,omitempty"`
+	AmountDetails             *AmountAndCurrencyExchange3       `xml:"AmtDtls,omitempty"`
+	Charges                   *Charges6                         `xml:"Chrgs,omitempty"`
+	TechnicalInputChannel     *TechnicalInputChannel1           `xml:"TechInptChanl,omitempty"`
+	Interest                  *TransactionInterest4             `xml:"Intrst,omitempty"`
+	EntryDetails              []EntryDetails9                   `xml:"NtryDtls,omitempty"`
+	AdditionalEntryInfo       *string                           `xml:"AddtlNtryInf,omitempty"`
+}
+
+// EntryStatus1 - Status of an entry: BOOK, PDNG or INFO
+type EntryStatus1 struct {
+	Code        *string `xml:"Cd,omitempty"`    // ExternalEntryStatus1Code
+	Proprietary *string `xml:"Prtry,omitempty"` // Max35Text
+}
+
+// TechnicalInputChannel1 - Channel through which the instruction reached the account servicer
+type TechnicalInputChannel1 struct {
+	Code        *string `xml:"Cd,omitempty"`    // ExternalTechnicalInputChannel1Code
+	Proprietary *string `xml:"Prtry,omitempty"` // Max35Text
+}
+
+// EntryDetails9 - Details of the transactions booked as one entry, with the batch they came from
+type EntryDetails9 struct {
+	Batch              *BatchInformation2   `xml:"Btch,omitempty"`
+	TransactionDetails []EntryTransaction10 `xml:"TxDtls,omitempty"`
+}
+
+// BatchInformation2 - Batch of transactions booked as a single entry
+type BatchInformation2 struct {
+	MessageID            *string                            `xml:"MsgId,omitempty"`    // Max35Text
+	PaymentInfoID        *string                            `xml:"PmtInfId,omitempty"` // Max35Text
+	NumberOfTransactions *string                            `xml:"NbOfTxs,omitempty"`  // Max15NumericText
+	TotalAmount          *ActiveOrHistoricCurrencyAndAmount `xml:"TtlAmt,omitempty"`
+	CreditDebitIndicator *CreditDebitCode                   `xml:"CdtDbtInd,omitempty"`
 }
 
 type AmountType4 struct {
@@ -2876,22 +3436,22 @@ type AmountType4 struct {
 }
 
 type PaymentTypeInfo19 struct {
-	InstructionPriority *string           `xml:"InstrPrty,omitempty"`
-	ClearingChannel     *string           `xml:"ClrChanl,omitempty"`
-	ServiceLevel        []ServiceLevel8   `xml:"SvcLvl,omitempty"`
-	LocalInstrument     *LocalInstrument2 `xml:"LclInstrm,omitempty"`
-	SequenceType        *string           `xml:"SeqTp,omitempty"`
-	CategoryPurpose     *CategoryPurpose1 `xml:"CtgyPurp,omitempty"`
+	InstructionPriority *Priority2Code        `xml:"InstrPrty,omitempty"`
+	ClearingChannel     *ClearingChannel2Code `xml:"ClrChanl,omitempty"`
+	ServiceLevel        []ServiceLevel8       `xml:"SvcLvl,omitempty"`
+	LocalInstrument     *LocalInstrument2     `xml:"LclInstrm,omitempty"`
+	SequenceType        *string               `xml:"SeqTp,omitempty"`
+	CategoryPurpose     *CategoryPurpose1     `xml:"CtgyPurp,omitempty"`
 }
 
 type MandateRelatedInfo14 struct {
 	MandateID            *string                 `xml:"MndtId,omitempty"`
-	DateOfSignature      *string                 `xml:"DtOfSgntr,omitempty"`
+	DateOfSignature      *ISODate                `xml:"DtOfSgntr,omitempty"`
 	AmentmentIndicator   *bool                   `xml:"AmdmntInd,omitempty"`
 	AmendmentInfoDetails *AmendmentInfoDetails13 `xml:"AmdmntInfDtls,omitempty"`
 	ElectronicSignature  *string                 `xml:"ElctrncSgntr,omitempty"`
-	FirstCollectionDate  *string                 `xml:"FrstColltnDt,omitempty"`
-	FinalCollectionDate  *string                 `xml:"FnlColltnDt,omitempty"`
+	FirstCollectionDate  *ISODate                `xml:"FrstColltnDt,omitempty"`
+	FinalCollectionDate  *ISODate                `xml:"FnlColltnDt,omitempty"`
 	Frequency            *string                 `xml:"Frqcy,omitempty"`
 	Reason               *MandateSetupReason1    `xml:"Rsn,omitempty"`
 	TrackingDays         *string                 `xml:"TrckgDays,omitempty"`
@@ -2934,7 +3494,7 @@ type BalanceSubType1 struct {
 type CashAvailability1 struct {
 	Date                 DateAndDateTime2                  `xml:"Dt"`
 	Amount               ActiveOrHistoricCurrencyAndAmount `xml:"Amt"`
-	CreditDebitIndicator string                            `xml:"CdtDbtInd"`
+	CreditDebitIndicator CreditDebitCode                   `xml:"CdtDbtInd"`
 }
 
 // NumberAndSumOfTransactions4 - Number and sum of transactions
@@ -2954,12 +3514,12 @@ type NumberAndSumOfTransactions1 struct {
 type EntryTransaction10 struct {
 	References                        *TransactionReferences6            `xml:"Refs,omitempty"`
 	Amount                            *ActiveOrHistoricCurrencyAndAmount `xml:"Amt,omitempty"`
-	CreditDebitIndicator              *string                            `xml:"CdtDbtInd,omitempty"`
+	CreditDebitIndicator              *CreditDebitCode                   `xml:"CdtDbtInd,omitempty"`
 	AmountDetails                     *AmountAndCurrencyExchange3        `xml:"AmtDtls,omitempty"`
 	Availability                      []CashAvailability1                `xml:"Avlbty,omitempty"`
 	BankTransactionCode               *BankTransactionCodeStructure4     `xml:"BkTxCd,omitempty"`
 	Charges                           *Charges6                          `xml:"Chrgs,omitempty"`
-	TechnicalInputChannel             *string                            `xml:"TechInptChanl,omitempty"`
+	TechnicalInputChannel             *TechnicalInputChannel1            `xml:"TechInptChanl,omitempty"`
 	Interest                          *TransactionInterest4              `xml:"Intrst,omitempty"`
 	RelatedParties                    *TransactionParties6               `xml:"RltdPties,omitempty"`
 	RelatedAgents                     *TransactionAgents5                `xml:"RltdAgts,omitempty"`
@@ -3013,7 +3573,7 @@ type AmendmentInfoDetails13 struct {
 	OriginalDebtorAccount        *CashAccount38                                `xml:"OrgnlDbtrAcct,omitempty"`
 	OriginalDebtorAgent          *BranchAndFinancialInstitutionIdentification6 `xml:"OrgnlDbtrAgt,omitempty"`
 	OriginalDebtorAgentAccount   *CashAccount38                                `xml:"OrgnlDbtrAgtAcct,omitempty"`
-	OriginalFinalCollectionDate  *string                                       `xml:"OrgnlFnlColltnDt,omitempty"`
+	OriginalFinalCollectionDate  *ISODate                                      `xml:"OrgnlFnlColltnDt,omitempty"`
 	OriginalFrequency            *Frequency36                                  `xml:"OrgnlFrqcy,omitempty"`
 	OriginalReason               *MandateSetupReason1                          `xml:"OrgnlRsn,omitempty"`
 	OriginalTrackingDays         *string                                       `xml:"OrgnlTrckgDays,omitempty"`
@@ -3064,24 +3624,24 @@ type TransactionParties8 struct {
 
 // OriginalGroupInfo3 - Original group information for investigations
 type OriginalGroupInfo3 struct {
-	OriginalMessageID            string     `xml:"OrgnlMsgId"`
-	OriginalMessageNameID        string     `xml:"OrgnlMsgNmId"`
-	OriginalCreationDateTime     *time.Time `xml:"OrgnlCreDtTm,omitempty"`
-	OriginalNumberOfTransactions *string    `xml:"OrgnlNbOfTxs,omitempty"`
-	OriginalControlSum           *Decimal   `xml:"OrgnlCtrlSum,omitempty"`
-	GroupCancellationID          *string    `xml:"GrpCxlId,omitempty"`
+	OriginalMessageID            string       `xml:"OrgnlMsgId"`
+	OriginalMessageNameID        string       `xml:"OrgnlMsgNmId"`
+	OriginalCreationDateTime     *ISODateTime `xml:"OrgnlCreDtTm,omitempty"`
+	OriginalNumberOfTransactions *string      `xml:"OrgnlNbOfTxs,omitempty"`
+	OriginalControlSum           *Decimal     `xml:"OrgnlCtrlSum,omitempty"`
+	GroupCancellationID          *string      `xml:"GrpCxlId,omitempty"`
 }
 
 // DatePeriodDetails1 the 'From Date' and 'To Date'.
 type DatePeriodDetails1 struct {
-	FromDate string  `xml:"FrDt"`
-	ToDate   *string `xml:"ToDt,omitempty"`
+	FromDate ISODate  `xml:"FrDt"`
+	ToDate   *ISODate `xml:"ToDt,omitempty"`
 }
 
 // TimePeriodDetails1 the 'From Time' and 'To Time'.
 type TimePeriodDetails1 struct {
-	FromTime string  `xml:"FrTm"`
-	ToTime   *string `xml:"ToTm,omitempty"`
+	FromTime ISOTime  `xml:"FrTm"`
+	ToTime   *ISOTime `xml:"ToTm,omitempty"`
 }
 
 // Period2 - Period specification choice
@@ -3175,10 +3735,89 @@ type Party44 struct {
 type ValidationError struct {
 	Field   string
 	Message string
+
+	// Rule identifies the check that failed, such as RuleIBANChecksum, so that it
+	// can be skipped or downgraded by Validate. It is empty for checks without an
+	// identifier.
+	Rule string
+
+	// MessageID and Args give Message in the message catalogs, so that it can be
+	// translated with LocalizedMessage. MessageID is empty for messages that are
+	// not in the catalogs.
+	MessageID string
+	Args      []interface{}
+
+	// Path locates the offending element from the message element down, in the
+	// style of an XPath location path with 1-based indices, e.g.
+	// FIToFICstmrCdtTrf/CdtTrfTxInf[3]/IntrBkSttlmAmt/@Ccy. It is empty when the
+	// error was produced without any nesting, in which case Field is the path.
+	Path string
+
+	// Line and Column give the position of the element in the source document.
+	// They are only set by ValidateXML and are zero otherwise.
+	Line   int
+	Column int
 }
 
 func (e ValidationError) Error() string {
-	return fmt.Sprintf("Field '%s': %s", e.Field, e.Message)
+	msg := fmt.Sprintf("Field '%s': %s", e.Location(), e.Message)
+	if e.Line > 0 {
+		msg += fmt.Sprintf(" (line %d, column %d)", e.Line, e.Column)
+	}
+	return msg
+}
+
+// Location returns Path, or Field written as a path when no path was recorded.
+func (e ValidationError) Location() string {
+	if e.Path != "" {
+		return e.Path
+	}
+	return strings.ReplaceAll(e.Field, ".", "/")
+}
+
+// nestErrors places the errors reported by a child component under element, so
+// that their paths continue from the parent. element may itself be a dotted or
+// slashed path. An error that is not a validation error is reported against
+// element as a whole.
+func nestErrors(element string, err error) ValidationErrors {
+	switch err := err.(type) {
+	case ValidationErrors:
+		return err.within(element)
+	case ValidationError:
+		return ValidationErrors{err}.within(element)
+	}
+	return ValidationErrors{{Field: element, Message: err.Error()}}
+}
+
+// attributeError marks a validation error as concerning an XML attribute, so that
+// its path reads @Name.
+func attributeError(err error) ValidationError {
+	valErr := err.(ValidationError)
+	valErr.Path = "@" + valErr.Field
+	return valErr
+}
+
+// within prefixes the path of every error with element. A choice error refers to
+// the element itself rather than to a child.
+func (errs ValidationErrors) within(element string) ValidationErrors {
+	parent := strings.ReplaceAll(element, ".", "/")
+	nested := make(ValidationErrors, len(errs))
+	for i, e := range errs {
+		child := e.Location()
+		if e.Field == "Choice" && e.Path == "" {
+			child = ""
+		}
+		switch {
+		case parent == "":
+			e.Path = child
+		case child == "":
+			e.Path = parent
+		default:
+			e.Path = parent + "/" + child
+		}
+		nested[i] = e
+	}
+	return nested
 }
 
 // ValidationErrors represents multiple validation errors
@@ -3199,6 +3838,22 @@ func (errs ValidationErrors) HasErrors() bool {
 	return len(errs) > 0
 }
 
+// merge appends the errors of more that errs does not already report for the same
+// location, so that a check made by both a component and its message shows once
+func (errs ValidationErrors) merge(more ValidationErrors) ValidationErrors {
+	reported := make(map[string]bool, len(errs))
+	for _, e := range errs {
+		reported[e.Location()+"\x00"+e.Message] = true
+	}
+	for _, e := range more {
+		if key := e.Location() + "\x00" + e.Message; !reported[key] {
+			reported[key] = true
+			errs = append(errs, e)
+		}
+	}
+	return errs
+}
+
 // validateRequired checks if a field has a non-zero value
 func validateRequired(value interface{}, fieldName string) error {
 	v := reflect.ValueOf(value)
@@ -3206,14 +3861,14 @@ func validateRequired(value interface{}, fieldName string) error {
 	// Handle pointers
 	if v.Kind() == reflect.Ptr {
 		if v.IsNil() {
-			return ValidationError{Field: fieldName, Message: "is required but is nil"}
+			return newValidationError(fieldName, RuleRequired, "REQUIRED_NIL")
 		}
 		v = v.Elem()
 	}
 
 	// Check if value is zero
 	if v.IsZero() {
-		return ValidationError{Field: fieldName, Message: "is required but is empty"}
+		return newValidationError(fieldName, RuleRequired, "REQUIRED")
 	}
 
 	return nil
@@ -3223,22 +3878,39 @@ func validateRequired(value interface{}, fieldName string) error {
 func validateStringLength(value string, minLen, maxLen int, fieldName string) error {
 	length := len(value)
 	if length < minLen {
-		return ValidationError{Field: fieldName, Message: fmt.Sprintf("length %d is below minimum %d", length, minLen)}
+		return newValidationError(fieldName, RuleLength, "LENGTH_MIN", length, minLen)
 	}
 	if length > maxLen {
-		return ValidationError{Field: fieldName, Message: fmt.Sprintf("length %d exceeds maximum %d", length, maxLen)}
+		return newValidationError(fieldName, RuleLength, "LENGTH_MAX", length, maxLen)
 	}
 	return nil
 }
 
+// compiledPatterns caches the regular expressions of the patterns validated against,
+// which are compiled once instead of for every value
+var compiledPatterns sync.Map
+
+// compilePattern returns the compiled regular expression of a pattern
+func compilePattern(pattern string) (*regexp.Regexp, error) {
+	if re, ok := compiledPatterns.Load(pattern); ok {
+		return re.(*regexp.Regexp), nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	compiledPatterns.Store(pattern, re)
+	return re, nil
+}
+
 // validatePattern validates string against regex pattern from XSD
 func validatePattern(value string, pattern string, fieldName string) error {
-	matched, err := regexp.MatchString(pattern, value)
+	re, err := compilePattern(pattern)
 	if err != nil {
-		return ValidationError{Field: fieldName, Message: fmt.Sprintf("pattern validation failed: %s", err.Error())}
+		return newValidationError(fieldName, RulePattern, "PATTERN_INVALID", err.Error())
 	}
-	if !matched {
-		return ValidationError{Field: fieldName, Message: fmt.Sprintf("does not match required pattern '%s'", pattern)}
+	if !re.MatchString(value) {
+		return newValidationError(fieldName, RulePattern, "PATTERN", pattern)
 	}
 	return nil
 }
@@ -3250,7 +3922,7 @@ func validateEnumeration(value string, validValues []string, fieldName string) e
 			return nil
 		}
 	}
-	return ValidationError{Field: fieldName, Message: fmt.Sprintf("'%s' is not a valid enumeration value", value)}
+	return newValidationError(fieldName, RuleCode, "CODE", value)
 }
 
 // validateCurrency validates currency code format (ISO 4217)
@@ -3262,6 +3934,20 @@ func validateCurrency(code string, fieldName string) error {
 	return validatePattern(code, `^[A-Z]{3}$`, fieldName)
 }
 
+// validateMinorUnits checks that an amount has no more decimal places than the
+// minor unit of its currency
+func validateMinorUnits(value Decimal, code string) error {
+	units, ok := currency.MinorUnits(code)
+	if !ok {
+		return nil
+	}
+	text := strconv.FormatFloat(float64(value), 'f', -1, 64)
+	if i := strings.IndexByte(text, '.'); i >= 0 && len(text)-i-1 > units {
+		return newValidationError("Value", RuleMinorUnits, "MINOR_UNITS", len(text)-i-1, units, code).at("text()")
+	}
+	return nil
+}
+
 // validateCountryCode validates country code format (ISO 3166-1 alpha-2)
 func validateCountryCode(code string, fieldName string) error {
 	if err := validateStringLength(code, 2, 2, fieldName); err != nil {
@@ -3280,24 +3966,6 @@ func validateBIC(bic string, fieldName string) error {
 	return validatePattern(bic, `^[A-Z]{4}[A-Z]{2}[A-Z0-9]{2}([A-Z0-9]{3})?$`, fieldName)
 }
 
-// validateIBAN validates IBAN format
-func validateIBAN(iban string, fieldName string) error {
-	if err := validateStringLength(iban, 15, 34, fieldName); err != nil {
-		return err
-	}
-	// Basic IBAN pattern (country code + 2 digits + up to 30 alphanumeric)
-	return validatePattern(iban, `^[A-Z]{2}[0-9]{2}[A-Z0-9]{1,30}$`, fieldName)
-}
-
-// validateLEI validates Legal Entity Identifier format
-func validateLEI(lei string, fieldName string) error {
-	if err := validateStringLength(lei, 20, 20, fieldName); err != nil {
-		return err
-	}
-	// LEI pattern: 18 alphanumeric characters + 2 check digits
-	return validatePattern(lei, `^[A-Z0-9]{18}[0-9]{2}$`, fieldName)
-}
-
 // validateUUID validates UUID v4 format
 func validateUUID(uuid string, fieldName string) error {
 	if err := validateStringLength(uuid, 36, 36, fieldName); err != nil {
@@ -3310,18 +3978,18 @@ func validateUUID(uuid string, fieldName string) error {
 // validateDate validates date string in YYYY-MM-DD format
 func validateDate(date string, fieldName string) error {
 	if date == "" {
-		return ValidationError{Field: fieldName, Message: "date is required"}
+		return newValidationError(fieldName, RuleRequired, "DATE_REQUIRED")
 	}
 
 	// Check format using regex
 	if err := validatePattern(date, `^\d{4}-\d{2}-\d{2}$`, fieldName); err != nil {
-		return ValidationError{Field: fieldName, Message: "date must be in YYYY-MM-DD format"}
+		return newValidationError(fieldName, RuleDate, "DATE_FORMAT")
 	}
 
 	// Parse and validate actual date values
 	_, err := time.Parse("2006-01-02", date)
 	if err != nil {
-		return ValidationError{Field: fieldName, Message: "invalid date value"}
+		return newValidationError(fieldName, RuleDate, "DATE_VALUE")
 	}
 
 	return nil
@@ -3330,12 +3998,12 @@ func validateDate(date string, fieldName string) error {
 // validateDateTime validates datetime string in YYYY-MM-DDTHH:MM:SS or YYYY-MM-DDTHH:MM:SS.000Z format
 func validateDateTime(dateTime string, fieldName string) error {
 	if dateTime == "" {
-		return ValidationError{Field: fieldName, Message: "datetime is required"}
+		return newValidationError(fieldName, RuleRequired, "DATETIME_REQUIRED")
 	}
 
 	// Check format using regex (supports both with and without milliseconds/timezone)
 	if err := validatePattern(dateTime, `^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(\.\d{3}Z?)?$`, fieldName); err != nil {
-		return ValidationError{Field: fieldName, Message: "datetime must be in YYYY-MM-DDTHH:MM:SS format"}
+		return newValidationError(fieldName, RuleDate, "DATETIME_FORMAT")
 	}
 
 	// Parse and validate actual datetime values
@@ -3354,7 +4022,7 @@ func validateDateTime(dateTime string, fieldName string) error {
 	}
 
 	if parseErr != nil {
-		return ValidationError{Field: fieldName, Message: "invalid datetime value"}
+		return newValidationError(fieldName, RuleDate, "DATETIME_VALUE")
 	}
 
 	return nil
@@ -3376,13 +4044,17 @@ func (a *ActiveCurrencyAndAmount) Validate() error {
 
 	// Value validation - must be positive
 	if a.Value <= 0 {
-		errs = append(errs, ValidationError{Field: "Value", Message: "must be positive"})
+		errs = append(errs, ValidationError{Field: "Value", Message: "must be positive", Path: "text()"})
 	}
 
 	// Currency validation (ISO 4217 - 3 letter code)
 	if a.Currency == "" {
-		errs = append(errs, ValidationError{Field: "Ccy", Message: "is required"})
+		errs = append(errs, ValidationError{Field: "Ccy", Message: "is required", Path: "@Ccy"})
 	} else if err := validateCurrency(a.Currency, "Ccy"); err != nil {
+		errs = append(errs, attributeError(err))
+	} else if !currency.IsActive(a.Currency) {
+		errs = append(errs, newValidationError("Ccy", RuleCurrency, "CURRENCY_INACTIVE", a.Currency).at("@Ccy"))
+	} else if err := validateMinorUnits(a.Value, a.Currency); err != nil {
 		errs = append(errs, err.(ValidationError))
 	}
 
@@ -3423,7 +4095,9 @@ func (g *GroupHeader93) Validate() error {
 	if err := validateRequired(g.SettlementInfo, "SttlmInf"); err != nil {
 		errs = append(errs, err.(ValidationError))
 	} else {
-		if err := validateRequired(g.SettlementInfo.SettlementMethod, "SttlmMtd"); err != nil {
+		if err := validateRequired(g.SettlementInfo.SettlementMethod, "SttlmInf.SttlmMtd"); err != nil {
+			errs = append(errs, err.(ValidationError))
+		} else if err := validateCode(g.SettlementInfo.SettlementMethod, string(g.SettlementInfo.SettlementMethod), "SttlmInf.SttlmMtd"); err != nil {
 			errs = append(errs, err.(ValidationError))
 		}
 	}
@@ -3436,27 +4110,27 @@ func (g *GroupHeader93) Validate() error {
 
 // Validate Pacs00800108Document according to pacs.008.001.08 XSD
 func (d *Pacs00800108Document) Validate() error {
+	return d.validateContext(context.Background())
+}
+
+func (d *Pacs00800108Document) validateContext(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
 	var errs ValidationErrors
 
 	// Validate required fields
 	if err := validateRequired(d.FICustomerCreditTransfer, "FIToFICstmrCdtTrf"); err != nil {
 		errs = append(errs, err.(ValidationError))
 	} else {
-		// Validate GroupHeader (required)
-		if err := d.FICustomerCreditTransfer.GroupHeader.Validate(); err != nil {
-			if valErrs, ok := err.(ValidationErrors); ok {
-				errs = append(errs, valErrs...)
-			} else {
-				errs = append(errs, ValidationError{Field: "GrpHdr", Message: err.Error()})
-			}
-		}
-
-		// Validate CreditTransferTransactionInfo array (required, min 1)
-		if len(d.FICustomerCreditTransfer.CreditTransferTransactionInfo) == 0 {
-			errs = append(errs, ValidationError{Field: "CdtTrfTxInf", Message: "at least one transaction is required"})
+		// Validate the group header and every transaction
+		if err := d.FICustomerCreditTransfer.validateContext(ctx); err != nil {
+			errs = append(errs, nestErrors("FIToFICstmrCdtTrf", err)...)
 		}
 	}
 
+	errs = errs.merge(validateStructure(d))
+
 	if errs.HasErrors() {
 		return errs
 	}
@@ -3471,11 +4145,22 @@ func (d *Camt05200108Document) Validate() error {
 	if err := validateRequired(d.BankAccountReport, "BkToCstmrAcctRpt"); err != nil {
 		errs = append(errs, err.(ValidationError))
 	} else {
-		// Validate nested structure - placeholder for now
-		// TODO: Implement full validation based on XSD constraints
-		_ = d.BankAccountReport
+		for i, rpt := range d.BankAccountReport.Report {
+			for j := range rpt.Balance {
+				if err := rpt.Balance[j].Validate(); err != nil {
+					errs = append(errs, nestErrors(fmt.Sprintf("BkToCstmrAcctRpt.Rpt[%d].Bal[%d]", i+1, j+1), err)...)
+				}
+			}
+			for j := range rpt.Entry {
+				if err := rpt.Entry[j].Validate(); err != nil {
+					errs = append(errs, nestErrors(fmt.Sprintf("BkToCstmrAcctRpt.Rpt[%d].Ntry[%d]", i+1, j+1), err)...)
+				}
+			}
+		}
 	}
 
+	errs = errs.merge(validateStructure(d))
+
 	if errs.HasErrors() {
 		return errs
 	}
@@ -3490,11 +4175,17 @@ func (d *Camt05400108Document) Validate() error {
 	if err := validateRequired(d.BankDebitCreditNotification, "BkToCstmrDbtCdtNtfctn"); err != nil {
 		errs = append(errs, err.(ValidationError))
 	} else {
-		// Validate nested structure - placeholder for now
-		// TODO: Implement full validation based on XSD constraints
-		_ = d.BankDebitCreditNotification
+		for i, rpt := range d.BankDebitCreditNotification.Notification {
+			for j := range rpt.Entry {
+				if err := rpt.Entry[j].Validate(); err != nil {
+					errs = append(errs, nestErrors(fmt.Sprintf("BkToCstmrDbtCdtNtfctn.Ntfctn[%d].Ntry[%d]", i+1, j+1), err)...)
+				}
+			}
+		}
 	}
 
+	errs = errs.merge(validateStructure(d))
+
 	if errs.HasErrors() {
 		return errs
 	}
@@ -3514,6 +4205,101 @@ func (d *Camt06000105Document) Validate() error {
 		_ = d.AccountReportingRequest
 	}
 
+	errs = errs.merge(validateStructure(d))
+
+	if errs.HasErrors() {
+		return errs
+	}
+	return nil
+}
+
+// Validate performs validation according to the pain.001.001.09 XSD
+func (d *Pain00100109Document) Validate() error {
+	return d.validateContext(context.Background())
+}
+
+func (d *Pain00100109Document) validateContext(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	var errs ValidationErrors
+
+	msg := &d.CustomerCreditTransferInitiation
+	if err := validateRequired(msg.GroupHeader.MessageID, "GrpHdr.MsgId"); err != nil {
+		errs = append(errs, err.(ValidationError))
+	} else if err := validateStringLength(msg.GroupHeader.MessageID, 1, 35, "GrpHdr.MsgId"); err != nil {
+		errs = append(errs, err.(ValidationError))
+	}
+	if err := validatePattern(msg.GroupHeader.NumberOfTransactions, `^[0-9]{1,15}$`, "GrpHdr.NbOfTxs"); err != nil {
+		errs = append(errs, err.(ValidationError))
+	}
+	if err := msg.GroupHeader.InitiatingParty.validateContext(ctx); err != nil {
+		errs = append(errs, nestErrors("GrpHdr.InitgPty", err)...)
+	}
+
+	if len(msg.PaymentInfo) == 0 {
+		errs = append(errs, ValidationError{Field: "PmtInf", Message: "at least one payment information block is required"})
+	}
+	for i, pmt := range msg.PaymentInfo {
+		if err := pmt.validateContext(ctx); err != nil {
+			errs = append(errs, nestErrors(fmt.Sprintf("PmtInf[%d]", i+1), err)...)
+		}
+	}
+
+	errs = errs.merge(validateStructure(&d.CustomerCreditTransferInitiation))
+
+	if errs.HasErrors() {
+		return errs.within("CstmrCdtTrfInitn")
+	}
+	return nil
+}
+
+// Validate performs validation for PaymentInstruction30
+func (p *PaymentInstruction30) Validate() error {
+	return p.validateContext(context.Background())
+}
+
+func (p *PaymentInstruction30) validateContext(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	var errs ValidationErrors
+
+	if err := validateRequired(p.PaymentInfoID, "PmtInfId"); err != nil {
+		errs = append(errs, err.(ValidationError))
+	} else if err := validateStringLength(p.PaymentInfoID, 1, 35, "PmtInfId"); err != nil {
+		errs = append(errs, err.(ValidationError))
+	}
+	if err := validateEnumeration(p.PaymentMethod, []string{"CHK", "TRF", "TRA"}, "PmtMtd"); err != nil {
+		errs = append(errs, err.(ValidationError))
+	}
+	if p.RequestedExecutionDate.Date == nil && p.RequestedExecutionDate.DateTime == nil {
+		errs = append(errs, ValidationError{Field: "ReqdExctnDt", Message: "a date or a date and time is required"})
+	}
+	if err := p.Debtor.validateContext(ctx); err != nil {
+		errs = append(errs, nestErrors("Dbtr", err)...)
+	}
+
+	if len(p.CreditTransferTransactionInfo) == 0 {
+		errs = append(errs, ValidationError{Field: "CdtTrfTxInf", Message: "at least one credit transfer transaction is required"})
+	}
+	for i, tx := range p.CreditTransferTransactionInfo {
+		path := fmt.Sprintf("CdtTrfTxInf[%d]", i+1)
+		if err := validateRequired(tx.PaymentID.EndToEndID, path+".PmtId.EndToEndId"); err != nil {
+			errs = append(errs, err.(ValidationError))
+		} else if err := validateStringLength(tx.PaymentID.EndToEndID, 1, 35, path+".PmtId.EndToEndId"); err != nil {
+			errs = append(errs, err.(ValidationError))
+		}
+		if (tx.Amount.InstructedAmount == nil) == (tx.Amount.EquivalentAmount == nil) {
+			errs = append(errs, ValidationError{Field: path + ".Amt", Message: "exactly one of InstdAmt and EqvtAmt is required"})
+		}
+		if tx.Creditor != nil {
+			if err := tx.Creditor.validateContext(ctx); err != nil {
+				errs = append(errs, nestErrors(path+".Cdtr", err)...)
+			}
+		}
+	}
+
 	if errs.HasErrors() {
 		return errs
 	}
@@ -3533,6 +4319,8 @@ func (d *Pain01300107Document) Validate() error {
 		_ = d.CreditorPaymentActivationRequest
 	}
 
+	errs = errs.merge(validateStructure(d))
+
 	if errs.HasErrors() {
 		return errs
 	}
@@ -3552,6 +4340,8 @@ func (d *Pain01400107Document) Validate() error {
 		_ = d.CreditorPaymentActivationStatusReport
 	}
 
+	errs = errs.merge(validateStructure(d))
+
 	if errs.HasErrors() {
 		return errs
 	}
@@ -3571,6 +4361,8 @@ func (d *Admi00400102Document) Validate() error {
 		_ = d.SystemEventNotification
 	}
 
+	errs = errs.merge(validateStructure(d))
+
 	if errs.HasErrors() {
 		return errs
 	}
@@ -3591,6 +4383,8 @@ func (d *Admi01100101Document) Validate() error {
 		}
 	}
 
+	errs = errs.merge(validateStructure(d))
+
 	if errs.HasErrors() {
 		return errs
 	}
@@ -3610,6 +4404,8 @@ func (d *Admi00600101Document) Validate() error {
 		_ = d.ResendRequest
 	}
 
+	errs = errs.merge(validateStructure(d))
+
 	if errs.HasErrors() {
 		return errs
 	}
@@ -3629,6 +4425,8 @@ func (d *Admi00700101Document) Validate() error {
 		_ = d.ReceiptAcknowledgement
 	}
 
+	errs = errs.merge(validateStructure(d))
+
 	if errs.HasErrors() {
 		return errs
 	}
@@ -3648,12 +4446,94 @@ func (d *Admi99800102Document) Validate() error {
 		_ = d.AdministrationMessage
 	}
 
+	errs = errs.merge(validateStructure(d))
+
 	if errs.HasErrors() {
 		return errs
 	}
 	return nil
 }
 
+// Validate checks the repeating elements and the choices of pacs.009.001.08
+func (d *Pacs00900108Document) Validate() error {
+	if errs := validateStructure(d); errs.HasErrors() {
+		return errs
+	}
+	return nil
+}
+
+// Validate checks the repeating elements and the choices of pacs.002.001.10
+func (d *Pacs00200110Document) Validate() error {
+	if errs := validateStructure(d); errs.HasErrors() {
+		return errs
+	}
+	return nil
+}
+
+// Validate checks the repeating elements and the choices of pacs.004.001.10
+func (d *Pacs00400110Document) Validate() error {
+	if errs := validateStructure(d); errs.HasErrors() {
+		return errs
+	}
+	return nil
+}
+
+// Validate checks the repeating elements and the choices of pacs.028.001.03
+func (d *Pacs02800103Document) Validate() error {
+	if errs := validateStructure(d); errs.HasErrors() {
+		return errs
+	}
+	return nil
+}
+
+// Validate checks the repeating elements and the choices of camt.026.001.07
+func (d *Camt02600107Document) Validate() error {
+	if errs := validateStructure(d); errs.HasErrors() {
+		return errs
+	}
+	return nil
+}
+
+// Validate checks the repeating elements and the choices of camt.028.001.09
+func (d *Camt02800109Document) Validate() error {
+	if errs := validateStructure(d); errs.HasErrors() {
+		return errs
+	}
+	return nil
+}
+
+// Validate checks the repeating elements and the choices of camt.029.001.09
+func (d *Camt02900109Document) Validate() error {
+	if errs := validateStructure(d); errs.HasErrors() {
+		return errs
+	}
+	return nil
+}
+
+// Validate checks the repeating elements and the choices of camt.055.001.09
+func (d *Camt05500109Document) Validate() error {
+	if errs := validateStructure(d); errs.HasErrors() {
+		return errs
+	}
+	return nil
+}
+
+// Validate checks the repeating elements and the choices of camt.056.001.08
+func (d *Camt05600108Document) Validate() error {
+	if errs := validateStructure(d); errs.HasErrors() {
+		return errs
+	}
+	return nil
+}
+
+// Validate checks the repeating elements and the choices of admi.002.001.01
+func (d *Admi00200101Document) Validate() error {
+	if errs := validateStructure(d); errs.HasErrors() {
+		return errs
+	}
+	return nil
+}
+
 // Additional missing types for compilation - XSD-based implementations
 
 // RateType4 - Rate type selection
@@ -3684,7 +4564,7 @@ type TotalNetEntryDetails1 struct {
 // AmountAndDirection35 - Amount with debit/credit direction
 type AmountAndDirection35 struct {
 	Amount               ActiveOrHistoricCurrencyAndAmount `xml:"Amt"`
-	CreditDebitIndicator string                            `xml:"CdtDbtInd"` // CreditDebitCode
+	CreditDebitIndicator CreditDebitCode                   `xml:"CdtDbtInd"`
 }
 
 // TransactionReferences6 - Transaction reference information
@@ -3733,20 +4613,19 @@ type CurrencyExchange5 struct {
 	UnitCurrency   *string  `xml:"UnitCcy,omitempty"`  // ActiveOrHistoricCurrencyCode
 	ExchangeRate   *Decimal `xml:"XchgRate,omitempty"` // BaseOneRate
 	ContractID     *string  `xml:"CtrctId,omitempty"`  // Max35Text
-	QuotationDate  *string  `xml:"QtnDt,omitempty"`    // ISODate
+	QuotationDate  *ISODate `xml:"QtnDt,omitempty"`    // ISODate
 }
 
-// BankTransactionCodeStructure4 - Bank transaction code structure
+// BankTransactionCodeStructure4 - Bank transaction code, structured or proprietary
 type BankTransactionCodeStructure4 struct {
-	Domain    BankTransactionCodeStructure5  `xml:"Domn"`
-	Family    BankTransactionCodeStructure6  `xml:"Fmly"`
-	SubFamily *BankTransactionCodeStructure7 `xml:"SubFmlyCd,omitempty"`
+	Domain      *BankTransactionCodeStructure5            `xml:"Domn,omitempty"`
+	Proprietary *ProprietaryBankTransactionCodeStructure1 `xml:"Prtry,omitempty"`
 }
 
 // BankTransactionCodeStructure5 - Bank transaction domain
 type BankTransactionCodeStructure5 struct {
-	Code   string `xml:"Cd"`   // ExternalBankTransactionDomain1Code
-	Family string `xml:"Fmly"` // ExternalBankTransactionFamily1Code
+	Code   string                        `xml:"Cd"` // ExternalBankTransactionDomain1Code
+	Family BankTransactionCodeStructure6 `xml:"Fmly"`
 }
 
 // BankTransactionCodeStructure6 - Bank transaction family
@@ -3755,9 +4634,10 @@ type BankTransactionCodeStructure6 struct {
 	SubFamilyCode string `xml:"SubFmlyCd"` // ExternalBankTransactionSubFamily1Code
 }
 
-// BankTransactionCodeStructure7 - Bank transaction sub-family
-type BankTransactionCodeStructure7 struct {
-	Code string `xml:"Cd"` // ExternalBankTransactionSubFamily1Code
+// ProprietaryBankTransactionCodeStructure1 - Bank transaction code of a local scheme
+type ProprietaryBankTransactionCodeStructure1 struct {
+	Code   string  `xml:"Cd"`             // Max35Text
+	Issuer *string `xml:"Issr,omitempty"` // Max35Text
 }
 
 // Charges6 - Charges information
@@ -3769,7 +4649,7 @@ type Charges6 struct {
 // ChargesRecord3 - Individual charge record
 type ChargesRecord3 struct {
 	Amount                   ActiveOrHistoricCurrencyAndAmount             `xml:"Amt"`
-	CreditDebitIndicator     *string                                       `xml:"CdtDbtInd,omitempty"` // CreditDebitCode
+	CreditDebitIndicator     *CreditDebitCode                              `xml:"CdtDbtInd,omitempty"`
 	ChargesIncludedIndicator *bool                                         `xml:"ChrgInclInd,omitempty"`
 	Type                     *ChargeType3                                  `xml:"Tp,omitempty"`
 	Rate                     *Decimal                                      `xml:"Rate,omitempty"` // PercentageRate
@@ -3784,9 +4664,6 @@ type ChargeType3 struct {
 	Proprietary *string `xml:"Prtry,omitempty"` // Max35Text
 }
 
-// ChargeBearerType1Code - Charge bearer type
-type ChargeBearerType1Code string
-
 // TransactionInterest4 - Transaction interest information
 type TransactionInterest4 struct {
 	TotalInterestAndTaxAmount *ActiveOrHistoricCurrencyAndAmount `xml:"TtlIntrsTAndTaxAmt,omitempty"`
@@ -3796,7 +4673,7 @@ type TransactionInterest4 struct {
 // InterestRecord2 - Individual interest record
 type InterestRecord2 struct {
 	Amount               ActiveOrHistoricCurrencyAndAmount `xml:"Amt"`
-	CreditDebitIndicator string                            `xml:"CdtDbtInd"` // CreditDebitCode
+	CreditDebitIndicator CreditDebitCode                   `xml:"CdtDbtInd"`
 	Type                 *InterestType1                    `xml:"Tp,omitempty"`
 	Rate                 *Rate4                            `xml:"Rate,omitempty"`
 	FromToDate           *DateTimePeriod1                  `xml:"FrToDt,omitempty"`
@@ -3806,21 +4683,21 @@ type InterestRecord2 struct {
 
 // TransactionParties6 - Transaction parties
 type TransactionParties6 struct {
-	InitiatingParty  *PartyIdentification135 `xml:"InitgPty,omitempty"`
-	Debtor           *PartyIdentification135 `xml:"Dbtr,omitempty"`
-	DebtorAccount    *CashAccount38          `xml:"DbtrAcct,omitempty"`
-	UltimateDebtor   *PartyIdentification135 `xml:"UltmtDbtr,omitempty"`
-	Creditor         *PartyIdentification135 `xml:"Cdtr,omitempty"`
-	CreditorAccount  *CashAccount38          `xml:"CdtrAcct,omitempty"`
-	UltimateCreditor *PartyIdentification135 `xml:"UltmtCdtr,omitempty"`
-	TradingParty     *PartyIdentification135 `xml:"TradgPty,omitempty"`
-	Proprietary      []ProprietaryParty5     `xml:"Prtry,omitempty"`
+	InitiatingParty  *Party40            `xml:"InitgPty,omitempty"`
+	Debtor           *Party40            `xml:"Dbtr,omitempty"`
+	DebtorAccount    *CashAccount38      `xml:"DbtrAcct,omitempty"`
+	UltimateDebtor   *Party40            `xml:"UltmtDbtr,omitempty"`
+	Creditor         *Party40            `xml:"Cdtr,omitempty"`
+	CreditorAccount  *CashAccount38      `xml:"CdtrAcct,omitempty"`
+	UltimateCreditor *Party40            `xml:"UltmtCdtr,omitempty"`
+	TradingParty     *Party40            `xml:"TradgPty,omitempty"`
+	Proprietary      []ProprietaryParty5 `xml:"Prtry,omitempty"`
 }
 
 // ProprietaryParty5 - Proprietary party information
 type ProprietaryParty5 struct {
-	Type  string                 `xml:"Tp"` // Max35Text
-	Party PartyIdentification135 `xml:"Pty"`
+	Type  string  `xml:"Tp"` // Max35Text
+	Party Party40 `xml:"Pty"`
 }
 
 // Frequency36 - Frequency choice
@@ -3887,21 +4764,21 @@ type RemittanceLocationData1 struct {
 
 // TransactionDates3 - Transaction dates
 type TransactionDates3 struct {
-	AcceptanceDateTime                  *time.Time         `xml:"AccptncDtTm,omitempty"`            // ISODateTime
-	TradeActivityContractSettlementDate *string            `xml:"TradActvtyCtrctSttlmDt,omitempty"` // ISODate
-	TradeDate                           *string            `xml:"TradDt,omitempty"`                 // ISODate
-	InterbankSettlementDate             *string            `xml:"IntrBkSttlmDt,omitempty"`          // ISODate
-	StartDate                           *string            `xml:"StartDt,omitempty"`                // ISODate
-	EndDate                             *string            `xml:"EndDt,omitempty"`                  // ISODate
-	TransactionDateTime                 *time.Time         `xml:"TxDtTm,omitempty"`                 // ISODateTime
+	AcceptanceDateTime                  *ISODateTime       `xml:"AccptncDtTm,omitempty"`            // ISODateTime
+	TradeActivityContractSettlementDate *ISODate           `xml:"TradActvtyCtrctSttlmDt,omitempty"` // ISODate
+	TradeDate                           *ISODate           `xml:"TradDt,omitempty"`                 // ISODate
+	InterbankSettlementDate             *ISODate           `xml:"IntrBkSttlmDt,omitempty"`          // ISODate
+	StartDate                           *ISODate           `xml:"StartDt,omitempty"`                // ISODate
+	EndDate                             *ISODate           `xml:"EndDt,omitempty"`                  // ISODate
+	TransactionDateTime                 *ISODateTime       `xml:"TxDtTm,omitempty"`                 // ISODateTime
 	Proprietary                         []ProprietaryDate3 `xml:"Prtry,omitempty"`
 }
 
 // ProprietaryDate3 - Proprietary date information
 type ProprietaryDate3 struct {
-	Type     string     `xml:"Tp"`             // Max35Text
-	Date     *string    `xml:"Dt,omitempty"`   // ISODate
-	DateTime *time.Time `xml:"DtTm,omitempty"` // ISODateTime
+	Type     string       `xml:"Tp"`             // Max35Text
+	Date     *ISODate     `xml:"Dt,omitempty"`   // ISODate
+	DateTime *ISODateTime `xml:"DtTm,omitempty"` // ISODateTime
 }
 
 // TransactionPrice4 - Transaction price choice
@@ -4034,19 +4911,19 @@ func (i *InterestType1) Validate() error {
 	choiceCount := 0
 	if i.Code != nil {
 		choiceCount++
-		if err := validateStringLength(*i.Code, 1, 35, "Code"); err != nil {
+		if err := validateStringLength(*i.Code, 1, 35, "Cd"); err != nil {
 			errs = append(errs, err.(ValidationError))
 		}
 	}
 	if i.Proprietary != nil {
 		choiceCount++
-		if err := validateStringLength(*i.Proprietary, 1, 35, "Proprietary"); err != nil {
+		if err := validateStringLength(*i.Proprietary, 1, 35, "Prtry"); err != nil {
 			errs = append(errs, err.(ValidationError))
 		}
 	}
 
 	if choiceCount != 1 {
-		errs = append(errs, ValidationError{Field: "Choice", Message: "exactly one choice must be present"})
+		errs = append(errs, newValidationError("Choice", RuleChoice, "CHOICE"))
 	}
 
 	if errs.HasErrors() {
@@ -4076,7 +4953,7 @@ func (t *TaxCharges2) Validate() error {
 	var errs ValidationErrors
 
 	if t.ID != nil {
-		if err := validateStringLength(*t.ID, 1, 35, "ID"); err != nil {
+		if err := validateStringLength(*t.ID, 1, 35, "Id"); err != nil {
 			errs = append(errs, err.(ValidationError))
 		}
 	}
@@ -4101,19 +4978,19 @@ func (b *BalanceType10) Validate() error {
 	choiceCount := 0
 	if b.Code != nil {
 		choiceCount++
-		if err := validateStringLength(*b.Code, 1, 35, "Code"); err != nil {
+		if err := validateStringLength(*b.Code, 1, 35, "Cd"); err != nil {
 			errs = append(errs, err.(ValidationError))
 		}
 	}
 	if b.Proprietary != nil {
 		choiceCount++
-		if err := validateStringLength(*b.Proprietary, 1, 35, "Proprietary"); err != nil {
+		if err := validateStringLength(*b.Proprietary, 1, 35, "Prtry"); err != nil {
 			errs = append(errs, err.(ValidationError))
 		}
 	}
 
 	if choiceCount != 1 {
-		errs = append(errs, ValidationError{Field: "Choice", Message: "exactly one choice must be present"})
+		errs = append(errs, newValidationError("Choice", RuleChoice, "CHOICE"))
 	}
 
 	if errs.HasErrors() {
@@ -4130,19 +5007,19 @@ func (b *BalanceSubType1) Validate() error {
 	choiceCount := 0
 	if b.Code != nil {
 		choiceCount++
-		if err := validateStringLength(*b.Code, 1, 35, "Code"); err != nil {
+		if err := validateStringLength(*b.Code, 1, 35, "Cd"); err != nil {
 			errs = append(errs, err.(ValidationError))
 		}
 	}
 	if b.Proprietary != nil {
 		choiceCount++
-		if err := validateStringLength(*b.Proprietary, 1, 35, "Proprietary"); err != nil {
+		if err := validateStringLength(*b.Proprietary, 1, 35, "Prtry"); err != nil {
 			errs = append(errs, err.(ValidationError))
 		}
 	}
 
 	if choiceCount != 1 {
-		errs = append(errs, ValidationError{Field: "Choice", Message: "exactly one choice must be present"})
+		errs = append(errs, newValidationError("Choice", RuleChoice, "CHOICE"))
 	}
 
 	if errs.HasErrors() {
@@ -4159,19 +5036,19 @@ func (s *ServiceLevel8) Validate() error {
 	choiceCount := 0
 	if s.Code != nil {
 		choiceCount++
-		if err := validateStringLength(*s.Code, 1, 35, "Code"); err != nil {
+		if err := validateStringLength(*s.Code, 1, 35, "Cd"); err != nil {
 			errs = append(errs, err.(ValidationError))
 		}
 	}
 	if s.Proprietary != nil {
 		choiceCount++
-		if err := validateStringLength(*s.Proprietary, 1, 35, "Proprietary"); err != nil {
+		if err := validateStringLength(*s.Proprietary, 1, 35, "Prtry"); err != nil {
 			errs = append(errs, err.(ValidationError))
 		}
 	}
 
 	if choiceCount != 1 {
-		errs = append(errs, ValidationError{Field: "Choice", Message: "exactly one choice must be present"})
+		errs = append(errs, newValidationError("Choice", RuleChoice, "CHOICE"))
 	}
 
 	if errs.HasErrors() {
@@ -4188,19 +5065,19 @@ func (l *LocalInstrument2) Validate() error {
 	choiceCount := 0
 	if l.Code != nil {
 		choiceCount++
-		if err := validateStringLength(*l.Code, 1, 35, "Code"); err != nil {
+		if err := validateStringLength(*l.Code, 1, 35, "Cd"); err != nil {
 			errs = append(errs, err.(ValidationError))
 		}
 	}
 	if l.Proprietary != nil {
 		choiceCount++
-		if err := validateStringLength(*l.Proprietary, 1, 35, "Proprietary"); err != nil {
+		if err := validateStringLength(*l.Proprietary, 1, 35, "Prtry"); err != nil {
 			errs = append(errs, err.(ValidationError))
 		}
 	}
 
 	if choiceCount != 1 {
-		errs = append(errs, ValidationError{Field: "Choice", Message: "exactly one choice must be present"})
+		errs = append(errs, newValidationError("Choice", RuleChoice, "CHOICE"))
 	}
 
 	if errs.HasErrors() {
@@ -4217,19 +5094,19 @@ func (c *CategoryPurpose1) Validate() error {
 	choiceCount := 0
 	if c.Code != nil {
 		choiceCount++
-		if err := validateStringLength(*c.Code, 1, 35, "Code"); err != nil {
+		if err := validateStringLength(*c.Code, 1, 35, "Cd"); err != nil {
 			errs = append(errs, err.(ValidationError))
 		}
 	}
 	if c.Proprietary != nil {
 		choiceCount++
-		if err := validateStringLength(*c.Proprietary, 1, 35, "Proprietary"); err != nil {
+		if err := validateStringLength(*c.Proprietary, 1, 35, "Prtry"); err != nil {
 			errs = append(errs, err.(ValidationError))
 		}
 	}
 
 	if choiceCount != 1 {
-		errs = append(errs, ValidationError{Field: "Choice", Message: "exactly one choice must be present"})
+		errs = append(errs, newValidationError("Choice", RuleChoice, "CHOICE"))
 	}
 
 	if errs.HasErrors() {
@@ -4246,19 +5123,19 @@ func (m *MandateSetupReason1) Validate() error {
 	choiceCount := 0
 	if m.Code != nil {
 		choiceCount++
-		if err := validateStringLength(*m.Code, 1, 35, "Code"); err != nil {
+		if err := validateStringLength(*m.Code, 1, 35, "Cd"); err != nil {
 			errs = append(errs, err.(ValidationError))
 		}
 	}
 	if m.Proprietary != nil {
 		choiceCount++
-		if err := validateStringLength(*m.Proprietary, 1, 70, "Proprietary"); err != nil {
+		if err := validateStringLength(*m.Proprietary, 1, 70, "Prtry"); err != nil {
 			errs = append(errs, err.(ValidationError))
 		}
 	}
 
 	if choiceCount != 1 {
-		errs = append(errs, ValidationError{Field: "Choice", Message: "exactly one choice must be present"})
+		errs = append(errs, newValidationError("Choice", RuleChoice, "CHOICE"))
 	}
 
 	if errs.HasErrors() {
@@ -4275,19 +5152,19 @@ func (c *CashAccountType2) Validate() error {
 	choiceCount := 0
 	if c.Code != nil {
 		choiceCount++
-		if err := validateStringLength(*c.Code, 1, 35, "Code"); err != nil {
+		if err := validateStringLength(*c.Code, 1, 35, "Cd"); err != nil {
 			errs = append(errs, err.(ValidationError))
 		}
 	}
 	if c.Proprietary != nil {
 		choiceCount++
-		if err := validateStringLength(*c.Proprietary, 1, 35, "Proprietary"); err != nil {
+		if err := validateStringLength(*c.Proprietary, 1, 35, "Prtry"); err != nil {
 			errs = append(errs, err.(ValidationError))
 		}
 	}
 
 	if choiceCount != 1 {
-		errs = append(errs, ValidationError{Field: "Choice", Message: "exactly one choice must be present"})
+		errs = append(errs, newValidationError("Choice", RuleChoice, "CHOICE"))
 	}
 
 	if errs.HasErrors() {
@@ -4300,24 +5177,24 @@ func (c *CashAccountType2) Validate() error {
 func (g *GenericIdentification30) Validate() error {
 	var errs ValidationErrors
 
-	if err := validateRequired(g.ID, "ID"); err != nil {
+	if err := validateRequired(g.ID, "Id"); err != nil {
 		errs = append(errs, err.(ValidationError))
 	} else {
 		if len(g.ID) != 4 {
-			errs = append(errs, ValidationError{Field: "ID", Message: "must be exactly 4 characters"})
+			errs = append(errs, ValidationError{Field: "Id", Message: "must be exactly 4 characters"})
 		}
 	}
 
-	if err := validateRequired(g.Issuer, "Issuer"); err != nil {
+	if err := validateRequired(g.Issuer, "Issr"); err != nil {
 		errs = append(errs, err.(ValidationError))
 	} else {
-		if err := validateStringLength(g.Issuer, 1, 35, "Issuer"); err != nil {
+		if err := validateStringLength(g.Issuer, 1, 35, "Issr"); err != nil {
 			errs = append(errs, err.(ValidationError))
 		}
 	}
 
 	if g.SchemeName != nil {
-		if err := validateStringLength(*g.SchemeName, 1, 35, "SchemeName"); err != nil {
+		if err := validateStringLength(*g.SchemeName, 1, 35, "SchmeNm"); err != nil {
 			errs = append(errs, err.(ValidationError))
 		}
 	}
@@ -4334,16 +5211,16 @@ func (g *GenericIdentification30) Validate() error {
 func (p *PaymentIdentification7) Validate() error {
 	var errs ValidationErrors
 
-	if err := validateRequired(p.EndToEndID, "EndToEndID"); err != nil {
+	if err := validateRequired(p.EndToEndID, "EndToEndId"); err != nil {
 		errs = append(errs, err.(ValidationError))
 	} else {
-		if err := validateStringLength(p.EndToEndID, 1, 35, "EndToEndID"); err != nil {
+		if err := validateStringLength(p.EndToEndID, 1, 35, "EndToEndId"); err != nil {
 			errs = append(errs, err.(ValidationError))
 		}
 	}
 
 	if p.TransactionID != nil {
-		if err := validateStringLength(*p.TransactionID, 1, 35, "TransactionID"); err != nil {
+		if err := validateStringLength(*p.TransactionID, 1, 35, "TxId"); err != nil {
 			errs = append(errs, err.(ValidationError))
 		}
 	}
@@ -4356,23 +5233,30 @@ func (p *PaymentIdentification7) Validate() error {
 
 // Validate performs validation for PartyIdentification135
 func (p *PartyIdentification135) Validate() error {
+	return p.validateContext(context.Background())
+}
+
+func (p *PartyIdentification135) validateContext(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
 	var errs ValidationErrors
 
 	if p.Name != nil {
-		if err := validateStringLength(*p.Name, 1, 140, "Name"); err != nil {
+		if err := validateStringLength(*p.Name, 1, 140, "Nm"); err != nil {
 			errs = append(errs, err.(ValidationError))
 		}
 	}
 
 	if p.PostalAddress != nil {
 		if err := p.PostalAddress.Validate(); err != nil {
-			errs = append(errs, ValidationError{Field: "PostalAddress", Message: err.Error()})
+			errs = append(errs, nestErrors("PstlAdr", err)...)
 		}
 	}
 
 	if p.ContactDetails != nil {
 		if err := p.ContactDetails.Validate(); err != nil {
-			errs = append(errs, ValidationError{Field: "ContactDetails", Message: err.Error()})
+			errs = append(errs, nestErrors("CtctDtls", err)...)
 		}
 	}
 
@@ -4387,37 +5271,37 @@ func (p *PostalAddress24) Validate() error {
 	var errs ValidationErrors
 
 	if p.Department != nil {
-		if err := validateStringLength(*p.Department, 1, 70, "Department"); err != nil {
+		if err := validateStringLength(*p.Department, 1, 70, "Dept"); err != nil {
 			errs = append(errs, err.(ValidationError))
 		}
 	}
 
 	if p.StreetName != nil {
-		if err := validateStringLength(*p.StreetName, 1, 70, "StreetName"); err != nil {
+		if err := validateStringLength(*p.StreetName, 1, 70, "StrtNm"); err != nil {
 			errs = append(errs, err.(ValidationError))
 		}
 	}
 
 	if p.BuildingNumber != nil {
-		if err := validateStringLength(*p.BuildingNumber, 1, 16, "BuildingNumber"); err != nil {
+		if err := validateStringLength(*p.BuildingNumber, 1, 16, "BldgNb"); err != nil {
 			errs = append(errs, err.(ValidationError))
 		}
 	}
 
 	if p.PostCode != nil {
-		if err := validateStringLength(*p.PostCode, 1, 16, "PostCode"); err != nil {
+		if err := validateStringLength(*p.PostCode, 1, 16, "PstCd"); err != nil {
 			errs = append(errs, err.(ValidationError))
 		}
 	}
 
 	if p.TownName != nil {
-		if err := validateStringLength(*p.TownName, 1, 35, "TownName"); err != nil {
+		if err := validateStringLength(*p.TownName, 1, 35, "TwnNm"); err != nil {
 			errs = append(errs, err.(ValidationError))
 		}
 	}
 
 	if p.Country != nil {
-		if err := validateCountryCode(*p.Country, "Country"); err != nil {
+		if err := validateCountryCode(*p.Country, "Ctry"); err != nil {
 			errs = append(errs, err.(ValidationError))
 		}
 	}
@@ -4433,19 +5317,19 @@ func (c *Contact4) Validate() error {
 	var errs ValidationErrors
 
 	if c.Name != nil {
-		if err := validateStringLength(*c.Name, 1, 140, "Name"); err != nil {
+		if err := validateStringLength(*c.Name, 1, 140, "Nm"); err != nil {
 			errs = append(errs, err.(ValidationError))
 		}
 	}
 
 	if c.EmailAddress != nil {
-		if err := validateStringLength(*c.EmailAddress, 1, 2048, "EmailAddress"); err != nil {
+		if err := validateStringLength(*c.EmailAddress, 1, 2048, "EmailAdr"); err != nil {
 			errs = append(errs, err.(ValidationError))
 		}
 	}
 
 	if c.PhoneNumber != nil {
-		if err := validateStringLength(*c.PhoneNumber, 1, 35, "PhoneNumber"); err != nil {
+		if err := validateStringLength(*c.PhoneNumber, 1, 35, "PhneNb"); err != nil {
 			errs = append(errs, err.(ValidationError))
 		}
 	}
@@ -4458,19 +5342,26 @@ func (c *Contact4) Validate() error {
 
 // Validate performs validation for BranchAndFinancialInstitutionIdentification6
 func (b *BranchAndFinancialInstitutionIdentification6) Validate() error {
+	return b.validateContext(context.Background())
+}
+
+func (b *BranchAndFinancialInstitutionIdentification6) validateContext(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
 	var errs ValidationErrors
 
-	if err := validateRequired(b.FinancialInstitutionID, "FinancialInstitutionID"); err != nil {
+	if err := validateRequired(b.FinancialInstitutionID, "FinInstnId"); err != nil {
 		errs = append(errs, err.(ValidationError))
 	} else {
-		if err := b.FinancialInstitutionID.Validate(); err != nil {
-			errs = append(errs, ValidationError{Field: "FinancialInstitutionID", Message: err.Error()})
+		if err := b.FinancialInstitutionID.validateContext(ctx); err != nil {
+			errs = append(errs, nestErrors("FinInstnId", err)...)
 		}
 	}
 
 	if b.BranchID != nil {
-		if err := b.BranchID.Validate(); err != nil {
-			errs = append(errs, ValidationError{Field: "BranchID", Message: err.Error()})
+		if err := b.BranchID.validateContext(ctx); err != nil {
+			errs = append(errs, nestErrors("BrnchId", err)...)
 		}
 	}
 
@@ -4482,24 +5373,141 @@ func (b *BranchAndFinancialInstitutionIdentification6) Validate() error {
 
 // Validate performs validation for FinancialInstitutionIdentification18
 func (f *FinancialInstitutionIdentification18) Validate() error {
+	return f.validateContext(context.Background())
+}
+
+func (f *FinancialInstitutionIdentification18) validateContext(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
 	var errs ValidationErrors
 
 	if f.BankIdentifierCode != nil {
-		if err := validateBIC(*f.BankIdentifierCode, "BankIdentifierCode"); err != nil {
+		if err := validateBIC(*f.BankIdentifierCode, "BICFI"); err != nil {
+			errs = append(errs, err.(ValidationError))
+		} else if err := validateBICDirectory(ctx, *f.BankIdentifierCode, "BICFI"); err != nil {
 			errs = append(errs, err.(ValidationError))
 		}
 	}
 
 	if f.LegalEntityIdentifier != nil {
-		if err := validateLEI(*f.LegalEntityIdentifier, "LegalEntityIdentifier"); err != nil {
+		if err := validateLEI(*f.LegalEntityIdentifier, "LEI"); err != nil {
 			errs = append(errs, err.(ValidationError))
+		} else if err := validateLEIRegistry(ctx, *f.LegalEntityIdentifier, "LEI"); err != nil {
+			errs = append(errs, err.(ValidationError))
+		}
+	}
+
+	if f.ClearingSystemMemberID != nil {
+		if err := f.ClearingSystemMemberID.validateContext(ctx); err != nil {
+			errs = append(errs, nestErrors("ClrSysMmbId", err)...)
 		}
 	}
 
 	if f.Name != nil {
-		if err := validateStringLength(*f.Name, 1, 140, "Name"); err != nil {
+		if err := validateStringLength(*f.Name, 1, 140, "Nm"); err != nil {
+			errs = append(errs, err.(ValidationError))
+		}
+	}
+
+	if errs.HasErrors() {
+		return errs
+	}
+	return nil
+}
+
+// Validate performs validation for ClearingSystemMemberIdentification. The member
+// identifier is checked against the format of the clearing system named by its code.
+func (c *ClearingSystemMemberIdentification) Validate() error {
+	return c.validateContext(context.Background())
+}
+
+func (c *ClearingSystemMemberIdentification) validateContext(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	var errs ValidationErrors
+
+	if c.ClearingSystemID != nil {
+		if err := c.ClearingSystemID.Validate(); err != nil {
+			errs = append(errs, nestErrors("ClrSysId", err)...)
+		}
+	}
+
+	if err := validateStringLength(c.MemberID, 1, 35, "MmbId"); err != nil {
+		errs = append(errs, err.(ValidationError))
+	} else if c.ClearingSystemID != nil && c.ClearingSystemID.Code != nil {
+		if err := validateClearingSystemMemberID(*c.ClearingSystemID.Code, c.MemberID, "MmbId"); err != nil {
+			errs = append(errs, err.(ValidationError))
+		}
+	}
+
+	if errs.HasErrors() {
+		return errs
+	}
+	return nil
+}
+
+// Validate performs validation for ClearingSystemIdentification
+func (c *ClearingSystemIdentification) Validate() error {
+	var errs ValidationErrors
+
+	// Exactly one choice must be present
+	choiceCount := 0
+	if c.Code != nil {
+		choiceCount++
+		if err := validateStringLength(*c.Code, 1, 5, "Cd"); err != nil {
+			errs = append(errs, err.(ValidationError))
+		}
+	}
+	if c.Proprietary != nil {
+		choiceCount++
+		if err := validateStringLength(*c.Proprietary, 1, 35, "Prtry"); err != nil {
+			errs = append(errs, err.(ValidationError))
+		}
+	}
+
+	if choiceCount != 1 {
+		errs = append(errs, newValidationError("Choice", RuleChoice, "CHOICE"))
+	}
+
+	if errs.HasErrors() {
+		return errs
+	}
+	return nil
+}
+
+// Validate performs validation for BankTransactionCodeStructure4. A structured code
+// must be a domain, family and sub-family combination of the ISO code list.
+func (b *BankTransactionCodeStructure4) Validate() error {
+	var errs ValidationErrors
+
+	// DomainOrProprietaryRule
+	if b.Domain == nil && b.Proprietary == nil {
+		errs = append(errs, newValidationError("Choice", RuleChoice, "CHOICE"))
+	}
+
+	if b.Domain != nil {
+		err := btc.Check(b.Domain.Code, b.Domain.Family.Code, b.Domain.Family.SubFamilyCode)
+		if codeErr, ok := err.(*btc.CodeError); ok {
+			field := map[btc.Level]string{
+				btc.LevelDomain:    "Domn.Cd",
+				btc.LevelFamily:    "Domn.Fmly.Cd",
+				btc.LevelSubFamily: "Domn.Fmly.SubFmlyCd",
+			}[codeErr.Level]
+			errs = append(errs, ValidationError{Field: field, Message: codeErr.Error()})
+		}
+	}
+
+	if b.Proprietary != nil {
+		if err := validateStringLength(b.Proprietary.Code, 1, 35, "Prtry.Cd"); err != nil {
 			errs = append(errs, err.(ValidationError))
 		}
+		if b.Proprietary.Issuer != nil {
+			if err := validateStringLength(*b.Proprietary.Issuer, 1, 35, "Prtry.Issr"); err != nil {
+				errs = append(errs, err.(ValidationError))
+			}
+		}
 	}
 
 	if errs.HasErrors() {
@@ -4510,22 +5518,31 @@ func (f *FinancialInstitutionIdentification18) Validate() error {
 
 // Validate performs validation for BranchData3
 func (b *BranchData3) Validate() error {
+	return b.validateContext(context.Background())
+}
+
+func (b *BranchData3) validateContext(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
 	var errs ValidationErrors
 
 	if b.ID != nil {
-		if err := validateStringLength(*b.ID, 1, 35, "ID"); err != nil {
+		if err := validateStringLength(*b.ID, 1, 35, "Id"); err != nil {
 			errs = append(errs, err.(ValidationError))
 		}
 	}
 
 	if b.LegalEntityIdentifier != nil {
-		if err := validateLEI(*b.LegalEntityIdentifier, "LegalEntityIdentifier"); err != nil {
+		if err := validateLEI(*b.LegalEntityIdentifier, "LEI"); err != nil {
+			errs = append(errs, err.(ValidationError))
+		} else if err := validateLEIRegistry(ctx, *b.LegalEntityIdentifier, "LEI"); err != nil {
 			errs = append(errs, err.(ValidationError))
 		}
 	}
 
 	if b.Name != nil {
-		if err := validateStringLength(*b.Name, 1, 140, "Name"); err != nil {
+		if err := validateStringLength(*b.Name, 1, 140, "Nm"); err != nil {
 			errs = append(errs, err.(ValidationError))
 		}
 	}
@@ -4542,10 +5559,10 @@ func (b *BranchData3) Validate() error {
 func (g *GenericAccountIdentification1) Validate() error {
 	var errs ValidationErrors
 
-	if err := validateRequired(g.ID, "ID"); err != nil {
+	if err := validateRequired(g.ID, "Id"); err != nil {
 		errs = append(errs, err.(ValidationError))
 	} else {
-		if err := validateStringLength(g.ID, 1, 34, "ID"); err != nil {
+		if err := validateStringLength(g.ID, 1, 34, "Id"); err != nil {
 			errs = append(errs, err.(ValidationError))
 		}
 	}
@@ -4553,7 +5570,7 @@ func (g *GenericAccountIdentification1) Validate() error {
 	// Note: SchemeName validation skipped as it uses custom type
 
 	if g.Issuer != nil {
-		if err := validateStringLength(*g.Issuer, 1, 35, "Issuer"); err != nil {
+		if err := validateStringLength(*g.Issuer, 1, 35, "Issr"); err != nil {
 			errs = append(errs, err.(ValidationError))
 		}
 	}
@@ -4566,21 +5583,28 @@ func (g *GenericAccountIdentification1) Validate() error {
 
 // Validate performs validation for CashAccount38
 func (c *CashAccount38) Validate() error {
+	return c.validateContext(context.Background())
+}
+
+func (c *CashAccount38) validateContext(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
 	var errs ValidationErrors
 
 	// ID is required - delegating to AccountIdentification4 validation
-	if err := c.ID.Validate(); err != nil {
-		errs = append(errs, ValidationError{Field: "ID", Message: err.Error()})
+	if err := c.ID.validateContext(ctx); err != nil {
+		errs = append(errs, nestErrors("Id", err)...)
 	}
 
 	if c.Currency != nil {
-		if err := validateStringLength(*c.Currency, 3, 3, "Currency"); err != nil {
+		if err := validateStringLength(*c.Currency, 3, 3, "Ccy"); err != nil {
 			errs = append(errs, err.(ValidationError))
 		}
 	}
 
 	if c.Name != nil {
-		if err := validateStringLength(*c.Name, 1, 70, "Name"); err != nil {
+		if err := validateStringLength(*c.Name, 1, 70, "Nm"); err != nil {
 			errs = append(errs, err.(ValidationError))
 		}
 	}
@@ -4593,29 +5617,32 @@ func (c *CashAccount38) Validate() error {
 
 // Validate performs validation for AccountIdentification4
 func (a *AccountIdentification4) Validate() error {
+	return a.validateContext(context.Background())
+}
+
+func (a *AccountIdentification4) validateContext(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
 	var errs ValidationErrors
 
 	// Must have exactly one choice - either IBAN or Other
 	hasIBAN := a.IBAN != nil
 	hasOther := a.Other != nil
 
-	if !hasIBAN && !hasOther {
-		errs = append(errs, ValidationError{Field: "Choice", Message: "must have either IBAN or Other"})
-	} else if hasIBAN && hasOther {
-		errs = append(errs, ValidationError{Field: "Choice", Message: "cannot have both IBAN and Other"})
+	if hasIBAN == hasOther {
+		errs = append(errs, newValidationError("Choice", RuleChoice, "CHOICE"))
 	}
 
 	if hasIBAN {
-		// IBAN should be validated with specific IBAN format
-		if err := validateStringLength(*a.IBAN, 15, 34, "IBAN"); err != nil {
+		if err := validateIBAN(*a.IBAN, "IBAN"); err != nil {
 			errs = append(errs, err.(ValidationError))
 		}
-		// Additional IBAN format validation could be added here
 	}
 
 	if hasOther {
 		if err := a.Other.Validate(); err != nil {
-			errs = append(errs, ValidationError{Field: "Other", Message: err.Error()})
+			errs = append(errs, nestErrors("Othr", err)...)
 		}
 	}
 
@@ -4630,15 +5657,13 @@ func (p *PaymentTypeInfo28) Validate() error {
 	var errs ValidationErrors
 
 	if p.InstructionPriority != nil {
-		// InstructionPriority should be a valid priority code
-		if err := validateStringLength(*p.InstructionPriority, 1, 4, "InstructionPriority"); err != nil {
+		if err := validateCode(*p.InstructionPriority, string(*p.InstructionPriority), "InstrPrty"); err != nil {
 			errs = append(errs, err.(ValidationError))
 		}
 	}
 
-	if p.SequenceType != nil {
-		// SequenceType validation - common values: FRST, RCUR, FNAL, OOFF
-		if err := validateStringLength(*p.SequenceType, 1, 4, "SequenceType"); err != nil {
+	if p.ClearingChannel != nil {
+		if err := validateCode(*p.ClearingChannel, string(*p.ClearingChannel), "ClrChanl"); err != nil {
 			errs = append(errs, err.(ValidationError))
 		}
 	}
@@ -4651,78 +5676,115 @@ func (p *PaymentTypeInfo28) Validate() error {
 	return nil
 }
 
-// Validate performs validation for CreditTransferTransaction39
-func (c *CreditTransferTransaction39) Validate() error {
-	var errs ValidationErrors
-
-	// PaymentID is required
-	if err := c.PaymentID.Validate(); err != nil {
-		errs = append(errs, ValidationError{Field: "PaymentID", Message: err.Error()})
-	}
-
-	// InterbankSettlementAmount is required
-	if err := c.InterbankSettlementAmount.Validate(); err != nil {
-		errs = append(errs, ValidationError{Field: "InterbankSettlementAmount", Message: err.Error()})
+// Validate checks the credit debit indicator of a balance
+func (b *CashBalance8) Validate() error {
+	if err := validateCode(b.CreditDebitIndicator, string(b.CreditDebitIndicator), "CdtDbtInd"); err != nil {
+		return ValidationErrors{err.(ValidationError)}
 	}
+	return nil
+}
 
-	// ChargeBearer is required and should be valid charge bearer code
-	if err := validateRequired(c.ChargeBearer, "ChargeBearer"); err != nil {
+// Validate checks the credit debit indicators of an entry and of its transaction
+// details
+func (e *ReportEntry10) Validate() error {
+	var errs ValidationErrors
+	if err := validateCode(e.CreditDebitIndicator, string(e.CreditDebitIndicator), "CdtDbtInd"); err != nil {
 		errs = append(errs, err.(ValidationError))
-	} else {
-		// Common values: DEBT, CRED, SHAR, SLEV
-		if err := validateStringLength(c.ChargeBearer, 1, 4, "ChargeBearer"); err != nil {
-			errs = append(errs, err.(ValidationError))
-		}
+	}
+	for i, details := range e.EntryDetails {
+		for j, tx := range details.TransactionDetails {
+			if tx.CreditDebitIndicator == nil {
+				continue
+			}
+			if err := validateCode(*tx.CreditDebitIndicator, string(*tx.CreditDebitIndicator), "CdtDbtInd"); err != nil {
+				errs = append(errs, nestErrors(fmt.Sprintf("NtryDtls[%d].TxDtls[%d]", i+1, j+1), err)...)
+			}
+		}
+	}
+	if errs.HasErrors() {
+		return errs
+	}
+	return nil
+}
+
+// Validate performs validation for CreditTransferTransaction39
+func (c *CreditTransferTransaction39) Validate() error {
+	return c.validateContext(context.Background())
+}
+
+func (c *CreditTransferTransaction39) validateContext(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	var errs ValidationErrors
+
+	// PaymentID is required
+	if err := c.PaymentID.Validate(); err != nil {
+		errs = append(errs, nestErrors("PmtId", err)...)
+	}
+
+	// InterbankSettlementAmount is required
+	if err := c.InterbankSettlementAmount.Validate(); err != nil {
+		errs = append(errs, nestErrors("IntrBkSttlmAmt", err)...)
+	}
+
+	// ChargeBearer is required and should be valid charge bearer code
+	if err := validateRequired(c.ChargeBearer, "ChrgBr"); err != nil {
+		errs = append(errs, err.(ValidationError))
+	} else {
+		if err := validateCode(c.ChargeBearer, string(c.ChargeBearer), "ChrgBr"); err != nil {
+			errs = append(errs, err.(ValidationError))
+		}
 	}
 
 	// Debtor is required
-	if err := c.Debtor.Validate(); err != nil {
-		errs = append(errs, ValidationError{Field: "Debtor", Message: err.Error()})
+	if err := c.Debtor.validateContext(ctx); err != nil {
+		errs = append(errs, nestErrors("Dbtr", err)...)
 	}
 
 	// DebtorAgent is required
-	if err := c.DebtorAgent.Validate(); err != nil {
-		errs = append(errs, ValidationError{Field: "DebtorAgent", Message: err.Error()})
+	if err := c.DebtorAgent.validateContext(ctx); err != nil {
+		errs = append(errs, nestErrors("DbtrAgt", err)...)
 	}
 
 	// Creditor is required
-	if err := c.Creditor.Validate(); err != nil {
-		errs = append(errs, ValidationError{Field: "Creditor", Message: err.Error()})
+	if err := c.Creditor.validateContext(ctx); err != nil {
+		errs = append(errs, nestErrors("Cdtr", err)...)
 	}
 
 	// CreditorAgent is required
-	if err := c.CreditorAgent.Validate(); err != nil {
-		errs = append(errs, ValidationError{Field: "CreditorAgent", Message: err.Error()})
+	if err := c.CreditorAgent.validateContext(ctx); err != nil {
+		errs = append(errs, nestErrors("CdtrAgt", err)...)
 	}
 
 	// Optional fields
 	if c.PaymentTypeInfo != nil {
 		if err := c.PaymentTypeInfo.Validate(); err != nil {
-			errs = append(errs, ValidationError{Field: "PaymentTypeInfo", Message: err.Error()})
+			errs = append(errs, nestErrors("PmtTpInf", err)...)
 		}
 	}
 
 	if c.DebtorAccount != nil {
-		if err := c.DebtorAccount.Validate(); err != nil {
-			errs = append(errs, ValidationError{Field: "DebtorAccount", Message: err.Error()})
+		if err := c.DebtorAccount.validateContext(ctx); err != nil {
+			errs = append(errs, nestErrors("DbtrAcct", err)...)
 		}
 	}
 
 	if c.CreditorAccount != nil {
-		if err := c.CreditorAccount.Validate(); err != nil {
-			errs = append(errs, ValidationError{Field: "CreditorAccount", Message: err.Error()})
+		if err := c.CreditorAccount.validateContext(ctx); err != nil {
+			errs = append(errs, nestErrors("CdtrAcct", err)...)
 		}
 	}
 
 	if c.UltimateDebtor != nil {
-		if err := c.UltimateDebtor.Validate(); err != nil {
-			errs = append(errs, ValidationError{Field: "UltimateDebtor", Message: err.Error()})
+		if err := c.UltimateDebtor.validateContext(ctx); err != nil {
+			errs = append(errs, nestErrors("UltmtDbtr", err)...)
 		}
 	}
 
 	if c.UltimateCreditor != nil {
-		if err := c.UltimateCreditor.Validate(); err != nil {
-			errs = append(errs, ValidationError{Field: "UltimateCreditor", Message: err.Error()})
+		if err := c.UltimateCreditor.validateContext(ctx); err != nil {
+			errs = append(errs, nestErrors("UltmtCdtr", err)...)
 		}
 	}
 
@@ -4734,20 +5796,27 @@ func (c *CreditTransferTransaction39) Validate() error {
 
 // Validate performs validation for FIToFICustomerCreditTransferV08
 func (f *FIToFICustomerCreditTransferV08) Validate() error {
+	return f.validateContext(context.Background())
+}
+
+func (f *FIToFICustomerCreditTransferV08) validateContext(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
 	var errs ValidationErrors
 
 	// GroupHeader is required
 	if err := f.GroupHeader.Validate(); err != nil {
-		errs = append(errs, ValidationError{Field: "GroupHeader", Message: err.Error()})
+		errs = append(errs, nestErrors("GrpHdr", err)...)
 	}
 
 	// CreditTransferTransactionInfo is required and must have at least one item
 	if len(f.CreditTransferTransactionInfo) == 0 {
-		errs = append(errs, ValidationError{Field: "CreditTransferTransactionInfo", Message: "at least one credit transfer transaction is required"})
+		errs = append(errs, ValidationError{Field: "CdtTrfTxInf", Message: "at least one credit transfer transaction is required"})
 	} else {
 		for i, tx := range f.CreditTransferTransactionInfo {
-			if err := tx.Validate(); err != nil {
-				errs = append(errs, ValidationError{Field: fmt.Sprintf("CreditTransferTransactionInfo[%d]", i), Message: err.Error()})
+			if err := tx.validateContext(ctx); err != nil {
+				errs = append(errs, nestErrors(fmt.Sprintf("CdtTrfTxInf[%d]", i+1), err)...)
 			}
 		}
 	}
@@ -4769,8 +5838,8 @@ type BusinessApplicationHeaderV02 struct {
 	MessageDefinitionID    string                        `xml:"MsgDefIdr"`            // Message definition identifier (Max35Text)
 	BusinessService        *string                       `xml:"BizSvc,omitempty"`     // Business service identifier (Max35Text)
 	MarketPractice         *ImplementationSpecification1 `xml:"MktPrctc,omitempty"`   // Market practice specification
-	CreationDate           time.Time                     `xml:"CreDt"`                // Creation date and time (ISODateTime) - required
-	BusinessProcessingDate *time.Time                    `xml:"BizPrcgDt,omitempty"`  // Business processing date (ISODateTime)
+	CreationDate           ISODateTime                   `xml:"CreDt"`                // Creation date and time (ISODateTime) - required
+	BusinessProcessingDate *ISODateTime                  `xml:"BizPrcgDt,omitempty"`  // Business processing date (ISODateTime)
 	CopyDuplicate          *CopyDuplicate1Code           `xml:"CpyDplct,omitempty"`   // Copy/duplicate indicator
 	PossibleDuplicate      *bool                         `xml:"PssblDplct,omitempty"` // Possible duplicate flag (YesNoIndicator)
 	Priority               *BusinessMessagePriorityCode  `xml:"Prty,omitempty"`       // Message priority
@@ -4810,81 +5879,88 @@ type BusinessApplicationHeader5 struct {
 	BusinessMessageID   string                       `xml:"BizMsgIdr"`            // Unique business message identifier
 	MessageDefinitionID string                       `xml:"MsgDefIdr"`            // Message definition identifier
 	BusinessService     *string                      `xml:"BizSvc,omitempty"`     // Business service identifier
-	CreationDate        time.Time                    `xml:"CreDt"`                // Creation date and time - required
+	CreationDate        ISODateTime                  `xml:"CreDt"`                // Creation date and time - required
 	CopyDuplicate       *CopyDuplicate1Code          `xml:"CpyDplct,omitempty"`   // Copy/duplicate indicator
 	PossibleDuplicate   *bool                        `xml:"PssblDplct,omitempty"` // Possible duplicate flag
 	Priority            *BusinessMessagePriorityCode `xml:"Prty,omitempty"`       // Message priority
 	Signature           *SignatureEnvelope           `xml:"Sgntr,omitempty"`      // Digital signature
 }
 
-// SignatureEnvelope represents a digital signature wrapper
+// SignatureEnvelope holds the XML digital signature (ds:Signature) of a header.
+// The signature is kept as raw XML so that it survives a round trip unchanged;
+// package xmldsig creates and verifies it.
 type SignatureEnvelope struct {
-	// Implementation depends on specific signature requirements
-	// This is a placeholder for XML signature structure
-	Value string `xml:",chardata"`
+	Value string `xml:",innerxml"`
 }
 
 // Validate validates the BusinessApplicationHeaderV02 structure
 func (b *BusinessApplicationHeaderV02) Validate() error {
+	return b.validateContext(context.Background())
+}
+
+func (b *BusinessApplicationHeaderV02) validateContext(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
 	var errs ValidationErrors
 
 	// BusinessMessageID is required and has format restrictions
 	if b.BusinessMessageID == "" {
-		errs = append(errs, ValidationError{Field: "BusinessMessageID", Message: "business message identifier is required"})
+		errs = append(errs, ValidationError{Field: "BizMsgIdr", Message: "business message identifier is required"})
 	} else if len(b.BusinessMessageID) > 35 {
-		errs = append(errs, ValidationError{Field: "BusinessMessageID", Message: "business message identifier must not exceed 35 characters"})
+		errs = append(errs, ValidationError{Field: "BizMsgIdr", Message: "business message identifier must not exceed 35 characters"})
 	}
 
 	// MessageDefinitionID is required
 	if b.MessageDefinitionID == "" {
-		errs = append(errs, ValidationError{Field: "MessageDefinitionID", Message: "message definition identifier is required"})
+		errs = append(errs, ValidationError{Field: "MsgDefIdr", Message: "message definition identifier is required"})
 	} else {
 		// Validate message definition identifier format (e.g., pacs.008.001.08)
 		msgDefPattern := regexp.MustCompile(`^[a-z]{4}\.\d{3}\.\d{3}\.\d{2}$`)
 		if !msgDefPattern.MatchString(b.MessageDefinitionID) {
-			errs = append(errs, ValidationError{Field: "MessageDefinitionID", Message: "message definition identifier must follow format like 'pacs.008.001.08'"})
+			errs = append(errs, ValidationError{Field: "MsgDefIdr", Message: "message definition identifier must follow format like 'pacs.008.001.08'"})
 		}
 	}
 
 	// From is required
-	if err := b.From.Validate(); err != nil {
-		errs = append(errs, ValidationError{Field: "From", Message: err.Error()})
+	if err := b.From.validateContext(ctx); err != nil {
+		errs = append(errs, nestErrors("Fr", err)...)
 	}
 
 	// To is required
-	if err := b.To.Validate(); err != nil {
-		errs = append(errs, ValidationError{Field: "To", Message: err.Error()})
+	if err := b.To.validateContext(ctx); err != nil {
+		errs = append(errs, nestErrors("To", err)...)
 	}
 
 	// CreationDate is required - check for zero value
 	if b.CreationDate.IsZero() {
-		errs = append(errs, ValidationError{Field: "CreationDate", Message: "creation date is required"})
+		errs = append(errs, ValidationError{Field: "CreDt", Message: "creation date is required"})
 	}
 
 	// Validate optional fields if present
 	if b.CharacterSet != nil && *b.CharacterSet != "" {
 		if len(*b.CharacterSet) > 35 {
-			errs = append(errs, ValidationError{Field: "CharacterSet", Message: "character set must not exceed 35 characters"})
+			errs = append(errs, ValidationError{Field: "CharSet", Message: "character set must not exceed 35 characters"})
 		}
 	}
 
 	if b.BusinessService != nil && *b.BusinessService != "" {
 		if len(*b.BusinessService) > 35 {
-			errs = append(errs, ValidationError{Field: "BusinessService", Message: "business service must not exceed 35 characters"})
+			errs = append(errs, ValidationError{Field: "BizSvc", Message: "business service must not exceed 35 characters"})
 		}
 	}
 
 	// V02 specific validations
 	if b.MarketPractice != nil {
 		if err := b.MarketPractice.Validate(); err != nil {
-			errs = append(errs, ValidationError{Field: "MarketPractice", Message: err.Error()})
+			errs = append(errs, nestErrors("MktPrctc", err)...)
 		}
 	}
 
 	// Validate Related headers if present
 	for i, related := range b.Related {
-		if err := related.Validate(); err != nil {
-			errs = append(errs, ValidationError{Field: fmt.Sprintf("Related[%d]", i), Message: err.Error()})
+		if err := related.validateContext(ctx); err != nil {
+			errs = append(errs, nestErrors(fmt.Sprintf("Related[%d]", i+1), err)...)
 		}
 	}
 
@@ -4896,20 +5972,27 @@ func (b *BusinessApplicationHeaderV02) Validate() error {
 
 // Validate validates the Party44 structure
 func (p *Party44) Validate() error {
+	return p.validateContext(context.Background())
+}
+
+func (p *Party44) validateContext(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
 	var errs ValidationErrors
 
 	// Exactly one choice must be present
 	choiceCount := 0
 	if p.FinancialInstitutionID != nil {
 		choiceCount++
-		if err := p.FinancialInstitutionID.Validate(); err != nil {
-			errs = append(errs, ValidationError{Field: "FinancialInstitutionID", Message: err.Error()})
+		if err := p.FinancialInstitutionID.validateContext(ctx); err != nil {
+			errs = append(errs, nestErrors("FIId", err)...)
 		}
 	}
 	if p.OrganisationIdentification != nil {
 		choiceCount++
-		if err := p.OrganisationIdentification.Validate(); err != nil {
-			errs = append(errs, ValidationError{Field: "OrganisationIdentification", Message: err.Error()})
+		if err := p.OrganisationIdentification.validateContext(ctx); err != nil {
+			errs = append(errs, nestErrors("OrgId", err)...)
 		}
 	}
 
@@ -4931,16 +6014,16 @@ func (i *ImplementationSpecification1) Validate() error {
 
 	// Registry is required
 	if i.Registry == nil || *i.Registry == "" {
-		errs = append(errs, ValidationError{Field: "Registry", Message: "registry is required"})
+		errs = append(errs, ValidationError{Field: "Regy", Message: "registry is required"})
 	} else if len(*i.Registry) > 350 {
-		errs = append(errs, ValidationError{Field: "Registry", Message: "registry must not exceed 350 characters"})
+		errs = append(errs, ValidationError{Field: "Regy", Message: "registry must not exceed 350 characters"})
 	}
 
 	// ID is required
 	if i.ID == nil || *i.ID == "" {
-		errs = append(errs, ValidationError{Field: "ID", Message: "ID is required"})
+		errs = append(errs, ValidationError{Field: "Id", Message: "ID is required"})
 	} else if len(*i.ID) > 2048 {
-		errs = append(errs, ValidationError{Field: "ID", Message: "ID must not exceed 2048 characters"})
+		errs = append(errs, ValidationError{Field: "Id", Message: "ID must not exceed 2048 characters"})
 	}
 
 	if errs.HasErrors() {
@@ -4951,47 +6034,54 @@ func (i *ImplementationSpecification1) Validate() error {
 
 // Validate validates the BusinessApplicationHeader5 structure
 func (b *BusinessApplicationHeader5) Validate() error {
+	return b.validateContext(context.Background())
+}
+
+func (b *BusinessApplicationHeader5) validateContext(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
 	var errs ValidationErrors
 
 	// BusinessMessageID is required
 	if b.BusinessMessageID == "" {
-		errs = append(errs, ValidationError{Field: "BusinessMessageID", Message: "business message identifier is required"})
+		errs = append(errs, ValidationError{Field: "BizMsgIdr", Message: "business message identifier is required"})
 	} else if len(b.BusinessMessageID) > 35 {
-		errs = append(errs, ValidationError{Field: "BusinessMessageID", Message: "business message identifier must not exceed 35 characters"})
+		errs = append(errs, ValidationError{Field: "BizMsgIdr", Message: "business message identifier must not exceed 35 characters"})
 	}
 
 	// MessageDefinitionID is required
 	if b.MessageDefinitionID == "" {
-		errs = append(errs, ValidationError{Field: "MessageDefinitionID", Message: "message definition identifier is required"})
+		errs = append(errs, ValidationError{Field: "MsgDefIdr", Message: "message definition identifier is required"})
 	} else if len(b.MessageDefinitionID) > 35 {
-		errs = append(errs, ValidationError{Field: "MessageDefinitionID", Message: "message definition identifier must not exceed 35 characters"})
+		errs = append(errs, ValidationError{Field: "MsgDefIdr", Message: "message definition identifier must not exceed 35 characters"})
 	}
 
 	// From is required
-	if err := b.From.Validate(); err != nil {
-		errs = append(errs, ValidationError{Field: "From", Message: err.Error()})
+	if err := b.From.validateContext(ctx); err != nil {
+		errs = append(errs, nestErrors("Fr", err)...)
 	}
 
 	// To is required
-	if err := b.To.Validate(); err != nil {
-		errs = append(errs, ValidationError{Field: "To", Message: err.Error()})
+	if err := b.To.validateContext(ctx); err != nil {
+		errs = append(errs, nestErrors("To", err)...)
 	}
 
 	// CreationDate is required - check for zero value
 	if b.CreationDate.IsZero() {
-		errs = append(errs, ValidationError{Field: "CreationDate", Message: "creation date is required"})
+		errs = append(errs, ValidationError{Field: "CreDt", Message: "creation date is required"})
 	}
 
 	// Validate optional fields if present
 	if b.CharacterSet != nil && *b.CharacterSet != "" {
 		if len(*b.CharacterSet) > 35 {
-			errs = append(errs, ValidationError{Field: "CharacterSet", Message: "character set must not exceed 35 characters"})
+			errs = append(errs, ValidationError{Field: "CharSet", Message: "character set must not exceed 35 characters"})
 		}
 	}
 
 	if b.BusinessService != nil && *b.BusinessService != "" {
 		if len(*b.BusinessService) > 35 {
-			errs = append(errs, ValidationError{Field: "BusinessService", Message: "business service must not exceed 35 characters"})
+			errs = append(errs, ValidationError{Field: "BizSvc", Message: "business service must not exceed 35 characters"})
 		}
 	}
 
@@ -5009,12 +6099,20 @@ type BusinessApplicationHeaderDocument struct {
 
 // Validate validates the BusinessApplicationHeaderDocument
 func (b *BusinessApplicationHeaderDocument) Validate() error {
-	return b.AppHdr.Validate()
+	return b.validateContext(context.Background())
 }
 
-// pain.013.001.07 types
+func (b *BusinessApplicationHeaderDocument) validateContext(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if err := b.AppHdr.validateContext(ctx); err != nil {
+		return nestErrors("AppHdr", err)
+	}
+	return nil
+}
 
-type Priority2Code string
+// pain.013.001.07 types
 
 type PaymentTypeInformation26 struct {
 	InstructionPriority *Priority2Code    `xml:"InstrPrty,omitempty"`
@@ -5058,8 +6156,8 @@ type Cheque11 struct {
 	ChequeFrom           *NameAndAddress16      `xml:"ChqFr,omitempty"`
 	DeliveryMethod       *ChequeDeliveryMethod1 `xml:"DlvryMtd,omitempty"`
 	DeliverTo            *NameAndAddress16      `xml:"DlvrTo,omitempty"`
-	InstructionPriority  *string                `xml:"InstrPrty,omitempty"`
-	ChequeMaturityDate   *string                `xml:"ChqMtrtyDt,omitempty"`
+	InstructionPriority  *Priority2Code         `xml:"InstrPrty,omitempty"`
+	ChequeMaturityDate   *ISODate               `xml:"ChqMtrtyDt,omitempty"`
 	FormsCode            *string                `xml:"FrmsCd,omitempty"`
 	MemoField            []string               `xml:"MemoFld,omitempty"`
 	RegionalClearingZone *string                `xml:"RgnlClrZone,omitempty"`
@@ -5094,6 +6192,36 @@ type Document12 struct {
 	Enclosure        []byte              `xml:"Nclsr"`
 }
 
+// CreditTransferTransaction34 - Credit transfer transaction of pain.001.001.09. Its
+// remittance, regulatory reporting and tax elements share their types with pacs.008.001.08.
+type CreditTransferTransaction34 struct {
+	PaymentID                    PaymentIdentification6                        `xml:"PmtId"`
+	PaymentTypeInfo              *PaymentTypeInformation26                     `xml:"PmtTpInf,omitempty"`
+	Amount                       AmountType4                                   `xml:"Amt"`
+	ChargeBearer                 *ChargeBearerType1Code                        `xml:"ChrgBr,omitempty"`
+	ChequeInstruction            *Cheque11                                     `xml:"ChqInstr,omitempty"`
+	UltimateDebtor               *PartyIdentification135                       `xml:"UltmtDbtr,omitempty"`
+	IntermediaryAgent1           *BranchAndFinancialInstitutionIdentification6 `xml:"IntrmyAgt1,omitempty"`
+	IntermediaryAgent1Account    *CashAccount38                                `xml:"IntrmyAgt1Acct,omitempty"`
+	IntermediaryAgent2           *BranchAndFinancialInstitutionIdentification6 `xml:"IntrmyAgt2,omitempty"`
+	IntermediaryAgent2Account    *CashAccount38                                `xml:"IntrmyAgt2Acct,omitempty"`
+	IntermediaryAgent3           *BranchAndFinancialInstitutionIdentification6 `xml:"IntrmyAgt3,omitempty"`
+	IntermediaryAgent3Account    *CashAccount38                                `xml:"IntrmyAgt3Acct,omitempty"`
+	CreditorAgent                *BranchAndFinancialInstitutionIdentification6 `xml:"CdtrAgt,omitempty"`
+	CreditorAgentAccount         *CashAccount38                                `xml:"CdtrAgtAcct,omitempty"`
+	Creditor                     *PartyIdentification135                       `xml:"Cdtr,omitempty"`
+	CreditorAccount              *CashAccount38                                `xml:"CdtrAcct,omitempty"`
+	UltimateCreditor             *PartyIdentification135                       `xml:"UltmtCdtr,omitempty"`
+	InstructionsForCreditorAgent []InstructionForCreditorAgent                 `xml:"InstrForCdtrAgt,omitempty"`
+	InstructionForDebtorAgent    *InstructionForCreditorAgent                  `xml:"InstrForDbtrAgt,omitempty"`
+	Purpose                      *Purpose                                      `xml:"Purp,omitempty"`
+	RegulatoryReporting          []RegulatoryReporting3                        `xml:"RgltryRptg,omitempty"` // max 10 elements
+	Tax                          *TaxInfo                                      `xml:"Tax,omitempty"`
+	RelatedRemittanceInfo        []RemittanceLocation                          `xml:"RltdRmtInf,omitempty"` // max 10 elements
+	RemittanceInfo               *RemittanceInfo                               `xml:"RmtInf,omitempty"`
+	SupplementaryData            []SupplementaryData                           `xml:"SplmtryData,omitempty"`
+}
+
 type CreditTransferTransaction35 struct {
 	PaymentID                    PaymentIdentification6                        `xml:"PmtId"`
 	PaymentTypeInfo              *PaymentTypeInformation26                     `xml:"PmtTpInf,omitempty"`
@@ -5118,3 +6246,1973 @@ type CreditTransferTransaction35 struct {
 	EnclosedFile                 []Document12                                  `xml:"NclsdFile,omitempty"`
 	SupplementaryData            []SupplementaryData1                          `xml:"SplmtryData,omitempty"`
 }
+
+// camt.050.001.05 / camt.051.001.05 / camt.025.001.05 types
+
+// LiquidityCreditTransferV05 - camt.050.001.05
+type LiquidityCreditTransferV05 struct {
+	MessageHeader           MessageHeader1           `xml:"MsgHdr"`
+	LiquidityCreditTransfer LiquidityCreditTransfer2 `xml:"LqdtyCdtTrf"`
+	SupplementaryData       []SupplementaryData1     `xml:"SplmtryData,omitempty"`
+}
+
+// LiquidityDebitTransferV05 - camt.051.001.05
+type LiquidityDebitTransferV05 struct {
+	MessageHeader          MessageHeader1          `xml:"MsgHdr"`
+	LiquidityDebitTransfer LiquidityDebitTransfer2 `xml:"LqdtyDbtTrf"`
+	SupplementaryData      []SupplementaryData1    `xml:"SplmtryData,omitempty"`
+}
+
+// ReceiptV05 - camt.025.001.05
+type ReceiptV05 struct {
+	MessageHeader     MessageHeader9       `xml:"MsgHdr"`
+	ReceiptDetails    []Receipt4           `xml:"RctDtls"`
+	SupplementaryData []SupplementaryData1 `xml:"SplmtryData,omitempty"`
+}
+
+// MessageHeader1 - Message identification for cash management requests
+type MessageHeader1 struct {
+	MessageID        string       `xml:"MsgId"`             // Max35Text - required
+	CreationDateTime *ISODateTime `xml:"CreDtTm,omitempty"` // ISODateTime - optional
+}
+
+// MessageHeader9 - Message header for camt.025.001.05
+type MessageHeader9 struct {
+	MessageID        string        `xml:"MsgId"`             // Max35Text - required
+	CreationDateTime *ISODateTime  `xml:"CreDtTm,omitempty"` // ISODateTime - optional
+	RequestType      *RequestType4 `xml:"ReqTp,omitempty"`
+}
+
+// PaymentIdentification8 - Liquidity transfer identification
+type PaymentIdentification8 struct {
+	InstructionID *string `xml:"InstrId,omitempty"` // Max35Text
+	EndToEndID    string  `xml:"EndToEndId"`        // Max35Text - required
+	TransactionID *string `xml:"TxId,omitempty"`    // Max35Text
+	UETR          *string `xml:"UETR,omitempty"`    // UUIDv4Identifier
+}
+
+// Amount2Choice - Amount with or without an explicit currency
+type Amount2Choice struct {
+	AmountWithoutCurrency *Decimal                 `xml:"AmtWthtCcy,omitempty"` // ImpliedCurrencyAndAmount
+	AmountWithCurrency    *ActiveCurrencyAndAmount `xml:"AmtWthCcy,omitempty"`
+}
+
+// LiquidityCreditTransfer2 - Liquidity credit transfer details from camt.050.001.05 XSD
+type LiquidityCreditTransfer2 struct {
+	LiquidityTransferID *PaymentIdentification8                       `xml:"LqdtyTrfId,omitempty"`
+	Creditor            *BranchAndFinancialInstitutionIdentification6 `xml:"Cdtr,omitempty"`
+	CreditorAccount     *CashAccount38                                `xml:"CdtrAcct,omitempty"`
+	TransferredAmount   Amount2Choice                                 `xml:"TrfdAmt"` // Required
+	Debtor              *BranchAndFinancialInstitutionIdentification6 `xml:"Dbtr,omitempty"`
+	DebtorAccount       *CashAccount38                                `xml:"DbtrAcct,omitempty"`
+	SettlementDate      *ISODate                                      `xml:"SttlmDt,omitempty"` // ISODate
+}
+
+// LiquidityDebitTransfer2 - Liquidity debit transfer details from camt.051.001.05 XSD
+type LiquidityDebitTransfer2 struct {
+	LiquidityTransferID *PaymentIdentification8                       `xml:"LqdtyTrfId,omitempty"`
+	Creditor            *BranchAndFinancialInstitutionIdentification6 `xml:"Cdtr,omitempty"`
+	CreditorAccount     *CashAccount38                                `xml:"CdtrAcct,omitempty"`
+	TransferredAmount   Amount2Choice                                 `xml:"TrfdAmt"` // Required
+	Debtor              *BranchAndFinancialInstitutionIdentification6 `xml:"Dbtr,omitempty"`
+	DebtorAccount       *CashAccount38                                `xml:"DbtrAcct,omitempty"`
+	SettlementDate      *ISODate                                      `xml:"SttlmDt,omitempty"` // ISODate
+}
+
+// OriginalMessageAndIssuer1 - Reference to the message a receipt applies to
+type OriginalMessageAndIssuer1 struct {
+	MessageID      string  `xml:"MsgId"`             // Max35Text - required
+	MessageNameID  *string `xml:"MsgNmId,omitempty"` // Max35Text
+	OriginatorName *string `xml:"OrgtrNm,omitempty"` // Max70Text
+}
+
+// Receipt4 - Receipt details for camt.025.001.05
+type Receipt4 struct {
+	OriginalMessageID OriginalMessageAndIssuer1 `xml:"OrgnlMsgId"`
+	OriginalPaymentID *PaymentIdentification8   `xml:"OrgnlPmtId,omitempty"`
+	RequestHandling   []RequestHandling2        `xml:"ReqHdlg,omitempty"`
+}
+
+// SettlementAccount returns a CashAccount38 identified by a proprietary settlement account number,
+// as used by RTGS systems that do not address settlement accounts by IBAN.
+func SettlementAccount(id string) CashAccount38 {
+	return CashAccount38{
+		ID: AccountIdentification4{
+			Other: &GenericAccountIdentification1{ID: id},
+		},
+	}
+}
+
+// NewLiquidityCreditTransfer builds a camt.050.001.05 message moving amount from the debtor settlement
+// account to the creditor settlement account. The message is stamped with the current UTC time.
+func NewLiquidityCreditTransfer(msgID, endToEndID string, amount ActiveCurrencyAndAmount, debtorAccount, creditorAccount CashAccount38, opts ...IDOption) (*Camt05000105Document, error) {
+	if err := newIDOptions(opts).assign(&msgID, &endToEndID); err != nil {
+		return nil, err
+	}
+	now := NewISODateTime(time.Now().UTC())
+	return &Camt05000105Document{
+		LiquidityCreditTransfer: LiquidityCreditTransferV05{
+			MessageHeader: MessageHeader1{MessageID: msgID, CreationDateTime: &now},
+			LiquidityCreditTransfer: LiquidityCreditTransfer2{
+				LiquidityTransferID: &PaymentIdentification8{EndToEndID: endToEndID},
+				CreditorAccount:     &creditorAccount,
+				TransferredAmount:   Amount2Choice{AmountWithCurrency: &amount},
+				DebtorAccount:       &debtorAccount,
+			},
+		},
+	}, nil
+}
+
+// NewLiquidityDebitTransfer builds a camt.051.001.05 message withdrawing amount from the debtor settlement
+// account in favour of the creditor settlement account. The message is stamped with the current UTC time.
+func NewLiquidityDebitTransfer(msgID, endToEndID string, amount ActiveCurrencyAndAmount, debtorAccount, creditorAccount CashAccount38, opts ...IDOption) (*Camt05100105Document, error) {
+	if err := newIDOptions(opts).assign(&msgID, &endToEndID); err != nil {
+		return nil, err
+	}
+	now := NewISODateTime(time.Now().UTC())
+	return &Camt05100105Document{
+		LiquidityDebitTransfer: LiquidityDebitTransferV05{
+			MessageHeader: MessageHeader1{MessageID: msgID, CreationDateTime: &now},
+			LiquidityDebitTransfer: LiquidityDebitTransfer2{
+				LiquidityTransferID: &PaymentIdentification8{EndToEndID: endToEndID},
+				CreditorAccount:     &creditorAccount,
+				TransferredAmount:   Amount2Choice{AmountWithCurrency: &amount},
+				DebtorAccount:       &debtorAccount,
+			},
+		},
+	}, nil
+}
+
+// NewReceipt builds a camt.025.001.05 receipt reporting statusCode for the original message.
+func NewReceipt(msgID, originalMsgID, originalMsgNameID, statusCode string, opts ...IDOption) (*Camt02500105Document, error) {
+	if err := newIDOptions(opts).assign(&msgID); err != nil {
+		return nil, err
+	}
+	now := NewISODateTime(time.Now().UTC())
+	return &Camt02500105Document{
+		Receipt: ReceiptV05{
+			MessageHeader: MessageHeader9{MessageID: msgID, CreationDateTime: &now},
+			ReceiptDetails: []Receipt4{
+				{
+					OriginalMessageID: OriginalMessageAndIssuer1{MessageID: originalMsgID, MessageNameID: &originalMsgNameID},
+					RequestHandling:   []RequestHandling2{{StatusCode: statusCode, StatusDateTime: &now}},
+				},
+			},
+		},
+	}, nil
+}
+
+// Validate performs validation for MessageHeader1
+func (m *MessageHeader1) Validate() error {
+	var errs ValidationErrors
+
+	if err := validateRequired(m.MessageID, "MsgId"); err != nil {
+		errs = append(errs, err.(ValidationError))
+	} else if err := validateStringLength(m.MessageID, 1, 35, "MsgId"); err != nil {
+		errs = append(errs, err.(ValidationError))
+	}
+
+	if errs.HasErrors() {
+		return errs
+	}
+	return nil
+}
+
+// Validate performs validation for Amount2Choice
+func (a *Amount2Choice) Validate() error {
+	return a.validateContext(context.Background())
+}
+
+func (a *Amount2Choice) validateContext(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	var errs ValidationErrors
+
+	// Exactly one choice must be present
+	choiceCount := 0
+	if a.AmountWithoutCurrency != nil {
+		choiceCount++
+		if *a.AmountWithoutCurrency < 0 {
+			errs = append(errs, ValidationError{Field: "AmtWthtCcy", Message: "amount cannot be negative"})
+		}
+	}
+	if a.AmountWithCurrency != nil {
+		choiceCount++
+		if err := a.AmountWithCurrency.Validate(); err != nil {
+			errs = append(errs, nestErrors("AmtWthCcy", err)...)
+		}
+	}
+
+	if choiceCount != 1 {
+		errs = append(errs, newValidationError("Choice", RuleChoice, "CHOICE"))
+	}
+
+	if errs.HasErrors() {
+		return errs
+	}
+	return nil
+}
+
+// validateLiquidityTransfer checks the fields shared by camt.050 and camt.051 transfers
+func validateLiquidityTransfer(amount *Amount2Choice, debtorAccount, creditorAccount *CashAccount38) ValidationErrors {
+	var errs ValidationErrors
+
+	if err := amount.Validate(); err != nil {
+		errs = append(errs, nestErrors("TrfdAmt", err)...)
+	}
+
+	if debtorAccount != nil {
+		if err := debtorAccount.Validate(); err != nil {
+			errs = append(errs, nestErrors("DbtrAcct", err)...)
+		}
+	}
+
+	if creditorAccount != nil {
+		if err := creditorAccount.Validate(); err != nil {
+			errs = append(errs, nestErrors("CdtrAcct", err)...)
+		}
+	}
+
+	return errs
+}
+
+// Validate performs comprehensive validation according to camt.050.001.05 XSD
+func (d *Camt05000105Document) Validate() error {
+	return d.validateContext(context.Background())
+}
+
+func (d *Camt05000105Document) validateContext(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	var errs ValidationErrors
+
+	msg := &d.LiquidityCreditTransfer
+	if err := msg.MessageHeader.Validate(); err != nil {
+		errs = append(errs, nestErrors("MsgHdr", err)...)
+	}
+
+	transfer := &msg.LiquidityCreditTransfer
+	errs = append(errs, validateLiquidityTransfer(&transfer.TransferredAmount, transfer.DebtorAccount, transfer.CreditorAccount).within("LqdtyCdtTrf")...)
+
+	errs = errs.merge(validateStructure(&d.LiquidityCreditTransfer))
+
+	if errs.HasErrors() {
+		return errs.within("LqdtyCdtTrf")
+	}
+	return nil
+}
+
+// Validate performs comprehensive validation according to camt.051.001.05 XSD
+func (d *Camt05100105Document) Validate() error {
+	return d.validateContext(context.Background())
+}
+
+func (d *Camt05100105Document) validateContext(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	var errs ValidationErrors
+
+	msg := &d.LiquidityDebitTransfer
+	if err := msg.MessageHeader.Validate(); err != nil {
+		errs = append(errs, nestErrors("MsgHdr", err)...)
+	}
+
+	transfer := &msg.LiquidityDebitTransfer
+	errs = append(errs, validateLiquidityTransfer(&transfer.TransferredAmount, transfer.DebtorAccount, transfer.CreditorAccount).within("LqdtyDbtTrf")...)
+
+	errs = errs.merge(validateStructure(&d.LiquidityDebitTransfer))
+
+	if errs.HasErrors() {
+		return errs.within("LqdtyDbtTrf")
+	}
+	return nil
+}
+
+// Validate performs comprehensive validation according to camt.025.001.05 XSD
+func (d *Camt02500105Document) Validate() error {
+	var errs ValidationErrors
+
+	hdr := d.Receipt.MessageHeader
+	if err := validateRequired(hdr.MessageID, "MsgHdr.MsgId"); err != nil {
+		errs = append(errs, err.(ValidationError))
+	} else if err := validateStringLength(hdr.MessageID, 1, 35, "MsgHdr.MsgId"); err != nil {
+		errs = append(errs, err.(ValidationError))
+	}
+
+	if len(d.Receipt.ReceiptDetails) == 0 {
+		errs = append(errs, ValidationError{Field: "RctDtls", Message: "at least one receipt is required"})
+	}
+	for i, rct := range d.Receipt.ReceiptDetails {
+		if err := validateRequired(rct.OriginalMessageID.MessageID, fmt.Sprintf("RctDtls[%d].OrgnlMsgId.MsgId", i+1)); err != nil {
+			errs = append(errs, err.(ValidationError))
+		}
+		for j, hdlg := range rct.RequestHandling {
+			if err := validateRequired(hdlg.StatusCode, fmt.Sprintf("RctDtls[%d].ReqHdlg[%d].StsCd", i+1, j+1)); err != nil {
+				errs = append(errs, err.(ValidationError))
+			}
+		}
+	}
+
+	errs = errs.merge(validateStructure(&d.Receipt))
+
+	if errs.HasErrors() {
+		return errs.within("Rct")
+	}
+	return nil
+}
+
+// camt.046.001.05 / camt.047.001.06 / camt.048.001.05 / camt.049.001.05 types
+
+// GetReservationV05 - camt.046.001.05
+type GetReservationV05 struct {
+	MessageHeader              MessageHeader9       `xml:"MsgHdr"`
+	ReservationQueryDefinition *ReservationQuery2   `xml:"RsvatnQryDef,omitempty"`
+	SupplementaryData          []SupplementaryData1 `xml:"SplmtryData,omitempty"`
+}
+
+// ReturnReservationV06 - camt.047.001.06
+type ReturnReservationV06 struct {
+	MessageHeader     MessageHeader7            `xml:"MsgHdr"`
+	ReportOrError     ReservationOrError7Choice `xml:"RptOrErr"`
+	SupplementaryData []SupplementaryData1      `xml:"SplmtryData,omitempty"`
+}
+
+// ModifyReservationV05 - camt.048.001.05
+type ModifyReservationV05 struct {
+	MessageHeader          MessageHeader1                     `xml:"MsgHdr"`
+	ReservationID          CurrentOrDefaultReservation2Choice `xml:"RsvatnId"`
+	NewReservationValueSet Reservation2                       `xml:"NewRsvatnValSet"`
+	SupplementaryData      []SupplementaryData1               `xml:"SplmtryData,omitempty"`
+}
+
+// DeleteReservationV05 - camt.049.001.05
+type DeleteReservationV05 struct {
+	MessageHeader      MessageHeader1                     `xml:"MsgHdr"`
+	CurrentReservation CurrentOrDefaultReservation2Choice `xml:"CurRsvatn"`
+	SupplementaryData  []SupplementaryData1               `xml:"SplmtryData,omitempty"`
+}
+
+// ReservationQuery2 - Query definition for camt.046
+type ReservationQuery2 struct {
+	QueryType *string                     `xml:"QryTp,omitempty"` // QueryType2Code: ALLL, CHNG, MODF
+	Criteria  *ReservationCriteria4Choice `xml:"RsvatnCrit,omitempty"`
+}
+
+// ReservationCriteria4Choice - Either a stored query name or new search criteria
+type ReservationCriteria4Choice struct {
+	QueryName   *string               `xml:"QryNm,omitempty"` // Max35Text
+	NewCriteria *ReservationCriteria5 `xml:"NewCrit,omitempty"`
+}
+
+// ReservationCriteria5 - Search criteria for reservations
+type ReservationCriteria5 struct {
+	NewQueryName   *string                      `xml:"NewQryNm,omitempty"` // Max35Text
+	SearchCriteria []ReservationIdentification2 `xml:"SchCrit,omitempty"`
+}
+
+// MarketInfrastructureIdentification1Choice - Identification of a market infrastructure
+type MarketInfrastructureIdentification1Choice struct {
+	Code        *string `xml:"Cd,omitempty"`    // ExternalMarketInfrastructure1Code
+	Proprietary *string `xml:"Prtry,omitempty"` // Max35Text
+}
+
+// SystemIdentification2Choice - Identification of the settlement system
+type SystemIdentification2Choice struct {
+	MarketInfrastructureID *MarketInfrastructureIdentification1Choice `xml:"MktInfrstrctrId,omitempty"`
+	Country                *string                                    `xml:"Ctry,omitempty"` // CountryCode
+}
+
+// ReservationType2Choice - Nature of the reservation
+type ReservationType2Choice struct {
+	Code        *string `xml:"Cd,omitempty"`    // ExternalReservationType1Code
+	Proprietary *string `xml:"Prtry,omitempty"` // Max35Text
+}
+
+// ReservationIdentification2 - Identifies a current reservation on a settlement account
+type ReservationIdentification2 struct {
+	ReservationID *string                                       `xml:"RsvatnId,omitempty"` // Max35Text
+	SystemID      *SystemIdentification2Choice                  `xml:"SysId,omitempty"`
+	Type          ReservationType2Choice                        `xml:"Tp"` // Required
+	AccountOwner  *BranchAndFinancialInstitutionIdentification6 `xml:"AcctOwnr,omitempty"`
+	AccountID     *AccountIdentification4                       `xml:"AcctId,omitempty"`
+}
+
+// CurrentOrDefaultReservation2Choice - Either the current or the default reservation
+type CurrentOrDefaultReservation2Choice struct {
+	Current *ReservationIdentification2 `xml:"Cur,omitempty"`
+	Default *ReservationIdentification2 `xml:"Dflt,omitempty"`
+}
+
+// ReservationStatus1Choice - Status of a reservation
+type ReservationStatus1Choice struct {
+	Code        *string `xml:"Cd,omitempty"`    // ReservationStatus1Code: ENAB, DISA, DELD, REQD
+	Proprietary *string `xml:"Prtry,omitempty"` // Max35Text
+}
+
+// Reservation2 - New values for a reservation
+type Reservation2 struct {
+	StartDateTime *DateAndDateTime2 `xml:"StartDtTm,omitempty"`
+	Amount        Amount2Choice     `xml:"Amt"` // Required
+}
+
+// Reservation3 - Reported reservation values
+type Reservation3 struct {
+	Amount        Amount2Choice             `xml:"Amt"`
+	Status        *ReservationStatus1Choice `xml:"Sts,omitempty"`
+	StartDateTime *DateAndDateTime2         `xml:"StartDtTm,omitempty"`
+}
+
+// ReservationReport6 - Reservation found for a search criterion
+type ReservationReport6 struct {
+	ReservationID ReservationIdentification2 `xml:"RsvatnId"`
+	Reservation   *Reservation3              `xml:"Rsvatn,omitempty"`
+	BusinessError []ErrorHandling5           `xml:"BizErr,omitempty"`
+}
+
+// ReservationOrError7Choice - Business report or operational error for camt.047
+type ReservationOrError7Choice struct {
+	BusinessReport   *ReservationReport7 `xml:"BizRpt,omitempty"`
+	OperationalError []ErrorHandling5    `xml:"OprlErr,omitempty"`
+}
+
+// ReservationReport7 - Current and default reservations reported in camt.047
+type ReservationReport7 struct {
+	CurrentReservation []ReservationReport6 `xml:"CurRsvatn,omitempty"`
+	DefaultReservation []ReservationReport6 `xml:"DfltRsvatn,omitempty"`
+}
+
+// NewReservationIdentification identifies the reservation of typeCode (e.g. CARE, UPAR, HPAR)
+// held on the given settlement account.
+func NewReservationIdentification(typeCode string, account CashAccount38) ReservationIdentification2 {
+	return ReservationIdentification2{
+		Type:      ReservationType2Choice{Code: &typeCode},
+		AccountID: &account.ID,
+	}
+}
+
+// NewGetReservation builds a camt.046.001.05 query for the given reservations. With no reservations
+// the query returns every reservation visible to the sender.
+func NewGetReservation(msgID string, reservations []ReservationIdentification2, opts ...IDOption) (*Camt04600105Document, error) {
+	if err := newIDOptions(opts).assign(&msgID); err != nil {
+		return nil, err
+	}
+	now := NewISODateTime(time.Now().UTC())
+	doc := &Camt04600105Document{
+		GetReservation: GetReservationV05{
+			MessageHeader: MessageHeader9{MessageID: msgID, CreationDateTime: &now},
+		},
+	}
+	if len(reservations) > 0 {
+		doc.GetReservation.ReservationQueryDefinition = &ReservationQuery2{
+			Criteria: &ReservationCriteria4Choice{
+				NewCriteria: &ReservationCriteria5{SearchCriteria: reservations},
+			},
+		}
+	}
+	return doc, nil
+}
+
+// NewModifyReservation builds a camt.048.001.05 message setting the current reservation to amount.
+func NewModifyReservation(msgID string, reservation ReservationIdentification2, amount ActiveCurrencyAndAmount, opts ...IDOption) (*Camt04800105Document, error) {
+	if err := newIDOptions(opts).assign(&msgID); err != nil {
+		return nil, err
+	}
+	now := NewISODateTime(time.Now().UTC())
+	return &Camt04800105Document{
+		ModifyReservation: ModifyReservationV05{
+			MessageHeader:          MessageHeader1{MessageID: msgID, CreationDateTime: &now},
+			ReservationID:          CurrentOrDefaultReservation2Choice{Current: &reservation},
+			NewReservationValueSet: Reservation2{Amount: Amount2Choice{AmountWithCurrency: &amount}},
+		},
+	}, nil
+}
+
+// NewDeleteReservation builds a camt.049.001.05 message deleting the current reservation.
+func NewDeleteReservation(msgID string, reservation ReservationIdentification2, opts ...IDOption) (*Camt04900105Document, error) {
+	if err := newIDOptions(opts).assign(&msgID); err != nil {
+		return nil, err
+	}
+	now := NewISODateTime(time.Now().UTC())
+	return &Camt04900105Document{
+		DeleteReservation: DeleteReservationV05{
+			MessageHeader:      MessageHeader1{MessageID: msgID, CreationDateTime: &now},
+			CurrentReservation: CurrentOrDefaultReservation2Choice{Current: &reservation},
+		},
+	}, nil
+}
+
+// Validate performs validation for ReservationIdentification2
+func (r *ReservationIdentification2) Validate() error {
+	return r.validateContext(context.Background())
+}
+
+func (r *ReservationIdentification2) validateContext(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	var errs ValidationErrors
+
+	if r.ReservationID != nil {
+		if err := validateStringLength(*r.ReservationID, 1, 35, "RsvatnId"); err != nil {
+			errs = append(errs, err.(ValidationError))
+		}
+	}
+
+	// Reservation type is a choice between code and proprietary
+	if (r.Type.Code == nil) == (r.Type.Proprietary == nil) {
+		errs = append(errs, ValidationError{Field: "Tp", Message: "exactly one choice must be present"})
+	} else if r.Type.Code != nil {
+		if err := validateEnumeration(*r.Type.Code, []string{"CARE", "UPAR", "NSSR", "HPAR", "THRE", "BLKD"}, "Tp.Cd"); err != nil {
+			errs = append(errs, err.(ValidationError))
+		}
+	}
+
+	if r.SystemID != nil && r.SystemID.Country != nil {
+		if err := validateCountryCode(*r.SystemID.Country, "SysId.Ctry"); err != nil {
+			errs = append(errs, err.(ValidationError))
+		}
+	}
+
+	if r.AccountOwner != nil {
+		if err := r.AccountOwner.validateContext(ctx); err != nil {
+			errs = append(errs, nestErrors("AcctOwnr", err)...)
+		}
+	}
+
+	if r.AccountID != nil {
+		if err := r.AccountID.validateContext(ctx); err != nil {
+			errs = append(errs, nestErrors("AcctId", err)...)
+		}
+	}
+
+	if errs.HasErrors() {
+		return errs
+	}
+	return nil
+}
+
+// Validate performs validation for CurrentOrDefaultReservation2Choice
+func (c *CurrentOrDefaultReservation2Choice) Validate() error {
+	return c.validateContext(context.Background())
+}
+
+func (c *CurrentOrDefaultReservation2Choice) validateContext(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	var errs ValidationErrors
+
+	// Exactly one choice must be present
+	choiceCount := 0
+	if c.Current != nil {
+		choiceCount++
+		if err := c.Current.validateContext(ctx); err != nil {
+			errs = append(errs, nestErrors("Cur", err)...)
+		}
+	}
+	if c.Default != nil {
+		choiceCount++
+		if err := c.Default.validateContext(ctx); err != nil {
+			errs = append(errs, nestErrors("Dflt", err)...)
+		}
+	}
+
+	if choiceCount != 1 {
+		errs = append(errs, newValidationError("Choice", RuleChoice, "CHOICE"))
+	}
+
+	if errs.HasErrors() {
+		return errs
+	}
+	return nil
+}
+
+// Validate performs comprehensive validation according to camt.046.001.05 XSD
+func (d *Camt04600105Document) Validate() error {
+	return d.validateContext(context.Background())
+}
+
+func (d *Camt04600105Document) validateContext(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	var errs ValidationErrors
+
+	msg := &d.GetReservation
+	if err := validateRequired(msg.MessageHeader.MessageID, "MsgHdr.MsgId"); err != nil {
+		errs = append(errs, err.(ValidationError))
+	} else if err := validateStringLength(msg.MessageHeader.MessageID, 1, 35, "MsgHdr.MsgId"); err != nil {
+		errs = append(errs, err.(ValidationError))
+	}
+
+	if def := msg.ReservationQueryDefinition; def != nil {
+		if def.QueryType != nil {
+			if err := validateEnumeration(*def.QueryType, []string{"ALLL", "CHNG", "MODF"}, "RsvatnQryDef.QryTp"); err != nil {
+				errs = append(errs, err.(ValidationError))
+			}
+		}
+		if def.Criteria != nil && def.Criteria.NewCriteria != nil {
+			for i, crit := range def.Criteria.NewCriteria.SearchCriteria {
+				if err := crit.validateContext(ctx); err != nil {
+					errs = append(errs, nestErrors(fmt.Sprintf("RsvatnQryDef.RsvatnCrit.NewCrit.SchCrit[%d]", i+1), err)...)
+				}
+			}
+		}
+	}
+
+	errs = errs.merge(validateStructure(&d.GetReservation))
+
+	if errs.HasErrors() {
+		return errs.within("GetRsvatn")
+	}
+	return nil
+}
+
+// Validate performs comprehensive validation according to camt.047.001.06 XSD
+func (d *Camt04700106Document) Validate() error {
+	return d.validateContext(context.Background())
+}
+
+func (d *Camt04700106Document) validateContext(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	var errs ValidationErrors
+
+	msg := &d.ReturnReservation
+	if err := validateRequired(msg.MessageHeader.MessageID, "MsgHdr.MsgId"); err != nil {
+		errs = append(errs, err.(ValidationError))
+	} else if err := validateStringLength(msg.MessageHeader.MessageID, 1, 35, "MsgHdr.MsgId"); err != nil {
+		errs = append(errs, err.(ValidationError))
+	}
+
+	// Report or error is a choice
+	hasReport := msg.ReportOrError.BusinessReport != nil
+	hasError := len(msg.ReportOrError.OperationalError) > 0
+	if hasReport == hasError {
+		errs = append(errs, ValidationError{Field: "RptOrErr", Message: "exactly one choice must be present"})
+	}
+
+	if hasReport {
+		rpt := msg.ReportOrError.BusinessReport
+		for i, r := range rpt.CurrentReservation {
+			if err := r.ReservationID.validateContext(ctx); err != nil {
+				errs = append(errs, nestErrors(fmt.Sprintf("RptOrErr.BizRpt.CurRsvatn[%d].RsvatnId", i+1), err)...)
+			}
+		}
+		for i, r := range rpt.DefaultReservation {
+			if err := r.ReservationID.validateContext(ctx); err != nil {
+				errs = append(errs, nestErrors(fmt.Sprintf("RptOrErr.BizRpt.DfltRsvatn[%d].RsvatnId", i+1), err)...)
+			}
+		}
+	}
+
+	errs = errs.merge(validateStructure(&d.ReturnReservation))
+
+	if errs.HasErrors() {
+		return errs.within("RtrRsvatn")
+	}
+	return nil
+}
+
+// Validate performs comprehensive validation according to camt.048.001.05 XSD
+func (d *Camt04800105Document) Validate() error {
+	return d.validateContext(context.Background())
+}
+
+func (d *Camt04800105Document) validateContext(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	var errs ValidationErrors
+
+	msg := &d.ModifyReservation
+	if err := msg.MessageHeader.Validate(); err != nil {
+		errs = append(errs, nestErrors("MsgHdr", err)...)
+	}
+
+	if err := msg.ReservationID.validateContext(ctx); err != nil {
+		errs = append(errs, nestErrors("RsvatnId", err)...)
+	}
+
+	if err := msg.NewReservationValueSet.Amount.validateContext(ctx); err != nil {
+		errs = append(errs, nestErrors("NewRsvatnValSet.Amt", err)...)
+	}
+
+	errs = errs.merge(validateStructure(&d.ModifyReservation))
+
+	if errs.HasErrors() {
+		return errs.within("ModfyRsvatn")
+	}
+	return nil
+}
+
+// Validate performs comprehensive validation according to camt.049.001.05 XSD
+func (d *Camt04900105Document) Validate() error {
+	return d.validateContext(context.Background())
+}
+
+func (d *Camt04900105Document) validateContext(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	var errs ValidationErrors
+
+	msg := &d.DeleteReservation
+	if err := msg.MessageHeader.Validate(); err != nil {
+		errs = append(errs, nestErrors("MsgHdr", err)...)
+	}
+
+	if err := msg.CurrentReservation.validateContext(ctx); err != nil {
+		errs = append(errs, nestErrors("CurRsvatn", err)...)
+	}
+
+	errs = errs.merge(validateStructure(&d.DeleteReservation))
+
+	if errs.HasErrors() {
+		return errs.within("DelRsvatn")
+	}
+	return nil
+}
+
+// camt.087.001.08 types
+
+// RequestToModifyPaymentV08 - camt.087.001.08
+type RequestToModifyPaymentV08 struct {
+	Assignment        CaseAssignment5        `xml:"Assgnmt"`
+	Case              *Case5                 `xml:"Case,omitempty"`
+	Underlying        UnderlyingTransaction5 `xml:"Undrlyg"`
+	Modification      RequestedModification8 `xml:"Mod"`
+	SupplementaryData []SupplementaryData1   `xml:"SplmtryData,omitempty"`
+}
+
+// RequestedModification8 - Modification entries requested on the underlying payment from camt.087.001.08 XSD.
+// Only the elements that must change are populated; absent elements are left as in the original instruction.
+type RequestedModification8 struct {
+	InstructionID             *string                                       `xml:"InstrId,omitempty"`    // Max35Text
+	EndToEndID                *string                                       `xml:"EndToEndId,omitempty"` // Max35Text
+	TransactionID             *string                                       `xml:"TxId,omitempty"`       // Max35Text
+	ValueDate                 *ISODate                                      `xml:"ValDt,omitempty"`      // ISODate
+	PaymentTypeInfo           *PaymentTypeInfo28                            `xml:"PmtTpInf,omitempty"`
+	RequestedExecutionDate    *DateAndDateTime2                             `xml:"ReqdExctnDt,omitempty"`
+	RequestedCollectionDate   *ISODate                                      `xml:"ReqdColltnDt,omitempty"`  // ISODate
+	InterbankSettlementDate   *ISODate                                      `xml:"IntrBkSttlmDt,omitempty"` // ISODate
+	Amount                    *AmountType4                                  `xml:"Amt,omitempty"`
+	InterbankSettlementAmount *ActiveOrHistoricCurrencyAndAmount            `xml:"IntrBkSttlmAmt,omitempty"`
+	ChargeBearer              *ChargeBearerType1Code                        `xml:"ChrgBr,omitempty"`
+	UltimateDebtor            *Party40                                      `xml:"UltmtDbtr,omitempty"`
+	Debtor                    *Party40                                      `xml:"Dbtr,omitempty"`
+	DebtorAccount             *CashAccount38                                `xml:"DbtrAcct,omitempty"`
+	DebtorAgent               *BranchAndFinancialInstitutionIdentification6 `xml:"DbtrAgt,omitempty"`
+	DebtorAgentAccount        *CashAccount38                                `xml:"DbtrAgtAcct,omitempty"`
+	CreditorAgent             *BranchAndFinancialInstitutionIdentification6 `xml:"CdtrAgt,omitempty"`
+	CreditorAgentAccount      *CashAccount38                                `xml:"CdtrAgtAcct,omitempty"`
+	Creditor                  *Party40                                      `xml:"Cdtr,omitempty"`
+	CreditorAccount           *CashAccount38                                `xml:"CdtrAcct,omitempty"`
+	UltimateCreditor          *Party40                                      `xml:"UltmtCdtr,omitempty"`
+	Purpose                   *Purpose2Choice                               `xml:"Purp,omitempty"`
+	InstructionForDebtorAgent *string                                       `xml:"InstrForDbtrAgt,omitempty"` // Max140Text
+	RemittanceInfo            *RemittanceInfo16                             `xml:"RmtInf,omitempty"`
+}
+
+// NewRequestToModifyPayment builds a camt.087.001.08 message for the underlying interbank transaction.
+// The case identification doubles as the assignment identification, which is the common practice when
+// the requesting agent opens the case itself.
+func NewRequestToModifyPayment(caseID string, assigner, assignee Party40, underlying UnderlyingPaymentTransaction4, modification RequestedModification8) *Camt08700108Document {
+	return &Camt08700108Document{
+		RequestToModifyPayment: RequestToModifyPaymentV08{
+			Assignment: CaseAssignment5{
+				ID:               caseID,
+				Assigner:         assigner,
+				Assignee:         assignee,
+				CreationDateTime: NewISODateTime(time.Now().UTC()),
+			},
+			Case:         &Case5{ID: caseID, Creator: assigner},
+			Underlying:   UnderlyingTransaction5{InterbankTransaction: &underlying},
+			Modification: modification,
+		},
+	}
+}
+
+// Validate performs validation for Party40
+func (p *Party40) Validate() error {
+	return p.validateContext(context.Background())
+}
+
+func (p *Party40) validateContext(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	var errs ValidationErrors
+
+	// Exactly one choice must be present
+	choiceCount := 0
+	if p.Party != nil {
+		choiceCount++
+		if err := p.Party.validateContext(ctx); err != nil {
+			errs = append(errs, nestErrors("Pty", err)...)
+		}
+	}
+	if p.Agent != nil {
+		choiceCount++
+		if err := p.Agent.validateContext(ctx); err != nil {
+			errs = append(errs, nestErrors("Agt", err)...)
+		}
+	}
+
+	if choiceCount != 1 {
+		errs = append(errs, newValidationError("Choice", RuleChoice, "CHOICE"))
+	}
+
+	if errs.HasErrors() {
+		return errs
+	}
+	return nil
+}
+
+// Validate performs validation for CaseAssignment5
+func (c *CaseAssignment5) Validate() error {
+	return c.validateContext(context.Background())
+}
+
+func (c *CaseAssignment5) validateContext(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	var errs ValidationErrors
+
+	if err := validateRequired(c.ID, "Id"); err != nil {
+		errs = append(errs, err.(ValidationError))
+	} else if err := validateStringLength(c.ID, 1, 35, "Id"); err != nil {
+		errs = append(errs, err.(ValidationError))
+	}
+
+	if err := c.Assigner.validateContext(ctx); err != nil {
+		errs = append(errs, nestErrors("Assgnr", err)...)
+	}
+
+	if err := c.Assignee.validateContext(ctx); err != nil {
+		errs = append(errs, nestErrors("Assgne", err)...)
+	}
+
+	if c.CreationDateTime.IsZero() {
+		errs = append(errs, ValidationError{Field: "CreDtTm", Message: "field is required"})
+	}
+
+	if errs.HasErrors() {
+		return errs
+	}
+	return nil
+}
+
+// Validate performs validation for Case5
+func (c *Case5) Validate() error {
+	return c.validateContext(context.Background())
+}
+
+func (c *Case5) validateContext(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	var errs ValidationErrors
+
+	if err := validateRequired(c.ID, "Id"); err != nil {
+		errs = append(errs, err.(ValidationError))
+	} else if err := validateStringLength(c.ID, 1, 35, "Id"); err != nil {
+		errs = append(errs, err.(ValidationError))
+	}
+
+	if err := c.Creator.validateContext(ctx); err != nil {
+		errs = append(errs, nestErrors("Cretr", err)...)
+	}
+
+	if errs.HasErrors() {
+		return errs
+	}
+	return nil
+}
+
+// Validate performs comprehensive validation according to camt.087.001.08 XSD
+func (d *Camt08700108Document) Validate() error {
+	return d.validateContext(context.Background())
+}
+
+func (d *Camt08700108Document) validateContext(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	var errs ValidationErrors
+
+	msg := &d.RequestToModifyPayment
+	if err := msg.Assignment.validateContext(ctx); err != nil {
+		errs = append(errs, nestErrors("Assgnmt", err)...)
+	}
+
+	if msg.Case != nil {
+		if err := msg.Case.validateContext(ctx); err != nil {
+			errs = append(errs, nestErrors("Case", err)...)
+		}
+	}
+
+	// Underlying is a choice
+	choiceCount := 0
+	if msg.Underlying.PaymentInstruction != nil {
+		choiceCount++
+	}
+	if msg.Underlying.InterbankTransaction != nil {
+		choiceCount++
+	}
+	if msg.Underlying.StatementEntry != nil {
+		choiceCount++
+	}
+	if choiceCount != 1 {
+		errs = append(errs, ValidationError{Field: "Undrlyg", Message: "exactly one choice must be present"})
+	}
+
+	mod := &msg.Modification
+	if mod.InstructionID != nil {
+		if err := validateStringLength(*mod.InstructionID, 1, 35, "Mod.InstrId"); err != nil {
+			errs = append(errs, err.(ValidationError))
+		}
+	}
+	if mod.EndToEndID != nil {
+		if err := validateStringLength(*mod.EndToEndID, 1, 35, "Mod.EndToEndId"); err != nil {
+			errs = append(errs, err.(ValidationError))
+		}
+	}
+	if mod.InterbankSettlementAmount != nil {
+		if err := validateCurrency(mod.InterbankSettlementAmount.Currency, "Mod.IntrBkSttlmAmt.@Ccy"); err != nil {
+			errs = append(errs, err.(ValidationError))
+		}
+		if mod.InterbankSettlementAmount.Value < 0 {
+			errs = append(errs, ValidationError{Field: "Mod.IntrBkSttlmAmt", Message: "amount cannot be negative"})
+		}
+	}
+	if mod.ChargeBearer != nil {
+		if err := validateCode(*mod.ChargeBearer, string(*mod.ChargeBearer), "Mod.ChrgBr"); err != nil {
+			errs = append(errs, err.(ValidationError))
+		}
+	}
+	parties := []struct {
+		field string
+		party *Party40
+	}{
+		{"Mod.UltmtDbtr", mod.UltimateDebtor},
+		{"Mod.Dbtr", mod.Debtor},
+		{"Mod.Cdtr", mod.Creditor},
+		{"Mod.UltmtCdtr", mod.UltimateCreditor},
+	}
+	for _, p := range parties {
+		if p.party == nil {
+			continue
+		}
+		if err := p.party.validateContext(ctx); err != nil {
+			errs = append(errs, nestErrors(p.field, err)...)
+		}
+	}
+	if mod.DebtorAccount != nil {
+		if err := mod.DebtorAccount.validateContext(ctx); err != nil {
+			errs = append(errs, nestErrors("Mod.DbtrAcct", err)...)
+		}
+	}
+	if mod.CreditorAccount != nil {
+		if err := mod.CreditorAccount.validateContext(ctx); err != nil {
+			errs = append(errs, nestErrors("Mod.CdtrAcct", err)...)
+		}
+	}
+
+	errs = errs.merge(validateStructure(&d.RequestToModifyPayment))
+
+	if errs.HasErrors() {
+		return errs.within("ReqToModfyPmt")
+	}
+	return nil
+}
+
+// camt.027.001.07 types
+
+// ClaimNonReceiptV07 - camt.027.001.07
+type ClaimNonReceiptV07 struct {
+	Assignment             CaseAssignment5          `xml:"Assgnmt"`
+	Case                   *Case5                   `xml:"Case,omitempty"`
+	Underlying             UnderlyingTransaction5   `xml:"Undrlyg"`
+	CoverDetails           *MissingCover4           `xml:"CoverDtls,omitempty"`
+	InstructionForAssignee *InstructionForAssignee1 `xml:"InstrForAssgne,omitempty"`
+	SupplementaryData      []SupplementaryData1     `xml:"SplmtryData,omitempty"`
+}
+
+// MissingCover4 - Cover information for a claim non receipt
+type MissingCover4 struct {
+	MissingCoverIndicator *bool                   `xml:"MssngCoverInd,omitempty"` // YesNoIndicator
+	CoverCorrection       *SettlementInstruction7 `xml:"CoverCrrctn,omitempty"`
+}
+
+// InstructionForAssignee1 - Further instruction for the assignee of a case
+type InstructionForAssignee1 struct {
+	Code            *string `xml:"Cd,omitempty"`       // ExternalAgentInstruction1Code
+	InstructionInfo *string `xml:"InstrInf,omitempty"` // Max140Text
+}
+
+// Claim non receipt confirmation codes used in camt.029 Sts/Conf
+const (
+	ClaimNonReceiptAccepted = "ACNR"
+	ClaimNonReceiptRejected = "RJNR"
+)
+
+// NewAdditionalPaymentInfoForClaim builds a camt.028.001.09 message answering claim with further
+// payment details. The assignment is returned to the claimant and the case and underlying are copied over.
+func NewAdditionalPaymentInfoForClaim(claim *Camt02700107Document, assignmentID string, info PaymentComplementaryInfo9) *Camt02800109Document {
+	in := &claim.ClaimNonReceipt
+	return &Camt02800109Document{
+		AdditionalPaymentInfo: AdditionalPaymentInfoV09{
+			Assignment: CaseAssignment5{
+				ID:               assignmentID,
+				Assigner:         in.Assignment.Assignee,
+				Assignee:         in.Assignment.Assigner,
+				CreationDateTime: NewISODateTime(time.Now().UTC()),
+			},
+			Case:       in.Case,
+			Underlying: in.Underlying,
+			Info:       info,
+		},
+	}
+}
+
+// NewClaimNonReceiptResolution builds a camt.029.001.09 message resolving claim. The confirmation code is
+// derived from resolution: ACNR when the claim is accepted, RJNR when it is rejected.
+func NewClaimNonReceiptResolution(claim *Camt02700107Document, assignmentID string, resolution ClaimNonReceipt2) *Camt02900109Document {
+	in := &claim.ClaimNonReceipt
+	conf := ClaimNonReceiptRejected
+	if resolution.Accepted != nil {
+		conf = ClaimNonReceiptAccepted
+	}
+	return &Camt02900109Document{
+		InvestigationResolution: ResolutionOfInvestigationV09{
+			Assignment: CaseAssignment5{
+				ID:               assignmentID,
+				Assigner:         in.Assignment.Assignee,
+				Assignee:         in.Assignment.Assigner,
+				CreationDateTime: NewISODateTime(time.Now().UTC()),
+			},
+			ResolvedCase:           in.Case,
+			Status:                 InvestigationStatus5{Confirmation: &conf},
+			ClaimNonReceiptDetails: &resolution,
+		},
+	}
+}
+
+// Validate performs validation according to the camt.035.001.05 XSD
+func (d *Camt03500105Document) Validate() error {
+	return d.validateContext(context.Background())
+}
+
+func (d *Camt03500105Document) validateContext(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	var errs ValidationErrors
+
+	msg := &d.ProprietaryFormatInvestigation
+	if err := msg.Assignment.validateContext(ctx); err != nil {
+		errs = append(errs, nestErrors("Assgnmt", err)...)
+	}
+
+	if msg.Case != nil {
+		if err := msg.Case.validateContext(ctx); err != nil {
+			errs = append(errs, nestErrors("Case", err)...)
+		}
+	}
+
+	if err := validateRequired(msg.ProprietaryData.Type, "PrtryData.Tp"); err != nil {
+		errs = append(errs, err.(ValidationError))
+	} else if err := validateStringLength(msg.ProprietaryData.Type, 1, 35, "PrtryData.Tp"); err != nil {
+		errs = append(errs, err.(ValidationError))
+	}
+
+	errs = errs.merge(validateStructure(d))
+
+	if errs.HasErrors() {
+		return errs
+	}
+	return nil
+}
+
+// Validate performs comprehensive validation according to camt.027.001.07 XSD
+func (d *Camt02700107Document) Validate() error {
+	return d.validateContext(context.Background())
+}
+
+func (d *Camt02700107Document) validateContext(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	var errs ValidationErrors
+
+	msg := &d.ClaimNonReceipt
+	if err := msg.Assignment.validateContext(ctx); err != nil {
+		errs = append(errs, nestErrors("Assgnmt", err)...)
+	}
+
+	if msg.Case != nil {
+		if err := msg.Case.validateContext(ctx); err != nil {
+			errs = append(errs, nestErrors("Case", err)...)
+		}
+	}
+
+	// Underlying is a choice
+	choiceCount := 0
+	if msg.Underlying.PaymentInstruction != nil {
+		choiceCount++
+	}
+	if msg.Underlying.InterbankTransaction != nil {
+		choiceCount++
+	}
+	if msg.Underlying.StatementEntry != nil {
+		choiceCount++
+	}
+	if choiceCount != 1 {
+		errs = append(errs, ValidationError{Field: "Undrlyg", Message: "exactly one choice must be present"})
+	}
+
+	if instr := msg.InstructionForAssignee; instr != nil && instr.InstructionInfo != nil {
+		if err := validateStringLength(*instr.InstructionInfo, 1, 140, "InstrForAssgne.InstrInf"); err != nil {
+			errs = append(errs, err.(ValidationError))
+		}
+	}
+
+	errs = errs.merge(validateStructure(&d.ClaimNonReceipt))
+
+	if errs.HasErrors() {
+		return errs.within("ClmNonRct")
+	}
+	return nil
+}
+
+// camt.030.001.05 / camt.031.001.06 types
+
+// NotificationOfCaseAssignmentV05 - camt.030.001.05
+type NotificationOfCaseAssignmentV05 struct {
+	Header            ReportHeader6               `xml:"Hdr"`
+	Case              Case5                       `xml:"Case"`
+	Assignment        CaseAssignment5             `xml:"Assgnmt"`
+	Notification      CaseForwardingNotification3 `xml:"Ntfctn"`
+	SupplementaryData []SupplementaryData1        `xml:"SplmtryData,omitempty"`
+}
+
+// ReportHeader6 - Header of a case assignment notification
+type ReportHeader6 struct {
+	ID               string      `xml:"Id"`      // Max35Text - required
+	From             Party40     `xml:"Fr"`      // Required
+	To               Party40     `xml:"To"`      // Required
+	CreationDateTime ISODateTime `xml:"CreDtTm"` // ISODateTime - required
+}
+
+// CaseForwardingNotification3 - Justification for forwarding a case
+type CaseForwardingNotification3 struct {
+	Justification string `xml:"Justfn"` // CaseForwardingNotification3Code - required
+}
+
+// RejectInvestigationV06 - camt.031.001.06
+type RejectInvestigationV06 struct {
+	Assignment        CaseAssignment5                      `xml:"Assgnmt"`
+	Case              *Case5                               `xml:"Case,omitempty"`
+	Justification     InvestigationRejectionJustification1 `xml:"Justfn"`
+	SupplementaryData []SupplementaryData1                 `xml:"SplmtryData,omitempty"`
+}
+
+// InvestigationRejectionJustification1 - Reason for rejecting an investigation
+type InvestigationRejectionJustification1 struct {
+	RejectionReason string `xml:"RjctnRsn"` // InvestigationRejection1Code - required
+}
+
+// NewNotificationOfCaseAssignment builds a camt.030.001.05 message telling the assigner of the received
+// assignment that the case was forwarded under next, with justification as CaseForwardingNotification3Code.
+func NewNotificationOfCaseAssignment(notificationID string, received CaseAssignment5, caseInfo Case5, next CaseAssignment5, justification string) *Camt03000105Document {
+	return &Camt03000105Document{
+		NotificationOfCaseAssignment: NotificationOfCaseAssignmentV05{
+			Header: ReportHeader6{
+				ID:               notificationID,
+				From:             received.Assignee,
+				To:               received.Assigner,
+				CreationDateTime: NewISODateTime(time.Now().UTC()),
+			},
+			Case:         caseInfo,
+			Assignment:   next,
+			Notification: CaseForwardingNotification3{Justification: justification},
+		},
+	}
+}
+
+// NewRejectInvestigation builds a camt.031.001.06 message rejecting the received assignment with
+// reason as InvestigationRejection1Code.
+func NewRejectInvestigation(assignmentID string, received CaseAssignment5, caseInfo *Case5, reason string) *Camt03100106Document {
+	return &Camt03100106Document{
+		RejectInvestigation: RejectInvestigationV06{
+			Assignment: CaseAssignment5{
+				ID:               assignmentID,
+				Assigner:         received.Assignee,
+				Assignee:         received.Assigner,
+				CreationDateTime: NewISODateTime(time.Now().UTC()),
+			},
+			Case:          caseInfo,
+			Justification: InvestigationRejectionJustification1{RejectionReason: reason},
+		},
+	}
+}
+
+// Validate performs comprehensive validation according to camt.030.001.05 XSD
+func (d *Camt03000105Document) Validate() error {
+	return d.validateContext(context.Background())
+}
+
+func (d *Camt03000105Document) validateContext(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	var errs ValidationErrors
+
+	msg := &d.NotificationOfCaseAssignment
+	if err := validateRequired(msg.Header.ID, "Hdr.Id"); err != nil {
+		errs = append(errs, err.(ValidationError))
+	} else if err := validateStringLength(msg.Header.ID, 1, 35, "Hdr.Id"); err != nil {
+		errs = append(errs, err.(ValidationError))
+	}
+	if err := msg.Header.From.validateContext(ctx); err != nil {
+		errs = append(errs, nestErrors("Hdr.Fr", err)...)
+	}
+	if err := msg.Header.To.validateContext(ctx); err != nil {
+		errs = append(errs, nestErrors("Hdr.To", err)...)
+	}
+	if msg.Header.CreationDateTime.IsZero() {
+		errs = append(errs, ValidationError{Field: "Hdr.CreDtTm", Message: "field is required"})
+	}
+
+	if err := msg.Case.validateContext(ctx); err != nil {
+		errs = append(errs, nestErrors("Case", err)...)
+	}
+
+	if err := msg.Assignment.validateContext(ctx); err != nil {
+		errs = append(errs, nestErrors("Assgnmt", err)...)
+	}
+
+	if err := validateEnumeration(msg.Notification.Justification, []string{"FTHI", "CANC", "MODI", "DTAU", "SAIN", "MINE"}, "Ntfctn.Justfn"); err != nil {
+		errs = append(errs, err.(ValidationError))
+	}
+
+	errs = errs.merge(validateStructure(&d.NotificationOfCaseAssignment))
+
+	if errs.HasErrors() {
+		return errs.within("NtfctnOfCaseAssgnmt")
+	}
+	return nil
+}
+
+// Validate performs comprehensive validation according to camt.031.001.06 XSD
+func (d *Camt03100106Document) Validate() error {
+	return d.validateContext(context.Background())
+}
+
+func (d *Camt03100106Document) validateContext(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	var errs ValidationErrors
+
+	msg := &d.RejectInvestigation
+	if err := msg.Assignment.validateContext(ctx); err != nil {
+		errs = append(errs, nestErrors("Assgnmt", err)...)
+	}
+
+	if msg.Case != nil {
+		if err := msg.Case.validateContext(ctx); err != nil {
+			errs = append(errs, nestErrors("Case", err)...)
+		}
+	}
+
+	if err := validateEnumeration(msg.Justification.RejectionReason, []string{"NFOU", "NAUT", "UKNW", "PCOR", "WMSG", "RNCR", "MROI"}, "Justfn.RjctnRsn"); err != nil {
+		errs = append(errs, err.(ValidationError))
+	}
+
+	errs = errs.merge(validateStructure(&d.RejectInvestigation))
+
+	if errs.HasErrors() {
+		return errs.within("RjctInvstgtn")
+	}
+	return nil
+}
+
+// admi.009.001.02 / admi.010.001.01 types
+
+// StaticDataRequestV02 - admi.009.001.02
+type StaticDataRequestV02 struct {
+	MessageHeader      MessageHeader7       `xml:"MsgHdr"`
+	DataRequestDetails StaticDataRequest2   `xml:"DataReqDtls"`
+	SupplementaryData  []SupplementaryData1 `xml:"SplmtryData,omitempty"`
+}
+
+// StaticDataReportV01 - admi.010.001.01
+type StaticDataReportV01 struct {
+	MessageHeader     MessageHeader7       `xml:"MsgHdr"`
+	ReportDetails     []StaticDataReport1  `xml:"RptDtls,omitempty"`
+	OperationalError  []ErrorHandling5     `xml:"OprlErr,omitempty"`
+	SupplementaryData []SupplementaryData1 `xml:"SplmtryData,omitempty"`
+}
+
+// Static data types that can be requested in StaticDataSearchCriteria1
+const (
+	StaticDataParticipantProfile = "PRFL"
+	StaticDataAccount            = "ACCT"
+	StaticDataCalendar           = "CALD"
+	StaticDataReachability       = "RCHB"
+)
+
+// StaticDataRequest2 - Static data request details
+type StaticDataRequest2 struct {
+	SearchCriteria []StaticDataSearchCriteria1 `xml:"SchCrit"` // 1..unbounded
+}
+
+// StaticDataSearchCriteria1 - Typed search criteria for static data
+type StaticDataSearchCriteria1 struct {
+	DataType      string                                        `xml:"DataTp"` // PRFL, ACCT, CALD, RCHB - required
+	ParticipantID *BranchAndFinancialInstitutionIdentification6 `xml:"PtcptId,omitempty"`
+	AccountID     *AccountIdentification4                       `xml:"AcctId,omitempty"`
+	EffectiveDate *ISODate                                      `xml:"FctvDt,omitempty"` // ISODate
+}
+
+// StaticDataReport1 - Static data returned for one search criterion
+type StaticDataReport1 struct {
+	ParticipantID      *BranchAndFinancialInstitutionIdentification6 `xml:"PtcptId,omitempty"`
+	ParticipantProfile *ParticipantProfile1                          `xml:"PtcptPrfl,omitempty"`
+	Account            []CashAccount38                               `xml:"Acct,omitempty"`
+	BusinessError      []ErrorHandling5                              `xml:"BizErr,omitempty"`
+}
+
+// ParticipantProfile1 - Participant profile held by a market infrastructure
+type ParticipantProfile1 struct {
+	Name          *string  `xml:"Nm,omitempty"`     // Max140Text
+	Status        string   `xml:"Sts"`              // ENBL, DSBL, SUSP - required
+	Service       []string `xml:"Svc,omitempty"`    // Max35Text
+	EffectiveDate *ISODate `xml:"FctvDt,omitempty"` // ISODate
+}
+
+// NewStaticDataRequest builds an admi.009.001.02 request for the given search criteria
+func NewStaticDataRequest(msgID string, criteria []StaticDataSearchCriteria1, opts ...IDOption) (*Admi00900102Document, error) {
+	if err := newIDOptions(opts).assign(&msgID); err != nil {
+		return nil, err
+	}
+	now := NewISODateTime(time.Now().UTC())
+	return &Admi00900102Document{
+		StaticDataRequest: StaticDataRequestV02{
+			MessageHeader:      MessageHeader7{MessageID: msgID, CreationDateTime: &now},
+			DataRequestDetails: StaticDataRequest2{SearchCriteria: criteria},
+		},
+	}, nil
+}
+
+// Validate performs comprehensive validation according to admi.009.001.02 XSD
+func (d *Admi00900102Document) Validate() error {
+	return d.validateContext(context.Background())
+}
+
+func (d *Admi00900102Document) validateContext(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	var errs ValidationErrors
+
+	msg := &d.StaticDataRequest
+	if err := validateRequired(msg.MessageHeader.MessageID, "MsgHdr.MsgId"); err != nil {
+		errs = append(errs, err.(ValidationError))
+	} else if err := validateStringLength(msg.MessageHeader.MessageID, 1, 35, "MsgHdr.MsgId"); err != nil {
+		errs = append(errs, err.(ValidationError))
+	}
+
+	if len(msg.DataRequestDetails.SearchCriteria) == 0 {
+		errs = append(errs, ValidationError{Field: "DataReqDtls.SchCrit", Message: "at least one search criterion is required"})
+	}
+	for i, crit := range msg.DataRequestDetails.SearchCriteria {
+		field := fmt.Sprintf("DataReqDtls.SchCrit[%d]", i+1)
+		if err := validateEnumeration(crit.DataType, []string{StaticDataParticipantProfile, StaticDataAccount, StaticDataCalendar, StaticDataReachability}, field+".DataTp"); err != nil {
+			errs = append(errs, err.(ValidationError))
+		}
+		if crit.ParticipantID != nil {
+			if err := crit.ParticipantID.validateContext(ctx); err != nil {
+				errs = append(errs, nestErrors(field+".PtcptId", err)...)
+			}
+		}
+		if crit.AccountID != nil {
+			if err := crit.AccountID.validateContext(ctx); err != nil {
+				errs = append(errs, nestErrors(field+".AcctId", err)...)
+			}
+		}
+	}
+
+	errs = errs.merge(validateStructure(&d.StaticDataRequest))
+
+	if errs.HasErrors() {
+		return errs.within("StatcDataReq")
+	}
+	return nil
+}
+
+// Validate performs comprehensive validation according to admi.010.001.01 XSD
+func (d *Admi01000101Document) Validate() error {
+	var errs ValidationErrors
+
+	msg := &d.StaticDataReport
+	if err := validateRequired(msg.MessageHeader.MessageID, "MsgHdr.MsgId"); err != nil {
+		errs = append(errs, err.(ValidationError))
+	} else if err := validateStringLength(msg.MessageHeader.MessageID, 1, 35, "MsgHdr.MsgId"); err != nil {
+		errs = append(errs, err.(ValidationError))
+	}
+
+	// Report details or operational error
+	if (len(msg.ReportDetails) > 0) == (len(msg.OperationalError) > 0) {
+		errs = append(errs, ValidationError{Field: "RptOrErr", Message: "exactly one choice must be present"})
+	}
+
+	for i, rpt := range msg.ReportDetails {
+		if prfl := rpt.ParticipantProfile; prfl != nil {
+			if err := validateEnumeration(prfl.Status, []string{"ENBL", "DSBL", "SUSP"}, fmt.Sprintf("RptDtls[%d].PtcptPrfl.Sts", i+1)); err != nil {
+				errs = append(errs, err.(ValidationError))
+			}
+		}
+	}
+
+	errs = errs.merge(validateStructure(&d.StaticDataReport))
+
+	if errs.HasErrors() {
+		return errs.within("StatcDataRpt")
+	}
+	return nil
+}
+
+// admi.005.001.01 types
+
+// ReportQueryRequestV01 - admi.005.001.01
+type ReportQueryRequestV01 struct {
+	MessageHeader       MessageHeader7         `xml:"MsgHdr"`
+	ReportQueryCriteria []ReportQueryCriteria2 `xml:"RptQryCrit"` // 1..unbounded
+	SupplementaryData   []SupplementaryData1   `xml:"SplmtryData,omitempty"`
+}
+
+// ReportQueryCriteria2 - Criteria for a report query
+type ReportQueryCriteria2 struct {
+	NewQueryName   *string                    `xml:"NewQryNm,omitempty"` // Max35Text
+	SearchCriteria ReportQuerySearchCriteria2 `xml:"SchCrit"`            // Required
+}
+
+// ReportQuerySearchCriteria2 - Search criteria identifying the requested report
+type ReportQuerySearchCriteria2 struct {
+	AccountID  []AccountIdentification4                      `xml:"AcctId,omitempty"`
+	ReportName string                                        `xml:"RptNm"` // Max35Text - required
+	PartyID    *BranchAndFinancialInstitutionIdentification6 `xml:"PtyId,omitempty"`
+	Responder  *BranchAndFinancialInstitutionIdentification6 `xml:"Rspndr,omitempty"`
+	Date       *DateAndDateTime2                             `xml:"DtSch,omitempty"`
+	Scheduled  *ISOTime                                      `xml:"SchdldTm,omitempty"` // ISOTime
+	Event      *string                                       `xml:"Evt,omitempty"`      // Max4AlphaNumericText
+}
+
+// NewReportQueryRequest builds an admi.005.001.01 message requesting reportName, optionally restricted
+// to the given accounts.
+func NewReportQueryRequest(msgID, reportName string, accounts ...AccountIdentification4) *Admi00500101Document {
+	now := NewISODateTime(time.Now().UTC())
+	return &Admi00500101Document{
+		ReportQueryRequest: ReportQueryRequestV01{
+			MessageHeader: MessageHeader7{MessageID: msgID, CreationDateTime: &now},
+			ReportQueryCriteria: []ReportQueryCriteria2{
+				{SearchCriteria: ReportQuerySearchCriteria2{AccountID: accounts, ReportName: reportName}},
+			},
+		},
+	}
+}
+
+// Validate performs comprehensive validation according to admi.005.001.01 XSD
+func (d *Admi00500101Document) Validate() error {
+	return d.validateContext(context.Background())
+}
+
+func (d *Admi00500101Document) validateContext(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	var errs ValidationErrors
+
+	msg := &d.ReportQueryRequest
+	if err := validateRequired(msg.MessageHeader.MessageID, "MsgHdr.MsgId"); err != nil {
+		errs = append(errs, err.(ValidationError))
+	} else if err := validateStringLength(msg.MessageHeader.MessageID, 1, 35, "MsgHdr.MsgId"); err != nil {
+		errs = append(errs, err.(ValidationError))
+	}
+
+	if len(msg.ReportQueryCriteria) == 0 {
+		errs = append(errs, ValidationError{Field: "RptQryCrit", Message: "at least one query criterion is required"})
+	}
+	for i, crit := range msg.ReportQueryCriteria {
+		field := fmt.Sprintf("RptQryCrit[%d]", i+1)
+		if crit.NewQueryName != nil {
+			if err := validateStringLength(*crit.NewQueryName, 1, 35, field+".NewQryNm"); err != nil {
+				errs = append(errs, err.(ValidationError))
+			}
+		}
+		sch := &crit.SearchCriteria
+		if err := validateRequired(sch.ReportName, field+".SchCrit.RptNm"); err != nil {
+			errs = append(errs, err.(ValidationError))
+		} else if err := validateStringLength(sch.ReportName, 1, 35, field+".SchCrit.RptNm"); err != nil {
+			errs = append(errs, err.(ValidationError))
+		}
+		for j := range sch.AccountID {
+			if err := sch.AccountID[j].validateContext(ctx); err != nil {
+				errs = append(errs, nestErrors(fmt.Sprintf("%s.SchCrit.AcctId[%d]", field, j+1), err)...)
+			}
+		}
+		if sch.Event != nil {
+			if err := validatePattern(*sch.Event, `^[a-zA-Z0-9]{1,4}$`, field+".SchCrit.Evt"); err != nil {
+				errs = append(errs, err.(ValidationError))
+			}
+		}
+	}
+
+	errs = errs.merge(validateStructure(&d.ReportQueryRequest))
+
+	if errs.HasErrors() {
+		return errs.within("RptQryReq")
+	}
+	return nil
+}
+
+// acmt.023.001.03 / acmt.024.001.03 types
+
+// IdentificationVerificationRequestV03 - acmt.023.001.03
+type IdentificationVerificationRequestV03 struct {
+	Assignment        IdentificationAssignment3     `xml:"Assgnmt"`
+	Verification      []IdentificationVerification4 `xml:"Vrfctn"` // 1..unbounded
+	SupplementaryData []SupplementaryData1          `xml:"SplmtryData,omitempty"`
+}
+
+// IdentificationVerificationReportV03 - acmt.024.001.03
+type IdentificationVerificationReportV03 struct {
+	Assignment         IdentificationAssignment3 `xml:"Assgnmt"`
+	OriginalAssignment *MessageIdentification5   `xml:"OrgnlAssgnmt,omitempty"`
+	Report             []VerificationReport4     `xml:"Rpt"` // 1..unbounded
+	SupplementaryData  []SupplementaryData1      `xml:"SplmtryData,omitempty"`
+}
+
+// IdentificationAssignment3 - Assignment of an identification verification
+type IdentificationAssignment3 struct {
+	MessageID        string      `xml:"MsgId"`   // Max35Text - required
+	CreationDateTime ISODateTime `xml:"CreDtTm"` // ISODateTime - required
+	Creator          *Party40    `xml:"Cretr,omitempty"`
+	Assigner         Party40     `xml:"Assgnr"` // Required
+	Assignee         Party40     `xml:"Assgne"` // Required
+}
+
+// MessageIdentification5 - Reference to the original assignment
+type MessageIdentification5 struct {
+	MessageID        string      `xml:"MsgId"`   // Max35Text - required
+	CreationDateTime ISODateTime `xml:"CreDtTm"` // ISODateTime - required
+}
+
+// IdentificationInformation4 - Party and account to be verified
+type IdentificationInformation4 struct {
+	Party   *PartyIdentification135                       `xml:"Pty,omitempty"`
+	Account *AccountIdentification4                       `xml:"Acct,omitempty"`
+	Agent   *BranchAndFinancialInstitutionIdentification6 `xml:"Agt,omitempty"`
+}
+
+// IdentificationVerification4 - One verification request
+type IdentificationVerification4 struct {
+	ID                string                     `xml:"Id"`           // Max35Text - required
+	PartyAndAccountID IdentificationInformation4 `xml:"PtyAndAcctId"` // Required
+}
+
+// VerificationReason1Choice - Reason a verification failed
+type VerificationReason1Choice struct {
+	Code        *string `xml:"Cd,omitempty"`    // ExternalVerificationReason1Code
+	Proprietary *string `xml:"Prtry,omitempty"` // Max35Text
+}
+
+// VerificationReport4 - Result of one verification
+type VerificationReport4 struct {
+	OriginalID                string                      `xml:"OrgnlId"` // Max35Text - required
+	Verification              bool                        `xml:"Vrfctn"`  // IdentificationVerificationIndicator - required
+	Reason                    *VerificationReason1Choice  `xml:"Rsn,omitempty"`
+	OriginalPartyAndAccountID *IdentificationInformation4 `xml:"OrgnlPtyAndAcctId,omitempty"`
+	UpdatedPartyAndAccountID  *IdentificationInformation4 `xml:"UpdtdPtyAndAcctId,omitempty"`
+}
+
+// MatchResult is the outcome of a name and account verification
+type MatchResult int
+
+const (
+	// NoMatch means the account does not belong to the named party
+	NoMatch MatchResult = iota
+	// CloseMatch means the name was not exact but the servicer returned the account holder's name
+	CloseMatch
+	// Match means the name and account combination was confirmed
+	Match
+)
+
+// String returns the match result name
+func (m MatchResult) String() string {
+	switch m {
+	case Match:
+		return "match"
+	case CloseMatch:
+		return "close match"
+	}
+	return "no match"
+}
+
+// VerificationResult is the interpreted content of a VerificationReport4
+type VerificationResult struct {
+	Result        MatchResult
+	ReasonCode    string // empty when the verification succeeded
+	SuggestedName string // account holder name returned on a close match
+}
+
+// NewIdentificationVerificationRequest builds an acmt.023.001.03 message asking assignee to verify that
+// account is held by name. verificationID identifies the request in the report.
+func NewIdentificationVerificationRequest(msgID string, assigner, assignee Party40, verificationID, name string, account AccountIdentification4, opts ...IDOption) (*Acmt02300103Document, error) {
+	if err := newIDOptions(opts).assign(&msgID, &verificationID); err != nil {
+		return nil, err
+	}
+	return &Acmt02300103Document{
+		IdentificationVerificationRequest: IdentificationVerificationRequestV03{
+			Assignment: IdentificationAssignment3{
+				MessageID:        msgID,
+				CreationDateTime: NewISODateTime(time.Now().UTC()),
+				Assigner:         assigner,
+				Assignee:         assignee,
+			},
+			Verification: []IdentificationVerification4{
+				{
+					ID: verificationID,
+					PartyAndAccountID: IdentificationInformation4{
+						Party:   &PartyIdentification135{Name: &name},
+						Account: &account,
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+// Result returns the interpreted result for verificationID and whether the report contains it
+func (d *Acmt02400103Document) Result(verificationID string) (VerificationResult, bool) {
+	for _, rpt := range d.IdentificationVerificationReport.Report {
+		if rpt.OriginalID != verificationID {
+			continue
+		}
+		if rpt.Verification {
+			return VerificationResult{Result: Match}, true
+		}
+
+		res := VerificationResult{Result: NoMatch}
+		if rpt.Reason != nil {
+			if rpt.Reason.Code != nil {
+				res.ReasonCode = *rpt.Reason.Code
+			} else if rpt.Reason.Proprietary != nil {
+				res.ReasonCode = *rpt.Reason.Proprietary
+			}
+		}
+		if upd := rpt.UpdatedPartyAndAccountID; upd != nil && upd.Party != nil && upd.Party.Name != nil {
+			res.Result = CloseMatch
+			res.SuggestedName = *upd.Party.Name
+		}
+		return res, true
+	}
+	return VerificationResult{}, false
+}
+
+// Validate performs validation for IdentificationAssignment3
+func (a *IdentificationAssignment3) Validate() error {
+	return a.validateContext(context.Background())
+}
+
+func (a *IdentificationAssignment3) validateContext(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	var errs ValidationErrors
+
+	if err := validateRequired(a.MessageID, "MsgId"); err != nil {
+		errs = append(errs, err.(ValidationError))
+	} else if err := validateStringLength(a.MessageID, 1, 35, "MsgId"); err != nil {
+		errs = append(errs, err.(ValidationError))
+	}
+
+	if a.CreationDateTime.IsZero() {
+		errs = append(errs, ValidationError{Field: "CreDtTm", Message: "field is required"})
+	}
+
+	if err := a.Assigner.validateContext(ctx); err != nil {
+		errs = append(errs, nestErrors("Assgnr", err)...)
+	}
+
+	if err := a.Assignee.validateContext(ctx); err != nil {
+		errs = append(errs, nestErrors("Assgne", err)...)
+	}
+
+	if errs.HasErrors() {
+		return errs
+	}
+	return nil
+}
+
+// Validate performs comprehensive validation according to acmt.023.001.03 XSD
+func (d *Acmt02300103Document) Validate() error {
+	return d.validateContext(context.Background())
+}
+
+func (d *Acmt02300103Document) validateContext(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	var errs ValidationErrors
+
+	msg := &d.IdentificationVerificationRequest
+	if err := msg.Assignment.validateContext(ctx); err != nil {
+		errs = append(errs, nestErrors("Assgnmt", err)...)
+	}
+
+	if len(msg.Verification) == 0 {
+		errs = append(errs, ValidationError{Field: "Vrfctn", Message: "at least one verification is required"})
+	}
+	for i, v := range msg.Verification {
+		field := fmt.Sprintf("Vrfctn[%d]", i+1)
+		if err := validateRequired(v.ID, field+".Id"); err != nil {
+			errs = append(errs, err.(ValidationError))
+		} else if err := validateStringLength(v.ID, 1, 35, field+".Id"); err != nil {
+			errs = append(errs, err.(ValidationError))
+		}
+		info := &v.PartyAndAccountID
+		if info.Party == nil && info.Account == nil && info.Agent == nil {
+			errs = append(errs, ValidationError{Field: field + ".PtyAndAcctId", Message: "party, account or agent is required"})
+		}
+		if info.Party != nil {
+			if err := info.Party.validateContext(ctx); err != nil {
+				errs = append(errs, nestErrors(field+".PtyAndAcctId.Pty", err)...)
+			}
+		}
+		if info.Account != nil {
+			if err := info.Account.validateContext(ctx); err != nil {
+				errs = append(errs, nestErrors(field+".PtyAndAcctId.Acct", err)...)
+			}
+		}
+	}
+
+	errs = errs.merge(validateStructure(&d.IdentificationVerificationRequest))
+
+	if errs.HasErrors() {
+		return errs.within("IdVrfctnReq")
+	}
+	return nil
+}
+
+// Validate performs comprehensive validation according to acmt.024.001.03 XSD
+func (d *Acmt02400103Document) Validate() error {
+	return d.validateContext(context.Background())
+}
+
+func (d *Acmt02400103Document) validateContext(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	var errs ValidationErrors
+
+	msg := &d.IdentificationVerificationReport
+	if err := msg.Assignment.validateContext(ctx); err != nil {
+		errs = append(errs, nestErrors("Assgnmt", err)...)
+	}
+
+	if len(msg.Report) == 0 {
+		errs = append(errs, ValidationError{Field: "Rpt", Message: "at least one report is required"})
+	}
+	for i, rpt := range msg.Report {
+		field := fmt.Sprintf("Rpt[%d]", i+1)
+		if err := validateRequired(rpt.OriginalID, field+".OrgnlId"); err != nil {
+			errs = append(errs, err.(ValidationError))
+		}
+		if rpt.Reason != nil && (rpt.Reason.Code == nil) == (rpt.Reason.Proprietary == nil) {
+			errs = append(errs, ValidationError{Field: field + ".Rsn", Message: "exactly one choice must be present"})
+		}
+	}
+
+	errs = errs.merge(validateStructure(&d.IdentificationVerificationReport))
+
+	if errs.HasErrors() {
+		return errs.within("IdVrfctnRpt")
+	}
+	return nil
+}
+
+// remt.001.001.05 types
+
+// RemittanceAdviceV05 - remt.001.001.05
+type RemittanceAdviceV05 struct {
+	GroupHeader       GroupHeader79             `xml:"GrpHdr"`
+	RemittanceInfo    []RemittanceInformation21 `xml:"RmtInf"` // 1..unbounded
+	SupplementaryData []SupplementaryData1      `xml:"SplmtryData,omitempty"`
+}
+
+// GroupHeader79 - Group header for remt.001.001.05
+type GroupHeader79 struct {
+	MessageID        string                  `xml:"MsgId"`   // Max35Text - required
+	CreationDateTime ISODateTime             `xml:"CreDtTm"` // ISODateTime - required
+	InitiatingParty  *PartyIdentification135 `xml:"InitgPty,omitempty"`
+	MessageRecipient *PartyIdentification135 `xml:"MsgRcpt,omitempty"`
+}
+
+// RemittanceInformation21 - Remittance information for one payment
+type RemittanceInformation21 struct {
+	RemittanceID        *string                      `xml:"RmtId,omitempty"` // Max35Text
+	OriginalPaymentInfo *OriginalPaymentInformation  `xml:"OrgnlPmtInf,omitempty"`
+	Structured          []StructuredRemittanceInfo16 `xml:"Strd,omitempty"`
+}
+
+// OriginalPaymentInformation - Payment the remittance advice relates to
+type OriginalPaymentInformation struct {
+	References TransactionReferences5             `xml:"Refs"` // Required
+	Amount     *ActiveOrHistoricCurrencyAndAmount `xml:"Amt,omitempty"`
+}
+
+// TransactionReferences5 - References of the related payment
+type TransactionReferences5 struct {
+	PaymentInfoID     *string `xml:"PmtInfId,omitempty"`   // Max35Text
+	InstructionID     *string `xml:"InstrId,omitempty"`    // Max35Text
+	EndToEndID        *string `xml:"EndToEndId,omitempty"` // Max35Text
+	UETR              *string `xml:"UETR,omitempty"`       // UUIDv4Identifier
+	TransactionID     *string `xml:"TxId,omitempty"`       // Max35Text
+	ClearingSystemRef *string `xml:"ClrSysRef,omitempty"`  // Max35Text
+}
+
+// NewRemittanceAdvice builds a remt.001.001.05 advice for tx, copying its end-to-end identification,
+// UETR and settlement amount so that the advice can be matched with the pacs.008 on receipt.
+func NewRemittanceAdvice(msgID string, tx *CreditTransferTransaction39, structured []StructuredRemittanceInfo16, opts ...IDOption) (*Remt00100105Document, error) {
+	if err := newIDOptions(opts).assign(&msgID); err != nil {
+		return nil, err
+	}
+	endToEndID := tx.PaymentID.EndToEndID
+	amount := ActiveOrHistoricCurrencyAndAmount{
+		Value:    tx.InterbankSettlementAmount.Value,
+		Currency: tx.InterbankSettlementAmount.Currency,
+	}
+	rmt := RemittanceInformation21{
+		OriginalPaymentInfo: &OriginalPaymentInformation{
+			References: TransactionReferences5{
+				InstructionID: tx.PaymentID.InstructionID,
+				EndToEndID:    &endToEndID,
+				UETR:          tx.PaymentID.UETR,
+				TransactionID: tx.PaymentID.TransactionID,
+			},
+			Amount: &amount,
+		},
+		Structured: structured,
+	}
+	if len(tx.RelatedRemittanceInfo) > 0 {
+		rmt.RemittanceID = tx.RelatedRemittanceInfo[0].RemittanceID
+	}
+
+	return &Remt00100105Document{
+		RemittanceAdvice: RemittanceAdviceV05{
+			GroupHeader: GroupHeader79{
+				MessageID:        msgID,
+				CreationDateTime: NewISODateTime(time.Now().UTC()),
+			},
+			RemittanceInfo: []RemittanceInformation21{rmt},
+		},
+	}, nil
+}
+
+// RefersTo reports whether the remittance information relates to tx. The UETR is compared when both
+// sides carry one; otherwise the remittance identification announced in the pacs.008 related remittance
+// information, and then the end-to-end identification, are used. NOTPROVIDED end-to-end identifications
+// never match.
+func (r *RemittanceInformation21) RefersTo(tx *CreditTransferTransaction39) bool {
+	var refs *TransactionReferences5
+	if r.OriginalPaymentInfo != nil {
+		refs = &r.OriginalPaymentInfo.References
+	}
+
+	if refs != nil && refs.UETR != nil && tx.PaymentID.UETR != nil {
+		return strings.EqualFold(*refs.UETR, *tx.PaymentID.UETR)
+	}
+
+	if r.RemittanceID != nil {
+		for _, loc := range tx.RelatedRemittanceInfo {
+			if loc.RemittanceID != nil && *loc.RemittanceID == *r.RemittanceID {
+				return true
+			}
+		}
+	}
+
+	if refs != nil && refs.EndToEndID != nil && *refs.EndToEndID != "NOTPROVIDED" {
+		return *refs.EndToEndID == tx.PaymentID.EndToEndID
+	}
+	return false
+}
+
+// RemittancesFor returns the remittance information in the advice that relates to tx
+func (d *Remt00100105Document) RemittancesFor(tx *CreditTransferTransaction39) []RemittanceInformation21 {
+	var out []RemittanceInformation21
+	for i := range d.RemittanceAdvice.RemittanceInfo {
+		if d.RemittanceAdvice.RemittanceInfo[i].RefersTo(tx) {
+			out = append(out, d.RemittanceAdvice.RemittanceInfo[i])
+		}
+	}
+	return out
+}
+
+// Validate performs comprehensive validation according to remt.001.001.05 XSD
+func (d *Remt00100105Document) Validate() error {
+	var errs ValidationErrors
+
+	msg := &d.RemittanceAdvice
+	if err := validateRequired(msg.GroupHeader.MessageID, "GrpHdr.MsgId"); err != nil {
+		errs = append(errs, err.(ValidationError))
+	} else if err := validateStringLength(msg.GroupHeader.MessageID, 1, 35, "GrpHdr.MsgId"); err != nil {
+		errs = append(errs, err.(ValidationError))
+	}
+	if msg.GroupHeader.CreationDateTime.IsZero() {
+		errs = append(errs, ValidationError{Field: "GrpHdr.CreDtTm", Message: "field is required"})
+	}
+
+	if len(msg.RemittanceInfo) == 0 {
+		errs = append(errs, ValidationError{Field: "RmtInf", Message: "at least one remittance information is required"})
+	}
+	for i, rmt := range msg.RemittanceInfo {
+		field := fmt.Sprintf("RmtInf[%d]", i+1)
+		if rmt.RemittanceID != nil {
+			if err := validateStringLength(*rmt.RemittanceID, 1, 35, field+".RmtId"); err != nil {
+				errs = append(errs, err.(ValidationError))
+			}
+		}
+		if rmt.OriginalPaymentInfo != nil {
+			refs := &rmt.OriginalPaymentInfo.References
+			if refs.UETR != nil {
+				if err := validateUUID(*refs.UETR, field+".OrgnlPmtInf.Refs.UETR"); err != nil {
+					errs = append(errs, err.(ValidationError))
+				}
+			}
+			if refs.EndToEndID != nil {
+				if err := validateStringLength(*refs.EndToEndID, 1, 35, field+".OrgnlPmtInf.Refs.EndToEndId"); err != nil {
+					errs = append(errs, err.(ValidationError))
+				}
+			}
+		}
+		if rmt.RemittanceID == nil && rmt.OriginalPaymentInfo == nil {
+			errs = append(errs, ValidationError{Field: field, Message: "remittance identification or original payment information is required"})
+		}
+	}
+
+	errs = errs.merge(validateStructure(&d.RemittanceAdvice))
+
+	if errs.HasErrors() {
+		return errs.within("RmtAdvc")
+	}
+	return nil
+}
@@ -11,7 +11,7 @@ func TestCreditTransferTransaction_NoInterfaceTypes(t *testing.T) {
 	tx := CreditTransferTransaction39{
 		PaymentID: PaymentIdentification7{
 			EndToEndID:    "END2END123",
-			TransactionID: stringPtr("TXN456"),
+			TransactionID: Ptr("TXN456"),
 		},
 		InterbankSettlementAmount: ActiveCurrencyAndAmount{
 			Value: 1000.50,
@@ -19,19 +19,19 @@ func TestCreditTransferTransaction_NoInterfaceTypes(t *testing.T) {
 		},
 		ChargeBearer: "SLEV",
 		Debtor: PartyIdentification135{
-			Name: stringPtr("John Doe"),
+			Name: Ptr("John Doe"),
 		},
 		DebtorAgent: BranchAndFinancialInstitutionIdentification6{
 			FinancialInstitutionID: FinancialInstitutionIdentification18{
-				BankIdentifierCode: stringPtr("CHASUS33"),
+				BankIdentifierCode: Ptr("CHASUS33"),
 			},
 		},
 		Creditor: PartyIdentification135{
-			Name: stringPtr("Jane Smith"),
+			Name: Ptr("Jane Smith"),
 		},
 		CreditorAgent: BranchAndFinancialInstitutionIdentification6{
 			FinancialInstitutionID: FinancialInstitutionIdentification18{
-				BankIdentifierCode: stringPtr("BOFA0011"),
+				BankIdentifierCode: Ptr("BOFA0011"),
 			},
 		},
 	}
@@ -71,7 +71,7 @@ func TestDocument_FullStructure(t *testing.T) {
 		FICustomerCreditTransfer: FIToFICustomerCreditTransferV08{
 			GroupHeader: GroupHeader93{
 				MessageID:            "MSG001",
-				CreationDateTime:     func() *time.Time { t := time.Now(); return &t }(),
+				CreationDateTime:     Ptr(NewISODateTime(time.Now())),
 				NumberOfTransactions: "1",
 				SettlementInfo:       SettlementInstruction7{SettlementMethod: "INDA"}, // Required field
 			},
@@ -79,7 +79,7 @@ func TestDocument_FullStructure(t *testing.T) {
 				{
 					PaymentID: PaymentIdentification7{
 						EndToEndID:    "END2END123",
-						TransactionID: stringPtr("TXN456"),
+						TransactionID: Ptr("TXN456"),
 					},
 					InterbankSettlementAmount: ActiveCurrencyAndAmount{
 						Value: 1000.00,
@@ -87,19 +87,19 @@ func TestDocument_FullStructure(t *testing.T) {
 					},
 					ChargeBearer: "SLEV",
 					Debtor: PartyIdentification135{
-						Name: stringPtr("Debtor Name"),
+						Name: Ptr("Debtor Name"),
 					},
 					DebtorAgent: BranchAndFinancialInstitutionIdentification6{
 						FinancialInstitutionID: FinancialInstitutionIdentification18{
-							BankIdentifierCode: stringPtr("CHASUS33"),
+							BankIdentifierCode: Ptr("CHASUS33"),
 						},
 					},
 					Creditor: PartyIdentification135{
-						Name: stringPtr("Creditor Name"),
+						Name: Ptr("Creditor Name"),
 					},
 					CreditorAgent: BranchAndFinancialInstitutionIdentification6{
 						FinancialInstitutionID: FinancialInstitutionIdentification18{
-							BankIdentifierCode: stringPtr("BOFA0011"),
+							BankIdentifierCode: Ptr("BOFA0011"),
 						},
 					},
 				},
@@ -156,32 +156,27 @@ func TestTypeSafety(t *testing.T) {
 	t.Log("All fields are strongly typed - no interface{} types!")
 }
 
-// Helper function to create string pointers
-func stringPtr(s string) *string {
-	return &s
-}
-
 func TestBusinessApplicationHeader_Structure(t *testing.T) {
 	// Create a sample Business Application Header V02
 	bah := BusinessApplicationHeaderV02{
 		From: Party44{
 			FinancialInstitutionID: &BranchAndFinancialInstitutionIdentification6{
 				FinancialInstitutionID: FinancialInstitutionIdentification18{
-					BankIdentifierCode: stringPtr("CHASUS33"),
+					BankIdentifierCode: Ptr("CHASUS33"),
 				},
 			},
 		},
 		To: Party44{
 			FinancialInstitutionID: &BranchAndFinancialInstitutionIdentification6{
 				FinancialInstitutionID: FinancialInstitutionIdentification18{
-					BankIdentifierCode: stringPtr("BOFA0011"),
+					BankIdentifierCode: Ptr("BOFA0011"),
 				},
 			},
 		},
 		BusinessMessageID: "BAH123456789",
 		MessageDefinitionID: "pacs.008.001.08",
-		CreationDate: time.Now(),
-		Priority: func() *BusinessMessagePriorityCode { p := BusinessMessagePriorityNormal; return &p }(),
+		CreationDate: NewISODateTime(time.Now()),
+		Priority: Ptr(BusinessMessagePriorityNormal),
 	}
 
 	// Test XML marshaling
@@ -215,20 +210,20 @@ func TestBusinessApplicationHeader_Validation(t *testing.T) {
 		From: Party44{
 			FinancialInstitutionID: &BranchAndFinancialInstitutionIdentification6{
 				FinancialInstitutionID: FinancialInstitutionIdentification18{
-					BankIdentifierCode: stringPtr("CHASUS33"),
+					BankIdentifierCode: Ptr("CHASUS33"),
 				},
 			},
 		},
 		To: Party44{
 			FinancialInstitutionID: &BranchAndFinancialInstitutionIdentification6{
 				FinancialInstitutionID: FinancialInstitutionIdentification18{
-					BankIdentifierCode: stringPtr("BOFA0011"),
+					BankIdentifierCode: Ptr("BOFA0011"),
 				},
 			},
 		},
 		BusinessMessageID: "BAH123",
 		MessageDefinitionID: "pacs.008.001.08",
-		CreationDate: time.Now(),
+		CreationDate: NewISODateTime(time.Now()),
 	}
 	
 	err := validBAH.Validate()
@@ -254,20 +249,20 @@ func TestBusinessApplicationHeader_Validation(t *testing.T) {
 		From: Party44{
 			FinancialInstitutionID: &BranchAndFinancialInstitutionIdentification6{
 				FinancialInstitutionID: FinancialInstitutionIdentification18{
-					BankIdentifierCode: stringPtr("CHASUS33"),
+					BankIdentifierCode: Ptr("CHASUS33"),
 				},
 			},
 		},
 		To: Party44{
 			FinancialInstitutionID: &BranchAndFinancialInstitutionIdentification6{
 				FinancialInstitutionID: FinancialInstitutionIdentification18{
-					BankIdentifierCode: stringPtr("BOFA0011"),
+					BankIdentifierCode: Ptr("BOFA0011"),
 				},
 			},
 		},
 		BusinessMessageID: "BAH123",
 		MessageDefinitionID: "INVALID.FORMAT", // Wrong format
-		CreationDate: time.Now(),
+		CreationDate: NewISODateTime(time.Now()),
 	}
 	
 	err = invalidMsgDef.Validate()
@@ -281,7 +276,7 @@ func TestParty44_Validation(t *testing.T) {
 	validFI := Party44{
 		FinancialInstitutionID: &BranchAndFinancialInstitutionIdentification6{
 			FinancialInstitutionID: FinancialInstitutionIdentification18{
-				BankIdentifierCode: stringPtr("CHASUS33"),
+				BankIdentifierCode: Ptr("CHASUS33"),
 			},
 		},
 	}
@@ -294,7 +289,7 @@ func TestParty44_Validation(t *testing.T) {
 	// Test valid choice with Org ID
 	validOrg := Party44{
 		OrganisationIdentification: &PartyIdentification135{
-			Name: stringPtr("Test Organization"),
+			Name: Ptr("Test Organization"),
 		},
 	}
 	
@@ -315,11 +310,11 @@ func TestParty44_Validation(t *testing.T) {
 	bothChoices := Party44{
 		FinancialInstitutionID: &BranchAndFinancialInstitutionIdentification6{
 			FinancialInstitutionID: FinancialInstitutionIdentification18{
-				BankIdentifierCode: stringPtr("CHASUS33"),
+				BankIdentifierCode: Ptr("CHASUS33"),
 			},
 		},
 		OrganisationIdentification: &PartyIdentification135{
-			Name: stringPtr("Test Organization"),
+			Name: Ptr("Test Organization"),
 		},
 	}
 	
@@ -331,44 +326,44 @@ func TestParty44_Validation(t *testing.T) {
 
 func TestBusinessApplicationHeaderDocument(t *testing.T) {
 	// Test complete BAH document V02
-	now := time.Now()
+	now := NewISODateTime(time.Now())
 	doc := BusinessApplicationHeaderDocument{
 		AppHdr: BusinessApplicationHeaderV02{
 			From: Party44{
 				FinancialInstitutionID: &BranchAndFinancialInstitutionIdentification6{
 					FinancialInstitutionID: FinancialInstitutionIdentification18{
-						BankIdentifierCode: stringPtr("CHASUS33"),
+						BankIdentifierCode: Ptr("CHASUS33"),
 					},
 				},
 			},
 			To: Party44{
 				FinancialInstitutionID: &BranchAndFinancialInstitutionIdentification6{
 					FinancialInstitutionID: FinancialInstitutionIdentification18{
-						BankIdentifierCode: stringPtr("BOFA0011"),
+						BankIdentifierCode: Ptr("BOFA0011"),
 					},
 				},
 			},
 			BusinessMessageID: "BAH001",
 			MessageDefinitionID: "pacs.008.001.08",
 			CreationDate: now,
-			BusinessProcessingDate: func() *time.Time { t := now.Add(time.Hour); return &t }(),
+			BusinessProcessingDate: Ptr(NewISODateTime(now.Add(time.Hour))),
 			MarketPractice: &ImplementationSpecification1{
-				Registry: stringPtr("ISO20022.org"),
-				ID:       stringPtr("CBPR+ v1.0"),
+				Registry: Ptr("ISO20022.org"),
+				ID:       Ptr("CBPR+ v1.0"),
 			},
 			Related: []BusinessApplicationHeader5{
 				{
 					From: Party44{
 						FinancialInstitutionID: &BranchAndFinancialInstitutionIdentification6{
 							FinancialInstitutionID: FinancialInstitutionIdentification18{
-								BankIdentifierCode: stringPtr("TESTUS33"),
+								BankIdentifierCode: Ptr("TESTUS33"),
 							},
 						},
 					},
 					To: Party44{
 						FinancialInstitutionID: &BranchAndFinancialInstitutionIdentification6{
 							FinancialInstitutionID: FinancialInstitutionIdentification18{
-								BankIdentifierCode: stringPtr("TSTBUS44"),
+								BankIdentifierCode: Ptr("TSTBUS44"),
 							},
 						},
 					},
@@ -398,8 +393,8 @@ func TestBusinessApplicationHeaderDocument(t *testing.T) {
 func TestMarketPractice_Validation(t *testing.T) {
 	// Test valid MarketPractice
 	validMP := ImplementationSpecification1{
-		Registry: stringPtr("ISO20022.org"),
-		ID:       stringPtr("CBPR+ Market Practice Guidelines v1.0"),
+		Registry: Ptr("ISO20022.org"),
+		ID:       Ptr("CBPR+ Market Practice Guidelines v1.0"),
 	}
 	
 	err := validMP.Validate()
@@ -409,7 +404,7 @@ func TestMarketPractice_Validation(t *testing.T) {
 
 	// Test invalid MarketPractice - missing Registry
 	invalidMP1 := ImplementationSpecification1{
-		ID: stringPtr("CBPR+ v1.0"),
+		ID: Ptr("CBPR+ v1.0"),
 	}
 	
 	err = invalidMP1.Validate()
@@ -419,7 +414,7 @@ func TestMarketPractice_Validation(t *testing.T) {
 
 	// Test invalid MarketPractice - missing ID
 	invalidMP2 := ImplementationSpecification1{
-		Registry: stringPtr("ISO20022.org"),
+		Registry: Ptr("ISO20022.org"),
 	}
 	
 	err = invalidMP2.Validate()
@@ -433,8 +428,8 @@ func TestMarketPractice_Validation(t *testing.T) {
 		longRegistry[i] = 'A'
 	}
 	invalidMP3 := ImplementationSpecification1{
-		Registry: stringPtr(string(longRegistry)),
-		ID:       stringPtr("CBPR+ v1.0"),
+		Registry: Ptr(string(longRegistry)),
+		ID:       Ptr("CBPR+ v1.0"),
 	}
 	
 	err = invalidMP3.Validate()
@@ -445,58 +440,58 @@ func TestMarketPractice_Validation(t *testing.T) {
 
 func TestBusinessApplicationHeaderV02_AllFields(t *testing.T) {
 	// Test complete BAH V02 with all optional fields
-	now := time.Now()
-	processingTime := now.Add(time.Hour)
+	now := NewISODateTime(time.Now())
+	processingTime := NewISODateTime(now.Add(time.Hour))
 	
 	completeBAH := BusinessApplicationHeaderV02{
-		CharacterSet:           stringPtr("UTF-8"),
+		CharacterSet:           Ptr("UTF-8"),
 		From: Party44{
 			FinancialInstitutionID: &BranchAndFinancialInstitutionIdentification6{
 				FinancialInstitutionID: FinancialInstitutionIdentification18{
-					BankIdentifierCode: stringPtr("CHASUS33"),
+					BankIdentifierCode: Ptr("CHASUS33"),
 				},
 			},
 		},
 		To: Party44{
 			FinancialInstitutionID: &BranchAndFinancialInstitutionIdentification6{
 				FinancialInstitutionID: FinancialInstitutionIdentification18{
-					BankIdentifierCode: stringPtr("BOFA0011"),
+					BankIdentifierCode: Ptr("BOFA0011"),
 				},
 			},
 		},
 		BusinessMessageID:      "FULL_TEST_BAH_001",
 		MessageDefinitionID:    "pacs.008.001.08",
-		BusinessService:        stringPtr("Payment Processing"),
+		BusinessService:        Ptr("Payment Processing"),
 		MarketPractice: &ImplementationSpecification1{
-			Registry: stringPtr("ISO20022.org"),
-			ID:       stringPtr("CBPR+ Cross-Border Payments v1.0"),
+			Registry: Ptr("ISO20022.org"),
+			ID:       Ptr("CBPR+ Cross-Border Payments v1.0"),
 		},
 		CreationDate:           now,
 		BusinessProcessingDate: &processingTime,
-		CopyDuplicate:          func() *CopyDuplicate1Code { c := CopyDuplicateCodeCopy; return &c }(),
-		PossibleDuplicate:      func() *bool { b := false; return &b }(),
-		Priority:               func() *BusinessMessagePriorityCode { p := BusinessMessagePriorityNormal; return &p }(),
+		CopyDuplicate:          Ptr(CopyDuplicateCodeCopy),
+		PossibleDuplicate:      Ptr(false),
+		Priority:               Ptr(BusinessMessagePriorityNormal),
 		Related: []BusinessApplicationHeader5{
 			{
 				From: Party44{
 					FinancialInstitutionID: &BranchAndFinancialInstitutionIdentification6{
 						FinancialInstitutionID: FinancialInstitutionIdentification18{
-							BankIdentifierCode: stringPtr("RELAUS33"),
+							BankIdentifierCode: Ptr("RELAUS33"),
 						},
 					},
 				},
 				To: Party44{
 					FinancialInstitutionID: &BranchAndFinancialInstitutionIdentification6{
 						FinancialInstitutionID: FinancialInstitutionIdentification18{
-							BankIdentifierCode: stringPtr("RELTUS44"),
+							BankIdentifierCode: Ptr("RELTUS44"),
 						},
 					},
 				},
 				BusinessMessageID:   "RELATED_MSG_001",
 				MessageDefinitionID: "pacs.002.001.10",
-				BusinessService:     stringPtr("Status Report"),
-				CreationDate:        now.Add(-time.Minute),
-				CopyDuplicate:       func() *CopyDuplicate1Code { c := CopyDuplicateCodeDupl; return &c }(),
+				BusinessService:     Ptr("Status Report"),
+				CreationDate:        NewISODateTime(now.Add(-time.Minute)),
+				CopyDuplicate:       Ptr(CopyDuplicateCodeDupl),
 			},
 		},
 	}
@@ -595,7 +590,7 @@ func TestValidation_GroupHeader93(t *testing.T) {
 	// Test valid group header
 	validHeader := GroupHeader93{
 		MessageID:            "MSG123456789",
-		CreationDateTime:     func() *time.Time { t := time.Now(); return &t }(),
+		CreationDateTime:     Ptr(NewISODateTime(time.Now())),
 		NumberOfTransactions: "5",
 		SettlementInfo: SettlementInstruction7{
 			SettlementMethod: "INDA",
@@ -612,7 +607,7 @@ func TestValidation_GroupHeader93(t *testing.T) {
 	// Test invalid message ID (too long)
 	invalidHeader := GroupHeader93{
 		MessageID:            "MSG123456789012345678901234567890123456", // >35 chars
-		CreationDateTime:     func() *time.Time { t := time.Now(); return &t }(),
+		CreationDateTime:     Ptr(NewISODateTime(time.Now())),
 		NumberOfTransactions: "5",
 		SettlementInfo: SettlementInstruction7{
 			SettlementMethod: "INDA",
@@ -627,7 +622,7 @@ func TestValidation_GroupHeader93(t *testing.T) {
 	// Test invalid number of transactions (non-numeric)
 	invalidNumTxs := GroupHeader93{
 		MessageID:            "MSG123",
-		CreationDateTime:     func() *time.Time { t := time.Now(); return &t }(),
+		CreationDateTime:     Ptr(NewISODateTime(time.Now())),
 		NumberOfTransactions: "ABC", // should be numeric
 		SettlementInfo: SettlementInstruction7{
 			SettlementMethod: "INDA",
@@ -648,7 +643,7 @@ func TestValidation_Document(t *testing.T) {
 		FICustomerCreditTransfer: FIToFICustomerCreditTransferV08{
 			GroupHeader: GroupHeader93{
 				MessageID:            "MSG001",
-				CreationDateTime:     func() *time.Time { t := time.Now(); return &t }(),
+				CreationDateTime:     Ptr(NewISODateTime(time.Now())),
 				NumberOfTransactions: "1",
 				SettlementInfo: SettlementInstruction7{
 					SettlementMethod: "INDA",
@@ -665,19 +660,19 @@ func TestValidation_Document(t *testing.T) {
 					},
 					ChargeBearer: "SLEV",
 					Debtor: PartyIdentification135{
-						Name: stringPtr("Test Debtor"),
+						Name: Ptr("Test Debtor"),
 					},
 					DebtorAgent: BranchAndFinancialInstitutionIdentification6{
 						FinancialInstitutionID: FinancialInstitutionIdentification18{
-							BankIdentifierCode: stringPtr("CHASUS33"),
+							BankIdentifierCode: Ptr("CHASUS33"),
 						},
 					},
 					Creditor: PartyIdentification135{
-						Name: stringPtr("Test Creditor"),
+						Name: Ptr("Test Creditor"),
 					},
 					CreditorAgent: BranchAndFinancialInstitutionIdentification6{
 						FinancialInstitutionID: FinancialInstitutionIdentification18{
-							BankIdentifierCode: stringPtr("BOFA0011"),
+							BankIdentifierCode: Ptr("BOFA0011"),
 						},
 					},
 				},
@@ -0,0 +1,256 @@
+package iso20022
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+)
+
+// pacs008Namespace is the namespace of the pacs.008.001.08 document element
+const pacs008Namespace = "urn:iso:std:iso:20022:tech:xsd:pacs.008.001.08"
+
+// RawXML is an element kept as it appears in a document, from its start tag to its
+// end tag, to be decoded only when needed
+type RawXML []byte
+
+// Decode unmarshals the element into v
+func (r RawXML) Decode(v interface{}) error {
+	return xml.Unmarshal(r, v)
+}
+
+// Pacs008Headers is a pacs.008 parsed in header-only mode, for gateways that route
+// on the group header and payment identifications alone. The transactions are
+// kept as RawXML and decoded in full only when asked for.
+type Pacs008Headers struct {
+	GroupHeader  GroupHeader93
+	Transactions []TransactionHeader
+}
+
+// TransactionHeader is the payment identification of a pacs.008 transaction, along
+// with the whole CdtTrfTxInf element
+type TransactionHeader struct {
+	PaymentID PaymentIdentification7
+	Raw       RawXML
+}
+
+// ParsePacs008Headers parses a pacs.008 in header-only mode, decoding the group
+// header and the PmtId of every transaction. Everything else is only scanned for
+// the end of its element, which allocates a fraction of what a full xml.Unmarshal
+// does; the skipped elements are checked for well-formedness only when decoded.
+// The RawXML of the transactions share data rather than copy it, so data must not be
+// modified while they are in use.
+func ParsePacs008Headers(data []byte) (*Pacs008Headers, error) {
+	// The document element is read by a decoder, which resolves its namespace
+	d := xml.NewDecoder(bytes.NewReader(data))
+	var root xml.StartElement
+	for {
+		tok, err := d.Token()
+		if err == io.EOF {
+			return nil, io.ErrUnexpectedEOF
+		}
+		if err != nil {
+			return nil, err
+		}
+		if start, ok := tok.(xml.StartElement); ok {
+			root = start
+			break
+		}
+	}
+	if root.Name.Local != "Document" || root.Name.Space != pacs008Namespace {
+		return nil, fmt.Errorf("expected element <Document xmlns=%q> but have <%s xmlns=%q>", pacs008Namespace, root.Name.Local, root.Name.Space)
+	}
+
+	h := new(Pacs008Headers)
+	depth := 1
+	for pos := int(d.InputOffset()); depth > 0; {
+		tag, err := nextTag(data, pos)
+		if err != nil {
+			return nil, err
+		}
+		pos = tag.end
+		switch {
+		case tag.closing:
+			depth--
+			continue
+		case tag.selfClosing:
+			continue
+		}
+		depth++
+		if depth == 2 {
+			if string(tag.local) != "FIToFICstmrCdtTrf" {
+				return nil, fmt.Errorf("expected element <FIToFICstmrCdtTrf> but have <%s>", tag.local)
+			}
+			continue
+		}
+		end, err := elementEnd(data, tag.end)
+		if err != nil {
+			return nil, err
+		}
+		switch string(tag.local) {
+		case "GrpHdr":
+			if err := xml.Unmarshal(data[tag.start:end], &h.GroupHeader); err != nil {
+				return nil, err
+			}
+		case "CdtTrfTxInf":
+			tx, err := transactionHeader(data, tag, end)
+			if err != nil {
+				return nil, fmt.Errorf("transaction %d: %w", len(h.Transactions)+1, err)
+			}
+			h.Transactions = append(h.Transactions, tx)
+		}
+		pos, depth = end, depth-1
+	}
+	return h, nil
+}
+
+// transactionHeader decodes the PmtId of the CdtTrfTxInf element that starts with
+// tag and ends at end
+func transactionHeader(data []byte, tag xmlTag, end int) (TransactionHeader, error) {
+	tx := TransactionHeader{Raw: RawXML(data[tag.start:end])}
+	for pos := tag.end; ; {
+		child, err := nextTag(data, pos)
+		if err != nil || child.closing {
+			return tx, err
+		}
+		if child.selfClosing {
+			pos = child.end
+			continue
+		}
+		childEnd, err := elementEnd(data, child.end)
+		if err != nil {
+			return tx, err
+		}
+		if string(child.local) == "PmtId" {
+			return tx, xml.Unmarshal(data[child.start:childEnd], &tx.PaymentID)
+		}
+		pos = childEnd
+	}
+}
+
+// xmlTag is a start or end tag found by nextTag, from start up to end
+type xmlTag struct {
+	start, end  int
+	local       []byte // name without its prefix
+	closing     bool
+	selfClosing bool
+}
+
+// nextTag returns the first start or end tag of data at or after pos, passing over
+// text, comments, CDATA sections, processing instructions and declarations
+func nextTag(data []byte, pos int) (xmlTag, error) {
+	for {
+		i := bytes.IndexByte(data[pos:], '<')
+		if i < 0 {
+			return xmlTag{}, io.ErrUnexpectedEOF
+		}
+		pos += i
+		rest := data[pos:]
+		var terminator string
+		switch {
+		case bytes.HasPrefix(rest, []byte("<!--")):
+			terminator = "-->"
+		case bytes.HasPrefix(rest, []byte("<![CDATA[")):
+			terminator = "]]>"
+		case bytes.HasPrefix(rest, []byte("<?")):
+			terminator = "?>"
+		case bytes.HasPrefix(rest, []byte("<!")):
+			terminator = ">"
+		}
+		if terminator != "" {
+			j := bytes.Index(rest, []byte(terminator))
+			if j < 0 {
+				return xmlTag{}, io.ErrUnexpectedEOF
+			}
+			pos += j + len(terminator)
+			continue
+		}
+
+		tag := xmlTag{start: pos}
+		name := pos + 1
+		if tag.closing = len(rest) > 1 && rest[1] == '/'; tag.closing {
+			name++
+		}
+		// Find the closing >, which may appear inside quoted attribute values
+		var quote byte
+		end := -1
+		for j := name; j < len(data) && end < 0; j++ {
+			switch c := data[j]; {
+			case quote != 0:
+				if c == quote {
+					quote = 0
+				}
+			case c == '"' || c == '\'':
+				quote = c
+			case c == '>':
+				end = j
+			}
+		}
+		if end < 0 {
+			return xmlTag{}, io.ErrUnexpectedEOF
+		}
+		tag.end = end + 1
+		tag.selfClosing = !tag.closing && data[end-1] == '/'
+		n := name
+		for n < end && !isTagNameEnd(data[n]) {
+			n++
+		}
+		tag.local = data[name:n]
+		if k := bytes.IndexByte(tag.local, ':'); k >= 0 {
+			tag.local = tag.local[k+1:]
+		}
+		if len(tag.local) == 0 {
+			return xmlTag{}, fmt.Errorf("malformed tag at offset %d", pos)
+		}
+		return tag, nil
+	}
+}
+
+// isTagNameEnd reports whether c ends the name of a tag
+func isTagNameEnd(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '/' || c == '>'
+}
+
+// elementEnd returns the offset just past the end tag of the element whose start
+// tag ends at pos
+func elementEnd(data []byte, pos int) (int, error) {
+	for depth := 1; ; {
+		tag, err := nextTag(data, pos)
+		if err != nil {
+			return 0, err
+		}
+		pos = tag.end
+		switch {
+		case tag.closing:
+			if depth--; depth == 0 {
+				return pos, nil
+			}
+		case !tag.selfClosing:
+			depth++
+		}
+	}
+}
+
+// Decode decodes the transaction in full
+func (t *TransactionHeader) Decode() (CreditTransferTransaction39, error) {
+	var tx CreditTransferTransaction39
+	err := t.Raw.Decode(&tx)
+	return tx, err
+}
+
+// Document decodes every transaction in full and returns the pacs.008 they make up
+// with the group header. The supplementary data of the message is not kept.
+func (h *Pacs008Headers) Document() (*Pacs00800108Document, error) {
+	doc := &Pacs00800108Document{FICustomerCreditTransfer: FIToFICustomerCreditTransferV08{
+		GroupHeader:                   h.GroupHeader,
+		CreditTransferTransactionInfo: make([]CreditTransferTransaction39, len(h.Transactions)),
+	}}
+	for i := range h.Transactions {
+		tx, err := h.Transactions[i].Decode()
+		if err != nil {
+			return nil, fmt.Errorf("transaction %d: %w", i+1, err)
+		}
+		doc.FICustomerCreditTransfer.CreditTransferTransactionInfo[i] = tx
+	}
+	return doc, nil
+}
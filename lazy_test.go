@@ -0,0 +1,67 @@
+package iso20022
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParsePacs008Headers(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("testdata", "roundtrip", "pacs.008.001.08", "customer_credit_transfer.xml"))
+	if err != nil {
+		t.Fatalf("Failed to read fixture: %v", err)
+	}
+	h, err := ParsePacs008Headers(data)
+	if err != nil {
+		t.Fatalf("ParsePacs008Headers failed: %v", err)
+	}
+	if h.GroupHeader.MessageID != "BBBBUS33-20240315-0001" || len(h.Transactions) != 1 {
+		t.Fatalf("Unexpected headers %+v", h)
+	}
+	tx := h.Transactions[0]
+	if tx.PaymentID.EndToEndID != "INV-2024-0042" || *tx.PaymentID.UETR != "8a562c67-ca16-48ba-b074-65581be6f011" {
+		t.Errorf("Unexpected payment identification %+v", tx.PaymentID)
+	}
+	if !strings.HasPrefix(string(tx.Raw), "<CdtTrfTxInf>") || !strings.HasSuffix(string(tx.Raw), "</CdtTrfTxInf>") {
+		t.Errorf("Expected the raw transaction element, got %s", tx.Raw)
+	}
+
+	doc, err := h.Document()
+	if err != nil {
+		t.Fatalf("Document failed: %v", err)
+	}
+	want := new(Pacs00800108Document)
+	if err := xml.Unmarshal(data, want); err != nil {
+		t.Fatal(err)
+	}
+	doc.XMLName = want.XMLName
+	if !reflect.DeepEqual(doc, want) {
+		t.Errorf("Expected the full decode to match xml.Unmarshal\n%+v\nwant\n%+v", doc, want)
+	}
+
+	prefixed := `<?xml version="1.0"?><!-- routed --><p:Document xmlns:p="urn:iso:std:iso:20022:tech:xsd:pacs.008.001.08"><p:FIToFICstmrCdtTrf>
+<p:GrpHdr><p:MsgId>MSG-1</p:MsgId><p:NbOfTxs>2</p:NbOfTxs><p:SttlmInf><p:SttlmMtd>CLRG</p:SttlmMtd></p:SttlmInf></p:GrpHdr>
+<p:CdtTrfTxInf><p:PmtTpInf/><p:IntrBkSttlmAmt Ccy="EUR">1</p:IntrBkSttlmAmt><p:PmtId><p:EndToEndId>E2E-1</p:EndToEndId></p:PmtId></p:CdtTrfTxInf>
+<p:CdtTrfTxInf><p:PmtId><p:EndToEndId><![CDATA[E2E-<2>]]></p:EndToEndId></p:PmtId><p:Purp><p:Prtry a="x>y">A</p:Prtry></p:Purp></p:CdtTrfTxInf>
+</p:FIToFICstmrCdtTrf></p:Document>`
+	h, err = ParsePacs008Headers([]byte(prefixed))
+	if err != nil {
+		t.Fatalf("ParsePacs008Headers failed on a prefixed document: %v", err)
+	}
+	if h.GroupHeader.MessageID != "MSG-1" || len(h.Transactions) != 2 || h.Transactions[0].PaymentID.EndToEndID != "E2E-1" ||
+		h.Transactions[1].PaymentID.EndToEndID != "E2E-<2>" || !strings.HasSuffix(string(h.Transactions[1].Raw), "</p:Purp></p:CdtTrfTxInf>") {
+		t.Errorf("Unexpected headers of a prefixed document %+v", h)
+	}
+
+	if _, err := ParsePacs008Headers([]byte(`<Document xmlns="urn:iso:std:iso:20022:tech:xsd:pacs.002.001.10"/>`)); err == nil {
+		t.Error("Expected an error for another message")
+	}
+	if _, err := ParsePacs008Headers(data[:len(data)/2]); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("Expected an error for a truncated message, got %v", err)
+	}
+}
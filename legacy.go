@@ -0,0 +1,77 @@
+package iso20022
+
+// PostalAddress24 returns the address as a PostalAddress24, or nil for a nil address
+func (a *PostalAddress) PostalAddress24() *PostalAddress24 {
+	if a == nil {
+		return nil
+	}
+	return &PostalAddress24{
+		AddressType:        a.AddressType,
+		Department:         a.Department,
+		SubDepartment:      a.SubDepartment,
+		StreetName:         a.StreetName,
+		BuildingNumber:     a.BuildingNumber,
+		BuildingName:       a.BuildingName,
+		Floor:              a.Floor,
+		PostBox:            a.PostBox,
+		Room:               a.Room,
+		PostCode:           a.PostalCode,
+		TownName:           a.TownName,
+		TownLocationName:   a.TownLocationName,
+		DistrictName:       a.DistrictName,
+		CountrySubDivision: a.CountrySubDivision,
+		Country:            a.Country,
+		AddressLine:        a.AddressLines,
+	}
+}
+
+// Legacy returns the address as a PostalAddress, or nil for a nil address
+func (a *PostalAddress24) Legacy() *PostalAddress {
+	if a == nil {
+		return nil
+	}
+	return &PostalAddress{
+		AddressType:        a.AddressType,
+		Department:         a.Department,
+		SubDepartment:      a.SubDepartment,
+		StreetName:         a.StreetName,
+		BuildingNumber:     a.BuildingNumber,
+		BuildingName:       a.BuildingName,
+		Floor:              a.Floor,
+		PostBox:            a.PostBox,
+		Room:               a.Room,
+		PostalCode:         a.PostCode,
+		TownName:           a.TownName,
+		TownLocationName:   a.TownLocationName,
+		DistrictName:       a.DistrictName,
+		CountrySubDivision: a.CountrySubDivision,
+		Country:            a.Country,
+		AddressLines:       a.AddressLine,
+	}
+}
+
+// PaymentIdentification7 returns the identification as a PaymentIdentification7,
+// leaving out an empty transaction identification
+func (p PaymentIdentification) PaymentIdentification7() PaymentIdentification7 {
+	id := PaymentIdentification7{
+		InstructionID: p.InstructionID,
+		EndToEndID:    p.EndToEndID,
+		UETR:          p.UETR,
+	}
+	if p.TransactionID != "" {
+		txID := p.TransactionID
+		id.TransactionID = &txID
+	}
+	return id
+}
+
+// Legacy returns the identification as a PaymentIdentification. The clearing
+// system reference, which PaymentIdentification has no field for, is left out.
+func (p PaymentIdentification7) Legacy() PaymentIdentification {
+	return PaymentIdentification{
+		InstructionID: p.InstructionID,
+		EndToEndID:    p.EndToEndID,
+		TransactionID: deref(p.TransactionID),
+		UETR:          p.UETR,
+	}
+}
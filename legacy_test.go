@@ -0,0 +1,40 @@
+package iso20022
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLegacyPostalAddress(t *testing.T) {
+	a := &PostalAddress{
+		StreetName:   Ptr("Main Street"),
+		PostalCode:   Ptr("10001"),
+		Country:      Ptr("US"),
+		AddressLines: []string{"Suite 100"},
+	}
+	b := a.PostalAddress24()
+	if deref(b.PostCode) != "10001" || len(b.AddressLine) != 1 || deref(b.StreetName) != "Main Street" {
+		t.Errorf("Unexpected address: %+v", b)
+	}
+	if back := b.Legacy(); !reflect.DeepEqual(back, a) {
+		t.Errorf("Expected %+v, got %+v", a, back)
+	}
+	if (*PostalAddress)(nil).PostalAddress24() != nil || (*PostalAddress24)(nil).Legacy() != nil {
+		t.Error("Expected nil addresses to stay nil")
+	}
+}
+
+func TestLegacyPaymentIdentification(t *testing.T) {
+	p := PaymentIdentification{EndToEndID: "INV-2024-0042", TransactionID: "TX-1"}
+	p7 := p.PaymentIdentification7()
+	if deref(p7.TransactionID) != "TX-1" || p7.EndToEndID != "INV-2024-0042" {
+		t.Errorf("Unexpected identification: %+v", p7)
+	}
+	p7.ClearingSystemReference = Ptr("CLR-1")
+	if back := p7.Legacy(); !reflect.DeepEqual(back, p) {
+		t.Errorf("Expected %+v, got %+v", p, back)
+	}
+	if (PaymentIdentification{EndToEndID: "E2E"}).PaymentIdentification7().TransactionID != nil {
+		t.Error("Expected an empty transaction identification to be left out")
+	}
+}
@@ -0,0 +1,208 @@
+package iso20022
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// LEIRecord is the reference data of a Legal Entity Identifier, as published by
+// GLEIF
+type LEIRecord struct {
+	LEI                string
+	LegalName          string
+	Country            string // country of the legal address
+	EntityStatus       string // ACTIVE or INACTIVE
+	RegistrationStatus string // ISSUED, LAPSED, RETIRED, ANNULLED, MERGED, DUPLICATE, ...
+}
+
+// Usable reports whether the LEI may still identify its entity in a payment. Lapsed
+// LEIs remain usable; retired, merged, duplicate and annulled ones do not, nor do
+// LEIs of inactive entities.
+func (r LEIRecord) Usable() bool {
+	switch strings.ToUpper(r.RegistrationStatus) {
+	case "RETIRED", "MERGED", "DUPLICATE", "ANNULLED":
+		return false
+	}
+	return !strings.EqualFold(r.EntityStatus, "INACTIVE")
+}
+
+// LEIResolver looks up LEIs in GLEIF data or a local copy of it. LookupLEI reports
+// whether the LEI is known; an error means the registry could not be consulted.
+type LEIResolver interface {
+	LookupLEI(lei string) (LEIRecord, bool, error)
+}
+
+// LEIContextResolver is an LEIResolver whose lookups can be cancelled. ValidateContext
+// and Validate pass their context to LookupLEIContext instead of calling LookupLEI.
+type LEIContextResolver interface {
+	LEIResolver
+	LookupLEIContext(ctx context.Context, lei string) (LEIRecord, bool, error)
+}
+
+// leiResolverHolder lets atomic.Pointer store an interface value
+type leiResolverHolder struct {
+	resolver LEIResolver
+}
+
+// defaultLEIResolver is consulted wherever Validate checks an LEI
+var defaultLEIResolver atomic.Pointer[leiResolverHolder]
+
+// SetLEIResolver makes Validate check every LEI against r, reporting LEIs that are
+// unknown or no longer usable. Passing nil restores the default of checking the
+// format and check digits only.
+func SetLEIResolver(r LEIResolver) {
+	if r == nil {
+		defaultLEIResolver.Store(nil)
+		return
+	}
+	defaultLEIResolver.Store(&leiResolverHolder{resolver: r})
+}
+
+// validateLEIRegistry checks a well-formed LEI against the configured resolver
+func validateLEIRegistry(ctx context.Context, lei string, fieldName string) error {
+	holder := defaultLEIResolver.Load()
+	// a validation whose context is done is abandoned, with the error of the context
+	if holder == nil || ctx.Err() != nil {
+		return nil
+	}
+	lookup := holder.resolver.LookupLEI
+	if r, ok := holder.resolver.(LEIContextResolver); ok {
+		lookup = func(lei string) (LEIRecord, bool, error) { return r.LookupLEIContext(ctx, lei) }
+	}
+	record, ok, err := lookup(lei)
+	switch {
+	case err != nil:
+		return newValidationError(fieldName, RuleLEIRegistry, "LEI_REGISTRY_ERROR", err)
+	case !ok:
+		return newValidationError(fieldName, RuleLEIRegistry, "LEI_REGISTRY_UNKNOWN", lei)
+	case !record.Usable():
+		return newValidationError(fieldName, RuleLEIRegistry, "LEI_REGISTRY_UNUSABLE", lei, record.RegistrationStatus, record.EntityStatus)
+	}
+	return nil
+}
+
+// EnrichFromLEI fills in the name of the institution with the legal name registered
+// for its LEI, keeping a name already present. It reports whether the LEI was found.
+func (f *FinancialInstitutionIdentification18) EnrichFromLEI(r LEIResolver) (bool, error) {
+	if f.LegalEntityIdentifier == nil {
+		return false, nil
+	}
+	record, ok, err := r.LookupLEI(*f.LegalEntityIdentifier)
+	if err != nil || !ok {
+		return false, err
+	}
+	if f.Name == nil && record.LegalName != "" {
+		name := record.LegalName
+		f.Name = &name
+	}
+	return true, nil
+}
+
+// LEIDirectory is an in-memory LEIResolver. It is safe for concurrent use.
+type LEIDirectory struct {
+	mu      sync.RWMutex
+	records map[string]LEIRecord
+}
+
+// NewLEIDirectory returns an empty directory
+func NewLEIDirectory() *LEIDirectory {
+	return &LEIDirectory{records: make(map[string]LEIRecord)}
+}
+
+// Add stores record, replacing any entry for the same LEI
+func (d *LEIDirectory) Add(record LEIRecord) {
+	record.LEI = strings.ToUpper(strings.TrimSpace(record.LEI))
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.records[record.LEI] = record
+}
+
+// Len returns the number of entries
+func (d *LEIDirectory) Len() int {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return len(d.records)
+}
+
+// LookupLEI implements LEIResolver
+func (d *LEIDirectory) LookupLEI(lei string) (LEIRecord, bool, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	record, ok := d.records[strings.ToUpper(strings.TrimSpace(lei))]
+	return record, ok, nil
+}
+
+// leiCSVColumns lists, for each LEIRecord field, the accepted column names: the
+// GLEIF golden copy header first, then a short form
+var leiCSVColumns = map[string][]string{
+	"lei":                 {"lei"},
+	"legal_name":          {"entity.legalname", "legal_name"},
+	"country":             {"entity.legaladdress.country", "country"},
+	"entity_status":       {"entity.entitystatus", "entity_status"},
+	"registration_status": {"registration.registrationstatus", "registration_status"},
+}
+
+// LoadLEIDirectoryCSV reads a directory from CSV with a header row, either the GLEIF
+// LEI-CDF golden copy (LEI, Entity.LegalName, Entity.LegalAddress.Country,
+// Entity.EntityStatus, Registration.RegistrationStatus) or the short columns lei,
+// legal_name, country, entity_status and registration_status. Only the lei column is
+// required and other columns are ignored. Every LEI must have valid check digits.
+func LoadLEIDirectoryCSV(r io.Reader) (*LEIDirectory, error) {
+	cr := csv.NewReader(r)
+	cr.TrimLeadingSpace = true
+	header, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("reading LEI directory header: %w", err)
+	}
+	positions := make(map[string]int)
+	for i, name := range header {
+		positions[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	columns := make(map[string]int)
+	for field, names := range leiCSVColumns {
+		for _, name := range names {
+			if i, ok := positions[name]; ok {
+				columns[field] = i
+				break
+			}
+		}
+	}
+	if _, ok := columns["lei"]; !ok {
+		return nil, fmt.Errorf("LEI directory has no LEI column")
+	}
+
+	dir := NewLEIDirectory()
+	for {
+		row, err := cr.Read()
+		if err == io.EOF {
+			return dir, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading LEI directory: %w", err)
+		}
+		line, _ := cr.FieldPos(0)
+		value := func(field string) string {
+			if i, ok := columns[field]; ok && i < len(row) {
+				return strings.TrimSpace(row[i])
+			}
+			return ""
+		}
+
+		record := LEIRecord{
+			LEI:                strings.ToUpper(value("lei")),
+			LegalName:          value("legal_name"),
+			Country:            value("country"),
+			EntityStatus:       value("entity_status"),
+			RegistrationStatus: value("registration_status"),
+		}
+		if err := validateLEI(record.LEI, "LEI"); err != nil {
+			return nil, fmt.Errorf("LEI directory line %d: %w", line, err)
+		}
+		dir.Add(record)
+	}
+}
@@ -0,0 +1,100 @@
+package iso20022
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+const testLEIDirectoryCSV = `LEI,Entity.LegalName,Entity.LegalAddress.Country,Entity.EntityStatus,Registration.RegistrationStatus
+5493001KJTIIGC8Y1R12,Bank B Corporation,US,ACTIVE,ISSUED
+529900T8BM49AURSDO55,Lapsed Holdings GmbH,DE,ACTIVE,LAPSED
+213800LBQA1Y9L22JB70,Retired Trading Ltd,GB,INACTIVE,RETIRED
+`
+
+func TestValidateLEI(t *testing.T) {
+	for _, lei := range []string{"5493001KJTIIGC8Y1R12", "529900T8BM49AURSDO55", "HWUPKR0MPOU8FGXBT394"} {
+		if err := ValidateLEI(lei); err != nil {
+			t.Errorf("ValidateLEI(%q) = %v, want nil", lei, err)
+		}
+	}
+	tests := []struct {
+		lei     string
+		message string
+	}{
+		{"5493001KJTIIGC8Y1R13", "check digits are invalid"},
+		{"12345678901234567890", "check digits are invalid"},
+		{"5493001KJTIIGC8Y1R1", "length 19 is below minimum 20"},
+		{"5493001kjtiigc8y1r12", "does not match required pattern"},
+	}
+	for _, tt := range tests {
+		err := ValidateLEI(tt.lei)
+		if err == nil || !strings.Contains(err.Error(), tt.message) {
+			t.Errorf("ValidateLEI(%q) = %v, want error containing %q", tt.lei, err, tt.message)
+		}
+	}
+}
+
+func TestLoadLEIDirectoryCSV(t *testing.T) {
+	dir, err := LoadLEIDirectoryCSV(strings.NewReader(testLEIDirectoryCSV))
+	if err != nil {
+		t.Fatalf("Failed to load directory: %v", err)
+	}
+	if dir.Len() != 3 {
+		t.Fatalf("Expected 3 entries, got %d", dir.Len())
+	}
+	record, ok, _ := dir.LookupLEI("5493001KJTIIGC8Y1R12")
+	if !ok || record.LegalName != "Bank B Corporation" || record.Country != "US" || !record.Usable() {
+		t.Errorf("Unexpected record %+v", record)
+	}
+
+	short := "lei,legal_name\nHWUPKR0MPOU8FGXBT394,Short Form SA\n"
+	if dir, err := LoadLEIDirectoryCSV(strings.NewReader(short)); err != nil || dir.Len() != 1 {
+		t.Errorf("Expected the short column names to load, got %v", err)
+	}
+	for _, bad := range []string{"name\nBank", "lei\n5493001KJTIIGC8Y1R13"} {
+		if _, err := LoadLEIDirectoryCSV(strings.NewReader(bad)); err == nil {
+			t.Errorf("Expected an error loading %q", bad)
+		}
+	}
+}
+
+func TestValidateWithLEIResolver(t *testing.T) {
+	dir, err := LoadLEIDirectoryCSV(strings.NewReader(testLEIDirectoryCSV))
+	if err != nil {
+		t.Fatalf("Failed to load directory: %v", err)
+	}
+	SetLEIResolver(dir)
+	defer SetLEIResolver(nil)
+
+	tests := []struct {
+		lei     string
+		message string
+	}{
+		{"5493001KJTIIGC8Y1R12", ""},
+		{"529900T8BM49AURSDO55", ""},
+		{"213800LBQA1Y9L22JB70", "is not usable"},
+		{"HWUPKR0MPOU8FGXBT394", "is not in the LEI registry"},
+	}
+	for _, tt := range tests {
+		fi := FinancialInstitutionIdentification18{LegalEntityIdentifier: Ptr(tt.lei)}
+		err := fi.Validate()
+		if tt.message == "" {
+			if err != nil {
+				t.Errorf("Expected %s to validate, got %v", tt.lei, err)
+			}
+		} else if err == nil || !strings.Contains(err.Error(), tt.message) {
+			t.Errorf("Expected %s to fail with %q, got %v", tt.lei, tt.message, err)
+		} else if errs := ValidationErrors(nil); !errors.As(err, &errs) || errs[0].Rule != RuleLEIRegistry {
+			t.Errorf("Expected %s to fail the %s rule, got %+v", tt.lei, RuleLEIRegistry, errs)
+		}
+	}
+
+	fi := FinancialInstitutionIdentification18{LegalEntityIdentifier: Ptr("5493001KJTIIGC8Y1R12")}
+	if found, err := fi.EnrichFromLEI(dir); !found || err != nil {
+		t.Fatalf("Expected the LEI to be found, got %v, %v", found, err)
+	}
+	if fi.Name == nil || *fi.Name != "Bank B Corporation" {
+		t.Errorf("Expected the legal name to be filled in, got %v", fi.Name)
+	}
+}
@@ -0,0 +1,376 @@
+// Package liquidity computes intraday liquidity metrics of accounts from the entries
+// their servicers report in camt.052 account reports and camt.054 notifications,
+// along the lines of the BCBS 248 monitoring tools for intraday liquidity management.
+//
+// A Monitor collects the booked movements of every account and currency and, for
+// every day, replays them in time order from the opening balance to give the
+// running balance, the largest net debit and net credit positions reached, the
+// time-weighted average net debit over the business day, the gross payments sent and
+// received and the throughput of the payments sent by the hour.
+package liquidity
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ckbaum/iso20022-go"
+)
+
+// ErrUnsupportedDocument is returned by AddDocument for documents other than camt.052
+// and camt.054
+var ErrUnsupportedDocument = errors.New("liquidity: unsupported document")
+
+// Movement is a booking on an account
+type Movement struct {
+	Account  string
+	Currency string
+	// Time is when the movement was booked. Movements reported with a date only are
+	// at the start of that day and have Untimed set.
+	Time    time.Time
+	Untimed bool
+	// Amount is positive for credits and negative for debits
+	Amount iso20022.Decimal
+	// Reversal marks the reversal of an earlier movement, which is not a payment
+	Reversal bool
+	// Reference identifies the movement across reports, such as the account servicer
+	// reference of the entry; movements with a reference already added are ignored
+	Reference string
+}
+
+// DailyMetrics are the intraday liquidity metrics of an account in a currency for a
+// day. The net position is the sum of the movements of the day, negative when more
+// was paid than received; usage is the net debit position, the opposite of a
+// negative net position.
+type DailyMetrics struct {
+	Account  string
+	Currency string
+	Date     iso20022.ISODate
+
+	OpeningBalance iso20022.Decimal
+	ClosingBalance iso20022.Decimal
+	LowestBalance  iso20022.Decimal
+	HighestBalance iso20022.Decimal
+
+	// PeakNetDebit is the largest usage of the day, with the time it was reached,
+	// zero when the position never was a net debit (BCBS 248 daily maximum intraday
+	// liquidity usage)
+	PeakNetDebit     iso20022.Decimal
+	PeakNetDebitTime time.Time
+	// PeakNetCredit is the largest net credit position of the day
+	PeakNetCredit     iso20022.Decimal
+	PeakNetCreditTime time.Time
+	// TimeWeightedUsage is the usage averaged over the business day
+	TimeWeightedUsage iso20022.Decimal
+
+	// GrossSent and GrossReceived are the totals of the debits and credits, without
+	// reversals (BCBS 248 total payments)
+	GrossSent     iso20022.Decimal
+	GrossReceived iso20022.Decimal
+	Sent          int
+	Received      int
+	// Throughput is, for every hour of the day, the share of GrossSent paid before
+	// the end of that hour, from 0 to 1
+	Throughput [24]float64
+
+	// Untimed is the number of movements reported with a date only, counted at the
+	// start of the day
+	Untimed int
+}
+
+// position is an account in a currency on a day
+type position struct {
+	account  string
+	currency string
+	date     iso20022.ISODate
+}
+
+// Monitor collects movements and computes their daily metrics. It is safe for
+// concurrent use.
+type Monitor struct {
+	mu        sync.Mutex
+	location  *time.Location
+	open      time.Duration
+	close     time.Duration
+	movements map[position][]Movement
+	opening   map[position]iso20022.Decimal
+	seen      map[string]bool
+}
+
+// Option configures a Monitor
+type Option func(*Monitor)
+
+// WithLocation sets the time zone the days of the account are counted in, UTC by
+// default
+func WithLocation(loc *time.Location) Option {
+	return func(m *Monitor) { m.location = loc }
+}
+
+// WithBusinessDay sets the hours of the business day usage is averaged over, as
+// offsets from midnight, such as 7 and 18 hours for TARGET2. The default is the
+// whole day.
+func WithBusinessDay(open, close time.Duration) Option {
+	return func(m *Monitor) { m.open, m.close = open, close }
+}
+
+// NewMonitor returns a monitor without movements
+func NewMonitor(opts ...Option) *Monitor {
+	m := &Monitor{
+		location:  time.UTC,
+		close:     24 * time.Hour,
+		movements: make(map[position][]Movement),
+		opening:   make(map[position]iso20022.Decimal),
+		seen:      make(map[string]bool),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// day returns the date of t in the location of the monitor
+func (m *Monitor) day(t time.Time) iso20022.ISODate {
+	y, mo, d := t.In(m.location).Date()
+	return iso20022.NewISODate(y, mo, d)
+}
+
+// SetOpeningBalance sets the balance of an account at the start of a day, negative
+// for a debit balance. Without one, the day opens at zero.
+func (m *Monitor) SetOpeningBalance(account, currency string, date iso20022.ISODate, balance iso20022.Decimal) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.opening[position{account, currency, date}] = balance
+}
+
+// Add adds a movement. It reports false for a movement whose reference was added
+// before.
+func (m *Monitor) Add(mv Movement) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if mv.Reference != "" {
+		key := mv.Account + "\x00" + mv.Currency + "\x00" + mv.Reference
+		if m.seen[key] {
+			return false
+		}
+		m.seen[key] = true
+	}
+	p := position{mv.Account, mv.Currency, m.day(mv.Time)}
+	m.movements[p] = append(m.movements[p], mv)
+	return true
+}
+
+// AddDocument adds the booked entries of the reports of a camt.052 or the
+// notifications of a camt.054, and takes the opening balances (OPBD) of the reports
+// that have them. Pending and information entries are left out. It returns the
+// number of movements added.
+func (m *Monitor) AddDocument(doc interface{}) (int, error) {
+	added := 0
+	switch d := doc.(type) {
+	case *iso20022.Camt05200108Document:
+		for _, report := range d.BankAccountReport.Report {
+			account := report.Account.ID.Identifier()
+			for _, bal := range report.Balance {
+				if bal.Type.CodeOrProprietary.Code != nil && *bal.Type.CodeOrProprietary.Code == "OPBD" && bal.Date.Date != nil {
+					m.SetOpeningBalance(account, bal.Amount.Currency, *bal.Date.Date, signed(bal.Amount.Value, bal.CreditDebitIndicator))
+				}
+			}
+			added += m.addEntries(account, report.Entry)
+		}
+	case *iso20022.Camt05400108Document:
+		for _, ntfctn := range d.BankDebitCreditNotification.Notification {
+			added += m.addEntries(ntfctn.Account.ID.Identifier(), ntfctn.Entry)
+		}
+	default:
+		return 0, fmt.Errorf("%w: %T", ErrUnsupportedDocument, doc)
+	}
+	return added, nil
+}
+
+// addEntries adds the booked entries of an account
+func (m *Monitor) addEntries(account string, entries []iso20022.ReportEntry10) int {
+	added := 0
+	for _, entry := range entries {
+		if entry.Status.Code == nil || *entry.Status.Code != "BOOK" {
+			continue
+		}
+		mv := Movement{
+			Account:  account,
+			Currency: entry.Amount.Currency,
+			Amount:   signed(entry.Amount.Value, entry.CreditDebitIndicator),
+			Reversal: entry.ReversalIndicator != nil && *entry.ReversalIndicator,
+		}
+		switch {
+		case entry.AccountServicerReference != nil:
+			mv.Reference = *entry.AccountServicerReference
+		case entry.EntryReference != nil:
+			mv.Reference = *entry.EntryReference
+		}
+		mv.Time, mv.Untimed = m.entryTime(entry)
+		if m.Add(mv) {
+			added++
+		}
+	}
+	return added
+}
+
+// entryTime returns the booking time of an entry, or its value time, or the start of
+// its booking or value date
+func (m *Monitor) entryTime(entry iso20022.ReportEntry10) (time.Time, bool) {
+	var date *iso20022.ISODate
+	for _, d := range []*iso20022.DateAndDateTime2{entry.BookingDate, entry.ValueDate} {
+		if d == nil {
+			continue
+		}
+		if d.DateTime != nil {
+			return d.DateTime.Time, false
+		}
+		if date == nil && d.Date != nil {
+			date = d.Date
+		}
+	}
+	if date == nil {
+		return time.Time{}, true
+	}
+	y, mo, d := date.Date()
+	return time.Date(y, mo, d, 0, 0, 0, 0, m.location), true
+}
+
+// Metrics returns the metrics of every account, currency and day with movements or
+// an opening balance, ordered by account, currency and date
+func (m *Monitor) Metrics() []DailyMetrics {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	positions := make(map[position]bool)
+	for p := range m.movements {
+		positions[p] = true
+	}
+	for p := range m.opening {
+		positions[p] = true
+	}
+	metrics := make([]DailyMetrics, 0, len(positions))
+	for p := range positions {
+		metrics = append(metrics, m.compute(p))
+	}
+	sort.Slice(metrics, func(i, j int) bool {
+		a, b := metrics[i], metrics[j]
+		if a.Account != b.Account {
+			return a.Account < b.Account
+		}
+		if a.Currency != b.Currency {
+			return a.Currency < b.Currency
+		}
+		return a.Date.Before(b.Date.Time)
+	})
+	return metrics
+}
+
+// compute replays the movements of a position in time order
+func (m *Monitor) compute(p position) DailyMetrics {
+	movements := append([]Movement(nil), m.movements[p]...)
+	sort.SliceStable(movements, func(i, j int) bool { return movements[i].Time.Before(movements[j].Time) })
+
+	y, mo, d := p.date.Date()
+	midnight := time.Date(y, mo, d, 0, 0, 0, 0, m.location)
+	open, close := midnight.Add(m.open), midnight.Add(m.close)
+
+	opening := iso20022.DecimalRat(m.opening[p])
+	balance, net := new(big.Rat).Set(opening), new(big.Rat)
+	lowest, highest := new(big.Rat).Set(opening), new(big.Rat).Set(opening)
+	peakDebit, peakCredit := new(big.Rat), new(big.Rat)
+	sent, received := new(big.Rat), new(big.Rat)
+	usage := new(big.Rat) // integral of the usage over the business day, in amount seconds
+	var sentBy [24]*big.Rat
+	for i := range sentBy {
+		sentBy[i] = new(big.Rat)
+	}
+	metrics := DailyMetrics{Account: p.account, Currency: p.currency, Date: p.date}
+
+	last := open
+	for _, mv := range movements {
+		// The usage until the movement
+		if at := clamp(mv.Time, open, close); at.After(last) {
+			if net.Sign() < 0 {
+				usage.Add(usage, new(big.Rat).Mul(new(big.Rat).Neg(net), big.NewRat(int64(at.Sub(last)/time.Second), 1)))
+			}
+			last = at
+		}
+
+		amount := iso20022.DecimalRat(mv.Amount)
+		balance.Add(balance, amount)
+		net.Add(net, amount)
+		if balance.Cmp(lowest) < 0 {
+			lowest.Set(balance)
+		}
+		if balance.Cmp(highest) > 0 {
+			highest.Set(balance)
+		}
+		if debit := new(big.Rat).Neg(net); debit.Cmp(peakDebit) > 0 {
+			peakDebit, metrics.PeakNetDebitTime = debit, mv.Time
+		}
+		if net.Cmp(peakCredit) > 0 {
+			peakCredit.Set(net)
+			metrics.PeakNetCreditTime = mv.Time
+		}
+		if mv.Untimed {
+			metrics.Untimed++
+		}
+		if mv.Reversal {
+			continue
+		}
+		switch {
+		case amount.Sign() < 0:
+			paid := new(big.Rat).Neg(amount)
+			sent.Add(sent, paid)
+			metrics.Sent++
+			for h := mv.Time.In(m.location).Hour(); h < 24; h++ {
+				sentBy[h].Add(sentBy[h], paid)
+			}
+		case amount.Sign() > 0:
+			received.Add(received, amount)
+			metrics.Received++
+		}
+	}
+	if close.After(last) && net.Sign() < 0 {
+		usage.Add(usage, new(big.Rat).Mul(new(big.Rat).Neg(net), big.NewRat(int64(close.Sub(last)/time.Second), 1)))
+	}
+
+	metrics.OpeningBalance = iso20022.RatDecimal(opening)
+	metrics.ClosingBalance = iso20022.RatDecimal(balance)
+	metrics.LowestBalance = iso20022.RatDecimal(lowest)
+	metrics.HighestBalance = iso20022.RatDecimal(highest)
+	metrics.PeakNetDebit = iso20022.RatDecimal(peakDebit)
+	metrics.PeakNetCredit = iso20022.RatDecimal(peakCredit)
+	if seconds := int64(close.Sub(open) / time.Second); seconds > 0 {
+		metrics.TimeWeightedUsage = iso20022.RatDecimal(usage.Quo(usage, big.NewRat(seconds, 1)))
+	}
+	metrics.GrossSent = iso20022.RatDecimal(sent)
+	metrics.GrossReceived = iso20022.RatDecimal(received)
+	for h := range sentBy {
+		if sent.Sign() > 0 {
+			metrics.Throughput[h], _ = new(big.Rat).Quo(sentBy[h], sent).Float64()
+		}
+	}
+	return metrics
+}
+
+// clamp returns t within [from, to]
+func clamp(t, from, to time.Time) time.Time {
+	switch {
+	case t.Before(from):
+		return from
+	case t.After(to):
+		return to
+	}
+	return t
+}
+
+// signed returns an amount negative for debits
+func signed(amount iso20022.Decimal, indicator iso20022.CreditDebitCode) iso20022.Decimal {
+	if indicator == iso20022.CreditDebitDBIT {
+		return -amount
+	}
+	return amount
+}
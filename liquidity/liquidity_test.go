@@ -0,0 +1,96 @@
+package liquidity
+
+import (
+	"encoding/xml"
+	"errors"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ckbaum/iso20022-go"
+)
+
+func TestMonitorMetrics(t *testing.T) {
+	at := func(hour int) time.Time { return time.Date(2024, time.March, 15, hour, 0, 0, 0, time.UTC) }
+	m := NewMonitor(WithBusinessDay(8*time.Hour, 18*time.Hour))
+	m.SetOpeningBalance("ACCT", "EUR", iso20022.NewISODate(2024, time.March, 15), 1000)
+	for _, mv := range []Movement{
+		{Time: at(9), Amount: -1500},
+		{Time: at(15), Amount: -700},
+		{Time: at(12), Amount: 2000},
+		{Time: at(17), Amount: -100, Reference: "R1"},
+		{Time: at(16), Amount: 50, Reversal: true, Reference: "R2"},
+	} {
+		mv.Account, mv.Currency = "ACCT", "EUR"
+		m.Add(mv)
+	}
+	if m.Add(Movement{Account: "ACCT", Currency: "EUR", Time: at(17), Amount: -100, Reference: "R1"}) {
+		t.Error("Expected the movement with a known reference to be ignored")
+	}
+
+	metrics := m.Metrics()
+	if len(metrics) != 1 {
+		t.Fatalf("Expected one day, got %+v", metrics)
+	}
+	got := metrics[0]
+	for name, c := range map[string][2]iso20022.Decimal{
+		"opening balance": {got.OpeningBalance, 1000},
+		"closing balance": {got.ClosingBalance, 750},
+		"lowest balance":  {got.LowestBalance, -500},
+		"highest balance": {got.HighestBalance, 1500},
+		"peak net debit":  {got.PeakNetDebit, 1500},
+		"peak net credit": {got.PeakNetCredit, 500},
+		// 1500 for 3 hours, 200 for 1, 150 for 1 and 250 for 1, over 10 hours
+		"time-weighted usage": {got.TimeWeightedUsage, 510},
+		"gross sent":          {got.GrossSent, 2300},
+		"gross received":      {got.GrossReceived, 2000},
+	} {
+		if c[0] != c[1] {
+			t.Errorf("Expected %s %v, got %v", name, c[1], c[0])
+		}
+	}
+	if !got.PeakNetDebitTime.Equal(at(9)) || !got.PeakNetCreditTime.Equal(at(12)) {
+		t.Errorf("Expected the peaks at 9:00 and 12:00, got %v and %v", got.PeakNetDebitTime, got.PeakNetCreditTime)
+	}
+	if got.Sent != 3 || got.Received != 1 {
+		t.Errorf("Expected 3 payments sent and 1 received, got %d and %d", got.Sent, got.Received)
+	}
+	for hour, want := range map[int]float64{8: 0, 9: 1500.0 / 2300, 14: 1500.0 / 2300, 15: 2200.0 / 2300, 17: 1, 23: 1} {
+		if math.Abs(got.Throughput[hour]-want) > 1e-9 {
+			t.Errorf("Expected throughput %v by the end of hour %d, got %v", want, hour, got.Throughput[hour])
+		}
+	}
+}
+
+func TestMonitorAddDocument(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("..", "testdata", "roundtrip", "camt.052.001.08", "account_report.xml"))
+	if err != nil {
+		t.Fatalf("Failed to read fixture: %v", err)
+	}
+	doc := new(iso20022.Camt05200108Document)
+	if err := xml.Unmarshal(data, doc); err != nil {
+		t.Fatalf("Failed to unmarshal fixture: %v", err)
+	}
+
+	m := NewMonitor()
+	if n, err := m.AddDocument(doc); n != 2 || err != nil {
+		t.Fatalf("Expected the two booked entries to be added, got %d, %v", n, err)
+	}
+	if n, _ := m.AddDocument(doc); n != 0 {
+		t.Errorf("Expected the entries to be added once, got %d", n)
+	}
+	metrics := m.Metrics()
+	if len(metrics) != 1 {
+		t.Fatalf("Expected one day, got %+v", metrics)
+	}
+	got := metrics[0]
+	if got.Account != "DE89370400440532013000" || got.OpeningBalance != 10000 || got.ClosingBalance != 10950 || got.Untimed != 2 || got.PeakNetDebit != 0 {
+		t.Errorf("Expected the day to close at the reported 10950, got %+v", got)
+	}
+
+	if _, err := m.AddDocument(&iso20022.Pacs00800108Document{}); !errors.Is(err, ErrUnsupportedDocument) {
+		t.Errorf("Expected a pacs.008 to be refused, got %v", err)
+	}
+}
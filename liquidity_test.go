@@ -0,0 +1,106 @@
+package iso20022
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+)
+
+func TestLiquidityCreditTransfer(t *testing.T) {
+	doc, err := NewLiquidityCreditTransfer("LQT001", "E2E001",
+		ActiveCurrencyAndAmount{Value: 250000, Currency: "EUR"},
+		SettlementAccount("MCAEURDEFFXXX001"), SettlementAccount("RTGSEURDEFFXXX002"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := doc.Validate(); err != nil {
+		t.Fatalf("Expected valid camt.050, got: %v", err)
+	}
+
+	xmlData, err := xml.Marshal(doc)
+	if err != nil {
+		t.Fatalf("Failed to marshal camt.050: %v", err)
+	}
+	if !strings.Contains(string(xmlData), "urn:iso:std:iso:20022:tech:xsd:camt.050.001.05") {
+		t.Errorf("Expected camt.050.001.05 namespace in %s", xmlData)
+	}
+
+	var parsed Camt05000105Document
+	if err := xml.Unmarshal(xmlData, &parsed); err != nil {
+		t.Fatalf("Failed to unmarshal camt.050: %v", err)
+	}
+	transfer := parsed.LiquidityCreditTransfer.LiquidityCreditTransfer
+	if transfer.CreditorAccount == nil || transfer.CreditorAccount.ID.Other.ID != "RTGSEURDEFFXXX002" {
+		t.Errorf("Expected creditor settlement account to round-trip, got %+v", transfer.CreditorAccount)
+	}
+	if transfer.TransferredAmount.AmountWithCurrency == nil || transfer.TransferredAmount.AmountWithCurrency.Value != 250000 {
+		t.Errorf("Expected transferred amount 250000, got %+v", transfer.TransferredAmount)
+	}
+
+	t.Run("Missing amount", func(t *testing.T) {
+		doc.LiquidityCreditTransfer.LiquidityCreditTransfer.TransferredAmount = Amount2Choice{}
+		if err := doc.Validate(); err == nil {
+			t.Error("Expected validation error for missing transferred amount")
+		}
+	})
+}
+
+func TestLiquidityDebitTransfer(t *testing.T) {
+	doc, err := NewLiquidityDebitTransfer("LQT002", "E2E002",
+		ActiveCurrencyAndAmount{Value: 1000, Currency: "EUR"},
+		SettlementAccount("RTGSEURDEFFXXX002"), SettlementAccount("MCAEURDEFFXXX001"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := doc.Validate(); err != nil {
+		t.Fatalf("Expected valid camt.051, got: %v", err)
+	}
+
+	t.Run("Invalid settlement date", func(t *testing.T) {
+		var transfer LiquidityDebitTransfer2
+		data := `<LqdtyDbtTrf><SttlmDt>2024-13-01</SttlmDt></LqdtyDbtTrf>`
+		if err := xml.Unmarshal([]byte(data), &transfer); err == nil {
+			t.Error("Expected unmarshal error for invalid settlement date")
+		}
+	})
+
+	t.Run("Missing message ID", func(t *testing.T) {
+		doc.LiquidityDebitTransfer.MessageHeader.MessageID = ""
+		if err := doc.Validate(); err == nil {
+			t.Error("Expected validation error for missing MsgId")
+		}
+	})
+}
+
+func TestReceipt(t *testing.T) {
+	doc, err := NewReceipt("RCT001", "LQT001", "camt.050.001.05", "COMP")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := doc.Validate(); err != nil {
+		t.Fatalf("Expected valid camt.025, got: %v", err)
+	}
+
+	xmlData, err := xml.Marshal(doc)
+	if err != nil {
+		t.Fatalf("Failed to marshal camt.025: %v", err)
+	}
+
+	var parsed Camt02500105Document
+	if err := xml.Unmarshal(xmlData, &parsed); err != nil {
+		t.Fatalf("Failed to unmarshal camt.025: %v", err)
+	}
+	if got := parsed.Receipt.ReceiptDetails[0].RequestHandling[0].StatusCode; got != "COMP" {
+		t.Errorf("Expected status code COMP, got %s", got)
+	}
+
+	t.Run("No receipt details", func(t *testing.T) {
+		doc.Receipt.ReceiptDetails = nil
+		if err := doc.Validate(); err == nil {
+			t.Error("Expected validation error for missing receipt details")
+		}
+	})
+}
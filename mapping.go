@@ -0,0 +1,386 @@
+package iso20022
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Mapping describes how tabular input, such as the rows of a CSV file or a database
+// query, becomes a pacs.008 or pain.001 with a transaction per record. Mappings are
+// usually kept in JSON files read with LoadMapping, such as
+//
+//	{
+//	  "message": "pacs.008.001.08",
+//	  "document": {"FIToFICstmrCdtTrf.GrpHdr.SttlmInf.SttlmMtd": "CLRG"},
+//	  "transaction": [
+//	    {"column": "amount", "path": "IntrBkSttlmAmt"},
+//	    {"value": "GBP", "path": "IntrBkSttlmAmt.@Ccy"},
+//	    {"column": "sort_code", "path": "CdtrAgt.FinInstnId.BICFI", "transforms": ["digits", "lookup:sort_codes"]}
+//	  ],
+//	  "lookups": {"sort_codes": {"200000": "BARCGB22"}}
+//	}
+//
+// Mappings written in YAML can be converted to this JSON, which has the same
+// structure.
+type Mapping struct {
+	// Message is the message name identification of the documents, pacs.008.001.08
+	// or pain.001.001.09
+	Message string `json:"message"`
+	// Document holds the values shared by every record, by their path in the
+	// document as for SetPath, such as the settlement method or the debtor of a
+	// pain.001
+	Document map[string]string `json:"document,omitempty"`
+	// Transaction maps the columns of a record to its transaction
+	Transaction []FieldMapping `json:"transaction"`
+	// Lookups are tables of values, by name, for the lookup transform
+	Lookups map[string]map[string]string `json:"lookups,omitempty"`
+}
+
+// FieldMapping sets an element of a transaction from a column of a record, or to a
+// fixed value
+type FieldMapping struct {
+	// Column is the column of the record holding the value
+	Column string `json:"column,omitempty"`
+	// Value is the value of the element when there is no column
+	Value string `json:"value,omitempty"`
+	// Path is the path of the element in the transaction, such as Cdtr.Nm or
+	// IntrBkSttlmAmt.@Ccy
+	Path string `json:"path"`
+	// Transforms are applied to the value in turn, before it is set. The transforms
+	// are trim, upper, lower, digits (keeping only the digits), compact (removing
+	// the spaces), default:<value> (giving a value to an empty one),
+	// lookup:<table> (replacing the value with its entry in a table of the
+	// mapping) and those given with MapTransform.
+	Transforms []string `json:"transforms,omitempty"`
+	// Required makes an empty value an error. Elements with an empty value are
+	// otherwise left out.
+	Required bool `json:"required,omitempty"`
+}
+
+// mappedMessage is a message a Mapping can produce
+type mappedMessage struct {
+	// transactions is the path of the repeated transaction element
+	transactions string
+	newDocument  func() interface{}
+	// finish sets the identification, creation time and totals of a document
+	finish func(doc interface{}, id string, created time.Time)
+}
+
+var mappedMessages = map[string]mappedMessage{
+	"pacs.008.001.08": {
+		transactions: "FIToFICstmrCdtTrf.CdtTrfTxInf",
+		newDocument:  func() interface{} { return new(Pacs00800108Document) },
+		finish: func(doc interface{}, id string, created time.Time) {
+			msg := &doc.(*Pacs00800108Document).FICustomerCreditTransfer
+			hdr := &msg.GroupHeader
+			if hdr.MessageID == "" {
+				hdr.MessageID = id
+			}
+			if hdr.CreationDateTime == nil {
+				t := NewISODateTime(created)
+				hdr.CreationDateTime = &t
+			}
+			if hdr.ControlSum == nil {
+				hdr.ControlSum = new(Decimal)
+			}
+			msg.recomputeTotals()
+		},
+	},
+	"pain.001.001.09": {
+		transactions: "CstmrCdtTrfInitn.PmtInf[0].CdtTrfTxInf",
+		newDocument:  func() interface{} { return new(Pain00100109Document) },
+		finish: func(doc interface{}, id string, created time.Time) {
+			msg := &doc.(*Pain00100109Document).CustomerCreditTransferInitiation
+			hdr := &msg.GroupHeader
+			if hdr.MessageID == "" {
+				hdr.MessageID = id
+			}
+			if hdr.CreationDateTime.IsZero() {
+				hdr.CreationDateTime = NewISODateTime(created)
+			}
+			sum, txs := new(big.Rat), 0
+			for i := range msg.PaymentInfo {
+				pmt := &msg.PaymentInfo[i]
+				if pmt.PaymentInfoID == "" {
+					pmt.PaymentInfoID = hdr.MessageID
+				}
+				pmtSum := new(big.Rat)
+				for _, tx := range pmt.CreditTransferTransactionInfo {
+					pmtSum.Add(pmtSum, DecimalRat(transactionAmount(tx.Amount).Value))
+				}
+				count, total := strconv.Itoa(len(pmt.CreditTransferTransactionInfo)), RatDecimal(pmtSum)
+				pmt.NumberOfTransactions, pmt.ControlSum = &count, &total
+				sum.Add(sum, pmtSum)
+				txs += len(pmt.CreditTransferTransactionInfo)
+			}
+			total := RatDecimal(sum)
+			hdr.NumberOfTransactions = strconv.Itoa(txs)
+			hdr.ControlSum = &total
+		},
+	},
+}
+
+// transactionAmount returns the instructed or equivalent amount of a pain.001
+// transaction
+func transactionAmount(a AmountType4) ActiveOrHistoricCurrencyAndAmount {
+	switch {
+	case a.InstructedAmount != nil:
+		return *a.InstructedAmount
+	case a.EquivalentAmount != nil:
+		return a.EquivalentAmount.Amount
+	}
+	return ActiveOrHistoricCurrencyAndAmount{}
+}
+
+// LoadMapping reads a mapping from JSON and checks its message, its document values
+// and that every transaction element has a column or a value
+func LoadMapping(r io.Reader) (*Mapping, error) {
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	var m Mapping
+	if err := dec.Decode(&m); err != nil {
+		return nil, fmt.Errorf("reading mapping: %w", err)
+	}
+	msg, ok := mappedMessages[m.Message]
+	if !ok {
+		return nil, fmt.Errorf("mapping: %w: %q, expected one of %s", ErrUnknownMessage, m.Message, strings.Join(mappedMessageNames(), ", "))
+	}
+	doc := msg.newDocument()
+	for _, path := range m.documentPaths() {
+		if err := SetPath(doc, path, m.Document[path]); err != nil {
+			return nil, fmt.Errorf("mapping: %w", err)
+		}
+	}
+	if len(m.Transaction) == 0 {
+		return nil, fmt.Errorf("mapping: no transaction elements")
+	}
+	for i, f := range m.Transaction {
+		if f.Path == "" || (f.Column == "") == (f.Value == "") {
+			return nil, fmt.Errorf("mapping: transaction element %d needs a path and either a column or a value", i+1)
+		}
+	}
+	return &m, nil
+}
+
+// mappedMessageNames returns the messages a mapping can produce, sorted
+func mappedMessageNames() []string {
+	names := make([]string, 0, len(mappedMessages))
+	for name := range mappedMessages {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// documentPaths returns the paths of the document values, sorted so that they are
+// always set in the same order
+func (m *Mapping) documentPaths() []string {
+	paths := make([]string, 0, len(m.Document))
+	for path := range m.Document {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// MapOption configures Map and MapCSV
+type MapOption func(*mapper)
+
+// MapTransform adds a transform, or replaces a built-in one, such as a lookup of
+// the BIC of a sort code in a bank directory. fn receives the value and returns the
+// transformed value.
+func MapTransform(name string, fn func(value string) (string, error)) MapOption {
+	return func(m *mapper) {
+		m.transforms[name] = fn
+	}
+}
+
+// MapIDs gives the message identification, when the mapping sets none, from ids
+// instead of the time of creation
+func MapIDs(ids IDGenerator) MapOption {
+	return func(m *mapper) {
+		m.ids = ids
+	}
+}
+
+// MapClock gives the creation time of the message, time.Now by default
+func MapClock(now func() time.Time) MapOption {
+	return func(m *mapper) {
+		m.now = now
+	}
+}
+
+type mapper struct {
+	transforms map[string]func(string) (string, error)
+	ids        IDGenerator
+	now        func() time.Time
+}
+
+// mapTransforms are the built-in transforms
+var mapTransforms = map[string]func(string) (string, error){
+	"trim":  func(s string) (string, error) { return strings.TrimSpace(s), nil },
+	"upper": func(s string) (string, error) { return strings.ToUpper(s), nil },
+	"lower": func(s string) (string, error) { return strings.ToLower(s), nil },
+	"digits": func(s string) (string, error) {
+		return strings.Map(func(r rune) rune {
+			if unicode.IsDigit(r) {
+				return r
+			}
+			return -1
+		}, s), nil
+	},
+	"compact": func(s string) (string, error) { return strings.Join(strings.Fields(s), ""), nil },
+}
+
+// Map returns the document of the records, each a row mapping column names to
+// values, with a transaction per record. The number of transactions and control
+// sums are computed, and the message identification and creation time set when the
+// mapping has none. The document is validated, including its business rules, and
+// returned only when it is valid; errors of the values of a record give its number,
+// counting from 1.
+func (m *Mapping) Map(records []map[string]string, opts ...MapOption) (interface{}, error) {
+	msg, ok := mappedMessages[m.Message]
+	if !ok {
+		return nil, fmt.Errorf("map: %w: %q", ErrUnknownMessage, m.Message)
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("map: no records")
+	}
+	mp := &mapper{transforms: make(map[string]func(string) (string, error)), now: time.Now}
+	for name, fn := range mapTransforms {
+		mp.transforms[name] = fn
+	}
+	for _, opt := range opts {
+		opt(mp)
+	}
+
+	doc := msg.newDocument()
+	for _, path := range m.documentPaths() {
+		if err := SetPath(doc, path, m.Document[path]); err != nil {
+			return nil, fmt.Errorf("map: %w", err)
+		}
+	}
+	for i, record := range records {
+		prefix := fmt.Sprintf("%s[%d].", msg.transactions, i)
+		for _, f := range m.Transaction {
+			value, err := m.value(mp, f, record)
+			if err != nil {
+				return nil, fmt.Errorf("map: record %d: %w", i+1, err)
+			}
+			if value == "" {
+				continue
+			}
+			if err := SetPath(doc, prefix+f.Path, value); err != nil {
+				return nil, fmt.Errorf("map: record %d: %w", i+1, err)
+			}
+		}
+	}
+
+	created := mp.now()
+	id := created.UTC().Format("20060102150405")
+	if mp.ids != nil {
+		var err error
+		if id, err = mp.ids.NextID(); err != nil {
+			return nil, err
+		}
+	}
+	msg.finish(doc, id, created)
+	report, err := Validate(context.Background(), doc.(Validator))
+	if err != nil {
+		return nil, err
+	}
+	if err := report.Err(); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// value returns the value of an element of a record, after its transforms
+func (m *Mapping) value(mp *mapper, f FieldMapping, record map[string]string) (string, error) {
+	value := f.Value
+	if f.Column != "" {
+		var ok bool
+		if value, ok = record[f.Column]; !ok {
+			return "", fmt.Errorf("no column %s", f.Column)
+		}
+	}
+	for _, name := range f.Transforms {
+		var err error
+		switch {
+		case strings.HasPrefix(name, "default:"):
+			if value == "" {
+				value = strings.TrimPrefix(name, "default:")
+			}
+		case strings.HasPrefix(name, "lookup:"):
+			table, ok := m.Lookups[strings.TrimPrefix(name, "lookup:")]
+			if !ok {
+				return "", fmt.Errorf("%s: no lookup table %s", f.Path, strings.TrimPrefix(name, "lookup:"))
+			}
+			if value == "" {
+				break
+			}
+			looked, ok := table[value]
+			if !ok {
+				return "", fmt.Errorf("%s: %q is not in lookup table %s", f.Path, value, strings.TrimPrefix(name, "lookup:"))
+			}
+			value = looked
+		default:
+			fn, ok := mp.transforms[name]
+			if !ok {
+				return "", fmt.Errorf("%s: unknown transform %s", f.Path, name)
+			}
+			if value, err = fn(value); err != nil {
+				return "", fmt.Errorf("%s: %s: %w", f.Path, name, err)
+			}
+		}
+	}
+	if value == "" && f.Required {
+		name := f.Column
+		if name == "" {
+			name = f.Path
+		}
+		return "", fmt.Errorf("%s is required", name)
+	}
+	return value, nil
+}
+
+// MapCSV maps the rows of CSV with a header row naming the columns, as Map does;
+// record 1 is the row after the header.
+func (m *Mapping) MapCSV(r io.Reader, opts ...MapOption) (interface{}, error) {
+	cr := csv.NewReader(r)
+	cr.TrimLeadingSpace = true
+	header, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("reading CSV header: %w", err)
+	}
+	for i := range header {
+		header[i] = strings.TrimSpace(header[i])
+	}
+	var records []map[string]string
+	for {
+		row, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading CSV: %w", err)
+		}
+		record := make(map[string]string, len(header))
+		for i, column := range header {
+			if i < len(row) {
+				record[column] = row[i]
+			}
+		}
+		records = append(records, record)
+	}
+	return m.Map(records, opts...)
+}
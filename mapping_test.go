@@ -0,0 +1,143 @@
+package iso20022
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testMapping = `{
+  "message": "pacs.008.001.08",
+  "document": {
+    "FIToFICstmrCdtTrf.GrpHdr.SttlmInf.SttlmMtd": "CLRG",
+    "FIToFICstmrCdtTrf.GrpHdr.IntrBkSttlmDt": "2024-03-28"
+  },
+  "transaction": [
+    {"column": "reference", "path": "PmtId.EndToEndId", "transforms": ["trim", "default:NOTPROVIDED"]},
+    {"column": "amount", "path": "IntrBkSttlmAmt", "required": true},
+    {"value": "GBP", "path": "IntrBkSttlmAmt.@Ccy"},
+    {"value": "SLEV", "path": "ChrgBr"},
+    {"value": "Acme Payroll Ltd", "path": "Dbtr.Nm"},
+    {"value": "NWBKGB2L", "path": "DbtrAgt.FinInstnId.BICFI"},
+    {"column": "name", "path": "Cdtr.Nm", "required": true},
+    {"column": "account", "path": "CdtrAcct.Id.IBAN", "transforms": ["compact", "upper"]},
+    {"column": "sort_code", "path": "CdtrAgt.FinInstnId.BICFI", "transforms": ["digits", "lookup:sort_codes"]}
+  ],
+  "lookups": {"sort_codes": {"601613": "NWBKGB2L", "200000": "BARCGB22"}}
+}`
+
+const testMappingCSV = `reference,amount,name,account,sort_code
+PAYROLL-JS,2500.50,Jane Smith,gb29 nwbk 6016 1331 9268 19,60-16-13
+,3100.25,John Doe,GB33BUKB20201555555555,20-00-00
+`
+
+func TestMapping(t *testing.T) {
+	m, err := LoadMapping(strings.NewReader(testMapping))
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := func() time.Time { return time.Date(2024, 3, 28, 8, 0, 0, 0, time.UTC) }
+	out, err := m.MapCSV(strings.NewReader(testMappingCSV), MapIDs(templateIDs(t)), MapClock(now))
+	if err != nil {
+		t.Fatalf("Expected a valid message, got %v", err)
+	}
+	msg := out.(*Pacs00800108Document).FICustomerCreditTransfer
+	hdr := msg.GroupHeader
+	if hdr.MessageID != "ACME-20240328-000001" || hdr.NumberOfTransactions != "2" || *hdr.ControlSum != 5600.75 ||
+		!hdr.CreationDateTime.Equal(now()) || hdr.SettlementInfo.SettlementMethod != "CLRG" {
+		t.Errorf("Unexpected group header %+v", hdr)
+	}
+	first, second := msg.CreditTransferTransactionInfo[0], msg.CreditTransferTransactionInfo[1]
+	if first.PaymentID.EndToEndID != "PAYROLL-JS" || second.PaymentID.EndToEndID != "NOTPROVIDED" {
+		t.Errorf("Unexpected end-to-end identifications %q, %q", first.PaymentID.EndToEndID, second.PaymentID.EndToEndID)
+	}
+	if *first.CreditorAccount.ID.IBAN != "GB29NWBK60161331926819" || *second.CreditorAgent.FinancialInstitutionID.BankIdentifierCode != "BARCGB22" ||
+		second.InterbankSettlementAmount != (ActiveCurrencyAndAmount{Value: 3100.25, Currency: "GBP"}) {
+		t.Errorf("Unexpected transactions %+v, %+v", first, second)
+	}
+
+	// A custom transform replaces the lookup table
+	bics := MapTransform("bic", func(sortCode string) (string, error) { return "NWBKGB2L", nil })
+	m.Transaction[8].Transforms = []string{"bic"}
+	out, err = m.Map([]map[string]string{{"reference": "X", "amount": "10", "name": "Jane Smith", "account": "", "sort_code": "99-99-99"}}, bics)
+	if err != nil {
+		t.Fatalf("Expected a valid message, got %v", err)
+	}
+	if tx := out.(*Pacs00800108Document).FICustomerCreditTransfer.CreditTransferTransactionInfo[0]; tx.CreditorAccount != nil ||
+		*tx.CreditorAgent.FinancialInstitutionID.BankIdentifierCode != "NWBKGB2L" {
+		t.Errorf("Unexpected transaction %+v", tx)
+	}
+}
+
+func TestMappingErrors(t *testing.T) {
+	for _, mapping := range []string{
+		`{"message": "pacs.009.001.08", "transaction": [{"column": "a", "path": "PmtId.EndToEndId"}]}`,
+		`{"message": "pacs.008.001.08", "transaction": []}`,
+		`{"message": "pacs.008.001.08", "transaction": [{"column": "a", "value": "b", "path": "PmtId.EndToEndId"}]}`,
+		`{"message": "pacs.008.001.08", "document": {"FIToFICstmrCdtTrf.GrpHdr.MsgID": "X"}, "transaction": [{"column": "a", "path": "PmtId.EndToEndId"}]}`,
+		`{"message": "pacs.008.001.08", "transaction": [{"column": "a", "path": "PmtId.EndToEndId"}], "unknown": true}`,
+	} {
+		if _, err := LoadMapping(strings.NewReader(mapping)); err == nil {
+			t.Errorf("Expected an error for %s", mapping)
+		}
+	}
+
+	m, err := LoadMapping(strings.NewReader(testMapping))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range []struct {
+		csv, want string
+	}{
+		{"reference,amount,name,account,sort_code\nX,,Jane Smith,,601613\n", "record 1: amount is required"},
+		{"reference,amount,name,account\nX,10,Jane Smith,\n", "record 1: no column sort_code"},
+		{"reference,amount,name,account,sort_code\nX,10,Jane Smith,,601613\nY,10,John Doe,,123456\n", `record 2: CdtrAgt.FinInstnId.BICFI: "123456" is not in lookup table sort_codes`},
+		{"reference,amount,name,account,sort_code\nX,ten,Jane Smith,,601613\n", "record 1: set FIToFICstmrCdtTrf.CdtTrfTxInf[0].IntrBkSttlmAmt"},
+	} {
+		if _, err := m.MapCSV(strings.NewReader(tt.csv)); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("Expected an error with %q, got %v", tt.want, err)
+		}
+	}
+
+	// The result is validated
+	_, err = m.MapCSV(strings.NewReader("reference,amount,name,account,sort_code\nX,10,Jane Smith,GB00NWBK60161331926819,601613\n"))
+	var errs ValidationErrors
+	if !errors.As(err, &errs) {
+		t.Errorf("Expected validation errors, got %v", err)
+	}
+}
+
+func TestMappingPaymentInitiation(t *testing.T) {
+	m, err := LoadMapping(strings.NewReader(`{
+  "message": "pain.001.001.09",
+  "document": {
+    "CstmrCdtTrfInitn.GrpHdr.InitgPty.Nm": "Acme Payroll Ltd",
+    "CstmrCdtTrfInitn.PmtInf.PmtMtd": "TRF",
+    "CstmrCdtTrfInitn.PmtInf.ReqdExctnDt.Dt": "2024-03-28",
+    "CstmrCdtTrfInitn.PmtInf.Dbtr.Nm": "Acme Payroll Ltd",
+    "CstmrCdtTrfInitn.PmtInf.DbtrAcct.Id.IBAN": "GB29NWBK60161331926819",
+    "CstmrCdtTrfInitn.PmtInf.DbtrAgt.FinInstnId.BICFI": "NWBKGB2L"
+  },
+  "transaction": [
+    {"column": "reference", "path": "PmtId.EndToEndId", "transforms": ["default:NOTPROVIDED"]},
+    {"column": "amount", "path": "Amt.InstdAmt", "required": true},
+    {"value": "GBP", "path": "Amt.InstdAmt.@Ccy"},
+    {"column": "name", "path": "Cdtr.Nm"},
+    {"column": "account", "path": "CdtrAcct.Id.IBAN", "transforms": ["compact", "upper"]}
+  ]
+}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, err := m.MapCSV(strings.NewReader(testMappingCSV), MapIDs(templateIDs(t)))
+	if err != nil {
+		t.Fatalf("Expected a valid message, got %v", err)
+	}
+	msg := out.(*Pain00100109Document).CustomerCreditTransferInitiation
+	pmt := msg.PaymentInfo[0]
+	if msg.GroupHeader.NumberOfTransactions != "2" || *msg.GroupHeader.ControlSum != 5600.75 || pmt.PaymentInfoID != "ACME-20240328-000001" ||
+		*pmt.NumberOfTransactions != "2" || *pmt.ControlSum != 5600.75 || len(pmt.CreditTransferTransactionInfo) != 2 {
+		t.Errorf("Unexpected message %+v", msg)
+	}
+}
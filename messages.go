@@ -0,0 +1,310 @@
+package iso20022
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Message catalogs give the text of the validation messages with an identifier in
+// each language, as fmt formats of the Args of the error. Translations refer to the
+// arguments by index where their order differs from English.
+var (
+	catalogsMu sync.RWMutex
+	catalogs   = map[string]map[string]string{
+		"en": {
+			"REQUIRED":                  "is required but is empty",
+			"REQUIRED_NIL":              "is required but is nil",
+			"LENGTH_MIN":                "length %d is below minimum %d",
+			"LENGTH_MAX":                "length %d exceeds maximum %d",
+			"PATTERN":                   "does not match required pattern '%s'",
+			"PATTERN_INVALID":           "pattern validation failed: %s",
+			"CODE":                      "'%s' is not a valid enumeration value",
+			"DATE_REQUIRED":             "date is required",
+			"DATE_FORMAT":               "date must be in YYYY-MM-DD format",
+			"DATE_VALUE":                "invalid date value",
+			"DATETIME_REQUIRED":         "datetime is required",
+			"DATETIME_FORMAT":           "datetime must be in YYYY-MM-DDTHH:MM:SS format",
+			"DATETIME_VALUE":            "invalid datetime value",
+			"OCCURRENCES_MIN_ONE":       "at least one occurrence is required",
+			"OCCURRENCES_MIN":           "at least %d occurrences are required, got %d",
+			"OCCURRENCES_MAX":           "at most %d occurrences are allowed, got %d",
+			"CHOICE":                    "exactly one choice must be present",
+			"IBAN_COUNTRY":              "country code '%s' does not issue IBANs",
+			"IBAN_LENGTH":               "length %d does not match length %d of %s IBANs",
+			"IBAN_CHECKSUM":             "check digits are invalid",
+			"LEI_CHECKSUM":              "check digits are invalid",
+			"BIC_DIRECTORY_ERROR":       "could not be checked against the BIC directory: %v",
+			"BIC_DIRECTORY_UNKNOWN":     "'%s' is not in the BIC directory",
+			"BIC_DIRECTORY_INACTIVE":    "'%s' is not an active BIC",
+			"LEI_REGISTRY_ERROR":        "could not be checked against the LEI registry: %v",
+			"LEI_REGISTRY_UNKNOWN":      "'%s' is not in the LEI registry",
+			"LEI_REGISTRY_UNUSABLE":     "'%s' is not usable (registration status %s, entity status %s)",
+			"CLEARING_MEMBER_ID":        "'%s' is not a valid %s member identifier",
+			"ROUTING_CHECKSUM":          "check digit of the routing number is invalid",
+			"CURRENCY_INACTIVE":         "'%s' is not an active ISO 4217 currency",
+			"MINOR_UNITS":               "has %d decimal places, more than the %d of %s",
+			"PROXY_PHONE":               "'%s' is not a phone number in E.164 format",
+			"PROXY_EMAIL":               "'%s' is not an email address",
+			"REGULATORY_CODE":           "'%s' is not a regulatory reporting code of %s",
+			"REGULATORY_MISSING":        "is required for cross-border payments to or from %s",
+			"NB_OF_TXS":                 "is %d but the message contains %d transactions",
+			"CONTROL_SUM":               "must equal the sum of the interbank settlement amounts (%s)",
+			"CONTROL_SUM_CURRENCY":      "must be %s, the currency of the total interbank settlement amount",
+			"SETTLEMENT_DATE_TWICE":     "is not allowed when GrpHdr/IntrBkSttlmDt is present",
+			"SETTLEMENT_DATE_MISSING":   "is required when GrpHdr/IntrBkSttlmDt is absent",
+			"GROUP_LEVEL":               "is not allowed when GrpHdr/%s is present",
+			"CHARGE_BEARER":             "is not allowed when ChrgBr is SLEV",
+			"CHARGES_REQUIRED":          "is required when ChrgBr is CRED",
+			"CHARGES_CURRENCY":          "%s cannot be converted to the settlement currency %s",
+			"CHARGES_MISMATCH":          "is %s but InstdAmt less the deducted charges is %s",
+			"EXCHANGE_RATE_MISSING":     "is required when InstdAmt and IntrBkSttlmAmt have different currencies",
+			"EXCHANGE_RATE_NOT_ALLOWED": "is not allowed when InstdAmt and IntrBkSttlmAmt have the same currency",
+			"EXCHANGE_RATE_POSITIVE":    "must be positive",
+			"EXCHANGE_RATE_MISMATCH":    "converts InstdAmt %s %s to %s %s, but IntrBkSttlmAmt plus deducted charges is %s %s",
+			"EXCHANGE_RATE_INVERTED":    "converts InstdAmt %s %s to %s %s, but IntrBkSttlmAmt plus deducted charges is %s %s; the rate appears to be inverted",
+			"REIMBURSEMENT_AGENTS":      "reimbursement agents are not allowed when SttlmMtd is %s",
+			"SETTLEMENT_ACCOUNT":        "is not allowed when SttlmMtd is CLRG",
+			"CLEARING_SYSTEM":           "is only allowed when SttlmMtd is CLRG",
+			"THIRD_REIMBURSEMENT_AGENT": "requires both InstgRmbrsmntAgt and InstdRmbrsmntAgt",
+			"AGENT_MISSING":             "is not allowed without %s",
+			"SETTLEMENT_CALENDAR":       "%s is not a business day of the %s calendar for %s",
+		},
+		"de": {
+			"REQUIRED":                  "ist erforderlich, aber leer",
+			"REQUIRED_NIL":              "ist erforderlich, fehlt aber",
+			"LENGTH_MIN":                "Länge %d unterschreitet das Minimum %d",
+			"LENGTH_MAX":                "Länge %d überschreitet das Maximum %d",
+			"PATTERN":                   "entspricht nicht dem erforderlichen Muster '%s'",
+			"PATTERN_INVALID":           "Musterprüfung fehlgeschlagen: %s",
+			"CODE":                      "'%s' ist kein gültiger Codewert",
+			"DATE_REQUIRED":             "Datum ist erforderlich",
+			"DATE_FORMAT":               "Datum muss im Format JJJJ-MM-TT angegeben sein",
+			"DATE_VALUE":                "ungültiges Datum",
+			"DATETIME_REQUIRED":         "Zeitpunkt ist erforderlich",
+			"DATETIME_FORMAT":           "Zeitpunkt muss im Format JJJJ-MM-TTTHH:MM:SS angegeben sein",
+			"DATETIME_VALUE":            "ungültiger Zeitpunkt",
+			"OCCURRENCES_MIN_ONE":       "muss mindestens einmal vorkommen",
+			"OCCURRENCES_MIN":           "muss mindestens %d-mal vorkommen, kommt %d-mal vor",
+			"OCCURRENCES_MAX":           "darf höchstens %d-mal vorkommen, kommt %d-mal vor",
+			"CHOICE":                    "genau eine Auswahl muss angegeben sein",
+			"IBAN_COUNTRY":              "Ländercode '%s' vergibt keine IBANs",
+			"IBAN_LENGTH":               "Länge %d entspricht nicht der Länge %d der IBANs aus %s",
+			"IBAN_CHECKSUM":             "Prüfziffern sind ungültig",
+			"LEI_CHECKSUM":              "Prüfziffern sind ungültig",
+			"BIC_DIRECTORY_ERROR":       "konnte nicht mit dem BIC-Verzeichnis abgeglichen werden: %v",
+			"BIC_DIRECTORY_UNKNOWN":     "'%s' ist nicht im BIC-Verzeichnis enthalten",
+			"BIC_DIRECTORY_INACTIVE":    "'%s' ist kein aktiver BIC",
+			"LEI_REGISTRY_ERROR":        "konnte nicht mit dem LEI-Register abgeglichen werden: %v",
+			"LEI_REGISTRY_UNKNOWN":      "'%s' ist nicht im LEI-Register enthalten",
+			"LEI_REGISTRY_UNUSABLE":     "'%s' ist nicht verwendbar (Registrierungsstatus %s, Status der Einheit %s)",
+			"CLEARING_MEMBER_ID":        "'%s' ist keine gültige Teilnehmerkennung von %s",
+			"ROUTING_CHECKSUM":          "Prüfziffer der Routing-Nummer ist ungültig",
+			"CURRENCY_INACTIVE":         "'%s' ist keine aktive ISO-4217-Währung",
+			"MINOR_UNITS":               "hat %d Nachkommastellen, mehr als die %d von %s",
+			"PROXY_PHONE":               "'%s' ist keine Telefonnummer im E.164-Format",
+			"PROXY_EMAIL":               "'%s' ist keine E-Mail-Adresse",
+			"REGULATORY_CODE":           "'%s' ist kein Meldecode von %s",
+			"REGULATORY_MISSING":        "ist für grenzüberschreitende Zahlungen von oder nach %s erforderlich",
+			"NB_OF_TXS":                 "ist %d, aber die Nachricht enthält %d Transaktionen",
+			"CONTROL_SUM":               "muss der Summe der Interbanken-Abwicklungsbeträge (%s) entsprechen",
+			"CONTROL_SUM_CURRENCY":      "muss %s sein, die Währung des gesamten Interbanken-Abwicklungsbetrags",
+			"SETTLEMENT_DATE_TWICE":     "ist nicht zulässig, wenn GrpHdr/IntrBkSttlmDt angegeben ist",
+			"SETTLEMENT_DATE_MISSING":   "ist erforderlich, wenn GrpHdr/IntrBkSttlmDt fehlt",
+			"GROUP_LEVEL":               "ist nicht zulässig, wenn GrpHdr/%s angegeben ist",
+			"CHARGE_BEARER":             "ist nicht zulässig, wenn ChrgBr SLEV ist",
+			"CHARGES_REQUIRED":          "ist erforderlich, wenn ChrgBr CRED ist",
+			"CHARGES_CURRENCY":          "%s kann nicht in die Abwicklungswährung %s umgerechnet werden",
+			"CHARGES_MISMATCH":          "ist %s, aber InstdAmt abzüglich der einbehaltenen Gebühren ist %s",
+			"EXCHANGE_RATE_MISSING":     "ist erforderlich, wenn InstdAmt und IntrBkSttlmAmt verschiedene Währungen haben",
+			"EXCHANGE_RATE_NOT_ALLOWED": "ist nicht zulässig, wenn InstdAmt und IntrBkSttlmAmt dieselbe Währung haben",
+			"EXCHANGE_RATE_POSITIVE":    "muss positiv sein",
+			"EXCHANGE_RATE_MISMATCH":    "rechnet InstdAmt %s %s in %s %s um, aber IntrBkSttlmAmt zuzüglich abgezogener Gebühren beträgt %s %s",
+			"EXCHANGE_RATE_INVERTED":    "rechnet InstdAmt %s %s in %s %s um, aber IntrBkSttlmAmt zuzüglich abgezogener Gebühren beträgt %s %s; der Kurs scheint umgekehrt angegeben zu sein",
+			"REIMBURSEMENT_AGENTS":      "Deckungsagenten sind nicht zulässig, wenn SttlmMtd %s ist",
+			"SETTLEMENT_ACCOUNT":        "ist nicht zulässig, wenn SttlmMtd CLRG ist",
+			"CLEARING_SYSTEM":           "ist nur zulässig, wenn SttlmMtd CLRG ist",
+			"THIRD_REIMBURSEMENT_AGENT": "erfordert sowohl InstgRmbrsmntAgt als auch InstdRmbrsmntAgt",
+			"AGENT_MISSING":             "ist ohne %s nicht zulässig",
+			"SETTLEMENT_CALENDAR":       "%s ist kein Geschäftstag des Kalenders %s für %s",
+		},
+		"fr": {
+			"REQUIRED":                  "est obligatoire mais vide",
+			"REQUIRED_NIL":              "est obligatoire mais absent",
+			"LENGTH_MIN":                "la longueur %d est inférieure au minimum %d",
+			"LENGTH_MAX":                "la longueur %d dépasse le maximum %d",
+			"PATTERN":                   "ne respecte pas le format requis '%s'",
+			"PATTERN_INVALID":           "échec du contrôle de format : %s",
+			"CODE":                      "'%s' n'est pas une valeur de code valide",
+			"DATE_REQUIRED":             "la date est obligatoire",
+			"DATE_FORMAT":               "la date doit être au format AAAA-MM-JJ",
+			"DATE_VALUE":                "date invalide",
+			"DATETIME_REQUIRED":         "l'horodatage est obligatoire",
+			"DATETIME_FORMAT":           "l'horodatage doit être au format AAAA-MM-JJTHH:MM:SS",
+			"DATETIME_VALUE":            "horodatage invalide",
+			"OCCURRENCES_MIN_ONE":       "au moins une occurrence est requise",
+			"OCCURRENCES_MIN":           "au moins %d occurrences sont requises, %d trouvées",
+			"OCCURRENCES_MAX":           "au plus %d occurrences sont autorisées, %d trouvées",
+			"CHOICE":                    "exactement un choix doit être présent",
+			"IBAN_COUNTRY":              "le code pays '%s' n'émet pas d'IBAN",
+			"IBAN_LENGTH":               "la longueur %d ne correspond pas à la longueur %d des IBAN %s",
+			"IBAN_CHECKSUM":             "la clé de contrôle est invalide",
+			"LEI_CHECKSUM":              "la clé de contrôle est invalide",
+			"BIC_DIRECTORY_ERROR":       "n'a pas pu être vérifié dans l'annuaire BIC : %v",
+			"BIC_DIRECTORY_UNKNOWN":     "'%s' ne figure pas dans l'annuaire BIC",
+			"BIC_DIRECTORY_INACTIVE":    "'%s' n'est pas un BIC actif",
+			"LEI_REGISTRY_ERROR":        "n'a pas pu être vérifié dans le registre LEI : %v",
+			"LEI_REGISTRY_UNKNOWN":      "'%s' ne figure pas dans le registre LEI",
+			"LEI_REGISTRY_UNUSABLE":     "'%s' n'est pas utilisable (statut d'enregistrement %s, statut de l'entité %s)",
+			"CLEARING_MEMBER_ID":        "'%s' n'est pas un identifiant de membre %s valide",
+			"ROUTING_CHECKSUM":          "le chiffre de contrôle du numéro de routage est invalide",
+			"CURRENCY_INACTIVE":         "'%s' n'est pas une devise ISO 4217 en vigueur",
+			"MINOR_UNITS":               "a %d décimales, plus que les %d de %s",
+			"PROXY_PHONE":               "'%s' n'est pas un numéro de téléphone au format E.164",
+			"PROXY_EMAIL":               "'%s' n'est pas une adresse e-mail",
+			"REGULATORY_CODE":           "'%s' n'est pas un code de déclaration réglementaire de %s",
+			"REGULATORY_MISSING":        "est obligatoire pour les paiements transfrontaliers vers ou depuis %s",
+			"NB_OF_TXS":                 "vaut %d mais le message contient %d transactions",
+			"CONTROL_SUM":               "doit être égal à la somme des montants de règlement interbancaire (%s)",
+			"CONTROL_SUM_CURRENCY":      "doit être %s, la devise du montant total de règlement interbancaire",
+			"SETTLEMENT_DATE_TWICE":     "n'est pas autorisé lorsque GrpHdr/IntrBkSttlmDt est présent",
+			"SETTLEMENT_DATE_MISSING":   "est obligatoire lorsque GrpHdr/IntrBkSttlmDt est absent",
+			"GROUP_LEVEL":               "n'est pas autorisé lorsque GrpHdr/%s est présent",
+			"CHARGE_BEARER":             "n'est pas autorisé lorsque ChrgBr vaut SLEV",
+			"CHARGES_REQUIRED":          "est requis lorsque ChrgBr vaut CRED",
+			"CHARGES_CURRENCY":          "%s ne peut pas être converti dans la devise de règlement %s",
+			"CHARGES_MISMATCH":          "vaut %s mais InstdAmt diminué des frais déduits vaut %s",
+			"EXCHANGE_RATE_MISSING":     "est obligatoire lorsque InstdAmt et IntrBkSttlmAmt ont des devises différentes",
+			"EXCHANGE_RATE_NOT_ALLOWED": "n'est pas autorisé lorsque InstdAmt et IntrBkSttlmAmt ont la même devise",
+			"EXCHANGE_RATE_POSITIVE":    "doit être positif",
+			"EXCHANGE_RATE_MISMATCH":    "convertit InstdAmt %s %s en %s %s, mais IntrBkSttlmAmt plus les frais déduits vaut %s %s",
+			"EXCHANGE_RATE_INVERTED":    "convertit InstdAmt %s %s en %s %s, mais IntrBkSttlmAmt plus les frais déduits vaut %s %s ; le taux semble inversé",
+			"REIMBURSEMENT_AGENTS":      "les agents de remboursement ne sont pas autorisés lorsque SttlmMtd vaut %s",
+			"SETTLEMENT_ACCOUNT":        "n'est pas autorisé lorsque SttlmMtd vaut CLRG",
+			"CLEARING_SYSTEM":           "n'est autorisé que lorsque SttlmMtd vaut CLRG",
+			"THIRD_REIMBURSEMENT_AGENT": "requiert InstgRmbrsmntAgt et InstdRmbrsmntAgt",
+			"AGENT_MISSING":             "n'est pas autorisé sans %s",
+			"SETTLEMENT_CALENDAR":       "%s n'est pas un jour ouvré du calendrier %s pour %s",
+		},
+		"es": {
+			"REQUIRED":                  "es obligatorio pero está vacío",
+			"REQUIRED_NIL":              "es obligatorio pero falta",
+			"LENGTH_MIN":                "la longitud %d es inferior al mínimo %d",
+			"LENGTH_MAX":                "la longitud %d supera el máximo %d",
+			"PATTERN":                   "no cumple el patrón requerido '%s'",
+			"PATTERN_INVALID":           "error en la validación del patrón: %s",
+			"CODE":                      "'%s' no es un valor de código válido",
+			"DATE_REQUIRED":             "la fecha es obligatoria",
+			"DATE_FORMAT":               "la fecha debe tener el formato AAAA-MM-DD",
+			"DATE_VALUE":                "fecha no válida",
+			"DATETIME_REQUIRED":         "la fecha y hora es obligatoria",
+			"DATETIME_FORMAT":           "la fecha y hora debe tener el formato AAAA-MM-DDTHH:MM:SS",
+			"DATETIME_VALUE":            "fecha y hora no válida",
+			"OCCURRENCES_MIN_ONE":       "se requiere al menos una aparición",
+			"OCCURRENCES_MIN":           "se requieren al menos %d apariciones, hay %d",
+			"OCCURRENCES_MAX":           "se permiten como máximo %d apariciones, hay %d",
+			"CHOICE":                    "debe haber exactamente una opción",
+			"IBAN_COUNTRY":              "el código de país '%s' no emite IBAN",
+			"IBAN_LENGTH":               "la longitud %d no coincide con la longitud %d de los IBAN de %s",
+			"IBAN_CHECKSUM":             "los dígitos de control no son válidos",
+			"LEI_CHECKSUM":              "los dígitos de control no son válidos",
+			"BIC_DIRECTORY_ERROR":       "no se pudo comprobar en el directorio BIC: %v",
+			"BIC_DIRECTORY_UNKNOWN":     "'%s' no figura en el directorio BIC",
+			"BIC_DIRECTORY_INACTIVE":    "'%s' no es un BIC activo",
+			"LEI_REGISTRY_ERROR":        "no se pudo comprobar en el registro LEI: %v",
+			"LEI_REGISTRY_UNKNOWN":      "'%s' no figura en el registro LEI",
+			"LEI_REGISTRY_UNUSABLE":     "'%s' no se puede usar (estado de registro %s, estado de la entidad %s)",
+			"CLEARING_MEMBER_ID":        "'%s' no es un identificador de miembro de %s válido",
+			"ROUTING_CHECKSUM":          "el dígito de control del número de ruta no es válido",
+			"CURRENCY_INACTIVE":         "'%s' no es una divisa ISO 4217 vigente",
+			"MINOR_UNITS":               "tiene %d decimales, más que los %d de %s",
+			"PROXY_PHONE":               "'%s' no es un número de teléfono en formato E.164",
+			"PROXY_EMAIL":               "'%s' no es una dirección de correo electrónico",
+			"REGULATORY_CODE":           "'%s' no es un código de declaración regulatoria de %s",
+			"REGULATORY_MISSING":        "es obligatorio para los pagos transfronterizos hacia o desde %s",
+			"NB_OF_TXS":                 "es %d pero el mensaje contiene %d transacciones",
+			"CONTROL_SUM":               "debe ser igual a la suma de los importes de liquidación interbancaria (%s)",
+			"CONTROL_SUM_CURRENCY":      "debe ser %s, la divisa del importe total de liquidación interbancaria",
+			"SETTLEMENT_DATE_TWICE":     "no se permite cuando GrpHdr/IntrBkSttlmDt está presente",
+			"SETTLEMENT_DATE_MISSING":   "es obligatorio cuando falta GrpHdr/IntrBkSttlmDt",
+			"GROUP_LEVEL":               "no se permite cuando GrpHdr/%s está presente",
+			"CHARGE_BEARER":             "no se permite cuando ChrgBr es SLEV",
+			"CHARGES_REQUIRED":          "es obligatorio cuando ChrgBr es CRED",
+			"CHARGES_CURRENCY":          "%s no se puede convertir a la divisa de liquidación %s",
+			"CHARGES_MISMATCH":          "es %s pero InstdAmt menos los gastos deducidos es %s",
+			"EXCHANGE_RATE_MISSING":     "es obligatorio cuando InstdAmt e IntrBkSttlmAmt tienen divisas distintas",
+			"EXCHANGE_RATE_NOT_ALLOWED": "no se permite cuando InstdAmt e IntrBkSttlmAmt tienen la misma divisa",
+			"EXCHANGE_RATE_POSITIVE":    "debe ser positivo",
+			"EXCHANGE_RATE_MISMATCH":    "convierte InstdAmt %s %s en %s %s, pero IntrBkSttlmAmt más los gastos deducidos es %s %s",
+			"EXCHANGE_RATE_INVERTED":    "convierte InstdAmt %s %s en %s %s, pero IntrBkSttlmAmt más los gastos deducidos es %s %s; el tipo parece estar invertido",
+			"REIMBURSEMENT_AGENTS":      "no se permiten agentes de reembolso cuando SttlmMtd es %s",
+			"SETTLEMENT_ACCOUNT":        "no se permite cuando SttlmMtd es CLRG",
+			"CLEARING_SYSTEM":           "solo se permite cuando SttlmMtd es CLRG",
+			"THIRD_REIMBURSEMENT_AGENT": "requiere InstgRmbrsmntAgt e InstdRmbrsmntAgt",
+			"AGENT_MISSING":             "no se permite sin %s",
+			"SETTLEMENT_CALENDAR":       "%s no es un día hábil del calendario %s para %s",
+		},
+	}
+)
+
+// RegisterCatalog adds the messages of a language, keyed by message identifier, to
+// the catalogs, replacing the messages it already has with the same identifiers.
+// Messages are fmt formats of the Args of the errors, which they may refer to by
+// index (%[2]s) to change their order.
+func RegisterCatalog(lang string, messages map[string]string) {
+	lang = strings.ToLower(lang)
+	catalogsMu.Lock()
+	defer catalogsMu.Unlock()
+	catalog := catalogs[lang]
+	if catalog == nil {
+		catalog = make(map[string]string, len(messages))
+		catalogs[lang] = catalog
+	}
+	for id, message := range messages {
+		catalog[id] = message
+	}
+}
+
+// catalogMessage returns the format of message id in lang. A language tag with a
+// region, such as de-CH, falls back to its language.
+func catalogMessage(lang, id string) (string, bool) {
+	lang = strings.ToLower(lang)
+	catalogsMu.RLock()
+	defer catalogsMu.RUnlock()
+	for {
+		if message, ok := catalogs[lang][id]; ok {
+			return message, true
+		}
+		i := strings.LastIndexAny(lang, "-_")
+		if i < 0 {
+			return "", false
+		}
+		lang = lang[:i]
+	}
+}
+
+// newValidationError returns the error of a failed rule with the English message id
+func newValidationError(field, rule, id string, args ...interface{}) ValidationError {
+	format, _ := catalogMessage("en", id)
+	return ValidationError{Field: field, Message: fmt.Sprintf(format, args...), Rule: rule, MessageID: id, Args: args}
+}
+
+// at sets the path of the error
+func (e ValidationError) at(path string) ValidationError {
+	e.Path = path
+	return e
+}
+
+// LocalizedMessage returns the message in lang, a language tag such as fr or de-CH.
+// Errors without a message identifier, and messages missing from the catalog of
+// lang, are given in English.
+func (e ValidationError) LocalizedMessage(lang string) string {
+	if e.MessageID == "" {
+		return e.Message
+	}
+	format, ok := catalogMessage(lang, e.MessageID)
+	if !ok {
+		return e.Message
+	}
+	return fmt.Sprintf(format, e.Args...)
+}
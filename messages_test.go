@@ -0,0 +1,106 @@
+package iso20022
+
+import (
+	"errors"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// TestCatalogs checks that every catalog translates every English message with
+// the same verbs
+func TestCatalogs(t *testing.T) {
+	verb := regexp.MustCompile(`%(\[\d+\])?[a-z]`)
+	verbs := func(format string) string {
+		found := verb.FindAllString(format, -1)
+		for i, v := range found {
+			found[i] = v[len(v)-1:]
+		}
+		sort.Strings(found)
+		return strings.Join(found, "")
+	}
+	for _, lang := range []string{"de", "fr", "es"} {
+		for id, english := range catalogs["en"] {
+			message, ok := catalogs[lang][id]
+			if !ok {
+				t.Errorf("%s: %s is missing", lang, id)
+				continue
+			}
+			if verbs(message) != verbs(english) {
+				t.Errorf("%s: %s has verbs %q, want %q", lang, id, verbs(message), verbs(english))
+			}
+		}
+		if len(catalogs[lang]) != len(catalogs["en"]) {
+			t.Errorf("%s has %d messages, want %d", lang, len(catalogs[lang]), len(catalogs["en"]))
+		}
+	}
+}
+
+func TestLocalizedMessage(t *testing.T) {
+	err := validateIBAN("GB29NWBK6016133192681", "IBAN").(ValidationError)
+	if err.Rule != RuleIBANLength || err.MessageID != "IBAN_LENGTH" {
+		t.Fatalf("Expected an IBAN_LENGTH error, got %+v", err)
+	}
+	tests := []struct {
+		lang, want string
+	}{
+		{"en", "length 21 does not match length 22 of GB IBANs"},
+		{"de", "Länge 21 entspricht nicht der Länge 22 der IBANs aus GB"},
+		{"de-CH", "Länge 21 entspricht nicht der Länge 22 der IBANs aus GB"},
+		{"FR", "la longueur 21 ne correspond pas à la longueur 22 des IBAN GB"},
+		{"es_ES", "la longitud 21 no coincide con la longitud 22 de los IBAN de GB"},
+		{"ja", "length 21 does not match length 22 of GB IBANs"},
+	}
+	for _, tt := range tests {
+		if got := err.LocalizedMessage(tt.lang); got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.lang, got, tt.want)
+		}
+	}
+	if err.Message != tests[0].want {
+		t.Errorf("Expected the English message, got %q", err.Message)
+	}
+
+	// Paths and rules are not translated
+	nested := nestErrors("CdtrAcct.Id", err)[0]
+	if nested.Location() != "CdtrAcct/Id/IBAN" || nested.LocalizedMessage("de") != tests[1].want {
+		t.Errorf("Unexpected nested error %+v", nested)
+	}
+
+	// Choices, directory lookups and charges are localized as well
+	var choice ValidationErrors
+	errors.As((&AccountIdentification4{}).Validate(), &choice)
+	if len(choice) != 1 || choice[0].Rule != RuleChoice || choice[0].LocalizedMessage("fr") != "exactement un choix doit être présent" {
+		t.Errorf("Expected a localized choice error, got %+v", choice)
+	}
+	tx := CreditTransferTransaction39{ChargeBearer: "CRED", InterbankSettlementAmount: ActiveCurrencyAndAmount{Value: 100, Currency: "EUR"}}
+	var charges ValidationErrors
+	if _, err := tx.ReconcileCharges(); !errors.As(err, &charges) || charges[0].Rule != RuleChargeBearer ||
+		charges[0].LocalizedMessage("de") != "ist erforderlich, wenn ChrgBr CRED ist" {
+		t.Errorf("Expected a localized charges error, got %v", err)
+	}
+
+	// Errors without a message identifier are given as they are
+	plain := ValidationError{Field: "Bal", Message: "has no closing booked balance (CLBD or ITBD)"}
+	if got := plain.LocalizedMessage("de"); got != plain.Message {
+		t.Errorf("Expected the message unchanged, got %q", got)
+	}
+}
+
+func TestRegisterCatalog(t *testing.T) {
+	RegisterCatalog("nl", map[string]string{"IBAN_LENGTH": "lengte %[1]d wijkt af van de lengte %[2]d van IBAN's uit %[3]s"})
+	defer func() {
+		catalogsMu.Lock()
+		delete(catalogs, "nl")
+		catalogsMu.Unlock()
+	}()
+
+	err := validateIBAN("GB29NWBK6016133192681", "IBAN").(ValidationError)
+	if got, want := err.LocalizedMessage("nl-BE"), "lengte 21 wijkt af van de lengte 22 van IBAN's uit GB"; got != want {
+		t.Errorf("Got %q, want %q", got, want)
+	}
+	checksum := validateIBAN("GB28NWBK60161331926819", "IBAN").(ValidationError)
+	if got := checksum.LocalizedMessage("nl"); got != checksum.Message {
+		t.Errorf("Expected messages missing from the catalog in English, got %q", got)
+	}
+}
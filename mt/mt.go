@@ -0,0 +1,488 @@
+// Package mt exports bank-to-customer cash reports in the SWIFT MT formats that
+// accounting systems still import: the MT940 customer statement and the MT942
+// interim transaction report.
+//
+// Account reports (camt.052) that carry an opening and a closing booked balance
+// become MT940 statements of their booked entries; other reports and debit credit
+// notifications (camt.054) become MT942 reports. Each entry becomes a :61:
+// statement line, followed by an :86: field with its references, counterparty and
+// remittance information. Text is transliterated to the SWIFT X character set and
+// cut to the field lengths. Lines end with CR LF and each message with a line
+// holding "-".
+package mt
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ckbaum/iso20022-go"
+	"github.com/ckbaum/iso20022-go/charset"
+)
+
+// Errors returned when a report cannot be converted
+var (
+	ErrMissingBalance = errors.New("mt: report has no opening or closing booked balance")
+	ErrMissingDate    = errors.New("mt: entry has no value or booking date")
+	ErrUnsupported    = errors.New("mt: unsupported document")
+)
+
+// Limits of the MT fields
+const (
+	referenceLength     = 16
+	accountLength       = 35
+	supplementaryLength = 34
+	informationLines    = 6
+	informationLength   = 65
+)
+
+// Export writes the messages converting a camt.052 or camt.054 document: one per
+// report or notification
+func Export(w io.Writer, doc interface{}) error {
+	var messages []string
+	switch d := doc.(type) {
+	case *iso20022.Camt05200108Document:
+		for _, report := range d.BankAccountReport.Report {
+			msg, err := Statement(report)
+			if errors.Is(err, ErrMissingBalance) {
+				msg, err = InterimReport(report)
+			}
+			if err != nil {
+				return fmt.Errorf("report %s: %w", report.ID, err)
+			}
+			messages = append(messages, msg)
+		}
+	case *iso20022.Camt05400108Document:
+		for _, n := range d.BankDebitCreditNotification.Notification {
+			if n.CreationDateTime == nil {
+				n.CreationDateTime = d.BankDebitCreditNotification.GroupHeader.CreationDateTime
+			}
+			msg, err := NotificationReport(n)
+			if err != nil {
+				return fmt.Errorf("notification %s: %w", n.ID, err)
+			}
+			messages = append(messages, msg)
+		}
+	default:
+		return fmt.Errorf("%w %T", ErrUnsupported, doc)
+	}
+	for _, msg := range messages {
+		if _, err := io.WriteString(w, msg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Statement converts an account report to an MT940 customer statement of its booked
+// entries. The report needs an opening booked balance (OPBD, or else PRCD) and a
+// closing booked balance (CLBD); closing available (CLAV) and forward available
+// (FWAV) balances are added when present. A page of a paginated report other than
+// the first opens instead with its first interim booked balance (ITBD) as :60M:,
+// and a page other than the last closes with its last ITBD as :62M:.
+func Statement(report iso20022.AccountReport25) (string, error) {
+	page := report.ReportPagination
+	openingTag, opening := "60F", findBalance(report.Balance, "OPBD", "PRCD")
+	if page != nil && page.PageNumber != "1" {
+		openingTag, opening = "60M", findBalance(report.Balance, "ITBD")
+	}
+	closingTag, closing := "62F", findBalance(report.Balance, "CLBD")
+	if page != nil && !page.LastPageIndex {
+		closingTag, closing = "62M", lastBalance(report.Balance, "ITBD")
+	}
+	if opening == nil || closing == nil {
+		return "", ErrMissingBalance
+	}
+
+	var b builder
+	b.header(report.ID, report.Account, report.ElectronicSequenceNumber, report.LegalSequenceNumber, page)
+	b.field(openingTag, balanceField(opening))
+	for _, entry := range report.Entry {
+		if entryStatus(entry) != "BOOK" {
+			continue
+		}
+		if err := b.entry(entry); err != nil {
+			return "", err
+		}
+	}
+	b.field(closingTag, balanceField(closing))
+	if available := findBalance(report.Balance, "CLAV"); available != nil {
+		b.field("64", balanceField(available))
+	}
+	for _, forward := range report.Balance {
+		if iso20022.BalanceCode(forward.Type) == "FWAV" {
+			b.field("65", balanceField(&forward))
+		}
+	}
+	return b.end(), nil
+}
+
+// InterimReport converts an account report to an MT942 interim transaction report
+// of its booked and pending entries. A report without a creation date and time is
+// dated now in :13D:, which MT942 requires.
+func InterimReport(report iso20022.AccountReport25) (string, error) {
+	return interim(report.ID, report.Account, report.ElectronicSequenceNumber, report.LegalSequenceNumber,
+		report.ReportPagination, report.CreationDateTime, report.Entry)
+}
+
+// NotificationReport converts a debit credit notification to an MT942 interim
+// transaction report. Export dates a notification without a creation date and time
+// with the creation date and time of its message.
+func NotificationReport(n iso20022.AccountNotification17) (string, error) {
+	return interim(n.ID, n.Account, n.ElectronicSequenceNumber, n.LegalSequenceNumber,
+		n.NotificationPagination, n.CreationDateTime, n.Entry)
+}
+
+func interim(id string, account iso20022.CashAccount39, electronic, legal *iso20022.Decimal, page *iso20022.Pagination1,
+	created *iso20022.ISODateTime, entries []iso20022.ReportEntry10) (string, error) {
+	currency := iso20022.AccountCurrency(account, entries)
+	var b builder
+	b.header(id, account, electronic, legal, page)
+	b.field("34F", currency+"0,")
+	at := time.Now()
+	if created != nil {
+		at = created.Time
+	}
+	b.field("13D", at.Format("0601021504-0700"))
+	var debits, credits int
+	debitSum, creditSum := new(big.Rat), new(big.Rat)
+	for _, entry := range entries {
+		if entryStatus(entry) == "INFO" {
+			continue
+		}
+		if err := b.entry(entry); err != nil {
+			return "", err
+		}
+		if entry.CreditDebitIndicator == "DBIT" {
+			debits++
+			debitSum.Add(debitSum, iso20022.DecimalRat(entry.Amount.Value))
+		} else {
+			credits++
+			creditSum.Add(creditSum, iso20022.DecimalRat(entry.Amount.Value))
+		}
+	}
+	b.field("90D", strconv.Itoa(debits)+currency+ratAmount(debitSum))
+	b.field("90C", strconv.Itoa(credits)+currency+ratAmount(creditSum))
+	return b.end(), nil
+}
+
+// builder writes the fields of a message
+type builder struct {
+	strings.Builder
+}
+
+func (b *builder) field(tag, value string) {
+	b.WriteString(":" + tag + ":" + value + "\r\n")
+}
+
+// header writes the transaction reference, account and statement number fields
+func (b *builder) header(id string, account iso20022.CashAccount39, electronic, legal *iso20022.Decimal, page *iso20022.Pagination1) {
+	b.field("20", text(id, referenceLength))
+	b.field("25", text(account.ID.Identifier(), accountLength))
+	number := "1"
+	switch {
+	case legal != nil:
+		number = strconv.FormatInt(int64(*legal), 10)
+	case electronic != nil:
+		number = strconv.FormatInt(int64(*electronic), 10)
+	}
+	if page != nil && page.PageNumber != "" {
+		number += "/" + page.PageNumber
+	}
+	b.field("28C", number)
+}
+
+// entry writes the statement line and the information of an entry
+func (b *builder) entry(entry iso20022.ReportEntry10) error {
+	line, err := statementLine(entry)
+	if err != nil {
+		return err
+	}
+	b.field("61", line)
+	if info := information(entry); len(info) > 0 {
+		b.field("86", strings.Join(info, "\r\n"))
+	}
+	return nil
+}
+
+func (b *builder) end() string {
+	b.WriteString("-\r\n")
+	return b.String()
+}
+
+// statementLine returns the :61: field of an entry: value date, entry date, debit
+// or credit mark, amount, transaction type, the references of the account owner
+// and of the account servicer, which is the entry reference when the entry has no
+// account servicer reference, and the additional entry information as
+// supplementary details
+func statementLine(entry iso20022.ReportEntry10) (string, error) {
+	value := dateOf(entry.ValueDate)
+	booked := dateOf(entry.BookingDate)
+	if value.IsZero() {
+		value = booked
+	}
+	if value.IsZero() {
+		return "", ErrMissingDate
+	}
+
+	var b strings.Builder
+	b.WriteString(value.Format("060102"))
+	if !booked.IsZero() {
+		b.WriteString(booked.Format("0102"))
+	}
+	b.WriteString(mark(entry))
+	b.WriteString(amount(entry.Amount.Value))
+	b.WriteString(transactionType(entry.BankTransactionCode))
+	b.WriteString(reference(ownerReference(entry)))
+	switch {
+	case entry.AccountServicerReference != nil:
+		b.WriteString("//" + reference(*entry.AccountServicerReference))
+	case entry.EntryReference != nil:
+		b.WriteString("//" + reference(*entry.EntryReference))
+	}
+	if entry.AdditionalEntryInfo != nil {
+		b.WriteString("\r\n" + text(*entry.AdditionalEntryInfo, supplementaryLength))
+	}
+	return b.String(), nil
+}
+
+// mark returns the debit or credit mark of an entry: C or D, and RC or RD for the
+// reversal of a credit or debit
+func mark(entry iso20022.ReportEntry10) string {
+	reversal := entry.ReversalIndicator != nil && *entry.ReversalIndicator
+	switch {
+	case entry.CreditDebitIndicator == "DBIT" && reversal:
+		return "RC"
+	case entry.CreditDebitIndicator == "DBIT":
+		return "D"
+	case reversal:
+		return "RD"
+	}
+	return "C"
+}
+
+// transactionTypes maps bank transaction families to SWIFT transaction type codes
+var transactionTypes = map[string]string{
+	"ICDT": "NTRF", "RCDT": "NTRF", "ICHQ": "NCHK", "RCHQ": "NCHK",
+	"IDDT": "NDDT", "RDDT": "NDDT", "LBOX": "NCOL", "DRFT": "NBOE",
+}
+
+// transactionType returns the transaction type identification code of an entry: a
+// proprietary code already in SWIFT form, or the code of its family
+func transactionType(code iso20022.BankTransactionCodeStructure4) string {
+	if p := code.Proprietary; p != nil && len(p.Code) == 4 && strings.ContainsRune("NSF", rune(p.Code[0])) {
+		return p.Code
+	}
+	if code.Domain != nil {
+		if code.Domain.Family.SubFamilyCode == "CHRG" || code.Domain.Family.Code == "CHRG" {
+			return "NCHG"
+		}
+		if code.Domain.Family.SubFamilyCode == "INTR" {
+			return "NINT"
+		}
+		if t, ok := transactionTypes[code.Domain.Family.Code]; ok {
+			return t
+		}
+	}
+	return "NMSC"
+}
+
+// ownerReference returns the reference of the account owner for an entry: the
+// end-to-end or instruction identification of its transaction, the payment
+// information identification of its batch, or NONREF
+func ownerReference(entry iso20022.ReportEntry10) string {
+	for _, details := range entry.EntryDetails {
+		if len(details.TransactionDetails) == 1 && details.TransactionDetails[0].References != nil {
+			refs := details.TransactionDetails[0].References
+			for _, ref := range []*string{refs.EndToEndID, refs.AccountOwnerTransactionID, refs.InstructionID} {
+				if ref != nil && *ref != "" && *ref != "NOTPROVIDED" {
+					return *ref
+				}
+			}
+		}
+		if details.Batch != nil && details.Batch.PaymentInfoID != nil {
+			return *details.Batch.PaymentInfoID
+		}
+	}
+	return "NONREF"
+}
+
+// information returns the lines of the :86: field of an entry. It holds, as
+// /KEYWORD/value pairs, the end-to-end identification (EREF), the name and account
+// of the counterparty (NAME, ACCT), the number of transactions of a batch (NBTX)
+// and the remittance information (REMI).
+func information(entry iso20022.ReportEntry10) []string {
+	var txs []iso20022.EntryTransaction10
+	for _, details := range entry.EntryDetails {
+		txs = append(txs, details.TransactionDetails...)
+	}
+
+	var parts []string
+	add := func(key, value string) {
+		if value = strings.TrimSpace(value); value != "" {
+			parts = append(parts, "/"+key+"/"+value)
+		}
+	}
+	if len(txs) == 1 {
+		tx := txs[0]
+		if tx.References != nil && tx.References.EndToEndID != nil && *tx.References.EndToEndID != "NOTPROVIDED" {
+			add("EREF", *tx.References.EndToEndID)
+		}
+		name, account := counterparty(tx, entry.CreditDebitIndicator)
+		add("NAME", name)
+		add("ACCT", account)
+		add("REMI", remittance(tx.RemittanceInfo))
+	} else if len(txs) > 1 {
+		add("NBTX", strconv.Itoa(len(txs)))
+	}
+	if entry.AdditionalEntryInfo != nil && len(txs) != 1 {
+		add("REMI", *entry.AdditionalEntryInfo)
+	}
+	return wrap(charset.FINX.Transliterate(strings.Join(parts, "")), informationLength, informationLines)
+}
+
+// counterparty returns the name and account of the debtor of a credit or the
+// creditor of a debit
+func counterparty(tx iso20022.EntryTransaction10, creditDebit iso20022.CreditDebitCode) (string, string) {
+	parties := tx.RelatedParties
+	if parties == nil {
+		return "", ""
+	}
+	party, account := parties.Creditor, parties.CreditorAccount
+	if creditDebit == iso20022.CreditDebitCRDT {
+		party, account = parties.Debtor, parties.DebtorAccount
+	}
+	var name, id string
+	if party != nil && party.Party != nil && party.Party.Name != nil {
+		name = *party.Party.Name
+	}
+	if account != nil {
+		id = account.ID.Identifier()
+	}
+	return name, id
+}
+
+// remittance returns the unstructured remittance information of a transaction, or
+// else its structured creditor references
+func remittance(info *iso20022.RemittanceInfo16) string {
+	if info == nil {
+		return ""
+	}
+	if len(info.Unstructured) > 0 {
+		return strings.Join(info.Unstructured, " ")
+	}
+	var refs []string
+	for _, s := range info.Structured {
+		if s.CreditorReferenceInfo != nil && s.CreditorReferenceInfo.Reference != nil {
+			refs = append(refs, *s.CreditorReferenceInfo.Reference)
+		}
+	}
+	return strings.Join(refs, " ")
+}
+
+// balanceField returns the value of a balance field: debit or credit mark, date,
+// currency and amount
+func balanceField(balance *iso20022.CashBalance8) string {
+	m := "C"
+	if balance.CreditDebitIndicator == "DBIT" {
+		m = "D"
+	}
+	return m + dateOf(&balance.Date).Format("060102") + balance.Amount.Currency + amount(balance.Amount.Value)
+}
+
+// findBalance returns the first balance with one of the codes, tried in order
+func findBalance(balances []iso20022.CashBalance8, codes ...string) *iso20022.CashBalance8 {
+	for _, code := range codes {
+		for i := range balances {
+			if iso20022.BalanceCode(balances[i].Type) == code {
+				return &balances[i]
+			}
+		}
+	}
+	return nil
+}
+
+// lastBalance returns the last balance with the code
+func lastBalance(balances []iso20022.CashBalance8, code string) *iso20022.CashBalance8 {
+	for i := len(balances) - 1; i >= 0; i-- {
+		if iso20022.BalanceCode(balances[i].Type) == code {
+			return &balances[i]
+		}
+	}
+	return nil
+}
+
+func entryStatus(entry iso20022.ReportEntry10) string {
+	if entry.Status.Code != nil {
+		return *entry.Status.Code
+	}
+	return ""
+}
+
+func dateOf(d *iso20022.DateAndDateTime2) time.Time {
+	switch {
+	case d == nil:
+		return time.Time{}
+	case d.Date != nil:
+		return d.Date.Time
+	case d.DateTime != nil:
+		return d.DateTime.Time
+	}
+	return time.Time{}
+}
+
+// amount formats an amount with a decimal comma, which MT amounts always have
+func amount(d iso20022.Decimal) string {
+	return ratAmount(iso20022.DecimalRat(d))
+}
+
+// ratAmount formats an exact decimal with a decimal comma
+func ratAmount(r *big.Rat) string {
+	f, _ := r.Float64()
+	s := strings.Replace(strconv.FormatFloat(f, 'f', -1, 64), ".", ",", 1)
+	if !strings.Contains(s, ",") {
+		s += ","
+	}
+	return s
+}
+
+// text transliterates text to the SWIFT X character set and cuts it to n characters
+func text(s string, n int) string {
+	s = charset.FINX.Transliterate(s)
+	if len(s) > n {
+		s = s[:n]
+	}
+	return s
+}
+
+// reference returns a reference fit for a :61: field: at most 16 characters, with
+// no slash at either end and no double slash
+func reference(s string) string {
+	s = charset.FINX.Transliterate(s)
+	for strings.Contains(s, "//") {
+		s = strings.ReplaceAll(s, "//", "/")
+	}
+	s = strings.Trim(text(strings.Trim(s, "/"), referenceLength), "/")
+	if s == "" {
+		return "NONREF"
+	}
+	return s
+}
+
+// wrap cuts text into at most lines lines of n characters
+func wrap(s string, n, lines int) []string {
+	var out []string
+	for s != "" && len(out) < lines {
+		if len(s) <= n {
+			out = append(out, s)
+			break
+		}
+		out = append(out, s[:n])
+		s = s[n:]
+	}
+	return out
+}
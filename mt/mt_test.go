@@ -0,0 +1,169 @@
+package mt
+
+import (
+	"encoding/xml"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ckbaum/iso20022-go"
+)
+
+// load decodes a round-trip fixture, whose fixed content the expected output of
+// these tests is written from
+func load(t *testing.T, dir, name string, doc interface{}) {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("..", "testdata", "roundtrip", dir, name))
+	if err != nil {
+		t.Fatalf("Failed to read sample: %v", err)
+	}
+	if err := xml.Unmarshal(data, doc); err != nil {
+		t.Fatalf("Failed to parse sample: %v", err)
+	}
+}
+
+func lines(s ...string) string {
+	return strings.Join(s, "\r\n") + "\r\n"
+}
+
+func TestStatement(t *testing.T) {
+	doc := &iso20022.Camt05200108Document{}
+	load(t, "camt.052.001.08", "account_report.xml", doc)
+	var b strings.Builder
+	if err := Export(&b, doc); err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+	want := lines(
+		":20:RPT-20240315-000",
+		":25:DE89370400440532013000",
+		":28C:1",
+		":60F:C240315EUR10000,",
+		":61:2403150315C1250,NTRFNONREF//E-0001",
+		":61:2403150315D300,NTRFNONREF//E-0002",
+		":62F:C240315EUR10950,",
+		"-",
+	)
+	if got := b.String(); got != want {
+		t.Errorf("Unexpected MT940\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestStatementPages(t *testing.T) {
+	doc := &iso20022.Camt05200108Document{}
+	load(t, "camt.052.001.08", "account_report.xml", doc)
+	report := doc.BankAccountReport.Report[0]
+	itbd := func(value iso20022.Decimal) iso20022.CashBalance8 {
+		b := report.Balance[0]
+		b.Type = iso20022.BalanceType13{CodeOrProprietary: iso20022.BalanceType10{Code: iso20022.Ptr("ITBD")}}
+		b.Amount.Value = value
+		return b
+	}
+	opbd, clbd := report.Balance[0], report.Balance[1]
+
+	tests := []struct {
+		page             iso20022.Pagination1
+		balances         []iso20022.CashBalance8
+		opening, closing string
+	}{
+		{iso20022.Pagination1{PageNumber: "1"}, []iso20022.CashBalance8{opbd, itbd(10500)}, ":60F:C240315EUR10000,", ":62M:C240315EUR10500,"},
+		{iso20022.Pagination1{PageNumber: "2"}, []iso20022.CashBalance8{itbd(10500), itbd(10700)}, ":60M:C240315EUR10500,", ":62M:C240315EUR10700,"},
+		{iso20022.Pagination1{PageNumber: "3", LastPageIndex: true}, []iso20022.CashBalance8{itbd(10700), clbd}, ":60M:C240315EUR10700,", ":62F:C240315EUR10950,"},
+	}
+	for _, tt := range tests {
+		page := tt.page
+		report.ReportPagination, report.Balance = &page, tt.balances
+		got, err := Statement(report)
+		if err != nil {
+			t.Fatalf("Page %s: Statement failed: %v", page.PageNumber, err)
+		}
+		for _, line := range []string{":28C:1/" + page.PageNumber, tt.opening, tt.closing} {
+			if !strings.Contains(got, line+"\r\n") {
+				t.Errorf("Page %s: expected %s in\n%s", page.PageNumber, line, got)
+			}
+		}
+	}
+}
+
+func TestInterimReport(t *testing.T) {
+	doc := &iso20022.Camt05200108Document{}
+	load(t, "camt.052.001.08", "account_report.xml", doc)
+	report := doc.BankAccountReport.Report[0]
+	if _, err := Statement(report); err != nil {
+		t.Fatalf("Statement failed: %v", err)
+	}
+	report.Balance = nil
+	if _, err := Statement(report); !errors.Is(err, ErrMissingBalance) {
+		t.Fatalf("Expected ErrMissingBalance, got %v", err)
+	}
+	got, err := InterimReport(report)
+	if err != nil {
+		t.Fatalf("InterimReport failed: %v", err)
+	}
+	for _, line := range []string{":34F:EUR0,", ":13D:2403152300+0000", ":61:240318D75,NMSCNONREF//E-0003", ":90D:2EUR375,", ":90C:1EUR1250,"} {
+		if !strings.Contains(got, line+"\r\n") {
+			t.Errorf("Expected %s in\n%s", line, got)
+		}
+	}
+
+	report.CreationDateTime = nil
+	if got, err := InterimReport(report); err != nil || !strings.Contains(got, ":13D:") {
+		t.Errorf("Expected a report without creation time to be dated now, got %v\n%s", err, got)
+	}
+}
+
+func TestNotificationReport(t *testing.T) {
+	doc := &iso20022.Camt05400108Document{}
+	load(t, "camt.054.001.08", "debit_credit_notification.xml", doc)
+	var b strings.Builder
+	if err := Export(&b, doc); err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+	want := lines(
+		":20:NTF-20240315-000",
+		":25:DE89370400440532013000",
+		":28C:1",
+		":34F:EUR0,",
+		":13D:2403151800+0000",
+		":61:2403150315C1250,NTRFINV-2024-0311//ASR-7781",
+		":86:/EREF/INV-2024-0311/NAME/Muller Maschinenbau GmbH/ACCT/DE75512108",
+		"001245126199/REMI/Invoice 2024-0311",
+		":61:2403150315D300,NTRFPMTINF-01//E-0002",
+		":86:/NBTX/2",
+		":90D:1EUR300,",
+		":90C:1EUR1250,",
+		"-",
+	)
+	if got := b.String(); got != want {
+		t.Errorf("Unexpected MT942\n%s\nwant\n%s", got, want)
+	}
+
+	if err := Export(&b, &iso20022.Pacs00800108Document{}); !errors.Is(err, ErrUnsupported) {
+		t.Errorf("Expected ErrUnsupported, got %v", err)
+	}
+}
+
+func TestStatementLine(t *testing.T) {
+	reversal := true
+	entry := iso20022.ReportEntry10{
+		Amount:                   iso20022.ActiveOrHistoricCurrencyAndAmount{Value: 12.5, Currency: "EUR"},
+		CreditDebitIndicator:     "DBIT",
+		ReversalIndicator:        &reversal,
+		ValueDate:                &iso20022.DateAndDateTime2{Date: iso20022.Ptr(iso20022.NewISODate(2024, 3, 18))},
+		AccountServicerReference: iso20022.Ptr("//REF-1234567890-TOOLONG"),
+		BankTransactionCode:      iso20022.BankTransactionCodeStructure4{Proprietary: &iso20022.ProprietaryBankTransactionCodeStructure1{Code: "NCHG"}},
+		AdditionalEntryInfo:      iso20022.Ptr("Gebühr für Überweisung"),
+	}
+	got, err := statementLine(entry)
+	if err != nil {
+		t.Fatalf("statementLine failed: %v", err)
+	}
+	if want := "240318RC12,5NCHGNONREF//REF-1234567890-T\r\nGebuhr fur Uberweisung"; got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+	entry.ValueDate = nil
+	if _, err := statementLine(entry); !errors.Is(err, ErrMissingDate) {
+		t.Errorf("Expected ErrMissingDate, got %v", err)
+	}
+}
@@ -0,0 +1,515 @@
+package nacha
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/ckbaum/iso20022-go"
+)
+
+// ClearingSystem is the proprietary clearing system identification of ACH in
+// SttlmInf/ClrSys
+const ClearingSystem = "ACH"
+
+// addendaLength is the length of the payment related information of an addenda
+const addendaLength = 80
+
+// CreditTransfers returns a pacs.008 per batch of a file, with a transaction per
+// entry. Batches must be PPD or CCD and their entries credits; ids generates the
+// message identifications.
+func CreditTransfers(f *File, ids iso20022.IDGenerator) ([]*iso20022.Pacs00800108Document, error) {
+	var docs []*iso20022.Pacs00800108Document
+	for _, batch := range f.Batches {
+		doc, err := CreditTransfer(f.Header, batch, ids)
+		if err != nil {
+			return nil, err
+		}
+		docs = append(docs, doc)
+	}
+	return docs, nil
+}
+
+// CreditTransfer returns the pacs.008 of a batch of PPD or CCD credit entries. The
+// company is the debtor, the ODFI the debtor and instructing agent, and each receiver
+// a creditor whose RDFI is the creditor and instructed agent. The trace number of an
+// entry becomes its InstrId and TxId, and its identification number its EndToEndId.
+func CreditTransfer(header FileHeader, batch Batch, ids iso20022.IDGenerator) (*iso20022.Pacs00800108Document, error) {
+	bh := batch.Header
+	if bh.StandardEntryClass != PPD && bh.StandardEntryClass != CCD {
+		return nil, fmt.Errorf("%w: %s batch %d", ErrUnsupported, bh.StandardEntryClass, bh.Number)
+	}
+	msgID, err := ids.NextID()
+	if err != nil {
+		return nil, err
+	}
+	created := iso20022.NewISODateTime(header.Created)
+	settlement := iso20022.NewISODate(bh.EffectiveDate.Year(), bh.EffectiveDate.Month(), bh.EffectiveDate.Day())
+	clearingSystem := ClearingSystem
+	odfi := agent(RoutingNumber(bh.ODFI))
+
+	var txs []iso20022.CreditTransferTransaction39
+	total := int64(0)
+	for _, e := range batch.Entries {
+		if !e.Credit() {
+			return nil, fmt.Errorf("%w: entry %s has transaction code %s, only credits have a pacs.008", ErrUnsupported, e.TraceNumber, e.TransactionCode)
+		}
+		total += e.Amount
+		trace, sec := e.TraceNumber, bh.StandardEntryClass
+		rdfi := agent(e.RDFI)
+		tx := iso20022.CreditTransferTransaction39{
+			PaymentID:                 iso20022.PaymentIdentification7{InstructionID: &trace, EndToEndID: endToEndID(e), TransactionID: &trace},
+			PaymentTypeInfo:           &iso20022.PaymentTypeInfo28{LocalInstrument: &iso20022.LocalInstrument{Proprietary: &sec}},
+			InterbankSettlementAmount: iso20022.ActiveCurrencyAndAmount{Value: dollars(e.Amount), Currency: "USD"},
+			ChargeBearer:              "SLEV",
+			InstructingAgent:          &odfi,
+			InstructedAgent:           &rdfi,
+			Debtor:                    company(bh),
+			DebtorAgent:               odfi,
+			CreditorAgent:             rdfi,
+			Creditor:                  iso20022.PartyIdentification135{Name: iso20022.Ptr(e.Name)},
+			CreditorAccount:           creditorAccount(e),
+			RemittanceInfo:            remittanceInfo(e.Addenda),
+		}
+		txs = append(txs, tx)
+	}
+
+	doc := &iso20022.Pacs00800108Document{}
+	doc.FICustomerCreditTransfer = iso20022.FIToFICustomerCreditTransferV08{
+		GroupHeader: iso20022.GroupHeader93{
+			MessageID:                      msgID,
+			CreationDateTime:               &created,
+			NumberOfTransactions:           strconv.Itoa(len(txs)),
+			TotalInterbankSettlementAmount: &iso20022.ActiveCurrencyAndAmount{Value: dollars(total), Currency: "USD"},
+			InterbankSettlementDate:        &settlement,
+			SettlementInfo: iso20022.SettlementInstruction7{
+				SettlementMethod: "CLRG",
+				ClearingSystem:   &iso20022.ClearingSystemIdentificationSecondary{Proprietary: &clearingSystem},
+			},
+		},
+		CreditTransferTransactionInfo: txs,
+	}
+	return doc, nil
+}
+
+// CreditTransferInitiations returns a pain.001 per batch of a file, with a
+// transaction per entry. Batches must be PPD or CCD and their entries credits;
+// account is the account of the originators that funds them, and ids generates the
+// message and payment information identifications.
+func CreditTransferInitiations(f *File, account iso20022.CashAccount38, ids iso20022.IDGenerator) ([]*iso20022.Pain00100109Document, error) {
+	var docs []*iso20022.Pain00100109Document
+	for _, batch := range f.Batches {
+		doc, err := CreditTransferInitiation(f.Header, batch, account, ids)
+		if err != nil {
+			return nil, err
+		}
+		docs = append(docs, doc)
+	}
+	return docs, nil
+}
+
+// CreditTransferInitiation returns the pain.001 of a batch of PPD or CCD credit
+// entries, as the company would have sent it to the ODFI: the company is the
+// initiating party and the debtor, whose account is account, the ODFI the debtor
+// agent and the effective date the requested execution date. Each receiver is a
+// creditor whose RDFI is the creditor agent. The trace number of an entry becomes its
+// InstrId, and its identification number its EndToEndId.
+func CreditTransferInitiation(header FileHeader, batch Batch, account iso20022.CashAccount38, ids iso20022.IDGenerator) (*iso20022.Pain00100109Document, error) {
+	bh := batch.Header
+	if bh.StandardEntryClass != PPD && bh.StandardEntryClass != CCD {
+		return nil, fmt.Errorf("%w: %s batch %d", ErrUnsupported, bh.StandardEntryClass, bh.Number)
+	}
+	msgID, err := ids.NextID()
+	if err != nil {
+		return nil, err
+	}
+	pmtID, err := ids.NextID()
+	if err != nil {
+		return nil, err
+	}
+	execution := iso20022.NewISODate(bh.EffectiveDate.Year(), bh.EffectiveDate.Month(), bh.EffectiveDate.Day())
+	sec := bh.StandardEntryClass
+	chargeBearer := iso20022.ChargeBearerType1Code("SLEV")
+
+	var txs []iso20022.CreditTransferTransaction34
+	total := int64(0)
+	for _, e := range batch.Entries {
+		if !e.Credit() {
+			return nil, fmt.Errorf("%w: entry %s has transaction code %s, only credits have a pain.001", ErrUnsupported, e.TraceNumber, e.TransactionCode)
+		}
+		total += e.Amount
+		trace, rdfi := e.TraceNumber, agent(e.RDFI)
+		txs = append(txs, iso20022.CreditTransferTransaction34{
+			PaymentID:       iso20022.PaymentIdentification6{InstructionID: &trace, EndToEndID: endToEndID(e)},
+			Amount:          iso20022.AmountType4{InstructedAmount: &iso20022.ActiveOrHistoricCurrencyAndAmount{Value: dollars(e.Amount), Currency: "USD"}},
+			CreditorAgent:   &rdfi,
+			Creditor:        &iso20022.PartyIdentification135{Name: iso20022.Ptr(e.Name)},
+			CreditorAccount: creditorAccount(e),
+			RemittanceInfo:  remittanceInfo(e.Addenda),
+		})
+	}
+
+	count, sum := strconv.Itoa(len(txs)), dollars(total)
+	doc := &iso20022.Pain00100109Document{}
+	doc.CustomerCreditTransferInitiation = iso20022.CustomerCreditTransferInitiationV09{
+		GroupHeader: iso20022.GroupHeader85{
+			MessageID:            msgID,
+			CreationDateTime:     iso20022.NewISODateTime(header.Created),
+			NumberOfTransactions: count,
+			ControlSum:           iso20022.Ptr(sum),
+			InitiatingParty:      company(bh),
+		},
+		PaymentInfo: []iso20022.PaymentInstruction30{{
+			PaymentInfoID:                 pmtID,
+			PaymentMethod:                 "TRF",
+			NumberOfTransactions:          &count,
+			ControlSum:                    iso20022.Ptr(sum),
+			PaymentTypeInfo:               &iso20022.PaymentTypeInformation26{LocalInstrument: &iso20022.LocalInstrument2{Proprietary: &sec}},
+			RequestedExecutionDate:        iso20022.DateAndDateTime2{Date: &execution},
+			Debtor:                        company(bh),
+			DebtorAccount:                 account,
+			DebtorAgent:                   agent(RoutingNumber(bh.ODFI)),
+			ChargeBearer:                  &chargeBearer,
+			CreditTransferTransactionInfo: txs,
+		}},
+	}
+	return doc, nil
+}
+
+// endToEndID returns the identification number of an entry, or NOTPROVIDED when it
+// has none
+func endToEndID(e Entry) string {
+	if e.IndividualID == "" {
+		return "NOTPROVIDED"
+	}
+	return e.IndividualID
+}
+
+// creditorAccount returns the account of the receiver of an entry
+func creditorAccount(e Entry) *iso20022.CashAccount38 {
+	accountType := "CACC"
+	if e.TransactionCode == SavingsCredit {
+		accountType = "SVGS"
+	}
+	return &iso20022.CashAccount38{
+		ID:   iso20022.AccountIdentification4{Other: &iso20022.GenericAccountIdentification1{ID: e.Account}},
+		Type: &iso20022.CashAccountType2{Code: &accountType},
+	}
+}
+
+// agent returns an agent identified by its routing number
+func agent(routing string) iso20022.BranchAndFinancialInstitutionIdentification6 {
+	return iso20022.AgentFromClearingSystem("USABA", routing, "")
+}
+
+// company returns the originator of a batch as a party identified by its company
+// identification
+func company(bh BatchHeader) iso20022.PartyIdentification135 {
+	party := iso20022.PartyIdentification135{Name: iso20022.Ptr(bh.CompanyName)}
+	if bh.CompanyID != "" {
+		party.ID = &iso20022.Party38{OrganizationID: &iso20022.OrganizationIdentification29{
+			Other: []iso20022.GenericOrganizationIdentification1{{ID: bh.CompanyID}},
+		}}
+	}
+	return party
+}
+
+// remittanceInfo returns the remittance information of the addenda of an entry
+func remittanceInfo(addenda []string) *iso20022.RemittanceInfo {
+	if len(addenda) == 0 {
+		return nil
+	}
+	info := &iso20022.RemittanceInfo{}
+	for _, a := range addenda {
+		if doc, ok := parseRMR(a); ok {
+			info.Structured = append(info.Structured, doc)
+		} else {
+			info.Unstructured = append(info.Unstructured, a)
+		}
+	}
+	return info
+}
+
+// documentTypes maps the reference qualifiers of X12 RMR segments to the codes of
+// referred document types
+var documentTypes = map[string]string{
+	"IV": "CINV", // invoice
+	"CM": "CREN", // credit memo
+	"DM": "DEBN", // debit memo
+	"PO": "PUOR", // purchase order
+}
+
+// parseRMR returns the referred document of an X12 RMR segment, such as
+// RMR*IV*INV-1001**250.00\, in an addenda
+func parseRMR(addenda string) (iso20022.StructuredRemittanceInfo, bool) {
+	if !strings.HasPrefix(addenda, "RMR*") {
+		return iso20022.StructuredRemittanceInfo{}, false
+	}
+	elements := strings.Split(strings.TrimSuffix(strings.TrimSpace(addenda), "\\"), "*")
+	if len(elements) < 3 || elements[2] == "" {
+		return iso20022.StructuredRemittanceInfo{}, false
+	}
+	docType := iso20022.ReferredDocumentTypeOption{Proprietary: &elements[1]}
+	if code, ok := documentTypes[elements[1]]; ok {
+		docType = iso20022.ReferredDocumentTypeOption{Code: &code}
+	}
+	info := iso20022.StructuredRemittanceInfo{ReferredDocumentInfo: []iso20022.ReferredDocumentInfo{{
+		Type:   &iso20022.ReferredDocumentType{CodeOrProprietary: docType},
+		Number: &elements[2],
+	}}}
+	if len(elements) > 4 {
+		if amount, err := strconv.ParseFloat(elements[4], 64); err == nil {
+			info.ReferredDocumentAmount = &iso20022.RemittanceAmountPrimary{
+				RemittedAmount: &iso20022.ActiveOrHistoricCurrencyAndAmount{Value: iso20022.Decimal(amount), Currency: "USD"},
+			}
+		}
+	}
+	return info, true
+}
+
+// BatchOptions complete the batch header of a pacs.008
+type BatchOptions struct {
+	EntryDescription string // shown to receivers, such as PAYROLL; required
+	Number           int    // batch number within the file
+	// StandardEntryClass is used for transactions without a PPD or CCD local
+	// instrument: CCD when empty and the creditor is an organisation, else PPD
+	StandardEntryClass string
+}
+
+// FromCreditTransfer returns the batch of PPD or CCD credit entries of a pacs.008 in
+// USD. All its transactions must have the same debtor, debtor agent and settlement
+// date, which make the batch header; the debtor must be identified by a company
+// identification. Entry trace numbers are the ODFI followed by the position of the
+// transaction. The remittance information becomes a single addenda: an RMR segment
+// for a referred document, or else the unstructured text when it fits.
+func FromCreditTransfer(doc *iso20022.Pacs00800108Document, opts BatchOptions) (Batch, error) {
+	ct := doc.FICustomerCreditTransfer
+	if len(ct.CreditTransferTransactionInfo) == 0 {
+		return Batch{}, fmt.Errorf("%w: no transactions", ErrUnsupported)
+	}
+	if opts.EntryDescription == "" {
+		return Batch{}, fmt.Errorf("nacha: no entry description")
+	}
+	first := ct.CreditTransferTransactionInfo[0]
+	odfi, err := routingNumber(&first.DebtorAgent)
+	if err != nil {
+		return Batch{}, fmt.Errorf("debtor agent: %w", err)
+	}
+	originator := companyID(first.Debtor)
+	if originator == "" {
+		return Batch{}, fmt.Errorf("%w: debtor has no company identification", ErrUnsupported)
+	}
+	settlement := first.InterbankSettlementDate
+	if settlement == nil {
+		settlement = ct.GroupHeader.InterbankSettlementDate
+	}
+	if settlement == nil {
+		return Batch{}, fmt.Errorf("%w: no interbank settlement date", ErrUnsupported)
+	}
+	sec := standardEntryClass(first, opts.StandardEntryClass)
+
+	batch := Batch{Header: BatchHeader{
+		ServiceClass:       CreditsOnly,
+		CompanyName:        value(first.Debtor.Name),
+		CompanyID:          originator,
+		StandardEntryClass: sec,
+		EntryDescription:   opts.EntryDescription,
+		EffectiveDate:      settlement.Time,
+		ODFI:               odfi[:8],
+		Number:             opts.Number,
+	}}
+	for i, tx := range ct.CreditTransferTransactionInfo {
+		e, err := entry(tx, odfi[:8]+fmt.Sprintf("%07d", i+1))
+		if err == nil {
+			err = sameBatch(batch.Header, tx, ct.GroupHeader.InterbankSettlementDate, opts.StandardEntryClass)
+		}
+		if err != nil {
+			return Batch{}, fmt.Errorf("transaction %d: %w", i+1, err)
+		}
+		batch.Entries = append(batch.Entries, e)
+	}
+	return batch, nil
+}
+
+// FromCreditTransferInitiation returns the batches of PPD or CCD credit entries of a
+// pain.001 in USD: one per pacs.008 that Transform makes of it, numbered from
+// opts.Number, with the entries FromCreditTransfer makes of each
+func FromCreditTransferInitiation(doc *iso20022.Pain00100109Document, opts BatchOptions) ([]Batch, error) {
+	parts, err := iso20022.Transform(doc)
+	if err != nil {
+		return nil, err
+	}
+	batches := make([]Batch, 0, len(parts))
+	for i, part := range parts {
+		batchOpts := opts
+		batchOpts.Number += i
+		batch, err := FromCreditTransfer(part, batchOpts)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", part.FICustomerCreditTransfer.GroupHeader.MessageID, err)
+		}
+		batches = append(batches, batch)
+	}
+	return batches, nil
+}
+
+// sameBatch checks that a transaction belongs in the batch of a header
+func sameBatch(bh BatchHeader, tx iso20022.CreditTransferTransaction39, groupSettlement *iso20022.ISODate, sec string) error {
+	odfi, err := routingNumber(&tx.DebtorAgent)
+	if err != nil {
+		return fmt.Errorf("debtor agent: %w", err)
+	}
+	settlement := tx.InterbankSettlementDate
+	if settlement == nil {
+		settlement = groupSettlement
+	}
+	switch {
+	case odfi[:8] != bh.ODFI:
+		return fmt.Errorf("%w: debtor agent %s differs from %s", ErrUnsupported, odfi, RoutingNumber(bh.ODFI))
+	case companyID(tx.Debtor) != bh.CompanyID:
+		return fmt.Errorf("%w: debtor differs from company %s", ErrUnsupported, bh.CompanyID)
+	case settlement == nil || !settlement.Equal(bh.EffectiveDate):
+		return fmt.Errorf("%w: settlement date differs from %s", ErrUnsupported, bh.EffectiveDate.Format("2006-01-02"))
+	case standardEntryClass(tx, sec) != bh.StandardEntryClass:
+		return fmt.Errorf("%w: entry class differs from %s", ErrUnsupported, bh.StandardEntryClass)
+	}
+	return nil
+}
+
+// entry returns the entry of a transaction
+func entry(tx iso20022.CreditTransferTransaction39, trace string) (Entry, error) {
+	amount := tx.InterbankSettlementAmount
+	if amount.Currency != "USD" {
+		return Entry{}, fmt.Errorf("%w: amount in %s", ErrUnsupported, amount.Currency)
+	}
+	rdfi, err := routingNumber(&tx.CreditorAgent)
+	if err != nil {
+		return Entry{}, fmt.Errorf("creditor agent: %w", err)
+	}
+	acct := tx.CreditorAccount
+	if acct == nil || acct.ID.Other == nil {
+		return Entry{}, fmt.Errorf("%w: creditor account is not identified by an account number", ErrUnsupported)
+	}
+	if len(acct.ID.Other.ID) > 17 {
+		return Entry{}, fmt.Errorf("%w: creditor account %s is longer than 17 characters", ErrUnsupported, acct.ID.Other.ID)
+	}
+	id := tx.PaymentID.EndToEndID
+	if id == "NOTPROVIDED" {
+		id = ""
+	}
+	if len(id) > 15 {
+		return Entry{}, fmt.Errorf("%w: EndToEndId %s is longer than 15 characters", ErrUnsupported, id)
+	}
+	code := CheckingCredit
+	if acct.Type != nil && acct.Type.Code != nil && *acct.Type.Code == "SVGS" {
+		code = SavingsCredit
+	}
+	addenda, err := addenda(tx.RemittanceInfo)
+	if err != nil {
+		return Entry{}, err
+	}
+	return Entry{
+		TransactionCode: code,
+		RDFI:            rdfi,
+		Account:         acct.ID.Other.ID,
+		Amount:          int64(math.Round(float64(amount.Value) * 100)),
+		IndividualID:    id,
+		Name:            value(tx.Creditor.Name),
+		TraceNumber:     trace,
+		Addenda:         addenda,
+	}, nil
+}
+
+// addenda returns the addenda of remittance information: PPD and CCD entries carry
+// at most one
+func addenda(info *iso20022.RemittanceInfo) ([]string, error) {
+	if info == nil {
+		return nil, nil
+	}
+	var addenda []string
+	for _, s := range info.Structured {
+		for _, doc := range s.ReferredDocumentInfo {
+			addenda = append(addenda, rmr(doc, s.ReferredDocumentAmount))
+		}
+	}
+	if len(info.Unstructured) > 0 {
+		addenda = append(addenda, strings.Join(info.Unstructured, " "))
+	}
+	switch {
+	case len(addenda) > 1:
+		return nil, fmt.Errorf("%w: remittance information needs %d addenda, only one is allowed", ErrUnsupported, len(addenda))
+	case len(addenda) == 1 && len(addenda[0]) > addendaLength:
+		return nil, fmt.Errorf("%w: remittance information is longer than %d characters", ErrUnsupported, addendaLength)
+	}
+	return addenda, nil
+}
+
+// rmr returns the X12 RMR segment of a referred document
+func rmr(doc iso20022.ReferredDocumentInfo, amount *iso20022.RemittanceAmountPrimary) string {
+	qualifier := "IV"
+	if doc.Type != nil {
+		if p := doc.Type.CodeOrProprietary.Proprietary; p != nil {
+			qualifier = *p
+		}
+		for q, code := range documentTypes {
+			if c := doc.Type.CodeOrProprietary.Code; c != nil && *c == code {
+				qualifier = q
+			}
+		}
+	}
+	segment := "RMR*" + qualifier + "*" + value(doc.Number)
+	if amount != nil && amount.RemittedAmount != nil {
+		segment += "**" + strconv.FormatFloat(float64(amount.RemittedAmount.Value), 'f', 2, 64)
+	}
+	return segment + "\\"
+}
+
+// routingNumber returns the checked routing number of an agent
+func routingNumber(agent *iso20022.BranchAndFinancialInstitutionIdentification6) (string, error) {
+	member := agent.FinancialInstitutionID.ClearingSystemMemberID
+	if member == nil || member.ClearingSystemID == nil || value(member.ClearingSystemID.Code) != "USABA" {
+		return "", fmt.Errorf("%w: agent is not identified by a USABA routing number", ErrUnsupported)
+	}
+	if err := iso20022.ValidateClearingSystemMemberID("USABA", member.MemberID); err != nil {
+		return "", err
+	}
+	return member.MemberID, nil
+}
+
+// companyID returns the company identification of a debtor, its first other
+// organisation identification
+func companyID(debtor iso20022.PartyIdentification135) string {
+	if debtor.ID == nil || debtor.ID.OrganizationID == nil || len(debtor.ID.OrganizationID.Other) == 0 {
+		return ""
+	}
+	return debtor.ID.OrganizationID.Other[0].ID
+}
+
+// standardEntryClass returns the entry class of a transaction, from its local
+// instrument or else fallback or its creditor
+func standardEntryClass(tx iso20022.CreditTransferTransaction39, fallback string) string {
+	if t := tx.PaymentTypeInfo; t != nil && t.LocalInstrument != nil {
+		if p := value(t.LocalInstrument.Proprietary); p == PPD || p == CCD {
+			return p
+		}
+	}
+	switch {
+	case fallback != "":
+		return fallback
+	case tx.Creditor.ID != nil && tx.Creditor.ID.OrganizationID != nil:
+		return CCD
+	}
+	return PPD
+}
+
+// dollars returns an amount in cents as a decimal amount of dollars
+func dollars(cents int64) iso20022.Decimal {
+	return iso20022.Decimal(float64(cents) / 100)
+}
+
+func value(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
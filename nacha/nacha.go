@@ -0,0 +1,407 @@
+// Package nacha bridges ACH files in the NACHA format and ISO 20022 credit
+// transfers, for processors that handle both.
+//
+// Parse reads a NACHA file into its batches and entries and Write writes one back,
+// computing the batch and file control records. CreditTransfers turns every batch of
+// PPD or CCD credit entries into a pacs.008, and FromCreditTransfer turns a pacs.008
+// back into a batch; CreditTransferInitiations and FromCreditTransferInitiation do
+// the same with pain.001 initiations. The originating and receiving depository financial institutions are the
+// debtor and creditor agents, identified by their routing numbers, and the addenda
+// of an entry are its remittance information: an ANSI X12 RMR segment maps to a
+// structured referred document and any other addenda to unstructured text.
+//
+// Only credit entries have an ISO 20022 equivalent in this module; debit entries
+// would need pacs.003, which it does not have.
+package nacha
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ckbaum/iso20022-go/charset"
+)
+
+var (
+	// ErrMalformed is returned for files that do not follow the NACHA record layout
+	ErrMalformed = errors.New("nacha: malformed file")
+	// ErrUnsupported is returned for entries and transactions that have no
+	// equivalent on the other side, such as debit entries or non-USD amounts
+	ErrUnsupported = errors.New("nacha: unsupported")
+)
+
+// recordLength is the length of every record of a NACHA file
+const recordLength = 94
+
+// blockingFactor is the number of records of a block; files are padded with
+// records of nines to a whole number of blocks
+const blockingFactor = 10
+
+// Standard entry class codes of the batches this package converts
+const (
+	PPD = "PPD" // prearranged payments and deposits, to consumers
+	CCD = "CCD" // corporate credits and debits, to businesses
+)
+
+// Transaction codes of entries
+const (
+	CheckingCredit = "22"
+	CheckingDebit  = "27"
+	SavingsCredit  = "32"
+	SavingsDebit   = "37"
+)
+
+// Service class codes of batches
+const (
+	MixedEntries = "200"
+	CreditsOnly  = "220"
+	DebitsOnly   = "225"
+)
+
+// File is a NACHA file
+type File struct {
+	Header  FileHeader
+	Batches []Batch
+}
+
+// FileHeader is the file header (1) record
+type FileHeader struct {
+	ImmediateDestination     string // routing number of the receiving point, 9 digits
+	ImmediateOrigin          string // routing number or identification of the sender, up to 10 characters
+	Created                  time.Time
+	IDModifier               string // distinguishes the files created on the same day, A to Z or 0 to 9
+	ImmediateDestinationName string
+	ImmediateOriginName      string
+	ReferenceCode            string
+}
+
+// Batch is a company batch: its header (5) record and its entries
+type Batch struct {
+	Header  BatchHeader
+	Entries []Entry
+}
+
+// BatchHeader is the company/batch header (5) record
+type BatchHeader struct {
+	ServiceClass           string // MixedEntries, CreditsOnly or DebitsOnly
+	CompanyName            string
+	CompanyDiscretionary   string
+	CompanyID              string // identification of the originator, up to 10 characters
+	StandardEntryClass     string // PPD or CCD
+	EntryDescription       string // shown to receivers, such as PAYROLL
+	CompanyDescriptiveDate string
+	EffectiveDate          time.Time
+	ODFI                   string // first 8 digits of the routing number of the originating bank
+	Number                 int
+}
+
+// Entry is an entry detail (6) record with its addenda (7) records
+type Entry struct {
+	TransactionCode string // CheckingCredit, CheckingDebit, SavingsCredit or SavingsDebit
+	RDFI            string // routing number of the receiving bank, 9 digits
+	Account         string
+	Amount          int64 // in cents
+	IndividualID    string
+	Name            string
+	Discretionary   string
+	TraceNumber     string // ODFI and a sequence number, 15 digits
+	Addenda         []string
+}
+
+// Credit reports whether the entry is a credit to the receiver
+func (e Entry) Credit() bool {
+	return e.TransactionCode == CheckingCredit || e.TransactionCode == SavingsCredit
+}
+
+// Parse reads a NACHA file. Lines may end with CRLF or LF, and the filler records
+// padding the last block are skipped.
+func Parse(r io.Reader) (*File, error) {
+	f := &File{}
+	var batch *Batch
+	header := false
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if line == "" || strings.Trim(line, "9") == "" {
+			continue
+		}
+		if len(line) != recordLength {
+			return nil, fmt.Errorf("%w: record %d has %d characters, not %d", ErrMalformed, n, len(line), recordLength)
+		}
+		rec := record(line)
+		var err error
+		switch rec.field(1, 1) {
+		case "1":
+			header = true
+			f.Header, err = parseFileHeader(rec)
+		case "5":
+			if batch != nil {
+				return nil, fmt.Errorf("%w: record %d opens a batch inside batch %d", ErrMalformed, n, batch.Header.Number)
+			}
+			f.Batches = append(f.Batches, Batch{})
+			batch = &f.Batches[len(f.Batches)-1]
+			batch.Header, err = parseBatchHeader(rec)
+		case "6":
+			if batch == nil {
+				return nil, fmt.Errorf("%w: record %d is an entry outside a batch", ErrMalformed, n)
+			}
+			var e Entry
+			e, err = parseEntry(rec)
+			batch.Entries = append(batch.Entries, e)
+		case "7":
+			if batch == nil || len(batch.Entries) == 0 {
+				return nil, fmt.Errorf("%w: record %d is an addenda without an entry", ErrMalformed, n)
+			}
+			e := &batch.Entries[len(batch.Entries)-1]
+			e.Addenda = append(e.Addenda, rec.field(4, 83))
+		case "8":
+			if batch == nil {
+				return nil, fmt.Errorf("%w: record %d closes no batch", ErrMalformed, n)
+			}
+			batch = nil
+		case "9":
+		default:
+			return nil, fmt.Errorf("%w: record %d has unknown type %q", ErrMalformed, n, line[:1])
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: record %d: %v", ErrMalformed, n, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if !header {
+		return nil, fmt.Errorf("%w: no file header", ErrMalformed)
+	}
+	if batch != nil {
+		return nil, fmt.Errorf("%w: batch %d is not closed", ErrMalformed, batch.Header.Number)
+	}
+	return f, nil
+}
+
+// record is a NACHA record, addressed by the 1-based positions of the specification
+type record string
+
+// field returns the text at positions from to to, without padding
+func (r record) field(from, to int) string {
+	return strings.TrimSpace(string(r[from-1 : to]))
+}
+
+// number returns the number at positions from to to
+func (r record) number(from, to int) (int64, error) {
+	s := r.field(from, to)
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("positions %d-%d: %q is not a number", from, to, s)
+	}
+	return n, nil
+}
+
+func parseFileHeader(r record) (FileHeader, error) {
+	created, err := time.Parse("0601021504", r.field(24, 33))
+	if err != nil {
+		return FileHeader{}, fmt.Errorf("invalid creation date and time %q", r.field(24, 33))
+	}
+	return FileHeader{
+		ImmediateDestination:     r.field(4, 13),
+		ImmediateOrigin:          r.field(14, 23),
+		Created:                  created,
+		IDModifier:               r.field(34, 34),
+		ImmediateDestinationName: r.field(41, 63),
+		ImmediateOriginName:      r.field(64, 86),
+		ReferenceCode:            r.field(87, 94),
+	}, nil
+}
+
+func parseBatchHeader(r record) (BatchHeader, error) {
+	effective, err := time.Parse("060102", r.field(70, 75))
+	if err != nil {
+		return BatchHeader{}, fmt.Errorf("invalid effective entry date %q", r.field(70, 75))
+	}
+	number, err := r.number(88, 94)
+	if err != nil {
+		return BatchHeader{}, err
+	}
+	return BatchHeader{
+		ServiceClass:           r.field(2, 4),
+		CompanyName:            r.field(5, 20),
+		CompanyDiscretionary:   r.field(21, 40),
+		CompanyID:              r.field(41, 50),
+		StandardEntryClass:     r.field(51, 53),
+		EntryDescription:       r.field(54, 63),
+		CompanyDescriptiveDate: r.field(64, 69),
+		EffectiveDate:          effective,
+		ODFI:                   r.field(80, 87),
+		Number:                 int(number),
+	}, nil
+}
+
+func parseEntry(r record) (Entry, error) {
+	amount, err := r.number(30, 39)
+	if err != nil {
+		return Entry{}, err
+	}
+	return Entry{
+		TransactionCode: r.field(2, 3),
+		RDFI:            r.field(4, 12),
+		Account:         r.field(13, 29),
+		Amount:          amount,
+		IndividualID:    r.field(40, 54),
+		Name:            r.field(55, 76),
+		Discretionary:   r.field(77, 78),
+		TraceNumber:     r.field(80, 94),
+	}, nil
+}
+
+// Write writes a NACHA file with its batch and file control records, padded with
+// filler records to a whole number of blocks. Text is transliterated to ASCII and
+// cut to the width of its field.
+func Write(w io.Writer, f *File) error {
+	var b strings.Builder
+	rec := func(fields ...string) {
+		line := strings.Join(fields, "")
+		b.WriteString(line + strings.Repeat(" ", recordLength-len(line)) + "\n")
+	}
+	h := f.Header
+	rec("1", "01", right(h.ImmediateDestination, 10), right(h.ImmediateOrigin, 10),
+		h.Created.Format("0601021504"), left(orDefault(h.IDModifier, "A"), 1), "094", "10", "1",
+		left(h.ImmediateDestinationName, 23), left(h.ImmediateOriginName, 23), left(h.ReferenceCode, 8))
+
+	records, entries := 1, 0
+	hash, debits, credits := new(big.Int), int64(0), int64(0)
+	for _, batch := range f.Batches {
+		bh := batch.Header
+		rec("5", left(orDefault(bh.ServiceClass, serviceClass(batch.Entries)), 3), left(bh.CompanyName, 16),
+			left(bh.CompanyDiscretionary, 20), left(bh.CompanyID, 10), left(bh.StandardEntryClass, 3),
+			left(bh.EntryDescription, 10), left(bh.CompanyDescriptiveDate, 6), bh.EffectiveDate.Format("060102"),
+			"   ", "1", zeros(bh.ODFI, 8), zeros(strconv.Itoa(bh.Number), 7))
+		count := 0
+		batchHash, batchDebits, batchCredits := new(big.Int), int64(0), int64(0)
+		for _, e := range batch.Entries {
+			if len(e.RDFI) < 8 {
+				return fmt.Errorf("%w: RDFI %q of entry %s is too short", ErrMalformed, e.RDFI, e.TraceNumber)
+			}
+			indicator := "0"
+			if len(e.Addenda) > 0 {
+				indicator = "1"
+			}
+			rec("6", left(e.TransactionCode, 2), zeros(e.RDFI, 9), left(e.Account, 17), zeros(strconv.FormatInt(e.Amount, 10), 10),
+				left(e.IndividualID, 15), left(e.Name, 22), left(e.Discretionary, 2), indicator, zeros(e.TraceNumber, 15))
+			for i, addenda := range e.Addenda {
+				rec("7", "05", left(addenda, 80), zeros(strconv.Itoa(i+1), 4), zeros(sequence(e.TraceNumber), 7))
+			}
+			count += 1 + len(e.Addenda)
+			routing, _ := new(big.Int).SetString(e.RDFI[:8], 10)
+			if routing == nil {
+				return fmt.Errorf("%w: RDFI %q of entry %s is not a number", ErrMalformed, e.RDFI, e.TraceNumber)
+			}
+			batchHash.Add(batchHash, routing)
+			if e.Credit() {
+				batchCredits += e.Amount
+			} else {
+				batchDebits += e.Amount
+			}
+		}
+		rec("8", left(orDefault(bh.ServiceClass, serviceClass(batch.Entries)), 3), zeros(strconv.Itoa(count), 6),
+			entryHash(batchHash), zeros(strconv.FormatInt(batchDebits, 10), 12), zeros(strconv.FormatInt(batchCredits, 10), 12),
+			left(bh.CompanyID, 10), strings.Repeat(" ", 25), zeros(bh.ODFI, 8), zeros(strconv.Itoa(bh.Number), 7))
+		records += count + 2
+		entries += count
+		hash.Add(hash, batchHash)
+		debits += batchDebits
+		credits += batchCredits
+	}
+	records++
+	blocks := (records + blockingFactor - 1) / blockingFactor
+	rec("9", zeros(strconv.Itoa(len(f.Batches)), 6), zeros(strconv.Itoa(blocks), 6), zeros(strconv.Itoa(entries), 8),
+		entryHash(hash), zeros(strconv.FormatInt(debits, 10), 12), zeros(strconv.FormatInt(credits, 10), 12))
+	for ; records < blocks*blockingFactor; records++ {
+		b.WriteString(strings.Repeat("9", recordLength) + "\n")
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// serviceClass returns the service class code of a batch of entries
+func serviceClass(entries []Entry) string {
+	var credit, debit bool
+	for _, e := range entries {
+		if e.Credit() {
+			credit = true
+		} else {
+			debit = true
+		}
+	}
+	switch {
+	case credit && !debit:
+		return CreditsOnly
+	case debit && !credit:
+		return DebitsOnly
+	}
+	return MixedEntries
+}
+
+// entryHash returns the last ten digits of the sum of the RDFI routing numbers
+func entryHash(sum *big.Int) string {
+	s := sum.String()
+	if len(s) > 10 {
+		s = s[len(s)-10:]
+	}
+	return zeros(s, 10)
+}
+
+// sequence returns the entry sequence number of a trace number, its last 7 digits
+func sequence(trace string) string {
+	if len(trace) > 7 {
+		return trace[len(trace)-7:]
+	}
+	return trace
+}
+
+// left returns text as an alphanumeric field: ASCII, left-justified and cut to width
+func left(s string, width int) string {
+	s = charset.CBPRPlus.Transliterate(s)
+	if len(s) > width {
+		return s[:width]
+	}
+	return s + strings.Repeat(" ", width-len(s))
+}
+
+// right returns text right-justified in a field of width
+func right(s string, width int) string {
+	if len(s) > width {
+		return s[len(s)-width:]
+	}
+	return strings.Repeat(" ", width-len(s)) + s
+}
+
+// zeros returns digits as a numeric field, right-justified and zero-filled
+func zeros(s string, width int) string {
+	if len(s) > width {
+		return s[len(s)-width:]
+	}
+	return strings.Repeat("0", width-len(s)) + s
+}
+
+func orDefault(s, fallback string) string {
+	if s == "" {
+		return fallback
+	}
+	return s
+}
+
+// RoutingNumber returns the nine-digit routing number of the first 8 digits of one,
+// as NACHA records carry the ODFI, by appending its check digit
+func RoutingNumber(prefix string) string {
+	weights := [8]int{3, 7, 1, 3, 7, 1, 3, 7}
+	sum := 0
+	for i := 0; i < len(prefix) && i < 8; i++ {
+		sum += int(prefix[i]-'0') * weights[i]
+	}
+	return prefix + strconv.Itoa((10-sum%10)%10)
+}
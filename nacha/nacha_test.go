@@ -0,0 +1,228 @@
+package nacha
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ckbaum/iso20022-go"
+)
+
+func sampleFile() *File {
+	return &File{
+		Header: FileHeader{
+			ImmediateDestination:     "026009593",
+			ImmediateOrigin:          "1234567890",
+			Created:                  time.Date(2024, 3, 14, 16, 30, 0, 0, time.UTC),
+			IDModifier:               "A",
+			ImmediateDestinationName: "Bank of America",
+			ImmediateOriginName:      "Acme Corp",
+		},
+		Batches: []Batch{{
+			Header: BatchHeader{
+				CompanyName:        "Acme Corp",
+				CompanyID:          "1234567890",
+				StandardEntryClass: PPD,
+				EntryDescription:   "PAYROLL",
+				EffectiveDate:      time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC),
+				ODFI:               "02100002",
+				Number:             1,
+			},
+			Entries: []Entry{{
+				TransactionCode: CheckingCredit,
+				RDFI:            "026009593",
+				Account:         "123456789",
+				Amount:          250000,
+				IndividualID:    "EMP-0042",
+				Name:            "Jane Doe",
+				TraceNumber:     "021000020000001",
+				Addenda:         []string{"March salary"},
+			}, {
+				TransactionCode: SavingsCredit,
+				RDFI:            "021000021",
+				Account:         "987654321",
+				Amount:          12550,
+				Name:            "José Núñez",
+				TraceNumber:     "021000020000002",
+				Addenda:         []string{`RMR*IV*INV-1001**125.50\`},
+			}},
+		}},
+	}
+}
+
+func TestWriteParse(t *testing.T) {
+	var b strings.Builder
+	if err := Write(&b, sampleFile()); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	records := strings.Split(strings.TrimSuffix(b.String(), "\n"), "\n")
+	if len(records) != blockingFactor {
+		t.Fatalf("Expected one block of %d records, got %d", blockingFactor, len(records))
+	}
+	for i, r := range records {
+		if len(r) != recordLength {
+			t.Errorf("Record %d has %d characters", i+1, len(r))
+		}
+	}
+	for i, want := range []string{
+		"622026009593123456789        0000250000EMP-0042       Jane Doe                1021000020000001",
+		"705March salary" + strings.Repeat(" ", 68) + "00010000001",
+		"632021000021987654321        0000012550               Jose Nunez              1021000020000002",
+		"705RMR*IV*INV-1001**125.50\\" + strings.Repeat(" ", 56) + "00010000002",
+		"822000000400047009610000000000000000002625501234567890" + strings.Repeat(" ", 25) + "021000020000001",
+		"9000001000001000000040004700961000000000000000000262550" + strings.Repeat(" ", 39),
+		strings.Repeat("9", recordLength),
+	} {
+		if got := records[i+2]; got != want {
+			t.Errorf("Record %d:\n%q\nwant\n%q", i+3, got, want)
+		}
+	}
+
+	f, err := Parse(strings.NewReader(b.String()))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if len(f.Batches) != 1 || len(f.Batches[0].Entries) != 2 {
+		t.Fatalf("Unexpected batches %+v", f.Batches)
+	}
+	e := f.Batches[0].Entries[1]
+	if e.Amount != 12550 || e.RDFI != "021000021" || e.Name != "Jose Nunez" || e.Addenda[0] != `RMR*IV*INV-1001**125.50\` {
+		t.Errorf("Unexpected entry %+v", e)
+	}
+	if !f.Header.Created.Equal(time.Date(2024, 3, 14, 16, 30, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected creation time %v", f.Header.Created)
+	}
+
+	if _, err := Parse(strings.NewReader("6220260095931234\n")); !errors.Is(err, ErrMalformed) {
+		t.Errorf("Expected ErrMalformed, got %v", err)
+	}
+}
+
+func TestCreditTransfers(t *testing.T) {
+	ids, err := iso20022.NewSequenceGenerator("ACH", iso20022.WithClock(func() time.Time { return time.Date(2024, 3, 14, 0, 0, 0, 0, time.UTC) }))
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := sampleFile()
+	docs, err := CreditTransfers(f, ids)
+	if err != nil {
+		t.Fatalf("CreditTransfers failed: %v", err)
+	}
+	if len(docs) != 1 {
+		t.Fatalf("Expected a pacs.008, got %d", len(docs))
+	}
+	if err := docs[0].Validate(); err != nil {
+		t.Errorf("Expected a valid pacs.008, got %v", err)
+	}
+	ct := docs[0].FICustomerCreditTransfer
+	if ct.GroupHeader.NumberOfTransactions != "2" || ct.GroupHeader.TotalInterbankSettlementAmount.Value != 2625.5 {
+		t.Errorf("Unexpected group header %+v", ct.GroupHeader)
+	}
+	tx := ct.CreditTransferTransactionInfo[1]
+	if *tx.PaymentID.TransactionID != "021000020000002" || tx.PaymentID.EndToEndID != "NOTPROVIDED" {
+		t.Errorf("Unexpected payment identification %+v", tx.PaymentID)
+	}
+	if got := tx.DebtorAgent.FinancialInstitutionID.ClearingSystemMemberID.MemberID; got != "021000021" {
+		t.Errorf("Expected the ODFI routing number with its check digit, got %s", got)
+	}
+	if *tx.CreditorAccount.Type.Code != "SVGS" {
+		t.Errorf("Expected a savings account, got %s", *tx.CreditorAccount.Type.Code)
+	}
+	strd := tx.RemittanceInfo.Structured
+	if len(strd) != 1 || *strd[0].ReferredDocumentInfo[0].Number != "INV-1001" ||
+		*strd[0].ReferredDocumentInfo[0].Type.CodeOrProprietary.Code != "CINV" || strd[0].ReferredDocumentAmount.RemittedAmount.Value != 125.5 {
+		t.Errorf("Expected the RMR addenda as a referred invoice, got %+v", tx.RemittanceInfo)
+	}
+
+	batch, err := FromCreditTransfer(docs[0], BatchOptions{EntryDescription: "PAYROLL", Number: 1})
+	if err != nil {
+		t.Fatalf("FromCreditTransfer failed: %v", err)
+	}
+	want := f.Batches[0]
+	want.Header.ServiceClass = CreditsOnly
+	if batch.Header != want.Header {
+		t.Errorf("Unexpected batch header\n%+v\nwant\n%+v", batch.Header, want.Header)
+	}
+	for i, e := range batch.Entries {
+		w := want.Entries[i]
+		if e.TransactionCode != w.TransactionCode || e.RDFI != w.RDFI || e.Account != w.Account || e.Amount != w.Amount ||
+			e.IndividualID != w.IndividualID || e.TraceNumber != w.TraceNumber || strings.Join(e.Addenda, "|") != strings.Join(w.Addenda, "|") {
+			t.Errorf("Entry %d:\n%+v\nwant\n%+v", i+1, e, w)
+		}
+	}
+
+	f.Batches[0].Entries[0].TransactionCode = CheckingDebit
+	if _, err := CreditTransfers(f, ids); !errors.Is(err, ErrUnsupported) {
+		t.Errorf("Expected ErrUnsupported for a debit, got %v", err)
+	}
+	docs[0].FICustomerCreditTransfer.CreditTransferTransactionInfo[1].DebtorAgent = agent("026009593")
+	if _, err := FromCreditTransfer(docs[0], BatchOptions{EntryDescription: "PAYROLL"}); !errors.Is(err, ErrUnsupported) {
+		t.Errorf("Expected ErrUnsupported for mixed debtor agents, got %v", err)
+	}
+}
+
+func TestCreditTransferInitiations(t *testing.T) {
+	ids, err := iso20022.NewSequenceGenerator("ACH", iso20022.WithClock(func() time.Time { return time.Date(2024, 3, 14, 0, 0, 0, 0, time.UTC) }))
+	if err != nil {
+		t.Fatal(err)
+	}
+	account := iso20022.CashAccount38{ID: iso20022.AccountIdentification4{Other: &iso20022.GenericAccountIdentification1{ID: "4455667788"}}}
+	f := sampleFile()
+	docs, err := CreditTransferInitiations(f, account, ids)
+	if err != nil {
+		t.Fatalf("CreditTransferInitiations failed: %v", err)
+	}
+	if len(docs) != 1 {
+		t.Fatalf("Expected a pain.001, got %d", len(docs))
+	}
+	if err := docs[0].Validate(); err != nil {
+		t.Errorf("Expected a valid pain.001, got %v", err)
+	}
+	msg := docs[0].CustomerCreditTransferInitiation
+	if msg.GroupHeader.NumberOfTransactions != "2" || *msg.GroupHeader.ControlSum != 2625.5 {
+		t.Errorf("Unexpected group header %+v", msg.GroupHeader)
+	}
+	pmt := msg.PaymentInfo[0]
+	if pmt.PaymentInfoID == msg.GroupHeader.MessageID || *pmt.PaymentTypeInfo.LocalInstrument.Proprietary != PPD ||
+		!pmt.RequestedExecutionDate.Date.Equal(f.Batches[0].Header.EffectiveDate) {
+		t.Errorf("Unexpected payment information %+v", pmt)
+	}
+	tx := pmt.CreditTransferTransactionInfo[1]
+	if *tx.PaymentID.InstructionID != "021000020000002" || tx.PaymentID.EndToEndID != "NOTPROVIDED" || tx.Amount.InstructedAmount.Value != 125.5 {
+		t.Errorf("Unexpected transaction %+v", tx)
+	}
+	if strd := tx.RemittanceInfo.Structured; len(strd) != 1 || *strd[0].ReferredDocumentInfo[0].Number != "INV-1001" {
+		t.Errorf("Expected the RMR addenda as a referred invoice, got %+v", tx.RemittanceInfo)
+	}
+
+	batches, err := FromCreditTransferInitiation(docs[0], BatchOptions{EntryDescription: "PAYROLL", Number: 1})
+	if err != nil {
+		t.Fatalf("FromCreditTransferInitiation failed: %v", err)
+	}
+	want := f.Batches[0]
+	want.Header.ServiceClass = CreditsOnly
+	if len(batches) != 1 || batches[0].Header != want.Header {
+		t.Fatalf("Unexpected batches %+v\nwant header\n%+v", batches, want.Header)
+	}
+	for i, e := range batches[0].Entries {
+		w := want.Entries[i]
+		if e.TransactionCode != w.TransactionCode || e.RDFI != w.RDFI || e.Account != w.Account || e.Amount != w.Amount ||
+			e.IndividualID != w.IndividualID || strings.Join(e.Addenda, "|") != strings.Join(w.Addenda, "|") {
+			t.Errorf("Entry %d:\n%+v\nwant\n%+v", i+1, e, w)
+		}
+	}
+
+	f.Batches[0].Header.StandardEntryClass = "WEB"
+	if _, err := CreditTransferInitiations(f, account, ids); !errors.Is(err, ErrUnsupported) {
+		t.Errorf("Expected ErrUnsupported for a WEB batch, got %v", err)
+	}
+}
+
+func TestRoutingNumber(t *testing.T) {
+	for _, routing := range []string{"021000021", "026009593", "011000015"} {
+		if got := RoutingNumber(routing[:8]); got != routing {
+			t.Errorf("Expected %s, got %s", routing, got)
+		}
+	}
+}
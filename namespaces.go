@@ -0,0 +1,191 @@
+package iso20022
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+// namespaceAliases maps the namespaces registered with RegisterNamespace to the
+// namespaces of the messages they stand for
+var namespaceAliases struct {
+	sync.RWMutex
+	m map[string]string
+}
+
+// RegisterNamespace registers namespace as an alias of the namespace of the message
+// with the given name identification, such as pacs.008.001.08. Some market
+// infrastructures use modified namespaces for otherwise identical schemas, such as
+// with a _GBT suffix or a proprietary URN. MessageType, DecodeDocument, Unmarshal
+// and ValidateXML accept documents in a registered namespace as documents of its
+// message, and WithNamespace writes them.
+func RegisterNamespace(msgType, namespace string) error {
+	if _, ok := documentsByType[msgType]; !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownMessage, msgType)
+	}
+	namespaceAliases.Lock()
+	defer namespaceAliases.Unlock()
+	if namespaceAliases.m == nil {
+		namespaceAliases.m = make(map[string]string)
+	}
+	namespaceAliases.m[namespace] = namespacePrefix + msgType
+	return nil
+}
+
+// canonicalNamespace returns the namespace of the message a registered namespace
+// stands for
+func canonicalNamespace(namespace string) (string, bool) {
+	namespaceAliases.RLock()
+	defer namespaceAliases.RUnlock()
+	canonical, ok := namespaceAliases.m[namespace]
+	return canonical, ok
+}
+
+// Unmarshal decodes data into v as xml.Unmarshal does, reading the elements of a
+// namespace registered with RegisterNamespace as elements of the namespace of its
+// message. Like encoding/xml, it ignores unexpected content unless the Strict
+// option is given.
+func Unmarshal(data []byte, v interface{}, opts ...DecodeOption) error {
+	return unmarshalContext(context.Background(), data, v, opts...)
+}
+
+// unmarshalContext is Unmarshal stopping with ctx.Err() once ctx is done. It tells
+// the Observer about the parse.
+func unmarshalContext(ctx context.Context, data []byte, v interface{}, opts ...DecodeOption) error {
+	ob := currentObserver()
+	if ob == nil {
+		return unmarshal(ctx, data, v, opts...)
+	}
+	start := time.Now()
+	err := unmarshal(ctx, data, v, opts...)
+	ob.Observe(ctx, Observation{
+		Operation:   OperationParse,
+		MessageType: documentMessageType(v),
+		Start:       start,
+		Duration:    time.Since(start),
+		Size:        len(data),
+		Err:         err,
+	})
+	return err
+}
+
+func unmarshal(ctx context.Context, data []byte, v interface{}, opts ...DecodeOption) error {
+	var o decodeOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.strict {
+		if err := checkStrict(data, v); err != nil {
+			return err
+		}
+	}
+	namespaceAliases.RLock()
+	aliased := len(namespaceAliases.m) > 0
+	namespaceAliases.RUnlock()
+	if !aliased && ctx.Done() == nil {
+		if err := xml.Unmarshal(data, v); err != nil {
+			return err
+		}
+		return recordUnmodelled(data, v)
+	}
+	d := xml.NewDecoder(bytes.NewReader(data))
+	var r xml.TokenReader = d
+	if aliased {
+		r = &namespaceReader{d: d}
+	}
+	if ctx.Done() != nil {
+		r = &contextReader{ctx: ctx, r: r}
+	}
+	if err := xml.NewTokenDecoder(r).Decode(v); err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		return err
+	}
+	return recordUnmodelled(data, v)
+}
+
+// contextCheckTokens is the number of tokens a contextReader reads between checks
+// of its context
+const contextCheckTokens = 256
+
+// contextReader reads the tokens of r until ctx is done
+type contextReader struct {
+	ctx context.Context
+	r   xml.TokenReader
+	n   int
+}
+
+func (r *contextReader) Token() (xml.Token, error) {
+	if r.n++; r.n%contextCheckTokens == 0 {
+		if err := r.ctx.Err(); err != nil {
+			return nil, err
+		}
+	}
+	return r.r.Token()
+}
+
+// namespaceReader reads the tokens of a decoder, replacing registered namespaces
+// with the namespaces of their messages
+type namespaceReader struct {
+	d *xml.Decoder
+}
+
+func (r *namespaceReader) Token() (xml.Token, error) {
+	tok, err := r.d.Token()
+	if err != nil {
+		return tok, err
+	}
+	switch t := tok.(type) {
+	case xml.StartElement:
+		t.Name.Space = resolveNamespace(t.Name.Space)
+		attrs := make([]xml.Attr, len(t.Attr))
+		for i, a := range t.Attr {
+			if a.Name.Space == "xmlns" || a.Name.Space == "" && a.Name.Local == "xmlns" {
+				a.Value = resolveNamespace(a.Value)
+			}
+			attrs[i] = a
+		}
+		t.Attr = attrs
+		return t, nil
+	case xml.EndElement:
+		t.Name.Space = resolveNamespace(t.Name.Space)
+		return t, nil
+	}
+	return tok, nil
+}
+
+// resolveNamespace returns the namespace of the message a registered namespace
+// stands for, or else the namespace itself
+func resolveNamespace(namespace string) string {
+	if canonical, ok := canonicalNamespace(namespace); ok {
+		return canonical
+	}
+	return namespace
+}
+
+// documentElement returns the name of the document element of v, read from the
+// tag of its XMLName field
+func documentElement(v interface{}) (string, bool) {
+	t := reflect.TypeOf(v)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return "", false
+	}
+	field, ok := t.FieldByName("XMLName")
+	if !ok {
+		return "", false
+	}
+	tag, _, _ := strings.Cut(field.Tag.Get("xml"), ",")
+	if i := strings.LastIndex(tag, " "); i >= 0 {
+		tag = tag[i+1:]
+	}
+	return tag, tag != ""
+}
@@ -0,0 +1,59 @@
+package iso20022
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRegisterNamespace(t *testing.T) {
+	const alias = "urn:iso:std:iso:20022:tech:xsd:pacs.008.001.08_GBT"
+	data, err := os.ReadFile(filepath.Join("testdata", "roundtrip", "pacs.008.001.08", "customer_credit_transfer.xml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	gbt := bytes.Replace(data, []byte(`xmlns="urn:iso:std:iso:20022:tech:xsd:pacs.008.001.08"`), []byte(`xmlns="`+alias+`"`), 1)
+	other := bytes.Replace(data, []byte(`xmlns="urn:iso:std:iso:20022:tech:xsd:pacs.008.001.08"`), []byte(`xmlns="urn:example:pacs.008"`), 1)
+
+	if err := RegisterNamespace("pacs.008.001.99", alias); !errors.Is(err, ErrUnknownMessage) {
+		t.Errorf("Expected ErrUnknownMessage for an unknown message, got %v", err)
+	}
+	if err := RegisterNamespace("pacs.008.001.08", alias); err != nil {
+		t.Fatalf("RegisterNamespace failed: %v", err)
+	}
+
+	if msgType, err := MessageType(gbt); err != nil || msgType != "pacs.008.001.08" {
+		t.Errorf("Expected pacs.008.001.08, got %q, %v", msgType, err)
+	}
+	if _, err := MessageType(other); !errors.Is(err, ErrUnknownMessage) {
+		t.Errorf("Expected ErrUnknownMessage for an unregistered namespace, got %v", err)
+	}
+	_, doc, err := DecodeDocument(gbt)
+	if err != nil {
+		t.Fatalf("DecodeDocument failed: %v", err)
+	}
+	d := doc.(*Pacs00800108Document)
+	if d.FICustomerCreditTransfer.GroupHeader.MessageID != "BBBBUS33-20240315-0001" {
+		t.Errorf("Unexpected group header %+v", d.FICustomerCreditTransfer.GroupHeader)
+	}
+	if err := ValidateXML(gbt, new(Pacs00800108Document)); err != nil {
+		t.Errorf("Unexpected validation errors: %v", err)
+	}
+	if err := Unmarshal(other, new(Pacs00800108Document)); err == nil {
+		t.Error("Expected an unregistered namespace to be rejected")
+	}
+
+	out, err := Marshal(d, WithNamespace(alias))
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if !bytes.HasPrefix(out, []byte(`<Document xmlns="`+alias+`">`)) {
+		t.Errorf("Expected the alias namespace, got %.80s", out)
+	}
+	again := new(Pacs00800108Document)
+	if err := Unmarshal(out, again); err != nil || again.FICustomerCreditTransfer.GroupHeader.MessageID != d.FICustomerCreditTransfer.GroupHeader.MessageID {
+		t.Errorf("Failed to read back the alias namespace: %v", err)
+	}
+}
@@ -0,0 +1,249 @@
+package iso20022
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// NewCreditNotification returns the camt.054 the creditor agent sends its customers
+// once the transactions of a pacs.008 are settled: one notification per creditor
+// account, in the order the accounts first appear, with a booked credit entry per
+// transaction built by CreditEntry. Notifications are identified by msgID suffixed
+// with their number (MSG-1, MSG-2, ...), or with WithIDs by the generator.
+func NewCreditNotification(msgID string, doc *Pacs00800108Document, opts ...IDOption) (*Camt05400108Document, error) {
+	o := newIDOptions(opts)
+	if err := o.assign(&msgID); err != nil {
+		return nil, err
+	}
+	msg := &doc.FICustomerCreditTransfer
+	now := NewISODateTime(time.Now().UTC())
+	var notifications []AccountNotification17
+	index := make(map[string]int)
+	for i := range msg.CreditTransferTransactionInfo {
+		tx := &msg.CreditTransferTransactionInfo[i]
+		entry, err := CreditEntry(&msg.GroupHeader, tx)
+		if err != nil {
+			return nil, fmt.Errorf("transaction %d (%s): %w", i+1, tx.PaymentID.EndToEndID, err)
+		}
+		acct := tx.CreditorAccount
+		key := acct.ID.Identifier()
+		n, ok := index[key]
+		if !ok {
+			n = len(notifications)
+			index[key] = n
+			id := ""
+			if o.ids == nil {
+				id = fmt.Sprintf("%s-%d", msgID, n+1)
+			} else if err := o.assign(&id); err != nil {
+				return nil, err
+			}
+			notifications = append(notifications, AccountNotification17{
+				ID:               id,
+				CreationDateTime: &now,
+				Account:          CashAccount39{ID: acct.ID, Type: acct.Type, Currency: acct.Currency, Name: acct.Name},
+			})
+		}
+		notifications[n].Entry = append(notifications[n].Entry, entry)
+	}
+	return &Camt05400108Document{BankDebitCreditNotification: BankToCustomerDebitCreditNotificationV08{
+		GroupHeader:  GroupHeader81{MsgID: msgID, CreationDateTime: &now},
+		Notification: notifications,
+	}}, nil
+}
+
+// CreditEntry returns the booked entry that credits the creditor account with a
+// settled pacs.008 transaction of the message with group header hdr. The entry is
+// booked and valued on the interbank settlement date for the settlement amount, and
+// its single transaction details carry the references, instructed amount and
+// exchange rate, charges, related parties and agents, purpose and remittance
+// information of the transaction. Its bank transaction code is chosen by
+// CreditTransferBankTransactionCode. The account servicer and entry references are
+// the bank's own and left for the caller to set.
+func CreditEntry(hdr *GroupHeader93, tx *CreditTransferTransaction39) (ReportEntry10, error) {
+	if tx.CreditorAccount == nil {
+		return ReportEntry10{}, errors.New("the transaction has no creditor account to credit")
+	}
+	settlement := tx.InterbankSettlementDate
+	if settlement == nil {
+		settlement = hdr.InterbankSettlementDate
+	}
+	if settlement == nil {
+		return ReportEntry10{}, errors.New("the transaction has no interbank settlement date")
+	}
+	date := *settlement
+	booked, credit := "BOOK", CreditDebitCRDT
+	amount := ActiveOrHistoricCurrencyAndAmount{Value: tx.InterbankSettlementAmount.Value, Currency: tx.InterbankSettlementAmount.Currency}
+	code := CreditTransferBankTransactionCode(tx)
+
+	msgID := hdr.MessageID
+	endToEndID := tx.PaymentID.EndToEndID
+	details := EntryTransaction10{
+		References: &TransactionReferences6{
+			MessageID:         &msgID,
+			InstructionID:     tx.PaymentID.InstructionID,
+			EndToEndID:        &endToEndID,
+			TransactionID:     tx.PaymentID.TransactionID,
+			ClearingSystemRef: tx.PaymentID.ClearingSystemReference,
+		},
+		Amount:               &amount,
+		CreditDebitIndicator: &credit,
+		AmountDetails:        amountDetails(tx),
+		BankTransactionCode:  &code,
+		Charges:              entryCharges(tx),
+		RelatedParties: &TransactionParties6{
+			InitiatingParty:  party40(tx.InitiatingParty),
+			Debtor:           party40(&tx.Debtor),
+			DebtorAccount:    tx.DebtorAccount,
+			UltimateDebtor:   party40(tx.UltimateDebtor),
+			Creditor:         party40(&tx.Creditor),
+			CreditorAccount:  tx.CreditorAccount,
+			UltimateCreditor: party40(tx.UltimateCreditor),
+		},
+		RelatedAgents: &TransactionAgents5{
+			InstructingAgent:   tx.InstructingAgent,
+			InstructedAgent:    tx.InstructedAgent,
+			DebtorAgent:        &tx.DebtorAgent,
+			CreditorAgent:      &tx.CreditorAgent,
+			IntermediaryAgent1: tx.IntermediaryAgent1,
+			IntermediaryAgent2: tx.IntermediaryAgent2,
+			IntermediaryAgent3: tx.IntermediaryAgent3,
+		},
+		RemittanceInfo: entryRemittanceInfo(tx.RemittanceInfo),
+		RelatedDates:   &TransactionDates3{InterbankSettlementDate: &date},
+	}
+	if tx.Purpose != nil {
+		details.Purpose = &Purpose2{Code: tx.Purpose.Code, Proprietary: tx.Purpose.Proprietary}
+	}
+
+	return ReportEntry10{
+		Amount:               amount,
+		CreditDebitIndicator: credit,
+		Status:               EntryStatus1{Code: &booked},
+		BookingDate:          &DateAndDateTime2{Date: &date},
+		ValueDate:            &DateAndDateTime2{Date: &date},
+		BankTransactionCode:  code,
+		EntryDetails:         []EntryDetails9{{TransactionDetails: []EntryTransaction10{details}}},
+	}, nil
+}
+
+// CreditTransferBankTransactionCode returns the bank transaction code of a received
+// credit transfer, PMNT/RCDT with the first matching subfamily: SALA for salary
+// payments, ESCT for SEPA credit transfers, BOOK when the debtor and creditor agents
+// are the same, XBCT when their BICs are of different countries, PRCT for high
+// priority payments, and DMCT otherwise.
+func CreditTransferBankTransactionCode(tx *CreditTransferTransaction39) BankTransactionCodeStructure4 {
+	subFamily := "DMCT"
+	var service, category string
+	var priority Priority2Code
+	if t := tx.PaymentTypeInfo; t != nil {
+		for _, level := range t.ServiceLevel {
+			if level.Code != nil && *level.Code == "SEPA" {
+				service = "SEPA"
+			}
+		}
+		if t.CategoryPurpose != nil {
+			category = deref(t.CategoryPurpose.Code)
+		}
+		if t.InstructionPriority != nil {
+			priority = *t.InstructionPriority
+		}
+	}
+	debtorBIC := deref(tx.DebtorAgent.FinancialInstitutionID.BankIdentifierCode)
+	creditorBIC := deref(tx.CreditorAgent.FinancialInstitutionID.BankIdentifierCode)
+	switch {
+	case category == "SALA":
+		subFamily = "SALA"
+	case service == "SEPA":
+		subFamily = "ESCT"
+	case agentKey(tx.DebtorAgent) != "" && agentKey(tx.DebtorAgent) == agentKey(tx.CreditorAgent):
+		subFamily = "BOOK"
+	case len(debtorBIC) >= 6 && len(creditorBIC) >= 6 && debtorBIC[4:6] != creditorBIC[4:6]:
+		subFamily = "XBCT"
+	case priority == PriorityHIGH:
+		subFamily = "PRCT"
+	}
+	return BankTransactionCodeStructure4{Domain: &BankTransactionCodeStructure5{
+		Code:   "PMNT",
+		Family: BankTransactionCodeStructure6{Code: "RCDT", SubFamilyCode: subFamily},
+	}}
+}
+
+// amountDetails returns the instructed and transaction amounts of a transaction
+// instructed in an amount of its own, with the exchange rate when converted
+func amountDetails(tx *CreditTransferTransaction39) *AmountAndCurrencyExchange3 {
+	if tx.InstructedAmount == nil {
+		return nil
+	}
+	instructed := &AmountAndCurrencyExchangeDetails4{Amount: *tx.InstructedAmount}
+	if tx.ExchangeRate != nil {
+		target := tx.InterbankSettlementAmount.Currency
+		instructed.CurrencyExchange = &CurrencyExchange5{SourceCurrency: tx.InstructedAmount.Currency, TargetCurrency: &target, ExchangeRate: tx.ExchangeRate}
+	}
+	return &AmountAndCurrencyExchange3{
+		InstructedAmount:  instructed,
+		TransactionAmount: &AmountAndCurrencyExchangeDetails4{Amount: ActiveOrHistoricCurrencyAndAmount{Value: tx.InterbankSettlementAmount.Value, Currency: tx.InterbankSettlementAmount.Currency}},
+	}
+}
+
+// entryCharges returns the charges taken by the agents of a transaction
+func entryCharges(tx *CreditTransferTransaction39) *Charges6 {
+	if len(tx.ChargesInfo) == 0 {
+		return nil
+	}
+	bearer := ChargeBearerType1Code(tx.ChargeBearer)
+	charges := &Charges6{}
+	for i := range tx.ChargesInfo {
+		c := &tx.ChargesInfo[i]
+		charges.Record = append(charges.Record, ChargesRecord3{Amount: c.Amount, Bearer: &bearer, Agent: &c.Agent})
+	}
+	return charges
+}
+
+// party40 returns a party of a transaction as a related party of an entry
+func party40(p *PartyIdentification135) *Party40 {
+	if p == nil {
+		return nil
+	}
+	return &Party40{Party: p}
+}
+
+// entryRemittanceInfo returns the remittance information of a transaction as the
+// remittance information of an entry, with the referred documents, amounts and
+// creditor references of its structured parts
+func entryRemittanceInfo(info *RemittanceInfo) *RemittanceInfo16 {
+	if info == nil {
+		return nil
+	}
+	out := &RemittanceInfo16{Unstructured: info.Unstructured}
+	for _, s := range info.Structured {
+		strd := StructuredRemittanceInfo16{}
+		for _, doc := range s.ReferredDocumentInfo {
+			ref := ReferredDocumentInfo7{Number: doc.Number, RelatedDate: doc.RelatedDate}
+			if doc.Type != nil {
+				ref.Type = &ReferredDocumentType4{
+					CodeOrProprietary: ReferredDocumentType3{Code: doc.Type.CodeOrProprietary.Code, Proprietary: doc.Type.CodeOrProprietary.Proprietary},
+					Issuer:            doc.Type.Issuer,
+				}
+			}
+			strd.ReferredDocumentInfo = append(strd.ReferredDocumentInfo, ref)
+		}
+		if a := s.ReferredDocumentAmount; a != nil {
+			strd.ReferredDocumentAmount = &RemittanceAmount2{DuePayableAmount: a.DuePayableAmount, CreditNoteAmount: a.CreditNoteAmount, RemittedAmount: a.RemittedAmount}
+		}
+		if r := s.CreditorReferenceInfo; r != nil {
+			strd.CreditorReferenceInfo = &CreditorReferenceInfo2{Reference: r.Reference}
+			if r.Type != nil {
+				strd.CreditorReferenceInfo.Type = &CreditorReferenceType2{
+					CodeOrProprietary: CreditorReferenceType1{Code: r.Type.CodeOrProprietary.Code, Proprietary: r.Type.CodeOrProprietary.Proprietary},
+					Issuer:            r.Type.Issuer,
+				}
+			}
+		}
+		if s.AdditionalRemittanceInfo != nil {
+			strd.AdditionalRemittanceInfo = []string{*s.AdditionalRemittanceInfo}
+		}
+		out.Structured = append(out.Structured, strd)
+	}
+	return out
+}
@@ -0,0 +1,90 @@
+package iso20022
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNewCreditNotification(t *testing.T) {
+	pacs := loadPacs008Sample(t)
+	doc, err := NewCreditNotification("CCCCGB2L-NTF-0001", pacs)
+	if err != nil {
+		t.Fatalf("NewCreditNotification failed: %v", err)
+	}
+	if err := doc.Validate(); err != nil {
+		t.Errorf("Expected a valid camt.054, got %v", err)
+	}
+	notifications := doc.BankDebitCreditNotification.Notification
+	if len(notifications) != 1 || notifications[0].ID != "CCCCGB2L-NTF-0001-1" {
+		t.Fatalf("Expected a notification for the creditor account, got %+v", notifications)
+	}
+	if iban := notifications[0].Account.ID.IBAN; iban == nil || *iban != "GB29NWBK60161331926819" {
+		t.Errorf("Expected the creditor account to be notified, got %+v", notifications[0].Account.ID)
+	}
+
+	postings, err := doc.Postings()
+	if err != nil {
+		t.Fatalf("Postings failed: %v", err)
+	}
+	if len(postings) != 1 {
+		t.Fatalf("Expected a posting, got %d", len(postings))
+	}
+	p := postings[0]
+	if p.Amount.Value != 15000 || p.Amount.Currency != "USD" || p.CdtDbt != "CRDT" || p.Status != "BOOK" ||
+		p.ValueDate.Format(isoDateLayout) != "2024-03-15" || p.BookingDate.Format(isoDateLayout) != "2024-03-15" {
+		t.Errorf("Unexpected posting %+v", p)
+	}
+	if *p.References.MessageID != "BBBBUS33-20240315-0001" || *p.References.EndToEndID != "INV-2024-0042" || *p.References.TransactionID != "BBBBUS33-TX-0001" {
+		t.Errorf("Unexpected references %+v", p.References)
+	}
+	if p.BankTxCode.Domain.Family.SubFamilyCode != "XBCT" {
+		t.Errorf("Expected a cross-border credit transfer, got %s", p.BankTxCode.Domain.Family.SubFamilyCode)
+	}
+
+	tx := notifications[0].Entry[0].EntryDetails[0].TransactionDetails[0]
+	if *tx.RelatedParties.Debtor.Party.Name != "Acme Manufacturing Inc" || *tx.RelatedAgents.DebtorAgent.FinancialInstitutionID.BankIdentifierCode != "BBBBUS33" {
+		t.Errorf("Unexpected related parties or agents %+v, %+v", tx.RelatedParties, tx.RelatedAgents)
+	}
+	if len(tx.Charges.Record) != 1 || tx.Charges.Record[0].Amount.Value != 25 {
+		t.Errorf("Expected the charges of the debtor agent, got %+v", tx.Charges)
+	}
+	strd := tx.RemittanceInfo.Structured
+	if len(strd) != 1 || *strd[0].ReferredDocumentInfo[0].Number != "INV-2024-0042" || strd[0].CreditorReferenceInfo.Reference == nil ||
+		*strd[0].CreditorReferenceInfo.Reference != "RF18539007547034" {
+		t.Errorf("Expected the structured remittance information, got %+v", tx.RemittanceInfo)
+	}
+
+	pacs.FICustomerCreditTransfer.CreditTransferTransactionInfo[0].CreditorAccount = nil
+	if _, err := NewCreditNotification("CCCCGB2L-NTF-0002", pacs); err == nil || !strings.Contains(err.Error(), "INV-2024-0042") {
+		t.Errorf("Expected an error for the missing creditor account, got %v", err)
+	}
+}
+
+func TestCreditTransferBankTransactionCode(t *testing.T) {
+	for _, tt := range []struct {
+		name   string
+		modify func(tx *CreditTransferTransaction39)
+		want   string
+	}{
+		{"cross-border", func(tx *CreditTransferTransaction39) {}, "XBCT"},
+		{"domestic", func(tx *CreditTransferTransaction39) {
+			tx.CreditorAgent = AgentFromClearingSystem("USABA", "026009593", "")
+		}, "DMCT"},
+		{"high priority", func(tx *CreditTransferTransaction39) {
+			tx.CreditorAgent = AgentFromClearingSystem("USABA", "026009593", "")
+			*tx.PaymentTypeInfo.InstructionPriority = "HIGH"
+		}, "PRCT"},
+		{"book transfer", func(tx *CreditTransferTransaction39) { tx.CreditorAgent = tx.DebtorAgent }, "BOOK"},
+		{"SEPA", func(tx *CreditTransferTransaction39) { tx.PaymentTypeInfo.ServiceLevel[0].Code = Ptr("SEPA") }, "ESCT"},
+		{"salary", func(tx *CreditTransferTransaction39) { tx.PaymentTypeInfo.CategoryPurpose.Code = Ptr("SALA") }, "SALA"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			tx := loadPacs008Sample(t).FICustomerCreditTransfer.CreditTransferTransactionInfo[0]
+			tt.modify(&tx)
+			code := CreditTransferBankTransactionCode(&tx)
+			if code.Domain.Code != "PMNT" || code.Domain.Family.Code != "RCDT" || code.Domain.Family.SubFamilyCode != tt.want {
+				t.Errorf("Expected PMNT/RCDT/%s, got %+v", tt.want, code.Domain)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package iso20022
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+)
+
+// Operation is an operation of the package reported to the Observer
+type Operation string
+
+const (
+	OperationParse    Operation = "parse"    // Unmarshal, and DecodeDocument and ValidateXML through it
+	OperationValidate Operation = "validate" // Validate
+	OperationMarshal  Operation = "marshal"  // Encoder.Encode, and Marshal through it
+)
+
+// Observation describes an operation once it has finished
+type Observation struct {
+	Operation Operation
+	// MessageType is the message name identification of the document, such as
+	// pacs.008.001.08, or "" for a value that is not a document
+	MessageType string
+	Start       time.Time
+	Duration    time.Duration
+	// Size is the number of bytes parsed or written, or 0 for a validation
+	Size int
+	// Err is the error the operation returned
+	Err error
+	// Report is the report of a validation that completed, or nil
+	Report *ValidationReport
+}
+
+// Observer is told about the operations of the package, for instance to record
+// metrics or spans. Observe is called synchronously by the goroutine that ran the
+// operation, so it must be quick and safe for concurrent use.
+type Observer interface {
+	Observe(ctx context.Context, o Observation)
+}
+
+// ObserverFunc is an Observer calling a function
+type ObserverFunc func(ctx context.Context, o Observation)
+
+func (f ObserverFunc) Observe(ctx context.Context, o Observation) {
+	f(ctx, o)
+}
+
+// Observers returns an Observer telling each of obs in turn
+func Observers(obs ...Observer) Observer {
+	return ObserverFunc(func(ctx context.Context, o Observation) {
+		for _, ob := range obs {
+			ob.Observe(ctx, o)
+		}
+	})
+}
+
+var (
+	observerMu sync.RWMutex
+	observer   Observer
+)
+
+// SetObserver sets the Observer told about every parse, validation and marshal,
+// replacing the previous one. Passing nil removes it, which is the default. Use
+// Observers to set more than one.
+func SetObserver(o Observer) {
+	observerMu.Lock()
+	defer observerMu.Unlock()
+	observer = o
+}
+
+// currentObserver returns the Observer set with SetObserver, or nil
+func currentObserver() Observer {
+	observerMu.RLock()
+	defer observerMu.RUnlock()
+	return observer
+}
+
+// countingWriter counts the bytes written to w
+type countingWriter struct {
+	w io.Writer
+	n int
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	n, err := w.w.Write(p)
+	w.n += n
+	return n, err
+}
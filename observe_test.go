@@ -0,0 +1,97 @@
+package iso20022
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+// recorder is an Observer keeping the observations it is told about
+type recorder struct {
+	mu  sync.Mutex
+	obs []Observation
+}
+
+func (r *recorder) Observe(ctx context.Context, o Observation) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.obs = append(r.obs, o)
+}
+
+func TestObserver(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("testdata", "roundtrip", "pacs.008.001.08", "customer_credit_transfer.xml"))
+	if err != nil {
+		t.Fatalf("Failed to read fixture: %v", err)
+	}
+	var r recorder
+	SetObserver(&r)
+	defer SetObserver(nil)
+
+	msgType, doc, err := DecodeDocument(data)
+	if err != nil {
+		t.Fatalf("Failed to decode: %v", err)
+	}
+	report, err := Validate(context.Background(), doc.(Validator))
+	if err != nil {
+		t.Fatalf("Failed to validate: %v", err)
+	}
+	out, err := Marshal(doc)
+	if err != nil {
+		t.Fatalf("Failed to marshal: %v", err)
+	}
+	if err := Unmarshal([]byte("<Document"), new(Pacs00800108Document)); err == nil {
+		t.Fatal("Expected truncated XML to fail")
+	}
+
+	if len(r.obs) != 4 {
+		t.Fatalf("Expected 4 observations, got %+v", r.obs)
+	}
+	parse, valid, marshal, failed := r.obs[0], r.obs[1], r.obs[2], r.obs[3]
+	if parse.Operation != OperationParse || parse.MessageType != msgType || parse.Size != len(data) || parse.Err != nil || parse.Start.IsZero() || parse.Duration <= 0 {
+		t.Errorf("Expected the parse of the sample, got %+v", parse)
+	}
+	if valid.Operation != OperationValidate || valid.MessageType != "pacs.008.001.08" || valid.Report != report || valid.Size != 0 {
+		t.Errorf("Expected the validation of the sample, got %+v", valid)
+	}
+	if marshal.Operation != OperationMarshal || marshal.MessageType != "pacs.008.001.08" || marshal.Size != len(out) {
+		t.Errorf("Expected the marshal of the sample in %d bytes, got %+v", len(out), marshal)
+	}
+	if failed.Operation != OperationParse || failed.Err == nil || failed.MessageType != "pacs.008.001.08" {
+		t.Errorf("Expected the failed parse, got %+v", failed)
+	}
+
+	// Each observer of Observers is told, and none once it is removed
+	var other recorder
+	SetObserver(Observers(&r, &other))
+	if _, err := Marshal(doc); err != nil {
+		t.Fatal(err)
+	}
+	SetObserver(nil)
+	if _, err := Marshal(doc); err != nil {
+		t.Fatal(err)
+	}
+	if len(r.obs) != 5 || len(other.obs) != 1 {
+		t.Errorf("Expected one more observation for each, got %d and %d", len(r.obs), len(other.obs))
+	}
+}
+
+func TestObserverEncoderSize(t *testing.T) {
+	var r recorder
+	SetObserver(&r)
+	defer SetObserver(nil)
+
+	buf := &countingWriter{w: io.Discard}
+	enc := NewEncoder(buf)
+	doc := loadPacs008Sample(t)
+	for i := 0; i < 2; i++ {
+		if err := enc.Encode(doc); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(r.obs) != 2 || r.obs[0].Size == 0 || r.obs[0].Size+r.obs[1].Size != buf.n {
+		t.Errorf("Expected each encode to count its own bytes of %d, got %+v", buf.n, r.obs)
+	}
+}
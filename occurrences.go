@@ -0,0 +1,128 @@
+package iso20022
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// occurs is the number of occurrences the XSD allows for a repeating element
+type occurs struct {
+	min, max int // max is 0 when unbounded
+}
+
+// occurrences maps component types to the occurrence bounds of their repeating
+// elements. Every slice field is checked: an element that is not listed may
+// occur any number of times, as most repeating ISO 20022 elements may. Minimums
+// that the Validate methods of the messages already check with messages of their
+// own, such as PmtInf in pain.001, are left out so that they are not reported
+// twice.
+var occurrences = map[string]map[string]occurs{
+	// Credit transfers
+	"FIToFICustomerCreditTransferV02":        {"CdtTrfTxInf": {min: 1}},
+	"FIToFICustomerCreditTransferV06":        {"CdtTrfTxInf": {min: 1}},
+	"FinancialInstitutionCreditTransferV08":  {"CdtTrfTxInf": {min: 1}},
+	"CreditTransferTransaction39":            {"RgltryRptg": {max: 10}, "RltdRmtInf": {max: 10}},
+	"CreditTransferTransaction64":            {"RgltryRptg": {max: 10}, "RltdRmtInf": {max: 10}},
+	"CreditTransferTransaction34":            {"RgltryRptg": {max: 10}, "RltdRmtInf": {max: 10}},
+	"CreditTransferTransaction35":            {"RgltryRptg": {max: 10}, "RltdRmtInf": {max: 10}},
+	"CreditTransferTransaction25":            {"RgltryRptg": {max: 10}, "RltdRmtInf": {max: 10}},
+	"CreditTransferTransactionInformation11": {"RgltryRptg": {max: 10}, "RltdRmtInf": {max: 10}},
+	"GroupHeader90":                          {"Authstn": {max: 2}},
+	"Cheque11":                               {"MemoFld": {max: 2}, "Sgntr": {max: 5}},
+
+	// Request to pay
+	"CreditorPaymentActivationRequestV07": {"PmtInf": {min: 1}},
+	"PaymentInstruction31":                {"CdtTrfTx": {min: 1}},
+
+	// Cancellations and investigations
+	"FIToFIPaymentCancellationRequestV08":   {"Undrlyg": {min: 1}},
+	"FIToFIPaymentCancellationRequestV11":   {"Undrlyg": {min: 1}},
+	"CustomerPaymentCancellationRequestV09": {"Undrlyg": {min: 1}},
+	"MissingOrIncorrectInformation3":        {"MssngInf": {max: 10}, "IncrrctInf": {max: 10}},
+
+	// Account reporting
+	"BankToCustomerAccountReportV08":           {"Rpt": {min: 1}},
+	"BankToCustomerDebitCreditNotificationV08": {"Ntfctn": {min: 1}},
+	"AccountReportingRequestV05":               {"RptgReq": {min: 1}},
+
+	// Administration
+	"ResendRequestV01":          {"RsndSchCrit": {min: 1}},
+	"ReceiptAcknowledgementV01": {"Rpt": {min: 1}},
+
+	// Shared components
+	"PostalAddress24":            {"AdrLine": {max: 7}},
+	"PostalAddress27":            {"AdrLine": {max: 7}},
+	"PostalAddress":              {"AdrLine": {max: 7}},
+	"PostalAddress1":             {"AdrLine": {max: 5}},
+	"StructuredRemittanceInfo16": {"AddtlRmtInf": {max: 3}},
+	"StructuredRemittanceInfo18": {"AddtlRmtInf": {max: 3}},
+	"DocumentLineInfo1":          {"Id": {min: 1}},
+}
+
+// validateStructure checks the number of occurrences of every repeating element
+// of v and of the components below it, and that every choice among them has
+// exactly one element. The paths of the errors start below v.
+func validateStructure(v interface{}) ValidationErrors {
+	var errs ValidationErrors
+	walkStructure(reflect.ValueOf(v), "", &errs)
+	return errs
+}
+
+// walkStructure checks the repeating elements and choices of the struct value v at
+// path
+func walkStructure(v reflect.Value, path string, errs *ValidationErrors) {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return
+	}
+	t := v.Type()
+	if isChoice(t) {
+		checkChoice(v, path, errs)
+	}
+	bounds := occurrences[t.Name()]
+	for _, field := range reflect.VisibleFields(t) {
+		if !field.IsExported() || field.Anonymous || field.Name == "XMLName" {
+			continue
+		}
+		name, flags, _ := strings.Cut(field.Tag.Get("xml"), ",")
+		if name == "-" || strings.Contains(flags, "attr") || strings.Contains(flags, "chardata") ||
+			strings.Contains(flags, "innerxml") || strings.Contains(flags, "comment") {
+			continue
+		}
+		if j := strings.LastIndex(name, " "); j >= 0 {
+			name = name[j+1:]
+		}
+		if name == "" {
+			name = field.Name
+		}
+		elementPath := name
+		if path != "" {
+			elementPath = path + "/" + name
+		}
+		value := v.FieldByIndex(field.Index)
+		if field.Type.Kind() != reflect.Slice || field.Type.Elem().Kind() == reflect.Uint8 {
+			walkStructure(value, elementPath, errs)
+			continue
+		}
+		n := value.Len()
+		if b, ok := bounds[name]; ok {
+			switch {
+			case n < b.min && b.min == 1:
+				*errs = append(*errs, newValidationError(name, RuleOccurrences, "OCCURRENCES_MIN_ONE").at(elementPath))
+			case n < b.min:
+				*errs = append(*errs, newValidationError(name, RuleOccurrences, "OCCURRENCES_MIN", b.min, n).at(elementPath))
+			case b.max > 0 && n > b.max:
+				*errs = append(*errs, newValidationError(name, RuleOccurrences, "OCCURRENCES_MAX", b.max, n).at(elementPath))
+			}
+		}
+		for i := 0; i < n; i++ {
+			walkStructure(value.Index(i), fmt.Sprintf("%s[%d]", elementPath, i+1), errs)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package iso20022
+
+import (
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestOccurrencesTable checks that every bounded element names a repeating field
+// of a component of the package
+func TestOccurrencesTable(t *testing.T) {
+	fields := make(map[string]bool)
+	seen := make(map[reflect.Type]bool)
+	var walk func(reflect.Type)
+	walk = func(typ reflect.Type) {
+		for typ.Kind() == reflect.Ptr || typ.Kind() == reflect.Slice {
+			typ = typ.Elem()
+		}
+		if typ.Kind() != reflect.Struct || seen[typ] {
+			return
+		}
+		seen[typ] = true
+		for _, field := range reflect.VisibleFields(typ) {
+			if field.Type.Kind() == reflect.Slice {
+				tag, _, _ := strings.Cut(field.Tag.Get("xml"), ",")
+				fields[typ.Name()+"."+tag] = true
+			}
+			walk(field.Type)
+		}
+	}
+	for _, newDocument := range documentTypes {
+		walk(reflect.TypeOf(newDocument()))
+	}
+	for typ, bounds := range occurrences {
+		for tag := range bounds {
+			if !fields[typ+"."+tag] {
+				t.Errorf("%s has no repeating element %s", typ, tag)
+			}
+		}
+	}
+}
+
+// TestOccurrencesGoldenFiles checks that the round-trip samples respect the
+// occurrence bounds and have exactly one element in every choice
+func TestOccurrencesGoldenFiles(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join("testdata", "roundtrip", "*", "*.xml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		doc := roundTripDocuments[filepath.Base(filepath.Dir(file))]()
+		if err := xml.Unmarshal(data, doc); err != nil {
+			t.Fatalf("%s: %v", file, err)
+		}
+		if errs := validateStructure(doc); errs.HasErrors() {
+			t.Errorf("%s: %v", file, errs)
+		}
+	}
+}
+
+func TestValidateOccurrences(t *testing.T) {
+	t.Run("pacs.008 regulatory reporting", func(t *testing.T) {
+		doc := loadPacs008Sample(t)
+		tx := &doc.FICustomerCreditTransfer.CreditTransferTransactionInfo[0]
+		tx.RegulatoryReporting = make([]RegulatoryReporting3, 11)
+		err := doc.Validate()
+		if err == nil || !strings.Contains(err.Error(), "Field 'FIToFICstmrCdtTrf/CdtTrfTxInf[1]/RgltryRptg': at most 10 occurrences are allowed, got 11") {
+			t.Errorf("Expected RgltryRptg to be reported, got %v", err)
+		}
+		tx.RegulatoryReporting = tx.RegulatoryReporting[:10]
+		if err := validateStructure(doc); err != nil {
+			t.Errorf("Expected 10 occurrences to be allowed, got %v", err)
+		}
+	})
+
+	t.Run("Older versions", func(t *testing.T) {
+		doc := loadPacs008Version(t, "pacs.008.001.02").(*Pacs00800102Document)
+		doc.FICustomerCreditTransfer.CreditTransferTransactionInfo[0].RegulatoryReporting = make([]RegulatoryReporting3, 11)
+		if err := doc.Validate(); err == nil || !strings.Contains(err.Error(), "CdtTrfTxInf[1]/RgltryRptg") {
+			t.Errorf("Expected RgltryRptg to be reported, got %v", err)
+		}
+	})
+
+	t.Run("Postal address lines", func(t *testing.T) {
+		party := PartyIdentification135{PostalAddress: &PostalAddress24{AddressLine: make([]string, 8)}}
+		errs := validateStructure(&party)
+		if len(errs) != 1 || errs[0].Location() != "PstlAdr/AdrLine" || errs[0].Field != "AdrLine" {
+			t.Errorf("Expected AdrLine to be reported, got %v", errs)
+		}
+	})
+
+	t.Run("Additional remittance information", func(t *testing.T) {
+		rmt := RemittanceInfo16{Structured: []StructuredRemittanceInfo16{{}, {AdditionalRemittanceInfo: []string{"a", "b", "c", "d"}}}}
+		errs := validateStructure(&rmt)
+		if len(errs) != 1 || errs[0].Location() != "Strd[2]/AddtlRmtInf" {
+			t.Errorf("Expected AddtlRmtInf to be reported, got %v", errs)
+		}
+	})
+
+	t.Run("Required repetition", func(t *testing.T) {
+		doc := new(Camt05600108Document)
+		err := doc.Validate()
+		if err == nil || !strings.Contains(err.Error(), "Field 'FIToFIPmtCxlReq/Undrlyg': at least one occurrence is required") {
+			t.Errorf("Expected Undrlyg to be reported, got %v", err)
+		}
+	})
+
+	t.Run("Paths within the message", func(t *testing.T) {
+		doc := new(Pain00100109Document)
+		doc.CustomerCreditTransferInitiation.PaymentInfo = []PaymentInstruction30{{
+			CreditTransferTransactionInfo: []CreditTransferTransaction34{{RelatedRemittanceInfo: make([]RemittanceLocation, 11)}},
+		}}
+		err := doc.Validate()
+		if err == nil || !strings.Contains(err.Error(), "Field 'CstmrCdtTrfInitn/PmtInf[1]/CdtTrfTxInf[1]/RltdRmtInf': at most 10") {
+			t.Errorf("Expected RltdRmtInf to be reported, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,380 @@
+package iso20022
+
+import (
+	"encoding/xml"
+	"fmt"
+	"time"
+)
+
+// Pacs002Versions are the versions of pacs.002 this package reads and writes, in
+// order
+var Pacs002Versions = []string{"pacs.002.001.03", "pacs.002.001.10", "pacs.002.001.12", "pacs.002.001.14"}
+
+// Pacs002 is a pacs.002 FI to FI payment status report of any of the
+// Pacs002Versions. It gives access to the statuses the report carries, so that code
+// handling them does not depend on the version a counterparty sends.
+type Pacs002 interface {
+	// Version returns the message name identification, such as pacs.002.001.10
+	Version() string
+	MessageID() string
+	CreationDateTime() time.Time
+	GroupStatuses() []Pacs002GroupStatus
+	TransactionStatuses() []Pacs002TransactionStatus
+}
+
+// Pacs002GroupStatus holds the status of an original message
+type Pacs002GroupStatus struct {
+	OriginalMessageID     string
+	OriginalMessageNameID string
+	Status                string   // empty when only the transactions have a status
+	Reasons               []string // status reason codes, or else proprietary reasons
+}
+
+// Pacs002TransactionStatus holds the status of an original transaction
+type Pacs002TransactionStatus struct {
+	// OriginalMessageID is that of the original group information of the
+	// transaction, or else that of the only original message of the report
+	OriginalMessageID     string
+	OriginalInstructionID string
+	OriginalEndToEndID    string
+	OriginalTransactionID string
+	OriginalUETR          string // empty in pacs.002.001.03
+	Status                string // empty when the transaction has no status of its own
+	Reasons               []string
+}
+
+// DecodePacs002 decodes a pacs.002 of any of the Pacs002Versions
+func DecodePacs002(data []byte) (Pacs002, error) {
+	msgType, doc, err := DecodeDocument(data)
+	if err != nil {
+		return nil, err
+	}
+	p, ok := doc.(Pacs002)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s is not a pacs.002", ErrUnknownMessage, msgType)
+	}
+	return p, nil
+}
+
+// ConvertPacs002 returns a pacs.002 in another of the Pacs002Versions. It fails
+// with ErrVersionLoss, naming the element, when the message has an element the
+// target version does not, such as an original UETR or the status of a second
+// original message in pacs.002.001.03. It also fails so when Unmarshal could not
+// decode an element of the message, which would otherwise be lost.
+func ConvertPacs002(doc Pacs002, version string) (Pacs002, error) {
+	newDocument, ok := documentsByType[version]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownMessage, version)
+	}
+	target, ok := newDocument().(Pacs002)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s is not a pacs.002", ErrUnknownMessage, version)
+	}
+	if err := unmodelledLoss(doc); err != nil {
+		return nil, fmt.Errorf("converting %s to %s: %w", doc.Version(), version, err)
+	}
+	if err := convertVersion(target, doc); err != nil {
+		return nil, fmt.Errorf("converting %s to %s: %w", doc.Version(), version, err)
+	}
+	return target, nil
+}
+
+// Pacs00200103Document is a pacs.002.001.03 message, the version of the 2009
+// message definitions Fedwire and many clearing systems base their status reports
+// on. It reports on a single original message, identifies financial institutions by
+// BIC and has no UETR.
+type Pacs00200103Document struct {
+	XMLName               xml.Name                     `xml:"urn:iso:std:iso:20022:tech:xsd:pacs.002.001.03 Document"`
+	FIPaymentStatusReport FIToFIPaymentStatusReportV03 `xml:"FIToFIPmtStsRpt"`
+}
+
+// FIToFIPaymentStatusReportV03 is the message of pacs.002.001.03
+type FIToFIPaymentStatusReportV03 struct {
+	GroupHeader                       GroupHeader53                     `xml:"GrpHdr"`
+	OriginalGroupInformationAndStatus OriginalGroupInformation20        `xml:"OrgnlGrpInfAndSts"`
+	TransactionInfoAndStatus          []PaymentTransactionInformation26 `xml:"TxInfAndSts,omitempty"`
+}
+
+// GroupHeader53 is the group header of pacs.002.001.03
+type GroupHeader53 struct {
+	MessageID        string                                        `xml:"MsgId"`
+	CreationDateTime ISODateTime                                   `xml:"CreDtTm"`
+	InstructingAgent *BranchAndFinancialInstitutionIdentification4 `xml:"InstgAgt,omitempty"`
+	InstructedAgent  *BranchAndFinancialInstitutionIdentification4 `xml:"InstdAgt,omitempty"`
+}
+
+// OriginalGroupInformation20 is the original message and its status in
+// pacs.002.001.03
+type OriginalGroupInformation20 struct {
+	OriginalMessageID             string                           `xml:"OrgnlMsgId"`
+	OriginalMessageNameID         string                           `xml:"OrgnlMsgNmId"`
+	OriginalCreationDateTime      *ISODateTime                     `xml:"OrgnlCreDtTm,omitempty"`
+	OriginalNumberOfTransactions  *string                          `xml:"OrgnlNbOfTxs,omitempty"`
+	OriginalControlSum            *Decimal                         `xml:"OrgnlCtrlSum,omitempty"`
+	GroupStatus                   *string                          `xml:"GrpSts,omitempty"`
+	StatusReasonInfo              []StatusReasonInformation8       `xml:"StsRsnInf,omitempty"`
+	NumberOfTransactionsPerStatus []NumberOfTransactionsPerStatus5 `xml:"NbOfTxsPerSts,omitempty"`
+}
+
+// PaymentTransactionInformation26 is a transaction status of pacs.002.001.03. It
+// has no original UETR, original group information or agents.
+type PaymentTransactionInformation26 struct {
+	StatusID                     *string                         `xml:"StsId,omitempty"`
+	OriginalInstructionID        *string                         `xml:"OrgnlInstrId,omitempty"`
+	OriginalEndToEndID           *string                         `xml:"OrgnlEndToEndId,omitempty"`
+	OriginalTransactionID        *string                         `xml:"OrgnlTxId,omitempty"`
+	TransactionStatus            *string                         `xml:"TxSts,omitempty"`
+	StatusReasonInfo             []StatusReasonInformation8      `xml:"StsRsnInf,omitempty"`
+	ChargesInfo                  []Charges1                      `xml:"ChrgsInf,omitempty"`
+	AcceptanceDateTime           *ISODateTime                    `xml:"AccptncDtTm,omitempty"`
+	AccountServicerReference     *string                         `xml:"AcctSvcrRef,omitempty"`
+	ClearingSystemReference      *string                         `xml:"ClrSysRef,omitempty"`
+	OriginalTransactionReference *OriginalTransactionReference13 `xml:"OrgnlTxRef,omitempty"`
+}
+
+// StatusReasonInformation8 is a status reason of pacs.002.001.03
+type StatusReasonInformation8 struct {
+	Originator            *PartyIdentification32 `xml:"Orgtr,omitempty"`
+	Reason                *StatusReason62        `xml:"Rsn,omitempty"`
+	AdditionalInformation []string               `xml:"AddtlInf,omitempty"`
+}
+
+// OriginalTransactionReference13 holds the references of the original transaction
+// in pacs.002.001.03 that status handling uses. The parties of the original
+// transaction, which this version does not wrap in a Pty element, are not decoded.
+type OriginalTransactionReference13 struct {
+	InterbankSettlementAmount *ActiveOrHistoricCurrencyAndAmount            `xml:"IntrBkSttlmAmt,omitempty"`
+	InterbankSettlementDate   *ISODate                                      `xml:"IntrBkSttlmDt,omitempty"`
+	RequestedCollectionDate   *ISODate                                      `xml:"ReqdColltnDt,omitempty"`
+	PaymentMethod             *string                                       `xml:"PmtMtd,omitempty"`
+	DebtorAccount             *CashAccount38                                `xml:"DbtrAcct,omitempty"`
+	DebtorAgent               *BranchAndFinancialInstitutionIdentification4 `xml:"DbtrAgt,omitempty"`
+	CreditorAgent             *BranchAndFinancialInstitutionIdentification4 `xml:"CdtrAgt,omitempty"`
+	CreditorAccount           *CashAccount38                                `xml:"CdtrAcct,omitempty"`
+}
+
+// Pacs00200112Document is a pacs.002.001.12 message. Its core is that of
+// pacs.002.001.10, whose components it uses. Unmarshal records the elements of
+// the later components these have no field for, which Unmodelled returns.
+type Pacs00200112Document struct {
+	XMLName               xml.Name                     `xml:"urn:iso:std:iso:20022:tech:xsd:pacs.002.001.12 Document"`
+	FIPaymentStatusReport FIToFIPaymentStatusReportV10 `xml:"FIToFIPmtStsRpt"`
+	unmodelled            []string
+}
+
+// Pacs00200114Document is a pacs.002.001.14 message. Its core is that of
+// pacs.002.001.10, whose components it uses. Unmarshal records the elements added
+// since, such as the CareOf of a postal address, which Unmodelled returns.
+type Pacs00200114Document struct {
+	XMLName               xml.Name                     `xml:"urn:iso:std:iso:20022:tech:xsd:pacs.002.001.14 Document"`
+	FIPaymentStatusReport FIToFIPaymentStatusReportV10 `xml:"FIToFIPmtStsRpt"`
+	unmodelled            []string
+}
+
+// Unmodelled returns the paths of the elements of the message, as read by
+// Unmarshal, that its components have no field for. They are not decoded, and
+// ConvertPacs002 fails on them with ErrVersionLoss.
+func (d *Pacs00200112Document) Unmodelled() []string { return d.unmodelled }
+
+func (d *Pacs00200112Document) setUnmodelled(paths []string) { d.unmodelled = paths }
+
+// Unmodelled returns the paths of the elements of the message, as read by
+// Unmarshal, that its components have no field for. They are not decoded, and
+// ConvertPacs002 fails on them with ErrVersionLoss.
+func (d *Pacs00200114Document) Unmodelled() []string { return d.unmodelled }
+
+func (d *Pacs00200114Document) setUnmodelled(paths []string) { d.unmodelled = paths }
+
+// Version implements Pacs002
+func (d *Pacs00200103Document) Version() string { return "pacs.002.001.03" }
+
+// Version implements Pacs002
+func (d *Pacs00200110Document) Version() string { return "pacs.002.001.10" }
+
+// Version implements Pacs002
+func (d *Pacs00200112Document) Version() string { return "pacs.002.001.12" }
+
+// Version implements Pacs002
+func (d *Pacs00200114Document) Version() string { return "pacs.002.001.14" }
+
+// MessageID implements Pacs002
+func (d *Pacs00200103Document) MessageID() string {
+	return d.FIPaymentStatusReport.GroupHeader.MessageID
+}
+
+// MessageID implements Pacs002
+func (d *Pacs00200110Document) MessageID() string {
+	return d.FIPaymentStatusReport.GroupHeader.MessageID
+}
+
+// MessageID implements Pacs002
+func (d *Pacs00200112Document) MessageID() string {
+	return d.FIPaymentStatusReport.GroupHeader.MessageID
+}
+
+// MessageID implements Pacs002
+func (d *Pacs00200114Document) MessageID() string {
+	return d.FIPaymentStatusReport.GroupHeader.MessageID
+}
+
+// CreationDateTime implements Pacs002
+func (d *Pacs00200103Document) CreationDateTime() time.Time {
+	return d.FIPaymentStatusReport.GroupHeader.CreationDateTime.Time
+}
+
+// CreationDateTime implements Pacs002
+func (d *Pacs00200110Document) CreationDateTime() time.Time {
+	return d.FIPaymentStatusReport.GroupHeader.CreationDateTime.Time
+}
+
+// CreationDateTime implements Pacs002
+func (d *Pacs00200112Document) CreationDateTime() time.Time {
+	return d.FIPaymentStatusReport.GroupHeader.CreationDateTime.Time
+}
+
+// CreationDateTime implements Pacs002
+func (d *Pacs00200114Document) CreationDateTime() time.Time {
+	return d.FIPaymentStatusReport.GroupHeader.CreationDateTime.Time
+}
+
+// GroupStatuses implements Pacs002
+func (d *Pacs00200103Document) GroupStatuses() []Pacs002GroupStatus {
+	grp := d.FIPaymentStatusReport.OriginalGroupInformationAndStatus
+	status := Pacs002GroupStatus{
+		OriginalMessageID:     grp.OriginalMessageID,
+		OriginalMessageNameID: grp.OriginalMessageNameID,
+		Status:                deref(grp.GroupStatus),
+	}
+	for _, reason := range grp.StatusReasonInfo {
+		status.Reasons = appendStatusReason(status.Reasons, reason.Reason)
+	}
+	return []Pacs002GroupStatus{status}
+}
+
+// GroupStatuses implements Pacs002
+func (d *Pacs00200110Document) GroupStatuses() []Pacs002GroupStatus {
+	return d.FIPaymentStatusReport.groupStatuses()
+}
+
+// GroupStatuses implements Pacs002
+func (d *Pacs00200112Document) GroupStatuses() []Pacs002GroupStatus {
+	return d.FIPaymentStatusReport.groupStatuses()
+}
+
+// GroupStatuses implements Pacs002
+func (d *Pacs00200114Document) GroupStatuses() []Pacs002GroupStatus {
+	return d.FIPaymentStatusReport.groupStatuses()
+}
+
+// TransactionStatuses implements Pacs002
+func (d *Pacs00200103Document) TransactionStatuses() []Pacs002TransactionStatus {
+	rpt := &d.FIPaymentStatusReport
+	txs := make([]Pacs002TransactionStatus, len(rpt.TransactionInfoAndStatus))
+	for i, tx := range rpt.TransactionInfoAndStatus {
+		txs[i] = Pacs002TransactionStatus{
+			OriginalMessageID:     rpt.OriginalGroupInformationAndStatus.OriginalMessageID,
+			OriginalInstructionID: deref(tx.OriginalInstructionID),
+			OriginalEndToEndID:    deref(tx.OriginalEndToEndID),
+			OriginalTransactionID: deref(tx.OriginalTransactionID),
+			Status:                deref(tx.TransactionStatus),
+		}
+		for _, reason := range tx.StatusReasonInfo {
+			txs[i].Reasons = appendStatusReason(txs[i].Reasons, reason.Reason)
+		}
+	}
+	return txs
+}
+
+// TransactionStatuses implements Pacs002
+func (d *Pacs00200110Document) TransactionStatuses() []Pacs002TransactionStatus {
+	return d.FIPaymentStatusReport.transactionStatuses()
+}
+
+// TransactionStatuses implements Pacs002
+func (d *Pacs00200112Document) TransactionStatuses() []Pacs002TransactionStatus {
+	return d.FIPaymentStatusReport.transactionStatuses()
+}
+
+// TransactionStatuses implements Pacs002
+func (d *Pacs00200114Document) TransactionStatuses() []Pacs002TransactionStatus {
+	return d.FIPaymentStatusReport.transactionStatuses()
+}
+
+func (r *FIToFIPaymentStatusReportV10) groupStatuses() []Pacs002GroupStatus {
+	statuses := make([]Pacs002GroupStatus, len(r.OriginalGroupInformationAndStatus))
+	for i, grp := range r.OriginalGroupInformationAndStatus {
+		statuses[i] = Pacs002GroupStatus{
+			OriginalMessageID:     grp.OriginalMessageID,
+			OriginalMessageNameID: grp.OriginalMessageNameID,
+			Status:                deref(grp.GroupStatus),
+		}
+		for _, reason := range grp.StatusReasonInfo {
+			statuses[i].Reasons = appendStatusReason(statuses[i].Reasons, reason.Reason)
+		}
+	}
+	return statuses
+}
+
+func (r *FIToFIPaymentStatusReportV10) transactionStatuses() []Pacs002TransactionStatus {
+	// The original message of transactions without their own group information
+	origMsgID := ""
+	if len(r.OriginalGroupInformationAndStatus) == 1 {
+		origMsgID = r.OriginalGroupInformationAndStatus[0].OriginalMessageID
+	}
+	txs := make([]Pacs002TransactionStatus, len(r.TransactionInfoAndStatus))
+	for i, tx := range r.TransactionInfoAndStatus {
+		txs[i] = Pacs002TransactionStatus{
+			OriginalMessageID:     origMsgID,
+			OriginalInstructionID: deref(tx.OriginalInstructionID),
+			OriginalEndToEndID:    deref(tx.OriginalEndToEndID),
+			OriginalTransactionID: deref(tx.OriginalTransactionID),
+			OriginalUETR:          deref(tx.OriginalUETR),
+			Status:                deref(tx.TransactionStatus),
+		}
+		if tx.OriginalGroupInfo != nil {
+			txs[i].OriginalMessageID = tx.OriginalGroupInfo.OriginalMessageID
+		}
+		for _, reason := range tx.StatusReasonInfo {
+			txs[i].Reasons = appendStatusReason(txs[i].Reasons, reason.Reason)
+		}
+	}
+	return txs
+}
+
+// appendStatusReason appends the code of a status reason, or else its proprietary
+// reason
+func appendStatusReason(reasons []string, r *StatusReason62) []string {
+	switch {
+	case r == nil:
+		return reasons
+	case r.Code != nil:
+		return append(reasons, *r.Code)
+	case r.Proprietary != nil:
+		return append(reasons, *r.Proprietary)
+	}
+	return reasons
+}
+
+// Validate checks the repeating elements and the choices of pacs.002.001.03
+func (d *Pacs00200103Document) Validate() error {
+	if errs := validateStructure(d); errs.HasErrors() {
+		return errs
+	}
+	return nil
+}
+
+// Validate checks the repeating elements and the choices of pacs.002.001.12
+func (d *Pacs00200112Document) Validate() error {
+	if errs := validateStructure(d); errs.HasErrors() {
+		return errs
+	}
+	return nil
+}
+
+// Validate checks the repeating elements and the choices of pacs.002.001.14
+func (d *Pacs00200114Document) Validate() error {
+	if errs := validateStructure(d); errs.HasErrors() {
+		return errs
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+package iso20022
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func loadPacs002Version(t *testing.T, version string) Pacs002 {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", "roundtrip", version, "rejected_transaction.xml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc, err := DecodePacs002(data)
+	if err != nil {
+		t.Fatalf("DecodePacs002 failed: %v", err)
+	}
+	return doc
+}
+
+func TestPacs002Versions(t *testing.T) {
+	for _, version := range Pacs002Versions {
+		doc := loadPacs002Version(t, version)
+		if doc.Version() != version {
+			t.Errorf("Expected %s, got %s", version, doc.Version())
+		}
+		if doc.MessageID() != "CCCCGB2L-STS-0001" || doc.CreationDateTime().IsZero() {
+			t.Errorf("%s: unexpected header %s %v", version, doc.MessageID(), doc.CreationDateTime())
+		}
+		grps := doc.GroupStatuses()
+		if len(grps) != 1 || grps[0].OriginalMessageID != "BBBBUS33-20240315-0001" || grps[0].Status != "" {
+			t.Errorf("%s: unexpected group statuses %+v", version, grps)
+		}
+		txs := doc.TransactionStatuses()
+		if len(txs) != 1 {
+			t.Fatalf("%s: expected 1 transaction status, got %d", version, len(txs))
+		}
+		tx := txs[0]
+		if tx.OriginalMessageID != "BBBBUS33-20240315-0001" || tx.OriginalEndToEndID != "INV-2024-0042" ||
+			tx.OriginalTransactionID != "BBBBUS33-TX-0001" || tx.Status != "RJCT" || len(tx.Reasons) != 1 || tx.Reasons[0] != "AC04" {
+			t.Errorf("%s: unexpected transaction status %+v", version, tx)
+		}
+		if hasUETR := tx.OriginalUETR != ""; hasUETR != (version >= "pacs.002.001.10") {
+			t.Errorf("%s: unexpected UETR %q", version, tx.OriginalUETR)
+		}
+	}
+
+	if _, err := DecodePacs002([]byte(`<Document xmlns="urn:iso:std:iso:20022:tech:xsd:pacs.008.001.08"/>`)); !errors.Is(err, ErrUnknownMessage) {
+		t.Errorf("Expected ErrUnknownMessage for a pacs.008, got %v", err)
+	}
+}
+
+func TestConvertPacs002(t *testing.T) {
+	v03 := loadPacs002Version(t, "pacs.002.001.03")
+	upgraded, err := ConvertPacs002(v03, "pacs.002.001.14")
+	if err != nil {
+		t.Fatalf("Upgrade failed: %v", err)
+	}
+	rpt := upgraded.(*Pacs00200114Document).FIPaymentStatusReport
+	if len(rpt.OriginalGroupInformationAndStatus) != 1 || rpt.OriginalGroupInformationAndStatus[0].OriginalMessageNameID != "pacs.008.001.02" ||
+		deref(rpt.GroupHeader.InstructingAgent.FinancialInstitutionID.BankIdentifierCode) != "CCCCGB2L" {
+		t.Errorf("Unexpected upgraded report %+v", rpt)
+	}
+	if !reflect.DeepEqual(upgraded.TransactionStatuses(), v03.TransactionStatuses()) {
+		t.Errorf("Upgrade changed the statuses:\n%+v\n%+v", upgraded.TransactionStatuses(), v03.TransactionStatuses())
+	}
+
+	// Downgrading the upgrade gives the original message back
+	downgraded, err := ConvertPacs002(upgraded, "pacs.002.001.03")
+	if err != nil {
+		t.Fatalf("Downgrade failed: %v", err)
+	}
+	original, _ := Marshal(v03)
+	again, _ := Marshal(downgraded)
+	if !bytes.Equal(original, again) {
+		t.Errorf("Round trip through pacs.002.001.14 changed the message:\n%s\n%s", original, again)
+	}
+}
+
+func TestConvertPacs002Loss(t *testing.T) {
+	doc := loadPacs002Version(t, "pacs.002.001.10").(*Pacs00200110Document)
+	rpt := &doc.FIPaymentStatusReport
+	_, err := ConvertPacs002(doc, "pacs.002.001.03")
+	if !errors.Is(err, ErrVersionLoss) || !strings.Contains(err.Error(), "TxInfAndSts[1]/OrgnlUETR") {
+		t.Fatalf("Expected the UETR to be reported, got %v", err)
+	}
+
+	rpt.TransactionInfoAndStatus[0].OriginalUETR = nil
+	rpt.TransactionInfoAndStatus[0].EffectiveInterbankSettlementDate = nil
+	rpt.TransactionInfoAndStatus[0].OriginalTransactionReference = nil
+	rpt.OriginalGroupInformationAndStatus = append(rpt.OriginalGroupInformationAndStatus, OriginalGroupHeader17{OriginalMessageID: "BBBBUS33-20240315-0002"})
+	_, err = ConvertPacs002(doc, "pacs.002.001.03")
+	if !errors.Is(err, ErrVersionLoss) || !strings.Contains(err.Error(), "OrgnlGrpInfAndSts[2]") {
+		t.Fatalf("Expected the second original message to be reported, got %v", err)
+	}
+	rpt.OriginalGroupInformationAndStatus = rpt.OriginalGroupInformationAndStatus[:1]
+	if _, err := ConvertPacs002(doc, "pacs.002.001.03"); err != nil {
+		t.Errorf("Expected the downgrade to succeed, got %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join("testdata", "roundtrip", "pacs.002.001.14", "rejected_transaction.xml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	data = bytes.Replace(data, []byte("<Nm>Creditor Bank</Nm>"), []byte("<Nm>Creditor Bank</Nm><PstlAdr><CareOf>Operations</CareOf></PstlAdr>"), 1)
+	var v14 Pacs00200114Document
+	if err := Unmarshal(data, &v14); err != nil {
+		t.Fatal(err)
+	}
+	if got := v14.Unmodelled(); len(got) != 1 || !strings.HasSuffix(got[0], "Orgtr/PstlAdr/CareOf") {
+		t.Fatalf("Expected CareOf to be recorded as not decoded, got %v", got)
+	}
+	if _, err := ConvertPacs002(&v14, "pacs.002.001.12"); !errors.Is(err, ErrVersionLoss) || !strings.Contains(err.Error(), "Orgtr/PstlAdr/CareOf") {
+		t.Errorf("Expected CareOf to be reported, got %v", err)
+	}
+
+	if _, err := ConvertPacs002(doc, "pacs.008.001.08"); !errors.Is(err, ErrUnknownMessage) {
+		t.Errorf("Expected ErrUnknownMessage for another message, got %v", err)
+	}
+}
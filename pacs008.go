@@ -0,0 +1,797 @@
+package iso20022
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"time"
+)
+
+// Pacs008Versions are the versions of pacs.008 this package reads and writes, in
+// order
+var Pacs008Versions = []string{"pacs.008.001.02", "pacs.008.001.06", "pacs.008.001.08", "pacs.008.001.10", "pacs.008.001.12"}
+
+// Pacs008 is a pacs.008 FI to FI customer credit transfer of any of the
+// Pacs008Versions. It gives access to the core of the message, which means the
+// same in every version, so that code handling credit transfers does not depend on
+// the version a counterparty sends.
+type Pacs008 interface {
+	Validator
+	// Version returns the message name identification, such as pacs.008.001.08
+	Version() string
+	MessageID() string
+	CreationDateTime() time.Time
+	SettlementMethod() SettlementMethod1Code
+	Transactions() []Pacs008Transaction
+}
+
+// Pacs008Transaction holds the core fields of a credit transfer transaction
+type Pacs008Transaction struct {
+	InstructionID             string
+	EndToEndID                string
+	TransactionID             string
+	UETR                      string // empty in the versions before pacs.008.001.07
+	InterbankSettlementAmount ActiveCurrencyAndAmount
+	InterbankSettlementDate   *ISODate
+	ChargeBearer              ChargeBearerType1Code
+	DebtorName                string
+	DebtorAccount             string // IBAN, or else the other identification
+	DebtorAgent               string // BIC, or else the clearing system member identification
+	CreditorName              string
+	CreditorAccount           string
+	CreditorAgent             string
+	Remittance                []string // unstructured remittance information
+}
+
+// DecodePacs008 decodes a pacs.008 of any of the Pacs008Versions
+func DecodePacs008(data []byte) (Pacs008, error) {
+	msgType, doc, err := DecodeDocument(data)
+	if err != nil {
+		return nil, err
+	}
+	p, ok := doc.(Pacs008)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s is not a pacs.008", ErrUnknownMessage, msgType)
+	}
+	return p, nil
+}
+
+// ConvertPacs008 returns a pacs.008 in another of the Pacs008Versions. It fails
+// with ErrVersionLoss, naming the element, when the message has an element the
+// target version does not, such as a UETR below pacs.008.001.07, the LEI of an
+// agent below pacs.008.001.08 or the CareOf of an address below
+// pacs.008.001.12. It also fails so when Unmarshal could not decode an element
+// of the message, which would otherwise be lost. Clear such elements first to
+// convert anyway.
+func ConvertPacs008(doc Pacs008, version string) (Pacs008, error) {
+	newDocument, ok := documentsByType[version]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownMessage, version)
+	}
+	target, ok := newDocument().(Pacs008)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s is not a pacs.008", ErrUnknownMessage, version)
+	}
+	if err := unmodelledLoss(doc); err != nil {
+		return nil, fmt.Errorf("converting %s to %s: %w", doc.Version(), version, err)
+	}
+	if err := convertVersion(target, doc); err != nil {
+		return nil, fmt.Errorf("converting %s to %s: %w", doc.Version(), version, err)
+	}
+	return target, nil
+}
+
+// Pacs00800102Document is a pacs.008.001.02 message, the version of the 2009
+// message definitions still sent by many clearing systems. It identifies financial
+// institutions by BIC and organisations by BICOrBEI, and has neither UETR nor LEI.
+type Pacs00800102Document struct {
+	XMLName                  xml.Name                        `xml:"urn:iso:std:iso:20022:tech:xsd:pacs.008.001.02 Document"`
+	FICustomerCreditTransfer FIToFICustomerCreditTransferV02 `xml:"FIToFICstmrCdtTrf"`
+}
+
+// FIToFICustomerCreditTransferV02 is the message of pacs.008.001.02
+type FIToFICustomerCreditTransferV02 struct {
+	GroupHeader                   GroupHeader33                            `xml:"GrpHdr"`
+	CreditTransferTransactionInfo []CreditTransferTransactionInformation11 `xml:"CdtTrfTxInf"`
+}
+
+// GroupHeader33 is the group header of pacs.008.001.02
+type GroupHeader33 struct {
+	MessageID                      string                                        `xml:"MsgId"`
+	CreationDateTime               ISODateTime                                   `xml:"CreDtTm"`
+	BatchBooking                   *bool                                         `xml:"BtchBookg,omitempty"`
+	NumberOfTransactions           string                                        `xml:"NbOfTxs"`
+	ControlSum                     *Decimal                                      `xml:"CtrlSum,omitempty"`
+	TotalInterbankSettlementAmount *ActiveCurrencyAndAmount                      `xml:"TtlIntrBkSttlmAmt,omitempty"`
+	InterbankSettlementDate        *ISODate                                      `xml:"IntrBkSttlmDt,omitempty"`
+	SettlementInfo                 SettlementInformation13                       `xml:"SttlmInf"`
+	PaymentTypeInfo                *PaymentTypeInfo28                            `xml:"PmtTpInf,omitempty"`
+	InstructingAgent               *BranchAndFinancialInstitutionIdentification4 `xml:"InstgAgt,omitempty"`
+	InstructedAgent                *BranchAndFinancialInstitutionIdentification4 `xml:"InstdAgt,omitempty"`
+}
+
+// SettlementInformation13 is the settlement information of pacs.008.001.02
+type SettlementInformation13 struct {
+	SettlementMethod                     SettlementMethod1Code                         `xml:"SttlmMtd"`
+	SettlementAccount                    *CashAccount                                  `xml:"SttlmAcct,omitempty"`
+	ClearingSystem                       *ClearingSystemIdentificationSecondary        `xml:"ClrSys,omitempty"`
+	InstructingReimbursementAgent        *BranchAndFinancialInstitutionIdentification4 `xml:"InstgRmbrsmntAgt,omitempty"`
+	InstructingReimbursementAgentAccount *CashAccount                                  `xml:"InstgRmbrsmntAgtAcct,omitempty"`
+	InstructedReimbursementAgent         *BranchAndFinancialInstitutionIdentification4 `xml:"InstdRmbrsmntAgt,omitempty"`
+	InstructedReimbursementAgentAccount  *CashAccount                                  `xml:"InstdRmbrsmntAgtAcct,omitempty"`
+	ThirdReimbursementAgent              *BranchAndFinancialInstitutionIdentification4 `xml:"ThrdRmbrsmntAgt,omitempty"`
+	ThirdReimbursementAgentAccount       *CashAccount                                  `xml:"ThrdRmbrsmntAgtAcct,omitempty"`
+}
+
+// CreditTransferTransactionInformation11 is a transaction of pacs.008.001.02. It has
+// a single previous instructing agent, which later versions number
+// PrvsInstgAgt1.
+type CreditTransferTransactionInformation11 struct {
+	PaymentID                        PaymentIdentification3                        `xml:"PmtId"`
+	PaymentTypeInfo                  *PaymentTypeInfo28                            `xml:"PmtTpInf,omitempty"`
+	InterbankSettlementAmount        ActiveCurrencyAndAmount                       `xml:"IntrBkSttlmAmt"`
+	InterbankSettlementDate          *ISODate                                      `xml:"IntrBkSttlmDt,omitempty"`
+	SettlementPriority               *string                                       `xml:"SttlmPrty,omitempty"`
+	SettlementTimeIndication         *SettlementDateTimeIndication                 `xml:"SttlmTmIndctn,omitempty"`
+	SettlementTimeRequest            *SettlementTimeRequest                        `xml:"SttlmTmReq,omitempty"`
+	AcceptanceDateTime               *ISODateTime                                  `xml:"AccptncDtTm,omitempty"`
+	PoolingAdjustmentDate            *ISODate                                      `xml:"PoolgAdjstmntDt,omitempty"`
+	InstructedAmount                 *ActiveOrHistoricCurrencyAndAmount            `xml:"InstdAmt,omitempty"`
+	ExchangeRate                     *Decimal                                      `xml:"XchgRate,omitempty"`
+	ChargeBearer                     ChargeBearerType1Code                         `xml:"ChrgBr"`
+	ChargesInfo                      []Charges1                                    `xml:"ChrgsInf,omitempty"`
+	PreviousInstructingAgent1        *BranchAndFinancialInstitutionIdentification4 `xml:"PrvsInstgAgt,omitempty"`
+	PreviousInstructingAgent1Account *CashAccount38                                `xml:"PrvsInstgAgtAcct,omitempty"`
+	InstructingAgent                 *BranchAndFinancialInstitutionIdentification4 `xml:"InstgAgt,omitempty"`
+	InstructedAgent                  *BranchAndFinancialInstitutionIdentification4 `xml:"InstdAgt,omitempty"`
+	IntermediaryAgent1               *BranchAndFinancialInstitutionIdentification4 `xml:"IntrmyAgt1,omitempty"`
+	IntermediaryAgent1Account        *CashAccount38                                `xml:"IntrmyAgt1Acct,omitempty"`
+	IntermediaryAgent2               *BranchAndFinancialInstitutionIdentification4 `xml:"IntrmyAgt2,omitempty"`
+	IntermediaryAgent2Account        *CashAccount38                                `xml:"IntrmyAgt2Acct,omitempty"`
+	IntermediaryAgent3               *BranchAndFinancialInstitutionIdentification4 `xml:"IntrmyAgt3,omitempty"`
+	IntermediaryAgent3Account        *CashAccount38                                `xml:"IntrmyAgt3Acct,omitempty"`
+	UltimateDebtor                   *PartyIdentification32                        `xml:"UltmtDbtr,omitempty"`
+	InitiatingParty                  *PartyIdentification32                        `xml:"InitgPty,omitempty"`
+	Debtor                           PartyIdentification32                         `xml:"Dbtr"`
+	DebtorAccount                    *CashAccount38                                `xml:"DbtrAcct,omitempty"`
+	DebtorAgent                      BranchAndFinancialInstitutionIdentification4  `xml:"DbtrAgt"`
+	DebtorAgentAccount               *CashAccount38                                `xml:"DbtrAgtAcct,omitempty"`
+	CreditorAgent                    BranchAndFinancialInstitutionIdentification4  `xml:"CdtrAgt"`
+	CreditorAgentAccount             *CashAccount38                                `xml:"CdtrAgtAcct,omitempty"`
+	Creditor                         PartyIdentification32                         `xml:"Cdtr"`
+	CreditorAccount                  *CashAccount38                                `xml:"CdtrAcct,omitempty"`
+	UltimateCreditor                 *PartyIdentification32                        `xml:"UltmtCdtr,omitempty"`
+	InstructionsForCreditorAgent     []InstructionForCreditorAgent                 `xml:"InstrForCdtrAgt,omitempty"`
+	InstructionsForNextAgent         []InstructionForNextAgent                     `xml:"InstrForNxtAgt,omitempty"`
+	Purpose                          *Purpose                                      `xml:"Purp,omitempty"`
+	RegulatoryReporting              []RegulatoryReporting3                        `xml:"RgltryRptg,omitempty"`
+	Tax                              *TaxInfo                                      `xml:"Tax,omitempty"`
+	RelatedRemittanceInfo            []RemittanceLocation                          `xml:"RltdRmtInf,omitempty"`
+	RemittanceInfo                   *RemittanceInfo                               `xml:"RmtInf,omitempty"`
+}
+
+// PaymentIdentification3 is the payment identification of pacs.008.001.02 and
+// pacs.008.001.06, which have no UETR and require the TxId
+type PaymentIdentification3 struct {
+	InstructionID           *string `xml:"InstrId,omitempty"`
+	EndToEndID              string  `xml:"EndToEndId"`
+	TransactionID           string  `xml:"TxId"`
+	ClearingSystemReference *string `xml:"ClrSysRef,omitempty"`
+}
+
+// BranchAndFinancialInstitutionIdentification4 identifies an agent in
+// pacs.008.001.02
+type BranchAndFinancialInstitutionIdentification4 struct {
+	FinancialInstitutionID FinancialInstitutionIdentification7 `xml:"FinInstnId"`
+	BranchID               *BranchData3                        `xml:"BrnchId,omitempty"`
+}
+
+// FinancialInstitutionIdentification7 identifies a financial institution by BIC
+// rather than BICFI, and has no LEI
+type FinancialInstitutionIdentification7 struct {
+	BankIdentifierCode     *string                             `xml:"BIC,omitempty"`
+	ClearingSystemMemberID *ClearingSystemMemberIdentification `xml:"ClrSysMmbId,omitempty"`
+	Name                   *string                             `xml:"Nm,omitempty"`
+	PostalAddress          *PostalAddress24                    `xml:"PstlAdr,omitempty"`
+	Other                  *GenericFinancialIdentification     `xml:"Othr,omitempty"`
+}
+
+// PartyIdentification32 identifies a party in pacs.008.001.02
+type PartyIdentification32 struct {
+	Name               *string          `xml:"Nm,omitempty"`
+	PostalAddress      *PostalAddress24 `xml:"PstlAdr,omitempty"`
+	ID                 *Party6          `xml:"Id,omitempty"`
+	CountryOfResidence *string          `xml:"CtryOfRes,omitempty"`
+	ContactDetails     *Contact4        `xml:"CtctDtls,omitempty"`
+}
+
+// Party6 is the choice between the identification of an organisation and of a
+// person
+type Party6 struct {
+	OrganizationID *OrganizationIdentification4 `xml:"OrgId,omitempty"`
+	PrivateID      *PersonIdentification13      `xml:"PrvtId,omitempty"`
+}
+
+// OrganizationIdentification4 identifies an organisation by BIC or BEI, and has no
+// LEI
+type OrganizationIdentification4 struct {
+	AnyBankIdentifierCode *string                              `xml:"BICOrBEI,omitempty"`
+	Other                 []GenericOrganizationIdentification1 `xml:"Othr,omitempty"`
+}
+
+// Charges1 is the charges information of pacs.008.001.02, whose agent is tagged Pty
+type Charges1 struct {
+	Amount ActiveOrHistoricCurrencyAndAmount            `xml:"Amt"`
+	Agent  BranchAndFinancialInstitutionIdentification4 `xml:"Pty"`
+}
+
+// Pacs00800106Document is a pacs.008.001.06 message. It has the components of
+// pacs.008.001.08 but for the payment identification, which has no UETR, and a
+// single previous instructing agent.
+type Pacs00800106Document struct {
+	XMLName                  xml.Name                        `xml:"urn:iso:std:iso:20022:tech:xsd:pacs.008.001.06 Document"`
+	FICustomerCreditTransfer FIToFICustomerCreditTransferV06 `xml:"FIToFICstmrCdtTrf"`
+}
+
+// FIToFICustomerCreditTransferV06 is the message of pacs.008.001.06
+type FIToFICustomerCreditTransferV06 struct {
+	GroupHeader                   GroupHeader93                 `xml:"GrpHdr"`
+	CreditTransferTransactionInfo []CreditTransferTransaction25 `xml:"CdtTrfTxInf"`
+	SupplementaryData             []SupplementaryData1          `xml:"SplmtryData,omitempty"`
+}
+
+// CreditTransferTransaction25 is a transaction of pacs.008.001.06
+type CreditTransferTransaction25 struct {
+	PaymentID                        PaymentIdentification3                        `xml:"PmtId"`
+	PaymentTypeInfo                  *PaymentTypeInfo28                            `xml:"PmtTpInf,omitempty"`
+	InterbankSettlementAmount        ActiveCurrencyAndAmount                       `xml:"IntrBkSttlmAmt"`
+	InterbankSettlementDate          *ISODate                                      `xml:"IntrBkSttlmDt,omitempty"`
+	SettlementPriority               *string                                       `xml:"SttlmPrty,omitempty"`
+	SettlementTimeIndication         *SettlementDateTimeIndication                 `xml:"SttlmTmIndctn,omitempty"`
+	SettlementTimeRequest            *SettlementTimeRequest                        `xml:"SttlmTmReq,omitempty"`
+	AcceptanceDateTime               *ISODateTime                                  `xml:"AccptncDtTm,omitempty"`
+	PoolingAdjustmentDate            *ISODate                                      `xml:"PoolgAdjstmntDt,omitempty"`
+	InstructedAmount                 *ActiveOrHistoricCurrencyAndAmount            `xml:"InstdAmt,omitempty"`
+	ExchangeRate                     *Decimal                                      `xml:"XchgRate,omitempty"`
+	ChargeBearer                     ChargeBearerType1Code                         `xml:"ChrgBr"`
+	ChargesInfo                      []Charges7                                    `xml:"ChrgsInf,omitempty"`
+	PreviousInstructingAgent1        *BranchAndFinancialInstitutionIdentification6 `xml:"PrvsInstgAgt,omitempty"`
+	PreviousInstructingAgent1Account *CashAccount38                                `xml:"PrvsInstgAgtAcct,omitempty"`
+	InstructingAgent                 *BranchAndFinancialInstitutionIdentification6 `xml:"InstgAgt,omitempty"`
+	InstructedAgent                  *BranchAndFinancialInstitutionIdentification6 `xml:"InstdAgt,omitempty"`
+	IntermediaryAgent1               *BranchAndFinancialInstitutionIdentification6 `xml:"IntrmyAgt1,omitempty"`
+	IntermediaryAgent1Account        *CashAccount38                                `xml:"IntrmyAgt1Acct,omitempty"`
+	IntermediaryAgent2               *BranchAndFinancialInstitutionIdentification6 `xml:"IntrmyAgt2,omitempty"`
+	IntermediaryAgent2Account        *CashAccount38                                `xml:"IntrmyAgt2Acct,omitempty"`
+	IntermediaryAgent3               *BranchAndFinancialInstitutionIdentification6 `xml:"IntrmyAgt3,omitempty"`
+	IntermediaryAgent3Account        *CashAccount38                                `xml:"IntrmyAgt3Acct,omitempty"`
+	UltimateDebtor                   *PartyIdentification135                       `xml:"UltmtDbtr,omitempty"`
+	InitiatingParty                  *PartyIdentification135                       `xml:"InitgPty,omitempty"`
+	Debtor                           PartyIdentification135                        `xml:"Dbtr"`
+	DebtorAccount                    *CashAccount38                                `xml:"DbtrAcct,omitempty"`
+	DebtorAgent                      BranchAndFinancialInstitutionIdentification6  `xml:"DbtrAgt"`
+	DebtorAgentAccount               *CashAccount38                                `xml:"DbtrAgtAcct,omitempty"`
+	CreditorAgent                    BranchAndFinancialInstitutionIdentification6  `xml:"CdtrAgt"`
+	CreditorAgentAccount             *CashAccount38                                `xml:"CdtrAgtAcct,omitempty"`
+	Creditor                         PartyIdentification135                        `xml:"Cdtr"`
+	CreditorAccount                  *CashAccount38                                `xml:"CdtrAcct,omitempty"`
+	UltimateCreditor                 *PartyIdentification135                       `xml:"UltmtCdtr,omitempty"`
+	InstructionsForCreditorAgent     []InstructionForCreditorAgent                 `xml:"InstrForCdtrAgt,omitempty"`
+	InstructionsForNextAgent         []InstructionForNextAgent                     `xml:"InstrForNxtAgt,omitempty"`
+	Purpose                          *Purpose                                      `xml:"Purp,omitempty"`
+	RegulatoryReporting              []RegulatoryReporting3                        `xml:"RgltryRptg,omitempty"`
+	Tax                              *TaxInfo                                      `xml:"Tax,omitempty"`
+	RelatedRemittanceInfo            []RemittanceLocation                          `xml:"RltdRmtInf,omitempty"`
+	RemittanceInfo                   *RemittanceInfo                               `xml:"RmtInf,omitempty"`
+	SupplementaryData                []SupplementaryData                           `xml:"SplmtryData,omitempty"`
+}
+
+// Pacs00800110Document is a pacs.008.001.10 message. Its core is that of
+// pacs.008.001.08, whose components it uses. Unmarshal records the elements of
+// the later components these have no field for, which Unmodelled returns.
+type Pacs00800110Document struct {
+	XMLName                  xml.Name                        `xml:"urn:iso:std:iso:20022:tech:xsd:pacs.008.001.10 Document"`
+	FICustomerCreditTransfer FIToFICustomerCreditTransferV08 `xml:"FIToFICstmrCdtTrf"`
+	unmodelled               []string
+}
+
+// Pacs00800112Document is a pacs.008.001.12 message. Its agents, parties and postal
+// addresses are those of the 2023 components, which add CareOf and UnitNb to
+// postal addresses. Unmarshal records the elements of other components it has no
+// field for, which Unmodelled returns.
+type Pacs00800112Document struct {
+	XMLName                  xml.Name                        `xml:"urn:iso:std:iso:20022:tech:xsd:pacs.008.001.12 Document"`
+	FICustomerCreditTransfer FIToFICustomerCreditTransferV12 `xml:"FIToFICstmrCdtTrf"`
+	unmodelled               []string
+}
+
+// FIToFICustomerCreditTransferV12 is the message of pacs.008.001.12
+type FIToFICustomerCreditTransferV12 struct {
+	GroupHeader                   GroupHeader113                `xml:"GrpHdr"`
+	CreditTransferTransactionInfo []CreditTransferTransaction64 `xml:"CdtTrfTxInf"`
+	SupplementaryData             []SupplementaryData1          `xml:"SplmtryData,omitempty"`
+}
+
+// GroupHeader113 is the group header of pacs.008.001.12
+type GroupHeader113 struct {
+	MessageID                      string                                        `xml:"MsgId"`
+	CreationDateTime               *ISODateTime                                  `xml:"CreDtTm,omitempty"`
+	BatchBooking                   *bool                                         `xml:"BtchBookg,omitempty"`
+	NumberOfTransactions           string                                        `xml:"NbOfTxs"`
+	ControlSum                     *Decimal                                      `xml:"CtrlSum,omitempty"`
+	TotalInterbankSettlementAmount *ActiveCurrencyAndAmount                      `xml:"TtlIntrBkSttlmAmt,omitempty"`
+	InterbankSettlementDate        *ISODate                                      `xml:"IntrBkSttlmDt,omitempty"`
+	SettlementInfo                 SettlementInstruction15                       `xml:"SttlmInf"`
+	PaymentTypeInfo                *PaymentTypeInfo28                            `xml:"PmtTpInf,omitempty"`
+	InstructingAgent               *BranchAndFinancialInstitutionIdentification8 `xml:"InstgAgt,omitempty"`
+	InstructedAgent                *BranchAndFinancialInstitutionIdentification8 `xml:"InstdAgt,omitempty"`
+}
+
+// SettlementInstruction15 is the settlement information of pacs.008.001.12
+type SettlementInstruction15 struct {
+	SettlementMethod                     SettlementMethod1Code                         `xml:"SttlmMtd"`
+	SettlementAccount                    *CashAccount40                                `xml:"SttlmAcct,omitempty"`
+	ClearingSystem                       *ClearingSystemIdentificationSecondary        `xml:"ClrSys,omitempty"`
+	InstructingReimbursementAgent        *BranchAndFinancialInstitutionIdentification8 `xml:"InstgRmbrsmntAgt,omitempty"`
+	InstructingReimbursementAgentAccount *CashAccount40                                `xml:"InstgRmbrsmntAgtAcct,omitempty"`
+	InstructedReimbursementAgent         *BranchAndFinancialInstitutionIdentification8 `xml:"InstdRmbrsmntAgt,omitempty"`
+	InstructedReimbursementAgentAccount  *CashAccount40                                `xml:"InstdRmbrsmntAgtAcct,omitempty"`
+	ThirdReimbursementAgent              *BranchAndFinancialInstitutionIdentification8 `xml:"ThrdRmbrsmntAgt,omitempty"`
+	ThirdReimbursementAgentAccount       *CashAccount40                                `xml:"ThrdRmbrsmntAgtAcct,omitempty"`
+}
+
+// CreditTransferTransaction64 is a transaction of pacs.008.001.12
+type CreditTransferTransaction64 struct {
+	PaymentID                        PaymentIdentification7                        `xml:"PmtId"`
+	PaymentTypeInfo                  *PaymentTypeInfo28                            `xml:"PmtTpInf,omitempty"`
+	InterbankSettlementAmount        ActiveCurrencyAndAmount                       `xml:"IntrBkSttlmAmt"`
+	InterbankSettlementDate          *ISODate                                      `xml:"IntrBkSttlmDt,omitempty"`
+	SettlementPriority               *string                                       `xml:"SttlmPrty,omitempty"`
+	SettlementTimeIndication         *SettlementDateTimeIndication                 `xml:"SttlmTmIndctn,omitempty"`
+	SettlementTimeRequest            *SettlementTimeRequest                        `xml:"SttlmTmReq,omitempty"`
+	AcceptanceDateTime               *ISODateTime                                  `xml:"AccptncDtTm,omitempty"`
+	PoolingAdjustmentDate            *ISODate                                      `xml:"PoolgAdjstmntDt,omitempty"`
+	InstructedAmount                 *ActiveOrHistoricCurrencyAndAmount            `xml:"InstdAmt,omitempty"`
+	ExchangeRate                     *Decimal                                      `xml:"XchgRate,omitempty"`
+	ChargeBearer                     ChargeBearerType1Code                         `xml:"ChrgBr"`
+	ChargesInfo                      []Charges16                                   `xml:"ChrgsInf,omitempty"`
+	PreviousInstructingAgent1        *BranchAndFinancialInstitutionIdentification8 `xml:"PrvsInstgAgt1,omitempty"`
+	PreviousInstructingAgent1Account *CashAccount40                                `xml:"PrvsInstgAgt1Acct,omitempty"`
+	PreviousInstructingAgent2        *BranchAndFinancialInstitutionIdentification8 `xml:"PrvsInstgAgt2,omitempty"`
+	PreviousInstructingAgent2Account *CashAccount40                                `xml:"PrvsInstgAgt2Acct,omitempty"`
+	PreviousInstructingAgent3        *BranchAndFinancialInstitutionIdentification8 `xml:"PrvsInstgAgt3,omitempty"`
+	PreviousInstructingAgent3Account *CashAccount40                                `xml:"PrvsInstgAgt3Acct,omitempty"`
+	InstructingAgent                 *BranchAndFinancialInstitutionIdentification8 `xml:"InstgAgt,omitempty"`
+	InstructedAgent                  *BranchAndFinancialInstitutionIdentification8 `xml:"InstdAgt,omitempty"`
+	IntermediaryAgent1               *BranchAndFinancialInstitutionIdentification8 `xml:"IntrmyAgt1,omitempty"`
+	IntermediaryAgent1Account        *CashAccount40                                `xml:"IntrmyAgt1Acct,omitempty"`
+	IntermediaryAgent2               *BranchAndFinancialInstitutionIdentification8 `xml:"IntrmyAgt2,omitempty"`
+	IntermediaryAgent2Account        *CashAccount40                                `xml:"IntrmyAgt2Acct,omitempty"`
+	IntermediaryAgent3               *BranchAndFinancialInstitutionIdentification8 `xml:"IntrmyAgt3,omitempty"`
+	IntermediaryAgent3Account        *CashAccount40                                `xml:"IntrmyAgt3Acct,omitempty"`
+	UltimateDebtor                   *PartyIdentification272                       `xml:"UltmtDbtr,omitempty"`
+	InitiatingParty                  *PartyIdentification272                       `xml:"InitgPty,omitempty"`
+	Debtor                           PartyIdentification272                        `xml:"Dbtr"`
+	DebtorAccount                    *CashAccount40                                `xml:"DbtrAcct,omitempty"`
+	DebtorAgent                      BranchAndFinancialInstitutionIdentification8  `xml:"DbtrAgt"`
+	DebtorAgentAccount               *CashAccount40                                `xml:"DbtrAgtAcct,omitempty"`
+	CreditorAgent                    BranchAndFinancialInstitutionIdentification8  `xml:"CdtrAgt"`
+	CreditorAgentAccount             *CashAccount40                                `xml:"CdtrAgtAcct,omitempty"`
+	Creditor                         PartyIdentification272                        `xml:"Cdtr"`
+	CreditorAccount                  *CashAccount40                                `xml:"CdtrAcct,omitempty"`
+	UltimateCreditor                 *PartyIdentification272                       `xml:"UltmtCdtr,omitempty"`
+	InstructionsForCreditorAgent     []InstructionForCreditorAgent                 `xml:"InstrForCdtrAgt,omitempty"`
+	InstructionsForNextAgent         []InstructionForNextAgent                     `xml:"InstrForNxtAgt,omitempty"`
+	Purpose                          *Purpose                                      `xml:"Purp,omitempty"`
+	RegulatoryReporting              []RegulatoryReporting3                        `xml:"RgltryRptg,omitempty"`
+	Tax                              *TaxInfo                                      `xml:"Tax,omitempty"`
+	RelatedRemittanceInfo            []RemittanceLocation                          `xml:"RltdRmtInf,omitempty"`
+	RemittanceInfo                   *RemittanceInfo                               `xml:"RmtInf,omitempty"`
+	SupplementaryData                []SupplementaryData                           `xml:"SplmtryData,omitempty"`
+}
+
+// BranchAndFinancialInstitutionIdentification8 identifies an agent in
+// pacs.008.001.12
+type BranchAndFinancialInstitutionIdentification8 struct {
+	FinancialInstitutionID FinancialInstitutionIdentification23 `xml:"FinInstnId"`
+	BranchID               *BranchData5                         `xml:"BrnchId,omitempty"`
+}
+
+// FinancialInstitutionIdentification23 identifies a financial institution, with a
+// PostalAddress27
+type FinancialInstitutionIdentification23 struct {
+	BankIdentifierCode     *string                             `xml:"BICFI,omitempty"`
+	ClearingSystemMemberID *ClearingSystemMemberIdentification `xml:"ClrSysMmbId,omitempty"`
+	LegalEntityIdentifier  *string                             `xml:"LEI,omitempty"`
+	Name                   *string                             `xml:"Nm,omitempty"`
+	PostalAddress          *PostalAddress27                    `xml:"PstlAdr,omitempty"`
+	Other                  *GenericFinancialIdentification     `xml:"Othr,omitempty"`
+}
+
+// BranchData5 identifies the branch of a financial institution, with a
+// PostalAddress27
+type BranchData5 struct {
+	ID                    *string          `xml:"Id,omitempty"`
+	LegalEntityIdentifier *string          `xml:"LEI,omitempty"`
+	Name                  *string          `xml:"Nm,omitempty"`
+	PostalAddress         *PostalAddress27 `xml:"PstlAdr,omitempty"`
+}
+
+// PartyIdentification272 identifies a party in pacs.008.001.12
+type PartyIdentification272 struct {
+	Name               *string          `xml:"Nm,omitempty"`
+	PostalAddress      *PostalAddress27 `xml:"PstlAdr,omitempty"`
+	ID                 *Party38         `xml:"Id,omitempty"`
+	CountryOfResidence *string          `xml:"CtryOfRes,omitempty"`
+	ContactDetails     *Contact13       `xml:"CtctDtls,omitempty"`
+}
+
+// PostalAddress27 is the postal address of the 2023 components. It has the
+// elements of PostalAddress24, and CareOf and UnitNb.
+type PostalAddress27 struct {
+	AddressType        *string  `xml:"AdrTp,omitempty"`
+	CareOf             *string  `xml:"CareOf,omitempty"`
+	Department         *string  `xml:"Dept,omitempty"`
+	SubDepartment      *string  `xml:"SubDept,omitempty"`
+	StreetName         *string  `xml:"StrtNm,omitempty"`
+	BuildingNumber     *string  `xml:"BldgNb,omitempty"`
+	BuildingName       *string  `xml:"BldgNm,omitempty"`
+	Floor              *string  `xml:"Flr,omitempty"`
+	UnitNumber         *string  `xml:"UnitNb,omitempty"`
+	PostBox            *string  `xml:"PstBx,omitempty"`
+	Room               *string  `xml:"Room,omitempty"`
+	PostCode           *string  `xml:"PstCd,omitempty"`
+	TownName           *string  `xml:"TwnNm,omitempty"`
+	TownLocationName   *string  `xml:"TwnLctnNm,omitempty"`
+	DistrictName       *string  `xml:"DstrctNm,omitempty"`
+	CountrySubDivision *string  `xml:"CtrySubDvsn,omitempty"`
+	Country            *string  `xml:"Ctry,omitempty"`
+	AddressLine        []string `xml:"AdrLine,omitempty"`
+}
+
+// Contact13 is the contact details of a party in pacs.008.001.12. It has the
+// elements of Contact4, and URLAdr.
+type Contact13 struct {
+	NamePrefix      *string         `xml:"NmPrfx,omitempty"`
+	Name            *string         `xml:"Nm,omitempty"`
+	PhoneNumber     *string         `xml:"PhneNb,omitempty"`
+	MobileNumber    *string         `xml:"MobNb,omitempty"`
+	FaxNumber       *string         `xml:"FaxNb,omitempty"`
+	URLAddress      *string         `xml:"URLAdr,omitempty"`
+	EmailAddress    *string         `xml:"EmailAdr,omitempty"`
+	EmailPurpose    *string         `xml:"EmailPurp,omitempty"`
+	JobTitle        *string         `xml:"JobTitl,omitempty"`
+	Responsibility  *string         `xml:"Rspnsblty,omitempty"`
+	Department      *string         `xml:"Dept,omitempty"`
+	Other           []OtherContact1 `xml:"Othr,omitempty"`
+	PreferredMethod *string         `xml:"PrefrdMtd,omitempty"`
+}
+
+// CashAccount40 is an account in pacs.008.001.12, which may be identified by its
+// proxy alone
+type CashAccount40 struct {
+	ID       *AccountIdentification4      `xml:"Id,omitempty"`
+	Type     *CashAccountType2            `xml:"Tp,omitempty"`
+	Currency *string                      `xml:"Ccy,omitempty"`
+	Name     *string                      `xml:"Nm,omitempty"`
+	Proxy    *ProxyAccountIdentification1 `xml:"Prxy,omitempty"`
+}
+
+// Charges16 is the charges information of pacs.008.001.12
+type Charges16 struct {
+	Amount ActiveOrHistoricCurrencyAndAmount            `xml:"Amt"`
+	Agent  BranchAndFinancialInstitutionIdentification8 `xml:"Agt"`
+}
+
+// Unmodelled returns the paths of the elements of the message, as read by
+// Unmarshal, that its components have no field for. They are not decoded, and
+// ConvertPacs008 fails on them with ErrVersionLoss.
+func (d *Pacs00800110Document) Unmodelled() []string { return d.unmodelled }
+
+func (d *Pacs00800110Document) setUnmodelled(paths []string) { d.unmodelled = paths }
+
+// Unmodelled returns the paths of the elements of the message, as read by
+// Unmarshal, that its components have no field for. They are not decoded, and
+// ConvertPacs008 fails on them with ErrVersionLoss.
+func (d *Pacs00800112Document) Unmodelled() []string { return d.unmodelled }
+
+func (d *Pacs00800112Document) setUnmodelled(paths []string) { d.unmodelled = paths }
+
+// Version implements Pacs008
+func (d *Pacs00800102Document) Version() string { return "pacs.008.001.02" }
+
+// Version implements Pacs008
+func (d *Pacs00800106Document) Version() string { return "pacs.008.001.06" }
+
+// Version implements Pacs008
+func (d *Pacs00800108Document) Version() string { return "pacs.008.001.08" }
+
+// Version implements Pacs008
+func (d *Pacs00800110Document) Version() string { return "pacs.008.001.10" }
+
+// Version implements Pacs008
+func (d *Pacs00800112Document) Version() string { return "pacs.008.001.12" }
+
+// MessageID implements Pacs008
+func (d *Pacs00800102Document) MessageID() string {
+	return d.FICustomerCreditTransfer.GroupHeader.MessageID
+}
+
+// MessageID implements Pacs008
+func (d *Pacs00800106Document) MessageID() string {
+	return d.FICustomerCreditTransfer.GroupHeader.MessageID
+}
+
+// MessageID implements Pacs008
+func (d *Pacs00800108Document) MessageID() string {
+	return d.FICustomerCreditTransfer.GroupHeader.MessageID
+}
+
+// MessageID implements Pacs008
+func (d *Pacs00800110Document) MessageID() string {
+	return d.FICustomerCreditTransfer.GroupHeader.MessageID
+}
+
+// MessageID implements Pacs008
+func (d *Pacs00800112Document) MessageID() string {
+	return d.FICustomerCreditTransfer.GroupHeader.MessageID
+}
+
+// CreationDateTime implements Pacs008
+func (d *Pacs00800102Document) CreationDateTime() time.Time {
+	return d.FICustomerCreditTransfer.GroupHeader.CreationDateTime.Time
+}
+
+// CreationDateTime implements Pacs008
+func (d *Pacs00800106Document) CreationDateTime() time.Time {
+	return creationTime(d.FICustomerCreditTransfer.GroupHeader.CreationDateTime)
+}
+
+// CreationDateTime implements Pacs008
+func (d *Pacs00800108Document) CreationDateTime() time.Time {
+	return creationTime(d.FICustomerCreditTransfer.GroupHeader.CreationDateTime)
+}
+
+// CreationDateTime implements Pacs008
+func (d *Pacs00800110Document) CreationDateTime() time.Time {
+	return creationTime(d.FICustomerCreditTransfer.GroupHeader.CreationDateTime)
+}
+
+// CreationDateTime implements Pacs008
+func (d *Pacs00800112Document) CreationDateTime() time.Time {
+	return creationTime(d.FICustomerCreditTransfer.GroupHeader.CreationDateTime)
+}
+
+// SettlementMethod implements Pacs008
+func (d *Pacs00800102Document) SettlementMethod() SettlementMethod1Code {
+	return d.FICustomerCreditTransfer.GroupHeader.SettlementInfo.SettlementMethod
+}
+
+// SettlementMethod implements Pacs008
+func (d *Pacs00800106Document) SettlementMethod() SettlementMethod1Code {
+	return d.FICustomerCreditTransfer.GroupHeader.SettlementInfo.SettlementMethod
+}
+
+// SettlementMethod implements Pacs008
+func (d *Pacs00800108Document) SettlementMethod() SettlementMethod1Code {
+	return d.FICustomerCreditTransfer.GroupHeader.SettlementInfo.SettlementMethod
+}
+
+// SettlementMethod implements Pacs008
+func (d *Pacs00800110Document) SettlementMethod() SettlementMethod1Code {
+	return d.FICustomerCreditTransfer.GroupHeader.SettlementInfo.SettlementMethod
+}
+
+// SettlementMethod implements Pacs008
+func (d *Pacs00800112Document) SettlementMethod() SettlementMethod1Code {
+	return d.FICustomerCreditTransfer.GroupHeader.SettlementInfo.SettlementMethod
+}
+
+// Transactions implements Pacs008
+func (d *Pacs00800102Document) Transactions() []Pacs008Transaction {
+	txs := make([]Pacs008Transaction, len(d.FICustomerCreditTransfer.CreditTransferTransactionInfo))
+	for i, tx := range d.FICustomerCreditTransfer.CreditTransferTransactionInfo {
+		txs[i] = Pacs008Transaction{
+			InstructionID:             deref(tx.PaymentID.InstructionID),
+			EndToEndID:                tx.PaymentID.EndToEndID,
+			TransactionID:             tx.PaymentID.TransactionID,
+			InterbankSettlementAmount: tx.InterbankSettlementAmount,
+			InterbankSettlementDate:   tx.InterbankSettlementDate,
+			ChargeBearer:              tx.ChargeBearer,
+			DebtorName:                deref(tx.Debtor.Name),
+			DebtorAccount:             cashAccountID(tx.DebtorAccount),
+			DebtorAgent:               agentID(tx.DebtorAgent.FinancialInstitutionID.BankIdentifierCode, tx.DebtorAgent.FinancialInstitutionID.ClearingSystemMemberID),
+			CreditorName:              deref(tx.Creditor.Name),
+			CreditorAccount:           cashAccountID(tx.CreditorAccount),
+			CreditorAgent:             agentID(tx.CreditorAgent.FinancialInstitutionID.BankIdentifierCode, tx.CreditorAgent.FinancialInstitutionID.ClearingSystemMemberID),
+			Remittance:                unstructuredRemittance(tx.RemittanceInfo),
+		}
+	}
+	return txs
+}
+
+// Transactions implements Pacs008
+func (d *Pacs00800106Document) Transactions() []Pacs008Transaction {
+	txs := make([]Pacs008Transaction, len(d.FICustomerCreditTransfer.CreditTransferTransactionInfo))
+	for i, tx := range d.FICustomerCreditTransfer.CreditTransferTransactionInfo {
+		txs[i] = Pacs008Transaction{
+			InstructionID:             deref(tx.PaymentID.InstructionID),
+			EndToEndID:                tx.PaymentID.EndToEndID,
+			TransactionID:             tx.PaymentID.TransactionID,
+			InterbankSettlementAmount: tx.InterbankSettlementAmount,
+			InterbankSettlementDate:   tx.InterbankSettlementDate,
+			ChargeBearer:              tx.ChargeBearer,
+			DebtorName:                deref(tx.Debtor.Name),
+			DebtorAccount:             cashAccountID(tx.DebtorAccount),
+			DebtorAgent:               agentID(tx.DebtorAgent.FinancialInstitutionID.BankIdentifierCode, tx.DebtorAgent.FinancialInstitutionID.ClearingSystemMemberID),
+			CreditorName:              deref(tx.Creditor.Name),
+			CreditorAccount:           cashAccountID(tx.CreditorAccount),
+			CreditorAgent:             agentID(tx.CreditorAgent.FinancialInstitutionID.BankIdentifierCode, tx.CreditorAgent.FinancialInstitutionID.ClearingSystemMemberID),
+			Remittance:                unstructuredRemittance(tx.RemittanceInfo),
+		}
+	}
+	return txs
+}
+
+// Transactions implements Pacs008
+func (d *Pacs00800108Document) Transactions() []Pacs008Transaction {
+	return d.FICustomerCreditTransfer.transactions()
+}
+
+// Transactions implements Pacs008
+func (d *Pacs00800110Document) Transactions() []Pacs008Transaction {
+	return d.FICustomerCreditTransfer.transactions()
+}
+
+// Transactions implements Pacs008
+func (d *Pacs00800112Document) Transactions() []Pacs008Transaction {
+	txs := make([]Pacs008Transaction, len(d.FICustomerCreditTransfer.CreditTransferTransactionInfo))
+	for i, tx := range d.FICustomerCreditTransfer.CreditTransferTransactionInfo {
+		txs[i] = Pacs008Transaction{
+			InstructionID:             deref(tx.PaymentID.InstructionID),
+			EndToEndID:                tx.PaymentID.EndToEndID,
+			TransactionID:             deref(tx.PaymentID.TransactionID),
+			UETR:                      deref(tx.PaymentID.UETR),
+			InterbankSettlementAmount: tx.InterbankSettlementAmount,
+			InterbankSettlementDate:   tx.InterbankSettlementDate,
+			ChargeBearer:              tx.ChargeBearer,
+			DebtorName:                deref(tx.Debtor.Name),
+			DebtorAccount:             cashAccount40ID(tx.DebtorAccount),
+			DebtorAgent:               agentID(tx.DebtorAgent.FinancialInstitutionID.BankIdentifierCode, tx.DebtorAgent.FinancialInstitutionID.ClearingSystemMemberID),
+			CreditorName:              deref(tx.Creditor.Name),
+			CreditorAccount:           cashAccount40ID(tx.CreditorAccount),
+			CreditorAgent:             agentID(tx.CreditorAgent.FinancialInstitutionID.BankIdentifierCode, tx.CreditorAgent.FinancialInstitutionID.ClearingSystemMemberID),
+			Remittance:                unstructuredRemittance(tx.RemittanceInfo),
+		}
+	}
+	return txs
+}
+
+func (f *FIToFICustomerCreditTransferV08) transactions() []Pacs008Transaction {
+	txs := make([]Pacs008Transaction, len(f.CreditTransferTransactionInfo))
+	for i, tx := range f.CreditTransferTransactionInfo {
+		txs[i] = Pacs008Transaction{
+			InstructionID:             deref(tx.PaymentID.InstructionID),
+			EndToEndID:                tx.PaymentID.EndToEndID,
+			TransactionID:             deref(tx.PaymentID.TransactionID),
+			UETR:                      deref(tx.PaymentID.UETR),
+			InterbankSettlementAmount: tx.InterbankSettlementAmount,
+			InterbankSettlementDate:   tx.InterbankSettlementDate,
+			ChargeBearer:              tx.ChargeBearer,
+			DebtorName:                deref(tx.Debtor.Name),
+			DebtorAccount:             cashAccountID(tx.DebtorAccount),
+			DebtorAgent:               agentID(tx.DebtorAgent.FinancialInstitutionID.BankIdentifierCode, tx.DebtorAgent.FinancialInstitutionID.ClearingSystemMemberID),
+			CreditorName:              deref(tx.Creditor.Name),
+			CreditorAccount:           cashAccountID(tx.CreditorAccount),
+			CreditorAgent:             agentID(tx.CreditorAgent.FinancialInstitutionID.BankIdentifierCode, tx.CreditorAgent.FinancialInstitutionID.ClearingSystemMemberID),
+			Remittance:                unstructuredRemittance(tx.RemittanceInfo),
+		}
+	}
+	return txs
+}
+
+// Validate validates the message as its pacs.008.001.08 upgrade. Errors are
+// reported under the names of the pacs.008.001.08 elements, such as BICFI for BIC.
+func (d *Pacs00800102Document) Validate() error {
+	return validateAsPacs00800108(context.Background(), d)
+}
+
+func (d *Pacs00800102Document) validateContext(ctx context.Context) error {
+	return validateAsPacs00800108(ctx, d)
+}
+
+// Validate validates the message as its pacs.008.001.08 upgrade. Errors are
+// reported under the names of the pacs.008.001.08 elements, such as PrvsInstgAgt1
+// for PrvsInstgAgt.
+func (d *Pacs00800106Document) Validate() error {
+	return validateAsPacs00800108(context.Background(), d)
+}
+
+func (d *Pacs00800106Document) validateContext(ctx context.Context) error {
+	return validateAsPacs00800108(ctx, d)
+}
+
+// Validate validates the message against the pacs.008.001.08 core it shares
+func (d *Pacs00800110Document) Validate() error {
+	return validateAsPacs00800108(context.Background(), d)
+}
+
+func (d *Pacs00800110Document) validateContext(ctx context.Context) error {
+	return validateAsPacs00800108(ctx, d)
+}
+
+// Validate validates the message against the pacs.008.001.08 core it shares
+func (d *Pacs00800112Document) Validate() error {
+	return validateAsPacs00800108(context.Background(), d)
+}
+
+func (d *Pacs00800112Document) validateContext(ctx context.Context) error {
+	return validateAsPacs00800108(ctx, d)
+}
+
+func validateAsPacs00800108(ctx context.Context, doc Pacs008) error {
+	upgraded, err := asPacs00800108(doc)
+	if err != nil {
+		return err
+	}
+	return upgraded.validateContext(ctx)
+}
+
+// asPacs00800108 returns doc when it is a pacs.008.001.08, or else its upgrade
+// without the elements pacs.008.001.08 does not have, for the code written
+// against that version
+func asPacs00800108(doc Pacs008) (*Pacs00800108Document, error) {
+	if d, ok := doc.(*Pacs00800108Document); ok {
+		return d, nil
+	}
+	upgraded := new(Pacs00800108Document)
+	if err := convertCore(upgraded, doc); err != nil {
+		return nil, err
+	}
+	return upgraded, nil
+}
+
+// creationTime returns the time of an optional creation date time
+func creationTime(dt *ISODateTime) time.Time {
+	if dt == nil {
+		return time.Time{}
+	}
+	return dt.Time
+}
+
+// agentID returns the BIC of an agent, or else its clearing system member
+// identification
+func agentID(bic *string, member *ClearingSystemMemberIdentification) string {
+	if bic != nil {
+		return *bic
+	}
+	if member != nil {
+		return member.MemberID
+	}
+	return ""
+}
+
+// unstructuredRemittance returns the unstructured lines of optional remittance
+// information
+func unstructuredRemittance(r *RemittanceInfo) []string {
+	if r == nil {
+		return nil
+	}
+	return r.Unstructured
+}
+
+// cashAccountID returns the identification of an optional account
+func cashAccountID(a *CashAccount38) string {
+	if a == nil {
+		return ""
+	}
+	return a.ID.Identifier()
+}
+
+// cashAccount40ID returns the identification of an optional account
+func cashAccount40ID(a *CashAccount40) string {
+	if a == nil || a.ID == nil {
+		return ""
+	}
+	return a.ID.Identifier()
+}
@@ -0,0 +1,168 @@
+package iso20022
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func loadPacs008Version(t *testing.T, version string) Pacs008 {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", "roundtrip", version, "customer_credit_transfer.xml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc, err := DecodePacs008(data)
+	if err != nil {
+		t.Fatalf("DecodePacs008 failed: %v", err)
+	}
+	return doc
+}
+
+func TestPacs008Versions(t *testing.T) {
+	for _, version := range Pacs008Versions {
+		doc := loadPacs008Version(t, version)
+		if doc.Version() != version {
+			t.Errorf("Expected %s, got %s", version, doc.Version())
+		}
+		if err := doc.Validate(); err != nil {
+			t.Errorf("%s: unexpected validation errors: %v", version, err)
+		}
+		if doc.MessageID() != "BBBBUS33-20240315-0001" || doc.CreationDateTime().IsZero() || doc.SettlementMethod() != "INDA" {
+			t.Errorf("%s: unexpected header %s %v %s", version, doc.MessageID(), doc.CreationDateTime(), doc.SettlementMethod())
+		}
+		txs := doc.Transactions()
+		if len(txs) != 1 {
+			t.Fatalf("%s: expected 1 transaction, got %d", version, len(txs))
+		}
+		tx := txs[0]
+		if tx.EndToEndID != "INV-2024-0042" || tx.TransactionID != "BBBBUS33-TX-0001" || tx.InterbankSettlementAmount.Value != 15000 ||
+			tx.DebtorAgent != "BBBBUS33" || tx.CreditorAgent != "CCCCGB2L" || tx.CreditorAccount != "GB29NWBK60161331926819" ||
+			tx.DebtorName != "Acme Manufacturing Inc" || tx.ChargeBearer != "SHAR" {
+			t.Errorf("%s: unexpected transaction %+v", version, tx)
+		}
+		if hasUETR := tx.UETR != ""; hasUETR != (version >= "pacs.008.001.08") {
+			t.Errorf("%s: unexpected UETR %q", version, tx.UETR)
+		}
+	}
+
+	if _, err := DecodePacs008([]byte(`<Document xmlns="urn:iso:std:iso:20022:tech:xsd:pacs.002.001.10"/>`)); !errors.Is(err, ErrUnknownMessage) {
+		t.Errorf("Expected ErrUnknownMessage for a pacs.002, got %v", err)
+	}
+}
+
+func TestConvertPacs008(t *testing.T) {
+	v02 := loadPacs008Version(t, "pacs.008.001.02")
+	upgraded, err := ConvertPacs008(v02, "pacs.008.001.12")
+	if err != nil {
+		t.Fatalf("Upgrade failed: %v", err)
+	}
+	ct := upgraded.(*Pacs00800112Document).FICustomerCreditTransfer
+	tx := ct.CreditTransferTransactionInfo[0]
+	if deref(tx.ChargesInfo[0].Agent.FinancialInstitutionID.BankIdentifierCode) != "BBBBUS33" ||
+		deref(tx.PaymentID.TransactionID) != "BBBBUS33-TX-0001" ||
+		tx.Debtor.ID.OrganizationID.Other[0].ID != "ACME-4471" {
+		t.Errorf("Unexpected upgraded transaction %+v", tx)
+	}
+	if !reflect.DeepEqual(upgraded.Transactions(), v02.Transactions()) {
+		t.Errorf("Upgrade changed the transactions:\n%+v\n%+v", upgraded.Transactions(), v02.Transactions())
+	}
+
+	// Downgrading the upgrade gives the original message back
+	downgraded, err := ConvertPacs008(upgraded, "pacs.008.001.02")
+	if err != nil {
+		t.Fatalf("Downgrade failed: %v", err)
+	}
+	original, _ := Marshal(v02)
+	again, _ := Marshal(downgraded)
+	if !bytes.Equal(original, again) {
+		t.Errorf("Round trip through pacs.008.001.12 changed the message:\n%s\n%s", original, again)
+	}
+
+	v06 := loadPacs008Version(t, "pacs.008.001.06")
+	v08, err := ConvertPacs008(v06, "pacs.008.001.08")
+	if err != nil {
+		t.Fatalf("Upgrade failed: %v", err)
+	}
+	if agent := v08.(*Pacs00800108Document).FICustomerCreditTransfer.CreditTransferTransactionInfo[0].PreviousInstructingAgent1; agent == nil ||
+		deref(agent.FinancialInstitutionID.BankIdentifierCode) != "DDDDUS44" {
+		t.Errorf("Expected PrvsInstgAgt to become PrvsInstgAgt1, got %+v", agent)
+	}
+}
+
+func TestConvertPacs008Loss(t *testing.T) {
+	v08 := loadPacs008Version(t, "pacs.008.001.08")
+	_, err := ConvertPacs008(v08, "pacs.008.001.06")
+	if !errors.Is(err, ErrVersionLoss) || !strings.Contains(err.Error(), "CdtTrfTxInf[1]/PmtId/UETR") {
+		t.Fatalf("Expected the UETR to be reported, got %v", err)
+	}
+
+	doc := v08.(*Pacs00800108Document)
+	doc.FICustomerCreditTransfer.CreditTransferTransactionInfo[0].PaymentID.UETR = nil
+	_, err = ConvertPacs008(doc, "pacs.008.001.02")
+	if !errors.Is(err, ErrVersionLoss) || !strings.Contains(err.Error(), "Dbtr/Id/OrgId/LEI") {
+		t.Fatalf("Expected the LEI to be reported, got %v", err)
+	}
+	doc.FICustomerCreditTransfer.CreditTransferTransactionInfo[0].Debtor.ID = nil
+	if _, err := ConvertPacs008(doc, "pacs.008.001.02"); err != nil {
+		t.Errorf("Expected the downgrade to succeed, got %v", err)
+	}
+
+	if _, err := ConvertPacs008(doc, "pacs.002.001.10"); !errors.Is(err, ErrUnknownMessage) {
+		t.Errorf("Expected ErrUnknownMessage for another message, got %v", err)
+	}
+}
+
+func TestConvertPacs008Unmodelled(t *testing.T) {
+	read := func(version string) []byte {
+		data, err := os.ReadFile(filepath.Join("testdata", "roundtrip", version, "customer_credit_transfer.xml"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return bytes.Replace(data, []byte("<StrtNm>Main Street</StrtNm>"), []byte("<CareOf>Accounts Payable</CareOf><StrtNm>Main Street</StrtNm>"), 1)
+	}
+
+	var v12 Pacs00800112Document
+	if err := Unmarshal(read("pacs.008.001.12"), &v12); err != nil {
+		t.Fatal(err)
+	}
+	if len(v12.Unmodelled()) != 0 || deref(v12.FICustomerCreditTransfer.CreditTransferTransactionInfo[0].Debtor.PostalAddress.CareOf) != "Accounts Payable" {
+		t.Fatalf("Expected CareOf to be decoded, got %v", v12.Unmodelled())
+	}
+	if out, _ := Marshal(&v12); !bytes.Contains(out, []byte("<CareOf>Accounts Payable</CareOf>")) {
+		t.Errorf("Expected CareOf to be written back, got %s", out)
+	}
+	if _, err := ConvertPacs008(&v12, "pacs.008.001.10"); !errors.Is(err, ErrVersionLoss) || !strings.Contains(err.Error(), "Dbtr/PstlAdr/CareOf") {
+		t.Errorf("Expected CareOf to be reported, got %v", err)
+	}
+
+	var v10 Pacs00800110Document
+	if err := Unmarshal(read("pacs.008.001.10"), &v10); err != nil {
+		t.Fatal(err)
+	}
+	if got := v10.Unmodelled(); len(got) != 1 || !strings.HasSuffix(got[0], "Dbtr/PstlAdr/CareOf") {
+		t.Fatalf("Expected CareOf to be recorded as not decoded, got %v", got)
+	}
+	if _, err := ConvertPacs008(&v10, "pacs.008.001.12"); !errors.Is(err, ErrVersionLoss) || !strings.Contains(err.Error(), "Dbtr/PstlAdr/CareOf") {
+		t.Errorf("Expected CareOf to be reported, got %v", err)
+	}
+	if err := v10.Validate(); err != nil {
+		t.Errorf("Expected the message to validate, got %v", err)
+	}
+}
+
+func TestNegotiateVersion(t *testing.T) {
+	if v, ok := NegotiateVersion(Pacs008Versions, []string{"pacs.008.001.12", "pacs.008.001.08", "pacs.008.001.09"}); !ok || v != "pacs.008.001.12" {
+		t.Errorf("Expected pacs.008.001.12, got %q", v)
+	}
+	if v, ok := NegotiateVersion(Pacs008Versions, []string{"pacs.008.001.09", "pacs.008.001.06"}); !ok || v != "pacs.008.001.06" {
+		t.Errorf("Expected pacs.008.001.06, got %q", v)
+	}
+	if _, ok := NegotiateVersion(Pacs008Versions, []string{"pacs.008.001.01"}); ok {
+		t.Error("Expected no common version")
+	}
+}
@@ -0,0 +1,186 @@
+package iso20022
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// countryAliases maps the country codes in use outside ISO 3166, such as the codes of
+// the EU institutions, to their ISO 3166 alpha-2 codes
+var countryAliases = map[string]string{
+	"UK": "GB",
+	"EL": "GR",
+}
+
+// CanonicalCountryCode returns the ISO 3166 alpha-2 code for a country code written
+// in any case and with surrounding spaces, such as " uk" for GB
+func CanonicalCountryCode(code string) string {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if alias, ok := countryAliases[code]; ok {
+		return alias
+	}
+	return code
+}
+
+// latinFolds maps the accented Latin letters of party names to their base letters
+var latinFolds = strings.NewReplacer(
+	"à", "a", "á", "a", "â", "a", "ã", "a", "ä", "a", "å", "a", "æ", "ae",
+	"ç", "c", "è", "e", "é", "e", "ê", "e", "ë", "e",
+	"ì", "i", "í", "i", "î", "i", "ï", "i", "ñ", "n",
+	"ò", "o", "ó", "o", "ô", "o", "õ", "o", "ö", "o", "ø", "o", "œ", "oe",
+	"ù", "u", "ú", "u", "û", "u", "ü", "u", "ý", "y", "ÿ", "y", "ß", "ss",
+)
+
+// NormalizeName returns the form of a party name parties are compared by: in lower
+// case, with accents removed from Latin letters, punctuation dropped and the words
+// separated by single spaces, so that "Müller & Co., Ltd." becomes "muller co ltd"
+func NormalizeName(name string) string {
+	name = latinFolds.Replace(strings.ToLower(name))
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '\''
+	})
+	for i, w := range words {
+		words[i] = strings.ReplaceAll(w, "'", "")
+	}
+	return strings.Join(words, " ")
+}
+
+// legalForms are the words of a normalized name giving the legal form of a company,
+// which NormalizeName keeps but MatchParties ignores
+var legalForms = map[string]bool{
+	"ltd": true, "limited": true, "plc": true, "llc": true, "llp": true, "inc": true,
+	"incorporated": true, "corp": true, "corporation": true, "co": true, "company": true,
+	"gmbh": true, "ag": true, "kg": true, "sa": true, "sas": true, "sarl": true,
+	"srl": true, "spa": true, "bv": true, "nv": true, "ab": true, "as": true, "oy": true,
+}
+
+// nameKey returns the normalized name without the legal forms
+func nameKey(name string) string {
+	words := strings.Fields(NormalizeName(name))
+	kept := words[:0]
+	for _, w := range words {
+		if !legalForms[w] {
+			kept = append(kept, w)
+		}
+	}
+	return strings.Join(kept, " ")
+}
+
+// Normalize cleans a party before it is sent or compared: it trims the name and the
+// elements of the postal address and collapses their runs of spaces, and gives the
+// country of the address and the country of residence in canonical form. The case
+// and punctuation of the name are kept.
+func (p *PartyIdentification135) Normalize() {
+	clean := func(s *string) {
+		if s != nil {
+			*s = strings.Join(strings.Fields(*s), " ")
+		}
+	}
+	clean(p.Name)
+	if p.CountryOfResidence != nil {
+		*p.CountryOfResidence = CanonicalCountryCode(*p.CountryOfResidence)
+	}
+	if a := p.PostalAddress; a != nil {
+		for _, s := range []*string{a.Department, a.SubDepartment, a.StreetName, a.BuildingNumber, a.BuildingName,
+			a.Floor, a.PostBox, a.Room, a.PostCode, a.TownName, a.TownLocationName, a.DistrictName, a.CountrySubDivision} {
+			clean(s)
+		}
+		for i := range a.AddressLine {
+			clean(&a.AddressLine[i])
+		}
+		if a.Country != nil {
+			*a.Country = CanonicalCountryCode(*a.Country)
+		}
+	}
+}
+
+// PartyMatch is how far two parties are likely to be the same
+type PartyMatch int
+
+const (
+	// PartyMismatch parties have different names, accounts or LEIs
+	PartyMismatch PartyMatch = iota
+	// PartyNameMatch parties have the same name but no account or LEI to confirm it
+	PartyNameMatch
+	// PartyNameAndAccountMatch parties have the same name and account
+	PartyNameAndAccountMatch
+	// PartyLEIMatch parties have the same LEI
+	PartyLEIMatch
+)
+
+func (m PartyMatch) String() string {
+	switch m {
+	case PartyMismatch:
+		return "mismatch"
+	case PartyNameMatch:
+		return "name match"
+	case PartyNameAndAccountMatch:
+		return "name and account match"
+	case PartyLEIMatch:
+		return "LEI match"
+	}
+	return fmt.Sprintf("PartyMatch(%d)", int(m))
+}
+
+// Probable reports whether the match identifies the party: by its LEI, or by its
+// name and account
+func (m PartyMatch) Probable() bool {
+	return m >= PartyNameAndAccountMatch
+}
+
+// MatchParties compares two parties, each with the account it is paid on or nil, as
+// when a beneficiary is checked against the account holder before a payment. Parties
+// with the same LEI match whatever their names. Otherwise, names are compared in
+// normalized form without their legal forms, so that ACME Ltd matches Acme Limited,
+// and accounts, by IBAN or other identification, must be the same when both are
+// given. Parties with different LEIs never match.
+func MatchParties(a *PartyIdentification135, accountA *CashAccount38, b *PartyIdentification135, accountB *CashAccount38) PartyMatch {
+	leiA, leiB := partyLEI(a), partyLEI(b)
+	switch {
+	case leiA != "" && leiA == leiB:
+		return PartyLEIMatch
+	case leiA != "" && leiB != "":
+		return PartyMismatch
+	}
+	if a.Name == nil || b.Name == nil {
+		return PartyMismatch
+	}
+	if key := nameKey(*a.Name); key == "" || key != nameKey(*b.Name) {
+		return PartyMismatch
+	}
+	idA, idB := accountKey(accountA), accountKey(accountB)
+	switch {
+	case idA == "" || idB == "":
+		return PartyNameMatch
+	case idA == idB:
+		return PartyNameAndAccountMatch
+	}
+	return PartyMismatch
+}
+
+// partyLEI returns the LEI of an organisation in upper case, or ""
+func partyLEI(p *PartyIdentification135) string {
+	if p.ID == nil || p.ID.OrganizationID == nil || p.ID.OrganizationID.LegalEntityIdentifier == nil {
+		return ""
+	}
+	return strings.ToUpper(strings.TrimSpace(*p.ID.OrganizationID.LegalEntityIdentifier))
+}
+
+// accountKey returns the IBAN or other identification of an account without spaces
+// and in upper case, or ""
+func accountKey(account *CashAccount38) string {
+	if account == nil {
+		return ""
+	}
+	var id string
+	switch {
+	case account.ID.IBAN != nil:
+		id = "IBAN:" + *account.ID.IBAN
+	case account.ID.Other != nil:
+		id = "OTHR:" + account.ID.Other.ID
+	default:
+		return ""
+	}
+	return strings.ToUpper(strings.Join(strings.Fields(id), ""))
+}
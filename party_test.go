@@ -0,0 +1,76 @@
+package iso20022
+
+import "testing"
+
+func TestNormalizeName(t *testing.T) {
+	tests := map[string]string{
+		"Müller & Co., Ltd.":     "muller co ltd",
+		"  ACME   Corporation  ": "acme corporation",
+		"O'Brien-Smith":          "obrien smith",
+		"Société Générale S.A.":  "societe generale s a",
+		"":                       "",
+	}
+	for in, want := range tests {
+		if got := NormalizeName(in); got != want {
+			t.Errorf("NormalizeName(%q) = %q, expected %q", in, got, want)
+		}
+	}
+	if got := CanonicalCountryCode(" uk"); got != "GB" {
+		t.Errorf("Expected UK to be given as GB, got %q", got)
+	}
+}
+
+func TestPartyNormalize(t *testing.T) {
+	p := PartyIdentification135{
+		Name:               Ptr("  ACME   Ltd. "),
+		CountryOfResidence: Ptr("el"),
+		PostalAddress: &PostalAddress24{
+			TownName:    Ptr(" London "),
+			Country:     Ptr("uk"),
+			AddressLine: []string{"1  Threadneedle  Street"},
+		},
+	}
+	p.Normalize()
+	if *p.Name != "ACME Ltd." || *p.CountryOfResidence != "GR" {
+		t.Errorf("Expected the name and country of residence to be cleaned, got %q and %q", *p.Name, *p.CountryOfResidence)
+	}
+	if a := p.PostalAddress; *a.TownName != "London" || *a.Country != "GB" || a.AddressLine[0] != "1 Threadneedle Street" {
+		t.Errorf("Expected the address to be cleaned, got %q, %q and %q", *a.TownName, *a.Country, a.AddressLine[0])
+	}
+}
+
+func TestMatchParties(t *testing.T) {
+	party := func(name, lei string) *PartyIdentification135 {
+		p := &PartyIdentification135{Name: Ptr(name)}
+		if lei != "" {
+			p.ID = &Party38{OrganizationID: &OrganizationIdentification29{LegalEntityIdentifier: Ptr(lei)}}
+		}
+		return p
+	}
+	iban := func(s string) *CashAccount38 {
+		return &CashAccount38{ID: AccountIdentification4{IBAN: Ptr(s)}}
+	}
+	tests := []struct {
+		name     string
+		a, b     *PartyIdentification135
+		accA     *CashAccount38
+		accB     *CashAccount38
+		expected PartyMatch
+	}{
+		{"same LEI", party("ACME Ltd", "5493001KJTIIGC8Y1R12"), party("Acme Holdings", "5493001kjtiigc8y1r12"), nil, nil, PartyLEIMatch},
+		{"different LEIs", party("ACME Ltd", "5493001KJTIIGC8Y1R12"), party("ACME Ltd", "529900T8BM49AURSDO55"), nil, nil, PartyMismatch},
+		{"name and account", party("ACME Ltd.", ""), party("Acme Limited", ""), iban("GB29 NWBK 6016 1331 9268 19"), iban("GB29NWBK60161331926819"), PartyNameAndAccountMatch},
+		{"name only", party("Müller GmbH", ""), party("MULLER", ""), iban("DE89370400440532013000"), nil, PartyNameMatch},
+		{"different accounts", party("ACME Ltd", ""), party("ACME Ltd", ""), iban("GB29NWBK60161331926819"), iban("DE89370400440532013000"), PartyMismatch},
+		{"different names", party("ACME Ltd", ""), party("Apex Ltd", ""), iban("GB29NWBK60161331926819"), iban("GB29NWBK60161331926819"), PartyMismatch},
+		{"legal form only", party("Ltd", ""), party("Limited", ""), nil, nil, PartyMismatch},
+	}
+	for _, tt := range tests {
+		if got := MatchParties(tt.a, tt.accA, tt.b, tt.accB); got != tt.expected {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.expected, got)
+		}
+	}
+	if PartyNameMatch.Probable() || !PartyNameAndAccountMatch.Probable() || !PartyLEIMatch.Probable() {
+		t.Error("Expected only account and LEI matches to be probable")
+	}
+}
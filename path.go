@@ -0,0 +1,237 @@
+package iso20022
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// ErrPath is returned by GetPath and SetPath for a path that does not lead to a
+// value of the document, such as a path with a misspelled element
+var ErrPath = errors.New("invalid path")
+
+// pathStep is an element of a path, with the 0-based index of its repetition
+type pathStep struct {
+	name  string
+	index int
+}
+
+// parsePath splits a path into its steps
+func parsePath(path string) ([]pathStep, error) {
+	if path == "" {
+		return nil, fmt.Errorf("%w: empty path", ErrPath)
+	}
+	var steps []pathStep
+	for _, s := range strings.FieldsFunc(path, func(r rune) bool { return r == '.' || r == '/' }) {
+		step := pathStep{name: s}
+		if i := strings.IndexByte(s, '['); i >= 0 {
+			n, err := strconv.Atoi(strings.TrimSuffix(s[i+1:], "]"))
+			if err != nil || n < 0 || !strings.HasSuffix(s, "]") {
+				return nil, fmt.Errorf("%w: bad index in %s", ErrPath, s)
+			}
+			step = pathStep{name: s[:i], index: n}
+		}
+		if len(steps) > 0 && strings.HasPrefix(steps[len(steps)-1].name, "@") {
+			return nil, fmt.Errorf("%w: %s follows an attribute", ErrPath, s)
+		}
+		steps = append(steps, step)
+	}
+	return steps, nil
+}
+
+// GetPath returns the text of the element or attribute of doc at path, as it is
+// written to XML. A path is made of element names separated by dots or slashes,
+// such as FIToFICstmrCdtTrf.CdtTrfTxInf[0].Cdtr.Nm; an index picks a repetition of
+// a repeated element, counting from 0 unlike the paths of ValidationError, and
+// defaults to the first. A last step such as @Ccy names an attribute. Elements that
+// are absent from doc have the empty text; a path the message definition does not
+// have is an ErrPath.
+func GetPath(doc interface{}, path string) (string, error) {
+	steps, err := parsePath(path)
+	if err != nil {
+		return "", err
+	}
+	v, err := resolvePath(reflect.ValueOf(doc), steps, lookupPath)
+	if err != nil || !v.IsValid() {
+		return "", err
+	}
+	return pathText(v, path)
+}
+
+// SetPath sets the element or attribute of doc at path, a path as for GetPath, to
+// the value written as text, which is parsed as the type of the element. doc must be
+// a pointer. The optional elements on the path are created as needed, and an index
+// one past the last repetition of a repeated element adds a repetition. doc is left
+// as it is when SetPath fails.
+func SetPath(doc interface{}, path, text string) error {
+	steps, err := parsePath(path)
+	if err != nil {
+		return err
+	}
+	v := reflect.ValueOf(doc)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return fmt.Errorf("set path: need a non-nil pointer, got %T", doc)
+	}
+	// Check the path and the text before creating anything
+	target, err := resolvePath(v, steps, checkPath)
+	if err != nil {
+		return err
+	}
+	if !target.IsValid() {
+		return fmt.Errorf("%w: %s goes through an element of no fixed type", ErrPath, path)
+	}
+	if err := setPathText(reflect.New(target.Type()).Elem(), path, text); err != nil {
+		return err
+	}
+	target, err = resolvePath(v, steps, createPath)
+	if err != nil {
+		return err
+	}
+	return setPathText(target, path, text)
+}
+
+// pathMode is what resolvePath does with the absent elements on a path
+type pathMode int
+
+const (
+	// lookupPath stops at an absent element
+	lookupPath pathMode = iota
+	// checkPath goes on with the zero value of absent elements, changing nothing
+	checkPath
+	// createPath adds the absent elements
+	createPath
+)
+
+// resolvePath follows steps from v. It returns the invalid value when it stops at
+// an absent element.
+func resolvePath(v reflect.Value, steps []pathStep, mode pathMode) (reflect.Value, error) {
+	for _, step := range steps {
+		v = pathElem(v, mode)
+		if !v.IsValid() {
+			return v, nil
+		}
+		child, ok := childElement(v, step.name)
+		if !ok {
+			return reflect.Value{}, fmt.Errorf("%w: %s has no element %s", ErrPath, v.Type().Name(), step.name)
+		}
+		if child.Kind() != reflect.Slice || child.Type().Elem().Kind() == reflect.Uint8 {
+			if step.index > 0 {
+				return reflect.Value{}, fmt.Errorf("%w: %s is not repeated", ErrPath, step.name)
+			}
+			v = child
+			continue
+		}
+		switch n := child.Len(); {
+		case step.index < n:
+			v = child.Index(step.index)
+		case step.index > n && mode != lookupPath:
+			return reflect.Value{}, fmt.Errorf("%w: %s has %d repetitions, cannot set %s[%d]", ErrPath, step.name, n, step.name, step.index)
+		case mode == lookupPath:
+			return reflect.Value{}, nil
+		case mode == checkPath:
+			v = reflect.Zero(child.Type().Elem())
+		default:
+			child.Set(reflect.Append(child, reflect.Zero(child.Type().Elem())))
+			v = child.Index(step.index)
+		}
+	}
+	return pathElem(v, mode), nil
+}
+
+// pathElem returns the value v points to. When v is nil, it returns the invalid
+// value for lookupPath, the zero value for checkPath and a new value for
+// createPath.
+func pathElem(v reflect.Value, mode pathMode) reflect.Value {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			switch {
+			case mode == lookupPath || v.Kind() == reflect.Interface:
+				return reflect.Value{}
+			case mode == checkPath:
+				return reflect.Zero(v.Type().Elem())
+			}
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+// chardataField returns the field of a struct holding its character data
+func chardataField(v reflect.Value) (reflect.Value, bool) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		if strings.HasSuffix(t.Field(i).Tag.Get("xml"), ",chardata") {
+			return v.Field(i), true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// pathText returns the text of the value at path
+func pathText(v reflect.Value, path string) (string, error) {
+	if text, ok := leafText(v); ok {
+		return text, nil
+	}
+	if v.Kind() == reflect.Struct {
+		if chardata, ok := chardataField(v); ok {
+			return pathText(chardata, path)
+		}
+	}
+	return "", fmt.Errorf("%w: %s is a %s, not a value", ErrPath, path, v.Type().Name())
+}
+
+// setPathText sets the value at path, which must be settable, to text
+func setPathText(v reflect.Value, path, text string) error {
+	if u, ok := v.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		if err := u.UnmarshalText([]byte(text)); err != nil {
+			return fmt.Errorf("set %s: %w", path, err)
+		}
+		return nil
+	}
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(text)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(text)
+		if err != nil {
+			return fmt.Errorf("set %s: %w", path, err)
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(text, 10, v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("set %s: %w", path, err)
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(text, 10, v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("set %s: %w", path, err)
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(strings.TrimSpace(text), v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("set %s: %w", path, err)
+		}
+		v.SetFloat(f)
+	case reflect.Slice:
+		if v.Type().Elem().Kind() != reflect.Uint8 {
+			return fmt.Errorf("%w: %s is not a value", ErrPath, path)
+		}
+		v.SetBytes([]byte(text))
+	case reflect.Struct:
+		chardata, ok := chardataField(v)
+		if !ok {
+			return fmt.Errorf("%w: %s is a %s, not a value", ErrPath, path, v.Type().Name())
+		}
+		return setPathText(chardata, path, text)
+	default:
+		return fmt.Errorf("%w: %s is not a value", ErrPath, path)
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package iso20022
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestGetPath(t *testing.T) {
+	doc := loadPacs008Sample(t)
+	tests := []struct {
+		path, want string
+	}{
+		{"FIToFICstmrCdtTrf.CdtTrfTxInf[0].Cdtr.Nm", "Widget Supplies Ltd"},
+		{"FIToFICstmrCdtTrf/CdtTrfTxInf/Cdtr/Nm", "Widget Supplies Ltd"},
+		{"FIToFICstmrCdtTrf.GrpHdr.NbOfTxs", "1"},
+		{"FIToFICstmrCdtTrf.GrpHdr.CreDtTm", "2024-03-15T09:30:47.000Z"},
+		{"FIToFICstmrCdtTrf.CdtTrfTxInf[0].IntrBkSttlmAmt", "15000"},
+		{"FIToFICstmrCdtTrf.CdtTrfTxInf[0].IntrBkSttlmAmt.@Ccy", "USD"},
+		{"FIToFICstmrCdtTrf.CdtTrfTxInf[0].IntrBkSttlmDt", "2024-03-15"},
+		{"FIToFICstmrCdtTrf.CdtTrfTxInf[0].InstgAgt.FinInstnId.BICFI", "BBBBUS33"},
+		// Absent elements
+		{"FIToFICstmrCdtTrf.GrpHdr.CtrlSum", ""},
+		{"FIToFICstmrCdtTrf.CdtTrfTxInf[1].Cdtr.Nm", ""},
+		{"FIToFICstmrCdtTrf.CdtTrfTxInf[0].RmtInf.Ustrd", ""},
+	}
+	for _, tt := range tests {
+		got, err := GetPath(doc, tt.path)
+		if err != nil || got != tt.want {
+			t.Errorf("%s: got %q, %v, want %q", tt.path, got, err, tt.want)
+		}
+	}
+
+	for _, path := range []string{
+		"",
+		"FIToFICstmrCdtTrf.GrpHdr.MsgID",
+		"FIToFICstmrCdtTrf.CdtTrfTxInf[0].Cdtr",
+		"FIToFICstmrCdtTrf.GrpHdr[1].MsgId",
+		"FIToFICstmrCdtTrf.CdtTrfTxInf[x].Cdtr.Nm",
+		"FIToFICstmrCdtTrf.CdtTrfTxInf[0].IntrBkSttlmAmt.@Ccy.Nm",
+	} {
+		if _, err := GetPath(doc, path); !errors.Is(err, ErrPath) {
+			t.Errorf("%q: expected ErrPath, got %v", path, err)
+		}
+	}
+}
+
+func TestSetPath(t *testing.T) {
+	doc := loadPacs008Sample(t)
+	sets := []struct {
+		path, text string
+	}{
+		{"FIToFICstmrCdtTrf.CdtTrfTxInf[0].Cdtr.Nm", "Gadget Supplies Ltd"},
+		{"FIToFICstmrCdtTrf.CdtTrfTxInf[0].IntrBkSttlmAmt", "15250.5"},
+		{"FIToFICstmrCdtTrf.CdtTrfTxInf[0].IntrBkSttlmAmt.@Ccy", "EUR"},
+		{"FIToFICstmrCdtTrf.CdtTrfTxInf[0].IntrBkSttlmDt", "2024-03-18"},
+		{"FIToFICstmrCdtTrf.GrpHdr.CtrlSum", "15250.5"},
+		{"FIToFICstmrCdtTrf.GrpHdr.BtchBookg", "true"},
+		{"FIToFICstmrCdtTrf.CdtTrfTxInf[0].RmtInf.Ustrd[0]", "INV-2024-0042"},
+		{"FIToFICstmrCdtTrf.CdtTrfTxInf[1].PmtId.EndToEndId", "INV-2024-0043"},
+		{"FIToFICstmrCdtTrf.CdtTrfTxInf[1].Cdtr.PstlAdr.Ctry", "GB"},
+	}
+	for _, s := range sets {
+		if err := SetPath(doc, s.path, s.text); err != nil {
+			t.Fatalf("%s: %v", s.path, err)
+		}
+	}
+
+	msg := doc.FICustomerCreditTransfer
+	tx := msg.CreditTransferTransactionInfo[0]
+	if *tx.Creditor.Name != "Gadget Supplies Ltd" || tx.InterbankSettlementAmount != (ActiveCurrencyAndAmount{Value: 15250.5, Currency: "EUR"}) ||
+		tx.InterbankSettlementDate.String() != "2024-03-18" || tx.RemittanceInfo.Unstructured[0] != "INV-2024-0042" {
+		t.Errorf("Unexpected transaction %+v", tx)
+	}
+	if *msg.GroupHeader.ControlSum != 15250.5 || !*msg.GroupHeader.BatchBooking {
+		t.Errorf("Unexpected group header %+v", msg.GroupHeader)
+	}
+	if len(msg.CreditTransferTransactionInfo) != 2 {
+		t.Fatalf("Expected a transaction to be added, got %d", len(msg.CreditTransferTransactionInfo))
+	}
+	added := msg.CreditTransferTransactionInfo[1]
+	if added.PaymentID.EndToEndID != "INV-2024-0043" || *added.Creditor.PostalAddress.Country != "GB" {
+		t.Errorf("Unexpected added transaction %+v", added)
+	}
+	for _, s := range sets {
+		if got, err := GetPath(doc, s.path); err != nil || got != s.text {
+			t.Errorf("%s: got %q, %v, want %q", s.path, got, err, s.text)
+		}
+	}
+}
+
+func TestSetPathFailures(t *testing.T) {
+	doc := loadPacs008Sample(t)
+	for _, s := range []struct {
+		path, text string
+	}{
+		{"FIToFICstmrCdtTrf.CdtTrfTxInf[0].IntrBkSttlmAmt", "many"},
+		{"FIToFICstmrCdtTrf.GrpHdr.CtrlSum", "15,000"},
+		{"FIToFICstmrCdtTrf.GrpHdr.IntrBkSttlmDt", "18/03/2024"},
+		{"FIToFICstmrCdtTrf.CdtTrfTxInf[2].PmtId.EndToEndId", "INV-2024-0044"},
+		{"FIToFICstmrCdtTrf.CdtTrfTxInf[0].UltmtCdtr.Nme", "Widget Holdings"},
+		{"FIToFICstmrCdtTrf.CdtTrfTxInf[0].UltmtCdtr", "Widget Holdings"},
+	} {
+		if err := SetPath(doc, s.path, s.text); err == nil {
+			t.Errorf("%s: expected an error", s.path)
+		}
+	}
+	if changes, err := Diff(loadPacs008Sample(t), doc, IgnoreElements()); err != nil || len(changes) != 0 {
+		t.Errorf("Expected failed sets to leave the document as it is, got %v, %v", changes, err)
+	}
+	if err := SetPath(*doc, "FIToFICstmrCdtTrf.GrpHdr.MsgId", "MSG"); err == nil {
+		t.Errorf("Expected a document that is not a pointer to be rejected")
+	}
+}
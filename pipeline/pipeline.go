@@ -0,0 +1,213 @@
+// Package pipeline runs ISO 20022 messages from a source through decoding,
+// validation and handlers, to build ingestion services on.
+//
+// A Source yields raw messages: the files dropped into a directory, or the documents
+// of a stream. A Pipeline reads them into a bounded queue and has a pool of workers
+// decode each one into the document of its message, told from its namespace,
+// validate it, and pass it to the handler registered for its message type. When the
+// workers fall behind the queue fills up and the source is no longer read, so a
+// slow handler holds back the source rather than letting messages pile up in
+// memory. Messages are handled concurrently and in no particular order.
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/ckbaum/iso20022-go"
+)
+
+// ErrNoHandler is reported for a message whose type has no handler
+var ErrNoHandler = errors.New("pipeline: no handler for the message type")
+
+// Message is a message going through a pipeline
+type Message struct {
+	Name     string      // where the message was read from, such as its file name
+	Data     []byte      // the message as read
+	Type     string      // message name identification, such as pacs.008.001.08
+	Document interface{} // the decoded document, such as *iso20022.Pacs00800108Document
+}
+
+// Handler processes a decoded and validated message
+type Handler func(ctx context.Context, msg *Message) error
+
+// ErrorHandler is told about a message that failed to decode, validate or be
+// handled. Type and Document are set as far as the message got.
+type ErrorHandler func(msg *Message, err error)
+
+// Option configures a Pipeline
+type Option func(*Pipeline)
+
+// WithWorkers sets the number of messages decoded, validated and handled at the
+// same time, runtime.GOMAXPROCS(0) by default
+func WithWorkers(n int) Option {
+	return func(p *Pipeline) {
+		p.workers = n
+	}
+}
+
+// WithQueue sets the number of messages read ahead of the workers, twice the
+// number of workers by default
+func WithQueue(n int) Option {
+	return func(p *Pipeline) {
+		p.queue = n
+	}
+}
+
+// WithoutValidation passes the messages to their handlers without validating them
+func WithoutValidation() Option {
+	return func(p *Pipeline) {
+		p.validate = false
+	}
+}
+
+// WithErrorHandler reports the messages that fail to eh and goes on with the next
+// ones. Without it, Run stops at the first failure and returns it.
+func WithErrorHandler(eh ErrorHandler) Option {
+	return func(p *Pipeline) {
+		p.onError = eh
+	}
+}
+
+// Pipeline decodes, validates and dispatches messages to the handlers of their
+// message types. Register the handlers before calling Run.
+type Pipeline struct {
+	workers  int
+	queue    int
+	validate bool
+	onError  ErrorHandler
+	handlers map[string]Handler
+}
+
+// New returns a pipeline without handlers
+func New(opts ...Option) *Pipeline {
+	p := &Pipeline{workers: runtime.GOMAXPROCS(0), validate: true, handlers: make(map[string]Handler)}
+	for _, opt := range opts {
+		opt(p)
+	}
+	if p.workers < 1 {
+		p.workers = 1
+	}
+	if p.queue < 1 {
+		p.queue = 2 * p.workers
+	}
+	return p
+}
+
+// Handle registers the handler of a message type. The type is a message name
+// identification such as pacs.008.001.08, or a prefix of one such as pacs.008 or
+// camt, in which case the handler takes the messages of every matching type without
+// a handler of its own; the longest match wins. The handler of the empty type takes
+// the messages no other handler does.
+func (p *Pipeline) Handle(msgType string, h Handler) {
+	p.handlers[msgType] = h
+}
+
+// handler returns the handler of a message type
+func (p *Pipeline) handler(msgType string) (Handler, bool) {
+	for prefix := msgType; ; {
+		if h, ok := p.handlers[prefix]; ok {
+			return h, true
+		}
+		if prefix == "" {
+			return nil, false
+		}
+		i := strings.LastIndexByte(prefix, '.')
+		if i < 0 {
+			i = 0
+		}
+		prefix = prefix[:i]
+	}
+}
+
+// Run processes the messages of src until it is exhausted or ctx is done, and
+// returns once the messages read have been processed. It returns the error that
+// stopped it: the error of the source or of ctx, or, without an error handler, the
+// first failure of a message.
+func (p *Pipeline) Run(ctx context.Context, src Source) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		once     sync.Once
+		firstErr error
+	)
+	stop := func(err error) {
+		once.Do(func() {
+			firstErr = err
+			cancel()
+		})
+	}
+
+	queue := make(chan *Message, p.queue)
+	var wg sync.WaitGroup
+	for i := 0; i < p.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for msg := range queue {
+				if err := p.process(ctx, msg); err != nil {
+					if p.onError == nil {
+						stop(err)
+						continue
+					}
+					p.onError(msg, err)
+				}
+			}
+		}()
+	}
+
+	for ctx.Err() == nil {
+		in, err := src.Next(ctx)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			if ctx.Err() == nil {
+				stop(err)
+			}
+			break
+		}
+		select {
+		case queue <- &Message{Name: in.Name, Data: in.Data}:
+		case <-ctx.Done():
+		}
+	}
+	close(queue)
+	wg.Wait()
+
+	if firstErr != nil {
+		return firstErr
+	}
+	return ctx.Err()
+}
+
+// process decodes, validates and handles one message
+func (p *Pipeline) process(ctx context.Context, msg *Message) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	msgType, doc, err := iso20022.DecodeDocument(msg.Data)
+	msg.Type, msg.Document = msgType, doc
+	if err != nil {
+		return fmt.Errorf("%s: %w", msg.Name, err)
+	}
+	if v, ok := doc.(iso20022.Validator); ok && p.validate {
+		if err := v.Validate(); err != nil {
+			return fmt.Errorf("%s: %w", msg.Name, err)
+		}
+	}
+	h, ok := p.handler(msgType)
+	if !ok {
+		return fmt.Errorf("%s: %w %s", msg.Name, ErrNoHandler, msgType)
+	}
+	if err := h(ctx, msg); err != nil {
+		return fmt.Errorf("%s: %w", msg.Name, err)
+	}
+	return nil
+}
@@ -0,0 +1,164 @@
+package pipeline
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ckbaum/iso20022-go"
+	"github.com/ckbaum/iso20022-go/samples"
+)
+
+// encode returns the XML of a document
+func encode(t *testing.T, doc interface{}) []byte {
+	t.Helper()
+	data, err := iso20022.Marshal(doc)
+	if err != nil {
+		t.Fatalf("Failed to encode sample: %v", err)
+	}
+	return data
+}
+
+func TestPipelineStream(t *testing.T) {
+	pacs008 := encode(t, samples.SamplePacs008())
+	camt054 := encode(t, samples.SampleCamt054())
+	stream := bytes.Join([][]byte{pacs008, camt054, []byte(`<Document xmlns="urn:iso:std:iso:20022:tech:xsd:pacs.008.001.13"/>`), pacs008}, []byte("\n"))
+
+	var mu sync.Mutex
+	handled := make(map[string][]string)
+	record := func(route string) Handler {
+		return func(ctx context.Context, msg *Message) error {
+			mu.Lock()
+			defer mu.Unlock()
+			handled[route] = append(handled[route], msg.Name)
+			return nil
+		}
+	}
+	var failed []string
+	p := New(WithWorkers(3), WithErrorHandler(func(msg *Message, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if !errors.Is(err, iso20022.ErrUnknownMessage) {
+			t.Errorf("Expected ErrUnknownMessage, got %v", err)
+		}
+		failed = append(failed, msg.Name)
+	}))
+	p.Handle("pacs.008", record("pacs.008"))
+	p.Handle("camt", record("camt"))
+
+	if err := p.Run(context.Background(), NewStreamSource("in", bytes.NewReader(stream))); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	sort.Strings(handled["pacs.008"])
+	if got := handled["pacs.008"]; len(got) != 2 || got[0] != "in#1" || got[1] != "in#4" {
+		t.Errorf("Unexpected pacs.008 messages %v", got)
+	}
+	if got := handled["camt"]; len(got) != 1 || got[0] != "in#2" {
+		t.Errorf("Unexpected camt messages %v", got)
+	}
+	if len(failed) != 1 || failed[0] != "in#3" {
+		t.Errorf("Expected the unknown message to fail, got %v", failed)
+	}
+}
+
+func TestPipelineStopsAtFirstFailure(t *testing.T) {
+	doc := samples.SamplePacs008()
+	doc.FICustomerCreditTransfer.GroupHeader.MessageID = ""
+	invalid := encode(t, doc)
+
+	p := New(WithWorkers(1))
+	p.Handle("", func(ctx context.Context, msg *Message) error { return nil })
+	err := p.Run(context.Background(), NewStreamSource("in", bytes.NewReader(invalid)))
+	var verrs iso20022.ValidationErrors
+	if !errors.As(err, &verrs) {
+		t.Fatalf("Expected the validation errors of the message, got %v", err)
+	}
+
+	p = New(WithWorkers(1), WithoutValidation())
+	if err := p.Run(context.Background(), NewStreamSource("in", bytes.NewReader(invalid))); !errors.Is(err, ErrNoHandler) {
+		t.Errorf("Expected ErrNoHandler, got %v", err)
+	}
+}
+
+func TestDirSource(t *testing.T) {
+	dir := t.TempDir()
+	pacs008 := encode(t, samples.SamplePacs008())
+	for _, name := range []string{"b.xml", "a.xml", "notes.txt"} {
+		if err := os.WriteFile(filepath.Join(dir, name), pacs008, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	src, err := NewDirSource(dir, "*.xml", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for {
+		in, err := src.Next(context.Background())
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		names = append(names, filepath.Base(in.Name))
+	}
+	if len(names) != 2 || names[0] != "a.xml" || names[1] != "b.xml" {
+		t.Errorf("Expected the XML files in name order, got %v", names)
+	}
+
+	// A watching source waits for new files until the context is done
+	src, _ = NewDirSource(dir, "*.xml", 10*time.Millisecond)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	src.Next(ctx)
+	src.Next(ctx)
+	go func() {
+		time.Sleep(30 * time.Millisecond)
+		os.WriteFile(filepath.Join(dir, "c.xml"), pacs008, 0o600)
+	}()
+	if in, err := src.Next(ctx); err != nil || filepath.Base(in.Name) != "c.xml" {
+		t.Errorf("Expected the new file, got %v, %v", in.Name, err)
+	}
+}
+
+// countingSource yields the same message forever, counting the reads
+type countingSource struct {
+	data  []byte
+	reads atomic.Int32
+}
+
+func (s *countingSource) Next(ctx context.Context) (Input, error) {
+	s.reads.Add(1)
+	return Input{Name: "msg", Data: s.data}, nil
+}
+
+func TestPipelineBackpressure(t *testing.T) {
+	src := &countingSource{data: encode(t, samples.SamplePacs008())}
+	p := New(WithWorkers(1), WithQueue(1))
+	p.Handle("pacs.008.001.08", func(ctx context.Context, msg *Message) error {
+		<-ctx.Done()
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- p.Run(ctx, src) }()
+	time.Sleep(50 * time.Millisecond)
+	// One message in the handler, one in the queue and one waiting to be queued
+	if reads := src.reads.Load(); reads > 3 {
+		t.Errorf("Expected the source to be held back by the blocked handler, got %d reads", reads)
+	}
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected the pipeline to stop with the context, got %v", err)
+	}
+}
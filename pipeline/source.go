@@ -0,0 +1,209 @@
+package pipeline
+
+import (
+	"bufio"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// Input is a raw message read by a source
+type Input struct {
+	Name string
+	Data []byte
+}
+
+// Source yields the raw messages of a pipeline. Next returns io.EOF when there are
+// no more; it is called from a single goroutine.
+type Source interface {
+	Next(ctx context.Context) (Input, error)
+}
+
+// DirSource yields the files of a directory that match a pattern, in name order,
+// and watches the directory for new ones. A file is yielded once; it is yielded
+// again only if it is changed or removed and created anew. Moving handled files
+// out of the directory is left to the handlers.
+type DirSource struct {
+	dir      string
+	pattern  string
+	interval time.Duration
+	pending  []string
+	seen     map[string]fileVersion
+}
+
+// fileVersion tells a changed file from the one yielded before
+type fileVersion struct {
+	size    int64
+	modTime time.Time
+}
+
+// NewDirSource returns a source of the files of dir matching pattern, such as
+// *.xml, that checks the directory for new files every interval. With an interval
+// of zero the directory is read once and the source is exhausted afterwards.
+func NewDirSource(dir, pattern string, interval time.Duration) (*DirSource, error) {
+	if _, err := filepath.Match(pattern, ""); err != nil {
+		return nil, err
+	}
+	return &DirSource{dir: dir, pattern: pattern, interval: interval, seen: make(map[string]fileVersion)}, nil
+}
+
+// Next returns the next file, waiting for one to appear unless the source reads
+// the directory once
+func (s *DirSource) Next(ctx context.Context) (Input, error) {
+	for scanned := false; ; {
+		for len(s.pending) > 0 {
+			path := s.pending[0]
+			s.pending = s.pending[1:]
+			data, err := os.ReadFile(path)
+			if os.IsNotExist(err) {
+				continue
+			}
+			if err != nil {
+				return Input{}, err
+			}
+			return Input{Name: path, Data: data}, nil
+		}
+		if scanned && s.interval == 0 {
+			return Input{}, io.EOF
+		}
+		if scanned {
+			select {
+			case <-ctx.Done():
+				return Input{}, ctx.Err()
+			case <-time.After(s.interval):
+			}
+		}
+		if err := s.scan(); err != nil {
+			return Input{}, err
+		}
+		scanned = true
+	}
+}
+
+// scan queues the files that are new or changed since the previous scan, and
+// forgets the ones that are gone
+func (s *DirSource) scan() error {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return err
+	}
+	present := make(map[string]bool, len(entries))
+	var found []string
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() {
+			continue
+		}
+		if ok, _ := filepath.Match(s.pattern, entry.Name()); !ok {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		present[entry.Name()] = true
+		version := fileVersion{size: info.Size(), modTime: info.ModTime()}
+		if s.seen[entry.Name()] != version {
+			s.seen[entry.Name()] = version
+			found = append(found, filepath.Join(s.dir, entry.Name()))
+		}
+	}
+	for name := range s.seen {
+		if !present[name] {
+			delete(s.seen, name)
+		}
+	}
+	sort.Strings(found)
+	s.pending = append(s.pending, found...)
+	return nil
+}
+
+// StreamSource yields the documents of a stream of concatenated XML documents, such
+// as a socket or a file of messages, each document being a top-level element
+type StreamSource struct {
+	name string
+	r    *recordingReader
+	d    *xml.Decoder
+	n    int
+}
+
+// NewStreamSource returns a source of the documents read from r. The messages are
+// named after name and their position in the stream: name#1, name#2, ...
+func NewStreamSource(name string, r io.Reader) *StreamSource {
+	rr := &recordingReader{r: bufio.NewReader(r)}
+	return &StreamSource{name: name, r: rr, d: xml.NewDecoder(rr)}
+}
+
+// Next returns the next document of the stream
+func (s *StreamSource) Next(ctx context.Context) (Input, error) {
+	depth := 0
+	var start int64
+	for {
+		if err := ctx.Err(); err != nil {
+			return Input{}, err
+		}
+		offset := s.d.InputOffset()
+		tok, err := s.d.RawToken()
+		if err == io.EOF && depth > 0 {
+			return Input{}, io.ErrUnexpectedEOF
+		}
+		if err != nil {
+			return Input{}, err
+		}
+		switch tok.(type) {
+		case xml.StartElement:
+			if depth == 0 {
+				start = offset
+				s.r.forget(start)
+			}
+			depth++
+		case xml.EndElement:
+			if depth--; depth == 0 {
+				s.n++
+				data := s.r.since(start, s.d.InputOffset())
+				return Input{Name: fmt.Sprintf("%s#%d", s.name, s.n), Data: data}, nil
+			}
+		}
+	}
+}
+
+// recordingReader keeps the bytes read from the stream since the start of the
+// current document, for the document to be cut out of them once it ends. As an
+// io.ByteReader it is read byte by byte by the decoder, so that the offsets of the
+// decoder are offsets into the recorded bytes.
+type recordingReader struct {
+	r    *bufio.Reader
+	base int64 // offset of buf[0] in the stream
+	buf  []byte
+}
+
+func (r *recordingReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.buf = append(r.buf, p[:n]...)
+	return n, err
+}
+
+func (r *recordingReader) ReadByte() (byte, error) {
+	c, err := r.r.ReadByte()
+	if err == nil {
+		r.buf = append(r.buf, c)
+	}
+	return c, err
+}
+
+// forget drops the bytes before offset
+func (r *recordingReader) forget(offset int64) {
+	r.buf = append(r.buf[:0], r.buf[offset-r.base:]...)
+	r.base = offset
+}
+
+// since returns a copy of the bytes from start up to end
+func (r *recordingReader) since(start, end int64) []byte {
+	data := make([]byte, end-start)
+	copy(data, r.buf[start-r.base:end-r.base])
+	return data
+}
@@ -0,0 +1,270 @@
+package iso20022
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+)
+
+// Posting is a single booking on an account, flattened out of a camt.054 entry for
+// ingestion into a ledger. An entry that books a batch yields one posting per
+// transaction.
+type Posting struct {
+	Account                  string // IBAN, or the other identification, of the notified account
+	Amount                   ActiveOrHistoricCurrencyAndAmount
+	CdtDbt                   CreditDebitCode
+	Reversal                 bool
+	Status                   string    // entry status code, such as BOOK or PDNG
+	ValueDate                time.Time // zero when the entry has none
+	BookingDate              time.Time // zero when the entry has none
+	EntryReference           string
+	AccountServicerReference string
+	BankTxCode               BankTransactionCodeStructure4
+	// References of the transaction, completed with the message and payment
+	// information identifications of the batch
+	References     TransactionReferences6
+	RelatedParties []PostingParty
+	RemittanceInfo []string // unstructured remittance information
+}
+
+// PostingParty is a party related to a posting, by its role in the transaction
+type PostingParty struct {
+	Role    string // Dbtr, UltmtDbtr, Cdtr, UltmtCdtr, InitgPty or TradgPty
+	Name    string
+	BIC     string // for parties identified as agents
+	Account string // IBAN, or the other identification, of the debtor or creditor account
+}
+
+// Postings flattens every entry of every notification in the document
+func (d *Camt05400108Document) Postings() ([]Posting, error) {
+	postings, err := d.BankDebitCreditNotification.Postings()
+	if err != nil {
+		return postings, nestErrors("BkToCstmrDbtCdtNtfctn", err)
+	}
+	return postings, nil
+}
+
+// Postings flattens every entry of every notification in the message
+func (n *BankToCustomerDebitCreditNotificationV08) Postings() ([]Posting, error) {
+	var postings []Posting
+	var errs ValidationErrors
+	for i := range n.Notification {
+		p, err := n.Notification[i].Postings()
+		postings = append(postings, p...)
+		if err != nil {
+			errs = append(errs, nestErrors(fmt.Sprintf("Ntfctn[%d]", i+1), err)...)
+		}
+	}
+	if errs.HasErrors() {
+		return postings, errs
+	}
+	return postings, nil
+}
+
+// Postings flattens the entries of the notification. An entry yields one posting,
+// unless its details list several transactions, in which case each transaction
+// yields a posting of its own amount. Transaction details take precedence over the
+// entry for the credit/debit indicator and bank transaction code. The transactions
+// of an entry must each carry an amount, and net to the entry amount; entries that
+// do not are reported, and the postings of the other entries are still returned.
+func (a *AccountNotification17) Postings() ([]Posting, error) {
+	var postings []Posting
+	var errs ValidationErrors
+	account := a.Account.ID.Identifier()
+	for i := range a.Entry {
+		p, err := a.Entry[i].postings(account)
+		if err != nil {
+			errs = append(errs, nestErrors(fmt.Sprintf("Ntry[%d]", i+1), err)...)
+			continue
+		}
+		postings = append(postings, p...)
+	}
+	if errs.HasErrors() {
+		return postings, errs
+	}
+	return postings, nil
+}
+
+// postings fans an entry out into one posting per transaction
+func (e *ReportEntry10) postings(account string) ([]Posting, error) {
+	entry := Posting{
+		Account:                  account,
+		Amount:                   e.Amount,
+		CdtDbt:                   e.CreditDebitIndicator,
+		Reversal:                 e.ReversalIndicator != nil && *e.ReversalIndicator,
+		ValueDate:                dateOf(e.ValueDate),
+		BookingDate:              dateOf(e.BookingDate),
+		EntryReference:           deref(e.EntryReference),
+		AccountServicerReference: deref(e.AccountServicerReference),
+		BankTxCode:               e.BankTransactionCode,
+	}
+	switch {
+	case e.Status.Code != nil:
+		entry.Status = *e.Status.Code
+	case e.Status.Proprietary != nil:
+		entry.Status = *e.Status.Proprietary
+	}
+
+	type detail struct {
+		path  string
+		batch *BatchInformation2
+		tx    *EntryTransaction10
+	}
+	var details []detail
+	for i := range e.EntryDetails {
+		dtls := &e.EntryDetails[i]
+		if len(dtls.TransactionDetails) == 0 {
+			details = append(details, detail{path: fmt.Sprintf("NtryDtls[%d]", i+1), batch: dtls.Batch})
+		}
+		for j := range dtls.TransactionDetails {
+			path := fmt.Sprintf("NtryDtls[%d]/TxDtls[%d]", i+1, j+1)
+			details = append(details, detail{path: path, batch: dtls.Batch, tx: &dtls.TransactionDetails[j]})
+		}
+	}
+	if len(details) == 0 {
+		return []Posting{entry}, nil
+	}
+
+	var errs ValidationErrors
+	postings := make([]Posting, 0, len(details))
+	net := new(big.Rat)
+	for _, d := range details {
+		p := entry
+		if d.tx != nil {
+			d.tx.applyTo(&p)
+		}
+		if len(details) > 1 {
+			var amount *ActiveOrHistoricCurrencyAndAmount
+			if d.tx != nil {
+				amount = d.tx.amount()
+			}
+			if amount == nil {
+				errs = append(errs, ValidationError{Field: "Amt", Path: d.path + "/Amt",
+					Message: "is required for each transaction of a batched entry"})
+				continue
+			}
+			p.Amount = *amount
+			if d.tx.CreditDebitIndicator != nil {
+				p.CdtDbt = *d.tx.CreditDebitIndicator
+			}
+			value := DecimalRat(amount.Value)
+			if p.CdtDbt != e.CreditDebitIndicator {
+				value.Neg(value)
+			}
+			net.Add(net, value)
+		}
+		if d.batch != nil {
+			if p.References.MessageID == nil {
+				p.References.MessageID = d.batch.MessageID
+			}
+			if p.References.PaymentInfoID == nil {
+				p.References.PaymentInfoID = d.batch.PaymentInfoID
+			}
+		}
+		postings = append(postings, p)
+	}
+	if len(details) > 1 && !errs.HasErrors() && net.Cmp(DecimalRat(e.Amount.Value)) != 0 {
+		errs = append(errs, ValidationError{Field: "Amt", Path: "Amt/text()",
+			Message: fmt.Sprintf("is %s but the transactions net to %s", formatRat(DecimalRat(e.Amount.Value)), formatRat(net))})
+	}
+	if errs.HasErrors() {
+		return nil, errs
+	}
+	return postings, nil
+}
+
+// amount returns the amount of the transaction, falling back to the transaction
+// amount of its amount details
+func (t *EntryTransaction10) amount() *ActiveOrHistoricCurrencyAndAmount {
+	if t.Amount != nil {
+		return t.Amount
+	}
+	if t.AmountDetails != nil && t.AmountDetails.TransactionAmount != nil {
+		return &t.AmountDetails.TransactionAmount.Amount
+	}
+	return nil
+}
+
+// applyTo copies the transaction's code, references, parties and remittance
+// information onto a posting
+func (t *EntryTransaction10) applyTo(p *Posting) {
+	if t.BankTransactionCode != nil {
+		p.BankTxCode = *t.BankTransactionCode
+	}
+	if t.References != nil {
+		p.References = *t.References
+	}
+	if t.RemittanceInfo != nil {
+		p.RemittanceInfo = t.RemittanceInfo.Unstructured
+	}
+	if rp := t.RelatedParties; rp != nil {
+		for _, party := range []struct {
+			role    string
+			party   *Party40
+			account *CashAccount38
+		}{
+			{"InitgPty", rp.InitiatingParty, nil},
+			{"Dbtr", rp.Debtor, rp.DebtorAccount},
+			{"UltmtDbtr", rp.UltimateDebtor, nil},
+			{"Cdtr", rp.Creditor, rp.CreditorAccount},
+			{"UltmtCdtr", rp.UltimateCreditor, nil},
+			{"TradgPty", rp.TradingParty, nil},
+		} {
+			if party.party == nil && party.account == nil {
+				continue
+			}
+			pp := PostingParty{Role: party.role}
+			if party.party != nil {
+				pp.Name, pp.BIC = partyName(party.party)
+			}
+			if party.account != nil {
+				pp.Account = party.account.ID.Identifier()
+			}
+			p.RelatedParties = append(p.RelatedParties, pp)
+		}
+	}
+}
+
+// partyName returns the name and, for agents, the BIC of a party
+func partyName(p *Party40) (name, bic string) {
+	switch {
+	case p.Party != nil:
+		return deref(p.Party.Name), ""
+	case p.Agent != nil:
+		fi := &p.Agent.FinancialInstitutionID
+		return deref(fi.Name), deref(fi.BankIdentifierCode)
+	}
+	return "", ""
+}
+
+// Identifier returns the IBAN of an account, or its other identification
+func (a AccountIdentification4) Identifier() string {
+	switch {
+	case a.IBAN != nil:
+		return *a.IBAN
+	case a.Other != nil:
+		return a.Other.ID
+	}
+	return ""
+}
+
+// dateOf returns the date or datetime of d, or the zero time
+func dateOf(d *DateAndDateTime2) time.Time {
+	switch {
+	case d == nil:
+		return time.Time{}
+	case d.Date != nil:
+		return d.Date.Time
+	case d.DateTime != nil:
+		return d.DateTime.Time
+	}
+	return time.Time{}
+}
+
+// deref returns the string pointed to by s, or "" when s is nil
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
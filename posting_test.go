@@ -0,0 +1,112 @@
+package iso20022
+
+import (
+	"testing"
+)
+
+func TestPostings(t *testing.T) {
+	// The camt.054 fixture has a single credit entry, and a debit entry booking a
+	// batch of two transactions
+	postings, err := loadSample[Camt05400108Document](t, "camt.054.001.08/debit_credit_notification.xml").Postings()
+	if err != nil {
+		t.Fatalf("Expected the sample to flatten, got %v", err)
+	}
+	if len(postings) != 3 {
+		t.Fatalf("Expected 3 postings, got %d", len(postings))
+	}
+
+	credit := postings[0]
+	if credit.Account != "DE89370400440532013000" || credit.Amount.Value != 1250 || credit.CdtDbt != "CRDT" || credit.Status != "BOOK" {
+		t.Errorf("Unexpected credit posting %+v", credit)
+	}
+	if credit.ValueDate.Format("2006-01-02") != "2024-03-15" || credit.AccountServicerReference != "ASR-7781" {
+		t.Errorf("Expected the entry dates and references on the posting, got %+v", credit)
+	}
+	if credit.BankTxCode.Domain == nil || credit.BankTxCode.Domain.Family.SubFamilyCode != "ESCT" {
+		t.Errorf("Expected bank transaction code PMNT/RCDT/ESCT, got %+v", credit.BankTxCode)
+	}
+	if len(credit.RelatedParties) != 1 || credit.RelatedParties[0] != (PostingParty{Role: "Dbtr", Name: "Muller Maschinenbau GmbH", Account: "DE75512108001245126199"}) {
+		t.Errorf("Unexpected related parties %+v", credit.RelatedParties)
+	}
+	if len(credit.RemittanceInfo) != 1 || credit.RemittanceInfo[0] != "Invoice 2024-0311" {
+		t.Errorf("Unexpected remittance information %v", credit.RemittanceInfo)
+	}
+
+	for i, want := range []struct {
+		endToEndID string
+		amount     Decimal
+	}{{"SAL-03-001", 100}, {"SAL-03-002", 200}} {
+		p := postings[i+1]
+		if deref(p.References.EndToEndID) != want.endToEndID || p.Amount.Value != want.amount || p.CdtDbt != "DBIT" {
+			t.Errorf("Batch posting %d: unexpected %+v", i+1, p)
+		}
+		if deref(p.References.MessageID) != "PAIN001-4471" || deref(p.References.PaymentInfoID) != "PMTINF-01" {
+			t.Errorf("Batch posting %d: expected the batch references, got %+v", i+1, p.References)
+		}
+		if p.EntryReference != "E-0002" {
+			t.Errorf("Batch posting %d: expected entry reference E-0002, got %s", i+1, p.EntryReference)
+		}
+	}
+}
+
+func TestPostingsReportsUnbalancedBatches(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(entry *ReportEntry10)
+		path   string
+	}{
+		{
+			name: "TransactionsDoNotNet",
+			modify: func(entry *ReportEntry10) {
+				entry.EntryDetails[0].TransactionDetails[1].Amount.Value = 250
+			},
+			path: "BkToCstmrDbtCdtNtfctn/Ntfctn[1]/Ntry[2]/Amt/text()",
+		},
+		{
+			name: "TransactionWithoutAmount",
+			modify: func(entry *ReportEntry10) {
+				entry.EntryDetails[0].TransactionDetails[0].Amount = nil
+			},
+			path: "BkToCstmrDbtCdtNtfctn/Ntfctn[1]/Ntry[2]/NtryDtls[1]/TxDtls[1]/Amt",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := loadSample[Camt05400108Document](t, "camt.054.001.08/debit_credit_notification.xml")
+			tt.modify(&doc.BankDebitCreditNotification.Notification[0].Entry[1])
+
+			postings, err := doc.Postings()
+			errs, ok := err.(ValidationErrors)
+			if !ok || len(errs) != 1 {
+				t.Fatalf("Expected one error, got %v", err)
+			}
+			if errs[0].Location() != tt.path {
+				t.Errorf("Expected error at %s, got %s", tt.path, errs[0].Location())
+			}
+			if len(postings) != 1 {
+				t.Errorf("Expected the balanced entry to be returned, got %d postings", len(postings))
+			}
+		})
+	}
+}
+
+func TestBankTransactionCodeValidate(t *testing.T) {
+	for _, entry := range loadSample[Camt05400108Document](t, "camt.054.001.08/debit_credit_notification.xml").BankDebitCreditNotification.Notification[0].Entry {
+		if err := entry.BankTransactionCode.Validate(); err != nil {
+			t.Errorf("Expected the sample codes to be valid, got %v", err)
+		}
+	}
+
+	code := BankTransactionCodeStructure4{Domain: &BankTransactionCodeStructure5{
+		Code:   "PMNT",
+		Family: BankTransactionCodeStructure6{Code: "RCDT", SubFamilyCode: "ESDD"},
+	}}
+	errs, ok := code.Validate().(ValidationErrors)
+	if !ok || len(errs) != 1 || errs[0].Location() != "Domn/Fmly/SubFmlyCd" {
+		t.Errorf("Expected a sub-family error at Domn/Fmly/SubFmlyCd, got %v", errs)
+	}
+
+	if err := (&BankTransactionCodeStructure4{}).Validate(); err == nil {
+		t.Error("Expected a code without Domn or Prtry to fail validation")
+	}
+}
@@ -0,0 +1,423 @@
+package iso20022
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// Limits are the size limits a payment scheme places on messages beyond the message
+// definitions. A zero limit is not enforced.
+type Limits struct {
+	// MaxTransactions is the number of transactions allowed in a message
+	MaxTransactions int
+	// MaxBytes is the size allowed for the encoded message
+	MaxBytes int
+	// MaxRemittanceLength is the number of characters of unstructured remittance
+	// information (RmtInf/Ustrd) allowed for a transaction, all lines together
+	MaxRemittanceLength int
+	// MaxAmount is the interbank settlement amount allowed for a transaction of the
+	// credit transfers (pacs.008 and pacs.009)
+	MaxAmount Decimal
+}
+
+// ProfileRule checks a document against a rule of a profile. It returns nil for the
+// documents the rule does not apply to, and errors whose paths start at the document
+// element otherwise.
+type ProfileRule func(doc interface{}) ValidationErrors
+
+// Profile is the set of rules a payment scheme or market infrastructure adds to the
+// ISO 20022 message definitions
+type Profile struct {
+	Name   string
+	Limits Limits
+	Rules  []ProfileRule
+	// Timeout is the time the scheme gives to confirm a payment after its
+	// acceptance, zero for schemes without one
+	Timeout time.Duration
+	// Settlement rejects the payments the scheme cannot settle on their settlement
+	// date, nil for schemes whose days and cut-off are not checked
+	Settlement *SettlementWindow
+}
+
+// Profiles with the limits of well-known schemes. Copy and adjust them to the
+// limits agreed with the scheme or clearing house.
+var (
+	FedwireProfile = Profile{Name: "Fedwire", Limits: Limits{MaxTransactions: 99}}
+	SEPAProfile    = Profile{Name: "SEPA", Limits: Limits{MaxTransactions: 100000, MaxRemittanceLength: 140}}
+)
+
+var (
+	profilesMu sync.RWMutex
+	profiles   = map[string]Profile{}
+)
+
+func init() {
+	for _, p := range []Profile{FedwireProfile, SEPAProfile, SEPACreditTransferProfile, SEPAInstantProfile, RTPProfile, CHIPSProfile, LynxProfile, CHAPSProfile, MEPSProfile, CBPRPlusProfile} {
+		RegisterProfile(p)
+	}
+}
+
+// RegisterProfile makes a profile selectable by name with LookupProfile, replacing
+// any profile registered under the same name
+func RegisterProfile(p Profile) {
+	profilesMu.Lock()
+	defer profilesMu.Unlock()
+	profiles[p.Name] = p
+}
+
+// LookupProfile returns the profile registered under name
+func LookupProfile(name string) (Profile, bool) {
+	profilesMu.RLock()
+	defer profilesMu.RUnlock()
+	p, ok := profiles[name]
+	return p, ok
+}
+
+// Check checks a document against the limits and the rules of the profile, and its
+// settlement dates against the settlement window of the profile. The rules and
+// the settlement window check a pacs.008 of another version as its pacs.008.001.08
+// upgrade, so their errors name the pacs.008.001.08 elements.
+func (p Profile) Check(doc interface{}) error {
+	var errs ValidationErrors
+	if err := p.CheckLimits(doc); err != nil {
+		verrs, ok := err.(ValidationErrors)
+		if !ok {
+			return err
+		}
+		errs = append(errs, verrs...)
+	}
+	if d, ok := doc.(Pacs008); ok {
+		upgraded, err := asPacs00800108(d)
+		if err != nil {
+			return err
+		}
+		doc = upgraded
+	}
+	for _, rule := range p.Rules {
+		errs = append(errs, rule(doc)...)
+	}
+	if p.Settlement != nil {
+		errs = append(errs, p.Settlement.check(doc, p.Name)...)
+	}
+	if errs.HasErrors() {
+		return errs
+	}
+	return nil
+}
+
+// CheckLimits checks a document against the limits of the profile before it is
+// submitted. Transactions, remittance information and amounts are checked in the
+// payment messages (pacs.002, pacs.004, pacs.008, pacs.009 and pain.013); the size
+// is checked for any document by encoding it with Marshal. Errors name the profile
+// and their paths start at the document element.
+func (p Profile) CheckLimits(doc interface{}) error {
+	var errs ValidationErrors
+	if p.Limits.MaxTransactions > 0 || p.Limits.MaxRemittanceLength > 0 || p.Limits.MaxAmount > 0 {
+		errs = append(errs, p.checkTransactions(doc)...)
+	}
+	if p.Limits.MaxBytes > 0 {
+		data, err := Marshal(doc)
+		if err != nil {
+			return err
+		}
+		if err := p.CheckSize(data); err != nil {
+			errs = append(errs, err.(ValidationErrors)...)
+		}
+	}
+	if errs.HasErrors() {
+		return errs
+	}
+	return nil
+}
+
+// CheckSize checks the size of an encoded message against the MaxBytes limit
+func (p Profile) CheckSize(data []byte) error {
+	if p.Limits.MaxBytes > 0 && len(data) > p.Limits.MaxBytes {
+		return ValidationErrors{{Field: "Document",
+			Message: fmt.Sprintf("is %d bytes, more than the %d allowed by %s", len(data), p.Limits.MaxBytes, p.Name)}}
+	}
+	return nil
+}
+
+// checkTransactions counts the transactions of the payment messages and checks
+// their remittance information and amounts
+func (p Profile) checkTransactions(doc interface{}) ValidationErrors {
+	var root, element string
+	var count int
+	var remittances [][]string
+	var amounts []Decimal
+	switch d := doc.(type) {
+	case Pacs008:
+		root, element = "FIToFICstmrCdtTrf", "CdtTrfTxInf"
+		txs := d.Transactions()
+		count = len(txs)
+		for _, tx := range txs {
+			remittances = append(remittances, tx.Remittance)
+			amounts = append(amounts, tx.InterbankSettlementAmount.Value)
+		}
+	case *Pacs00900108Document:
+		root, element = "FICdtTrf", "CdtTrfTxInf"
+		count = len(d.FICreditTransfer.CreditTransferTransactionInfo)
+		for _, tx := range d.FICreditTransfer.CreditTransferTransactionInfo {
+			amounts = append(amounts, tx.InterbankSettlementAmount.Value)
+		}
+	case *Pacs00200110Document:
+		root, element = "FIToFIPmtStsRpt", "TxInfAndSts"
+		count = len(d.FIPaymentStatusReport.TransactionInfoAndStatus)
+	case *Pacs00400110Document:
+		root, element = "PmtRtr", "TxInf"
+		count = len(d.PaymentReturn.TransactionInfo)
+	case *Pain01300107Document:
+		root = "CdtrPmtActvtnReq"
+		var errs ValidationErrors
+		for i, pmt := range d.CreditorPaymentActivationRequest.PaymentInfo {
+			count += len(pmt.CreditTransferTransaction)
+			for j, tx := range pmt.CreditTransferTransaction {
+				if tx.RemittanceInfo != nil {
+					errs = append(errs, p.checkRemittance(fmt.Sprintf("PmtInf[%d]/CdtTrfTx[%d]", i+1, j+1), tx.RemittanceInfo.Unstructured)...)
+				}
+			}
+		}
+		errs = append(p.checkTransactionCount(count), errs...)
+		return errs.within(root)
+	default:
+		return nil
+	}
+
+	errs := p.checkTransactionCount(count)
+	for i, ustrd := range remittances {
+		errs = append(errs, p.checkRemittance(fmt.Sprintf("%s[%d]", element, i+1), ustrd)...)
+	}
+	for i, amount := range amounts {
+		errs = append(errs, p.checkAmount(fmt.Sprintf("%s[%d]", element, i+1), amount)...)
+	}
+	return errs.within(root)
+}
+
+// checkTransactionCount checks the number of transactions of a message
+func (p Profile) checkTransactionCount(count int) ValidationErrors {
+	if p.Limits.MaxTransactions > 0 && count > p.Limits.MaxTransactions {
+		return ValidationErrors{{Field: "GrpHdr.NbOfTxs",
+			Message: fmt.Sprintf("the message has %d transactions, more than the %d allowed by %s", count, p.Limits.MaxTransactions, p.Name)}}
+	}
+	return nil
+}
+
+// checkRemittance checks the length of the unstructured remittance information of
+// the transaction at path
+func (p Profile) checkRemittance(path string, ustrd []string) ValidationErrors {
+	if p.Limits.MaxRemittanceLength <= 0 {
+		return nil
+	}
+	if n := utf8.RuneCountInString(strings.Join(ustrd, "")); n > p.Limits.MaxRemittanceLength {
+		return ValidationErrors{{Field: "Ustrd", Path: path + "/RmtInf/Ustrd",
+			Message: fmt.Sprintf("has %d characters, more than the %d allowed by %s", n, p.Limits.MaxRemittanceLength, p.Name)}}
+	}
+	return nil
+}
+
+// checkAmount checks the interbank settlement amount of the transaction at path
+func (p Profile) checkAmount(path string, amount Decimal) ValidationErrors {
+	if p.Limits.MaxAmount > 0 && DecimalRat(amount).Cmp(DecimalRat(p.Limits.MaxAmount)) > 0 {
+		return ValidationErrors{{Field: "IntrBkSttlmAmt", Path: path + "/IntrBkSttlmAmt", Rule: RuleAmountLimit,
+			Message: fmt.Sprintf("is %s, more than the %s allowed by %s", formatRat(DecimalRat(amount)), formatRat(DecimalRat(p.Limits.MaxAmount)), p.Name)}}
+	}
+	return nil
+}
+
+// Deadline returns the time by which a payment accepted at accepted must be
+// confirmed, or the zero time when the profile has no timeout
+func (p Profile) Deadline(accepted time.Time) time.Time {
+	if p.Timeout <= 0 {
+		return time.Time{}
+	}
+	return accepted.Add(p.Timeout)
+}
+
+// TimedOut reports whether a credit transfer can no longer be confirmed at now,
+// the timeout of the profile having passed since its acceptance date and time. It
+// fails when the transaction has no acceptance date and time.
+func (p Profile) TimedOut(tx *CreditTransferTransaction39, now time.Time) (bool, error) {
+	if p.Timeout <= 0 {
+		return false, nil
+	}
+	if tx.AcceptanceDateTime == nil {
+		return false, fmt.Errorf("transaction %s has no acceptance date and time", tx.PaymentID.EndToEndID)
+	}
+	return now.After(p.Deadline(tx.AcceptanceDateTime.Time)), nil
+}
+
+// FieldRule is a rule on the elements at a path of a message, so that profiles can
+// be written as data: the elements a scheme makes mandatory, the subset of codes it
+// allows and the lengths it restricts. A rule applies to the messages whose
+// document element contains the first element of its path.
+type FieldRule struct {
+	// Path is the path of the elements from the message element, such as
+	// FIToFICstmrCdtTrf/CdtTrfTxInf/ChrgBr. A last step @Ccy names an attribute.
+	// Every repetition of the repeated elements on the path is checked.
+	Path string
+	// Required makes the element mandatory, and with it any optional element on the
+	// path to it
+	Required bool
+	// Codes are the values allowed, none for any value
+	Codes []string
+	// MaxLength is the number of characters allowed, zero for any number
+	MaxLength int
+}
+
+// FieldRules returns a profile rule checking documents against field rules. Errors
+// name the scheme.
+func FieldRules(scheme string, rules ...FieldRule) ProfileRule {
+	return func(doc interface{}) ValidationErrors {
+		v := reflect.ValueOf(doc)
+		for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+			if v.IsNil() {
+				return nil
+			}
+			v = v.Elem()
+		}
+		if v.Kind() != reflect.Struct {
+			return nil
+		}
+		var errs ValidationErrors
+		for _, rule := range rules {
+			errs = append(errs, rule.check(scheme, v)...)
+		}
+		return errs
+	}
+}
+
+// check checks the elements of the rule in a document
+func (r FieldRule) check(scheme string, doc reflect.Value) ValidationErrors {
+	steps := strings.Split(r.Path, "/")
+	root, ok := childElement(doc, steps[0])
+	if !ok {
+		return nil
+	}
+	var errs ValidationErrors
+	visitElements(root, steps[0], steps[1:], func(path string, text string, present bool) {
+		field := path[strings.LastIndexByte(path, '/')+1:]
+		switch {
+		case !present:
+			if r.Required {
+				errs = append(errs, ValidationError{Field: field, Path: path, Message: "is required in " + scheme})
+			}
+		case len(r.Codes) > 0 && !containsString(r.Codes, text):
+			errs = append(errs, ValidationError{Field: field, Path: path,
+				Message: fmt.Sprintf("%q is not allowed in %s, use one of %s", text, scheme, strings.Join(r.Codes, ", "))})
+		case r.MaxLength > 0 && utf8.RuneCountInString(text) > r.MaxLength:
+			errs = append(errs, ValidationError{Field: field, Path: path,
+				Message: fmt.Sprintf("has %d characters, more than the %d allowed by %s", utf8.RuneCountInString(text), r.MaxLength, scheme)})
+		}
+	})
+	return errs
+}
+
+// visitElements calls visit with the text of every element at the steps below v,
+// whose path is path, or with present false for the paths that end early
+func visitElements(v reflect.Value, path string, steps []string, visit func(path, text string, present bool)) {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			visit(strings.Join(append([]string{path}, steps...), "/"), "", false)
+			return
+		}
+		v = v.Elem()
+	}
+	if v.Kind() == reflect.Slice && v.Type().Elem().Kind() != reflect.Uint8 {
+		if v.Len() == 0 {
+			visit(strings.Join(append([]string{path}, steps...), "/"), "", false)
+		}
+		for i := 0; i < v.Len(); i++ {
+			visitElements(v.Index(i), fmt.Sprintf("%s[%d]", path, i+1), steps, visit)
+		}
+		return
+	}
+	if len(steps) == 0 {
+		text, present := elementText(v)
+		visit(path, text, present)
+		return
+	}
+	child, ok := childElement(v, steps[0])
+	if !ok || v.IsZero() {
+		visit(strings.Join(append([]string{path}, steps...), "/"), "", false)
+		return
+	}
+	visitElements(child, path+"/"+steps[0], steps[1:], visit)
+}
+
+// childElement returns the field of a struct holding the child element, or the
+// attribute for names starting with @
+func childElement(v reflect.Value, name string) (reflect.Value, bool) {
+	if v.Kind() != reflect.Struct {
+		return reflect.Value{}, false
+	}
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		if strings.HasPrefix(name, "@") {
+			tag := strings.Split(field.Tag.Get("xml"), ",")
+			if len(tag) > 1 && tag[1] == "attr" && tag[0] == name[1:] {
+				return v.Field(i), true
+			}
+			continue
+		}
+		element, ok := elementName(field)
+		if !ok {
+			continue
+		}
+		if element == "" {
+			if child, ok := childElement(v.Field(i), name); ok {
+				return child, true
+			}
+			continue
+		}
+		if element == name {
+			return v.Field(i), true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// elementText returns the text of an element and whether it is present. Elements
+// with children are present when not empty and have no text.
+func elementText(v reflect.Value) (string, bool) {
+	if s, ok := v.Interface().(fmt.Stringer); ok {
+		return s.String(), !v.IsZero()
+	}
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), v.String() != ""
+	case reflect.Float64:
+		return formatRat(DecimalRat(Decimal(v.Float()))), true
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), true
+	case reflect.Int, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), true
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			if strings.HasSuffix(t.Field(i).Tag.Get("xml"), ",chardata") {
+				text, _ := elementText(v.Field(i))
+				return text, !v.IsZero()
+			}
+		}
+	}
+	return "", !v.IsZero()
+}
+
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,129 @@
+package iso20022
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestProfileCheckLimits(t *testing.T) {
+	doc := loadPacs008Sample(t)
+	if err := SEPAProfile.CheckLimits(doc); err != nil {
+		t.Fatalf("Expected the sample to fit the SEPA limits, got %v", err)
+	}
+
+	txs := &doc.FICustomerCreditTransfer.CreditTransferTransactionInfo
+	for len(*txs) <= 3 {
+		*txs = append(*txs, (*txs)[0])
+		(*txs)[len(*txs)-1].RemittanceInfo = nil
+	}
+	(*txs)[0].RemittanceInfo = &RemittanceInfo{Unstructured: []string{strings.Repeat("x", 100), strings.Repeat("é", 41)}}
+
+	profile := Profile{Name: "Test", Limits: Limits{MaxTransactions: 3, MaxRemittanceLength: 140, MaxBytes: 512}}
+	err := profile.CheckLimits(doc)
+	errs, ok := err.(ValidationErrors)
+	if !ok {
+		t.Fatalf("Expected ValidationErrors, got %v", err)
+	}
+	want := map[string]bool{
+		"FIToFICstmrCdtTrf/GrpHdr/NbOfTxs":              false,
+		"FIToFICstmrCdtTrf/CdtTrfTxInf[1]/RmtInf/Ustrd": false,
+		"Document": false,
+	}
+	for _, e := range errs {
+		if _, ok := want[e.Location()]; !ok {
+			t.Errorf("Unexpected error %v", e)
+		}
+		want[e.Location()] = true
+		if !strings.Contains(e.Message, "allowed by Test") {
+			t.Errorf("Expected the profile to be named in %q", e.Message)
+		}
+	}
+	for location, found := range want {
+		if !found {
+			t.Errorf("Expected an error at %s, got %v", location, errs)
+		}
+	}
+}
+
+func TestProfileCheckVersions(t *testing.T) {
+	profile := RTPProfile
+	profile.Limits = Limits{MaxAmount: 100}
+	for _, version := range Pacs008Versions {
+		err := profile.Check(loadPacs008Version(t, version))
+		errs, ok := err.(ValidationErrors)
+		if !ok {
+			t.Fatalf("%s: expected ValidationErrors, got %v", version, err)
+		}
+		want := map[string]bool{
+			"FIToFICstmrCdtTrf/CdtTrfTxInf[1]/IntrBkSttlmAmt": false,
+			"FIToFICstmrCdtTrf/GrpHdr/SttlmInf/SttlmMtd":      false,
+		}
+		for _, e := range errs {
+			if _, ok := want[e.Location()]; ok {
+				want[e.Location()] = true
+			}
+		}
+		for location, found := range want {
+			if !found {
+				t.Errorf("%s: expected an error at %s, got %v", version, location, errs)
+			}
+		}
+	}
+}
+
+func TestProfileCheckSize(t *testing.T) {
+	profile := Profile{Name: "Test", Limits: Limits{MaxBytes: 4}}
+	if err := profile.CheckSize([]byte("1234")); err != nil {
+		t.Errorf("Expected no error at the limit, got %v", err)
+	}
+	if err := profile.CheckSize([]byte("12345")); err == nil {
+		t.Error("Expected an error above the limit")
+	}
+}
+
+func TestProfileSettlementWindow(t *testing.T) {
+	doc := loadPacs008Sample(t) // settles in USD on Friday 15 March 2024
+	newYork := time.FixedZone("EDT", -4*3600)
+	at := func(hour, minute int) func() time.Time {
+		return func() time.Time { return time.Date(2024, time.March, 15, hour, minute, 0, 0, newYork) }
+	}
+	profile := FedwireProfile
+	profile.Settlement = &SettlementWindow{Calendar: FedCalendar, CutOff: 18 * time.Hour, Location: newYork, Tolerance: 5 * time.Minute, Now: at(17, 0)}
+	if err := profile.Check(doc); err != nil {
+		t.Errorf("Expected a payment before the cut-off to be accepted, got %v", err)
+	}
+	profile.Settlement.Now = at(18, 4)
+	if err := profile.Check(doc); err != nil {
+		t.Errorf("Expected a payment within the tolerance to be accepted, got %v", err)
+	}
+	profile.Settlement.Now = at(18, 6)
+	err := profile.Check(doc)
+	if errs, ok := err.(ValidationErrors); !ok || len(errs) != 1 || errs[0].Location() != "FIToFICstmrCdtTrf/CdtTrfTxInf[1]/IntrBkSttlmDt" ||
+		!strings.Contains(errs[0].Message, "closed at 18:00") {
+		t.Errorf("Expected a same-day error, got %v", err)
+	}
+	// The cut-off only applies to same-day settlement
+	profile.Settlement.Now = func() time.Time { return time.Date(2024, time.March, 14, 20, 0, 0, 0, newYork) }
+	if err := profile.Check(doc); err != nil {
+		t.Errorf("Expected a payment for the next day to be accepted, got %v", err)
+	}
+
+	// Non-business days, from the calendar registered for the currency
+	profile.Settlement = &SettlementWindow{Now: at(9, 0)}
+	saturday := NewISODate(2024, time.March, 16)
+	doc.FICustomerCreditTransfer.CreditTransferTransactionInfo[0].InterbankSettlementDate = &saturday
+	err = profile.Check(doc)
+	if errs, ok := err.(ValidationErrors); !ok || len(errs) != 1 || errs[0].Message != "2024-03-16 is not a business day of Fedwire" {
+		t.Errorf("Expected a business day error, got %v", err)
+	}
+
+	pain := loadSample[Pain00100109Document](t, "pain.001.001.09/credit_transfer_initiation.xml")
+	good := NewISODate(2024, time.March, 29) // Good Friday
+	pain.CustomerCreditTransferInitiation.PaymentInfo[1].RequestedExecutionDate = DateAndDateTime2{Date: &good}
+	profile.Settlement = &SettlementWindow{Calendar: TARGET2Calendar, Now: at(9, 0)}
+	err = profile.Check(pain)
+	if errs, ok := err.(ValidationErrors); !ok || len(errs) != 1 || errs[0].Location() != "CstmrCdtTrfInitn/PmtInf[2]/ReqdExctnDt/Dt" {
+		t.Errorf("Expected an execution date error, got %v", err)
+	}
+}
@@ -0,0 +1,408 @@
+// Package protobuf exchanges the messages of package iso20022 as protocol buffers,
+// for services that pass parsed messages to each other without encoding them to XML
+// and parsing them again.
+//
+// Schema writes the proto3 definitions of documents, such as iso20022.proto for
+// pacs.008, pacs.002 and camt.054, from which services in other languages generate
+// their code. Marshal and Unmarshal convert the Go structs of package iso20022 to
+// and from the protocol buffer encoding of those definitions.
+//
+// A struct is a message named after its Go type, and a field is numbered after its
+// position in the struct, from 1, so that the numbers stay the same as long as the
+// fields of the struct are not reordered. Decimals, dates, times and codes are
+// strings written as in XML, pointers to values are optional fields and slices are
+// repeated fields.
+package protobuf
+
+//go:generate sh -c "go run ../cmd/iso20022 proto > iso20022.proto"
+
+import (
+	"encoding"
+	"encoding/binary"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"sync"
+	"unicode/utf8"
+)
+
+// CoreMessageTypes are the messages whose definitions are in iso20022.proto
+var CoreMessageTypes = []string{"pacs.008.001.08", "pacs.002.001.10", "camt.054.001.08"}
+
+// ErrInvalid is returned by Unmarshal for data that is not a valid encoding of the
+// message
+var ErrInvalid = errors.New("protobuf: invalid encoding")
+
+// kind is how a field value is encoded
+type kind int
+
+const (
+	kindString  kind = iota // string
+	kindText                // string written with MarshalText
+	kindDecimal             // string written as a decimal number
+	kindBool                // varint
+	kindInt                 // varint
+	kindUint                // varint
+	kindBytes               // bytes
+	kindMessage             // embedded message
+)
+
+// Wire types
+const (
+	wireVarint  = 0
+	wireFixed64 = 1
+	wireBytes   = 2
+	wireFixed32 = 5
+)
+
+// fieldInfo describes a field of a message
+type fieldInfo struct {
+	index    int
+	number   int
+	name     string // proto field name
+	element  string // XML element, or @attribute, or "" for character data
+	kind     kind
+	typ      reflect.Type // type of a single value, without pointer
+	optional bool         // a pointer to a value
+	repeated bool         // a slice
+	pointers bool         // a slice of pointers
+}
+
+// packed reports whether the repeated values of the field are written packed
+func (f *fieldInfo) packed() bool {
+	return f.kind == kindBool || f.kind == kindInt || f.kind == kindUint
+}
+
+// messageInfo describes the message of a struct type
+type messageInfo struct {
+	typ      reflect.Type
+	fields   []*fieldInfo
+	byNumber map[int]*fieldInfo
+}
+
+var (
+	textMarshaler   = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	textUnmarshaler = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	xmlName         = reflect.TypeOf(xml.Name{})
+)
+
+// messages caches the messageInfo of struct types
+var messages sync.Map
+
+// messageOf returns the description of the message of a struct type
+func messageOf(t reflect.Type) (*messageInfo, error) {
+	if m, ok := messages.Load(t); ok {
+		return m.(*messageInfo), nil
+	}
+	m := &messageInfo{typ: t, byNumber: make(map[int]*fieldInfo)}
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() || sf.Type == xmlName {
+			continue
+		}
+		f := &fieldInfo{index: i, number: i + 1, name: fieldName(sf.Name), element: elementName(sf)}
+		ft := sf.Type
+		switch {
+		case ft.Kind() == reflect.Ptr:
+			f.optional, ft = true, ft.Elem()
+		case ft.Kind() == reflect.Slice && ft.Elem().Kind() != reflect.Uint8:
+			f.repeated, ft = true, ft.Elem()
+			if ft.Kind() == reflect.Ptr {
+				f.pointers, ft = true, ft.Elem()
+			}
+		}
+		k, err := kindOf(ft)
+		if err != nil {
+			return nil, fmt.Errorf("protobuf: field %s of %s: %w", sf.Name, t, err)
+		}
+		f.kind, f.typ = k, ft
+		if k == kindMessage && f.optional {
+			f.optional = false // messages have presence of their own
+		}
+		m.fields = append(m.fields, f)
+		m.byNumber[f.number] = f
+	}
+	actual, _ := messages.LoadOrStore(t, m)
+	return actual.(*messageInfo), nil
+}
+
+// kindOf returns how values of a type are encoded
+func kindOf(t reflect.Type) (kind, error) {
+	if t.Implements(textMarshaler) && reflect.PtrTo(t).Implements(textUnmarshaler) {
+		return kindText, nil
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return kindString, nil
+	case reflect.Float32, reflect.Float64:
+		return kindDecimal, nil
+	case reflect.Bool:
+		return kindBool, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return kindInt, nil
+	case reflect.Uint, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return kindUint, nil
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return kindBytes, nil
+		}
+	case reflect.Struct:
+		return kindMessage, nil
+	}
+	return 0, fmt.Errorf("type %s is not supported", t)
+}
+
+// Marshal returns the protocol buffer encoding of v, a pointer to a struct of
+// package iso20022 such as a document
+func Marshal(v interface{}) ([]byte, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return nil, fmt.Errorf("protobuf: Marshal of %T, not a pointer to a struct", v)
+	}
+	return appendMessage(nil, rv.Elem())
+}
+
+// appendMessage appends the fields of a struct
+func appendMessage(b []byte, v reflect.Value) ([]byte, error) {
+	m, err := messageOf(v.Type())
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range m.fields {
+		fv := v.Field(f.index)
+		switch {
+		case f.repeated:
+			if fv.Len() == 0 {
+				continue
+			}
+			if f.packed() {
+				var packed []byte
+				for i := 0; i < fv.Len(); i++ {
+					packed = binary.AppendUvarint(packed, varint(f, elem(f, fv.Index(i))))
+				}
+				b = appendTag(b, f.number, wireBytes)
+				b = appendBytes(b, packed)
+				continue
+			}
+			for i := 0; i < fv.Len(); i++ {
+				if b, err = appendValue(b, f, elem(f, fv.Index(i))); err != nil {
+					return nil, err
+				}
+			}
+		case fv.Kind() == reflect.Ptr:
+			if fv.IsNil() {
+				continue
+			}
+			if b, err = appendValue(b, f, fv.Elem()); err != nil {
+				return nil, err
+			}
+		default:
+			if fv.IsZero() {
+				continue
+			}
+			if b, err = appendValue(b, f, fv); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return b, nil
+}
+
+// elem returns an element of a repeated field, a zero value for a nil pointer
+func elem(f *fieldInfo, v reflect.Value) reflect.Value {
+	if f.pointers {
+		if v.IsNil() {
+			return reflect.Zero(f.typ)
+		}
+		return v.Elem()
+	}
+	return v
+}
+
+// varint returns the varint of a bool or integer value
+func varint(f *fieldInfo, v reflect.Value) uint64 {
+	switch f.kind {
+	case kindBool:
+		if v.Bool() {
+			return 1
+		}
+		return 0
+	case kindInt:
+		return uint64(v.Int())
+	}
+	return v.Uint()
+}
+
+// appendValue appends one value of a field with its tag
+func appendValue(b []byte, f *fieldInfo, v reflect.Value) ([]byte, error) {
+	switch f.kind {
+	case kindBool, kindInt, kindUint:
+		b = appendTag(b, f.number, wireVarint)
+		return binary.AppendUvarint(b, varint(f, v)), nil
+	case kindMessage:
+		sub, err := appendMessage(nil, v)
+		if err != nil {
+			return nil, err
+		}
+		b = appendTag(b, f.number, wireBytes)
+		return appendBytes(b, sub), nil
+	}
+	var data []byte
+	switch f.kind {
+	case kindString:
+		data = []byte(v.String())
+	case kindText:
+		text, err := v.Interface().(encoding.TextMarshaler).MarshalText()
+		if err != nil {
+			return nil, err
+		}
+		data = text
+	case kindDecimal:
+		data = []byte(strconv.FormatFloat(v.Float(), 'f', -1, 64))
+	case kindBytes:
+		data = v.Bytes()
+	}
+	b = appendTag(b, f.number, wireBytes)
+	return appendBytes(b, data), nil
+}
+
+func appendTag(b []byte, number, wire int) []byte {
+	return binary.AppendUvarint(b, uint64(number)<<3|uint64(wire))
+}
+
+func appendBytes(b, data []byte) []byte {
+	b = binary.AppendUvarint(b, uint64(len(data)))
+	return append(b, data...)
+}
+
+// Unmarshal decodes the protocol buffer encoding of a message into v, a pointer to
+// a struct of package iso20022. Fields v does not have are skipped.
+func Unmarshal(data []byte, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("protobuf: Unmarshal into %T, not a pointer to a struct", v)
+	}
+	return decodeMessage(data, rv.Elem())
+}
+
+// decodeMessage decodes the fields of a message into a struct
+func decodeMessage(data []byte, v reflect.Value) error {
+	m, err := messageOf(v.Type())
+	if err != nil {
+		return err
+	}
+	for len(data) > 0 {
+		tag, n := binary.Uvarint(data)
+		if n <= 0 || tag>>3 == 0 || tag>>3 > math.MaxInt32 {
+			return fmt.Errorf("%w: bad tag in %s", ErrInvalid, v.Type().Name())
+		}
+		data = data[n:]
+		number, wire := int(tag>>3), int(tag&7)
+		var value []byte
+		var u uint64
+		switch wire {
+		case wireVarint:
+			if u, n = binary.Uvarint(data); n <= 0 {
+				return fmt.Errorf("%w: bad varint in field %d of %s", ErrInvalid, number, v.Type().Name())
+			}
+		case wireFixed64:
+			n = 8
+		case wireFixed32:
+			n = 4
+		case wireBytes:
+			size, k := binary.Uvarint(data)
+			if k <= 0 || size > uint64(len(data)-k) {
+				return fmt.Errorf("%w: bad length in field %d of %s", ErrInvalid, number, v.Type().Name())
+			}
+			value, n = data[k:k+int(size)], k+int(size)
+		default:
+			return fmt.Errorf("%w: wire type %d in field %d of %s", ErrInvalid, wire, number, v.Type().Name())
+		}
+		if n > len(data) {
+			return fmt.Errorf("%w: truncated field %d of %s", ErrInvalid, number, v.Type().Name())
+		}
+		data = data[n:]
+
+		f, ok := m.byNumber[number]
+		if !ok {
+			continue
+		}
+		if err := decodeField(f, v.Field(f.index), wire, u, value); err != nil {
+			return fmt.Errorf("%s.%s: %w", v.Type().Name(), f.name, err)
+		}
+	}
+	return nil
+}
+
+// decodeField decodes a value of a field read with the given wire type, whose
+// varint is u or whose bytes are value
+func decodeField(f *fieldInfo, fv reflect.Value, wire int, u uint64, value []byte) error {
+	if f.repeated && f.packed() && wire == wireBytes {
+		for len(value) > 0 {
+			u, n := binary.Uvarint(value)
+			if n <= 0 {
+				return fmt.Errorf("%w: bad packed varint", ErrInvalid)
+			}
+			value = value[n:]
+			if err := decodeField(f, fv, wireVarint, u, nil); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	want := wireBytes
+	if f.packed() {
+		want = wireVarint
+	}
+	if wire != want {
+		return fmt.Errorf("%w: wire type %d", ErrInvalid, wire)
+	}
+
+	var target reflect.Value
+	switch {
+	case f.repeated:
+		fv.Set(reflect.Append(fv, reflect.Zero(fv.Type().Elem())))
+		target = fv.Index(fv.Len() - 1)
+		if f.pointers {
+			target.Set(reflect.New(f.typ))
+			target = target.Elem()
+		}
+	case fv.Kind() == reflect.Ptr:
+		if fv.IsNil() {
+			fv.Set(reflect.New(f.typ))
+		}
+		target = fv.Elem()
+	default:
+		target = fv
+	}
+
+	switch f.kind {
+	case kindBool:
+		target.SetBool(u != 0)
+	case kindInt:
+		target.SetInt(int64(u))
+	case kindUint:
+		target.SetUint(u)
+	case kindMessage:
+		return decodeMessage(value, target)
+	case kindString:
+		if !utf8.Valid(value) {
+			return fmt.Errorf("%w: string is not UTF-8", ErrInvalid)
+		}
+		target.SetString(string(value))
+	case kindText:
+		return target.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText(value)
+	case kindDecimal:
+		d, err := strconv.ParseFloat(string(value), 64)
+		if err != nil {
+			return fmt.Errorf("%w: decimal %q", ErrInvalid, value)
+		}
+		target.SetFloat(d)
+	case kindBytes:
+		target.SetBytes(append([]byte(nil), value...))
+	}
+	return nil
+}
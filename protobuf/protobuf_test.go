@@ -0,0 +1,111 @@
+package protobuf
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ckbaum/iso20022-go"
+)
+
+func TestSchemaUpToDate(t *testing.T) {
+	var docs []interface{}
+	for _, msgType := range CoreMessageTypes {
+		doc, _ := iso20022.NewDocument(msgType)
+		docs = append(docs, doc)
+	}
+	schema, err := Schema("", docs...)
+	if err != nil {
+		t.Fatalf("Failed to write the schema: %v", err)
+	}
+	want, err := os.ReadFile("iso20022.proto")
+	if err != nil {
+		t.Fatalf("Failed to read iso20022.proto: %v", err)
+	}
+	if !bytes.Equal(schema, want) {
+		t.Error("iso20022.proto is out of date, run go generate")
+	}
+	for _, line := range []string{
+		"message Pacs00800108Document {\n  FIToFICustomerCreditTransferV08 fi_customer_credit_transfer = 2; // FIToFICstmrCdtTrf\n}",
+		"  repeated CreditTransferTransaction39 credit_transfer_transaction_info = 2; // CdtTrfTxInf\n",
+		"  optional string uetr = 4; // UETR\n",
+		"message ActiveOrHistoricCurrencyAndAmount {\n  string value = 1;\n  string currency = 2; // @Ccy\n}",
+	} {
+		if !bytes.Contains(schema, []byte(line)) {
+			t.Errorf("Expected the schema to have %q", line)
+		}
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	for _, c := range []struct{ msgType, file string }{
+		{"pacs.008.001.08", "customer_credit_transfer.xml"},
+		{"pacs.002.001.10", "rejected_transaction.xml"},
+		{"camt.054.001.08", "debit_credit_notification.xml"},
+	} {
+		data, err := os.ReadFile(filepath.Join("..", "testdata", "roundtrip", c.msgType, c.file))
+		if err != nil {
+			t.Fatalf("Failed to read fixture: %v", err)
+		}
+		_, doc, err := iso20022.DecodeDocument(data)
+		if err != nil {
+			t.Fatalf("%s: failed to decode: %v", c.msgType, err)
+		}
+		encoded, err := Marshal(doc)
+		if err != nil {
+			t.Fatalf("%s: failed to marshal: %v", c.msgType, err)
+		}
+		decoded, _ := iso20022.NewDocument(c.msgType)
+		if err := Unmarshal(encoded, decoded); err != nil {
+			t.Fatalf("%s: failed to unmarshal: %v", c.msgType, err)
+		}
+		want, _ := iso20022.Marshal(doc)
+		got, err := iso20022.Marshal(decoded)
+		if err != nil {
+			t.Fatalf("%s: failed to encode the decoded document: %v", c.msgType, err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("%s: expected the document back, got\n%s\nwant\n%s", c.msgType, got, want)
+		}
+		if len(encoded) >= len(data) {
+			t.Errorf("%s: expected the encoding to be smaller than the XML, got %d bytes for %d", c.msgType, len(encoded), len(data))
+		}
+	}
+}
+
+func TestUnmarshalInvalid(t *testing.T) {
+	doc := new(iso20022.Pacs00800108Document)
+	for name, data := range map[string][]byte{
+		"truncated length": {0x12, 0x05, 0x0a},
+		"bad tag":          {0x00},
+		"group wire type":  {0x13},
+		"bad wire type":    {0x10, 0x01},
+	} {
+		if err := Unmarshal(data, doc); !errors.Is(err, ErrInvalid) {
+			t.Errorf("%s: expected ErrInvalid, got %v", name, err)
+		}
+	}
+	// Unknown fields are skipped
+	if err := Unmarshal([]byte{0xf8, 0x01, 0x01, 0x12, 0x00}, doc); err != nil {
+		t.Errorf("Expected an unknown field to be skipped, got %v", err)
+	}
+	if err := Unmarshal(nil, iso20022.Pacs00800108Document{}); err == nil {
+		t.Error("Expected a struct that is not a pointer to be refused")
+	}
+}
+
+func TestFieldName(t *testing.T) {
+	for name, want := range map[string]string{
+		"EndToEndID":                "end_to_end_id",
+		"FICustomerCreditTransfer":  "fi_customer_credit_transfer",
+		"UETR":                      "uetr",
+		"IntermediaryAgent1Account": "intermediary_agent1_account",
+		"IBAN":                      "iban",
+	} {
+		if got := fieldName(name); got != want {
+			t.Errorf("fieldName(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
@@ -0,0 +1,153 @@
+package protobuf
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// DefaultPackage is the proto package of Schema when it is given none
+const DefaultPackage = "iso20022.v1"
+
+// Schema returns the proto3 definitions of the messages of docs, pointers to
+// structs of package iso20022 such as documents, and of every struct they contain.
+// The messages are sorted by name, the documents first.
+func Schema(pkg string, docs ...interface{}) ([]byte, error) {
+	if pkg == "" {
+		pkg = DefaultPackage
+	}
+	var roots []reflect.Type
+	seen := make(map[reflect.Type]bool)
+	var others []reflect.Type
+	var visit func(t reflect.Type, root bool) error
+	visit = func(t reflect.Type, root bool) error {
+		if seen[t] {
+			return nil
+		}
+		seen[t] = true
+		if root {
+			roots = append(roots, t)
+		} else {
+			others = append(others, t)
+		}
+		m, err := messageOf(t)
+		if err != nil {
+			return err
+		}
+		for _, f := range m.fields {
+			if f.kind == kindMessage {
+				if err := visit(f.typ, false); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+	for _, doc := range docs {
+		t := reflect.TypeOf(doc)
+		if t == nil || t.Kind() != reflect.Ptr || t.Elem().Kind() != reflect.Struct {
+			return nil, fmt.Errorf("protobuf: Schema of %T, not a pointer to a struct", doc)
+		}
+		if err := visit(t.Elem(), true); err != nil {
+			return nil, err
+		}
+	}
+	sort.Slice(others, func(i, j int) bool { return others[i].Name() < others[j].Name() })
+
+	var b bytes.Buffer
+	b.WriteString("// Code generated by iso20022 proto. DO NOT EDIT.\n\n")
+	b.WriteString("syntax = \"proto3\";\n\n")
+	fmt.Fprintf(&b, "package %s;\n", pkg)
+	names := make(map[string]reflect.Type)
+	for _, t := range append(roots, others...) {
+		if other, ok := names[t.Name()]; ok {
+			return nil, fmt.Errorf("protobuf: types %s and %s have the same name", other, t)
+		}
+		names[t.Name()] = t
+		m, _ := messageOf(t)
+		writeMessage(&b, m)
+	}
+	return b.Bytes(), nil
+}
+
+// writeMessage writes the definition of a message
+func writeMessage(b *bytes.Buffer, m *messageInfo) {
+	fmt.Fprintf(b, "\nmessage %s {\n", m.typ.Name())
+	for _, f := range m.fields {
+		label := ""
+		switch {
+		case f.repeated:
+			label = "repeated "
+		case f.optional:
+			label = "optional "
+		}
+		fmt.Fprintf(b, "  %s%s %s = %d;", label, protoType(f), f.name, f.number)
+		if f.element != "" {
+			fmt.Fprintf(b, " // %s", f.element)
+		}
+		b.WriteByte('\n')
+	}
+	b.WriteString("}\n")
+}
+
+// protoType returns the proto type of the values of a field
+func protoType(f *fieldInfo) string {
+	switch f.kind {
+	case kindBool:
+		return "bool"
+	case kindInt:
+		return "int64"
+	case kindUint:
+		return "uint64"
+	case kindBytes:
+		return "bytes"
+	case kindMessage:
+		return f.typ.Name()
+	}
+	return "string"
+}
+
+// fieldName returns the proto name of a Go field, in lower snake case: a word starts
+// at an upper case letter following a lower case letter or digit, or followed by a
+// lower case letter, so that EndToEndID is end_to_end_id and
+// FICustomerCreditTransfer is fi_customer_credit_transfer
+func fieldName(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) {
+			prev := runes[i-1]
+			next := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || unicode.IsUpper(prev) && next {
+				b.WriteByte('_')
+			}
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
+
+// elementName returns the XML name of a field as written in the comments of the
+// schema: the element name, @name for an attribute, or "" for character data
+func elementName(sf reflect.StructField) string {
+	parts := strings.Split(sf.Tag.Get("xml"), ",")
+	name := parts[0]
+	if i := strings.LastIndexAny(name, " >"); i >= 0 {
+		name = name[i+1:]
+	}
+	for _, opt := range parts[1:] {
+		switch opt {
+		case "attr":
+			return "@" + name
+		case "chardata", "innerxml", "comment":
+			return ""
+		}
+	}
+	if name == "-" {
+		return ""
+	}
+	return name
+}
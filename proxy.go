@@ -0,0 +1,155 @@
+package iso20022
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// Proxy types of the ISO external proxy account type code list, used in Prxy/Tp/Cd
+// of an account. NPP PayIDs, FedNow and UPI aliases use them for phone numbers and
+// email addresses; scheme specific aliases have a proprietary type.
+const (
+	ProxyTypeTelephone     = "TELE" // phone number
+	ProxyTypeMobile        = "MBNO" // mobile phone number
+	ProxyTypeEmail         = "EMAL" // email address
+	ProxyTypeLEI           = "LEIC" // legal entity identifier
+	ProxyTypeCompanyNumber = "CINC" // company registration number
+	ProxyTypeCorporateTax  = "COTX" // corporate tax identification
+	ProxyTypePrivateTax    = "PVTX" // private tax identification
+	ProxyTypeDisplayName   = "DNAM" // display name
+	ProxyTypeToken         = "TOKN" // token
+)
+
+var (
+	// e164Number is a phone number in E.164 format: a plus sign, the country code
+	// and the subscriber number, at most 15 digits in all
+	e164Number = regexp.MustCompile(`^\+[1-9][0-9]{6,14}$`)
+	// emailAddress is an address with a local part and a domain with a dot
+	emailAddress = regexp.MustCompile(`^[^@\s]+@[^@\s.]+(\.[^@\s.]+)+$`)
+)
+
+// ValidateProxy checks the identification of a proxy against the format of its
+// type: phone numbers (TELE, MBNO) must be in E.164 format, such as +61412345678,
+// email addresses (EMAL) must have a local part and a domain, and LEIs (LEIC) must
+// have valid check digits. Proxies of other or proprietary types are only checked
+// for their length. It returns a ValidationError for field Id.
+func ValidateProxy(proxy ProxyAccountIdentification1) error {
+	if err := validateStringLength(proxy.ID, 1, 2048, "Id"); err != nil {
+		return err
+	}
+	if proxy.Type == nil || proxy.Type.Code == nil {
+		return nil
+	}
+	switch *proxy.Type.Code {
+	case ProxyTypeTelephone, ProxyTypeMobile:
+		if !e164Number.MatchString(proxy.ID) {
+			return newValidationError("Id", RuleProxy, "PROXY_PHONE", proxy.ID)
+		}
+	case ProxyTypeEmail:
+		if !emailAddress.MatchString(proxy.ID) {
+			return newValidationError("Id", RuleProxy, "PROXY_EMAIL", proxy.ID)
+		}
+	case ProxyTypeLEI:
+		return validateLEI(proxy.ID, "Id")
+	}
+	return nil
+}
+
+// ErrProxyNotFound is returned by a ProxyResolver for a proxy that is not
+// registered
+var ErrProxyNotFound = errors.New("iso20022: proxy not registered")
+
+// ProxyResolver looks up the account a proxy is registered to, such as the
+// addressing service of NPP, FedNow or UPI. ResolveProxy returns ErrProxyNotFound
+// for proxies that are not registered.
+type ProxyResolver interface {
+	ResolveProxy(ctx context.Context, proxy ProxyAccountIdentification1) (AccountIdentification4, error)
+}
+
+// ProxyDirectory is a ProxyResolver holding the accounts of the proxies in memory,
+// under the keys ProxyKey gives them
+type ProxyDirectory map[string]AccountIdentification4
+
+// ProxyKey returns the key of a proxy in a ProxyDirectory: its type code or
+// proprietary type and its identification, as "EMAL:jane@example.com". Email
+// addresses are compared without case.
+func ProxyKey(proxy ProxyAccountIdentification1) string {
+	var kind string
+	if proxy.Type != nil {
+		kind = deref(proxy.Type.Code)
+		if kind == "" {
+			kind = deref(proxy.Type.Proprietary)
+		}
+	}
+	id := strings.TrimSpace(proxy.ID)
+	if kind == ProxyTypeEmail {
+		id = strings.ToLower(id)
+	}
+	return kind + ":" + id
+}
+
+// ResolveProxy returns the account registered for the proxy
+func (d ProxyDirectory) ResolveProxy(_ context.Context, proxy ProxyAccountIdentification1) (AccountIdentification4, error) {
+	account, ok := d[ProxyKey(proxy)]
+	if !ok {
+		return AccountIdentification4{}, ErrProxyNotFound
+	}
+	return account, nil
+}
+
+// ProxyRule returns a profile rule checking the proxies of the accounts of a
+// message (Prxy) with ValidateProxy and, with a resolver, that they are registered
+// and resolve to the account identification given with them, if any. Errors name
+// the scheme. The resolver is called without a deadline; resolvers calling a remote
+// service should set their own.
+func ProxyRule(scheme string, resolver ProxyResolver) ProfileRule {
+	return func(doc interface{}) ValidationErrors {
+		var errs ValidationErrors
+		visitStructs(reflect.ValueOf(doc), "", isProxyAccount, func(path string, v reflect.Value) {
+			proxy := v.FieldByName("Proxy").Interface().(*ProxyAccountIdentification1)
+			if proxy == nil {
+				return
+			}
+			id := path + "/Prxy/Id"
+			if err := ValidateProxy(*proxy); err != nil {
+				e := err.(ValidationError).at(id)
+				e.Message += " in " + scheme
+				errs = append(errs, e)
+				return
+			}
+			if resolver == nil {
+				return
+			}
+			resolved, err := resolver.ResolveProxy(context.Background(), *proxy)
+			switch {
+			case errors.Is(err, ErrProxyNotFound):
+				errs = append(errs, ValidationError{Field: "Id", Path: id, Message: "is not a registered " + scheme + " proxy"})
+				return
+			case err != nil:
+				errs = append(errs, ValidationError{Field: "Id", Path: id, Message: fmt.Sprintf("could not be resolved in %s: %v", scheme, err)})
+				return
+			}
+			given := accountKey(&CashAccount38{ID: v.FieldByName("ID").Interface().(AccountIdentification4)})
+			if want := accountKey(&CashAccount38{ID: resolved}); given != "" && given != want {
+				errs = append(errs, ValidationError{Field: "Id", Path: path + "/Id",
+					Message: fmt.Sprintf("is not the account proxy %s resolves to in %s", proxy.ID, scheme)})
+			}
+		})
+		return errs
+	}
+}
+
+// isProxyAccount reports whether t is an account with an identification and a
+// proxy, such as CashAccount38
+func isProxyAccount(t reflect.Type) bool {
+	id, ok := t.FieldByName("ID")
+	if !ok || id.Type != reflect.TypeOf(AccountIdentification4{}) {
+		return false
+	}
+	proxy, ok := t.FieldByName("Proxy")
+	return ok && proxy.Type == reflect.TypeOf((*ProxyAccountIdentification1)(nil))
+}
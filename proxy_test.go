@@ -0,0 +1,71 @@
+package iso20022
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestValidateProxy(t *testing.T) {
+	proxy := func(code, id string) ProxyAccountIdentification1 {
+		return ProxyAccountIdentification1{Type: &ProxyAccountType1{Code: Ptr(code)}, ID: id}
+	}
+	tests := []struct {
+		proxy ProxyAccountIdentification1
+		rule  string
+	}{
+		{proxy(ProxyTypeMobile, "+61412345678"), ""},
+		{proxy(ProxyTypeTelephone, "+44-7700900123"), RuleProxy},
+		{proxy(ProxyTypeMobile, "0412345678"), RuleProxy},
+		{proxy(ProxyTypeEmail, "jane.doe@example.com.au"), ""},
+		{proxy(ProxyTypeEmail, "jane.doe@localhost"), RuleProxy},
+		{proxy(ProxyTypeLEI, "5493001KJTIIGC8Y1R12"), ""},
+		{proxy(ProxyTypeLEI, "5493001KJTIIGC8Y1R13"), RuleLEIChecksum},
+		{proxy(ProxyTypeDisplayName, "Jane's shop"), ""},
+		{ProxyAccountIdentification1{Type: &ProxyAccountType1{Proprietary: Ptr("VPA")}, ID: "jane@upi"}, ""},
+		{ProxyAccountIdentification1{}, RuleLength},
+	}
+	for _, tt := range tests {
+		err := ValidateProxy(tt.proxy)
+		if tt.rule == "" {
+			if err != nil {
+				t.Errorf("Expected proxy %q to be valid, got %v", tt.proxy.ID, err)
+			}
+			continue
+		}
+		var verr ValidationError
+		if !errors.As(err, &verr) || verr.Rule != tt.rule {
+			t.Errorf("Expected proxy %q to fail rule %s, got %v", tt.proxy.ID, tt.rule, err)
+		}
+	}
+}
+
+func TestProxyRule(t *testing.T) {
+	doc := loadPacs008Sample(t)
+	account := doc.FICustomerCreditTransfer.CreditTransferTransactionInfo[0].CreditorAccount
+	account.Proxy = &ProxyAccountIdentification1{Type: &ProxyAccountType1{Code: Ptr(ProxyTypeEmail)}, ID: "Jane.Doe@example.co.uk"}
+
+	directory := ProxyDirectory{"EMAL:jane.doe@example.co.uk": AccountIdentification4{IBAN: Ptr("GB29NWBK60161331926819")}}
+	if errs := ProxyRule("NPP", directory)(doc); len(errs) != 0 {
+		t.Errorf("Expected the registered proxy to be accepted, got %v", errs)
+	}
+	if got, err := directory.ResolveProxy(context.Background(), *account.Proxy); err != nil || *got.IBAN != "GB29NWBK60161331926819" {
+		t.Errorf("Expected the proxy to resolve to the creditor account, got %v, %v", got, err)
+	}
+
+	path := "FIToFICstmrCdtTrf/CdtTrfTxInf[1]/CdtrAcct"
+	directory["EMAL:jane.doe@example.co.uk"] = AccountIdentification4{IBAN: Ptr("DE89370400440532013000")}
+	if errs := ProxyRule("NPP", directory)(doc); len(errs) != 1 || errs[0].Location() != path+"/Id" {
+		t.Errorf("Expected the account to be rejected, got %v", errs)
+	}
+	if errs := ProxyRule("NPP", ProxyDirectory{})(doc); len(errs) != 1 || !strings.Contains(errs[0].Message, "not a registered NPP proxy") {
+		t.Errorf("Expected the unregistered proxy to be rejected, got %v", errs)
+	}
+
+	account.Proxy.ID = "jane.doe"
+	errs := ProxyRule("NPP", nil)(doc)
+	if len(errs) != 1 || errs[0].Location() != path+"/Prxy/Id" || errs[0].Rule != RuleProxy || !strings.HasSuffix(errs[0].Message, "in NPP") {
+		t.Errorf("Expected the malformed proxy to be rejected, got %v", errs)
+	}
+}
@@ -0,0 +1,205 @@
+package iso20022
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// rawElement is where an element of a RawMessage is written: from the start of
+// its start tag to the end of its end tag, with its content in between
+type rawElement struct {
+	start, contentStart, contentEnd, end int
+	leaf                                 bool
+}
+
+// selfClosing reports whether the element is written as an empty-element tag
+func (el *rawElement) selfClosing(data []byte) bool {
+	return el.contentStart == el.end && bytes.HasSuffix(data[el.start:el.contentStart], []byte("/>"))
+}
+
+// RawMessage is an inbound message kept byte for byte, for gateways that forward
+// a message unchanged except for a few elements. ParseRaw records where each
+// element of the message is written; Set replaces the text of an element, and
+// Bytes writes the message again with the original bytes of everything else, its
+// prolog, namespace prefixes, whitespace and comments included. Signatures over
+// parts of the message that were not edited, such as the Document of a message
+// whose header is rewritten, still verify.
+type RawMessage struct {
+	data     []byte
+	elements map[string]*rawElement
+	edits    map[string]string
+}
+
+// ParseRaw reads the elements of data. Their paths are relative to the root
+// element, as for GetPath: FIToFICstmrCdtTrf.GrpHdr.MsgId in a Document, or
+// AppHdr.BizMsgIdr in an envelope holding an AppHdr and a Document.
+func ParseRaw(data []byte) (*RawMessage, error) {
+	type frame struct {
+		key    string
+		el     *rawElement
+		counts map[string]int
+	}
+	m := &RawMessage{data: data, elements: make(map[string]*rawElement), edits: make(map[string]string)}
+	d := xml.NewDecoder(bytes.NewReader(data))
+	var (
+		stack []frame
+		root  bool
+	)
+	for {
+		offset := int(d.InputOffset())
+		tok, err := d.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			el := &rawElement{start: offset, contentStart: int(d.InputOffset()), leaf: true}
+			var key string
+			if len(stack) > 0 {
+				parent := &stack[len(stack)-1]
+				parent.el.leaf = false
+				key = t.Name.Local + "[" + strconv.Itoa(parent.counts[t.Name.Local]) + "]"
+				parent.counts[t.Name.Local]++
+				if parent.key != "" {
+					key = parent.key + "." + key
+				}
+				m.elements[key] = el
+			} else if root {
+				return nil, fmt.Errorf("%w: more than one root element", ErrUnexpectedElement)
+			}
+			root = true
+			stack = append(stack, frame{key: key, el: el, counts: make(map[string]int)})
+		case xml.EndElement:
+			f := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			f.el.contentEnd, f.el.end = offset, int(d.InputOffset())
+		}
+	}
+	if len(stack) > 0 || !root {
+		return nil, io.ErrUnexpectedEOF
+	}
+	return m, nil
+}
+
+// rawKey returns the key of the element at path, with the index of every step
+func rawKey(path string) (string, error) {
+	steps, err := parsePath(path)
+	if err != nil {
+		return "", err
+	}
+	keys := make([]string, len(steps))
+	for i, s := range steps {
+		if strings.HasPrefix(s.name, "@") {
+			return "", fmt.Errorf("%w: %s is an attribute", ErrPath, s.name)
+		}
+		keys[i] = s.name + "[" + strconv.Itoa(s.index) + "]"
+	}
+	return strings.Join(keys, "."), nil
+}
+
+// element returns the element at path
+func (m *RawMessage) element(path string) (string, *rawElement, error) {
+	key, err := rawKey(path)
+	if err != nil {
+		return "", nil, err
+	}
+	el, ok := m.elements[key]
+	if !ok {
+		return "", nil, fmt.Errorf("%w: %s is not in the message", ErrPath, path)
+	}
+	return key, el, nil
+}
+
+// Get returns the text of the element at path, as set by Set or else as read
+func (m *RawMessage) Get(path string) (string, error) {
+	key, el, err := m.element(path)
+	if err != nil {
+		return "", err
+	}
+	if text, ok := m.edits[key]; ok {
+		return text, nil
+	}
+	var text strings.Builder
+	d := xml.NewDecoder(bytes.NewReader(m.data[el.start:el.end]))
+	for {
+		tok, err := d.Token()
+		if err == io.EOF {
+			return text.String(), nil
+		}
+		if err != nil {
+			return "", err
+		}
+		if data, ok := tok.(xml.CharData); ok {
+			text.Write(data)
+		}
+	}
+}
+
+// Set replaces the text of the element at path, which must be in the message and
+// have no child elements. Its tags are kept as they are written.
+func (m *RawMessage) Set(path, text string) error {
+	key, el, err := m.element(path)
+	if err != nil {
+		return err
+	}
+	if !el.leaf {
+		return fmt.Errorf("%w: %s has child elements", ErrPath, path)
+	}
+	m.edits[key] = text
+	return nil
+}
+
+// Bytes returns the message with the edits of Set, identical to the message read
+// outside the content of the edited elements
+func (m *RawMessage) Bytes() []byte {
+	if len(m.edits) == 0 {
+		return bytes.Clone(m.data)
+	}
+	keys := make([]string, 0, len(m.edits))
+	for key := range m.edits {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return m.elements[keys[i]].start < m.elements[keys[j]].start })
+
+	var out bytes.Buffer
+	out.Grow(len(m.data))
+	written := 0
+	for _, key := range keys {
+		el := m.elements[key]
+		if el.selfClosing(m.data) {
+			// <Nm/> becomes <Nm>text</Nm>
+			tag := m.data[el.start:el.contentStart]
+			out.Write(m.data[written:el.start])
+			out.Write(tag[:len(tag)-2])
+			out.WriteByte('>')
+			xml.EscapeText(&out, []byte(m.edits[key]))
+			name := tag[1 : len(tag)-2]
+			if i := bytes.IndexAny(name, " \t\r\n"); i >= 0 {
+				name = name[:i]
+			}
+			out.WriteString("</")
+			out.Write(name)
+			out.WriteByte('>')
+			written = el.end
+			continue
+		}
+		out.Write(m.data[written:el.contentStart])
+		xml.EscapeText(&out, []byte(m.edits[key]))
+		written = el.contentEnd
+	}
+	out.Write(m.data[written:])
+	return out.Bytes()
+}
+
+// Decode unmarshals the message with its edits into v, as Unmarshal does
+func (m *RawMessage) Decode(v interface{}, opts ...DecodeOption) error {
+	return Unmarshal(m.Bytes(), v, opts...)
+}
@@ -0,0 +1,84 @@
+package iso20022
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRawMessage(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("testdata", "roundtrip", "pacs.008.001.08", "customer_credit_transfer.xml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err := ParseRaw(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out := m.Bytes(); !bytes.Equal(out, data) {
+		t.Fatal("Expected the message to be written back unchanged")
+	}
+	if id, err := m.Get("FIToFICstmrCdtTrf.GrpHdr.MsgId"); err != nil || id != "BBBBUS33-20240315-0001" {
+		t.Errorf("Expected the message identification, got %q, %v", id, err)
+	}
+
+	if err := m.Set("FIToFICstmrCdtTrf/GrpHdr/MsgId", "GW-0001 & co"); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Set("FIToFICstmrCdtTrf.CdtTrfTxInf[0].PmtId.EndToEndId", "INV-2024-0043"); err != nil {
+		t.Fatal(err)
+	}
+	want := bytes.Replace(data, []byte(">BBBBUS33-20240315-0001<"), []byte(">GW-0001 &amp; co<"), 1)
+	want = bytes.Replace(want, []byte(">INV-2024-0042<"), []byte(">INV-2024-0043<"), 1)
+	if out := m.Bytes(); !bytes.Equal(out, want) {
+		t.Errorf("Expected only the edited elements to change, got\n%s", out)
+	}
+	doc := new(Pacs00800108Document)
+	if err := m.Decode(doc); err != nil {
+		t.Fatal(err)
+	}
+	if doc.FICustomerCreditTransfer.GroupHeader.MessageID != "GW-0001 & co" || doc.FICustomerCreditTransfer.CreditTransferTransactionInfo[0].PaymentID.EndToEndID != "INV-2024-0043" {
+		t.Errorf("Expected the edits to be decoded, got %+v", doc.FICustomerCreditTransfer.GroupHeader)
+	}
+
+	if err := m.Set("FIToFICstmrCdtTrf.GrpHdr", "x"); !errors.Is(err, ErrPath) {
+		t.Errorf("Expected an element with children to be refused, got %v", err)
+	}
+	if err := m.Set("FIToFICstmrCdtTrf.GrpHdr.Nope", "x"); !errors.Is(err, ErrPath) {
+		t.Errorf("Expected an absent element to be refused, got %v", err)
+	}
+}
+
+func TestRawMessageEnvelope(t *testing.T) {
+	data := []byte(`<?xml version="1.0"?>
+<Envelope>
+  <h:AppHdr xmlns:h="urn:iso:std:iso:20022:tech:xsd:head.001.001.02"><h:BizMsgIdr>BAH-0001</h:BizMsgIdr><h:PssblDplct/></h:AppHdr>
+  <!-- unchanged -->
+  <Document xmlns="urn:iso:std:iso:20022:tech:xsd:admi.004.001.02"><SysEvtNtfctn><EvtInf><EvtCd>EXTN</EvtCd><EvtParam>18:30</EvtParam><EvtParam>19:00</EvtParam></EvtInf></SysEvtNtfctn></Document>
+</Envelope>`)
+	m, err := ParseRaw(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Set("AppHdr.BizMsgIdr", "BAH-0002"); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Set("AppHdr.PssblDplct", "true"); err != nil {
+		t.Fatal(err)
+	}
+	if p, err := m.Get("Document.SysEvtNtfctn.EvtInf.EvtParam[1]"); err != nil || p != "19:00" {
+		t.Errorf("Expected the second parameter, got %q, %v", p, err)
+	}
+	want := bytes.Replace(data, []byte("BAH-0001</h:BizMsgIdr><h:PssblDplct/>"), []byte("BAH-0002</h:BizMsgIdr><h:PssblDplct>true</h:PssblDplct>"), 1)
+	if out := m.Bytes(); !bytes.Equal(out, want) {
+		t.Errorf("Expected\n%s\ngot\n%s", want, out)
+	}
+
+	for _, bad := range []string{"", "<A>", "<A/><B/>"} {
+		if _, err := ParseRaw([]byte(bad)); err == nil {
+			t.Errorf("Expected %q to fail", bad)
+		}
+	}
+}
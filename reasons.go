@@ -0,0 +1,120 @@
+package iso20022
+
+import (
+	"strings"
+	"sync"
+)
+
+// Status reason codes of the ExternalStatusReason1Code list that StatusReasonCode
+// reports beyond those of statusReasonCodes
+const (
+	reasonIncorrectAccount       = "AC01" // IncorrectAccountNumber
+	reasonInvalidDebtorAccount   = "AC02" // InvalidDebtorAccountNumber
+	reasonInvalidCreditorAccount = "AC03" // InvalidCreditorAccountNumber
+	reasonInvalidBIC             = "RC01" // BankIdentifierIncorrect
+	reasonNotSpecifiedByAgent    = "MS03" // NotSpecifiedReasonAgentGenerated
+)
+
+// maxAdditionalInformationRunes is the length of the Max105Text of AddtlInf
+const maxAdditionalInformationRunes = 105
+
+var (
+	statusReasonCodesMu sync.RWMutex
+	// statusReasonCodes maps the rules of the validation errors to the
+	// ExternalStatusReason1Code reporting them
+	statusReasonCodes = map[string]string{
+		RuleRequired:             "CH21", // RequiredCompulsoryElementMissing
+		RuleLength:               "CH16", // ElementContentFormallyIncorrect
+		RulePattern:              "CH16",
+		RuleCode:                 "CH16",
+		RuleProxy:                "CH16",
+		RuleDate:                 "DT01", // InvalidDate
+		RuleOccurrences:          "FF01", // InvalidFileFormat
+		RuleChoice:               "FF01",
+		RuleGroupLevel:           "CH17", // ElementNotAdmitted
+		RuleIBANLength:           reasonIncorrectAccount,
+		RuleIBANChecksum:         reasonIncorrectAccount,
+		RuleClearingMemberID:     "RC08", // InvalidClearingSystemMemberIdentifier
+		RuleCurrency:             "AM03", // NotAllowedCurrency
+		RuleMinorUnits:           "AM12", // InvalidAmount
+		RuleAmountLimit:          "AM02", // NotAllowedAmount
+		RuleRegulatory:           "RR04", // RegulatoryReason
+		RuleNumberOfTransactions: "AM18", // InvalidNumberOfTransactions
+		RuleControlSum:           "AM10", // InvalidControlSum
+		RuleSettlementDate:       "DT01",
+		RuleSettlementCalendar:   "DT01",
+	}
+)
+
+// RegisterStatusReasonCode makes StatusReasonCode report the errors of rule with
+// code, an ExternalStatusReason1Code, for the schemes that expect another code than
+// the default or for rules of the caller's own
+func RegisterStatusReasonCode(rule, code string) {
+	statusReasonCodesMu.Lock()
+	defer statusReasonCodesMu.Unlock()
+	statusReasonCodes[rule] = code
+}
+
+// StatusReasonCode returns the ExternalStatusReason1Code that reports e in the
+// StsRsnInf of a pacs.002 rejection. An error in a BIC is reported as RC01 and an
+// error in the identification of an account as AC02 or AC03 for the debtor or
+// creditor account, or else AC01, whatever its rule. The other errors are reported
+// by their Rule, such as AM02 for an amount over the limit of a profile or CH21 for
+// a missing element, and as MS03, not specified reason, when it has no code.
+func StatusReasonCode(e ValidationError) string {
+	var steps []string
+	for _, step := range strings.Split(e.Location(), "/") {
+		if i := strings.IndexByte(step, '['); i >= 0 {
+			step = step[:i]
+		}
+		if step != "" && !strings.HasPrefix(step, "@") {
+			steps = append(steps, step)
+		}
+	}
+	if n := len(steps); n > 0 {
+		switch steps[n-1] {
+		case "BICFI", "BIC", "AnyBIC", "BICOrBEI":
+			return reasonInvalidBIC
+		}
+		for i := n - 1; i >= 0; i-- {
+			// the account itself or its identification, not its currency or name
+			if !strings.HasSuffix(steps[i], "Acct") || i < n-1 && steps[i+1] != "Id" {
+				continue
+			}
+			switch steps[i] {
+			case "DbtrAcct":
+				return reasonInvalidDebtorAccount
+			case "CdtrAcct":
+				return reasonInvalidCreditorAccount
+			}
+			return reasonIncorrectAccount
+		}
+	}
+	statusReasonCodesMu.RLock()
+	defer statusReasonCodesMu.RUnlock()
+	if code, ok := statusReasonCodes[e.Rule]; ok {
+		return code
+	}
+	return reasonNotSpecifiedByAgent
+}
+
+// StatusReasons returns the status reason information reporting errs in a pacs.002
+// rejection: one StsRsnInf per distinct StatusReasonCode, in the order of the first
+// error of each, with the location and message of every error it reports as
+// additional information
+func (errs ValidationErrors) StatusReasons() []StatusReasonInfo12 {
+	var reasons []StatusReasonInfo12
+	index := make(map[string]int)
+	for _, e := range errs {
+		code := StatusReasonCode(e)
+		i, ok := index[code]
+		if !ok {
+			i = len(reasons)
+			index[code] = i
+			reasons = append(reasons, StatusReasonInfo12{Reason: &StatusReason62{Code: &code}})
+		}
+		info := truncateRunes(e.Location()+": "+e.Message, maxAdditionalInformationRunes)
+		reasons[i].AdditionalInformation = append(reasons[i].AdditionalInformation, info)
+	}
+	return reasons
+}
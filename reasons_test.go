@@ -0,0 +1,82 @@
+package iso20022
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestStatusReasonCode(t *testing.T) {
+	tests := []struct {
+		err  ValidationError
+		want string
+	}{
+		{ValidationError{Path: "FIToFICstmrCdtTrf/CdtTrfTxInf[1]/InstgAgt/FinInstnId/BICFI", Rule: RulePattern}, "RC01"},
+		{ValidationError{Path: "FIToFICstmrCdtTrf/CdtTrfTxInf[1]/CdtrAgt/FinInstnId/BICFI", Message: "'UNKNGB2L' is not in the BIC directory"}, "RC01"},
+		{ValidationError{Path: "FIToFICstmrCdtTrf/CdtTrfTxInf[2]/DbtrAcct/Id/IBAN", Rule: RuleIBANChecksum}, "AC02"},
+		{ValidationError{Path: "FIToFICstmrCdtTrf/CdtTrfTxInf[2]/CdtrAcct/Id/Othr/Id", Rule: RuleLength}, "AC03"},
+		{ValidationError{Path: "FIToFICstmrCdtTrf/CdtTrfTxInf[2]/CdtrAcct", Rule: RuleRequired}, "AC03"},
+		{ValidationError{Path: "FIToFICstmrCdtTrf/CdtTrfTxInf[2]/DbtrAgtAcct/Id/IBAN", Rule: RuleIBANLength}, "AC01"},
+		{ValidationError{Field: "IBAN", Rule: RuleIBANChecksum}, "AC01"},
+		{ValidationError{Path: "FIToFICstmrCdtTrf/CdtTrfTxInf[2]/CdtrAcct/Ccy", Rule: RuleCurrency}, "AM03"},
+		{ValidationError{Path: "FIToFICstmrCdtTrf/CdtTrfTxInf[1]/IntrBkSttlmAmt/@Ccy", Rule: RuleMinorUnits}, "AM12"},
+		{ValidationError{Path: "FIToFICstmrCdtTrf/GrpHdr/MsgId", Rule: RuleRequired}, "CH21"},
+		{ValidationError{Path: "FIToFICstmrCdtTrf/GrpHdr/CtrlSum", Rule: RuleControlSum}, "AM10"},
+		{ValidationError{Path: "FIToFICstmrCdtTrf/GrpHdr/IntrBkSttlmDt", Rule: RuleSettlementCalendar}, "DT01"},
+		{ValidationError{Path: "FIToFICstmrCdtTrf/CdtTrfTxInf[1]/ChrgBr", Rule: RuleChargeBearer}, "MS03"},
+		{ValidationError{Path: "FIToFICstmrCdtTrf/CdtTrfTxInf[1]/Purp/Cd"}, "MS03"},
+	}
+	for _, tt := range tests {
+		if got := StatusReasonCode(tt.err); got != tt.want {
+			t.Errorf("%s (%s): expected %s, got %s", tt.err.Location(), tt.err.Rule, tt.want, got)
+		}
+	}
+
+	RegisterStatusReasonCode(RuleChargeBearer, "AG03")
+	defer func() {
+		statusReasonCodesMu.Lock()
+		delete(statusReasonCodes, RuleChargeBearer)
+		statusReasonCodesMu.Unlock()
+	}()
+	if got := StatusReasonCode(ValidationError{Field: "ChrgBr", Rule: RuleChargeBearer}); got != "AG03" {
+		t.Errorf("Expected the registered code, got %s", got)
+	}
+}
+
+func TestStatusReasonCodeFromValidation(t *testing.T) {
+	doc := loadPacs008Sample(t)
+	doc.FICustomerCreditTransfer.CreditTransferTransactionInfo[0].CreditorAccount.ID.IBAN = Ptr("GB29NWBK60161331926818")
+	var errs ValidationErrors
+	if !errors.As(doc.Validate(), &errs) || len(errs) != 1 || StatusReasonCode(errs[0]) != "AC03" {
+		t.Errorf("Expected an invalid creditor account, got %v", errs)
+	}
+
+	doc = loadPacs008Sample(t)
+	doc.FICustomerCreditTransfer.CreditTransferTransactionInfo[0].InterbankSettlementAmount.Value = 20000000
+	if !errors.As(RTPProfile.CheckLimits(doc), &errs) || StatusReasonCode(errs[0]) != "AM02" {
+		t.Errorf("Expected an amount over the limit, got %v", errs)
+	}
+}
+
+func TestStatusReasons(t *testing.T) {
+	errs := ValidationErrors{
+		{Path: "FIToFICstmrCdtTrf/GrpHdr/MsgId", Rule: RuleRequired, Message: "is required but is empty"},
+		{Path: "FIToFICstmrCdtTrf/CdtTrfTxInf[1]/InstgAgt/FinInstnId/BICFI", Rule: RulePattern, Message: strings.Repeat("x", 200)},
+		{Path: "FIToFICstmrCdtTrf/CdtTrfTxInf[1]/UETR", Rule: RuleRequired, Message: "is required but is empty"},
+	}
+	reasons := errs.StatusReasons()
+	if len(reasons) != 2 || *reasons[0].Reason.Code != "CH21" || *reasons[1].Reason.Code != "RC01" {
+		t.Fatalf("Expected a reason per code in order, got %+v", reasons)
+	}
+	want := []string{"FIToFICstmrCdtTrf/GrpHdr/MsgId: is required but is empty", "FIToFICstmrCdtTrf/CdtTrfTxInf[1]/UETR: is required but is empty"}
+	if !reflect.DeepEqual(reasons[0].AdditionalInformation, want) {
+		t.Errorf("Expected %q, got %q", want, reasons[0].AdditionalInformation)
+	}
+	if info := reasons[1].AdditionalInformation; len(info) != 1 || len(info[0]) != 105 {
+		t.Errorf("Expected the information to be cut to 105 characters, got %q", info)
+	}
+	if ValidationErrors(nil).StatusReasons() != nil {
+		t.Error("Expected no reasons without errors")
+	}
+}
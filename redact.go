@@ -0,0 +1,298 @@
+package iso20022
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+// RedactAction is what Redact does with a category of personal data
+type RedactAction int
+
+// Redaction actions
+const (
+	// Keep leaves the data as it is
+	Keep RedactAction = iota
+	// Mask replaces every letter with X and every digit with 0
+	Mask
+	// Pseudonymize replaces every letter and digit with one derived from the value
+	// and the policy key, so that equal values get equal pseudonyms
+	Pseudonymize
+	// Remove empties the data: optional elements are dropped and required ones left
+	// empty
+	Remove
+)
+
+// RedactionPolicy says what to do with each category of personal data
+type RedactionPolicy struct {
+	Names          RedactAction // Nm of parties, other than financial institutions
+	Addresses      RedactAction // PstlAdr and Adr, other than those of financial institutions; countries are kept
+	Accounts       RedactAction // IBAN, and the identification and name of accounts
+	BirthDetails   RedactAction // DtAndPlcOfBirth; the country of birth is kept
+	ContactDetails RedactAction // CtctDtls; codes such as NmPrfx are kept
+	PersonIDs      RedactAction // identifications of persons (PrvtId)
+	// Key keys the pseudonyms. It is required when an action is Pseudonymize; keep
+	// it secret, since anyone who has it can check guesses against pseudonyms.
+	Key []byte
+}
+
+// MaskPersonalData masks every category of personal data
+var MaskPersonalData = RedactionPolicy{
+	Names: Mask, Addresses: Mask, Accounts: Mask, BirthDetails: Mask, ContactDetails: Mask, PersonIDs: Mask,
+}
+
+// maskedBirthDate replaces masked and pseudonymized birth dates
+var maskedBirthDate = NewISODate(1900, 1, 1)
+
+// Redact masks, pseudonymizes or removes the personal data of a message according
+// to policy. It walks any document or component through a pointer and changes it in
+// place, recognizing personal data by element names, so the message keeps its
+// structure. Masked and pseudonymized values keep their length and the position of
+// letters, digits and punctuation; IBANs keep their country code and get valid
+// check digits. Financial institutions, codes and amounts are not personal data and
+// are kept.
+func Redact(doc interface{}, policy RedactionPolicy) error {
+	v := reflect.ValueOf(doc)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return fmt.Errorf("redact: need a non-nil pointer, got %T", doc)
+	}
+	for _, action := range []RedactAction{policy.Names, policy.Addresses, policy.Accounts,
+		policy.BirthDetails, policy.ContactDetails, policy.PersonIDs} {
+		if action == Pseudonymize && len(policy.Key) == 0 {
+			return errors.New("redact: Pseudonymize requires a key")
+		}
+	}
+	r := &redactor{policy: policy}
+	r.walk(v.Elem(), nil)
+	return nil
+}
+
+type redactor struct {
+	policy RedactionPolicy
+}
+
+var (
+	stringType  = reflect.TypeOf("")
+	isoDateType = reflect.TypeOf(ISODate{})
+)
+
+// walk visits the elements of v, whose element path is path
+func (r *redactor) walk(v reflect.Value, path []string) {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if !v.IsNil() {
+			r.walk(v.Elem(), path)
+		}
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			r.walk(v.Index(i), path)
+		}
+	case reflect.Struct:
+		if v.Type() == isoDateType {
+			return
+		}
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			name, ok := elementName(field)
+			if !ok {
+				continue
+			}
+			if name == "" {
+				r.walk(v.Field(i), path)
+				continue
+			}
+			child := append(path[:len(path):len(path)], name)
+			if action := r.action(child); action != Keep && r.redact(v.Field(i), action, name == "IBAN") {
+				continue
+			}
+			r.walk(v.Field(i), child)
+		}
+	}
+}
+
+// elementName returns the XML element name of a struct field, "" for embedded
+// structs without one, and false for attributes, character data and XMLName
+func elementName(field reflect.StructField) (string, bool) {
+	if field.Name == "XMLName" {
+		return "", false
+	}
+	tag := field.Tag.Get("xml")
+	parts := strings.Split(tag, ",")
+	for _, opt := range parts[1:] {
+		if opt == "attr" || opt == "chardata" || opt == "innerxml" || opt == "comment" {
+			return "", false
+		}
+	}
+	name := parts[0]
+	if i := strings.LastIndexAny(name, " >"); i >= 0 {
+		name = name[i+1:]
+	}
+	if name == "" && !field.Anonymous {
+		name = field.Name
+	}
+	return name, true
+}
+
+// action returns what the policy does with the element at path
+func (r *redactor) action(path []string) RedactAction {
+	leaf := path[len(path)-1]
+	within := func(names ...string) bool {
+		for _, element := range path[:len(path)-1] {
+			for _, name := range names {
+				if element == name {
+					return true
+				}
+			}
+		}
+		return false
+	}
+	inAccount := false
+	for _, element := range path[:len(path)-1] {
+		if strings.HasSuffix(element, "Acct") {
+			inAccount = true
+		}
+	}
+
+	switch {
+	case within("DtAndPlcOfBirth"):
+		if leaf == "CtryOfBirth" {
+			return Keep
+		}
+		return r.policy.BirthDetails
+	case within("CtctDtls"):
+		switch leaf {
+		case "NmPrfx", "PrefrdMtd", "EmailPurp", "ChanlTp":
+			return Keep
+		}
+		return r.policy.ContactDetails
+	case within("PrvtId"):
+		if leaf == "Id" {
+			return r.policy.PersonIDs
+		}
+		return Keep
+	case within("FinInstnId"):
+		return Keep
+	case leaf == "IBAN", inAccount && (leaf == "Id" || leaf == "Nm"):
+		return r.policy.Accounts
+	case within("PstlAdr", "Adr"):
+		switch leaf {
+		case "Ctry", "Cd", "Prtry", "Id", "Issr", "SchmeNm":
+			return Keep
+		}
+		return r.policy.Addresses
+	case leaf == "Nm":
+		return r.policy.Names
+	}
+	return Keep
+}
+
+// redact applies action to a leaf value and reports whether v was a leaf
+func (r *redactor) redact(v reflect.Value, action RedactAction, iban bool) bool {
+	switch {
+	case v.Type() == stringType:
+		if action == Remove {
+			v.SetString("")
+		} else {
+			v.SetString(r.transform(v.String(), action, iban))
+		}
+	case v.Kind() == reflect.Ptr && v.Type().Elem() == stringType:
+		if v.IsNil() {
+			return true
+		}
+		if action == Remove {
+			v.Set(reflect.Zero(v.Type()))
+		} else {
+			s := r.transform(v.Elem().String(), action, iban)
+			v.Set(reflect.ValueOf(&s))
+		}
+	case v.Kind() == reflect.Slice && v.Type().Elem() == stringType:
+		if action == Remove {
+			v.Set(reflect.Zero(v.Type()))
+			return true
+		}
+		lines := make([]string, v.Len())
+		for i := range lines {
+			lines[i] = r.transform(v.Index(i).String(), action, iban)
+		}
+		v.Set(reflect.ValueOf(lines))
+	case v.Type() == isoDateType:
+		if action == Remove {
+			v.Set(reflect.Zero(v.Type()))
+		} else {
+			v.Set(reflect.ValueOf(maskedBirthDate))
+		}
+	case v.Kind() == reflect.Ptr && v.Type().Elem() == isoDateType:
+		if v.IsNil() {
+			return true
+		}
+		if action == Remove {
+			v.Set(reflect.Zero(v.Type()))
+		} else {
+			date := maskedBirthDate
+			v.Set(reflect.ValueOf(&date))
+		}
+	default:
+		return false
+	}
+	return true
+}
+
+// transform masks or pseudonymizes a value, keeping the class of each character
+func (r *redactor) transform(s string, action RedactAction, iban bool) string {
+	var stream []byte
+	if action == Pseudonymize {
+		stream = r.keyStream(s, len(s))
+	}
+	runes := []rune(s)
+	for i, c := range runes {
+		if iban && i < 2 {
+			continue // the country code
+		}
+		switch {
+		case unicode.IsDigit(c):
+			if action == Mask {
+				runes[i] = '0'
+			} else {
+				runes[i] = rune('0' + stream[i%len(stream)]%10)
+			}
+		case unicode.IsLetter(c):
+			switch {
+			case action == Mask:
+				runes[i] = 'X'
+			case unicode.IsLower(c):
+				runes[i] = rune('a' + stream[i%len(stream)]%26)
+			default:
+				runes[i] = rune('A' + stream[i%len(stream)]%26)
+			}
+		}
+	}
+	out := string(runes)
+	if iban && len(out) > 4 {
+		check := 98 - mod97(strings.ToUpper(out[4:]+out[:2]+"00"))
+		out = fmt.Sprintf("%s%02d%s", out[:2], check, out[4:])
+	}
+	return out
+}
+
+// keyStream derives n pseudorandom bytes from the policy key and a value
+func (r *redactor) keyStream(s string, n int) []byte {
+	var stream []byte
+	for counter := uint32(0); len(stream) < n || len(stream) == 0; counter++ {
+		mac := hmac.New(sha256.New, r.policy.Key)
+		var c [4]byte
+		binary.BigEndian.PutUint32(c[:], counter)
+		mac.Write(c[:])
+		mac.Write([]byte(s))
+		stream = mac.Sum(stream)
+	}
+	return stream
+}
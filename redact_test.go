@@ -0,0 +1,104 @@
+package iso20022
+
+import (
+	"reflect"
+	"testing"
+)
+
+// loadPersonalSample returns the pacs.008 sample with a debtor who is a person
+func loadPersonalSample(t *testing.T) *Pacs00800108Document {
+	t.Helper()
+	doc := loadPacs008Sample(t)
+	tx := &doc.FICustomerCreditTransfer.CreditTransferTransactionInfo[0]
+	birthDate := NewISODate(1980, 5, 17)
+	tx.UltimateDebtor = &PartyIdentification135{
+		Name: Ptr("Jane Doe"),
+		ID: &Party38{PrivateID: &PersonIdentification13{
+			DateAndPlaceOfBirth: &DateAndPlaceOfBirth1{BirthDate: &birthDate, CityOfBirth: "Springfield", CountryOfBirth: "US"},
+		}},
+		ContactDetails: &Contact4{NamePrefix: Ptr("MADM"), PhoneNumber: Ptr("+1-555-0100"), EmailAddress: Ptr("jane.doe@example.com")},
+	}
+	return doc
+}
+
+func TestRedactMask(t *testing.T) {
+	doc := loadPersonalSample(t)
+	if err := Redact(doc, MaskPersonalData); err != nil {
+		t.Fatalf("Redact failed: %v", err)
+	}
+	tx := &doc.FICustomerCreditTransfer.CreditTransferTransactionInfo[0]
+	checks := map[string][2]string{
+		"Dbtr/Nm":            {*tx.Debtor.Name, "XXXX XXXXXXXXXXXXX XXX"},
+		"Dbtr/PstlAdr/TwnNm": {*tx.Debtor.PostalAddress.TownName, "XXX XXXX"},
+		"Dbtr/PstlAdr/Ctry":  {*tx.Debtor.PostalAddress.Country, "US"},
+		"DbtrAcct/Id/Othr":   {tx.DebtorAccount.ID.Other.ID, "000000000"},
+		"CdtrAcct/Id/IBAN":   {*tx.CreditorAccount.ID.IBAN, "GB76XXXX00000000000000"},
+		"DbtrAgt/BICFI":      {*tx.DebtorAgent.FinancialInstitutionID.BankIdentifierCode, "BBBBUS33"},
+		"UltmtDbtr/CtctDtls": {*tx.UltimateDebtor.ContactDetails.EmailAddress, "XXXX.XXX@XXXXXXX.XXX"},
+		"NmPrfx":             {*tx.UltimateDebtor.ContactDetails.NamePrefix, "MADM"},
+		"CityOfBirth":        {tx.UltimateDebtor.ID.PrivateID.DateAndPlaceOfBirth.CityOfBirth, "XXXXXXXXXXX"},
+		"BirthDt":            {tx.UltimateDebtor.ID.PrivateID.DateAndPlaceOfBirth.BirthDate.Format("2006-01-02"), "1900-01-01"},
+		"Dbtr/OrgId/LEI":     {*tx.Debtor.ID.OrganizationID.LegalEntityIdentifier, "5493001KJTIIGC8Y1R12"},
+	}
+	for field, c := range checks {
+		if c[0] != c[1] {
+			t.Errorf("%s: expected %q, got %q", field, c[1], c[0])
+		}
+	}
+	if err := ValidateIBAN(*tx.CreditorAccount.ID.IBAN); err != nil {
+		t.Errorf("Expected the masked IBAN to stay valid: %v", err)
+	}
+}
+
+func TestRedactPseudonymize(t *testing.T) {
+	policy := RedactionPolicy{Names: Pseudonymize, Accounts: Pseudonymize, Key: []byte("secret")}
+	a, b := loadPersonalSample(t), loadPersonalSample(t)
+	if err := Redact(a, policy); err != nil {
+		t.Fatalf("Redact failed: %v", err)
+	}
+	if err := Redact(b, policy); err != nil {
+		t.Fatalf("Redact failed: %v", err)
+	}
+	if !reflect.DeepEqual(a, b) {
+		t.Error("Expected the same pseudonyms for the same key")
+	}
+
+	tx := &a.FICustomerCreditTransfer.CreditTransferTransactionInfo[0]
+	if name := *tx.Debtor.Name; name == "Acme Manufacturing Inc" || len(name) != len("Acme Manufacturing Inc") {
+		t.Errorf("Expected a pseudonym of the same length, got %q", name)
+	}
+	if iban := *tx.CreditorAccount.ID.IBAN; iban[:2] != "GB" || ValidateIBAN(iban) != nil {
+		t.Errorf("Expected a valid GB IBAN, got %q", iban)
+	}
+	if town := *tx.Debtor.PostalAddress.TownName; town != "New York" {
+		t.Errorf("Expected addresses to be kept, got %q", town)
+	}
+
+	policy.Key = []byte("other")
+	c := loadPersonalSample(t)
+	Redact(c, policy)
+	if *c.FICustomerCreditTransfer.CreditTransferTransactionInfo[0].Debtor.Name == *tx.Debtor.Name {
+		t.Error("Expected other pseudonyms for another key")
+	}
+
+	if err := Redact(c, RedactionPolicy{Names: Pseudonymize}); err == nil {
+		t.Error("Expected an error for pseudonymizing without a key")
+	}
+	if err := Redact(*c, MaskPersonalData); err == nil {
+		t.Error("Expected an error for a non-pointer")
+	}
+}
+
+func TestRedactRemove(t *testing.T) {
+	doc := loadPersonalSample(t)
+	if err := Redact(doc, RedactionPolicy{ContactDetails: Remove, BirthDetails: Remove}); err != nil {
+		t.Fatalf("Redact failed: %v", err)
+	}
+	party := doc.FICustomerCreditTransfer.CreditTransferTransactionInfo[0].UltimateDebtor
+	if party.ContactDetails.PhoneNumber != nil || party.ContactDetails.EmailAddress != nil || party.ID.PrivateID.DateAndPlaceOfBirth.BirthDate != nil {
+		t.Errorf("Expected contact and birth details to be removed, got %+v", party)
+	}
+	if *party.Name != "Jane Doe" {
+		t.Errorf("Expected the name to be kept, got %q", *party.Name)
+	}
+}
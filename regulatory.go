@@ -0,0 +1,217 @@
+package iso20022
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// RegulatoryRequirement is what the central bank of a country requires in the
+// regulatory reporting (RgltryRptg) of cross-border payments
+type RegulatoryRequirement struct {
+	// Country is the ISO 3166 code of the country
+	Country string
+	// Authority is the name of the authority the reports are made to (Authrty/Nm)
+	Authority string
+	// Indicator is the side of the payment reported (DbtCdtRptgInd): CRED, DEBT or
+	// BOTH, "" to leave it out
+	Indicator string
+	// Codes are the codes the authority accepts (Dtls/Cd) with their meaning, nil to
+	// accept any code matching Pattern
+	Codes map[string]string
+	// Pattern is the pattern of the codes, "" when Codes lists them
+	Pattern string
+	// Required makes a report to the authority required for every payment between
+	// the country and another country
+	Required bool
+}
+
+// regulatoryRequirements gives the requirements of the countries with a balance of
+// payments or purpose code reporting. They give the patterns of the codes; register
+// a requirement with the codes the central bank publishes to check them fully.
+var (
+	regulatoryRequirementsMu sync.RWMutex
+	regulatoryRequirements   = map[string]RegulatoryRequirement{
+		// Balance of payments category, with its subcategory as in 401 or 512/01
+		"ZA": {Country: "ZA", Authority: "South African Reserve Bank", Indicator: "BOTH", Pattern: `^[0-9]{3}(/[0-9]{2})?$`, Required: true},
+		// RBI purpose code of the remittance, such as P1006
+		"IN": {Country: "IN", Authority: "Reserve Bank of India", Indicator: "BOTH", Pattern: `^[PS][0-9]{4}$`, Required: true},
+		// Purpose of payment code of the CBUAE, such as SAL for salaries
+		"AE": {Country: "AE", Authority: "Central Bank of the UAE", Indicator: "BOTH", Pattern: `^[A-Z]{3}$`, Required: true},
+		// SAMA purpose of transfer code
+		"SA": {Country: "SA", Authority: "Saudi Central Bank", Indicator: "BOTH", Pattern: `^[A-Z0-9]{2,4}$`},
+	}
+)
+
+// RegisterRegulatoryRequirement sets the requirement of a country, replacing any
+// requirement it has
+func RegisterRegulatoryRequirement(r RegulatoryRequirement) error {
+	r.Country = strings.ToUpper(r.Country)
+	if len(r.Country) != 2 {
+		return fmt.Errorf("iso20022: regulatory requirement for invalid country %q", r.Country)
+	}
+	if r.Pattern != "" {
+		if _, err := compilePattern(r.Pattern); err != nil {
+			return fmt.Errorf("iso20022: regulatory requirement for %s: %w", r.Country, err)
+		}
+	}
+	regulatoryRequirementsMu.Lock()
+	defer regulatoryRequirementsMu.Unlock()
+	regulatoryRequirements[r.Country] = r
+	return nil
+}
+
+// RegulatoryRequirementFor returns the requirement registered for a country
+func RegulatoryRequirementFor(country string) (RegulatoryRequirement, bool) {
+	regulatoryRequirementsMu.RLock()
+	defer regulatoryRequirementsMu.RUnlock()
+	r, ok := regulatoryRequirements[CanonicalCountryCode(country)]
+	return r, ok
+}
+
+// checkCode checks a code against the codes or the pattern of the requirement
+func (r RegulatoryRequirement) checkCode(code string) error {
+	if r.Codes != nil {
+		if _, ok := r.Codes[code]; ok {
+			return nil
+		}
+	} else if r.Pattern == "" || validatePattern(code, r.Pattern, "Cd") == nil {
+		return nil
+	}
+	return newValidationError("Cd", RuleRegulatory, "REGULATORY_CODE", code, r.Country)
+}
+
+// NewRegulatoryReporting returns the report of a payment to the authority of a
+// country, with the code of the payment and its amount, which may be nil. The
+// authority and the indicator are those of the requirement of the country; the code
+// is checked against it. The meaning of listed codes is given as information.
+func NewRegulatoryReporting(country, code string, amount *ActiveOrHistoricCurrencyAndAmount) (RegulatoryReporting3, error) {
+	r, ok := RegulatoryRequirementFor(country)
+	if !ok {
+		return RegulatoryReporting3{}, fmt.Errorf("iso20022: no regulatory requirement registered for %s", country)
+	}
+	if err := r.checkCode(code); err != nil {
+		return RegulatoryReporting3{}, err
+	}
+	details := StructuredRegulatoryReporting3{Country: &r.Country, Code: &code, Amount: amount}
+	if meaning := r.Codes[code]; meaning != "" {
+		details.Information = []string{meaning}
+	}
+	report := RegulatoryReporting3{
+		Authority: &RegulatoryAuthority2{Name: &r.Authority, Country: &r.Country},
+		Dtls:      []StructuredRegulatoryReporting3{details},
+	}
+	if r.Authority == "" {
+		report.Authority.Name = nil
+	}
+	if r.Indicator != "" {
+		report.DebitCreditReportingIndicator = &r.Indicator
+	}
+	return report, nil
+}
+
+// reportCountry returns the country a report is made to: the country of its details
+// or of its authority
+func reportCountry(report RegulatoryReporting3, details *StructuredRegulatoryReporting3) string {
+	if details != nil && details.Country != nil {
+		return CanonicalCountryCode(*details.Country)
+	}
+	if report.Authority != nil && report.Authority.Country != nil {
+		return CanonicalCountryCode(*report.Authority.Country)
+	}
+	return ""
+}
+
+// ValidateRegulatoryReporting checks the codes of a report against the requirement of
+// the country they are reported to. Reports to countries without a requirement are
+// not checked. Errors have paths from the RgltryRptg element.
+func ValidateRegulatoryReporting(report RegulatoryReporting3) error {
+	var errs ValidationErrors
+	for i := range report.Dtls {
+		details := &report.Dtls[i]
+		r, ok := RegulatoryRequirementFor(reportCountry(report, details))
+		if !ok || details.Code == nil {
+			continue
+		}
+		if err := r.checkCode(*details.Code); err != nil {
+			errs = append(errs, err.(ValidationError).at(fmt.Sprintf("Dtls[%d]/Cd", i+1)))
+		}
+	}
+	if errs.HasErrors() {
+		return errs
+	}
+	return nil
+}
+
+// RegulatoryReportingRule returns a profile rule checking the regulatory reporting of
+// the transactions of a message with ValidateRegulatoryReporting, and that the
+// transactions between a country whose requirement is Required and another country
+// are reported to it. The countries of a transaction are those of the postal
+// addresses of the debtor and creditor and of the BICs of their agents. Errors name
+// the scheme.
+func RegulatoryReportingRule(scheme string) ProfileRule {
+	return func(doc interface{}) ValidationErrors {
+		var errs ValidationErrors
+		visitStructs(reflect.ValueOf(doc), "", isReportingTransaction, func(path string, v reflect.Value) {
+			reports := v.FieldByName("RegulatoryReporting").Interface().([]RegulatoryReporting3)
+			reported := map[string]bool{}
+			for i, report := range reports {
+				if err := ValidateRegulatoryReporting(report); err != nil {
+					for _, e := range err.(ValidationErrors) {
+						e.Path = fmt.Sprintf("%s/RgltryRptg[%d]/%s", path, i+1, e.Path)
+						e.Message += " in " + scheme
+						errs = append(errs, e)
+					}
+				}
+				reported[reportCountry(report, nil)] = true
+				for j := range report.Dtls {
+					reported[reportCountry(report, &report.Dtls[j])] = true
+				}
+			}
+			countries := transactionCountries(v)
+			if len(countries) < 2 {
+				return
+			}
+			for _, country := range countries {
+				if r, ok := RegulatoryRequirementFor(country); ok && r.Required && !reported[country] {
+					e := newValidationError("RgltryRptg", RuleRegulatory, "REGULATORY_MISSING", country).at(path + "/RgltryRptg")
+					e.Message += " in " + scheme
+					errs = append(errs, e)
+				}
+			}
+		})
+		return errs
+	}
+}
+
+// isReportingTransaction reports whether t is a transaction with regulatory
+// reporting, such as CreditTransferTransaction39
+func isReportingTransaction(t reflect.Type) bool {
+	f, ok := t.FieldByName("RegulatoryReporting")
+	return ok && f.Type == reflect.TypeOf([]RegulatoryReporting3(nil))
+}
+
+// transactionCountries returns the sorted countries of the parties and agents of a
+// transaction
+func transactionCountries(v reflect.Value) []string {
+	set := map[string]bool{}
+	tx := v.Interface()
+	for _, path := range []string{"Dbtr/PstlAdr/Ctry", "Cdtr/PstlAdr/Ctry"} {
+		if country, _ := GetPath(tx, path); country != "" {
+			set[CanonicalCountryCode(country)] = true
+		}
+	}
+	for _, path := range []string{"DbtrAgt/FinInstnId/BICFI", "CdtrAgt/FinInstnId/BICFI"} {
+		if bic, _ := GetPath(tx, path); len(bic) >= 6 {
+			set[strings.ToUpper(bic[4:6])] = true
+		}
+	}
+	countries := make([]string, 0, len(set))
+	for country := range set {
+		countries = append(countries, country)
+	}
+	sort.Strings(countries)
+	return countries
+}
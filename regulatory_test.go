@@ -0,0 +1,73 @@
+package iso20022
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNewRegulatoryReporting(t *testing.T) {
+	report, err := NewRegulatoryReporting("za", "512/01", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *report.Authority.Country != "ZA" || *report.DebitCreditReportingIndicator != "BOTH" || *report.Dtls[0].Code != "512/01" {
+		t.Errorf("Expected a report to the South African Reserve Bank, got %+v", report)
+	}
+	if _, err := NewRegulatoryReporting("IN", "X1006", nil); err == nil || err.(ValidationError).Rule != RuleRegulatory {
+		t.Errorf("Expected an invalid purpose code to be refused, got %v", err)
+	}
+	if _, err := NewRegulatoryReporting("FR", "1", nil); err == nil {
+		t.Error("Expected a country without a requirement to be refused")
+	}
+
+	err = RegisterRegulatoryRequirement(RegulatoryRequirement{Country: "ng", Authority: "Central Bank of Nigeria", Codes: map[string]string{"FX01": "Import of goods"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		regulatoryRequirementsMu.Lock()
+		delete(regulatoryRequirements, "NG")
+		regulatoryRequirementsMu.Unlock()
+	})
+	report, err = NewRegulatoryReporting("NG", "FX01", nil)
+	if err != nil || report.DebitCreditReportingIndicator != nil || report.Dtls[0].Information[0] != "Import of goods" {
+		t.Errorf("Expected the meaning of the registered code to be given, got %+v, %v", report, err)
+	}
+	if _, err := NewRegulatoryReporting("NG", "FX02", nil); err == nil {
+		t.Error("Expected an unlisted code to be refused")
+	}
+	if err := RegisterRegulatoryRequirement(RegulatoryRequirement{Country: "NG", Pattern: "("}); err == nil {
+		t.Error("Expected an invalid pattern to be refused")
+	}
+}
+
+func TestRegulatoryReportingRule(t *testing.T) {
+	doc := loadPacs008Sample(t)
+	rule := RegulatoryReportingRule("CBPR+")
+	if errs := rule(doc); len(errs) != 0 {
+		t.Errorf("Expected a US to GB payment to need no report, got %v", errs)
+	}
+
+	tx := &doc.FICustomerCreditTransfer.CreditTransferTransactionInfo[0]
+	tx.CreditorAgent.FinancialInstitutionID.BankIdentifierCode = Ptr("FIRNZAJJ")
+	tx.Creditor.PostalAddress.Country = Ptr("ZA")
+	errs := rule(doc)
+	if len(errs) != 1 || errs[0].Location() != "FIToFICstmrCdtTrf/CdtTrfTxInf[1]/RgltryRptg" || !strings.Contains(errs[0].Message, "ZA") {
+		t.Errorf("Expected the missing ZA report to be reported, got %v", errs)
+	}
+
+	report, err := NewRegulatoryReporting("ZA", "101/01", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx.RegulatoryReporting = []RegulatoryReporting3{report}
+	if errs := rule(doc); len(errs) != 0 {
+		t.Errorf("Expected the reported payment to be accepted, got %v", errs)
+	}
+
+	*tx.RegulatoryReporting[0].Dtls[0].Code = "1O1"
+	errs = rule(doc)
+	if len(errs) != 1 || errs[0].Location() != "FIToFICstmrCdtTrf/CdtTrfTxInf[1]/RgltryRptg[1]/Dtls[1]/Cd" || errs[0].Rule != RuleRegulatory {
+		t.Errorf("Expected the invalid category to be reported, got %v", errs)
+	}
+}
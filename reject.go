@@ -0,0 +1,144 @@
+package iso20022
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RejectOption configures RejectFor
+type RejectOption func(*rejectOptions)
+
+type rejectOptions struct {
+	ids IDGenerator
+}
+
+// RejectIDs takes the MsgId of the report from ids rather than from a
+// ULIDGenerator
+func RejectIDs(ids IDGenerator) RejectOption {
+	return func(o *rejectOptions) {
+		o.ids = ids
+	}
+}
+
+// rejectedTransaction is what RejectFor reports of a transaction of the original
+type rejectedTransaction struct {
+	id          *PaymentIdentification7
+	instructing *BranchAndFinancialInstitutionIdentification6
+	instructed  *BranchAndFinancialInstitutionIdentification6
+}
+
+// RejectFor returns the pacs.002 rejecting original, a pacs.008.001.08 or
+// pacs.009.001.08, for the validation errors found in it, such as the Errors of
+// the report of Validate. Each transaction with errors under its CdtTrfTxInf is
+// reported with status RJCT and a StsRsnInf per StatusReasonCode of its errors. The
+// errors outside the transactions reject the whole message: they are reported in
+// the StsRsnInf of the original group, whose status is then RJCT. Otherwise the
+// group status is RJCT when every transaction is rejected and PART when some are.
+// The report goes back from the instructed agent to the instructing agent of the
+// original.
+func RejectFor(original interface{}, errs ValidationErrors, opts ...RejectOption) (*Pacs00200110Document, error) {
+	if len(errs) == 0 {
+		return nil, errors.New("no validation errors to reject the message for")
+	}
+	var o rejectOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.ids == nil {
+		ids, err := NewULIDGenerator("")
+		if err != nil {
+			return nil, err
+		}
+		o.ids = ids
+	}
+
+	var (
+		hdr     *GroupHeader93
+		root    string
+		msgType string
+		txs     []rejectedTransaction
+	)
+	switch doc := original.(type) {
+	case *Pacs00800108Document:
+		hdr, root, msgType = &doc.FICustomerCreditTransfer.GroupHeader, "FIToFICstmrCdtTrf", "pacs.008.001.08"
+		for i := range doc.FICustomerCreditTransfer.CreditTransferTransactionInfo {
+			tx := &doc.FICustomerCreditTransfer.CreditTransferTransactionInfo[i]
+			txs = append(txs, rejectedTransaction{id: &tx.PaymentID, instructing: tx.InstructingAgent, instructed: tx.InstructedAgent})
+		}
+	case *Pacs00900108Document:
+		hdr, root, msgType = &doc.FICreditTransfer.GroupHeader, "FICdtTrf", "pacs.009.001.08"
+		for i := range doc.FICreditTransfer.CreditTransferTransactionInfo {
+			tx := &doc.FICreditTransfer.CreditTransferTransactionInfo[i]
+			txs = append(txs, rejectedTransaction{id: &tx.PaymentID, instructing: tx.InstructingAgent, instructed: tx.InstructedAgent})
+		}
+	default:
+		return nil, fmt.Errorf("%w: cannot reject a %T", ErrUnknownMessage, original)
+	}
+
+	// Sort the errors out by transaction
+	var groupErrs ValidationErrors
+	txErrs := make(map[int]ValidationErrors)
+	prefix := root + "/CdtTrfTxInf["
+	for _, e := range errs {
+		location := e.Location()
+		if rest, ok := strings.CutPrefix(location, prefix); ok {
+			if end := strings.IndexByte(rest, ']'); end > 0 {
+				if n, err := strconv.Atoi(rest[:end]); err == nil && n >= 1 && n <= len(txs) {
+					txErrs[n-1] = append(txErrs[n-1], e)
+					continue
+				}
+			}
+		}
+		groupErrs = append(groupErrs, e)
+	}
+
+	msgID, err := o.ids.NextID()
+	if err != nil {
+		return nil, err
+	}
+	status, nbOfTxs := "RJCT", hdr.NumberOfTransactions
+	group := OriginalGroupHeader17{
+		OriginalMessageID:            hdr.MessageID,
+		OriginalMessageNameID:        msgType,
+		OriginalCreationDateTime:     hdr.CreationDateTime,
+		OriginalNumberOfTransactions: &nbOfTxs,
+		OriginalControlSum:           hdr.ControlSum,
+		StatusReasonInfo:             groupErrs.StatusReasons(),
+	}
+	if len(groupErrs) == 0 && len(txErrs) < len(txs) {
+		status = "PART"
+	}
+	group.GroupStatus = &status
+
+	report := FIToFIPaymentStatusReportV10{
+		GroupHeader: GroupHeader91{
+			MessageID:        msgID,
+			CreationDateTime: NewISODateTime(time.Now().UTC()),
+			InstructingAgent: hdr.InstructedAgent,
+			InstructedAgent:  hdr.InstructingAgent,
+		},
+		OriginalGroupInformationAndStatus: []OriginalGroupHeader17{group},
+	}
+	rejected := "RJCT"
+	for i, tx := range txs {
+		found, ok := txErrs[i]
+		if !ok {
+			continue
+		}
+		endToEndID := tx.id.EndToEndID
+		report.TransactionInfoAndStatus = append(report.TransactionInfoAndStatus, PaymentTransaction110{
+			OriginalInstructionID: tx.id.InstructionID,
+			OriginalEndToEndID:    &endToEndID,
+			OriginalTransactionID: tx.id.TransactionID,
+			OriginalUETR:          tx.id.UETR,
+			TransactionStatus:     &rejected,
+			StatusReasonInfo:      found.StatusReasons(),
+			InstructingAgent:      tx.instructed,
+			InstructedAgent:       tx.instructing,
+		})
+	}
+	return &Pacs00200110Document{FIPaymentStatusReport: report}, nil
+}
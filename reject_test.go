@@ -0,0 +1,81 @@
+package iso20022
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestRejectFor(t *testing.T) {
+	// The second of three transactions has an invalid creditor IBAN
+	data := threeTransactionPacs008(t, func(i int, tx string) string {
+		tx = strings.Replace(tx, "INV-2024-0042", "INV-2024-004"+string(rune('0'+i)), 1)
+		if i == 2 {
+			tx = strings.Replace(tx, "GB29NWBK60161331926819", "GB29NWBK60161331926818", 1)
+		}
+		return tx
+	})
+	doc := new(Pacs00800108Document)
+	if err := Unmarshal([]byte(data), doc); err != nil {
+		t.Fatal(err)
+	}
+	doc.FICustomerCreditTransfer.GroupHeader.NumberOfTransactions = "3"
+	report, err := Validate(context.Background(), doc)
+	if err != nil || len(report.Errors) == 0 {
+		t.Fatalf("Expected validation errors, got %v, %v", report, err)
+	}
+	ids, err := NewSequenceGenerator("CCCCGB2L-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rejection, err := RejectFor(doc, report.Errors, RejectIDs(ids))
+	if err != nil {
+		t.Fatalf("Failed to build the rejection: %v", err)
+	}
+	if err := rejection.Validate(); err != nil {
+		t.Errorf("Expected a valid pacs.002, got %v", err)
+	}
+
+	r := &rejection.FIPaymentStatusReport
+	if !strings.HasPrefix(r.GroupHeader.MessageID, "CCCCGB2L-") || r.GroupHeader.CreationDateTime.IsZero() {
+		t.Errorf("Expected a message identification from the generator, got %+v", r.GroupHeader)
+	}
+	group := r.OriginalGroupInformationAndStatus[0]
+	if group.OriginalMessageID != "BBBBUS33-20240315-0001" || group.OriginalMessageNameID != "pacs.008.001.08" || *group.GroupStatus != "PART" || len(group.StatusReasonInfo) != 0 {
+		t.Errorf("Expected a partial rejection of the original, got %+v", group)
+	}
+	if len(r.TransactionInfoAndStatus) != 1 {
+		t.Fatalf("Expected the second transaction to be rejected alone, got %+v", r.TransactionInfoAndStatus)
+	}
+	tx := r.TransactionInfoAndStatus[0]
+	if *tx.OriginalEndToEndID != "INV-2024-0042" || *tx.TransactionStatus != "RJCT" || *tx.StatusReasonInfo[0].Reason.Code != "AC03" ||
+		*tx.InstructingAgent.FinancialInstitutionID.BankIdentifierCode != "CCCCGB2L" || *tx.InstructedAgent.FinancialInstitutionID.BankIdentifierCode != "BBBBUS33" {
+		t.Errorf("Expected the transaction to be rejected for its creditor account back to its instructing agent, got %+v", tx)
+	}
+	statuses := rejection.TransactionStatuses()
+	if len(statuses) != 1 || statuses[0].Reasons[0] != "AC03" {
+		t.Errorf("Expected the reason to be read back, got %+v", statuses)
+	}
+
+	// An error outside the transactions rejects the whole message
+	groupErr := ValidationErrors{{Path: "FIToFICstmrCdtTrf/GrpHdr/CtrlSum", Rule: RuleControlSum, Message: "does not match"}}
+	rejection, err = RejectFor(doc, groupErr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	group = rejection.FIPaymentStatusReport.OriginalGroupInformationAndStatus[0]
+	if *group.GroupStatus != "RJCT" || *group.StatusReasonInfo[0].Reason.Code != "AM10" || len(rejection.FIPaymentStatusReport.TransactionInfoAndStatus) != 0 {
+		t.Errorf("Expected the group to be rejected, got %+v", group)
+	}
+	if rejection.FIPaymentStatusReport.GroupHeader.MessageID == "" {
+		t.Error("Expected a default message identification")
+	}
+
+	if _, err := RejectFor(doc, nil); err == nil {
+		t.Error("Expected a rejection without errors to fail")
+	}
+	if _, err := RejectFor(&Camt05400108Document{}, groupErr); !errors.Is(err, ErrUnknownMessage) {
+		t.Errorf("Expected a camt.054 to be refused, got %v", err)
+	}
+}
@@ -0,0 +1,173 @@
+package remittance
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// csvHeader lists the columns written by WriteCSV
+var csvHeader = []string{
+	"source", "end_to_end_id", "uetr", "document_type", "document_number", "document_date",
+	"creditor_reference", "currency", "due_payable", "discount", "credit_note", "tax",
+	"adjustment", "remitted", "adjustment_reasons", "unstructured",
+}
+
+// WriteCSV writes items as CSV with a header row. Adjustments are summed into a single signed column
+// and their reason codes are joined with "|".
+func WriteCSV(w io.Writer, items []Item) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+	for _, item := range items {
+		reasons := make([]string, 0, len(item.Adjustments))
+		for _, a := range item.Adjustments {
+			reasons = append(reasons, a.Reason)
+		}
+		record := []string{
+			item.Source,
+			item.EndToEndID,
+			item.UETR,
+			item.DocumentType,
+			item.DocumentNumber,
+			item.DocumentDate,
+			item.CreditorReference,
+			item.Currency,
+			formatAmount(item.DuePayable),
+			formatAmount(item.Discount),
+			formatAmount(item.CreditNote),
+			formatAmount(item.Tax),
+			formatAmount(item.AdjustmentTotal()),
+			formatAmount(item.Remitted),
+			strings.Join(reasons, "|"),
+			strings.Join(item.Unstructured, " "),
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// EDIOptions controls the interchange envelope and header segments written by WriteEDI820
+type EDIOptions struct {
+	SenderID      string    // ISA06, padded to 15 characters
+	ReceiverID    string    // ISA08, padded to 15 characters
+	ControlNumber int       // interchange, group and transaction set control number
+	PayerName     string    // N1*PR
+	PayeeName     string    // N1*PE
+	TraceNumber   string    // TRN02; defaults to the first item's end-to-end identification
+	Date          time.Time // interchange date; defaults to the current time
+}
+
+// X12 delimiters
+const (
+	ediElement    = "*"
+	ediSubElement = ":"
+	ediSegment    = "~"
+)
+
+// docTypeQualifiers maps ISO 20022 document types to X12 RMR01 reference qualifiers
+var docTypeQualifiers = map[string]string{
+	"CINV": "IV",
+	"CREN": "CM",
+	"DEBN": "IV",
+	"SOAC": "IV",
+	"PUOR": "PO",
+	"CMCN": "CT",
+}
+
+// WriteEDI820 writes items as a single X12 005010 820 transaction set wrapped in an ISA/GS envelope.
+// Each item becomes an RMR loop; the document date is written as DTM*003 and adjustments as ADX.
+func WriteEDI820(w io.Writer, items []Item, opts EDIOptions) error {
+	if opts.Date.IsZero() {
+		opts.Date = time.Now().UTC()
+	}
+	if opts.ControlNumber == 0 {
+		opts.ControlNumber = 1
+	}
+	trace := opts.TraceNumber
+	if trace == "" && len(items) > 0 {
+		trace = items[0].EndToEndID
+	}
+
+	var total float64
+	for _, item := range items {
+		total += item.Remitted
+	}
+
+	bw := bufio.NewWriter(w)
+	segment := func(elements ...string) {
+		bw.WriteString(strings.Join(elements, ediElement))
+		bw.WriteString(ediSegment)
+		bw.WriteString("\n")
+	}
+
+	ctrl := fmt.Sprintf("%09d", opts.ControlNumber)
+	segment("ISA", "00", pad("", 10), "00", pad("", 10), "ZZ", pad(opts.SenderID, 15), "ZZ", pad(opts.ReceiverID, 15),
+		opts.Date.Format("060102"), opts.Date.Format("1504"), "^", "00501", ctrl, "0", "P", ediSubElement)
+	segment("GS", "RA", opts.SenderID, opts.ReceiverID, opts.Date.Format("20060102"), opts.Date.Format("1504"),
+		strconv.Itoa(opts.ControlNumber), "X", "005010X218")
+
+	stCtrl := fmt.Sprintf("%04d", opts.ControlNumber)
+	count := 0
+	txSegment := func(elements ...string) {
+		count++
+		segment(elements...)
+	}
+	txSegment("ST", "820", stCtrl, "005010X218")
+	txSegment("BPR", "I", formatAmount(total), "C", "NON", "", "", "", "", "", "", "", "", "", "", "", opts.Date.Format("20060102"))
+	txSegment("TRN", "3", sanitize(trace))
+	if opts.PayerName != "" {
+		txSegment("N1", "PR", sanitize(opts.PayerName))
+	}
+	if opts.PayeeName != "" {
+		txSegment("N1", "PE", sanitize(opts.PayeeName))
+	}
+	txSegment("ENT", "1")
+	for _, item := range items {
+		qualifier := docTypeQualifiers[item.DocumentType]
+		if qualifier == "" {
+			qualifier = "IV"
+		}
+		reference := item.DocumentNumber
+		if reference == "" {
+			reference = item.CreditorReference
+		}
+		txSegment("RMR", qualifier, sanitize(reference), "", formatAmount(item.Remitted), formatAmount(item.DuePayable), formatAmount(item.Discount))
+		if date, err := time.Parse("2006-01-02", item.DocumentDate); err == nil {
+			txSegment("DTM", "003", date.Format("20060102"))
+		}
+		for _, adj := range item.Adjustments {
+			txSegment("ADX", formatAmount(adj.Amount), sanitize(adj.Reason))
+		}
+	}
+	count++
+	segment("SE", strconv.Itoa(count), stCtrl)
+	segment("GE", "1", strconv.Itoa(opts.ControlNumber))
+	segment("IEA", "1", ctrl)
+
+	return bw.Flush()
+}
+
+func formatAmount(v float64) string {
+	return strconv.FormatFloat(v, 'f', 2, 64)
+}
+
+func pad(s string, n int) string {
+	if len(s) >= n {
+		return s[:n]
+	}
+	return s + strings.Repeat(" ", n-len(s))
+}
+
+// sanitize removes X12 delimiters from free text
+func sanitize(s string) string {
+	return strings.NewReplacer(ediElement, " ", ediSubElement, " ", ediSegment, " ").Replace(s)
+}
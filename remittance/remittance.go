@@ -0,0 +1,256 @@
+// Package remittance extracts structured remittance information from payment and cash management
+// messages into a flat, normalized form suitable for accounts receivable reconciliation, and exports
+// it as CSV or as an ANSI X12 820 payment order/remittance advice.
+package remittance
+
+import (
+	"fmt"
+
+	"github.com/ckbaum/iso20022-go"
+)
+
+// Adjustment is a signed adjustment applied to a referred document. Debit adjustments reduce the
+// amount due and are reported with a negative Amount.
+type Adjustment struct {
+	Amount float64
+	Reason string
+	Info   string
+}
+
+// Item is one remitted document, such as an invoice or credit note, together with the payment that
+// settled it.
+type Item struct {
+	Source            string // message identifier, e.g. "pacs.008"
+	EndToEndID        string
+	UETR              string
+	DocumentType      string // ExternalDocumentType code, e.g. CINV, CREN
+	DocumentNumber    string
+	DocumentDate      string // ISODate
+	CreditorReference string
+	Currency          string
+	DuePayable        float64
+	Discount          float64
+	CreditNote        float64
+	Tax               float64
+	Remitted          float64
+	Adjustments       []Adjustment
+	Unstructured      []string
+}
+
+// AdjustmentTotal returns the signed sum of the item's adjustments
+func (i Item) AdjustmentTotal() float64 {
+	var total float64
+	for _, a := range i.Adjustments {
+		total += a.Amount
+	}
+	return total
+}
+
+// Extract walks a supported message and returns its remittance items in document order.
+// Supported messages are pacs.008.001.08, camt.054.001.08 and remt.001.001.05.
+func Extract(doc interface{}) ([]Item, error) {
+	switch d := doc.(type) {
+	case *iso20022.Pacs00800108Document:
+		return fromPacs008(d), nil
+	case iso20022.Pacs00800108Document:
+		return fromPacs008(&d), nil
+	case *iso20022.Camt05400108Document:
+		return fromCamt054(d), nil
+	case iso20022.Camt05400108Document:
+		return fromCamt054(&d), nil
+	case *iso20022.Remt00100105Document:
+		return fromRemt001(d), nil
+	case iso20022.Remt00100105Document:
+		return fromRemt001(&d), nil
+	}
+	return nil, fmt.Errorf("remittance: unsupported message %T", doc)
+}
+
+func fromPacs008(d *iso20022.Pacs00800108Document) []Item {
+	var items []Item
+	for _, tx := range d.FICustomerCreditTransfer.CreditTransferTransactionInfo {
+		if tx.RemittanceInfo == nil {
+			continue
+		}
+		base := Item{
+			Source:     "pacs.008",
+			EndToEndID: tx.PaymentID.EndToEndID,
+			UETR:       deref(tx.PaymentID.UETR),
+			Currency:   tx.InterbankSettlementAmount.Currency,
+		}
+		items = append(items, legacyItems(base, tx.RemittanceInfo)...)
+	}
+	return items
+}
+
+func fromCamt054(d *iso20022.Camt05400108Document) []Item {
+	var items []Item
+	for _, ntfctn := range d.BankDebitCreditNotification.Notification {
+		for _, ntry := range ntfctn.Entry {
+			for _, dtls := range ntry.EntryDetails {
+				for _, tx := range dtls.TransactionDetails {
+					if tx.RemittanceInfo == nil {
+						continue
+					}
+					base := Item{Source: "camt.054", Currency: ntry.Amount.Currency}
+					if tx.Amount != nil {
+						base.Currency = tx.Amount.Currency
+					}
+					if tx.References != nil {
+						base.EndToEndID = deref(tx.References.EndToEndID)
+					}
+					items = append(items, items16(base, tx.RemittanceInfo.Structured, tx.RemittanceInfo.Unstructured)...)
+				}
+			}
+		}
+	}
+	return items
+}
+
+func fromRemt001(d *iso20022.Remt00100105Document) []Item {
+	var items []Item
+	for _, rmt := range d.RemittanceAdvice.RemittanceInfo {
+		base := Item{Source: "remt.001"}
+		if info := rmt.OriginalPaymentInfo; info != nil {
+			base.EndToEndID = deref(info.References.EndToEndID)
+			base.UETR = deref(info.References.UETR)
+			if info.Amount != nil {
+				base.Currency = info.Amount.Currency
+			}
+		}
+		items = append(items, items16(base, rmt.Structured, nil)...)
+	}
+	return items
+}
+
+// legacyItems flattens the remittance information used by pacs.008.001.08
+func legacyItems(base Item, rmt *iso20022.RemittanceInfo) []Item {
+	var items []Item
+	for _, strd := range rmt.Structured {
+		item := base
+		if strd.CreditorReferenceInfo != nil {
+			item.CreditorReference = deref(strd.CreditorReferenceInfo.Reference)
+		}
+		if amt := strd.ReferredDocumentAmount; amt != nil {
+			item.DuePayable = amount(amt.DuePayableAmount, &item.Currency)
+			item.CreditNote = amount(amt.CreditNoteAmount, &item.Currency)
+			item.Remitted = amount(amt.RemittedAmount, &item.Currency)
+			for _, dscnt := range amt.DiscountAppliedAmount {
+				item.Discount += float64(dscnt.Amount.Value)
+			}
+			for _, tax := range amt.TaxAmount {
+				item.Tax += float64(tax.Amount.Value)
+			}
+			for _, adj := range amt.AdjustmentAmountAndReason {
+				item.Adjustments = append(item.Adjustments, adjustment(adj.Amount, adj.CreditDebitIndicator, adj.Reason, adj.AdditionalInformation))
+			}
+		}
+		if strd.AdditionalRemittanceInfo != nil {
+			item.Unstructured = []string{*strd.AdditionalRemittanceInfo}
+		}
+		if len(strd.ReferredDocumentInfo) == 0 {
+			items = append(items, item)
+			continue
+		}
+		for _, doc := range strd.ReferredDocumentInfo {
+			docItem := item
+			docItem.DocumentNumber = deref(doc.Number)
+			docItem.DocumentDate = date(doc.RelatedDate)
+			if doc.Type != nil {
+				docItem.DocumentType = codeOrProprietary(doc.Type.CodeOrProprietary.Code, doc.Type.CodeOrProprietary.Proprietary)
+			}
+			items = append(items, docItem)
+		}
+	}
+	if len(rmt.Structured) == 0 && len(rmt.Unstructured) > 0 {
+		item := base
+		item.Unstructured = rmt.Unstructured
+		items = append(items, item)
+	}
+	return items
+}
+
+// items16 flattens StructuredRemittanceInfo16 as used by camt.054 and remt.001
+func items16(base Item, structured []iso20022.StructuredRemittanceInfo16, unstructured []string) []Item {
+	var items []Item
+	for _, strd := range structured {
+		item := base
+		if strd.CreditorReferenceInfo != nil {
+			item.CreditorReference = deref(strd.CreditorReferenceInfo.Reference)
+		}
+		if amt := strd.ReferredDocumentAmount; amt != nil {
+			item.DuePayable = amount(amt.DuePayableAmount, &item.Currency)
+			item.CreditNote = amount(amt.CreditNoteAmount, &item.Currency)
+			item.Remitted = amount(amt.RemittedAmount, &item.Currency)
+			for _, dscnt := range amt.DiscountAppliedAmount {
+				item.Discount += float64(dscnt.Amount.Value)
+			}
+			for _, tax := range amt.TaxAmount {
+				item.Tax += float64(tax.Amount.Value)
+			}
+			for _, adj := range amt.AdjustmentAmountAndReason {
+				item.Adjustments = append(item.Adjustments, adjustment(adj.Amount, adj.CreditDebitIndicator, adj.Reason, adj.AdditionalInfo))
+			}
+		}
+		item.Unstructured = strd.AdditionalRemittanceInfo
+		if len(strd.ReferredDocumentInfo) == 0 {
+			items = append(items, item)
+			continue
+		}
+		for _, doc := range strd.ReferredDocumentInfo {
+			docItem := item
+			docItem.DocumentNumber = deref(doc.Number)
+			docItem.DocumentDate = date(doc.RelatedDate)
+			if doc.Type != nil {
+				docItem.DocumentType = codeOrProprietary(doc.Type.CodeOrProprietary.Code, doc.Type.CodeOrProprietary.Proprietary)
+			}
+			items = append(items, docItem)
+		}
+	}
+	if len(structured) == 0 && len(unstructured) > 0 {
+		item := base
+		item.Unstructured = unstructured
+		items = append(items, item)
+	}
+	return items
+}
+
+func adjustment(amt iso20022.ActiveOrHistoricCurrencyAndAmount, cdtDbt *iso20022.CreditDebitCode, reason, info *string) Adjustment {
+	v := float64(amt.Value)
+	if cdtDbt != nil && *cdtDbt == iso20022.CreditDebitDBIT {
+		v = -v
+	}
+	return Adjustment{Amount: v, Reason: deref(reason), Info: deref(info)}
+}
+
+// amount returns the value of amt and records its currency when none is known yet
+func amount(amt *iso20022.ActiveOrHistoricCurrencyAndAmount, currency *string) float64 {
+	if amt == nil {
+		return 0
+	}
+	if *currency == "" {
+		*currency = amt.Currency
+	}
+	return float64(amt.Value)
+}
+
+func codeOrProprietary(code, prtry *string) string {
+	if code != nil {
+		return *code
+	}
+	return deref(prtry)
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func date(d *iso20022.ISODate) string {
+	if d == nil {
+		return ""
+	}
+	return d.String()
+}
@@ -0,0 +1,135 @@
+package remittance
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ckbaum/iso20022-go"
+)
+
+func sampleAdvice(t *testing.T) *iso20022.Remt00100105Document {
+	t.Helper()
+	dbit := iso20022.CreditDebitDBIT
+	date := iso20022.NewISODate(2024, time.February, 1)
+	tx := iso20022.CreditTransferTransaction39{
+		PaymentID:                 iso20022.PaymentIdentification7{EndToEndID: "E2E-42"},
+		InterbankSettlementAmount: iso20022.ActiveCurrencyAndAmount{Value: 970, Currency: "USD"},
+	}
+	advice, err := iso20022.NewRemittanceAdvice("RMT-1", &tx, []iso20022.StructuredRemittanceInfo16{{
+		ReferredDocumentInfo: []iso20022.ReferredDocumentInfo7{{
+			Type:        &iso20022.ReferredDocumentType4{CodeOrProprietary: iso20022.ReferredDocumentType3{Code: iso20022.Ptr("CINV")}},
+			Number:      iso20022.Ptr("INV-1001"),
+			RelatedDate: &date,
+		}},
+		ReferredDocumentAmount: &iso20022.RemittanceAmount2{
+			DuePayableAmount:      &iso20022.ActiveOrHistoricCurrencyAndAmount{Value: 1000, Currency: "USD"},
+			DiscountAppliedAmount: []iso20022.DiscountAmountAndType1{{Amount: iso20022.ActiveOrHistoricCurrencyAndAmount{Value: 20, Currency: "USD"}}},
+			AdjustmentAmountAndReason: []iso20022.DocumentAdjustment1{{
+				Amount:               iso20022.ActiveOrHistoricCurrencyAndAmount{Value: 10, Currency: "USD"},
+				CreditDebitIndicator: &dbit,
+				Reason:               iso20022.Ptr("DMG"),
+			}},
+			RemittedAmount: &iso20022.ActiveOrHistoricCurrencyAndAmount{Value: 970, Currency: "USD"},
+		},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return advice
+}
+
+func TestExtract(t *testing.T) {
+	items, err := Extract(sampleAdvice(t))
+	if err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+	if len(items) != 1 {
+		t.Fatalf("Expected 1 item, got %d", len(items))
+	}
+	item := items[0]
+	if item.DocumentNumber != "INV-1001" || item.DocumentType != "CINV" || item.EndToEndID != "E2E-42" {
+		t.Errorf("Unexpected item references: %+v", item)
+	}
+	if item.DuePayable != 1000 || item.Discount != 20 || item.Remitted != 970 || item.AdjustmentTotal() != -10 {
+		t.Errorf("Unexpected item amounts: %+v", item)
+	}
+
+	t.Run("pacs.008", func(t *testing.T) {
+		doc := &iso20022.Pacs00800108Document{
+			FICustomerCreditTransfer: iso20022.FIToFICustomerCreditTransferV08{
+				CreditTransferTransactionInfo: []iso20022.CreditTransferTransaction39{{
+					PaymentID:                 iso20022.PaymentIdentification7{EndToEndID: "E2E-7"},
+					InterbankSettlementAmount: iso20022.ActiveCurrencyAndAmount{Value: 50, Currency: "EUR"},
+					RemittanceInfo: &iso20022.RemittanceInfo{
+						Structured: []iso20022.StructuredRemittanceInfo{{
+							ReferredDocumentInfo: []iso20022.ReferredDocumentInfo{{Number: iso20022.Ptr("A1")}, {Number: iso20022.Ptr("A2")}},
+						}},
+					},
+				}},
+			},
+		}
+		items, err := Extract(doc)
+		if err != nil {
+			t.Fatalf("Extract failed: %v", err)
+		}
+		if len(items) != 2 || items[1].DocumentNumber != "A2" || items[1].Currency != "EUR" {
+			t.Errorf("Expected two EUR items, got %+v", items)
+		}
+	})
+
+	t.Run("Unsupported", func(t *testing.T) {
+		if _, err := Extract("nope"); err == nil {
+			t.Error("Expected error for unsupported message")
+		}
+	})
+}
+
+func TestWriteCSV(t *testing.T) {
+	items, _ := Extract(sampleAdvice(t))
+
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, items); err != nil {
+		t.Fatalf("WriteCSV failed: %v", err)
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("Failed to read CSV: %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("Expected header and one row, got %d rows", len(records))
+	}
+	if records[1][4] != "INV-1001" || records[1][12] != "-10.00" || records[1][14] != "DMG" {
+		t.Errorf("Unexpected CSV row: %v", records[1])
+	}
+}
+
+func TestWriteEDI820(t *testing.T) {
+	items, _ := Extract(sampleAdvice(t))
+
+	var buf bytes.Buffer
+	err := WriteEDI820(&buf, items, EDIOptions{SenderID: "PAYER", ReceiverID: "PAYEE", ControlNumber: 7, PayeeName: "ACME*CORP"})
+	if err != nil {
+		t.Fatalf("WriteEDI820 failed: %v", err)
+	}
+	out := buf.String()
+
+	for _, want := range []string{
+		"ST*820*0007*005010X218~",
+		"BPR*I*970.00*C*NON",
+		"TRN*3*E2E-42~",
+		"N1*PE*ACME CORP~",
+		"RMR*IV*INV-1001**970.00*1000.00*20.00~",
+		"DTM*003*20240201~",
+		"ADX*-10.00*DMG~",
+		"SE*9*0007~",
+		"IEA*1*000000007~",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected %q in output:\n%s", want, out)
+		}
+	}
+}
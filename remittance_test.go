@@ -0,0 +1,73 @@
+package iso20022
+
+import (
+	"encoding/xml"
+	"testing"
+)
+
+func TestRemittanceAdviceLinkage(t *testing.T) {
+	tx := CreditTransferTransaction39{
+		PaymentID: PaymentIdentification7{
+			EndToEndID: "INV-2024-001",
+			UETR:       Ptr("eb6305c9-1f7f-49de-aed0-16487c27b42d"),
+		},
+		InterbankSettlementAmount: ActiveCurrencyAndAmount{Value: 4200, Currency: "EUR"},
+	}
+	invoice := StructuredRemittanceInfo16{
+		ReferredDocumentInfo: []ReferredDocumentInfo7{{Number: Ptr("INV-2024-001")}},
+	}
+
+	advice, err := NewRemittanceAdvice("RMT001", &tx, []StructuredRemittanceInfo16{invoice})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := advice.Validate(); err != nil {
+		t.Fatalf("Expected valid remt.001, got: %v", err)
+	}
+
+	xmlData, err := xml.Marshal(advice)
+	if err != nil {
+		t.Fatalf("Failed to marshal remt.001: %v", err)
+	}
+	var parsed Remt00100105Document
+	if err := xml.Unmarshal(xmlData, &parsed); err != nil {
+		t.Fatalf("Failed to unmarshal remt.001: %v", err)
+	}
+
+	if got := parsed.RemittancesFor(&tx); len(got) != 1 {
+		t.Fatalf("Expected one linked remittance, got %d", len(got))
+	}
+
+	t.Run("UETR mismatch", func(t *testing.T) {
+		other := tx
+		other.PaymentID.UETR = Ptr("1b0d1e3c-6a0a-4f8e-9d5c-0a9d6c1b2e3f")
+		if got := parsed.RemittancesFor(&other); len(got) != 0 {
+			t.Errorf("Expected no linked remittance for a different UETR, got %d", len(got))
+		}
+	})
+
+	t.Run("Linked by end-to-end ID", func(t *testing.T) {
+		other := tx
+		other.PaymentID.UETR = nil
+		if got := parsed.RemittancesFor(&other); len(got) != 1 {
+			t.Errorf("Expected remittance linked by EndToEndId, got %d", len(got))
+		}
+	})
+
+	t.Run("Linked by remittance ID", func(t *testing.T) {
+		rmt := RemittanceInformation21{RemittanceID: Ptr("RMTID-9")}
+		other := tx
+		other.PaymentID.UETR = nil
+		other.RelatedRemittanceInfo = []RemittanceLocation{{RemittanceID: Ptr("RMTID-9")}}
+		if !rmt.RefersTo(&other) {
+			t.Error("Expected remittance to be linked by RmtId")
+		}
+	})
+
+	t.Run("Invalid UETR", func(t *testing.T) {
+		parsed.RemittanceAdvice.RemittanceInfo[0].OriginalPaymentInfo.References.UETR = Ptr("not-a-uuid")
+		if err := parsed.Validate(); err == nil {
+			t.Error("Expected validation error for invalid UETR")
+		}
+	})
+}
@@ -0,0 +1,288 @@
+// Package resend detects the business messages missing from the stream received
+// from a sender, asks for them again with admi.006 resend requests and puts the
+// resent messages back in order.
+//
+// A Manager follows the sequence numbers of the messages passed to Receive, read
+// from their BizMsgIdr by default. A message received ahead of its predecessors
+// opens a gap of missing sequence numbers and is held back; ResendRequest builds
+// the admi.006 asking for the gaps not requested yet, or requested longer than the
+// timeout ago. Once a resent message fills a gap, Receive returns it with the held
+// back messages that follow it, so that the stream is delivered in sequence.
+package resend
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ckbaum/iso20022-go"
+)
+
+// Errors returned by a Manager
+var (
+	ErrDuplicate  = errors.New("resend: message already received")
+	ErrNoSequence = errors.New("resend: no sequence number in the business message identifier")
+)
+
+// Message is a received business message
+type Message struct {
+	// Sequence is the sequence number of the message; Receive reads it from
+	// BusinessMessageID when it is zero
+	Sequence          uint64
+	BusinessMessageID string
+	Header            *iso20022.BusinessApplicationHeaderV02
+	Document          interface{}
+}
+
+// Gap is a range of missing sequence numbers
+type Gap struct {
+	From, To    uint64
+	Requests    int       // number of resend requests sent
+	RequestedAt time.Time // when the latest resend request was sent
+}
+
+// SequenceFunc reads the sequence number of a message from its BizMsgIdr
+type SequenceFunc func(businessMessageID string) (uint64, error)
+
+// TrailingSequence reads the sequence number from the digits after the last hyphen
+// of the identification, such as 42 of BBBBUS33-20240315-000042 from an
+// iso20022.SequenceGenerator
+func TrailingSequence(businessMessageID string) (uint64, error) {
+	digits := businessMessageID[strings.LastIndexByte(businessMessageID, '-')+1:]
+	n, err := strconv.ParseUint(digits, 10, 64)
+	if err != nil || n == 0 {
+		return 0, fmt.Errorf("%w: %q", ErrNoSequence, businessMessageID)
+	}
+	return n, nil
+}
+
+// Option configures a Manager
+type Option func(*Manager)
+
+// WithSequence sets how the sequence number of a message is read from its
+// BizMsgIdr, TrailingSequence by default
+func WithSequence(f SequenceFunc) Option {
+	return func(m *Manager) {
+		m.sequence = f
+	}
+}
+
+// WithFirst sets the sequence number of the first message expected, 1 by default
+func WithFirst(seq uint64) Option {
+	return func(m *Manager) {
+		m.next, m.high = seq, seq
+	}
+}
+
+// WithTimeout sets how long a gap waits for the resent messages before it is
+// requested again; 5 minutes by default
+func WithTimeout(d time.Duration) Option {
+	return func(m *Manager) {
+		m.timeout = d
+	}
+}
+
+// WithClock sets the function the manager reads the time from, time.Now by default
+func WithClock(now func() time.Time) Option {
+	return func(m *Manager) {
+		m.now = now
+	}
+}
+
+// Manager tracks the messages received from a sender. It is safe for concurrent
+// use.
+type Manager struct {
+	mu        sync.Mutex
+	recipient iso20022.PartyIdentification136
+	ids       iso20022.IDGenerator
+	sequence  SequenceFunc
+	timeout   time.Duration
+	now       func() time.Time
+	next      uint64 // next sequence number to deliver
+	high      uint64 // sequence number following the highest received
+	gaps      []*Gap
+	held      map[uint64]Message
+}
+
+// New returns a manager of the messages received by recipient, whose resend
+// requests take their identifications from ids
+func New(recipient iso20022.PartyIdentification136, ids iso20022.IDGenerator, opts ...Option) *Manager {
+	m := &Manager{
+		recipient: recipient,
+		ids:       ids,
+		sequence:  TrailingSequence,
+		timeout:   5 * time.Minute,
+		now:       time.Now,
+		next:      1,
+		high:      1,
+		held:      make(map[uint64]Message),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// Receive records a received message and returns the messages that can now be
+// delivered in sequence: none when the message is ahead of a gap, else the message
+// followed by the messages held back behind it. A message whose sequence number
+// was already received, such as a resent message that had arrived after all,
+// fails with ErrDuplicate.
+func (m *Manager) Receive(msg Message) ([]Message, error) {
+	if msg.Sequence == 0 {
+		id := msg.BusinessMessageID
+		if id == "" && msg.Header != nil {
+			id = msg.Header.BusinessMessageID
+		}
+		seq, err := m.sequence(id)
+		if err != nil {
+			return nil, err
+		}
+		msg.Sequence = seq
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	seq := msg.Sequence
+	switch {
+	case seq >= m.high:
+		if seq > m.high {
+			m.gaps = append(m.gaps, &Gap{From: m.high, To: seq - 1})
+		}
+		m.high = seq + 1
+	case seq < m.next || !m.fill(seq):
+		return nil, fmt.Errorf("%w: sequence %d", ErrDuplicate, seq)
+	}
+	m.held[seq] = msg
+	return m.release(), nil
+}
+
+// fill removes seq from the gap it belongs to, and reports whether it was missing
+func (m *Manager) fill(seq uint64) bool {
+	for i, g := range m.gaps {
+		if seq < g.From || seq > g.To {
+			continue
+		}
+		switch {
+		case g.From == g.To:
+			m.gaps = append(m.gaps[:i], m.gaps[i+1:]...)
+		case seq == g.From:
+			g.From++
+		case seq == g.To:
+			g.To--
+		default:
+			after := *g
+			after.From, g.To = seq+1, seq-1
+			m.gaps = append(m.gaps[:i+1], append([]*Gap{&after}, m.gaps[i+1:]...)...)
+		}
+		return true
+	}
+	return false
+}
+
+// release returns the held back messages from the next sequence number on, until
+// the first one missing
+func (m *Manager) release() []Message {
+	var out []Message
+	for {
+		msg, ok := m.held[m.next]
+		if !ok {
+			return out
+		}
+		delete(m.held, m.next)
+		out = append(out, msg)
+		m.next++
+	}
+}
+
+// Gaps returns copies of the gaps, lowest sequence numbers first
+func (m *Manager) Gaps() []Gap {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	out := make([]Gap, 0, len(m.gaps))
+	for _, g := range m.gaps {
+		out = append(out, *g)
+	}
+	return out
+}
+
+// ResendRequest builds the admi.006 asking for the gaps never requested or
+// requested at least the timeout ago, with a search criteria per gap: its sequence
+// number, or its range of sequence numbers. The gaps are recorded as requested. It
+// returns nil when no gap is due.
+func (m *Manager) ResendRequest() (*iso20022.Admi00600101Document, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	var due []*Gap
+	for _, g := range m.gaps {
+		if g.Requests == 0 || !now.Before(g.RequestedAt.Add(m.timeout)) {
+			due = append(due, g)
+		}
+	}
+	if len(due) == 0 {
+		return nil, nil
+	}
+	msgID, err := m.ids.NextID()
+	if err != nil {
+		return nil, err
+	}
+	created := iso20022.NewISODateTime(now.UTC())
+	req := iso20022.ResendRequestV01{
+		MessageHeader: iso20022.MessageHeader7{MessageID: msgID, CreationDateTime: &created},
+	}
+	for _, g := range due {
+		criteria := iso20022.ResendSearchCriteria2{Recipient: m.recipient}
+		from := strconv.FormatUint(g.From, 10)
+		if g.From == g.To {
+			criteria.SequenceNumber = &from
+		} else {
+			criteria.SequenceRange = &iso20022.SequenceRange1{FromToSequence: []iso20022.SequenceRange1Admi{
+				{FromSequence: from, ToSequence: strconv.FormatUint(g.To, 10)},
+			}}
+		}
+		req.ResendSearchCriteria = append(req.ResendSearchCriteria, criteria)
+		g.Requests++
+		g.RequestedAt = now
+	}
+	return &iso20022.Admi00600101Document{ResendRequest: req}, nil
+}
+
+// Abandon gives up on the messages of the gaps between from and to, which the
+// sender cannot resend, and returns the held back messages that can now be
+// delivered
+func (m *Manager) Abandon(from, to uint64) []Message {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var kept []*Gap
+	for _, g := range m.gaps {
+		if g.To < from || g.From > to {
+			kept = append(kept, g)
+			continue
+		}
+		if g.From < from {
+			kept = append(kept, &Gap{From: g.From, To: from - 1, Requests: g.Requests, RequestedAt: g.RequestedAt})
+		}
+		if g.To > to {
+			kept = append(kept, &Gap{From: to + 1, To: g.To, Requests: g.Requests, RequestedAt: g.RequestedAt})
+		}
+	}
+	m.gaps = kept
+
+	// Skip the next sequence numbers no gap is waiting for
+	for len(m.held) > 0 {
+		if _, ok := m.held[m.next]; ok {
+			break
+		}
+		if i := sort.Search(len(m.gaps), func(i int) bool { return m.gaps[i].To >= m.next }); i < len(m.gaps) && m.gaps[i].From <= m.next {
+			break
+		}
+		m.next++
+	}
+	return m.release()
+}
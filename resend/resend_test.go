@@ -0,0 +1,139 @@
+package resend
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ckbaum/iso20022-go"
+)
+
+type sequence struct{ n int }
+
+func (s *sequence) NextID() (string, error) {
+	s.n++
+	return fmt.Sprintf("RSNDREQ-%d", s.n), nil
+}
+
+func recipient() iso20022.PartyIdentification136 {
+	bic := "CCCCGB2L"
+	return iso20022.PartyIdentification136{ID: iso20022.PartyIdentification120{AnyBIC: &bic}}
+}
+
+func message(seq int) Message {
+	return Message{BusinessMessageID: fmt.Sprintf("BBBBUS33-20240315-%06d", seq)}
+}
+
+func sequences(msgs []Message) []uint64 {
+	var out []uint64
+	for _, msg := range msgs {
+		out = append(out, msg.Sequence)
+	}
+	return out
+}
+
+func TestResendGaps(t *testing.T) {
+	now := time.Date(2024, 3, 15, 10, 0, 0, 0, time.UTC)
+	m := New(recipient(), &sequence{}, WithClock(func() time.Time { return now }))
+
+	receive := func(seq int, want ...uint64) {
+		t.Helper()
+		got, err := m.Receive(message(seq))
+		if err != nil {
+			t.Fatalf("Failed to receive %d: %v", seq, err)
+		}
+		if !reflect.DeepEqual(sequences(got), want) {
+			t.Errorf("Receiving %d: expected %v to be delivered, got %v", seq, want, sequences(got))
+		}
+	}
+	receive(1, 1)
+	receive(2, 2)
+	receive(5)
+	receive(7)
+	if gaps := m.Gaps(); len(gaps) != 2 || gaps[0].From != 3 || gaps[0].To != 4 || gaps[1].From != 6 || gaps[1].To != 6 {
+		t.Fatalf("Expected gaps 3-4 and 6, got %+v", gaps)
+	}
+
+	req, err := m.ResendRequest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := req.Validate(); err != nil {
+		t.Errorf("Expected a valid admi.006, got %v", err)
+	}
+	criteria := req.ResendRequest.ResendSearchCriteria
+	if req.ResendRequest.MessageHeader.MessageID != "RSNDREQ-1" || len(criteria) != 2 ||
+		criteria[0].SequenceRange.FromToSequence[0] != (iso20022.SequenceRange1Admi{FromSequence: "3", ToSequence: "4"}) ||
+		*criteria[1].SequenceNumber != "6" || *criteria[1].Recipient.ID.AnyBIC != "CCCCGB2L" {
+		t.Errorf("Expected the gaps to be requested, got %+v", req.ResendRequest)
+	}
+	data, err := xml.Marshal(req)
+	if err != nil || !strings.Contains(string(data), "<SeqRg><FrToSeq><FrSeq>3</FrSeq><ToSeq>4</ToSeq></FrToSeq></SeqRg>") {
+		t.Errorf("Expected the range to marshal, got %s, %v", data, err)
+	}
+
+	// Requested gaps wait for the timeout before they are requested again
+	if req, err := m.ResendRequest(); req != nil || err != nil {
+		t.Errorf("Expected no request before the timeout, got %+v, %v", req, err)
+	}
+
+	// The resent messages fill the gaps and release the messages held back
+	receive(4)
+	receive(3, 3, 4, 5)
+	if _, err := m.Receive(message(4)); !errors.Is(err, ErrDuplicate) {
+		t.Errorf("Expected a duplicate, got %v", err)
+	}
+	now = now.Add(5 * time.Minute)
+	req, err = m.ResendRequest()
+	if err != nil || len(req.ResendRequest.ResendSearchCriteria) != 1 || *req.ResendRequest.ResendSearchCriteria[0].SequenceNumber != "6" {
+		t.Errorf("Expected the remaining gap to be requested again, got %+v, %v", req, err)
+	}
+	if gaps := m.Gaps(); gaps[0].Requests != 2 {
+		t.Errorf("Expected two requests, got %+v", gaps)
+	}
+	receive(6, 6, 7)
+	if gaps := m.Gaps(); len(gaps) != 0 {
+		t.Errorf("Expected no gaps left, got %+v", gaps)
+	}
+}
+
+func TestResendSplitAndAbandon(t *testing.T) {
+	m := New(recipient(), &sequence{})
+	for _, seq := range []int{1, 10, 5} {
+		if _, err := m.Receive(message(seq)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if gaps := m.Gaps(); len(gaps) != 2 || gaps[0].From != 2 || gaps[0].To != 4 || gaps[1].From != 6 || gaps[1].To != 9 {
+		t.Fatalf("Expected the gap to be split around 5, got %+v", gaps)
+	}
+	if got := sequences(m.Abandon(2, 4)); !reflect.DeepEqual(got, []uint64{5}) {
+		t.Errorf("Expected 5 to be released, got %v", got)
+	}
+	if got := sequences(m.Abandon(0, 100)); !reflect.DeepEqual(got, []uint64{10}) {
+		t.Errorf("Expected 10 to be released, got %v", got)
+	}
+	if _, err := m.Receive(message(7)); !errors.Is(err, ErrDuplicate) {
+		t.Errorf("Expected an abandoned message to be refused, got %v", err)
+	}
+	if got, err := m.Receive(message(11)); err != nil || !reflect.DeepEqual(sequences(got), []uint64{11}) {
+		t.Errorf("Expected 11 to be delivered, got %v, %v", sequences(got), err)
+	}
+}
+
+func TestSequence(t *testing.T) {
+	if _, err := New(recipient(), &sequence{}).Receive(Message{BusinessMessageID: "NOSEQ"}); !errors.Is(err, ErrNoSequence) {
+		t.Errorf("Expected no sequence number, got %v", err)
+	}
+	m := New(recipient(), &sequence{}, WithFirst(100), WithSequence(func(id string) (uint64, error) {
+		return TrailingSequence(strings.TrimPrefix(id, "X"))
+	}))
+	hdr := &iso20022.BusinessApplicationHeaderV02{BusinessMessageID: "X100"}
+	if got, err := m.Receive(Message{Header: hdr}); err != nil || len(got) != 1 || got[0].Sequence != 100 {
+		t.Errorf("Expected the header identification to be read, got %+v, %v", got, err)
+	}
+}
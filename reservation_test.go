@@ -0,0 +1,86 @@
+package iso20022
+
+import (
+	"encoding/xml"
+	"testing"
+)
+
+func TestReservationMessages(t *testing.T) {
+	account := SettlementAccount("RTGSEURDEFFXXX002")
+	reservation := NewReservationIdentification("HPAR", account)
+
+	t.Run("Get reservation", func(t *testing.T) {
+		doc, err := NewGetReservation("GET001", []ReservationIdentification2{reservation})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := doc.Validate(); err != nil {
+			t.Fatalf("Expected valid camt.046, got: %v", err)
+		}
+
+		xmlData, err := xml.Marshal(doc)
+		if err != nil {
+			t.Fatalf("Failed to marshal camt.046: %v", err)
+		}
+		var parsed Camt04600105Document
+		if err := xml.Unmarshal(xmlData, &parsed); err != nil {
+			t.Fatalf("Failed to unmarshal camt.046: %v", err)
+		}
+		crit := parsed.GetReservation.ReservationQueryDefinition.Criteria.NewCriteria.SearchCriteria
+		if len(crit) != 1 || *crit[0].Type.Code != "HPAR" {
+			t.Errorf("Expected one HPAR search criterion, got %+v", crit)
+		}
+	})
+
+	t.Run("Modify reservation", func(t *testing.T) {
+		doc, err := NewModifyReservation("MOD001", reservation, ActiveCurrencyAndAmount{Value: 5000000, Currency: "EUR"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := doc.Validate(); err != nil {
+			t.Fatalf("Expected valid camt.048, got: %v", err)
+		}
+
+		doc.ModifyReservation.ReservationID.Default = &reservation
+		if err := doc.Validate(); err == nil {
+			t.Error("Expected validation error when both current and default reservation are set")
+		}
+	})
+
+	t.Run("Delete reservation", func(t *testing.T) {
+		doc, err := NewDeleteReservation("DEL001", reservation)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := doc.Validate(); err != nil {
+			t.Fatalf("Expected valid camt.049, got: %v", err)
+		}
+
+		bad := NewReservationIdentification("XXXX", account)
+		doc.DeleteReservation.CurrentReservation.Current = &bad
+		if err := doc.Validate(); err == nil {
+			t.Error("Expected validation error for unknown reservation type")
+		}
+	})
+
+	t.Run("Return reservation", func(t *testing.T) {
+		doc := Camt04700106Document{
+			ReturnReservation: ReturnReservationV06{
+				MessageHeader: MessageHeader7{MessageID: "RTR001"},
+				ReportOrError: ReservationOrError7Choice{
+					BusinessReport: &ReservationReport7{
+						CurrentReservation: []ReservationReport6{{ReservationID: reservation}},
+					},
+				},
+			},
+		}
+		if err := doc.Validate(); err != nil {
+			t.Fatalf("Expected valid camt.047, got: %v", err)
+		}
+
+		doc.ReturnReservation.ReportOrError.OperationalError = []ErrorHandling5{{ErrorCode: "X020"}}
+		if err := doc.Validate(); err == nil {
+			t.Error("Expected validation error when both report and error are present")
+		}
+	})
+}
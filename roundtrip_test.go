@@ -0,0 +1,267 @@
+package iso20022
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// roundTripDocuments maps each fixture directory under testdata/roundtrip to the
+// document type its samples unmarshal into. Every directory must be registered, and
+// every registered message must have a corpus, so nothing is silently skipped.
+var roundTripDocuments = map[string]func() interface{}{
+	"pacs.008.001.02": func() interface{} { return new(Pacs00800102Document) },
+	"pacs.008.001.06": func() interface{} { return new(Pacs00800106Document) },
+	"pacs.008.001.08": func() interface{} { return new(Pacs00800108Document) },
+	"pacs.008.001.10": func() interface{} { return new(Pacs00800110Document) },
+	"pacs.008.001.12": func() interface{} { return new(Pacs00800112Document) },
+	"pacs.002.001.03": func() interface{} { return new(Pacs00200103Document) },
+	"pacs.002.001.10": func() interface{} { return new(Pacs00200110Document) },
+	"pacs.002.001.12": func() interface{} { return new(Pacs00200112Document) },
+	"pacs.002.001.14": func() interface{} { return new(Pacs00200114Document) },
+	"pacs.004.001.10": func() interface{} { return new(Pacs00400110Document) },
+	"camt.056.001.08": func() interface{} { return new(Camt05600108Document) },
+	"camt.056.001.11": func() interface{} { return new(Camt05600111Document) },
+	"camt.029.001.09": func() interface{} { return new(Camt02900109Document) },
+	"camt.029.001.13": func() interface{} { return new(Camt02900113Document) },
+	"camt.035.001.05": func() interface{} { return new(Camt03500105Document) },
+	"camt.050.001.05": func() interface{} { return new(Camt05000105Document) },
+	"camt.052.001.08": func() interface{} { return new(Camt05200108Document) },
+	"camt.054.001.08": func() interface{} { return new(Camt05400108Document) },
+	"pain.001.001.09": func() interface{} { return new(Pain00100109Document) },
+	"remt.001.001.05": func() interface{} { return new(Remt00100105Document) },
+	"acmt.023.001.03": func() interface{} { return new(Acmt02300103Document) },
+	"admi.005.001.01": func() interface{} { return new(Admi00500101Document) },
+	"head.001.001.02": func() interface{} { return new(BusinessApplicationHeaderDocument) },
+}
+
+// TestRoundTripGoldenFiles unmarshals every sample in testdata/roundtrip, marshals it
+// again and compares the result with the original. A field that is missing from a
+// struct, or tagged with the wrong name, shows up as a dropped element.
+func TestRoundTripGoldenFiles(t *testing.T) {
+	dirs, err := os.ReadDir(filepath.Join("testdata", "roundtrip"))
+	if err != nil {
+		t.Fatalf("Failed to read round-trip corpus: %v", err)
+	}
+
+	covered := make(map[string]bool)
+	for _, dir := range dirs {
+		if !dir.IsDir() {
+			continue
+		}
+		covered[dir.Name()] = true
+		newDocument, ok := roundTripDocuments[dir.Name()]
+		if !ok {
+			t.Errorf("No document type registered for testdata/roundtrip/%s", dir.Name())
+			continue
+		}
+
+		files, err := filepath.Glob(filepath.Join("testdata", "roundtrip", dir.Name(), "*.xml"))
+		if err != nil {
+			t.Fatalf("Failed to list %s fixtures: %v", dir.Name(), err)
+		}
+		if len(files) == 0 {
+			t.Errorf("Expected at least one fixture in testdata/roundtrip/%s", dir.Name())
+		}
+
+		for _, file := range files {
+			file := file
+			t.Run(dir.Name()+"/"+filepath.Base(file), func(t *testing.T) {
+				original, err := os.ReadFile(file)
+				if err != nil {
+					t.Fatalf("Failed to read fixture: %v", err)
+				}
+
+				doc := newDocument()
+				if err := xml.Unmarshal(original, doc); err != nil {
+					t.Fatalf("Failed to unmarshal fixture: %v", err)
+				}
+				remarshaled, err := xml.Marshal(doc)
+				if err != nil {
+					t.Fatalf("Failed to marshal document: %v", err)
+				}
+
+				for _, diff := range diffXML(t, original, remarshaled) {
+					t.Error(diff)
+				}
+			})
+		}
+	}
+
+	for name := range roundTripDocuments {
+		if !covered[name] {
+			t.Errorf("No fixtures in testdata/roundtrip/%s", name)
+		}
+	}
+}
+
+// xmlNode is a namespace-resolved element tree used to compare documents
+// independently of prefixes, attribute order and insignificant whitespace.
+type xmlNode struct {
+	Name     xml.Name
+	Attrs    []xml.Attr
+	Text     string
+	Children []*xmlNode
+}
+
+// parseXMLTree builds an xmlNode tree from the document's root element.
+func parseXMLTree(t *testing.T, data []byte) *xmlNode {
+	t.Helper()
+	dec := xml.NewDecoder(bytes.NewReader(data))
+	var stack []*xmlNode
+	var root *xmlNode
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Failed to parse XML: %v", err)
+		}
+		switch tok := tok.(type) {
+		case xml.StartElement:
+			node := &xmlNode{Name: tok.Name}
+			for _, attr := range tok.Attr {
+				if attr.Name.Space == "xmlns" || (attr.Name.Space == "" && attr.Name.Local == "xmlns") {
+					continue
+				}
+				node.Attrs = append(node.Attrs, attr)
+			}
+			sort.Slice(node.Attrs, func(i, j int) bool {
+				return node.Attrs[i].Name.Space+node.Attrs[i].Name.Local < node.Attrs[j].Name.Space+node.Attrs[j].Name.Local
+			})
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent.Children = append(parent.Children, node)
+			} else {
+				root = node
+			}
+			stack = append(stack, node)
+		case xml.EndElement:
+			node := stack[len(stack)-1]
+			node.Text = strings.TrimSpace(node.Text)
+			stack = stack[:len(stack)-1]
+		case xml.CharData:
+			if len(stack) > 0 {
+				stack[len(stack)-1].Text += string(tok)
+			}
+		}
+	}
+	if root == nil {
+		t.Fatalf("XML document has no root element")
+	}
+	return root
+}
+
+// diffXML compares two documents element by element and returns one message per
+// difference, each prefixed with the path of the element concerned.
+func diffXML(t *testing.T, want, got []byte) []string {
+	t.Helper()
+	root := parseXMLTree(t, want)
+	return diffXMLNodes("/"+root.Name.Local, root, parseXMLTree(t, got))
+}
+
+func diffXMLNodes(path string, want, got *xmlNode) []string {
+	if want.Name != got.Name {
+		return []string{fmt.Sprintf("%s: element {%s}%s re-marshaled as {%s}%s",
+			path, want.Name.Space, want.Name.Local, got.Name.Space, got.Name.Local)}
+	}
+
+	var diffs []string
+	if !sameXMLAttrs(want.Attrs, got.Attrs) {
+		diffs = append(diffs, fmt.Sprintf("%s: attributes %v re-marshaled as %v", path, want.Attrs, got.Attrs))
+	}
+	if len(want.Children) == 0 && len(got.Children) == 0 && !sameXMLValue(want.Text, got.Text) {
+		diffs = append(diffs, fmt.Sprintf("%s: value %q re-marshaled as %q", path, want.Text, got.Text))
+	}
+
+	// Walk both child sequences together. An element missing from the re-marshaled
+	// output is reported and skipped, so later siblings are still compared.
+	total := make(map[string]int)
+	for _, child := range want.Children {
+		total[child.Name.Local]++
+	}
+	seen := make(map[string]int)
+	j := 0
+	for _, child := range want.Children {
+		seen[child.Name.Local]++
+		childPath := path + "/" + child.Name.Local
+		if total[child.Name.Local] > 1 {
+			childPath += fmt.Sprintf("[%d]", seen[child.Name.Local])
+		}
+		switch {
+		case j < len(got.Children) && got.Children[j].Name == child.Name:
+			diffs = append(diffs, diffXMLNodes(childPath, child, got.Children[j])...)
+			j++
+		case containsXMLNode(got.Children[j:], child.Name):
+			return append(diffs, fmt.Sprintf("%s: element order changed, found %s", childPath, got.Children[j].Name.Local))
+		default:
+			diffs = append(diffs, fmt.Sprintf("%s: element dropped on round-trip", childPath))
+		}
+	}
+	for _, extra := range got.Children[j:] {
+		diffs = append(diffs, fmt.Sprintf("%s/%s: element added on round-trip", path, extra.Name.Local))
+	}
+	return diffs
+}
+
+func containsXMLNode(nodes []*xmlNode, name xml.Name) bool {
+	for _, node := range nodes {
+		if node.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func sameXMLAttrs(want, got []xml.Attr) bool {
+	if len(want) != len(got) {
+		return false
+	}
+	for i := range want {
+		if want[i].Name != got[i].Name || !sameXMLValue(want[i].Value, got[i].Value) {
+			return false
+		}
+	}
+	return true
+}
+
+// sameXMLValue compares simple content the way the schema does for decimals, by
+// value, so 100.50 matches 100.5. Everything else, date times included, must come
+// back as it was written.
+func sameXMLValue(want, got string) bool {
+	if want == got {
+		return true
+	}
+	if a, ok := new(big.Rat).SetString(want); ok {
+		if b, ok := new(big.Rat).SetString(got); ok {
+			return a.Cmp(b) == 0
+		}
+	}
+	return false
+}
+
+func TestSameXMLValue(t *testing.T) {
+	tests := []struct {
+		want, got string
+		same      bool
+	}{
+		{"100.50", "100.5", true},
+		{"2024-03-15T09:30:47.000Z", "2024-03-15T09:30:47.000Z", true},
+		{"2023-01-01T10:00:00", "2023-01-01T10:00:00Z", false},
+		{"2024-03-15T09:30:47.120+01:00", "2024-03-15T09:30:47.12+01:00", false},
+		{"2024-03-15T09:31:05+00:00", "2024-03-15T09:31:05Z", false},
+	}
+	for _, tt := range tests {
+		if got := sameXMLValue(tt.want, tt.got); got != tt.same {
+			t.Errorf("sameXMLValue(%q, %q) = %v, want %v", tt.want, tt.got, got, tt.same)
+		}
+	}
+}
@@ -0,0 +1,284 @@
+package iso20022
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// RTPProfile is the RTP network of The Clearing House: single USD credit transfers
+// between participants identified by their routing numbers, with their pacs.002
+// status reports, requests for payment (pain.013 and pain.014), requests for
+// information (camt.026), acknowledgements (camt.035) and message rejections
+// (admi.002). Status and rejection reasons must be RTP reason codes. The amount cap
+// is the network maximum; participants usually agree lower ones.
+var RTPProfile = Profile{
+	Name:    "RTP",
+	Limits:  Limits{MaxTransactions: 1, MaxRemittanceLength: 140, MaxAmount: rtpMaxAmount},
+	Rules:   []ProfileRule{rtpCreditTransferRule, rtpStatusReportRule, rtpRequestForPaymentRule, rtpRequestForPaymentStatusRule, rtpInvestigationRule, rtpRejectionRule},
+	Timeout: 20 * time.Second,
+}
+
+// rtpMaxAmount is the network limit of a single RTP payment in USD
+const rtpMaxAmount = 10000000
+
+// RTPClearingSystem is the proprietary clearing system identification of RTP in
+// SttlmInf/ClrSys
+const RTPClearingSystem = "TCH"
+
+// RTPReasonCodes are the reason codes RTP accepts in rejections, with their meaning
+var RTPReasonCodes = map[string]string{
+	"AC02": "invalid debtor account number",
+	"AC03": "invalid creditor account number",
+	"AC04": "closed account number",
+	"AC06": "blocked account",
+	"AC07": "closed creditor account number",
+	"AC10": "invalid debtor account currency",
+	"AC11": "invalid creditor account currency",
+	"AC13": "invalid debtor account type",
+	"AC14": "invalid creditor account type",
+	"AG01": "transaction forbidden",
+	"AG03": "transaction type not supported",
+	"AM02": "amount exceeds the allowed maximum",
+	"AM04": "insufficient funds",
+	"AM09": "wrong amount",
+	"AM12": "invalid amount",
+	"AM13": "amount exceeds the clearing system limit",
+	"AM14": "amount exceeds the agreed limit",
+	"BE04": "missing creditor address",
+	"BE06": "unknown end customer",
+	"BE07": "missing debtor address",
+	"BE10": "invalid debtor country",
+	"BE11": "invalid creditor country",
+	"BE13": "invalid debtor country of residence",
+	"BE17": "invalid creditor identifier",
+	"DS0H": "signer not allowed to sign for this account",
+	"DT04": "future date not supported",
+	"DUPL": "duplicate payment",
+	"FF02": "syntax error",
+	"FF03": "invalid payment type information",
+	"FF08": "invalid end-to-end identification",
+	"MD07": "end customer deceased",
+	"NARR": "narrative",
+	"RC01": "invalid bank identifier",
+	"RC02": "invalid bank identifier format",
+	"RC03": "invalid debtor bank identifier",
+	"RC04": "invalid creditor bank identifier",
+	"1100": "other reasons",
+	"9909": "central switch system malfunction",
+	"9910": "instructed agent signed off",
+	"9912": "recipient connection not available",
+	"9934": "instructing agent signed off",
+	"9946": "instructing agent suspended",
+	"9947": "instructed agent suspended",
+	"9948": "central switch service suspended",
+}
+
+// RTPAgent returns the agent identification of the RTP participant with the given
+// routing number
+func RTPAgent(routingNumber string) BranchAndFinancialInstitutionIdentification6 {
+	return AgentFromClearingSystem("USABA", routingNumber, "")
+}
+
+// RTPParticipantID returns the routing number identifying an RTP participant. It
+// fails when the agent is not identified by a valid USABA clearing system member
+// identification.
+func RTPParticipantID(agent *BranchAndFinancialInstitutionIdentification6) (string, error) {
+	return memberIDOf(agent, "USABA")
+}
+
+// rtpCreditTransferRule checks a pacs.008 against the RTP rules
+func rtpCreditTransferRule(doc interface{}) ValidationErrors {
+	d, ok := doc.(*Pacs00800108Document)
+	if !ok {
+		return nil
+	}
+	f := &d.FICustomerCreditTransfer
+	hdr := &f.GroupHeader
+	var errs ValidationErrors
+	if hdr.InstructingAgent != nil {
+		errs = append(errs, rtpParticipant("GrpHdr/InstgAgt", hdr.InstructingAgent)...)
+	}
+	if hdr.InstructedAgent != nil {
+		errs = append(errs, rtpParticipant("GrpHdr/InstdAgt", hdr.InstructedAgent)...)
+	}
+	if hdr.SettlementInfo.SettlementMethod != "CLRG" {
+		errs = append(errs, rtpError("GrpHdr/SttlmInf/SttlmMtd", "must be CLRG"))
+	}
+	if cs := hdr.SettlementInfo.ClearingSystem; cs == nil || cs.Proprietary == nil || *cs.Proprietary != RTPClearingSystem {
+		errs = append(errs, rtpError("GrpHdr/SttlmInf/ClrSys/Prtry", "must be "+RTPClearingSystem))
+	}
+
+	for i := range f.CreditTransferTransactionInfo {
+		tx := &f.CreditTransferTransactionInfo[i]
+		var txErrs ValidationErrors
+		if tx.InterbankSettlementAmount.Currency != "USD" {
+			txErrs = append(txErrs, rtpError("IntrBkSttlmAmt/@Ccy", fmt.Sprintf("must be USD, got %s", tx.InterbankSettlementAmount.Currency)))
+		}
+		if tx.ChargeBearer != "SLEV" {
+			txErrs = append(txErrs, rtpError("ChrgBr", "must be SLEV"))
+		}
+		if tx.AcceptanceDateTime == nil {
+			txErrs = append(txErrs, rtpError("AccptncDtTm", "is required"))
+		}
+		// The instructing and instructed agents may be given once in the group header
+		if tx.InstructingAgent != nil || hdr.InstructingAgent == nil {
+			txErrs = append(txErrs, rtpParticipant("InstgAgt", tx.InstructingAgent)...)
+		}
+		if tx.InstructedAgent != nil || hdr.InstructedAgent == nil {
+			txErrs = append(txErrs, rtpParticipant("InstdAgt", tx.InstructedAgent)...)
+		}
+		txErrs = append(txErrs, rtpParticipant("DbtrAgt", &tx.DebtorAgent)...)
+		txErrs = append(txErrs, rtpParticipant("CdtrAgt", &tx.CreditorAgent)...)
+		if tx.Debtor.Name == nil {
+			txErrs = append(txErrs, rtpError("Dbtr/Nm", "is required"))
+		}
+		if tx.Creditor.Name == nil {
+			txErrs = append(txErrs, rtpError("Cdtr/Nm", "is required"))
+		}
+		txErrs = append(txErrs, rtpAccount("DbtrAcct", tx.DebtorAccount)...)
+		txErrs = append(txErrs, rtpAccount("CdtrAcct", tx.CreditorAccount)...)
+		errs = append(errs, txErrs.within(fmt.Sprintf("CdtTrfTxInf[%d]", i+1))...)
+	}
+	return errs.within("FIToFICstmrCdtTrf")
+}
+
+// rtpStatusReportRule checks the statuses and reasons of a pacs.002
+func rtpStatusReportRule(doc interface{}) ValidationErrors {
+	d, ok := doc.(*Pacs00200110Document)
+	if !ok {
+		return nil
+	}
+	var errs ValidationErrors
+	for i, tx := range d.FIPaymentStatusReport.TransactionInfoAndStatus {
+		path := fmt.Sprintf("TxInfAndSts[%d]", i+1)
+		var status string
+		if tx.TransactionStatus != nil {
+			status = *tx.TransactionStatus
+		}
+		switch status {
+		case "ACTC", "ACWP", "ACCP", "ACSC", "ACCC", "PDNG":
+		case "RJCT":
+			errs = append(errs, rtpReason(path+"/StsRsnInf", tx.StatusReasonInfo)...)
+		default:
+			errs = append(errs, rtpError(path+"/TxSts", fmt.Sprintf("%q is not an RTP transaction status", status)))
+		}
+	}
+	return errs.within("FIToFIPmtStsRpt")
+}
+
+// rtpRequestForPaymentRule checks a pain.013 request for payment
+func rtpRequestForPaymentRule(doc interface{}) ValidationErrors {
+	d, ok := doc.(*Pain01300107Document)
+	if !ok {
+		return nil
+	}
+	var errs ValidationErrors
+	for i := range d.CreditorPaymentActivationRequest.PaymentInfo {
+		pmt := &d.CreditorPaymentActivationRequest.PaymentInfo[i]
+		path := fmt.Sprintf("PmtInf[%d]", i+1)
+		errs = append(errs, rtpParticipant(path+"/DbtrAgt", &pmt.DebtorAgent)...)
+		for j := range pmt.CreditTransferTransaction {
+			tx := &pmt.CreditTransferTransaction[j]
+			txPath := fmt.Sprintf("%s/CdtTrfTx[%d]", path, j+1)
+			if amt := tx.Amount.InstructedAmount; amt == nil || amt.Currency != "USD" {
+				errs = append(errs, rtpError(txPath+"/Amt/InstdAmt", "must be an amount in USD"))
+			} else if DecimalRat(amt.Value).Cmp(DecimalRat(rtpMaxAmount)) > 0 {
+				errs = append(errs, rtpError(txPath+"/Amt/InstdAmt", fmt.Sprintf("must not exceed %s", formatRat(DecimalRat(rtpMaxAmount)))))
+			}
+			errs = append(errs, rtpParticipant(txPath+"/CdtrAgt", &tx.CreditorAgent)...)
+		}
+	}
+	return errs.within("CdtrPmtActvtnReq")
+}
+
+// rtpRequestForPaymentStatusRule checks the rejection reasons of a pain.014
+func rtpRequestForPaymentStatusRule(doc interface{}) ValidationErrors {
+	d, ok := doc.(*Pain01400107Document)
+	if !ok {
+		return nil
+	}
+	var errs ValidationErrors
+	for i, pmt := range d.CreditorPaymentActivationStatusReport.OriginalPaymentInfoAndStatus {
+		for j, tx := range pmt.TransactionInfoAndStatus {
+			if tx.TransactionStatus != nil && *tx.TransactionStatus == "RJCT" {
+				errs = append(errs, rtpReason(fmt.Sprintf("OrgnlPmtInfAndSts[%d]/TxInfAndSts[%d]/StsRsnInf", i+1, j+1), tx.StatusReasonInfo)...)
+			}
+		}
+	}
+	return errs.within("CdtrPmtActvtnReqStsRpt")
+}
+
+// rtpInvestigationRule checks that the assigner and assignee of camt.026 requests
+// for information and camt.035 acknowledgements are RTP participants
+func rtpInvestigationRule(doc interface{}) ValidationErrors {
+	var root string
+	var assignment *CaseAssignment5
+	switch d := doc.(type) {
+	case *Camt02600107Document:
+		root, assignment = "UblToApply", &d.UnableToApply.Assignment
+	case *Camt03500105Document:
+		root, assignment = "PrtryFrmtInvstgtn", &d.ProprietaryFormatInvestigation.Assignment
+	default:
+		return nil
+	}
+	var errs ValidationErrors
+	errs = append(errs, rtpParticipant("Assgnmt/Assgnr/Agt", assignment.Assigner.Agent)...)
+	errs = append(errs, rtpParticipant("Assgnmt/Assgne/Agt", assignment.Assignee.Agent)...)
+	return errs.within(root)
+}
+
+// rtpRejectionRule checks the reason of an admi.002 message rejection
+func rtpRejectionRule(doc interface{}) ValidationErrors {
+	d, ok := doc.(*Admi00200101Document)
+	if !ok {
+		return nil
+	}
+	if reason := d.MessageRejection.Reason.RejectingPartyReason; RTPReasonCodes[reason] == "" {
+		return ValidationErrors{rtpError("admi.002.001.01/Rsn/RjctgPtyRsn", fmt.Sprintf("%q is not an RTP reason code", reason))}
+	}
+	return nil
+}
+
+func rtpError(path, message string) ValidationError {
+	return ValidationError{Field: path[strings.LastIndexByte(path, '/')+1:], Path: path, Message: message + " in RTP"}
+}
+
+// rtpParticipant checks that an agent is identified by its routing number
+func rtpParticipant(path string, agent *BranchAndFinancialInstitutionIdentification6) ValidationErrors {
+	if _, err := RTPParticipantID(agent); err != nil {
+		return ValidationErrors{rtpError(path+"/FinInstnId/ClrSysMmbId", "must identify an RTP participant: "+err.Error())}
+	}
+	return nil
+}
+
+// rtpAccount checks that an account is identified by an account number
+func rtpAccount(path string, account *CashAccount38) ValidationErrors {
+	if account == nil || account.ID.Other == nil || account.ID.Other.ID == "" {
+		return ValidationErrors{rtpError(path+"/Id/Othr/Id", "is required")}
+	}
+	if n := len(account.ID.Other.ID); n > 17 {
+		return ValidationErrors{rtpError(path+"/Id/Othr/Id", fmt.Sprintf("must be at most 17 characters, got %d", n))}
+	}
+	return nil
+}
+
+// rtpReason checks that a rejection has an RTP reason code
+func rtpReason(path string, reasons []StatusReasonInfo12) ValidationErrors {
+	var code string
+	if len(reasons) > 0 && reasons[0].Reason != nil {
+		switch {
+		case reasons[0].Reason.Code != nil:
+			code = *reasons[0].Reason.Code
+		case reasons[0].Reason.Proprietary != nil:
+			code = *reasons[0].Reason.Proprietary
+		}
+	}
+	if code == "" {
+		return ValidationErrors{rtpError(path+"/Rsn", "is required for a rejection")}
+	}
+	if RTPReasonCodes[code] == "" {
+		return ValidationErrors{rtpError(path+"/Rsn", fmt.Sprintf("%q is not an RTP reason code", code))}
+	}
+	return nil
+}
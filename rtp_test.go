@@ -0,0 +1,133 @@
+package iso20022
+
+import (
+	"encoding/xml"
+	"os"
+	"testing"
+	"time"
+)
+
+// loadRTPSample returns the pacs.008 sample made to follow the RTP rules
+func loadRTPSample(t *testing.T) *Pacs00800108Document {
+	t.Helper()
+	doc := loadPacs008Sample(t)
+	hdr := &doc.FICustomerCreditTransfer.GroupHeader
+	hdr.SettlementInfo.SettlementMethod = "CLRG"
+	hdr.SettlementInfo.ClearingSystem = &ClearingSystemIdentificationSecondary{Proprietary: Ptr(RTPClearingSystem)}
+	tx := &doc.FICustomerCreditTransfer.CreditTransferTransactionInfo[0]
+	sender, receiver := RTPAgent("021000021"), RTPAgent("011000015")
+	tx.ChargeBearer = "SLEV"
+	accepted := NewISODateTime(time.Date(2024, 3, 15, 9, 0, 0, 0, time.UTC))
+	tx.AcceptanceDateTime = &accepted
+	tx.InstructingAgent, tx.InstructedAgent = &sender, &receiver
+	tx.DebtorAgent, tx.CreditorAgent = sender, receiver
+	tx.CreditorAccount.ID = AccountIdentification4{Other: &GenericAccountIdentification1{ID: "987654321"}}
+	return doc
+}
+
+func TestRTPProfile(t *testing.T) {
+	profile, ok := LookupProfile("RTP")
+	if !ok {
+		t.Fatal("Expected the RTP profile to be registered")
+	}
+	if err := profile.Check(loadRTPSample(t)); err != nil {
+		t.Fatalf("Expected the RTP sample to pass, got %v", err)
+	}
+
+	errs, ok := profile.Check(loadPacs008Sample(t)).(ValidationErrors)
+	if !ok {
+		t.Fatal("Expected ValidationErrors for the sample without RTP details")
+	}
+	got := map[string]bool{}
+	for _, e := range errs {
+		got[e.Location()] = true
+	}
+	for _, location := range []string{
+		"FIToFICstmrCdtTrf/CdtTrfTxInf[1]/InstgAgt/FinInstnId/ClrSysMmbId",
+		"FIToFICstmrCdtTrf/GrpHdr/SttlmInf/ClrSys/Prtry",
+		"FIToFICstmrCdtTrf/CdtTrfTxInf[1]/ChrgBr",
+		"FIToFICstmrCdtTrf/CdtTrfTxInf[1]/AccptncDtTm",
+		"FIToFICstmrCdtTrf/CdtTrfTxInf[1]/CdtrAgt/FinInstnId/ClrSysMmbId",
+		"FIToFICstmrCdtTrf/CdtTrfTxInf[1]/CdtrAcct/Id/Othr/Id",
+	} {
+		if !got[location] {
+			t.Errorf("Expected an error at %s, got %v", location, errs)
+		}
+	}
+
+	doc := loadRTPSample(t)
+	doc.FICustomerCreditTransfer.CreditTransferTransactionInfo[0].InterbankSettlementAmount.Value = 20000000
+	if err := profile.Check(doc); err == nil {
+		t.Error("Expected the amount cap to be enforced")
+	}
+}
+
+func TestRTPParticipantID(t *testing.T) {
+	agent := RTPAgent("021000021")
+	if id, err := RTPParticipantID(&agent); err != nil || id != "021000021" {
+		t.Errorf("Expected 021000021, got %q, %v", id, err)
+	}
+	agent = RTPAgent("021000022")
+	if _, err := RTPParticipantID(&agent); err == nil {
+		t.Error("Expected an error for a routing number with a bad checksum")
+	}
+	bic := BranchAndFinancialInstitutionIdentification6{FinancialInstitutionID: FinancialInstitutionIdentification18{BankIdentifierCode: Ptr("BBBBUS33")}}
+	if _, err := RTPParticipantID(&bic); err == nil {
+		t.Error("Expected an error for an agent identified by BIC")
+	}
+}
+
+func TestRTPReasonCodes(t *testing.T) {
+	rejected := "RJCT"
+	report := &Pacs00200110Document{FIPaymentStatusReport: FIToFIPaymentStatusReportV10{
+		TransactionInfoAndStatus: []PaymentTransaction110{
+			{TransactionStatus: &rejected, StatusReasonInfo: []StatusReasonInfo12{{Reason: &StatusReason62{Code: Ptr("AC03")}}}},
+			{TransactionStatus: &rejected, StatusReasonInfo: []StatusReasonInfo12{{Reason: &StatusReason62{Code: Ptr("G001")}}}},
+			{TransactionStatus: &rejected},
+			{TransactionStatus: Ptr("ACSP")},
+		},
+	}}
+	errs := rtpStatusReportRule(report)
+	want := []string{
+		"FIToFIPmtStsRpt/TxInfAndSts[2]/StsRsnInf/Rsn",
+		"FIToFIPmtStsRpt/TxInfAndSts[3]/StsRsnInf/Rsn",
+		"FIToFIPmtStsRpt/TxInfAndSts[4]/TxSts",
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("Expected %d errors, got %v", len(want), errs)
+	}
+	for i, location := range want {
+		if errs[i].Location() != location {
+			t.Errorf("Expected an error at %s, got %v", location, errs[i])
+		}
+	}
+
+	rejection := &Admi00200101Document{MessageRejection: MessageRejectionV01{Reason: RejectionReason2{RejectingPartyReason: "9910"}}}
+	if err := RTPProfile.Check(rejection); err != nil {
+		t.Errorf("Expected 9910 to be accepted, got %v", err)
+	}
+	rejection.MessageRejection.Reason.RejectingPartyReason = "X999"
+	if err := RTPProfile.Check(rejection); err == nil {
+		t.Error("Expected an unknown rejection reason to fail")
+	}
+}
+
+func TestRTPAcknowledgement(t *testing.T) {
+	data, err := os.ReadFile("testdata/roundtrip/camt.035.001.05/acknowledgement.xml")
+	if err != nil {
+		t.Fatalf("Failed to read sample: %v", err)
+	}
+	doc := &Camt03500105Document{}
+	if err := xml.Unmarshal(data, doc); err != nil {
+		t.Fatalf("Failed to parse sample: %v", err)
+	}
+	if err := RTPProfile.Check(doc); err != nil {
+		t.Errorf("Expected the acknowledgement to pass, got %v", err)
+	}
+
+	doc.ProprietaryFormatInvestigation.Assignment.Assignee.Agent.FinancialInstitutionID.ClearingSystemMemberID = nil
+	errs, _ := RTPProfile.Check(doc).(ValidationErrors)
+	if len(errs) != 1 || errs[0].Location() != "PrtryFrmtInvstgtn/Assgnmt/Assgne/Agt/FinInstnId/ClrSysMmbId" {
+		t.Errorf("Expected an error for the assignee, got %v", errs)
+	}
+}
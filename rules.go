@@ -0,0 +1,203 @@
+package iso20022
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+)
+
+// ValidateBusinessRules checks the pacs.008.001.08 cross-element rules that the XSD
+// cannot express, such as NbOfTxs and CtrlSum matching the transactions. It assumes
+// the document is structurally valid and is meant to be run after Validate.
+func (d *Pacs00800108Document) ValidateBusinessRules() error {
+	if err := d.FICustomerCreditTransfer.ValidateBusinessRules(); err != nil {
+		return nestErrors("FIToFICstmrCdtTrf", err)
+	}
+	return nil
+}
+
+// ValidateBusinessRules checks the cross-element rules of the FIToFICustomerCreditTransfer
+// message definition
+func (f *FIToFICustomerCreditTransferV08) ValidateBusinessRules() error {
+	var errs ValidationErrors
+	hdr := &f.GroupHeader
+	txs := f.CreditTransferTransactionInfo
+
+	// GroupHeaderNumberOfTransactionsRule
+	if n, err := strconv.Atoi(hdr.NumberOfTransactions); err == nil && n != len(txs) {
+		errs = append(errs, newValidationError("GrpHdr.NbOfTxs", RuleNumberOfTransactions, "NB_OF_TXS", n, len(txs)))
+	}
+
+	// ControlSumRule and TotalInterbankSettlementAmountRule
+	sum := new(big.Rat)
+	for _, tx := range txs {
+		sum.Add(sum, DecimalRat(tx.InterbankSettlementAmount.Value))
+	}
+	if hdr.ControlSum != nil && DecimalRat(*hdr.ControlSum).Cmp(sum) != 0 {
+		errs = append(errs, newValidationError("GrpHdr.CtrlSum", RuleControlSum, "CONTROL_SUM", formatRat(sum)))
+	}
+	if total := hdr.TotalInterbankSettlementAmount; total != nil {
+		if DecimalRat(total.Value).Cmp(sum) != 0 {
+			errs = append(errs, newValidationError("GrpHdr.TtlIntrBkSttlmAmt", RuleControlSum, "CONTROL_SUM", formatRat(sum)))
+		}
+		for i, tx := range txs {
+			if tx.InterbankSettlementAmount.Currency != total.Currency {
+				errs = append(errs, newValidationError("Ccy", RuleControlSum, "CONTROL_SUM_CURRENCY", total.Currency).
+					at(fmt.Sprintf("CdtTrfTxInf[%d]/IntrBkSttlmAmt/@Ccy", i+1)))
+			}
+		}
+	}
+
+	errs = append(errs, hdr.SettlementInfo.validateBusinessRules().within("GrpHdr.SttlmInf")...)
+
+	for i, tx := range txs {
+		field := fmt.Sprintf("CdtTrfTxInf[%d]", i+1)
+
+		// GroupHeaderInterbankSettlementDateRule: the date is given once for the group
+		// or on every transaction
+		switch {
+		case hdr.InterbankSettlementDate != nil && tx.InterbankSettlementDate != nil:
+			errs = append(errs, newValidationError(field+".IntrBkSttlmDt", RuleSettlementDate, "SETTLEMENT_DATE_TWICE"))
+		case hdr.InterbankSettlementDate == nil && tx.InterbankSettlementDate == nil:
+			errs = append(errs, newValidationError(field+".IntrBkSttlmDt", RuleSettlementDate, "SETTLEMENT_DATE_MISSING"))
+		}
+
+		// Elements given at group level apply to every transaction
+		if hdr.PaymentTypeInfo != nil && tx.PaymentTypeInfo != nil {
+			errs = append(errs, newValidationError(field+".PmtTpInf", RuleGroupLevel, "GROUP_LEVEL", "PmtTpInf"))
+		}
+		if hdr.InstructingAgent != nil && tx.InstructingAgent != nil {
+			errs = append(errs, newValidationError(field+".InstgAgt", RuleGroupLevel, "GROUP_LEVEL", "InstgAgt"))
+		}
+		if hdr.InstructedAgent != nil && tx.InstructedAgent != nil {
+			errs = append(errs, newValidationError(field+".InstdAgt", RuleGroupLevel, "GROUP_LEVEL", "InstdAgt"))
+		}
+
+		if err := tx.ValidateBusinessRules(); err != nil {
+			errs = append(errs, nestErrors(field, err)...)
+		}
+	}
+
+	if errs.HasErrors() {
+		return errs
+	}
+	return nil
+}
+
+// ValidateBusinessRules checks the rules of a single transaction that do not depend
+// on the group header
+func (c *CreditTransferTransaction39) ValidateBusinessRules() error {
+	var errs ValidationErrors
+
+	// ChargeBearerAndChargesInformationRule: with SLEV the charges follow the
+	// service level, so none may be itemised
+	if c.ChargeBearer == "SLEV" && len(c.ChargesInfo) > 0 {
+		errs = append(errs, newValidationError("ChrgsInf", RuleChargeBearer, "CHARGE_BEARER"))
+	}
+
+	// InstructedAmountAndExchangeRateRule: a rate is given exactly when the
+	// instructed amount is in another currency
+	if c.InstructedAmount != nil {
+		sameCurrency := c.InstructedAmount.Currency == c.InterbankSettlementAmount.Currency
+		if !sameCurrency && c.ExchangeRate == nil {
+			errs = append(errs, newValidationError("XchgRate", RuleExchangeRate, "EXCHANGE_RATE_MISSING"))
+		}
+		if sameCurrency && c.ExchangeRate != nil {
+			errs = append(errs, newValidationError("XchgRate", RuleExchangeRate, "EXCHANGE_RATE_NOT_ALLOWED"))
+		}
+	}
+
+	// The exchange rate must convert the instructed amount into the settled amount
+	errs = append(errs, c.validateExchangeRate()...)
+
+	// Agent chains must be filled in order, and an account needs its agent
+	errs = append(errs, validateAgentChain(
+		[]string{"PrvsInstgAgt1", "PrvsInstgAgt2", "PrvsInstgAgt3"},
+		[]bool{c.PreviousInstructingAgent1 != nil, c.PreviousInstructingAgent2 != nil, c.PreviousInstructingAgent3 != nil},
+		[]bool{c.PreviousInstructingAgent1Account != nil, c.PreviousInstructingAgent2Account != nil, c.PreviousInstructingAgent3Account != nil},
+	)...)
+	errs = append(errs, validateAgentChain(
+		[]string{"IntrmyAgt1", "IntrmyAgt2", "IntrmyAgt3"},
+		[]bool{c.IntermediaryAgent1 != nil, c.IntermediaryAgent2 != nil, c.IntermediaryAgent3 != nil},
+		[]bool{c.IntermediaryAgent1Account != nil, c.IntermediaryAgent2Account != nil, c.IntermediaryAgent3Account != nil},
+	)...)
+
+	if errs.HasErrors() {
+		return errs
+	}
+	return nil
+}
+
+// validateBusinessRules checks the settlement method rules of SettlementInstruction7
+func (s *SettlementInstruction7) validateBusinessRules() ValidationErrors {
+	var errs ValidationErrors
+
+	hasReimbursementAgent := s.InstructingReimbursementAgent != nil || s.InstructedReimbursementAgent != nil || s.ThirdReimbursementAgent != nil
+	switch s.SettlementMethod {
+	case "INDA", "INGA":
+		// SettlementMethodAgentRule
+		if hasReimbursementAgent {
+			errs = append(errs, newValidationError("SttlmMtd", RuleSettlementMethod, "REIMBURSEMENT_AGENTS", s.SettlementMethod))
+		}
+	case "CLRG":
+		// SettlementMethodClearingRule
+		if hasReimbursementAgent {
+			errs = append(errs, newValidationError("SttlmMtd", RuleSettlementMethod, "REIMBURSEMENT_AGENTS", "CLRG"))
+		}
+		if s.SettlementAccount != nil {
+			errs = append(errs, newValidationError("SttlmAcct", RuleSettlementMethod, "SETTLEMENT_ACCOUNT"))
+		}
+	}
+	if s.SettlementMethod != "CLRG" && s.ClearingSystem != nil {
+		errs = append(errs, newValidationError("ClrSys", RuleSettlementMethod, "CLEARING_SYSTEM"))
+	}
+
+	// ThirdReimbursementAgentRule
+	if s.ThirdReimbursementAgent != nil && (s.InstructingReimbursementAgent == nil || s.InstructedReimbursementAgent == nil) {
+		errs = append(errs, newValidationError("ThrdRmbrsmntAgt", RuleSettlementMethod, "THIRD_REIMBURSEMENT_AGENT"))
+	}
+
+	// Each reimbursement agent account requires its agent
+	for _, a := range []struct {
+		name           string
+		agent, account bool
+	}{
+		{"InstgRmbrsmntAgt", s.InstructingReimbursementAgent != nil, s.InstructingReimbursementAgentAccount != nil},
+		{"InstdRmbrsmntAgt", s.InstructedReimbursementAgent != nil, s.InstructedReimbursementAgentAccount != nil},
+		{"ThrdRmbrsmntAgt", s.ThirdReimbursementAgent != nil, s.ThirdReimbursementAgentAccount != nil},
+	} {
+		if a.account && !a.agent {
+			errs = append(errs, newValidationError(a.name+"Acct", RuleSettlementMethod, "AGENT_MISSING", a.name))
+		}
+	}
+
+	return errs
+}
+
+// validateAgentChain checks that agent n+1 is only present with agent n, and that
+// every agent account is accompanied by its agent
+func validateAgentChain(names []string, agents, accounts []bool) ValidationErrors {
+	var errs ValidationErrors
+	for i := range names {
+		if i > 0 && agents[i] && !agents[i-1] {
+			errs = append(errs, newValidationError(names[i], RuleAgentChain, "AGENT_MISSING", names[i-1]))
+		}
+		if accounts[i] && !agents[i] {
+			errs = append(errs, newValidationError(names[i]+"Acct", RuleAgentChain, "AGENT_MISSING", names[i]))
+		}
+	}
+	return errs
+}
+
+// DecimalRat converts a Decimal to the exact decimal it was parsed from, so that
+// amounts can be summed without floating-point error
+func DecimalRat(d Decimal) *big.Rat {
+	r, _ := new(big.Rat).SetString(strconv.FormatFloat(float64(d), 'f', -1, 64))
+	return r
+}
+
+// formatRat formats a sum of decimals with as many fraction digits as needed
+func formatRat(r *big.Rat) string {
+	f, _ := r.Float64()
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
@@ -0,0 +1,124 @@
+package iso20022
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPacs008BusinessRules(t *testing.T) {
+	if err := loadPacs008Sample(t).ValidateBusinessRules(); err != nil {
+		t.Fatalf("Expected the sample to satisfy the business rules, got %v", err)
+	}
+
+	date := ISODate{}
+	tests := []struct {
+		name   string
+		modify func(msg *FIToFICustomerCreditTransferV08)
+		path   string
+	}{
+		{
+			name: "NbOfTxs",
+			modify: func(msg *FIToFICustomerCreditTransferV08) {
+				msg.GroupHeader.NumberOfTransactions = "2"
+			},
+			path: "FIToFICstmrCdtTrf/GrpHdr/NbOfTxs",
+		},
+		{
+			name: "CtrlSum",
+			modify: func(msg *FIToFICustomerCreditTransferV08) {
+				sum := msg.CreditTransferTransactionInfo[0].InterbankSettlementAmount.Value + 0.01
+				msg.GroupHeader.ControlSum = &sum
+			},
+			path: "FIToFICstmrCdtTrf/GrpHdr/CtrlSum",
+		},
+		{
+			name: "TotalInterbankSettlementAmountCurrency",
+			modify: func(msg *FIToFICustomerCreditTransferV08) {
+				amount := msg.CreditTransferTransactionInfo[0].InterbankSettlementAmount
+				amount.Currency = "EUR"
+				msg.GroupHeader.TotalInterbankSettlementAmount = &amount
+			},
+			path: "FIToFICstmrCdtTrf/CdtTrfTxInf[1]/IntrBkSttlmAmt/@Ccy",
+		},
+		{
+			name: "InterbankSettlementDateAtBothLevels",
+			modify: func(msg *FIToFICustomerCreditTransferV08) {
+				msg.GroupHeader.InterbankSettlementDate = &date
+			},
+			path: "FIToFICstmrCdtTrf/CdtTrfTxInf[1]/IntrBkSttlmDt",
+		},
+		{
+			name: "InterbankSettlementDateMissing",
+			modify: func(msg *FIToFICustomerCreditTransferV08) {
+				msg.CreditTransferTransactionInfo[0].InterbankSettlementDate = nil
+			},
+			path: "FIToFICstmrCdtTrf/CdtTrfTxInf[1]/IntrBkSttlmDt",
+		},
+		{
+			name: "SLEVWithCharges",
+			modify: func(msg *FIToFICustomerCreditTransferV08) {
+				msg.CreditTransferTransactionInfo[0].ChargeBearer = "SLEV"
+			},
+			path: "FIToFICstmrCdtTrf/CdtTrfTxInf[1]/ChrgsInf",
+		},
+		{
+			name: "ExchangeRateMissing",
+			modify: func(msg *FIToFICustomerCreditTransferV08) {
+				msg.CreditTransferTransactionInfo[0].InstructedAmount.Currency = "EUR"
+			},
+			path: "FIToFICstmrCdtTrf/CdtTrfTxInf[1]/XchgRate",
+		},
+		{
+			name: "ExchangeRateInconsistent",
+			modify: func(msg *FIToFICustomerCreditTransferV08) {
+				rate := Decimal(1.2)
+				tx := &msg.CreditTransferTransactionInfo[0]
+				tx.InstructedAmount.Currency = "EUR"
+				tx.ExchangeRate = &rate
+			},
+			path: "FIToFICstmrCdtTrf/CdtTrfTxInf[1]/XchgRate",
+		},
+		{
+			name: "IntermediaryAgentGap",
+			modify: func(msg *FIToFICustomerCreditTransferV08) {
+				agent := testAgentParty("CCCCGB2L").Agent
+				msg.CreditTransferTransactionInfo[0].IntermediaryAgent2 = agent
+			},
+			path: "FIToFICstmrCdtTrf/CdtTrfTxInf[1]/IntrmyAgt2",
+		},
+		{
+			name: "ClearingSystemWithoutCLRG",
+			modify: func(msg *FIToFICustomerCreditTransferV08) {
+				msg.GroupHeader.SettlementInfo.ClearingSystem = &ClearingSystemIdentificationSecondary{}
+			},
+			path: "FIToFICstmrCdtTrf/GrpHdr/SttlmInf/ClrSys",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := loadPacs008Sample(t)
+			tt.modify(&doc.FICustomerCreditTransfer)
+
+			err := doc.ValidateBusinessRules()
+			errs, ok := err.(ValidationErrors)
+			if !ok || len(errs) != 1 {
+				t.Fatalf("Expected one business rule error, got %v", err)
+			}
+			if errs[0].Location() != tt.path {
+				t.Errorf("Expected error at %s, got %s", tt.path, errs[0].Location())
+			}
+		})
+	}
+}
+
+func TestDecimalRatSumsExactly(t *testing.T) {
+	sum := DecimalRat(0.1)
+	sum.Add(sum, DecimalRat(0.2))
+	if got := formatRat(sum); got != "0.3" {
+		t.Errorf("Expected 0.1 + 0.2 to sum to 0.3, got %s", got)
+	}
+	if strings.Contains(formatRat(DecimalRat(1e21)), "e") {
+		t.Errorf("Expected no exponent in %s", formatRat(DecimalRat(1e21)))
+	}
+}
@@ -0,0 +1,146 @@
+package samples
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/ckbaum/iso20022-go"
+)
+
+// SamplePacs008 returns a pacs.008 sent by the debtor agent to the creditor agent,
+// two of the BICs, settling its transactions in one of the currencies on the day of
+// the date. Each transaction credits a customer of the creditor agent with a UETR,
+// instructed amount, shared charges, purpose and an invoice number as remittance
+// information. The message satisfies Validate and ValidateBusinessRules.
+func SamplePacs008(opts ...Option) *iso20022.Pacs00800108Document {
+	return newConfig(opts).pacs008()
+}
+
+// SamplePacs002 returns the pacs.002 the creditor agent sends back for the pacs.008
+// SamplePacs008 returns for the same options, reporting every transaction settled
+// (ACSC) a minute after it was sent
+func SamplePacs002(opts ...Option) *iso20022.Pacs00200110Document {
+	c := newConfig(opts)
+	pacs008 := c.pacs008()
+	msg := &pacs008.FICustomerCreditTransfer
+	hdr := &msg.GroupHeader
+	accepted := iso20022.NewISODateTime(c.date.Add(time.Minute))
+	tx := &msg.CreditTransferTransactionInfo[0]
+
+	report := &iso20022.Pacs00200110Document{FIPaymentStatusReport: iso20022.FIToFIPaymentStatusReportV10{
+		GroupHeader: iso20022.GroupHeader91{
+			MessageID:        messageID(*tx.CreditorAgent.FinancialInstitutionID.BankIdentifierCode, c.date, c.digits(4)),
+			CreationDateTime: accepted,
+			InstructingAgent: tx.InstructedAgent,
+			InstructedAgent:  tx.InstructingAgent,
+		},
+		OriginalGroupInformationAndStatus: []iso20022.OriginalGroupHeader17{{
+			OriginalMessageID:            hdr.MessageID,
+			OriginalMessageNameID:        "pacs.008.001.08",
+			OriginalCreationDateTime:     hdr.CreationDateTime,
+			OriginalNumberOfTransactions: iso20022.Ptr(hdr.NumberOfTransactions),
+			OriginalControlSum:           hdr.ControlSum,
+			GroupStatus:                  iso20022.Ptr("ACSC"),
+		}},
+	}}
+	for _, tx := range msg.CreditTransferTransactionInfo {
+		report.FIPaymentStatusReport.TransactionInfoAndStatus = append(report.FIPaymentStatusReport.TransactionInfoAndStatus, iso20022.PaymentTransaction110{
+			OriginalInstructionID: tx.PaymentID.InstructionID,
+			OriginalEndToEndID:    iso20022.Ptr(tx.PaymentID.EndToEndID),
+			OriginalTransactionID: tx.PaymentID.TransactionID,
+			OriginalUETR:          tx.PaymentID.UETR,
+			TransactionStatus:     iso20022.Ptr("ACSC"),
+			AcceptanceDateTime:    &accepted,
+		})
+	}
+	return report
+}
+
+// SampleCamt054 returns the camt.054 the creditor agent sends its customers an hour
+// after the pacs.008 SamplePacs008 returns for the same options settled: a
+// notification per creditor account crediting it with its transaction, as
+// iso20022.NewCreditNotification builds them, with entry and account servicer
+// references of the creditor agent
+func SampleCamt054(opts ...Option) *iso20022.Camt05400108Document {
+	c := newConfig(opts)
+	pacs008 := c.pacs008()
+	bic := *pacs008.FICustomerCreditTransfer.CreditTransferTransactionInfo[0].CreditorAgent.FinancialInstitutionID.BankIdentifierCode
+	doc, err := iso20022.NewCreditNotification(messageID(bic, c.date, c.digits(4)), pacs008)
+	if err != nil {
+		// The sample pacs.008 has the creditor accounts and settlement date needed
+		panic(fmt.Sprintf("samples: %v", err))
+	}
+	created := iso20022.NewISODateTime(c.date.Add(time.Hour))
+	notification := &doc.BankDebitCreditNotification
+	notification.GroupHeader.CreationDateTime = &created
+	for i := range notification.Notification {
+		n := &notification.Notification[i]
+		n.CreationDateTime = &created
+		for j := range n.Entry {
+			n.Entry[j].EntryReference = iso20022.Ptr(strconv.Itoa(j + 1))
+			n.Entry[j].AccountServicerReference = iso20022.Ptr(bic[:4] + c.digits(12))
+		}
+	}
+	return doc
+}
+
+// pacs008 draws a pacs.008
+func (c *config) pacs008() *iso20022.Pacs00800108Document {
+	currency := c.pick(c.currencies)
+	debtorBIC, creditorBIC := c.agents()
+	debtorAgent, creditorAgent := iso20022.AgentFromBIC(debtorBIC), iso20022.AgentFromBIC(creditorBIC)
+	created := iso20022.NewISODateTime(c.date)
+	date := iso20022.NewISODate(c.date.Year(), c.date.Month(), c.date.Day())
+	msgID := messageID(debtorBIC, c.date, c.digits(4))
+
+	var sum int64 // in ten-thousandths, the finest minor unit
+	txs := make([]iso20022.CreditTransferTransaction39, 0, c.transactions)
+	for i := 0; i < c.transactions; i++ {
+		value := c.amount(currency)
+		sum += int64(math.Round(float64(value) * 1e4))
+		ref := fmt.Sprintf("%s-%d", msgID, i+1)
+		invoice := "INV-" + c.date.Format("2006") + "-" + c.digits(4)
+		txs = append(txs, iso20022.CreditTransferTransaction39{
+			PaymentID: iso20022.PaymentIdentification7{
+				InstructionID: iso20022.Ptr(ref),
+				EndToEndID:    invoice,
+				TransactionID: iso20022.Ptr(ref),
+				UETR:          iso20022.Ptr(c.uetr()),
+			},
+			InterbankSettlementAmount: iso20022.ActiveCurrencyAndAmount{Value: value, Currency: currency},
+			InstructedAmount:          &iso20022.ActiveOrHistoricCurrencyAndAmount{Value: value, Currency: currency},
+			ChargeBearer:              "SHAR",
+			InstructingAgent:          iso20022.Ptr(debtorAgent),
+			InstructedAgent:           iso20022.Ptr(creditorAgent),
+			Debtor:                    c.party(debtorBIC),
+			DebtorAccount:             c.account(debtorBIC),
+			DebtorAgent:               debtorAgent,
+			CreditorAgent:             creditorAgent,
+			Creditor:                  c.party(creditorBIC),
+			CreditorAccount:           c.account(creditorBIC),
+			Purpose:                   &iso20022.Purpose{Code: iso20022.Ptr(c.pick([]string{"GDDS", "SUPP", "SCVE"}))},
+			RemittanceInfo:            &iso20022.RemittanceInfo{Unstructured: []string{"Invoice " + invoice}},
+		})
+	}
+
+	controlSum := iso20022.Decimal(float64(sum) / 1e4)
+	return &iso20022.Pacs00800108Document{FICustomerCreditTransfer: iso20022.FIToFICustomerCreditTransferV08{
+		GroupHeader: iso20022.GroupHeader93{
+			MessageID:                      msgID,
+			CreationDateTime:               &created,
+			NumberOfTransactions:           strconv.Itoa(len(txs)),
+			ControlSum:                     &controlSum,
+			TotalInterbankSettlementAmount: &iso20022.ActiveCurrencyAndAmount{Value: controlSum, Currency: currency},
+			InterbankSettlementDate:        &date,
+			SettlementInfo:                 iso20022.SettlementInstruction7{SettlementMethod: "INDA"},
+		},
+		CreditTransferTransactionInfo: txs,
+	}}
+}
+
+// messageID returns a message identification of the sender identified by bic
+func messageID(bic string, date time.Time, seq string) string {
+	return bic[:8] + "-" + date.Format("20060102") + "-" + seq
+}
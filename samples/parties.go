@@ -0,0 +1,97 @@
+package samples
+
+import (
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/ckbaum/iso20022-go"
+)
+
+// bbanFormats gives the basic bank account number of the IBANs of a country, a for
+// a letter and n for a digit
+var bbanFormats = map[string]string{
+	"BE": "nnnnnnnnnnnn",
+	"CH": "nnnnnnnnnnnnnnnnn",
+	"DE": "nnnnnnnnnnnnnnnnnn",
+	"ES": "nnnnnnnnnnnnnnnnnnnn",
+	"FR": "nnnnnnnnnnnnnnnnnnnnnnn",
+	"GB": "aaaannnnnnnnnnnnnn",
+	"IT": "annnnnnnnnnnnnnnnnnnnnn",
+	"NL": "aaaannnnnnnnnn",
+}
+
+// towns gives a town, post code and street of a country
+var towns = map[string][3]string{
+	"BE": {"Brussels", "1000", "Rue Royale"},
+	"CH": {"Zurich", "8001", "Bahnhofstrasse"},
+	"DE": {"Frankfurt am Main", "60311", "Kaiserstrasse"},
+	"ES": {"Madrid", "28013", "Calle Mayor"},
+	"FR": {"Paris", "75002", "Rue de la Paix"},
+	"GB": {"London", "EC2R 8AH", "Threadneedle Street"},
+	"IT": {"Milan", "20121", "Via Montenapoleone"},
+	"JP": {"Tokyo", "100-0005", "Marunouchi"},
+	"NL": {"Amsterdam", "1012 JS", "Damrak"},
+	"US": {"New York", "10001", "Main Street"},
+}
+
+// companies are the names of debtors and creditors
+var companies = []string{
+	"Acme Manufacturing Inc", "Widget Supplies Ltd", "Northwind Traders", "Globex Corporation",
+	"Initech GmbH", "Umbrella Logistics SA", "Stark Components BV", "Wayne Enterprises AG",
+	"Hooli Services SARL", "Soylent Foods SpA",
+}
+
+// party returns a company of the country of bic with its postal address
+func (c *config) party(bic string) iso20022.PartyIdentification135 {
+	ctry := country(bic)
+	p := iso20022.PartyIdentification135{Name: iso20022.Ptr(c.pick(companies))}
+	if town, ok := towns[ctry]; ok {
+		p.PostalAddress = &iso20022.PostalAddress24{
+			StreetName:     iso20022.Ptr(town[2]),
+			BuildingNumber: iso20022.Ptr(strconv.Itoa(1 + c.rand.Intn(200))),
+			PostCode:       iso20022.Ptr(town[1]),
+			TownName:       iso20022.Ptr(town[0]),
+			Country:        iso20022.Ptr(ctry),
+		}
+	}
+	return p
+}
+
+// account returns an account held with the agent identified by bic, an IBAN when
+// its country issues IBANs of a known format and an account number otherwise
+func (c *config) account(bic string) *iso20022.CashAccount38 {
+	ctry := country(bic)
+	format, ok := bbanFormats[ctry]
+	if !ok {
+		return &iso20022.CashAccount38{ID: iso20022.AccountIdentification4{
+			Other: &iso20022.GenericAccountIdentification1{ID: c.digits(12)},
+		}}
+	}
+	var bban strings.Builder
+	for _, f := range format {
+		if f == 'a' {
+			bban.WriteByte(byte('A' + c.rand.Intn(26)))
+		} else {
+			bban.WriteString(c.digits(1))
+		}
+	}
+	iban := ibanCheckDigits(ctry, bban.String())
+	return &iso20022.CashAccount38{ID: iso20022.AccountIdentification4{IBAN: &iban}}
+}
+
+// ibanCheckDigits returns the IBAN of a country and basic bank account number, with
+// the check digits that make it 1 mod 97
+func ibanCheckDigits(ctry, bban string) string {
+	var digits strings.Builder
+	for _, r := range bban + ctry + "00" {
+		if r >= 'A' && r <= 'Z' {
+			digits.WriteString(big.NewInt(int64(r - 'A' + 10)).String())
+		} else {
+			digits.WriteRune(r)
+		}
+	}
+	n, _ := new(big.Int).SetString(digits.String(), 10)
+	check := 98 - new(big.Int).Mod(n, big.NewInt(97)).Int64()
+	return ctry + string([]byte{byte('0' + check/10), byte('0' + check%10)}) + bban
+}
@@ -0,0 +1,147 @@
+// Package samples builds realistic, schema-valid messages for tests, in this module
+// and in the tests of its users. Tests that compare their output with an expected
+// text written out in full are better served by a hand-written fixture, whose
+// content is fixed.
+//
+// Every sample is drawn from a pseudo-random source seeded with 1 unless WithSeed
+// says otherwise, so the same options always give the same documents. The related
+// samples follow from each other: SamplePacs002 and SampleCamt054 report on the
+// transactions of the pacs.008 SamplePacs008 returns for the same options.
+package samples
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/ckbaum/iso20022-go"
+)
+
+// Option configures the samples
+type Option func(*config)
+
+// WithSeed seeds the pseudo-random source the samples are drawn from, 1 by default
+func WithSeed(seed int64) Option {
+	return func(c *config) {
+		c.seed = seed
+	}
+}
+
+// WithCurrencies sets the currencies a message settles in, one of EUR, USD, GBP, CHF
+// and JPY by default
+func WithCurrencies(currencies ...string) Option {
+	return func(c *config) {
+		c.currencies = currencies
+	}
+}
+
+// WithBICs sets the BICs the agents are chosen from, a few banks of different
+// countries by default. Accounts at agents of countries that issue IBANs known to
+// the package are identified by IBAN.
+func WithBICs(bics ...string) Option {
+	return func(c *config) {
+		c.bics = bics
+	}
+}
+
+// WithAmounts sets the range the amounts are drawn from, 10 to 100000 by default.
+// Amounts are rounded to the minor unit of their currency.
+func WithAmounts(min, max iso20022.Decimal) Option {
+	return func(c *config) {
+		c.min, c.max = float64(min), float64(max)
+	}
+}
+
+// WithTransactions sets the number of transactions of a message, 3 by default
+func WithTransactions(n int) Option {
+	return func(c *config) {
+		c.transactions = n
+	}
+}
+
+// WithDate sets when the messages are created; their transactions settle on the day
+// of date. 2024-03-15 09:30 UTC by default.
+func WithDate(date time.Time) Option {
+	return func(c *config) {
+		c.date = date
+	}
+}
+
+// config holds the options and the random source of the samples
+type config struct {
+	seed         int64
+	currencies   []string
+	bics         []string
+	min, max     float64
+	transactions int
+	date         time.Time
+	rand         *rand.Rand
+}
+
+// newConfig applies the options to the defaults
+func newConfig(opts []Option) *config {
+	c := &config{
+		seed:         1,
+		currencies:   []string{"EUR", "USD", "GBP", "CHF", "JPY"},
+		bics:         []string{"COBADEFFXXX", "BNPAFRPPXXX", "NWBKGB2LXXX", "INGBNL2AXXX", "UBSWCHZH80A", "CHASUS33XXX", "BOFAUS3NXXX", "MHCBJPJTXXX"},
+		min:          10,
+		max:          100000,
+		transactions: 3,
+		date:         time.Date(2024, 3, 15, 9, 30, 0, 0, time.UTC),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	c.rand = rand.New(rand.NewSource(c.seed))
+	return c
+}
+
+// pick returns a random element of values
+func (c *config) pick(values []string) string {
+	return values[c.rand.Intn(len(values))]
+}
+
+// digits returns n random digits
+func (c *config) digits(n int) string {
+	var b strings.Builder
+	for i := 0; i < n; i++ {
+		b.WriteByte(byte('0' + c.rand.Intn(10)))
+	}
+	return b.String()
+}
+
+// amount returns a random amount in currency
+func (c *config) amount(currency string) iso20022.Decimal {
+	value := c.min + c.rand.Float64()*(c.max-c.min)
+	return iso20022.ConvertAmount(iso20022.Decimal(math.Round(value*1e4)/1e4), 1, currency)
+}
+
+// uetr returns a random version 4 UUID
+func (c *config) uetr() string {
+	var b [16]byte
+	c.rand.Read(b[:])
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+// country returns the country of a BIC
+func country(bic string) string {
+	if len(bic) < 6 {
+		return ""
+	}
+	return bic[4:6]
+}
+
+// agents returns the BICs of the debtor and creditor agents, two different entries
+// of the BICs when there are several to choose from
+func (c *config) agents() (string, string) {
+	n := len(c.bics)
+	i := c.rand.Intn(n)
+	if n == 1 {
+		return c.bics[i], c.bics[i]
+	}
+	return c.bics[i], c.bics[(i+1+c.rand.Intn(n-1))%n]
+}
@@ -0,0 +1,92 @@
+package samples
+
+import (
+	"encoding/xml"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSamplePacs008(t *testing.T) {
+	for seed := int64(1); seed <= 20; seed++ {
+		doc := SamplePacs008(WithSeed(seed))
+		if err := doc.Validate(); err != nil {
+			t.Errorf("Seed %d: expected a valid pacs.008, got %v", seed, err)
+		}
+		if err := doc.ValidateBusinessRules(); err != nil {
+			t.Errorf("Seed %d: expected the business rules to hold, got %v", seed, err)
+		}
+	}
+
+	if !reflect.DeepEqual(SamplePacs008(), SamplePacs008()) {
+		t.Error("Expected the same options to give the same sample")
+	}
+	if reflect.DeepEqual(SamplePacs008(WithSeed(1)), SamplePacs008(WithSeed(2))) {
+		t.Error("Expected different seeds to give different samples")
+	}
+
+	doc := SamplePacs008(WithCurrencies("JPY"), WithBICs("MHCBJPJT", "COBADEFF"), WithAmounts(1000, 2000), WithTransactions(5),
+		WithDate(time.Date(2025, 1, 6, 8, 0, 0, 0, time.UTC)))
+	msg := doc.FICustomerCreditTransfer
+	if msg.GroupHeader.NumberOfTransactions != "5" || msg.GroupHeader.InterbankSettlementDate.String() != "2025-01-06" {
+		t.Errorf("Unexpected group header %+v", msg.GroupHeader)
+	}
+	for i, tx := range msg.CreditTransferTransactionInfo {
+		amount := tx.InterbankSettlementAmount
+		if amount.Currency != "JPY" || amount.Value < 1000 || amount.Value > 2000 || float64(amount.Value) != math.Trunc(float64(amount.Value)) {
+			t.Errorf("Transaction %d: unexpected amount %+v", i+1, amount)
+		}
+		if *tx.DebtorAgent.FinancialInstitutionID.BankIdentifierCode == *tx.CreditorAgent.FinancialInstitutionID.BankIdentifierCode {
+			t.Errorf("Transaction %d: expected different debtor and creditor agents", i+1)
+		}
+	}
+	if err := doc.Validate(); err != nil {
+		t.Errorf("Expected a valid pacs.008, got %v", err)
+	}
+}
+
+func TestSamplePacs002(t *testing.T) {
+	pacs008 := SamplePacs008(WithSeed(7))
+	report := SamplePacs002(WithSeed(7)).FIPaymentStatusReport
+	if report.OriginalGroupInformationAndStatus[0].OriginalMessageID != pacs008.FICustomerCreditTransfer.GroupHeader.MessageID {
+		t.Errorf("Expected the report to refer to the pacs.008, got %+v", report.OriginalGroupInformationAndStatus[0])
+	}
+	for i, tx := range pacs008.FICustomerCreditTransfer.CreditTransferTransactionInfo {
+		status := report.TransactionInfoAndStatus[i]
+		if *status.OriginalUETR != *tx.PaymentID.UETR || *status.TransactionStatus != "ACSC" {
+			t.Errorf("Transaction %d: unexpected status %+v", i+1, status)
+		}
+	}
+	if _, err := xml.Marshal(SamplePacs002()); err != nil {
+		t.Errorf("Failed to marshal the pacs.002: %v", err)
+	}
+}
+
+func TestSampleCamt054(t *testing.T) {
+	doc := SampleCamt054(WithSeed(3))
+	if err := doc.Validate(); err != nil {
+		t.Errorf("Expected a valid camt.054, got %v", err)
+	}
+	postings, err := doc.Postings()
+	if err != nil {
+		t.Fatalf("Postings failed: %v", err)
+	}
+	txs := SamplePacs008(WithSeed(3)).FICustomerCreditTransfer.CreditTransferTransactionInfo
+	if len(postings) != len(txs) {
+		t.Fatalf("Expected a posting per transaction, got %d", len(postings))
+	}
+	for i, p := range postings {
+		tx := txs[i]
+		if p.CdtDbt != "CRDT" || p.Amount.Value != tx.InterbankSettlementAmount.Value || *p.References.EndToEndID != tx.PaymentID.EndToEndID ||
+			p.AccountServicerReference == "" {
+			t.Errorf("Posting %d: unexpected %+v", i+1, p)
+		}
+	}
+}
+
+func TestIBANCheckDigits(t *testing.T) {
+	if got := ibanCheckDigits("GB", "NWBK60161331926819"); got != "GB29NWBK60161331926819" {
+		t.Errorf("Expected GB29NWBK60161331926819, got %s", got)
+	}
+}
@@ -0,0 +1,398 @@
+package iso20022
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// SEPACreditTransferProfile is the SEPA Credit Transfer scheme of the EPC rulebook
+// for the interbank messages: pacs.008 credit transfers, pacs.004 returns and
+// camt.056 recalls. Errors name the rulebook attribute (AT-xx) an element carries
+// where it has one.
+var SEPACreditTransferProfile = Profile{
+	Name:   "SEPA SCT",
+	Limits: Limits{MaxTransactions: 100000, MaxRemittanceLength: 140},
+	Rules:  sctChecker{scheme: "SEPA SCT"}.rules(),
+}
+
+// SEPAInstantProfile is the SEPA Instant Credit Transfer scheme (SCT Inst) for
+// pacs.008 credit transfers, pacs.002 confirmations, pacs.004 returns, camt.056
+// recalls and camt.029 answers to recalls. On top of the SCT rules, credit transfers
+// carry the local instrument INST and their acceptance date and time, and must not
+// exceed the amount cap of the scheme, which is left to adjust to the cap agreed
+// with the CSM. Timeout is the time the scheme gives to confirm a credit transfer
+// after its acceptance.
+var SEPAInstantProfile = Profile{
+	Name:    "SEPA SCT Inst",
+	Limits:  Limits{MaxTransactions: 1, MaxRemittanceLength: 140, MaxAmount: 100000},
+	Rules:   sctChecker{scheme: "SEPA SCT Inst", instant: true}.rules(),
+	Timeout: 20 * time.Second,
+}
+
+// SCTInstExecutionTime is the target time of SCT Inst from the acceptance of a
+// credit transfer to the confirmation sent to the originator
+const SCTInstExecutionTime = 10 * time.Second
+
+// SCTAttributes maps the attributes of the SCT rulebook to the pacs.008 elements that
+// carry them, relative to CdtTrfTxInf
+var SCTAttributes = map[string]string{
+	"AT-01": "DbtrAcct/Id/IBAN",
+	"AT-02": "Dbtr/Nm",
+	"AT-03": "Dbtr/PstlAdr",
+	"AT-04": "IntrBkSttlmAmt",
+	"AT-05": "RmtInf",
+	"AT-06": "DbtrAgt/FinInstnId/BICFI",
+	"AT-08": "UltmtDbtr/Nm",
+	"AT-09": "UltmtDbtr/Id",
+	"AT-10": "Dbtr/Id",
+	"AT-20": "CdtrAcct/Id/IBAN",
+	"AT-21": "Cdtr/Nm",
+	"AT-22": "Cdtr/PstlAdr",
+	"AT-23": "CdtrAgt/FinInstnId/BICFI",
+	"AT-24": "Cdtr/Id",
+	"AT-28": "UltmtCdtr/Nm",
+	"AT-29": "UltmtCdtr/Id",
+	"AT-40": "PmtTpInf/SvcLvl/Cd",
+	"AT-41": "PmtId/EndToEndId",
+	"AT-42": "IntrBkSttlmDt",
+	"AT-43": "PmtId/TxId",
+	"AT-44": "Purp/Cd",
+	"AT-45": "PmtTpInf/CtgyPurp/Cd",
+}
+
+// SEPA amounts are in euro, from 0.01 to 999999999.99
+var (
+	sepaMinAmount = big.NewRat(1, 100)
+	sepaMaxAmount = big.NewRat(99999999999, 100)
+)
+
+// SCT return reasons (pacs.004 RtrRsnInf/Rsn/Cd) and recall reasons (camt.056
+// CxlRsnInf/Rsn/Cd)
+var (
+	sctReturnReasons = map[string]bool{
+		"AC01": true, "AC04": true, "AC06": true, "AC13": true, "AG01": true, "AG02": true,
+		"AM05": true, "BE04": true, "CNOR": true, "CUST": true, "DNOR": true, "ERIN": true,
+		"FF01": true, "FOCR": true, "FRAD": true, "MD07": true, "MS02": true, "MS03": true,
+		"RC01": true, "RR01": true, "RR02": true, "RR03": true, "RR04": true, "TM01": true,
+	}
+	sctRecallReasons = map[string]bool{
+		"AC03": true, "AM09": true, "CUST": true, "DUPL": true, "FRAD": true, "TECH": true,
+	}
+	// Reasons for refusing a recall (camt.029 CxlStsRsnInf/Rsn/Cd)
+	sctRecallRefusals = map[string]bool{
+		"AC04": true, "AM04": true, "ARDT": true, "CUST": true, "LEGL": true, "NOAS": true, "NOOR": true,
+	}
+)
+
+// sctChecker checks messages against the SCT rulebook or a scheme built on it
+type sctChecker struct {
+	scheme  string // named in the errors
+	instant bool   // SCT Inst
+}
+
+// rules returns the profile rules of the scheme
+func (c sctChecker) rules() []ProfileRule {
+	rules := []ProfileRule{c.creditTransfer, c.paymentReturn, c.recall}
+	if c.instant {
+		rules = append(rules, c.statusReport, c.recallResolution)
+	}
+	return rules
+}
+
+// isSEPAText reports whether text only holds characters of the SEPA basic Latin
+// character set: a-z A-Z 0-9 / - ? : ( ) . , ' + and space
+func isSEPAText(text string) bool {
+	for _, r := range text {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case strings.ContainsRune("/-?:().,'+ ", r):
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// creditTransfer checks a pacs.008 against the SCT rulebook
+func (c sctChecker) creditTransfer(doc interface{}) ValidationErrors {
+	d, ok := doc.(*Pacs00800108Document)
+	if !ok {
+		return nil
+	}
+	f := &d.FICustomerCreditTransfer
+	var errs ValidationErrors
+	if f.GroupHeader.TotalInterbankSettlementAmount != nil {
+		errs = append(errs, c.euro("GrpHdr/TtlIntrBkSttlmAmt", f.GroupHeader.TotalInterbankSettlementAmount.Currency, "")...)
+	}
+	for i := range f.CreditTransferTransactionInfo {
+		tx := &f.CreditTransferTransactionInfo[i]
+		pmtTpInf := tx.PaymentTypeInfo
+		if pmtTpInf == nil {
+			pmtTpInf = f.GroupHeader.PaymentTypeInfo
+		}
+		errs = append(errs, c.transaction(tx, pmtTpInf).within(fmt.Sprintf("CdtTrfTxInf[%d]", i+1))...)
+	}
+	return errs.within("FIToFICstmrCdtTrf")
+}
+
+// transaction checks a credit transfer transaction, whose payment type is given
+// at transaction or group level
+func (c sctChecker) transaction(tx *CreditTransferTransaction39, pmtTpInf *PaymentTypeInfo28) ValidationErrors {
+	var errs ValidationErrors
+	sepa := false
+	if pmtTpInf != nil {
+		for _, lvl := range pmtTpInf.ServiceLevel {
+			sepa = sepa || lvl.Code != nil && *lvl.Code == "SEPA"
+		}
+	}
+	if !sepa {
+		errs = append(errs, c.error("PmtTpInf/SvcLvl/Cd", "AT-40", "must be SEPA"))
+	}
+
+	if c.instant {
+		if pmtTpInf == nil || pmtTpInf.LocalInstrument == nil || pmtTpInf.LocalInstrument.Code == nil || *pmtTpInf.LocalInstrument.Code != "INST" {
+			errs = append(errs, c.error("PmtTpInf/LclInstrm/Cd", "", "must be INST"))
+		}
+		if tx.AcceptanceDateTime == nil {
+			errs = append(errs, c.error("AccptncDtTm", "", "is required"))
+		}
+	}
+
+	amt := tx.InterbankSettlementAmount
+	errs = append(errs, c.euro("IntrBkSttlmAmt", amt.Currency, "AT-04")...)
+	errs = append(errs, c.amount("IntrBkSttlmAmt", amt.Value, "AT-04")...)
+	if tx.ChargeBearer != "SLEV" {
+		errs = append(errs, c.error("ChrgBr", "", "must be SLEV"))
+	}
+
+	errs = append(errs, c.reference("PmtId/EndToEndId", tx.PaymentID.EndToEndID, "AT-41")...)
+	errs = append(errs, c.party("Dbtr", &tx.Debtor, "AT-02", true)...)
+	errs = append(errs, c.party("Cdtr", &tx.Creditor, "AT-21", true)...)
+	if tx.UltimateDebtor != nil {
+		errs = append(errs, c.party("UltmtDbtr", tx.UltimateDebtor, "AT-08", false)...)
+	}
+	if tx.UltimateCreditor != nil {
+		errs = append(errs, c.party("UltmtCdtr", tx.UltimateCreditor, "AT-28", false)...)
+	}
+	errs = append(errs, c.iban("DbtrAcct", tx.DebtorAccount, "AT-01")...)
+	errs = append(errs, c.iban("CdtrAcct", tx.CreditorAccount, "AT-20")...)
+	errs = append(errs, c.bic("DbtrAgt", &tx.DebtorAgent, "AT-06")...)
+	errs = append(errs, c.bic("CdtrAgt", &tx.CreditorAgent, "AT-23")...)
+
+	if rmt := tx.RemittanceInfo; rmt != nil {
+		switch {
+		case len(rmt.Unstructured) > 0 && len(rmt.Structured) > 0:
+			errs = append(errs, c.error("RmtInf", "AT-05", "must be either unstructured or structured, not both"))
+		case len(rmt.Unstructured) > 1:
+			errs = append(errs, c.error("RmtInf/Ustrd", "AT-05", "must occur at most once"))
+		case len(rmt.Structured) > 1:
+			errs = append(errs, c.error("RmtInf/Strd", "AT-05", "must occur at most once"))
+		}
+		for _, line := range rmt.Unstructured {
+			if !isSEPAText(line) {
+				errs = append(errs, c.error("RmtInf/Ustrd", "AT-05", "contains characters outside the SEPA character set"))
+			}
+		}
+	}
+	return errs
+}
+
+// paymentReturn checks a pacs.004 against the SCT rulebook
+func (c sctChecker) paymentReturn(doc interface{}) ValidationErrors {
+	d, ok := doc.(*Pacs00400110Document)
+	if !ok {
+		return nil
+	}
+	var errs ValidationErrors
+	for i := range d.PaymentReturn.TransactionInfo {
+		tx := &d.PaymentReturn.TransactionInfo[i]
+		var txErrs ValidationErrors
+		txErrs = append(txErrs, c.euro("RtrdIntrBkSttlmAmt", tx.ReturnedInterbankSettlementAmount.Currency, "")...)
+		txErrs = append(txErrs, c.amount("RtrdIntrBkSttlmAmt", tx.ReturnedInterbankSettlementAmount.Value, "")...)
+		if tx.OriginalInterbankSettlementAmount == nil {
+			txErrs = append(txErrs, c.error("OrgnlIntrBkSttlmAmt", "", "is required"))
+		} else {
+			txErrs = append(txErrs, c.euro("OrgnlIntrBkSttlmAmt", tx.OriginalInterbankSettlementAmount.Currency, "")...)
+		}
+		if tx.ChargeBearer != nil && *tx.ChargeBearer != "SLEV" {
+			txErrs = append(txErrs, c.error("ChrgBr", "", "must be SLEV"))
+		}
+		var reason *string
+		if len(tx.ReturnReasonInfo) > 0 && tx.ReturnReasonInfo[0].Reason != nil {
+			reason = tx.ReturnReasonInfo[0].Reason.Code
+		}
+		txErrs = append(txErrs, c.reason("RtrRsnInf", reason, sctReturnReasons)...)
+		errs = append(errs, txErrs.within(fmt.Sprintf("TxInf[%d]", i+1))...)
+	}
+	return errs.within("PmtRtr")
+}
+
+// recall checks a camt.056 against the SCT rulebook
+func (c sctChecker) recall(doc interface{}) ValidationErrors {
+	d, ok := doc.(*Camt05600108Document)
+	if !ok {
+		return nil
+	}
+	var errs ValidationErrors
+	for i, undrlyg := range d.FIPaymentCancelRequest.Underlying {
+		for j := range undrlyg.TransactionInfo {
+			tx := &undrlyg.TransactionInfo[j]
+			var txErrs ValidationErrors
+			if tx.OriginalInterbankSettlementAmount == nil {
+				txErrs = append(txErrs, c.error("OrgnlIntrBkSttlmAmt", "", "is required"))
+			} else {
+				txErrs = append(txErrs, c.euro("OrgnlIntrBkSttlmAmt", tx.OriginalInterbankSettlementAmount.Currency, "")...)
+			}
+			var reason *string
+			if len(tx.CancellationReasonInfo) > 0 && tx.CancellationReasonInfo[0].Reason != nil {
+				reason = tx.CancellationReasonInfo[0].Reason.Code
+			}
+			txErrs = append(txErrs, c.reason("CxlRsnInf", reason, sctRecallReasons)...)
+			errs = append(errs, txErrs.within(fmt.Sprintf("Undrlyg[%d]/TxInf[%d]", i+1, j+1))...)
+		}
+	}
+	return errs.within("FIToFIPmtCxlReq")
+}
+
+// statusReport checks a pacs.002 confirming SCT Inst credit transfers, which are
+// either accepted or rejected with a reason
+func (c sctChecker) statusReport(doc interface{}) ValidationErrors {
+	d, ok := doc.(*Pacs00200110Document)
+	if !ok {
+		return nil
+	}
+	var errs ValidationErrors
+	for i, tx := range d.FIPaymentStatusReport.TransactionInfoAndStatus {
+		path := fmt.Sprintf("TxInfAndSts[%d]", i+1)
+		var status string
+		if tx.TransactionStatus != nil {
+			status = *tx.TransactionStatus
+		}
+		switch status {
+		case "ACCP", "ACSC":
+		case "RJCT":
+			if len(tx.StatusReasonInfo) == 0 || tx.StatusReasonInfo[0].Reason == nil || tx.StatusReasonInfo[0].Reason.Code == nil {
+				errs = append(errs, c.error(path+"/StsRsnInf/Rsn/Cd", "", "is required for a rejection"))
+			}
+		default:
+			errs = append(errs, c.error(path+"/TxSts", "", fmt.Sprintf("must be ACCP, ACSC or RJCT, got %q", status)))
+		}
+		if tx.OriginalTransactionID == nil {
+			errs = append(errs, c.error(path+"/OrgnlTxId", "", "is required"))
+		}
+	}
+	return errs.within("FIToFIPmtStsRpt")
+}
+
+// recallResolution checks a camt.029 answering SCT Inst recalls, whose refusals
+// need a reason
+func (c sctChecker) recallResolution(doc interface{}) ValidationErrors {
+	d, ok := doc.(*Camt02900109Document)
+	if !ok {
+		return nil
+	}
+	var errs ValidationErrors
+	for i, details := range d.InvestigationResolution.CancellationDetails {
+		for j, tx := range details.TransactionInfo {
+			if tx.TransactionCancellationStatus == nil || *tx.TransactionCancellationStatus != "RJCR" {
+				continue
+			}
+			path := fmt.Sprintf("CxlDtls[%d]/TxInfAndSts[%d]/CxlStsRsnInf", i+1, j+1)
+			var code *string
+			if len(tx.CancellationStatusReasonInfo) > 0 && tx.CancellationStatusReasonInfo[0].Reason != nil {
+				code = tx.CancellationStatusReasonInfo[0].Reason.Code
+			}
+			errs = append(errs, c.reason(path, code, sctRecallRefusals)...)
+		}
+	}
+	return errs.within("RsltnOfInvstgtn")
+}
+
+// error returns an error at path, naming the rulebook attribute when there is one
+func (c sctChecker) error(path, attribute, message string) ValidationError {
+	message += " in " + c.scheme
+	if attribute != "" {
+		message += " (" + attribute + ")"
+	}
+	return ValidationError{Field: path[strings.LastIndexByte(path, '/')+1:], Path: path, Message: message}
+}
+
+func (c sctChecker) euro(path, currency, attribute string) ValidationErrors {
+	if currency != "EUR" {
+		return ValidationErrors{c.error(path+"/@Ccy", attribute, fmt.Sprintf("must be EUR, got %s", currency))}
+	}
+	return nil
+}
+
+func (c sctChecker) amount(path string, value Decimal, attribute string) ValidationErrors {
+	amount := DecimalRat(value)
+	if amount.Cmp(sepaMinAmount) < 0 || amount.Cmp(sepaMaxAmount) > 0 {
+		return ValidationErrors{c.error(path, attribute, fmt.Sprintf("must be between 0.01 and 999999999.99, got %s", formatRat(amount)))}
+	}
+	return nil
+}
+
+// reference checks a reference passed on unchanged to the creditor
+func (c sctChecker) reference(path, ref, attribute string) ValidationErrors {
+	switch {
+	case !isSEPAText(ref):
+		return ValidationErrors{c.error(path, attribute, "contains characters outside the SEPA character set")}
+	case strings.HasPrefix(ref, "/"), strings.HasSuffix(ref, "/"), strings.Contains(ref, "//"):
+		return ValidationErrors{c.error(path, attribute, "must not start or end with / or contain //")}
+	}
+	return nil
+}
+
+// party checks the name and address of a party
+func (c sctChecker) party(path string, party *PartyIdentification135, attribute string, required bool) ValidationErrors {
+	var errs ValidationErrors
+	switch {
+	case party.Name == nil || *party.Name == "":
+		if required {
+			errs = append(errs, c.error(path+"/Nm", attribute, "is required"))
+		}
+	case utf8.RuneCountInString(*party.Name) > 70:
+		errs = append(errs, c.error(path+"/Nm", attribute, "must be at most 70 characters"))
+	case !isSEPAText(*party.Name):
+		errs = append(errs, c.error(path+"/Nm", attribute, "contains characters outside the SEPA character set"))
+	}
+	if adr := party.PostalAddress; adr != nil {
+		if len(adr.AddressLine) > 2 {
+			errs = append(errs, c.error(path+"/PstlAdr/AdrLine", "", "must occur at most twice"))
+		}
+		for _, line := range adr.AddressLine {
+			if !isSEPAText(line) {
+				errs = append(errs, c.error(path+"/PstlAdr/AdrLine", "", "contains characters outside the SEPA character set"))
+			}
+		}
+	}
+	return errs
+}
+
+func (c sctChecker) iban(path string, account *CashAccount38, attribute string) ValidationErrors {
+	if account == nil || account.ID.IBAN == nil {
+		return ValidationErrors{c.error(path+"/Id/IBAN", attribute, "is required")}
+	}
+	return nil
+}
+
+func (c sctChecker) bic(path string, agent *BranchAndFinancialInstitutionIdentification6, attribute string) ValidationErrors {
+	if agent.FinancialInstitutionID.BankIdentifierCode == nil {
+		return ValidationErrors{c.error(path+"/FinInstnId/BICFI", attribute, "is required")}
+	}
+	return nil
+}
+
+// reason checks the code of the first reason of a return or recall
+func (c sctChecker) reason(path string, code *string, codes map[string]bool) ValidationErrors {
+	if code == nil {
+		return ValidationErrors{c.error(path+"/Rsn/Cd", "", "is required")}
+	}
+	if !codes[*code] {
+		return ValidationErrors{c.error(path+"/Rsn/Cd", "", fmt.Sprintf("has reason %s, which the rulebook does not allow", *code))}
+	}
+	return nil
+}
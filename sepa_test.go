@@ -0,0 +1,173 @@
+package iso20022
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// loadSCTSample returns the pacs.008 sample made to follow the SCT rulebook
+func loadSCTSample(t *testing.T) *Pacs00800108Document {
+	t.Helper()
+	doc := loadPacs008Sample(t)
+	tx := &doc.FICustomerCreditTransfer.CreditTransferTransactionInfo[0]
+	tx.PaymentTypeInfo.ServiceLevel = []ServiceLevel{{Code: Ptr("SEPA")}}
+	tx.InterbankSettlementAmount.Currency = "EUR"
+	tx.ChargeBearer = "SLEV"
+	tx.DebtorAccount.ID = AccountIdentification4{IBAN: Ptr("DE89370400440532013000")}
+	return doc
+}
+
+func TestSEPACreditTransferProfile(t *testing.T) {
+	profile, ok := LookupProfile("SEPA SCT")
+	if !ok {
+		t.Fatal("Expected the SEPA SCT profile to be registered")
+	}
+	if err := profile.Check(loadSCTSample(t)); err != nil {
+		t.Fatalf("Expected the SCT sample to pass, got %v", err)
+	}
+
+	err := profile.Check(loadPacs008Sample(t))
+	errs, ok := err.(ValidationErrors)
+	if !ok {
+		t.Fatalf("Expected ValidationErrors, got %v", err)
+	}
+	want := map[string]string{
+		"FIToFICstmrCdtTrf/CdtTrfTxInf[1]/PmtTpInf/SvcLvl/Cd":  "AT-40",
+		"FIToFICstmrCdtTrf/CdtTrfTxInf[1]/IntrBkSttlmAmt/@Ccy": "AT-04",
+		"FIToFICstmrCdtTrf/CdtTrfTxInf[1]/ChrgBr":              "SLEV",
+		"FIToFICstmrCdtTrf/CdtTrfTxInf[1]/DbtrAcct/Id/IBAN":    "AT-01",
+	}
+	for _, e := range errs {
+		hint, ok := want[e.Location()]
+		if !ok {
+			t.Errorf("Unexpected error %v", e)
+			continue
+		}
+		if !strings.Contains(e.Message, hint) {
+			t.Errorf("Expected %q in %v", hint, e)
+		}
+		delete(want, e.Location())
+	}
+	for location := range want {
+		t.Errorf("Expected an error at %s, got %v", location, errs)
+	}
+}
+
+func TestSEPACreditTransferText(t *testing.T) {
+	doc := loadSCTSample(t)
+	tx := &doc.FICustomerCreditTransfer.CreditTransferTransactionInfo[0]
+	tx.Debtor.Name = Ptr("Müller & Söhne")
+	tx.PaymentID.EndToEndID = "INV//42"
+	tx.InterbankSettlementAmount.Value = 1000000000
+	tx.RemittanceInfo = &RemittanceInfo{Unstructured: []string{"a", "b"}}
+
+	errs, _ := SEPACreditTransferProfile.Check(doc).(ValidationErrors)
+	got := map[string]bool{}
+	for _, e := range errs {
+		got[e.Location()] = true
+	}
+	for _, location := range []string{"Dbtr/Nm", "PmtId/EndToEndId", "IntrBkSttlmAmt", "RmtInf/Ustrd"} {
+		if !got["FIToFICstmrCdtTrf/CdtTrfTxInf[1]/"+location] {
+			t.Errorf("Expected an error at %s, got %v", location, errs)
+		}
+	}
+}
+
+func TestSEPAReturnAndRecall(t *testing.T) {
+	ret := &Pacs00400110Document{PaymentReturn: PaymentReturnV10{TransactionInfo: []PaymentTransaction118{{
+		OriginalInterbankSettlementAmount: &ActiveOrHistoricCurrencyAndAmount{Value: 100, Currency: "EUR"},
+		ReturnedInterbankSettlementAmount: ActiveCurrencyAndAmount{Value: 100, Currency: "EUR"},
+		ReturnReasonInfo:                  []PaymentReturnReason6{{Reason: &ReturnReason5{Code: Ptr("AC04")}}},
+	}}}}
+	if err := SEPACreditTransferProfile.Check(ret); err != nil {
+		t.Errorf("Expected the return to pass, got %v", err)
+	}
+	ret.PaymentReturn.TransactionInfo[0].ReturnReasonInfo[0].Reason.Code = Ptr("NARR")
+	if err := SEPACreditTransferProfile.Check(ret); err == nil || !strings.Contains(err.Error(), "NARR") {
+		t.Errorf("Expected an error for reason NARR, got %v", err)
+	}
+
+	recall := &Camt05600108Document{FIPaymentCancelRequest: FIToFIPaymentCancellationRequestV08{Underlying: []UnderlyingTransaction23{{
+		TransactionInfo: []PaymentTransaction106{{
+			OriginalInterbankSettlementAmount: &ActiveOrHistoricCurrencyAndAmount{Value: 100, Currency: "USD"},
+			CancellationReasonInfo:            []PaymentCancellationReason5{{Reason: &CancellationReason33{Code: Ptr("DUPL")}}},
+		}},
+	}}}}
+	err := SEPACreditTransferProfile.Check(recall)
+	if errs, ok := err.(ValidationErrors); !ok || len(errs) != 1 || errs[0].Location() != "FIToFIPmtCxlReq/Undrlyg[1]/TxInf[1]/OrgnlIntrBkSttlmAmt/@Ccy" {
+		t.Errorf("Expected one currency error, got %v", err)
+	}
+}
+
+func TestSEPAInstantProfile(t *testing.T) {
+	doc := loadSCTSample(t)
+	tx := &doc.FICustomerCreditTransfer.CreditTransferTransactionInfo[0]
+	tx.InterbankSettlementAmount.Value = 150000
+
+	err := SEPAInstantProfile.Check(doc)
+	errs, ok := err.(ValidationErrors)
+	if !ok {
+		t.Fatalf("Expected ValidationErrors, got %v", err)
+	}
+	want := map[string]bool{
+		"FIToFICstmrCdtTrf/CdtTrfTxInf[1]/PmtTpInf/LclInstrm/Cd": true,
+		"FIToFICstmrCdtTrf/CdtTrfTxInf[1]/AccptncDtTm":           true,
+		"FIToFICstmrCdtTrf/CdtTrfTxInf[1]/IntrBkSttlmAmt":        true,
+	}
+	for _, e := range errs {
+		if !want[e.Location()] {
+			t.Errorf("Unexpected error %v", e)
+		}
+		delete(want, e.Location())
+	}
+	for location := range want {
+		t.Errorf("Expected an error at %s, got %v", location, errs)
+	}
+
+	accepted := time.Date(2024, 3, 15, 9, 30, 0, 0, time.UTC)
+	tx.InterbankSettlementAmount.Value = 15000
+	tx.PaymentTypeInfo.LocalInstrument = &LocalInstrument{Code: Ptr("INST")}
+	acceptance := NewISODateTime(accepted)
+	tx.AcceptanceDateTime = &acceptance
+	if err := SEPAInstantProfile.Check(doc); err != nil {
+		t.Fatalf("Expected the instant credit transfer to pass, got %v", err)
+	}
+
+	if deadline := SEPAInstantProfile.Deadline(accepted); !deadline.Equal(accepted.Add(20 * time.Second)) {
+		t.Errorf("Unexpected deadline %v", deadline)
+	}
+	if late, err := SEPAInstantProfile.TimedOut(tx, accepted.Add(SCTInstExecutionTime)); err != nil || late {
+		t.Errorf("Expected no timeout within the execution time, got %v, %v", late, err)
+	}
+	if late, _ := SEPAInstantProfile.TimedOut(tx, accepted.Add(21*time.Second)); !late {
+		t.Error("Expected a timeout after 21 seconds")
+	}
+}
+
+func TestSEPAInstantStatusAndResolution(t *testing.T) {
+	rejected, accepted := "RJCT", "ACTC"
+	report := &Pacs00200110Document{FIPaymentStatusReport: FIToFIPaymentStatusReportV10{TransactionInfoAndStatus: []PaymentTransaction110{
+		{OriginalTransactionID: Ptr("TX1"), TransactionStatus: &rejected},
+		{OriginalTransactionID: Ptr("TX2"), TransactionStatus: &accepted},
+	}}}
+	// SCT Inst reports the status of one transaction per message
+	errs, _ := SEPAInstantProfile.Check(report).(ValidationErrors)
+	if len(errs) != 3 || errs[0].Location() != "FIToFIPmtStsRpt/GrpHdr/NbOfTxs" ||
+		errs[1].Location() != "FIToFIPmtStsRpt/TxInfAndSts[1]/StsRsnInf/Rsn/Cd" || errs[2].Location() != "FIToFIPmtStsRpt/TxInfAndSts[2]/TxSts" {
+		t.Errorf("Unexpected errors %v", errs)
+	}
+
+	refused := "RJCR"
+	resolution := &Camt02900109Document{InvestigationResolution: ResolutionOfInvestigationV09{CancellationDetails: []UnderlyingTransaction22{{
+		TransactionInfo: []PaymentTransaction102{{TransactionCancellationStatus: &refused,
+			CancellationStatusReasonInfo: []CancellationStatusReason4{{Reason: &CancellationStatusReason3Choice{Code: Ptr("NOOR")}}}}},
+	}}}}
+	if err := SEPAInstantProfile.Check(resolution); err != nil {
+		t.Errorf("Expected the refusal to pass, got %v", err)
+	}
+	resolution.InvestigationResolution.CancellationDetails[0].TransactionInfo[0].CancellationStatusReasonInfo = nil
+	if err := SEPAInstantProfile.Check(resolution); err == nil {
+		t.Error("Expected an error for a refusal without a reason")
+	}
+}
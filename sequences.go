@@ -0,0 +1,311 @@
+package iso20022
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// ErrSequence is returned by an Encoder created with WithSequenceCheck for a
+// document with a component whose fields would be written in another order than
+// the XSD sequence of the component
+var ErrSequence = errors.New("elements out of XSD sequence order")
+
+// xsdSequences maps component types to the elements of their XSD sequence, in
+// schema order. An entry names only the elements its struct models. The table
+// covers the components shared by the messages of this package; iso20022gen -sequences emits the sequences of the components of a
+// message, which register themselves with registerSequences. Elements that a
+// struct has but its sequence does not, from the version of the component it also
+// serves, are not checked.
+var xsdSequences = map[string][]string{
+	// Shared components
+	"BranchAndFinancialInstitutionIdentification6": {"FinInstnId", "BrnchId"},
+	"FinancialInstitutionIdentification18":         {"BICFI", "ClrSysMmbId", "LEI", "Nm", "PstlAdr", "Othr"},
+	"BranchData3":                                  {"Id", "LEI", "Nm", "PstlAdr"},
+	"PartyIdentification135":                       {"Nm", "PstlAdr", "Id", "CtryOfRes", "CtctDtls"},
+	"PostalAddress24": {"AdrTp", "Dept", "SubDept", "StrtNm", "BldgNb", "BldgNm", "Flr", "PstBx", "Room",
+		"PstCd", "TwnNm", "TwnLctnNm", "DstrctNm", "CtrySubDvsn", "Ctry", "AdrLine"},
+	"BranchAndFinancialInstitutionIdentification8": {"FinInstnId", "BrnchId"},
+	"FinancialInstitutionIdentification23":         {"BICFI", "ClrSysMmbId", "LEI", "Nm", "PstlAdr", "Othr"},
+	"BranchData5":                                  {"Id", "LEI", "Nm", "PstlAdr"},
+	"PartyIdentification272":                       {"Nm", "PstlAdr", "Id", "CtryOfRes", "CtctDtls"},
+	"PostalAddress27": {"AdrTp", "CareOf", "Dept", "SubDept", "StrtNm", "BldgNb", "BldgNm", "Flr", "UnitNb",
+		"PstBx", "Room", "PstCd", "TwnNm", "TwnLctnNm", "DstrctNm", "CtrySubDvsn", "Ctry", "AdrLine"},
+	"Contact13": {"NmPrfx", "Nm", "PhneNb", "MobNb", "FaxNb", "URLAdr", "EmailAdr", "EmailPurp", "JobTitl",
+		"Rspnsblty", "Dept", "Othr", "PrefrdMtd"},
+	"CashAccount40":  {"Id", "Tp", "Ccy", "Nm", "Prxy"},
+	"PostalAddress1": {"AdrTp", "AdrLine", "StrtNm", "BldgNb", "PstCd", "TwnNm", "CtrySubDvsn", "Ctry"},
+	"Contact4": {"NmPrfx", "Nm", "PhneNb", "MobNb", "FaxNb", "EmailAdr", "EmailPurp", "JobTitl", "Rspnsblty",
+		"Dept", "Othr", "PrefrdMtd"},
+	"CashAccount38":                      {"Id", "Tp", "Ccy", "Nm", "Prxy"},
+	"CashAccount39":                      {"Id", "Tp", "Ccy", "Nm", "Prxy"},
+	"GenericAccountIdentification1":      {"Id", "SchmeNm", "Issr"},
+	"GenericOrganizationIdentification1": {"Id", "SchmeNm", "Issr"},
+	"GenericPersonIdentification2":       {"Id", "SchmeNm", "Issr"},
+	"OrganizationIdentification29":       {"AnyBIC", "LEI", "Othr"},
+	"PersonIdentification13":             {"DtAndPlcOfBirth", "Othr"},
+	"DateAndPlaceOfBirth1":               {"BirthDt", "PrvcOfBirth", "CityOfBirth", "CtryOfBirth"},
+	"PaymentIdentification7":             {"InstrId", "EndToEndId", "TxId", "UETR", "ClrSysRef"},
+	"PaymentTypeInfo28":                  {"InstrPrty", "ClrChanl", "SvcLvl", "LclInstrm", "CtgyPurp"},
+	"SettlementInstruction7": {"SttlmMtd", "SttlmAcct", "ClrSys", "InstgRmbrsmntAgt", "InstgRmbrsmntAgtAcct",
+		"InstdRmbrsmntAgt", "InstdRmbrsmntAgtAcct", "ThrdRmbrsmntAgt", "ThrdRmbrsmntAgtAcct"},
+	"Charges7":                       {"Amt", "Agt"},
+	"InstructionForCreditorAgent2":   {"Cd", "InstrInf"},
+	"InstructionForNextAgent1":       {"Cd", "InstrInf"},
+	"RegulatoryReporting3":           {"DbtCdtRptgInd", "Authrty", "Dtls"},
+	"StructuredRegulatoryReporting3": {"Tp", "Dt", "Ctry", "Cd", "Amt", "Inf"},
+	"RemittanceInfo16":               {"Ustrd", "Strd"},
+	"StructuredRemittanceInfo16": {"RfrdDocInf", "RfrdDocAmt", "CdtrRefInf", "Invcr", "Invcee", "TaxRmt",
+		"GrnshmtRmt", "AddtlRmtInf"},
+	"RemittanceAmount2":      {"DuePyblAmt", "DscntApldAmt", "CdtNoteAmt", "TaxAmt", "AdjstmntAmtAndRsn", "RmtdAmt"},
+	"CreditorReferenceInfo2": {"Tp", "Ref"},
+	"TaxInfo8": {"Cdtr", "Dbtr", "AdmstnZone", "RefNb", "Mtd", "TtlTaxblBaseAmt", "TtlTaxAmt", "Dt",
+		"SeqNb", "Rcrd"},
+	"SupplementaryData1": {"PlcAndNm", "Envlp"},
+	"MessageHeader1":     {"MsgId", "CreDtTm"},
+
+	// pacs.008, pacs.009 and pacs.004
+	"FIToFICustomerCreditTransferV08": {"GrpHdr", "CdtTrfTxInf", "SplmtryData"},
+	"GroupHeader93": {"MsgId", "CreDtTm", "BtchBookg", "NbOfTxs", "CtrlSum", "TtlIntrBkSttlmAmt",
+		"IntrBkSttlmDt", "SttlmInf", "PmtTpInf", "InstgAgt", "InstdAgt"},
+	"CreditTransferTransaction39": {"PmtId", "PmtTpInf", "IntrBkSttlmAmt", "IntrBkSttlmDt", "SttlmPrty",
+		"SttlmTmIndctn", "SttlmTmReq", "AccptncDtTm", "PoolgAdjstmntDt", "InstdAmt", "XchgRate", "ChrgBr",
+		"ChrgsInf", "PrvsInstgAgt1", "PrvsInstgAgt1Acct", "PrvsInstgAgt2", "PrvsInstgAgt2Acct", "PrvsInstgAgt3",
+		"PrvsInstgAgt3Acct", "InstgAgt", "InstdAgt", "IntrmyAgt1", "IntrmyAgt1Acct", "IntrmyAgt2",
+		"IntrmyAgt2Acct", "IntrmyAgt3", "IntrmyAgt3Acct", "UltmtDbtr", "InitgPty", "Dbtr", "DbtrAcct", "DbtrAgt",
+		"DbtrAgtAcct", "CdtrAgt", "CdtrAgtAcct", "Cdtr", "CdtrAcct", "UltmtCdtr", "InstrForCdtrAgt",
+		"InstrForNxtAgt", "Purp", "RgltryRptg", "Tax", "RltdRmtInf", "RmtInf", "SplmtryData"},
+	"FIToFICustomerCreditTransferV12": {"GrpHdr", "CdtTrfTxInf", "SplmtryData"},
+	"GroupHeader113": {"MsgId", "CreDtTm", "BtchBookg", "NbOfTxs", "CtrlSum", "TtlIntrBkSttlmAmt",
+		"IntrBkSttlmDt", "SttlmInf", "PmtTpInf", "InstgAgt", "InstdAgt"},
+	"SettlementInstruction15": {"SttlmMtd", "SttlmAcct", "ClrSys", "InstgRmbrsmntAgt", "InstgRmbrsmntAgtAcct",
+		"InstdRmbrsmntAgt", "InstdRmbrsmntAgtAcct", "ThrdRmbrsmntAgt", "ThrdRmbrsmntAgtAcct"},
+	"CreditTransferTransaction64": {"PmtId", "PmtTpInf", "IntrBkSttlmAmt", "IntrBkSttlmDt", "SttlmPrty",
+		"SttlmTmIndctn", "SttlmTmReq", "AccptncDtTm", "PoolgAdjstmntDt", "InstdAmt", "XchgRate", "ChrgBr",
+		"ChrgsInf", "PrvsInstgAgt1", "PrvsInstgAgt1Acct", "PrvsInstgAgt2", "PrvsInstgAgt2Acct", "PrvsInstgAgt3",
+		"PrvsInstgAgt3Acct", "InstgAgt", "InstdAgt", "IntrmyAgt1", "IntrmyAgt1Acct", "IntrmyAgt2",
+		"IntrmyAgt2Acct", "IntrmyAgt3", "IntrmyAgt3Acct", "UltmtDbtr", "InitgPty", "Dbtr", "DbtrAcct", "DbtrAgt",
+		"DbtrAgtAcct", "CdtrAgt", "CdtrAgtAcct", "Cdtr", "CdtrAcct", "UltmtCdtr", "InstrForCdtrAgt",
+		"InstrForNxtAgt", "Purp", "RgltryRptg", "Tax", "RltdRmtInf", "RmtInf", "SplmtryData"},
+	"Charges16": {"Amt", "Agt"},
+	"CreditTransferTransaction36": {"PmtId", "PmtTpInf", "IntrBkSttlmAmt", "IntrBkSttlmDt", "SttlmPrty",
+		"SttlmTmIndctn", "SttlmTmReq", "PrvsInstgAgt1", "PrvsInstgAgt1Acct", "PrvsInstgAgt2",
+		"PrvsInstgAgt2Acct", "PrvsInstgAgt3", "PrvsInstgAgt3Acct", "InstgAgt", "InstdAgt", "IntrmyAgt1",
+		"IntrmyAgt1Acct", "IntrmyAgt2", "IntrmyAgt2Acct", "IntrmyAgt3", "IntrmyAgt3Acct", "UltmtDbtr", "Dbtr",
+		"DbtrAcct", "DbtrAgt", "DbtrAgtAcct", "CdtrAgt", "CdtrAgtAcct", "Cdtr", "CdtrAcct", "UltmtCdtr",
+		"InstrForCdtrAgt", "InstrForNxtAgt", "Purp", "RmtInf", "UndrlygCstmrCdtTrf", "SplmtryData"},
+	"CreditTransferTransaction37": {"UltmtDbtr", "InitgPty", "Dbtr", "DbtrAcct", "DbtrAgt", "DbtrAgtAcct",
+		"PrvsInstgAgt1", "PrvsInstgAgt1Acct", "PrvsInstgAgt2", "PrvsInstgAgt2Acct", "PrvsInstgAgt3",
+		"PrvsInstgAgt3Acct", "IntrmyAgt1", "IntrmyAgt1Acct", "IntrmyAgt2", "IntrmyAgt2Acct", "IntrmyAgt3",
+		"IntrmyAgt3Acct", "CdtrAgt", "CdtrAgtAcct", "Cdtr", "CdtrAcct", "UltmtCdtr", "InstrForCdtrAgt",
+		"InstrForNxtAgt", "Tax", "RmtInf", "InstdAmt"},
+	"PaymentReturnV10": {"GrpHdr", "OrgnlGrpInf", "TxInf", "SplmtryData"},
+	"GroupHeader90": {"MsgId", "CreDtTm", "Authstn", "BtchBookg", "NbOfTxs", "CtrlSum", "GrpRtr",
+		"TtlRtrdIntrBkSttlmAmt", "IntrBkSttlmDt", "SttlmInf", "InstgAgt", "InstdAgt"},
+	"PaymentTransaction118": {"RtrId", "OrgnlGrpInf", "OrgnlInstrId", "OrgnlEndToEndId", "OrgnlTxId",
+		"OrgnlUETR", "OrgnlClrSysRef", "OrgnlIntrBkSttlmAmt", "OrgnlIntrBkSttlmDt", "RtrdIntrBkSttlmAmt",
+		"IntrBkSttlmDt", "SttlmPrty", "SttlmTmIndctn", "RtrdInstdAmt",
+		"XchgRate", "CompstnAmt", "ChrgBr", "ChrgsInf", "ClrSysRef", "InstgAgt", "InstdAgt", "RtrChain",
+		"RtrRsnInf", "OrgnlTxRef", "SplmtryData"},
+	"TransactionParties8": {"UltmtDbtr", "Dbtr", "DbtrAcct", "InitgPty", "DbtrAgt", "DbtrAgtAcct",
+		"PrvsInstgAgt1", "PrvsInstgAgt1Acct", "PrvsInstgAgt2", "PrvsInstgAgt2Acct", "PrvsInstgAgt3",
+		"PrvsInstgAgt3Acct", "IntrmyAgt1", "IntrmyAgt1Acct", "IntrmyAgt2", "IntrmyAgt2Acct", "IntrmyAgt3",
+		"IntrmyAgt3Acct", "CdtrAgt", "CdtrAgtAcct", "Cdtr", "CdtrAcct", "UltmtCdtr"},
+	"PaymentReturnReason6": {"Orgtr", "Rsn", "AddtlInf"},
+	"OriginalTransactionReference28": {"IntrBkSttlmAmt", "Amt", "IntrBkSttlmDt", "ReqdColltnDt",
+		"ReqdExctnDt", "CdtrSchmeId", "SttlmInf", "PmtTpInf", "PmtMtd", "MndtRltdInf", "RmtInf", "UltmtDbtr",
+		"Dbtr", "DbtrAcct", "DbtrAgt", "DbtrAgtAcct", "CdtrAgt", "CdtrAgtAcct", "Cdtr", "CdtrAcct", "UltmtCdtr",
+		"Purp"},
+
+	// pacs.002 and pacs.028
+	"FIToFIPaymentStatusReportV10": {"GrpHdr", "OrgnlGrpInfAndSts", "TxInfAndSts", "SplmtryData"},
+	"GroupHeader91":                {"MsgId", "CreDtTm", "InstgAgt", "InstdAgt"},
+	"OriginalGroupHeader17": {"OrgnlMsgId", "OrgnlMsgNmId", "OrgnlCreDtTm", "OrgnlNbOfTxs", "OrgnlCtrlSum",
+		"GrpSts", "StsRsnInf", "NbOfTxsPerSts"},
+	"PaymentTransaction110": {"StsId", "OrgnlGrpInf", "OrgnlInstrId", "OrgnlEndToEndId", "OrgnlTxId",
+		"OrgnlUETR", "TxSts", "StsRsnInf", "ChrgsInf", "AccptncDtTm", "FctvIntrBkSttlmDt", "AcctSvcrRef",
+		"ClrSysRef", "InstgAgt", "InstdAgt", "OrgnlTxRef", "SplmtryData"},
+	"StatusReasonInfo12": {"Orgtr", "Rsn", "AddtlInf"},
+	"PaymentTransaction113": {"StsReqId", "OrgnlGrpInf", "OrgnlInstrId", "OrgnlEndToEndId", "OrgnlTxId",
+		"OrgnlUETR", "AccptncDtTm", "ClrSysRef", "InstgAgt", "InstdAgt", "OrgnlTxRef", "SplmtryData"},
+	"GroupHeader53": {"MsgId", "CreDtTm", "InstgAgt", "InstdAgt"},
+	"OriginalGroupInformation20": {"OrgnlMsgId", "OrgnlMsgNmId", "OrgnlCreDtTm", "OrgnlNbOfTxs",
+		"OrgnlCtrlSum", "GrpSts", "StsRsnInf", "NbOfTxsPerSts"},
+	"PaymentTransactionInformation26": {"StsId", "OrgnlInstrId", "OrgnlEndToEndId", "OrgnlTxId", "TxSts",
+		"StsRsnInf", "ChrgsInf", "AccptncDtTm", "AcctSvcrRef", "ClrSysRef", "OrgnlTxRef"},
+	"StatusReasonInformation8": {"Orgtr", "Rsn", "AddtlInf"},
+
+	// camt.056 and camt.029
+	"FIToFIPaymentCancellationRequestV08": {"Assgnmt", "Case", "CtrlData", "Undrlyg", "SplmtryData"},
+	"CaseAssignment5":                     {"Id", "Assgnr", "Assgne", "CreDtTm"},
+	"Case5":                               {"Id", "Cretr", "ReopCaseIndctn"},
+	"OriginalGroupHeader15": {"GrpCxlId", "Case", "OrgnlMsgId", "OrgnlMsgNmId", "OrgnlCreDtTm", "NbOfTxs",
+		"CtrlSum", "GrpCxl", "CxlRsnInf"},
+	"PaymentTransaction106": {"CxlId", "Case", "OrgnlGrpInf", "OrgnlInstrId", "OrgnlEndToEndId", "OrgnlTxId",
+		"OrgnlUETR", "OrgnlClrSysRef", "OrgnlIntrBkSttlmAmt", "OrgnlIntrBkSttlmDt", "Assgnr", "Assgne",
+		"CxlRsnInf", "OrgnlTxRef", "SplmtryData"},
+	"PaymentCancellationReason5": {"Orgtr", "Rsn", "AddtlInf"},
+	"ResolutionOfInvestigationV09": {"Assgnmt", "RslvdCase", "Sts", "CxlDtls", "ModDtls", "ClmNonRctDtls",
+		"StmtDtls", "CrrctnTx", "RsltnRltdInf", "SplmtryData"},
+	"UnderlyingTransaction22": {"OrgnlGrpInfAndSts", "OrgnlPmtInfAndSts", "TxInfAndSts"},
+	"OriginalGroupHeader14": {"OrgnlGrpCxlId", "RslvdCase", "OrgnlMsgId", "OrgnlMsgNmId", "OrgnlCreDtTm",
+		"OrgnlNbOfTxs", "OrgnlCtrlSum", "GrpCxlSts", "CxlStsRsnInf", "NbOfTxsPerCxlSts"},
+	"PaymentTransaction102": {"CxlStsId", "RslvdCase", "OrgnlGrpInf", "OrgnlInstrId", "OrgnlEndToEndId",
+		"OrgnlTxId", "OrgnlClrSysRef", "OrgnlUETR", "TxCxlSts", "CxlStsRsnInf", "RsltnRltdInf",
+		"OrgnlIntrBkSttlmAmt", "OrgnlIntrBkSttlmDt", "Assgnr", "Assgne", "OrgnlTxRef"},
+	"CancellationStatusReason4": {"Orgtr", "Rsn", "AddtlInf"},
+
+	// camt.056.001.11 and camt.029.001.13
+	"FIToFIPaymentCancellationRequestV11": {"Assgnmt", "Case", "CtrlData", "Undrlyg", "SplmtryData"},
+	"CaseAssignment6":                     {"Id", "Assgnr", "Assgne", "CreDtTm"},
+	"Case6":                               {"Id", "Cretr", "ReopCaseIndctn"},
+	"OriginalGroupHeader21": {"GrpCxlId", "Case", "OrgnlMsgId", "OrgnlMsgNmId", "OrgnlCreDtTm", "NbOfTxs",
+		"CtrlSum", "GrpCxl", "CxlRsnInf"},
+	"PaymentTransaction155": {"CxlId", "Case", "OrgnlGrpInf", "OrgnlInstrId", "OrgnlEndToEndId", "OrgnlTxId",
+		"OrgnlUETR", "OrgnlClrSysRef", "OrgnlIntrBkSttlmAmt", "OrgnlIntrBkSttlmDt", "Assgnr", "Assgne",
+		"CxlRsnInf", "OrgnlTxRef", "SplmtryData"},
+	"PaymentCancellationReason6": {"Orgtr", "Rsn", "AddtlInf"},
+	"ResolutionOfInvestigationV13": {"Assgnmt", "RslvdCase", "Sts", "CxlDtls", "ModDtls", "ClmNonRctDtls",
+		"StmtDtls", "CrrctnTx", "RsltnRltdInf", "SplmtryData"},
+	"UnderlyingTransaction32": {"OrgnlGrpInfAndSts", "OrgnlPmtInfAndSts", "TxInfAndSts"},
+	"OriginalGroupHeader20": {"OrgnlGrpCxlId", "RslvdCase", "OrgnlMsgId", "OrgnlMsgNmId", "OrgnlCreDtTm",
+		"OrgnlNbOfTxs", "OrgnlCtrlSum", "GrpCxlSts", "CxlStsRsnInf", "NbOfTxsPerCxlSts"},
+	"PaymentTransaction152": {"CxlStsId", "RslvdCase", "OrgnlGrpInf", "OrgnlInstrId", "OrgnlEndToEndId",
+		"OrgnlTxId", "OrgnlClrSysRef", "OrgnlUETR", "TxCxlSts", "CxlStsRsnInf", "RsltnRltdInf",
+		"OrgnlIntrBkSttlmAmt", "OrgnlIntrBkSttlmDt", "Assgnr", "Assgne", "OrgnlTxRef"},
+	"CancellationStatusReason5": {"Orgtr", "Rsn", "AddtlInf"},
+	"OriginalTransactionReference42": {"IntrBkSttlmAmt", "Amt", "IntrBkSttlmDt", "ReqdColltnDt",
+		"ReqdExctnDt", "CdtrSchmeId", "SttlmInf", "PmtTpInf", "PmtMtd", "MndtRltdInf", "RmtInf", "UltmtDbtr",
+		"Dbtr", "DbtrAcct", "DbtrAgt", "DbtrAgtAcct", "CdtrAgt", "CdtrAgtAcct", "Cdtr", "CdtrAcct", "UltmtCdtr",
+		"Purp"},
+	"RemittanceInfo22": {"Ustrd", "Strd"},
+	"StructuredRemittanceInfo18": {"RfrdDocInf", "RfrdDocAmt", "CdtrRefInf", "Invcr", "Invcee", "TaxRmt",
+		"GrnshmtRmt", "AddtlRmtInf"},
+
+	// camt.052, camt.053 and camt.054
+	"BankToCustomerAccountReportV08":           {"GrpHdr", "Rpt", "SplmtryData"},
+	"BankToCustomerDebitCreditNotificationV08": {"GrpHdr", "Ntfctn", "SplmtryData"},
+	"GroupHeader81":                            {"MsgId", "CreDtTm", "MsgRcpt", "MsgPgntn", "OrgnlBizQry", "AddtlInf"},
+	"Pagination1":                              {"PgNb", "LastPgInd"},
+	"AccountReport25": {"Id", "RptPgntn", "ElctrncSeqNb", "RptgSeq", "LglSeqNb", "CreDtTm", "FrToDt",
+		"CpyDplctInd", "RptgSrc", "Acct", "RltdAcct", "Intrst", "Bal", "TxsSummry", "Ntry", "AddtlRptInf"},
+	"AccountNotification17": {"Id", "NtfctnPgntn", "ElctrncSeqNb", "RptgSeq", "LglSeqNb", "CreDtTm", "FrToDt",
+		"CpyDplctInd", "RptgSrc", "Acct", "RltdAcct", "Intrst", "TxsSummry", "Ntry", "AddtlNtfctnInf"},
+	"CashBalance8": {"Tp", "CdtLine", "Amt", "CdtDbtInd", "Dt", "Avlbty"},
+	"ReportEntry10": {"NtryRef", "Amt", "CdtDbtInd", "RvslInd", "Sts", "BookgDt", "ValDt", "AcctSvcrRef",
+		"Avlbty", "BkTxCd", "ComssnWvrInd", "AddtlInfInd", "AmtDtls", "Chrgs", "TechInptChanl", "Intrst",
+		"NtryDtls", "AddtlNtryInf"},
+	"EntryDetails9": {"Btch", "TxDtls"},
+	"EntryTransaction10": {"Refs", "Amt", "CdtDbtInd", "AmtDtls", "Avlbty", "BkTxCd", "Chrgs", "Intrst",
+		"RltdPties", "RltdAgts", "Purp", "RltdRmtInf", "RmtInf", "RltdDts", "RltdPric",
+		"RltdQties", "FinInstrmId", "Tax", "RtrInf", "CorpActn", "AddtlTxInf",
+		"SplmtryData"},
+	"TransactionReferences6": {"MsgId", "AcctSvcrRef", "PmtInfId", "InstrId", "EndToEndId", "TxId",
+		"MndtId", "ChqNb", "ClrSysRef", "AcctOwnrTxId", "AcctSvcrTxId", "MktInfrstrctrTxId", "PrcgId"},
+	"AmountAndCurrencyExchange3": {"InstdAmt", "TxAmt", "CntrValAmt", "AnncdPstngAmt", "PrtryAmt"},
+	"TransactionParties6": {"InitgPty", "Dbtr", "DbtrAcct", "UltmtDbtr", "Cdtr", "CdtrAcct", "UltmtCdtr",
+		"TradgPty", "Prtry"},
+	"TransactionAgents5": {"InstgAgt", "InstdAgt", "DbtrAgt", "CdtrAgt", "IntrmyAgt1", "IntrmyAgt2",
+		"IntrmyAgt3", "RcvgAgt", "DlvrgAgt", "IssgAgt", "Prtry"},
+
+	// pain.001 and pain.013
+	"CustomerCreditTransferInitiationV09": {"GrpHdr", "PmtInf", "SplmtryData"},
+	"GroupHeader85":                       {"MsgId", "CreDtTm", "NbOfTxs", "CtrlSum", "InitgPty", "FwdgAgt"},
+	"PaymentInstruction30": {"PmtInfId", "PmtMtd", "BtchBookg", "NbOfTxs", "CtrlSum", "PmtTpInf",
+		"ReqdExctnDt", "PoolgAdjstmntDt", "Dbtr", "DbtrAcct", "DbtrAgt", "DbtrAgtAcct", "InstrForDbtrAgt",
+		"UltmtDbtr", "ChrgBr", "ChrgsAcct", "ChrgsAcctAgt", "CdtTrfTxInf"},
+	"CreditTransferTransaction34": {"PmtId", "PmtTpInf", "Amt", "ChrgBr", "ChqInstr",
+		"UltmtDbtr", "IntrmyAgt1", "IntrmyAgt1Acct", "IntrmyAgt2", "IntrmyAgt2Acct", "IntrmyAgt3",
+		"IntrmyAgt3Acct", "CdtrAgt", "CdtrAgtAcct", "Cdtr", "CdtrAcct", "UltmtCdtr", "InstrForCdtrAgt",
+		"InstrForDbtrAgt", "Purp", "RgltryRptg", "Tax", "RltdRmtInf", "RmtInf", "SplmtryData"},
+	"PaymentInstruction31": {"PmtInfId", "PmtMtd", "PmtTpInf", "ReqdExctnDt", "XpryDt", "PmtCond", "Dbtr",
+		"DbtrAcct", "DbtrAgt", "UltmtDbtr", "ChrgBr", "CdtTrfTx"},
+	"CreditTransferTransaction35": {"PmtId", "PmtTpInf", "PmtCond", "Amt", "ChrgBr", "ChqInstr", "UltmtDbtr",
+		"IntrmyAgt1", "IntrmyAgt2", "IntrmyAgt3", "CdtrAgt", "Cdtr", "CdtrAcct", "UltmtCdtr", "InstrForCdtrAgt",
+		"Purp", "RgltryRptg", "Tax", "RltdRmtInf", "RmtInf", "NclsdFile", "SplmtryData"},
+
+	// pacs.008.001.02
+	"GroupHeader33": {"MsgId", "CreDtTm", "BtchBookg", "NbOfTxs", "CtrlSum", "TtlIntrBkSttlmAmt",
+		"IntrBkSttlmDt", "SttlmInf", "PmtTpInf", "InstgAgt", "InstdAgt"},
+	"SettlementInformation13": {"SttlmMtd", "SttlmAcct", "ClrSys", "InstgRmbrsmntAgt", "InstgRmbrsmntAgtAcct",
+		"InstdRmbrsmntAgt", "InstdRmbrsmntAgtAcct", "ThrdRmbrsmntAgt", "ThrdRmbrsmntAgtAcct"},
+	"CreditTransferTransactionInformation11": {"PmtId", "PmtTpInf", "IntrBkSttlmAmt", "IntrBkSttlmDt",
+		"SttlmPrty", "SttlmTmIndctn", "SttlmTmReq", "AccptncDtTm", "PoolgAdjstmntDt", "InstdAmt", "XchgRate",
+		"ChrgBr", "ChrgsInf", "PrvsInstgAgt", "PrvsInstgAgtAcct", "InstgAgt", "InstdAgt", "IntrmyAgt1",
+		"IntrmyAgt1Acct", "IntrmyAgt2", "IntrmyAgt2Acct", "IntrmyAgt3", "IntrmyAgt3Acct", "UltmtDbtr",
+		"InitgPty", "Dbtr", "DbtrAcct", "DbtrAgt", "DbtrAgtAcct", "CdtrAgt", "CdtrAgtAcct", "Cdtr", "CdtrAcct",
+		"UltmtCdtr", "InstrForCdtrAgt", "InstrForNxtAgt", "Purp", "RgltryRptg", "Tax", "RltdRmtInf", "RmtInf"},
+	"PaymentIdentification3":              {"InstrId", "EndToEndId", "TxId", "ClrSysRef"},
+	"FinancialInstitutionIdentification7": {"BIC", "ClrSysMmbId", "Nm", "PstlAdr", "Othr"},
+	"PartyIdentification32":               {"Nm", "PstlAdr", "Id", "CtryOfRes", "CtctDtls"},
+}
+
+// registerSequences adds the XSD sequences of generated components to the table.
+// It is called from the init functions of files written by iso20022gen -sequences.
+func registerSequences(sequences map[string][]string) {
+	for name, elements := range sequences {
+		xsdSequences[name] = elements
+	}
+}
+
+// sequenceChecks caches the result of checkSequences per type
+var sequenceChecks sync.Map // reflect.Type → error
+
+// checkSequences checks that the fields of every component reachable from t are
+// declared in the order of the XSD sequence of the component, so that
+// encoding/xml writes them in schema order
+func checkSequences(t reflect.Type) error {
+	if err, ok := sequenceChecks.Load(t); ok {
+		if err == nil {
+			return nil
+		}
+		return err.(error)
+	}
+	err := walkSequences(t, make(map[reflect.Type]bool))
+	sequenceChecks.Store(t, err)
+	return err
+}
+
+// walkSequences checks the struct types reachable from t that are not in seen
+func walkSequences(t reflect.Type, seen map[reflect.Type]bool) error {
+	for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || seen[t] {
+		return nil
+	}
+	seen[t] = true
+	var positions map[string]int
+	if elements, ok := xsdSequences[t.Name()]; ok {
+		positions = make(map[string]int, len(elements))
+		for i, name := range elements {
+			positions[name] = i
+		}
+	}
+	last, lastName := -1, ""
+	for _, field := range reflect.VisibleFields(t) {
+		if !field.IsExported() || field.Anonymous || field.Name == "XMLName" {
+			continue
+		}
+		name, flags, _ := strings.Cut(field.Tag.Get("xml"), ",")
+		if name == "-" || strings.Contains(flags, "attr") || strings.Contains(flags, "chardata") ||
+			strings.Contains(flags, "innerxml") || strings.Contains(flags, "comment") {
+			continue
+		}
+		if j := strings.LastIndex(name, " "); j >= 0 {
+			name = name[j+1:]
+		}
+		if pos, ok := positions[name]; ok {
+			if pos < last {
+				return fmt.Errorf("%w: %s writes %s before %s", ErrSequence, t.Name(), lastName, name)
+			}
+			last, lastName = pos, name
+		}
+		if err := walkSequences(field.Type, seen); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,189 @@
+package iso20022
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestSequences checks the field order of the components of every message against
+// their XSD sequences
+func TestSequences(t *testing.T) {
+	for _, newDocument := range documentTypes {
+		doc := newDocument()
+		if err := checkSequences(reflect.TypeOf(doc)); err != nil {
+			t.Errorf("%T: %v", doc, err)
+		}
+	}
+}
+
+// TestSequenceEmittedOrder marshals every round-trip sample and checks that the
+// children of each element with a known sequence are written in schema order
+func TestSequenceEmittedOrder(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join("testdata", "roundtrip", "*", "*.xml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		doc := roundTripDocuments[filepath.Base(filepath.Dir(file))]()
+		if err := xml.Unmarshal(data, doc); err != nil {
+			t.Fatalf("%s: %v", file, err)
+		}
+		out, err := Marshal(doc, WithSequenceCheck())
+		if err != nil {
+			t.Fatalf("%s: %v", file, err)
+		}
+		if err := emittedOrder(out, reflect.TypeOf(doc)); err != nil {
+			t.Errorf("%s: %v", file, err)
+		}
+	}
+}
+
+// emittedOrder walks the elements of data, decoded into t, and reports the first
+// one written before an element that follows it in the sequence of its parent
+func emittedOrder(data []byte, t reflect.Type) error {
+	type frame struct {
+		typ  reflect.Type
+		last int
+		name string
+	}
+	var stack []frame
+	d := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		tok, err := d.Token()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		switch tok := tok.(type) {
+		case xml.StartElement:
+			if len(stack) == 0 {
+				stack = append(stack, frame{typ: t, last: -1})
+				continue
+			}
+			parent := &stack[len(stack)-1]
+			child := elementType(parent.typ, tok.Name.Local)
+			if parent.typ != nil {
+				for i, name := range xsdSequences[parent.typ.Name()] {
+					if name != tok.Name.Local {
+						continue
+					}
+					if i < parent.last {
+						return errors.New(parent.typ.Name() + ": " + parent.name + " is written before " + name)
+					}
+					parent.last, parent.name = i, name
+				}
+			}
+			stack = append(stack, frame{typ: child, last: -1})
+		case xml.EndElement:
+			stack = stack[:len(stack)-1]
+		}
+	}
+}
+
+// elementType returns the struct type of the field of t tagged name, or nil
+func elementType(t reflect.Type, name string) reflect.Type {
+	for t != nil && (t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice) {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil
+	}
+	for _, field := range reflect.VisibleFields(t) {
+		tag, _, _ := strings.Cut(field.Tag.Get("xml"), ",")
+		if tag == name {
+			ft := field.Type
+			for ft.Kind() == reflect.Ptr || ft.Kind() == reflect.Slice {
+				ft = ft.Elem()
+			}
+			return ft
+		}
+	}
+	return nil
+}
+
+// sequenceTestHeader declares its elements in the wrong order
+type sequenceTestHeader struct {
+	CreationDateTime string `xml:"CreDtTm"`
+	MessageID        string `xml:"MsgId"`
+}
+
+type sequenceTestDocument struct {
+	XMLName xml.Name           `xml:"Document"`
+	Header  sequenceTestHeader `xml:"GrpHdr"`
+}
+
+func TestSequenceCheck(t *testing.T) {
+	registerSequences(map[string][]string{"sequenceTestHeader": {"MsgId", "CreDtTm"}})
+	defer delete(xsdSequences, "sequenceTestHeader")
+
+	doc := &sequenceTestDocument{Header: sequenceTestHeader{CreationDateTime: "2024-03-15T09:30:47Z", MessageID: "MSG1"}}
+	_, err := Marshal(doc, WithSequenceCheck())
+	if !errors.Is(err, ErrSequence) || !strings.Contains(err.Error(), "sequenceTestHeader writes CreDtTm before MsgId") {
+		t.Errorf("Expected ErrSequence, got %v", err)
+	}
+	if _, err := Marshal(doc); err != nil {
+		t.Errorf("Expected the document to be written without the check, got %v", err)
+	}
+	if err := emittedOrder([]byte(`<Document><GrpHdr><CreDtTm/><MsgId/></GrpHdr></Document>`), reflect.TypeOf(doc)); err == nil {
+		t.Errorf("Expected the emitted order to be reported")
+	}
+}
+
+// TestSequenceFields checks that every element named in the XSD sequence of a
+// component is a field of its struct
+func TestSequenceFields(t *testing.T) {
+	seen := make(map[reflect.Type]bool)
+	for _, newDocument := range documentTypes {
+		sequenceFields(t, reflect.TypeOf(newDocument()), seen)
+	}
+}
+
+// sequenceFields reports the sequence elements missing from the struct types
+// reachable from typ that are not in seen
+func sequenceFields(t *testing.T, typ reflect.Type, seen map[reflect.Type]bool) {
+	for typ.Kind() == reflect.Ptr || typ.Kind() == reflect.Slice {
+		typ = typ.Elem()
+	}
+	if typ.Kind() != reflect.Struct || seen[typ] {
+		return
+	}
+	seen[typ] = true
+	elements, ok := xsdSequences[typ.Name()]
+	fields := make(map[string]bool)
+	for _, field := range reflect.VisibleFields(typ) {
+		if !field.IsExported() || field.Anonymous || field.Name == "XMLName" {
+			continue
+		}
+		sequenceFields(t, field.Type, seen)
+		name, flags, _ := strings.Cut(field.Tag.Get("xml"), ",")
+		if name == "" || name == "-" || strings.Contains(flags, "attr") || strings.Contains(flags, "chardata") ||
+			strings.Contains(flags, "innerxml") || strings.Contains(flags, "comment") {
+			continue
+		}
+		if j := strings.LastIndex(name, " "); j >= 0 {
+			name = name[j+1:]
+		}
+		fields[name] = true
+	}
+	if !ok {
+		return
+	}
+	for _, name := range elements {
+		if !fields[name] {
+			t.Errorf("%s: the sequence names %s, which the struct does not declare", typ.Name(), name)
+		}
+	}
+}
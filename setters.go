@@ -0,0 +1,80 @@
+package iso20022
+
+// Ptr returns a pointer to v, for the optional fields of messages:
+//
+//	tx.SettlementPriority = iso20022.Ptr("HIGH")
+func Ptr[T any](v T) *T {
+	return &v
+}
+
+// The With methods return a copy of a value with one optional element set, so that
+// the common components of a message can be written as one expression:
+//
+//	tx.CreditorAgent = iso20022.AgentFromBIC("CHASUS33").WithName("JPMorgan Chase Bank")
+//	tx.Creditor = iso20022.PartyIdentification135{}.WithName("ACME Corp").WithCountryOfResidence("US")
+
+// WithName returns the agent with name Nm
+func (a BranchAndFinancialInstitutionIdentification6) WithName(name string) BranchAndFinancialInstitutionIdentification6 {
+	a.FinancialInstitutionID.Name = &name
+	return a
+}
+
+// WithBIC returns the agent with BIC BICFI
+func (a BranchAndFinancialInstitutionIdentification6) WithBIC(bic string) BranchAndFinancialInstitutionIdentification6 {
+	a.FinancialInstitutionID.BankIdentifierCode = &bic
+	return a
+}
+
+// WithLEI returns the agent with legal entity identifier LEI
+func (a BranchAndFinancialInstitutionIdentification6) WithLEI(lei string) BranchAndFinancialInstitutionIdentification6 {
+	a.FinancialInstitutionID.LegalEntityIdentifier = &lei
+	return a
+}
+
+// WithName returns the party with name Nm
+func (p PartyIdentification135) WithName(name string) PartyIdentification135 {
+	p.Name = &name
+	return p
+}
+
+// WithPostalAddress returns the party with postal address PstlAdr
+func (p PartyIdentification135) WithPostalAddress(address PostalAddress24) PartyIdentification135 {
+	p.PostalAddress = &address
+	return p
+}
+
+// WithCountryOfResidence returns the party with country of residence CtryOfRes
+func (p PartyIdentification135) WithCountryOfResidence(country string) PartyIdentification135 {
+	p.CountryOfResidence = &country
+	return p
+}
+
+// WithCurrency returns the account with currency Ccy
+func (a CashAccount38) WithCurrency(currency string) CashAccount38 {
+	a.Currency = &currency
+	return a
+}
+
+// WithName returns the account with name Nm
+func (a CashAccount38) WithName(name string) CashAccount38 {
+	a.Name = &name
+	return a
+}
+
+// WithInstructionID returns the identification with instruction identification InstrId
+func (p PaymentIdentification7) WithInstructionID(id string) PaymentIdentification7 {
+	p.InstructionID = &id
+	return p
+}
+
+// WithTransactionID returns the identification with transaction identification TxId
+func (p PaymentIdentification7) WithTransactionID(id string) PaymentIdentification7 {
+	p.TransactionID = &id
+	return p
+}
+
+// WithUETR returns the identification with UETR
+func (p PaymentIdentification7) WithUETR(uetr string) PaymentIdentification7 {
+	p.UETR = &uetr
+	return p
+}
@@ -0,0 +1,39 @@
+package iso20022
+
+import "testing"
+
+func TestPtr(t *testing.T) {
+	s := "HIGH"
+	p := Ptr(s)
+	s = "NORM"
+	if *p != "HIGH" {
+		t.Errorf("Expected a copy, got %s", *p)
+	}
+	if d := Ptr(Decimal(2.5)); *d != 2.5 {
+		t.Errorf("Unexpected decimal %v", *d)
+	}
+}
+
+func TestWithSetters(t *testing.T) {
+	agent := AgentFromBIC("CHASUS33").WithName("JPMorgan Chase Bank").WithLEI("8I5DZWZKVSZI1NUHU748")
+	if deref(agent.FinancialInstitutionID.Name) != "JPMorgan Chase Bank" || deref(agent.FinancialInstitutionID.LegalEntityIdentifier) != "8I5DZWZKVSZI1NUHU748" {
+		t.Errorf("Unexpected agent: %+v", agent.FinancialInstitutionID)
+	}
+	base := AgentFromClearingSystem("USABA", "026009593", "")
+	if withBIC := base.WithBIC("BOFAUS3N"); base.FinancialInstitutionID.BankIdentifierCode != nil || deref(withBIC.FinancialInstitutionID.BankIdentifierCode) != "BOFAUS3N" {
+		t.Error("Expected WithBIC to set a copy")
+	}
+
+	party := PartyIdentification135{}.WithName("ACME Corp").WithCountryOfResidence("US").WithPostalAddress(PostalAddress24{Country: Ptr("US")})
+	if deref(party.Name) != "ACME Corp" || deref(party.CountryOfResidence) != "US" || deref(party.PostalAddress.Country) != "US" {
+		t.Errorf("Unexpected party: %+v", party)
+	}
+	account := CashAccount38{ID: AccountIdentification4{IBAN: Ptr("GB29NWBK60161331926819")}}.WithCurrency("GBP").WithName("Operations")
+	if deref(account.Currency) != "GBP" || deref(account.Name) != "Operations" {
+		t.Errorf("Unexpected account: %+v", account)
+	}
+	id := PaymentIdentification7{EndToEndID: "INV-2024-0042"}.WithInstructionID("INSTR-1").WithTransactionID("TX-1").WithUETR("eb6305c9-1f7f-49de-aed0-16487c27b42d")
+	if deref(id.InstructionID) != "INSTR-1" || deref(id.TransactionID) != "TX-1" || deref(id.UETR) == "" {
+		t.Errorf("Unexpected identification: %+v", id)
+	}
+}
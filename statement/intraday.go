@@ -0,0 +1,167 @@
+package statement
+
+import (
+	"encoding/xml"
+	"sort"
+	"sync"
+
+	"github.com/ckbaum/iso20022-go"
+)
+
+// maxMissing bounds the sequence numbers a Delta lists as missing, so that a report
+// with a wrong sequence number does not list millions
+const maxMissing = 1000
+
+// Delta is what an intraday report adds to the earlier reports of its account
+type Delta struct {
+	ReportID string
+	Account  string
+	// Sequence is the electronic sequence number of the report, or its legal
+	// sequence number, 0 for reports with neither
+	Sequence int64
+	// Entries are the entries of the report that no earlier report of the account
+	// had, in report order
+	Entries []iso20022.ReportEntry10
+	// Balances are the balances of the report when it is the latest of its account,
+	// nil when a report with a higher sequence number came before it
+	Balances []iso20022.CashBalance8
+	// Duplicate reports that a report with the same sequence number was received
+	// before
+	Duplicate bool
+	// Late reports that a report with a higher sequence number was received before
+	Late bool
+	// Missing are the sequence numbers between the lowest and the highest received
+	// for the account that have not been received, in order
+	Missing []int64
+}
+
+// intradayAccount is what Intraday remembers of the reports of an account
+type intradayAccount struct {
+	entries  map[string]bool
+	received map[int64]bool
+	low      int64
+	high     int64
+}
+
+// Intraday computes the new entries of the successive intraday camt.052 reports of
+// accounts. Servicers send intraday reports either with all the entries of the day
+// or with those since the previous report, and reports may be received twice or out
+// of order, so entries are told apart by their account servicer reference, or entry
+// reference, or else their content, together with their status: a pending entry that
+// is later booked is new when it is reported as booked. It is safe for concurrent
+// use.
+type Intraday struct {
+	mu       sync.Mutex
+	accounts map[string]*intradayAccount
+}
+
+// NewIntraday returns an Intraday that has received no reports
+func NewIntraday() *Intraday {
+	return &Intraday{accounts: make(map[string]*intradayAccount)}
+}
+
+// Add adds the reports of a camt.052 message and returns their deltas, in report
+// order
+func (d *Intraday) Add(doc *iso20022.Camt05200108Document) []Delta {
+	deltas := make([]Delta, 0, len(doc.BankAccountReport.Report))
+	for _, report := range doc.BankAccountReport.Report {
+		deltas = append(deltas, d.AddReport(report))
+	}
+	return deltas
+}
+
+// AddReport adds a report, merged by an Assembler when it was paginated, and returns
+// what it adds to the earlier reports of its account
+func (d *Intraday) AddReport(report iso20022.AccountReport25) Delta {
+	delta := Delta{ReportID: report.ID, Account: report.Account.ID.Identifier(), Sequence: sequenceNumber(report)}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	account, ok := d.accounts[delta.Account]
+	if !ok {
+		account = &intradayAccount{entries: make(map[string]bool), received: make(map[int64]bool)}
+		d.accounts[delta.Account] = account
+	}
+
+	for _, entry := range report.Entry {
+		key := entryKey(entry)
+		if !account.entries[key] {
+			account.entries[key] = true
+			delta.Entries = append(delta.Entries, entry)
+		}
+	}
+
+	if seq := delta.Sequence; seq > 0 {
+		delta.Duplicate = account.received[seq]
+		delta.Late = seq < account.high
+		account.received[seq] = true
+		if account.low == 0 || seq < account.low {
+			account.low = seq
+		}
+		if seq > account.high {
+			account.high = seq
+		}
+	}
+	if !delta.Late && !delta.Duplicate {
+		delta.Balances = report.Balance
+	}
+	for seq := account.low; seq < account.high && len(delta.Missing) < maxMissing; seq++ {
+		if !account.received[seq] {
+			delta.Missing = append(delta.Missing, seq)
+		}
+	}
+	return delta
+}
+
+// Accounts returns the accounts reports were received for, in order
+func (d *Intraday) Accounts() []string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	accounts := make([]string, 0, len(d.accounts))
+	for account := range d.accounts {
+		accounts = append(accounts, account)
+	}
+	sort.Strings(accounts)
+	return accounts
+}
+
+// Discard forgets the reports of an account, for instance at the end of the day once
+// the statement has arrived. It reports whether any were received.
+func (d *Intraday) Discard(account string) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	_, ok := d.accounts[account]
+	delete(d.accounts, account)
+	return ok
+}
+
+// sequenceNumber returns the electronic or else the legal sequence number of a
+// report, 0 without either
+func sequenceNumber(report iso20022.AccountReport25) int64 {
+	switch {
+	case report.ElectronicSequenceNumber != nil:
+		return int64(*report.ElectronicSequenceNumber)
+	case report.LegalSequenceNumber != nil:
+		return int64(*report.LegalSequenceNumber)
+	}
+	return 0
+}
+
+// entryKey identifies an entry across reports: its status with its account servicer
+// reference, entry reference or, without either, its content
+func entryKey(entry iso20022.ReportEntry10) string {
+	status := ""
+	if entry.Status.Code != nil {
+		status = *entry.Status.Code
+	} else if entry.Status.Proprietary != nil {
+		status = *entry.Status.Proprietary
+	}
+	switch {
+	case entry.AccountServicerReference != nil:
+		return status + "\x00servicer\x00" + *entry.AccountServicerReference
+	case entry.EntryReference != nil:
+		return status + "\x00entry\x00" + *entry.EntryReference
+	}
+	data, _ := xml.Marshal(entry)
+	return status + "\x00content\x00" + string(data)
+}
@@ -0,0 +1,97 @@
+package statement
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ckbaum/iso20022-go"
+)
+
+// intradayReports splits the report of the sample into cumulative intraday reports
+// with sequence numbers 1 to 3: the first with one entry, each next with one more
+func intradayReports(t *testing.T) []iso20022.AccountReport25 {
+	t.Helper()
+	report := loadSample(t).BankAccountReport.Report[0]
+	reports := make([]iso20022.AccountReport25, 3)
+	for i := range reports {
+		seq := iso20022.Decimal(i + 1)
+		reports[i] = report
+		reports[i].ElectronicSequenceNumber = &seq
+		reports[i].Entry = report.Entry[:i+1]
+	}
+	return reports
+}
+
+func entryRefs(entries []iso20022.ReportEntry10) []string {
+	var refs []string
+	for _, e := range entries {
+		refs = append(refs, *e.EntryReference)
+	}
+	return refs
+}
+
+func TestIntradayDeltas(t *testing.T) {
+	reports := intradayReports(t)
+	d := NewIntraday()
+
+	first := d.AddReport(reports[0])
+	if got := entryRefs(first.Entries); !reflect.DeepEqual(got, []string{"E-0001"}) || first.Sequence != 1 || first.Balances == nil {
+		t.Errorf("Expected the first entry with the balances, got %v", first)
+	}
+
+	// Report 3 overtakes report 2
+	third := d.AddReport(reports[2])
+	if got := entryRefs(third.Entries); !reflect.DeepEqual(got, []string{"E-0002", "E-0003"}) || !reflect.DeepEqual(third.Missing, []int64{2}) {
+		t.Errorf("Expected entries 2 and 3 with report 2 missing, got %v", third)
+	}
+	second := d.AddReport(reports[1])
+	if len(second.Entries) != 0 || !second.Late || second.Balances != nil || second.Missing != nil {
+		t.Errorf("Expected the late report to add nothing, got %+v", second)
+	}
+	again := d.AddReport(reports[2])
+	if len(again.Entries) != 0 || !again.Duplicate || again.Balances != nil {
+		t.Errorf("Expected the duplicate report to add nothing, got %+v", again)
+	}
+
+	// The pending entry is booked in report 4, which only has the new entries
+	booked := reports[2]
+	seq := iso20022.Decimal(4)
+	booked.ElectronicSequenceNumber = &seq
+	entry := booked.Entry[2]
+	entry.Status = iso20022.EntryStatus1{Code: iso20022.Ptr("BOOK")}
+	booked.Entry = []iso20022.ReportEntry10{entry}
+	fourth := d.AddReport(booked)
+	if got := entryRefs(fourth.Entries); !reflect.DeepEqual(got, []string{"E-0003"}) || *fourth.Entries[0].Status.Code != "BOOK" {
+		t.Errorf("Expected the booked entry, got %v", fourth)
+	}
+
+	account := first.Account
+	if got := d.Accounts(); !reflect.DeepEqual(got, []string{account}) {
+		t.Errorf("Expected account %s, got %v", account, got)
+	}
+	if !d.Discard(account) || d.Discard(account) {
+		t.Error("Expected the account to be discarded once")
+	}
+	if again := d.AddReport(reports[0]); len(again.Entries) != 1 {
+		t.Errorf("Expected a discarded account to start over, got %v", again)
+	}
+}
+
+func TestIntradayWithoutSequenceNumbers(t *testing.T) {
+	doc := loadSample(t)
+	report := &doc.BankAccountReport.Report[0]
+	report.ElectronicSequenceNumber, report.LegalSequenceNumber = nil, nil
+	for i := range report.Entry {
+		report.Entry[i].EntryReference = nil
+		report.Entry[i].AccountServicerReference = nil
+	}
+
+	d := NewIntraday()
+	deltas := d.Add(doc)
+	if len(deltas) != 1 || len(deltas[0].Entries) != len(report.Entry) || deltas[0].Sequence != 0 {
+		t.Fatalf("Expected all entries of the first report, got %+v", deltas)
+	}
+	if deltas := d.Add(doc); len(deltas[0].Entries) != 0 || deltas[0].Balances == nil {
+		t.Errorf("Expected entries to be told apart by their content, got %+v", deltas[0])
+	}
+}
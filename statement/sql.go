@@ -0,0 +1,234 @@
+package statement
+
+import (
+	"context"
+	"database/sql"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ckbaum/iso20022-go"
+)
+
+// SQLSchema creates the tables of an SQL store. Booking dates are kept as
+// YYYY-MM-DD text, which orders and compares the same in every database, and
+// entries as their XML encoding.
+const SQLSchema = `CREATE TABLE statement_message (
+	msg_id   VARCHAR(35) NOT NULL PRIMARY KEY,
+	msg_type VARCHAR(35) NOT NULL,
+	document TEXT        NOT NULL
+);
+
+CREATE TABLE statement_entry (
+	msg_id       VARCHAR(35)    NOT NULL REFERENCES statement_message (msg_id),
+	seq          INTEGER        NOT NULL,
+	report_id    VARCHAR(35)    NOT NULL,
+	account      VARCHAR(34)    NOT NULL,
+	reference    VARCHAR(35),
+	currency     CHAR(3)        NOT NULL,
+	amount       DECIMAL(18, 5) NOT NULL,
+	status       VARCHAR(35)    NOT NULL,
+	booking_date CHAR(10),
+	entry        TEXT           NOT NULL,
+	PRIMARY KEY (msg_id, seq)
+);
+
+CREATE INDEX statement_entry_account ON statement_entry (account, booking_date);
+`
+
+// Queries of an SQL store, with ? placeholders
+const (
+	sqlMessageExists = `SELECT 1 FROM statement_message WHERE msg_id = ?`
+	sqlInsertMessage = `INSERT INTO statement_message (msg_id, msg_type, document) VALUES (?, ?, ?)`
+	sqlInsertEntry   = `INSERT INTO statement_entry (msg_id, seq, report_id, account, reference, currency, amount, status, booking_date, entry) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+	sqlLoadMessage   = `SELECT document FROM statement_message WHERE msg_id = ?`
+	sqlSelectEntries = `SELECT msg_id, seq, report_id, account, reference, currency, amount, status, booking_date, entry FROM statement_entry`
+	sqlOrderEntries  = ` ORDER BY booking_date, msg_id, seq`
+)
+
+// isoDateLayout is the layout of the booking_date column
+const isoDateLayout = "2006-01-02"
+
+// SQL is a Store backed by a database/sql database with the tables of SQLSchema.
+// It is safe for concurrent use.
+type SQL struct {
+	db       *sql.DB
+	numbered bool
+}
+
+// SQLOption configures an SQL store
+type SQLOption func(*SQL)
+
+// WithNumberedPlaceholders makes the store write its queries with $1, $2...
+// placeholders, as PostgreSQL expects, instead of ?
+func WithNumberedPlaceholders() SQLOption {
+	return func(s *SQL) {
+		s.numbered = true
+	}
+}
+
+// NewSQL returns a store saving to db, whose tables must have been created with
+// SQLSchema
+func NewSQL(db *sql.DB, opts ...SQLOption) *SQL {
+	s := &SQL{db: db}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Save implements Store. The message and its entries are saved in one transaction.
+func (s *SQL) Save(ctx context.Context, doc interface{}) error {
+	msgID, entries, err := ExtractEntries(doc)
+	if err != nil {
+		return err
+	}
+	msgType, err := messageType(doc)
+	if err != nil {
+		return err
+	}
+	data, err := iso20022.Marshal(doc)
+	if err != nil {
+		return err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var exists int
+	switch err := tx.QueryRowContext(ctx, s.bind(sqlMessageExists), msgID).Scan(&exists); {
+	case err == nil:
+		return fmt.Errorf("%w: %s", ErrDuplicateMessage, msgID)
+	case !errors.Is(err, sql.ErrNoRows):
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, s.bind(sqlInsertMessage), msgID, msgType, string(data)); err != nil {
+		return err
+	}
+	if len(entries) > 0 {
+		stmt, err := tx.PrepareContext(ctx, s.bind(sqlInsertEntry))
+		if err != nil {
+			return err
+		}
+		defer stmt.Close()
+		for _, e := range entries {
+			entry, err := xml.Marshal(struct {
+				XMLName xml.Name `xml:"Ntry"`
+				iso20022.ReportEntry10
+			}{ReportEntry10: e.Entry})
+			if err != nil {
+				return err
+			}
+			var bookingDate sql.NullString
+			if !e.BookingDate.IsZero() {
+				bookingDate = sql.NullString{String: e.BookingDate.Format(isoDateLayout), Valid: true}
+			}
+			_, err = stmt.ExecContext(ctx, e.MessageID, e.Sequence, e.ReportID, e.Account,
+				sql.NullString{String: e.Reference, Valid: e.Reference != ""},
+				e.Currency, float64(e.Amount), e.Status, bookingDate, string(entry))
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return tx.Commit()
+}
+
+// Load implements Store
+func (s *SQL) Load(ctx context.Context, msgID string) (interface{}, error) {
+	var data string
+	err := s.db.QueryRowContext(ctx, s.bind(sqlLoadMessage), msgID).Scan(&data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, msgID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	_, doc, err := iso20022.DecodeDocument([]byte(data))
+	return doc, err
+}
+
+// Entries implements Store
+func (s *SQL) Entries(ctx context.Context, q Query) ([]Entry, error) {
+	var where []string
+	var args []interface{}
+	if q.MessageID != "" {
+		where, args = append(where, "msg_id = ?"), append(args, q.MessageID)
+	}
+	if q.Account != "" {
+		where, args = append(where, "account = ?"), append(args, q.Account)
+	}
+	if !q.From.IsZero() {
+		where, args = append(where, "booking_date >= ?"), append(args, q.From.Format(isoDateLayout))
+	}
+	if !q.To.IsZero() {
+		where, args = append(where, "booking_date <= ?"), append(args, q.To.Format(isoDateLayout))
+	}
+	query := sqlSelectEntries
+	if len(where) > 0 {
+		query += " WHERE " + strings.Join(where, " AND ")
+	}
+	rows, err := s.db.QueryContext(ctx, s.bind(query+sqlOrderEntries), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []Entry
+	for rows.Next() {
+		var e Entry
+		var reference, bookingDate sql.NullString
+		var amount float64
+		var entry string
+		if err := rows.Scan(&e.MessageID, &e.Sequence, &e.ReportID, &e.Account, &reference, &e.Currency, &amount, &e.Status, &bookingDate, &entry); err != nil {
+			return nil, err
+		}
+		e.Reference, e.Amount = reference.String, iso20022.Decimal(amount)
+		if bookingDate.Valid {
+			date, err := iso20022.ParseISODate(bookingDate.String)
+			if err != nil {
+				return nil, fmt.Errorf("statement: booking date of entry %d of message %s: %w", e.Sequence, e.MessageID, err)
+			}
+			e.BookingDate = date
+		}
+		if err := xml.Unmarshal([]byte(entry), &e.Entry); err != nil {
+			return nil, fmt.Errorf("statement: entry %d of message %s: %w", e.Sequence, e.MessageID, err)
+		}
+		entries = append(entries, e)
+	}
+	return entries, rows.Err()
+}
+
+// bind rewrites the ? placeholders of a query as the store's database expects them
+func (s *SQL) bind(query string) string {
+	if !s.numbered {
+		return query
+	}
+	var b strings.Builder
+	n := 0
+	for _, r := range query {
+		if r == '?' {
+			n++
+			b.WriteString("$" + strconv.Itoa(n))
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// messageType returns the message name identification of a document
+func messageType(doc interface{}) (string, error) {
+	switch doc.(type) {
+	case *iso20022.Camt05200108Document:
+		return "camt.052.001.08", nil
+	case *iso20022.Camt05400108Document:
+		return "camt.054.001.08", nil
+	}
+	return "", fmt.Errorf("%w: %T", ErrUnsupportedDocument, doc)
+}
@@ -0,0 +1,188 @@
+package statement
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ckbaum/iso20022-go"
+)
+
+// fakeDB is a database/sql driver that records the statements executed and answers
+// queries with the rows queued for them, in order
+type fakeDB struct {
+	mu       sync.Mutex
+	execs    []fakeCall
+	queries  []fakeCall
+	results  [][][]driver.Value
+	commits  int
+	rollback int
+}
+
+type fakeCall struct {
+	query string
+	args  []driver.Value
+}
+
+func (db *fakeDB) Connect(context.Context) (driver.Conn, error) { return fakeConn{db}, nil }
+func (db *fakeDB) Driver() driver.Driver                        { return nil }
+
+// queue adds the rows the next query returns
+func (db *fakeDB) queue(rows ...[]driver.Value) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	db.results = append(db.results, rows)
+}
+
+type fakeConn struct{ db *fakeDB }
+
+func (c fakeConn) Prepare(query string) (driver.Stmt, error) { return fakeStmt{c.db, query}, nil }
+func (c fakeConn) Close() error                              { return nil }
+func (c fakeConn) Begin() (driver.Tx, error)                 { return fakeTx{c.db}, nil }
+
+type fakeTx struct{ db *fakeDB }
+
+func (tx fakeTx) Commit() error {
+	tx.db.mu.Lock()
+	defer tx.db.mu.Unlock()
+	tx.db.commits++
+	return nil
+}
+
+func (tx fakeTx) Rollback() error {
+	tx.db.mu.Lock()
+	defer tx.db.mu.Unlock()
+	tx.db.rollback++
+	return nil
+}
+
+type fakeStmt struct {
+	db    *fakeDB
+	query string
+}
+
+func (s fakeStmt) Close() error  { return nil }
+func (s fakeStmt) NumInput() int { return -1 }
+
+func (s fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	s.db.execs = append(s.db.execs, fakeCall{s.query, args})
+	return driver.RowsAffected(1), nil
+}
+
+func (s fakeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	s.db.queries = append(s.db.queries, fakeCall{s.query, args})
+	if len(s.db.results) == 0 {
+		return &fakeRows{}, nil
+	}
+	rows := s.db.results[0]
+	s.db.results = s.db.results[1:]
+	return &fakeRows{rows: rows}, nil
+}
+
+type fakeRows struct{ rows [][]driver.Value }
+
+func (r *fakeRows) Columns() []string {
+	if len(r.rows) == 0 {
+		return []string{"1"}
+	}
+	return make([]string, len(r.rows[0]))
+}
+
+func (r *fakeRows) Close() error { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
+
+func TestSQLSave(t *testing.T) {
+	ctx := context.Background()
+	fake := new(fakeDB)
+	s := NewSQL(sql.OpenDB(fake))
+	doc := loadSample(t)
+	if err := s.Save(ctx, doc); err != nil {
+		t.Fatalf("Failed to save: %v", err)
+	}
+	if len(fake.execs) != 4 || fake.commits != 1 {
+		t.Fatalf("Expected the message and its three entries to be inserted in one transaction, got %+v", fake.execs)
+	}
+	msg := fake.execs[0]
+	if !strings.HasPrefix(msg.query, "INSERT INTO statement_message") || msg.args[0] != "RPT-20240315-0001" || msg.args[1] != "camt.052.001.08" {
+		t.Errorf("Expected the message to be inserted, got %+v", msg)
+	}
+	entry := fake.execs[1]
+	want := []driver.Value{"RPT-20240315-0001", int64(0), "RPT-20240315-0001-1", "DE89370400440532013000", "E-0001", "EUR", 1250.0, "BOOK", "2024-03-15"}
+	if got := entry.args[:9]; !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected entry columns %v, got %v", want, got)
+	}
+	if pending := fake.execs[3]; pending.args[8] != nil || pending.args[6] != -75.0 {
+		t.Errorf("Expected the pending debit without a booking date, got %v", pending.args)
+	}
+
+	fake.queue([]driver.Value{int64(1)})
+	if err := s.Save(ctx, doc); !errors.Is(err, ErrDuplicateMessage) || len(fake.execs) != 4 || fake.rollback != 1 {
+		t.Errorf("Expected the second save to be refused, got %v", err)
+	}
+	if err := s.Save(ctx, &iso20022.Pacs00800108Document{}); !errors.Is(err, ErrUnsupportedDocument) {
+		t.Errorf("Expected a pacs.008 to be refused, got %v", err)
+	}
+}
+
+func TestSQLLoadAndEntries(t *testing.T) {
+	ctx := context.Background()
+	fake := new(fakeDB)
+	s := NewSQL(sql.OpenDB(fake), WithNumberedPlaceholders())
+	doc := loadSample(t)
+	data, err := iso20022.Marshal(doc)
+	if err != nil {
+		t.Fatalf("Failed to marshal: %v", err)
+	}
+
+	fake.queue([]driver.Value{string(data)})
+	got, err := s.Load(ctx, "RPT-20240315-0001")
+	if err != nil {
+		t.Fatalf("Failed to load: %v", err)
+	}
+	if loaded, ok := got.(*iso20022.Camt05200108Document); !ok || loaded.BankAccountReport.GroupHeader.MsgID != "RPT-20240315-0001" {
+		t.Errorf("Expected the camt.052 to be decoded, got %T", got)
+	}
+	if q := fake.queries[0].query; q != "SELECT document FROM statement_message WHERE msg_id = $1" {
+		t.Errorf("Expected a numbered placeholder, got %q", q)
+	}
+	if _, err := s.Load(ctx, "UNKNOWN"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+
+	fake.queue([]driver.Value{"RPT-20240315-0001", int64(1), "RPT-20240315-0001-1", "DE89370400440532013000", "E-0002", "EUR", "-300.00000", "BOOK", "2024-03-15",
+		"<Ntry><NtryRef>E-0002</NtryRef><Amt Ccy=\"EUR\">300</Amt><CdtDbtInd>DBIT</CdtDbtInd><Sts><Cd>BOOK</Cd></Sts></Ntry>"})
+	entries, err := s.Entries(ctx, Query{Account: "DE89370400440532013000", From: iso20022.NewISODate(2024, time.March, 15)})
+	if err != nil {
+		t.Fatalf("Failed to query entries: %v", err)
+	}
+	query := fake.queries[len(fake.queries)-1]
+	if !strings.HasSuffix(query.query, " WHERE account = $1 AND booking_date >= $2 ORDER BY booking_date, msg_id, seq") || !reflect.DeepEqual(query.args, []driver.Value{"DE89370400440532013000", "2024-03-15"}) {
+		t.Errorf("Expected the entries to be selected by account and booking date, got %+v", query)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("Expected one entry, got %+v", entries)
+	}
+	e := entries[0]
+	if e.Sequence != 1 || e.Amount != -300 || e.Reference != "E-0002" || e.BookingDate.String() != "2024-03-15" || e.Entry.CreditDebitIndicator != "DBIT" {
+		t.Errorf("Expected entry E-0002, got %+v", e)
+	}
+}
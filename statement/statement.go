@@ -0,0 +1,208 @@
+// Package statement reassembles account reports that the account servicer split
+// across several camt.052 messages.
+//
+// A large report is sent as a series of pages, each in a message of its own with its
+// own MsgId, that share the report identification and account. Each page carries its
+// page number and whether it is the last one, in Rpt/RptPgntn or, failing that, in
+// GrpHdr/MsgPgntn. An Assembler collects the pages of every report and, once page 1
+// up to the last page have arrived, yields the report merged back into one
+// AccountReport25.
+//
+// An Intraday then follows the successive intraday reports of an account and
+// yields the entries each adds to those reported before, whatever order the reports
+// arrive in.
+//
+// A Store saves camt.052 and camt.054 messages with their entries, to load them
+// back by MsgId and look entries up by account and booking date: Memory keeps them
+// in memory and SQL in a database with the tables of SQLSchema.
+package statement
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/ckbaum/iso20022-go"
+)
+
+// Errors returned by Assembler.Add and Assembler.AddReport
+var (
+	ErrInvalidPage     = errors.New("statement: invalid page number")
+	ErrDuplicatePage   = errors.New("statement: page already received")
+	ErrDuplicateMsg    = errors.New("statement: message already received for another page")
+	ErrPageAfterLast   = errors.New("statement: page number beyond the last page")
+	ErrConflictingLast = errors.New("statement: last page already received with another number")
+)
+
+// Progress describes a report whose pages are still being collected
+type Progress struct {
+	ReportID string
+	Account  string
+	Received []int // page numbers received, in order
+	Last     int   // number of the last page, or 0 while it has not arrived
+}
+
+// page is one received page of a report
+type page struct {
+	msgID  string
+	report iso20022.AccountReport25
+}
+
+// pageSet collects the pages of one report
+type pageSet struct {
+	reportID string
+	account  string
+	pages    map[int]page
+	last     int
+}
+
+// Assembler collects the pages of paginated reports. It is safe for concurrent use.
+type Assembler struct {
+	mu      sync.Mutex
+	pending map[string]*pageSet
+}
+
+// NewAssembler returns an assembler with no pending reports
+func NewAssembler() *Assembler {
+	return &Assembler{pending: make(map[string]*pageSet)}
+}
+
+// Add adds the reports of a camt.052 message and returns the reports it completed.
+// Reports that are not paginated are returned as they are. Reports added before a
+// page is rejected stay added.
+func (a *Assembler) Add(doc *iso20022.Camt05200108Document) ([]iso20022.AccountReport25, error) {
+	var complete []iso20022.AccountReport25
+	msg := &doc.BankAccountReport
+	for _, report := range msg.Report {
+		merged, err := a.AddReport(msg.GroupHeader, report)
+		if err != nil {
+			return complete, err
+		}
+		if merged != nil {
+			complete = append(complete, *merged)
+		}
+	}
+	return complete, nil
+}
+
+// AddReport adds one page of a report, received in a message with the given group
+// header. It returns the merged report when the page completes it, and nil while
+// pages are missing.
+func (a *Assembler) AddReport(hdr iso20022.GroupHeader81, report iso20022.AccountReport25) (*iso20022.AccountReport25, error) {
+	pagination := report.ReportPagination
+	if pagination == nil {
+		pagination = hdr.MessagePagination
+	}
+	if pagination == nil {
+		return &report, nil
+	}
+	number, err := strconv.Atoi(pagination.PageNumber)
+	if err != nil || number < 1 {
+		return nil, fmt.Errorf("%w %q in report %s", ErrInvalidPage, pagination.PageNumber, report.ID)
+	}
+	if number == 1 && pagination.LastPageIndex {
+		return &report, nil
+	}
+
+	account := report.Account.ID.Identifier()
+	key := report.ID + "\x00" + account
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	set, ok := a.pending[key]
+	if !ok {
+		set = &pageSet{reportID: report.ID, account: account, pages: make(map[int]page)}
+		a.pending[key] = set
+	}
+	if _, ok := set.pages[number]; ok {
+		return nil, fmt.Errorf("%w: page %d of report %s", ErrDuplicatePage, number, report.ID)
+	}
+	for n, p := range set.pages {
+		if p.msgID == hdr.MsgID {
+			return nil, fmt.Errorf("%w: message %s carried page %d of report %s", ErrDuplicateMsg, hdr.MsgID, n, report.ID)
+		}
+	}
+	if set.last > 0 && number > set.last {
+		return nil, fmt.Errorf("%w: page %d of report %s, which ends at page %d", ErrPageAfterLast, number, report.ID, set.last)
+	}
+	if pagination.LastPageIndex {
+		if set.last > 0 {
+			return nil, fmt.Errorf("%w: page %d of report %s, which ends at page %d", ErrConflictingLast, number, report.ID, set.last)
+		}
+		for n := range set.pages {
+			if n > number {
+				return nil, fmt.Errorf("%w: page %d of report %s was received before last page %d", ErrPageAfterLast, n, report.ID, number)
+			}
+		}
+		set.last = number
+	}
+	set.pages[number] = page{msgID: hdr.MsgID, report: report}
+
+	if set.last == 0 || len(set.pages) < set.last {
+		return nil, nil
+	}
+	delete(a.pending, key)
+	merged := set.merge()
+	return &merged, nil
+}
+
+// Pending returns the reports still missing pages, ordered by report identification
+func (a *Assembler) Pending() []Progress {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	progress := make([]Progress, 0, len(a.pending))
+	for _, set := range a.pending {
+		p := Progress{ReportID: set.reportID, Account: set.account, Last: set.last}
+		for n := range set.pages {
+			p.Received = append(p.Received, n)
+		}
+		sort.Ints(p.Received)
+		progress = append(progress, p)
+	}
+	sort.Slice(progress, func(i, j int) bool {
+		if progress[i].ReportID != progress[j].ReportID {
+			return progress[i].ReportID < progress[j].ReportID
+		}
+		return progress[i].Account < progress[j].Account
+	})
+	return progress
+}
+
+// Discard drops the pages collected for a report, for instance once the servicer
+// has been asked to resend it. It reports whether any were pending.
+func (a *Assembler) Discard(reportID, account string) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	key := reportID + "\x00" + account
+	_, ok := a.pending[key]
+	delete(a.pending, key)
+	return ok
+}
+
+// merge joins the pages in page order. The report identification, account and
+// other single-valued elements come from the first page that has them; balances,
+// interest and entries are concatenated.
+func (s *pageSet) merge() iso20022.AccountReport25 {
+	merged := s.pages[1].report
+	merged.ReportPagination = nil
+	for n := 2; n <= s.last; n++ {
+		p := s.pages[n].report
+		merged.Interest = append(merged.Interest, p.Interest...)
+		merged.Balance = append(merged.Balance, p.Balance...)
+		merged.Entry = append(merged.Entry, p.Entry...)
+		if merged.RelatedAccount == nil {
+			merged.RelatedAccount = p.RelatedAccount
+		}
+		if merged.TransactionsSummary == nil {
+			merged.TransactionsSummary = p.TransactionsSummary
+		}
+		if merged.AdditionalReportInfo == nil {
+			merged.AdditionalReportInfo = p.AdditionalReportInfo
+		}
+	}
+	return merged
+}
@@ -0,0 +1,149 @@
+package statement
+
+import (
+	"encoding/xml"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/ckbaum/iso20022-go"
+)
+
+func loadSample(t *testing.T) *iso20022.Camt05200108Document {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("..", "testdata", "roundtrip", "camt.052.001.08", "account_report.xml"))
+	if err != nil {
+		t.Fatalf("Failed to read fixture: %v", err)
+	}
+	doc := new(iso20022.Camt05200108Document)
+	if err := xml.Unmarshal(data, doc); err != nil {
+		t.Fatalf("Failed to unmarshal fixture: %v", err)
+	}
+	return doc
+}
+
+// paginate splits the report of the sample into one message per entry, the
+// balances travelling on the first page and the summary on the last
+func paginate(t *testing.T) (iso20022.AccountReport25, []*iso20022.Camt05200108Document) {
+	t.Helper()
+	sample := loadSample(t)
+	report := sample.BankAccountReport.Report[0]
+	var pages []*iso20022.Camt05200108Document
+	for i, entry := range report.Entry {
+		doc := loadSample(t)
+		hdr := &doc.BankAccountReport.GroupHeader
+		hdr.MsgID += "-" + strconv.Itoa(i+1)
+		page := report
+		page.Entry = []iso20022.ReportEntry10{entry}
+		page.ReportPagination = &iso20022.Pagination1{PageNumber: strconv.Itoa(i + 1), LastPageIndex: i == len(report.Entry)-1}
+		if i > 0 {
+			page.Balance = nil
+		}
+		if i < len(report.Entry)-1 {
+			page.TransactionsSummary = nil
+		}
+		doc.BankAccountReport.Report = []iso20022.AccountReport25{page}
+		pages = append(pages, doc)
+	}
+	if len(pages) < 3 {
+		t.Fatalf("Expected the sample to have at least three entries, got %d", len(pages))
+	}
+	return report, pages
+}
+
+func TestAssemblerMergesPagesInOrder(t *testing.T) {
+	report, pages := paginate(t)
+	a := NewAssembler()
+
+	// Deliver the last page first and the others in reverse
+	for i := len(pages) - 1; i > 0; i-- {
+		complete, err := a.Add(pages[i])
+		if err != nil || len(complete) != 0 {
+			t.Fatalf("Page %d: expected no complete report, got %d, %v", i+1, len(complete), err)
+		}
+	}
+	pending := a.Pending()
+	if len(pending) != 1 || pending[0].ReportID != report.ID || pending[0].Last != len(pages) || len(pending[0].Received) != len(pages)-1 {
+		t.Fatalf("Unexpected pending reports: %+v", pending)
+	}
+
+	complete, err := a.Add(pages[0])
+	if err != nil || len(complete) != 1 {
+		t.Fatalf("Expected the report to complete, got %d, %v", len(complete), err)
+	}
+	if !reflect.DeepEqual(complete[0], report) {
+		t.Errorf("Merged report differs from the original:\n%+v\n%+v", complete[0], report)
+	}
+	if _, err := iso20022.ReconcileBalances(complete[0]); err != nil {
+		t.Errorf("Merged report does not reconcile: %v", err)
+	}
+	if len(a.Pending()) != 0 {
+		t.Errorf("Expected no pending reports, got %+v", a.Pending())
+	}
+}
+
+func TestAssemblerUnpaginatedReport(t *testing.T) {
+	complete, err := NewAssembler().Add(loadSample(t))
+	if err != nil || len(complete) != 1 {
+		t.Fatalf("Expected the report as is, got %d, %v", len(complete), err)
+	}
+}
+
+func TestAssemblerRejectsInconsistentPages(t *testing.T) {
+	_, pages := paginate(t)
+	last := pages[len(pages)-1]
+
+	tests := []struct {
+		name  string
+		setup func(a *Assembler) *iso20022.Camt05200108Document
+		want  error
+	}{
+		{"DuplicatePage", func(a *Assembler) *iso20022.Camt05200108Document {
+			a.Add(pages[0])
+			return pages[0]
+		}, ErrDuplicatePage},
+		{"ResentMessage", func(a *Assembler) *iso20022.Camt05200108Document {
+			a.Add(pages[0])
+			resent := *pages[1]
+			resent.BankAccountReport.GroupHeader.MsgID = pages[0].BankAccountReport.GroupHeader.MsgID
+			return &resent
+		}, ErrDuplicateMsg},
+		{"PageAfterLast", func(a *Assembler) *iso20022.Camt05200108Document {
+			early := *pages[1]
+			early.BankAccountReport.Report = []iso20022.AccountReport25{early.BankAccountReport.Report[0]}
+			early.BankAccountReport.Report[0].ReportPagination = &iso20022.Pagination1{PageNumber: "2", LastPageIndex: true}
+			a.Add(&early)
+			return last
+		}, ErrPageAfterLast},
+		{"InvalidPage", func(a *Assembler) *iso20022.Camt05200108Document {
+			bad := *pages[0]
+			bad.BankAccountReport.Report = []iso20022.AccountReport25{bad.BankAccountReport.Report[0]}
+			bad.BankAccountReport.Report[0].ReportPagination = &iso20022.Pagination1{PageNumber: "0"}
+			return &bad
+		}, ErrInvalidPage},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := NewAssembler()
+			doc := tt.setup(a)
+			if _, err := a.Add(doc); !errors.Is(err, tt.want) {
+				t.Errorf("Expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestAssemblerDiscard(t *testing.T) {
+	report, pages := paginate(t)
+	a := NewAssembler()
+	a.Add(pages[0])
+	if !a.Discard(report.ID, report.Account.ID.Identifier()) {
+		t.Fatal("Expected the report to be pending")
+	}
+	if a.Discard(report.ID, report.Account.ID.Identifier()) {
+		t.Error("Expected nothing left to discard")
+	}
+}
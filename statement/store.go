@@ -0,0 +1,205 @@
+package statement
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/ckbaum/iso20022-go"
+)
+
+// Errors returned by stores
+var (
+	ErrUnsupportedDocument = errors.New("statement: document is not a camt.052 or camt.054")
+	ErrDuplicateMessage    = errors.New("statement: message already saved")
+	ErrNotFound            = errors.New("statement: message not found")
+)
+
+// Entry is an entry of a saved message, with the columns entries are looked up by
+type Entry struct {
+	MessageID string
+	ReportID  string // identification of the report or notification
+	Account   string // IBAN of the account, or its other identification
+	// Sequence is the position of the entry in the message, from 0
+	Sequence int
+	// Reference is the account servicer reference of the entry, or else its entry
+	// reference
+	Reference string
+	Currency  string
+	// Amount is negative for debits
+	Amount iso20022.Decimal
+	Status string
+	// BookingDate is zero for entries without one, such as pending entries
+	BookingDate iso20022.ISODate
+	Entry       iso20022.ReportEntry10
+}
+
+// Query selects saved entries. Zero fields match every entry; entries without a
+// booking date only match a query without From and To.
+type Query struct {
+	MessageID string
+	Account   string
+	From      iso20022.ISODate // first booking date, inclusive
+	To        iso20022.ISODate // last booking date, inclusive
+}
+
+// Store saves camt.052 and camt.054 messages together with their entries, so that
+// statement ingestion can look messages up by MsgId and entries by account and
+// booking date
+type Store interface {
+	// Save saves a *Camt05200108Document or *Camt05400108Document and its entries.
+	// It returns ErrDuplicateMessage when a message with the same MsgId was saved.
+	Save(ctx context.Context, doc interface{}) error
+	// Load returns the saved message with the given MsgId, or ErrNotFound
+	Load(ctx context.Context, msgID string) (interface{}, error)
+	// Entries returns the saved entries the query selects, ordered by booking date,
+	// message and sequence
+	Entries(ctx context.Context, q Query) ([]Entry, error)
+}
+
+// ExtractEntries returns the MsgId of a camt.052 or camt.054 message and its entries
+func ExtractEntries(doc interface{}) (msgID string, entries []Entry, err error) {
+	add := func(reportID string, account iso20022.AccountIdentification4, reported []iso20022.ReportEntry10) {
+		for _, e := range reported {
+			entries = append(entries, newEntry(msgID, reportID, account.Identifier(), len(entries), e))
+		}
+	}
+	switch doc := doc.(type) {
+	case *iso20022.Camt05200108Document:
+		msgID = doc.BankAccountReport.GroupHeader.MsgID
+		for _, report := range doc.BankAccountReport.Report {
+			add(report.ID, report.Account.ID, report.Entry)
+		}
+	case *iso20022.Camt05400108Document:
+		msgID = doc.BankDebitCreditNotification.GroupHeader.MsgID
+		for _, ntfctn := range doc.BankDebitCreditNotification.Notification {
+			add(ntfctn.ID, ntfctn.Account.ID, ntfctn.Entry)
+		}
+	default:
+		return "", nil, fmt.Errorf("%w: %T", ErrUnsupportedDocument, doc)
+	}
+	return msgID, entries, nil
+}
+
+// newEntry returns the entry at position seq of a message
+func newEntry(msgID, reportID, account string, seq int, e iso20022.ReportEntry10) Entry {
+	entry := Entry{
+		MessageID: msgID,
+		ReportID:  reportID,
+		Account:   account,
+		Sequence:  seq,
+		Currency:  e.Amount.Currency,
+		Amount:    e.Amount.Value,
+		Entry:     e,
+	}
+	if e.CreditDebitIndicator == "DBIT" {
+		entry.Amount = -entry.Amount
+	}
+	switch {
+	case e.AccountServicerReference != nil:
+		entry.Reference = *e.AccountServicerReference
+	case e.EntryReference != nil:
+		entry.Reference = *e.EntryReference
+	}
+	if e.Status.Code != nil {
+		entry.Status = *e.Status.Code
+	} else if e.Status.Proprietary != nil {
+		entry.Status = *e.Status.Proprietary
+	}
+	if b := e.BookingDate; b != nil {
+		switch {
+		case b.Date != nil:
+			entry.BookingDate = *b.Date
+		case b.DateTime != nil:
+			y, m, d := b.DateTime.Date()
+			entry.BookingDate = iso20022.NewISODate(y, m, d)
+		}
+	}
+	return entry
+}
+
+// matches reports whether the query selects an entry
+func (q Query) matches(e Entry) bool {
+	switch {
+	case q.MessageID != "" && e.MessageID != q.MessageID,
+		q.Account != "" && e.Account != q.Account:
+		return false
+	case (!q.From.IsZero() || !q.To.IsZero()) && e.BookingDate.IsZero():
+		return false
+	case !q.From.IsZero() && e.BookingDate.Before(q.From.Time),
+		!q.To.IsZero() && e.BookingDate.After(q.To.Time):
+		return false
+	}
+	return true
+}
+
+// sortEntries orders entries by booking date, message and sequence
+func sortEntries(entries []Entry) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if !a.BookingDate.Equal(b.BookingDate.Time) {
+			return a.BookingDate.Before(b.BookingDate.Time)
+		}
+		if a.MessageID != b.MessageID {
+			return a.MessageID < b.MessageID
+		}
+		return a.Sequence < b.Sequence
+	})
+}
+
+// Memory is a Store that keeps messages in memory, for tests and small tools. The
+// documents saved are kept as they are and should not be modified afterwards. It is
+// safe for concurrent use.
+type Memory struct {
+	mu      sync.RWMutex
+	docs    map[string]interface{}
+	entries []Entry
+}
+
+// NewMemory returns an empty in-memory store
+func NewMemory() *Memory {
+	return &Memory{docs: make(map[string]interface{})}
+}
+
+// Save implements Store
+func (m *Memory) Save(ctx context.Context, doc interface{}) error {
+	msgID, entries, err := ExtractEntries(doc)
+	if err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.docs[msgID]; ok {
+		return fmt.Errorf("%w: %s", ErrDuplicateMessage, msgID)
+	}
+	m.docs[msgID] = doc
+	m.entries = append(m.entries, entries...)
+	return nil
+}
+
+// Load implements Store
+func (m *Memory) Load(ctx context.Context, msgID string) (interface{}, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	doc, ok := m.docs[msgID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, msgID)
+	}
+	return doc, nil
+}
+
+// Entries implements Store
+func (m *Memory) Entries(ctx context.Context, q Query) ([]Entry, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var entries []Entry
+	for _, e := range m.entries {
+		if q.matches(e) {
+			entries = append(entries, e)
+		}
+	}
+	sortEntries(entries)
+	return entries, nil
+}
@@ -0,0 +1,86 @@
+package statement
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ckbaum/iso20022-go"
+)
+
+func TestExtractEntries(t *testing.T) {
+	msgID, entries, err := ExtractEntries(loadSample(t))
+	if err != nil {
+		t.Fatalf("Failed to extract entries: %v", err)
+	}
+	if msgID != "RPT-20240315-0001" || len(entries) != 3 {
+		t.Fatalf("Expected the three entries of RPT-20240315-0001, got %s with %+v", msgID, entries)
+	}
+	var amounts []iso20022.Decimal
+	var refs []string
+	for _, e := range entries {
+		amounts = append(amounts, e.Amount)
+		refs = append(refs, e.Reference)
+	}
+	if !reflect.DeepEqual(amounts, []iso20022.Decimal{1250, -300, -75}) || !reflect.DeepEqual(refs, []string{"E-0001", "E-0002", "E-0003"}) {
+		t.Errorf("Expected signed amounts and entry references, got %v and %v", amounts, refs)
+	}
+	if first := entries[0]; first.Account != "DE89370400440532013000" || first.Status != "BOOK" || !first.BookingDate.Equal(iso20022.NewISODate(2024, time.March, 15).Time) {
+		t.Errorf("Expected the first entry booked on the account on 2024-03-15, got %+v", first)
+	}
+
+	if _, _, err := ExtractEntries(&iso20022.Pacs00800108Document{}); !errors.Is(err, ErrUnsupportedDocument) {
+		t.Errorf("Expected a pacs.008 to be refused, got %v", err)
+	}
+}
+
+func TestMemoryStore(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemory()
+	doc := loadSample(t)
+	if err := s.Save(ctx, doc); err != nil {
+		t.Fatalf("Failed to save: %v", err)
+	}
+	if err := s.Save(ctx, doc); !errors.Is(err, ErrDuplicateMessage) {
+		t.Errorf("Expected the second save to be refused, got %v", err)
+	}
+	later := loadSample(t)
+	later.BankAccountReport.GroupHeader.MsgID = "RPT-20240316-0001"
+	later.BankAccountReport.Report[0].Entry = later.BankAccountReport.Report[0].Entry[:1]
+	later.BankAccountReport.Report[0].Entry[0].BookingDate.Date = &iso20022.ISODate{Time: time.Date(2024, time.March, 16, 0, 0, 0, 0, time.UTC)}
+	if err := s.Save(ctx, later); err != nil {
+		t.Fatalf("Failed to save: %v", err)
+	}
+
+	if got, err := s.Load(ctx, "RPT-20240315-0001"); err != nil || got != doc {
+		t.Errorf("Expected the saved document, got %v, %v", got, err)
+	}
+	if _, err := s.Load(ctx, "UNKNOWN"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+
+	for name, c := range map[string]struct {
+		q    Query
+		want []string
+	}{
+		"account":    {Query{Account: "DE89370400440532013000"}, []string{"E-0003", "E-0001", "E-0002", "E-0001"}},
+		"message":    {Query{MessageID: "RPT-20240316-0001"}, []string{"E-0001"}},
+		"date range": {Query{From: iso20022.NewISODate(2024, time.March, 15), To: iso20022.NewISODate(2024, time.March, 15)}, []string{"E-0001", "E-0002"}},
+		"from":       {Query{From: iso20022.NewISODate(2024, time.March, 16)}, []string{"E-0001"}},
+		"other":      {Query{Account: "GB29NWBK60161331926819"}, nil},
+	} {
+		entries, err := s.Entries(ctx, c.q)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		var refs []string
+		for _, e := range entries {
+			refs = append(refs, e.Reference)
+		}
+		if !reflect.DeepEqual(refs, c.want) {
+			t.Errorf("%s: expected entries %v, got %v", name, c.want, refs)
+		}
+	}
+}
@@ -0,0 +1,201 @@
+package iso20022
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// ErrUnexpectedElement is returned in strict mode for an element that the type it
+// is decoded into has no field for, that comes before an element its type puts
+// first, or that is repeated where its type allows a single one
+var ErrUnexpectedElement = errors.New("unexpected element")
+
+// UnexpectedElementError is the error of strict decoding, locating the element
+type UnexpectedElementError struct {
+	Path    string // from below the document element, e.g. FIToFICstmrCdtTrf/GrpHdr/Foo
+	Line    int
+	Problem string // unknown, out of order or repeated
+}
+
+func (e *UnexpectedElementError) Error() string {
+	return fmt.Sprintf("%v: %s at line %d is %s", ErrUnexpectedElement, e.Path, e.Line, e.Problem)
+}
+
+// Unwrap returns ErrUnexpectedElement
+func (e *UnexpectedElementError) Unwrap() error {
+	return ErrUnexpectedElement
+}
+
+// DecodeOption configures how Unmarshal and DecodeDocument read a document
+type DecodeOption func(*decodeOptions)
+
+type decodeOptions struct {
+	strict bool
+}
+
+// Strict makes decoding fail with ErrUnexpectedElement on content that
+// encoding/xml ignores: unknown elements, elements out of the order of their type
+// and repetitions of elements that occur at most once. It detects malformed files
+// from counterparties before any of their content is used.
+func Strict() DecodeOption {
+	return func(o *decodeOptions) {
+		o.strict = true
+	}
+}
+
+// strictKind tells how the children of an element are checked
+type strictKind int
+
+const (
+	strictUnchecked strictKind = iota // decoded by the type itself, or kept as is
+	strictLeaf                        // character data only
+	strictStruct                      // the elements of the fields of the type
+)
+
+// strictField is an element of a struct type
+type strictField struct {
+	order    int
+	repeated bool
+	typ      reflect.Type
+}
+
+// strictType is how the elements of a type are checked
+type strictType struct {
+	kind   strictKind
+	fields map[string]strictField
+}
+
+var (
+	strictTypes     sync.Map // reflect.Type → *strictType
+	xmlUnmarshaler  = reflect.TypeOf((*xml.Unmarshaler)(nil)).Elem()
+	textUnmarshaler = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// strictTypeOf returns how the elements of t are checked
+func strictTypeOf(t reflect.Type) *strictType {
+	for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice && t.Elem().Kind() != reflect.Uint8 {
+		t = t.Elem()
+	}
+	if st, ok := strictTypes.Load(t); ok {
+		return st.(*strictType)
+	}
+	st := &strictType{kind: strictLeaf}
+	switch {
+	case reflect.PointerTo(t).Implements(xmlUnmarshaler):
+		st.kind = strictUnchecked
+	case reflect.PointerTo(t).Implements(textUnmarshaler):
+	case t.Kind() == reflect.Interface:
+		st.kind = strictUnchecked
+	case t.Kind() == reflect.Struct:
+		st.kind, st.fields = strictStruct, make(map[string]strictField)
+		for i, field := range reflect.VisibleFields(t) {
+			if !field.IsExported() || field.Anonymous || field.Name == "XMLName" {
+				continue
+			}
+			tag := field.Tag.Get("xml")
+			name, flags, _ := strings.Cut(tag, ",")
+			if strings.Contains(flags, "innerxml") || strings.Contains(flags, "any") {
+				st.kind, st.fields = strictUnchecked, nil
+				break
+			}
+			if name == "-" || strings.Contains(flags, "attr") || strings.Contains(flags, "chardata") || strings.Contains(flags, "comment") {
+				continue
+			}
+			if j := strings.LastIndex(name, " "); j >= 0 {
+				name = name[j+1:]
+			}
+			if name == "" {
+				name = field.Name
+			}
+			st.fields[name] = strictField{
+				order:    i,
+				repeated: field.Type.Kind() == reflect.Slice && field.Type.Elem().Kind() != reflect.Uint8,
+				typ:      field.Type,
+			}
+		}
+	}
+	strictTypes.Store(t, st)
+	return st
+}
+
+// checkStrict checks that the elements of data are those of the type of v, in the
+// order of its fields
+func checkStrict(data []byte, v interface{}) error {
+	var first error
+	err := walkUnexpected(data, v, func(e *UnexpectedElementError) bool {
+		first = e
+		return false
+	})
+	if first != nil {
+		return first
+	}
+	return err
+}
+
+// walkUnexpected calls report with each element of data that the type of v has no
+// field for, that is out of the order of its fields or that is repeated where it
+// has a single value, until report returns false. The content of an unknown
+// element is not reported.
+func walkUnexpected(data []byte, v interface{}, report func(*UnexpectedElementError) bool) error {
+	type frame struct {
+		typ  *strictType
+		path string
+		last int
+	}
+	unknown := &strictType{kind: strictUnchecked}
+	var stack []frame
+	d := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		tok, err := d.Token()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if len(stack) == 0 {
+				stack = append(stack, frame{typ: strictTypeOf(reflect.TypeOf(v)), last: -1})
+				continue
+			}
+			parent := &stack[len(stack)-1]
+			path := t.Name.Local
+			if parent.path != "" {
+				path = parent.path + "/" + path
+			}
+			line, _ := d.InputPos()
+			var problem string
+			field, ok := parent.typ.fields[t.Name.Local]
+			switch {
+			case parent.typ.kind == strictUnchecked:
+				stack = append(stack, frame{typ: parent.typ, path: path})
+				continue
+			case parent.typ.kind == strictLeaf, !ok:
+				problem = "unknown"
+			case field.order < parent.last:
+				problem = "out of order"
+			case field.order == parent.last && !field.repeated:
+				problem = "repeated"
+			}
+			if problem != "" && !report(&UnexpectedElementError{Path: path, Line: line, Problem: problem}) {
+				return nil
+			}
+			if problem == "unknown" {
+				stack = append(stack, frame{typ: unknown, path: path})
+				continue
+			}
+			parent.last = field.order
+			stack = append(stack, frame{typ: strictTypeOf(field.typ), path: path, last: -1})
+		case xml.EndElement:
+			stack = stack[:len(stack)-1]
+		}
+	}
+}
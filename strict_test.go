@@ -0,0 +1,61 @@
+package iso20022
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestStrictGoldenFiles decodes every round-trip sample in strict mode, which must
+// accept the documents the package writes
+func TestStrictGoldenFiles(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join("testdata", "roundtrip", "*", "*.xml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		doc := roundTripDocuments[filepath.Base(filepath.Dir(file))]()
+		if err := Unmarshal(data, doc, Strict()); err != nil {
+			t.Errorf("%s: %v", file, err)
+		}
+	}
+}
+
+func TestStrict(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("testdata", "roundtrip", "pacs.008.001.08", "customer_credit_transfer.xml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	const msgID = "<MsgId>BBBBUS33-20240315-0001</MsgId>"
+	const creDtTm = "<CreDtTm>2024-03-15T09:30:47.000Z</CreDtTm>"
+	tests := []struct {
+		name, old, new, want string
+	}{
+		{"unknown", msgID, msgID + "<Note>x</Note>", "FIToFICstmrCdtTrf/GrpHdr/Note at line 5 is unknown"},
+		{"out of order", msgID + "\n      " + creDtTm, creDtTm + msgID, "FIToFICstmrCdtTrf/GrpHdr/MsgId at line 5 is out of order"},
+		{"repeated", msgID, msgID + msgID, "FIToFICstmrCdtTrf/GrpHdr/MsgId at line 5 is repeated"},
+		{"child of a value", msgID, "<MsgId>BBBBUS33<X/></MsgId>", "FIToFICstmrCdtTrf/GrpHdr/MsgId/X at line 5 is unknown"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			malformed := bytes.Replace(data, []byte(tt.old), []byte(tt.new), 1)
+			if bytes.Equal(malformed, data) {
+				t.Fatalf("%q not found in the sample", tt.old)
+			}
+			if err := Unmarshal(malformed, new(Pacs00800108Document)); err != nil {
+				t.Errorf("Expected the lenient mode to accept the document, got %v", err)
+			}
+			_, _, err := DecodeDocument(malformed, Strict())
+			if !errors.Is(err, ErrUnexpectedElement) || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Expected %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
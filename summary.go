@@ -0,0 +1,192 @@
+package iso20022
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+)
+
+// Summary gives the figures of a payment message that are checked before it is
+// released, such as on an operations dashboard
+type Summary struct {
+	MessageID    string
+	Transactions int
+	// Totals are the sums of the transaction amounts by currency
+	Totals map[string]Decimal
+	// Agents are the distinct agents of the transactions, instructing, instructed,
+	// intermediary, debtor and creditor agents, by BIC, clearing system member
+	// identification or LEI, sorted
+	Agents []string
+	// SettlementDates are the distinct interbank settlement dates, or requested
+	// execution dates of a pain.001, in order. Transactions without one are left
+	// out.
+	SettlementDates []ISODate
+	// Priorities counts the transactions by instruction priority, HIGH or NORM,
+	// under "" for those without one
+	Priorities map[string]int
+	// Largest is the largest transaction of each currency
+	Largest map[string]SummaryTransaction
+}
+
+// SummaryTransaction identifies a transaction of a Summary
+type SummaryTransaction struct {
+	// Index is the position of the transaction in the message, from 0
+	Index      int
+	EndToEndID string
+	Amount     Decimal
+	Currency   string
+}
+
+// Summarize returns the summary of a pacs.008 of any of the Pacs008Versions,
+// pacs.009 or pain.001 message. The transactions of a pain.001 are counted across
+// its payment information blocks.
+func Summarize(doc interface{}) (*Summary, error) {
+	s := newSummarizer()
+	switch d := doc.(type) {
+	case Pacs008:
+		upgraded, err := asPacs00800108(d)
+		if err != nil {
+			return nil, fmt.Errorf("summarize: %w", err)
+		}
+		msg := &upgraded.FICustomerCreditTransfer
+		s.summary.MessageID = msg.GroupHeader.MessageID
+		for _, tx := range msg.CreditTransferTransactionInfo {
+			s.transaction(tx.PaymentID.EndToEndID, tx.InterbankSettlementAmount.Value, tx.InterbankSettlementAmount.Currency)
+			s.date(firstDate(tx.InterbankSettlementDate, msg.GroupHeader.InterbankSettlementDate))
+			s.priority(instructionPriority(tx.PaymentTypeInfo, msg.GroupHeader.PaymentTypeInfo))
+			s.agents(tx.InstructingAgent, tx.InstructedAgent, tx.IntermediaryAgent1, tx.IntermediaryAgent2, tx.IntermediaryAgent3, &tx.DebtorAgent, &tx.CreditorAgent)
+		}
+		s.agents(msg.GroupHeader.InstructingAgent, msg.GroupHeader.InstructedAgent)
+	case *Pacs00900108Document:
+		msg := &d.FICreditTransfer
+		s.summary.MessageID = msg.GroupHeader.MessageID
+		for _, tx := range msg.CreditTransferTransactionInfo {
+			s.transaction(tx.PaymentID.EndToEndID, tx.InterbankSettlementAmount.Value, tx.InterbankSettlementAmount.Currency)
+			s.date(firstDate(tx.InterbankSettlementDate, msg.GroupHeader.InterbankSettlementDate))
+			s.priority(instructionPriority(tx.PaymentTypeInfo, msg.GroupHeader.PaymentTypeInfo))
+			s.agents(tx.InstructingAgent, tx.InstructedAgent, tx.IntermediaryAgent1, tx.IntermediaryAgent2, tx.IntermediaryAgent3, tx.DebtorAgent, tx.CreditorAgent)
+		}
+		s.agents(msg.GroupHeader.InstructingAgent, msg.GroupHeader.InstructedAgent)
+	case *Pain00100109Document:
+		msg := &d.CustomerCreditTransferInitiation
+		s.summary.MessageID = msg.GroupHeader.MessageID
+		for _, pmt := range msg.PaymentInfo {
+			date, err := executionDate(pmt.RequestedExecutionDate)
+			for _, tx := range pmt.CreditTransferTransactionInfo {
+				amount := transactionAmount(tx.Amount)
+				s.transaction(tx.PaymentID.EndToEndID, amount.Value, amount.Currency)
+				if err == nil {
+					s.date(&date)
+				}
+				paymentType := tx.PaymentTypeInfo
+				if paymentType == nil {
+					paymentType = pmt.PaymentTypeInfo
+				}
+				s.priority(instructionPriority(paymentTypeInfo(paymentType), nil))
+				s.agents(&pmt.DebtorAgent, tx.IntermediaryAgent1, tx.IntermediaryAgent2, tx.IntermediaryAgent3, tx.CreditorAgent)
+			}
+		}
+	default:
+		return nil, fmt.Errorf("summarize: %T is not a pacs.008, pacs.009 or pain.001 message", doc)
+	}
+	return s.finish(), nil
+}
+
+// summarizer accumulates the figures of a Summary
+type summarizer struct {
+	summary *Summary
+	totals  map[string]*big.Rat
+	agentOK map[string]bool
+	dateOK  map[string]bool
+}
+
+func newSummarizer() *summarizer {
+	return &summarizer{
+		summary: &Summary{
+			Totals:     make(map[string]Decimal),
+			Priorities: make(map[string]int),
+			Largest:    make(map[string]SummaryTransaction),
+		},
+		totals:  make(map[string]*big.Rat),
+		agentOK: make(map[string]bool),
+		dateOK:  make(map[string]bool),
+	}
+}
+
+// transaction counts a transaction and its amount
+func (s *summarizer) transaction(endToEndID string, amount Decimal, currency string) {
+	tx := SummaryTransaction{Index: s.summary.Transactions, EndToEndID: endToEndID, Amount: amount, Currency: currency}
+	s.summary.Transactions++
+	total, ok := s.totals[currency]
+	if !ok {
+		total = new(big.Rat)
+		s.totals[currency] = total
+	}
+	total.Add(total, DecimalRat(amount))
+	if largest, ok := s.summary.Largest[currency]; !ok || amount > largest.Amount {
+		s.summary.Largest[currency] = tx
+	}
+}
+
+// date adds a settlement date
+func (s *summarizer) date(d *ISODate) {
+	if d == nil || d.IsZero() {
+		return
+	}
+	key := d.Format(isoDateLayout)
+	if !s.dateOK[key] {
+		s.dateOK[key] = true
+		s.summary.SettlementDates = append(s.summary.SettlementDates, *d)
+	}
+}
+
+// priority counts a transaction under its instruction priority
+func (s *summarizer) priority(p string) {
+	s.summary.Priorities[p]++
+}
+
+// agents adds the agents that are present and identified
+func (s *summarizer) agents(agents ...*BranchAndFinancialInstitutionIdentification6) {
+	for _, agent := range agents {
+		if agent == nil {
+			continue
+		}
+		if key := agentKey(*agent); key != "" && !s.agentOK[key] {
+			s.agentOK[key] = true
+			s.summary.Agents = append(s.summary.Agents, key)
+		}
+	}
+}
+
+// finish sorts the summary and sets its totals
+func (s *summarizer) finish() *Summary {
+	for currency, total := range s.totals {
+		s.summary.Totals[currency] = RatDecimal(total)
+	}
+	sort.Strings(s.summary.Agents)
+	sort.Slice(s.summary.SettlementDates, func(i, j int) bool {
+		return s.summary.SettlementDates[i].Before(s.summary.SettlementDates[j].Time)
+	})
+	return s.summary
+}
+
+// firstDate returns the first date that is present
+func firstDate(dates ...*ISODate) *ISODate {
+	for _, d := range dates {
+		if d != nil {
+			return d
+		}
+	}
+	return nil
+}
+
+// instructionPriority returns the instruction priority of a transaction, or of the
+// group when the transaction has none
+func instructionPriority(tx, group *PaymentTypeInfo28) string {
+	for _, p := range []*PaymentTypeInfo28{tx, group} {
+		if p != nil && p.InstructionPriority != nil {
+			return string(*p.InstructionPriority)
+		}
+	}
+	return ""
+}
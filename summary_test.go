@@ -0,0 +1,98 @@
+package iso20022
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSummarize(t *testing.T) {
+	t.Run("pacs.008", func(t *testing.T) {
+		doc := loadPacs008Sample(t)
+		second := doc.FICustomerCreditTransfer.CreditTransferTransactionInfo[0]
+		second.PaymentID.EndToEndID = "INV-2024-0050"
+		second.InterbankSettlementAmount = ActiveCurrencyAndAmount{Value: 20000.1, Currency: "EUR"}
+		date := NewISODate(2024, time.March, 18)
+		second.InterbankSettlementDate = &date
+		priority := PriorityHIGH
+		second.PaymentTypeInfo = &PaymentTypeInfo28{InstructionPriority: &priority}
+		doc.FICustomerCreditTransfer.CreditTransferTransactionInfo = append(doc.FICustomerCreditTransfer.CreditTransferTransactionInfo, second)
+
+		s, err := Summarize(doc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s.MessageID != "BBBBUS33-20240315-0001" || s.Transactions != 2 {
+			t.Errorf("Unexpected summary %+v", s)
+		}
+		if want := map[string]Decimal{"USD": 15000, "EUR": 20000.1}; !reflect.DeepEqual(s.Totals, want) {
+			t.Errorf("Expected totals %v, got %v", want, s.Totals)
+		}
+		if want := []string{"BBBBUS33", "CCCCGB2L"}; !reflect.DeepEqual(s.Agents, want) {
+			t.Errorf("Expected agents %v, got %v", want, s.Agents)
+		}
+		if len(s.SettlementDates) != 2 || s.SettlementDates[0].String() != "2024-03-15" || s.SettlementDates[1].String() != "2024-03-18" {
+			t.Errorf("Unexpected settlement dates %v", s.SettlementDates)
+		}
+		if want := map[string]int{"NORM": 1, "HIGH": 1}; !reflect.DeepEqual(s.Priorities, want) {
+			t.Errorf("Expected priorities %v, got %v", want, s.Priorities)
+		}
+		if largest := s.Largest["EUR"]; largest.Index != 1 || largest.EndToEndID != "INV-2024-0050" {
+			t.Errorf("Unexpected largest transaction %+v", largest)
+		}
+	})
+
+	t.Run("pacs.008 versions", func(t *testing.T) {
+		for _, version := range Pacs008Versions {
+			s, err := Summarize(loadPacs008Version(t, version))
+			if err != nil {
+				t.Fatalf("%s: %v", version, err)
+			}
+			if s.MessageID != "BBBBUS33-20240315-0001" || s.Transactions != 1 || s.Totals["USD"] != 15000 || len(s.SettlementDates) != 1 {
+				t.Errorf("%s: unexpected summary %+v", version, s)
+			}
+			if want := []string{"BBBBUS33", "CCCCGB2L"}; !reflect.DeepEqual(s.Agents, want) {
+				t.Errorf("%s: expected agents %v, got %v", version, want, s.Agents)
+			}
+		}
+	})
+
+	t.Run("pain.001", func(t *testing.T) {
+		s, err := Summarize(loadSample[Pain00100109Document](t, "pain.001.001.09/credit_transfer_initiation.xml"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s.Transactions != 3 || s.Totals["USD"] != 18250 || len(s.SettlementDates) != 2 || s.Priorities["NORM"] != 2 || s.Priorities[""] != 1 {
+			t.Errorf("Unexpected summary %+v", s)
+		}
+		if want := []string{"026009593", "BBBBUS33", "CCCCGB2L", "DDDDUS44"}; !reflect.DeepEqual(s.Agents, want) {
+			t.Errorf("Expected agents %v, got %v", want, s.Agents)
+		}
+		if largest := s.Largest["USD"]; largest.EndToEndID != "INV-2024-0042" || largest.Amount != 15000 {
+			t.Errorf("Unexpected largest transaction %+v", largest)
+		}
+	})
+
+	t.Run("pacs.009", func(t *testing.T) {
+		bic := "DDDDDEFF"
+		doc := &Pacs00900108Document{FICreditTransfer: FinancialInstitutionCreditTransferV08{
+			GroupHeader: GroupHeader93{MessageID: "FI-1"},
+			CreditTransferTransactionInfo: []CreditTransferTransaction36{{
+				PaymentID:                 PaymentIdentification7{EndToEndID: "COVER-1"},
+				InterbankSettlementAmount: ActiveCurrencyAndAmount{Value: 1e6, Currency: "EUR"},
+				CreditorAgent:             &BranchAndFinancialInstitutionIdentification6{FinancialInstitutionID: FinancialInstitutionIdentification18{BankIdentifierCode: &bic}},
+			}},
+		}}
+		s, err := Summarize(doc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s.Transactions != 1 || s.Totals["EUR"] != 1e6 || !reflect.DeepEqual(s.Agents, []string{bic}) || s.SettlementDates != nil {
+			t.Errorf("Unexpected summary %+v", s)
+		}
+	})
+
+	if _, err := Summarize(&Pacs00200110Document{}); err == nil {
+		t.Errorf("Expected an error for a status report")
+	}
+}
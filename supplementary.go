@@ -0,0 +1,177 @@
+package iso20022
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// supplementaryKey identifies the supplementary data bound to a type: the place and
+// name (PlcAndNm) it is given for and the namespace of the element of its envelope
+type supplementaryKey struct {
+	placeAndName, namespace string
+}
+
+// supplementaryTypes maps supplementary data to the structs they are decoded into
+var (
+	supplementaryTypesMu sync.RWMutex
+	supplementaryTypes   = map[supplementaryKey]reflect.Type{}
+)
+
+// RegisterSupplementaryData binds the supplementary data given for a place and name
+// (PlcAndNm), in an envelope whose element is in the namespace, to the struct type of
+// v, a struct or a pointer to one, so that Typed decodes them into it. Either key may
+// be empty to match any place and name or any namespace; data matching both a
+// binding of its place and name and one of its namespace use the one of its
+// namespace. Passing a nil v removes the binding. Envelopes are decoded with
+// encoding/xml, so the struct gives the element of the envelope by its XMLName.
+func RegisterSupplementaryData(placeAndName, namespace string, v interface{}) error {
+	key := supplementaryKey{strings.TrimSpace(placeAndName), namespace}
+	if key == (supplementaryKey{}) {
+		return fmt.Errorf("iso20022: supplementary data needs a place and name or a namespace")
+	}
+	supplementaryTypesMu.Lock()
+	defer supplementaryTypesMu.Unlock()
+	if v == nil {
+		delete(supplementaryTypes, key)
+		return nil
+	}
+	t := reflect.TypeOf(v)
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return fmt.Errorf("iso20022: supplementary data must be bound to a struct, not %s", t)
+	}
+	supplementaryTypes[key] = t
+	return nil
+}
+
+// supplementaryType returns the struct type bound to the supplementary data: the
+// binding of both keys first, then of the namespace, then of the place and name
+func supplementaryType(placeAndName, namespace string) (reflect.Type, bool) {
+	supplementaryTypesMu.RLock()
+	defer supplementaryTypesMu.RUnlock()
+	placeAndName = strings.TrimSpace(placeAndName)
+	for _, key := range []supplementaryKey{{placeAndName, namespace}, {"", namespace}, {placeAndName, ""}} {
+		if key.namespace == "" && key.placeAndName == "" {
+			continue
+		}
+		if t, ok := supplementaryTypes[key]; ok {
+			return t, true
+		}
+	}
+	return nil, false
+}
+
+// envelopeNamespace returns the namespace of the first element of an envelope, or ""
+func envelopeNamespace(content string) string {
+	dec := xml.NewDecoder(strings.NewReader(content))
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return ""
+		}
+		if start, ok := tok.(xml.StartElement); ok {
+			return start.Name.Space
+		}
+	}
+}
+
+// decodeSupplementaryData decodes the content of an envelope into a new value of the
+// struct type bound to it. It returns nil for data without a binding.
+func decodeSupplementaryData(placeAndName *string, content string) (interface{}, error) {
+	t, ok := supplementaryType(deref(placeAndName), envelopeNamespace(content))
+	if !ok {
+		return nil, nil
+	}
+	v := reflect.New(t)
+	dec := xml.NewDecoder(strings.NewReader(content))
+	if err := dec.Decode(v.Interface()); err != nil && err != io.EOF {
+		return nil, fmt.Errorf("iso20022: decoding supplementary data as %s: %w", t, err)
+	}
+	return v.Interface(), nil
+}
+
+// encodeSupplementaryData encodes v as the content of an envelope
+func encodeSupplementaryData(v interface{}) (string, error) {
+	data, err := xml.Marshal(v)
+	if err != nil {
+		return "", fmt.Errorf("iso20022: encoding supplementary data: %w", err)
+	}
+	return string(data), nil
+}
+
+// Typed decodes the envelope into a new value of the struct bound to the data by
+// RegisterSupplementaryData, returned as a pointer. It returns nil without an error
+// for data without a binding, whose content stays raw in the envelope.
+func (s *SupplementaryData1) Typed() (interface{}, error) {
+	return decodeSupplementaryData(s.PlaceAndName, s.Envelope.Content)
+}
+
+// SetTyped encodes v, a struct with its XMLName, as the content of the envelope
+func (s *SupplementaryData1) SetTyped(v interface{}) error {
+	content, err := encodeSupplementaryData(v)
+	if err != nil {
+		return err
+	}
+	s.Envelope.Content = content
+	return nil
+}
+
+// Typed decodes the envelope into the struct bound to the data, as
+// SupplementaryData1.Typed does
+func (s *SupplementaryData) Typed() (interface{}, error) {
+	return decodeSupplementaryData(s.PlaceAndName, s.Envelope.Content)
+}
+
+// SetTyped encodes v, a struct with its XMLName, as the content of the envelope
+func (s *SupplementaryData) SetTyped(v interface{}) error {
+	content, err := encodeSupplementaryData(v)
+	if err != nil {
+		return err
+	}
+	s.Envelope.Content = content
+	return nil
+}
+
+// TypedSupplementaryData is supplementary data of a document decoded into the struct
+// bound to it
+type TypedSupplementaryData struct {
+	// Path is the path of the SplmtryData element from the document element
+	Path         string
+	PlaceAndName string
+	Namespace    string
+	// Value is a pointer to the struct bound to the data
+	Value interface{}
+}
+
+// SupplementaryDataOf decodes the supplementary data of a document that have a
+// binding, at the group, transaction or any other level. Data without a binding are
+// skipped; errors give the path of the data that could not be decoded.
+func SupplementaryDataOf(doc interface{}) ([]TypedSupplementaryData, error) {
+	var (
+		typed    []TypedSupplementaryData
+		firstErr error
+	)
+	visitStructs(reflect.ValueOf(doc), "", isSupplementaryData, func(path string, v reflect.Value) {
+		placeAndName := v.FieldByName("PlaceAndName").Interface().(*string)
+		content := v.FieldByName("Envelope").FieldByName("Content").String()
+		value, err := decodeSupplementaryData(placeAndName, content)
+		switch {
+		case err != nil && firstErr == nil:
+			firstErr = fmt.Errorf("%s: %w", path, err)
+		case value != nil:
+			typed = append(typed, TypedSupplementaryData{Path: path, PlaceAndName: deref(placeAndName), Namespace: envelopeNamespace(content), Value: value})
+		}
+	})
+	return typed, firstErr
+}
+
+// isSupplementaryData reports whether t is one of the supplementary data types
+func isSupplementaryData(t reflect.Type) bool {
+	return t == reflect.TypeOf(SupplementaryData{}) || t == reflect.TypeOf(SupplementaryData1{})
+}
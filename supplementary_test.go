@@ -0,0 +1,75 @@
+package iso20022
+
+import (
+	"encoding/xml"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type testTechnicalInfo struct {
+	XMLName xml.Name `xml:"urn:example:fedwire TechInf"`
+	IMAD    string   `xml:"IMAD"`
+}
+
+type testChannel struct {
+	XMLName xml.Name `xml:"Chnl"`
+	Code    string   `xml:"Cd"`
+}
+
+func TestSupplementaryData(t *testing.T) {
+	if err := RegisterSupplementaryData("", "urn:example:fedwire", testTechnicalInfo{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := RegisterSupplementaryData("RTP/Channel", "", &testChannel{}); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		RegisterSupplementaryData("", "urn:example:fedwire", nil)
+		RegisterSupplementaryData("RTP/Channel", "", nil)
+	})
+	if err := RegisterSupplementaryData("X", "", "not a struct"); err == nil {
+		t.Error("Expected a binding to a string to be refused")
+	}
+
+	doc := loadPacs008Sample(t)
+	tx := &doc.FICustomerCreditTransfer.CreditTransferTransactionInfo[0]
+	tx.SupplementaryData = []SupplementaryData{
+		{PlaceAndName: Ptr("Fedwire"), Envelope: SupplementaryDataEnvelope{Content: `<TechInf xmlns="urn:example:fedwire"><IMAD>20240315QMGFT001000001</IMAD></TechInf>`}},
+		{PlaceAndName: Ptr("Unknown"), Envelope: SupplementaryDataEnvelope{Content: `<Other>raw</Other>`}},
+		{PlaceAndName: Ptr("RTP/Channel")},
+	}
+	if err := tx.SupplementaryData[2].SetTyped(testChannel{Code: "MOBL"}); err != nil {
+		t.Fatal(err)
+	}
+
+	typed, err := SupplementaryDataOf(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []TypedSupplementaryData{
+		{Path: "FIToFICstmrCdtTrf/CdtTrfTxInf[1]/SplmtryData[1]", PlaceAndName: "Fedwire", Namespace: "urn:example:fedwire",
+			Value: &testTechnicalInfo{XMLName: xml.Name{Space: "urn:example:fedwire", Local: "TechInf"}, IMAD: "20240315QMGFT001000001"}},
+		{Path: "FIToFICstmrCdtTrf/CdtTrfTxInf[1]/SplmtryData[3]", PlaceAndName: "RTP/Channel",
+			Value: &testChannel{XMLName: xml.Name{Local: "Chnl"}, Code: "MOBL"}},
+	}
+	if !reflect.DeepEqual(typed, want) {
+		t.Errorf("Expected %+v, got %+v", want, typed)
+	}
+	if v, err := tx.SupplementaryData[1].Typed(); v != nil || err != nil {
+		t.Errorf("Expected unbound data to stay raw, got %v, %v", v, err)
+	}
+
+	data, err := xml.Marshal(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "<Other>raw</Other>") || !strings.Contains(string(data), "<Chnl><Cd>MOBL</Cd></Chnl>") {
+		t.Errorf("Expected the envelopes to be written unchanged, got %s", data)
+	}
+
+	tx.SupplementaryData[0].Envelope.Content = `<TechInf xmlns="urn:example:fedwire"><IMAD>`
+	if _, err := SupplementaryDataOf(doc); err == nil || !strings.HasPrefix(err.Error(), "FIToFICstmrCdtTrf/CdtTrfTxInf[1]/SplmtryData[1]: ") {
+		t.Errorf("Expected the malformed envelope to be reported at its path, got %v", err)
+	}
+}
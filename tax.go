@@ -0,0 +1,212 @@
+package iso20022
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// TaxRate is a tax a jurisdiction withholds from a payment
+type TaxRate struct {
+	// Type and Category are given in the tax record (Rcrd/Tp and Rcrd/Ctgy), such as
+	// FEDERAL or STATE for the type of an income tax
+	Type     string
+	Category string
+	// Rate is the percentage of the taxable base withheld, 15 for 15%
+	Rate Decimal
+	// Exemption is the part of the gross amount that is not taxed
+	Exemption Decimal
+	// Cap is the most that is withheld, zero for no limit
+	Cap Decimal
+}
+
+// TaxJurisdiction is the withholding rules of a tax administration zone
+type TaxJurisdiction struct {
+	// Zone is the tax administration zone (AdmstnZone), such as a state
+	Zone string
+	// Method is the method used for the tax (Mtd), "" to leave it out
+	Method string
+	Rates  []TaxRate
+}
+
+// TaxWithholding is the tax withheld from a payment
+type TaxWithholding struct {
+	Currency string
+	Gross    Decimal
+	// Tax is the tax withheld, the sum of the tax amounts of Records
+	Tax Decimal
+	// Net is the amount paid, Gross less Tax
+	Net     Decimal
+	Zone    string
+	Method  string
+	Records []TaxRecord2
+}
+
+// Withhold computes the taxes of the jurisdiction on a gross amount: for every rate,
+// the taxable base is the gross amount less the exemption, and the tax the rate of
+// the base, capped and rounded half away from zero to the minor unit of the
+// currency. Every rate gives a tax record, even with a zero tax.
+func (j TaxJurisdiction) Withhold(gross ActiveOrHistoricCurrencyAndAmount) TaxWithholding {
+	digits := MinorUnits(gross.Currency)
+	w := TaxWithholding{Currency: gross.Currency, Gross: gross.Value, Zone: j.Zone, Method: j.Method}
+	total := new(big.Rat)
+	for _, rate := range j.Rates {
+		base := new(big.Rat).Sub(DecimalRat(gross.Value), DecimalRat(rate.Exemption))
+		if base.Sign() < 0 {
+			base.SetInt64(0)
+		}
+		tax := new(big.Rat).Mul(base, DecimalRat(rate.Rate))
+		tax.Quo(tax, big.NewRat(100, 1))
+		if limit := DecimalRat(rate.Cap); rate.Cap > 0 && tax.Cmp(limit) > 0 {
+			tax = limit
+		}
+		tax = roundRat(tax, digits)
+		total.Add(total, tax)
+
+		percent := rate.Rate
+		record := TaxRecord2{TaxAmount: &TaxAmount2{
+			Rate:              &percent,
+			TaxableBaseAmount: &ActiveOrHistoricCurrencyAndAmount{Value: RatDecimal(base), Currency: gross.Currency},
+			TotalAmount:       &ActiveOrHistoricCurrencyAndAmount{Value: RatDecimal(tax), Currency: gross.Currency},
+		}}
+		if typ := rate.Type; typ != "" {
+			record.Type = &typ
+		}
+		if category := rate.Category; category != "" {
+			record.Category = &category
+		}
+		w.Records = append(w.Records, record)
+	}
+	w.Tax = RatDecimal(total)
+	w.Net = RatDecimal(new(big.Rat).Sub(DecimalRat(gross.Value), total))
+	return w
+}
+
+// TaxInfo8 returns the tax information of the withholding for the transactions of
+// pain.001 and pain.013, with the gross amount as the total taxable base. The
+// parties are left for the caller to fill in.
+func (w TaxWithholding) TaxInfo8() *TaxInfo8 {
+	info := &TaxInfo8{
+		TotalTaxableBaseAmount: &ActiveOrHistoricCurrencyAndAmount{Value: w.Gross, Currency: w.Currency},
+		TotalTaxAmount:         &ActiveOrHistoricCurrencyAndAmount{Value: w.Tax, Currency: w.Currency},
+		Record:                 append([]TaxRecord2(nil), w.Records...),
+	}
+	if zone := w.Zone; zone != "" {
+		info.AdministrationZone = &zone
+	}
+	if method := w.Method; method != "" {
+		info.Method = &method
+	}
+	return info
+}
+
+// TaxInfo returns the tax information of the withholding for the transactions of
+// pacs.008, as TaxInfo8 does
+func (w TaxWithholding) TaxInfo() *TaxInfo {
+	info8 := w.TaxInfo8()
+	info := &TaxInfo{
+		AdministrationZone:     info8.AdministrationZone,
+		Method:                 info8.Method,
+		TotalTaxableBaseAmount: info8.TotalTaxableBaseAmount,
+		TotalTaxAmount:         info8.TotalTaxAmount,
+	}
+	for _, r := range w.Records {
+		record := TaxRecord{Type: r.Type, Category: r.Category}
+		if r.TaxAmount != nil {
+			record.TaxAmount = &TaxAmount{Rate: r.TaxAmount.Rate, TaxableBaseAmount: r.TaxAmount.TaxableBaseAmount, TotalAmount: r.TaxAmount.TotalAmount}
+		}
+		info.Record = append(info.Record, record)
+	}
+	return info
+}
+
+// taxTotal is the total of a tax record with the amounts of its details
+type taxTotal struct {
+	total   *ActiveOrHistoricCurrencyAndAmount
+	details []ActiveOrHistoricCurrencyAndAmount
+}
+
+// CheckTotals checks that the total tax amount (TtlTaxAmt) is the sum of the total
+// amounts of the records, and that the total amount of every record with details is
+// the sum of their amounts. Amounts must all be in the currency of the total.
+func (t *TaxInfo8) CheckTotals() error {
+	records := make([]taxTotal, len(t.Record))
+	for i, r := range t.Record {
+		if r.TaxAmount == nil {
+			continue
+		}
+		records[i].total = r.TaxAmount.TotalAmount
+		for _, d := range r.TaxAmount.Details {
+			records[i].details = append(records[i].details, d.Amount)
+		}
+	}
+	return checkTaxTotals(t.TotalTaxAmount, records)
+}
+
+// CheckTotals checks the totals of the tax information, as TaxInfo8.CheckTotals does
+func (t *TaxInfo) CheckTotals() error {
+	records := make([]taxTotal, len(t.Record))
+	for i, r := range t.Record {
+		if r.TaxAmount == nil {
+			continue
+		}
+		records[i].total = r.TaxAmount.TotalAmount
+		for _, d := range r.TaxAmount.Details {
+			records[i].details = append(records[i].details, d.Amount)
+		}
+	}
+	return checkTaxTotals(t.TotalTaxAmount, records)
+}
+
+// checkTaxTotals checks the totals of tax records against their details and the
+// total tax amount. Errors have paths from the Tax element.
+func checkTaxTotals(total *ActiveOrHistoricCurrencyAndAmount, records []taxTotal) error {
+	var errs ValidationErrors
+	currency := ""
+	if total != nil {
+		currency = total.Currency
+	}
+	sameCurrency := func(path string, amount ActiveOrHistoricCurrencyAndAmount) bool {
+		if currency == "" {
+			currency = amount.Currency
+		}
+		if amount.Currency != currency {
+			errs = append(errs, ValidationError{Field: "Ccy", Path: path + "/@Ccy",
+				Message: fmt.Sprintf("is %s but the tax amounts are in %s", amount.Currency, currency)})
+			return false
+		}
+		return true
+	}
+
+	sum := new(big.Rat)
+	for i, r := range records {
+		path := fmt.Sprintf("Rcrd[%d]/TaxAmt", i+1)
+		if r.total == nil {
+			continue
+		}
+		if !sameCurrency(path+"/TtlAmt", *r.total) {
+			continue
+		}
+		sum.Add(sum, DecimalRat(r.total.Value))
+		if len(r.details) == 0 {
+			continue
+		}
+		details := new(big.Rat)
+		for j, d := range r.details {
+			if sameCurrency(fmt.Sprintf("%s/Dtls[%d]/Amt", path, j+1), d) {
+				details.Add(details, DecimalRat(d.Value))
+			}
+		}
+		if details.Cmp(DecimalRat(r.total.Value)) != 0 {
+			errs = append(errs, ValidationError{Field: "TtlAmt", Path: path + "/TtlAmt/text()",
+				Message: fmt.Sprintf("must equal the sum of the amounts of its details (%s)", formatRat(details))})
+		}
+	}
+	if total != nil && sum.Cmp(DecimalRat(total.Value)) != 0 {
+		errs = append(errs, ValidationError{Field: "TtlTaxAmt", Path: "TtlTaxAmt/text()",
+			Message: fmt.Sprintf("must equal the sum of the total amounts of the records (%s)", formatRat(sum))})
+	}
+	if errs.HasErrors() {
+		return errs
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package iso20022
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+)
+
+func TestTaxJurisdictionWithhold(t *testing.T) {
+	state := TaxJurisdiction{
+		Zone: "NY",
+		Rates: []TaxRate{
+			{Type: "FEDERAL", Rate: 24},
+			{Type: "STATE", Category: "INCOME", Rate: 6.85, Exemption: 1000},
+			{Type: "LOCAL", Rate: 10, Cap: 50},
+		},
+	}
+	w := state.Withhold(ActiveOrHistoricCurrencyAndAmount{Value: 2500.55, Currency: "USD"})
+	// 600.132 rounds to 600.13, 1500.55 * 6.85% = 102.787675 to 102.79, 250.055 is capped at 50
+	if w.Tax != 752.92 || w.Net != 1747.63 || len(w.Records) != 3 {
+		t.Fatalf("Expected 752.92 withheld and 1747.63 paid, got %+v", w)
+	}
+	if r := w.Records[1]; *r.Type != "STATE" || *r.Category != "INCOME" || r.TaxAmount.TaxableBaseAmount.Value != 1500.55 || r.TaxAmount.TotalAmount.Value != 102.79 {
+		t.Errorf("Expected the state record to tax 1500.55, got %+v", r.TaxAmount)
+	}
+
+	info := w.TaxInfo8()
+	if *info.AdministrationZone != "NY" || info.Method != nil || info.TotalTaxAmount.Value != 752.92 || info.TotalTaxableBaseAmount.Value != 2500.55 {
+		t.Errorf("Expected the totals of the withholding, got %+v", info)
+	}
+	if err := info.CheckTotals(); err != nil {
+		t.Errorf("Expected the computed totals to check, got %v", err)
+	}
+	data, err := xml.Marshal(w.TaxInfo())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `<Rcrd><Tp>LOCAL</Tp><TaxAmt><Rate>10</Rate><TaxblBaseAmt Ccy="USD">2500.55</TaxblBaseAmt><TtlAmt Ccy="USD">50</TtlAmt></TaxAmt></Rcrd>`) {
+		t.Errorf("Expected the pacs.008 tax records, got %s", data)
+	}
+
+	if w := state.Withhold(ActiveOrHistoricCurrencyAndAmount{Value: 100, Currency: "JPY"}); w.Records[1].TaxAmount.TaxableBaseAmount.Value != 0 || w.Tax != 34 {
+		t.Errorf("Expected the exemption to leave no state tax and yen to be whole, got %+v", w)
+	}
+}
+
+func TestTaxInfoCheckTotals(t *testing.T) {
+	amount := func(v Decimal, ccy string) *ActiveOrHistoricCurrencyAndAmount {
+		return &ActiveOrHistoricCurrencyAndAmount{Value: v, Currency: ccy}
+	}
+	info := TaxInfo{
+		TotalTaxAmount: amount(150, "USD"),
+		Record: []TaxRecord{
+			{TaxAmount: &TaxAmount{TotalAmount: amount(100, "USD"), Details: []TaxRecordDetails{{Amount: *amount(60, "USD")}, {Amount: *amount(30, "USD")}}}},
+			{TaxAmount: &TaxAmount{TotalAmount: amount(40, "USD")}},
+			{TaxAmount: &TaxAmount{TotalAmount: amount(5, "EUR")}},
+			{},
+		},
+	}
+	err := info.CheckTotals()
+	errs, ok := err.(ValidationErrors)
+	if !ok || len(errs) != 3 {
+		t.Fatalf("Expected three errors, got %v", err)
+	}
+	for i, want := range []string{"Rcrd[1]/TaxAmt/TtlAmt/text()", "Rcrd[3]/TaxAmt/TtlAmt/@Ccy", "TtlTaxAmt/text()"} {
+		if errs[i].Location() != want {
+			t.Errorf("Expected error %d at %s, got %v", i+1, want, errs[i])
+		}
+	}
+	if !strings.Contains(errs[2].Message, "(140)") {
+		t.Errorf("Expected the sum of the records to be given, got %q", errs[2].Message)
+	}
+}
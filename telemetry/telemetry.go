@@ -0,0 +1,158 @@
+// Package telemetry records the operations of package iso20022 as OpenTelemetry
+// spans and metrics, with the same names and attributes in every service.
+//
+// NewObserver returns an iso20022.Observer to pass to iso20022.SetObserver. So that
+// the module keeps no dependencies, it records through the small Tracer, Int64Counter
+// and Float64Histogram interfaces rather than the OpenTelemetry API itself; each is
+// a few lines over go.opentelemetry.io/otel:
+//
+//	type tracer struct{ t trace.Tracer }
+//
+//	func (t tracer) StartSpan(ctx context.Context, name string, start time.Time, attrs ...telemetry.Attribute) telemetry.Span {
+//		_, span := t.t.Start(ctx, name, trace.WithTimestamp(start), trace.WithAttributes(kvs(attrs)...))
+//		return otelSpan{span}
+//	}
+//
+//	type otelSpan struct{ s trace.Span }
+//
+//	func (s otelSpan) RecordError(err error) {
+//		s.s.RecordError(err)
+//		s.s.SetStatus(codes.Error, err.Error())
+//	}
+//
+//	func (s otelSpan) End(end time.Time) { s.s.End(trace.WithTimestamp(end)) }
+//
+// where kvs converts the attributes to attribute.String values, and similarly for
+// metric.Int64Counter and metric.Float64Histogram with metric.WithAttributes.
+package telemetry
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/ckbaum/iso20022-go"
+)
+
+// Names of the metrics, with the units they are recorded in
+const (
+	MetricDuration = "iso20022.operation.duration"  // s, a histogram of every operation
+	MetricSize     = "iso20022.message.size"        // By, a histogram of the parsed and written messages
+	MetricFindings = "iso20022.validation.findings" // {finding}, a counter of the validation errors and warnings
+)
+
+// Names of the attributes of the spans and metrics
+const (
+	AttributeOperation   = "iso20022.operation"    // parse, validate or marshal
+	AttributeMessageType = "iso20022.message_type" // such as pacs.008.001.08, when known
+	AttributeOutcome     = "iso20022.outcome"      // ok, invalid or error
+	AttributeRule        = "iso20022.rule"         // the Rule of a finding, when it has one
+	AttributeSeverity    = "iso20022.severity"     // error or warning
+	AttributeErrors      = "iso20022.validation.errors"
+	AttributeWarnings    = "iso20022.validation.warnings"
+)
+
+// Outcomes of an operation, the values of AttributeOutcome
+const (
+	OutcomeOK      = "ok"      // the operation succeeded and, for a validation, the document is valid
+	OutcomeInvalid = "invalid" // the validation reported errors
+	OutcomeError   = "error"   // the operation returned an error
+)
+
+// Attribute is a string attribute of a span or measurement
+type Attribute struct {
+	Key   string
+	Value string
+}
+
+// Span is a span started by a Tracer
+type Span interface {
+	RecordError(err error)
+	End(end time.Time)
+}
+
+// Tracer starts spans at a given time, as trace.Tracer.Start does with
+// trace.WithTimestamp
+type Tracer interface {
+	StartSpan(ctx context.Context, name string, start time.Time, attrs ...Attribute) Span
+}
+
+// Int64Counter is a counter, as metric.Int64Counter
+type Int64Counter interface {
+	Add(ctx context.Context, incr int64, attrs ...Attribute)
+}
+
+// Float64Histogram is a histogram, as metric.Float64Histogram
+type Float64Histogram interface {
+	Record(ctx context.Context, value float64, attrs ...Attribute)
+}
+
+// Metrics are the instruments an Observer records to. Any of them may be nil.
+type Metrics struct {
+	Duration Float64Histogram // MetricDuration
+	Size     Float64Histogram // MetricSize
+	Findings Int64Counter     // MetricFindings
+}
+
+// Observer records the operations it is told about as spans named
+// iso20022.<operation> and as measurements of its metrics
+type Observer struct {
+	tracer  Tracer
+	metrics Metrics
+}
+
+// NewObserver returns an Observer recording to tracer, which may be nil to record
+// no spans, and to metrics
+func NewObserver(tracer Tracer, metrics Metrics) *Observer {
+	return &Observer{tracer: tracer, metrics: metrics}
+}
+
+// Observe implements iso20022.Observer
+func (ob *Observer) Observe(ctx context.Context, o iso20022.Observation) {
+	attrs := []Attribute{{AttributeOperation, string(o.Operation)}}
+	if o.MessageType != "" {
+		attrs = append(attrs, Attribute{AttributeMessageType, o.MessageType})
+	}
+	outcome := OutcomeOK
+	switch {
+	case o.Err != nil:
+		outcome = OutcomeError
+	case o.Report != nil && !o.Report.Valid():
+		outcome = OutcomeInvalid
+	}
+
+	if ob.tracer != nil {
+		spanAttrs := append(attrs[:len(attrs):len(attrs)], Attribute{AttributeOutcome, outcome})
+		if o.Report != nil {
+			spanAttrs = append(spanAttrs,
+				Attribute{AttributeErrors, strconv.Itoa(len(o.Report.Errors))},
+				Attribute{AttributeWarnings, strconv.Itoa(len(o.Report.Warnings))})
+		}
+		span := ob.tracer.StartSpan(ctx, "iso20022."+string(o.Operation), o.Start, spanAttrs...)
+		if o.Err != nil {
+			span.RecordError(o.Err)
+		}
+		span.End(o.Start.Add(o.Duration))
+	}
+	if ob.metrics.Duration != nil {
+		ob.metrics.Duration.Record(ctx, o.Duration.Seconds(), append(attrs[:len(attrs):len(attrs)], Attribute{AttributeOutcome, outcome})...)
+	}
+	if ob.metrics.Size != nil && o.Size > 0 {
+		ob.metrics.Size.Record(ctx, float64(o.Size), attrs...)
+	}
+	if ob.metrics.Findings != nil && o.Report != nil {
+		for severity, errs := range map[iso20022.Severity]iso20022.ValidationErrors{iso20022.SeverityError: o.Report.Errors, iso20022.SeverityWarning: o.Report.Warnings} {
+			counts := make(map[string]int64)
+			for _, e := range errs {
+				counts[e.Rule]++
+			}
+			for rule, n := range counts {
+				findingAttrs := append(attrs[:len(attrs):len(attrs)], Attribute{AttributeSeverity, severity.String()})
+				if rule != "" {
+					findingAttrs = append(findingAttrs, Attribute{AttributeRule, rule})
+				}
+				ob.metrics.Findings.Add(ctx, n, findingAttrs...)
+			}
+		}
+	}
+}
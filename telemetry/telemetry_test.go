@@ -0,0 +1,112 @@
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/ckbaum/iso20022-go"
+)
+
+type span struct {
+	name       string
+	start, end time.Time
+	attrs      []Attribute
+	err        error
+}
+
+func (s *span) RecordError(err error) { s.err = err }
+func (s *span) End(end time.Time)     { s.end = end }
+
+type tracer struct{ spans []*span }
+
+func (t *tracer) StartSpan(ctx context.Context, name string, start time.Time, attrs ...Attribute) Span {
+	s := &span{name: name, start: start, attrs: attrs}
+	t.spans = append(t.spans, s)
+	return s
+}
+
+type measurement struct {
+	value float64
+	attrs []Attribute
+}
+
+type instrument struct{ measurements []measurement }
+
+func (i *instrument) Record(ctx context.Context, value float64, attrs ...Attribute) {
+	i.measurements = append(i.measurements, measurement{value, attrs})
+}
+
+func (i *instrument) Add(ctx context.Context, incr int64, attrs ...Attribute) {
+	i.Record(ctx, float64(incr), attrs...)
+}
+
+func TestObserver(t *testing.T) {
+	var tr tracer
+	var duration, size, findings instrument
+	ob := NewObserver(&tr, Metrics{Duration: &duration, Size: &size, Findings: &findings})
+	start := time.Date(2024, time.March, 15, 12, 0, 0, 0, time.UTC)
+
+	ob.Observe(context.Background(), iso20022.Observation{
+		Operation:   iso20022.OperationParse,
+		MessageType: "pacs.008.001.08",
+		Start:       start,
+		Duration:    2 * time.Millisecond,
+		Size:        4096,
+	})
+	if len(tr.spans) != 1 {
+		t.Fatalf("Expected a span, got %d", len(tr.spans))
+	}
+	s := tr.spans[0]
+	want := []Attribute{{AttributeOperation, "parse"}, {AttributeMessageType, "pacs.008.001.08"}, {AttributeOutcome, OutcomeOK}}
+	if s.name != "iso20022.parse" || !s.start.Equal(start) || !s.end.Equal(start.Add(2*time.Millisecond)) || !reflect.DeepEqual(s.attrs, want) {
+		t.Errorf("Expected the parse span, got %+v", s)
+	}
+	if len(duration.measurements) != 1 || duration.measurements[0].value != 0.002 || !reflect.DeepEqual(duration.measurements[0].attrs, want) {
+		t.Errorf("Expected the duration in seconds, got %+v", duration.measurements)
+	}
+	if len(size.measurements) != 1 || size.measurements[0].value != 4096 || len(size.measurements[0].attrs) != 2 {
+		t.Errorf("Expected the size, got %+v", size.measurements)
+	}
+
+	// A validation with findings
+	report := &iso20022.ValidationReport{
+		Errors:   iso20022.ValidationErrors{{Rule: iso20022.RuleRequired}, {Rule: iso20022.RuleRequired}, {Rule: iso20022.RuleCode}},
+		Warnings: iso20022.ValidationErrors{{Rule: iso20022.RuleSettlementCalendar}},
+	}
+	ob.Observe(context.Background(), iso20022.Observation{Operation: iso20022.OperationValidate, Start: start, Report: report})
+	s = tr.spans[1]
+	if got := s.attrs[1]; got != (Attribute{AttributeOutcome, OutcomeInvalid}) {
+		t.Errorf("Expected an invalid outcome, got %v", got)
+	}
+	if got := s.attrs[2:]; !reflect.DeepEqual(got, []Attribute{{AttributeErrors, "3"}, {AttributeWarnings, "1"}}) {
+		t.Errorf("Expected the counts of findings, got %v", got)
+	}
+	if len(size.measurements) != 1 {
+		t.Errorf("Expected no size for a validation, got %+v", size.measurements)
+	}
+	var got []string
+	for _, m := range findings.measurements {
+		got = append(got, fmt.Sprintf("%s %s %v", m.attrs[1].Value, m.attrs[2].Value, m.value))
+	}
+	sort.Strings(got)
+	if want := []string{"error CODE 1", "error REQUIRED 2", "warning SETTLEMENT_CALENDAR 1"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected findings %v, got %v", want, got)
+	}
+
+	// A failed operation
+	failure := errors.New("boom")
+	ob.Observe(context.Background(), iso20022.Observation{Operation: iso20022.OperationMarshal, Start: start, Err: failure})
+	if s := tr.spans[2]; s.err != failure || s.attrs[1] != (Attribute{AttributeOutcome, OutcomeError}) {
+		t.Errorf("Expected the error to be recorded, got %+v", s)
+	}
+}
+
+func TestObserverNoInstruments(t *testing.T) {
+	ob := NewObserver(nil, Metrics{})
+	ob.Observe(context.Background(), iso20022.Observation{Operation: iso20022.OperationParse, Report: &iso20022.ValidationReport{}})
+}
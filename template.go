@@ -0,0 +1,269 @@
+package iso20022
+
+import (
+	"math/big"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// TemplatePayment holds the values that change from one payment of a template to
+// the next. The zero value of an optional field keeps what the template has.
+type TemplatePayment struct {
+	Amount Decimal
+	// Currency of the amount, by default the currency of the template transaction
+	Currency        string
+	Creditor        PartyIdentification135
+	CreditorAccount *CashAccount38
+	CreditorAgent   *BranchAndFinancialInstitutionIdentification6
+	// EndToEndID is the reference of the payment, NOTPROVIDED when empty
+	EndToEndID string
+	// Remittance is unstructured remittance information, such as an invoice number,
+	// which replaces that of the template
+	Remittance string
+}
+
+// CreditTransferTemplate is a pacs.008 with the elements shared by a run of
+// payments, such as the settlement information and the debtor, its account and
+// agent, from which Instantiate generates messages. The template itself is never
+// changed.
+type CreditTransferTemplate struct {
+	// Header is the group header of the messages. MsgId, CreDtTm, NbOfTxs and
+	// CtrlSum are set for each message, and TtlIntrBkSttlmAmt when the template has
+	// one.
+	Header GroupHeader93
+	// Transaction is copied into every transaction, which then takes the values of
+	// its payment, and an InstrId and TxId from the identification generator. Its
+	// UETR is not copied.
+	Transaction CreditTransferTransaction39
+	// Now gives the creation time of the messages, time.Now when nil
+	Now func() time.Time
+}
+
+// Instantiate returns a pacs.008 with a transaction per payment, identified with
+// ids. The message is validated, including the business rules, and returned only
+// when it is valid.
+func (t *CreditTransferTemplate) Instantiate(ids IDGenerator, payments ...TemplatePayment) (*Pacs00800108Document, error) {
+	msgID, err := ids.NextID()
+	if err != nil {
+		return nil, err
+	}
+	hdr := deepCopy(t.Header)
+	hdr.MessageID = msgID
+	created := NewISODateTime(templateNow(t.Now))
+	hdr.CreationDateTime = &created
+
+	txs := make([]CreditTransferTransaction39, 0, len(payments))
+	sum := new(big.Rat)
+	for _, p := range payments {
+		instrID, err := ids.NextID()
+		if err != nil {
+			return nil, err
+		}
+		tx := deepCopy(t.Transaction)
+		txID := instrID
+		tx.PaymentID.InstructionID = &instrID
+		tx.PaymentID.TransactionID = &txID
+		tx.PaymentID.EndToEndID = endToEndID(p.EndToEndID)
+		tx.PaymentID.UETR = nil
+		tx.InterbankSettlementAmount.Value = p.Amount
+		if p.Currency != "" {
+			tx.InterbankSettlementAmount.Currency = p.Currency
+		}
+		tx.Creditor = p.Creditor
+		if p.CreditorAccount != nil {
+			tx.CreditorAccount = p.CreditorAccount
+		}
+		if p.CreditorAgent != nil {
+			tx.CreditorAgent = *p.CreditorAgent
+		}
+		if p.Remittance != "" {
+			tx.RemittanceInfo = &RemittanceInfo{Unstructured: []string{p.Remittance}}
+		}
+		sum.Add(sum, DecimalRat(p.Amount))
+		txs = append(txs, tx)
+	}
+
+	total := RatDecimal(sum)
+	hdr.NumberOfTransactions = strconv.Itoa(len(txs))
+	hdr.ControlSum = &total
+	if hdr.TotalInterbankSettlementAmount != nil {
+		hdr.TotalInterbankSettlementAmount.Value = total
+	}
+	doc := &Pacs00800108Document{FICustomerCreditTransfer: FIToFICustomerCreditTransferV08{
+		GroupHeader:                   hdr,
+		CreditTransferTransactionInfo: txs,
+	}}
+	if err := doc.Validate(); err != nil {
+		return nil, err
+	}
+	if err := doc.ValidateBusinessRules(); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// PaymentInitiationTemplate is a pain.001 with the elements shared by a run of
+// payments, such as a payroll: the initiating party, and the debtor, its account
+// and agent in the payment information block. Instantiate generates messages from
+// it without changing it.
+type PaymentInitiationTemplate struct {
+	// Header is the group header of the messages. MsgId, CreDtTm, NbOfTxs and
+	// CtrlSum are set for each message.
+	Header GroupHeader85
+	// PaymentInfo is the payment information block of the messages. PmtInfId,
+	// NbOfTxs and CtrlSum are set for each message, and its transactions replaced.
+	PaymentInfo PaymentInstruction30
+	// Transaction is copied into every transaction, which then takes the values of
+	// its payment and an InstrId from the identification generator. Its UETR is not
+	// copied.
+	Transaction CreditTransferTransaction34
+	// Now gives the creation time of the messages, time.Now when nil
+	Now func() time.Time
+}
+
+// Instantiate returns a pain.001 with a payment information block holding a
+// transaction per payment, identified with ids. The message is validated and
+// returned only when it is valid.
+func (t *PaymentInitiationTemplate) Instantiate(ids IDGenerator, payments ...TemplatePayment) (*Pain00100109Document, error) {
+	msgID, err := ids.NextID()
+	if err != nil {
+		return nil, err
+	}
+	pmtInfID, err := ids.NextID()
+	if err != nil {
+		return nil, err
+	}
+	hdr := deepCopy(t.Header)
+	hdr.MessageID = msgID
+	hdr.CreationDateTime = NewISODateTime(templateNow(t.Now))
+	pmt := deepCopy(t.PaymentInfo)
+	pmt.PaymentInfoID = pmtInfID
+
+	currency := ""
+	if t.Transaction.Amount.InstructedAmount != nil {
+		currency = t.Transaction.Amount.InstructedAmount.Currency
+	}
+	txs := make([]CreditTransferTransaction34, 0, len(payments))
+	sum := new(big.Rat)
+	for _, p := range payments {
+		instrID, err := ids.NextID()
+		if err != nil {
+			return nil, err
+		}
+		tx := deepCopy(t.Transaction)
+		tx.PaymentID.InstructionID = &instrID
+		tx.PaymentID.EndToEndID = endToEndID(p.EndToEndID)
+		tx.PaymentID.UETR = nil
+		amount := ActiveOrHistoricCurrencyAndAmount{Value: p.Amount, Currency: currency}
+		if p.Currency != "" {
+			amount.Currency = p.Currency
+		}
+		tx.Amount = AmountType4{InstructedAmount: &amount}
+		creditor := p.Creditor
+		tx.Creditor = &creditor
+		if p.CreditorAccount != nil {
+			tx.CreditorAccount = p.CreditorAccount
+		}
+		if p.CreditorAgent != nil {
+			tx.CreditorAgent = p.CreditorAgent
+		}
+		if p.Remittance != "" {
+			tx.RemittanceInfo = &RemittanceInfo{Unstructured: []string{p.Remittance}}
+		}
+		sum.Add(sum, DecimalRat(p.Amount))
+		txs = append(txs, tx)
+	}
+
+	total := RatDecimal(sum)
+	count := strconv.Itoa(len(txs))
+	hdr.NumberOfTransactions = count
+	hdr.ControlSum = &total
+	pmt.NumberOfTransactions = &count
+	pmtTotal := total
+	pmt.ControlSum = &pmtTotal
+	pmt.CreditTransferTransactionInfo = txs
+	doc := &Pain00100109Document{CustomerCreditTransferInitiation: CustomerCreditTransferInitiationV09{
+		GroupHeader: hdr,
+		PaymentInfo: []PaymentInstruction30{pmt},
+	}}
+	if err := doc.Validate(); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// templateNow returns the time given by now, or the current time
+func templateNow(now func() time.Time) time.Time {
+	if now == nil {
+		return time.Now()
+	}
+	return now()
+}
+
+// endToEndID returns id, or NOTPROVIDED when it is empty
+func endToEndID(id string) string {
+	if id == "" {
+		return "NOTPROVIDED"
+	}
+	return id
+}
+
+// deepCopy returns a copy of v that shares no pointers, slices or maps with it, so
+// that changing one leaves the other as it is
+func deepCopy[T any](v T) T {
+	var out T
+	copyValue(reflect.ValueOf(&out).Elem(), reflect.ValueOf(v))
+	return out
+}
+
+// copyValue sets dst, which must be settable, to a deep copy of src
+func copyValue(dst, src reflect.Value) {
+	switch src.Kind() {
+	case reflect.Ptr:
+		if src.IsNil() {
+			return
+		}
+		p := reflect.New(src.Type().Elem())
+		copyValue(p.Elem(), src.Elem())
+		dst.Set(p)
+	case reflect.Interface:
+		if src.IsNil() {
+			return
+		}
+		elem := reflect.New(src.Elem().Type()).Elem()
+		copyValue(elem, src.Elem())
+		dst.Set(elem)
+	case reflect.Slice:
+		if src.IsNil() {
+			return
+		}
+		s := reflect.MakeSlice(src.Type(), src.Len(), src.Len())
+		for i := 0; i < src.Len(); i++ {
+			copyValue(s.Index(i), src.Index(i))
+		}
+		dst.Set(s)
+	case reflect.Map:
+		if src.IsNil() {
+			return
+		}
+		m := reflect.MakeMapWithSize(src.Type(), src.Len())
+		iter := src.MapRange()
+		for iter.Next() {
+			elem := reflect.New(src.Type().Elem()).Elem()
+			copyValue(elem, iter.Value())
+			m.SetMapIndex(iter.Key(), elem)
+		}
+		dst.Set(m)
+	case reflect.Struct:
+		// Unexported fields, such as those of time.Time, are copied as they are
+		dst.Set(src)
+		for i := 0; i < src.NumField(); i++ {
+			if src.Type().Field(i).IsExported() {
+				copyValue(dst.Field(i), src.Field(i))
+			}
+		}
+	default:
+		dst.Set(src)
+	}
+}
@@ -0,0 +1,134 @@
+package iso20022
+
+import (
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// templatePayments are the payments of a small payroll
+var templatePayments = []TemplatePayment{
+	{Amount: 2500.5, Creditor: PartyIdentification135{Name: Ptr("Jane Smith")},
+		CreditorAccount: &CashAccount38{ID: AccountIdentification4{IBAN: Ptr("GB29NWBK60161331926819")}},
+		EndToEndID:      "PAYROLL-2024-03-JS", Remittance: "Salary March 2024"},
+	{Amount: 3100.25, Creditor: PartyIdentification135{Name: Ptr("John Doe")},
+		CreditorAccount: &CashAccount38{ID: AccountIdentification4{IBAN: Ptr("DE89370400440532013000")}}},
+}
+
+func templateIDs(t *testing.T) IDGenerator {
+	t.Helper()
+	ids, err := NewSequenceGenerator("ACME-", WithClock(func() time.Time { return time.Date(2024, 3, 28, 8, 0, 0, 0, time.UTC) }))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ids
+}
+
+func TestCreditTransferTemplate(t *testing.T) {
+	sample := loadPacs008Sample(t).FICustomerCreditTransfer
+	tmpl := &CreditTransferTemplate{
+		Header:      sample.GroupHeader,
+		Transaction: sample.CreditTransferTransactionInfo[0],
+		Now:         func() time.Time { return time.Date(2024, 3, 28, 8, 0, 0, 0, time.UTC) },
+	}
+	tmpl.Transaction.InstructedAmount = nil
+	tmpl.Transaction.ChargesInfo = nil
+	tmpl.Transaction.RemittanceInfo = nil
+	total := ActiveCurrencyAndAmount{Currency: "USD"}
+	tmpl.Header.TotalInterbankSettlementAmount = &total
+
+	doc, err := tmpl.Instantiate(templateIDs(t), templatePayments...)
+	if err != nil {
+		t.Fatalf("Expected a valid message, got %v", err)
+	}
+	msg := doc.FICustomerCreditTransfer
+	hdr := msg.GroupHeader
+	if hdr.MessageID != "ACME-20240328-000001" || hdr.NumberOfTransactions != "2" || *hdr.ControlSum != 5600.75 ||
+		hdr.TotalInterbankSettlementAmount.Value != 5600.75 || !hdr.CreationDateTime.Equal(tmpl.Now()) {
+		t.Errorf("Unexpected group header %+v", hdr)
+	}
+	first, second := msg.CreditTransferTransactionInfo[0], msg.CreditTransferTransactionInfo[1]
+	if *first.PaymentID.InstructionID != "ACME-20240328-000002" || *second.PaymentID.TransactionID != "ACME-20240328-000003" ||
+		first.PaymentID.UETR != nil {
+		t.Errorf("Unexpected payment identifications %+v, %+v", first.PaymentID, second.PaymentID)
+	}
+	if first.PaymentID.EndToEndID != "PAYROLL-2024-03-JS" || second.PaymentID.EndToEndID != "NOTPROVIDED" {
+		t.Errorf("Unexpected end-to-end identifications %q, %q", first.PaymentID.EndToEndID, second.PaymentID.EndToEndID)
+	}
+	if first.InterbankSettlementAmount != (ActiveCurrencyAndAmount{Value: 2500.5, Currency: "USD"}) || *second.Creditor.Name != "John Doe" ||
+		first.RemittanceInfo.Unstructured[0] != "Salary March 2024" || second.RemittanceInfo != nil {
+		t.Errorf("Unexpected transactions %+v, %+v", first, second)
+	}
+
+	// The messages share nothing with the template
+	*first.Debtor.Name = "Changed"
+	if *tmpl.Transaction.Debtor.Name == "Changed" || *second.Debtor.Name == "Changed" {
+		t.Errorf("Expected the transactions to have their own debtor")
+	}
+	if total.Value != 0 {
+		t.Errorf("Expected the template total to be left as it is, got %v", total.Value)
+	}
+
+	// Invalid payments give no message
+	invalid := templatePayments[0]
+	invalid.Currency = "usd"
+	if doc, err := tmpl.Instantiate(templateIDs(t), invalid); err == nil {
+		t.Errorf("Expected a validation error, got %+v", doc)
+	}
+}
+
+func TestPaymentInitiationTemplate(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("testdata", "roundtrip", "pain.001.001.09", "credit_transfer_initiation.xml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var sample Pain00100109Document
+	if err := xml.Unmarshal(data, &sample); err != nil {
+		t.Fatal(err)
+	}
+	msg := sample.CustomerCreditTransferInitiation
+	tmpl := &PaymentInitiationTemplate{
+		Header:      msg.GroupHeader,
+		PaymentInfo: msg.PaymentInfo[0],
+		Transaction: msg.PaymentInfo[0].CreditTransferTransactionInfo[0],
+	}
+	tmpl.Transaction.RemittanceInfo = nil
+
+	doc, err := tmpl.Instantiate(templateIDs(t), templatePayments...)
+	if err != nil {
+		t.Fatalf("Expected a valid message, got %v", err)
+	}
+	out := doc.CustomerCreditTransferInitiation
+	pmt := out.PaymentInfo[0]
+	if out.GroupHeader.MessageID != "ACME-20240328-000001" || pmt.PaymentInfoID != "ACME-20240328-000002" {
+		t.Errorf("Unexpected identifications %q, %q", out.GroupHeader.MessageID, pmt.PaymentInfoID)
+	}
+	if out.GroupHeader.NumberOfTransactions != "2" || *out.GroupHeader.ControlSum != 5600.75 ||
+		*pmt.NumberOfTransactions != "2" || *pmt.ControlSum != 5600.75 || len(pmt.CreditTransferTransactionInfo) != 2 {
+		t.Errorf("Unexpected totals %+v, %+v", out.GroupHeader, pmt)
+	}
+	tx := pmt.CreditTransferTransactionInfo[1]
+	currency := tmpl.Transaction.Amount.InstructedAmount.Currency
+	if *tx.PaymentID.InstructionID != "ACME-20240328-000004" || *tx.Amount.InstructedAmount != (ActiveOrHistoricCurrencyAndAmount{Value: 3100.25, Currency: currency}) ||
+		*tx.Creditor.Name != "John Doe" || *tx.CreditorAccount.ID.IBAN != "DE89370400440532013000" {
+		t.Errorf("Unexpected transaction %+v", tx)
+	}
+	if len(tmpl.PaymentInfo.CreditTransferTransactionInfo) != 2 || *tmpl.PaymentInfo.NumberOfTransactions != "2" {
+		t.Errorf("Expected the template to be left as it is")
+	}
+}
+
+func TestDeepCopy(t *testing.T) {
+	date := NewISODate(2024, 3, 28)
+	in := RemittanceInfo{Unstructured: []string{"INV-1"}, Structured: []StructuredRemittanceInfo{{}}}
+	out := deepCopy(in)
+	out.Unstructured[0] = "INV-2"
+	if in.Unstructured[0] != "INV-1" {
+		t.Errorf("Expected the slices to be copied")
+	}
+	if got := deepCopy(&date); got == &date || !got.Equal(date.Time) {
+		t.Errorf("Expected a copy of the date, got %v", got)
+	}
+}
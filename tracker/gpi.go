@@ -0,0 +1,173 @@
+package tracker
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Transaction statuses of the SWIFT gpi Tracker
+const (
+	GpiCredited = "ACCC" // credited to the account of the beneficiary
+	GpiSettled  = "ACSC" // settled between the agents, for transfers between institutions
+	GpiPending  = "ACSP" // in progress, detailed by a G reason code
+	GpiRejected = "RJCT"
+	GpiCanceled = "CANC"
+)
+
+// Reason codes of the in-progress status ACSP
+const (
+	GpiInProgress         = "G000" // transferred to the next agent, which is tracked
+	GpiNotTracked         = "G001" // transferred to the next agent, which is not tracked
+	GpiCreditNotSameDay   = "G002" // credit to the beneficiary may not happen the same day
+	GpiPendingDocuments   = "G003" // credit pending documents or information from the beneficiary
+	GpiPendingFunds       = "G004" // credit pending the arrival of the funds
+	GpiDeliveredToGpi     = "G005" // delivered to a tracked creditor agent
+	GpiDeliveredToNonGpi  = "G006" // delivered to a creditor agent that is not tracked
+	GpiPendingAuthorities = "G007" // credit pending the approval of authorities
+)
+
+var gpiReasons = map[string]string{
+	GpiInProgress:         "in progress at the next agent",
+	GpiNotTracked:         "transferred to an agent that is not tracked",
+	GpiCreditNotSameDay:   "credit may not be on the same day",
+	GpiPendingDocuments:   "credit pending documents or information",
+	GpiPendingFunds:       "credit pending funds",
+	GpiDeliveredToGpi:     "delivered to a tracked creditor agent",
+	GpiDeliveredToNonGpi:  "delivered to a creditor agent that is not tracked",
+	GpiPendingAuthorities: "credit pending approval of authorities",
+}
+
+// GpiStatus is a status as the SWIFT gpi Tracker reports it: a transaction status
+// and, for ACSP and RJCT, a reason code
+type GpiStatus struct {
+	Status string
+	Reason string
+}
+
+// String returns the status as ACSP/G000, or the bare status without a reason
+func (s GpiStatus) String() string {
+	if s.Reason == "" {
+		return s.Status
+	}
+	return s.Status + "/" + s.Reason
+}
+
+// Description returns a short description of the status
+func (s GpiStatus) Description() string {
+	switch s.Status {
+	case GpiCredited:
+		return "credited to the beneficiary"
+	case GpiSettled:
+		return "settled"
+	case GpiPending:
+		if d, ok := gpiReasons[s.Reason]; ok {
+			return d
+		}
+		return "in progress"
+	case GpiRejected:
+		if s.Reason != "" {
+			return "rejected (" + s.Reason + ")"
+		}
+		return "rejected"
+	case GpiCanceled:
+		return "canceled"
+	}
+	return "unknown status " + s.Status
+}
+
+// ParseGpiStatus parses a status written as ACSP/G000 or ACCC
+func ParseGpiStatus(s string) (GpiStatus, error) {
+	status, reason, _ := strings.Cut(strings.TrimSpace(s), "/")
+	switch status {
+	case GpiCredited, GpiSettled, GpiCanceled:
+		if reason != "" {
+			return GpiStatus{}, fmt.Errorf("gpi status %s takes no reason, got %q", status, s)
+		}
+	case GpiPending:
+		if _, ok := gpiReasons[reason]; !ok {
+			return GpiStatus{}, fmt.Errorf("gpi status ACSP needs a G reason code, got %q", s)
+		}
+	case GpiRejected:
+	default:
+		return GpiStatus{}, fmt.Errorf("unknown gpi status %q", s)
+	}
+	return GpiStatus{Status: status, Reason: reason}, nil
+}
+
+// GpiStatusOf maps a pacs.002 transaction status and its status reason codes to the
+// gpi Tracker status. The interim statuses ACTC, ACCP, ACWC, ACSP, RCVD and PDNG
+// become ACSP, with the first G reason code, or G000 without one; PDNG without one
+// becomes G002. Rejections keep their first reason code.
+func GpiStatusOf(status string, reasons ...string) (GpiStatus, error) {
+	var gpiReason, first string
+	for _, r := range reasons {
+		if first == "" {
+			first = r
+		}
+		if _, ok := gpiReasons[r]; ok && gpiReason == "" {
+			gpiReason = r
+		}
+	}
+
+	switch status {
+	case "ACCC":
+		return GpiStatus{Status: GpiCredited}, nil
+	case "ACSC":
+		return GpiStatus{Status: GpiSettled}, nil
+	case "CANC":
+		return GpiStatus{Status: GpiCanceled}, nil
+	case "RJCT":
+		return GpiStatus{Status: GpiRejected, Reason: first}, nil
+	case "ACTC", "ACCP", "ACWC", "ACSP", "RCVD", "PDNG":
+		if gpiReason == "" {
+			gpiReason = GpiInProgress
+			if status == "PDNG" {
+				gpiReason = GpiCreditNotSameDay
+			}
+		}
+		return GpiStatus{Status: GpiPending, Reason: gpiReason}, nil
+	}
+	return GpiStatus{}, fmt.Errorf("pacs.002 status %q has no gpi equivalent", status)
+}
+
+// GpiStatusOfUpdate maps a status update of a Tracker to the gpi Tracker status
+func GpiStatusOfUpdate(u Update) (GpiStatus, error) {
+	return GpiStatusOf(u.Status, u.Reasons...)
+}
+
+// IsFinal reports whether a transaction status, of pacs.002 or of the gpi Tracker,
+// ends the processing of a transaction: ACCC, ACSC, RJCT or CANC
+func IsFinal(status string) bool {
+	switch status {
+	case "ACCC", "ACSC", "RJCT", "CANC":
+		return true
+	}
+	return false
+}
+
+// IsFinal reports whether the status ends the processing of the transaction
+func (s GpiStatus) IsFinal() bool {
+	return IsFinal(s.Status)
+}
+
+// IsCreditedToBeneficiary reports whether the status confirms that the funds reached
+// the account of the beneficiary. ACSC only confirms it for transfers between
+// institutions, where the creditor agent is the beneficiary, so it counts when
+// institutionTransfer is set.
+func IsCreditedToBeneficiary(s GpiStatus, institutionTransfer bool) bool {
+	return s.Status == GpiCredited || institutionTransfer && s.Status == GpiSettled
+}
+
+// IsDelivered reports whether the payment reached the creditor agent, credited or not
+func IsDelivered(s GpiStatus) bool {
+	switch {
+	case s.Status == GpiCredited, s.Status == GpiSettled:
+		return true
+	case s.Status == GpiPending:
+		switch s.Reason {
+		case GpiCreditNotSameDay, GpiPendingDocuments, GpiPendingFunds, GpiDeliveredToGpi, GpiDeliveredToNonGpi, GpiPendingAuthorities:
+			return true
+		}
+	}
+	return false
+}
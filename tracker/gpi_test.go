@@ -0,0 +1,57 @@
+package tracker
+
+import "testing"
+
+func TestGpiStatusOf(t *testing.T) {
+	tests := []struct {
+		status  string
+		reasons []string
+		want    string
+		final   bool
+	}{
+		{"ACCC", nil, "ACCC", true},
+		{"ACSC", nil, "ACSC", true},
+		{"ACSP", []string{"G004"}, "ACSP/G004", false},
+		{"ACSP", nil, "ACSP/G000", false},
+		{"ACTC", []string{"NARR", "G001"}, "ACSP/G001", false},
+		{"PDNG", nil, "ACSP/G002", false},
+		{"RJCT", []string{"AC04"}, "RJCT/AC04", true},
+	}
+	for _, tt := range tests {
+		got, err := GpiStatusOf(tt.status, tt.reasons...)
+		if err != nil {
+			t.Errorf("GpiStatusOf(%s, %v) failed: %v", tt.status, tt.reasons, err)
+			continue
+		}
+		if got.String() != tt.want || got.IsFinal() != tt.final {
+			t.Errorf("GpiStatusOf(%s, %v) = %s (final %v), expected %s (final %v)", tt.status, tt.reasons, got, got.IsFinal(), tt.want, tt.final)
+		}
+		if parsed, err := ParseGpiStatus(tt.want); err != nil || parsed != got {
+			t.Errorf("ParseGpiStatus(%s) = %v, %v", tt.want, parsed, err)
+		}
+	}
+
+	if _, err := GpiStatusOf("XXXX"); err == nil {
+		t.Error("Expected an error for an unknown status")
+	}
+	if _, err := ParseGpiStatus("ACSP/G999"); err == nil {
+		t.Error("Expected an error for an unknown G reason code")
+	}
+}
+
+func TestGpiInterpretation(t *testing.T) {
+	credited := GpiStatus{Status: GpiCredited}
+	settled := GpiStatus{Status: GpiSettled}
+	funds := GpiStatus{Status: GpiPending, Reason: GpiPendingFunds}
+	next := GpiStatus{Status: GpiPending, Reason: GpiInProgress}
+
+	if !IsCreditedToBeneficiary(credited, false) || IsCreditedToBeneficiary(settled, false) || !IsCreditedToBeneficiary(settled, true) {
+		t.Error("Unexpected IsCreditedToBeneficiary")
+	}
+	if IsCreditedToBeneficiary(funds, false) || !IsDelivered(funds) || IsDelivered(next) {
+		t.Error("Unexpected interpretation of pending statuses")
+	}
+	if funds.Description() != "credit pending funds" {
+		t.Errorf("Unexpected description %q", funds.Description())
+	}
+}
@@ -0,0 +1,415 @@
+// Package tracker follows sent pacs.008 transactions until a pacs.002 reports a final
+// status for them, and asks for the status of the ones that stay unanswered.
+//
+// A Tracker records the transactions of every pacs.008 passed to Track. Transactions
+// without a final status after the configured timeout become due, and StatusRequests
+// builds pacs.028 FIToFIPaymentStatusRequestV03 messages for them, carrying their
+// UETR, original references and agents. Status reports, whether they answer a
+// pacs.028 or arrive on their own, are fed back through the Correlator interface,
+// which matches them to the tracked transactions.
+package tracker
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ckbaum/iso20022-go"
+)
+
+// pacs008 is the message name identification of the tracked messages
+const pacs008 = "pacs.008.001.08"
+
+// Errors returned by a Tracker
+var (
+	ErrDuplicateTransaction = errors.New("tracker: transaction already tracked")
+	ErrUnknownTransaction   = errors.New("tracker: status for an unknown transaction")
+)
+
+// Transaction is a tracked pacs.008 transaction
+type Transaction struct {
+	MessageID        string
+	CreationDateTime *iso20022.ISODateTime
+	Payment          iso20022.CreditTransferTransaction39
+	InstructingAgent *iso20022.BranchAndFinancialInstitutionIdentification6 // of the transaction, else of the message
+	InstructedAgent  *iso20022.BranchAndFinancialInstitutionIdentification6
+	SentAt           time.Time
+	Status           string    // latest transaction status, empty until one is reported
+	Requests         int       // number of status requests sent
+	RequestedAt      time.Time // when the latest status request was sent
+}
+
+// Ref returns the reference the transaction is tracked by: its UETR, or else its
+// message and end-to-end identifications
+func (t *Transaction) Ref() string {
+	if uetr := t.Payment.PaymentID.UETR; uetr != nil && *uetr != "" {
+		return *uetr
+	}
+	return t.MessageID + "/" + t.Payment.PaymentID.EndToEndID
+}
+
+// Update is a status reported for a tracked transaction
+type Update struct {
+	Transaction Transaction
+	Status      string
+	Reasons     []string // status reason codes
+	Final       bool     // the transaction is no longer tracked
+}
+
+// Correlator matches status reports to the transactions they answer. Tracker
+// implements it; other correlation engines can implement it to receive the
+// responses to the status requests of a Tracker as well.
+type Correlator interface {
+	Correlate(report iso20022.Pacs002) ([]Update, error)
+}
+
+// Option configures a Tracker
+type Option func(*Tracker)
+
+// WithTimeout sets how long a transaction waits for a final status before a status
+// request is sent for it, and between status requests; 30 minutes by default
+func WithTimeout(d time.Duration) Option {
+	return func(t *Tracker) {
+		t.timeout = d
+	}
+}
+
+// WithClock sets the function the tracker reads the time from, time.Now by default
+func WithClock(now func() time.Time) Option {
+	return func(t *Tracker) {
+		t.now = now
+	}
+}
+
+// WithForward passes every status report correlated by the tracker on to c, such
+// as the correlation engine of the sending application
+func WithForward(c Correlator) Option {
+	return func(t *Tracker) {
+		t.forward = c
+	}
+}
+
+// Tracker tracks sent pacs.008 transactions. It is safe for concurrent use.
+type Tracker struct {
+	mu      sync.Mutex
+	ids     iso20022.IDGenerator
+	timeout time.Duration
+	now     func() time.Time
+	forward Correlator
+	pending map[string]*Transaction
+	byE2E   map[string]string // message and end-to-end identifications to Ref
+}
+
+// New returns a tracker that takes the identifications of its status requests from ids
+func New(ids iso20022.IDGenerator, opts ...Option) *Tracker {
+	t := &Tracker{
+		ids:     ids,
+		timeout: 30 * time.Minute,
+		now:     time.Now,
+		pending: make(map[string]*Transaction),
+		byE2E:   make(map[string]string),
+	}
+	for _, opt := range opts {
+		opt(t)
+	}
+	return t
+}
+
+// Track starts tracking the transactions of a sent pacs.008
+func (t *Tracker) Track(doc *iso20022.Pacs00800108Document) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	hdr := &doc.FICustomerCreditTransfer.GroupHeader
+	now := t.now()
+	var added []*Transaction
+	for i := range doc.FICustomerCreditTransfer.CreditTransferTransactionInfo {
+		payment := doc.FICustomerCreditTransfer.CreditTransferTransactionInfo[i]
+		tx := &Transaction{
+			MessageID:        hdr.MessageID,
+			CreationDateTime: hdr.CreationDateTime,
+			Payment:          payment,
+			InstructingAgent: firstAgent(payment.InstructingAgent, hdr.InstructingAgent),
+			InstructedAgent:  firstAgent(payment.InstructedAgent, hdr.InstructedAgent),
+			SentAt:           now,
+		}
+		ref := tx.Ref()
+		if _, ok := t.pending[ref]; ok {
+			for _, tx := range added {
+				t.remove(tx)
+			}
+			return fmt.Errorf("%w: %s", ErrDuplicateTransaction, ref)
+		}
+		t.pending[ref] = tx
+		t.byE2E[hdr.MessageID+"/"+payment.PaymentID.EndToEndID] = ref
+		added = append(added, tx)
+	}
+	return nil
+}
+
+// Pending returns copies of the tracked transactions, oldest first
+func (t *Tracker) Pending() []Transaction {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	var out []Transaction
+	for _, tx := range t.sorted() {
+		out = append(out, *tx)
+	}
+	return out
+}
+
+// Due returns copies of the transactions a status request is due for: those sent,
+// or last requested, at least the timeout ago
+func (t *Tracker) Due() []Transaction {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	var out []Transaction
+	for _, tx := range t.due() {
+		out = append(out, *tx)
+	}
+	return out
+}
+
+// StatusRequests builds the pacs.028 messages for the transactions that are due,
+// one per pair of instructing and instructed agents, and records them as requested
+func (t *Tracker) StatusRequests() ([]*iso20022.Pacs02800103Document, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	groups := make(map[string][]Transaction)
+	var order []string
+	for _, tx := range t.due() {
+		key := agentKey(tx.InstructingAgent) + ">" + agentKey(tx.InstructedAgent)
+		if _, ok := groups[key]; !ok {
+			order = append(order, key)
+		}
+		groups[key] = append(groups[key], *tx)
+	}
+
+	now := t.now()
+	var docs []*iso20022.Pacs02800103Document
+	for _, key := range order {
+		msgID, err := t.ids.NextID()
+		if err != nil {
+			return nil, err
+		}
+		doc, err := NewStatusRequest(msgID, now, groups[key]...)
+		if err != nil {
+			return nil, err
+		}
+		docs = append(docs, doc)
+	}
+	// Only record the requests once they all could be built
+	for _, key := range order {
+		for _, tx := range groups[key] {
+			p := t.pending[tx.Ref()]
+			p.Requests++
+			p.RequestedAt = now
+		}
+	}
+	return docs, nil
+}
+
+// NewStatusRequest builds a pacs.028 asking for the status of the given transactions.
+// The agents of the first transaction become those of the message.
+func NewStatusRequest(msgID string, at time.Time, txs ...Transaction) (*iso20022.Pacs02800103Document, error) {
+	if len(txs) == 0 {
+		return nil, errors.New("tracker: no transactions to request the status of")
+	}
+	req := iso20022.FIToFIPaymentStatusRequestV03{
+		GroupHeader: iso20022.GroupHeader91{
+			MessageID:        msgID,
+			CreationDateTime: iso20022.NewISODateTime(at),
+			InstructingAgent: txs[0].InstructingAgent,
+			InstructedAgent:  txs[0].InstructedAgent,
+		},
+	}
+	for i := range txs {
+		tx := &txs[i]
+		p := &tx.Payment
+		ref := &iso20022.OriginalTransactionReference28{
+			InterbankSettlementAmount: &iso20022.ActiveOrHistoricCurrencyAndAmount{
+				Value:    p.InterbankSettlementAmount.Value,
+				Currency: p.InterbankSettlementAmount.Currency,
+			},
+			InterbankSettlementDate: p.InterbankSettlementDate,
+			DebtorAgent:             iso20022.Ptr(p.DebtorAgent),
+			CreditorAgent:           iso20022.Ptr(p.CreditorAgent),
+		}
+		statusReqID := fmt.Sprintf("%s-%d", msgID, i+1)
+		if len(txs) == 1 {
+			statusReqID = msgID
+		}
+		info := iso20022.PaymentTransaction113{
+			StatusRequestID: &statusReqID,
+			OriginalGroupInfo: &iso20022.OriginalGroupInformation29{
+				OriginalMessageID:        tx.MessageID,
+				OriginalMessageNameID:    pacs008,
+				OriginalCreationDateTime: tx.CreationDateTime,
+			},
+			OriginalInstructionID:        p.PaymentID.InstructionID,
+			OriginalEndToEndID:           &p.PaymentID.EndToEndID,
+			OriginalTransactionID:        p.PaymentID.TransactionID,
+			OriginalUETR:                 p.PaymentID.UETR,
+			AcceptanceDateTime:           p.AcceptanceDateTime,
+			ClearingSystemReference:      p.PaymentID.ClearingSystemReference,
+			OriginalTransactionReference: ref,
+		}
+		if agentKey(tx.InstructingAgent) != agentKey(req.GroupHeader.InstructingAgent) ||
+			agentKey(tx.InstructedAgent) != agentKey(req.GroupHeader.InstructedAgent) {
+			info.InstructingAgent, info.InstructedAgent = tx.InstructingAgent, tx.InstructedAgent
+		}
+		req.TransactionInfo = append(req.TransactionInfo, info)
+	}
+	return &iso20022.Pacs02800103Document{FIPaymentStatusRequest: req}, nil
+}
+
+// Correlate implements Correlator. It records the transaction statuses of a pacs.002
+// of any of the iso20022.Pacs002Versions and stops tracking the transactions that
+// reached a final status. A final group status applies to the transactions of the
+// original message without their own status. Statuses for unknown transactions are
+// reported as ErrUnknownTransaction after the others are recorded.
+func (t *Tracker) Correlate(report iso20022.Pacs002) ([]Update, error) {
+	updates, err := t.correlate(report)
+	if t.forward != nil {
+		if _, ferr := t.forward.Correlate(report); ferr != nil {
+			err = errors.Join(err, ferr)
+		}
+	}
+	return updates, err
+}
+
+func (t *Tracker) correlate(report iso20022.Pacs002) ([]Update, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var updates []Update
+	var errs []error
+	answered := make(map[string]bool)
+	for _, status := range report.TransactionStatuses() {
+		tx := t.lookup(status.OriginalUETR, status.OriginalMessageID, status.OriginalEndToEndID)
+		if tx == nil {
+			errs = append(errs, fmt.Errorf("%w: %s", ErrUnknownTransaction, describe(status)))
+			continue
+		}
+		answered[tx.Ref()] = true
+		if status.Status == "" {
+			continue
+		}
+		updates = append(updates, t.update(tx, status.Status, status.Reasons))
+	}
+
+	for _, grp := range report.GroupStatuses() {
+		if !IsFinal(grp.Status) {
+			continue
+		}
+		for _, tx := range t.sorted() {
+			if tx.MessageID == grp.OriginalMessageID && !answered[tx.Ref()] {
+				updates = append(updates, t.update(tx, grp.Status, grp.Reasons))
+			}
+		}
+	}
+	return updates, errors.Join(errs...)
+}
+
+// update records a status for a tracked transaction
+func (t *Tracker) update(tx *Transaction, status string, reasons []string) Update {
+	tx.Status = status
+	u := Update{Transaction: *tx, Status: status, Reasons: reasons, Final: IsFinal(status)}
+	if u.Final {
+		t.remove(tx)
+	}
+	return u
+}
+
+// lookup finds a tracked transaction by UETR, or else by message and end-to-end
+// identifications
+func (t *Tracker) lookup(uetr, msgID, endToEndID string) *Transaction {
+	if uetr != "" {
+		if tx, ok := t.pending[uetr]; ok {
+			return tx
+		}
+	}
+	if endToEndID != "" && msgID != "" {
+		if ref, ok := t.byE2E[msgID+"/"+endToEndID]; ok {
+			return t.pending[ref]
+		}
+	}
+	return nil
+}
+
+func (t *Tracker) remove(tx *Transaction) {
+	delete(t.pending, tx.Ref())
+	delete(t.byE2E, tx.MessageID+"/"+tx.Payment.PaymentID.EndToEndID)
+}
+
+// due returns the transactions a status request is due for, oldest first
+func (t *Tracker) due() []*Transaction {
+	now := t.now()
+	var out []*Transaction
+	for _, tx := range t.sorted() {
+		last := tx.SentAt
+		if tx.Requests > 0 {
+			last = tx.RequestedAt
+		}
+		if !now.Before(last.Add(t.timeout)) {
+			out = append(out, tx)
+		}
+	}
+	return out
+}
+
+// sorted returns the tracked transactions, oldest first
+func (t *Tracker) sorted() []*Transaction {
+	out := make([]*Transaction, 0, len(t.pending))
+	for _, tx := range t.pending {
+		out = append(out, tx)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if !out[i].SentAt.Equal(out[j].SentAt) {
+			return out[i].SentAt.Before(out[j].SentAt)
+		}
+		return out[i].Ref() < out[j].Ref()
+	})
+	return out
+}
+
+func firstAgent(agents ...*iso20022.BranchAndFinancialInstitutionIdentification6) *iso20022.BranchAndFinancialInstitutionIdentification6 {
+	for _, a := range agents {
+		if a != nil {
+			return a
+		}
+	}
+	return nil
+}
+
+// agentKey identifies an agent by BIC, clearing system member or name
+func agentKey(a *iso20022.BranchAndFinancialInstitutionIdentification6) string {
+	if a == nil {
+		return ""
+	}
+	id := &a.FinancialInstitutionID
+	switch {
+	case id.BankIdentifierCode != nil:
+		return *id.BankIdentifierCode
+	case id.ClearingSystemMemberID != nil:
+		return id.ClearingSystemMemberID.MemberID
+	case id.LegalEntityIdentifier != nil:
+		return *id.LegalEntityIdentifier
+	case id.Name != nil:
+		return *id.Name
+	}
+	return ""
+}
+
+func describe(status iso20022.Pacs002TransactionStatus) string {
+	if status.OriginalUETR != "" {
+		return status.OriginalUETR
+	}
+	if status.OriginalEndToEndID != "" {
+		return status.OriginalMessageID + "/" + status.OriginalEndToEndID
+	}
+	return status.OriginalMessageID
+}
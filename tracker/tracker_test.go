@@ -0,0 +1,146 @@
+package tracker
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ckbaum/iso20022-go"
+	"github.com/ckbaum/iso20022-go/samples"
+)
+
+type sequence struct{ n int }
+
+func (s *sequence) NextID() (string, error) {
+	s.n++
+	return "STSREQ-" + string(rune('0'+s.n)), nil
+}
+
+type recorder struct{ reports int }
+
+func (r *recorder) Correlate(iso20022.Pacs002) ([]Update, error) {
+	r.reports++
+	return nil, nil
+}
+
+func TestStatusRequestAfterTimeout(t *testing.T) {
+	now := time.Date(2024, 3, 15, 9, 30, 0, 0, time.UTC)
+	engine := &recorder{}
+	tr := New(&sequence{}, WithTimeout(10*time.Minute), WithClock(func() time.Time { return now }), WithForward(engine))
+
+	sent := samples.SamplePacs008(samples.WithTransactions(1))
+	tx := sent.FICustomerCreditTransfer.CreditTransferTransactionInfo[0]
+	if err := tr.Track(sent); err != nil {
+		t.Fatalf("Track failed: %v", err)
+	}
+	if err := tr.Track(sent); !errors.Is(err, ErrDuplicateTransaction) {
+		t.Errorf("Expected ErrDuplicateTransaction, got %v", err)
+	}
+
+	now = now.Add(9 * time.Minute)
+	if docs, err := tr.StatusRequests(); err != nil || len(docs) != 0 {
+		t.Fatalf("Expected no status request before the timeout, got %d, %v", len(docs), err)
+	}
+
+	now = now.Add(time.Minute)
+	docs, err := tr.StatusRequests()
+	if err != nil || len(docs) != 1 {
+		t.Fatalf("Expected one status request, got %d, %v", len(docs), err)
+	}
+	req := docs[0].FIPaymentStatusRequest
+	creditorAgent := *tx.CreditorAgent.FinancialInstitutionID.BankIdentifierCode
+	if req.GroupHeader.MessageID != "STSREQ-1" || *req.GroupHeader.InstructedAgent.FinancialInstitutionID.BankIdentifierCode != creditorAgent {
+		t.Errorf("Unexpected group header %+v", req.GroupHeader)
+	}
+	info := req.TransactionInfo[0]
+	if *info.OriginalUETR != *tx.PaymentID.UETR || *info.OriginalEndToEndID != tx.PaymentID.EndToEndID ||
+		info.OriginalGroupInfo.OriginalMessageID != sent.FICustomerCreditTransfer.GroupHeader.MessageID || info.OriginalGroupInfo.OriginalMessageNameID != "pacs.008.001.08" {
+		t.Errorf("Unexpected original references %+v", info)
+	}
+	if info.InstructedAgent != nil || info.OriginalTransactionReference.InterbankSettlementAmount.Currency != tx.InterbankSettlementAmount.Currency {
+		t.Errorf("Unexpected transaction details %+v", info)
+	}
+	data, err := iso20022.Marshal(docs[0])
+	if err != nil || !strings.Contains(string(data), "<OrgnlUETR>"+*tx.PaymentID.UETR+"</OrgnlUETR>") {
+		t.Errorf("Unexpected pacs.028 %s, %v", data, err)
+	}
+
+	// The next request waits for another timeout
+	if docs, _ := tr.StatusRequests(); len(docs) != 0 {
+		t.Errorf("Expected no repeated request, got %d", len(docs))
+	}
+	if pending := tr.Pending(); len(pending) != 1 || pending[0].Requests != 1 {
+		t.Fatalf("Expected one requested transaction, got %+v", pending)
+	}
+
+	report := samples.SamplePacs002(samples.WithTransactions(1))
+	updates, err := tr.Correlate(report)
+	if err != nil || len(updates) != 1 {
+		t.Fatalf("Expected one update, got %+v, %v", updates, err)
+	}
+	if u := updates[0]; u.Status != "ACSC" || !u.Final || len(u.Reasons) != 0 {
+		t.Errorf("Unexpected update %+v", u)
+	}
+	if len(tr.Pending()) != 0 || engine.reports != 1 {
+		t.Errorf("Expected the transaction to be settled and the report forwarded")
+	}
+
+	if _, err := tr.Correlate(report); !errors.Is(err, ErrUnknownTransaction) {
+		t.Errorf("Expected ErrUnknownTransaction, got %v", err)
+	}
+}
+
+func TestCorrelateGroupStatus(t *testing.T) {
+	tr := New(&sequence{})
+	sent := samples.SamplePacs008(samples.WithTransactions(1))
+	sent.FICustomerCreditTransfer.CreditTransferTransactionInfo[0].PaymentID.UETR = nil
+	msgID, endToEndID := sent.FICustomerCreditTransfer.GroupHeader.MessageID, sent.FICustomerCreditTransfer.CreditTransferTransactionInfo[0].PaymentID.EndToEndID
+	if err := tr.Track(sent); err != nil {
+		t.Fatalf("Track failed: %v", err)
+	}
+
+	pending := "ACSP"
+	accepted := "ACSC"
+	report := &iso20022.Pacs00200110Document{}
+	report.FIPaymentStatusReport.OriginalGroupInformationAndStatus = []iso20022.OriginalGroupHeader17{
+		{OriginalMessageID: msgID, OriginalMessageNameID: "pacs.008.001.08", GroupStatus: &pending},
+	}
+	if updates, err := tr.Correlate(report); err != nil || len(updates) != 0 {
+		t.Fatalf("Expected no update for an interim group status, got %+v, %v", updates, err)
+	}
+
+	report.FIPaymentStatusReport.OriginalGroupInformationAndStatus[0].GroupStatus = &accepted
+	updates, err := tr.Correlate(report)
+	if err != nil || len(updates) != 1 || !updates[0].Final || updates[0].Transaction.Ref() != msgID+"/"+endToEndID {
+		t.Fatalf("Expected the group status to settle the transaction, got %+v, %v", updates, err)
+	}
+}
+
+func TestCorrelatePacs00200103(t *testing.T) {
+	tr := New(&sequence{})
+	sent := samples.SamplePacs008(samples.WithTransactions(1))
+	if err := tr.Track(sent); err != nil {
+		t.Fatalf("Track failed: %v", err)
+	}
+
+	// The report has no UETR, so the transaction is found by its end-to-end
+	// identification
+	report := &iso20022.Pacs00200103Document{}
+	report.FIPaymentStatusReport.OriginalGroupInformationAndStatus = iso20022.OriginalGroupInformation20{
+		OriginalMessageID:     sent.FICustomerCreditTransfer.GroupHeader.MessageID,
+		OriginalMessageNameID: "pacs.008.001.08",
+	}
+	report.FIPaymentStatusReport.TransactionInfoAndStatus = []iso20022.PaymentTransactionInformation26{{
+		OriginalEndToEndID: iso20022.Ptr(sent.FICustomerCreditTransfer.CreditTransferTransactionInfo[0].PaymentID.EndToEndID),
+		TransactionStatus:  iso20022.Ptr("RJCT"),
+		StatusReasonInfo:   []iso20022.StatusReasonInformation8{{Reason: &iso20022.StatusReason62{Code: iso20022.Ptr("AC04")}}},
+	}}
+	updates, err := tr.Correlate(report)
+	if err != nil || len(updates) != 1 {
+		t.Fatalf("Expected one update, got %+v, %v", updates, err)
+	}
+	if u := updates[0]; u.Status != "RJCT" || !u.Final || len(u.Reasons) != 1 || u.Reasons[0] != "AC04" {
+		t.Errorf("Unexpected update %+v", u)
+	}
+}
@@ -0,0 +1,212 @@
+package iso20022
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Transform turns a pain.001 into the pacs.008 messages its debtor agent sends on,
+// the transformation the debtor agent makes before forwarding the payments.
+// Transactions are grouped into one pacs.008 per requested execution date, debtor
+// agent and currency, in the order they first appear; the execution date becomes
+// the interbank settlement date of the group. Each part is identified by the
+// identification of the pain.001 suffixed with its number (MSG-1, MSG-2, ...), as
+// Split does, unless there is only one.
+//
+// A transaction keeps its identifications, amount, parties, agents, purpose,
+// regulatory reporting, tax and remittance information, and takes the payment type
+// information, ultimate debtor and charge bearer of its payment information block
+// when it has none of its own; the charge bearer defaults to SHAR. The debtor agent
+// is the instructing agent and the first intermediary agent, or else the creditor
+// agent, the instructed agent. Settlement is INDA, through the accounts the debtor
+// agent holds with the instructed agent; set SttlmInf for other routes.
+//
+// Payment information blocks paying by cheque, and transactions without a creditor
+// or creditor agent or with an equivalent amount, have no pacs.008 equivalent and
+// return an error.
+func Transform(doc *Pain00100109Document) ([]*Pacs00800108Document, error) {
+	msg := &doc.CustomerCreditTransferInitiation
+	type group struct {
+		date     ISODate
+		currency string
+		txs      []CreditTransferTransaction39
+	}
+	var groups []*group
+	index := make(map[string]*group)
+	for i, pmt := range msg.PaymentInfo {
+		if pmt.PaymentMethod == "CHK" {
+			return nil, fmt.Errorf("payment information %d (%s) pays by cheque", i+1, pmt.PaymentInfoID)
+		}
+		date, err := executionDate(pmt.RequestedExecutionDate)
+		if err != nil {
+			return nil, fmt.Errorf("payment information %d (%s): %w", i+1, pmt.PaymentInfoID, err)
+		}
+		for j, tx := range pmt.CreditTransferTransactionInfo {
+			out, err := transformTransaction(&msg.GroupHeader, &pmt, &tx)
+			if err != nil {
+				return nil, fmt.Errorf("payment information %d (%s), transaction %d (%s): %w", i+1, pmt.PaymentInfoID, j+1, tx.PaymentID.EndToEndID, err)
+			}
+			currency := out.InterbankSettlementAmount.Currency
+			key := date.Format(isoDateLayout) + "/" + agentKey(pmt.DebtorAgent) + "/" + currency
+			g, ok := index[key]
+			if !ok {
+				g = &group{date: date, currency: currency}
+				index[key] = g
+				groups = append(groups, g)
+			}
+			g.txs = append(g.txs, out)
+		}
+	}
+
+	base := msg.GroupHeader.MessageID
+	if suffixLength := len(strconv.Itoa(len(groups))) + 1; len(groups) > 1 && len(base)+suffixLength > maxMessageIDLength {
+		base = base[:maxMessageIDLength-suffixLength]
+	}
+	created := NewISODateTime(time.Now())
+	docs := make([]*Pacs00800108Document, 0, len(groups))
+	for i, g := range groups {
+		date, sum := g.date, Decimal(0)
+		part := &Pacs00800108Document{FICustomerCreditTransfer: FIToFICustomerCreditTransferV08{
+			GroupHeader: GroupHeader93{
+				MessageID:                      base,
+				CreationDateTime:               &created,
+				ControlSum:                     &sum,
+				TotalInterbankSettlementAmount: &ActiveCurrencyAndAmount{Currency: g.currency},
+				InterbankSettlementDate:        &date,
+				SettlementInfo:                 SettlementInstruction7{SettlementMethod: "INDA"},
+			},
+			CreditTransferTransactionInfo: g.txs,
+		}}
+		if len(groups) > 1 {
+			part.FICustomerCreditTransfer.GroupHeader.MessageID = fmt.Sprintf("%s-%d", base, i+1)
+		}
+		part.FICustomerCreditTransfer.recomputeTotals()
+		docs = append(docs, part)
+	}
+	return docs, nil
+}
+
+// transformTransaction returns the pacs.008 transaction of a pain.001 transaction
+func transformTransaction(hdr *GroupHeader85, pmt *PaymentInstruction30, tx *CreditTransferTransaction34) (CreditTransferTransaction39, error) {
+	if tx.Amount.InstructedAmount == nil {
+		return CreditTransferTransaction39{}, errors.New("only instructed amounts can be settled, the equivalent amount needs a currency conversion")
+	}
+	if tx.Creditor == nil || tx.CreditorAgent == nil {
+		return CreditTransferTransaction39{}, errors.New("the creditor and creditor agent are required")
+	}
+	instructed := *tx.Amount.InstructedAmount
+	chargeBearer := ChargeBearerSHAR
+	switch {
+	case tx.ChargeBearer != nil:
+		chargeBearer = *tx.ChargeBearer
+	case pmt.ChargeBearer != nil:
+		chargeBearer = *pmt.ChargeBearer
+	}
+	paymentType := tx.PaymentTypeInfo
+	if paymentType == nil {
+		paymentType = pmt.PaymentTypeInfo
+	}
+	ultimateDebtor := tx.UltimateDebtor
+	if ultimateDebtor == nil {
+		ultimateDebtor = pmt.UltimateDebtor
+	}
+	debtorAgent := pmt.DebtorAgent
+	instructedAgent := tx.CreditorAgent
+	if tx.IntermediaryAgent1 != nil {
+		instructedAgent = tx.IntermediaryAgent1
+	}
+	debtorAccount := pmt.DebtorAccount
+
+	out := CreditTransferTransaction39{
+		PaymentID: PaymentIdentification7{
+			InstructionID: tx.PaymentID.InstructionID,
+			EndToEndID:    tx.PaymentID.EndToEndID,
+			UETR:          tx.PaymentID.UETR,
+		},
+		PaymentTypeInfo:              paymentTypeInfo(paymentType),
+		InterbankSettlementAmount:    ActiveCurrencyAndAmount{Value: instructed.Value, Currency: instructed.Currency},
+		InstructedAmount:             &instructed,
+		ChargeBearer:                 chargeBearer,
+		InstructingAgent:             &debtorAgent,
+		InstructedAgent:              instructedAgent,
+		IntermediaryAgent1:           tx.IntermediaryAgent1,
+		IntermediaryAgent1Account:    tx.IntermediaryAgent1Account,
+		IntermediaryAgent2:           tx.IntermediaryAgent2,
+		IntermediaryAgent2Account:    tx.IntermediaryAgent2Account,
+		IntermediaryAgent3:           tx.IntermediaryAgent3,
+		IntermediaryAgent3Account:    tx.IntermediaryAgent3Account,
+		UltimateDebtor:               ultimateDebtor,
+		Debtor:                       pmt.Debtor,
+		DebtorAccount:                &debtorAccount,
+		DebtorAgent:                  debtorAgent,
+		DebtorAgentAccount:           pmt.DebtorAgentAccount,
+		CreditorAgent:                *tx.CreditorAgent,
+		CreditorAgentAccount:         tx.CreditorAgentAccount,
+		Creditor:                     *tx.Creditor,
+		CreditorAccount:              tx.CreditorAccount,
+		UltimateCreditor:             tx.UltimateCreditor,
+		InstructionsForCreditorAgent: tx.InstructionsForCreditorAgent,
+		Purpose:                      tx.Purpose,
+		RegulatoryReporting:          tx.RegulatoryReporting,
+		Tax:                          tx.Tax,
+		RelatedRemittanceInfo:        tx.RelatedRemittanceInfo,
+		RemittanceInfo:               tx.RemittanceInfo,
+		SupplementaryData:            tx.SupplementaryData,
+	}
+	if initiator := hdr.InitiatingParty; initiator.Name != nil || initiator.ID != nil {
+		out.InitiatingParty = &initiator
+	}
+	return out, nil
+}
+
+// paymentTypeInfo returns the pacs.008 payment type information of a pain.001 one
+func paymentTypeInfo(p *PaymentTypeInformation26) *PaymentTypeInfo28 {
+	if p == nil {
+		return nil
+	}
+	out := &PaymentTypeInfo28{}
+	if p.InstructionPriority != nil {
+		priority := *p.InstructionPriority
+		out.InstructionPriority = &priority
+	}
+	for _, level := range p.ServiceLevel {
+		out.ServiceLevel = append(out.ServiceLevel, ServiceLevel{Code: level.Code, Proprietary: level.Proprietary})
+	}
+	if l := p.LocalInstrument; l != nil {
+		out.LocalInstrument = &LocalInstrument{Code: l.Code, Proprietary: l.Proprietary}
+	}
+	if c := p.CategoryPurpose; c != nil {
+		out.CategoryPurpose = &CategoryPurpose{Code: c.Code, Proprietary: c.Proprietary}
+	}
+	return out
+}
+
+// executionDate returns the requested execution date of a payment information
+// block, the date of its date and time in its own offset when it has one
+func executionDate(d DateAndDateTime2) (ISODate, error) {
+	switch {
+	case d.Date != nil:
+		return *d.Date, nil
+	case d.DateTime != nil:
+		t := d.DateTime.Time
+		return NewISODate(t.Year(), t.Month(), t.Day()), nil
+	}
+	return ISODate{}, errors.New("no requested execution date")
+}
+
+// agentKey returns a key identifying an agent by its BIC, clearing system member
+// identification or LEI
+func agentKey(agent BranchAndFinancialInstitutionIdentification6) string {
+	id := agent.FinancialInstitutionID
+	switch {
+	case id.BankIdentifierCode != nil:
+		return *id.BankIdentifierCode
+	case id.ClearingSystemMemberID != nil:
+		return id.ClearingSystemMemberID.MemberID
+	case id.LegalEntityIdentifier != nil:
+		return *id.LegalEntityIdentifier
+	}
+	return ""
+}
@@ -0,0 +1,78 @@
+package iso20022
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTransform(t *testing.T) {
+	pain := loadSample[Pain00100109Document](t, "pain.001.001.09/credit_transfer_initiation.xml")
+	if err := pain.Validate(); err != nil {
+		t.Fatalf("Expected the sample to be valid, got %v", err)
+	}
+	docs, err := Transform(pain)
+	if err != nil {
+		t.Fatalf("Transform failed: %v", err)
+	}
+	if len(docs) != 2 {
+		t.Fatalf("Expected a pacs.008 per execution date, got %d", len(docs))
+	}
+	for i, doc := range docs {
+		if err := doc.Validate(); err != nil {
+			t.Errorf("Message %d is invalid: %v", i+1, err)
+		}
+		if err := doc.ValidateBusinessRules(); err != nil {
+			t.Errorf("Message %d breaks the business rules: %v", i+1, err)
+		}
+	}
+
+	hdr := docs[0].FICustomerCreditTransfer.GroupHeader
+	if hdr.MessageID != "ACME-PAY-20240314-01-1" || hdr.NumberOfTransactions != "2" || *hdr.ControlSum != 17000 ||
+		hdr.InterbankSettlementDate.String() != "2024-03-15" {
+		t.Errorf("Unexpected group header %+v", hdr)
+	}
+	txs := docs[0].FICustomerCreditTransfer.CreditTransferTransactionInfo
+	first := txs[0]
+	if first.ChargeBearer != "SHAR" || *first.PaymentTypeInfo.ServiceLevel[0].Code != "G001" {
+		t.Errorf("Expected the charge bearer and payment type of the payment information, got %s, %+v", first.ChargeBearer, first.PaymentTypeInfo)
+	}
+	if *first.InstructingAgent.FinancialInstitutionID.BankIdentifierCode != "BBBBUS33" || *first.InstructedAgent.FinancialInstitutionID.BankIdentifierCode != "CCCCGB2L" {
+		t.Errorf("Expected the debtor agent to instruct the creditor agent, got %+v, %+v", first.InstructingAgent, first.InstructedAgent)
+	}
+	if len(first.RegulatoryReporting) != 1 || *first.RemittanceInfo.Structured[0].ReferredDocumentInfo[0].Number != "INV-2024-0042" {
+		t.Errorf("Expected regulatory reporting and remittance information to be carried forward, got %+v", first)
+	}
+	if *first.InitiatingParty.Name != "Acme Manufacturing Inc" || first.DebtorAccount.ID.Other.ID != "123456789012" {
+		t.Errorf("Unexpected initiating party or debtor account: %+v, %+v", first.InitiatingParty, first.DebtorAccount)
+	}
+	if txs[1].ChargeBearer != "DEBT" {
+		t.Errorf("Expected the charge bearer of the transaction, got %s", txs[1].ChargeBearer)
+	}
+
+	second := docs[1].FICustomerCreditTransfer
+	if second.GroupHeader.MessageID != "ACME-PAY-20240314-01-2" || second.GroupHeader.InterbankSettlementDate.String() != "2024-03-18" {
+		t.Errorf("Unexpected group header %+v", second.GroupHeader)
+	}
+	if tx := second.CreditTransferTransactionInfo[0]; *tx.UltimateDebtor.Name != "Acme Services LLC" || tx.PaymentTypeInfo != nil {
+		t.Errorf("Expected the ultimate debtor of the payment information, got %+v", tx)
+	}
+
+	pain.CustomerCreditTransferInitiation.PaymentInfo = pain.CustomerCreditTransferInitiation.PaymentInfo[1:]
+	docs, err = Transform(pain)
+	if err != nil {
+		t.Fatalf("Transform failed: %v", err)
+	}
+	if len(docs) != 1 || docs[0].FICustomerCreditTransfer.GroupHeader.MessageID != "ACME-PAY-20240314-01" {
+		t.Errorf("Expected a single message keeping the identification, got %d", len(docs))
+	}
+
+	tx := &pain.CustomerCreditTransferInitiation.PaymentInfo[0].CreditTransferTransactionInfo[0]
+	tx.Amount = AmountType4{EquivalentAmount: &EquivalentAmount2{Amount: ActiveOrHistoricCurrencyAndAmount{Value: 1000, Currency: "EUR"}, CurrencyOfTransfer: "USD"}}
+	if _, err := Transform(pain); err == nil || !strings.Contains(err.Error(), "RENT-2024-03") {
+		t.Errorf("Expected an error for the equivalent amount, got %v", err)
+	}
+	pain.CustomerCreditTransferInitiation.PaymentInfo[0].PaymentMethod = "CHK"
+	if _, err := Transform(pain); err == nil || !strings.Contains(err.Error(), "cheque") {
+		t.Errorf("Expected an error for a cheque, got %v", err)
+	}
+}
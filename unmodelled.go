@@ -0,0 +1,41 @@
+package iso20022
+
+import "fmt"
+
+// unmodelledRecorder is a message whose type does not have a field for every
+// element of its version. Unmarshal records on it the elements it could not decode.
+type unmodelledRecorder interface {
+	setUnmodelled(paths []string)
+}
+
+// recordUnmodelled records on v, when it is an unmodelledRecorder, the paths of the
+// elements of data that encoding/xml left out: those its type has no field for and
+// the repetitions of those it has a single value for
+func recordUnmodelled(data []byte, v interface{}) error {
+	r, ok := v.(unmodelledRecorder)
+	if !ok {
+		return nil
+	}
+	var paths []string
+	err := walkUnexpected(data, v, func(e *UnexpectedElementError) bool {
+		if e.Problem != "out of order" {
+			paths = append(paths, e.Path)
+		}
+		return true
+	})
+	r.setUnmodelled(paths)
+	return err
+}
+
+// unmodelledLoss returns an error wrapping ErrVersionLoss that names the first
+// element doc could not decode, or nil when it decoded all of them
+func unmodelledLoss(doc interface{}) error {
+	u, ok := doc.(interface{ Unmodelled() []string })
+	if !ok {
+		return nil
+	}
+	if paths := u.Unmodelled(); len(paths) > 0 {
+		return fmt.Errorf("%w: %s was not decoded", ErrVersionLoss, paths[0])
+	}
+	return nil
+}
@@ -0,0 +1,329 @@
+package iso20022
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Validator is implemented by every document and component type
+type Validator interface {
+	Validate() error
+}
+
+// Identifiers of the checks of Validate and ValidateBusinessRules, carried in the
+// Rule of the errors they report. Checks without an identifier cannot be skipped or
+// downgraded.
+const (
+	RuleRequired         = "REQUIRED"           // a mandatory element is missing or empty
+	RuleLength           = "LENGTH"             // a text is shorter or longer than its type allows
+	RulePattern          = "PATTERN"            // a text does not match the pattern of its type
+	RuleCode             = "CODE"               // a code is not in its code set
+	RuleDate             = "DATE"               // a date or date time is malformed
+	RuleOccurrences      = "OCCURRENCES"        // a repeating element occurs too few or too many times
+	RuleChoice           = "CHOICE"             // a choice has no element or more than one
+	RuleIBANLength       = "IBAN_LENGTH"        // an IBAN has the wrong length for its country
+	RuleIBANChecksum     = "IBAN_CHECKSUM"      // the check digits of an IBAN are invalid
+	RuleLEIChecksum      = "LEI_CHECKSUM"       // the check digits of an LEI are invalid
+	RuleBICDirectory     = "BIC_DIRECTORY"      // a BIC is not an active entry of the configured BIC directory
+	RuleLEIRegistry      = "LEI_REGISTRY"       // an LEI is not a usable entry of the configured LEI registry
+	RuleClearingMemberID = "CLEARING_MEMBER_ID" // a clearing system member identifier is invalid
+	RuleCurrency         = "CURRENCY"           // a currency is unknown or withdrawn where an active one is required
+	RuleMinorUnits       = "MINOR_UNITS"        // an amount has more decimal places than its currency
+	RuleProxy            = "PROXY"              // a proxy does not have the format of its type
+	RuleRegulatory       = "REGULATORY"         // a regulatory reporting code is unknown or a required report is missing
+	RuleAmountLimit      = "AMOUNT_LIMIT"       // an amount exceeds the limit of a profile
+
+	// Business rules of pacs.008
+	RuleNumberOfTransactions = "NB_OF_TXS"         // GroupHeaderNumberOfTransactionsRule
+	RuleControlSum           = "CONTROL_SUM"       // ControlSumRule and TotalInterbankSettlementAmountRule
+	RuleSettlementDate       = "SETTLEMENT_DATE"   // GroupHeaderInterbankSettlementDateRule
+	RuleGroupLevel           = "GROUP_LEVEL"       // elements given both for the group and a transaction
+	RuleChargeBearer         = "CHARGE_BEARER"     // ChargeBearerAndChargesInformationRule
+	RuleCharges              = "CHARGES"           // charges that do not reconcile with the amounts of a transaction
+	RuleExchangeRate         = "EXCHANGE_RATE"     // InstructedAmountAndExchangeRateRule and the rate itself
+	RuleAgentChain           = "AGENT_CHAIN"       // agents and agent accounts given out of order
+	RuleSettlementMethod     = "SETTLEMENT_METHOD" // the settlement method and reimbursement agent rules
+
+	// RuleSettlementCalendar reports interbank settlement dates that are closing
+	// days of the calendar of the settlement currency. Validate reports it as a
+	// warning unless WithSeverity says otherwise.
+	RuleSettlementCalendar = "SETTLEMENT_CALENDAR"
+)
+
+// Severity is the severity Validate reports a finding with
+type Severity int
+
+const (
+	SeverityError Severity = iota
+	SeverityWarning
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityError:
+		return "error"
+	case SeverityWarning:
+		return "warning"
+	}
+	return fmt.Sprintf("Severity(%d)", int(s))
+}
+
+// ValidationReport is the outcome of Validate, with the findings split by severity
+type ValidationReport struct {
+	Errors   ValidationErrors
+	Warnings ValidationErrors
+}
+
+// Valid reports whether the document has no errors. It may have warnings.
+func (r *ValidationReport) Valid() bool {
+	return !r.Errors.HasErrors()
+}
+
+// Err returns the errors of the report, or nil when it has none
+func (r *ValidationReport) Err() error {
+	if r.Errors.HasErrors() {
+		return r.Errors
+	}
+	return nil
+}
+
+// ValidateOption changes how Validate reports the findings
+type ValidateOption func(*validateOptions)
+
+type validateOptions struct {
+	severities map[string]Severity
+	skipped    map[string]bool
+	failFast   bool
+}
+
+// WithSeverity reports the findings of the rules with severity s, for instance
+// SeverityWarning to accept documents that break them
+func WithSeverity(s Severity, rules ...string) ValidateOption {
+	return func(o *validateOptions) {
+		for _, rule := range rules {
+			o.severities[rule] = s
+		}
+	}
+}
+
+// SkipRules leaves the findings of the rules out of the report
+func SkipRules(rules ...string) ValidateOption {
+	return func(o *validateOptions) {
+		for _, rule := range rules {
+			o.skipped[rule] = true
+		}
+	}
+}
+
+// FailFast stops the validation at the first error. The warnings found until then
+// are reported with it.
+func FailFast() ValidateOption {
+	return func(o *validateOptions) {
+		o.failFast = true
+	}
+}
+
+// businessRuleValidator is implemented by the documents with cross-element rules
+type businessRuleValidator interface {
+	ValidateBusinessRules() error
+}
+
+// Validate validates doc and, for the documents that have them, checks its business
+// rules and its settlement dates against the calendars, which are only checked when
+// the document has no errors. The findings are
+// reported as errors or warnings according to the options; the error is only set
+// when ctx is done or a check fails other than with validation errors. doc is
+// validated as by ValidateContext, so the validation stops as soon as ctx is done.
+func Validate(ctx context.Context, doc Validator, opts ...ValidateOption) (*ValidationReport, error) {
+	ob := currentObserver()
+	if ob == nil {
+		return validate(ctx, doc, opts...)
+	}
+	start := time.Now()
+	report, err := validate(ctx, doc, opts...)
+	ob.Observe(ctx, Observation{
+		Operation:   OperationValidate,
+		MessageType: documentMessageType(doc),
+		Start:       start,
+		Duration:    time.Since(start),
+		Err:         err,
+		Report:      report,
+	})
+	return report, err
+}
+
+func validate(ctx context.Context, doc Validator, opts ...ValidateOption) (*ValidationReport, error) {
+	o := validateOptions{severities: map[string]Severity{RuleSettlementCalendar: SeverityWarning}, skipped: map[string]bool{}}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	checks := []func() error{func() error { return ValidateContext(ctx, doc) }}
+	if r, ok := doc.(businessRuleValidator); ok {
+		checks = append(checks, r.ValidateBusinessRules)
+	}
+	if c, ok := doc.(settlementCalendarValidator); ok {
+		checks = append(checks, func() error { return c.validateSettlementCalendars() })
+	}
+
+	report := &ValidationReport{}
+	for _, check := range checks {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if report.Errors.HasErrors() {
+			break
+		}
+		var errs ValidationErrors
+		switch err := check().(type) {
+		case nil:
+		case ValidationErrors:
+			errs = err
+		case ValidationError:
+			errs = ValidationErrors{err}
+		default:
+			return nil, err
+		}
+		for _, e := range errs {
+			if o.skipped[e.Rule] {
+				continue
+			}
+			if o.severities[e.Rule] == SeverityWarning {
+				report.Warnings = append(report.Warnings, e)
+				continue
+			}
+			report.Errors = append(report.Errors, e)
+			if o.failFast {
+				return report, nil
+			}
+		}
+	}
+	return report, nil
+}
+
+// contextValidator is implemented by the documents and components whose validation
+// walks into others or consults a resolver, to pass the context down the walk
+type contextValidator interface {
+	validateContext(ctx context.Context) error
+}
+
+// ValidateContext validates doc as doc.Validate does, checking ctx at every
+// component of the walk and passing it to the resolvers that take one, such as a
+// BICContextResolver, so that the validation of a large document or one held up by
+// a slow directory can be cancelled or given a deadline. Once ctx is done it
+// returns ctx.Err() rather than the errors found until then.
+func ValidateContext(ctx context.Context, doc Validator) error {
+	var err error
+	if v, ok := doc.(contextValidator); ok {
+		err = v.validateContext(ctx)
+	} else if err = ctx.Err(); err == nil {
+		err = doc.Validate()
+	}
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return ctxErr
+	}
+	return err
+}
+
+// ValidateXML unmarshals data into doc and validates it. Validation errors are
+// returned as ValidationErrors whose Line and Column point at the offending
+// element in data, so that errors can be located in large files. An error in an
+// attribute or in an element's value points at the element; an error for a missing
+// element points at the closest enclosing element that is present.
+func ValidateXML(data []byte, doc Validator) error {
+	if err := Unmarshal(data, doc); err != nil {
+		return err
+	}
+	err := doc.Validate()
+	if err == nil {
+		return nil
+	}
+	errs, ok := err.(ValidationErrors)
+	if !ok {
+		return err
+	}
+
+	positions, err := elementPositions(data)
+	if err != nil {
+		return err
+	}
+	located := make(ValidationErrors, len(errs))
+	for i, e := range errs {
+		e.Line, e.Column = positions.locate(e.Location())
+		located[i] = e
+	}
+	return located
+}
+
+// sourcePosition is the line and column of an element's start tag
+type sourcePosition struct {
+	line, column int
+}
+
+// positionIndex maps element paths below the document element, with every step
+// indexed (e.g. FIToFICstmrCdtTrf[1]/CdtTrfTxInf[3]), to their start tags. The
+// empty path is the document element itself.
+type positionIndex map[string]sourcePosition
+
+// elementPositions records the position of every element in data
+func elementPositions(data []byte) (positionIndex, error) {
+	type frame struct {
+		path     string
+		children map[string]int
+	}
+
+	index := make(positionIndex)
+	dec := xml.NewDecoder(bytes.NewReader(data))
+	var stack []frame
+	for {
+		line, column := dec.InputPos()
+		tok, err := dec.Token()
+		if err == io.EOF {
+			return index, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch tok := tok.(type) {
+		case xml.StartElement:
+			path := ""
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent.children[tok.Name.Local]++
+				path = fmt.Sprintf("%s[%d]", tok.Name.Local, parent.children[tok.Name.Local])
+				if parent.path != "" {
+					path = parent.path + "/" + path
+				}
+			}
+			index[path] = sourcePosition{line, column}
+			stack = append(stack, frame{path: path, children: make(map[string]int)})
+		case xml.EndElement:
+			stack = stack[:len(stack)-1]
+		}
+	}
+}
+
+// locate returns the position of the element a validation error path refers to,
+// falling back to its nearest recorded ancestor
+func (index positionIndex) locate(path string) (line, column int) {
+	var steps []string
+	for _, step := range strings.Split(path, "/") {
+		if step == "" || strings.HasPrefix(step, "@") || step == "text()" {
+			continue
+		}
+		if !strings.HasSuffix(step, "]") {
+			step += "[1]"
+		}
+		steps = append(steps, step)
+	}
+	for n := len(steps); n >= 0; n-- {
+		if pos, ok := index[strings.Join(steps[:n], "/")]; ok {
+			return pos.line, pos.column
+		}
+	}
+	return 0, 0
+}
@@ -0,0 +1,294 @@
+package iso20022
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// threeTransactionPacs008 builds a pacs.008 with three copies of the sample
+// transaction, passing each copy through edit with its 1-based index.
+func threeTransactionPacs008(t *testing.T, edit func(i int, tx string) string) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", "roundtrip", "pacs.008.001.08", "customer_credit_transfer.xml"))
+	if err != nil {
+		t.Fatalf("Failed to read fixture: %v", err)
+	}
+	sample := string(data)
+	tx := regexp.MustCompile(`(?s)    <CdtTrfTxInf>.*</CdtTrfTxInf>\n`).FindString(sample)
+	if tx == "" {
+		t.Fatalf("Fixture has no CdtTrfTxInf")
+	}
+	var txs strings.Builder
+	for i := 1; i <= 3; i++ {
+		txs.WriteString(edit(i, tx))
+	}
+	return strings.Replace(sample, tx, txs.String(), 1)
+}
+
+// positionOf returns the 1-based line and column of the n-th occurrence of s
+func positionOf(doc, s string, n int) (line, column int) {
+	offset := 0
+	for ; n > 0; n-- {
+		offset += strings.Index(doc[offset:], s) + 1
+	}
+	offset--
+	return strings.Count(doc[:offset], "\n") + 1, offset - strings.LastIndex(doc[:offset], "\n")
+}
+
+func TestValidateXMLLocatesErrors(t *testing.T) {
+	t.Run("Attribute", func(t *testing.T) {
+		doc := threeTransactionPacs008(t, func(i int, tx string) string {
+			if i == 3 {
+				return strings.Replace(tx, `<IntrBkSttlmAmt Ccy="USD">`, `<IntrBkSttlmAmt Ccy="usd">`, 1)
+			}
+			return tx
+		})
+
+		err := ValidateXML([]byte(doc), new(Pacs00800108Document))
+		errs, ok := err.(ValidationErrors)
+		if !ok || len(errs) != 1 {
+			t.Fatalf("Expected one validation error, got %v", err)
+		}
+		if errs[0].Field != "Ccy" {
+			t.Errorf("Expected Field 'Ccy', got %q", errs[0].Field)
+		}
+		if want := "FIToFICstmrCdtTrf/CdtTrfTxInf[3]/IntrBkSttlmAmt/@Ccy"; errs[0].Path != want {
+			t.Errorf("Expected Path %q, got %q", want, errs[0].Path)
+		}
+		line, column := positionOf(doc, "<IntrBkSttlmAmt", 3)
+		if errs[0].Line != line || errs[0].Column != column {
+			t.Errorf("Expected line %d, column %d, got line %d, column %d", line, column, errs[0].Line, errs[0].Column)
+		}
+		if !strings.Contains(errs[0].Error(), "CdtTrfTxInf[3]/IntrBkSttlmAmt/@Ccy") || !strings.Contains(errs[0].Error(), "(line ") {
+			t.Errorf("Expected the error message to include the path and position, got %q", errs[0].Error())
+		}
+	})
+
+	t.Run("MissingElement", func(t *testing.T) {
+		doc := threeTransactionPacs008(t, func(i int, tx string) string {
+			if i == 2 {
+				return regexp.MustCompile(`\s*<EndToEndId>[^<]*</EndToEndId>`).ReplaceAllString(tx, "")
+			}
+			return tx
+		})
+
+		err := ValidateXML([]byte(doc), new(Pacs00800108Document))
+		errs, ok := err.(ValidationErrors)
+		if !ok || len(errs) != 1 {
+			t.Fatalf("Expected one validation error, got %v", err)
+		}
+		if want := "FIToFICstmrCdtTrf/CdtTrfTxInf[2]/PmtId/EndToEndId"; errs[0].Path != want {
+			t.Errorf("Expected Path %q, got %q", want, errs[0].Path)
+		}
+		// The missing element is reported at its parent
+		line, column := positionOf(doc, "<PmtId>", 2)
+		if errs[0].Line != line || errs[0].Column != column {
+			t.Errorf("Expected line %d, column %d, got line %d, column %d", line, column, errs[0].Line, errs[0].Column)
+		}
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		doc := threeTransactionPacs008(t, func(_ int, tx string) string { return tx })
+		if err := ValidateXML([]byte(doc), new(Pacs00800108Document)); err != nil {
+			t.Errorf("Expected no errors, got %v", err)
+		}
+	})
+}
+
+func TestValidationErrorPaths(t *testing.T) {
+	t.Run("NestedChoice", func(t *testing.T) {
+		report := ReservationOrError7Choice{}
+		err := (&Camt04700106Document{ReturnReservation: ReturnReservationV06{ReportOrError: report}}).Validate()
+		errs, ok := err.(ValidationErrors)
+		if !ok {
+			t.Fatalf("Expected ValidationErrors, got %v", err)
+		}
+		found := false
+		for _, e := range errs {
+			if e.Path == "RtrRsvatn/RptOrErr" {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("Expected an error at RtrRsvatn/RptOrErr, got %v", errs)
+		}
+	})
+
+	t.Run("Unnested", func(t *testing.T) {
+		err := ValidationError{Field: "GrpHdr.MsgId", Message: "is required"}
+		if err.Location() != "GrpHdr/MsgId" {
+			t.Errorf("Expected location GrpHdr/MsgId, got %q", err.Location())
+		}
+		if err.Error() != "Field 'GrpHdr/MsgId': is required" {
+			t.Errorf("Unexpected error message %q", err.Error())
+		}
+	})
+}
+
+// invalidIBANPacs008 returns the sample pacs.008 with wrong IBAN check digits and a
+// NbOfTxs that does not match its transactions
+func invalidIBANPacs008(t *testing.T) *Pacs00800108Document {
+	t.Helper()
+	doc := loadPacs008Sample(t)
+	doc.FICustomerCreditTransfer.CreditTransferTransactionInfo[0].CreditorAccount.ID.IBAN = Ptr("GB28NWBK60161331926819")
+	doc.FICustomerCreditTransfer.GroupHeader.NumberOfTransactions = "2"
+	return doc
+}
+
+func TestValidateOptions(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Valid", func(t *testing.T) {
+		report, err := Validate(ctx, loadPacs008Sample(t))
+		if err != nil || !report.Valid() || len(report.Warnings) != 0 || report.Err() != nil {
+			t.Errorf("Expected a clean report, got %+v, %v", report, err)
+		}
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		report, err := Validate(ctx, invalidIBANPacs008(t))
+		if err != nil {
+			t.Fatal(err)
+		}
+		// The business rules are not checked while the document has errors
+		if len(report.Errors) != 1 || report.Errors[0].Rule != RuleIBANChecksum {
+			t.Fatalf("Expected the IBAN error, got %v", report.Errors)
+		}
+		if want := "FIToFICstmrCdtTrf/CdtTrfTxInf[1]/CdtrAcct/Id/IBAN"; report.Errors[0].Location() != want {
+			t.Errorf("Expected the error at %s, got %s", want, report.Errors[0].Location())
+		}
+		if report.Valid() || report.Err() == nil {
+			t.Errorf("Expected the report to be invalid")
+		}
+	})
+
+	t.Run("Warnings", func(t *testing.T) {
+		report, err := Validate(ctx, invalidIBANPacs008(t), WithSeverity(SeverityWarning, RuleIBANChecksum))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(report.Warnings) != 1 || report.Warnings[0].Rule != RuleIBANChecksum {
+			t.Errorf("Expected the IBAN warning, got %v", report.Warnings)
+		}
+		if len(report.Errors) != 1 || report.Errors[0].Rule != RuleNumberOfTransactions {
+			t.Errorf("Expected the business rules to be checked, got %v", report.Errors)
+		}
+	})
+
+	t.Run("SkipRules", func(t *testing.T) {
+		report, err := Validate(ctx, invalidIBANPacs008(t), SkipRules(RuleIBANChecksum, RuleNumberOfTransactions))
+		if err != nil || !report.Valid() || len(report.Warnings) != 0 {
+			t.Errorf("Expected the rules to be skipped, got %+v, %v", report, err)
+		}
+	})
+
+	t.Run("FailFast", func(t *testing.T) {
+		doc := invalidIBANPacs008(t)
+		doc.FICustomerCreditTransfer.CreditTransferTransactionInfo[0].InterbankSettlementAmount.Currency = "usd"
+		report, err := Validate(ctx, doc)
+		if err != nil || len(report.Errors) < 2 {
+			t.Fatalf("Expected several errors, got %+v, %v", report, err)
+		}
+		report, err = Validate(ctx, doc, FailFast())
+		if err != nil || len(report.Errors) != 1 {
+			t.Errorf("Expected a single error, got %+v, %v", report, err)
+		}
+	})
+
+	t.Run("Canceled", func(t *testing.T) {
+		canceled, cancel := context.WithCancel(ctx)
+		cancel()
+		if _, err := Validate(canceled, loadPacs008Sample(t)); !errors.Is(err, context.Canceled) {
+			t.Errorf("Expected context.Canceled, got %v", err)
+		}
+	})
+}
+
+func TestValidateCurrencyAmount(t *testing.T) {
+	tests := []struct {
+		amount ActiveCurrencyAndAmount
+		rule   string
+	}{
+		{ActiveCurrencyAndAmount{Value: 100.25, Currency: "USD"}, ""},
+		{ActiveCurrencyAndAmount{Value: 100, Currency: "JPY"}, ""},
+		{ActiveCurrencyAndAmount{Value: 100.5, Currency: "JPY"}, RuleMinorUnits},
+		{ActiveCurrencyAndAmount{Value: 10.125, Currency: "BHD"}, ""},
+		{ActiveCurrencyAndAmount{Value: 10.1255, Currency: "BHD"}, RuleMinorUnits},
+		{ActiveCurrencyAndAmount{Value: 1.123, Currency: "XAU"}, ""},
+		{ActiveCurrencyAndAmount{Value: 100, Currency: "DEM"}, RuleCurrency},
+		{ActiveCurrencyAndAmount{Value: 100, Currency: "ABC"}, RuleCurrency},
+	}
+	for _, tt := range tests {
+		err := tt.amount.Validate()
+		if tt.rule == "" {
+			if err != nil {
+				t.Errorf("%v: expected no error, got %v", tt.amount, err)
+			}
+			continue
+		}
+		errs, ok := err.(ValidationErrors)
+		if !ok || len(errs) != 1 || errs[0].Rule != tt.rule {
+			t.Errorf("%v: expected a %s error, got %v", tt.amount, tt.rule, err)
+		}
+	}
+
+	err := (&ActiveCurrencyAndAmount{Value: 100.5, Currency: "JPY"}).Validate().(ValidationErrors)[0]
+	if err.Location() != "text()" || err.Message != "has 1 decimal places, more than the 0 of JPY" {
+		t.Errorf("Unexpected error %+v", err)
+	}
+}
+
+// countdownContext is a context that is done once Err has been called n times
+type countdownContext struct {
+	context.Context
+	n    int
+	done chan struct{}
+}
+
+func newCountdownContext(n int) *countdownContext {
+	return &countdownContext{Context: context.Background(), n: n, done: make(chan struct{})}
+}
+
+func (c *countdownContext) Done() <-chan struct{} { return c.done }
+
+func (c *countdownContext) Err() error {
+	if c.n--; c.n < 0 {
+		return context.DeadlineExceeded
+	}
+	return nil
+}
+
+func TestValidateContext(t *testing.T) {
+	doc := loadPacs008Sample(t)
+	if err := ValidateContext(context.Background(), doc); err != nil {
+		t.Fatalf("Expected the sample to be valid, got %v", err)
+	}
+
+	// The walk checks the context at each component, not only before starting
+	ctx := newCountdownContext(5)
+	if err := ValidateContext(ctx, doc); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected the deadline to be reported, got %v", err)
+	}
+
+	// Documents without components to walk into are checked before validating
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := ValidateContext(cancelled, &Camt02900113Document{}); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected the cancellation to be reported, got %v", err)
+	}
+	if _, err := Validate(cancelled, doc); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected Validate to report the cancellation, got %v", err)
+	}
+
+	// Errors are still reported as before
+	doc.FICustomerCreditTransfer.GroupHeader.MessageID = ""
+	var errs ValidationErrors
+	if err := ValidateContext(context.Background(), doc); !errors.As(err, &errs) || errs.Error() != doc.Validate().Error() {
+		t.Errorf("Expected the errors of Validate, got %v", err)
+	}
+}
@@ -10,7 +10,7 @@ func TestNewValidationFunctions(t *testing.T) {
 	t.Run("InterestType1", func(t *testing.T) {
 		// Valid case - exactly one choice
 		validChoice := InterestType1{
-			Code: stringPtr("FIXED"),
+			Code: Ptr("FIXED"),
 		}
 		if err := validChoice.Validate(); err != nil {
 			t.Errorf("Valid InterestType1 should not have errors: %v", err)
@@ -25,8 +25,8 @@ func TestNewValidationFunctions(t *testing.T) {
 
 		// Invalid case - both choices
 		bothChoices := InterestType1{
-			Code:        stringPtr("FIXED"),
-			Proprietary: stringPtr("CUSTOM"),
+			Code:        Ptr("FIXED"),
+			Proprietary: Ptr("CUSTOM"),
 		}
 		err = bothChoices.Validate()
 		if err == nil {
@@ -38,7 +38,7 @@ func TestNewValidationFunctions(t *testing.T) {
 	t.Run("ServiceLevel8", func(t *testing.T) {
 		// Valid case
 		validService := ServiceLevel8{
-			Code: stringPtr("SEPA"),
+			Code: Ptr("SEPA"),
 		}
 		if err := validService.Validate(); err != nil {
 			t.Errorf("Valid ServiceLevel8 should not have errors: %v", err)
@@ -58,7 +58,7 @@ func TestNewValidationFunctions(t *testing.T) {
 		validGeneric := GenericIdentification30{
 			ID:         "TEST",
 			Issuer:     "Test Issuer",
-			SchemeName: stringPtr("TestScheme"),
+			SchemeName: Ptr("TestScheme"),
 		}
 		if err := validGeneric.Validate(); err != nil {
 			t.Errorf("Valid GenericIdentification30 should not have errors: %v", err)
@@ -89,7 +89,7 @@ func TestNewValidationFunctions(t *testing.T) {
 	t.Run("Rate4", func(t *testing.T) {
 		// Valid case
 		validRate := Rate4{
-			Rate: floatPtr(2.5),
+			Rate: Ptr(Decimal(2.5)),
 		}
 		if err := validRate.Validate(); err != nil {
 			t.Errorf("Valid Rate4 should not have errors: %v", err)
@@ -97,7 +97,7 @@ func TestNewValidationFunctions(t *testing.T) {
 
 		// Invalid case - negative rate
 		negativeRate := Rate4{
-			Rate: floatPtr(-1.0),
+			Rate: Ptr(Decimal(-1.0)),
 		}
 		err := negativeRate.Validate()
 		if err == nil {
@@ -109,7 +109,7 @@ func TestNewValidationFunctions(t *testing.T) {
 	t.Run("BalanceType10", func(t *testing.T) {
 		// Valid case
 		validBalance := BalanceType10{
-			Code: stringPtr("CLBD"),
+			Code: Ptr("CLBD"),
 		}
 		if err := validBalance.Validate(); err != nil {
 			t.Errorf("Valid BalanceType10 should not have errors: %v", err)
@@ -132,7 +132,7 @@ func TestAdditionalValidationFunctions(t *testing.T) {
 		// Valid case
 		validPayment := PaymentIdentification7{
 			EndToEndID:    "END2END123",
-			TransactionID: stringPtr("TXN456"),
+			TransactionID: Ptr("TXN456"),
 		}
 		if err := validPayment.Validate(); err != nil {
 			t.Errorf("Valid PaymentIdentification7 should not have errors: %v", err)
@@ -159,10 +159,10 @@ func TestAdditionalValidationFunctions(t *testing.T) {
 	t.Run("PartyIdentification135", func(t *testing.T) {
 		// Valid case
 		validParty := PartyIdentification135{
-			Name: stringPtr("Test Party Name"),
+			Name: Ptr("Test Party Name"),
 			PostalAddress: &PostalAddress24{
-				TownName: stringPtr("Test City"),
-				Country:  stringPtr("US"),
+				TownName: Ptr("Test City"),
+				Country:  Ptr("US"),
 			},
 		}
 		if err := validParty.Validate(); err != nil {
@@ -171,7 +171,7 @@ func TestAdditionalValidationFunctions(t *testing.T) {
 
 		// Invalid case - name too long
 		invalidParty := PartyIdentification135{
-			Name: stringPtr("This is an extremely long party name that exceeds the maximum allowed length of 140 characters and should therefore fail validation when tested"),
+			Name: Ptr("This is an extremely long party name that exceeds the maximum allowed length of 140 characters and should therefore fail validation when tested"),
 		}
 		err := invalidParty.Validate()
 		if err == nil {
@@ -183,11 +183,11 @@ func TestAdditionalValidationFunctions(t *testing.T) {
 	t.Run("PostalAddress24", func(t *testing.T) {
 		// Valid case
 		validAddress := PostalAddress24{
-			StreetName:     stringPtr("123 Main Street"),
-			BuildingNumber: stringPtr("123"),
-			PostCode:       stringPtr("12345"),
-			TownName:       stringPtr("Anytown"),
-			Country:        stringPtr("US"),
+			StreetName:     Ptr("123 Main Street"),
+			BuildingNumber: Ptr("123"),
+			PostCode:       Ptr("12345"),
+			TownName:       Ptr("Anytown"),
+			Country:        Ptr("US"),
 		}
 		if err := validAddress.Validate(); err != nil {
 			t.Errorf("Valid PostalAddress24 should not have errors: %v", err)
@@ -195,8 +195,8 @@ func TestAdditionalValidationFunctions(t *testing.T) {
 
 		// Invalid case - invalid country code
 		invalidAddress := PostalAddress24{
-			TownName: stringPtr("Test City"),
-			Country:  stringPtr("INVALID"),
+			TownName: Ptr("Test City"),
+			Country:  Ptr("INVALID"),
 		}
 		err := invalidAddress.Validate()
 		if err == nil {
@@ -208,9 +208,9 @@ func TestAdditionalValidationFunctions(t *testing.T) {
 	t.Run("FinancialInstitutionIdentification18", func(t *testing.T) {
 		// Valid case
 		validFI := FinancialInstitutionIdentification18{
-			BankIdentifierCode:    stringPtr("CHASUS33"),
-			LegalEntityIdentifier: stringPtr("12345678901234567890"),
-			Name:                  stringPtr("Test Bank"),
+			BankIdentifierCode:    Ptr("CHASUS33"),
+			LegalEntityIdentifier: Ptr("5493001KJTIIGC8Y1R12"),
+			Name:                  Ptr("Test Bank"),
 		}
 		if err := validFI.Validate(); err != nil {
 			t.Errorf("Valid FinancialInstitutionIdentification18 should not have errors: %v", err)
@@ -218,8 +218,8 @@ func TestAdditionalValidationFunctions(t *testing.T) {
 
 		// Invalid case - invalid BIC
 		invalidFI := FinancialInstitutionIdentification18{
-			BankIdentifierCode: stringPtr("INVALID"),
-			Name:               stringPtr("Test Bank"),
+			BankIdentifierCode: Ptr("INVALID"),
+			Name:               Ptr("Test Bank"),
 		}
 		err := invalidFI.Validate()
 		if err == nil {
@@ -232,7 +232,7 @@ func TestAdditionalValidationFunctions(t *testing.T) {
 		// Valid case
 		validGeneric := GenericAccountIdentification1{
 			ID:     "1234567890",
-			Issuer: stringPtr("Test Issuer"),
+			Issuer: Ptr("Test Issuer"),
 		}
 		if err := validGeneric.Validate(); err != nil {
 			t.Errorf("Valid GenericAccountIdentification1 should not have errors: %v", err)
@@ -249,7 +249,7 @@ func TestAdditionalValidationFunctions(t *testing.T) {
 
 		// Invalid case - missing required ID
 		missingID := GenericAccountIdentification1{
-			Issuer: stringPtr("Test Issuer"),
+			Issuer: Ptr("Test Issuer"),
 		}
 		err = missingID.Validate()
 		if err == nil {
@@ -266,10 +266,10 @@ func TestMoreValidationFunctions(t *testing.T) {
 		// Valid case with IBAN
 		validAccount := CashAccount38{
 			ID: AccountIdentification4{
-				IBAN: stringPtr("DE89370400440532013000"),
+				IBAN: Ptr("DE89370400440532013000"),
 			},
-			Currency: stringPtr("EUR"),
-			Name:     stringPtr("Test Account"),
+			Currency: Ptr("EUR"),
+			Name:     Ptr("Test Account"),
 		}
 		if err := validAccount.Validate(); err != nil {
 			t.Errorf("Valid CashAccount38 should not have errors: %v", err)
@@ -278,9 +278,9 @@ func TestMoreValidationFunctions(t *testing.T) {
 		// Invalid case - currency too short
 		invalidAccount := CashAccount38{
 			ID: AccountIdentification4{
-				IBAN: stringPtr("DE89370400440532013000"),
+				IBAN: Ptr("DE89370400440532013000"),
 			},
-			Currency: stringPtr("EU"), // Too short
+			Currency: Ptr("EU"), // Too short
 		}
 		err := invalidAccount.Validate()
 		if err == nil {
@@ -290,7 +290,7 @@ func TestMoreValidationFunctions(t *testing.T) {
 		// Invalid case - no account identification choice
 		noChoiceAccount := CashAccount38{
 			ID:       AccountIdentification4{},
-			Currency: stringPtr("USD"),
+			Currency: Ptr("USD"),
 		}
 		err = noChoiceAccount.Validate()
 		if err == nil {
@@ -302,7 +302,7 @@ func TestMoreValidationFunctions(t *testing.T) {
 	t.Run("AccountIdentification4", func(t *testing.T) {
 		// Valid case - IBAN only
 		validIBAN := AccountIdentification4{
-			IBAN: stringPtr("DE89370400440532013000"),
+			IBAN: Ptr("DE89370400440532013000"),
 		}
 		if err := validIBAN.Validate(); err != nil {
 			t.Errorf("Valid IBAN AccountIdentification4 should not have errors: %v", err)
@@ -312,7 +312,7 @@ func TestMoreValidationFunctions(t *testing.T) {
 		validOther := AccountIdentification4{
 			Other: &GenericAccountIdentification1{
 				ID:     "12345",
-				Issuer: stringPtr("Test Issuer"),
+				Issuer: Ptr("Test Issuer"),
 			},
 		}
 		if err := validOther.Validate(); err != nil {
@@ -328,7 +328,7 @@ func TestMoreValidationFunctions(t *testing.T) {
 
 		// Invalid case - both choices
 		bothChoices := AccountIdentification4{
-			IBAN:  stringPtr("DE89370400440532013000"),
+			IBAN:  Ptr("DE89370400440532013000"),
 			Other: &GenericAccountIdentification1{ID: "12345"},
 		}
 		err = bothChoices.Validate()
@@ -338,7 +338,7 @@ func TestMoreValidationFunctions(t *testing.T) {
 
 		// Invalid case - IBAN too short
 		shortIBAN := AccountIdentification4{
-			IBAN: stringPtr("DE123"),
+			IBAN: Ptr("DE123"),
 		}
 		err = shortIBAN.Validate()
 		if err == nil {
@@ -350,8 +350,8 @@ func TestMoreValidationFunctions(t *testing.T) {
 	t.Run("PaymentTypeInfo28", func(t *testing.T) {
 		// Valid case
 		validPaymentType := PaymentTypeInfo28{
-			InstructionPriority: stringPtr("HIGH"),
-			SequenceType:        stringPtr("FRST"),
+			InstructionPriority: Ptr(PriorityHIGH),
+			ClearingChannel:     Ptr(ClearingChannelRTGS),
 		}
 		if err := validPaymentType.Validate(); err != nil {
 			t.Errorf("Valid PaymentTypeInfo28 should not have errors: %v", err)
@@ -359,20 +359,20 @@ func TestMoreValidationFunctions(t *testing.T) {
 
 		// Invalid case - instruction priority too long
 		invalidPriority := PaymentTypeInfo28{
-			InstructionPriority: stringPtr("VERYHIGHPRIORITY"), // Too long
+			InstructionPriority: Ptr(Priority2Code("VERYHIGHPRIORITY")), // Too long
 		}
 		err := invalidPriority.Validate()
 		if err == nil {
 			t.Error("PaymentTypeInfo28 with too long priority should have validation error")
 		}
 
-		// Invalid case - sequence type too long
-		invalidSequence := PaymentTypeInfo28{
-			SequenceType: stringPtr("VERYLONGSEQUENCETYPE"), // Too long
+		// Invalid case - unknown clearing channel
+		invalidChannel := PaymentTypeInfo28{
+			ClearingChannel: Ptr(ClearingChannel2Code("SWIFT")),
 		}
-		err = invalidSequence.Validate()
+		err = invalidChannel.Validate()
 		if err == nil {
-			t.Error("PaymentTypeInfo28 with too long sequence type should have validation error")
+			t.Error("PaymentTypeInfo28 with unknown clearing channel should have validation error")
 		}
 	})
 
@@ -389,19 +389,19 @@ func TestMoreValidationFunctions(t *testing.T) {
 			},
 			ChargeBearer: "SLEV",
 			Debtor: PartyIdentification135{
-				Name: stringPtr("Test Debtor"),
+				Name: Ptr("Test Debtor"),
 			},
 			DebtorAgent: BranchAndFinancialInstitutionIdentification6{
 				FinancialInstitutionID: FinancialInstitutionIdentification18{
-					BankIdentifierCode: stringPtr("CHASUS33"),
+					BankIdentifierCode: Ptr("CHASUS33"),
 				},
 			},
 			Creditor: PartyIdentification135{
-				Name: stringPtr("Test Creditor"),
+				Name: Ptr("Test Creditor"),
 			},
 			CreditorAgent: BranchAndFinancialInstitutionIdentification6{
 				FinancialInstitutionID: FinancialInstitutionIdentification18{
-					BankIdentifierCode: stringPtr("BOFAUS3N"),
+					BankIdentifierCode: Ptr("BOFAUS3N"),
 				},
 			},
 		}
@@ -448,7 +448,7 @@ func TestMoreValidationFunctions(t *testing.T) {
 		validTransfer := FIToFICustomerCreditTransferV08{
 			GroupHeader: GroupHeader93{
 				MessageID:            "MSG123",
-				CreationDateTime:     func() *time.Time { t := time.Now(); return &t }(),
+				CreationDateTime:     Ptr(NewISODateTime(time.Now())),
 				NumberOfTransactions: "1",
 				SettlementInfo: SettlementInstruction7{
 					SettlementMethod: "INDA",
@@ -465,19 +465,19 @@ func TestMoreValidationFunctions(t *testing.T) {
 					},
 					ChargeBearer: "SLEV",
 					Debtor: PartyIdentification135{
-						Name: stringPtr("Test Debtor"),
+						Name: Ptr("Test Debtor"),
 					},
 					DebtorAgent: BranchAndFinancialInstitutionIdentification6{
 						FinancialInstitutionID: FinancialInstitutionIdentification18{
-							BankIdentifierCode: stringPtr("CHASUS33"),
+							BankIdentifierCode: Ptr("CHASUS33"),
 						},
 					},
 					Creditor: PartyIdentification135{
-						Name: stringPtr("Test Creditor"),
+						Name: Ptr("Test Creditor"),
 					},
 					CreditorAgent: BranchAndFinancialInstitutionIdentification6{
 						FinancialInstitutionID: FinancialInstitutionIdentification18{
-							BankIdentifierCode: stringPtr("BOFAUS3N"),
+							BankIdentifierCode: Ptr("BOFAUS3N"),
 						},
 					},
 				},
@@ -491,7 +491,7 @@ func TestMoreValidationFunctions(t *testing.T) {
 		noTransactions := FIToFICustomerCreditTransferV08{
 			GroupHeader: GroupHeader93{
 				MessageID:            "MSG123",
-				CreationDateTime:     func() *time.Time { t := time.Now(); return &t }(),
+				CreationDateTime:     Ptr(NewISODateTime(time.Now())),
 				NumberOfTransactions: "0",
 				SettlementInfo: SettlementInstruction7{
 					SettlementMethod: "INDA",
@@ -507,9 +507,3 @@ func TestMoreValidationFunctions(t *testing.T) {
 
 	t.Log("All new validation functions are working correctly!")
 }
-
-// Helper function to create Decimal pointers
-func floatPtr(f float64) *Decimal {
-	d := Decimal(f)
-	return &d
-}
\ No newline at end of file
@@ -0,0 +1,79 @@
+package iso20022
+
+import (
+	"encoding/xml"
+	"testing"
+	"time"
+)
+
+func TestIdentificationVerification(t *testing.T) {
+	account := AccountIdentification4{IBAN: Ptr("GB33BUKB20201555555555")}
+	req, err := NewIdentificationVerificationRequest("IDV001", testAgentParty("NWBKGB2L"), testAgentParty("BUKBGB22"), "V1", "Jane Smith", account)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := req.Validate(); err != nil {
+		t.Fatalf("Expected valid acmt.023, got: %v", err)
+	}
+
+	xmlData, err := xml.Marshal(req)
+	if err != nil {
+		t.Fatalf("Failed to marshal acmt.023: %v", err)
+	}
+	var parsed Acmt02300103Document
+	if err := xml.Unmarshal(xmlData, &parsed); err != nil {
+		t.Fatalf("Failed to unmarshal acmt.023: %v", err)
+	}
+	if got := *parsed.IdentificationVerificationRequest.Verification[0].PartyAndAccountID.Party.Name; got != "Jane Smith" {
+		t.Errorf("Expected name Jane Smith, got %s", got)
+	}
+
+	rpt := Acmt02400103Document{
+		IdentificationVerificationReport: IdentificationVerificationReportV03{
+			Assignment: IdentificationAssignment3{
+				MessageID:        "IDV-RPT-001",
+				CreationDateTime: NewISODateTime(time.Now()),
+				Assigner:         testAgentParty("BUKBGB22"),
+				Assignee:         testAgentParty("NWBKGB2L"),
+			},
+			Report: []VerificationReport4{
+				{OriginalID: "V1", Verification: true},
+				{
+					OriginalID:               "V2",
+					Reason:                   &VerificationReason1Choice{Code: Ptr("MBAM")},
+					UpdatedPartyAndAccountID: &IdentificationInformation4{Party: &PartyIdentification135{Name: Ptr("Jane A Smith")}},
+				},
+				{OriginalID: "V3", Reason: &VerificationReason1Choice{Code: Ptr("AC01")}},
+			},
+		},
+	}
+	if err := rpt.Validate(); err != nil {
+		t.Fatalf("Expected valid acmt.024, got: %v", err)
+	}
+
+	tests := []struct {
+		id     string
+		result MatchResult
+		reason string
+		name   string
+	}{
+		{"V1", Match, "", ""},
+		{"V2", CloseMatch, "MBAM", "Jane A Smith"},
+		{"V3", NoMatch, "AC01", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			res, ok := rpt.Result(tt.id)
+			if !ok {
+				t.Fatalf("Expected result for %s", tt.id)
+			}
+			if res.Result != tt.result || res.ReasonCode != tt.reason || res.SuggestedName != tt.name {
+				t.Errorf("Expected %v/%s/%s, got %+v", tt.result, tt.reason, tt.name, res)
+			}
+		})
+	}
+
+	if _, ok := rpt.Result("V9"); ok {
+		t.Error("Expected no result for unknown verification")
+	}
+}
@@ -0,0 +1,147 @@
+package iso20022
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ErrVersionLoss is returned when a message is converted to a version that cannot
+// carry one of its elements, such as the UETR of a pacs.008 converted to
+// pacs.008.001.06
+var ErrVersionLoss = errors.New("element not supported by the target version")
+
+// NegotiateVersion returns the latest message version that both sides support,
+// given the message name identifications each of them accepts, such as
+// pacs.008.001.08. It returns false when they have none in common.
+func NegotiateVersion(ours, theirs []string) (string, bool) {
+	best := ""
+	for _, v := range ours {
+		for _, w := range theirs {
+			// Identifications of the same message have the same length, so the
+			// latest version sorts last
+			if v == w && v > best {
+				best = v
+			}
+		}
+	}
+	return best, best != ""
+}
+
+// convertVersion copies the message src into dst, another version of the same
+// message. Elements are matched by the names of their fields, which are the same in
+// every version of a component even when their XML names differ; a value is
+// converted between the types of two versions of a component, between a single
+// value and an optional one, between a single value and a list of them, and between
+// named types of the same kind. An element of src that dst has no field for, or a
+// second element of a list that dst has a single value for, is an error unless it is
+// empty.
+func convertVersion(dst, src interface{}) error {
+	return convertValue(reflect.ValueOf(dst).Elem(), reflect.ValueOf(src).Elem(), "", false)
+}
+
+// convertCore copies the message src into dst as convertVersion does, leaving out
+// the elements dst cannot carry rather than failing on them
+func convertCore(dst, src interface{}) error {
+	return convertValue(reflect.ValueOf(dst).Elem(), reflect.ValueOf(src).Elem(), "", true)
+}
+
+func convertValue(dst, src reflect.Value, path string, lossy bool) error {
+	if src.Type() == dst.Type() {
+		dst.Set(src)
+		return nil
+	}
+	switch {
+	case src.Kind() == reflect.Ptr:
+		if src.IsNil() {
+			return nil
+		}
+		return convertValue(dst, src.Elem(), path, lossy)
+	case dst.Kind() == reflect.Ptr:
+		if isEmptyValue(src) {
+			return nil
+		}
+		v := reflect.New(dst.Type().Elem())
+		if err := convertValue(v.Elem(), src, path, lossy); err != nil {
+			return err
+		}
+		dst.Set(v)
+		return nil
+	case src.Kind() == reflect.Struct && dst.Kind() == reflect.Struct:
+		for i := 0; i < src.NumField(); i++ {
+			field := src.Type().Field(i)
+			if !field.IsExported() || field.Name == "XMLName" {
+				continue
+			}
+			name, _, _ := strings.Cut(field.Tag.Get("xml"), ",")
+			fieldPath := name
+			if path != "" && name != "" {
+				fieldPath = path + "/" + name
+			}
+			target := dst.FieldByName(field.Name)
+			if !target.IsValid() {
+				if lossy || isEmptyValue(src.Field(i)) {
+					continue
+				}
+				return fmt.Errorf("%w: %s", ErrVersionLoss, fieldPath)
+			}
+			if err := convertValue(target, src.Field(i), fieldPath, lossy); err != nil {
+				return err
+			}
+		}
+		return nil
+	case src.Kind() == reflect.Slice && dst.Kind() == reflect.Slice:
+		if src.Len() == 0 {
+			return nil
+		}
+		s := reflect.MakeSlice(dst.Type(), src.Len(), src.Len())
+		for i := 0; i < src.Len(); i++ {
+			if err := convertValue(s.Index(i), src.Index(i), fmt.Sprintf("%s[%d]", path, i+1), lossy); err != nil {
+				return err
+			}
+		}
+		dst.Set(s)
+		return nil
+	case src.Kind() == reflect.Slice:
+		for i := 1; i < src.Len() && !lossy; i++ {
+			if !isEmptyValue(src.Index(i)) {
+				return fmt.Errorf("%w: %s[%d]", ErrVersionLoss, path, i+1)
+			}
+		}
+		if src.Len() == 0 {
+			return nil
+		}
+		return convertValue(dst, src.Index(0), path, lossy)
+	case dst.Kind() == reflect.Slice:
+		if isEmptyValue(src) {
+			return nil
+		}
+		s := reflect.MakeSlice(dst.Type(), 1, 1)
+		if err := convertValue(s.Index(0), src, path, lossy); err != nil {
+			return err
+		}
+		dst.Set(s)
+		return nil
+	case src.Kind() == dst.Kind() && src.Type().ConvertibleTo(dst.Type()):
+		dst.Set(src.Convert(dst.Type()))
+		return nil
+	}
+	return fmt.Errorf("cannot convert %s from %s to %s", path, src.Type(), dst.Type())
+}
+
+// isEmptyValue tells whether a value is absent from the XML encoding of a message
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Slice, reflect.Map:
+		return v.Len() == 0
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).Name != "XMLName" && !isEmptyValue(v.Field(i)) {
+				return false
+			}
+		}
+		return true
+	}
+	return v.IsZero()
+}
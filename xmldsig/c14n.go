@@ -0,0 +1,252 @@
+package xmldsig
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+	"sort"
+	"strings"
+)
+
+// xmlNamespace is the namespace bound to the xml prefix
+const xmlNamespace = "http://www.w3.org/XML/1998/namespace"
+
+// element identifies an element of the input to canonicalize or exclude
+type element struct {
+	local   string
+	space   string // namespace URI
+	attr    []xml.Attr
+	parents []string // local names of the ancestors, outermost first
+}
+
+// selector picks an element
+type selector func(e element) bool
+
+// byName selects elements by local name and namespace URI; an empty space matches
+// any namespace
+func byName(local, space string) selector {
+	return func(e element) bool {
+		return e.local == local && (space == "" || e.space == space)
+	}
+}
+
+// under restricts a selector to elements whose closest ancestors have the given
+// local names, outermost first
+func under(sel selector, ancestors ...string) selector {
+	return func(e element) bool {
+		n, m := len(e.parents), len(ancestors)
+		if n < m {
+			return false
+		}
+		for i, name := range ancestors {
+			if e.parents[n-m+i] != name {
+				return false
+			}
+		}
+		return sel(e)
+	}
+}
+
+// byID selects the element whose Id attribute is id
+func byID(id string) selector {
+	return func(e element) bool {
+		for _, a := range e.attr {
+			if a.Name.Space == "" && a.Name.Local == "Id" && a.Value == id {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// errNotFound is returned by canonicalize when no element is selected
+var errNotFound = errors.New("element not found")
+
+// canonicalize returns the Exclusive XML Canonicalization (without comments) of the
+// first element of data picked by target, leaving out the subtrees of the elements
+// picked by exclude, as the enveloped signature transform does. Namespaces declared
+// on the ancestors of the element are taken into account, so the element may be
+// part of a larger document. Attribute values are taken as the parser returns them,
+// without normalizing literal whitespace.
+func canonicalize(data []byte, target, exclude selector) ([]byte, error) {
+	type frame struct {
+		name     string // qualified name as written
+		local    string
+		scope    map[string]string // namespaces in scope, by prefix
+		rendered map[string]string // namespaces rendered on output ancestors, by prefix
+		skip     bool
+	}
+
+	var out bytes.Buffer
+	dec := xml.NewDecoder(bytes.NewReader(data))
+	stack := []frame{{scope: map[string]string{"xml": xmlNamespace}}}
+	started, depth := false, 0
+	for {
+		tok, err := dec.RawToken()
+		if err == io.EOF {
+			if !started {
+				return nil, errNotFound
+			}
+			return nil, io.ErrUnexpectedEOF
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch tok := tok.(type) {
+		case xml.StartElement:
+			parent := stack[len(stack)-1]
+			scope := parent.scope
+			var attrs []xml.Attr
+			for _, a := range tok.Attr {
+				switch {
+				case a.Name.Space == "" && a.Name.Local == "xmlns":
+					scope = with(scope, "", a.Value)
+				case a.Name.Space == "xmlns":
+					scope = with(scope, a.Name.Local, a.Value)
+				default:
+					attrs = append(attrs, a)
+				}
+			}
+			var parents []string
+			for _, f := range stack[1:] {
+				parents = append(parents, f.local)
+			}
+			e := element{local: tok.Name.Local, space: scope[tok.Name.Space], attr: attrs, parents: parents}
+			f := frame{name: qualified(tok.Name), local: tok.Name.Local, scope: scope, rendered: parent.rendered, skip: parent.skip}
+
+			if !started && target(e) {
+				started, f.rendered = true, map[string]string{"": ""}
+			}
+			if started && !f.skip && exclude != nil && exclude(e) {
+				f.skip = true
+			}
+			if started {
+				depth++
+			}
+			if started && !f.skip {
+				f.rendered = renderStart(&out, tok.Name, attrs, scope, f.rendered)
+			}
+			stack = append(stack, f)
+
+		case xml.EndElement:
+			f := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if !started {
+				continue
+			}
+			if !f.skip {
+				out.WriteString("</" + f.name + ">")
+			}
+			if depth--; depth == 0 {
+				return out.Bytes(), nil
+			}
+
+		case xml.CharData:
+			if started && !stack[len(stack)-1].skip {
+				escapeText(&out, string(tok))
+			}
+
+		case xml.ProcInst:
+			if started && !stack[len(stack)-1].skip {
+				out.WriteString("<?" + tok.Target)
+				if len(tok.Inst) > 0 {
+					out.WriteString(" " + string(tok.Inst))
+				}
+				out.WriteString("?>")
+			}
+		}
+	}
+}
+
+// renderStart writes a start tag with the namespace declarations it visibly
+// utilizes that its output ancestors have not rendered, and returns the namespaces
+// rendered for its children
+func renderStart(out *bytes.Buffer, name xml.Name, attrs []xml.Attr, scope, rendered map[string]string) map[string]string {
+	utilized := []string{name.Space}
+	for _, a := range attrs {
+		if a.Name.Space != "" && a.Name.Space != "xml" {
+			utilized = append(utilized, a.Name.Space)
+		}
+	}
+	var decls []string
+	for _, prefix := range utilized {
+		uri := scope[prefix]
+		if current, ok := rendered[prefix]; ok && current == uri {
+			continue
+		}
+		if prefix != "" && uri == "" {
+			// An undeclared prefix cannot be rendered
+			continue
+		}
+		rendered = with(rendered, prefix, uri)
+		decls = append(decls, prefix)
+	}
+	sort.Strings(decls)
+
+	sort.SliceStable(attrs, func(i, j int) bool {
+		si, sj := attrSpace(attrs[i], scope), attrSpace(attrs[j], scope)
+		if si != sj {
+			return si < sj
+		}
+		return attrs[i].Name.Local < attrs[j].Name.Local
+	})
+
+	out.WriteString("<" + qualified(name))
+	for _, prefix := range decls {
+		if prefix == "" {
+			out.WriteString(` xmlns="`)
+		} else {
+			out.WriteString(` xmlns:` + prefix + `="`)
+		}
+		escapeAttr(out, rendered[prefix])
+		out.WriteString(`"`)
+	}
+	for _, a := range attrs {
+		out.WriteString(" " + qualified(a.Name) + `="`)
+		escapeAttr(out, a.Value)
+		out.WriteString(`"`)
+	}
+	out.WriteString(">")
+	return rendered
+}
+
+// attrSpace returns the namespace URI of an attribute; unprefixed attributes have none
+func attrSpace(a xml.Attr, scope map[string]string) string {
+	if a.Name.Space == "" {
+		return ""
+	}
+	return scope[a.Name.Space]
+}
+
+// with returns a copy of m with prefix bound to uri
+func with(m map[string]string, prefix, uri string) map[string]string {
+	c := make(map[string]string, len(m)+1)
+	for k, v := range m {
+		c[k] = v
+	}
+	c[prefix] = uri
+	return c
+}
+
+// qualified returns a raw name as prefix:local
+func qualified(n xml.Name) string {
+	if n.Space == "" {
+		return n.Local
+	}
+	return n.Space + ":" + n.Local
+}
+
+var (
+	textEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", "\r", "&#xD;")
+	attrEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", `"`, "&quot;", "\t", "&#x9;", "\n", "&#xA;", "\r", "&#xD;")
+)
+
+func escapeText(out *bytes.Buffer, s string) {
+	textEscaper.WriteString(out, s)
+}
+
+func escapeAttr(out *bytes.Buffer, s string) {
+	attrEscaper.WriteString(out, s)
+}
@@ -0,0 +1,462 @@
+// Package xmldsig signs and verifies business messages with enveloped XML digital
+// signatures, as the ISO 20022 Business Application Header and the SWIFT
+// guidelines for it describe.
+//
+// The signature is placed in the Sgntr element of the AppHdr. Its SignedInfo holds
+// three references, each canonicalized with Exclusive XML Canonicalization and
+// digested with SHA-256:
+//
+//   - the KeyInfo of the signature, which identifies the signing certificate by
+//     issuer and serial number;
+//   - the AppHdr itself (URI ""), with the enveloped signature transform;
+//   - the business Document, as the reference without URI.
+//
+// SignedInfo is signed with RSA (PKCS #1 v1.5) or ECDSA over SHA-256.
+//
+// The header and the document may be given as separate XML documents, or both as
+// the same envelope containing the AppHdr and the Document elements.
+package xmldsig
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"strings"
+)
+
+// Namespaces and algorithm identifiers
+const (
+	Namespace            = "http://www.w3.org/2000/09/xmldsig#"
+	ExclusiveC14N        = "http://www.w3.org/2001/10/xml-exc-c14n#"
+	EnvelopedSignature   = "http://www.w3.org/2000/09/xmldsig#enveloped-signature"
+	SHA256               = "http://www.w3.org/2001/04/xmlenc#sha256"
+	RSAWithSHA256        = "http://www.w3.org/2001/04/xmldsig-more#rsa-sha256"
+	ECDSAWithSHA256      = "http://www.w3.org/2001/04/xmldsig-more#ecdsa-sha256"
+	appHdrElement        = "AppHdr"
+	documentElement      = "Document"
+	signatureElement     = "Signature"
+	signedInfoElement    = "SignedInfo"
+	signatureContainer   = "Sgntr"
+	relatedHeaderElement = "Rltd"
+)
+
+// envelopedSignature selects the signature of the AppHdr, which the enveloped
+// signature transform leaves out of the AppHdr digest
+var envelopedSignature = under(byName(signatureElement, Namespace), appHdrElement, signatureContainer)
+
+// Errors returned by Sign and Verify
+var (
+	ErrAlreadySigned        = errors.New("xmldsig: AppHdr already has a Sgntr element")
+	ErrNoSignature          = errors.New("xmldsig: AppHdr has no signature")
+	ErrUnsupportedAlgorithm = errors.New("xmldsig: unsupported algorithm")
+	ErrMissingReference     = errors.New("xmldsig: signature does not cover both AppHdr and Document")
+	ErrDigestMismatch       = errors.New("xmldsig: digest mismatch")
+	ErrUnknownCertificate   = errors.New("xmldsig: signing certificate not among the provided certificates")
+	ErrInvalidSignature     = errors.New("xmldsig: invalid signature value")
+)
+
+// Sign signs a message and returns appHdr with the signature in the Sgntr element
+// of its AppHdr. appHdr holds the AppHdr element and document the Document element;
+// both may be the same envelope. The AppHdr must not have a Sgntr element yet. The
+// key must be the private key of cert, an RSA or ECDSA key.
+func Sign(appHdr, document []byte, key crypto.Signer, cert *x509.Certificate) ([]byte, error) {
+	method, err := signatureMethod(key.Public())
+	if err != nil {
+		return nil, err
+	}
+
+	// The AppHdr digest covers the Sgntr element, without the signature inside it
+	signed, insertAt, err := insertSgntr(appHdr)
+	if err != nil {
+		return nil, err
+	}
+	hdrDigest, err := digest(signed, byName(appHdrElement, ""), envelopedSignature)
+	if err != nil {
+		return nil, fmt.Errorf("xmldsig: AppHdr: %w", err)
+	}
+	if bytes.Equal(appHdr, document) {
+		document = signed
+	}
+	docDigest, err := digest(document, byName(documentElement, ""), nil)
+	if err != nil {
+		return nil, fmt.Errorf("xmldsig: Document: %w", err)
+	}
+
+	var id [16]byte
+	if _, err := io.ReadFull(rand.Reader, id[:]); err != nil {
+		return nil, err
+	}
+	keyInfoID := "_" + hex.EncodeToString(id[:])
+	keyInfo := fmt.Sprintf(`<ds:KeyInfo Id="%s"><ds:X509Data><ds:X509IssuerSerial><ds:X509IssuerName>%s</ds:X509IssuerName>`+
+		`<ds:X509SerialNumber>%s</ds:X509SerialNumber></ds:X509IssuerSerial></ds:X509Data></ds:KeyInfo>`,
+		keyInfoID, escape(cert.Issuer.String()), cert.SerialNumber.String())
+	keyInfoDigest, err := digest([]byte(`<ds:Signature xmlns:ds="`+Namespace+`">`+keyInfo+`</ds:Signature>`), byID(keyInfoID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	signedInfo := `<ds:SignedInfo>` +
+		`<ds:CanonicalizationMethod Algorithm="` + ExclusiveC14N + `"/>` +
+		`<ds:SignatureMethod Algorithm="` + method + `"/>` +
+		referenceXML(` URI="#`+keyInfoID+`"`, keyInfoDigest, ExclusiveC14N) +
+		referenceXML(` URI=""`, hdrDigest, EnvelopedSignature, ExclusiveC14N) +
+		referenceXML(``, docDigest, ExclusiveC14N) +
+		`</ds:SignedInfo>`
+	canonical, err := canonicalize([]byte(`<ds:Signature xmlns:ds="`+Namespace+`">`+signedInfo+`</ds:Signature>`),
+		byName(signedInfoElement, Namespace), nil)
+	if err != nil {
+		return nil, err
+	}
+	value, err := signDigest(key, canonical)
+	if err != nil {
+		return nil, err
+	}
+
+	signature := `<ds:Signature xmlns:ds="` + Namespace + `">` + signedInfo +
+		`<ds:SignatureValue>` + base64.StdEncoding.EncodeToString(value) + `</ds:SignatureValue>` +
+		keyInfo + `</ds:Signature>`
+	out := make([]byte, 0, len(signed)+len(signature))
+	out = append(out, signed[:insertAt]...)
+	out = append(out, signature...)
+	return append(out, signed[insertAt:]...), nil
+}
+
+// Verify checks the signature in the AppHdr of appHdr over the AppHdr and the
+// Document of document, which may be the same envelope. The signing certificate
+// must be one of certs; it is identified by the issuer and serial number or the
+// certificate in the KeyInfo of the signature, or tried in turn when the KeyInfo
+// identifies none. Verify returns the certificate that verified the signature. It
+// does not check the certificate chain, validity period or revocation of the
+// certificates, which is left to the caller.
+func Verify(appHdr, document []byte, certs ...*x509.Certificate) (*x509.Certificate, error) {
+	sig, err := findSignature(appHdr)
+	if err != nil {
+		return nil, err
+	}
+	info := &sig.SignedInfo
+	if info.CanonicalizationMethod.Algorithm != ExclusiveC14N {
+		return nil, fmt.Errorf("%w: canonicalization %s", ErrUnsupportedAlgorithm, info.CanonicalizationMethod.Algorithm)
+	}
+
+	coversHeader, coversDocument := false, false
+	for i, ref := range info.References {
+		if ref.DigestMethod.Algorithm != SHA256 {
+			return nil, fmt.Errorf("%w: digest %s", ErrUnsupportedAlgorithm, ref.DigestMethod.Algorithm)
+		}
+		var data []byte
+		var target, exclude selector
+		var transforms []string
+		switch {
+		case ref.URI == nil:
+			data, target, transforms = document, byName(documentElement, ""), []string{ExclusiveC14N}
+			coversDocument = true
+		case *ref.URI == "":
+			data, target, exclude = appHdr, byName(appHdrElement, ""), envelopedSignature
+			transforms = []string{EnvelopedSignature, ExclusiveC14N}
+			coversHeader = true
+		case strings.HasPrefix(*ref.URI, "#"):
+			data, transforms = appHdr, []string{ExclusiveC14N}
+			target = under(byID(strings.TrimPrefix(*ref.URI, "#")), appHdrElement, signatureContainer, signatureElement)
+		default:
+			return nil, fmt.Errorf("%w: reference URI %s", ErrUnsupportedAlgorithm, *ref.URI)
+		}
+		if got := ref.transforms(); !equal(got, transforms) {
+			return nil, fmt.Errorf("%w: transforms %v of reference %d", ErrUnsupportedAlgorithm, got, i+1)
+		}
+		computed, err := digest(data, target, exclude)
+		if err != nil {
+			return nil, fmt.Errorf("xmldsig: reference %d: %w", i+1, err)
+		}
+		if computed != strings.TrimSpace(ref.DigestValue) {
+			return nil, fmt.Errorf("%w: reference %d", ErrDigestMismatch, i+1)
+		}
+	}
+	if !coversHeader || !coversDocument {
+		return nil, ErrMissingReference
+	}
+
+	canonical, err := canonicalize(appHdr, under(byName(signedInfoElement, Namespace), appHdrElement, signatureContainer, signatureElement), nil)
+	if err != nil {
+		return nil, fmt.Errorf("xmldsig: SignedInfo: %w", err)
+	}
+	value, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(sig.SignatureValue), ""))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSignature, err)
+	}
+	for _, cert := range sig.KeyInfo.candidates(certs) {
+		method, err := signatureMethod(cert.PublicKey)
+		if err != nil || method != info.SignatureMethod.Algorithm {
+			continue
+		}
+		if verifyDigest(cert.PublicKey, canonical, value) {
+			return cert, nil
+		}
+		if sig.KeyInfo.identifies(cert) {
+			return nil, ErrInvalidSignature
+		}
+	}
+	if len(sig.KeyInfo.X509Data) > 0 && len(sig.KeyInfo.candidates(certs)) == 0 {
+		return nil, ErrUnknownCertificate
+	}
+	return nil, ErrInvalidSignature
+}
+
+// signature is the part of ds:Signature that Verify reads
+type signature struct {
+	SignedInfo struct {
+		CanonicalizationMethod algorithm         `xml:"CanonicalizationMethod"`
+		SignatureMethod        algorithm         `xml:"SignatureMethod"`
+		References             []signedReference `xml:"Reference"`
+	} `xml:"SignedInfo"`
+	SignatureValue string  `xml:"SignatureValue"`
+	KeyInfo        keyInfo `xml:"KeyInfo"`
+}
+
+type algorithm struct {
+	Algorithm string `xml:"Algorithm,attr"`
+}
+
+type signedReference struct {
+	URI        *string `xml:"URI,attr"`
+	Transforms struct {
+		Transform []algorithm `xml:"Transform"`
+	} `xml:"Transforms"`
+	DigestMethod algorithm `xml:"DigestMethod"`
+	DigestValue  string    `xml:"DigestValue"`
+}
+
+// transforms returns the transform algorithms of a reference
+func (r *signedReference) transforms() []string {
+	var algorithms []string
+	for _, t := range r.Transforms.Transform {
+		algorithms = append(algorithms, t.Algorithm)
+	}
+	return algorithms
+}
+
+type keyInfo struct {
+	X509Data []struct {
+		IssuerSerial *struct {
+			IssuerName   string `xml:"X509IssuerName"`
+			SerialNumber string `xml:"X509SerialNumber"`
+		} `xml:"X509IssuerSerial"`
+		Certificates []string `xml:"X509Certificate"`
+	} `xml:"X509Data"`
+}
+
+// identifies reports whether the KeyInfo names cert, by issuer and serial number
+// or by including it
+func (k *keyInfo) identifies(cert *x509.Certificate) bool {
+	for _, data := range k.X509Data {
+		if is := data.IssuerSerial; is != nil {
+			serial, ok := new(big.Int).SetString(strings.TrimSpace(is.SerialNumber), 10)
+			if ok && serial.Cmp(cert.SerialNumber) == 0 && strings.TrimSpace(is.IssuerName) == cert.Issuer.String() {
+				return true
+			}
+		}
+		for _, c := range data.Certificates {
+			der, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(c), ""))
+			if err == nil && bytes.Equal(der, cert.Raw) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// candidates returns the certificates the KeyInfo identifies, or all of them when
+// it identifies none
+func (k *keyInfo) candidates(certs []*x509.Certificate) []*x509.Certificate {
+	if len(k.X509Data) == 0 {
+		return certs
+	}
+	var matched []*x509.Certificate
+	for _, cert := range certs {
+		if k.identifies(cert) {
+			matched = append(matched, cert)
+		}
+	}
+	return matched
+}
+
+// findSignature decodes the ds:Signature in the Sgntr element of the AppHdr
+func findSignature(data []byte) (*signature, error) {
+	dec := xml.NewDecoder(bytes.NewReader(data))
+	var path []string
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			return nil, ErrNoSignature
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch tok := tok.(type) {
+		case xml.StartElement:
+			n := len(path)
+			if tok.Name.Local == signatureElement && tok.Name.Space == Namespace &&
+				n >= 2 && path[n-1] == signatureContainer && path[n-2] == appHdrElement {
+				sig := new(signature)
+				if err := dec.DecodeElement(sig, &tok); err != nil {
+					return nil, err
+				}
+				return sig, nil
+			}
+			path = append(path, tok.Name.Local)
+		case xml.EndElement:
+			path = path[:len(path)-1]
+		}
+	}
+}
+
+// insertSgntr adds an empty Sgntr element to the AppHdr, in the namespace and with
+// the prefix of the AppHdr, before the related headers or else at the end. It
+// returns the header and the offset of the Sgntr content.
+func insertSgntr(data []byte) ([]byte, int, error) {
+	dec := xml.NewDecoder(bytes.NewReader(data))
+	prefix := ""
+	depth, hdrDepth := 0, -1
+	for {
+		offset := int(dec.InputOffset())
+		tok, err := dec.RawToken()
+		if err == io.EOF {
+			return nil, 0, fmt.Errorf("xmldsig: no %s element", appHdrElement)
+		}
+		if err != nil {
+			return nil, 0, err
+		}
+		insert := false
+		switch tok := tok.(type) {
+		case xml.StartElement:
+			depth++
+			switch {
+			case hdrDepth < 0 && tok.Name.Local == appHdrElement:
+				hdrDepth, prefix = depth, tok.Name.Space
+			case hdrDepth > 0 && depth == hdrDepth+1 && tok.Name.Local == signatureContainer:
+				return nil, 0, ErrAlreadySigned
+			case hdrDepth > 0 && depth == hdrDepth+1 && tok.Name.Local == relatedHeaderElement:
+				insert = true
+			}
+		case xml.EndElement:
+			insert = depth == hdrDepth
+			depth--
+		}
+		if insert {
+			name := signatureContainer
+			if prefix != "" {
+				name = prefix + ":" + name
+			}
+			open, close := "<"+name+">", "</"+name+">"
+			out := make([]byte, 0, len(data)+len(open)+len(close))
+			out = append(out, data[:offset]...)
+			out = append(out, open...)
+			out = append(out, close...)
+			out = append(out, data[offset:]...)
+			return out, offset + len(open), nil
+		}
+	}
+}
+
+// digest returns the base64 SHA-256 digest of the canonical form of an element
+func digest(data []byte, target, exclude selector) (string, error) {
+	canonical, err := canonicalize(data, target, exclude)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(canonical)
+	return base64.StdEncoding.EncodeToString(sum[:]), nil
+}
+
+// referenceXML returns a ds:Reference with the given URI attribute
+func referenceXML(uri, digestValue string, transforms ...string) string {
+	var b strings.Builder
+	b.WriteString(`<ds:Reference` + uri + `><ds:Transforms>`)
+	for _, t := range transforms {
+		b.WriteString(`<ds:Transform Algorithm="` + t + `"/>`)
+	}
+	b.WriteString(`</ds:Transforms><ds:DigestMethod Algorithm="` + SHA256 + `"/>`)
+	b.WriteString(`<ds:DigestValue>` + digestValue + `</ds:DigestValue></ds:Reference>`)
+	return b.String()
+}
+
+// signatureMethod returns the signature algorithm for a public key
+func signatureMethod(pub crypto.PublicKey) (string, error) {
+	switch pub.(type) {
+	case *rsa.PublicKey:
+		return RSAWithSHA256, nil
+	case *ecdsa.PublicKey:
+		return ECDSAWithSHA256, nil
+	}
+	return "", fmt.Errorf("%w: key type %T", ErrUnsupportedAlgorithm, pub)
+}
+
+// signDigest signs the SHA-256 digest of data. ECDSA signatures are encoded as the
+// concatenated r and s values that XML signatures use.
+func signDigest(key crypto.Signer, data []byte) ([]byte, error) {
+	sum := sha256.Sum256(data)
+	value, err := key.Sign(rand.Reader, sum[:], crypto.SHA256)
+	if err != nil {
+		return nil, err
+	}
+	pub, ok := key.Public().(*ecdsa.PublicKey)
+	if !ok {
+		return value, nil
+	}
+	var rs struct{ R, S *big.Int }
+	if _, err := asn1.Unmarshal(value, &rs); err != nil {
+		return nil, err
+	}
+	size := (pub.Curve.Params().BitSize + 7) / 8
+	out := make([]byte, 2*size)
+	rs.R.FillBytes(out[:size])
+	rs.S.FillBytes(out[size:])
+	return out, nil
+}
+
+// verifyDigest checks a signature over the SHA-256 digest of data
+func verifyDigest(pub crypto.PublicKey, data, value []byte) bool {
+	sum := sha256.Sum256(data)
+	switch pub := pub.(type) {
+	case *rsa.PublicKey:
+		return rsa.VerifyPKCS1v15(pub, crypto.SHA256, sum[:], value) == nil
+	case *ecdsa.PublicKey:
+		size := (pub.Curve.Params().BitSize + 7) / 8
+		if len(value) != 2*size {
+			return false
+		}
+		r, s := new(big.Int).SetBytes(value[:size]), new(big.Int).SetBytes(value[size:])
+		return ecdsa.Verify(pub, sum[:], r, s)
+	}
+	return false
+}
+
+// escape escapes text for inclusion in the generated XML
+func escape(s string) string {
+	var b bytes.Buffer
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
+
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,143 @@
+package xmldsig
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/xml"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ckbaum/iso20022-go"
+)
+
+func readFixture(t *testing.T, dir, name string) []byte {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("..", "testdata", "roundtrip", dir, name))
+	if err != nil {
+		t.Fatalf("Failed to read fixture: %v", err)
+	}
+	return data
+}
+
+func newCertificate(t *testing.T, key crypto.Signer, serial int64) *x509.Certificate {
+	t.Helper()
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: "BBBBUS33", Organization: []string{"Bank B"}},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	if err != nil {
+		t.Fatalf("Failed to create certificate: %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("Failed to parse certificate: %v", err)
+	}
+	return cert
+}
+
+func TestSignAndVerify(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	appHdr := readFixture(t, "head.001.001.02", "business_application_header.xml")
+	document := readFixture(t, "pacs.008.001.08", "customer_credit_transfer.xml")
+
+	for name, key := range map[string]crypto.Signer{"RSA": rsaKey, "ECDSA": ecKey} {
+		t.Run(name, func(t *testing.T) {
+			cert := newCertificate(t, key, 42)
+			other := newCertificate(t, key, 43)
+
+			signed, err := Sign(appHdr, document, key, cert)
+			if err != nil {
+				t.Fatalf("Sign failed: %v", err)
+			}
+			if got, err := Verify(signed, document, other, cert); err != nil || got != cert {
+				t.Fatalf("Verify failed: %v", err)
+			}
+
+			// The signature is carried by the header type
+			var hdr iso20022.BusinessApplicationHeaderDocument
+			if err := xml.Unmarshal(signed, &hdr); err != nil {
+				t.Fatalf("Failed to unmarshal signed header: %v", err)
+			}
+			if hdr.AppHdr.Signature == nil || !bytes.Contains([]byte(hdr.AppHdr.Signature.Value), []byte("SignatureValue")) {
+				t.Errorf("Expected the signature in AppHdr.Signature, got %+v", hdr.AppHdr.Signature)
+			}
+
+			tamperedDoc := bytes.Replace(document, []byte("15000.00</IntrBkSttlmAmt>"), []byte("15000.01</IntrBkSttlmAmt>"), 1)
+			if _, err := Verify(signed, tamperedDoc, cert); !errors.Is(err, ErrDigestMismatch) {
+				t.Errorf("Expected ErrDigestMismatch for a changed document, got %v", err)
+			}
+			tamperedHdr := bytes.Replace(signed, []byte("CCCCGB2L"), []byte("DDDDGB2L"), 1)
+			if _, err := Verify(tamperedHdr, document, cert); !errors.Is(err, ErrDigestMismatch) {
+				t.Errorf("Expected ErrDigestMismatch for a changed header, got %v", err)
+			}
+			if _, err := Verify(signed, document, other); !errors.Is(err, ErrUnknownCertificate) {
+				t.Errorf("Expected ErrUnknownCertificate, got %v", err)
+			}
+			if _, err := Sign(signed, document, key, cert); !errors.Is(err, ErrAlreadySigned) {
+				t.Errorf("Expected ErrAlreadySigned, got %v", err)
+			}
+		})
+	}
+
+	if _, err := Verify(appHdr, document); !errors.Is(err, ErrNoSignature) {
+		t.Errorf("Expected ErrNoSignature, got %v", err)
+	}
+}
+
+func TestSignEnvelope(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert := newCertificate(t, key, 1)
+	envelope := []byte(`<BizMsg xmlns:h="urn:iso:std:iso:20022:tech:xsd:head.001.001.02">` +
+		`<h:AppHdr><h:BizMsgIdr>MSG-1</h:BizMsgIdr><h:Rltd><h:BizMsgIdr>MSG-0</h:BizMsgIdr></h:Rltd></h:AppHdr>` +
+		`<Document xmlns="urn:iso:std:iso:20022:tech:xsd:pacs.002.001.10"><FIToFIPmtStsRpt/></Document></BizMsg>`)
+
+	signed, err := Sign(envelope, envelope, key, cert)
+	if err != nil {
+		t.Fatalf("Sign failed: %v", err)
+	}
+	if !bytes.Contains(signed, []byte(`</h:BizMsgIdr><h:Sgntr><ds:Signature`)) || !bytes.Contains(signed, []byte(`</h:Sgntr><h:Rltd>`)) {
+		t.Errorf("Expected the signature before the related header:\n%s", signed)
+	}
+	if _, err := Verify(signed, signed, cert); err != nil {
+		t.Errorf("Verify failed: %v", err)
+	}
+}
+
+func TestCanonicalize(t *testing.T) {
+	input := `<?xml version="1.0"?>
+<a:Root xmlns:a="urn:a" xmlns:b="urn:b" xmlns="urn:default" xmlns:unused="urn:unused">
+  <b:Child z="1" b:y="2" a:x="3" id='q"&amp;'><!-- comment --><Leaf><![CDATA[x < y]]></Leaf><Empty/></b:Child>
+</a:Root>`
+	want := `<b:Child xmlns:a="urn:a" xmlns:b="urn:b" id="q&quot;&amp;" z="1" a:x="3" b:y="2">` +
+		`<Leaf xmlns="urn:default">x &lt; y</Leaf><Empty xmlns="urn:default"></Empty></b:Child>`
+	got, err := canonicalize([]byte(input), byName("Child", "urn:b"), nil)
+	if err != nil {
+		t.Fatalf("canonicalize failed: %v", err)
+	}
+	if string(got) != want {
+		t.Errorf("Unexpected canonical form:\n got %s\nwant %s", got, want)
+	}
+}
@@ -0,0 +1,262 @@
+// Package xmlenc encrypts and decrypts the business Document of a message with XML
+// Encryption, for market infrastructures that require encrypted payloads at rest or
+// over channels that do not encrypt them.
+//
+// Encrypt replaces the Document element with an xenc:EncryptedData element of type
+// Element. The Document is encrypted with a fresh AES-256-GCM key, and the key is
+// wrapped with RSA-OAEP (SHA-256, MGF1 with SHA-256) for each recipient, in an
+// xenc:EncryptedKey that identifies the recipient certificate by issuer and serial
+// number. Decrypt reverses it. The rest of the message, such as the AppHdr and its
+// signature, is left as it is.
+package xmlenc
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"strings"
+)
+
+// Namespaces and algorithm identifiers
+const (
+	Namespace     = "http://www.w3.org/2001/04/xmlenc#"
+	DSigNamespace = "http://www.w3.org/2000/09/xmldsig#"
+	TypeElement   = "http://www.w3.org/2001/04/xmlenc#Element"
+	AES256GCM     = "http://www.w3.org/2009/xmlenc11#aes256-gcm"
+	RSAOAEP       = "http://www.w3.org/2009/xmlenc11#rsa-oaep"
+	SHA256        = "http://www.w3.org/2001/04/xmlenc#sha256"
+	MGF1SHA256    = "http://www.w3.org/2009/xmlenc11#mgf1sha256"
+)
+
+// Errors returned by Encrypt and Decrypt
+var (
+	ErrNoDocument           = errors.New("xmlenc: no Document element")
+	ErrNoEncryptedData      = errors.New("xmlenc: no EncryptedData element")
+	ErrNoRecipient          = errors.New("xmlenc: no key encrypted for the recipient")
+	ErrUnsupportedAlgorithm = errors.New("xmlenc: unsupported algorithm")
+	ErrDecryption           = errors.New("xmlenc: decryption failed")
+)
+
+// Encrypt returns data with its first Document element replaced by its encryption
+// for the given recipients, whose certificates must hold RSA public keys. The
+// Document is encrypted as written in data; namespaces it inherits from enclosing
+// elements remain declared there.
+func Encrypt(data []byte, recipients ...*x509.Certificate) ([]byte, error) {
+	if len(recipients) == 0 {
+		return nil, errors.New("xmlenc: no recipients")
+	}
+	start, end, err := findElement(data, "Document", "")
+	if err != nil {
+		return nil, err
+	}
+
+	key := make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return nil, err
+	}
+	var keys strings.Builder
+	for _, cert := range recipients {
+		pub, ok := cert.PublicKey.(*rsa.PublicKey)
+		if !ok {
+			return nil, fmt.Errorf("%w: key type %T of %s", ErrUnsupportedAlgorithm, cert.PublicKey, cert.Subject)
+		}
+		wrapped, err := rsa.EncryptOAEP(sha256.New(), rand.Reader, pub, key, nil)
+		if err != nil {
+			return nil, err
+		}
+		fmt.Fprintf(&keys, `<xenc:EncryptedKey><xenc:EncryptionMethod Algorithm="%s">`+
+			`<ds:DigestMethod Algorithm="%s"/><xenc11:MGF Algorithm="%s"/></xenc:EncryptionMethod>`+
+			`<ds:KeyInfo><ds:X509Data><ds:X509IssuerSerial><ds:X509IssuerName>%s</ds:X509IssuerName>`+
+			`<ds:X509SerialNumber>%s</ds:X509SerialNumber></ds:X509IssuerSerial></ds:X509Data></ds:KeyInfo>`+
+			`<xenc:CipherData><xenc:CipherValue>%s</xenc:CipherValue></xenc:CipherData></xenc:EncryptedKey>`,
+			RSAOAEP, SHA256, MGF1SHA256, escape(cert.Issuer.String()), cert.SerialNumber, base64.StdEncoding.EncodeToString(wrapped))
+	}
+
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	// XML Encryption 1.1 stores the nonce before the ciphertext and tag
+	cipherValue := gcm.Seal(nonce, nonce, data[start:end], nil)
+
+	encrypted := fmt.Sprintf(`<xenc:EncryptedData xmlns:xenc="%s" xmlns:xenc11="http://www.w3.org/2009/xmlenc11#" xmlns:ds="%s" Type="%s">`+
+		`<xenc:EncryptionMethod Algorithm="%s"/><ds:KeyInfo>%s</ds:KeyInfo>`+
+		`<xenc:CipherData><xenc:CipherValue>%s</xenc:CipherValue></xenc:CipherData></xenc:EncryptedData>`,
+		Namespace, DSigNamespace, TypeElement, AES256GCM, keys.String(), base64.StdEncoding.EncodeToString(cipherValue))
+
+	out := make([]byte, 0, start+len(encrypted)+len(data)-end)
+	out = append(out, data[:start]...)
+	out = append(out, encrypted...)
+	return append(out, data[end:]...), nil
+}
+
+// Decrypt returns data with its first EncryptedData element replaced by the
+// Document it encrypts. The content key is unwrapped with key from the
+// EncryptedKey for cert, or from each EncryptedKey in turn when cert is nil.
+func Decrypt(data []byte, key *rsa.PrivateKey, cert *x509.Certificate) ([]byte, error) {
+	start, end, err := findElement(data, "EncryptedData", Namespace)
+	if err != nil {
+		return nil, err
+	}
+	var enc encryptedData
+	if err := xml.Unmarshal(data[start:end], &enc); err != nil {
+		return nil, err
+	}
+	if enc.EncryptionMethod.Algorithm != AES256GCM {
+		return nil, fmt.Errorf("%w: content encryption %s", ErrUnsupportedAlgorithm, enc.EncryptionMethod.Algorithm)
+	}
+
+	var contentKey []byte
+	for _, ek := range enc.KeyInfo.EncryptedKeys {
+		if cert != nil && !ek.KeyInfo.identifies(cert) {
+			continue
+		}
+		if m := ek.EncryptionMethod; m.Algorithm != RSAOAEP || m.DigestMethod.Algorithm != SHA256 || m.MGF.Algorithm != MGF1SHA256 {
+			return nil, fmt.Errorf("%w: key transport %s", ErrUnsupportedAlgorithm, m.Algorithm)
+		}
+		wrapped, err := decodeBase64(ek.CipherValue)
+		if err != nil {
+			return nil, err
+		}
+		if contentKey, err = rsa.DecryptOAEP(sha256.New(), nil, key, wrapped, nil); err == nil {
+			break
+		}
+		if cert != nil {
+			return nil, ErrDecryption
+		}
+	}
+	if contentKey == nil {
+		return nil, ErrNoRecipient
+	}
+
+	gcm, err := newGCM(contentKey)
+	if err != nil {
+		return nil, err
+	}
+	cipherValue, err := decodeBase64(enc.CipherValue)
+	if err != nil {
+		return nil, err
+	}
+	if len(cipherValue) < gcm.NonceSize()+gcm.Overhead() {
+		return nil, ErrDecryption
+	}
+	nonce, ciphertext := cipherValue[:gcm.NonceSize()], cipherValue[gcm.NonceSize():]
+	document, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, ErrDecryption
+	}
+
+	out := make([]byte, 0, start+len(document)+len(data)-end)
+	out = append(out, data[:start]...)
+	out = append(out, document...)
+	return append(out, data[end:]...), nil
+}
+
+// encryptedData is the part of xenc:EncryptedData that Decrypt reads
+type encryptedData struct {
+	EncryptionMethod struct {
+		Algorithm string `xml:"Algorithm,attr"`
+	} `xml:"EncryptionMethod"`
+	KeyInfo struct {
+		EncryptedKeys []encryptedKey `xml:"http://www.w3.org/2001/04/xmlenc# EncryptedKey"`
+	} `xml:"http://www.w3.org/2000/09/xmldsig# KeyInfo"`
+	CipherValue string `xml:"CipherData>CipherValue"`
+}
+
+type encryptedKey struct {
+	EncryptionMethod struct {
+		Algorithm    string `xml:"Algorithm,attr"`
+		DigestMethod struct {
+			Algorithm string `xml:"Algorithm,attr"`
+		} `xml:"http://www.w3.org/2000/09/xmldsig# DigestMethod"`
+		MGF struct {
+			Algorithm string `xml:"Algorithm,attr"`
+		} `xml:"MGF"`
+	} `xml:"EncryptionMethod"`
+	KeyInfo     keyInfo `xml:"http://www.w3.org/2000/09/xmldsig# KeyInfo"`
+	CipherValue string  `xml:"CipherData>CipherValue"`
+}
+
+type keyInfo struct {
+	IssuerName   string `xml:"X509Data>X509IssuerSerial>X509IssuerName"`
+	SerialNumber string `xml:"X509Data>X509IssuerSerial>X509SerialNumber"`
+}
+
+// identifies reports whether the KeyInfo names cert by issuer and serial number
+func (k *keyInfo) identifies(cert *x509.Certificate) bool {
+	serial, ok := new(big.Int).SetString(strings.TrimSpace(k.SerialNumber), 10)
+	return ok && serial.Cmp(cert.SerialNumber) == 0 && strings.TrimSpace(k.IssuerName) == cert.Issuer.String()
+}
+
+// findElement returns the byte offsets of the first element with the given local
+// name and, unless space is empty, namespace
+func findElement(data []byte, local, space string) (start, end int, err error) {
+	dec := xml.NewDecoder(bytes.NewReader(data))
+	depth := 0
+	for {
+		offset := int(dec.InputOffset())
+		tok, err := dec.Token()
+		if err == io.EOF {
+			if local == "Document" {
+				return 0, 0, ErrNoDocument
+			}
+			return 0, 0, ErrNoEncryptedData
+		}
+		if err != nil {
+			return 0, 0, err
+		}
+		switch tok := tok.(type) {
+		case xml.StartElement:
+			if depth == 0 && tok.Name.Local == local && (space == "" || tok.Name.Space == space) {
+				start, depth = offset, 1
+				continue
+			}
+			if depth > 0 {
+				depth++
+			}
+		case xml.EndElement:
+			if depth > 0 {
+				if depth--; depth == 0 {
+					return start, int(dec.InputOffset()), nil
+				}
+			}
+		}
+	}
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func decodeBase64(s string) ([]byte, error) {
+	b, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(s), ""))
+	if err != nil {
+		return nil, fmt.Errorf("xmlenc: CipherValue: %w", err)
+	}
+	return b, nil
+}
+
+// escape escapes text for inclusion in the generated XML
+func escape(s string) string {
+	var b bytes.Buffer
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
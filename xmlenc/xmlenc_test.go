@@ -0,0 +1,121 @@
+package xmlenc
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/xml"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ckbaum/iso20022-go"
+)
+
+func newRecipient(t *testing.T, serial int64) (*rsa.PrivateKey, *x509.Certificate) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: "CCCCGB2L"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key, cert
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+	document, err := os.ReadFile(filepath.Join("..", "testdata", "roundtrip", "pacs.008.001.08", "customer_credit_transfer.xml"))
+	if err != nil {
+		t.Fatalf("Failed to read fixture: %v", err)
+	}
+	key1, cert1 := newRecipient(t, 1)
+	key2, cert2 := newRecipient(t, 2)
+	other, _ := newRecipient(t, 3)
+
+	encrypted, err := Encrypt(document, cert1, cert2)
+	if err != nil {
+		t.Fatalf("Encrypt failed: %v", err)
+	}
+	if bytes.Contains(encrypted, []byte("15000.00")) || !bytes.HasPrefix(encrypted, []byte(`<?xml`)) {
+		t.Errorf("Expected the Document to be replaced by its encryption:\n%s", encrypted)
+	}
+
+	for _, tt := range []struct {
+		name string
+		key  *rsa.PrivateKey
+		cert *x509.Certificate
+	}{
+		{"FirstRecipient", key1, cert1},
+		{"SecondRecipient", key2, cert2},
+		{"WithoutCertificate", key2, nil},
+	} {
+		decrypted, err := Decrypt(encrypted, tt.key, tt.cert)
+		if err != nil {
+			t.Fatalf("%s: Decrypt failed: %v", tt.name, err)
+		}
+		if !bytes.Equal(decrypted, document) {
+			t.Errorf("%s: decrypted message differs from the original", tt.name)
+		}
+	}
+
+	if _, err := Decrypt(encrypted, other, nil); !errors.Is(err, ErrNoRecipient) {
+		t.Errorf("Expected ErrNoRecipient, got %v", err)
+	}
+	if _, err := Decrypt(encrypted, other, cert1); !errors.Is(err, ErrDecryption) {
+		t.Errorf("Expected ErrDecryption for the wrong key, got %v", err)
+	}
+	if _, err := Decrypt(document, key1, cert1); !errors.Is(err, ErrNoEncryptedData) {
+		t.Errorf("Expected ErrNoEncryptedData, got %v", err)
+	}
+}
+
+func TestEncryptEnvelopeKeepsHeader(t *testing.T) {
+	key, cert := newRecipient(t, 1)
+	envelope := []byte(`<BizMsg><AppHdr xmlns="urn:iso:std:iso:20022:tech:xsd:head.001.001.02"><BizMsgIdr>MSG-1</BizMsgIdr></AppHdr>` +
+		`<Document xmlns="urn:iso:std:iso:20022:tech:xsd:pacs.002.001.10"><FIToFIPmtStsRpt><GrpHdr><MsgId>MSG-1</MsgId></GrpHdr></FIToFIPmtStsRpt></Document></BizMsg>`)
+
+	encrypted, err := Encrypt(envelope, cert)
+	if err != nil {
+		t.Fatalf("Encrypt failed: %v", err)
+	}
+	var msg struct {
+		AppHdr iso20022.BusinessApplicationHeaderV02 `xml:"AppHdr"`
+	}
+	if err := xml.Unmarshal(encrypted, &msg); err != nil || msg.AppHdr.BusinessMessageID != "MSG-1" {
+		t.Errorf("Expected the header to stay readable, got %+v, %v", msg.AppHdr, err)
+	}
+
+	// Any change to the ciphertext is detected
+	i := bytes.LastIndex(encrypted, []byte("<xenc:CipherValue>")) + len("<xenc:CipherValue>") + 20
+	tampered := append([]byte(nil), encrypted...)
+	if tampered[i] == 'A' {
+		tampered[i] = 'B'
+	} else {
+		tampered[i] = 'A'
+	}
+	if _, err := Decrypt(tampered, key, cert); !errors.Is(err, ErrDecryption) {
+		t.Errorf("Expected ErrDecryption for a changed ciphertext, got %v", err)
+	}
+
+	decrypted, err := Decrypt(encrypted, key, cert)
+	if err != nil || !bytes.Equal(decrypted, envelope) {
+		t.Errorf("Expected the original envelope back, got %s, %v", decrypted, err)
+	}
+}